@@ -0,0 +1,48 @@
+// Package main implements the runvoy orchestrator.
+// It handles API requests and orchestrates the executions, on the runtime of the configured backend provider.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/logger"
+	"github.com/runvoy/runvoy/internal/providers/aws/lambdaapi"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func main() {
+	cfg := config.MustLoadOrchestrator()
+	log := logger.Initialize(constants.Production, cfg.GetLogLevel())
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.InitTimeout)
+
+	svc, err := orchestrator.Initialize(ctx, cfg, log)
+	cancel()
+	if err != nil {
+		log.Error("failed to initialize orchestrator", "error", err)
+		os.Exit(1)
+	}
+
+	log.With("version", *constants.GetVersion()).Debug("starting orchestrator",
+		"provider", cfg.BackendProvider)
+	if err = start(cfg, svc); err != nil {
+		log.Error("failed to start orchestrator", "error", err)
+		os.Exit(1)
+	}
+}
+
+// start serves the orchestrator on the runtime of the backend provider, the AWS provider runs on Lambda.
+func start(cfg *config.Config, svc *orchestrator.Service) error {
+	switch cfg.BackendProvider {
+	case constants.AWS:
+		lambda.Start(lambdaapi.NewHandler(svc, cfg.RequestTimeout, cfg.CORSAllowedOrigins))
+		return nil
+	default:
+		return fmt.Errorf("no orchestrator runtime for backend provider %s, use the local server", cfg.BackendProvider)
+	}
+}
@@ -0,0 +1,50 @@
+// Package main implements the runvoy event processor.
+// It processes the asynchronous events of the configured backend provider, such as the task completions
+// and the WebSocket events, on the runtime of the provider.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/runvoy/runvoy/internal/backend/processor"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/logger"
+	"github.com/runvoy/runvoy/internal/providers/aws/lambdaapi"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func main() {
+	cfg := config.MustLoadEventProcessor()
+	log := logger.Initialize(constants.Production, cfg.GetLogLevel())
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.InitTimeout)
+
+	proc, err := processor.Initialize(ctx, cfg, log)
+	cancel()
+	if err != nil {
+		log.Error("failed to initialize event processor", "error", err)
+		os.Exit(1)
+	}
+
+	log.With("version", *constants.GetVersion()).Debug("starting event processor",
+		"provider", cfg.BackendProvider)
+	if err = start(cfg, proc); err != nil {
+		log.Error("failed to start event processor", "error", err)
+		os.Exit(1)
+	}
+}
+
+// start serves the event processor on the runtime of the backend provider, the AWS provider runs on Lambda.
+func start(cfg *config.Config, proc processor.Processor) error {
+	switch cfg.BackendProvider {
+	case constants.AWS:
+		lambda.Start(lambdaapi.NewEventProcessorHandler(proc))
+		return nil
+	default:
+		return fmt.Errorf("no event processor runtime for backend provider %s, use the local server",
+			cfg.BackendProvider)
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// admin flags, shared by all subcommands.
+	adminStackName string
+	adminRegion    string
+	adminProvider  string
+
+	// admin migrate up flags.
+	adminMigrateDryRun bool
+	adminMigrateTarget int
+)
+
+// adminCmd is the parent command for backend administration operations.
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Backend administration commands",
+	Long:  "Commands for administering the deployed backend with provider credentials.",
+}
+
+// adminMigrateCmd is the parent command for database migrations.
+var adminMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage backend database migrations",
+	Long: `Apply and inspect the versioned migrations of the backend database.
+
+Applied migrations are recorded in a ledger stored alongside the backend data,
+so each migration runs once per backend.`,
+}
+
+var adminMigrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	Example: fmt.Sprintf(
+		"  # Show the changes of pending migrations without applying them\n"+
+			"  %s admin migrate up --dry-run\n\n"+
+			"  # Apply pending migrations up to version 3\n"+
+			"  %s admin migrate up --to 3",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: adminMigrateUpRun,
+}
+
+var adminMigrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Run:   adminMigrateStatusRun,
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminMigrateCmd)
+	adminMigrateCmd.AddCommand(adminMigrateUpCmd)
+	adminMigrateCmd.AddCommand(adminMigrateStatusCmd)
+
+	cfg, err := config.Load()
+	if err != nil {
+		output.Fatalf("failed to load config: %v", err)
+	}
+
+	adminCmd.PersistentFlags().StringVar(&adminProvider, "provider", cfg.GetProviderIdentifier(),
+		"Cloud provider (currently supported: aws)")
+	adminCmd.PersistentFlags().StringVar(&adminStackName, "stack-name", cfg.GetDefaultStackName(),
+		"Infrastructure stack name")
+	adminCmd.PersistentFlags().StringVar(&adminRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
+
+	adminMigrateUpCmd.Flags().BoolVar(&adminMigrateDryRun, "dry-run", false,
+		"Count the changes of pending migrations without applying them")
+	adminMigrateUpCmd.Flags().IntVar(&adminMigrateTarget, "to", 0,
+		"Last migration version to apply. Defaults to the latest")
+}
+
+func adminMigrateUpRun(cmd *cobra.Command, _ []string) {
+	if adminMigrateTarget < 0 {
+		output.Fatalf("--to must be a positive migration version")
+	}
+	runner := newStackMigrationRunner(cmd.Context(), adminProvider, adminStackName, adminRegion)
+	if err := applyMigrations(cmd.Context(), runner, migrations.UpOptions{
+		Target: adminMigrateTarget,
+		DryRun: adminMigrateDryRun,
+	}); err != nil {
+		output.Fatalf(err.Error())
+	}
+}
+
+func adminMigrateStatusRun(cmd *cobra.Command, _ []string) {
+	runner := newStackMigrationRunner(cmd.Context(), adminProvider, adminStackName, adminRegion)
+	statuses, err := runner.Status(cmd.Context())
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+
+	pending := 0
+	rows := make([][]string, 0, len(statuses))
+	for i := range statuses {
+		rows = append(rows, formatMigrationStatus(&statuses[i]))
+		if !statuses[i].Applied {
+			pending++
+		}
+	}
+	output.Table([]string{"Version", "Name", "Status", "Applied at", "Changes", "Description"}, rows)
+	output.Blank()
+	if pending == 0 {
+		output.Successf("Database is up to date")
+		return
+	}
+	output.Infof("%d pending migrations, run %s admin migrate up to apply them", pending, constants.ProjectName)
+}
+
+func formatMigrationStatus(status *migrations.Status) []string {
+	if !status.Applied {
+		return []string{strconv.Itoa(status.Version), status.Name, "pending", "", "", status.Description}
+	}
+	return []string{
+		strconv.Itoa(status.Version),
+		status.Name,
+		"applied",
+		status.Record.AppliedAt.Format(time.RFC3339),
+		strconv.Itoa(status.Record.Changes),
+		status.Description,
+	}
+}
+
+// newStackMigrationRunner creates a migrations runner for the backend deployed by the stack.
+func newStackMigrationRunner(ctx context.Context, provider, stackName, region string) *migrations.Runner {
+	deployer, err := infra.NewDeployer(ctx, provider, region)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	runner, err := stackMigrationRunner(ctx, deployer, provider, stackName)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	return runner
+}
+
+func stackMigrationRunner(
+	ctx context.Context,
+	deployer infra.Deployer,
+	provider, stackName string,
+) (*migrations.Runner, error) {
+	outputs, err := deployer.GetStackOutputs(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack outputs: %w", err)
+	}
+	runner, err := infra.NewMigrationRunner(ctx, provider, deployer.GetRegion(), outputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	return runner, nil
+}
+
+// applyMigrations runs the pending migrations and reports their results.
+func applyMigrations(ctx context.Context, runner *migrations.Runner, opts migrations.UpOptions) error {
+	msg := "Applying migrations..."
+	if opts.DryRun {
+		msg = "Checking pending migrations..."
+	}
+	spinner := output.NewSpinner(msg)
+	spinner.Start()
+
+	results, err := runner.Up(ctx, opts)
+	if err != nil {
+		spinner.Error("Migrations failed")
+		printMigrationResults(results, opts.DryRun)
+		return fmt.Errorf("%w, fix the issue and run the migrations again", err)
+	}
+	if len(results) == 0 {
+		spinner.Success("No pending migrations")
+		return nil
+	}
+	if opts.DryRun {
+		spinner.Success(fmt.Sprintf("%d pending migrations, dry run, no changes applied", len(results)))
+	} else {
+		spinner.Success(fmt.Sprintf("%d migrations applied", len(results)))
+	}
+	printMigrationResults(results, opts.DryRun)
+	return nil
+}
+
+func printMigrationResults(results []migrations.Result, dryRun bool) {
+	if len(results) == 0 {
+		return
+	}
+	changesHeader := "Changes"
+	if dryRun {
+		changesHeader = "Pending changes"
+	}
+
+	rows := make([][]string, 0, len(results))
+	for i := range results {
+		rows = append(rows, []string{
+			strconv.Itoa(results[i].Version),
+			results[i].Name,
+			strconv.Itoa(results[i].Changes),
+			output.Duration(results[i].Duration),
+		})
+	}
+	output.Blank()
+	output.Table([]string{"Version", "Name", changesHeader, "Duration"}, rows)
+	output.Blank()
+}
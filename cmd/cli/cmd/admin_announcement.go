@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var adminAnnouncementCmd = &cobra.Command{
+	Use:   "announcement",
+	Short: "Manage the announcement shown to the CLI users",
+	Long: `Set an announcement, such as an outage notice or a deprecation, that the backend sends with every
+response. The CLI shows each announcement once per day.
+
+Unlike most admin commands, the requests are sent to the API with the configured API key.`,
+}
+
+var adminAnnouncementSetCmd = &cobra.Command{
+	Use:   "set <message>",
+	Short: "Set the announcement, replacing the current one",
+	Example: fmt.Sprintf(
+		"  %s admin announcement set \"Runs in eu-west-1 are delayed until 3pm UTC\"",
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  adminAnnouncementSetRun,
+}
+
+var adminAnnouncementShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the current announcement",
+	Args:  cobra.NoArgs,
+	Run:   adminAnnouncementShowRun,
+}
+
+var adminAnnouncementClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the announcement",
+	Args:  cobra.NoArgs,
+	Run:   adminAnnouncementClearRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminAnnouncementCmd)
+	adminAnnouncementCmd.AddCommand(adminAnnouncementSetCmd)
+	adminAnnouncementCmd.AddCommand(adminAnnouncementShowCmd)
+	adminAnnouncementCmd.AddCommand(adminAnnouncementClearCmd)
+}
+
+func adminAnnouncementSetRun(cmd *cobra.Command, args []string) {
+	message := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewAnnouncementService(c, NewOutputWrapper()).Set(ctx, message)
+	})
+}
+
+func adminAnnouncementShowRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewAnnouncementService(c, NewOutputWrapper()).Show(ctx)
+	})
+}
+
+func adminAnnouncementClearRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewAnnouncementService(c, NewOutputWrapper()).Clear(ctx)
+	})
+}
+
+// AnnouncementService handles managing the announcement.
+type AnnouncementService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewAnnouncementService creates a new AnnouncementService with the provided dependencies.
+func NewAnnouncementService(apiClient client.Interface, outputter OutputInterface) *AnnouncementService {
+	return &AnnouncementService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// Set sets the announcement.
+func (s *AnnouncementService) Set(ctx context.Context, message string) error {
+	resp, err := s.client.SetAnnouncement(ctx, api.AnnouncementRequest{Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to set announcement: %w", err)
+	}
+
+	s.output.Successf("Announcement set successfully")
+	s.showAnnouncement(resp.Announcement)
+	return nil
+}
+
+// Show shows the current announcement.
+func (s *AnnouncementService) Show(ctx context.Context) error {
+	resp, err := s.client.GetMeta(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get announcement: %w", err)
+	}
+
+	if resp.Announcement == nil {
+		s.output.Infof("There is no announcement")
+		return nil
+	}
+	s.showAnnouncement(resp.Announcement)
+	return nil
+}
+
+// Clear removes the announcement.
+func (s *AnnouncementService) Clear(ctx context.Context) error {
+	if _, err := s.client.ClearAnnouncement(ctx); err != nil {
+		return fmt.Errorf("failed to clear announcement: %w", err)
+	}
+
+	s.output.Successf("Announcement cleared successfully")
+	return nil
+}
+
+func (s *AnnouncementService) showAnnouncement(announcement *api.Announcement) {
+	if announcement == nil {
+		return
+	}
+	s.output.KeyValue("Message", announcement.Message)
+	if announcement.UpdatedBy != "" {
+		s.output.KeyValue("Set By", announcement.UpdatedBy)
+	}
+	if announcement.UpdatedAt != nil {
+		s.output.KeyValue("Set At", announcement.UpdatedAt.Format(time.DateTime))
+	}
+}
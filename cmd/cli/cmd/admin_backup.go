@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database/backup"
+
+	"github.com/spf13/cobra"
+)
+
+// backupPassphraseEnvVar is the environment variable read for the archive passphrase before prompting for it.
+const backupPassphraseEnvVar = "RUNVOY_BACKUP_PASSPHRASE" //nolint:gosec // G101: Environment variable name
+
+var adminBackupOutput string
+
+var adminBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up users, images and secrets to an encrypted archive",
+	Long: fmt.Sprintf(`Export the users (with their API key hashes), image configurations and secrets
+(with their values) of the backend to an archive encrypted with a passphrase.
+
+The passphrase is read from %s or prompted for. Keep it with the archive:
+it cannot be restored without it.`, backupPassphraseEnvVar),
+	Example: fmt.Sprintf(
+		"  # Back up the default stack\n"+
+			"  %s admin backup --output runvoy.backup",
+		constants.ProjectName,
+	),
+	Run: adminBackupRun,
+}
+
+var adminRestoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore users, images and secrets from an encrypted archive",
+	Long: `Import the records of a backup archive missing from the backend, for example into a fresh stack.
+
+Existing users, images and secrets are kept unchanged, so the command can be re-run.
+Secret values are encrypted again with the destination stack's key.
+
+Archives of another provider are converted like with admin transfer, mapping roles
+with --map-identity.`,
+	Example: fmt.Sprintf(
+		"  # Restore into another stack\n"+
+			"  %s admin restore runvoy.backup --stack-name my-new-stack",
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  adminRestoreRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminBackupCmd)
+	adminCmd.AddCommand(adminRestoreCmd)
+
+	adminBackupCmd.Flags().StringVarP(&adminBackupOutput, "output", "o", "",
+		"Archive file path. Defaults to <stack-name>-<timestamp>.backup")
+}
+
+func adminBackupRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	store := newStackBackupStore(ctx, adminProvider, adminStackName, adminRegion)
+
+	passphrase, err := readBackupPassphrase(true)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+
+	spinner := output.NewSpinner("Exporting backend data...")
+	spinner.Start()
+	archive, err := backup.Create(ctx, store, adminProvider, time.Now())
+	if err != nil {
+		spinner.Error("Backup failed")
+		output.Fatalf(err.Error())
+	}
+	sealed, err := backup.Seal(archive, passphrase)
+	if err != nil {
+		spinner.Error("Backup failed")
+		output.Fatalf(err.Error())
+	}
+
+	path := adminBackupOutput
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.backup", adminStackName, archive.CreatedAt.Format("20060102-150405"))
+	}
+	if err = os.WriteFile(path, sealed, 0o600); err != nil {
+		spinner.Error("Backup failed")
+		output.Fatalf("failed to write archive: %v", err)
+	}
+	spinner.Success("Backup written to " + path)
+
+	output.KeyValue("Users", strconv.Itoa(len(archive.Users)))
+	output.KeyValue("Images", strconv.Itoa(len(archive.Images)))
+	output.KeyValue("Secrets", strconv.Itoa(len(archive.Secrets)))
+}
+
+func adminRestoreRun(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		output.Fatalf("failed to read archive: %v", err)
+	}
+	passphrase, err := readBackupPassphrase(false)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	archive, err := backup.Open(data, passphrase)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+
+	output.Infof("Restoring backup of %s created at %s", archive.Provider, archive.CreatedAt.Format(time.RFC3339))
+	archive = convertArchive(archive, adminProvider, adminRestoreIdentities)
+	store := newStackBackupStore(ctx, adminProvider, adminStackName, adminRegion)
+	spinner := output.NewSpinner("Restoring backend data...")
+	spinner.Start()
+	report, err := backup.Restore(ctx, store, archive)
+	if err != nil {
+		spinner.Error("Restore failed")
+		printRestoreReport(report)
+		output.Fatalf("%v, fix the issue and run the restore again", err)
+	}
+	spinner.Success("Restore completed")
+	printRestoreReport(report)
+
+	if report.Images.Restored > 0 {
+		output.Infof("Run %s health reconcile against the restored backend to register the images' task definitions",
+			constants.ProjectName)
+	}
+}
+
+func printRestoreReport(report *backup.RestoreReport) {
+	if report == nil {
+		return
+	}
+	output.Blank()
+	output.Table([]string{"Records", "Restored", "Skipped (existing)"}, [][]string{
+		{"Users", strconv.Itoa(report.Users.Restored), strconv.Itoa(report.Users.Skipped)},
+		{"Images", strconv.Itoa(report.Images.Restored), strconv.Itoa(report.Images.Skipped)},
+		{"Secrets", strconv.Itoa(report.Secrets.Restored), strconv.Itoa(report.Secrets.Skipped)},
+	})
+	output.Blank()
+}
+
+// newStackBackupStore creates the backup store of the backend deployed by the stack.
+func newStackBackupStore(ctx context.Context, provider, stackName, region string) backup.Store {
+	deployer, err := infra.NewDeployer(ctx, provider, region)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(ctx, stackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+	store, err := infra.NewBackupStore(ctx, provider, deployer.GetRegion(), outputs)
+	if err != nil {
+		output.Fatalf("failed to initialize backup store: %v", err)
+	}
+	return store
+}
+
+// readBackupPassphrase reads the archive passphrase from the environment or prompts for it,
+// asking for a confirmation when it protects a new archive.
+func readBackupPassphrase(confirm bool) (string, error) {
+	passphrase := os.Getenv(backupPassphraseEnvVar)
+	fromEnv := passphrase != ""
+	if !fromEnv {
+		passphrase = output.PromptSecret("Archive passphrase")
+	}
+
+	switch {
+	case passphrase == "":
+		return "", errors.New("passphrase is required")
+	case confirm && len(passphrase) < backup.MinPassphraseLength:
+		return "", fmt.Errorf("passphrase must be at least %d characters", backup.MinPassphraseLength)
+	case confirm && !fromEnv && output.PromptSecret("Confirm passphrase") != passphrase:
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
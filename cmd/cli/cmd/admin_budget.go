@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adminBudgetMonthly float64
+	adminBudgetHardCap bool
+)
+
+var adminBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Manage the monthly budgets of the users",
+	Long: `Set a monthly budget on the estimated compute cost of the executions of a user. Reaching 80% and
+100% of the budget is notified to the alarm topic, and the runs of the user show a warning. With a hard
+cap, the new runs of the user are refused once the budget is spent, unless they may override it (admins).
+
+Unlike most admin commands, the requests are sent to the API with the configured API key.`,
+}
+
+var adminBudgetSetCmd = &cobra.Command{
+	Use:   "set <email>",
+	Short: "Set the monthly budget of a user, replacing the current one",
+	Example: fmt.Sprintf(
+		"  # Warn at 80%% and 100%% of $50 a month\n"+
+			"  %s admin budget set alice@example.com --monthly 50\n\n"+
+			"  # Refuse new runs once $50 is spent\n"+
+			"  %s admin budget set alice@example.com --monthly 50 --hard-cap",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  adminBudgetSetRun,
+}
+
+var adminBudgetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the budgets with the spend of the current month",
+	Args:  cobra.NoArgs,
+	Run:   adminBudgetListRun,
+}
+
+var adminBudgetRemoveCmd = &cobra.Command{
+	Use:   "remove <email>",
+	Short: "Remove the monthly budget of a user",
+	Args:  cobra.ExactArgs(1),
+	Run:   adminBudgetRemoveRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminBudgetCmd)
+	adminBudgetCmd.AddCommand(adminBudgetSetCmd)
+	adminBudgetCmd.AddCommand(adminBudgetListCmd)
+	adminBudgetCmd.AddCommand(adminBudgetRemoveCmd)
+
+	adminBudgetSetCmd.Flags().Float64Var(&adminBudgetMonthly, "monthly", 0, "Monthly budget in USD")
+	adminBudgetSetCmd.Flags().BoolVar(&adminBudgetHardCap, "hard-cap", false,
+		"Refuse the new runs of the user once the budget is spent")
+	_ = adminBudgetSetCmd.MarkFlagRequired("monthly")
+}
+
+func adminBudgetSetRun(cmd *cobra.Command, args []string) {
+	email := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewBudgetService(c, NewOutputWrapper()).Set(ctx, email, adminBudgetMonthly, adminBudgetHardCap)
+	})
+}
+
+func adminBudgetListRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewBudgetService(c, NewOutputWrapper()).List(ctx)
+	})
+}
+
+func adminBudgetRemoveRun(cmd *cobra.Command, args []string) {
+	email := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewBudgetService(c, NewOutputWrapper()).Remove(ctx, email)
+	})
+}
+
+// BudgetService handles managing the monthly budgets of the users.
+type BudgetService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewBudgetService creates a new BudgetService with the provided dependencies.
+func NewBudgetService(apiClient client.Interface, outputter OutputInterface) *BudgetService {
+	return &BudgetService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// Set sets the monthly budget of the user.
+func (s *BudgetService) Set(ctx context.Context, email string, monthlyLimitUSD float64, hardCap bool) error {
+	resp, err := s.client.SetBudget(ctx, email, api.BudgetRequest{
+		MonthlyLimitUSD: monthlyLimitUSD,
+		HardCap:         hardCap,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set budget: %w", err)
+	}
+
+	s.output.Successf("Budget set successfully")
+	if resp.Budget != nil {
+		s.output.KeyValue("User", resp.Budget.UserEmail)
+		s.output.KeyValue("Monthly Budget", formatUSD(resp.Budget.MonthlyLimitUSD))
+		s.output.KeyValue("Hard Cap", fmt.Sprintf("%t", resp.Budget.HardCap))
+		s.output.KeyValue("Spent This Month", fmt.Sprintf("%s (%.0f%%)",
+			formatUSD(resp.Budget.SpentUSD), resp.Budget.PercentUsed))
+	}
+	return nil
+}
+
+// List lists the budgets with the spend of the current month.
+func (s *BudgetService) List(ctx context.Context) error {
+	resp, err := s.client.ListBudgets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	if len(resp.Budgets) == 0 {
+		s.output.Infof("No budgets set")
+		return nil
+	}
+	rows := make([][]string, 0, len(resp.Budgets))
+	for i := range resp.Budgets {
+		budget := &resp.Budgets[i]
+		rows = append(rows, []string{
+			budget.UserEmail,
+			formatUSD(budget.MonthlyLimitUSD),
+			formatUSD(budget.SpentUSD),
+			fmt.Sprintf("%.0f%%", budget.PercentUsed),
+			fmt.Sprintf("%t", budget.HardCap),
+		})
+	}
+	s.output.Table([]string{"User", "Monthly Budget", "Spent", "Used", "Hard Cap"}, rows)
+	return nil
+}
+
+// Remove removes the monthly budget of the user.
+func (s *BudgetService) Remove(ctx context.Context, email string) error {
+	if _, err := s.client.RemoveBudget(ctx, email); err != nil {
+		return fmt.Errorf("failed to remove budget: %w", err)
+	}
+
+	s.output.Successf("Budget of %s removed successfully", email)
+	return nil
+}
+
+func formatUSD(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestBudgetService_Set(t *testing.T) {
+	mockClient := &mockClientInterface{
+		setBudgetFunc: func(_ context.Context, email string, req api.BudgetRequest) (*api.BudgetResponse, error) {
+			assert.Equal(t, "alice@example.com", email)
+			assert.InDelta(t, 50, req.MonthlyLimitUSD, 0)
+			assert.True(t, req.HardCap)
+			return &api.BudgetResponse{Budget: &api.BudgetStatus{
+				Budget:      api.Budget{UserEmail: email, MonthlyLimitUSD: req.MonthlyLimitUSD, HardCap: true},
+				SpentUSD:    12.5,
+				PercentUsed: 25,
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewBudgetService(mockClient, mockOutput).Set(context.Background(), "alice@example.com", 50, true)
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "$50.00", keyValues["Monthly Budget"])
+	assert.Equal(t, "true", keyValues["Hard Cap"])
+	assert.Equal(t, "$12.50 (25%)", keyValues["Spent This Month"])
+}
+
+func TestBudgetService_List(t *testing.T) {
+	mockClient := &mockClientInterface{
+		listBudgetsFunc: func(_ context.Context) (*api.ListBudgetsResponse, error) {
+			return &api.ListBudgetsResponse{Budgets: []api.BudgetStatus{{
+				Budget:      api.Budget{UserEmail: "alice@example.com", MonthlyLimitUSD: 50},
+				SpentUSD:    45,
+				PercentUsed: 90,
+			}}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewBudgetService(mockClient, mockOutput).List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, mockOutput.calls, 1)
+	assert.Equal(t, "Table", mockOutput.calls[0].method)
+	rows := mockOutput.calls[0].args[1].([][]string)
+	assert.Equal(t, []string{"alice@example.com", "$50.00", "$45.00", "90%", "false"}, rows[0])
+}
+
+func TestBudgetService_Remove(t *testing.T) {
+	mockClient := &mockClientInterface{
+		removeBudgetFunc: func(_ context.Context, _ string) (*api.BudgetResponse, error) {
+			return nil, errors.New("user alice@example.com has no budget")
+		},
+	}
+
+	err := NewBudgetService(mockClient, &mockOutputInterface{}).Remove(context.Background(), "alice@example.com")
+
+	assert.ErrorContains(t, err, "failed to remove budget")
+}
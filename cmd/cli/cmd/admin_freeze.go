@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var adminFreezeMessage string
+
+var adminFreezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Reject new runs, e.g. during a planned backend upgrade",
+	Long: `Freeze the runs: the backend rejects the new executions with the message until the runs are
+unfrozen. Reads and the executions already running are not affected.
+
+Unlike most admin commands, the request is sent to the API with the configured API key.`,
+	Example: fmt.Sprintf(
+		"  # Reject new runs during an upgrade\n"+
+			"  %s admin freeze --message \"maintenance until 5pm\"\n\n"+
+			"  # Show whether the runs are frozen\n"+
+			"  %s admin freeze status",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.NoArgs,
+	Run:  adminFreezeRun,
+}
+
+var adminFreezeStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the runs are frozen",
+	Args:  cobra.NoArgs,
+	Run:   adminFreezeStatusRun,
+}
+
+var adminUnfreezeCmd = &cobra.Command{
+	Use:     "unfreeze",
+	Short:   "Accept new runs again",
+	Example: fmt.Sprintf("  %s admin unfreeze", constants.ProjectName),
+	Args:    cobra.NoArgs,
+	Run:     adminUnfreezeRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminFreezeCmd)
+	adminCmd.AddCommand(adminUnfreezeCmd)
+	adminFreezeCmd.AddCommand(adminFreezeStatusCmd)
+
+	adminFreezeCmd.Flags().StringVar(&adminFreezeMessage, "message", "",
+		"Message shown to the users whose runs are rejected")
+}
+
+func adminFreezeRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewRunFreezeService(c, NewOutputWrapper()).Freeze(ctx, adminFreezeMessage)
+	})
+}
+
+func adminFreezeStatusRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewRunFreezeService(c, NewOutputWrapper()).Status(ctx)
+	})
+}
+
+func adminUnfreezeRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewRunFreezeService(c, NewOutputWrapper()).Unfreeze(ctx)
+	})
+}
+
+// RunFreezeService handles freezing and unfreezing the runs.
+type RunFreezeService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewRunFreezeService creates a new RunFreezeService with the provided dependencies.
+func NewRunFreezeService(apiClient client.Interface, outputter OutputInterface) *RunFreezeService {
+	return &RunFreezeService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// Freeze rejects the new runs with the message.
+func (s *RunFreezeService) Freeze(ctx context.Context, message string) error {
+	resp, err := s.client.FreezeRuns(ctx, api.RunFreezeRequest{Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to freeze runs: %w", err)
+	}
+
+	s.output.Successf("Runs frozen, new executions are rejected until %s admin unfreeze",
+		constants.ProjectName)
+	s.showFreeze(&resp.Freeze)
+	return nil
+}
+
+// Unfreeze accepts the new runs again.
+func (s *RunFreezeService) Unfreeze(ctx context.Context) error {
+	if _, err := s.client.UnfreezeRuns(ctx); err != nil {
+		return fmt.Errorf("failed to unfreeze runs: %w", err)
+	}
+
+	s.output.Successf("Runs unfrozen, new executions are accepted")
+	return nil
+}
+
+// Status shows whether the runs are frozen.
+func (s *RunFreezeService) Status(ctx context.Context) error {
+	resp, err := s.client.GetRunFreeze(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get run freeze: %w", err)
+	}
+
+	if !resp.Freeze.Frozen {
+		s.output.Infof("Runs are not frozen")
+		return nil
+	}
+	s.output.Warningf("Runs are frozen, new executions are rejected")
+	s.showFreeze(&resp.Freeze)
+	return nil
+}
+
+func (s *RunFreezeService) showFreeze(freeze *api.RunFreeze) {
+	if freeze.Message != "" {
+		s.output.KeyValue("Message", freeze.Message)
+	}
+	if freeze.UpdatedBy != "" {
+		s.output.KeyValue("Frozen By", freeze.UpdatedBy)
+	}
+	if freeze.UpdatedAt != nil {
+		s.output.KeyValue("Frozen At", freeze.UpdatedAt.Format(time.DateTime))
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestRunFreezeService_Freeze(t *testing.T) {
+	frozenAt := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	mockClient := &mockClientInterface{
+		freezeRunsFunc: func(_ context.Context, req api.RunFreezeRequest) (*api.RunFreezeResponse, error) {
+			assert.Equal(t, "maintenance until 5pm", req.Message)
+			return &api.RunFreezeResponse{Freeze: api.RunFreeze{
+				Frozen: true, Message: req.Message, UpdatedBy: "admin@example.com", UpdatedAt: &frozenAt,
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewRunFreezeService(mockClient, mockOutput).Freeze(context.Background(), "maintenance until 5pm")
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "maintenance until 5pm", keyValues["Message"])
+	assert.Equal(t, "admin@example.com", keyValues["Frozen By"])
+	assert.Equal(t, "2026-10-18 15:00:00", keyValues["Frozen At"])
+}
+
+func TestRunFreezeService_Status(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getRunFreezeFunc: func(_ context.Context) (*api.RunFreezeResponse, error) {
+			return &api.RunFreezeResponse{}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewRunFreezeService(mockClient, mockOutput).Status(context.Background())
+
+	require.NoError(t, err)
+	require.NotEmpty(t, mockOutput.calls)
+	assert.Equal(t, "Infof", mockOutput.calls[0].method)
+}
+
+func TestRunFreezeService_Unfreeze(t *testing.T) {
+	mockClient := &mockClientInterface{
+		unfreezeRunsFunc: func(_ context.Context) (*api.RunFreezeResponse, error) {
+			return nil, errors.New("run freeze is not configured")
+		},
+	}
+
+	err := NewRunFreezeService(mockClient, &mockOutputInterface{}).Unfreeze(context.Background())
+
+	assert.ErrorContains(t, err, "failed to unfreeze runs")
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	loadTestDefaultRPS      = 10
+	loadTestDefaultDuration = time.Minute
+	// loadTestMaxErrorRate is the share of failed requests above which a load test is reported as failed.
+	loadTestMaxErrorRate = 0.01
+	// loadTestMinRateRatio is the share of the target rate the backend must sustain.
+	loadTestMinRateRatio = 0.9
+	// loadTestP99 is the percentile of the latency summarized when the backend sustained the load.
+	loadTestP99 = 0.99
+)
+
+// loadTestPercentiles are the latency percentiles of the report, with their column headers.
+var loadTestPercentiles = []struct {
+	header string
+	p      float64
+}{{"p50", 0.5}, {"p90", 0.9}, {"p95", 0.95}, {"p99", loadTestP99}, {"Max", 1}}
+
+var (
+	adminLoadtestRPS      int
+	adminLoadtestDuration time.Duration
+	adminLoadtestDryTasks bool
+	adminLoadtestCommand  string
+	adminLoadtestImage    string
+)
+
+var adminLoadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Load test the run API of the backend",
+	Long: `Send run requests to the backend at a constant rate, then report their latency percentiles,
+error rate and the rate the backend sustained, to validate a deployment before onboarding a team.
+
+Unlike most admin commands, the requests are sent to the API with the configured API key.
+Each request starts an execution of a no-op command, unless --dry-tasks is set: the backend then
+validates and authorizes the requests and resolves their image and secrets, but starts no task
+and records no execution, so the API is tested without compute costs.
+
+The load test is bounded by --timeout, raise it for runs longer than 10 minutes.`,
+	Example: fmt.Sprintf(
+		"  # Send 20 requests per second for 2 minutes without starting tasks\n"+
+			"  %s admin loadtest --rps 20 --duration 2m --dry-tasks\n\n"+
+			"  # Start 5 executions per second of the default image for 30 seconds\n"+
+			"  %s admin loadtest --rps 5 --duration 30s",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: adminLoadtestRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminLoadtestCmd)
+
+	adminLoadtestCmd.Flags().IntVar(&adminLoadtestRPS, "rps", loadTestDefaultRPS, "Requests sent per second")
+	adminLoadtestCmd.Flags().DurationVar(&adminLoadtestDuration, "duration", loadTestDefaultDuration,
+		"How long to send requests")
+	adminLoadtestCmd.Flags().BoolVar(&adminLoadtestDryTasks, "dry-tasks", false,
+		"Exercise the run API without starting tasks or recording executions")
+	adminLoadtestCmd.Flags().StringVar(&adminLoadtestCommand, "command", "true", "Command of the executions")
+	adminLoadtestCmd.Flags().StringVar(&adminLoadtestImage, "image", "",
+		"Image of the executions. Uses the default image if not specified")
+}
+
+func adminLoadtestRun(cmd *cobra.Command, _ []string) {
+	if adminLoadtestRPS <= 0 {
+		output.Fatalf("--rps must be positive")
+	}
+	if adminLoadtestDuration <= 0 {
+		output.Fatalf("--duration must be positive")
+	}
+
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewLoadTestService(c, NewOutputWrapper())
+		opts := &LoadTestOptions{
+			RPS:      adminLoadtestRPS,
+			Duration: adminLoadtestDuration,
+			DryTasks: adminLoadtestDryTasks,
+			Command:  adminLoadtestCommand,
+			Image:    adminLoadtestImage,
+		}
+
+		spinner := output.NewSpinner(fmt.Sprintf("Sending %d requests per second for %s...", opts.RPS, opts.Duration))
+		spinner.Start()
+		report, err := service.Run(ctx, opts)
+		if err != nil {
+			spinner.Error("Load test failed")
+			return err
+		}
+		spinner.Success("Load test completed")
+
+		service.DisplayReport(report)
+		return nil
+	})
+}
+
+// LoadTestOptions configures a load test of the run API.
+type LoadTestOptions struct {
+	RPS      int
+	Duration time.Duration
+	DryTasks bool
+	Command  string
+	Image    string
+}
+
+// LoadTestReport holds the results of a load test.
+type LoadTestReport struct {
+	Options *LoadTestOptions
+	// Latencies are the sorted durations of all the requests, succeeded or not.
+	Latencies []time.Duration
+	// Errors counts the failed requests by error.
+	Errors map[string]int
+	// Elapsed is the time from the first request to the last response.
+	Elapsed time.Duration
+}
+
+// Failed returns the number of failed requests.
+func (r *LoadTestReport) Failed() int {
+	failed := 0
+	for _, count := range r.Errors {
+		failed += count
+	}
+	return failed
+}
+
+// Succeeded returns the number of succeeded requests.
+func (r *LoadTestReport) Succeeded() int {
+	return len(r.Latencies) - r.Failed()
+}
+
+// ErrorRate returns the share of failed requests, between 0 and 1.
+func (r *LoadTestReport) ErrorRate() float64 {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	return float64(r.Failed()) / float64(len(r.Latencies))
+}
+
+// Throughput returns the number of succeeded requests per second.
+func (r *LoadTestReport) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Succeeded()) / r.Elapsed.Seconds()
+}
+
+// Percentile returns the nearest-rank percentile p, between 0 and 1, of the latencies.
+func (r *LoadTestReport) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	return r.Latencies[max(int(math.Ceil(p*float64(len(r.Latencies))))-1, 0)]
+}
+
+// LoadTestService handles load testing the run API.
+type LoadTestService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewLoadTestService creates a new LoadTestService with the provided dependencies.
+func NewLoadTestService(apiClient client.Interface, outputter OutputInterface) *LoadTestService {
+	return &LoadTestService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// Run sends run requests at the rate of the options for their duration and waits for all the responses.
+func (s *LoadTestService) Run(ctx context.Context, opts *LoadTestOptions) (*LoadTestReport, error) {
+	if opts.RPS <= 0 || opts.Duration <= 0 {
+		return nil, errors.New("the rate and duration of a load test must be positive")
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < opts.Duration {
+		return nil, fmt.Errorf("the load test lasts longer than the remaining timeout of %s, raise --timeout",
+			time.Until(deadline).Round(time.Second))
+	}
+
+	report := &LoadTestReport{Options: opts, Errors: make(map[string]int)}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	send := func() {
+		req := &api.ExecutionRequest{Command: opts.Command, Image: opts.Image, DryRun: opts.DryTasks}
+		start := time.Now()
+		_, err := s.client.RunCommand(ctx, req)
+		latency := time.Since(start)
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.Latencies = append(report.Latencies, latency)
+		if err != nil {
+			report.Errors[loadTestErrorKey(err)]++
+		}
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(opts.RPS))
+	defer ticker.Stop()
+	done := time.After(opts.Duration)
+	wg.Go(send)
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-done:
+			break loop
+		case <-ticker.C:
+			wg.Go(send)
+		}
+	}
+	wg.Wait()
+	report.Elapsed = time.Since(start)
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("load test interrupted: %w", err)
+	}
+	slices.Sort(report.Latencies)
+	return report, nil
+}
+
+// loadTestErrorKey groups the errors of the client by their status code and message, leaving out
+// the details that differ between requests.
+func loadTestErrorKey(err error) string {
+	key, _, _ := strings.Cut(err.Error(), ": ")
+	return key
+}
+
+// DisplayReport displays the latency percentiles, errors and sustained rate of a load test.
+func (s *LoadTestService) DisplayReport(report *LoadTestReport) {
+	opts := report.Options
+
+	s.output.Blank()
+	s.output.KeyValue("Target", fmt.Sprintf("%d req/s for %s", opts.RPS, opts.Duration))
+	s.output.KeyValue("Requests", strconv.Itoa(len(report.Latencies)))
+	s.output.KeyValue("Succeeded", strconv.Itoa(report.Succeeded()))
+	s.output.KeyValue("Failed", fmt.Sprintf("%d (%.2f%%)", report.Failed(), report.ErrorRate()*percent))
+	s.output.KeyValue("Throughput", fmt.Sprintf("%.1f req/s", report.Throughput()))
+	s.output.Blank()
+
+	headers := make([]string, 0, len(loadTestPercentiles))
+	latencies := make([]string, 0, len(loadTestPercentiles))
+	for _, percentile := range loadTestPercentiles {
+		headers = append(headers, percentile.header)
+		latencies = append(latencies, formatLoadTestLatency(report.Percentile(percentile.p)))
+	}
+	s.output.Table(headers, [][]string{latencies})
+
+	if len(report.Errors) > 0 {
+		errs := slices.SortedFunc(maps.Keys(report.Errors), func(a, b string) int {
+			return cmp.Or(cmp.Compare(report.Errors[b], report.Errors[a]), strings.Compare(a, b))
+		})
+		rows := make([][]string, 0, len(errs))
+		for _, e := range errs {
+			rows = append(rows, []string{e, strconv.Itoa(report.Errors[e])})
+		}
+		s.output.Blank()
+		s.output.Table([]string{"Error", "Count"}, rows)
+	}
+
+	s.output.Blank()
+	switch {
+	case report.ErrorRate() > loadTestMaxErrorRate:
+		s.output.Warningf("The backend failed %.2f%% of the requests at %d req/s, more than the %.0f%% tolerated",
+			report.ErrorRate()*percent, opts.RPS, loadTestMaxErrorRate*percent)
+	case report.Throughput() < float64(opts.RPS)*loadTestMinRateRatio:
+		s.output.Warningf("The backend only sustained %.1f of the %d req/s sent", report.Throughput(), opts.RPS)
+	default:
+		s.output.Successf("The backend sustained %d req/s with a p99 latency of %s",
+			opts.RPS, formatLoadTestLatency(report.Percentile(loadTestP99)))
+	}
+	if opts.DryTasks {
+		s.output.Infof("No task was started (--dry-tasks), the capacity of the compute platform was not tested")
+	} else {
+		s.output.Infof("Started %d executions of %q", report.Succeeded(), opts.Command)
+	}
+}
+
+// formatLoadTestLatency formats a request latency to the millisecond.
+func formatLoadTestLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestLoadTestService_Run(t *testing.T) {
+	t.Run("sends requests at the rate of the options", func(t *testing.T) {
+		var sent atomic.Int32
+		mockClient := &mockClientInterfaceForRun{
+			mockClientInterface: &mockClientInterface{},
+			runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+				assert.True(t, req.DryRun)
+				assert.Equal(t, "true", req.Command)
+				if sent.Add(1)%2 == 0 {
+					return nil, errors.New("[503] failed to run command: injected fault")
+				}
+				return &api.ExecutionResponse{DryRun: true}, nil
+			},
+		}
+		service := NewLoadTestService(mockClient, &mockOutputInterface{})
+
+		report, err := service.Run(context.Background(), &LoadTestOptions{
+			RPS: 50, Duration: 200 * time.Millisecond, DryTasks: true, Command: "true",
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 10, len(report.Latencies), 2)
+		assert.Equal(t, int(sent.Load()), len(report.Latencies))
+		assert.Equal(t, map[string]int{"[503] failed to run command": report.Failed()}, report.Errors)
+		assert.Equal(t, len(report.Latencies)/2, report.Failed())
+		assert.IsNonDecreasing(t, report.Latencies)
+	})
+
+	t.Run("refuses a load test longer than the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		service := NewLoadTestService(&mockClientInterfaceForRun{}, &mockOutputInterface{})
+
+		_, err := service.Run(ctx, &LoadTestOptions{RPS: 1, Duration: time.Minute, Command: "true"})
+
+		assert.ErrorContains(t, err, "raise --timeout")
+	})
+
+	t.Run("rejects a zero rate", func(t *testing.T) {
+		service := NewLoadTestService(&mockClientInterfaceForRun{}, &mockOutputInterface{})
+
+		_, err := service.Run(context.Background(), &LoadTestOptions{Duration: time.Second})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadTestReport(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	report := &LoadTestReport{
+		Options:   &LoadTestOptions{RPS: 10, Duration: 10 * time.Second},
+		Latencies: latencies,
+		Errors:    map[string]int{"[503] failed to run command": 5},
+		Elapsed:   10 * time.Second,
+	}
+
+	assert.Equal(t, 50*time.Millisecond, report.Percentile(0.5))
+	assert.Equal(t, 99*time.Millisecond, report.Percentile(0.99))
+	assert.Equal(t, 100*time.Millisecond, report.Percentile(1))
+	assert.Equal(t, 95, report.Succeeded())
+	assert.InDelta(t, 0.05, report.ErrorRate(), 1e-9)
+	assert.InDelta(t, 9.5, report.Throughput(), 1e-9)
+}
+
+func TestLoadTestService_DisplayReport(t *testing.T) {
+	report := &LoadTestReport{
+		Options:   &LoadTestOptions{RPS: 2, Duration: time.Second, DryTasks: true},
+		Latencies: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		Errors:    map[string]int{},
+		Elapsed:   time.Second,
+	}
+
+	t.Run("reports a sustained load", func(t *testing.T) {
+		mockOutput := &mockOutputInterface{}
+		NewLoadTestService(&mockClientInterfaceForRun{}, mockOutput).DisplayReport(report)
+
+		methods := make([]string, 0, len(mockOutput.calls))
+		for _, call := range mockOutput.calls {
+			methods = append(methods, call.method)
+		}
+		assert.Contains(t, methods, "Successf")
+		assert.NotContains(t, methods, "Warningf")
+	})
+
+	t.Run("warns about errors", func(t *testing.T) {
+		failing := *report
+		failing.Errors = map[string]int{"failed to make request": 1}
+		mockOutput := &mockOutputInterface{}
+		NewLoadTestService(&mockClientInterfaceForRun{}, mockOutput).DisplayReport(&failing)
+
+		var warned bool
+		for _, call := range mockOutput.calls {
+			if call.method == "Table" && call.args[0].([]string)[0] == "Error" {
+				assert.Equal(t, [][]string{{"failed to make request", "1"}}, call.args[1])
+			}
+			warned = warned || call.method == "Warningf"
+		}
+		assert.True(t, warned)
+	})
+}
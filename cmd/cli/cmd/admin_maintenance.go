@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// admin maintenance flags, shared by the maintenance subcommands.
+	adminMaintenanceDryRun bool
+	adminMaintenanceYes    bool
+)
+
+var adminDeleteBucketsCmd = &cobra.Command{
+	Use:   "delete-buckets <bucket>...",
+	Short: "Empty and delete storage buckets",
+	Long: `Delete all the objects, object versions and delete markers of the buckets, then the buckets.
+
+This cannot be undone and must be confirmed (use --yes to skip the prompt).`,
+	Example: fmt.Sprintf(
+		"  # Count the objects that would be deleted\n"+
+			"  %s admin delete-buckets my-bucket --dry-run\n\n"+
+			"  # Delete two buckets without prompting\n"+
+			"  %s admin delete-buckets my-bucket my-other-bucket --yes",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.MinimumNArgs(1),
+	Run:  adminDeleteBucketsRun,
+}
+
+var adminTruncateTableCmd = &cobra.Command{
+	Use:   "truncate-table <table>",
+	Short: "Delete all the items of a database table",
+	Long: `Delete all the items of a backend database table, keeping the table.
+
+This cannot be undone and must be confirmed (use --yes to skip the prompt).`,
+	Example: fmt.Sprintf(
+		"  # Count the items that would be deleted\n"+
+			"  %s admin truncate-table runvoy-execution-logs --dry-run",
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  adminTruncateTableRun,
+}
+
+var adminCreateConfigCmd = &cobra.Command{
+	Use:   "create-config",
+	Short: "Save the API endpoint of the stack to the config file",
+	Long:  "Create or update the CLI config file with the API endpoint of the deployed stack, keeping the API key.",
+	Args:  cobra.NoArgs,
+	Run:   adminCreateConfigRun,
+}
+
+var adminSeedAdminCmd = &cobra.Command{
+	Use:   "seed-admin <email>",
+	Short: "Create the admin user of the backend",
+	Long: `Create the admin user of the backend and save its API key to the config file.
+
+It is safe to re-run: an existing user is displayed and kept unchanged.`,
+	Args: cobra.ExactArgs(1),
+	Run:  adminSeedAdminRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminDeleteBucketsCmd)
+	adminCmd.AddCommand(adminTruncateTableCmd)
+	adminCmd.AddCommand(adminCreateConfigCmd)
+	adminCmd.AddCommand(adminSeedAdminCmd)
+
+	for _, cmd := range []*cobra.Command{
+		adminDeleteBucketsCmd, adminTruncateTableCmd, adminCreateConfigCmd, adminSeedAdminCmd,
+	} {
+		cmd.Flags().BoolVar(&adminMaintenanceDryRun, "dry-run", false, "Show what would change without changing it")
+	}
+	for _, cmd := range []*cobra.Command{adminDeleteBucketsCmd, adminTruncateTableCmd} {
+		cmd.Flags().BoolVarP(&adminMaintenanceYes, "yes", "y", false, "Skip the confirmation prompt")
+	}
+}
+
+// newAdminMaintainer creates the maintainer of the provider resources, exiting on failure.
+func newAdminMaintainer(ctx context.Context) infra.Maintainer {
+	maintainer, err := infra.NewMaintainer(ctx, adminProvider, adminRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize maintainer: %v", err)
+	}
+	return maintainer
+}
+
+// confirmDestructive asks to confirm a destructive operation, unless it is a dry run or --yes is set.
+func confirmDestructive(prompt string, dryRun, skipConfirmation bool) bool {
+	if dryRun || skipConfirmation {
+		return true
+	}
+	return output.Confirm(prompt)
+}
+
+func adminDeleteBucketsRun(cmd *cobra.Command, args []string) {
+	if !confirmDestructive(fmt.Sprintf("Permanently delete %s and all their objects?", strings.Join(args, ", ")),
+		adminMaintenanceDryRun, adminMaintenanceYes) {
+		output.Infof("Aborted, no bucket deleted")
+		return
+	}
+
+	maintainer := newAdminMaintainer(cmd.Context())
+	var failed []string
+	for _, bucket := range args {
+		deleted, err := maintainer.DeleteBucket(cmd.Context(), bucket, adminMaintenanceDryRun)
+		switch {
+		case err != nil:
+			output.Errorf("Bucket %s: %v (%d objects deleted)", bucket, err, deleted)
+			failed = append(failed, bucket)
+		case adminMaintenanceDryRun:
+			output.Infof("Bucket %s: %d objects would be deleted, dry run, no changes applied", bucket, deleted)
+		default:
+			output.Successf("Bucket %s deleted with its %d objects", bucket, deleted)
+		}
+	}
+	if len(failed) > 0 {
+		output.Fatalf("failed to delete %d buckets: %s", len(failed), strings.Join(failed, ", "))
+	}
+}
+
+func adminTruncateTableRun(cmd *cobra.Command, args []string) {
+	table := args[0]
+	if !confirmDestructive(fmt.Sprintf("Permanently delete all the items of %s?", table),
+		adminMaintenanceDryRun, adminMaintenanceYes) {
+		output.Infof("Aborted, no item deleted")
+		return
+	}
+
+	deleted, err := newAdminMaintainer(cmd.Context()).TruncateTable(cmd.Context(), table, adminMaintenanceDryRun)
+	if err != nil {
+		output.Fatalf("failed to truncate %s after deleting %d items: %v", table, deleted, err)
+	}
+	if adminMaintenanceDryRun {
+		output.Infof("%d items of %s would be deleted, dry run, no changes applied", deleted, table)
+		return
+	}
+	output.Successf("%d items of %s deleted", deleted, table)
+}
+
+func adminCreateConfigRun(cmd *cobra.Command, _ []string) {
+	outputs := adminStackOutputs(cmd.Context())
+	endpoint := outputs["APIEndpoint"]
+	if endpoint == "" {
+		output.Fatalf("APIEndpoint not found in the outputs of stack %s", adminStackName)
+	}
+	if adminMaintenanceDryRun {
+		output.Infof("The config file would be updated with API endpoint %s, dry run, no changes applied", endpoint)
+		return
+	}
+	if err := saveAPIEndpointToConfig(endpoint); err != nil {
+		output.Fatalf(err.Error())
+	}
+	output.Successf("Config file updated with API endpoint %s", endpoint)
+}
+
+// saveAPIEndpointToConfig saves the API endpoint to the config file, creating it if needed.
+func saveAPIEndpointToConfig(endpoint string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		// Config doesn't exist yet, the API key is set by seed-admin or configure
+		cfg = &config.Config{}
+	}
+	cfg.APIEndpoint = endpoint
+	if err = config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+	return nil
+}
+
+func adminSeedAdminRun(cmd *cobra.Command, args []string) {
+	email := args[0]
+	deployer, err := infra.NewDeployer(cmd.Context(), adminProvider, adminRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(cmd.Context(), adminStackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+	if adminMaintenanceDryRun {
+		output.Infof("Admin user %s would be created in stack %s unless it exists, dry run, no changes applied",
+			email, adminStackName)
+		return
+	}
+	if err = bootstrapAdmin(cmd.Context(), adminProvider, deployer.GetRegion(), email, outputs); err != nil {
+		output.Fatalf(err.Error())
+	}
+}
+
+// adminStackOutputs returns the outputs of the stack of the admin commands, exiting on failure.
+func adminStackOutputs(ctx context.Context) map[string]string {
+	deployer, err := infra.NewDeployer(ctx, adminProvider, adminRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(ctx, adminStackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+	return outputs
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmDestructive_SkipsPrompt(t *testing.T) {
+	assert.True(t, confirmDestructive("Delete?", true, false))
+	assert.True(t, confirmDestructive("Delete?", false, true))
+}
+
+func TestAdminMaintenanceCommands_Flags(t *testing.T) {
+	for _, cmd := range []string{"delete-buckets", "truncate-table", "create-config", "seed-admin"} {
+		found, _, err := adminCmd.Find([]string{cmd})
+		if assert.NoError(t, err, cmd) {
+			assert.NotNil(t, found.Flags().Lookup("dry-run"), cmd)
+		}
+	}
+	for _, cmd := range []string{"delete-buckets", "truncate-table"} {
+		found, _, _ := adminCmd.Find([]string{cmd})
+		assert.NotNil(t, found.Flags().ShorthandLookup("y"), cmd)
+	}
+}
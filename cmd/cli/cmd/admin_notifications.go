@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adminNotificationsUsageReport           bool
+	adminNotificationsUsageReportRecipients []string
+)
+
+var adminNotificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Manage the notifications sent by the backend",
+	Long: `Manage the weekly usage report, emailed every Monday with the runs, success rate and estimated
+cost of each user over the past week. It is sent to the admins unless custom recipients are set, and
+only when the event processor is configured with an SMTP server.`,
+}
+
+var adminNotificationsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the notification settings",
+	Args:  cobra.NoArgs,
+	Run:   adminNotificationsShowRun,
+}
+
+var adminNotificationsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change the notification settings, the flags not given being left as they are",
+	Example: fmt.Sprintf(
+		"  # Opt out of the weekly usage report\n"+
+			"  %s admin notifications set --usage-report=false\n\n"+
+			"  # Send the report to the finance team instead of the admins\n"+
+			"  %s admin notifications set --usage-report-recipients finance@example.com\n\n"+
+			"  # Send the report to the admins again\n"+
+			"  %s admin notifications set --usage-report-recipients \"\"",
+		constants.ProjectName,
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.NoArgs,
+	Run:  adminNotificationsSetRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminNotificationsCmd)
+	adminNotificationsCmd.AddCommand(adminNotificationsShowCmd)
+	adminNotificationsCmd.AddCommand(adminNotificationsSetCmd)
+
+	adminNotificationsSetCmd.Flags().BoolVar(&adminNotificationsUsageReport, "usage-report", true,
+		"Send the weekly usage report")
+	adminNotificationsSetCmd.Flags().StringSliceVar(&adminNotificationsUsageReportRecipients,
+		"usage-report-recipients", nil, "Email addresses receiving the usage report instead of the admins, "+
+			"empty to send it to the admins")
+	adminNotificationsSetCmd.MarkFlagsOneRequired("usage-report", "usage-report-recipients")
+}
+
+func adminNotificationsShowRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewNotificationService(c, NewOutputWrapper()).Show(ctx)
+	})
+}
+
+func adminNotificationsSetRun(cmd *cobra.Command, _ []string) {
+	var update NotificationSettingsUpdate
+	if cmd.Flags().Changed("usage-report") {
+		update.UsageReport = &adminNotificationsUsageReport
+	}
+	if cmd.Flags().Changed("usage-report-recipients") {
+		update.UsageReportRecipients = &adminNotificationsUsageReportRecipients
+	}
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewNotificationService(c, NewOutputWrapper()).Set(ctx, update)
+	})
+}
+
+// NotificationSettingsUpdate holds the notification settings to change, those left nil being kept.
+type NotificationSettingsUpdate struct {
+	UsageReport           *bool
+	UsageReportRecipients *[]string
+}
+
+// NotificationService handles managing the notification settings.
+type NotificationService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewNotificationService creates a new NotificationService with the provided dependencies.
+func NewNotificationService(apiClient client.Interface, outputter OutputInterface) *NotificationService {
+	return &NotificationService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// Show shows the notification settings.
+func (s *NotificationService) Show(ctx context.Context) error {
+	resp, err := s.client.GetNotificationSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	s.showSettings(&resp.Settings)
+	return nil
+}
+
+// Set changes the notification settings of the update, reading the current ones first as they are replaced
+// as a whole.
+func (s *NotificationService) Set(ctx context.Context, update NotificationSettingsUpdate) error {
+	current, err := s.client.GetNotificationSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	req := api.NotificationSettingsRequest{UsageReport: current.Settings.UsageReport}
+	if update.UsageReport != nil {
+		req.UsageReport.Disabled = !*update.UsageReport
+	}
+	if update.UsageReportRecipients != nil {
+		req.UsageReport.Recipients = nil
+		for _, recipient := range *update.UsageReportRecipients {
+			if recipient = strings.TrimSpace(recipient); recipient != "" {
+				req.UsageReport.Recipients = append(req.UsageReport.Recipients, recipient)
+			}
+		}
+	}
+	resp, err := s.client.SetNotificationSettings(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to set notification settings: %w", err)
+	}
+
+	s.output.Successf("Notification settings set successfully")
+	s.showSettings(&resp.Settings)
+	return nil
+}
+
+func (s *NotificationService) showSettings(settings *api.NotificationSettings) {
+	s.output.KeyValue("Usage Report", formatEnabled(!settings.UsageReport.Disabled))
+	recipients := "admins"
+	if len(settings.UsageReport.Recipients) > 0 {
+		recipients = strings.Join(settings.UsageReport.Recipients, ", ")
+	}
+	s.output.KeyValue("Usage Report Recipients", recipients)
+	if settings.UpdatedBy != "" {
+		s.output.KeyValue("Updated By", settings.UpdatedBy)
+	}
+	if settings.UpdatedAt != nil {
+		s.output.KeyValue("Updated At", settings.UpdatedAt.Format(time.DateTime))
+	}
+}
+
+func formatEnabled(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
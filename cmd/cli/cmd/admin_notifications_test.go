@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestNotificationService_Show(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getNotificationSettingsFunc: func(_ context.Context) (*api.NotificationSettingsResponse, error) {
+			return &api.NotificationSettingsResponse{}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewNotificationService(mockClient, mockOutput).Show(context.Background())
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "enabled", keyValues["Usage Report"])
+	assert.Equal(t, "admins", keyValues["Usage Report Recipients"])
+}
+
+func TestNotificationService_Set(t *testing.T) {
+	current := api.NotificationSettings{
+		UsageReport: api.UsageReportSettings{Recipients: []string{"finance@example.com"}},
+	}
+	var sent api.NotificationSettingsRequest
+	mockClient := &mockClientInterface{
+		getNotificationSettingsFunc: func(_ context.Context) (*api.NotificationSettingsResponse, error) {
+			return &api.NotificationSettingsResponse{Settings: current}, nil
+		},
+		setNotificationSettingsFunc: func(
+			_ context.Context, req api.NotificationSettingsRequest,
+		) (*api.NotificationSettingsResponse, error) {
+			sent = req
+			return &api.NotificationSettingsResponse{Settings: api.NotificationSettings{UsageReport: req.UsageReport}}, nil
+		},
+	}
+	svc := NewNotificationService(mockClient, &mockOutputInterface{})
+
+	disabled := false
+	require.NoError(t, svc.Set(context.Background(), NotificationSettingsUpdate{UsageReport: &disabled}))
+	assert.True(t, sent.UsageReport.Disabled)
+	assert.Equal(t, []string{"finance@example.com"}, sent.UsageReport.Recipients, "the recipients are kept")
+
+	recipients := []string{""}
+	require.NoError(t, svc.Set(context.Background(), NotificationSettingsUpdate{UsageReportRecipients: &recipients}))
+	assert.False(t, sent.UsageReport.Disabled, "the opt out is kept")
+	assert.Empty(t, sent.UsageReport.Recipients, "an empty recipient sends the report to the admins again")
+}
+
+func TestNotificationService_SetError(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getNotificationSettingsFunc: func(_ context.Context) (*api.NotificationSettingsResponse, error) {
+			return nil, errors.New("notification settings are not configured")
+		},
+	}
+
+	err := NewNotificationService(mockClient, &mockOutputInterface{}).Set(context.Background(),
+		NotificationSettingsUpdate{})
+
+	assert.ErrorContains(t, err, "failed to get notification settings")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/crypto"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adminRotateKeysForce bool
+	adminRotateKeysAfter string
+)
+
+var adminRotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Re-encrypt the secret values under the current key",
+	Long: `Re-encrypt the stored secret values whose data key was wrapped by another key than the
+current key of the stack, for example after switching to a customer managed key with
+infra apply --kms-key. Values stored before envelope encryption are encrypted too.
+
+The previous key must stay enabled until the rotation completes. Values are rotated one
+at a time, so an interrupted rotation can be run again: the values already under the
+current key are left unchanged. With --force, every value is re-encrypted with a new data
+key, and --after resumes an interrupted forced rotation.`,
+	Example: fmt.Sprintf(
+		"  # Re-encrypt the values under the current key\n"+
+			"  %s admin rotate-keys\n\n"+
+			"  # Re-encrypt every value, resuming after the secret github-token\n"+
+			"  %s admin rotate-keys --force --after github-token",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: adminRotateKeysRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminRotateKeysCmd)
+
+	adminRotateKeysCmd.Flags().BoolVar(&adminRotateKeysForce, "force", false,
+		"Re-encrypt the values already under the current key")
+	adminRotateKeysCmd.Flags().StringVar(&adminRotateKeysAfter, "after", "",
+		"Resume an interrupted rotation after this secret")
+}
+
+func adminRotateKeysRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	rotator := newStackKeyRotator(ctx, adminProvider, adminStackName, adminRegion)
+
+	var bar *output.ProgressBar
+	summary, err := crypto.RotateAll(ctx, rotator, crypto.RotateOptions{
+		Force: adminRotateKeysForce,
+		After: adminRotateKeysAfter,
+		Progress: func(p crypto.RotationProgress) {
+			if bar == nil {
+				bar = output.NewProgressBar(p.Total, "Rotating secrets")
+			}
+			bar.Update(p.Done)
+		},
+	})
+	if err != nil {
+		output.Blank()
+		printRotationSummary(summary)
+		if summary != nil && summary.Last != "" {
+			output.Fatalf("%v, fix the issue and resume with %s admin rotate-keys --after %s",
+				err, constants.ProjectName, summary.Last)
+		}
+		output.Fatalf("%v, fix the issue and run the rotation again", err)
+	}
+	printRotationSummary(summary)
+	output.Successf("Secret values are encrypted under the current key")
+}
+
+func printRotationSummary(summary *crypto.RotationSummary) {
+	if summary == nil {
+		return
+	}
+	output.Blank()
+	output.Table([]string{"Secrets", "Count"}, [][]string{
+		{"Rotated", strconv.Itoa(summary.Outcomes[crypto.RotationRotated])},
+		{"Encrypted (stored before envelope encryption)", strconv.Itoa(summary.Outcomes[crypto.RotationEncrypted])},
+		{"Already under the current key", strconv.Itoa(summary.Outcomes[crypto.RotationCurrent])},
+		{"Skipped (--after)", strconv.Itoa(summary.Skipped)},
+	})
+	output.Blank()
+}
+
+// newStackKeyRotator creates the rotator of the encrypted values of the backend deployed by the stack.
+func newStackKeyRotator(ctx context.Context, provider, stackName, region string) crypto.Rotator {
+	deployer, err := infra.NewDeployer(ctx, provider, region)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(ctx, stackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+	rotator, err := infra.NewKeyRotator(ctx, provider, deployer.GetRegion(), outputs)
+	if err != nil {
+		output.Fatalf("failed to initialize key rotation: %v", err)
+	}
+	return rotator
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/runvoy/runvoy/internal/database/migrations"
+)
+
+func TestFormatMigrationStatus(t *testing.T) {
+	migration := migrations.Migration{Version: 1, Name: "backfill-all-field", Description: "Add the _all attribute"}
+
+	assert.Equal(t,
+		[]string{"1", "backfill-all-field", "pending", "", "", "Add the _all attribute"},
+		formatMigrationStatus(&migrations.Status{Migration: migration}))
+
+	assert.Equal(t,
+		[]string{"1", "backfill-all-field", "applied", "2026-01-02T03:04:05Z", "12", "Add the _all attribute"},
+		formatMigrationStatus(&migrations.Status{
+			Migration: migration,
+			Applied:   true,
+			Record:    &migrations.Record{AppliedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Changes: 12},
+		}))
+}
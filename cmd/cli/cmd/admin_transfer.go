@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database/backup"
+
+	"github.com/spf13/cobra"
+)
+
+// identitiesFlagUsage describes the --map-identity flag of the commands importing archives.
+const identitiesFlagUsage = "Map a source task or execution role to a destination identity, as source=destination"
+
+var (
+	adminTransferToProvider  string
+	adminTransferToStackName string
+	adminTransferToRegion    string
+	adminTransferIdentities  map[string]string
+	adminTransferDryRun      bool
+	adminRestoreIdentities   map[string]string
+)
+
+var adminTransferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Copy users, images and secrets to another backend",
+	Long: `Copy the users (with their API key hashes), image configurations and secrets of the backend
+selected by --provider, --stack-name and --region to another backend, which may use another provider.
+
+Provider-specific image fields are remapped for the destination: CPU amounts are converted,
+task definitions are registered again by the destination's health reconciliation, and roles are
+mapped with --map-identity (IAM role names on AWS, service account emails on GCP). Roles without
+a mapping are replaced by the destination defaults when changing provider.
+
+Secret values are encrypted with the destination's key. Existing records of the destination
+are kept unchanged, so the command can be re-run.`,
+	Example: fmt.Sprintf(
+		"  # Preview a transfer to a stack in another region\n"+
+			"  %s admin transfer --to-stack-name runvoy-eu --to-region eu-west-1 --dry-run\n\n"+
+			"  # Transfer to another stack, mapping a task role\n"+
+			"  %s admin transfer --to-stack-name runvoy-new --map-identity old-task-role=new-task-role",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: adminTransferRun,
+}
+
+func init() {
+	adminCmd.AddCommand(adminTransferCmd)
+
+	adminTransferCmd.Flags().StringVar(&adminTransferToProvider, "to-provider", "",
+		"Destination cloud provider. Defaults to the source provider")
+	adminTransferCmd.Flags().StringVar(&adminTransferToStackName, "to-stack-name", "",
+		"Destination infrastructure stack name")
+	adminTransferCmd.Flags().StringVar(&adminTransferToRegion, "to-region", "",
+		"Destination provider region. Uses provider default if not specified")
+	adminTransferCmd.Flags().StringToStringVar(&adminTransferIdentities, "map-identity", nil, identitiesFlagUsage)
+	adminTransferCmd.Flags().BoolVar(&adminTransferDryRun, "dry-run", false,
+		"Show the records and remapping warnings without writing to the destination")
+	_ = adminTransferCmd.MarkFlagRequired("to-stack-name")
+
+	adminRestoreCmd.Flags().StringToStringVar(&adminRestoreIdentities, "map-identity", nil, identitiesFlagUsage)
+}
+
+func adminTransferRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	toProvider := adminTransferToProvider
+	if toProvider == "" {
+		toProvider = adminProvider
+	}
+	if strings.EqualFold(toProvider, adminProvider) && adminTransferToStackName == adminStackName &&
+		adminTransferToRegion == adminRegion {
+		output.Fatalf("the destination must differ from the source backend")
+	}
+
+	source := newStackBackupStore(ctx, adminProvider, adminStackName, adminRegion)
+	spinner := output.NewSpinner("Exporting source backend data...")
+	spinner.Start()
+	archive, err := backup.Create(ctx, source, adminProvider, time.Now())
+	if err != nil {
+		spinner.Error("Export failed")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Source backend data exported")
+
+	archive = convertArchive(archive, toProvider, adminTransferIdentities)
+	if adminTransferDryRun {
+		output.Blank()
+		output.KeyValue("Users", strconv.Itoa(len(archive.Users)))
+		output.KeyValue("Images", strconv.Itoa(len(archive.Images)))
+		output.KeyValue("Secrets", strconv.Itoa(len(archive.Secrets)))
+		output.Blank()
+		output.Infof("Dry run, nothing was written to %s", adminTransferToStackName)
+		return
+	}
+
+	destination := newStackBackupStore(ctx, toProvider, adminTransferToStackName, adminTransferToRegion)
+	spinner = output.NewSpinner(fmt.Sprintf("Importing data into %s...", adminTransferToStackName))
+	spinner.Start()
+	report, err := backup.Restore(ctx, destination, archive)
+	if err != nil {
+		spinner.Error("Transfer failed")
+		printRestoreReport(report)
+		output.Fatalf("%v, fix the issue and run the transfer again", err)
+	}
+	spinner.Success("Transfer completed")
+	printRestoreReport(report)
+
+	if report.Images.Restored > 0 {
+		output.Infof("Run %s health reconcile against the destination backend to register the images' task definitions",
+			constants.ProjectName)
+	}
+}
+
+// convertArchive remaps the provider-specific fields of the archive for a backend of the provider,
+// reporting the values that could not be carried over.
+func convertArchive(archive *backup.Archive, provider string, identities map[string]string) *backup.Archive {
+	converted, warnings, err := backup.Convert(archive, provider, &backup.ConvertOptions{Identities: identities})
+	if err != nil {
+		output.Fatalf("failed to convert archive: %v", err)
+	}
+	for _, warning := range warnings {
+		output.Warningf("%s", warning)
+	}
+	return converted
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <execution-id> <note>",
+	Short: "Attach a note to a command execution",
+	Long: `Attach a note to a command execution after the fact, e.g. to record why it failed.
+The note is recorded with its author and time, and shown by the status and trace commands
+and in the web viewer. Several words are joined into a single note.`,
+	Example: fmt.Sprintf(
+		`  - %s annotate 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10 "this failure was caused by an upstream outage"`,
+		constants.ProjectName),
+	Run:               annotateRun,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
+}
+
+func init() {
+	rootCmd.AddCommand(annotateCmd)
+}
+
+func annotateRun(cmd *cobra.Command, args []string) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+
+	c := client.New(cfg, slog.Default())
+	service := NewAnnotateService(c, NewOutputWrapper())
+	if err = service.AnnotateExecution(cmd.Context(), args[0], strings.Join(args[1:], " ")); err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// AnnotateService handles attaching notes to executions.
+type AnnotateService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewAnnotateService creates a new AnnotateService with the provided dependencies.
+func NewAnnotateService(apiClient client.Interface, outputter OutputInterface) *AnnotateService {
+	return &AnnotateService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// AnnotateExecution attaches the note to the execution and displays the recorded annotation.
+func (s *AnnotateService) AnnotateExecution(ctx context.Context, executionID, note string) error {
+	annotation, err := s.client.AnnotateExecution(ctx, executionID, note)
+	if err != nil {
+		return fmt.Errorf("failed to annotate execution: %w", err)
+	}
+
+	s.output.Successf("Execution annotated successfully")
+	s.output.KeyValue("Execution ID", executionID)
+	s.output.KeyValue("Author", annotation.Author)
+	s.output.KeyValue("Created At", annotation.CreatedAt.Local().Format(time.DateTime))
+	return nil
+}
+
+// displayAnnotations shows the notes attached to an execution, oldest first.
+func displayAnnotations(out OutputInterface, annotations []api.ExecutionAnnotation) {
+	rows := make([][]string, 0, len(annotations))
+	for _, annotation := range annotations {
+		rows = append(rows, []string{
+			annotation.CreatedAt.Local().Format(time.DateTime),
+			annotation.Author,
+			annotation.Note,
+		})
+	}
+	out.Table([]string{"Time", "Author", "Note"}, rows)
+}
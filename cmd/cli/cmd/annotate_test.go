@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestAnnotateService_AnnotateExecution(t *testing.T) {
+	t.Run("attaches the note", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			annotateExecutionFunc: func(_ context.Context, executionID, note string) (*api.ExecutionAnnotation, error) {
+				assert.Equal(t, "exec-123", executionID)
+				return &api.ExecutionAnnotation{Note: note, Author: "alice@example.com", CreatedAt: time.Now()}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewAnnotateService(mockClient, mockOutput)
+
+		err := service.AnnotateExecution(context.Background(), "exec-123", "caused by an upstream outage")
+
+		require.NoError(t, err)
+		keyValues := map[string]any{}
+		for _, call := range mockOutput.calls {
+			if call.method == "KeyValue" {
+				keyValues[call.args[0].(string)] = call.args[1]
+			}
+		}
+		assert.Equal(t, "alice@example.com", keyValues["Author"])
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			annotateExecutionFunc: func(_ context.Context, _, _ string) (*api.ExecutionAnnotation, error) {
+				return nil, errors.New("not allowed to read execution exec-123")
+			},
+		}
+		service := NewAnnotateService(mockClient, &mockOutputInterface{})
+
+		err := service.AnnotateExecution(context.Background(), "exec-123", "note")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to annotate execution")
+	})
+}
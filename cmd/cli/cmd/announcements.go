@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// showAnnouncement prints the announcement sent with the API responses of the command, at most once per
+// constants.AnnouncementShowInterval for the same message.
+func showAnnouncement() {
+	message := client.LastAnnouncement()
+	if message == "" {
+		return
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return
+	}
+	notifier := &AnnouncementNotifier{
+		statePath: filepath.Join(configDir, constants.AnnouncementsStateFileName),
+		now:       time.Now,
+	}
+	if notifier.ShouldShow(message) {
+		output.Warningf("📢 %s", message)
+	}
+}
+
+// AnnouncementNotifier tells when an announcement is due to be shown, recording when each announcement
+// was last shown in a state file.
+type AnnouncementNotifier struct {
+	statePath string
+	now       func() time.Time
+}
+
+// ShouldShow reports whether the announcement was not shown within constants.AnnouncementShowInterval,
+// and records it as shown if so. An unreadable state file shows the announcement.
+func (n *AnnouncementNotifier) ShouldShow(message string) bool {
+	now := n.now()
+	state := n.load()
+
+	sum := sha256.Sum256([]byte(message))
+	key := hex.EncodeToString(sum[:])
+	if shownAt, ok := state[key]; ok && now.Sub(shownAt) < constants.AnnouncementShowInterval {
+		return false
+	}
+
+	// Announcements shown before the interval would be shown again anyway, so they are dropped.
+	for k, shownAt := range state {
+		if now.Sub(shownAt) >= constants.AnnouncementShowInterval {
+			delete(state, k)
+		}
+	}
+	state[key] = now
+	_ = n.save(state)
+	return true
+}
+
+// load reads the times the announcements were last shown, by SHA-256 of their message.
+func (n *AnnouncementNotifier) load() map[string]time.Time {
+	state := map[string]time.Time{}
+	data, err := os.ReadFile(n.statePath)
+	if err != nil {
+		return state
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return map[string]time.Time{}
+	}
+	return state
+}
+
+func (n *AnnouncementNotifier) save(state map[string]time.Time) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal announcements state: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(n.statePath), constants.ConfigDirPermissions); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err = os.WriteFile(n.statePath, data, constants.ConfigFilePermissions); err != nil {
+		return fmt.Errorf("failed to write announcements state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func TestAnnouncementNotifier_ShouldShow(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	notifier := &AnnouncementNotifier{
+		statePath: filepath.Join(t.TempDir(), "runvoy", constants.AnnouncementsStateFileName),
+		now:       func() time.Time { return now },
+	}
+
+	assert.True(t, notifier.ShouldShow("Outage until 3pm"), "a new announcement is shown")
+	assert.False(t, notifier.ShouldShow("Outage until 3pm"), "it is shown once per day")
+	assert.True(t, notifier.ShouldShow("Outage until 5pm"), "a changed announcement is shown")
+
+	now = now.Add(constants.AnnouncementShowInterval)
+	assert.True(t, notifier.ShouldShow("Outage until 3pm"), "it is shown again the next day")
+}
+
+func TestAnnouncementNotifier_CorruptState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), constants.AnnouncementsStateFileName)
+	require.NoError(t, os.WriteFile(statePath, []byte("not json"), constants.ConfigFilePermissions))
+	notifier := &AnnouncementNotifier{statePath: statePath, now: time.Now}
+
+	assert.True(t, notifier.ShouldShow("Outage until 3pm"))
+	assert.False(t, notifier.ShouldShow("Outage until 3pm"), "the state file is rewritten")
+}
+
+func TestAnnouncementService_Show(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getMetaFunc: func(_ context.Context) (*api.MetaResponse, error) {
+			return &api.MetaResponse{Announcement: &api.Announcement{
+				Message: "Outage until 3pm", UpdatedBy: "admin@example.com",
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewAnnouncementService(mockClient, mockOutput).Show(context.Background())
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "Outage until 3pm", keyValues["Message"])
+	assert.Equal(t, "admin@example.com", keyValues["Set By"])
+}
+
+func TestAnnouncementService_Set(t *testing.T) {
+	mockClient := &mockClientInterface{
+		setAnnouncementFunc: func(_ context.Context, req api.AnnouncementRequest) (*api.AnnouncementResponse, error) {
+			assert.Equal(t, "Outage until 3pm", req.Message)
+			return &api.AnnouncementResponse{Announcement: &api.Announcement{Message: req.Message}}, nil
+		},
+	}
+
+	err := NewAnnouncementService(mockClient, &mockOutputInterface{}).Set(context.Background(), "Outage until 3pm")
+
+	require.NoError(t, err)
+}
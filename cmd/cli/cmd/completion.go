@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+// completionFetcher returns completion candidates for the given prefix using the provided service.
+type completionFetcher func(ctx context.Context, s *CompletionService, toComplete string) ([]cobra.Completion, error)
+
+// CompletionService fetches live resource names from the backend for dynamic shell completions.
+type CompletionService struct {
+	client client.Interface
+}
+
+// NewCompletionService creates a new CompletionService with the provided dependencies.
+func NewCompletionService(apiClient client.Interface) *CompletionService {
+	return &CompletionService{
+		client: apiClient,
+	}
+}
+
+// ExecutionIDs returns the most recent execution IDs matching the given prefix,
+// annotated with their status and command.
+func (s *CompletionService) ExecutionIDs(ctx context.Context, toComplete string) ([]cobra.Completion, error) {
+	execs, err := s.client.ListExecutions(ctx, constants.CompletionExecutionLimit, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	completions := make([]cobra.Completion, 0, len(execs))
+	for i := range execs {
+		e := &execs[i]
+		if !strings.HasPrefix(e.ExecutionID, toComplete) {
+			continue
+		}
+		completions = append(completions, cobra.CompletionWithDesc(
+			e.ExecutionID, e.Status+" "+truncateCommand(e.Command),
+		))
+	}
+	return completions, nil
+}
+
+// ImageNames returns the registered image names and image IDs matching the given prefix.
+func (s *CompletionService) ImageNames(ctx context.Context, toComplete string) ([]cobra.Completion, error) {
+	resp, err := s.client.ListImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	completions := make([]cobra.Completion, 0, len(resp.Images))
+	seen := make(map[string]struct{}, len(resp.Images))
+	for i := range resp.Images {
+		img := &resp.Images[i]
+		for _, candidate := range []string{img.Image, img.ImageID} {
+			if candidate == "" || !strings.HasPrefix(candidate, toComplete) {
+				continue
+			}
+			if _, ok := seen[candidate]; ok {
+				continue
+			}
+			seen[candidate] = struct{}{}
+			completions = append(completions, cobra.CompletionWithDesc(candidate, img.ImageID))
+		}
+	}
+	return completions, nil
+}
+
+// SecretNames returns the secret names matching the given prefix, annotated with their key names.
+func (s *CompletionService) SecretNames(ctx context.Context, toComplete string) ([]cobra.Completion, error) {
+	resp, err := s.client.ListSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	completions := make([]cobra.Completion, 0, len(resp.Secrets))
+	for _, secret := range resp.Secrets {
+		if secret == nil || !strings.HasPrefix(secret.Name, toComplete) {
+			continue
+		}
+		completions = append(completions, cobra.CompletionWithDesc(secret.Name, secret.KeyName))
+	}
+	return completions, nil
+}
+
+// SavedFilterNames returns the names of the execution list filters saved by the user matching the given prefix,
+// annotated with their criteria.
+func (s *CompletionService) SavedFilterNames(ctx context.Context, toComplete string) ([]cobra.Completion, error) {
+	resp, err := s.client.ListSavedFilters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved filters: %w", err)
+	}
+
+	completions := make([]cobra.Completion, 0, len(resp.Filters))
+	for i := range resp.Filters {
+		filter := &resp.Filters[i]
+		if !strings.HasPrefix(filter.Name, toComplete) {
+			continue
+		}
+		completions = append(completions, cobra.CompletionWithDesc(filter.Name, formatListFilter(filter)))
+	}
+	return completions, nil
+}
+
+// completeFirstArg builds a cobra completion function that completes only the first positional
+// argument using live data fetched from the backend.
+func completeFirstArg(fetch completionFetcher) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeFromBackend(cmd, toComplete, fetch)
+	}
+}
+
+// completeEveryArg builds a cobra completion function that completes all positional arguments
+// using live data fetched from the backend.
+func completeEveryArg(fetch completionFetcher) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completeFromBackend(cmd, toComplete, fetch)
+	}
+}
+
+// completeFlag builds a cobra completion function for flag values using live data fetched from the backend.
+func completeFlag(fetch completionFetcher) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completeFromBackend(cmd, toComplete, fetch)
+	}
+}
+
+// completeFromBackend loads the CLI configuration and fetches completion candidates.
+// Completion runs outside the normal command lifecycle, so the configuration is loaded directly
+// and any failure silently results in no suggestions.
+func completeFromBackend(
+	cmd *cobra.Command,
+	toComplete string,
+	fetch completionFetcher,
+) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := config.LoadCLI()
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to load configuration: %v", err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), constants.CompletionRequestTimeout)
+	defer cancel()
+
+	completions, err := fetch(ctx, NewCompletionService(client.New(cfg, slog.Default())), toComplete)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// fetchExecutionIDs is a completionFetcher for execution IDs.
+func fetchExecutionIDs(ctx context.Context, s *CompletionService, toComplete string) ([]cobra.Completion, error) {
+	return s.ExecutionIDs(ctx, toComplete)
+}
+
+// fetchImageNames is a completionFetcher for registered image names.
+func fetchImageNames(ctx context.Context, s *CompletionService, toComplete string) ([]cobra.Completion, error) {
+	return s.ImageNames(ctx, toComplete)
+}
+
+// fetchSecretNames is a completionFetcher for secret names.
+func fetchSecretNames(ctx context.Context, s *CompletionService, toComplete string) ([]cobra.Completion, error) {
+	return s.SecretNames(ctx, toComplete)
+}
+
+// fetchSavedFilterNames is a completionFetcher for the names of saved execution list filters.
+func fetchSavedFilterNames(ctx context.Context, s *CompletionService, toComplete string) ([]cobra.Completion, error) {
+	return s.SavedFilterNames(ctx, toComplete)
+}
+
+// fetchSavedFilterReferences is a completionFetcher for saved execution list filters referenced as "saved:<name>".
+func fetchSavedFilterReferences(
+	ctx context.Context,
+	s *CompletionService,
+	toComplete string,
+) ([]cobra.Completion, error) {
+	name, _ := strings.CutPrefix(toComplete, savedFilterPrefix)
+	completions, err := s.SavedFilterNames(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range completions {
+		completions[i] = savedFilterPrefix + completions[i]
+	}
+	return completions, nil
+}
+
+// truncateCommand shortens a command for compact display in tables and completion descriptions.
+func truncateCommand(command string) string {
+	if len(command) > maxCommandLength {
+		return command[:maxCommandLength] + "..."
+	}
+	return command
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func TestCompletionService_ExecutionIDs(t *testing.T) {
+	mockClient := &mockClientInterfaceForList{
+		mockClientInterface: &mockClientInterface{},
+		listExecutionsFunc: func(_ context.Context, limit int, statuses string) ([]api.Execution, error) {
+			assert.Equal(t, constants.CompletionExecutionLimit, limit)
+			assert.Empty(t, statuses)
+			return []api.Execution{
+				{ExecutionID: "abc123", Status: "RUNNING", Command: "echo hello"},
+				{ExecutionID: "abd456", Status: "SUCCEEDED", Command: "ls"},
+				{ExecutionID: "xyz789", Status: "FAILED", Command: "false"},
+			}, nil
+		},
+	}
+
+	completions, err := NewCompletionService(mockClient).ExecutionIDs(context.Background(), "ab")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc123\tRUNNING echo hello", "abd456\tSUCCEEDED ls"}, completions)
+}
+
+func TestCompletionService_ExecutionIDs_Error(t *testing.T) {
+	mockClient := &mockClientInterfaceForList{
+		mockClientInterface: &mockClientInterface{},
+		listExecutionsFunc: func(_ context.Context, _ int, _ string) ([]api.Execution, error) {
+			return nil, errors.New("network error")
+		},
+	}
+
+	completions, err := NewCompletionService(mockClient).ExecutionIDs(context.Background(), "")
+	require.Error(t, err)
+	assert.Nil(t, completions)
+	assert.Contains(t, err.Error(), "failed to list executions")
+}
+
+func TestCompletionService_ImageNames(t *testing.T) {
+	mockClient := &mockClientInterfaceForImages{
+		mockClientInterface: &mockClientInterface{},
+		listImagesFunc: func(_ context.Context) (*api.ListImagesResponse, error) {
+			return &api.ListImagesResponse{
+				Images: []api.ImageInfo{
+					{Image: "alpine:latest", ImageID: "alpine:latest-a1b2c3d4"},
+					{Image: "ubuntu:22.04", ImageID: "ubuntu:22.04-e5f6a7b8"},
+				},
+			}, nil
+		},
+	}
+
+	completions, err := NewCompletionService(mockClient).ImageNames(context.Background(), "alp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alpine:latest\talpine:latest-a1b2c3d4",
+		"alpine:latest-a1b2c3d4\talpine:latest-a1b2c3d4",
+	}, completions)
+}
+
+func TestCompletionService_SecretNames(t *testing.T) {
+	mockClient := &mockClientInterfaceForSecrets{
+		mockClientInterface: &mockClientInterface{},
+		listSecretsFunc: func(_ context.Context) (*api.ListSecretsResponse, error) {
+			return &api.ListSecretsResponse{
+				Secrets: []*api.Secret{
+					{Name: "github-token", KeyName: "GITHUB_TOKEN"},
+					{Name: "db-password", KeyName: "DB_PASSWORD"},
+					nil,
+				},
+			}, nil
+		},
+	}
+
+	completions, err := NewCompletionService(mockClient).SecretNames(context.Background(), "git")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github-token\tGITHUB_TOKEN"}, completions)
+}
+
+func TestCompletionService_SavedFilterNames(t *testing.T) {
+	mockClient := &mockClientInterface{
+		listSavedFiltersFunc: func(_ context.Context) (*api.ListSavedFiltersResponse, error) {
+			return &api.ListSavedFiltersResponse{Filters: []api.ExecutionListFilter{
+				{Name: "failures", Statuses: []string{"FAILED"}},
+				{Name: "starred", Starred: true},
+			}}, nil
+		},
+	}
+	svc := NewCompletionService(mockClient)
+
+	completions, err := svc.SavedFilterNames(context.Background(), "fail")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"failures\t--status FAILED"}, completions)
+
+	completions, err = fetchSavedFilterReferences(context.Background(), svc, "saved:st")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"saved:starred\t--starred"}, completions)
+}
+
+func TestTruncateCommand(t *testing.T) {
+	assert.Equal(t, "short", truncateCommand("short"))
+	long := "echo this is a very long command that exceeds the limit"
+	truncated := truncateCommand(long)
+	assert.Len(t, truncated, maxCommandLength+len("..."))
+	assert.Equal(t, long[:maxCommandLength]+"...", truncated)
+}
@@ -147,8 +147,9 @@ func (s *ConfigureService) Configure(_ context.Context) error {
 	}
 
 	cfg := &config.Config{
-		APIEndpoint: endpoint,
-		APIKey:      apiKey,
+		APIEndpoint:   endpoint,
+		APIKey:        apiKey,
+		UpdateChannel: existingConfig.UpdateChannel,
 	}
 
 	if err = s.configSaver.Save(cfg); err != nil {
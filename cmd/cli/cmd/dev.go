@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+// devDefaultEnvFile is the .env file synced without a profile.
+const devDefaultEnvFile = ".env"
+
+var (
+	// dev flags, shared by all subcommands.
+	devProvider  string
+	devStackName string
+	devRegion    string
+
+	// dev sync-env flags.
+	devSyncEnvComponent string
+	devSyncEnvProfile   string
+	devSyncEnvFile      string
+	devSyncEnvCheck     bool
+	devSyncEnvDryRun    bool
+)
+
+// devCmd is the parent command for local development helpers.
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Local development commands",
+	Long:  "Commands for developing against a deployed backend with provider credentials.",
+}
+
+var devSyncEnvCmd = &cobra.Command{
+	Use:   "sync-env",
+	Short: "Sync the environment variables of a deployed component to a local .env file",
+	Long: fmt.Sprintf(`Merge the environment variables of a deployed backend component into a .env file
+for local development.
+
+Comments and local-only variables are kept, existing variables are updated and the new ones
+are appended. With --profile, the variables are synced to %s.<profile>, so the settings of
+several stacks can be kept side by side.
+
+With --check, nothing is written and the command fails if the file drifted from the
+deployed component, listing the variable names (never their values).`, devDefaultEnvFile),
+	Example: fmt.Sprintf(
+		"  # Sync the orchestrator environment to .env\n"+
+			"  %s dev sync-env\n\n"+
+			"  # Sync the processor environment of the staging stack to .env.staging\n"+
+			"  %s dev sync-env --component processor --stack-name runvoy-staging --profile staging\n\n"+
+			"  # Fail if .env drifted from the deployed orchestrator\n"+
+			"  %s dev sync-env --check",
+		constants.ProjectName,
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.NoArgs,
+	Run:  devSyncEnvRun,
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(devSyncEnvCmd)
+
+	cfg, err := config.Load()
+	if err != nil {
+		output.Fatalf("failed to load config: %v", err)
+	}
+
+	devCmd.PersistentFlags().StringVar(&devProvider, "provider", cfg.GetProviderIdentifier(),
+		"Cloud provider (currently supported: aws)")
+	devCmd.PersistentFlags().StringVar(&devStackName, "stack-name", cfg.GetDefaultStackName(),
+		"Infrastructure stack name")
+	devCmd.PersistentFlags().StringVar(&devRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
+
+	devSyncEnvCmd.Flags().StringVar(&devSyncEnvComponent, "component", string(infra.ComponentOrchestrator),
+		fmt.Sprintf("Backend component to read the environment variables from (%s)", backendComponentNames()))
+	devSyncEnvCmd.Flags().StringVar(&devSyncEnvProfile, "profile", "",
+		fmt.Sprintf("Profile of the .env file, synced to %s.<profile>", devDefaultEnvFile))
+	devSyncEnvCmd.Flags().StringVar(&devSyncEnvFile, "env-file", "",
+		fmt.Sprintf("Path of the .env file. Defaults to %s, or the file of the profile", devDefaultEnvFile))
+	devSyncEnvCmd.Flags().BoolVar(&devSyncEnvCheck, "check", false,
+		"Fail if the .env file drifted from the deployed component, without writing it")
+	devSyncEnvCmd.Flags().BoolVar(&devSyncEnvDryRun, "dry-run", false, "Show the changes without writing the file")
+}
+
+func backendComponentNames() string {
+	names := make([]string, 0, len(infra.BackendComponents()))
+	for _, component := range infra.BackendComponents() {
+		names = append(names, string(component))
+	}
+	return strings.Join(names, "|")
+}
+
+// devEnvFilePath returns the .env file of the profile, unless a file is given.
+func devEnvFilePath(envFile, profile string) string {
+	if envFile != "" {
+		return envFile
+	}
+	if profile == "" {
+		return devDefaultEnvFile
+	}
+	return devDefaultEnvFile + "." + profile
+}
+
+func devSyncEnvRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	component := infra.BackendComponent(devSyncEnvComponent)
+	if !slices.Contains(infra.BackendComponents(), component) {
+		output.Fatalf("unknown component %s (supported: %s)", devSyncEnvComponent, backendComponentNames())
+	}
+	envFile := devEnvFilePath(devSyncEnvFile, devSyncEnvProfile)
+
+	deployer, err := infra.NewDeployer(ctx, devProvider, devRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(ctx, devStackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+	maintainer, err := infra.NewMaintainer(ctx, devProvider, deployer.GetRegion())
+	if err != nil {
+		output.Fatalf("failed to initialize maintainer: %v", err)
+	}
+	env, err := maintainer.GetComponentEnv(ctx, component, outputs)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	if len(env) == 0 {
+		output.Fatalf("no environment variables found for component %s", component)
+	}
+
+	merge, err := infra.MergeEnvFile(envFile, env)
+	if err != nil {
+		output.Fatalf("failed to merge %s: %v", envFile, err)
+	}
+
+	switch {
+	case devSyncEnvCheck:
+		if merge.Drifted() {
+			printEnvDrift(merge)
+			output.Fatalf("%s drifted from the deployed %s, run %s dev sync-env to update it",
+				envFile, component, constants.ProjectName)
+		}
+		output.Successf("%s is in sync with the deployed %s (%d variables)", envFile, component, merge.Unchanged)
+	case devSyncEnvDryRun:
+		printEnvDrift(merge)
+		output.Infof("%s would be synced with the deployed %s (%d updated, %d new), dry run, no changes applied",
+			envFile, component, len(merge.Updated), len(merge.Added))
+	default:
+		if err = os.WriteFile(envFile, []byte(merge.Content), constants.ConfigFilePermissions); err != nil {
+			output.Fatalf("failed to write %s: %v", envFile, err)
+		}
+		output.Successf("%d variables of the deployed %s synced to %s (%d updated, %d new)",
+			len(env), component, envFile, len(merge.Updated), len(merge.Added))
+	}
+}
+
+// printEnvDrift lists the names of the variables differing between the .env file and the deployed component.
+func printEnvDrift(merge *infra.EnvFileMerge) {
+	if !merge.Drifted() {
+		return
+	}
+	rows := make([][]string, 0, len(merge.Updated)+len(merge.Added))
+	for _, name := range merge.Updated {
+		rows = append(rows, []string{name, "changed"})
+	}
+	for _, name := range merge.Added {
+		rows = append(rows, []string{name, "missing locally"})
+	}
+	output.Table([]string{"Variable", "Drift"}, rows)
+	output.Blank()
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client/devstack"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+// dev send-event flags.
+var devSendEventProcessor string
+
+var devSendEventCmd = &cobra.Command{
+	Use:   "send-event <file>",
+	Short: "Send a raw event to the processor of a local backend",
+	Long: fmt.Sprintf(`Post the raw event of a JSON file to the event processor of a local backend, as the
+compute platform or the WebSocket gateway would, and print its response.
+
+The request is signed with the secret of %s, as printed by %s dev up.
+Without a secret it is sent unsigned, which the processor only accepts from the same machine
+when it was started without a secret either.`, devstack.SigningSecretEnvVar, constants.ProjectName),
+	Example: fmt.Sprintf(
+		"  # Replay a task completion event against the backend of dev up\n"+
+			"  export %s=<secret printed by dev up>\n"+
+			"  %s dev send-event cmd/local/examples/ecs-task-completion.json",
+		devstack.SigningSecretEnvVar,
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  devSendEventRun,
+}
+
+func init() {
+	devCmd.AddCommand(devSendEventCmd)
+
+	devSendEventCmd.Flags().StringVar(&devSendEventProcessor, "processor", (&devstack.Options{}).Endpoints().Processor,
+		"URL of the event processor")
+}
+
+func devSendEventRun(cmd *cobra.Command, args []string) {
+	event, err := os.ReadFile(args[0])
+	if err != nil {
+		output.Fatalf("failed to read the event: %v", err)
+	}
+
+	secret := os.Getenv(devstack.SigningSecretEnvVar)
+	if secret == "" {
+		output.Warningf("%s is not set, sending the event unsigned", devstack.SigningSecretEnvVar)
+	}
+	resp, err := devstack.SendEvent(cmd.Context(), devSendEventProcessor, secret, event)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	output.Successf("Event processed by %s", devSendEventProcessor)
+	output.Println(strings.TrimSpace(string(resp)))
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevEnvFilePath(t *testing.T) {
+	assert.Equal(t, ".env", devEnvFilePath("", ""))
+	assert.Equal(t, ".env.staging", devEnvFilePath("", "staging"))
+	assert.Equal(t, "local.env", devEnvFilePath("local.env", "staging"))
+}
+
+func TestBackendComponentNames(t *testing.T) {
+	assert.Equal(t, "orchestrator|processor", backendComponentNames())
+}
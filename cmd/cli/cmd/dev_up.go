@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client/devstack"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// dev up flags.
+	devUpPort         int
+	devUpRunner       string
+	devUpServer       string
+	devUpConfigDir    string
+	devUpConfigure    bool
+	devUpQuiet        bool
+	devUpReadyTimeout time.Duration
+	devUpDynamoDB     bool
+)
+
+var devUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Run a local backend on the in-memory fake provider",
+	Long: fmt.Sprintf(`Build and start the local server with the orchestrator, the event processor and the
+WebSocket hub of the in-memory fake backend, seed an admin user and print the endpoints.
+The processor only accepts the events signed with the secret printed at startup.
+
+Nothing is persisted and no cloud account is needed. The CLI configuration of the local
+backend is written to a separate directory, used with %s, unless --configure
+saves it as the default configuration. Press Ctrl+C to stop the backend.
+
+With --dynamodb-local, DynamoDB Local is started with docker and the users, their API keys
+and the settings are kept in it instead of in memory.`, constants.ConfigDirEnvVar),
+	Example: fmt.Sprintf(
+		"  # Start the local backend from a checkout of the sources\n"+
+			"  %s dev up\n\n"+
+			"  # Run the commands as local processes instead of the built-in interpreter\n"+
+			"  %s dev up --runner exec\n\n"+
+			"  # Keep the users and the settings in DynamoDB Local\n"+
+			"  %s dev up --dynamodb-local",
+		constants.ProjectName,
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.NoArgs,
+	Run:  devUpRun,
+}
+
+func init() {
+	devCmd.AddCommand(devUpCmd)
+
+	devUpCmd.Flags().IntVar(&devUpPort, "port", devstack.DefaultPort,
+		"Port of the API, the processor and the WebSocket hub use the next two")
+	devUpCmd.Flags().StringVar(&devUpRunner, "runner", "",
+		"How the fake backend runs the commands: script (default) or exec")
+	devUpCmd.Flags().StringVar(&devUpServer, "server", "",
+		"Local server binary built with the fake tag. Defaults to building it from the sources")
+	devUpCmd.Flags().StringVar(&devUpConfigDir, "config-dir", filepath.Join(os.TempDir(), constants.ProjectName+"-dev"),
+		"Directory of the CLI configuration of the local backend")
+	devUpCmd.Flags().BoolVar(&devUpConfigure, "configure", false,
+		"Save the local backend as the default CLI configuration instead")
+	devUpCmd.Flags().BoolVarP(&devUpQuiet, "quiet", "q", false, "Don't print the logs of the local server")
+	devUpCmd.Flags().DurationVar(&devUpReadyTimeout, "ready-timeout", devstack.DefaultReadyTimeout,
+		"Time given to the local server to be ready")
+	devUpCmd.Flags().BoolVar(&devUpDynamoDB, "dynamodb-local", false,
+		"Start DynamoDB Local with docker, on the port after the WebSocket hub, and keep the users and settings in it")
+}
+
+func devUpRun(cmd *cobra.Command, _ []string) {
+	// The backend runs until interrupted, not until the command timeout
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(cmd.Context()), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		output.Fatalf("failed to get the working directory: %v", err)
+	}
+	var logs io.Writer = os.Stderr
+	if devUpQuiet {
+		logs = io.Discard
+	}
+
+	spinner := output.NewSpinner("Starting the local backend...")
+	spinner.Start()
+	stack, err := devstack.Start(ctx, &devstack.Options{
+		Port:          devUpPort,
+		Runner:        devUpRunner,
+		ServerBinary:  devUpServer,
+		WorkDir:       workDir,
+		ReadyTimeout:  devUpReadyTimeout,
+		Output:        logs,
+		DynamoDBLocal: devUpDynamoDB,
+	})
+	if err != nil {
+		spinner.Error("Local backend failed to start")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Local backend ready")
+
+	if err = saveDevConfig(stack); err != nil {
+		_ = stack.Stop()
+		output.Fatalf(err.Error())
+	}
+	printDevStack(stack)
+
+	select {
+	case <-ctx.Done():
+		output.Infof("Stopping the local backend...")
+		if err = stack.Stop(); err != nil {
+			output.Fatalf(err.Error())
+		}
+	case <-stack.Done():
+		output.Fatalf("the local backend stopped: %v", stack.Err())
+	}
+}
+
+// saveDevConfig saves the CLI configuration of the local backend.
+func saveDevConfig(stack *devstack.Stack) error {
+	cfg := &config.Config{APIEndpoint: stack.Endpoints.API, APIKey: stack.APIKey}
+	if devUpConfigure {
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config file: %w", err)
+		}
+		return nil
+	}
+	if err := config.SaveToDir(cfg, devUpConfigDir); err != nil {
+		return fmt.Errorf("failed to save the config of the local backend: %w", err)
+	}
+	return nil
+}
+
+func printDevStack(stack *devstack.Stack) {
+	output.Blank()
+	output.KeyValue("API", stack.Endpoints.API)
+	output.KeyValue("Processor", stack.Endpoints.Processor+"/process")
+	output.KeyValue("Log streams", stack.Endpoints.WebSocket)
+	if stack.Endpoints.DynamoDB != "" {
+		output.KeyValue("DynamoDB", stack.Endpoints.DynamoDB)
+	}
+	output.KeyValue("Admin", stack.AdminEmail)
+	output.KeyValue("API key", stack.APIKey)
+	output.KeyValue("Processor signing secret", stack.SigningSecret)
+	output.Blank()
+	if devUpConfigure {
+		output.Infof("Saved as the default configuration, try: %s users list", constants.ProjectName)
+	} else {
+		output.Infof("To use it from another shell:")
+		output.Infof("  export %s=%s", constants.ConfigDirEnvVar, devUpConfigDir)
+		output.Infof("  %s users list", constants.ProjectName)
+	}
+	output.Infof("To send events to the processor, which rejects the unsigned ones:")
+	output.Infof("  export %s=%s", devstack.SigningSecretEnvVar, stack.SigningSecret)
+	output.Infof("  %s dev send-event <event.json>", constants.ProjectName)
+	output.Blank()
+}
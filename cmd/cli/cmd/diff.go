@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <execution-id-a> <execution-id-b>",
+	Short: "Compare two command executions",
+	Long: `Compare two command executions.
+
+Shows the differences between the image, command, environment variable names, status,
+exit code and duration of both executions. Use --logs to also diff the final log lines.`,
+	Example: fmt.Sprintf(`  - %s diff 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10 0b6d0a2e-8f0e-4c1b-a7a5-55d5b1c2e3f4
+  - %s diff 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10 0b6d0a2e-8f0e-4c1b-a7a5-55d5b1c2e3f4 --logs 50`,
+		constants.ProjectName, constants.ProjectName),
+	Run:               diffRun,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeExecutionPair,
+}
+
+func init() {
+	diffCmd.Flags().Int("logs", 0,
+		fmt.Sprintf("Number of final log lines to diff (max %d, 0 to skip)", constants.MaxExecutionDiffLogLines))
+	rootCmd.AddCommand(diffCmd)
+}
+
+// completeExecutionPair completes execution IDs for both positional arguments of diff.
+func completeExecutionPair(
+	cmd *cobra.Command, args []string, toComplete string,
+) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeFromBackend(cmd, toComplete, fetchExecutionIDs)
+}
+
+func diffRun(cmd *cobra.Command, args []string) {
+	logLines, _ := cmd.Flags().GetInt("logs")
+
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+
+	c := client.New(cfg, slog.Default())
+	service := NewDiffService(c, NewOutputWrapper())
+	if err = service.DiffExecutions(cmd.Context(), args[0], args[1], logLines); err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// DiffService handles execution comparison logic.
+type DiffService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewDiffService creates a new DiffService with the provided dependencies.
+func NewDiffService(apiClient client.Interface, outputter OutputInterface) *DiffService {
+	return &DiffService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// DiffExecutions compares two executions and displays their differences.
+func (s *DiffService) DiffExecutions(ctx context.Context, executionIDA, executionIDB string, logLines int) error {
+	resp, err := s.client.DiffExecutions(ctx, executionIDA, executionIDB, logLines)
+	if err != nil {
+		return fmt.Errorf("failed to diff executions: %w", err)
+	}
+
+	s.output.KeyValue("Execution A", resp.ExecutionA.ExecutionID)
+	s.output.KeyValue("Execution B", resp.ExecutionB.ExecutionID)
+	s.output.Blank()
+
+	if len(resp.Differences) == 0 {
+		s.output.Successf("Executions match")
+	} else {
+		s.output.Table([]string{"Field", "A", "B"}, formatFieldDiffs(resp.Differences))
+	}
+
+	if logLines > 0 {
+		s.displayLogDiff(resp.LogDiff, logLines)
+	}
+	return nil
+}
+
+func (s *DiffService) displayLogDiff(logDiff []string, logLines int) {
+	s.output.Blank()
+	if len(logDiff) == 0 {
+		s.output.Infof("No logs to compare")
+		return
+	}
+
+	rows := make([][]string, 0, len(logDiff))
+	for _, line := range logDiff {
+		rows = append(rows, []string{line})
+	}
+	s.output.Table([]string{fmt.Sprintf("Log diff (last %d lines)", logLines)}, rows)
+}
+
+func formatFieldDiffs(differences []api.ExecutionFieldDiff) [][]string {
+	rows := make([][]string, 0, len(differences))
+	for _, diff := range differences {
+		rows = append(rows, []string{diff.Field, diff.A, diff.B})
+	}
+	return rows
+}
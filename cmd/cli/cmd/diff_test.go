@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// mockClientInterfaceForDiff extends mockClientInterface with DiffExecutions
+type mockClientInterfaceForDiff struct {
+	*mockClientInterface
+	diffExecutionsFunc func(ctx context.Context, a, b string, logLines int) (*api.ExecutionDiffResponse, error)
+}
+
+func (m *mockClientInterfaceForDiff) DiffExecutions(
+	ctx context.Context, executionIDA, executionIDB string, logLines int,
+) (*api.ExecutionDiffResponse, error) {
+	if m.diffExecutionsFunc != nil {
+		return m.diffExecutionsFunc(ctx, executionIDA, executionIDB, logLines)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func countCalls(calls []call, method string) int {
+	count := 0
+	for _, c := range calls {
+		if c.method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDiffService_DiffExecutions(t *testing.T) {
+	snapshots := api.ExecutionDiffResponse{
+		ExecutionA: api.ExecutionSnapshot{ExecutionID: "exec-a"},
+		ExecutionB: api.ExecutionSnapshot{ExecutionID: "exec-b"},
+	}
+
+	tests := []struct {
+		name        string
+		resp        *api.ExecutionDiffResponse
+		err         error
+		logLines    int
+		wantErr     bool
+		wantTables  int
+		wantSuccess bool
+		wantInfo    bool
+	}{
+		{
+			name: "shows differences",
+			resp: &api.ExecutionDiffResponse{
+				ExecutionA:  snapshots.ExecutionA,
+				ExecutionB:  snapshots.ExecutionB,
+				Differences: []api.ExecutionFieldDiff{{Field: "exit_code", A: "0", B: "1"}},
+			},
+			wantTables: 1,
+		},
+		{
+			name:        "reports matching executions",
+			resp:        &snapshots,
+			wantSuccess: true,
+		},
+		{
+			name: "shows log diff",
+			resp: &api.ExecutionDiffResponse{
+				ExecutionA: snapshots.ExecutionA,
+				ExecutionB: snapshots.ExecutionB,
+				LogDiff:    []string{"  setup", "- ok", "+ FAIL"},
+			},
+			logLines:    10,
+			wantSuccess: true,
+			wantTables:  1,
+		},
+		{
+			name:        "reports missing logs",
+			resp:        &snapshots,
+			logLines:    10,
+			wantSuccess: true,
+			wantInfo:    true,
+		},
+		{
+			name:    "returns client errors",
+			err:     errors.New("execution missing not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterfaceForDiff{
+				mockClientInterface: &mockClientInterface{},
+				diffExecutionsFunc: func(_ context.Context, a, b string, logLines int) (*api.ExecutionDiffResponse, error) {
+					assert.Equal(t, "exec-a", a)
+					assert.Equal(t, "exec-b", b)
+					assert.Equal(t, tt.logLines, logLines)
+					return tt.resp, tt.err
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+			service := NewDiffService(mockClient, mockOutput)
+
+			err := service.DiffExecutions(context.Background(), "exec-a", "exec-b", tt.logLines)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to diff executions")
+				assert.Empty(t, mockOutput.calls)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTables, countCalls(mockOutput.calls, "Table"))
+			assert.Equal(t, tt.wantSuccess, countCalls(mockOutput.calls, "Successf") > 0)
+			assert.Equal(t, tt.wantInfo, countCalls(mockOutput.calls, "Infof") > 0)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var filtersCmd = &cobra.Command{
+	Use:   "filters",
+	Short: "Saved execution list filters commands",
+	Long: fmt.Sprintf(`Save named filters of the list command to apply them with list --filter saved:<name>.
+Filters are saved server-side for your user, "me" standing for whoever applies the filter.
+
+Run "%s list --help" for the available criteria.`, constants.ProjectName),
+}
+
+var saveFilterCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save an execution list filter",
+	Long:  `Save the criteria given as flags under the name, replacing any filter previously saved under it`,
+	Example: fmt.Sprintf(`  # Save your failed executions of the last week as "failures"
+  - %s filters save failures --status FAILED --user me --since 168h
+  - %s list --filter saved:failures`,
+		constants.ProjectName, constants.ProjectName),
+	Run:               runSaveFilter,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchSavedFilterNames),
+}
+
+var (
+	saveFilterStatus  string
+	saveFilterUser    string
+	saveFilterSince   time.Duration
+	saveFilterStarred bool
+	saveFilterSearch  string
+)
+
+func init() {
+	filtersCmd.AddCommand(saveFilterCmd)
+	saveFilterCmd.Flags().StringVar(&saveFilterStatus, "status", "",
+		"comma-separated list of execution statuses to filter by (e.g., FAILED,TERMINATED)")
+	saveFilterCmd.Flags().StringVar(&saveFilterUser, "user", "",
+		"only list executions created by this user email (\"me\" for whoever applies the filter)")
+	saveFilterCmd.Flags().DurationVar(&saveFilterSince, "since", 0,
+		"only list executions started within this duration (e.g., 168h)")
+	saveFilterCmd.Flags().BoolVar(&saveFilterStarred, "starred", false, "only list starred executions")
+	saveFilterCmd.Flags().StringVar(&saveFilterSearch, "search", "",
+		"only list executions whose command, image or playbook contain all these words (case-insensitive)")
+	rootCmd.AddCommand(filtersCmd)
+}
+
+func runSaveFilter(cmd *cobra.Command, args []string) {
+	filter := api.ExecutionListFilter{
+		Name:      args[0],
+		CreatedBy: saveFilterUser,
+		Starred:   saveFilterStarred,
+		Search:    saveFilterSearch,
+	}
+	if saveFilterStatus != "" {
+		filter.Statuses = strings.Split(strings.ToUpper(saveFilterStatus), ",")
+	}
+	if saveFilterSince > 0 {
+		filter.Since = saveFilterSince.String()
+	}
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewFiltersService(c, NewOutputWrapper()).SaveFilter(ctx, filter)
+	})
+}
+
+var listFiltersCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List your saved execution list filters",
+	Example: fmt.Sprintf(`  - %s filters list`, constants.ProjectName),
+	Run:     runListFilters,
+}
+
+func init() {
+	filtersCmd.AddCommand(listFiltersCmd)
+}
+
+func runListFilters(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewFiltersService(c, NewOutputWrapper()).ListFilters(ctx)
+	})
+}
+
+var deleteFilterCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Short:             "Delete a saved execution list filter",
+	Example:           fmt.Sprintf(`  - %s filters delete failures`, constants.ProjectName),
+	Run:               runDeleteFilter,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchSavedFilterNames),
+}
+
+func init() {
+	filtersCmd.AddCommand(deleteFilterCmd)
+}
+
+func runDeleteFilter(cmd *cobra.Command, args []string) {
+	name := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewFiltersService(c, NewOutputWrapper()).DeleteFilter(ctx, name)
+	})
+}
+
+// FiltersService handles saved execution list filters operations.
+type FiltersService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewFiltersService creates a new FiltersService with the provided dependencies.
+func NewFiltersService(apiClient client.Interface, outputter OutputInterface) *FiltersService {
+	return &FiltersService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// SaveFilter saves the execution list filter under its name.
+func (s *FiltersService) SaveFilter(ctx context.Context, filter api.ExecutionListFilter) error {
+	saved, err := s.client.SaveFilter(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to save filter: %w", err)
+	}
+
+	s.output.Successf("Filter saved successfully")
+	s.output.KeyValue("Name", saved.Name)
+	s.output.KeyValue("Criteria", formatListFilter(saved))
+	s.output.KeyValue("Usage", fmt.Sprintf("%s list --filter %s%s", constants.ProjectName, savedFilterPrefix, saved.Name))
+	return nil
+}
+
+// ListFilters lists the execution list filters saved by the user.
+func (s *FiltersService) ListFilters(ctx context.Context) error {
+	resp, err := s.client.ListSavedFilters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list saved filters: %w", err)
+	}
+
+	rows := make([][]string, 0, len(resp.Filters))
+	for i := range resp.Filters {
+		rows = append(rows, []string{
+			s.output.Bold(resp.Filters[i].Name),
+			formatListFilter(&resp.Filters[i]),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Name", "Criteria"}, rows)
+	s.output.Blank()
+	s.output.Successf("Listed %d saved filters", len(resp.Filters))
+	return nil
+}
+
+// DeleteFilter deletes the execution list filter saved under the name.
+func (s *FiltersService) DeleteFilter(ctx context.Context, name string) error {
+	resp, err := s.client.DeleteSavedFilter(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete filter: %w", err)
+	}
+
+	s.output.Successf("Filter %s deleted successfully", resp.Name)
+	return nil
+}
+
+// formatListFilter describes the criteria of an execution list filter with the equivalent list flags.
+func formatListFilter(filter *api.ExecutionListFilter) string {
+	var criteria []string
+	if len(filter.Statuses) > 0 {
+		criteria = append(criteria, "--status "+strings.Join(filter.Statuses, ","))
+	}
+	if filter.CreatedBy != "" {
+		criteria = append(criteria, "--user "+filter.CreatedBy)
+	}
+	if filter.Since != "" {
+		criteria = append(criteria, "--since "+filter.Since)
+	}
+	if filter.Starred {
+		criteria = append(criteria, "--starred")
+	}
+	if filter.Search != "" {
+		criteria = append(criteria, fmt.Sprintf("--search %q", filter.Search))
+	}
+	if len(criteria) == 0 {
+		return "all executions"
+	}
+	return strings.Join(criteria, " ")
+}
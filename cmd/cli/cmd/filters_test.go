@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestFiltersService_SaveFilter(t *testing.T) {
+	filter := api.ExecutionListFilter{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me"}
+	mockClient := &mockClientInterface{
+		saveFilterFunc: func(_ context.Context, f api.ExecutionListFilter) (*api.ExecutionListFilter, error) {
+			assert.Equal(t, filter, f)
+			return &f, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewFiltersService(mockClient, mockOutput)
+
+	err := service.SaveFilter(context.Background(), filter)
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "--status FAILED --user me", keyValues["Criteria"])
+	assert.Equal(t, "runvoy list --filter saved:failures", keyValues["Usage"])
+}
+
+func TestFiltersService_ListFilters(t *testing.T) {
+	mockClient := &mockClientInterface{
+		listSavedFiltersFunc: func(_ context.Context) (*api.ListSavedFiltersResponse, error) {
+			return &api.ListSavedFiltersResponse{Filters: []api.ExecutionListFilter{
+				{Name: "failures", Statuses: []string{"FAILED", "TERMINATED"}, Since: "168h"},
+				{Name: "starred", Starred: true},
+				{Name: "all"},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewFiltersService(mockClient, mockOutput)
+
+	err := service.ListFilters(context.Background())
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	assert.Equal(t, [][]string{
+		{"failures", "--status FAILED,TERMINATED --since 168h"},
+		{"starred", "--starred"},
+		{"all", "all executions"},
+	}, rows)
+}
+
+func TestFiltersService_DeleteFilter(t *testing.T) {
+	t.Run("deletes the filter", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			deleteSavedFilterFunc: func(_ context.Context, name string) (*api.DeleteSavedFilterResponse, error) {
+				return &api.DeleteSavedFilterResponse{Name: name}, nil
+			},
+		}
+		service := NewFiltersService(mockClient, &mockOutputInterface{})
+
+		require.NoError(t, service.DeleteFilter(context.Background(), "failures"))
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			deleteSavedFilterFunc: func(_ context.Context, _ string) (*api.DeleteSavedFilterResponse, error) {
+				return nil, errors.New(`saved filter "failures" not found`)
+			},
+		}
+		service := NewFiltersService(mockClient, &mockOutputInterface{})
+
+		err := service.DeleteFilter(context.Background(), "failures")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete filter")
+	})
+}
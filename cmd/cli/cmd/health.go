@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
@@ -20,15 +23,82 @@ var healthCmd = &cobra.Command{
 }
 
 var healthReconcileCmd = &cobra.Command{
-	Use:     "reconcile",
-	Short:   "Run a full health reconciliation",
-	Long:    "Trigger a full health reconciliation across managed resources and display a report",
-	Example: fmt.Sprintf(`  - %s health reconcile`, constants.ProjectName),
-	Run:     runHealthReconcile,
+	Use:   "reconcile",
+	Short: "Run a full health reconciliation",
+	Long: `Trigger a full health reconciliation across managed resources and display a report.
+
+With --canary, the reconciliation also launches a tiny "` + constants.CanaryCommand + `" execution as you and
+checks the result of the canary launched by the previous canary reconciliation: the run, logs and completion
+phases are timed and reported as passed or failed, which catches broken event wiring end-to-end.`,
+	Example: fmt.Sprintf(`  - %s health reconcile
+
+  # Also evaluate the previous canary and launch a new one
+  - %s health reconcile --canary`, constants.ProjectName, constants.ProjectName),
+	Run: runHealthReconcile,
 }
 
+var healthHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show past health reconciliation reports",
+	Long: `Show stored health reconciliation reports, most recent first, with the resources recreated
+and the errors found by each run. Resources reported by several runs are listed as recurring issues,
+which usually points to infrastructure drift that keeps coming back.`,
+	Example: fmt.Sprintf(`  # Show the last %d reports
+  - %s health history
+
+  # Show the last 100 reports
+  - %s health history --limit 100`,
+		constants.DefaultHealthReportListLimit, constants.ProjectName, constants.ProjectName),
+	Run: runHealthHistory,
+}
+
+var healthCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete provider resources that are no longer referenced",
+	Long: `Delete the provider resources created by ` + constants.ProjectName + ` that nothing references anymore,
+such as deregistered task definitions, task definitions of removed images and log streams whose events
+all expired. The same cleanup runs after each scheduled health reconciliation.
+
+With --dry-run, the resources are only listed.`,
+	Example: fmt.Sprintf(`  # List what would be deleted
+  - %s health cleanup --dry-run
+
+  - %s health cleanup`, constants.ProjectName, constants.ProjectName),
+	Run: runHealthCleanup,
+}
+
+var healthStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show database query statistics",
+	Long: `Show the latency and item counts of the database queries run by the backend, by access pattern
+(operation, table and index), the patterns taking the most time overall first. Queries scanning far more
+items than they return, or often slower than the slow query threshold, point to hot partitions and
+missing indexes.
+
+Statistics are kept in memory by each backend instance since it started, the command shows those of the
+instance serving the request.`,
+	Example: fmt.Sprintf(`  - %s health stats`, constants.ProjectName),
+	Run:     runHealthStats,
+}
+
+var (
+	healthReconcileCanary bool
+	healthHistoryLimit    int
+	healthCleanupDryRun   bool
+)
+
 func init() {
+	healthReconcileCmd.Flags().BoolVar(&healthReconcileCanary, "canary", false,
+		"evaluate the previous canary execution and launch a new one")
+	healthHistoryCmd.Flags().IntVar(&healthHistoryLimit, "limit", constants.DefaultHealthReportListLimit,
+		fmt.Sprintf("maximum number of reports to show (max %d)", constants.MaxHealthReportListLimit))
+	healthCleanupCmd.Flags().BoolVar(&healthCleanupDryRun, "dry-run", false,
+		"only list the resources that would be deleted")
+
 	healthCmd.AddCommand(healthReconcileCmd)
+	healthCmd.AddCommand(healthHistoryCmd)
+	healthCmd.AddCommand(healthCleanupCmd)
+	healthCmd.AddCommand(healthStatsCmd)
 	rootCmd.AddCommand(healthCmd)
 }
 
@@ -42,7 +112,7 @@ func runHealthReconcile(cmd *cobra.Command, _ []string) {
 	c := client.New(cfg, slog.Default())
 	output.Infof("Reconciling health…")
 
-	resp, err := c.ReconcileHealth(context.Background())
+	resp, err := c.ReconcileHealth(context.Background(), healthReconcileCanary)
 	if err != nil {
 		output.Errorf("reconciliation failed: %v", err)
 		return
@@ -64,6 +134,7 @@ func runHealthReconcile(cmd *cobra.Command, _ []string) {
 	printSecretsReport(r)
 	printIdentityReport(r)
 	printIssuesTable(r)
+	printCanaryReport(r.Canary)
 
 	output.Successf("Health reconciliation completed")
 }
@@ -123,3 +194,272 @@ func printIssuesTable(r *api.HealthReport) {
 	)
 	output.Blank()
 }
+
+func printCanaryReport(canary *api.CanaryReport) {
+	if canary == nil {
+		return
+	}
+	output.Subheader("Canary")
+	if canary.Result != nil {
+		printCanaryResult("Previous", canary.Result)
+	}
+	if canary.Launched != nil {
+		printCanaryResult("Launched", canary.Launched)
+	}
+}
+
+func printCanaryResult(label string, result *api.CanaryResult) {
+	status := result.Status
+	if result.ExecutionID != "" {
+		status = fmt.Sprintf("%s (%s)", status, result.ExecutionID)
+	}
+	output.KeyValue(label, status)
+	if result.Error != "" {
+		output.KeyValue("Error", result.Error)
+	}
+	if len(result.Phases) > 0 {
+		rows := make([][]string, 0, len(result.Phases))
+		for _, phase := range result.Phases {
+			passed := "yes"
+			if !phase.Passed {
+				passed = "no"
+			}
+			rows = append(rows, []string{
+				phase.Name,
+				passed,
+				(time.Duration(phase.DurationMs) * time.Millisecond).String(),
+				phase.Message,
+			})
+		}
+		output.Table([]string{"Phase", "Passed", "Duration", "Message"}, rows)
+	}
+	output.Blank()
+}
+
+func runHealthHistory(cmd *cobra.Command, _ []string) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+
+	c := client.New(cfg, slog.Default())
+	service := NewHealthService(c, NewOutputWrapper())
+	if err = service.ShowHistory(cmd.Context(), healthHistoryLimit); err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// HealthService handles health report history display logic.
+type HealthService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewHealthService creates a new HealthService with the provided dependencies.
+func NewHealthService(apiClient client.Interface, outputter OutputInterface) *HealthService {
+	return &HealthService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// ShowHistory displays the stored health reports and the issues reported by more than one of them.
+func (s *HealthService) ShowHistory(ctx context.Context, limit int) error {
+	if limit < 1 || limit > constants.MaxHealthReportListLimit {
+		return fmt.Errorf("limit must be between 1 and %d, got %d", constants.MaxHealthReportListLimit, limit)
+	}
+
+	s.output.Infof("Listing health reports…")
+
+	resp, err := s.client.ListHealthReports(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list health reports: %w", err)
+	}
+
+	if len(resp.Reports) == 0 {
+		s.output.Infof("No health reports found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(resp.Reports))
+	for i := range resp.Reports {
+		r := &resp.Reports[i]
+		rows = append(rows, []string{
+			r.Timestamp.UTC().Format(time.DateTime),
+			strconv.Itoa(r.ReconciledCount),
+			strconv.Itoa(r.ComputeStatus.RecreatedCount),
+			strconv.Itoa(len(r.Issues)),
+			strconv.Itoa(r.ErrorCount),
+			canaryHistoryStatus(r.Canary),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Time (UTC)", "Reconciled", "Recreated", "Issues", "Errors", "Canary"}, rows)
+	s.output.Blank()
+
+	if recurring := recurringHealthIssues(resp.Reports); len(recurring) > 0 {
+		s.output.Warningf("Issues reported by more than one reconciliation:")
+		s.output.Table([]string{"Resource", "ID", "Reports", "Last Action"}, recurring)
+		s.output.Blank()
+	}
+
+	s.output.Successf("Listed %d health reports", len(resp.Reports))
+	return nil
+}
+
+func runHealthCleanup(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewHealthService(c, NewOutputWrapper()).Cleanup(ctx, healthCleanupDryRun)
+	})
+}
+
+// Cleanup deletes the provider resources no longer referenced, or only lists them when dryRun is true.
+func (s *HealthService) Cleanup(ctx context.Context, dryRun bool) error {
+	if dryRun {
+		s.output.Infof("Looking for unreferenced resources (dry run)…")
+	} else {
+		s.output.Infof("Cleaning up unreferenced resources…")
+	}
+
+	resp, err := s.client.CleanupHealth(ctx, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to clean up resources: %w", err)
+	}
+	if resp == nil || resp.Report == nil {
+		return errors.New("invalid response from server")
+	}
+
+	r := resp.Report
+	if len(r.Resources) == 0 {
+		s.output.Successf("No unreferenced resources found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(r.Resources))
+	for _, resource := range r.Resources {
+		detail := resource.Reason
+		if resource.Error != "" {
+			detail = resource.Error
+		}
+		rows = append(rows, []string{resource.ResourceType, resource.ResourceID, resource.Action, detail})
+	}
+	s.output.Blank()
+	s.output.Table([]string{"Resource", "ID", "Action", "Reason"}, rows)
+	s.output.Blank()
+
+	if r.DryRun {
+		s.output.Successf("Found %d unreferenced resources, run without --dry-run to delete them", len(r.Resources))
+		return nil
+	}
+	if r.FailedCount > 0 {
+		s.output.Warningf("Failed to delete %d resources", r.FailedCount)
+	}
+	s.output.Successf("Deleted %d unreferenced resources", r.DeletedCount)
+	return nil
+}
+
+func runHealthStats(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewHealthService(c, NewOutputWrapper()).ShowStats(ctx)
+	})
+}
+
+// ShowStats shows the database queries of the backend instance aggregated by access pattern.
+func (s *HealthService) ShowStats(ctx context.Context) error {
+	resp, err := s.client.GetHealthStats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get stats: %w", err)
+	}
+	if resp == nil || resp.Database == nil {
+		return errors.New("invalid response from server")
+	}
+
+	stats := resp.Database
+	rows := make([][]string, 0, len(stats.Queries))
+	for i := range stats.Queries {
+		query := &stats.Queries[i]
+		rows = append(rows, []string{
+			query.Pattern,
+			strconv.FormatInt(query.Count, 10),
+			fmt.Sprintf("%.1f", query.AvgDurationMs),
+			fmt.Sprintf("%.1f", query.MaxDurationMs),
+			strconv.FormatInt(query.Items, 10),
+			strconv.FormatInt(query.Slow, 10),
+			strconv.FormatInt(query.Errors, 10),
+		})
+	}
+
+	s.output.Blank()
+	s.output.KeyValue("Since", stats.Since.Format(time.DateTime))
+	s.output.KeyValue("Slow query threshold", fmt.Sprintf("%dms", stats.SlowQueryThresholdMs))
+	s.output.Blank()
+	s.output.Table([]string{"Pattern", "Count", "Avg (ms)", "Max (ms)", "Items", "Slow", "Errors"}, rows)
+	s.output.Blank()
+	s.output.Successf("Listed %d access patterns", len(stats.Queries))
+	return nil
+}
+
+// canaryHistoryStatus summarizes the canary of a report: the evaluated result if there is one,
+// otherwise the launch status, or "-" for reports without canary.
+func canaryHistoryStatus(canary *api.CanaryReport) string {
+	switch {
+	case canary == nil:
+		return "-"
+	case canary.Result != nil:
+		return canary.Result.Status
+	case canary.Launched != nil:
+		return "launched: " + canary.Launched.Status
+	default:
+		return "-"
+	}
+}
+
+// recurringHealthIssues returns the resources reported by more than one report, most frequent first.
+// Reports are expected newest first, so the action shown is the most recent one.
+func recurringHealthIssues(reports []api.HealthReport) [][]string {
+	type occurrence struct {
+		resourceType string
+		resourceID   string
+		count        int
+		lastAction   string
+	}
+
+	var order []string
+	occurrences := map[string]*occurrence{}
+	for i := range reports {
+		seen := map[string]bool{}
+		for _, issue := range reports[i].Issues {
+			key := issue.ResourceType + "/" + issue.ResourceID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			o, ok := occurrences[key]
+			if !ok {
+				o = &occurrence{resourceType: issue.ResourceType, resourceID: issue.ResourceID, lastAction: issue.Action}
+				occurrences[key] = o
+				order = append(order, key)
+			}
+			o.count++
+		}
+	}
+
+	recurring := make([]*occurrence, 0, len(order))
+	for _, key := range order {
+		if occurrences[key].count > 1 {
+			recurring = append(recurring, occurrences[key])
+		}
+	}
+	sort.SliceStable(recurring, func(i, j int) bool {
+		return recurring[i].count > recurring[j].count
+	})
+
+	rows := make([][]string, 0, len(recurring))
+	for _, o := range recurring {
+		rows = append(rows, []string{o.resourceType, o.resourceID, strconv.Itoa(o.count), o.lastAction})
+	}
+	return rows
+}
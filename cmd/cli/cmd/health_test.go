@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthService_ShowHistory(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	driftIssue := api.HealthIssue{
+		ResourceType: "ecs_task_definition", ResourceID: "runvoy-image-ubuntu", Severity: "error", Action: "recreated",
+	}
+	mockClient := &mockClientInterface{
+		listHealthReportsFunc: func(_ context.Context, limit int) (*api.HealthReportsResponse, error) {
+			assert.Equal(t, 3, limit)
+			return &api.HealthReportsResponse{Reports: []api.HealthReport{
+				{
+					Timestamp:       now,
+					ReconciledCount: 1,
+					ErrorCount:      1,
+					ComputeStatus:   api.ComputeHealthStatus{RecreatedCount: 1},
+					Issues:          []api.HealthIssue{driftIssue},
+					Canary: &api.CanaryReport{
+						Result: &api.CanaryResult{ExecutionID: "canary-1", Status: "passed"},
+					},
+				},
+				{Timestamp: now.Add(-time.Hour)},
+				{
+					Timestamp:  now.Add(-2 * time.Hour),
+					ErrorCount: 1,
+					Issues: []api.HealthIssue{
+						driftIssue,
+						{ResourceType: "ssm_parameter", ResourceID: "once", Severity: "warning", Action: "reported"},
+					},
+				},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewHealthService(mockClient, mockOutput).ShowHistory(context.Background(), 3)
+
+	require.NoError(t, err)
+	var tables [][][]string
+	for _, c := range mockOutput.calls {
+		if c.method == "Table" {
+			tables = append(tables, c.args[1].([][]string))
+		}
+	}
+	require.Len(t, tables, 2)
+	assert.Equal(t, []string{"2026-10-18 12:00:00", "1", "1", "1", "1", "passed"}, tables[0][0])
+	assert.Equal(t, "-", tables[0][1][5])
+	assert.Len(t, tables[0], 3)
+	assert.Equal(t, [][]string{{"ecs_task_definition", "runvoy-image-ubuntu", "2", "recreated"}}, tables[1])
+}
+
+func TestHealthService_ShowHistory_Errors(t *testing.T) {
+	service := NewHealthService(&mockClientInterface{}, &mockOutputInterface{})
+	assert.Error(t, service.ShowHistory(context.Background(), 0))
+
+	mockClient := &mockClientInterface{
+		listHealthReportsFunc: func(_ context.Context, _ int) (*api.HealthReportsResponse, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	err := NewHealthService(mockClient, &mockOutputInterface{}).ShowHistory(context.Background(), 5)
+	assert.ErrorContains(t, err, "failed to list health reports")
+}
+
+func TestHealthService_Cleanup(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      bool
+		report      *api.CleanupReport
+		wantRows    int
+		wantSuccess string
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+			report: &api.CleanupReport{DryRun: true, Resources: []api.CleanedResource{
+				{ResourceType: "log_stream", ResourceID: "task/runner/old", Reason: "all log events expired",
+					Action: "would_delete"},
+			}},
+			wantRows:    1,
+			wantSuccess: "Found %d unreferenced resources, run without --dry-run to delete them",
+		},
+		{
+			name: "cleanup",
+			report: &api.CleanupReport{DeletedCount: 1, FailedCount: 1, Resources: []api.CleanedResource{
+				{ResourceType: "log_stream", ResourceID: "task/runner/old", Action: "deleted"},
+				{ResourceType: "ecs_task_definition", ResourceID: "arn", Action: "failed", Error: "in use"},
+			}},
+			wantRows:    2,
+			wantSuccess: "Deleted %d unreferenced resources",
+		},
+		{
+			name:        "nothing to clean up",
+			report:      &api.CleanupReport{},
+			wantSuccess: "No unreferenced resources found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterface{
+				cleanupHealthFunc: func(_ context.Context, dryRun bool) (*api.HealthCleanupResponse, error) {
+					assert.Equal(t, tt.dryRun, dryRun)
+					return &api.HealthCleanupResponse{Status: "ok", Report: tt.report}, nil
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+
+			err := NewHealthService(mockClient, mockOutput).Cleanup(context.Background(), tt.dryRun)
+
+			require.NoError(t, err)
+			var rows [][]string
+			var success string
+			for _, c := range mockOutput.calls {
+				switch c.method {
+				case "Table":
+					rows = c.args[1].([][]string)
+				case "Successf":
+					success = c.args[0].(string)
+				}
+			}
+			assert.Len(t, rows, tt.wantRows)
+			assert.Equal(t, tt.wantSuccess, success)
+		})
+	}
+}
+
+func TestHealthService_ShowStats(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getHealthStatsFunc: func(_ context.Context) (*api.HealthStatsResponse, error) {
+			return &api.HealthStatsResponse{Database: &api.DatabaseStats{
+				SlowQueryThresholdMs: 500,
+				Queries: []api.QueryPatternStats{
+					{Pattern: "Query runvoy-executions/all-started_at", Count: 4, Items: 80, AvgDurationMs: 12.34},
+					{Pattern: "GetItem runvoy-api-keys", Count: 10, Items: 10, Slow: 1},
+				},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewHealthService(mockClient, mockOutput).ShowStats(context.Background())
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, c := range mockOutput.calls {
+		if c.method == "Table" {
+			rows = c.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"Query runvoy-executions/all-started_at", "4", "12.3", "0.0", "80", "0", "0"}, rows[0])
+}
+
+func TestHealthService_ShowStats_Error(t *testing.T) {
+	mockClient := &mockClientInterface{}
+
+	err := NewHealthService(mockClient, &mockOutputInterface{}).ShowStats(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get stats")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
@@ -25,6 +26,12 @@ var (
 	registerImageCPU             string
 	registerImageMemory          string
 	registerImageRuntimePlatform string
+	registerImageLogMaxLines     int
+	registerImageLogMaxMB        int
+	registerImageWarmPool        int
+	registerImageWorkDir         string
+	registerImageShell           string
+	unregisterImagePurge         bool
 )
 
 var registerImageCmd = &cobra.Command{
@@ -37,7 +44,13 @@ and there'll be no validation on the Docker image existence in the registry
 nor the ability for the task executor to pull the image correctly.`,
 	Example: fmt.Sprintf(`  - %s images register alpine:latest
   - %s images register ecr-public.us-east-1.amazonaws.com/docker/library/ubuntu:22.04
-  - %s images register ubuntu:22.04 --set-default`,
+  - %s images register ubuntu:22.04 --set-default
+  - %s images register busybox:latest --log-max-lines-per-second 100 --log-max-mb 10
+  - %s images register python:3.12-slim --warm-pool 2
+  - %s images register node:22 --workdir /app --shell bash`,
+		constants.ProjectName,
+		constants.ProjectName,
+		constants.ProjectName,
 		constants.ProjectName,
 		constants.ProjectName,
 		constants.ProjectName,
@@ -57,15 +70,32 @@ var showImageCmd = &cobra.Command{
 	Short: "Show detailed information about a Docker image",
 	Example: fmt.Sprintf(`  - %s images show alpine:latest
   - %s images show alpine:latest-a1b2c3d4`, constants.ProjectName, constants.ProjectName),
-	Run:  showImageRun,
-	Args: cobra.ExactArgs(1),
+	Run:               showImageRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchImageNames),
 }
 
 var unregisterImageCmd = &cobra.Command{
-	Use:     "unregister <image>",
-	Short:   "Unregister a Docker image",
-	Example: fmt.Sprintf(`  - %s images unregister alpine:latest`, constants.ProjectName),
-	Run:     unregisterImageRun,
+	Use:   "unregister <image>",
+	Short: "Unregister a Docker image",
+	Long: fmt.Sprintf(`Unregister a Docker image.
+
+The image is soft-deleted: executions can no longer use it, but it can be brought
+back with 'images restore' for %d days. After that it is purged by the resource cleanup.
+Admins can use --purge to remove the image permanently right away.`,
+		int(constants.ImageDeletionRetention/(24*time.Hour))),
+	Example: fmt.Sprintf(`  - %s images unregister alpine:latest
+  - %s images unregister alpine:latest --purge`, constants.ProjectName, constants.ProjectName),
+	Run:               unregisterImageRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchImageNames),
+}
+
+var restoreImageCmd = &cobra.Command{
+	Use:     "restore <image>",
+	Short:   "Restore a deleted Docker image",
+	Example: fmt.Sprintf(`  - %s images restore alpine:latest-a1b2c3d4`, constants.ProjectName),
+	Run:     restoreImageRun,
 	Args:    cobra.ExactArgs(1),
 }
 
@@ -83,10 +113,29 @@ func init() {
 	registerImageCmd.Flags().StringVar(&registerImageRuntimePlatform,
 		"runtime-platform", "",
 		"Optional runtime platform (e.g., Linux/ARM64, Linux/X86_64). Defaults to Linux/ARM64 if not specified")
+	registerImageCmd.Flags().IntVar(&registerImageLogMaxLines,
+		"log-max-lines-per-second", 0,
+		fmt.Sprintf("Optional maximum log lines per second kept for executions (default %d)",
+			constants.DefaultLogMaxLinesPerSecond))
+	registerImageCmd.Flags().IntVar(&registerImageLogMaxMB,
+		"log-max-mb", 0,
+		fmt.Sprintf("Optional maximum log output in MB kept for executions (default %d)",
+			constants.DefaultLogMaxBytes/constants.BytesPerMB))
+	registerImageCmd.Flags().IntVar(&registerImageWarmPool,
+		"warm-pool", 0,
+		fmt.Sprintf("Optional number of pre-provisioned slots kept for low-latency starts (0 disables, max %d)",
+			constants.MaxWarmPoolSize))
+	registerImageCmd.Flags().StringVar(&registerImageWorkDir,
+		"workdir", "", "Optional working directory of the executions using the image, when they don't set one")
+	registerImageCmd.Flags().StringVar(&registerImageShell,
+		"shell", "", "Optional shell running the commands of the executions using the image: sh, bash or pwsh")
+	unregisterImageCmd.Flags().BoolVar(&unregisterImagePurge,
+		"purge", false, "Permanently remove the image without a restore window (admin only)")
 	imagesCmd.AddCommand(registerImageCmd)
 	imagesCmd.AddCommand(listImagesCmd)
 	imagesCmd.AddCommand(showImageCmd)
 	imagesCmd.AddCommand(unregisterImageCmd)
+	imagesCmd.AddCommand(restoreImageCmd)
 	rootCmd.AddCommand(imagesCmd)
 }
 
@@ -133,9 +182,42 @@ func registerImageRun(cmd *cobra.Command, args []string) {
 		runtimePlatform = &registerImageRuntimePlatform
 	}
 
+	var logLimits *api.LogLimits
+	if cmd.Flags().Changed("log-max-lines-per-second") || cmd.Flags().Changed("log-max-mb") {
+		if registerImageLogMaxLines < 0 || registerImageLogMaxMB < 0 {
+			output.Errorf("log limits must not be negative")
+			return
+		}
+		logLimits = &api.LogLimits{
+			MaxLinesPerSecond: registerImageLogMaxLines,
+			MaxBytes:          int64(registerImageLogMaxMB) * constants.BytesPerMB,
+		}
+	}
+
+	var warmPoolSize *int
+	if cmd.Flags().Changed("warm-pool") {
+		if registerImageWarmPool < 0 || registerImageWarmPool > constants.MaxWarmPoolSize {
+			output.Errorf("warm pool size must be between 0 and %d", constants.MaxWarmPoolSize)
+			return
+		}
+		warmPoolSize = &registerImageWarmPool
+	}
+
+	var runDefaults *api.RunDefaults
+	if cmd.Flags().Changed("workdir") || cmd.Flags().Changed("shell") {
+		if registerImageShell != "" && !constants.ExecutionShell(registerImageShell).Valid() {
+			output.Errorf("invalid shell %q, use sh, bash or pwsh", registerImageShell)
+			return
+		}
+		runDefaults = &api.RunDefaults{WorkDir: registerImageWorkDir, Shell: registerImageShell}
+	}
+
 	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
 		service := NewImagesService(c, NewOutputWrapper())
-		return service.RegisterImage(ctx, image, isDefault, taskRoleName, taskExecutionRoleName, cpu, memory, runtimePlatform)
+		return service.RegisterImage(
+			ctx, image, isDefault, taskRoleName, taskExecutionRoleName, cpu, memory, runtimePlatform, logLimits,
+			warmPoolSize, runDefaults,
+		)
 	})
 }
 
@@ -158,10 +240,21 @@ func unregisterImageRun(cmd *cobra.Command, args []string) {
 	image := args[0]
 	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
 		service := NewImagesService(c, NewOutputWrapper())
+		if unregisterImagePurge {
+			return service.PurgeImage(ctx, image)
+		}
 		return service.UnregisterImage(ctx, image)
 	})
 }
 
+func restoreImageRun(cmd *cobra.Command, args []string) {
+	image := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewImagesService(c, NewOutputWrapper())
+		return service.RestoreImage(ctx, image)
+	})
+}
+
 // ImagesService handles image management logic.
 type ImagesService struct {
 	client client.Interface
@@ -181,9 +274,13 @@ func (s *ImagesService) RegisterImage(
 	ctx context.Context, image string, isDefault *bool, taskRoleName, taskExecutionRoleName *string,
 	cpu, memory *int,
 	runtimePlatform *string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
 ) error {
 	resp, err := s.client.RegisterImage(
-		ctx, image, isDefault, taskRoleName, taskExecutionRoleName, cpu, memory, runtimePlatform,
+		ctx, image, isDefault, taskRoleName, taskExecutionRoleName, cpu, memory, runtimePlatform, logLimits,
+		warmPoolSize, runDefaults,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register image: %w", err)
@@ -215,6 +312,7 @@ func (s *ImagesService) ListImages(ctx context.Context) error {
 			"Memory",
 			"Runtime Platform",
 			"Is Default",
+			"Deleted",
 		},
 		rows,
 	)
@@ -267,12 +365,44 @@ func (s *ImagesService) ShowImage(ctx context.Context, image string) error {
 		defaultStr = strconv.FormatBool(true)
 	}
 	s.output.KeyValue("Is Default", defaultStr)
+	logMaxLines := "default"
+	logMaxSize := "default"
+	if imageInfo.LogLimits != nil {
+		if imageInfo.LogLimits.MaxLinesPerSecond > 0 {
+			logMaxLines = strconv.Itoa(imageInfo.LogLimits.MaxLinesPerSecond)
+		}
+		if imageInfo.LogLimits.MaxBytes > 0 {
+			logMaxSize = fmt.Sprintf("%d MB", imageInfo.LogLimits.MaxBytes/constants.BytesPerMB)
+		}
+	}
+	s.output.KeyValue("Log Max Lines/Second", logMaxLines)
+	s.output.KeyValue("Log Max Size", logMaxSize)
+	warmPool := "disabled"
+	if imageInfo.WarmPoolSize > 0 {
+		warmPool = fmt.Sprintf("%d slots", imageInfo.WarmPoolSize)
+	}
+	s.output.KeyValue("Warm Pool", warmPool)
+	workDir, shell := "default", string(constants.ExecutionShellSh)
+	if imageInfo.RunDefaults != nil {
+		if imageInfo.RunDefaults.WorkDir != "" {
+			workDir = imageInfo.RunDefaults.WorkDir
+		}
+		if imageInfo.RunDefaults.Shell != "" {
+			shell = imageInfo.RunDefaults.Shell
+		}
+	}
+	s.output.KeyValue("Working Directory", workDir)
+	s.output.KeyValue("Shell", shell)
+	if imageInfo.DeletedAt != nil {
+		s.output.KeyValue("Deleted At", imageInfo.DeletedAt.Format(time.RFC3339))
+		s.output.KeyValue("Deleted By", imageInfo.DeletedBy)
+	}
 	s.output.Blank()
 	s.output.Successf("Image information retrieved successfully")
 	return nil
 }
 
-// UnregisterImage unregisters an image.
+// UnregisterImage soft-deletes an image, keeping it restorable until its retention window expires.
 func (s *ImagesService) UnregisterImage(ctx context.Context, image string) error {
 	resp, err := s.client.UnregisterImage(ctx, image)
 	if err != nil {
@@ -281,6 +411,35 @@ func (s *ImagesService) UnregisterImage(ctx context.Context, image string) error
 
 	s.output.Successf("Image removed successfully")
 	s.output.KeyValue("Image", resp.Image)
+	if resp.RestorableUntil != nil {
+		s.output.KeyValue("Restorable Until", resp.RestorableUntil.Format(time.RFC3339))
+	}
+	s.output.KeyValue("Message", resp.Message)
+	return nil
+}
+
+// PurgeImage permanently removes an image.
+func (s *ImagesService) PurgeImage(ctx context.Context, image string) error {
+	resp, err := s.client.PurgeImage(ctx, image)
+	if err != nil {
+		return fmt.Errorf("failed to purge image: %w", err)
+	}
+
+	s.output.Successf("Image purged successfully")
+	s.output.KeyValue("Image", resp.Image)
+	s.output.KeyValue("Message", resp.Message)
+	return nil
+}
+
+// RestoreImage restores a deleted image.
+func (s *ImagesService) RestoreImage(ctx context.Context, image string) error {
+	resp, err := s.client.RestoreImage(ctx, image)
+	if err != nil {
+		return fmt.Errorf("failed to restore image: %w", err)
+	}
+
+	s.output.Successf("Image restored successfully")
+	s.output.KeyValue("Image", resp.Image)
 	s.output.KeyValue("Message", resp.Message)
 	return nil
 }
@@ -301,6 +460,11 @@ func (s *ImagesService) formatImages(images []api.ImageInfo) [][]string {
 			platformStr = "-"
 		}
 
+		deletedStr := "-"
+		if image.DeletedAt != nil {
+			deletedStr = image.DeletedAt.Format(time.RFC3339)
+		}
+
 		rows = append(rows, []string{
 			image.ImageID,
 			image.Image,
@@ -308,6 +472,7 @@ func (s *ImagesService) formatImages(images []api.ImageInfo) [][]string {
 			strconv.Itoa(image.Memory),
 			platformStr,
 			defaultStr,
+			deletedStr,
 		})
 	}
 	return rows
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var imageAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Image aliases commands",
+	Long: `Manage image aliases, stable names such as python:stable pointing at a registered image.
+
+Runs referencing an alias use the image it points at when they start, and record both,
+so pointing the alias at a new image upgrades every playbook and script using it.`,
+}
+
+var setImageAliasCmd = &cobra.Command{
+	Use:   "set <alias> <image>",
+	Short: "Point an image alias at a registered image",
+	Long:  `Create the alias, or point it at another image in a single update`,
+	Example: fmt.Sprintf(`  - %s images alias set python:stable python:3.12
+  - %s images alias set python:stable python:3.13-a1b2c3d4
+  - %s run --image python:stable "python --version"`,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName),
+	Run:  setImageAliasRun,
+	Args: cobra.ExactArgs(2),
+}
+
+var listImageAliasesCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List image aliases",
+	Example: fmt.Sprintf(`  - %s images alias list`, constants.ProjectName),
+	Run:     listImageAliasesRun,
+}
+
+var removeImageAliasCmd = &cobra.Command{
+	Use:     "remove <alias>",
+	Short:   "Remove an image alias",
+	Long:    `Remove the alias, the image it points at stays registered`,
+	Example: fmt.Sprintf(`  - %s images alias remove python:stable`, constants.ProjectName),
+	Run:     removeImageAliasRun,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	imageAliasCmd.AddCommand(setImageAliasCmd)
+	imageAliasCmd.AddCommand(listImageAliasesCmd)
+	imageAliasCmd.AddCommand(removeImageAliasCmd)
+	imagesCmd.AddCommand(imageAliasCmd)
+}
+
+func setImageAliasRun(cmd *cobra.Command, args []string) {
+	alias, image := args[0], args[1]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewImagesService(c, NewOutputWrapper()).SetImageAlias(ctx, alias, image)
+	})
+}
+
+func listImageAliasesRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewImagesService(c, NewOutputWrapper()).ListImageAliases(ctx)
+	})
+}
+
+func removeImageAliasRun(cmd *cobra.Command, args []string) {
+	alias := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewImagesService(c, NewOutputWrapper()).RemoveImageAlias(ctx, alias)
+	})
+}
+
+// SetImageAlias points an image alias at a registered image.
+func (s *ImagesService) SetImageAlias(ctx context.Context, alias, image string) error {
+	resp, err := s.client.SetImageAlias(ctx, alias, image)
+	if err != nil {
+		return fmt.Errorf("failed to set image alias: %w", err)
+	}
+
+	s.output.Successf("Image alias set successfully")
+	s.output.KeyValue("Alias", resp.Alias.Alias)
+	s.output.KeyValue("Image ID", resp.Alias.ImageID)
+	if resp.PreviousImageID != "" {
+		s.output.KeyValue("Previous Image ID", resp.PreviousImageID)
+	}
+	return nil
+}
+
+// ListImageAliases lists all image aliases.
+func (s *ImagesService) ListImageAliases(ctx context.Context) error {
+	resp, err := s.client.ListImageAliases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list image aliases: %w", err)
+	}
+
+	rows := make([][]string, 0, len(resp.Aliases))
+	for i := range resp.Aliases {
+		alias := &resp.Aliases[i]
+		rows = append(rows, []string{
+			alias.Alias,
+			alias.ImageID,
+			alias.UpdatedBy,
+			alias.UpdatedAt.Format(time.DateTime),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Alias", "Image ID", "Updated By", "Updated At"}, rows)
+	s.output.Blank()
+	s.output.Successf("Image aliases listed successfully")
+	return nil
+}
+
+// RemoveImageAlias removes an image alias.
+func (s *ImagesService) RemoveImageAlias(ctx context.Context, alias string) error {
+	resp, err := s.client.RemoveImageAlias(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("failed to remove image alias: %w", err)
+	}
+
+	s.output.Successf("Image alias removed successfully")
+	s.output.KeyValue("Alias", resp.Alias)
+	return nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestImagesService_SetImageAlias(t *testing.T) {
+	t.Run("shows the previous image", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForImages{
+			mockClientInterface: &mockClientInterface{},
+			setImageAliasFunc: func(_ context.Context, alias, image string) (*api.SetImageAliasResponse, error) {
+				assert.Equal(t, "python:stable", alias)
+				assert.Equal(t, "python:3.13", image)
+				return &api.SetImageAliasResponse{
+					Alias:           api.ImageAlias{Alias: alias, ImageID: "python:3.13-a1b2c3d4"},
+					PreviousImageID: "python:3.12-e5f6a7b8",
+				}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+
+		err := NewImagesService(mockClient, mockOutput).SetImageAlias(context.Background(), "python:stable", "python:3.13")
+
+		require.NoError(t, err)
+		keyValues := map[string]any{}
+		for _, call := range mockOutput.calls {
+			if call.method == "KeyValue" {
+				keyValues[call.args[0].(string)] = call.args[1]
+			}
+		}
+		assert.Equal(t, "python:3.13-a1b2c3d4", keyValues["Image ID"])
+		assert.Equal(t, "python:3.12-e5f6a7b8", keyValues["Previous Image ID"])
+	})
+
+	t.Run("handles client error", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForImages{
+			mockClientInterface: &mockClientInterface{},
+			setImageAliasFunc: func(_ context.Context, _, _ string) (*api.SetImageAliasResponse, error) {
+				return nil, errors.New("image not found")
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+
+		err := NewImagesService(mockClient, mockOutput).SetImageAlias(context.Background(), "python:stable", "python:4")
+
+		assert.ErrorContains(t, err, "failed to set image alias")
+		assert.Empty(t, mockOutput.calls)
+	})
+}
+
+func TestImagesService_ListImageAliases(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockClient := &mockClientInterfaceForImages{
+		mockClientInterface: &mockClientInterface{},
+		listImageAliasesFunc: func(_ context.Context) (*api.ListImageAliasesResponse, error) {
+			return &api.ListImageAliasesResponse{Aliases: []api.ImageAlias{{
+				Alias: "python:stable", ImageID: "python:3.12-a1b2c3d4", UpdatedBy: "user@example.com", UpdatedAt: updatedAt,
+			}}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewImagesService(mockClient, mockOutput).ListImageAliases(context.Background())
+
+	require.NoError(t, err)
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows := call.args[1].([][]string)
+			assert.Equal(t, [][]string{
+				{"python:stable", "python:3.12-a1b2c3d4", "user@example.com", "2026-01-02 03:04:05"},
+			}, rows)
+			return
+		}
+	}
+	t.Fatal("expected Table call")
+}
+
+func TestImagesService_RemoveImageAlias(t *testing.T) {
+	var removed string
+	mockClient := &mockClientInterfaceForImages{
+		mockClientInterface: &mockClientInterface{},
+		removeImageAliasFunc: func(_ context.Context, alias string) (*api.RemoveImageAliasResponse, error) {
+			removed = alias
+			return &api.RemoveImageAliasResponse{Alias: alias}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewImagesService(mockClient, mockOutput).RemoveImageAlias(context.Background(), "python:stable")
+
+	require.NoError(t, err)
+	assert.Equal(t, "python:stable", removed)
+	assert.Equal(t, "Successf", mockOutput.calls[0].method)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,12 +24,21 @@ type mockClientInterfaceForImages struct {
 	) (*api.RegisterImageResponse, error)
 	listImagesFunc      func(ctx context.Context) (*api.ListImagesResponse, error)
 	unregisterImageFunc func(ctx context.Context, image string) (*api.RemoveImageResponse, error)
+	restoreImageFunc    func(ctx context.Context, image string) (*api.RestoreImageResponse, error)
+	purgeImageFunc      func(ctx context.Context, image string) (*api.RemoveImageResponse, error)
+
+	setImageAliasFunc    func(ctx context.Context, alias, image string) (*api.SetImageAliasResponse, error)
+	listImageAliasesFunc func(ctx context.Context) (*api.ListImageAliasesResponse, error)
+	removeImageAliasFunc func(ctx context.Context, alias string) (*api.RemoveImageAliasResponse, error)
 }
 
 func (m *mockClientInterfaceForImages) RegisterImage(
 	ctx context.Context, image string, isDefault *bool, taskRoleName, taskExecutionRoleName *string,
 	cpu, memory *int,
 	runtimePlatform *string,
+	_ *api.LogLimits,
+	_ *int,
+	_ *api.RunDefaults,
 ) (*api.RegisterImageResponse, error) {
 	if m.registerImageFunc != nil {
 		return m.registerImageFunc(ctx, image, isDefault, taskRoleName, taskExecutionRoleName, cpu, memory, runtimePlatform)
@@ -52,6 +62,49 @@ func (m *mockClientInterfaceForImages) UnregisterImage(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClientInterfaceForImages) RestoreImage(
+	ctx context.Context, image string,
+) (*api.RestoreImageResponse, error) {
+	if m.restoreImageFunc != nil {
+		return m.restoreImageFunc(ctx, image)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForImages) PurgeImage(
+	ctx context.Context, image string,
+) (*api.RemoveImageResponse, error) {
+	if m.purgeImageFunc != nil {
+		return m.purgeImageFunc(ctx, image)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForImages) SetImageAlias(
+	ctx context.Context, alias, image string,
+) (*api.SetImageAliasResponse, error) {
+	if m.setImageAliasFunc != nil {
+		return m.setImageAliasFunc(ctx, alias, image)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForImages) ListImageAliases(ctx context.Context) (*api.ListImageAliasesResponse, error) {
+	if m.listImageAliasesFunc != nil {
+		return m.listImageAliasesFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForImages) RemoveImageAlias(
+	ctx context.Context, alias string,
+) (*api.RemoveImageAliasResponse, error) {
+	if m.removeImageAliasFunc != nil {
+		return m.removeImageAliasFunc(ctx, alias)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockClientInterfaceForImages) FetchBackendLogs(_ context.Context, _ string) (*api.TraceResponse, error) {
 	return nil, nil
 }
@@ -206,7 +259,8 @@ func TestImagesService_RegisterImage(t *testing.T) {
 			service := NewImagesService(mockClient, mockOutput)
 
 			err := service.RegisterImage(
-				context.Background(), tt.image, tt.isDefault, tt.taskRoleName, tt.taskExecutionRoleName, nil, nil, nil,
+				context.Background(), tt.image, tt.isDefault, tt.taskRoleName, tt.taskExecutionRoleName, nil, nil, nil, nil, nil,
+				nil,
 			)
 
 			if tt.wantErr {
@@ -421,3 +475,91 @@ func TestImagesService_UnregisterImage(t *testing.T) {
 		})
 	}
 }
+
+func TestImagesService_UnregisterImage_ShowsRestorableUntil(t *testing.T) {
+	restorableUntil := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	mockClient := &mockClientInterfaceForImages{
+		mockClientInterface: &mockClientInterface{},
+		unregisterImageFunc: func(_ context.Context, image string) (*api.RemoveImageResponse, error) {
+			return &api.RemoveImageResponse{Image: image, RestorableUntil: &restorableUntil}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewImagesService(mockClient, mockOutput)
+
+	err := service.UnregisterImage(context.Background(), "alpine:latest")
+
+	assert.NoError(t, err)
+	found := false
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" && call.args[0] == "Restorable Until" {
+			found = true
+			assert.Equal(t, "2026-01-08T00:00:00Z", call.args[1])
+		}
+	}
+	assert.True(t, found, "Expected Restorable Until KeyValue call")
+}
+
+func TestImagesService_RestoreImage(t *testing.T) {
+	t.Run("restores image", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForImages{
+			mockClientInterface: &mockClientInterface{},
+			restoreImageFunc: func(_ context.Context, image string) (*api.RestoreImageResponse, error) {
+				assert.Equal(t, "alpine:latest-a1b2c3d4", image)
+				return &api.RestoreImageResponse{Image: image, Message: "Image restored successfully"}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewImagesService(mockClient, mockOutput)
+
+		err := service.RestoreImage(context.Background(), "alpine:latest-a1b2c3d4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Successf", mockOutput.calls[0].method)
+	})
+
+	t.Run("handles client error", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForImages{
+			mockClientInterface: &mockClientInterface{},
+			restoreImageFunc: func(_ context.Context, _ string) (*api.RestoreImageResponse, error) {
+				return nil, errors.New("image is not deleted")
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewImagesService(mockClient, mockOutput)
+
+		err := service.RestoreImage(context.Background(), "alpine:latest-a1b2c3d4")
+
+		assert.ErrorContains(t, err, "failed to restore image")
+		assert.Empty(t, mockOutput.calls)
+	})
+}
+
+func TestImagesService_PurgeImage(t *testing.T) {
+	mockClient := &mockClientInterfaceForImages{
+		mockClientInterface: &mockClientInterface{},
+		purgeImageFunc: func(_ context.Context, image string) (*api.RemoveImageResponse, error) {
+			return &api.RemoveImageResponse{Image: image, Message: "Image removed successfully"}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewImagesService(mockClient, mockOutput)
+
+	err := service.PurgeImage(context.Background(), "alpine:latest")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Successf", mockOutput.calls[0].method)
+}
+
+func TestImagesService_FormatImages_Deleted(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	service := NewImagesService(&mockClientInterface{}, &mockOutputInterface{})
+
+	rows := service.formatImages([]api.ImageInfo{
+		{ImageID: "alpine:latest-a1b2c3d4", Image: "alpine:latest"},
+		{ImageID: "ubuntu:22.04-e5f6a7b8", Image: "ubuntu:22.04", DeletedAt: &deletedAt},
+	})
+
+	assert.Equal(t, "-", rows[0][6])
+	assert.Equal(t, "2026-01-01T00:00:00Z", rows[1][6])
+}
@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/client/infra"
 	"github.com/runvoy/runvoy/internal/client/output"
@@ -24,12 +24,50 @@ var (
 	infraApplyRegion        string
 	infraApplyProvider      string
 	infraApplySeedAdminUser string
+	infraApplyAlarmTopic    string
+	infraApplyDomain        string
+	infraApplyDNSZone       string
+	infraApplyIPv6          bool
+	infraApplyKMSKey        string
+	infraApplyTags          map[string]string
+	infraApplySSOIssuer     string
+	infraApplySSOClientID   string
+	infraApplySCIMRoles     map[string]string
+	infraApplyGitHubTrusts  map[string]string
+	infraApplyConcurrency   int
 
 	// infra destroy flags.
 	infraDestroyStackName string
 	infraDestroyWait      bool
 	infraDestroyRegion    string
 	infraDestroyProvider  string
+
+	// infra status flags.
+	infraStatusStackName string
+	infraStatusVersion   string
+	infraStatusFix       bool
+	infraStatusRegion    string
+	infraStatusProvider  string
+
+	// infra upgrade flags.
+	infraUpgradeStackName string
+	infraUpgradeVersion   string
+	infraUpgradeDryRun    bool
+	infraUpgradeForce     bool
+	infraUpgradeRegion    string
+	infraUpgradeProvider  string
+
+	// infra capacity flags.
+	infraCapacityStackName string
+	infraCapacityPeriod    time.Duration
+	infraCapacityApply     bool
+	infraCapacityRegion    string
+	infraCapacityProvider  string
+
+	// infra bootstrap-admin flags.
+	infraBootstrapAdminStackName string
+	infraBootstrapAdminRegion    string
+	infraBootstrapAdminProvider  string
 )
 
 // infraCmd is the parent command for infrastructure operations.
@@ -60,6 +98,8 @@ or a local file path.`,
 			"  # Apply with custom parameters\n"+
 			"  %s infra apply --stack-name my-stack --parameter ProjectName=myproject "+
 			"--parameter LambdaCodeBucket=my-bucket\n\n"+
+			"  # Apply with a custom domain whose records and certificate are managed in a hosted zone\n"+
+			"  %s infra apply --stack-name my-stack --domain api.mycompany.com --dns-zone Z0123456789ABC\n\n"+
 			"  # Apply and automatically configure CLI\n"+
 			"  %s infra apply --stack-name my-stack --configure\n\n"+
 			"  # Apply, configure CLI, and seed admin user\n"+
@@ -71,6 +111,7 @@ or a local file path.`,
 		constants.ProjectName,
 		constants.ProjectName,
 		constants.ProjectName,
+		constants.ProjectName,
 	),
 	Run: infraApplyRun,
 }
@@ -94,10 +135,100 @@ the CloudFormation stack and all associated AWS resources.`,
 	Run: infraDestroyRun,
 }
 
+// infraStatusCmd reports configuration drift of the deployed backend.
+var infraStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Detect configuration drift of backend infrastructure",
+	Long: `Compare the live backend resources with their expected configuration.
+
+Reports resources changed or deleted outside of the infrastructure stack (for example
+a disabled TTL, a changed environment variable or a deleted log group) and whether the
+deployed release matches the expected version. With --fix, the expected version is
+applied when it differs and the backend health reconciliation is run.`,
+	Example: fmt.Sprintf(
+		"  # Detect drift of the default stack\n"+
+			"  %s infra status\n\n"+
+			"  # Detect drift and reconcile it\n"+
+			"  %s infra status --stack-name my-stack --fix",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: infraStatusRun,
+}
+
+// infraUpgradeCmd upgrades the deployed backend to a new release.
+var infraUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade backend infrastructure to a new release",
+	Long: `Upgrade the deployed backend to the CLI version or the specified release.
+
+The deployed version is read from the backend health endpoint and compared with the
+target version: downgrades are refused unless --force is set. The data migrations
+required between both versions are run first, then the stack is updated with the
+target release, which updates the backend functions.`,
+	Example: fmt.Sprintf(
+		"  # Show the upgrade plan without applying it\n"+
+			"  %s infra upgrade --dry-run\n\n"+
+			"  # Upgrade to a specific release\n"+
+			"  %s infra upgrade --stack-name my-stack --version v0.6.0",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: infraUpgradeRun,
+}
+
+// infraCapacityCmd recommends the billing mode of the execution logs table.
+var infraCapacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Recommend the billing mode of the execution logs table",
+	Long: `Analyze the throttled requests and consumed capacity of the execution logs table and
+recommend switching it between on-demand and provisioned capacity with autoscaling.
+
+Provisioned tables that throttled requests or cost more than on-demand are switched to
+on-demand. On-demand tables with steady traffic that would cost significantly less with
+provisioned capacity are switched to provisioned, with autoscaling bounds derived from
+the usage. With --apply, the recommendation is applied to the stack parameters, which
+later upgrades keep.`,
+	Example: fmt.Sprintf(
+		"  # Show the recommendation for the last 14 days\n"+
+			"  %s infra capacity\n\n"+
+			"  # Apply the recommendation based on the last 30 days\n"+
+			"  %s infra capacity --period 720h --apply",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: infraCapacityRun,
+}
+
+// infraBootstrapAdminCmd creates the admin user of the deployed backend.
+var infraBootstrapAdminCmd = &cobra.Command{
+	Use:   "bootstrap-admin <email>",
+	Short: "Create the admin user of the backend",
+	Long: `Create the admin user of the deployed backend and save its API key to the CLI configuration.
+
+The backend resources are resolved from the infrastructure stack outputs. The command
+is safe to re-run: when a user with the email already exists, it is displayed and no
+new API key is generated.`,
+	Example: fmt.Sprintf(
+		"  # Create the admin user of the default stack\n"+
+			"  %s infra bootstrap-admin admin@example.com\n\n"+
+			"  # Create the admin user of a specific stack\n"+
+			"  %s infra bootstrap-admin admin@example.com --stack-name my-stack",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.ExactArgs(1),
+	Run:  infraBootstrapAdminRun,
+}
+
 func init() {
 	rootCmd.AddCommand(infraCmd)
 	infraCmd.AddCommand(infraApplyCmd)
 	infraCmd.AddCommand(infraDestroyCmd)
+	infraCmd.AddCommand(infraStatusCmd)
+	infraCmd.AddCommand(infraUpgradeCmd)
+	infraCmd.AddCommand(infraCapacityCmd)
+	infraCmd.AddCommand(infraBootstrapAdminCmd)
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -126,6 +257,34 @@ func init() {
 		"Provider region. Uses provider default if not specified")
 	infraApplyCmd.Flags().StringVar(&infraApplySeedAdminUser, "seed-admin-user", "",
 		"Email address for the admin user to seed into DynamoDB after successful deployment")
+	infraApplyCmd.Flags().StringVar(&infraApplyAlarmTopic, "alarm-topic", "",
+		"Notification target of the backend alarms (SNS topic ARN on AWS). A dedicated topic is created if not specified")
+	infraApplyCmd.Flags().StringVar(&infraApplyDomain, "domain", "",
+		"Custom domain of the API, e.g. api.mycompany.com, with a managed TLS certificate")
+	infraApplyCmd.Flags().StringVar(&infraApplyDNSZone, "dns-zone", "",
+		"DNS zone of the custom domain (Route53 hosted zone ID on AWS) to create its records and validate "+
+			"its certificate in. Records are left to you if not specified")
+	infraApplyCmd.Flags().BoolVar(&infraApplyIPv6, "ipv6", false,
+		"Enable dual-stack (IPv4 and IPv6) API endpoints and execution networking")
+	infraApplyCmd.Flags().StringVar(&infraApplyKMSKey, "kms-key", "",
+		"Customer managed key encrypting the secrets and the backend data (KMS key ARN on AWS). "+
+			"A dedicated key is created for the secrets if not specified")
+	infraApplyCmd.Flags().StringToStringVar(&infraApplyTags, "tag", nil,
+		"Tag in KEY=VALUE format applied to the stack resources and the resources created at runtime "+
+			"(can be specified multiple times). The current tags are kept if not specified")
+	infraApplyCmd.Flags().StringVar(&infraApplySSOIssuer, "sso-issuer", "",
+		"Issuer URL of the OpenID Connect identity provider users log in with, e.g. https://mycompany.okta.com")
+	infraApplyCmd.Flags().StringVar(&infraApplySSOClientID, "sso-client-id", "",
+		"Client ID of the runvoy application registered with the identity provider")
+	infraApplyCmd.Flags().StringToStringVar(&infraApplySCIMRoles, "scim-group-role", nil,
+		"Identity provider group mapped to a role in GROUP=ROLE format, for the users provisioned through SCIM "+
+			"(can be specified multiple times). The current mapping is kept if not specified")
+	infraApplyCmd.Flags().StringToStringVar(&infraApplyGitHubTrusts, "github-trust", nil,
+		"GitHub repository, owner/name or owner/*, whose Actions workflows run jobs as a user, in REPOSITORY=EMAIL "+
+			"format (can be specified multiple times). The current trusts are kept if not specified")
+	infraApplyCmd.Flags().IntVar(&infraApplyConcurrency, "provisioned-concurrency", 0,
+		"Number of orchestrator instances kept initialized to avoid cold starts, billed while idle, 0 for none. "+
+			"The current setting is kept if not specified")
 
 	// Define flags for infra destroy
 	infraDestroyCmd.Flags().StringVar(&infraDestroyProvider, "provider", defaultProvider,
@@ -136,6 +295,52 @@ func init() {
 		"Wait for stack deletion to complete")
 	infraDestroyCmd.Flags().StringVar(&infraDestroyRegion, "region", "",
 		"Provider region. Uses provider default if not specified")
+
+	// Define flags for infra status
+	infraStatusCmd.Flags().StringVar(&infraStatusProvider, "provider", defaultProvider,
+		"Cloud provider (currently supported: aws)")
+	infraStatusCmd.Flags().StringVar(&infraStatusStackName, "stack-name", defaultStackName,
+		"Infrastructure stack name")
+	infraStatusCmd.Flags().StringVar(&infraStatusVersion, "version", "",
+		"Expected release version. Defaults to CLI version")
+	infraStatusCmd.Flags().BoolVar(&infraStatusFix, "fix", false,
+		"Reconcile detected drift")
+	infraStatusCmd.Flags().StringVar(&infraStatusRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
+
+	// Define flags for infra upgrade
+	infraUpgradeCmd.Flags().StringVar(&infraUpgradeProvider, "provider", defaultProvider,
+		"Cloud provider (currently supported: aws)")
+	infraUpgradeCmd.Flags().StringVar(&infraUpgradeStackName, "stack-name", defaultStackName,
+		"Infrastructure stack name")
+	infraUpgradeCmd.Flags().StringVar(&infraUpgradeVersion, "version", "",
+		"Release version to upgrade to. Defaults to CLI version")
+	infraUpgradeCmd.Flags().BoolVar(&infraUpgradeDryRun, "dry-run", false,
+		"Show the upgrade plan without applying it")
+	infraUpgradeCmd.Flags().BoolVar(&infraUpgradeForce, "force", false,
+		"Apply the target version even when it is older than or equal to the deployed one")
+	infraUpgradeCmd.Flags().StringVar(&infraUpgradeRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
+
+	// Define flags for infra capacity
+	infraCapacityCmd.Flags().StringVar(&infraCapacityProvider, "provider", defaultProvider,
+		"Cloud provider (currently supported: aws)")
+	infraCapacityCmd.Flags().StringVar(&infraCapacityStackName, "stack-name", defaultStackName,
+		"Infrastructure stack name")
+	infraCapacityCmd.Flags().DurationVar(&infraCapacityPeriod, "period", infra.DefaultCapacityPeriod,
+		"Usage period the recommendation is based on")
+	infraCapacityCmd.Flags().BoolVar(&infraCapacityApply, "apply", false,
+		"Apply the recommended billing mode and capacity settings")
+	infraCapacityCmd.Flags().StringVar(&infraCapacityRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
+
+	// Define flags for infra bootstrap-admin
+	infraBootstrapAdminCmd.Flags().StringVar(&infraBootstrapAdminProvider, "provider", defaultProvider,
+		"Cloud provider (currently supported: aws)")
+	infraBootstrapAdminCmd.Flags().StringVar(&infraBootstrapAdminStackName, "stack-name", defaultStackName,
+		"Infrastructure stack name")
+	infraBootstrapAdminCmd.Flags().StringVar(&infraBootstrapAdminRegion, "region", "",
+		"Provider region. Uses provider default if not specified")
 }
 
 func infraApplyRun(cmd *cobra.Command, _ []string) {
@@ -144,6 +349,23 @@ func infraApplyRun(cmd *cobra.Command, _ []string) {
 		version = *constants.GetVersion()
 	}
 
+	if err := config.ValidateResourceTags(infraApplyTags); err != nil {
+		output.Fatalf("invalid --tag: %v", err)
+	}
+	ssoConfig := config.SSOConfig{Issuer: infraApplySSOIssuer, ClientID: infraApplySSOClientID}
+	if err := ssoConfig.Validate(); err != nil {
+		output.Fatalf("invalid --sso-issuer or --sso-client-id: %v", err)
+	}
+	if _, err := config.ParseGroupRoles(config.FormatGroupRoles(infraApplySCIMRoles)); err != nil {
+		output.Fatalf("invalid --scim-group-role: %v", err)
+	}
+	if _, err := config.ParseGitHubTrusts(config.FormatGitHubTrusts(infraApplyGitHubTrusts)); err != nil {
+		output.Fatalf("invalid --github-trust: %v", err)
+	}
+	if infraApplyConcurrency < 0 {
+		output.Fatalf("invalid --provisioned-concurrency: %d, must not be negative", infraApplyConcurrency)
+	}
+
 	applier, err := infra.NewDeployer(cmd.Context(), infraApplyProvider, infraApplyRegion)
 	if err != nil {
 		output.Fatalf("failed to initialize applier: %v", err)
@@ -173,6 +395,20 @@ func infraApplyRun(cmd *cobra.Command, _ []string) {
 		Parameters: infraApplyParameters,
 		Wait:       infraApplyWait,
 		Region:     infraApplyRegion,
+		AlarmTopic: infraApplyAlarmTopic,
+		Domain:     infraApplyDomain,
+		DNSZone:    infraApplyDNSZone,
+		IPv6:       infraApplyIPv6,
+		KMSKey:     infraApplyKMSKey,
+		Tags:       infraApplyTags,
+		SSOIssuer:  infraApplySSOIssuer,
+		SSOClient:  infraApplySSOClientID,
+		GroupRoles: infraApplySCIMRoles,
+
+		GitHubTrusts: infraApplyGitHubTrusts,
+	}
+	if cmd.Flags().Changed("provisioned-concurrency") {
+		opts.ProvisionedConcurrency = &infraApplyConcurrency
 	}
 
 	stackExists, err := applier.CheckStackExists(cmd.Context(), infraApplyStackName)
@@ -197,7 +433,7 @@ func infraApplyRun(cmd *cobra.Command, _ []string) {
 		result,
 		spinner,
 		infraApplyConfigure, infraApplySeedAdminUser,
-		infraApplyRegion,
+		infraApplyProvider, infraApplyRegion,
 	)
 }
 
@@ -207,7 +443,7 @@ func handleApplyResult(
 	spinner *output.Spinner,
 	configure bool,
 	seedAdminUserEmail,
-	region string,
+	provider, region string,
 ) {
 	if result.NoChanges {
 		spinner.Success("Stack is already up to date")
@@ -240,12 +476,9 @@ func handleApplyResult(
 	}
 
 	if seedAdminUserEmail != "" {
-		ctx := context.Background()
-		if err := seedAdminUser(ctx, seedAdminUserEmail, region, result.Outputs); err != nil {
+		output.Blank()
+		if err := bootstrapAdmin(context.Background(), provider, region, seedAdminUserEmail, result.Outputs); err != nil {
 			output.Warningf("Failed to seed admin user: %v", err)
-		} else {
-			output.Blank()
-			output.Successf("Admin user %s seeded successfully", seedAdminUserEmail)
 		}
 	}
 }
@@ -287,28 +520,6 @@ func configureEndpoint(endpoint string) error {
 	return nil
 }
 
-// seedAdminUser seeds an admin user into the database.
-func seedAdminUser(ctx context.Context, adminEmail, region string, stackOutputs map[string]string) error {
-	tableName, ok := stackOutputs["APIKeysTableName"]
-	if !ok {
-		return errors.New("APIKeysTableName not found in stack outputs")
-	}
-
-	apiKey, err := infra.SeedAdminUser(ctx, adminEmail, region, tableName)
-	if err != nil {
-		return fmt.Errorf("failed to seed admin user: %w", err)
-	}
-
-	endpoint := stackOutputs["APIEndpoint"]
-	err = saveAPIKeyToConfig(apiKey, endpoint)
-	if err != nil {
-		return err
-	}
-
-	output.Infof("API key saved to config file")
-	return nil
-}
-
 // saveAPIKeyToConfig saves the API key to the config file
 // It preserves the existing endpoint if set, or uses the provided endpoint if the config doesn't have one.
 func saveAPIKeyToConfig(apiKey, endpoint string) error {
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+
+	"github.com/spf13/cobra"
+)
+
+func infraBootstrapAdminRun(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+
+	deployer, err := infra.NewDeployer(ctx, infraBootstrapAdminProvider, infraBootstrapAdminRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+	outputs, err := deployer.GetStackOutputs(ctx, infraBootstrapAdminStackName)
+	if err != nil {
+		output.Fatalf("failed to get stack outputs: %v", err)
+	}
+
+	if err = bootstrapAdmin(ctx, infraBootstrapAdminProvider, deployer.GetRegion(), args[0], outputs); err != nil {
+		output.Fatalf(err.Error())
+	}
+}
+
+// bootstrapAdmin creates the admin user of the backend described by the stack outputs and
+// saves its API key to the CLI configuration. An existing user is displayed instead.
+func bootstrapAdmin(ctx context.Context, provider, region, adminEmail string, outputs map[string]string) error {
+	result, err := infra.BootstrapAdmin(ctx, provider, region, adminEmail, outputs)
+	if err != nil {
+		return err
+	}
+
+	if !result.Created {
+		output.Infof("User %s already exists, no API key generated", adminEmail)
+		output.KeyValue("Role", result.User.Role)
+		output.KeyValue("Created at", result.User.CreatedAt.Format(time.RFC3339))
+		if result.User.Revoked {
+			output.Warningf("The user's API key is revoked")
+		}
+		if result.User.Role != "admin" {
+			output.Warningf("The user does not have the admin role")
+		}
+		return nil
+	}
+
+	if err = saveAPIKeyToConfig(result.APIKey, outputs["APIEndpoint"]); err != nil {
+		return fmt.Errorf("admin user created but %w, store this API key somewhere safe: %s", err, result.APIKey)
+	}
+	output.Successf("Admin user %s created, API key saved to config file", adminEmail)
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+
+	"github.com/spf13/cobra"
+)
+
+func infraCapacityRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+
+	deployer, err := infra.NewDeployer(ctx, infraCapacityProvider, infraCapacityRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+
+	spinner := output.NewSpinner("Analyzing execution logs table usage...")
+	spinner.Start()
+	report, err := deployer.AnalyzeCapacity(ctx, &infra.CapacityOptions{
+		StackName: infraCapacityStackName,
+		Period:    infraCapacityPeriod,
+	})
+	if err != nil {
+		spinner.Error("Failed to analyze capacity")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Capacity analysis completed")
+
+	printCapacityReport(report, deployer.GetRegion())
+
+	recommendation := report.Recommendation
+	if !recommendation.Change {
+		output.Successf("Keep the current settings: %s", recommendation.Reason)
+		return
+	}
+	if !infraCapacityApply {
+		output.Warningf("Switch to %s: %s, run with --apply to switch",
+			recommendation.Settings.BillingMode, recommendation.Reason)
+		return
+	}
+
+	spinner = output.NewSpinner(fmt.Sprintf("Switching to %s...", recommendation.Settings.BillingMode))
+	spinner.Start()
+	result, err := deployer.ApplyCapacity(ctx, infraCapacityStackName, recommendation.Settings, true)
+	if err != nil {
+		spinner.Error("Failed to apply capacity settings")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Stack operation completed with status: " + result.Status)
+}
+
+func printCapacityReport(report *infra.CapacityReport, region string) {
+	output.Blank()
+	output.KeyValue("Stack name", report.StackName)
+	output.KeyValue("Region", region)
+	output.KeyValue("Table", report.TableName)
+	output.KeyValue("Period", report.Usage.Period.String())
+	output.KeyValue("Throttled requests", strconv.FormatInt(report.Usage.Throttles, 10))
+	output.Blank()
+
+	usage := &report.Usage
+	output.Table([]string{"Usage", "Average units/s", "Peak units/s"}, [][]string{
+		{"Read", formatUnits(usage.AverageReadPerSecond()), formatUnits(usage.PeakReadPerSecond())},
+		{"Write", formatUnits(usage.AverageWritePerSecond()), formatUnits(usage.PeakWritePerSecond())},
+	})
+	output.Blank()
+
+	recommendation := &report.Recommendation
+	output.Table([]string{"Settings", "Billing mode", "Read capacity", "Write capacity", "Estimated cost"},
+		[][]string{
+			formatCapacitySettings("Current", &report.Current, recommendation.CurrentCost),
+			formatCapacitySettings("Recommended", &recommendation.Settings, recommendation.EstimatedCost),
+		})
+	output.Blank()
+}
+
+func formatCapacitySettings(label string, settings *infra.CapacitySettings, cost float64) []string {
+	readCapacity, writeCapacity := "-", "-"
+	if settings.BillingMode == infra.BillingModeProvisioned {
+		readCapacity = fmt.Sprintf("%d-%d", settings.ReadCapacity, settings.MaxReadCapacity)
+		writeCapacity = fmt.Sprintf("%d-%d", settings.WriteCapacity, settings.MaxWriteCapacity)
+	}
+	return []string{label, settings.BillingMode, readCapacity, writeCapacity, fmt.Sprintf("$%.2f", cost)}
+}
+
+func formatUnits(units float64) string {
+	return strconv.FormatFloat(units, 'f', 2, 64)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+)
+
+func TestFormatCapacitySettings(t *testing.T) {
+	provisioned := &infra.CapacitySettings{
+		BillingMode:      infra.BillingModeProvisioned,
+		ReadCapacity:     5,
+		WriteCapacity:    10,
+		MaxReadCapacity:  50,
+		MaxWriteCapacity: 100,
+	}
+	onDemand := &infra.CapacitySettings{BillingMode: infra.BillingModeOnDemand, ReadCapacity: 5}
+
+	assert.Equal(t, []string{"Current", "PROVISIONED", "5-50", "10-100", "$1.50"},
+		formatCapacitySettings("Current", provisioned, 1.5))
+	assert.Equal(t, []string{"Recommended", "PAY_PER_REQUEST", "-", "-", "$0.12"},
+		formatCapacitySettings("Recommended", onDemand, 0.123))
+}
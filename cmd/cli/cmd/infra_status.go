@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+func infraStatusRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	version := infraStatusVersion
+	if version == "" {
+		version = *constants.GetVersion()
+	}
+
+	deployer, err := infra.NewDeployer(ctx, infraStatusProvider, infraStatusRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+
+	stackExists, err := deployer.CheckStackExists(ctx, infraStatusStackName)
+	if err != nil {
+		output.Fatalf("failed to check stack status: %v", err)
+	}
+	if !stackExists {
+		output.Fatalf("stack %s does not exist", infraStatusStackName)
+	}
+
+	result := detectDrift(ctx, deployer, version)
+	printDriftResult(result, deployer.GetRegion())
+
+	if !result.HasDrift() {
+		output.Successf("No drift detected")
+		return
+	}
+	if !infraStatusFix {
+		output.Warningf("Drift detected, run with --fix to reconcile it")
+		return
+	}
+
+	fixDrift(cmd, deployer, result)
+}
+
+func detectDrift(ctx context.Context, deployer infra.Deployer, version string) *infra.DriftResult {
+	spinner := output.NewSpinner("Detecting drift...")
+	spinner.Start()
+
+	result, err := deployer.DetectDrift(ctx, &infra.DriftOptions{
+		StackName:       infraStatusStackName,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		spinner.Error("Failed to detect drift")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Drift detection completed")
+	return result
+}
+
+func printDriftResult(result *infra.DriftResult, region string) {
+	output.Blank()
+	output.KeyValue("Stack name", result.StackName)
+	output.KeyValue("Region", region)
+	output.KeyValue("Stack status", result.StackStatus)
+	output.KeyValue("Deployed version", result.DeployedVersion)
+	output.KeyValue("Expected version", result.ExpectedVersion)
+	output.KeyValue("Drift status", result.DriftStatus)
+	output.Blank()
+
+	if len(result.Resources) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(result.Resources))
+	for _, resource := range result.Resources {
+		rows = append(rows, []string{
+			resource.LogicalID,
+			resource.ResourceType,
+			resource.Status,
+			formatPropertyDrifts(resource.Differences),
+		})
+	}
+	output.Table([]string{"Resource", "Type", "Drift", "Differences"}, rows)
+	output.Blank()
+}
+
+func formatPropertyDrifts(differences []infra.PropertyDrift) string {
+	parts := make([]string, 0, len(differences))
+	for _, diff := range differences {
+		switch diff.Type {
+		case "ADD":
+			parts = append(parts, fmt.Sprintf("%s: added %s", diff.Path, diff.Actual))
+		case "REMOVE":
+			parts = append(parts, fmt.Sprintf("%s: removed %s", diff.Path, diff.Expected))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s -> %s", diff.Path, diff.Expected, diff.Actual))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// fixDrift applies the expected version when the deployed one differs, then runs the backend
+// health reconciliation, which restores the resources managed by the backend itself.
+// Drift of stack resources with an unchanged template cannot be reverted by re-applying it,
+// so the remaining drift is reported for manual reconciliation.
+func fixDrift(cmd *cobra.Command, deployer infra.Deployer, result *infra.DriftResult) {
+	ctx := cmd.Context()
+
+	if !result.VersionMatches() {
+		spinner := output.NewSpinner(fmt.Sprintf("Applying version %s...", result.ExpectedVersion))
+		spinner.Start()
+		deployResult, err := deployer.Deploy(ctx, &infra.DeployOptions{
+			StackName: infraStatusStackName,
+			Version:   result.ExpectedVersion,
+			Wait:      true,
+			Region:    infraStatusRegion,
+		})
+		if err != nil {
+			spinner.Error("Failed to apply stack")
+			output.Fatalf(err.Error())
+		}
+		spinner.Success("Stack operation completed with status: " + deployResult.Status)
+	}
+
+	if err := reconcileBackendHealth(cmd); err != nil {
+		output.Warningf("Failed to reconcile backend health: %v", err)
+	}
+
+	remaining := detectDrift(ctx, deployer, result.ExpectedVersion)
+	if !remaining.HasDrift() {
+		output.Successf("Drift reconciled")
+		return
+	}
+	printDriftResult(remaining, deployer.GetRegion())
+	output.Warningf("%d resources still differ from the stack template, update them to match it "+
+		"or recreate them before applying the stack again", len(remaining.Resources))
+}
+
+func reconcileBackendHealth(cmd *cobra.Command) error {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		return err
+	}
+	if cfg.APIEndpoint == "" || cfg.APIKey == "" {
+		return fmt.Errorf("CLI is not configured, run %s configure first", constants.ProjectName)
+	}
+
+	output.Infof("Reconciling backend health...")
+	resp, err := client.New(cfg, slog.Default()).ReconcileHealth(cmd.Context(), false)
+	if err != nil {
+		return err
+	}
+	if resp != nil && resp.Report != nil {
+		output.KeyValue("Reconciled", strconv.Itoa(resp.Report.ReconciledCount))
+		output.KeyValue("Errors", strconv.Itoa(resp.Report.ErrorCount))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/runvoy/runvoy/internal/client/infra"
+)
+
+func TestFormatPropertyDrifts(t *testing.T) {
+	differences := []infra.PropertyDrift{
+		{Path: "/TimeToLiveSpecification/Enabled", Type: "NOT_EQUAL", Expected: "true", Actual: "false"},
+		{Path: "/Environment/Variables/DEBUG", Type: "ADD", Actual: "1"},
+		{Path: "/Tags/0", Type: "REMOVE", Expected: `{"Key":"Application"}`},
+	}
+
+	assert.Equal(t,
+		`/TimeToLiveSpecification/Enabled: true -> false; /Environment/Variables/DEBUG: added 1; `+
+			`/Tags/0: removed {"Key":"Application"}`,
+		formatPropertyDrifts(differences))
+	assert.Empty(t, formatPropertyDrifts(nil))
+}
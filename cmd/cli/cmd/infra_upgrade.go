@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/infra"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database/migrations"
+	"github.com/runvoy/runvoy/internal/semver"
+
+	"github.com/spf13/cobra"
+)
+
+func infraUpgradeRun(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	target := infraUpgradeVersion
+	if target == "" {
+		target = *constants.GetVersion()
+	}
+	if _, err := semver.Parse(target); err != nil {
+		output.Fatalf("CLI version %s cannot be used as upgrade target, specify one with --version", target)
+	}
+
+	deployed, err := fetchDeployedVersion(cmd)
+	if err != nil {
+		output.Fatalf("failed to detect deployed backend version: %v", err)
+	}
+
+	plan, err := infra.PlanUpgrade(deployed, target, infraUpgradeForce)
+	if err != nil {
+		output.Fatalf("%v, use --force to apply it anyway", err)
+	}
+
+	deployer, err := infra.NewDeployer(ctx, infraUpgradeProvider, infraUpgradeRegion)
+	if err != nil {
+		output.Fatalf("failed to initialize deployer: %v", err)
+	}
+
+	printUpgradePlan(plan, deployer.GetRegion())
+	if plan.UpToDate() && !infraUpgradeForce {
+		output.Successf("Backend is already running version %s", plan.TargetVersion)
+		return
+	}
+	if infraUpgradeDryRun {
+		previewUpgradeMigrations(cmd, deployer)
+		output.Infof("Dry run, no changes applied")
+		return
+	}
+
+	spinner := output.NewSpinner(fmt.Sprintf("Applying version %s...", target))
+	spinner.Start()
+	result, err := deployer.Deploy(ctx, &infra.DeployOptions{
+		StackName: infraUpgradeStackName,
+		Version:   target,
+		Wait:      true,
+		Region:    infraUpgradeRegion,
+	})
+	if err != nil {
+		spinner.Error("Failed to apply stack")
+		output.Fatalf(err.Error())
+	}
+	spinner.Success("Stack operation completed with status: " + result.Status)
+
+	// Migrations run once the stack is updated, since it provides the migrations ledger
+	runner, err := stackMigrationRunner(ctx, deployer, infraUpgradeProvider, infraUpgradeStackName)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	if err = applyMigrations(ctx, runner, migrations.UpOptions{}); err != nil {
+		output.Fatalf(err.Error())
+	}
+
+	verifyUpgrade(cmd, plan.TargetVersion)
+}
+
+// fetchDeployedVersion returns the version reported by the backend health endpoint.
+func fetchDeployedVersion(cmd *cobra.Command) (string, error) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		return "", err
+	}
+	if cfg.APIEndpoint == "" {
+		return "", fmt.Errorf("CLI is not configured, run %s configure first", constants.ProjectName)
+	}
+
+	health, err := client.New(cfg, slog.Default()).GetHealth(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	if health.Version == "" {
+		return "", fmt.Errorf("backend did not report its version")
+	}
+	return health.Version, nil
+}
+
+func printUpgradePlan(plan *infra.UpgradePlan, region string) {
+	output.Infof("Backend upgrade plan")
+	output.KeyValue("Provider", infraUpgradeProvider)
+	output.KeyValue("Stack name", infraUpgradeStackName)
+	output.KeyValue("Region", region)
+	output.KeyValue("Deployed version", plan.CurrentVersion.String())
+	output.KeyValue("Target version", plan.TargetVersion.String())
+	output.Blank()
+
+	if plan.MajorUpgrade() {
+		output.Warningf("Upgrading to a new major version, review the release notes for breaking changes")
+	}
+}
+
+// previewUpgradeMigrations lists the migrations pending on the deployed backend.
+// Backends deployed before the migrations ledger existed cannot be inspected until the upgrade is applied.
+func previewUpgradeMigrations(cmd *cobra.Command, deployer infra.Deployer) {
+	runner, err := stackMigrationRunner(cmd.Context(), deployer, infraUpgradeProvider, infraUpgradeStackName)
+	if err != nil {
+		output.Warningf("Cannot list pending migrations: %v", err)
+		return
+	}
+	if err = applyMigrations(cmd.Context(), runner, migrations.UpOptions{DryRun: true}); err != nil {
+		output.Warningf("Cannot list pending migrations: %v", err)
+	}
+}
+
+// verifyUpgrade checks that the backend reports the target version after the stack update.
+func verifyUpgrade(cmd *cobra.Command, target semver.Version) {
+	deployed, err := fetchDeployedVersion(cmd)
+	if err != nil {
+		output.Warningf("Failed to verify the upgrade: %v", err)
+		return
+	}
+	version, err := semver.Parse(deployed)
+	if err != nil || version.Compare(target) != 0 {
+		output.Warningf("Backend reports version %s instead of %s", deployed, target)
+		return
+	}
+	output.Successf("Backend upgraded to version %s", target)
+}
@@ -2,29 +2,73 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
 	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
 
 	"github.com/spf13/cobra"
 )
 
 var killCmd = &cobra.Command{
-	Use:   "kill <execution-id>",
+	Use:   "kill [execution-id]",
 	Short: "Kill a running command execution",
-	Long:  `Kill a running command execution`,
-	Run:   killRun,
-	Args:  cobra.ExactArgs(1),
+	Long: `Kill a running command execution.
+
+With --all, kill every execution matching the filters instead. The matching executions are listed
+and must be confirmed before anything is killed (use --yes to skip the prompt).`,
+	Example: fmt.Sprintf(`  # Kill a single execution
+  - %s kill 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10
+
+  # Kill all your executions running for more than 2 hours
+  - %s kill --all --user me --older-than 2h
+
+  # Kill all STARTING executions without prompting
+  - %s kill --all --status STARTING --yes`,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName),
+	Run:               killRun,
+	Args:              validateKillArgs,
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
 }
 
+var (
+	killAllFlag       bool
+	killStatusFlag    string
+	killUserFlag      string
+	killOlderThanFlag time.Duration
+	killYesFlag       bool
+)
+
 func init() {
 	rootCmd.AddCommand(killCmd)
+	killCmd.Flags().BoolVar(&killAllFlag, "all", false, "kill all executions matching the filters")
+	killCmd.Flags().StringVar(&killStatusFlag, "status", "",
+		"with --all, comma-separated list of statuses to kill (default: STARTING,RUNNING)")
+	killCmd.Flags().StringVar(&killUserFlag, "user", "",
+		"with --all, only kill executions created by this user email (\"me\" for yourself)")
+	killCmd.Flags().DurationVar(&killOlderThanFlag, "older-than", 0,
+		"with --all, only kill executions started at least this long ago (e.g. 2h)")
+	killCmd.Flags().BoolVarP(&killYesFlag, "yes", "y", false, "with --all, skip the confirmation prompt")
+}
+
+func validateKillArgs(cmd *cobra.Command, args []string) error {
+	if killAllFlag {
+		return cobra.NoArgs(cmd, args)
+	}
+	if cmd.Flags().Changed("status") || cmd.Flags().Changed("user") ||
+		cmd.Flags().Changed("older-than") || cmd.Flags().Changed("yes") {
+		return errors.New("--status, --user, --older-than and --yes require --all")
+	}
+	return cobra.ExactArgs(1)(cmd, args)
 }
 
 func killRun(cmd *cobra.Command, args []string) {
-	executionID := args[0]
 	cfg, err := getConfigFromContext(cmd)
 	if err != nil {
 		output.Errorf("failed to load configuration: %v", err)
@@ -33,7 +77,19 @@ func killRun(cmd *cobra.Command, args []string) {
 
 	c := client.New(cfg, slog.Default())
 	service := NewKillService(c, NewOutputWrapper())
-	if err = service.KillExecution(cmd.Context(), executionID); err != nil {
+	if killAllFlag {
+		filter := api.KillExecutionsFilter{
+			CreatedBy: killUserFlag,
+			OlderThan: killOlderThanFlag,
+		}
+		if killStatusFlag != "" {
+			filter.Statuses = strings.Split(strings.ToUpper(killStatusFlag), ",")
+		}
+		err = service.KillExecutions(cmd.Context(), filter, killYesFlag)
+	} else {
+		err = service.KillExecution(cmd.Context(), args[0])
+	}
+	if err != nil {
 		output.Errorf(err.Error())
 	}
 }
@@ -70,3 +126,54 @@ func (s *KillService) KillExecution(ctx context.Context, executionID string) err
 	s.output.KeyValue("Message", resp.Message)
 	return nil
 }
+
+// KillExecutions kills all executions matching the filter after previewing them and asking for
+// confirmation, unless skipConfirmation is set.
+func (s *KillService) KillExecutions(
+	ctx context.Context,
+	filter api.KillExecutionsFilter,
+	skipConfirmation bool,
+) error {
+	preview, err := s.client.KillExecutions(ctx, filter, "")
+	if err != nil {
+		return fmt.Errorf("failed to list executions to kill: %w", err)
+	}
+
+	if len(preview.ExecutionIDs) == 0 {
+		s.output.Infof("No executions match the filters, no action taken")
+		return nil
+	}
+
+	s.output.Infof("%d execution(s) will be killed:", len(preview.ExecutionIDs))
+	for _, executionID := range preview.ExecutionIDs {
+		s.output.Infof("  %s", s.output.Bold(executionID))
+	}
+
+	if !skipConfirmation {
+		answer := strings.ToLower(s.output.Prompt("Type 'yes' to kill these executions"))
+		if answer != "yes" && answer != "y" {
+			s.output.Warningf("Aborted, no executions were killed")
+			return nil
+		}
+	}
+
+	resp, err := s.client.KillExecutions(ctx, filter, preview.ConfirmationToken)
+	if err != nil {
+		return fmt.Errorf("failed to kill executions: %w", err)
+	}
+
+	s.output.Blank()
+	if len(resp.Killed) > 0 {
+		s.output.Successf("Kill started for %d execution(s)", len(resp.Killed))
+	}
+	if len(resp.Skipped) > 0 {
+		s.output.Infof("%d execution(s) already terminated: %s", len(resp.Skipped), strings.Join(resp.Skipped, ", "))
+	}
+	for _, failure := range resp.Failed {
+		s.output.Errorf("failed to kill execution %s: %s", failure.ExecutionID, failure.Error)
+	}
+	if len(resp.Failed) > 0 {
+		return fmt.Errorf("failed to kill %d of %d execution(s)", len(resp.Failed), len(resp.ExecutionIDs))
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/runvoy/runvoy/internal/api"
 )
@@ -13,7 +15,10 @@ import (
 // mockClientInterfaceForKill extends mockClientInterface with KillExecution
 type mockClientInterfaceForKill struct {
 	*mockClientInterface
-	killExecutionFunc func(ctx context.Context, executionID string) (*api.KillExecutionResponse, error)
+	killExecutionFunc  func(ctx context.Context, executionID string) (*api.KillExecutionResponse, error)
+	killExecutionsFunc func(
+		ctx context.Context, filter api.KillExecutionsFilter, confirmationToken string,
+	) (*api.KillExecutionsResponse, error)
 }
 
 func (m *mockClientInterfaceForKill) KillExecution(
@@ -25,6 +30,15 @@ func (m *mockClientInterfaceForKill) KillExecution(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClientInterfaceForKill) KillExecutions(
+	ctx context.Context, filter api.KillExecutionsFilter, confirmationToken string,
+) (*api.KillExecutionsResponse, error) {
+	if m.killExecutionsFunc != nil {
+		return m.killExecutionsFunc(ctx, filter, confirmationToken)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockClientInterfaceForKill) FetchBackendLogs(_ context.Context, _ string) (*api.TraceResponse, error) {
 	return nil, nil
 }
@@ -184,3 +198,101 @@ func TestKillService_KillExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestKillService_KillExecutions(t *testing.T) {
+	filter := api.KillExecutionsFilter{CreatedBy: "me", OlderThan: 2 * time.Hour}
+
+	newMockClient := func(tokens *[]string, confirmed *api.KillExecutionsResponse) *mockClientInterfaceForKill {
+		return &mockClientInterfaceForKill{
+			mockClientInterface: &mockClientInterface{},
+			killExecutionsFunc: func(
+				_ context.Context, f api.KillExecutionsFilter, confirmationToken string,
+			) (*api.KillExecutionsResponse, error) {
+				assert.Equal(t, filter, f)
+				*tokens = append(*tokens, confirmationToken)
+				if confirmationToken == "" {
+					return &api.KillExecutionsResponse{
+						ExecutionIDs:      []string{"exec-1", "exec-2"},
+						ConfirmationToken: "token-123",
+						DryRun:            true,
+					}, nil
+				}
+				return confirmed, nil
+			},
+		}
+	}
+
+	t.Run("kills after confirmation", func(t *testing.T) {
+		var tokens []string
+		mockClient := newMockClient(&tokens, &api.KillExecutionsResponse{
+			ExecutionIDs: []string{"exec-1", "exec-2"},
+			Killed:       []string{"exec-1"},
+			Skipped:      []string{"exec-2"},
+		})
+		mockOutput := &mockOutputInterfaceWithPrompt{
+			mockOutputInterface: &mockOutputInterface{},
+			promptFunc:          func(_ string) string { return "yes" },
+		}
+
+		err := NewKillService(mockClient, mockOutput).KillExecutions(context.Background(), filter, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "token-123"}, tokens)
+		var hasPrompt, hasSuccess bool
+		for _, call := range mockOutput.calls {
+			hasPrompt = hasPrompt || call.method == "Prompt"
+			hasSuccess = hasSuccess || call.method == "Successf"
+		}
+		assert.True(t, hasPrompt, "Expected confirmation prompt")
+		assert.True(t, hasSuccess, "Expected Successf call")
+	})
+
+	t.Run("aborts when not confirmed", func(t *testing.T) {
+		var tokens []string
+		mockClient := newMockClient(&tokens, nil)
+		mockOutput := &mockOutputInterfaceWithPrompt{
+			mockOutputInterface: &mockOutputInterface{},
+			promptFunc:          func(_ string) string { return "n" },
+		}
+
+		err := NewKillService(mockClient, mockOutput).KillExecutions(context.Background(), filter, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, tokens)
+	})
+
+	t.Run("skips prompt with yes and reports failures", func(t *testing.T) {
+		var tokens []string
+		mockClient := newMockClient(&tokens, &api.KillExecutionsResponse{
+			ExecutionIDs: []string{"exec-1", "exec-2"},
+			Killed:       []string{"exec-1"},
+			Failed:       []api.KillExecutionFailure{{ExecutionID: "exec-2", Error: "task not found"}},
+		})
+		mockOutput := &mockOutputInterface{}
+
+		err := NewKillService(mockClient, mockOutput).KillExecutions(context.Background(), filter, true)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to kill 1 of 2")
+		assert.Equal(t, []string{"", "token-123"}, tokens)
+		for _, call := range mockOutput.calls {
+			assert.NotEqual(t, "Prompt", call.method)
+		}
+	})
+
+	t.Run("does nothing when no execution matches", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForKill{
+			mockClientInterface: &mockClientInterface{},
+			killExecutionsFunc: func(
+				_ context.Context, _ api.KillExecutionsFilter, confirmationToken string,
+			) (*api.KillExecutionsResponse, error) {
+				assert.Empty(t, confirmationToken)
+				return &api.KillExecutionsResponse{DryRun: true}, nil
+			},
+		}
+
+		err := NewKillService(mockClient, &mockOutputInterface{}).KillExecutions(context.Background(), filter, false)
+
+		require.NoError(t, err)
+	})
+}
@@ -22,7 +22,9 @@ var executionsCmd = &cobra.Command{
 	Short: "List command executions",
 	Long: fmt.Sprintf(
 		`List command executions present in the runvoy backend with optional filtering.
-Show last %d executions and all statuses by default. Use --limit and --status flags to customize the output.`,
+Show last %d executions and all statuses by default. Use --limit and --status flags to customize the output,
+--user, --since, --starred and --search to narrow it down, or --filter to apply a filter saved with the filters command.
+Starred executions are marked with a star.`,
 		constants.DefaultExecutionListLimit,
 	),
 	Example: fmt.Sprintf(`  # Show last %d executions
@@ -32,15 +34,33 @@ Show last %d executions and all statuses by default. Use --limit and --status fl
   - %s list --limit 100
 
   # Show last 20 executions and filter by RUNNING and SUCCEEDED statuses
-  - %s list --limit 20 --status RUNNING,SUCCEEDED`,
+  - %s list --limit 20 --status RUNNING,SUCCEEDED
+
+  # Show your failed executions of the last week
+  - %s list --status FAILED --user me --since 168h
+
+  # Show the executions running pytest
+  - %s list --search pytest
+
+  # Show the executions matching the filter saved as "failures"
+  - %s list --filter saved:failures`,
 		constants.DefaultExecutionListLimit,
-		constants.ProjectName, constants.ProjectName, constants.ProjectName),
+		constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName, constants.ProjectName),
 	Run: executionsRun,
 }
 
+// savedFilterPrefix prefixes the name of a saved filter in the value of the list --filter flag.
+const savedFilterPrefix = "saved:"
+
 var (
-	limitFlag  int
-	statusFlag string
+	limitFlag   int
+	statusFlag  string
+	userFlag    string
+	sinceFlag   time.Duration
+	starredFlag bool
+	searchFlag  string
+	filterFlag  string
 )
 
 func init() {
@@ -55,6 +75,16 @@ func init() {
 	)
 	executionsCmd.Flags().StringVar(&statusFlag, "status", "",
 		"comma-separated list of execution statuses to filter by (e.g., RUNNING,TERMINATING)")
+	executionsCmd.Flags().StringVar(&userFlag, "user", "",
+		"only list executions created by this user email (\"me\" for yourself)")
+	executionsCmd.Flags().DurationVar(&sinceFlag, "since", 0,
+		"only list executions started within this duration (e.g., 24h)")
+	executionsCmd.Flags().BoolVar(&starredFlag, "starred", false, "only list the executions you starred")
+	executionsCmd.Flags().StringVar(&searchFlag, "search", "",
+		"only list executions whose command, image or playbook contain all these words (case-insensitive)")
+	executionsCmd.Flags().StringVar(&filterFlag, "filter", "",
+		"apply a saved filter (saved:<name>), combined with the other flags")
+	_ = executionsCmd.RegisterFlagCompletionFunc("filter", completeFlag(fetchSavedFilterReferences))
 }
 
 func executionsRun(cmd *cobra.Command, _ []string) {
@@ -68,11 +98,42 @@ func executionsRun(cmd *cobra.Command, _ []string) {
 	service := NewListService(c, NewOutputWrapper())
 	// Convert status flag to uppercase to allow case-insensitive input
 	upperStatus := strings.ToUpper(statusFlag)
-	if err = service.ListExecutions(cmd.Context(), limitFlag, upperStatus); err != nil {
+	if userFlag == "" && sinceFlag == 0 && !starredFlag && searchFlag == "" && filterFlag == "" {
+		err = service.ListExecutions(cmd.Context(), limitFlag, upperStatus)
+	} else {
+		var filter *api.ExecutionListFilter
+		filter, err = parseListFilter(filterFlag)
+		if err == nil {
+			if upperStatus != "" {
+				filter.Statuses = strings.Split(upperStatus, ",")
+			}
+			filter.CreatedBy = userFlag
+			if sinceFlag > 0 {
+				filter.Since = sinceFlag.String()
+			}
+			filter.Starred = starredFlag
+			filter.Search = searchFlag
+			err = service.ListFilteredExecutions(cmd.Context(), limitFlag, filter)
+		}
+	}
+	if err != nil {
 		output.Errorf(err.Error())
 	}
 }
 
+// parseListFilter returns the execution list filter referenced by the value of the --filter flag,
+// "saved:<name>" selecting the filter saved under that name.
+func parseListFilter(value string) (*api.ExecutionListFilter, error) {
+	if value == "" {
+		return &api.ExecutionListFilter{}, nil
+	}
+	name, saved := strings.CutPrefix(value, savedFilterPrefix)
+	if !saved || name == "" {
+		return nil, fmt.Errorf("invalid filter %q, expected %s<name>", value, savedFilterPrefix)
+	}
+	return &api.ExecutionListFilter{Name: name}, nil
+}
+
 // ListService handles execution listing and formatting logic.
 type ListService struct {
 	client client.Interface
@@ -100,6 +161,29 @@ func (s *ListService) ListExecutions(ctx context.Context, limit int, statuses st
 		return fmt.Errorf("failed to list executions: %w", err)
 	}
 
+	s.displayExecutions(execs)
+	return nil
+}
+
+// ListFilteredExecutions lists the executions matching the filter and displays them in a table format.
+func (s *ListService) ListFilteredExecutions(ctx context.Context, limit int, filter *api.ExecutionListFilter) error {
+	if limit < 0 {
+		return fmt.Errorf("limit must be zero or a positive integer, got %d", limit)
+	}
+
+	s.output.Infof("Listing executions…")
+
+	execs, err := s.client.ListFilteredExecutions(ctx, limit, *filter)
+	if err != nil {
+		return fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	s.displayExecutions(execs)
+	return nil
+}
+
+// displayExecutions displays the listed executions in a table format.
+func (s *ListService) displayExecutions(execs []api.Execution) {
 	rows := s.formatExecutions(execs)
 
 	s.output.Blank()
@@ -117,7 +201,6 @@ func (s *ListService) ListExecutions(ctx context.Context, limit int, statuses st
 	)
 	s.output.Blank()
 	s.output.Successf("Executions listed successfully")
-	return nil
 }
 
 // formatExecutions formats execution data into table rows.
@@ -135,17 +218,15 @@ func (s *ListService) formatExecutions(execs []api.Execution) [][]string {
 			duration = fmt.Sprintf("%ds", e.DurationSeconds)
 		}
 
-		command := ""
-		if len(e.Command) > maxCommandLength {
-			command = e.Command[:maxCommandLength] + "..."
-		} else {
-			command = e.Command
+		executionID := s.output.Bold(e.ExecutionID)
+		if e.Starred {
+			executionID += " ★"
 		}
 
 		rows = append(rows, []string{
-			s.output.Bold(e.ExecutionID),
-			e.Status,
-			command,
+			executionID,
+			formatExecutionStatus(e.Status, e.FailureReason),
+			truncateCommand(e.Command),
 			e.CreatedBy,
 			started,
 			completed,
@@ -281,3 +281,48 @@ func TestListService_ListExecutions(t *testing.T) {
 		})
 	}
 }
+
+func TestListService_ListFilteredExecutions(t *testing.T) {
+	filter := &api.ExecutionListFilter{Name: "failures", Starred: true}
+	mockClient := &mockClientInterface{
+		listFilteredFunc: func(_ context.Context, limit int, f api.ExecutionListFilter) ([]api.Execution, error) {
+			assert.Equal(t, 10, limit)
+			assert.Equal(t, *filter, f)
+			return []api.Execution{
+				{ExecutionID: "exec-1", Status: "FAILED", Starred: true},
+				{ExecutionID: "exec-2", Status: "FAILED"},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewListService(mockClient, mockOutput)
+
+	err := service.ListFilteredExecutions(context.Background(), 10, filter)
+
+	assert.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, "exec-1 ★", rows[0][0])
+		assert.Equal(t, "exec-2", rows[1][0])
+	}
+}
+
+func TestParseListFilter(t *testing.T) {
+	filter, err := parseListFilter("")
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ExecutionListFilter{}, filter)
+
+	filter, err = parseListFilter("saved:failures")
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ExecutionListFilter{Name: "failures"}, filter)
+
+	for _, value := range []string{"failures", "saved:", "status=FAILED"} {
+		_, err = parseListFilter(value)
+		assert.Error(t, err, value)
+	}
+}
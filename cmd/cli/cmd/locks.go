@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var locksCmd = &cobra.Command{
+	Use:     "locks",
+	Aliases: []string{"lock"},
+	Short:   "Execution lock commands",
+	Long: fmt.Sprintf(`Manage the named locks held by the executions started with %s run --lock.
+
+A single execution holding a lock runs at a time: runs asking for a held lock fail fast, or wait for it
+with --lock-wait. The lock is released when its execution completes, and expires past the execution
+timeout, or after a day without one, should the completion of the execution never be processed.`,
+		constants.ProjectName),
+}
+
+var listLocksCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the execution locks currently held",
+	Example: fmt.Sprintf(`  - %s locks list`, constants.ProjectName),
+	Run:     runListLocks,
+}
+
+var releaseLockCmd = &cobra.Command{
+	Use:   "release <name>",
+	Short: "Release an execution lock",
+	Long: `Release an execution lock whatever holds it, letting another execution take it while the one holding
+it may still run. Operators and admins only.`,
+	Example: fmt.Sprintf(`  - %s locks release deploy-prod`, constants.ProjectName),
+	Run:     runReleaseLock,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	locksCmd.AddCommand(listLocksCmd)
+	locksCmd.AddCommand(releaseLockCmd)
+	rootCmd.AddCommand(locksCmd)
+}
+
+func runListLocks(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewLocksService(c, NewOutputWrapper()).ListLocks(ctx)
+	})
+}
+
+func runReleaseLock(cmd *cobra.Command, args []string) {
+	name := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewLocksService(c, NewOutputWrapper()).ReleaseLock(ctx, name)
+	})
+}
+
+// LocksService handles execution lock operations.
+type LocksService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewLocksService creates a new LocksService with the provided dependencies.
+func NewLocksService(apiClient client.Interface, outputter OutputInterface) *LocksService {
+	return &LocksService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// ListLocks lists the execution locks currently held.
+func (s *LocksService) ListLocks(ctx context.Context) error {
+	resp, err := s.client.ListLocks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list locks: %w", err)
+	}
+
+	rows := make([][]string, 0, len(resp.Locks))
+	for i := range resp.Locks {
+		lock := &resp.Locks[i]
+		executionID := lock.ExecutionID
+		if executionID == "" {
+			executionID = "(starting)"
+		}
+		rows = append(rows, []string{
+			s.output.Bold(lock.Name),
+			executionID,
+			lock.HeldBy,
+			lock.AcquiredAt.Format(time.DateTime),
+			lock.ExpiresAt.Format(time.DateTime),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Name", "Execution ID", "Held By", "Acquired At", "Expires At"}, rows)
+	s.output.Blank()
+	s.output.Successf("Listed %d locks", len(resp.Locks))
+	return nil
+}
+
+// ReleaseLock releases an execution lock.
+func (s *LocksService) ReleaseLock(ctx context.Context, name string) error {
+	if _, err := s.client.ReleaseLock(ctx, name); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	s.output.Successf("Lock %s released", name)
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestLocksService_ListLocks(t *testing.T) {
+	acquiredAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockClient := &mockClientInterface{
+		listLocksFunc: func(_ context.Context) (*api.ListLocksResponse, error) {
+			return &api.ListLocksResponse{Locks: []api.ExecutionLock{
+				{
+					Name: "deploy-prod", ExecutionID: "abc123", HeldBy: "alice@example.com",
+					AcquiredAt: acquiredAt, ExpiresAt: acquiredAt.Add(time.Hour),
+				},
+				{Name: "migrate", HeldBy: "bob@example.com", AcquiredAt: acquiredAt, ExpiresAt: acquiredAt},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewLocksService(mockClient, mockOutput).ListLocks(context.Background())
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"abc123", "alice@example.com", "2026-01-02 03:04:05", "2026-01-02 04:04:05"},
+		rows[0][1:])
+	assert.Equal(t, "(starting)", rows[1][1])
+}
+
+func TestLocksService_ReleaseLock(t *testing.T) {
+	t.Run("releases the lock", func(t *testing.T) {
+		var released string
+		mockClient := &mockClientInterface{
+			releaseLockFunc: func(_ context.Context, name string) (*api.ReleaseLockResponse, error) {
+				released = name
+				return &api.ReleaseLockResponse{Name: name}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+
+		require.NoError(t, NewLocksService(mockClient, mockOutput).ReleaseLock(context.Background(), "deploy-prod"))
+		assert.Equal(t, "deploy-prod", released)
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			releaseLockFunc: func(_ context.Context, _ string) (*api.ReleaseLockResponse, error) {
+				return nil, errors.New("lock missing is not held")
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+
+		err := NewLocksService(mockClient, mockOutput).ReleaseLock(context.Background(), "missing")
+
+		assert.ErrorContains(t, err, "failed to release lock")
+		assert.Empty(t, mockOutput.calls)
+	})
+}
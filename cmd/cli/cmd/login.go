@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with your identity provider",
+	Long: `Log in with the identity provider (SSO) users are provisioned from, and save the API key issued.
+The first login of a provisioned user issues their API key, each following login replaces it.
+Requires the API endpoint to be configured, see the configure command.
+
+In a GitHub Actions job granted the id-token: write permission, --github exchanges the OIDC token of the
+workflow for a short-lived run token instead, without storing an API key as a secret. The repository must be
+trusted by the deployment, see the --github-trust flag of infra apply.`,
+	Example: fmt.Sprintf(`  - %[1]s login
+  - %[1]s login --github --endpoint https://runvoy.example.com`, constants.ProjectName),
+	Run: runLogin,
+}
+
+var (
+	loginGitHub         bool
+	loginEndpoint       string
+	loginGitHubAudience string
+)
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	loginCmd.Flags().BoolVar(&loginGitHub, "github", false,
+		"Exchange the GitHub Actions OIDC token of the workflow for a run token")
+	loginCmd.Flags().StringVar(&loginEndpoint, "endpoint", "",
+		"API endpoint to save to the config, e.g. when logging in from a CI job without config")
+	loginCmd.Flags().StringVar(&loginGitHubAudience, "audience", constants.DefaultGitHubOIDCAudience,
+		"Audience of the GitHub Actions OIDC token, as configured in the deployment")
+}
+
+func runLogin(cmd *cobra.Command, _ []string) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil && loginEndpoint == "" {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	if loginEndpoint != "" {
+		cfg.APIEndpoint = loginEndpoint
+	}
+
+	c := client.New(cfg, slog.Default())
+	service := NewLoginService(c, NewOutputWrapper(), NewConfigSaver(), newOIDCDeviceFlow)
+	if loginGitHub {
+		idToken, tokenErr := oidc.RequestGitHubActionsToken(cmd.Context(),
+			&http.Client{Timeout: constants.IdentityProviderTimeout}, loginGitHubAudience)
+		if tokenErr != nil {
+			output.Errorf(tokenErr.Error())
+			return
+		}
+		err = service.LoginWithGitHub(cmd.Context(), cfg, idToken)
+	} else {
+		err = service.Login(cmd.Context(), cfg)
+	}
+	if err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// DeviceFlow obtains an ID token from the identity provider for the user of the CLI.
+type DeviceFlow interface {
+	Authorize(ctx context.Context) (*oidc.DeviceAuthorization, error)
+	Wait(ctx context.Context, authorization *oidc.DeviceAuthorization) (string, error)
+}
+
+func newOIDCDeviceFlow(issuer, clientID string) DeviceFlow {
+	return oidc.NewDeviceFlow(issuer, clientID, &http.Client{Timeout: constants.IdentityProviderTimeout})
+}
+
+// LoginService handles logging in with the identity provider.
+type LoginService struct {
+	client        client.Interface
+	output        OutputInterface
+	configSaver   ConfigSaver
+	newDeviceFlow func(issuer, clientID string) DeviceFlow
+}
+
+// NewLoginService creates a new LoginService with the provided dependencies.
+func NewLoginService(
+	apiClient client.Interface,
+	outputter OutputInterface,
+	configSaver ConfigSaver,
+	newDeviceFlow func(issuer, clientID string) DeviceFlow,
+) *LoginService {
+	return &LoginService{
+		client:        apiClient,
+		output:        outputter,
+		configSaver:   configSaver,
+		newDeviceFlow: newDeviceFlow,
+	}
+}
+
+// Login logs the user in with the device authorization flow of the identity provider, exchanges the ID token
+// for an API key and saves it to the config.
+func (s *LoginService) Login(ctx context.Context, cfg *config.Config) error {
+	loginConfig, err := s.client.GetLoginConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the login configuration: %w", err)
+	}
+
+	flow := s.newDeviceFlow(loginConfig.Issuer, loginConfig.ClientID)
+	authorization, err := flow.Authorize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start logging in: %w", err)
+	}
+	verificationURI := authorization.VerificationURI
+	if authorization.VerificationURIComplete != "" {
+		verificationURI = authorization.VerificationURIComplete
+	}
+	s.output.Infof("To log in, open %s and enter the code %s",
+		s.output.Bold(verificationURI), s.output.Bold(authorization.UserCode))
+	s.output.Infof("Waiting for the login to be approved...")
+
+	idToken, err := flow.Wait(ctx, authorization)
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	resp, err := s.client.Login(ctx, api.LoginRequest{IDToken: idToken})
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+
+	cfg.APIKey = resp.APIKey
+	if err = s.configSaver.Save(cfg); err != nil {
+		s.output.Errorf("failed to save API key to config: %v", err)
+		s.output.Warningf("API Key => %s", s.output.Bold(resp.APIKey))
+		return fmt.Errorf("failed to save API key to config: %w", err)
+	}
+
+	s.output.Successf("Logged in as %s (%s), API key saved to config", resp.UserEmail, resp.Role)
+	return nil
+}
+
+// LoginWithGitHub exchanges the OIDC token of a GitHub Actions workflow for a run token and saves it to the
+// config in place of the API key.
+func (s *LoginService) LoginWithGitHub(ctx context.Context, cfg *config.Config, idToken string) error {
+	if cfg.APIEndpoint == "" {
+		return errors.New("the API endpoint is not configured, pass --endpoint")
+	}
+	resp, err := s.client.ExchangeGitHubToken(ctx, api.GitHubTokenRequest{IDToken: idToken})
+	if err != nil {
+		return fmt.Errorf("failed to exchange the GitHub Actions OIDC token: %w", err)
+	}
+
+	cfg.APIKey = resp.Token
+	if err = s.configSaver.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save run token to config: %w", err)
+	}
+
+	s.output.Successf("Logged in as %s for %s, run token saved to config (expires at %s)",
+		resp.UserEmail, resp.Repository, resp.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
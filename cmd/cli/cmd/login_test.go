@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	"github.com/runvoy/runvoy/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClientInterfaceForLogin struct {
+	*mockClientInterface
+	loginConfig *api.LoginConfigResponse
+	loginFunc   func(ctx context.Context, req api.LoginRequest) (*api.LoginResponse, error)
+
+	exchangeGitHubTokenFunc func(ctx context.Context, req api.GitHubTokenRequest) (*api.GitHubTokenResponse, error)
+}
+
+func (m *mockClientInterfaceForLogin) GetLoginConfig(_ context.Context) (*api.LoginConfigResponse, error) {
+	if m.loginConfig == nil {
+		return nil, errors.New("[404] failed to get login configuration: not configured")
+	}
+	return m.loginConfig, nil
+}
+
+func (m *mockClientInterfaceForLogin) Login(ctx context.Context, req api.LoginRequest) (*api.LoginResponse, error) {
+	return m.loginFunc(ctx, req)
+}
+
+func (m *mockClientInterfaceForLogin) ExchangeGitHubToken(
+	ctx context.Context,
+	req api.GitHubTokenRequest,
+) (*api.GitHubTokenResponse, error) {
+	return m.exchangeGitHubTokenFunc(ctx, req)
+}
+
+type mockDeviceFlow struct {
+	idToken string
+	waitErr error
+}
+
+func (m *mockDeviceFlow) Authorize(_ context.Context) (*oidc.DeviceAuthorization, error) {
+	return &oidc.DeviceAuthorization{
+		DeviceCode:      "device-code",
+		UserCode:        "ABCD-EFGH",
+		VerificationURI: "https://idp.example.com/activate",
+		ExpiresIn:       600,
+	}, nil
+}
+
+func (m *mockDeviceFlow) Wait(_ context.Context, _ *oidc.DeviceAuthorization) (string, error) {
+	return m.idToken, m.waitErr
+}
+
+func TestLoginService_Login(t *testing.T) {
+	mockClient := &mockClientInterfaceForLogin{
+		mockClientInterface: &mockClientInterface{},
+		loginConfig:         &api.LoginConfigResponse{Issuer: "https://idp.example.com", ClientID: "runvoy"},
+		loginFunc: func(_ context.Context, req api.LoginRequest) (*api.LoginResponse, error) {
+			assert.Equal(t, "id-token", req.IDToken)
+			return &api.LoginResponse{APIKey: "new-api-key", UserEmail: "alice@example.com", Role: "developer"}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	saved := false
+	saver := &mockConfigSaver{saveFunc: func(cfg *config.Config) error {
+		saved = cfg.APIKey == "new-api-key"
+		return nil
+	}}
+	newFlow := func(issuer, clientID string) DeviceFlow {
+		assert.Equal(t, "https://idp.example.com", issuer)
+		assert.Equal(t, "runvoy", clientID)
+		return &mockDeviceFlow{idToken: "id-token"}
+	}
+
+	cfg := &config.Config{APIEndpoint: "https://api.example.com"}
+	err := NewLoginService(mockClient, mockOutput, saver, newFlow).Login(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.True(t, saved)
+	assert.Equal(t, "new-api-key", cfg.APIKey)
+	assert.Equal(t, "Successf", mockOutput.calls[len(mockOutput.calls)-1].method)
+}
+
+func TestLoginService_Login_Errors(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForLogin{mockClientInterface: &mockClientInterface{}}
+		service := NewLoginService(mockClient, &mockOutputInterface{}, &mockConfigSaver{}, nil)
+		err := service.Login(context.Background(), &config.Config{})
+		require.ErrorContains(t, err, "failed to get the login configuration")
+	})
+
+	t.Run("login denied", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForLogin{
+			mockClientInterface: &mockClientInterface{},
+			loginConfig:         &api.LoginConfigResponse{Issuer: "https://idp.example.com", ClientID: "runvoy"},
+		}
+		newFlow := func(_, _ string) DeviceFlow {
+			return &mockDeviceFlow{waitErr: errors.New("the device authorization failed: access_denied")}
+		}
+		cfg := &config.Config{APIKey: "old-api-key"}
+		err := NewLoginService(mockClient, &mockOutputInterface{}, &mockConfigSaver{}, newFlow).
+			Login(context.Background(), cfg)
+		require.ErrorContains(t, err, "access_denied")
+		assert.Equal(t, "old-api-key", cfg.APIKey)
+	})
+}
+
+func TestLoginService_LoginWithGitHub(t *testing.T) {
+	mockClient := &mockClientInterfaceForLogin{
+		mockClientInterface: &mockClientInterface{},
+		exchangeGitHubTokenFunc: func(_ context.Context, req api.GitHubTokenRequest) (*api.GitHubTokenResponse, error) {
+			assert.Equal(t, "github-token", req.IDToken)
+			return &api.GitHubTokenResponse{
+				Token:      "rvrt_token",
+				UserEmail:  "ci@example.com",
+				Repository: "acme/api",
+				ExpiresAt:  time.Now().Add(time.Hour),
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	var saved *config.Config
+	saver := &mockConfigSaver{saveFunc: func(cfg *config.Config) error {
+		saved = cfg
+		return nil
+	}}
+
+	cfg := &config.Config{APIEndpoint: "https://api.example.com"}
+	err := NewLoginService(mockClient, mockOutput, saver, nil).LoginWithGitHub(context.Background(), cfg, "github-token")
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "rvrt_token", saved.APIKey)
+	assert.Equal(t, "https://api.example.com", saved.APIEndpoint)
+	assert.Equal(t, "Successf", mockOutput.calls[len(mockOutput.calls)-1].method)
+}
+
+func TestLoginService_LoginWithGitHub_Errors(t *testing.T) {
+	t.Run("no endpoint", func(t *testing.T) {
+		service := NewLoginService(&mockClientInterfaceForLogin{}, &mockOutputInterface{}, &mockConfigSaver{}, nil)
+		err := service.LoginWithGitHub(context.Background(), &config.Config{}, "github-token")
+		require.ErrorContains(t, err, "--endpoint")
+	})
+
+	t.Run("repository not trusted", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForLogin{
+			mockClientInterface: &mockClientInterface{},
+			exchangeGitHubTokenFunc: func(_ context.Context, _ api.GitHubTokenRequest) (*api.GitHubTokenResponse, error) {
+				return nil, errors.New(`[403] repository "acme/web" is not trusted`)
+			},
+		}
+		cfg := &config.Config{APIEndpoint: "https://api.example.com"}
+		err := NewLoginService(mockClient, &mockOutputInterface{}, &mockConfigSaver{}, nil).
+			LoginWithGitHub(context.Background(), cfg, "github-token")
+		require.ErrorContains(t, err, "not trusted")
+		assert.Empty(t, cfg.APIKey)
+	})
+}
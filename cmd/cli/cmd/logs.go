@@ -10,6 +10,7 @@ import (
 	"os/signal"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -25,11 +26,12 @@ import (
 )
 
 var logsCmd = &cobra.Command{
-	Use:   "logs <execution-id>",
-	Short: "Get logs for an execution",
-	Long:  `Get logs for an execution`,
-	Run:   logsRun,
-	Args:  cobra.ExactArgs(1),
+	Use:               "logs <execution-id>",
+	Short:             "Get logs for an execution",
+	Long:              `Get logs for an execution`,
+	Run:               logsRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
 }
 
 func init() {
@@ -41,6 +43,15 @@ func isTerminalStatus(status string) bool {
 	return slices.Contains(constants.TerminalExecutionStatuses(), constants.ExecutionStatus(status))
 }
 
+// activeStatusesFilter returns the comma-separated list of non-terminal execution statuses.
+func activeStatusesFilter() string {
+	statuses := make([]string, 0, len(constants.ActiveExecutionStatuses()))
+	for _, status := range constants.ActiveExecutionStatuses() {
+		statuses = append(statuses, string(status))
+	}
+	return strings.Join(statuses, ",")
+}
+
 func logsRun(cmd *cobra.Command, args []string) {
 	executionID := args[0]
 	cfg, err := getConfigFromContext(cmd)
@@ -77,14 +88,46 @@ func NewLogsService(apiClient client.Interface, outputter OutputInterface) *Logs
 	return service
 }
 
-// readWebSocketMessages reads messages from WebSocket and sends log events to a channel.
+// streamMessage is a message received on the log stream: a log event, a status change or the
+// notification that the execution completed.
+type streamMessage struct {
+	logEvent   *api.LogEvent
+	status     *api.ExecutionStatusChange
+	disconnect bool
+}
+
+// parseStreamMessage decodes a WebSocket message by its type. Messages without type are log events
+// sent by servers predating the versioned envelope; messages of unknown types are returned empty.
+func parseStreamMessage(data []byte) (streamMessage, error) {
+	var msg api.WebSocketMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return streamMessage{}, fmt.Errorf("failed to decode websocket message: %w", err)
+	}
+
+	switch msg.Type {
+	case api.WebSocketMessageTypeDisconnect:
+		return streamMessage{disconnect: true}, nil
+	case api.WebSocketMessageTypeStatus:
+		return streamMessage{status: msg.Status}, nil
+	case api.WebSocketMessageTypeLog, "":
+		var logEvent api.LogEvent
+		if err := json.Unmarshal(data, &logEvent); err != nil {
+			return streamMessage{}, fmt.Errorf("failed to decode log event: %w", err)
+		}
+		return streamMessage{logEvent: &logEvent}, nil
+	default:
+		return streamMessage{}, nil
+	}
+}
+
+// readWebSocketMessages reads messages from WebSocket and sends log events and status changes to a channel.
 func (s *LogsService) readWebSocketMessages(
 	conn *websocket.Conn,
-	logChan chan<- api.LogEvent,
+	messageChan chan<- streamMessage,
 	done chan struct{},
 	closeOnce *sync.Once,
 ) {
-	defer close(logChan)
+	defer close(messageChan)
 	defer closeOnce.Do(func() { close(done) })
 	for {
 		select {
@@ -99,11 +142,11 @@ func (s *LogsService) readWebSocketMessages(
 				return
 			}
 
-			// Check for disconnect message
-			var msg struct {
-				Type string `json:"type,omitempty"`
+			msg, err := parseStreamMessage(messageBytes)
+			if err != nil {
+				continue
 			}
-			if err = json.Unmarshal(messageBytes, &msg); err == nil && msg.Type == string(api.WebSocketMessageTypeDisconnect) {
+			if msg.disconnect {
 				s.output.Infof("Execution completed. Closing connection...")
 				_ = conn.WriteMessage(
 					websocket.CloseMessage,
@@ -111,14 +154,12 @@ func (s *LogsService) readWebSocketMessages(
 				)
 				return
 			}
-
-			var logEvent api.LogEvent
-			if err = json.Unmarshal(messageBytes, &logEvent); err != nil {
+			if msg.logEvent == nil && msg.status == nil {
 				continue
 			}
 
 			select {
-			case logChan <- logEvent:
+			case messageChan <- msg:
 			case <-done:
 				return
 			}
@@ -158,19 +199,25 @@ func (s *LogsService) streamLogsViaWebSocket(
 
 	bufferSize := 10
 	done := make(chan struct{})
-	logChan := make(chan api.LogEvent, bufferSize) // buffered channel for better throughput
+	messageChan := make(chan streamMessage, bufferSize) // buffered channel for better throughput
 	var closeOnce sync.Once
 
 	// Goroutine 1: Read from websocket and send to channel
-	go s.readWebSocketMessages(conn, logChan, done, &closeOnce)
+	go s.readWebSocketMessages(conn, messageChan, done, &closeOnce)
 
-	// Goroutine 2: Read from channel and print logs
+	// Goroutine 2: Read from channel and print logs and status changes in the order received
 	// Backend sends incremental logs, so we just count from 1
+	printed := make(chan struct{})
 	go func() {
+		defer close(printed)
 		lineNumber := 0
-		for logEvent := range logChan {
+		for msg := range messageChan {
+			if msg.status != nil {
+				s.printStatusChange(msg.status)
+				continue
+			}
 			lineNumber++
-			s.printLogLine(lineNumber, logEvent)
+			s.printLogLine(lineNumber, *msg.logEvent)
 		}
 	}()
 
@@ -179,6 +226,8 @@ func (s *LogsService) streamLogsViaWebSocket(
 		s.output.Infof("Received interrupt signal, closing connection...")
 		closeOnce.Do(func() { close(done) })
 	case <-done:
+		// Print the logs received right before the connection closed.
+		<-printed
 		s.output.Infof("WebSocket connection closed")
 	}
 
@@ -196,6 +245,9 @@ func (s *LogsService) DisplayLogs(ctx context.Context, executionID, webURL strin
 	if isTerminalStatus(resp.Status) {
 		s.displayLogEvents(resp.Events)
 		s.output.Infof("Execution has completed with status: %s", resp.Status)
+		if resp.ResourceSummary != nil {
+			displayResourceSummary(s.output, resp.ResourceSummary)
+		}
 		return nil
 	}
 
@@ -250,6 +302,24 @@ func (s *LogsService) printLogLine(lineNumber int, log api.LogEvent) {
 	)
 }
 
+// printStatusChange prints a status change of the execution, with its exit code and failure once completed.
+func (s *LogsService) printStatusChange(change *api.ExecutionStatusChange) {
+	message := "Execution status: " + s.output.Bold(change.Status)
+	if change.ExitCode != nil {
+		message += fmt.Sprintf(" (exit code %d)", *change.ExitCode)
+	}
+	if change.FailureReason == "" && change.FailureMessage == "" {
+		s.output.Infof("%s", message)
+		return
+	}
+
+	failure := change.FailureReason
+	if change.FailureMessage != "" {
+		failure = strings.TrimPrefix(failure+": "+change.FailureMessage, ": ")
+	}
+	s.output.Warningf("%s: %s", message, failure)
+}
+
 // printWebviewerURL prints the web application URL.
 func (s *LogsService) printWebviewerURL(webURL, executionID string) {
 	urlStr := infra.BuildLogsURL(webURL, executionID)
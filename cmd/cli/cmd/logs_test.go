@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -78,6 +79,36 @@ func TestLogsService_DisplayLogs(t *testing.T) {
 				assert.True(t, hasTable, "Expected Table call to display logs")
 			},
 		},
+		{
+			name:        "displays the resource summary of completed executions",
+			executionID: "exec-789",
+			webURL:      "https://logs.example.com",
+			setupMock: func(m *mockClientInterfaceForLogs) {
+				m.getLogsFunc = func(_ context.Context, _ string) (*api.LogsResponse, error) {
+					return &api.LogsResponse{
+						ExecutionID: "exec-789",
+						Status:      string(constants.ExecutionSucceeded),
+						Events:      []api.LogEvent{},
+						ResourceSummary: &api.ResourceSummary{
+							CPUReserved:           1024,
+							MemoryReservedMiB:     2048,
+							BilledDurationSeconds: 90,
+						},
+					}, nil
+				}
+			},
+			wantErr: false,
+			verifyOutput: func(t *testing.T, m *mockOutputInterface) {
+				keyValues := map[string]any{}
+				for _, call := range m.calls {
+					if call.method == "KeyValue" {
+						keyValues[call.args[0].(string)] = call.args[1]
+					}
+				}
+				assert.Equal(t, "1m30s", keyValues["Billed Duration"])
+				assert.Contains(t, keyValues, "Resources")
+			},
+		},
 		{
 			name:        "displays empty logs",
 			executionID: "exec-456",
@@ -262,3 +293,97 @@ func TestIsTerminalStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStreamMessage(t *testing.T) {
+	t.Run("decodes log messages", func(t *testing.T) {
+		msg, err := parseStreamMessage([]byte(`{"version":1,"type":"log","event_id":"e1","timestamp":1,"message":"hi"}`))
+
+		require.NoError(t, err)
+		require.NotNil(t, msg.logEvent)
+		assert.Equal(t, api.LogEvent{EventID: "e1", Timestamp: 1, Message: "hi"}, *msg.logEvent)
+	})
+
+	t.Run("decodes log events without envelope", func(t *testing.T) {
+		msg, err := parseStreamMessage([]byte(`{"event_id":"e1","timestamp":1,"message":"hi"}`))
+
+		require.NoError(t, err)
+		require.NotNil(t, msg.logEvent)
+		assert.Equal(t, "hi", msg.logEvent.Message)
+	})
+
+	t.Run("decodes status messages", func(t *testing.T) {
+		msg, err := parseStreamMessage([]byte(
+			`{"version":1,"type":"status","status":{"execution_id":"exec-1","status":"FAILED","exit_code":2}}`))
+
+		require.NoError(t, err)
+		assert.Nil(t, msg.logEvent)
+		require.NotNil(t, msg.status)
+		assert.Equal(t, "FAILED", msg.status.Status)
+		require.NotNil(t, msg.status.ExitCode)
+		assert.Equal(t, 2, *msg.status.ExitCode)
+	})
+
+	t.Run("decodes disconnect messages", func(t *testing.T) {
+		msg, err := parseStreamMessage([]byte(`{"version":1,"type":"disconnect","reason":"execution_completed"}`))
+
+		require.NoError(t, err)
+		assert.True(t, msg.disconnect)
+	})
+
+	t.Run("ignores unknown message types", func(t *testing.T) {
+		msg, err := parseStreamMessage([]byte(`{"version":2,"type":"progress","percent":50}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, streamMessage{}, msg)
+	})
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		_, err := parseStreamMessage([]byte(`not json`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLogsService_PrintStatusChange(t *testing.T) {
+	exitCode := 137
+	tests := []struct {
+		name       string
+		change     api.ExecutionStatusChange
+		wantMethod string
+		want       string
+	}{
+		{
+			name:       "running",
+			change:     api.ExecutionStatusChange{Status: "RUNNING"},
+			wantMethod: "Infof",
+			want:       "Execution status: RUNNING",
+		},
+		{
+			name: "failed with a failure",
+			change: api.ExecutionStatusChange{
+				Status: "FAILED", ExitCode: &exitCode, FailureReason: "oom_killed", FailureMessage: "out of memory",
+			},
+			wantMethod: "Warningf",
+			want:       "Execution status: FAILED (exit code 137): oom_killed: out of memory",
+		},
+		{
+			name:       "failed without a reason",
+			change:     api.ExecutionStatusChange{Status: "FAILED", ExitCode: &exitCode, FailureMessage: "killed"},
+			wantMethod: "Warningf",
+			want:       "Execution status: FAILED (exit code 137): killed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOutput := &mockOutputInterface{}
+			service := NewLogsService(&mockClientInterface{}, mockOutput)
+
+			service.printStatusChange(&tt.change)
+
+			require.Len(t, mockOutput.calls, 1)
+			call := mockOutput.calls[0]
+			assert.Equal(t, tt.wantMethod, call.method)
+			assert.Equal(t, tt.want, fmt.Sprintf(call.args[0].(string), call.args[1].([]any)...))
+		})
+	}
+}
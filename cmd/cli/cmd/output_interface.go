@@ -14,6 +14,7 @@ type OutputInterface interface {
 	Cyan(text string) string
 	KeyValue(key, value string)
 	Prompt(prompt string) string
+	Println(a ...any)
 }
 
 // outputWrapper wraps the global output package functions to implement OutputInterface.
@@ -63,3 +64,7 @@ func (o *outputWrapper) KeyValue(key, value string) {
 func (o *outputWrapper) Prompt(prompt string) string {
 	return output.Prompt(prompt)
 }
+
+func (o *outputWrapper) Println(a ...any) {
+	output.Println(a...)
+}
@@ -6,12 +6,14 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
 	"github.com/runvoy/runvoy/internal/client/output"
 	"github.com/runvoy/runvoy/internal/client/playbooks"
 	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/validation"
 
 	"github.com/spf13/cobra"
 )
@@ -39,12 +41,16 @@ var playbookShowCmd = &cobra.Command{
 }
 
 var playbookRunCmd = &cobra.Command{
-	Use:     "run <name>",
-	Short:   "Execute a playbook",
-	Long:    "Execute a playbook with optional flag overrides",
-	Example: fmt.Sprintf(`  - %s playbook run terraform-plan`, constants.ProjectName),
-	Run:     playbookRunRun,
-	Args:    cobra.ExactArgs(1),
+	Use:   "run <name>",
+	Short: "Execute a playbook",
+	Long: `Execute a playbook with optional flag overrides.
+
+The environment variables the playbook declares in its env_schema are validated by the backend, which rejects
+the run when a required variable is missing or a value is not accepted. --help-env prints them without running.`,
+	Example: fmt.Sprintf(`  - %s playbook run terraform-plan
+  - %s playbook run deploy --help-env`, constants.ProjectName, constants.ProjectName),
+	Run:  playbookRunRun,
+	Args: cobra.ExactArgs(1),
 }
 
 func init() {
@@ -58,6 +64,7 @@ func init() {
 	playbookRunCmd.Flags().StringP("git-ref", "r", "", "Override git reference")
 	playbookRunCmd.Flags().StringP("git-path", "p", "", "Override git path")
 	playbookRunCmd.Flags().StringSlice("secret", []string{}, "Add additional secrets (merge with playbook secrets)")
+	playbookRunCmd.Flags().Bool("help-env", false, "Print the environment variables the playbook expects and exit")
 }
 
 func playbookListRun(cmd *cobra.Command, _ []string) {
@@ -79,6 +86,13 @@ func playbookShowRun(cmd *cobra.Command, args []string) {
 
 func playbookRunRun(cmd *cobra.Command, args []string) {
 	name := args[0]
+	if helpEnv, _ := cmd.Flags().GetBool("help-env"); helpEnv {
+		service := NewPlaybookService(playbooks.NewPlaybookLoader(), nil, NewOutputWrapper())
+		if err := service.ShowPlaybookEnv(cmd.Context(), name); err != nil {
+			output.Errorf(err.Error())
+		}
+		return
+	}
 	cfg, err := getConfigFromContext(cmd)
 	if err != nil {
 		output.Errorf("failed to load configuration: %v", err)
@@ -222,6 +236,12 @@ func (s *PlaybookService) ShowPlaybook(_ context.Context, name string) error {
 	if pb.GitPath != "" {
 		s.output.KeyValue("Git Path", pb.GitPath)
 	}
+	if pb.WorkDir != "" {
+		s.output.KeyValue("Working Directory", pb.WorkDir)
+	}
+	if pb.Shell != "" {
+		s.output.KeyValue("Shell", pb.Shell)
+	}
 	if len(pb.Secrets) > 0 {
 		s.output.KeyValue("Secrets", strings.Join(pb.Secrets, ", "))
 	}
@@ -232,12 +252,74 @@ func (s *PlaybookService) ShowPlaybook(_ context.Context, name string) error {
 		}
 		s.output.KeyValue("Environment Variables", strings.Join(envPairs, ", "))
 	}
+	if pb.MaxDuration > 0 {
+		s.output.KeyValue("Max Duration", pb.MaxDuration.String())
+	}
 	s.output.KeyValue("Commands", strings.Join(pb.Commands, " && "))
 	s.output.Blank()
 
 	return nil
 }
 
+// ShowPlaybookEnv displays the environment variables a playbook declares in its env schema.
+func (s *PlaybookService) ShowPlaybookEnv(_ context.Context, name string) error {
+	pb, err := s.loader.LoadPlaybook(name)
+	if err != nil {
+		return fmt.Errorf("failed to load playbook: %w", err)
+	}
+
+	if len(pb.EnvSchema) == 0 {
+		s.output.Warningf("Playbook %s declares no environment variables", name)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(pb.EnvSchema))
+	for i := range pb.EnvSchema {
+		spec := &pb.EnvSchema[i]
+		required := "no"
+		if spec.Required {
+			required = "yes"
+		}
+		if _, ok := pb.Env[spec.Name]; ok {
+			required += " (set by playbook)"
+		}
+		description := spec.Description
+		if description == "" {
+			description = "-"
+		}
+		rows = append(rows, []string{
+			s.output.Bold(spec.Name),
+			string(validation.EnvVarSpecType(spec)),
+			required,
+			formatEnvVarConstraint(spec),
+			description,
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Variable", "Type", "Required", "Accepted Values", "Description"}, rows)
+	s.output.Blank()
+	s.output.Infof("Set them with the RUNVOY_USER_ prefix, e.g. RUNVOY_USER_<NAME>=value, or with secrets")
+	return nil
+}
+
+// formatEnvVarConstraint describes the values a declared environment variable accepts.
+func formatEnvVarConstraint(spec *api.EnvVarSpec) string {
+	switch validation.EnvVarSpecType(spec) {
+	case constants.EnvVarTypeURL:
+		return "absolute URL"
+	case constants.EnvVarTypeInt:
+		if bounds := validation.IntRange(spec); bounds != "" {
+			return "integer " + bounds
+		}
+		return "integer"
+	case constants.EnvVarTypeEnum:
+		return strings.Join(spec.Values, ", ")
+	default:
+		return "any"
+	}
+}
+
 // RunPlaybook executes a playbook with optional overrides.
 func (s *PlaybookService) RunPlaybook(
 	ctx context.Context,
@@ -263,10 +345,16 @@ func (s *PlaybookService) RunPlaybook(
 		GitRepo: execReq.GitRepo,
 		GitRef:  execReq.GitRef,
 		GitPath: execReq.GitPath,
+		WorkDir: execReq.WorkDir,
+		Shell:   execReq.Shell,
 		Image:   execReq.Image,
 		Env:     execReq.Env,
 		Secrets: execReq.Secrets,
 		WebURL:  webURL,
+
+		Playbook:            name,
+		ExpectedMaxDuration: time.Duration(execReq.ExpectedMaxDuration) * time.Second,
+		EnvSchema:           execReq.EnvSchema,
 	}
 
 	if execErr := runService.ExecuteCommand(ctx, &req); execErr != nil {
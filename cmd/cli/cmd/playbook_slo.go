@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var playbookSLOCmd = &cobra.Command{
+	Use:   "slo [name]",
+	Short: "Report playbook duration SLO breaches",
+	Long: `Report the rate of executions of the playbooks running longer than their max_duration,
+over the period and per day for a single playbook. Executions still running within their
+max duration are not accounted for yet.`,
+	Example: fmt.Sprintf(`  - %s playbook slo
+  - %s playbook slo terraform-plan --since 168h`, constants.ProjectName, constants.ProjectName),
+	Run:  playbookSLORun,
+	Args: cobra.MaximumNArgs(1),
+}
+
+var playbookSLOSince time.Duration
+
+func init() {
+	playbookCmd.AddCommand(playbookSLOCmd)
+	playbookSLOCmd.Flags().DurationVar(&playbookSLOSince, "since", constants.DefaultSLOReportPeriod,
+		"period covered by the report, counted back from now")
+}
+
+func playbookSLORun(cmd *cobra.Command, args []string) {
+	playbook := ""
+	if len(args) > 0 {
+		playbook = args[0]
+	}
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewPlaybookSLOService(c, NewOutputWrapper()).ReportSLO(ctx, playbookSLOSince, playbook)
+	})
+}
+
+// PlaybookSLOService handles playbook duration SLO reports.
+type PlaybookSLOService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewPlaybookSLOService creates a new PlaybookSLOService with the provided dependencies.
+func NewPlaybookSLOService(apiClient client.Interface, outputter OutputInterface) *PlaybookSLOService {
+	return &PlaybookSLOService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// ReportSLO displays the duration SLO breach rates of the playbooks, per day when restricted to a playbook.
+func (s *PlaybookSLOService) ReportSLO(ctx context.Context, since time.Duration, playbook string) error {
+	report, err := s.client.GetExecutionSLOReport(ctx, since, playbook)
+	if err != nil {
+		return fmt.Errorf("failed to get SLO report: %w", err)
+	}
+
+	rows := make([][]string, 0, len(report.Playbooks))
+	for i := range report.Playbooks {
+		pb := &report.Playbooks[i]
+		rows = append(rows, []string{
+			s.output.Bold(pb.Playbook),
+			(time.Duration(pb.ExpectedMaxDurationSeconds) * time.Second).String(),
+			strconv.Itoa(pb.Executions),
+			strconv.Itoa(pb.Breaches),
+			formatBreachRate(pb.BreachRate),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Playbook", "Max Duration", "Executions", "Breaches", "Breach Rate"}, rows)
+	if playbook != "" && len(report.Playbooks) == 1 {
+		s.output.Blank()
+		s.output.Table([]string{"Date", "Executions", "Breaches", "Breach Rate"},
+			sloReportDayRows(report.Playbooks[0].Days))
+	}
+	s.output.Blank()
+	s.output.Successf("Reported %d playbooks since %s", len(report.Playbooks), report.Since.Format(time.RFC3339))
+	return nil
+}
+
+// sloReportDayRows returns the table rows of the days of a playbook SLO report.
+func sloReportDayRows(days []api.SLOReportPeriod) [][]string {
+	rows := make([][]string, 0, len(days))
+	for _, day := range days {
+		rows = append(rows, []string{
+			day.Date,
+			strconv.Itoa(day.Executions),
+			strconv.Itoa(day.Breaches),
+			formatBreachRate(day.BreachRate),
+		})
+	}
+	return rows
+}
+
+// formatBreachRate formats an SLO breach rate as a percentage.
+func formatBreachRate(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestPlaybookSLOService_ReportSLO(t *testing.T) {
+	report := &api.ExecutionSLOReport{
+		Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Playbooks: []api.PlaybookSLOReport{{
+			Playbook:                   "deploy",
+			ExpectedMaxDurationSeconds: 900,
+			Executions:                 4,
+			Breaches:                   1,
+			BreachRate:                 0.25,
+			Days: []api.SLOReportPeriod{
+				{Date: "2026-01-02", Executions: 4, Breaches: 1, BreachRate: 0.25},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		playbook   string
+		wantTables int
+	}{
+		{"all playbooks", "", 1},
+		{"single playbook with days", "deploy", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterface{
+				getSLOReportFunc: func(_ context.Context, since time.Duration, playbook string) (
+					*api.ExecutionSLOReport, error) {
+					assert.Equal(t, 168*time.Hour, since)
+					assert.Equal(t, tt.playbook, playbook)
+					return report, nil
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+			service := NewPlaybookSLOService(mockClient, mockOutput)
+
+			err := service.ReportSLO(context.Background(), 168*time.Hour, tt.playbook)
+
+			require.NoError(t, err)
+			var tables [][][]string
+			for _, call := range mockOutput.calls {
+				if call.method == "Table" {
+					tables = append(tables, call.args[1].([][]string))
+				}
+			}
+			require.Len(t, tables, tt.wantTables)
+			assert.Equal(t, [][]string{{"deploy", "15m0s", "4", "1", "25.0%"}}, tables[0])
+			if tt.wantTables == 2 {
+				assert.Equal(t, [][]string{{"2026-01-02", "4", "1", "25.0%"}}, tables[1])
+			}
+		})
+	}
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getSLOReportFunc: func(_ context.Context, _ time.Duration, _ string) (*api.ExecutionSLOReport, error) {
+				return nil, errors.New("invalid since parameter")
+			},
+		}
+		service := NewPlaybookSLOService(mockClient, &mockOutputInterface{})
+
+		err := service.ReportSLO(context.Background(), time.Hour, "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get SLO report")
+	})
+}
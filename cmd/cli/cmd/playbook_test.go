@@ -168,6 +168,66 @@ commands:
 	})
 }
 
+func TestPlaybookService_ShowPlaybookEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	playbookDir := filepath.Join(tmpDir, ".runvoy")
+	require.NoError(t, os.MkdirAll(playbookDir, 0o750))
+	yamlContent := `env:
+  REGION: eu-west-1
+env_schema:
+  - name: API_URL
+    type: url
+    required: true
+    description: API endpoint
+  - name: PORT
+    type: int
+    min: 1
+    max: 65535
+  - name: REGION
+    type: enum
+    values: [eu-west-1, us-east-1]
+    required: true
+commands:
+  - deploy
+`
+	require.NoError(t, os.WriteFile(filepath.Join(playbookDir, "deploy.yaml"), []byte(yamlContent), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(playbookDir, "plain.yaml"), []byte("commands: [echo]\n"), 0o600))
+
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(oldWd) }()
+	require.NoError(t, os.Chdir(tmpDir))
+
+	t.Run("prints the declared variables", func(t *testing.T) {
+		mockOutput := &mockOutputInterface{}
+		service := NewPlaybookService(playbooks.NewPlaybookLoader(), nil, mockOutput)
+
+		require.NoError(t, service.ShowPlaybookEnv(context.Background(), "deploy"))
+
+		var rows [][]string
+		for _, c := range mockOutput.calls {
+			if c.method == "Table" {
+				rows = c.args[1].([][]string)
+			}
+		}
+		assert.Equal(t, [][]string{
+			{"API_URL", "url", "yes", "absolute URL", "API endpoint"},
+			{"PORT", "int", "no", "integer between 1 and 65535", "-"},
+			{"REGION", "enum", "yes (set by playbook)", "eu-west-1, us-east-1", "-"},
+		}, rows)
+	})
+
+	t.Run("warns when the playbook declares no variables", func(t *testing.T) {
+		mockOutput := &mockOutputInterface{}
+		service := NewPlaybookService(playbooks.NewPlaybookLoader(), nil, mockOutput)
+
+		require.NoError(t, service.ShowPlaybookEnv(context.Background(), "plain"))
+
+		require.NotEmpty(t, mockOutput.calls)
+		assert.Equal(t, "Warningf", mockOutput.calls[0].method)
+	})
+}
+
 func TestPlaybookService_RunPlaybook(t *testing.T) {
 	t.Run("executes playbook successfully", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -178,6 +238,7 @@ func TestPlaybookService_RunPlaybook(t *testing.T) {
 		yamlContent := `description: Test playbook
 commands:
   - echo hello
+max_duration: 5m
 `
 		err = os.WriteFile(filepath.Join(playbookDir, "test.yaml"), []byte(yamlContent), 0o600)
 		require.NoError(t, err)
@@ -197,6 +258,8 @@ commands:
 		}
 		mockClient.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
 			assert.Equal(t, "echo hello", req.Command)
+			assert.Equal(t, "test", req.Playbook)
+			assert.Equal(t, 300, req.ExpectedMaxDuration)
 			return &api.ExecutionResponse{
 				ExecutionID:  "exec-123",
 				Status:       "STARTING",
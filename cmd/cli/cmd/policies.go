@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var policiesCmd = &cobra.Command{
+	Use:     "policies",
+	Aliases: []string{"policy"},
+	Short:   "Command policy commands",
+	Long: `Manage the command policy rules the backend evaluates before starting an execution. Admin only.
+
+A deny rule refuses the executions whose command it matches. Once a role has allow rules, the executions
+of its users must match one of them, command and image. Rules apply to all roles and images unless
+restricted with --roles and --images. Decisions are logged to the audit trail of the backend.`,
+}
+
+var addPolicyCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a command policy rule",
+	Example: fmt.Sprintf(`  # Refuse rm -rf / whoever runs it
+  - %s policies add deny-rm-root --effect deny --match regex --pattern 'rm\s+-rf\s+/(\s|$)'
+
+  # Only let viewers run terraform plan with the terraform image
+  - %s policies add viewer-terraform-plan --effect allow --match prefix --pattern "terraform plan" \
+      --roles viewer --images hashicorp/terraform:1.9`,
+		constants.ProjectName, constants.ProjectName),
+	Run:  runAddPolicy,
+	Args: cobra.ExactArgs(1),
+}
+
+var updatePolicyCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Replace a command policy rule",
+	Long:  `Replace the effect, pattern, roles, images and description of an existing rule with the flags given`,
+	Example: fmt.Sprintf(`  - %s policies update deny-rm-root --effect deny --match prefix --pattern "rm -rf /"`,
+		constants.ProjectName),
+	Run:  runUpdatePolicy,
+	Args: cobra.ExactArgs(1),
+}
+
+var listPoliciesCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List command policy rules",
+	Example: fmt.Sprintf(`  - %s policies list`, constants.ProjectName),
+	Run:     runListPolicies,
+}
+
+var removePolicyCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Remove a command policy rule",
+	Example: fmt.Sprintf(`  - %s policies remove deny-rm-root`, constants.ProjectName),
+	Run:     runRemovePolicy,
+	Args:    cobra.ExactArgs(1),
+}
+
+var (
+	policyEffect      string
+	policyMatch       string
+	policyPattern     string
+	policyRoles       []string
+	policyImages      []string
+	policyDescription string
+)
+
+func init() {
+	for _, c := range []*cobra.Command{addPolicyCmd, updatePolicyCmd} {
+		c.Flags().StringVar(&policyEffect, "effect", "", "allow or deny")
+		c.Flags().StringVar(&policyMatch, "match", string(constants.CommandPolicyMatchPrefix),
+			"how the pattern matches the command: prefix or regex")
+		c.Flags().StringVar(&policyPattern, "pattern", "", "command prefix or Go regular expression")
+		c.Flags().StringSliceVar(&policyRoles, "roles", nil, "roles the rule applies to (default all roles)")
+		c.Flags().StringSliceVar(&policyImages, "images", nil,
+			"image names or IDs the rule applies to (default all images)")
+		c.Flags().StringVar(&policyDescription, "description", "", "description of the rule")
+		_ = c.MarkFlagRequired("effect")
+		_ = c.MarkFlagRequired("pattern")
+		policiesCmd.AddCommand(c)
+	}
+	policiesCmd.AddCommand(listPoliciesCmd)
+	policiesCmd.AddCommand(removePolicyCmd)
+	rootCmd.AddCommand(policiesCmd)
+}
+
+func policyRuleRequestFromFlags() api.CommandPolicyRuleRequest {
+	return api.CommandPolicyRuleRequest{
+		Effect:      policyEffect,
+		MatchType:   policyMatch,
+		Pattern:     policyPattern,
+		Roles:       policyRoles,
+		Images:      policyImages,
+		Description: policyDescription,
+	}
+}
+
+func runAddPolicy(cmd *cobra.Command, args []string) {
+	req := policyRuleRequestFromFlags()
+	req.Name = args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewPoliciesService(c, NewOutputWrapper()).AddRule(ctx, req)
+	})
+}
+
+func runUpdatePolicy(cmd *cobra.Command, args []string) {
+	name := args[0]
+	req := policyRuleRequestFromFlags()
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewPoliciesService(c, NewOutputWrapper()).UpdateRule(ctx, name, req)
+	})
+}
+
+func runListPolicies(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewPoliciesService(c, NewOutputWrapper()).ListRules(ctx)
+	})
+}
+
+func runRemovePolicy(cmd *cobra.Command, args []string) {
+	name := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewPoliciesService(c, NewOutputWrapper()).RemoveRule(ctx, name)
+	})
+}
+
+// PoliciesService handles command policy operations.
+type PoliciesService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewPoliciesService creates a new PoliciesService with the provided dependencies.
+func NewPoliciesService(apiClient client.Interface, outputter OutputInterface) *PoliciesService {
+	return &PoliciesService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// AddRule creates a command policy rule.
+func (s *PoliciesService) AddRule(ctx context.Context, req api.CommandPolicyRuleRequest) error {
+	resp, err := s.client.CreateCommandPolicyRule(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to add command policy rule: %w", err)
+	}
+
+	s.output.Successf("Command policy rule added successfully")
+	s.showRule(&resp.Rule)
+	return nil
+}
+
+// UpdateRule replaces a command policy rule.
+func (s *PoliciesService) UpdateRule(ctx context.Context, name string, req api.CommandPolicyRuleRequest) error {
+	resp, err := s.client.UpdateCommandPolicyRule(ctx, name, req)
+	if err != nil {
+		return fmt.Errorf("failed to update command policy rule: %w", err)
+	}
+
+	s.output.Successf("Command policy rule updated successfully")
+	s.showRule(&resp.Rule)
+	return nil
+}
+
+// ListRules lists the command policy rules.
+func (s *PoliciesService) ListRules(ctx context.Context) error {
+	resp, err := s.client.ListCommandPolicyRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list command policy rules: %w", err)
+	}
+
+	rows := make([][]string, 0, len(resp.Rules))
+	for i := range resp.Rules {
+		rule := &resp.Rules[i]
+		rows = append(rows, []string{
+			s.output.Bold(rule.Name),
+			rule.Effect,
+			rule.MatchType,
+			rule.Pattern,
+			formatPolicyScope(rule.Roles),
+			formatPolicyScope(rule.Images),
+			rule.UpdatedAt.Format(time.DateTime),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Name", "Effect", "Match", "Pattern", "Roles", "Images", "Updated At"}, rows)
+	s.output.Blank()
+	s.output.Successf("Listed %d command policy rules", len(resp.Rules))
+	return nil
+}
+
+// RemoveRule deletes a command policy rule.
+func (s *PoliciesService) RemoveRule(ctx context.Context, name string) error {
+	resp, err := s.client.DeleteCommandPolicyRule(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to remove command policy rule: %w", err)
+	}
+
+	s.output.Successf("Command policy rule %s removed successfully", resp.Name)
+	return nil
+}
+
+func (s *PoliciesService) showRule(rule *api.CommandPolicyRule) {
+	s.output.KeyValue("Name", rule.Name)
+	s.output.KeyValue("Effect", rule.Effect)
+	s.output.KeyValue("Match", rule.MatchType)
+	s.output.KeyValue("Pattern", rule.Pattern)
+	s.output.KeyValue("Roles", formatPolicyScope(rule.Roles))
+	s.output.KeyValue("Images", formatPolicyScope(rule.Images))
+	if rule.Description != "" {
+		s.output.KeyValue("Description", rule.Description)
+	}
+}
+
+// formatPolicyScope describes the roles or images a rule applies to, all of them when empty.
+func formatPolicyScope(values []string) string {
+	if len(values) == 0 {
+		return "all"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestPoliciesService_AddRule(t *testing.T) {
+	req := api.CommandPolicyRuleRequest{
+		Name: "viewer-terraform-plan", Effect: "allow", MatchType: "prefix", Pattern: "terraform plan",
+		Roles: []string{"viewer"},
+	}
+	mockClient := &mockClientInterface{
+		createCommandPolicyRuleFunc: func(
+			_ context.Context, r api.CommandPolicyRuleRequest,
+		) (*api.CommandPolicyRuleResponse, error) {
+			assert.Equal(t, req, r)
+			return &api.CommandPolicyRuleResponse{Rule: api.CommandPolicyRule{
+				Name: r.Name, Effect: r.Effect, MatchType: r.MatchType, Pattern: r.Pattern, Roles: r.Roles,
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewPoliciesService(mockClient, mockOutput).AddRule(context.Background(), req)
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "viewer", keyValues["Roles"])
+	assert.Equal(t, "all", keyValues["Images"])
+}
+
+func TestPoliciesService_ListRules(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockClient := &mockClientInterface{
+		listCommandPolicyRulesFunc: func(_ context.Context) (*api.ListCommandPolicyRulesResponse, error) {
+			return &api.ListCommandPolicyRulesResponse{Rules: []api.CommandPolicyRule{{
+				Name: "deny-rm-root", Effect: "deny", MatchType: "prefix", Pattern: "rm -rf /",
+				Images: []string{"alpine:latest", "ubuntu:24.04"}, UpdatedAt: updatedAt,
+			}}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewPoliciesService(mockClient, mockOutput).ListRules(context.Background())
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 1)
+	assert.Equal(t, []string{"prefix", "rm -rf /", "all", "alpine:latest, ubuntu:24.04", "2026-01-02 03:04:05"},
+		rows[0][2:])
+}
+
+func TestPoliciesService_RemoveRule(t *testing.T) {
+	mockClient := &mockClientInterface{
+		deleteCommandPolicyRuleFunc: func(_ context.Context, _ string) (*api.DeleteCommandPolicyRuleResponse, error) {
+			return nil, errors.New("command policy rule not found")
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	err := NewPoliciesService(mockClient, mockOutput).RemoveRule(context.Background(), "missing")
+
+	assert.ErrorContains(t, err, "failed to remove command policy rule")
+	assert.Empty(t, mockOutput.calls)
+}
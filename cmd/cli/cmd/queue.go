@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+const queuedRunIDRandomLength = 8
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Manage the runs queued while the API was unreachable",
+	Long: fmt.Sprintf(`Manage the runs queued by "%s run --queue-offline" while the API was unreachable.
+
+Queued runs are kept in the %s directory of the configuration directory, one file per run, readable by
+you only. They hold the whole request, including the values of the RUNVOY_USER_ environment variables,
+the standard input and the context directory archive. Each run is submitted to the API endpoint it was
+queued for.`, constants.ProjectName, constants.OfflineQueueDirName),
+}
+
+var queueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the queued runs, oldest first",
+	Args:  cobra.NoArgs,
+	Run:   queueListRun,
+}
+
+var queueFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Submit the queued runs, oldest first",
+	Long: `Submit the queued runs of the configured API endpoint, oldest first, without following their logs.
+Submitted runs are removed from the queue. Runs the API rejects stay queued, to fix and run again or
+remove. Flushing stops at the first run the API is still unreachable for.`,
+	Example: fmt.Sprintf("  - %s queue flush", constants.ProjectName),
+	Args:    cobra.NoArgs,
+	Run:     queueFlushRun,
+}
+
+var queueRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a queued run without submitting it",
+	Args:  cobra.ExactArgs(1),
+	Run:   queueRemoveRun,
+}
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queueFlushCmd)
+	queueCmd.AddCommand(queueRemoveCmd)
+}
+
+func queueListRun(cmd *cobra.Command, _ []string) {
+	service, err := newQueueService(cmd)
+	if err == nil {
+		err = service.List()
+	}
+	if err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+func queueFlushRun(cmd *cobra.Command, _ []string) {
+	service, err := newQueueService(cmd)
+	if err == nil {
+		err = service.Flush(cmd.Context())
+	}
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+}
+
+func queueRemoveRun(cmd *cobra.Command, args []string) {
+	service, err := newQueueService(cmd)
+	if err == nil {
+		err = service.Remove(args[0])
+	}
+	if err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+func newQueueService(cmd *cobra.Command) (*QueueService, error) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	queue, err := NewOfflineQueue(cfg.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	outputter := NewOutputWrapper()
+	return NewQueueService(queue, NewRunService(client.New(cfg, slog.Default()), outputter), outputter), nil
+}
+
+// QueuedRun is a run request kept in the offline queue.
+type QueuedRun struct {
+	ID          string                `json:"id"`
+	QueuedAt    time.Time             `json:"queued_at"`
+	APIEndpoint string                `json:"api_endpoint"`
+	Request     ExecuteCommandRequest `json:"request"`
+}
+
+// OfflineQueue keeps the run requests made while the API was unreachable in a directory, one file per run.
+type OfflineQueue struct {
+	dir         string
+	apiEndpoint string
+	now         func() time.Time
+}
+
+// NewOfflineQueue returns the offline queue of the configuration directory, queuing the runs for the API
+// endpoint.
+func NewOfflineQueue(apiEndpoint string) (*OfflineQueue, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &OfflineQueue{
+		dir:         filepath.Join(configDir, constants.OfflineQueueDirName),
+		apiEndpoint: apiEndpoint,
+		now:         time.Now,
+	}, nil
+}
+
+// Add queues the run request. Its ID starts with the time it was queued at, so the IDs sort oldest first.
+func (q *OfflineQueue) Add(req *ExecuteCommandRequest) (*QueuedRun, error) {
+	now := q.now().UTC()
+	run := &QueuedRun{
+		ID:          now.Format("20060102-150405") + "-" + auth.GenerateUUID()[:queuedRunIDRandomLength],
+		QueuedAt:    now,
+		APIEndpoint: q.apiEndpoint,
+		Request:     *req,
+	}
+	run.Request.QueueOffline = false
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the queued run: %w", err)
+	}
+	if err = os.MkdirAll(q.dir, constants.ConfigDirPermissions); err != nil {
+		return nil, fmt.Errorf("failed to create the queue directory: %w", err)
+	}
+	if err = os.WriteFile(q.path(run.ID), data, constants.ConfigFilePermissions); err != nil {
+		return nil, fmt.Errorf("failed to write the queued run: %w", err)
+	}
+	return run, nil
+}
+
+// List returns the queued runs, oldest first.
+func (q *OfflineQueue) List() ([]*QueuedRun, error) {
+	entries, err := os.ReadDir(q.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the queue directory: %w", err)
+	}
+
+	runs := make([]*QueuedRun, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, readErr := os.ReadFile(filepath.Join(q.dir, entry.Name()))
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read the queued run: %w", readErr)
+		}
+		var run QueuedRun
+		if readErr = json.Unmarshal(data, &run); readErr != nil {
+			return nil, fmt.Errorf("failed to parse the queued run %s: %w", entry.Name(), readErr)
+		}
+		runs = append(runs, &run)
+	}
+	slices.SortFunc(runs, func(a, b *QueuedRun) int { return strings.Compare(a.ID, b.ID) })
+	return runs, nil
+}
+
+// Remove removes a queued run.
+func (q *OfflineQueue) Remove(id string) error {
+	if id == "" || filepath.Base(id) != id {
+		return fmt.Errorf("invalid queued run ID %q", id)
+	}
+	err := os.Remove(q.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("queued run %s not found", id)
+	}
+	return err
+}
+
+func (q *OfflineQueue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+// QueueService handles the runs of the offline queue.
+type QueueService struct {
+	queue  *OfflineQueue
+	run    *RunService
+	output OutputInterface
+}
+
+// NewQueueService creates a new QueueService submitting the queued runs with the RunService.
+func NewQueueService(queue *OfflineQueue, run *RunService, outputter OutputInterface) *QueueService {
+	return &QueueService{queue: queue, run: run, output: outputter}
+}
+
+// List lists the queued runs, oldest first.
+func (s *QueueService) List() error {
+	runs, err := s.queue.List()
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		s.output.Infof("No queued runs")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(runs))
+	for _, run := range runs {
+		rows = append(rows, []string{run.ID, run.QueuedAt.Local().Format(time.DateTime), run.Request.Command,
+			run.APIEndpoint})
+	}
+	s.output.Table([]string{"ID", "Queued At", "Command", "API Endpoint"}, rows)
+	return nil
+}
+
+// Flush submits the queued runs of the API endpoint of the queue, oldest first, and removes those started.
+// It stops at the first run the API is unreachable for.
+func (s *QueueService) Flush(ctx context.Context) error {
+	runs, err := s.queue.List()
+	if err != nil {
+		return err
+	}
+
+	submitted, failed, skipped := 0, 0, 0
+	for _, run := range runs {
+		if run.APIEndpoint != s.queue.apiEndpoint {
+			skipped++
+			continue
+		}
+
+		s.output.Blank()
+		s.output.Infof("Submitting queued run %s", s.output.Bold(run.ID))
+		req := run.Request
+		req.SubmitOnly = true
+		if err = s.run.ExecuteCommand(ctx, &req); err != nil {
+			if client.IsUnreachable(err) {
+				left, _ := s.queue.List()
+				return fmt.Errorf("API still unreachable, %d runs left queued: %w", len(left), err)
+			}
+			s.output.Errorf("Queued run %s failed, it stays queued: %v", run.ID, err)
+			failed++
+			continue
+		}
+		if err = s.queue.Remove(run.ID); err != nil {
+			return fmt.Errorf("run %s was submitted but could not be removed from the queue: %w", run.ID, err)
+		}
+		submitted++
+	}
+
+	s.output.Blank()
+	if skipped > 0 {
+		s.output.Warningf("%d queued runs are for another API endpoint and were left queued", skipped)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d queued runs failed, see them with %s queue list", failed, constants.ProjectName)
+	}
+	s.output.Successf("Submitted %d queued runs", submitted)
+	return nil
+}
+
+// Remove removes a queued run without submitting it.
+func (s *QueueService) Remove(id string) error {
+	if err := s.queue.Remove(id); err != nil {
+		return err
+	}
+	s.output.Successf("Queued run %s removed", id)
+	return nil
+}
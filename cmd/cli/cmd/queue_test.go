@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func newTestOfflineQueue(t *testing.T, apiEndpoint string) *OfflineQueue {
+	t.Helper()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &OfflineQueue{
+		dir:         filepath.Join(t.TempDir(), constants.OfflineQueueDirName),
+		apiEndpoint: apiEndpoint,
+		now: func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		},
+	}
+}
+
+func TestOfflineQueue(t *testing.T) {
+	queue := newTestOfflineQueue(t, "https://api.example.com")
+
+	runs, err := queue.List()
+	require.NoError(t, err)
+	assert.Empty(t, runs, "an empty queue has no directory yet")
+
+	first, err := queue.Add(&ExecuteCommandRequest{
+		Command: "make report", Env: map[string]string{"TOKEN": "secret"}, Stdin: []byte("input"), QueueOffline: true,
+	})
+	require.NoError(t, err)
+	second, err := queue.Add(&ExecuteCommandRequest{
+		Command: "make backup", Args: []string{"make", "backup"}, StopGracePeriod: 30 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^20260102-030406-[0-9a-f]{8}$`, first.ID)
+
+	info, err := os.Stat(queue.path(first.ID))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(constants.ConfigFilePermissions), info.Mode().Perm())
+
+	runs, err = queue.List()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, first.ID, runs[0].ID, "oldest first")
+	assert.Equal(t, "https://api.example.com", runs[0].APIEndpoint)
+	assert.Equal(t, "secret", runs[0].Request.Env["TOKEN"])
+	assert.Equal(t, []byte("input"), runs[0].Request.Stdin)
+	assert.False(t, runs[0].Request.QueueOffline)
+	assert.Equal(t, 30*time.Second, runs[1].Request.StopGracePeriod)
+	assert.Equal(t, []string{"make", "backup"}, runs[1].Request.Args)
+
+	require.NoError(t, queue.Remove(second.ID))
+	assert.ErrorContains(t, queue.Remove(second.ID), "not found")
+	assert.ErrorContains(t, queue.Remove("../config"), "invalid queued run ID")
+	runs, err = queue.List()
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
+
+func TestRunService_ExecuteCommandQueueOffline(t *testing.T) {
+	unreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name         string
+		err          error
+		queueOffline bool
+		wantQueued   bool
+	}{
+		{name: "queues when the API is unreachable", err: unreachable, queueOffline: true, wantQueued: true},
+		{name: "fails without queue-offline", err: unreachable},
+		{
+			name:         "fails when the API may have been reached",
+			err:          &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")},
+			queueOffline: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterfaceForRun{
+				mockClientInterface: &mockClientInterface{},
+				runCommandFunc: func(_ context.Context, _ *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					return nil, tt.err
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+			service := NewRunService(mockClient, mockOutput)
+			service.queue = newTestOfflineQueue(t, "https://api.example.com")
+
+			err := service.ExecuteCommand(context.Background(),
+				&ExecuteCommandRequest{Command: "make report", QueueOffline: tt.queueOffline})
+
+			runs, listErr := service.queue.List()
+			require.NoError(t, listErr)
+			if !tt.wantQueued {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, runs)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, runs, 1)
+			assert.Equal(t, "make report", runs[0].Request.Command)
+			assert.True(t, hasCall(mockOutput, "Successf"), "the queued run is reported")
+		})
+	}
+}
+
+func TestQueueService_Flush(t *testing.T) {
+	const apiEndpoint = "https://api.example.com"
+	queue := newTestOfflineQueue(t, apiEndpoint)
+	for _, command := range []string{"make ok", "make rejected", "make unreachable", "make later"} {
+		_, err := queue.Add(&ExecuteCommandRequest{Command: command})
+		require.NoError(t, err)
+	}
+	other := *queue
+	other.apiEndpoint = "https://other.example.com"
+	_, err := other.Add(&ExecuteCommandRequest{Command: "make elsewhere"})
+	require.NoError(t, err)
+
+	var submitted []string
+	mockClient := &mockClientInterfaceForRun{
+		mockClientInterface: &mockClientInterface{},
+		runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+			submitted = append(submitted, req.Command)
+			switch req.Command {
+			case "make rejected":
+				return nil, errors.New("invalid request")
+			case "make unreachable":
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			}
+			return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+		},
+		getLogsFunc: func(_ context.Context, _ string) (*api.LogsResponse, error) {
+			t.Fatal("flushed runs are not followed")
+			return nil, nil
+		},
+	}
+	service := NewQueueService(queue, NewRunService(mockClient, &mockOutputInterface{}), &mockOutputInterface{})
+
+	err = service.Flush(context.Background())
+	assert.ErrorContains(t, err, "API still unreachable, 4 runs left queued")
+	assert.Equal(t, []string{"make ok", "make rejected", "make unreachable"}, submitted)
+
+	runs, err := queue.List()
+	require.NoError(t, err)
+	commands := []string{}
+	for _, run := range runs {
+		commands = append(commands, run.Request.Command)
+	}
+	assert.Equal(t, []string{"make rejected", "make unreachable", "make later", "make elsewhere"}, commands)
+}
+
+func hasCall(m *mockOutputInterface, method string) bool {
+	for _, c := range m.calls {
+		if c.method == method {
+			return true
+		}
+	}
+	return false
+}
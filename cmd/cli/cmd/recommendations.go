@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var recommendationsCmd = &cobra.Command{
+	Use:   "recommendations",
+	Short: "Recommend lower CPU and memory for the images",
+	Long: fmt.Sprintf(`Recommend lower CPU and memory for the images whose executions use far less than they reserve.
+
+The recommendations are based on the 95th percentile of the average CPU and of the peak memory of the
+recent succeeded executions of each image, with a %gx headroom. An image needs at least %d executions
+with sampled usage to get a recommendation.
+
+To apply a recommendation, register the image again with the recommended --cpu and --memory.`,
+		constants.RecommendationHeadroom, constants.MinRecommendationExecutions),
+	Example: fmt.Sprintf(`  - %s recommendations`, constants.ProjectName),
+	Run:     recommendationsRun,
+}
+
+func init() {
+	rootCmd.AddCommand(recommendationsCmd)
+}
+
+func recommendationsRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewRecommendationsService(c, NewOutputWrapper())
+		return service.DisplayRecommendations(ctx)
+	})
+}
+
+// RecommendationsService handles displaying the resource right-sizing recommendations of the images.
+type RecommendationsService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewRecommendationsService creates a new RecommendationsService with the provided dependencies.
+func NewRecommendationsService(apiClient client.Interface, outputter OutputInterface) *RecommendationsService {
+	return &RecommendationsService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// DisplayRecommendations retrieves and displays the resource right-sizing recommendations of the images.
+func (s *RecommendationsService) DisplayRecommendations(ctx context.Context) error {
+	resp, err := s.client.GetResourceRecommendations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get recommendations: %w", err)
+	}
+
+	if len(resp.Recommendations) == 0 {
+		s.output.Successf("No recommendations, the images use the resources they reserve")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(resp.Recommendations))
+	for i := range resp.Recommendations {
+		r := &resp.Recommendations[i]
+		rows = append(rows, []string{
+			s.output.Bold(r.ImageID),
+			strconv.Itoa(r.Executions),
+			formatRecommendedResource(r.CPU, r.RecommendedCPU),
+			formatRecommendedResource(r.Memory, r.RecommendedMemory),
+			fmt.Sprintf("%.0f", r.P95CPUUtilized),
+			fmt.Sprintf("%.0f MiB", r.P95MemoryUtilizedMiB),
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Image ID", "Executions", "CPU", "Memory", "CPU (p95)", "Memory (p95)"}, rows)
+	s.output.Blank()
+	s.output.Infof("Register an image again with the recommended --cpu and --memory to apply its recommendation")
+	return nil
+}
+
+// formatRecommendedResource formats a current and recommended resource, e.g. "1024 -> 256".
+func formatRecommendedResource(current, recommended int) string {
+	if recommended == current {
+		return strconv.Itoa(current)
+	}
+	return fmt.Sprintf("%d -> %d", current, recommended)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestRecommendationsService_DisplayRecommendations(t *testing.T) {
+	t.Run("displays the recommendations", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getRecommendationsFunc: func(_ context.Context) (*api.ResourceRecommendationsResponse, error) {
+				return &api.ResourceRecommendationsResponse{Recommendations: []api.ResourceRecommendation{{
+					ImageID:              "alpine:latest",
+					Executions:           12,
+					CPU:                  1024,
+					Memory:               4096,
+					P95CPUUtilized:       120.4,
+					P95MemoryUtilizedMiB: 1800,
+					RecommendedCPU:       256,
+					RecommendedMemory:    4096,
+				}}}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewRecommendationsService(mockClient, mockOutput)
+
+		err := service.DisplayRecommendations(context.Background())
+
+		require.NoError(t, err)
+		var rows [][]string
+		for _, call := range mockOutput.calls {
+			if call.method == "Table" {
+				rows = call.args[1].([][]string)
+			}
+		}
+		require.Len(t, rows, 1)
+		assert.Equal(t, []string{"alpine:latest", "12", "1024 -> 256", "4096", "120", "1800 MiB"}, rows[0])
+	})
+
+	t.Run("reports images without recommendation", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getRecommendationsFunc: func(_ context.Context) (*api.ResourceRecommendationsResponse, error) {
+				return &api.ResourceRecommendationsResponse{Recommendations: []api.ResourceRecommendation{}}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewRecommendationsService(mockClient, mockOutput)
+
+		err := service.DisplayRecommendations(context.Background())
+
+		require.NoError(t, err)
+		for _, call := range mockOutput.calls {
+			assert.NotEqual(t, "Table", call.method)
+		}
+	})
+
+	t.Run("returns client errors", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getRecommendationsFunc: func(_ context.Context) (*api.ResourceRecommendationsResponse, error) {
+				return nil, errors.New("forbidden")
+			},
+		}
+		service := NewRecommendationsService(mockClient, &mockOutputInterface{})
+
+		err := service.DisplayRecommendations(context.Background())
+
+		assert.ErrorContains(t, err, "failed to get recommendations")
+	})
+}
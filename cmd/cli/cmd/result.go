@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var resultCmd = &cobra.Command{
+	Use:   "result <execution-id>",
+	Short: "Show the JSON result reported by a command execution",
+	Long: `Show the JSON result file written by a command execution, so that downstream automation
+does not have to parse its logs. The command writes a JSON document, at most 16 KiB, to the file
+named by the RUNVOY_RESULT_FILE environment variable; it is recorded once the execution ends.
+
+--jq selects a value of the result with a path expression: .key, .key.nested, .list[0] or .["key"].
+Strings are printed without quotes, other values as JSON.`,
+	Example: fmt.Sprintf(`  - %s result 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10
+  - %s result 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10 --jq .summary`,
+		constants.ProjectName, constants.ProjectName),
+	Run:               resultRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
+}
+
+func init() {
+	resultCmd.Flags().String("jq", "", "Path expression selecting a value of the result, e.g. .summary")
+	rootCmd.AddCommand(resultCmd)
+}
+
+func resultRun(cmd *cobra.Command, args []string) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+
+	jqExpr, _ := cmd.Flags().GetString("jq")
+	c := client.New(cfg, slog.Default())
+	service := NewResultService(c, NewOutputWrapper())
+	if err = service.DisplayResult(cmd.Context(), args[0], jqExpr); err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// ResultService handles displaying the results of executions.
+type ResultService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewResultService creates a new ResultService with the provided dependencies.
+func NewResultService(apiClient client.Interface, outputter OutputInterface) *ResultService {
+	return &ResultService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// DisplayResult prints the result of the execution, or the value the path expression selects in it.
+func (s *ResultService) DisplayResult(ctx context.Context, executionID, jqExpr string) error {
+	resp, err := s.client.GetExecutionResult(ctx, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to get execution result: %w", err)
+	}
+
+	var result any
+	if err = json.Unmarshal(resp.Result, &result); err != nil {
+		return fmt.Errorf("failed to decode execution result: %w", err)
+	}
+	if jqExpr != "" {
+		if result, err = selectJSONPath(result, jqExpr); err != nil {
+			return err
+		}
+	}
+
+	if text, ok := result.(string); ok {
+		s.output.Println(text)
+		return nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode execution result: %w", err)
+	}
+	s.output.Println(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
+
+// selectJSONPath returns the value a jq style path expression selects in a decoded JSON document:
+// "." for the document, ".key" or `.["key"]` for an object member and "[N]" for an array element,
+// chained. A missing member selects null, as with jq.
+func selectJSONPath(value any, expr string) (any, error) {
+	rest := strings.TrimSpace(expr)
+	if !strings.HasPrefix(rest, ".") {
+		return nil, fmt.Errorf("invalid path expression %q: must start with '.'", expr)
+	}
+	rest = strings.TrimPrefix(rest, ".")
+
+	for rest != "" {
+		var err error
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path expression %q: unclosed '['", expr)
+			}
+			if value, err = selectJSONIndex(value, rest[1:end], expr); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			if rest == "" || strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("invalid path expression %q: empty key", expr)
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if value, err = selectJSONKey(value, rest[:end], expr); err != nil {
+				return nil, err
+			}
+			rest = rest[end:]
+		}
+	}
+	return value, nil
+}
+
+// selectJSONIndex selects an array element, or an object member when the index is a quoted key.
+func selectJSONIndex(value any, index, expr string) (any, error) {
+	if key, err := strconv.Unquote(index); err == nil {
+		return selectJSONKey(value, key, expr)
+	}
+	n, err := strconv.Atoi(index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path expression %q: index %q is not a number or a quoted key", expr, index)
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		if n < 0 {
+			n += len(v)
+		}
+		if n < 0 || n >= len(v) {
+			return nil, nil
+		}
+		return v[n], nil
+	default:
+		return nil, fmt.Errorf("cannot index %s with a number in %q", jsonTypeName(value), expr)
+	}
+}
+
+// selectJSONKey selects an object member.
+func selectJSONKey(value any, key, expr string) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return v[key], nil
+	default:
+		return nil, fmt.Errorf("cannot index %s with %q in %q", jsonTypeName(value), key, expr)
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "an object"
+	}
+}
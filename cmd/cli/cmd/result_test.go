@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestResultService_DisplayResult(t *testing.T) {
+	newClient := func(result string) *mockClientInterface {
+		return &mockClientInterface{
+			getExecutionResultFunc: func(_ context.Context, executionID string) (*api.ExecutionResultResponse, error) {
+				return &api.ExecutionResultResponse{ExecutionID: executionID, Result: json.RawMessage(result)}, nil
+			},
+		}
+	}
+	printed := func(mockOutput *mockOutputInterface) []any {
+		var lines []any
+		for _, call := range mockOutput.calls {
+			if call.method == "Println" {
+				lines = append(lines, call.args...)
+			}
+		}
+		return lines
+	}
+
+	tests := []struct {
+		name   string
+		result string
+		jq     string
+		want   string
+	}{
+		{name: "prints the whole result", result: `{"summary":"3 passed"}`, want: "{\n  \"summary\": \"3 passed\"\n}"},
+		{name: "prints selected strings without quotes", result: `{"summary":"3 passed"}`, jq: ".summary",
+			want: "3 passed"},
+		{name: "selects nested values", result: `{"tests":{"failed":[{"name":"a"}]}}`, jq: `.tests.failed[0]["name"]`,
+			want: "a"},
+		{name: "selects null for missing keys", result: `{"summary":"ok"}`, jq: ".missing.key", want: "null"},
+		{name: "prints selected numbers as JSON", result: `{"count":3}`, jq: ".count", want: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOutput := &mockOutputInterface{}
+			service := NewResultService(newClient(tt.result), mockOutput)
+
+			err := service.DisplayResult(context.Background(), "exec-123", tt.jq)
+
+			require.NoError(t, err)
+			assert.Equal(t, []any{tt.want}, printed(mockOutput))
+		})
+	}
+
+	t.Run("rejects invalid path expressions", func(t *testing.T) {
+		for _, expr := range []string{"summary", ".a[", ".a[x]", ".a..b", ".summary[0]"} {
+			service := NewResultService(newClient(`{"a":[1],"summary":"ok"}`), &mockOutputInterface{})
+
+			err := service.DisplayResult(context.Background(), "exec-123", expr)
+
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getExecutionResultFunc: func(_ context.Context, _ string) (*api.ExecutionResultResponse, error) {
+				return nil, errors.New("execution exec-123 has not reported a result")
+			},
+		}
+		service := NewResultService(mockClient, &mockOutputInterface{})
+
+		err := service.DisplayResult(context.Background(), "exec-123", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get execution result")
+	})
+}
@@ -111,6 +111,9 @@ func Execute() {
 	if timeoutCancel != nil {
 		timeoutCancel()
 	}
+	// Shown here rather than in PersistentPostRun, which is skipped when the command fails.
+	showAnnouncement()
+	showVersionSkew()
 
 	if err != nil {
 		os.Exit(1)
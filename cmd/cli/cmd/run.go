@@ -2,30 +2,73 @@ package cmd
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/infra"
 	"github.com/runvoy/runvoy/internal/client/output"
 	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/secrets"
+	"github.com/runvoy/runvoy/internal/shellquote"
 
 	"github.com/spf13/cobra"
 )
 
 var runCmd = &cobra.Command{
-	Use:   "run <command>",
+	Use:   "run <command> [args...]",
 	Short: "Run a command",
-	Long: `Run a command in a remote environment with optional Git repository cloning.
+	Long: `Run a command in a remote environment with optional Git repository cloning
+or local directory upload.
+
+The program and its arguments are run as given, without a shell, so nothing in them
+needs escaping. A command given as a single argument, or with --shell, is run with
+"/bin/sh -c" instead, for pipes, redirections and variable expansion. --shell=bash
+and --shell=pwsh run it with bash or PowerShell, which the image must provide. The
+image may set a default shell and working directory, see "images register".
+
+--workdir sets the working directory of the command, relative to the Git path or
+the uploaded context when there is one.
+
+--log-retention-days deletes the logs of the execution that many days after it
+completes, within the bounds set by the backend. The logs are otherwise kept for
+the retention of the log storage, which a run can shorten but not extend.
 
 User environment variables prefixed with RUNVOY_USER_ are saved to .env file
-in the command working directory.`,
+in the command working directory, along with the variables of --env, --env-file
+and --pass-env. --env takes precedence over the RUNVOY_USER_ variables, which
+take precedence over the --env-file and then the --pass-env variables.
+
+--env-file reads a file in the KEY=VALUE format of "dev sync-env", and refuses it
+when it holds variables looking like secrets (use --allow-secrets to send them).
+--pass-env sends the variables of your shell matching its names or patterns, such
+as CI_*. Their names are listed and, in an interactive terminal, must be confirmed
+before they are sent (use --yes to skip the prompt). The RUNVOY_ variables of the
+CLI itself are never passed.`,
 	Example: fmt.Sprintf(`  - %s run echo hello world
   - %s run terraform plan
 
+  # With shell features, as a single argument or with --shell
+  - %s run 'make build && make test'
+  - %s run --shell -- echo '$HOME' '|' wc -c
+  - %s run --shell=bash 'shopt -s globstar && ls **/*.go'
+
+  # In a subdirectory of the repository, without "cd" in the command
+  - %s run --git-repo https://github.com/mycompany/myproject.git --workdir web npm test
+
   # With private Git repository cloning
   - %s run --secret github-token \
                --git-repo https://github.com/mycompany/myproject.git \
@@ -39,7 +82,48 @@ in the command working directory.`,
 
   # With user environment variables
   - RUNVOY_USER_MY_VAR=1234567890 %s run cat .env # Outputs => MY_VAR=1234567890
-`, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName),
+
+  # With the variables of a .env file, one of them overridden
+  - %s run --env-file .env.staging --env LOG_LEVEL=debug ./migrate.sh
+
+  # In CI, pass the variables already set in the environment
+  - %s run --pass-env 'AWS_REGION,CI_*' --yes ./deploy.sh
+
+  # Allow the command 30 seconds to clean up when stopped with "%s stop"
+  - %s run --stop-grace-period 30s ./deploy.sh
+
+  # Feed local data to the command's standard input
+  - cat data.csv | %s run --stdin -- python process.py
+
+  # Upload a local directory and use it as the working directory
+  - %s run --context ./my-project make test
+
+  # Keep the execution and its logs visible only to you and admins
+  - %s run --visibility private ./rotate-credentials.sh
+
+  # Split a test suite in 10 shards, each reading RUNVOY_SHARD_INDEX and RUNVOY_SHARD_TOTAL
+  - %s run --parallel 10 -- ./test-shard.sh
+  - %s status <group-id>
+
+  # Wait for the command to complete and exit with its exit code, e.g. in CI
+  - %s run --wait make test
+
+  # Deploy once at a time, waiting up to 10 minutes for a deploy holding the lock to complete
+  - %s run --lock deploy-prod --lock-wait 10m ./deploy.sh
+  - %s locks list
+
+  # As an admin, reproduce a run of another user, attributed to that user
+  - %s run --as alice@example.com ./report.sh
+
+  # On a flaky network, queue a non-urgent run if the API can't be reached, and submit it later
+  - %s run --queue-offline ./nightly-report.sh
+  - %s queue flush
+`, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName, constants.ProjectName,
+		constants.ProjectName),
 	Run:  runRun,
 	Args: cobra.MinimumNArgs(1),
 }
@@ -51,17 +135,88 @@ func init() {
 	runCmd.Flags().StringP("git-path", "p", "", "Git path")
 	runCmd.Flags().StringP("image", "i", "", "Image to use")
 	runCmd.Flags().StringSlice("secret", []string{}, "Secret name to inject (repeatable)")
+	runCmd.Flags().Duration("stop-grace-period", 0,
+		"time the command is given to exit after SIGTERM when stopped, before being killed (e.g. 30s)")
+	runCmd.Flags().Bool("stdin", false,
+		fmt.Sprintf("Read standard input and feed it to the command (max %s)", output.Bytes(constants.MaxStdinBytes)))
+	runCmd.Flags().String("context", "",
+		fmt.Sprintf("Local directory uploaded as the working directory, instead of a Git repository (max %s compressed)",
+			output.Bytes(constants.MaxContextBytes)))
+	runCmd.Flags().String("visibility", "",
+		"Who besides you and admins may see the execution and its logs: private, team or public. "+
+			"Uses the backend default if not specified")
+	runCmd.Flags().Int("parallel", 0,
+		fmt.Sprintf("Start this many executions of the command as the shards of a group (max %d), "+
+			"each with RUNVOY_SHARD_INDEX and RUNVOY_SHARD_TOTAL set", constants.MaxExecutionGroupSize))
+	runCmd.Flags().String("as", "",
+		"Email of the user to run the command on behalf of, attributing the execution to that user (admins only)")
+	runCmd.Flags().Bool("wait", false,
+		"Wait for the command to complete and exit with its exit code, failing if it doesn't succeed")
+	runCmd.Flags().StringArrayP("env", "e", []string{},
+		"Environment variable to set, as KEY=VALUE (repeatable), taking precedence over the other variables")
+	runCmd.Flags().String("env-file", "", "Read the environment variables of a .env file, such as .env.staging")
+	runCmd.Flags().Bool("allow-secrets", false,
+		"Send the variables of --env-file even when they look like secrets, instead of refusing the file")
+	runCmd.Flags().StringSlice("pass-env", []string{},
+		"Names or patterns such as CI_* of the variables of your environment to send with the run (repeatable)")
+	runCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation of the variables sent with --pass-env")
+	runCmd.Flags().String("shell", "",
+		"Run the arguments joined with spaces as a shell command line, instead of without a shell: "+
+			"sh (the default of --shell), bash or pwsh, as in --shell=bash")
+	runCmd.Flags().Lookup("shell").NoOptDefVal = string(constants.ExecutionShellSh)
+	runCmd.Flags().String("workdir", "",
+		"Working directory of the command, absolute or relative to the Git path or the uploaded context")
+	runCmd.Flags().Int("log-retention-days", 0,
+		"Days the logs of the execution are kept after it completes, within the backend bounds. "+
+			"Uses the retention of the log storage if not specified")
+	runCmd.Flags().String("lock", "",
+		"Name of a lock held by the execution while it runs, such as deploy-prod: the run fails while "+
+			"another execution holds the lock, unless --lock-wait is set")
+	runCmd.Flags().Duration("lock-wait", 0,
+		"How long to wait for the lock of --lock when another execution holds it, such as 10m, "+
+			"instead of failing right away")
+	runCmd.Flags().Bool("queue-offline", false,
+		fmt.Sprintf("When the API is unreachable, queue the run to submit it later with %s queue flush",
+			constants.ProjectName))
+	_ = runCmd.MarkFlagDirname("context")
+	_ = runCmd.MarkFlagFilename("env-file")
+	_ = runCmd.RegisterFlagCompletionFunc("visibility", cobra.FixedCompletions(
+		[]string{
+			string(constants.ExecutionVisibilityPrivate),
+			string(constants.ExecutionVisibilityTeam),
+			string(constants.ExecutionVisibilityPublic),
+		},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+	_ = runCmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(
+		[]string{
+			string(constants.ExecutionShellSh),
+			string(constants.ExecutionShellBash),
+			string(constants.ExecutionShellPwsh),
+		},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+	_ = runCmd.RegisterFlagCompletionFunc("image", completeFlag(fetchImageNames))
+	_ = runCmd.RegisterFlagCompletionFunc("secret", completeFlag(fetchSecretNames))
 }
 
 func runRun(cmd *cobra.Command, args []string) {
-	command := strings.Join(args, " ")
+	shell, _ := cmd.Flags().GetString("shell")
+	if shell != "" && !constants.ExecutionShell(shell).Valid() {
+		output.Fatalf("invalid shell %q, must be sh, bash or pwsh", shell)
+	}
+	command, commandArgs := commandFromArgs(args, shell != "")
+	workDir, _ := cmd.Flags().GetString("workdir")
 	cfg, err := getConfigFromContext(cmd)
 	if err != nil {
 		output.Errorf("failed to load configuration: %v", err)
 		return
 	}
 
-	envs := extractUserEnvVars(os.Environ())
+	envs, err := runEnvVars(cmd, os.Environ())
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
 	gitRepo := cmd.Flag("git-repo").Value.String()
 	gitRef := cmd.Flag("git-ref").Value.String()
 	gitPath := cmd.Flag("git-path").Value.String()
@@ -70,22 +225,230 @@ func runRun(cmd *cobra.Command, args []string) {
 	if err != nil {
 		output.Fatalf("failed to parse secrets: %v", err)
 	}
+	stopGracePeriod, err := cmd.Flags().GetDuration("stop-grace-period")
+	if err != nil {
+		output.Fatalf("failed to parse stop grace period: %v", err)
+	}
+	visibility := cmd.Flag("visibility").Value.String()
+	if visibility != "" && !constants.ExecutionVisibility(visibility).Valid() {
+		output.Fatalf("invalid visibility %q, must be private, team or public", visibility)
+	}
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		output.Fatalf("failed to parse parallel: %v", err)
+	}
+	if parallel < 0 || parallel > constants.MaxExecutionGroupSize {
+		output.Fatalf("invalid parallel %d, must be between 1 and %d", parallel, constants.MaxExecutionGroupSize)
+	}
+	logRetentionDays, _ := cmd.Flags().GetInt("log-retention-days")
+	if logRetentionDays < 0 {
+		output.Fatalf("invalid log retention %d days, must not be negative", logRetentionDays)
+	}
+	onBehalfOf, _ := cmd.Flags().GetString("as")
+	lock, _ := cmd.Flags().GetString("lock")
+	lockWait, _ := cmd.Flags().GetDuration("lock-wait")
+	if lockWait < 0 {
+		output.Fatalf("invalid lock wait %s, must not be negative", lockWait)
+	}
+	if lockWait > 0 && lock == "" {
+		output.Fatalf("--lock-wait requires --lock")
+	}
+	if lock != "" && parallel > 0 {
+		output.Fatalf("--lock cannot be combined with --parallel")
+	}
+	wait, _ := cmd.Flags().GetBool("wait")
+	if wait && parallel > 0 {
+		output.Fatalf("--wait cannot be combined with --parallel")
+	}
+	queueOffline, _ := cmd.Flags().GetBool("queue-offline")
+	if queueOffline && wait {
+		output.Fatalf("--queue-offline cannot be combined with --wait")
+	}
+	stdin, contextArchive, err := readRunInputs(cmd, gitRepo)
+	if err != nil {
+		output.Errorf(err.Error())
+		return
+	}
 
 	c := client.New(cfg, slog.Default())
 	service := NewRunService(c, NewOutputWrapper())
+	if queueOffline {
+		if service.queue, err = NewOfflineQueue(cfg.APIEndpoint); err != nil {
+			output.Fatalf(err.Error())
+		}
+	}
 	req := ExecuteCommandRequest{
-		Command: command,
-		GitRepo: gitRepo,
-		GitRef:  gitRef,
-		GitPath: gitPath,
-		Image:   image,
-		Env:     envs,
-		Secrets: secrets,
-		WebURL:  cfg.WebURL,
+		Command:         command,
+		Args:            commandArgs,
+		Shell:           shell,
+		WorkDir:         workDir,
+		GitRepo:         gitRepo,
+		GitRef:          gitRef,
+		GitPath:         gitPath,
+		Image:           image,
+		Env:             envs,
+		Secrets:         secrets,
+		WebURL:          cfg.WebURL,
+		StopGracePeriod: stopGracePeriod,
+		Visibility:      visibility,
+		LogRetention:    logRetentionDays,
+		Stdin:           stdin,
+		ContextArchive:  contextArchive,
+		Parallel:        parallel,
+		Lock:            lock,
+		LockWait:        lockWait,
+		Wait:            wait,
+		OnBehalfOf:      onBehalfOf,
+		QueueOffline:    queueOffline,
 	}
 	if err = service.ExecuteCommand(cmd.Context(), &req); err != nil {
 		output.Errorf(err.Error())
+		if wait {
+			os.Exit(exitCodeOf(err))
+		}
+	}
+}
+
+// readRunInputs reads the standard input and archives the context directory when requested by the flags.
+func readRunInputs(cmd *cobra.Command, gitRepo string) (stdin, contextArchive []byte, err error) {
+	if readStdin, _ := cmd.Flags().GetBool("stdin"); readStdin {
+		if stdin, err = readStdinData(os.Stdin); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	contextDir, _ := cmd.Flags().GetString("context")
+	if contextDir == "" {
+		return stdin, nil, nil
+	}
+	if gitRepo != "" {
+		return nil, nil, errors.New("--context cannot be combined with --git-repo")
+	}
+	if contextArchive, err = archiveContextDir(contextDir); err != nil {
+		return nil, nil, err
 	}
+	return stdin, contextArchive, nil
+}
+
+// readStdinData reads the standard input fed to a command, up to constants.MaxStdinBytes.
+func readStdinData(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, constants.MaxStdinBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	if len(data) > constants.MaxStdinBytes {
+		return nil, fmt.Errorf("stdin exceeds the maximum size of %s", output.Bytes(constants.MaxStdinBytes))
+	}
+	return data, nil
+}
+
+// commandFromArgs returns the shell command line of the arguments of the run command, with the arguments to
+// run without a shell unless the command is a single argument or shell is set. The command line of the
+// arguments quotes each of them, so that a backend running it with the shell runs the same arguments.
+func commandFromArgs(args []string, shell bool) (command string, commandArgs []string) {
+	if shell || len(args) == 1 {
+		return strings.Join(args, " "), nil
+	}
+	return shellquote.Join(args), args
+}
+
+// runEnvVars returns the environment variables of the run, from the lowest to the highest precedence: the
+// variables passed with --pass-env once confirmed, those of --env-file, the RUNVOY_USER_ variables and --env.
+func runEnvVars(cmd *cobra.Command, environ []string) (map[string]string, error) {
+	envs, err := passedEnvVars(cmd, environ)
+	if err != nil {
+		return nil, err
+	}
+	fileEnvs, err := envFileVars(cmd)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(envs, fileEnvs)
+	maps.Copy(envs, extractUserEnvVars(environ))
+
+	flags, _ := cmd.Flags().GetStringArray("env")
+	for _, flag := range flags {
+		name, value, ok := strings.Cut(flag, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --env %q, expected KEY=VALUE", flag)
+		}
+		envs[name] = value
+	}
+	return envs, nil
+}
+
+// passedEnvVars returns the variables passed with --pass-env, listing their names to confirm sending them.
+func passedEnvVars(cmd *cobra.Command, environ []string) (map[string]string, error) {
+	patterns, _ := cmd.Flags().GetStringSlice("pass-env")
+	if len(patterns) == 0 {
+		return map[string]string{}, nil
+	}
+
+	passed, err := passEnvVars(environ, patterns)
+	if err != nil {
+		return nil, err
+	}
+	if len(passed) == 0 {
+		output.Warningf("--pass-env matched no environment variables")
+		return passed, nil
+	}
+	names := slices.Sorted(maps.Keys(passed))
+	output.Infof("Passing %d environment variables from your shell: %s", len(names), strings.Join(names, ", "))
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes && output.IsInteractive() &&
+		!output.Confirm("Send their values with the run request?") {
+		return nil, errors.New("run canceled")
+	}
+	return passed, nil
+}
+
+// envFileVars returns the variables of the --env-file, refusing a file with variables looking like secrets,
+// by their names or values, unless --allow-secrets is set.
+func envFileVars(cmd *cobra.Command) (map[string]string, error) {
+	path, _ := cmd.Flags().GetString("env-file")
+	if path == "" {
+		return nil, nil
+	}
+	vars, err := infra.ParseEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowSecrets, _ := cmd.Flags().GetBool("allow-secrets"); !allowSecrets {
+		names := secrets.MergeSecretVarNames(secrets.GetSecretVariableNames(vars), secrets.GetSecretValueNames(vars))
+		if len(names) > 0 {
+			slices.Sort(names)
+			return nil, fmt.Errorf("%s holds variables looking like secrets: %s. Store them with %s secrets create "+
+				"and pass them with --secret, or use --allow-secrets", path, strings.Join(names, ", "),
+				constants.ProjectName)
+		}
+	}
+	output.Infof("Read %d environment variables from %s", len(vars), path)
+	return vars, nil
+}
+
+// passEnvVars returns the variables of environ whose names match one of the patterns, names or shell
+// patterns such as CI_*. The RUNVOY_ variables configuring the CLI, such as its API key, never match.
+func passEnvVars(environ, patterns []string) (map[string]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --pass-env pattern %q: %w", pattern, err)
+		}
+	}
+
+	envs := make(map[string]string)
+	for _, env := range environ {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || name == "" || strings.HasPrefix(name, "RUNVOY_") {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				envs[name] = value
+				break
+			}
+		}
+	}
+	return envs, nil
 }
 
 func extractUserEnvVars(envVars []string) map[string]string {
@@ -107,7 +470,14 @@ func extractUserEnvVars(envVars []string) map[string]string {
 
 // ExecuteCommandRequest contains all parameters needed to execute a command.
 type ExecuteCommandRequest struct {
+	// Command is the shell command line, which is the equivalent of Args when they are set.
 	Command string
+	// Args is the program and its arguments, run without a shell when not empty.
+	Args []string
+	// Shell runs the Command, the default of the image when empty. WorkDir is the working directory of the
+	// command, the default of the image when empty.
+	Shell   string
+	WorkDir string
 	GitRepo string
 	GitRef  string
 	GitPath string
@@ -115,13 +485,73 @@ type ExecuteCommandRequest struct {
 	Env     map[string]string
 	Secrets []string
 	WebURL  string
+	// StopGracePeriod is the time the command is given to exit after SIGTERM when stopped.
+	StopGracePeriod time.Duration
+	// Visibility is the execution visibility, the backend default when empty.
+	Visibility string
+	// LogRetention is the number of days the logs are kept after the execution completes, the retention of
+	// the log storage when zero.
+	LogRetention int
+	// Stdin is fed to the command's standard input when not empty.
+	Stdin []byte
+	// ContextArchive is a gzip-compressed tar archive extracted as the working directory when not empty.
+	ContextArchive []byte
+	// Parallel starts that many shards of the command as a group when positive.
+	Parallel int
+	// Lock is the name of the lock the execution holds while it runs, when not empty. LockWait is how long
+	// to wait for the lock while another execution holds it, the run failing right away when zero.
+	Lock     string
+	LockWait time.Duration
+	// OnBehalfOf is the email of the user the command is run on behalf of, when not empty.
+	OnBehalfOf string
+	// Playbook is the name of the playbook the command is run from, with the max duration it expects
+	// when positive, for the backend to flag executions running longer.
+	Playbook            string
+	ExpectedMaxDuration time.Duration
+	// EnvSchema declares the environment variables the playbook expects, validated by the backend.
+	EnvSchema []api.EnvVarSpec
+	// Wait waits for the command to complete after its logs, returning an ExecutionFailedError unless it
+	// succeeded.
+	Wait bool
+	// QueueOffline keeps the request in the offline queue when the API is unreachable, to submit it later.
+	QueueOffline bool
+	// SubmitOnly returns once the execution started, without following its logs.
+	SubmitOnly bool
+}
+
+// ExecutionFailedError is returned when waiting for a command which didn't succeed.
+type ExecutionFailedError struct {
+	ExecutionID string
+	Status      string
+	ExitCode    *int
+}
+
+func (e *ExecutionFailedError) Error() string {
+	if e.ExitCode != nil {
+		return fmt.Sprintf("execution %s %s with exit code %d", e.ExecutionID, strings.ToLower(e.Status), *e.ExitCode)
+	}
+	return fmt.Sprintf("execution %s %s", e.ExecutionID, strings.ToLower(e.Status))
+}
+
+// exitCodeOf returns the exit code of the command of a failed execution, or 1 for the other errors.
+func exitCodeOf(err error) int {
+	var failedErr *ExecutionFailedError
+	if errors.As(err, &failedErr) && failedErr.ExitCode != nil && *failedErr.ExitCode > 0 {
+		return *failedErr.ExitCode
+	}
+	return 1
 }
 
 // RunService handles command execution logic.
 type RunService struct {
-	client     client.Interface
-	output     OutputInterface
-	streamLogs func(logsService *LogsService, websocketURL, webURL, executionID string) error
+	client       client.Interface
+	output       OutputInterface
+	streamLogs   func(logsService *LogsService, websocketURL, webURL, executionID string) error
+	pollInterval time.Duration
+	// lockPollInterval is the interval between the attempts to start an execution whose lock is held.
+	lockPollInterval time.Duration
+	// queue keeps the requests run with QueueOffline while the API is unreachable.
+	queue *OfflineQueue
 }
 
 // NewRunService creates a new RunService with the provided dependencies.
@@ -132,12 +562,196 @@ func NewRunService(apiClient client.Interface, outputter OutputInterface) *RunSe
 		streamLogs: func(logsService *LogsService, websocketURL, webURL, executionID string) error {
 			return logsService.streamLogsViaWebSocket(websocketURL, webURL, executionID)
 		},
+		pollInterval:     constants.WatchPollInterval,
+		lockPollInterval: constants.LockWaitPollInterval,
 	}
 }
 
 // ExecuteCommand executes a command remotely and displays the results.
 func (s *RunService) ExecuteCommand(ctx context.Context, req *ExecuteCommandRequest) error {
+	s.displayRequest(req)
+
+	resp, err := s.submit(ctx, req)
+	if err != nil {
+		if req.QueueOffline && s.queue != nil && client.IsUnreachable(err) {
+			return s.queueOffline(req, err)
+		}
+		return err
+	}
+	if resp.BudgetWarning != "" {
+		s.output.Warningf("Budget: %s", resp.BudgetWarning)
+	}
+
+	if resp.GroupID != "" {
+		s.displayExecutionGroup(resp)
+		return nil
+	}
+
+	s.output.Successf("Command execution started successfully")
+	s.output.KeyValue("Execution ID", s.output.Cyan(resp.ExecutionID))
+	s.output.KeyValue("Status", resp.Status)
+	if resp.ImageID != "" {
+		s.output.KeyValue("Image ID", s.output.Cyan(resp.ImageID))
+	}
+	if resp.ImageAlias != "" {
+		s.output.KeyValue("Image Alias", resp.ImageAlias)
+	}
+	if req.SubmitOnly {
+		return nil
+	}
+
+	if err = s.displayLogs(ctx, resp, req.WebURL); err != nil {
+		return err
+	}
+	if req.Wait {
+		return s.waitForCompletion(ctx, resp.ExecutionID)
+	}
+	return nil
+}
+
+// submit uploads the inputs of the command and starts its execution.
+func (s *RunService) submit(ctx context.Context, req *ExecuteCommandRequest) (*api.ExecutionResponse, error) {
+	execReq := api.ExecutionRequest{
+		Command:         req.Command,
+		Args:            req.Args,
+		Shell:           req.Shell,
+		WorkDir:         req.WorkDir,
+		GitRepo:         req.GitRepo,
+		GitRef:          req.GitRef,
+		GitPath:         req.GitPath,
+		Env:             req.Env,
+		Image:           req.Image,
+		Secrets:         req.Secrets,
+		StopGracePeriod: int(req.StopGracePeriod.Seconds()),
+		Visibility:      req.Visibility,
+		Parallel:        req.Parallel,
+		Lock:            req.Lock,
+		OnBehalfOf:      req.OnBehalfOf,
+
+		LogRetentionDays:    req.LogRetention,
+		Playbook:            req.Playbook,
+		ExpectedMaxDuration: int(req.ExpectedMaxDuration.Seconds()),
+		EnvSchema:           req.EnvSchema,
+	}
+	if err := s.attachStdin(ctx, &execReq, req.Stdin); err != nil {
+		return nil, err
+	}
+	if err := s.attachContext(ctx, &execReq, req.ContextArchive); err != nil {
+		return nil, err
+	}
+	resp, err := s.runCommand(ctx, &execReq, req.LockWait)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run command: %w", err)
+	}
+	return resp, nil
+}
+
+// runCommand starts the execution, retrying for up to lockWait while another execution holds its lock.
+func (s *RunService) runCommand(
+	ctx context.Context,
+	execReq *api.ExecutionRequest,
+	lockWait time.Duration,
+) (*api.ExecutionResponse, error) {
+	deadline := time.Now().Add(lockWait)
+	waiting := false
+	for {
+		resp, err := s.client.RunCommand(ctx, execReq)
+		if err == nil || !client.HasErrorCode(err, apperrors.ErrCodeLockHeld) ||
+			time.Now().Add(s.lockPollInterval).After(deadline) {
+			return resp, err
+		}
+		if !waiting {
+			s.output.Infof("Waiting up to %s for lock %s: %v", lockWait, execReq.Lock, err)
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("canceled waiting for lock %s: %w", execReq.Lock, ctx.Err())
+		case <-time.After(s.lockPollInterval):
+		}
+	}
+}
+
+// queueOffline keeps the request in the offline queue after the API was found unreachable.
+func (s *RunService) queueOffline(req *ExecuteCommandRequest, cause error) error {
+	queued, err := s.queue.Add(req)
+	if err != nil {
+		return fmt.Errorf("%w, and failed to queue the run: %w", cause, err)
+	}
+	s.output.Warningf("API unreachable: %v", cause)
+	s.output.Successf("Run queued as %s", queued.ID)
+	s.output.Infof("Submit it once back online with: %s queue flush", constants.ProjectName)
+	return nil
+}
+
+// displayLogs streams the logs of the execution similar to the logs command.
+func (s *RunService) displayLogs(ctx context.Context, resp *api.ExecutionResponse, webURL string) error {
+	logsService := NewLogsService(s.client, s.output)
+	if resp.WebSocketURL != "" && s.streamLogs != nil {
+		streamErr := s.streamLogs(logsService, resp.WebSocketURL, webURL, resp.ExecutionID)
+		if streamErr == nil {
+			return nil
+		}
+		s.output.Warningf("Failed to stream logs directly, falling back to fetching logs: %v", streamErr)
+	}
+	if serviceErr := logsService.DisplayLogs(ctx, resp.ExecutionID, webURL); serviceErr != nil {
+		return fmt.Errorf("failed to stream logs: %w", serviceErr)
+	}
+	return nil
+}
+
+// waitForCompletion polls the status of the execution until it completes, as the log stream may end first.
+func (s *RunService) waitForCompletion(ctx context.Context, executionID string) error {
+	for {
+		status, err := s.client.GetExecutionStatus(ctx, executionID)
+		if err != nil {
+			return fmt.Errorf("failed to get execution status: %w", err)
+		}
+		if isTerminalStatus(status.Status) {
+			if status.Status != string(constants.ExecutionSucceeded) {
+				return &ExecutionFailedError{ExecutionID: executionID, Status: status.Status, ExitCode: status.ExitCode}
+			}
+			s.output.Successf("Execution %s succeeded", executionID)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for execution %s: %w", executionID, ctx.Err())
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// displayExecutionGroup shows the shards started by a parallel run.
+// Their logs are not streamed, they are followed with the status of the group.
+func (s *RunService) displayExecutionGroup(resp *api.ExecutionResponse) {
+	s.output.Successf("Started %d shards of the command", len(resp.Shards))
+	s.output.KeyValue("Group ID", s.output.Cyan(resp.GroupID))
+	s.output.KeyValue("Status", resp.Status)
+	if resp.ImageID != "" {
+		s.output.KeyValue("Image ID", s.output.Cyan(resp.ImageID))
+	}
+	s.output.Blank()
+
+	rows := make([][]string, 0, len(resp.Shards))
+	for _, shard := range resp.Shards {
+		rows = append(rows, []string{strconv.Itoa(shard.ShardIndex), shard.ExecutionID})
+	}
+	s.output.Table([]string{"Shard", "Execution ID"}, rows)
+	s.output.Blank()
+
+	s.output.Infof("Follow the group with: %s status %s", constants.ProjectName, resp.GroupID)
+	s.output.Infof("Read the logs of a shard with: %s logs <execution-id>", constants.ProjectName)
+}
+
+// displayRequest shows the command and the options it is run with.
+func (s *RunService) displayRequest(req *ExecuteCommandRequest) {
 	s.output.Infof("Running command: %s", s.output.Bold(req.Command))
+	if req.OnBehalfOf != "" {
+		s.output.Infof("On behalf of: %s", s.output.Bold(req.OnBehalfOf))
+	}
 	if req.GitRepo != "" {
 		s.output.Infof("Git repository: %s", s.output.Bold(req.GitRepo))
 	}
@@ -147,6 +761,24 @@ func (s *RunService) ExecuteCommand(ctx context.Context, req *ExecuteCommandRequ
 	if req.GitPath != "" {
 		s.output.Infof("Git path: %s", s.output.Bold(req.GitPath))
 	}
+	if req.WorkDir != "" {
+		s.output.Infof("Working directory: %s", s.output.Bold(req.WorkDir))
+	}
+	if req.Shell != "" {
+		s.output.Infof("Shell: %s", s.output.Bold(req.Shell))
+	}
+	if req.Visibility != "" {
+		s.output.Infof("Visibility: %s", s.output.Bold(req.Visibility))
+	}
+	if req.LogRetention > 0 {
+		s.output.Infof("Log retention: %s", s.output.Bold(strconv.Itoa(req.LogRetention)+" days"))
+	}
+	if req.Parallel > 0 {
+		s.output.Infof("Parallel shards: %s", s.output.Bold(strconv.Itoa(req.Parallel)))
+	}
+	if req.Lock != "" {
+		s.output.Infof("Lock: %s", s.output.Bold(req.Lock))
+	}
 
 	envKeys := make([]string, 0, len(req.Env))
 	for key := range req.Env {
@@ -156,40 +788,28 @@ func (s *RunService) ExecuteCommand(ctx context.Context, req *ExecuteCommandRequ
 		sort.Strings(envKeys)
 		s.output.Infof("Injecting user environment variables: %s", s.output.Bold(strings.Join(envKeys, ", ")))
 	}
+}
 
-	execReq := api.ExecutionRequest{
-		Command: req.Command,
-		GitRepo: req.GitRepo,
-		GitRef:  req.GitRef,
-		GitPath: req.GitPath,
-		Env:     req.Env,
-		Image:   req.Image,
-		Secrets: req.Secrets,
-	}
-	resp, err := s.client.RunCommand(ctx, &execReq)
-	if err != nil {
-		return fmt.Errorf("failed to run command: %w", err)
+// attachStdin adds the standard input to an execution request: inline when small enough,
+// otherwise uploaded beforehand and referenced by its upload ID.
+func (s *RunService) attachStdin(ctx context.Context, execReq *api.ExecutionRequest, data []byte) error {
+	if len(data) == 0 {
+		return nil
 	}
 
-	s.output.Successf("Command execution started successfully")
-	s.output.KeyValue("Execution ID", s.output.Cyan(resp.ExecutionID))
-	s.output.KeyValue("Status", resp.Status)
-	if resp.ImageID != "" {
-		s.output.KeyValue("Image ID", s.output.Cyan(resp.ImageID))
+	if len(data) <= constants.MaxInlineStdinBytes {
+		execReq.Stdin = base64.StdEncoding.EncodeToString(data)
+		return nil
 	}
 
-	// Stream logs similar to the logs command
-	logsService := NewLogsService(s.client, s.output)
-	if resp.WebSocketURL != "" && s.streamLogs != nil {
-		streamErr := s.streamLogs(logsService, resp.WebSocketURL, req.WebURL, resp.ExecutionID)
-		if streamErr == nil {
-			return nil
-		}
-		s.output.Warningf("Failed to stream logs directly, falling back to fetching logs: %v", streamErr)
+	s.output.Infof("Uploading standard input (%s)", output.Bytes(int64(len(data))))
+	upload, err := s.client.CreateStdinUpload(ctx, int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to create stdin upload: %w", err)
 	}
-	if serviceErr := logsService.DisplayLogs(ctx, resp.ExecutionID, req.WebURL); serviceErr != nil {
-		return fmt.Errorf("failed to stream logs: %w", serviceErr)
+	if err = s.client.UploadInput(ctx, upload.UploadURL, data); err != nil {
+		return err
 	}
-
+	execReq.StdinUploadID = upload.UploadID
 	return nil
 }
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// windowsContextFileMode is the mode of the files archived on Windows, which has no executable bit: every
+// file is made executable so scripts of the context can be run.
+const windowsContextFileMode = 0o755
+
+// contextExcludedDirs lists the directories left out of context archives.
+var contextExcludedDirs = map[string]struct{}{
+	".git": {},
+}
+
+// attachContext uploads the working directory archive and references it in the execution request.
+func (s *RunService) attachContext(ctx context.Context, execReq *api.ExecutionRequest, archive []byte) error {
+	if len(archive) == 0 {
+		return nil
+	}
+
+	s.output.Infof("Uploading context (%s)", output.Bytes(int64(len(archive))))
+	upload, err := s.client.CreateContextUpload(ctx, int64(len(archive)))
+	if err != nil {
+		return fmt.Errorf("failed to create context upload: %w", err)
+	}
+	if err = s.client.UploadInput(ctx, upload.UploadURL, archive); err != nil {
+		return err
+	}
+	execReq.ContextUploadID = upload.UploadID
+	return nil
+}
+
+// archiveContextDir builds a gzip-compressed tar archive of dir, up to constants.MaxContextBytes.
+// Regular files, directories and symbolic links are kept; .git directories are skipped.
+func archiveContextDir(dir string) ([]byte, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("context %s is not a directory", dir)
+	}
+
+	buf := &limitedBuffer{limit: constants.MaxContextBytes}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		return addContextEntry(tarWriter, dir, path, entry)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to archive context directory: %w", err)
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to archive context directory: %w", err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to archive context directory: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func addContextEntry(tarWriter *tar.Writer, root, path string, entry fs.DirEntry) error {
+	relPath, err := filepath.Rel(root, path)
+	if err != nil || relPath == "." {
+		return err
+	}
+	if _, excluded := contextExcludedDirs[entry.Name()]; excluded && entry.IsDir() {
+		return filepath.SkipDir
+	}
+
+	info, err := entry.Info()
+	if err != nil {
+		return err
+	}
+	link := ""
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	case !info.Mode().IsRegular() && !info.IsDir():
+		// Sockets, devices and named pipes cannot be uploaded
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(relPath)
+	normalizeContextHeader(header, runtime.GOOS)
+	if err = tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path) //nolint:gosec // G304: File path from the context directory is intentional
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// normalizeContextHeader makes the header of an entry archived on goos portable to the Linux containers:
+// on Windows, symbolic link targets use forward slashes and the files and directories are made executable.
+func normalizeContextHeader(header *tar.Header, goos string) {
+	if goos != "windows" {
+		return
+	}
+	header.Linkname = filepath.ToSlash(header.Linkname)
+	if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeDir {
+		header.Mode = windowsContextFileMode
+	}
+}
+
+// limitedBuffer is a bytes.Buffer that fails writes growing it beyond limit bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("context exceeds the maximum compressed size of %s", output.Bytes(int64(b.limit)))
+	}
+	return b.Buffer.Write(p)
+}
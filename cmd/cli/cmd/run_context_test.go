@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func TestArchiveContextDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Makefile"), []byte("test:\n\tgo test ./...\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o600))
+
+	archive, err := archiveContextDir(dir)
+	require.NoError(t, err)
+
+	entries := readArchive(t, archive)
+	assert.Equal(t, "test:\n\tgo test ./...\n", entries["Makefile"])
+	assert.Equal(t, "package main\n", entries["src/main.go"])
+	assert.Contains(t, entries, "src")
+	assert.NotContains(t, entries, ".git")
+	assert.NotContains(t, entries, ".git/HEAD")
+}
+
+func TestArchiveContextDir_Errors(t *testing.T) {
+	_, err := archiveContextDir(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read context directory")
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+	_, err = archiveContextDir(file)
+	assert.ErrorContains(t, err, "is not a directory")
+}
+
+func TestNormalizeContextHeader(t *testing.T) {
+	script := &tar.Header{Name: "scripts/build.sh", Typeflag: tar.TypeReg, Mode: 0o666}
+	normalizeContextHeader(script, "windows")
+	assert.Equal(t, int64(0o755), script.Mode, "Windows files have no executable bit")
+
+	link := &tar.Header{Name: "current", Typeflag: tar.TypeSymlink, Linkname: `releases\v1`, Mode: 0o777}
+	normalizeContextHeader(link, "windows")
+	assert.Equal(t, int64(0o777), link.Mode)
+
+	unix := &tar.Header{Name: "README.md", Typeflag: tar.TypeReg, Mode: 0o644}
+	normalizeContextHeader(unix, "linux")
+	assert.Equal(t, int64(0o644), unix.Mode)
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 4}
+
+	_, err := buf.Write([]byte("abc"))
+	require.NoError(t, err)
+	_, err = buf.Write([]byte("de"))
+	assert.ErrorContains(t, err, "context exceeds the maximum compressed size")
+	assert.Equal(t, "abc", buf.String())
+}
+
+func TestRunService_ExecuteCommandWithContext(t *testing.T) {
+	archive := []byte("archive")
+
+	t.Run("uploads the context", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForRun{
+			mockClientInterface: &mockClientInterface{},
+			createContextUploadFunc: func(_ context.Context, size int64) (*api.InputUploadResponse, error) {
+				assert.Equal(t, int64(len(archive)), size)
+				return &api.InputUploadResponse{UploadID: "upload-123", UploadURL: "https://uploads.example.com"}, nil
+			},
+			uploadInputFunc: func(_ context.Context, uploadURL string, data []byte) error {
+				assert.Equal(t, "https://uploads.example.com", uploadURL)
+				assert.Equal(t, archive, data)
+				return nil
+			},
+			runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+				assert.Equal(t, "upload-123", req.ContextUploadID)
+				return &api.ExecutionResponse{ExecutionID: "exec-context", Status: "pending"}, nil
+			},
+			getLogsFunc: func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+				return &api.LogsResponse{
+					ExecutionID: executionID,
+					Status:      string(constants.ExecutionSucceeded),
+				}, nil
+			},
+		}
+
+		service := NewRunService(mockClient, &mockOutputInterface{})
+		err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+			Command:        "make test",
+			ContextArchive: archive,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns upload errors", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForRun{
+			mockClientInterface: &mockClientInterface{},
+			createContextUploadFunc: func(_ context.Context, _ int64) (*api.InputUploadResponse, error) {
+				return nil, errors.New("service unavailable")
+			},
+		}
+
+		service := NewRunService(mockClient, &mockOutputInterface{})
+		err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+			Command:        "make test",
+			ContextArchive: archive,
+		})
+
+		assert.ErrorContains(t, err, "failed to create context upload")
+	})
+}
+
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	entries := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		entries[header.Name] = string(content)
+	}
+}
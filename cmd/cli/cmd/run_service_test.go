@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
 	"github.com/runvoy/runvoy/internal/constants"
 )
 
@@ -17,6 +24,11 @@ type mockClientInterfaceForRun struct {
 	*mockClientInterface
 	runCommandFunc func(ctx context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error)
 	getLogsFunc    func(ctx context.Context, executionID string) (*api.LogsResponse, error)
+	// stdin and context upload hooks
+	createStdinUploadFunc   func(ctx context.Context, size int64) (*api.InputUploadResponse, error)
+	uploadInputFunc         func(ctx context.Context, uploadURL string, data []byte) error
+	createContextUploadFunc func(ctx context.Context, size int64) (*api.InputUploadResponse, error)
+	getExecutionStatusFunc  func(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error)
 }
 
 func (m *mockClientInterfaceForRun) RunCommand(
@@ -35,6 +47,40 @@ func (m *mockClientInterfaceForRun) GetLogs(ctx context.Context, executionID str
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClientInterfaceForRun) CreateStdinUpload(
+	ctx context.Context, size int64,
+) (*api.InputUploadResponse, error) {
+	if m.createStdinUploadFunc != nil {
+		return m.createStdinUploadFunc(ctx, size)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForRun) CreateContextUpload(
+	ctx context.Context, size int64,
+) (*api.InputUploadResponse, error) {
+	if m.createContextUploadFunc != nil {
+		return m.createContextUploadFunc(ctx, size)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForRun) UploadInput(ctx context.Context, uploadURL string, data []byte) error {
+	if m.uploadInputFunc != nil {
+		return m.uploadInputFunc(ctx, uploadURL, data)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForRun) GetExecutionStatus(
+	ctx context.Context, executionID string,
+) (*api.ExecutionStatusResponse, error) {
+	if m.getExecutionStatusFunc != nil {
+		return m.getExecutionStatusFunc(ctx, executionID)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockClientInterfaceForRun) FetchBackendLogs(_ context.Context, _ string) (*api.TraceResponse, error) {
 	return nil, nil
 }
@@ -48,6 +94,23 @@ func TestRunService_ExecuteCommand(t *testing.T) {
 		expectStream bool
 		verifyOutput func(*testing.T, *mockOutputInterface)
 	}{
+		{
+			name: "runs on behalf of another user",
+			request: ExecuteCommandRequest{
+				Command:    "echo hello",
+				OnBehalfOf: "dev@example.com",
+				WebURL:     "https://logs.example.com",
+			},
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Equal(t, "dev@example.com", req.OnBehalfOf)
+					return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "pending"}, nil
+				}
+				m.getLogsFunc = func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+					return &api.LogsResponse{ExecutionID: executionID, Status: string(constants.ExecutionSucceeded)}, nil
+				}
+			},
+		},
 		{
 			name: "successfully executes simple command",
 			request: ExecuteCommandRequest{
@@ -87,6 +150,69 @@ func TestRunService_ExecuteCommand(t *testing.T) {
 				assert.True(t, hasSuccess, "Expected Successf call")
 			},
 		},
+		{
+			name: "displays the shards of a parallel run without streaming logs",
+			request: ExecuteCommandRequest{
+				Command:  "./test-shard.sh",
+				Parallel: 2,
+				WebURL:   "https://logs.example.com",
+			},
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Equal(t, 2, req.Parallel)
+					return &api.ExecutionResponse{
+						GroupID: "group-abc",
+						Status:  string(constants.ExecutionStarting),
+						Command: "./test-shard.sh",
+						Shards: []api.ExecutionGroupShard{
+							{ShardIndex: 0, ExecutionID: "exec-0"},
+							{ShardIndex: 1, ExecutionID: "exec-1"},
+						},
+					}, nil
+				}
+			},
+			wantErr: false,
+			verifyOutput: func(t *testing.T, m *mockOutputInterface) {
+				var groupShown bool
+				var rows [][]string
+				for _, call := range m.calls {
+					if call.method == "KeyValue" && call.args[0] == "Group ID" {
+						groupShown = true
+					}
+					if call.method == "Table" {
+						rows = call.args[1].([][]string)
+					}
+				}
+				assert.True(t, groupShown, "Expected the group ID")
+				assert.Equal(t, [][]string{{"0", "exec-0"}, {"1", "exec-1"}}, rows)
+			},
+		},
+		{
+			name: "warns about the budget of the user",
+			request: ExecuteCommandRequest{
+				Command:  "./test-shard.sh",
+				Parallel: 2,
+				WebURL:   "https://logs.example.com",
+			},
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.runCommandFunc = func(_ context.Context, _ *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					return &api.ExecutionResponse{
+						GroupID:       "group-abc",
+						BudgetWarning: "you have used 85% of your monthly budget ($42.50 of $50.00)",
+					}, nil
+				}
+			},
+			verifyOutput: func(t *testing.T, m *mockOutputInterface) {
+				var warnings [][]any
+				for _, call := range m.calls {
+					if call.method == "Warningf" && call.args[0] == "Budget: %s" {
+						warnings = append(warnings, call.args[1].([]any))
+					}
+				}
+				require.Len(t, warnings, 1)
+				assert.Equal(t, "you have used 85% of your monthly budget ($42.50 of $50.00)", warnings[0][0])
+			},
+		},
 		{
 			name: "displays git repository information",
 			request: ExecuteCommandRequest{
@@ -130,6 +256,41 @@ func TestRunService_ExecuteCommand(t *testing.T) {
 				assert.True(t, hasGitRepo || hasGitRef, "Should display git information")
 			},
 		},
+		{
+			name: "sends the requested visibility",
+			request: ExecuteCommandRequest{
+				Command:    "./rotate-credentials.sh",
+				Visibility: "private",
+				WebURL:     "https://logs.example.com",
+			},
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Equal(t, "private", req.Visibility)
+					return &api.ExecutionResponse{
+						ExecutionID: "exec-private",
+						Status:      "pending",
+						Command:     "./rotate-credentials.sh",
+					}, nil
+				}
+				m.getLogsFunc = func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+					return &api.LogsResponse{
+						ExecutionID: executionID,
+						Status:      string(constants.ExecutionSucceeded),
+						Events:      []api.LogEvent{},
+					}, nil
+				}
+			},
+			wantErr: false,
+			verifyOutput: func(t *testing.T, m *mockOutputInterface) {
+				hasVisibility := false
+				for _, call := range m.calls {
+					if call.method == "Infof" && len(call.args) >= 2 && call.args[0] == "Visibility: %s" {
+						hasVisibility = true
+					}
+				}
+				assert.True(t, hasVisibility, "Should display the visibility")
+			},
+		},
 		{
 			name: "displays user environment variables",
 			request: ExecuteCommandRequest{
@@ -421,3 +582,269 @@ func TestRunService_ExecuteCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestRunService_ExecuteCommandWithStdin(t *testing.T) {
+	largeStdin := bytes.Repeat([]byte("x"), constants.MaxInlineStdinBytes+1)
+
+	tests := []struct {
+		name      string
+		stdin     []byte
+		setupMock func(*mockClientInterfaceForRun)
+		wantErr   bool
+	}{
+		{
+			name:  "sends small stdin inline",
+			stdin: []byte("a,b\n1,2\n"),
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n")), req.Stdin)
+					assert.Empty(t, req.StdinUploadID)
+					return &api.ExecutionResponse{ExecutionID: "exec-stdin", Status: "pending"}, nil
+				}
+			},
+		},
+		{
+			name:  "uploads large stdin",
+			stdin: largeStdin,
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.createStdinUploadFunc = func(_ context.Context, size int64) (*api.InputUploadResponse, error) {
+					assert.Equal(t, int64(len(largeStdin)), size)
+					return &api.InputUploadResponse{UploadID: "upload-123", UploadURL: "https://uploads.example.com"}, nil
+				}
+				m.uploadInputFunc = func(_ context.Context, uploadURL string, data []byte) error {
+					assert.Equal(t, "https://uploads.example.com", uploadURL)
+					assert.Equal(t, largeStdin, data)
+					return nil
+				}
+				m.runCommandFunc = func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Empty(t, req.Stdin)
+					assert.Equal(t, "upload-123", req.StdinUploadID)
+					return &api.ExecutionResponse{ExecutionID: "exec-stdin", Status: "pending"}, nil
+				}
+			},
+		},
+		{
+			name:  "returns upload errors without running the command",
+			stdin: largeStdin,
+			setupMock: func(m *mockClientInterfaceForRun) {
+				m.createStdinUploadFunc = func(_ context.Context, _ int64) (*api.InputUploadResponse, error) {
+					return &api.InputUploadResponse{UploadID: "upload-123", UploadURL: "https://uploads.example.com"}, nil
+				}
+				m.uploadInputFunc = func(_ context.Context, _ string, _ []byte) error {
+					return errors.New("upload failed")
+				}
+				m.runCommandFunc = func(_ context.Context, _ *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					t.Fatal("command should not run when the stdin upload fails")
+					return nil, nil
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterfaceForRun{
+				mockClientInterface: &mockClientInterface{},
+				getLogsFunc: func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+					return &api.LogsResponse{
+						ExecutionID: executionID,
+						Status:      string(constants.ExecutionSucceeded),
+					}, nil
+				},
+			}
+			tt.setupMock(mockClient)
+
+			service := NewRunService(mockClient, &mockOutputInterface{})
+			err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+				Command: "python process.py",
+				Stdin:   tt.stdin,
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadStdinData(t *testing.T) {
+	data, err := readStdinData(strings.NewReader("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), data)
+
+	_, err = readStdinData(io.LimitReader(zeroReader{}, constants.MaxStdinBytes+1))
+	assert.ErrorContains(t, err, "stdin exceeds the maximum size")
+}
+
+// zeroReader is an endless source of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestRunService_ExecuteCommandWait(t *testing.T) {
+	exitCode := 3
+	tests := []struct {
+		name         string
+		statuses     []string
+		exitCode     *int
+		wantErr      bool
+		wantExitCode int
+	}{
+		{name: "succeeded", statuses: []string{"RUNNING", string(constants.ExecutionSucceeded)}},
+		{
+			name:         "failed with the exit code of the command",
+			statuses:     []string{string(constants.ExecutionFailed)},
+			exitCode:     &exitCode,
+			wantErr:      true,
+			wantExitCode: 3,
+		},
+		{name: "stopped", statuses: []string{string(constants.ExecutionStopped)}, wantErr: true, wantExitCode: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			mockClient := &mockClientInterfaceForRun{
+				mockClientInterface: &mockClientInterface{},
+				runCommandFunc: func(_ context.Context, _ *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+				},
+				getLogsFunc: func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+					return &api.LogsResponse{ExecutionID: executionID, Status: tt.statuses[len(tt.statuses)-1]}, nil
+				},
+				getExecutionStatusFunc: func(_ context.Context, executionID string) (*api.ExecutionStatusResponse, error) {
+					status := tt.statuses[min(polls, len(tt.statuses)-1)]
+					polls++
+					return &api.ExecutionStatusResponse{ExecutionID: executionID, Status: status, ExitCode: tt.exitCode}, nil
+				},
+			}
+			service := NewRunService(mockClient, &mockOutputInterface{})
+			service.streamLogs = nil
+			service.pollInterval = 0
+
+			err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{Command: "make test", Wait: true})
+			assert.Equal(t, len(tt.statuses), polls)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var failedErr *ExecutionFailedError
+			assert.ErrorAs(t, err, &failedErr)
+			assert.Equal(t, tt.wantExitCode, exitCodeOf(err))
+		})
+	}
+
+	assert.Equal(t, 1, exitCodeOf(errors.New("failed to run command")))
+}
+
+func TestRunService_ExecuteCommandArgs(t *testing.T) {
+	var got *api.ExecutionRequest
+	mockClient := &mockClientInterfaceForRun{
+		mockClientInterface: &mockClientInterface{},
+		runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+			got = req
+			return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+		},
+	}
+	service := NewRunService(mockClient, &mockOutputInterface{})
+
+	command, args := commandFromArgs([]string{"echo", "hello world"}, false)
+	err := service.ExecuteCommand(context.Background(),
+		&ExecuteCommandRequest{Command: command, Args: args, SubmitOnly: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"echo", "hello world"}, got.Args)
+	assert.Equal(t, "echo 'hello world'", got.Command, "older backends run the same arguments with the shell")
+}
+
+func TestRunService_ExecuteCommandWorkDirAndShell(t *testing.T) {
+	var got *api.ExecutionRequest
+	mockClient := &mockClientInterfaceForRun{
+		mockClientInterface: &mockClientInterface{},
+		runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+			got = req
+			return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+		},
+	}
+	service := NewRunService(mockClient, &mockOutputInterface{})
+
+	err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+		Command: "shopt -s globstar && ls **/*.go", Shell: "bash", WorkDir: "web", SubmitOnly: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "bash", got.Shell)
+	assert.Equal(t, "web", got.WorkDir)
+}
+
+func TestRunService_ExecuteCommandLogRetention(t *testing.T) {
+	var got *api.ExecutionRequest
+	mockClient := &mockClientInterfaceForRun{
+		mockClientInterface: &mockClientInterface{},
+		runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+			got = req
+			return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+		},
+	}
+	service := NewRunService(mockClient, &mockOutputInterface{})
+
+	err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+		Command: "make load-test", LogRetention: 1, SubmitOnly: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.LogRetentionDays)
+}
+
+func TestRunService_ExecuteCommandLockWait(t *testing.T) {
+	lockHeld := &client.APIError{StatusCode: 409, Code: "LOCK_HELD", Message: "lock deploy-prod is held"}
+	tests := []struct {
+		name         string
+		lockWait     time.Duration
+		pollInterval time.Duration
+		heldAttempts int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "fails fast without a wait", heldAttempts: 1, wantAttempts: 1, wantErr: true},
+		{name: "starts once the lock is released", lockWait: time.Minute, heldAttempts: 2, wantAttempts: 3},
+		{
+			name:     "gives up when the next attempt would be past the wait",
+			lockWait: time.Minute, pollInterval: time.Hour, heldAttempts: 5, wantAttempts: 1, wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			mockClient := &mockClientInterfaceForRun{
+				mockClientInterface: &mockClientInterface{},
+				runCommandFunc: func(_ context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+					assert.Equal(t, "deploy-prod", req.Lock)
+					attempts++
+					if attempts <= tt.heldAttempts {
+						return nil, lockHeld
+					}
+					return &api.ExecutionResponse{ExecutionID: "exec-123", Status: "STARTING"}, nil
+				},
+			}
+			service := NewRunService(mockClient, &mockOutputInterface{})
+			service.lockPollInterval = tt.pollInterval
+
+			err := service.ExecuteCommand(context.Background(), &ExecuteCommandRequest{
+				Command: "./deploy.sh", Lock: "deploy-prod", LockWait: tt.lockWait, SubmitOnly: true,
+			})
+
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr {
+				assert.True(t, client.HasErrorCode(err, "LOCK_HELD"))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
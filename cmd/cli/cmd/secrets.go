@@ -4,17 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
 	"github.com/runvoy/runvoy/internal/constants"
 
 	"github.com/spf13/cobra"
 )
 
-const createSecretArgCount = 3
+const (
+	createSecretArgCount = 3
+	importSecretsMinArgs = 2
+
+	defaultSecretsAuditDays = 90
+)
 
 var secretsCmd = &cobra.Command{
 	Use:   "secrets",
@@ -54,12 +62,13 @@ func runCreateSecret(cmd *cobra.Command, args []string) {
 }
 
 var getSecretCmd = &cobra.Command{
-	Use:     "get <name>",
-	Short:   "Get a secret by name",
-	Long:    `Retrieve a secret by its name, including its value`,
-	Example: fmt.Sprintf(`  - %s secrets get github-token`, constants.ProjectName),
-	Run:     runGetSecret,
-	Args:    cobra.ExactArgs(1),
+	Use:               "get <name>",
+	Short:             "Get a secret by name",
+	Long:              `Retrieve a secret by its name, including its value`,
+	Example:           fmt.Sprintf(`  - %s secrets get github-token`, constants.ProjectName),
+	Run:               runGetSecret,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchSecretNames),
 }
 
 func init() {
@@ -103,8 +112,9 @@ var updateSecretCmd = &cobra.Command{
 		constants.ProjectName,
 		constants.ProjectName,
 	),
-	Run:  runUpdateSecret,
-	Args: cobra.ExactArgs(1),
+	Run:               runUpdateSecret,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchSecretNames),
 }
 
 var updateSecretKeyName string
@@ -137,12 +147,13 @@ func runUpdateSecret(cmd *cobra.Command, args []string) {
 }
 
 var deleteSecretCmd = &cobra.Command{
-	Use:     "delete <name>",
-	Short:   "Delete a secret",
-	Long:    `Delete a secret by its name`,
-	Example: fmt.Sprintf(`  - %s secrets delete github-token`, constants.ProjectName),
-	Run:     runDeleteSecret,
-	Args:    cobra.ExactArgs(1),
+	Use:               "delete <name>",
+	Short:             "Delete a secret",
+	Long:              `Delete a secret by its name`,
+	Example:           fmt.Sprintf(`  - %s secrets delete github-token`, constants.ProjectName),
+	Run:               runDeleteSecret,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchSecretNames),
 }
 
 func init() {
@@ -157,6 +168,109 @@ func runDeleteSecret(cmd *cobra.Command, args []string) {
 	})
 }
 
+var exportSecretsCmd = &cobra.Command{
+	Use:   "export <name>...",
+	Short: "Export secrets to an encrypted bundle",
+	Long: fmt.Sprintf(`Export the listed secrets, with their values, to a bundle encrypted with a passphrase,
+to import them into another environment with secrets import. Requires the admin role.
+
+The passphrase is read from %s or prompted for.`, backupPassphraseEnvVar),
+	Example: fmt.Sprintf(
+		"  - %s secrets export github-token db-password --output secrets.bundle",
+		constants.ProjectName,
+	),
+	Run:               runExportSecrets,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeEveryArg(fetchSecretNames),
+}
+
+var exportSecretsOutput string
+
+func init() {
+	secretsCmd.AddCommand(exportSecretsCmd)
+	exportSecretsCmd.Flags().StringVarP(&exportSecretsOutput, "output", "o", "secrets.bundle", "Bundle file path")
+}
+
+func runExportSecrets(cmd *cobra.Command, args []string) {
+	passphrase, err := readBackupPassphrase(true)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewSecretsService(c, NewOutputWrapper())
+		return service.ExportSecrets(ctx, args, passphrase, exportSecretsOutput)
+	})
+}
+
+var importSecretsCmd = &cobra.Command{
+	Use:   "import <bundle> <name>...",
+	Short: "Import secrets from an encrypted bundle",
+	Long: `Import the listed secrets of a bundle created with secrets export. Only the listed secrets
+are imported, and their values are encrypted with this environment's key. Requires the admin role.
+
+Existing secrets are left unchanged unless --overwrite is set.`,
+	Example: fmt.Sprintf(
+		"  - %s secrets import secrets.bundle github-token db-password\n"+
+			"  - %s secrets import secrets.bundle github-token --overwrite",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run:  runImportSecrets,
+	Args: cobra.MinimumNArgs(importSecretsMinArgs),
+}
+
+var importSecretsOverwrite bool
+
+func init() {
+	secretsCmd.AddCommand(importSecretsCmd)
+	importSecretsCmd.Flags().BoolVar(&importSecretsOverwrite, "overwrite", false,
+		"Update the secrets that already exist")
+}
+
+func runImportSecrets(cmd *cobra.Command, args []string) {
+	bundle, err := os.ReadFile(args[0])
+	if err != nil {
+		output.Fatalf("failed to read bundle: %v", err)
+	}
+	passphrase, err := readBackupPassphrase(false)
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewSecretsService(c, NewOutputWrapper())
+		return service.ImportSecrets(ctx, bundle, args[1:], passphrase, importSecretsOverwrite)
+	})
+}
+
+var auditSecretsCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List stale secrets",
+	Long: `List the secrets no execution has used in the last --days days, oldest usage first.
+Secrets never used are listed once they are older than --days days.`,
+	Example: fmt.Sprintf(
+		"  - %s secrets audit\n"+
+			"  - %s secrets audit --days 30",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Run: runAuditSecrets,
+}
+
+var auditSecretsDays int
+
+func init() {
+	secretsCmd.AddCommand(auditSecretsCmd)
+	auditSecretsCmd.Flags().IntVar(&auditSecretsDays, "days", defaultSecretsAuditDays,
+		"Number of days without usage after which a secret is stale")
+}
+
+func runAuditSecrets(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewSecretsService(c, NewOutputWrapper())
+		return service.AuditSecrets(ctx, auditSecretsDays)
+	})
+}
+
 // SecretsService handles secrets management logic.
 type SecretsService struct {
 	client client.Interface
@@ -233,6 +347,10 @@ func (s *SecretsService) GetSecret(ctx context.Context, name string) error {
 	s.output.KeyValue("Created At", secret.CreatedAt.UTC().Format(time.DateTime))
 	s.output.KeyValue("Updated By", secret.UpdatedBy)
 	s.output.KeyValue("Updated At", secret.UpdatedAt.UTC().Format(time.DateTime))
+	s.output.KeyValue("Last Used At", formatSecretLastUsedAt(secret))
+	if secret.LastUsedByExecutionID != "" {
+		s.output.KeyValue("Last Used By Execution", secret.LastUsedByExecutionID)
+	}
 	s.output.Blank()
 	s.output.Successf("Secret retrieved successfully")
 	return nil
@@ -271,6 +389,67 @@ func (s *SecretsService) ListSecrets(ctx context.Context) error {
 	return nil
 }
 
+// AuditSecrets lists the secrets not used by any execution in the last days, oldest usage first.
+// Secrets never used are stale once they were created more than days ago.
+func (s *SecretsService) AuditSecrets(ctx context.Context, days int) error {
+	if days <= 0 {
+		return errors.New("--days must be positive")
+	}
+	s.output.Infof("Auditing secrets not used in the last %d days…", days)
+
+	resp, err := s.client.ListSecrets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -days)
+	stale := make([]*api.Secret, 0, len(resp.Secrets))
+	for _, secret := range resp.Secrets {
+		if secretLastActivity(secret).Before(cutoff) {
+			stale = append(stale, secret)
+		}
+	}
+	slices.SortStableFunc(stale, func(a, b *api.Secret) int {
+		return secretLastActivity(a).Compare(secretLastActivity(b))
+	})
+
+	if len(stale) == 0 {
+		s.output.Blank()
+		s.output.Successf("No stale secrets found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(stale))
+	for _, secret := range stale {
+		lastExecution := secret.LastUsedByExecutionID
+		if lastExecution == "" {
+			lastExecution = "-"
+		}
+		rows = append(rows, []string{
+			s.output.Bold(secret.Name),
+			secret.KeyName,
+			formatSecretLastUsedAt(secret),
+			lastExecution,
+			secret.CreatedBy,
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table(
+		[]string{
+			"Name",
+			"Key Name",
+			"Last Used At (UTC)",
+			"Last Execution",
+			"Created By",
+		},
+		rows,
+	)
+	s.output.Blank()
+	s.output.Warningf("%d of %d secrets not used in the last %d days", len(stale), len(resp.Secrets), days)
+	return nil
+}
+
 // UpdateSecret updates a secret's metadata and/or value.
 func (s *SecretsService) UpdateSecret(ctx context.Context, name, keyName, value, description string) error {
 	s.output.Infof("Updating secret %s...", name)
@@ -318,6 +497,80 @@ func (s *SecretsService) DeleteSecret(ctx context.Context, name string) error {
 	return nil
 }
 
+// ExportSecrets exports the listed secrets to an encrypted bundle file.
+func (s *SecretsService) ExportSecrets(ctx context.Context, names []string, passphrase, path string) error {
+	s.output.Infof("Exporting %d secrets...", len(names))
+
+	resp, err := s.client.ExportSecrets(ctx, api.ExportSecretsRequest{
+		Names:      names,
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export secrets: %w", err)
+	}
+	if err = os.WriteFile(path, resp.Bundle, 0o600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	s.output.Successf("Secrets exported successfully")
+	s.output.KeyValue("Secrets", strings.Join(resp.Secrets, ", "))
+	s.output.KeyValue("Bundle", path)
+	return nil
+}
+
+// ImportSecrets imports the listed secrets of an encrypted bundle.
+func (s *SecretsService) ImportSecrets(
+	ctx context.Context,
+	bundle []byte,
+	names []string,
+	passphrase string,
+	overwrite bool,
+) error {
+	s.output.Infof("Importing %d secrets...", len(names))
+
+	resp, err := s.client.ImportSecrets(ctx, api.ImportSecretsRequest{
+		Bundle:     bundle,
+		Passphrase: passphrase,
+		Names:      names,
+		Overwrite:  overwrite,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import secrets: %w", err)
+	}
+
+	s.output.Successf("Secrets imported successfully")
+	s.output.KeyValue("Created", formatSecretNames(resp.Created))
+	s.output.KeyValue("Updated", formatSecretNames(resp.Updated))
+	s.output.KeyValue("Skipped", formatSecretNames(resp.Skipped))
+	if len(resp.Skipped) > 0 {
+		s.output.Blank()
+		s.output.Infof("Skipped secrets already exist, use --overwrite to update them")
+	}
+	return nil
+}
+
+// secretLastActivity returns when the secret was last used, or created when it was never used.
+func secretLastActivity(secret *api.Secret) time.Time {
+	if secret.LastUsedAt != nil {
+		return *secret.LastUsedAt
+	}
+	return secret.CreatedAt
+}
+
+func formatSecretLastUsedAt(secret *api.Secret) string {
+	if secret.LastUsedAt == nil {
+		return "never"
+	}
+	return secret.LastUsedAt.UTC().Format(time.DateTime)
+}
+
+func formatSecretNames(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
 // formatSecrets formats secret data into table rows.
 func (s *SecretsService) formatSecrets(secrets []*api.Secret) [][]string {
 	rows := make([][]string, 0, len(secrets))
@@ -3,10 +3,13 @@ package cmd
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/runvoy/runvoy/internal/api"
 )
@@ -14,11 +17,13 @@ import (
 // mockClientInterfaceForSecrets extends mockClientInterface with secrets management methods
 type mockClientInterfaceForSecrets struct {
 	*mockClientInterface
-	createSecretFunc func(ctx context.Context, req api.CreateSecretRequest) (*api.CreateSecretResponse, error)
-	getSecretFunc    func(ctx context.Context, name string) (*api.GetSecretResponse, error)
-	listSecretsFunc  func(ctx context.Context) (*api.ListSecretsResponse, error)
-	updateSecretFunc func(ctx context.Context, name string, req api.UpdateSecretRequest) (*api.UpdateSecretResponse, error)
-	deleteSecretFunc func(ctx context.Context, name string) (*api.DeleteSecretResponse, error)
+	createSecretFunc  func(ctx context.Context, req api.CreateSecretRequest) (*api.CreateSecretResponse, error)
+	getSecretFunc     func(ctx context.Context, name string) (*api.GetSecretResponse, error)
+	listSecretsFunc   func(ctx context.Context) (*api.ListSecretsResponse, error)
+	updateSecretFunc  func(ctx context.Context, name string, req api.UpdateSecretRequest) (*api.UpdateSecretResponse, error)
+	deleteSecretFunc  func(ctx context.Context, name string) (*api.DeleteSecretResponse, error)
+	exportSecretsFunc func(ctx context.Context, req api.ExportSecretsRequest) (*api.ExportSecretsResponse, error)
+	importSecretsFunc func(ctx context.Context, req api.ImportSecretsRequest) (*api.ImportSecretsResponse, error)
 }
 
 func (m *mockClientInterfaceForSecrets) CreateSecret(
@@ -63,6 +68,24 @@ func (m *mockClientInterfaceForSecrets) DeleteSecret(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClientInterfaceForSecrets) ExportSecrets(
+	ctx context.Context, req api.ExportSecretsRequest,
+) (*api.ExportSecretsResponse, error) {
+	if m.exportSecretsFunc != nil {
+		return m.exportSecretsFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterfaceForSecrets) ImportSecrets(
+	ctx context.Context, req api.ImportSecretsRequest,
+) (*api.ImportSecretsResponse, error) {
+	if m.importSecretsFunc != nil {
+		return m.importSecretsFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockClientInterfaceForSecrets) FetchBackendLogs(_ context.Context, _ string) (*api.TraceResponse, error) {
 	return nil, nil
 }
@@ -414,6 +437,55 @@ func TestSecretsService_ListSecrets(t *testing.T) {
 	}
 }
 
+func TestSecretsService_AuditSecrets(t *testing.T) {
+	now := time.Now().UTC()
+	recent := now.AddDate(0, 0, -5)
+	old := now.AddDate(0, 0, -200)
+	older := now.AddDate(0, 0, -400)
+	mockClient := &mockClientInterfaceForSecrets{
+		mockClientInterface: &mockClientInterface{},
+		listSecretsFunc: func(_ context.Context) (*api.ListSecretsResponse, error) {
+			return &api.ListSecretsResponse{
+				Secrets: []*api.Secret{
+					{Name: "used-recently", KeyName: "A", CreatedAt: older, LastUsedAt: &recent},
+					{Name: "used-long-ago", KeyName: "B", CreatedAt: older, LastUsedAt: &old,
+						LastUsedByExecutionID: "exec-1"},
+					{Name: "never-used-old", KeyName: "C", CreatedAt: older},
+					{Name: "never-used-new", KeyName: "D", CreatedAt: recent},
+				},
+				Total: 4,
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewSecretsService(mockClient, mockOutput)
+
+	err := service.AuditSecrets(context.Background(), 90)
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, c := range mockOutput.calls {
+		if c.method == "Table" {
+			rows = c.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, "never-used-old", rows[0][0], "oldest activity first")
+	assert.Equal(t, "never", rows[0][2])
+	assert.Equal(t, "-", rows[0][3])
+	assert.Equal(t, "used-long-ago", rows[1][0])
+	assert.Equal(t, "exec-1", rows[1][3])
+}
+
+func TestSecretsService_AuditSecrets_InvalidDays(t *testing.T) {
+	service := NewSecretsService(&mockClientInterfaceForSecrets{mockClientInterface: &mockClientInterface{}},
+		&mockOutputInterface{})
+
+	err := service.AuditSecrets(context.Background(), 0)
+
+	assert.Error(t, err)
+}
+
 func TestSecretsService_UpdateSecret(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -661,3 +733,72 @@ func TestSecretsService_DeleteSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretsService_ExportSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.bundle")
+	mockClient := &mockClientInterfaceForSecrets{
+		mockClientInterface: &mockClientInterface{},
+		exportSecretsFunc: func(_ context.Context, req api.ExportSecretsRequest) (*api.ExportSecretsResponse, error) {
+			assert.Equal(t, []string{"github-token", "db-password"}, req.Names)
+			assert.Equal(t, "correct horse battery staple", req.Passphrase)
+			return &api.ExportSecretsResponse{Bundle: []byte("sealed"), Secrets: req.Names}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewSecretsService(mockClient, mockOutput)
+
+	err := service.ExportSecrets(context.Background(), []string{"github-token", "db-password"},
+		"correct horse battery staple", path)
+
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "sealed", string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestSecretsService_ImportSecrets(t *testing.T) {
+	mockClient := &mockClientInterfaceForSecrets{
+		mockClientInterface: &mockClientInterface{},
+		importSecretsFunc: func(_ context.Context, req api.ImportSecretsRequest) (*api.ImportSecretsResponse, error) {
+			assert.Equal(t, []byte("sealed"), req.Bundle)
+			assert.Equal(t, []string{"github-token"}, req.Names)
+			assert.True(t, req.Overwrite)
+			return &api.ImportSecretsResponse{
+				Created: []string{},
+				Updated: []string{"github-token"},
+				Skipped: []string{},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewSecretsService(mockClient, mockOutput)
+
+	err := service.ImportSecrets(context.Background(), []byte("sealed"), []string{"github-token"},
+		"correct horse battery staple", true)
+
+	require.NoError(t, err)
+	keyValues := map[string]string{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1].(string)
+		}
+	}
+	assert.Equal(t, map[string]string{"Created": "-", "Updated": "github-token", "Skipped": "-"}, keyValues)
+}
+
+func TestSecretsService_ImportSecretsError(t *testing.T) {
+	mockClient := &mockClientInterfaceForSecrets{
+		mockClientInterface: &mockClientInterface{},
+		importSecretsFunc: func(_ context.Context, _ api.ImportSecretsRequest) (*api.ImportSecretsResponse, error) {
+			return nil, errors.New("failed to decrypt secrets bundle")
+		},
+	}
+	service := NewSecretsService(mockClient, &mockOutputInterface{})
+
+	err := service.ImportSecrets(context.Background(), nil, []string{"github-token"}, "passphrase", false)
+
+	assert.ErrorContains(t, err, "failed to import secrets: failed to decrypt secrets bundle")
+}
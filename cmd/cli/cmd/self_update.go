@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/client/selfupdate"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	selfUpdateChannel string
+	selfUpdateDryRun  bool
+	selfUpdateForce   bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update the CLI to the latest release",
+	Long: `Find the latest release of the CLI on GitHub, download the archive of this platform, verify it and
+replace the running binary with the one it contains.
+
+The archive must match the SHA-256 of the checksums file of the release, and the checksums file must be
+signed with the release key built into the CLI. Development builds have no release key and only verify
+the checksum. The binary is replaced atomically: it is written next to the current one and renamed over it.
+
+The stable channel only installs releases, the beta channel pre-releases too. The channel is read from
+update_channel in the configuration, stable by default, and --channel overrides it. With --dry-run, the
+archive is still downloaded and verified but the binary is left untouched.`,
+	Example: fmt.Sprintf(
+		"  # Check what would be installed, without replacing the binary\n"+
+			"  %s self-update --dry-run\n\n"+
+			"  # Install the latest pre-release\n"+
+			"  %s self-update --channel beta",
+		constants.ProjectName,
+		constants.ProjectName,
+	),
+	Args: cobra.NoArgs,
+	Run:  selfUpdateRun,
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+	selfUpdateCmd.Flags().StringVar(&selfUpdateChannel, "channel", "",
+		"Release channel: stable or beta. Defaults to update_channel of the configuration, or stable")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateDryRun, "dry-run", false,
+		"Download and verify the release without replacing the binary")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false,
+		"Install the latest release of the channel even if it is not newer than this CLI")
+}
+
+func selfUpdateRun(cmd *cobra.Command, _ []string) {
+	channel := selfUpdateChannel
+	if cfg, err := getConfigFromContext(cmd); err == nil && channel == "" {
+		channel = cfg.UpdateChannel
+	}
+
+	updater, err := selfupdate.New(constants.GetReleaseSigningKey())
+	if err != nil {
+		output.Fatalf(err.Error())
+	}
+	path, err := os.Executable()
+	if err == nil {
+		path, err = filepath.EvalSymlinks(path)
+	}
+	if err != nil {
+		output.Fatalf("failed to locate the CLI binary: %v", err)
+	}
+
+	service := NewSelfUpdateService(updater, updater.PublicKey != nil, selfupdate.Replace, NewOutputWrapper())
+	if err = service.Update(cmd.Context(), &SelfUpdateOptions{
+		Channel: channel,
+		Path:    path,
+		DryRun:  selfUpdateDryRun,
+		Force:   selfUpdateForce,
+	}); err != nil {
+		output.Fatalf(err.Error())
+	}
+}
+
+// ReleaseSource finds and downloads the releases of the CLI.
+type ReleaseSource interface {
+	Latest(ctx context.Context, channel string) (*selfupdate.Release, error)
+	Download(ctx context.Context, release *selfupdate.Release) ([]byte, error)
+}
+
+// SelfUpdateOptions selects the release installed by SelfUpdateService.Update.
+type SelfUpdateOptions struct {
+	Channel string
+	// Path is the binary to replace.
+	Path   string
+	DryRun bool
+	Force  bool
+}
+
+// SelfUpdateService handles updating the CLI binary.
+type SelfUpdateService struct {
+	releases ReleaseSource
+	signed   bool
+	replace  func(path string, binary []byte) error
+	output   OutputInterface
+	current  string
+}
+
+// NewSelfUpdateService creates a new SelfUpdateService replacing the binary of the running version.
+// signed tells whether the releases are verified with the release key.
+func NewSelfUpdateService(
+	releases ReleaseSource,
+	signed bool,
+	replace func(path string, binary []byte) error,
+	outputter OutputInterface,
+) *SelfUpdateService {
+	return &SelfUpdateService{
+		releases: releases,
+		signed:   signed,
+		replace:  replace,
+		output:   outputter,
+		current:  *constants.GetVersion(),
+	}
+}
+
+// Update installs the latest release of the channel when it is newer than the running version.
+func (s *SelfUpdateService) Update(ctx context.Context, opts *SelfUpdateOptions) error {
+	channel, err := selfupdate.ParseChannel(opts.Channel)
+	if err != nil {
+		return err
+	}
+	release, err := s.releases.Latest(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	s.output.KeyValue("Channel", channel)
+	s.output.KeyValue("Current version", s.current)
+	s.output.KeyValue("Latest version", release.Version.String())
+	s.output.Blank()
+
+	current, parseErr := semver.Parse(s.current)
+	if parseErr == nil && release.Version.Compare(current) <= 0 && !opts.Force {
+		s.output.Successf("Already up to date with the latest %s release", channel)
+		return nil
+	}
+	if parseErr != nil {
+		s.output.Warningf("Cannot compare with the running version %q, installing the latest release", s.current)
+	}
+	if !s.signed {
+		s.output.Warningf("This build has no release signing key, only the checksum of the release is verified")
+	}
+
+	binary, err := s.releases.Download(ctx, release)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", release.Tag, err)
+	}
+	if opts.DryRun {
+		s.output.Successf("Downloaded and verified %s", release.Tag)
+		s.output.Infof("Dry run, %s was not replaced", opts.Path)
+		return nil
+	}
+
+	if err = s.replace(opts.Path, binary); err != nil {
+		return err
+	}
+	s.output.Successf("Updated %s to %s", opts.Path, release.Tag)
+	return nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/client/selfupdate"
+	"github.com/runvoy/runvoy/internal/semver"
+)
+
+type mockReleaseSource struct {
+	release      *selfupdate.Release
+	channel      string
+	downloadErr  error
+	downloadedAt int
+}
+
+func (m *mockReleaseSource) Latest(_ context.Context, channel string) (*selfupdate.Release, error) {
+	m.channel = channel
+	return m.release, nil
+}
+
+func (m *mockReleaseSource) Download(_ context.Context, _ *selfupdate.Release) ([]byte, error) {
+	m.downloadedAt++
+	return []byte("binary"), m.downloadErr
+}
+
+func newTestSelfUpdate(
+	current, latest string,
+) (*SelfUpdateService, *mockReleaseSource, *mockOutputInterface, *[]string) {
+	version, _ := semver.Parse(latest)
+	source := &mockReleaseSource{release: &selfupdate.Release{Version: version, Tag: latest}}
+	out := &mockOutputInterface{}
+	replaced := &[]string{}
+	service := NewSelfUpdateService(source, true, func(path string, _ []byte) error {
+		*replaced = append(*replaced, path)
+		return nil
+	}, out)
+	service.current = current
+	return service, source, out, replaced
+}
+
+func TestSelfUpdateService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces the binary with a newer release", func(t *testing.T) {
+		service, source, _, replaced := newTestSelfUpdate("v1.0.0", "v1.1.0")
+
+		require.NoError(t, service.Update(ctx, &SelfUpdateOptions{Path: "/usr/local/bin/runvoy"}))
+
+		assert.Equal(t, selfupdate.ChannelStable, source.channel)
+		assert.Equal(t, []string{"/usr/local/bin/runvoy"}, *replaced)
+	})
+
+	t.Run("does nothing when up to date", func(t *testing.T) {
+		service, source, _, replaced := newTestSelfUpdate("v1.1.0", "v1.1.0")
+
+		require.NoError(t, service.Update(ctx, &SelfUpdateOptions{Channel: "beta", Path: "runvoy"}))
+
+		assert.Equal(t, selfupdate.ChannelBeta, source.channel)
+		assert.Zero(t, source.downloadedAt)
+		assert.Empty(t, *replaced)
+	})
+
+	t.Run("reinstalls with force", func(t *testing.T) {
+		service, _, _, replaced := newTestSelfUpdate("v1.1.0", "v1.1.0")
+
+		require.NoError(t, service.Update(ctx, &SelfUpdateOptions{Path: "runvoy", Force: true}))
+
+		assert.Len(t, *replaced, 1)
+	})
+
+	t.Run("dry run verifies the release without replacing the binary", func(t *testing.T) {
+		service, source, _, replaced := newTestSelfUpdate("v1.0.0", "v1.1.0")
+
+		require.NoError(t, service.Update(ctx, &SelfUpdateOptions{Path: "runvoy", DryRun: true}))
+
+		assert.Equal(t, 1, source.downloadedAt)
+		assert.Empty(t, *replaced)
+	})
+
+	t.Run("does not replace the binary when the verification fails", func(t *testing.T) {
+		service, source, _, replaced := newTestSelfUpdate("v1.0.0", "v1.1.0")
+		source.downloadErr = errors.New("checksum mismatch")
+
+		err := service.Update(ctx, &SelfUpdateOptions{Path: "runvoy"})
+
+		require.ErrorContains(t, err, "failed to download v1.1.0: checksum mismatch")
+		assert.Empty(t, *replaced)
+	})
+
+	t.Run("warns when the releases are not signed", func(t *testing.T) {
+		service, _, out, _ := newTestSelfUpdate("0.0.0-development", "v1.1.0")
+		service.signed = false
+
+		require.NoError(t, service.Update(ctx, &SelfUpdateOptions{Path: "runvoy", DryRun: true}))
+
+		warnings := 0
+		for _, c := range out.calls {
+			if c.method == "Warningf" {
+				warnings++
+			}
+		}
+		assert.Equal(t, 1, warnings, "the development version is a pre-release and still compares")
+	})
+
+	t.Run("rejects an unknown channel", func(t *testing.T) {
+		service, _, _, _ := newTestSelfUpdate("v1.0.0", "v1.1.0")
+
+		assert.ErrorContains(t, service.Update(ctx, &SelfUpdateOptions{Channel: "nightly"}), "invalid update channel")
+	})
+}
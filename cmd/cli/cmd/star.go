@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var starCmd = &cobra.Command{
+	Use:   "star <execution-id>",
+	Short: "Star a command execution",
+	Long: `Star a command execution to find it again later.
+Starred executions are marked in the list command output and listed with list --starred.`,
+	Example: fmt.Sprintf(`  - %s star 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10
+  - %s list --starred`, constants.ProjectName, constants.ProjectName),
+	Run:               starRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
+}
+
+var unstarCmd = &cobra.Command{
+	Use:               "unstar <execution-id>",
+	Short:             "Unstar a command execution",
+	Example:           fmt.Sprintf(`  - %s unstar 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10`, constants.ProjectName),
+	Run:               unstarRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
+}
+
+func init() {
+	rootCmd.AddCommand(starCmd)
+	rootCmd.AddCommand(unstarCmd)
+}
+
+func starRun(cmd *cobra.Command, args []string) {
+	executionID := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewStarService(c, NewOutputWrapper()).StarExecution(ctx, executionID, true)
+	})
+}
+
+func unstarRun(cmd *cobra.Command, args []string) {
+	executionID := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		return NewStarService(c, NewOutputWrapper()).StarExecution(ctx, executionID, false)
+	})
+}
+
+// StarService handles starring and unstarring executions.
+type StarService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewStarService creates a new StarService with the provided dependencies.
+func NewStarService(apiClient client.Interface, outputter OutputInterface) *StarService {
+	return &StarService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// StarExecution stars the execution, or unstars it when starred is false.
+func (s *StarService) StarExecution(ctx context.Context, executionID string, starred bool) error {
+	resp, err := s.client.StarExecution(ctx, executionID, starred)
+	if err != nil {
+		if starred {
+			return fmt.Errorf("failed to star execution: %w", err)
+		}
+		return fmt.Errorf("failed to unstar execution: %w", err)
+	}
+
+	if resp.Starred {
+		s.output.Successf("Execution %s starred", resp.ExecutionID)
+	} else {
+		s.output.Successf("Execution %s unstarred", resp.ExecutionID)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func TestStarService_StarExecution(t *testing.T) {
+	for _, starred := range []bool{true, false} {
+		mockClient := &mockClientInterface{
+			starFunc: func(_ context.Context, executionID string, s bool) (*api.StarExecutionResponse, error) {
+				assert.Equal(t, "exec-123", executionID)
+				assert.Equal(t, starred, s)
+				return &api.StarExecutionResponse{ExecutionID: executionID, Starred: s}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service := NewStarService(mockClient, mockOutput)
+
+		err := service.StarExecution(context.Background(), "exec-123", starred)
+
+		require.NoError(t, err)
+		require.Len(t, mockOutput.calls, 1)
+		if starred {
+			assert.Equal(t, "Execution %s starred", mockOutput.calls[0].args[0])
+		} else {
+			assert.Equal(t, "Execution %s unstarred", mockOutput.calls[0].args[0])
+		}
+	}
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			starFunc: func(_ context.Context, _ string, _ bool) (*api.StarExecutionResponse, error) {
+				return nil, errors.New("execution not found")
+			},
+		}
+		service := NewStarService(mockClient, &mockOutputInterface{})
+
+		err := service.StarExecution(context.Background(), "exec-123", true)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to star execution")
+	})
+}
@@ -2,24 +2,44 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/client"
 	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
 
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
-	Use:   "status <execution-id>",
+	Use:   "status <execution-id|group-id>",
 	Short: "Get the status of a command execution",
-	Run:   statusRun, Args: cobra.ExactArgs(1),
+	Long: `Get the status of a command execution.
+
+Given the group ID of a parallel run (run --parallel), the status of every shard is shown along with
+the status of the group and its combined exit code: 0 when all shards succeeded, otherwise the exit
+code of the first shard that did not.
+
+With --events, the lifecycle timeline of the execution is shown as well: when it was submitted,
+provisioned, pulled its image, started running and stopped, with the reasons reported by the
+compute platform. It tells where a slow start spent its time and why an execution failed.`,
+	Run: statusRun, Args: cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
 }
 
+var statusShowEvents bool
+
 func init() {
+	statusCmd.Flags().BoolVar(&statusShowEvents, "events", false,
+		"Show the lifecycle timeline of the execution")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -33,7 +53,7 @@ func statusRun(cmd *cobra.Command, args []string) {
 
 	c := client.New(cfg, slog.Default())
 	service := NewStatusService(c, NewOutputWrapper())
-	if err = service.DisplayStatus(cmd.Context(), executionID); err != nil {
+	if err = service.DisplayStatus(cmd.Context(), executionID, statusShowEvents); err != nil {
 		output.Errorf(err.Error())
 	}
 }
@@ -52,17 +72,31 @@ func NewStatusService(apiClient client.Interface, outputter OutputInterface) *St
 	}
 }
 
-// DisplayStatus retrieves and displays the status of an execution.
-func (s *StatusService) DisplayStatus(ctx context.Context, executionID string) error {
+// DisplayStatus retrieves and displays the status of an execution,
+// followed by its lifecycle timeline when showEvents is set.
+func (s *StatusService) DisplayStatus(ctx context.Context, executionID string, showEvents bool) error {
+	if strings.HasPrefix(executionID, constants.ExecutionGroupIDPrefix) {
+		if showEvents {
+			return errors.New("--events is not supported for execution groups, pass the execution ID of a shard")
+		}
+		return s.displayGroupStatus(ctx, executionID)
+	}
+
 	status, err := s.client.GetExecutionStatus(ctx, executionID)
 	if err != nil {
 		return fmt.Errorf("failed to get status: %w", err)
 	}
 
 	s.output.KeyValue("Execution ID", status.ExecutionID)
-	s.output.KeyValue("Status", status.Status)
+	s.output.KeyValue("Status", formatExecutionStatus(status.Status, status.FailureReason))
+	if status.FailureMessage != "" {
+		s.output.KeyValue("Failure", status.FailureMessage)
+	}
 	s.output.KeyValue("Command", status.Command)
 	s.output.KeyValue("Image ID", status.ImageID)
+	if status.ImageAlias != "" {
+		s.output.KeyValue("Image Alias", status.ImageAlias)
+	}
 	s.output.KeyValue("Started At", status.StartedAt.Format(time.DateTime))
 	s.output.KeyValue("Started At (Unix)", strconv.FormatInt(status.StartedAt.Unix(), 10))
 	if status.CompletedAt != nil {
@@ -72,7 +106,176 @@ func (s *StatusService) DisplayStatus(ctx context.Context, executionID string) e
 	if status.ExitCode != nil {
 		s.output.KeyValue("Exit Code", strconv.Itoa(*status.ExitCode))
 	}
+	if status.GroupID != "" {
+		s.output.KeyValue("Group ID", status.GroupID)
+	}
+	if status.KillRequestedAt != nil {
+		s.output.KeyValue("Kill Requested By", status.KillRequestedBy)
+		s.output.KeyValue("Kill Requested At", status.KillRequestedAt.Format(time.DateTime))
+	}
+	if status.ResourceSummary != nil {
+		displayResourceSummary(s.output, status.ResourceSummary)
+	}
+	if status.Provenance != nil {
+		displayProvenance(s.output, status.Provenance)
+	}
 	s.output.Blank()
+
+	if len(status.Annotations) > 0 {
+		displayAnnotations(s.output, status.Annotations)
+		s.output.Blank()
+	}
+
+	if hint, ok := failureReasonHints[constants.FailureReason(status.FailureReason)]; ok {
+		s.output.Warningf("%s: %s", status.FailureReason, hint)
+		s.output.Blank()
+	}
+	if status.KillStalled && status.Status == string(constants.ExecutionTerminating) {
+		s.output.Warningf("the task has not stopped since the kill was requested, the backend is killing it again")
+		s.output.Blank()
+	}
+
+	if showEvents {
+		if err = s.displayEvents(ctx, executionID); err != nil {
+			return err
+		}
+	}
+
 	s.output.Successf("Status retrieved successfully")
 	return nil
 }
+
+// displayResourceSummary prints the resource usage summary of a completed execution,
+// to compare what the command used with the resources reserved for it.
+func displayResourceSummary(out OutputInterface, summary *api.ResourceSummary) {
+	out.KeyValue("Billed Duration", (time.Duration(summary.BilledDurationSeconds) * time.Second).String())
+	if summary.Samples == 0 {
+		out.KeyValue("Resources", fmt.Sprintf("%g vCPU, %.0f MiB reserved (stopped before its usage was sampled)",
+			summary.CPUReserved/cpuUnitsPerVCPU, summary.MemoryReservedMiB))
+		return
+	}
+	out.KeyValue("CPU (average)", formatCPUUsage(&api.ResourceUsage{
+		CPUUtilized: summary.AverageCPUUtilized,
+		CPUReserved: summary.CPUReserved,
+	}))
+	out.KeyValue("Memory (peak)", formatMemoryUsage(&api.ResourceUsage{
+		MemoryUtilizedMiB: summary.PeakMemoryUtilizedMiB,
+		MemoryReservedMiB: summary.MemoryReservedMiB,
+	}))
+	out.KeyValue("Network", fmt.Sprintf("%s received, %s sent",
+		output.Bytes(summary.NetworkRxBytes), output.Bytes(summary.NetworkTxBytes)))
+}
+
+// displayProvenance prints the client that submitted an execution, as it reported itself.
+func displayProvenance(out OutputInterface, provenance *api.ExecutionProvenance) {
+	submittedFrom := provenance.Hostname
+	if submittedFrom == "" {
+		submittedFrom = "unknown host"
+	}
+	if provenance.OS != "" {
+		submittedFrom += " (" + provenance.OS + ")"
+	}
+	out.KeyValue("Submitted From", submittedFrom)
+	if provenance.ClientVersion != "" {
+		out.KeyValue("Client Version", provenance.ClientVersion)
+	}
+	if provenance.CIProvider != "" {
+		ciRun := provenance.CIProvider
+		if provenance.CIRunID != "" {
+			ciRun += " run " + provenance.CIRunID
+		}
+		out.KeyValue("CI Run", ciRun)
+	}
+	if provenance.CIRunURL != "" {
+		out.KeyValue("CI Run URL", provenance.CIRunURL)
+	}
+}
+
+// failureReasonHints tells users what to do about the failures the compute platform reports a cause for.
+var failureReasonHints = map[constants.FailureReason]string{
+	constants.FailureReasonOOMKilled: "the command ran out of memory, " +
+		"register the image with more memory (" + constants.ProjectName + " images register --memory)",
+	constants.FailureReasonImagePullFailed: "the container image could not be pulled, " +
+		"check that it exists and that its registry is reachable",
+	constants.FailureReasonCapacityUnavailable: "the compute platform had no capacity for the task, run it again later",
+	constants.FailureReasonStartFailed:         "the task failed to start, see the failure message above",
+}
+
+// formatExecutionStatus appends the failure reason to the status of failed executions, e.g. "FAILED (OOM_KILLED)".
+func formatExecutionStatus(status, failureReason string) string {
+	if failureReason == "" {
+		return status
+	}
+	return fmt.Sprintf("%s (%s)", status, failureReason)
+}
+
+// displayEvents shows the lifecycle timeline of an execution with the time spent since the previous event.
+func (s *StatusService) displayEvents(ctx context.Context, executionID string) error {
+	resp, err := s.client.GetExecutionEvents(ctx, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+
+	rows := make([][]string, 0, len(resp.Events))
+	for i, event := range resp.Events {
+		sincePrevious := "-"
+		if i > 0 {
+			sincePrevious = "+" + event.Timestamp.Sub(resp.Events[i-1].Timestamp).Round(time.Millisecond).String()
+		}
+		rows = append(rows, []string{
+			event.Type,
+			event.Timestamp.Local().Format(time.DateTime),
+			sincePrevious,
+			event.Reason,
+		})
+	}
+	s.output.Table([]string{"Event", "Time", "Since Previous", "Reason"}, rows)
+	s.output.Blank()
+	return nil
+}
+
+// displayGroupStatus shows the aggregated status of a parallel run followed by the status of each shard.
+func (s *StatusService) displayGroupStatus(ctx context.Context, groupID string) error {
+	status, err := s.client.GetExecutionGroupStatus(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group status: %w", err)
+	}
+
+	s.output.KeyValue("Group ID", status.GroupID)
+	s.output.KeyValue("Status", status.Status)
+	s.output.KeyValue("Shards", formatStatusCount(status.StatusCount))
+	s.output.KeyValue("Command", status.Command)
+	s.output.KeyValue("Image ID", status.ImageID)
+	s.output.KeyValue("Started At", status.StartedAt.Format(time.DateTime))
+	if status.CompletedAt != nil {
+		s.output.KeyValue("Completed At", status.CompletedAt.Format(time.DateTime))
+	}
+	if status.ExitCode != nil {
+		s.output.KeyValue("Exit Code", strconv.Itoa(*status.ExitCode))
+	}
+	s.output.Blank()
+
+	rows := make([][]string, 0, len(status.Shards))
+	for _, shard := range status.Shards {
+		exitCode := "-"
+		if shard.ExitCode != nil {
+			exitCode = strconv.Itoa(*shard.ExitCode)
+		}
+		rows = append(rows, []string{strconv.Itoa(shard.ShardIndex), shard.ExecutionID, shard.Status, exitCode})
+	}
+	s.output.Table([]string{"Shard", "Execution ID", "Status", "Exit Code"}, rows)
+	s.output.Blank()
+
+	s.output.Successf("Status retrieved successfully")
+	return nil
+}
+
+// formatStatusCount summarizes the number of shards per status, e.g. "1 FAILED, 3 SUCCEEDED".
+func formatStatusCount(statusCount map[string]int) string {
+	statuses := slices.Sorted(maps.Keys(statusCount))
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", statusCount[status], status))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -15,6 +15,51 @@ import (
 // mockClientInterface is a manual mock for testing
 type mockClientInterface struct {
 	getExecutionStatusFunc func(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error)
+	listHealthReportsFunc  func(ctx context.Context, limit int) (*api.HealthReportsResponse, error)
+	getExecutionEventsFunc func(ctx context.Context, executionID string) (*api.ExecutionEventsResponse, error)
+	getExecutionResultFunc func(ctx context.Context, executionID string) (*api.ExecutionResultResponse, error)
+	getGroupStatusFunc     func(ctx context.Context, groupID string) (*api.ExecutionGroupStatusResponse, error)
+	getResourcesFunc       func(ctx context.Context) (*api.ExecutionResourcesResponse, error)
+	getRecommendationsFunc func(ctx context.Context) (*api.ResourceRecommendationsResponse, error)
+	annotateExecutionFunc  func(ctx context.Context, executionID, note string) (*api.ExecutionAnnotation, error)
+
+	listFilteredFunc      func(ctx context.Context, limit int, filter api.ExecutionListFilter) ([]api.Execution, error)
+	starFunc              func(ctx context.Context, executionID string, starred bool) (*api.StarExecutionResponse, error)
+	listSavedFiltersFunc  func(ctx context.Context) (*api.ListSavedFiltersResponse, error)
+	saveFilterFunc        func(ctx context.Context, filter api.ExecutionListFilter) (*api.ExecutionListFilter, error)
+	deleteSavedFilterFunc func(ctx context.Context, name string) (*api.DeleteSavedFilterResponse, error)
+	getSLOReportFunc      func(ctx context.Context, since time.Duration, playbook string) (*api.ExecutionSLOReport, error)
+	cleanupHealthFunc     func(ctx context.Context, dryRun bool) (*api.HealthCleanupResponse, error)
+	getHealthStatsFunc    func(ctx context.Context) (*api.HealthStatsResponse, error)
+
+	listCommandPolicyRulesFunc  func(ctx context.Context) (*api.ListCommandPolicyRulesResponse, error)
+	createCommandPolicyRuleFunc func(
+		ctx context.Context, req api.CommandPolicyRuleRequest,
+	) (*api.CommandPolicyRuleResponse, error)
+	updateCommandPolicyRuleFunc func(
+		ctx context.Context, name string, req api.CommandPolicyRuleRequest,
+	) (*api.CommandPolicyRuleResponse, error)
+	deleteCommandPolicyRuleFunc func(ctx context.Context, name string) (*api.DeleteCommandPolicyRuleResponse, error)
+	getRunFreezeFunc            func(ctx context.Context) (*api.RunFreezeResponse, error)
+	freezeRunsFunc              func(ctx context.Context, req api.RunFreezeRequest) (*api.RunFreezeResponse, error)
+	unfreezeRunsFunc            func(ctx context.Context) (*api.RunFreezeResponse, error)
+	getMetaFunc                 func(ctx context.Context) (*api.MetaResponse, error)
+	setAnnouncementFunc         func(ctx context.Context, req api.AnnouncementRequest) (*api.AnnouncementResponse, error)
+	clearAnnouncementFunc       func(ctx context.Context) (*api.AnnouncementResponse, error)
+	listBudgetsFunc             func(ctx context.Context) (*api.ListBudgetsResponse, error)
+	setBudgetFunc               func(ctx context.Context, email string, req api.BudgetRequest) (*api.BudgetResponse, error)
+	removeBudgetFunc            func(ctx context.Context, email string) (*api.BudgetResponse, error)
+	getNotificationSettingsFunc func(ctx context.Context) (*api.NotificationSettingsResponse, error)
+	setNotificationSettingsFunc func(
+		ctx context.Context, req api.NotificationSettingsRequest,
+	) (*api.NotificationSettingsResponse, error)
+	listLocksFunc         func(ctx context.Context) (*api.ListLocksResponse, error)
+	releaseLockFunc       func(ctx context.Context, name string) (*api.ReleaseLockResponse, error)
+	listPendingUsersFunc  func(ctx context.Context) (*api.ListUsersResponse, error)
+	reissueClaimTokenFunc func(ctx context.Context, email string) (*api.CreateUserResponse, error)
+	transferOwnershipFunc func(
+		ctx context.Context, email string, req api.TransferOwnershipRequest,
+	) (*api.TransferOwnershipResponse, error)
 }
 
 func (m *mockClientInterface) GetExecutionStatus(
@@ -26,6 +71,67 @@ func (m *mockClientInterface) GetExecutionStatus(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClientInterface) GetExecutionEvents(
+	ctx context.Context, executionID string,
+) (*api.ExecutionEventsResponse, error) {
+	if m.getExecutionEventsFunc != nil {
+		return m.getExecutionEventsFunc(ctx, executionID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetExecutionResult(
+	ctx context.Context, executionID string,
+) (*api.ExecutionResultResponse, error) {
+	if m.getExecutionResultFunc != nil {
+		return m.getExecutionResultFunc(ctx, executionID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) AnnotateExecution(
+	ctx context.Context, executionID, note string,
+) (*api.ExecutionAnnotation, error) {
+	if m.annotateExecutionFunc != nil {
+		return m.annotateExecutionFunc(ctx, executionID, note)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetExecutionGroupStatus(
+	ctx context.Context, groupID string,
+) (*api.ExecutionGroupStatusResponse, error) {
+	if m.getGroupStatusFunc != nil {
+		return m.getGroupStatusFunc(ctx, groupID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetExecutionResources(ctx context.Context) (*api.ExecutionResourcesResponse, error) {
+	if m.getResourcesFunc != nil {
+		return m.getResourcesFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetExecutionSLOReport(
+	ctx context.Context, since time.Duration, playbook string,
+) (*api.ExecutionSLOReport, error) {
+	if m.getSLOReportFunc != nil {
+		return m.getSLOReportFunc(ctx, since, playbook)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetResourceRecommendations(
+	ctx context.Context,
+) (*api.ResourceRecommendationsResponse, error) {
+	if m.getRecommendationsFunc != nil {
+		return m.getRecommendationsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
 // Implement other Interface methods (not used in StatusService, but needed to satisfy interface)
 func (m *mockClientInterface) GetLogs(_ context.Context, _ string) (*api.LogsResponse, error) {
 	return nil, errors.New("not implemented")
@@ -36,12 +142,89 @@ func (m *mockClientInterface) RunCommand(_ context.Context, _ *api.ExecutionRequ
 func (m *mockClientInterface) KillExecution(_ context.Context, _ string) (*api.KillExecutionResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) CreateStdinUpload(_ context.Context, _ int64) (*api.InputUploadResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) CreateContextUpload(_ context.Context, _ int64) (*api.InputUploadResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) UploadInput(_ context.Context, _ string, _ []byte) error {
+	return errors.New("not implemented")
+}
+func (m *mockClientInterface) StopExecution(_ context.Context, _ string) (*api.KillExecutionResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) DiffExecutions(
+	_ context.Context, _, _ string, _ int,
+) (*api.ExecutionDiffResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) KillExecutions(
+	_ context.Context, _ api.KillExecutionsFilter, _ string,
+) (*api.KillExecutionsResponse, error) {
+	return nil, errors.New("not implemented")
+}
 func (m *mockClientInterface) ListExecutions(_ context.Context, _ int, _ string) ([]api.Execution, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) ListFilteredExecutions(
+	ctx context.Context, limit int, filter api.ExecutionListFilter,
+) ([]api.Execution, error) {
+	if m.listFilteredFunc != nil {
+		return m.listFilteredFunc(ctx, limit, filter)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) StarExecution(
+	ctx context.Context, executionID string, starred bool,
+) (*api.StarExecutionResponse, error) {
+	if m.starFunc != nil {
+		return m.starFunc(ctx, executionID, starred)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) ListSavedFilters(ctx context.Context) (*api.ListSavedFiltersResponse, error) {
+	if m.listSavedFiltersFunc != nil {
+		return m.listSavedFiltersFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) SaveFilter(
+	ctx context.Context, filter api.ExecutionListFilter,
+) (*api.ExecutionListFilter, error) {
+	if m.saveFilterFunc != nil {
+		return m.saveFilterFunc(ctx, filter)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) DeleteSavedFilter(
+	ctx context.Context, name string,
+) (*api.DeleteSavedFilterResponse, error) {
+	if m.deleteSavedFilterFunc != nil {
+		return m.deleteSavedFilterFunc(ctx, name)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) StreamExecutions(
+	_ context.Context, _ string, _ func([]api.Execution) error,
+) error {
+	return errors.New("not implemented")
+}
 func (m *mockClientInterface) ClaimAPIKey(_ context.Context, _ string) (*api.ClaimAPIKeyResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) GetLoginConfig(_ context.Context) (*api.LoginConfigResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) Login(_ context.Context, _ api.LoginRequest) (*api.LoginResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) ExchangeGitHubToken(
+	_ context.Context,
+	_ api.GitHubTokenRequest,
+) (*api.GitHubTokenResponse, error) {
+	return nil, errors.New("not implemented")
+}
 func (m *mockClientInterface) CreateUser(_ context.Context, _ api.CreateUserRequest) (*api.CreateUserResponse, error) {
 	return nil, errors.New("not implemented")
 }
@@ -51,8 +234,29 @@ func (m *mockClientInterface) RevokeUser(_ context.Context, _ api.RevokeUserRequ
 func (m *mockClientInterface) ListUsers(_ context.Context) (*api.ListUsersResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) ListPendingUsers(ctx context.Context) (*api.ListUsersResponse, error) {
+	if m.listPendingUsersFunc != nil {
+		return m.listPendingUsersFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) ReissueClaimToken(ctx context.Context, email string) (*api.CreateUserResponse, error) {
+	if m.reissueClaimTokenFunc != nil {
+		return m.reissueClaimTokenFunc(ctx, email)
+	}
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) TransferOwnership(
+	ctx context.Context, email string, req api.TransferOwnershipRequest,
+) (*api.TransferOwnershipResponse, error) {
+	if m.transferOwnershipFunc != nil {
+		return m.transferOwnershipFunc(ctx, email, req)
+	}
+	return nil, errors.New("not implemented")
+}
 func (m *mockClientInterface) RegisterImage(
-	_ context.Context, _ string, _ *bool, _, _ *string, _, _ *int, _ *string,
+	_ context.Context, _ string, _ *bool, _, _ *string, _, _ *int, _ *string, _ *api.LogLimits, _ *int,
+	_ *api.RunDefaults,
 ) (*api.RegisterImageResponse, error) {
 	return nil, errors.New("not implemented")
 }
@@ -62,6 +266,21 @@ func (m *mockClientInterface) ListImages(_ context.Context) (*api.ListImagesResp
 func (m *mockClientInterface) UnregisterImage(_ context.Context, _ string) (*api.RemoveImageResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) RestoreImage(_ context.Context, _ string) (*api.RestoreImageResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) PurgeImage(_ context.Context, _ string) (*api.RemoveImageResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) SetImageAlias(_ context.Context, _, _ string) (*api.SetImageAliasResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) ListImageAliases(_ context.Context) (*api.ListImageAliasesResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) RemoveImageAlias(_ context.Context, _ string) (*api.RemoveImageAliasResponse, error) {
+	return nil, errors.New("not implemented")
+}
 func (m *mockClientInterface) GetImage(_ context.Context, _ string) (*api.ImageInfo, error) {
 	return nil, errors.New("not implemented")
 }
@@ -87,8 +306,174 @@ func (m *mockClientInterface) UpdateSecret(
 func (m *mockClientInterface) DeleteSecret(_ context.Context, _ string) (*api.DeleteSecretResponse, error) {
 	return nil, errors.New("not implemented")
 }
+func (m *mockClientInterface) ExportSecrets(
+	_ context.Context, _ api.ExportSecretsRequest,
+) (*api.ExportSecretsResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (m *mockClientInterface) ImportSecrets(
+	_ context.Context, _ api.ImportSecretsRequest,
+) (*api.ImportSecretsResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ListCommandPolicyRules(ctx context.Context) (*api.ListCommandPolicyRulesResponse, error) {
+	if m.listCommandPolicyRulesFunc != nil {
+		return m.listCommandPolicyRulesFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) CreateCommandPolicyRule(
+	ctx context.Context, req api.CommandPolicyRuleRequest,
+) (*api.CommandPolicyRuleResponse, error) {
+	if m.createCommandPolicyRuleFunc != nil {
+		return m.createCommandPolicyRuleFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) UpdateCommandPolicyRule(
+	ctx context.Context, name string, req api.CommandPolicyRuleRequest,
+) (*api.CommandPolicyRuleResponse, error) {
+	if m.updateCommandPolicyRuleFunc != nil {
+		return m.updateCommandPolicyRuleFunc(ctx, name, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) DeleteCommandPolicyRule(
+	ctx context.Context, name string,
+) (*api.DeleteCommandPolicyRuleResponse, error) {
+	if m.deleteCommandPolicyRuleFunc != nil {
+		return m.deleteCommandPolicyRuleFunc(ctx, name)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetRunFreeze(ctx context.Context) (*api.RunFreezeResponse, error) {
+	if m.getRunFreezeFunc != nil {
+		return m.getRunFreezeFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) FreezeRuns(
+	ctx context.Context, req api.RunFreezeRequest,
+) (*api.RunFreezeResponse, error) {
+	if m.freezeRunsFunc != nil {
+		return m.freezeRunsFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) UnfreezeRuns(ctx context.Context) (*api.RunFreezeResponse, error) {
+	if m.unfreezeRunsFunc != nil {
+		return m.unfreezeRunsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetMeta(ctx context.Context) (*api.MetaResponse, error) {
+	if m.getMetaFunc != nil {
+		return m.getMetaFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) SetAnnouncement(
+	ctx context.Context, req api.AnnouncementRequest,
+) (*api.AnnouncementResponse, error) {
+	if m.setAnnouncementFunc != nil {
+		return m.setAnnouncementFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ClearAnnouncement(ctx context.Context) (*api.AnnouncementResponse, error) {
+	if m.clearAnnouncementFunc != nil {
+		return m.clearAnnouncementFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ListBudgets(ctx context.Context) (*api.ListBudgetsResponse, error) {
+	if m.listBudgetsFunc != nil {
+		return m.listBudgetsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) SetBudget(
+	ctx context.Context, email string, req api.BudgetRequest,
+) (*api.BudgetResponse, error) {
+	if m.setBudgetFunc != nil {
+		return m.setBudgetFunc(ctx, email, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) RemoveBudget(ctx context.Context, email string) (*api.BudgetResponse, error) {
+	if m.removeBudgetFunc != nil {
+		return m.removeBudgetFunc(ctx, email)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetNotificationSettings(
+	ctx context.Context,
+) (*api.NotificationSettingsResponse, error) {
+	if m.getNotificationSettingsFunc != nil {
+		return m.getNotificationSettingsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) SetNotificationSettings(
+	ctx context.Context, req api.NotificationSettingsRequest,
+) (*api.NotificationSettingsResponse, error) {
+	if m.setNotificationSettingsFunc != nil {
+		return m.setNotificationSettingsFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ListLocks(ctx context.Context) (*api.ListLocksResponse, error) {
+	if m.listLocksFunc != nil {
+		return m.listLocksFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ReleaseLock(ctx context.Context, name string) (*api.ReleaseLockResponse, error) {
+	if m.releaseLockFunc != nil {
+		return m.releaseLockFunc(ctx, name)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) ReconcileHealth(_ context.Context, _ bool) (*api.HealthReconcileResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) CleanupHealth(ctx context.Context, dryRun bool) (*api.HealthCleanupResponse, error) {
+	if m.cleanupHealthFunc != nil {
+		return m.cleanupHealthFunc(ctx, dryRun)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClientInterface) GetHealthStats(ctx context.Context) (*api.HealthStatsResponse, error) {
+	if m.getHealthStatsFunc != nil {
+		return m.getHealthStatsFunc(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
 
-func (m *mockClientInterface) ReconcileHealth(_ context.Context) (*api.HealthReconcileResponse, error) {
+func (m *mockClientInterface) ListHealthReports(ctx context.Context, limit int) (*api.HealthReportsResponse, error) {
+	if m.listHealthReportsFunc != nil {
+		return m.listHealthReportsFunc(ctx, limit)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -133,6 +518,9 @@ func (m *mockOutputInterface) Cyan(text string) string {
 func (m *mockOutputInterface) KeyValue(key, value string) {
 	m.calls = append(m.calls, call{method: "KeyValue", args: []any{key, value}})
 }
+func (m *mockOutputInterface) Println(a ...any) {
+	m.calls = append(m.calls, call{method: "Println", args: a})
+}
 func (m *mockOutputInterface) Prompt(prompt string) string {
 	m.calls = append(m.calls, call{method: "Prompt", args: []any{prompt}})
 	// Return empty string by default - tests can override by checking calls
@@ -250,7 +638,7 @@ func TestStatusService_DisplayStatus(t *testing.T) {
 			mockOutput := &mockOutputInterface{}
 			service := NewStatusService(mockClient, mockOutput)
 
-			err := service.DisplayStatus(context.Background(), tt.executionID)
+			err := service.DisplayStatus(context.Background(), tt.executionID, false)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -264,3 +652,298 @@ func TestStatusService_DisplayStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusService_DisplayStatusWithEvents(t *testing.T) {
+	submittedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{ExecutionID: "exec-123", Status: "FAILED", StartedAt: submittedAt}, nil
+		},
+		getExecutionEventsFunc: func(_ context.Context, executionID string) (*api.ExecutionEventsResponse, error) {
+			assert.Equal(t, "exec-123", executionID)
+			return &api.ExecutionEventsResponse{
+				ExecutionID: executionID,
+				Events: []api.ExecutionEvent{
+					{Type: "SUBMITTED", Timestamp: submittedAt},
+					{Type: "PULLING_IMAGE", Timestamp: submittedAt.Add(1500 * time.Millisecond)},
+					{Type: "STOPPED", Timestamp: submittedAt.Add(90 * time.Second), Reason: "TaskFailedToStart"},
+				},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewStatusService(mockClient, mockOutput)
+
+	err := service.DisplayStatus(context.Background(), "exec-123", true)
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			assert.Equal(t, []string{"Event", "Time", "Since Previous", "Reason"}, call.args[0])
+			rows = call.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 3)
+	assert.Equal(t, "-", rows[0][2])
+	assert.Equal(t, "+1.5s", rows[1][2])
+	assert.Equal(t, []string{"STOPPED", "+1m28.5s", "TaskFailedToStart"},
+		[]string{rows[2][0], rows[2][2], rows[2][3]})
+}
+
+func TestStatusService_DisplayStatusWithEventsError(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{ExecutionID: "exec-123"}, nil
+		},
+	}
+	service := NewStatusService(mockClient, &mockOutputInterface{})
+
+	err := service.DisplayStatus(context.Background(), "exec-123", true)
+
+	assert.ErrorContains(t, err, "failed to get events")
+}
+
+func TestStatusService_DisplayGroupStatus(t *testing.T) {
+	startedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	exitCode := 2
+	mockClient := &mockClientInterface{
+		getGroupStatusFunc: func(_ context.Context, groupID string) (*api.ExecutionGroupStatusResponse, error) {
+			assert.Equal(t, "group-abc", groupID)
+			return &api.ExecutionGroupStatusResponse{
+				GroupID:     groupID,
+				Status:      "RUNNING",
+				Command:     "./test-shard.sh",
+				StartedAt:   startedAt,
+				StatusCount: map[string]int{"RUNNING": 1, "FAILED": 1},
+				Shards: []api.ExecutionGroupShard{
+					{ShardIndex: 0, ExecutionID: "exec-0", Status: "FAILED", ExitCode: &exitCode},
+					{ShardIndex: 1, ExecutionID: "exec-1", Status: "RUNNING"},
+				},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewStatusService(mockClient, mockOutput)
+
+	err := service.DisplayStatus(context.Background(), "group-abc", false)
+
+	require.NoError(t, err)
+	values := map[string]any{}
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			values[call.args[0].(string)] = call.args[1]
+		}
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	assert.Equal(t, "group-abc", values["Group ID"])
+	assert.Equal(t, "1 FAILED, 1 RUNNING", values["Shards"])
+	assert.Equal(t, [][]string{{"0", "exec-0", "FAILED", "2"}, {"1", "exec-1", "RUNNING", "-"}}, rows)
+
+	err = service.DisplayStatus(context.Background(), "group-abc", true)
+	assert.ErrorContains(t, err, "events")
+}
+
+func TestStatusService_DisplayStatusWithFailureReason(t *testing.T) {
+	exitCode := 137
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{
+				ExecutionID:    "exec-oom",
+				Status:         "FAILED",
+				ExitCode:       &exitCode,
+				FailureReason:  "OOM_KILLED",
+				FailureMessage: "OutOfMemoryError: Container killed due to memory usage",
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewStatusService(mockClient, mockOutput)
+
+	err := service.DisplayStatus(context.Background(), "exec-oom", false)
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	var warning string
+	for _, call := range mockOutput.calls {
+		switch call.method {
+		case "KeyValue":
+			keyValues[call.args[0].(string)] = call.args[1]
+		case "Warningf":
+			warning = call.args[0].(string)
+		}
+	}
+	assert.Equal(t, "FAILED (OOM_KILLED)", keyValues["Status"])
+	assert.Equal(t, "OutOfMemoryError: Container killed due to memory usage", keyValues["Failure"])
+	assert.NotEmpty(t, warning, "Expected a hint for the failure reason")
+}
+
+func TestStatusService_DisplayStatusWithKillRequest(t *testing.T) {
+	killRequestedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{
+				ExecutionID:     "exec-killed",
+				Status:          "TERMINATING",
+				KillRequestedBy: "alice@example.com",
+				KillRequestedAt: &killRequestedAt,
+				KillStalled:     true,
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewStatusService(mockClient, mockOutput)
+
+	err := service.DisplayStatus(context.Background(), "exec-killed", false)
+
+	require.NoError(t, err)
+	keyValues := map[string]any{}
+	var warning string
+	for _, call := range mockOutput.calls {
+		switch call.method {
+		case "KeyValue":
+			keyValues[call.args[0].(string)] = call.args[1]
+		case "Warningf":
+			warning = call.args[0].(string)
+		}
+	}
+	assert.Equal(t, "alice@example.com", keyValues["Kill Requested By"])
+	assert.Equal(t, "2025-01-02 03:04:05", keyValues["Kill Requested At"])
+	assert.Contains(t, warning, "has not stopped")
+}
+
+func TestStatusService_DisplayStatusWithProvenance(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{
+				ExecutionID: "exec-ci",
+				Status:      "RUNNING",
+				Provenance: &api.ExecutionProvenance{
+					ClientVersion: "v1.2.3",
+					OS:            "linux/amd64",
+					Hostname:      "runner-1",
+					CIProvider:    "github-actions",
+					CIRunID:       "42",
+					CIRunURL:      "https://github.com/acme/app/actions/runs/42",
+				},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	require.NoError(t, NewStatusService(mockClient, mockOutput).DisplayStatus(context.Background(), "exec-ci", false))
+
+	keyValues := map[string]any{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1]
+		}
+	}
+	assert.Equal(t, "runner-1 (linux/amd64)", keyValues["Submitted From"])
+	assert.Equal(t, "v1.2.3", keyValues["Client Version"])
+	assert.Equal(t, "github-actions run 42", keyValues["CI Run"])
+	assert.Equal(t, "https://github.com/acme/app/actions/runs/42", keyValues["CI Run URL"])
+}
+
+func TestStatusService_DisplayStatusWithResourceSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *api.ResourceSummary
+		want    map[string]any
+	}{
+		{
+			name: "sampled",
+			summary: &api.ResourceSummary{
+				Samples:               5,
+				AverageCPUUtilized:    256,
+				CPUReserved:           1024,
+				PeakMemoryUtilizedMiB: 1536,
+				MemoryReservedMiB:     2048,
+				NetworkRxBytes:        2048,
+				NetworkTxBytes:        100,
+				BilledDurationSeconds: 321,
+			},
+			want: map[string]any{
+				"Billed Duration": "5m21s",
+				"CPU (average)":   "25% of 1 vCPU",
+				"Memory (peak)":   "1536/2048 MiB (75%)",
+				"Network":         "2.0 KB received, 100 B sent",
+			},
+		},
+		{
+			name: "not sampled",
+			summary: &api.ResourceSummary{
+				CPUReserved:           512,
+				MemoryReservedMiB:     1024,
+				BilledDurationSeconds: 60,
+			},
+			want: map[string]any{
+				"Billed Duration": "1m0s",
+				"Resources":       "0.5 vCPU, 1024 MiB reserved (stopped before its usage was sampled)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode := 0
+			mockClient := &mockClientInterface{
+				getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+					return &api.ExecutionStatusResponse{
+						ExecutionID:     "exec-123",
+						Status:          "SUCCEEDED",
+						ExitCode:        &exitCode,
+						ResourceSummary: tt.summary,
+					}, nil
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+			service := NewStatusService(mockClient, mockOutput)
+
+			err := service.DisplayStatus(context.Background(), "exec-123", false)
+
+			require.NoError(t, err)
+			keyValues := map[string]any{}
+			for _, call := range mockOutput.calls {
+				if call.method == "KeyValue" {
+					keyValues[call.args[0].(string)] = call.args[1]
+				}
+			}
+			for key, value := range tt.want {
+				assert.Equal(t, value, keyValues[key], key)
+			}
+		})
+	}
+}
+
+func TestStatusService_DisplayStatusWithAnnotations(t *testing.T) {
+	mockClient := &mockClientInterface{
+		getExecutionStatusFunc: func(_ context.Context, _ string) (*api.ExecutionStatusResponse, error) {
+			return &api.ExecutionStatusResponse{
+				ExecutionID: "exec-123",
+				Status:      "FAILED",
+				Annotations: []api.ExecutionAnnotation{
+					{Note: "caused by an upstream outage", Author: "alice@example.com", CreatedAt: time.Now()},
+				},
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+	service := NewStatusService(mockClient, mockOutput)
+
+	err := service.DisplayStatus(context.Background(), "exec-123", false)
+
+	require.NoError(t, err)
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 1)
+	assert.Equal(t, "alice@example.com", rows[0][1])
+	assert.Equal(t, "caused by an upstream outage", rows[0][2])
+}
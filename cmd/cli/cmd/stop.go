@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop <execution-id>",
+	Short: "Gracefully stop a running command execution",
+	Long: `Gracefully stop a running command execution.
+
+Unlike kill, the command receives SIGTERM and is given the grace period set with
+"run --stop-grace-period" to run its cleanup (e.g. shell traps) before being killed.
+Executions started without a grace period can only be killed.`,
+	Example:           fmt.Sprintf(`  - %s stop 72f57686-2b4c-4a1f-9b3e-3c1e5b2a8f10`, constants.ProjectName),
+	Run:               stopRun,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstArg(fetchExecutionIDs),
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+}
+
+func stopRun(cmd *cobra.Command, args []string) {
+	cfg, err := getConfigFromContext(cmd)
+	if err != nil {
+		output.Errorf("failed to load configuration: %v", err)
+		return
+	}
+
+	c := client.New(cfg, slog.Default())
+	service := NewStopService(c, NewOutputWrapper())
+	if err = service.StopExecution(cmd.Context(), args[0]); err != nil {
+		output.Errorf(err.Error())
+	}
+}
+
+// StopService handles graceful execution stopping logic.
+type StopService struct {
+	client client.Interface
+	output OutputInterface
+}
+
+// NewStopService creates a new StopService with the provided dependencies.
+func NewStopService(apiClient client.Interface, outputter OutputInterface) *StopService {
+	return &StopService{
+		client: apiClient,
+		output: outputter,
+	}
+}
+
+// StopExecution gracefully stops a running execution and displays the results.
+func (s *StopService) StopExecution(ctx context.Context, executionID string) error {
+	resp, err := s.client.StopExecution(ctx, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to stop execution: %w", err)
+	}
+
+	if resp == nil {
+		s.output.Successf("Execution is already terminated, no action taken")
+		s.output.KeyValue("Execution ID", executionID)
+		return nil
+	}
+
+	s.output.Successf("Execution stop started successfully")
+	s.output.KeyValue("Execution ID", resp.ExecutionID)
+	s.output.KeyValue("Message", resp.Message)
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// mockClientInterfaceForStop extends mockClientInterface with StopExecution
+type mockClientInterfaceForStop struct {
+	*mockClientInterface
+	stopExecutionFunc func(ctx context.Context, executionID string) (*api.KillExecutionResponse, error)
+}
+
+func (m *mockClientInterfaceForStop) StopExecution(
+	ctx context.Context, executionID string,
+) (*api.KillExecutionResponse, error) {
+	if m.stopExecutionFunc != nil {
+		return m.stopExecutionFunc(ctx, executionID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestStopService_StopExecution(t *testing.T) {
+	tests := []struct {
+		name         string
+		resp         *api.KillExecutionResponse
+		err          error
+		wantErr      bool
+		wantSuccess  bool
+		wantKeyValue string
+	}{
+		{
+			name: "successfully stops execution",
+			resp: &api.KillExecutionResponse{
+				ExecutionID: "exec-123",
+				Message:     "Graceful stop initiated, the command has 30 seconds to exit",
+			},
+			wantSuccess:  true,
+			wantKeyValue: "Graceful stop initiated, the command has 30 seconds to exit",
+		},
+		{
+			name:         "handles already completed execution",
+			wantSuccess:  true,
+			wantKeyValue: "exec-123",
+		},
+		{
+			name:    "handles execution without grace period",
+			err:     errors.New("execution was started without a stop grace period, use kill instead"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockClientInterfaceForStop{
+				mockClientInterface: &mockClientInterface{},
+				stopExecutionFunc: func(_ context.Context, executionID string) (*api.KillExecutionResponse, error) {
+					assert.Equal(t, "exec-123", executionID)
+					return tt.resp, tt.err
+				},
+			}
+			mockOutput := &mockOutputInterface{}
+			service := NewStopService(mockClient, mockOutput)
+
+			err := service.StopExecution(context.Background(), "exec-123")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to stop execution")
+				assert.Empty(t, mockOutput.calls)
+				return
+			}
+
+			require.NoError(t, err)
+			hasSuccess := false
+			hasKeyValue := false
+			for _, call := range mockOutput.calls {
+				if call.method == "Successf" {
+					hasSuccess = true
+				}
+				if call.method == "KeyValue" && len(call.args) >= 2 && call.args[1] == tt.wantKeyValue {
+					hasKeyValue = true
+				}
+			}
+			assert.Equal(t, tt.wantSuccess, hasSuccess)
+			assert.True(t, hasKeyValue, "expected %q to be displayed", tt.wantKeyValue)
+		})
+	}
+}
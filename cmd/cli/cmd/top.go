@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// cpuUnitsPerVCPU is the number of CPU units of a vCPU in the resource usage samples.
+	cpuUnitsPerVCPU = 1024
+	percent         = 100
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show the resource usage of running executions",
+	Long: `Show the CPU and memory utilization of the running executions in a continuously updating table,
+to tell whether a command is CPU-bound or about to run out of memory.
+
+Utilization is sampled by the compute platform about every minute, the Sampled column shows the age of
+the latest sample. Executions started less than a minute ago may not have a sample yet.
+
+NOTICE: the command timeout does not apply, press Ctrl+C to exit.`,
+	Example: fmt.Sprintf(`  # Show the resource usage of the running executions
+  - %s top
+
+  # Refresh every 30 seconds
+  - %s top --interval 30s`,
+		constants.ProjectName, constants.ProjectName),
+	Run: topRun,
+}
+
+var topIntervalFlag time.Duration
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.Flags().DurationVar(&topIntervalFlag, "interval", constants.TopPollInterval, "refresh interval")
+}
+
+func topRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(_ context.Context, c client.Interface) error {
+		// Like watch, top is long-lived and deliberately ignores the command timeout.
+		ctx, stop := signal.NotifyContext(context.WithoutCancel(cmd.Context()), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		service := NewTopService(c, NewOutputWrapper())
+		return service.Poll(ctx, topIntervalFlag)
+	})
+}
+
+// TopService handles rendering a continuously updating view of the resource usage of running executions.
+type TopService struct {
+	client client.Interface
+	output OutputInterface
+	clear  func()
+	now    func() time.Time
+}
+
+// NewTopService creates a new TopService with the provided dependencies.
+func NewTopService(apiClient client.Interface, outputter OutputInterface) *TopService {
+	return &TopService{
+		client: apiClient,
+		output: outputter,
+		clear: func() {
+			output.Printf("\033[H\033[2J")
+		},
+		now: time.Now,
+	}
+}
+
+// Poll refreshes the resource usage view every interval until the context is canceled.
+func (s *TopService) Poll(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := s.client.GetExecutionResources(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to get execution resources: %w", err)
+		}
+		s.render(resp.Executions)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// render redraws the resource usage table in place, followed by a warning for each execution
+// about to run out of memory.
+func (s *TopService) render(executions []api.ExecutionResourceUsage) {
+	now := s.now()
+	rows := make([][]string, 0, len(executions))
+	var nearOOM []string
+	for i := range executions {
+		e := &executions[i]
+		cpu, memory, sampled := "-", "-", "-"
+		if e.Usage != nil {
+			cpu = formatCPUUsage(e.Usage)
+			memory = formatMemoryUsage(e.Usage)
+			sampled = now.Sub(e.Usage.SampledAt).Truncate(time.Second).String() + " ago"
+			if percentOf(e.Usage.MemoryUtilizedMiB, e.Usage.MemoryReservedMiB) >= constants.TopMemoryWarningPercent {
+				nearOOM = append(nearOOM, e.ExecutionID)
+			}
+		}
+		rows = append(rows, []string{
+			s.output.Bold(e.ExecutionID),
+			e.CreatedBy,
+			now.Sub(e.StartedAt).Truncate(time.Second).String(),
+			cpu,
+			memory,
+			sampled,
+			truncateCommand(e.Command),
+		})
+	}
+
+	s.clear()
+	s.output.Infof("Resource usage of %d running executions (updated %s), press Ctrl+C to exit",
+		len(executions), now.UTC().Format(time.DateTime))
+	s.output.Blank()
+	s.output.Table([]string{"Execution ID", "User", "Duration", "CPU", "Memory", "Sampled", "Command"}, rows)
+	for _, executionID := range nearOOM {
+		s.output.Warningf("%s is using more than %d%% of its memory and may be killed when running out of it",
+			executionID, constants.TopMemoryWarningPercent)
+	}
+}
+
+// formatCPUUsage formats the CPU utilization, e.g. "45% of 1 vCPU".
+func formatCPUUsage(usage *api.ResourceUsage) string {
+	return fmt.Sprintf("%.0f%% of %g vCPU",
+		percentOf(usage.CPUUtilized, usage.CPUReserved), usage.CPUReserved/cpuUnitsPerVCPU)
+}
+
+// formatMemoryUsage formats the memory utilization, e.g. "900/2048 MiB (44%)".
+func formatMemoryUsage(usage *api.ResourceUsage) string {
+	return fmt.Sprintf("%.0f/%.0f MiB (%.0f%%)",
+		usage.MemoryUtilizedMiB, usage.MemoryReservedMiB, percentOf(usage.MemoryUtilizedMiB, usage.MemoryReservedMiB))
+}
+
+// percentOf returns value as a percentage of total, 0 when total is unknown.
+func percentOf(value, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return value / total * percent
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func newTestTopService(mockClient *mockClientInterface, mockOutput *mockOutputInterface) (*TopService, *int) {
+	clears := 0
+	service := NewTopService(mockClient, mockOutput)
+	service.clear = func() { clears++ }
+	service.now = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return service, &clears
+}
+
+func TestTopService_Poll(t *testing.T) {
+	t.Run("renders the resource usage on every refresh until canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		mockClient := &mockClientInterface{
+			getResourcesFunc: func(_ context.Context) (*api.ExecutionResourcesResponse, error) {
+				calls++
+				if calls == 2 {
+					cancel()
+				}
+				return &api.ExecutionResourcesResponse{Executions: []api.ExecutionResourceUsage{
+					{
+						ExecutionID: "exec-1",
+						Command:     "make build",
+						CreatedBy:   "user@example.com",
+						StartedAt:   time.Date(2025, 1, 1, 11, 50, 0, 0, time.UTC),
+						Usage: &api.ResourceUsage{
+							SampledAt:         time.Date(2025, 1, 1, 11, 59, 30, 0, time.UTC),
+							CPUUtilized:       460.8,
+							CPUReserved:       512,
+							MemoryUtilizedMiB: 1000,
+							MemoryReservedMiB: 1024,
+						},
+					},
+					{
+						ExecutionID: "exec-2",
+						Command:     "sleep 60",
+						CreatedBy:   "user@example.com",
+						StartedAt:   time.Date(2025, 1, 1, 11, 59, 40, 0, time.UTC),
+					},
+				}}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service, clears := newTestTopService(mockClient, mockOutput)
+
+		err := service.Poll(ctx, time.Millisecond)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, *clears)
+
+		var rows [][]string
+		var warnings int
+		for _, call := range mockOutput.calls {
+			switch call.method {
+			case "Table":
+				assert.Equal(t,
+					[]string{"Execution ID", "User", "Duration", "CPU", "Memory", "Sampled", "Command"}, call.args[0])
+				rows = call.args[1].([][]string)
+			case "Warningf":
+				warnings++
+			}
+		}
+		require.Len(t, rows, 2)
+		assert.Equal(t, []string{"10m0s", "90% of 0.5 vCPU", "1000/1024 MiB (98%)", "30s ago"}, rows[0][2:6])
+		assert.Equal(t, []string{"-", "-", "-"}, rows[1][3:6])
+		assert.Equal(t, 2, warnings, "the execution about to run out of memory is reported on every refresh")
+	})
+
+	t.Run("returns client errors", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			getResourcesFunc: func(_ context.Context) (*api.ExecutionResourcesResponse, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		service, _ := newTestTopService(mockClient, &mockOutputInterface{})
+
+		err := service.Poll(context.Background(), time.Second)
+
+		assert.ErrorContains(t, err, "failed to get execution resources")
+	})
+
+	t.Run("rejects non-positive intervals", func(t *testing.T) {
+		service, _ := newTestTopService(&mockClientInterface{}, &mockOutputInterface{})
+
+		assert.Error(t, service.Poll(context.Background(), 0))
+	})
+}
@@ -135,6 +135,24 @@ func (s *TraceService) displayExecutions(executions []*api.Execution) {
 	}
 
 	s.output.Table(headers, rows)
+
+	for _, exec := range executions {
+		if exec.Provenance == nil {
+			continue
+		}
+		s.output.Blank()
+		s.output.Infof("Provenance of execution %s", exec.ExecutionID)
+		displayProvenance(s.output, exec.Provenance)
+	}
+
+	for _, exec := range executions {
+		if len(exec.Annotations) == 0 {
+			continue
+		}
+		s.output.Blank()
+		s.output.Infof("Annotations of execution %s", exec.ExecutionID)
+		displayAnnotations(s.output, exec.Annotations)
+	}
 }
 
 func (s *TraceService) displaySecrets(secrets []*api.Secret) {
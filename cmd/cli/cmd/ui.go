@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Interactive terminal UI for active executions",
+	Long: `Open an interactive terminal UI showing active executions (STARTING, RUNNING, TERMINATING)
+with live logs for the selected execution.
+
+Keys: ↑/k and ↓/j to move, enter to follow logs, esc to go back, r to refresh, q to quit.
+
+NOTICE: the command timeout does not apply to the interactive UI.`,
+	Example: fmt.Sprintf(`  - %s ui`, constants.ProjectName),
+	Run:     uiRun,
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
+
+func uiRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(_ context.Context, c client.Interface) error {
+		// The UI is long-lived, so it deliberately ignores the command timeout.
+		ctx := context.WithoutCancel(cmd.Context())
+		program := tea.NewProgram(newUIModel(ctx, c), tea.WithAltScreen(), tea.WithContext(ctx))
+		if _, err := program.Run(); err != nil {
+			return fmt.Errorf("failed to run interactive UI: %w", err)
+		}
+		return nil
+	})
+}
+
+// uiExecutionsMsg carries the result of an executions refresh.
+type uiExecutionsMsg struct {
+	executions []api.Execution
+	err        error
+}
+
+// uiTickMsg triggers a periodic executions refresh.
+type uiTickMsg struct{}
+
+// uiLogsMsg carries the initial logs response for the followed execution.
+type uiLogsMsg struct {
+	executionID string
+	resp        *api.LogsResponse
+	stream      *uiLogStream
+	err         error
+}
+
+// uiLogEventMsg carries a single streamed log event.
+type uiLogEventMsg struct {
+	executionID string
+	event       api.LogEvent
+}
+
+// uiLogStreamClosedMsg signals the log stream for an execution has ended.
+type uiLogStreamClosedMsg struct {
+	executionID string
+}
+
+// uiLogStream wraps a WebSocket log stream and exposes its events as bubbletea messages.
+type uiLogStream struct {
+	executionID string
+	conn        *websocket.Conn
+	events      chan tea.Msg
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// uiModel is the bubbletea model backing the interactive UI.
+type uiModel struct {
+	ctx        context.Context
+	client     client.Interface
+	dial       func(ctx context.Context, url string) (*websocket.Conn, error)
+	executions []api.Execution
+	cursor     int
+	following  string
+	logs       []api.LogEvent
+	logStatus  string
+	stream     *uiLogStream
+	err        error
+	height     int
+	lastUpdate time.Time
+}
+
+func newUIModel(ctx context.Context, c client.Interface) *uiModel {
+	return &uiModel{
+		ctx:    ctx,
+		client: c,
+		dial: func(ctx context.Context, url string) (*websocket.Conn, error) {
+			conn, resp, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+			if resp != nil && resp.Body != nil {
+				_ = resp.Body.Close()
+			}
+			return conn, err
+		},
+	}
+}
+
+// Init implements tea.Model.
+func (m *uiModel) Init() tea.Cmd {
+	return m.fetchExecutions()
+}
+
+// Update implements tea.Model.
+func (m *uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case uiTickMsg:
+		return m, m.fetchExecutions()
+	case uiExecutionsMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.executions = msg.executions
+			m.lastUpdate = time.Now()
+			m.cursor = min(m.cursor, max(len(m.executions)-1, 0))
+		}
+		return m, tea.Tick(constants.UIRefreshInterval, func(time.Time) tea.Msg { return uiTickMsg{} })
+	case uiLogsMsg:
+		return m.handleLogs(msg)
+	case uiLogEventMsg:
+		if msg.executionID != m.following || m.stream == nil {
+			return m, nil
+		}
+		m.logs = append(m.logs, msg.event)
+		return m, m.stream.next()
+	case uiLogStreamClosedMsg:
+		if msg.executionID == m.following {
+			m.logStatus = "stream closed"
+			m.stream = nil
+		}
+	}
+	return m, nil
+}
+
+func (m *uiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		m.closeStream()
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.executions)-1, 0))
+	case "r":
+		return m, m.fetchExecutions()
+	case "esc":
+		m.closeStream()
+		m.following = ""
+		m.logs = nil
+		m.logStatus = ""
+	case "enter":
+		if len(m.executions) == 0 {
+			return m, nil
+		}
+		m.closeStream()
+		m.following = m.executions[m.cursor].ExecutionID
+		m.logs = nil
+		m.logStatus = "loading"
+		return m, m.openLogs(m.following)
+	}
+	return m, nil
+}
+
+func (m *uiModel) handleLogs(msg uiLogsMsg) (tea.Model, tea.Cmd) {
+	if msg.executionID != m.following {
+		msg.stream.close()
+		return m, nil
+	}
+	if msg.err != nil {
+		m.logStatus = "error: " + msg.err.Error()
+		return m, nil
+	}
+	m.logs = msg.resp.Events
+	m.logStatus = msg.resp.Status
+	if msg.stream == nil {
+		return m, nil
+	}
+	m.stream = msg.stream
+	m.logStatus += " (streaming)"
+	return m, m.stream.next()
+}
+
+func (m *uiModel) closeStream() {
+	m.stream.close()
+	m.stream = nil
+}
+
+func (m *uiModel) fetchExecutions() tea.Cmd {
+	return func() tea.Msg {
+		execs, err := m.client.ListExecutions(m.ctx, constants.UIExecutionLimit, activeStatusesFilter())
+		return uiExecutionsMsg{executions: execs, err: err}
+	}
+}
+
+// openLogs fetches the current logs for an execution and, if it is still active,
+// connects to its WebSocket log stream.
+func (m *uiModel) openLogs(executionID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.client.GetLogs(m.ctx, executionID)
+		if err != nil {
+			return uiLogsMsg{executionID: executionID, err: err}
+		}
+		if isTerminalStatus(resp.Status) || resp.WebSocketURL == "" {
+			return uiLogsMsg{executionID: executionID, resp: resp}
+		}
+		conn, err := m.dial(m.ctx, resp.WebSocketURL)
+		if err != nil {
+			return uiLogsMsg{executionID: executionID, err: fmt.Errorf("failed to connect to log stream: %w", err)}
+		}
+		stream := &uiLogStream{
+			executionID: executionID,
+			conn:        conn,
+			events:      make(chan tea.Msg, constants.UILogStreamBufferSize),
+			done:        make(chan struct{}),
+		}
+		go stream.read()
+		return uiLogsMsg{executionID: executionID, resp: resp, stream: stream}
+	}
+}
+
+// read forwards WebSocket messages to the events channel until the connection closes.
+func (s *uiLogStream) read() {
+	defer close(s.events)
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		msg, err := parseStreamMessage(data)
+		if err != nil {
+			continue
+		}
+		if msg.disconnect {
+			return
+		}
+		// Status changes are shown by the executions list, which polls them.
+		if msg.logEvent == nil {
+			continue
+		}
+		select {
+		case s.events <- uiLogEventMsg{executionID: s.executionID, event: *msg.logEvent}:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// next returns a command waiting for the next message on the stream.
+func (s *uiLogStream) next() tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-s.events
+		if !ok {
+			return uiLogStreamClosedMsg{executionID: s.executionID}
+		}
+		return msg
+	}
+}
+
+func (s *uiLogStream) close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.done)
+		_ = s.conn.Close()
+	})
+}
+
+// View implements tea.Model.
+func (m *uiModel) View() string {
+	var b strings.Builder
+	b.WriteString(output.Bold(constants.ProjectName+" ui") + " - active executions")
+	if !m.lastUpdate.IsZero() {
+		b.WriteString(output.Gray(" (updated " + m.lastUpdate.Format(time.TimeOnly) + ")"))
+	}
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(output.Red("error: "+m.err.Error()) + "\n\n")
+	}
+
+	if len(m.executions) == 0 {
+		b.WriteString("No active executions\n")
+	}
+	for i := range m.executions {
+		b.WriteString(m.renderExecution(i) + "\n")
+	}
+
+	if m.following != "" {
+		b.WriteString("\n" + output.Bold("Logs for "+m.following) + " [" + m.logStatus + "]\n")
+		for _, event := range m.visibleLogs() {
+			timestamp := time.UnixMilli(event.Timestamp).UTC().Format(time.TimeOnly)
+			b.WriteString(output.Gray(timestamp) + " " + event.Message + "\n")
+		}
+	}
+
+	b.WriteString("\n" + output.Gray("↑/↓ move • enter follow logs • esc back • r refresh • q quit") + "\n")
+	return b.String()
+}
+
+func (m *uiModel) renderExecution(i int) string {
+	e := &m.executions[i]
+	cursor := "  "
+	if i == m.cursor {
+		cursor = output.Cyan("> ")
+	}
+	duration := time.Since(e.StartedAt).Truncate(time.Second)
+	return fmt.Sprintf("%s%-36s %-12s %-25s %-25s %8s  %s",
+		cursor, e.ExecutionID, e.Status, e.CreatedBy, e.ImageID, duration, truncateCommand(e.Command))
+}
+
+// visibleLogs returns the tail of the followed logs that fits in the remaining screen height.
+func (m *uiModel) visibleLogs() []api.LogEvent {
+	available := constants.UIDefaultLogLines
+	if m.height > 0 {
+		available = max(m.height-len(m.executions)-constants.UIReservedLines, 1)
+	}
+	if len(m.logs) <= available {
+		return m.logs
+	}
+	return m.logs[len(m.logs)-available:]
+}
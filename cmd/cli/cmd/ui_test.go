@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func newTestUIModel(logsFunc func(ctx context.Context, executionID string) (*api.LogsResponse, error)) *uiModel {
+	mockClient := &mockClientInterfaceForLogs{
+		mockClientInterface: &mockClientInterface{},
+		getLogsFunc:         logsFunc,
+	}
+	return newUIModel(context.Background(), mockClient)
+}
+
+func TestUIModel_ExecutionsRefreshAndNavigation(t *testing.T) {
+	m := newTestUIModel(nil)
+
+	_, cmd := m.Update(uiExecutionsMsg{executions: []api.Execution{
+		{ExecutionID: "exec-1", Status: "RUNNING", StartedAt: time.Now()},
+		{ExecutionID: "exec-2", Status: "STARTING", StartedAt: time.Now()},
+	}})
+	assert.NotNil(t, cmd, "expected a refresh tick to be scheduled")
+	assert.Len(t, m.executions, 2)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, m.cursor)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, m.cursor, "cursor should not move past the last execution")
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 0, m.cursor)
+
+	view := m.View()
+	assert.Contains(t, view, "exec-1")
+	assert.Contains(t, view, "exec-2")
+
+	m.Update(uiExecutionsMsg{executions: []api.Execution{{ExecutionID: "exec-3", Status: "RUNNING"}}})
+	assert.Equal(t, 0, m.cursor)
+}
+
+func TestUIModel_ExecutionsRefreshError(t *testing.T) {
+	m := newTestUIModel(nil)
+	m.executions = []api.Execution{{ExecutionID: "exec-1"}}
+
+	m.Update(uiExecutionsMsg{err: errors.New("boom")})
+	assert.Len(t, m.executions, 1, "previous executions should be kept on error")
+	assert.Contains(t, m.View(), "boom")
+}
+
+func TestUIModel_FollowTerminalExecutionLogs(t *testing.T) {
+	m := newTestUIModel(func(_ context.Context, executionID string) (*api.LogsResponse, error) {
+		return &api.LogsResponse{
+			ExecutionID: executionID,
+			Status:      "SUCCEEDED",
+			Events:      []api.LogEvent{{EventID: "1", Timestamp: 1000, Message: "hello world"}},
+		}, nil
+	})
+	m.executions = []api.Execution{{ExecutionID: "exec-1", Status: "RUNNING"}}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "exec-1", m.following)
+
+	msg := cmd()
+	logsMsg, ok := msg.(uiLogsMsg)
+	require.True(t, ok)
+	assert.Nil(t, logsMsg.stream)
+
+	m.Update(logsMsg)
+	assert.Equal(t, "SUCCEEDED", m.logStatus)
+	assert.Contains(t, m.View(), "hello world")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, m.following)
+	assert.Nil(t, m.logs)
+}
+
+func TestUIModel_IgnoresStaleLogMessages(t *testing.T) {
+	m := newTestUIModel(nil)
+	m.following = "exec-2"
+
+	m.Update(uiLogsMsg{executionID: "exec-1", resp: &api.LogsResponse{Status: "RUNNING"}})
+	assert.Empty(t, m.logStatus)
+
+	m.Update(uiLogEventMsg{executionID: "exec-1", event: api.LogEvent{Message: "stale"}})
+	assert.Empty(t, m.logs)
+}
+
+func TestUIModel_LogsError(t *testing.T) {
+	m := newTestUIModel(func(_ context.Context, _ string) (*api.LogsResponse, error) {
+		return nil, errors.New("not found")
+	})
+	m.executions = []api.Execution{{ExecutionID: "exec-1"}}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Contains(t, m.logStatus, "not found")
+}
+
+func TestUIModel_VisibleLogs(t *testing.T) {
+	m := newTestUIModel(nil)
+	for i := range 30 {
+		m.logs = append(m.logs, api.LogEvent{EventID: string(rune('a' + i))})
+	}
+
+	assert.Len(t, m.visibleLogs(), 20)
+
+	m.Update(tea.WindowSizeMsg{Height: 12})
+	visible := m.visibleLogs()
+	assert.Len(t, visible, 4)
+	assert.Equal(t, m.logs[len(m.logs)-1], visible[len(visible)-1])
+}
+
+func TestUIModel_Quit(t *testing.T) {
+	m := newTestUIModel(nil)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
@@ -50,6 +50,68 @@ func init() {
 	usersCmd.AddCommand(revokeUserCmd)
 }
 
+var pendingUsersCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List the invited users who have not claimed their API key",
+	Long: `List the invited users who have not claimed their API key yet, with the expiry of their claim token.
+Users whose claim token expired stay pending until a new token is issued to them, unless the backend
+is configured to delete them.`,
+	Example: fmt.Sprintf(`  - %s users pending`, constants.ProjectName),
+	Run:     runPendingUsers,
+}
+
+func runPendingUsers(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewUsersService(c, NewOutputWrapper())
+		return service.ListPendingUsers(ctx)
+	})
+}
+
+var reissueClaimTokenCmd = &cobra.Command{
+	Use:   "reissue <email>",
+	Short: "Issue a new claim token to an invited user",
+	Long: `Issue a new claim token to an invited user who has not claimed their API key yet.
+The previous claim token of the user can no longer be claimed.`,
+	Example: fmt.Sprintf(`  - %s users pending reissue alice@example.com`, constants.ProjectName),
+	Run:     runReissueClaimToken,
+	Args:    cobra.ExactArgs(1),
+}
+
+func runReissueClaimToken(cmd *cobra.Command, args []string) {
+	email := args[0]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewUsersService(c, NewOutputWrapper())
+		return service.ReissueClaimToken(ctx, email)
+	})
+}
+
+func init() {
+	pendingUsersCmd.AddCommand(reissueClaimTokenCmd)
+	usersCmd.AddCommand(pendingUsersCmd)
+}
+
+var transferOwnershipCmd = &cobra.Command{
+	Use:   "transfer <service-account> <new-owner>",
+	Short: "Transfer a service account to another owner",
+	Long: `Transfer a service account to another owner, e.g. before revoking the user who owns it.
+The service account keeps its API key, role and budget, so the automations using it keep working.`,
+	Example: fmt.Sprintf(`  - %s users transfer ci-deploy@example.com bob@example.com`, constants.ProjectName),
+	Run:     runTransferOwnership,
+	Args:    cobra.ExactArgs(2),
+}
+
+func runTransferOwnership(cmd *cobra.Command, args []string) {
+	email, owner := args[0], args[1]
+	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
+		service := NewUsersService(c, NewOutputWrapper())
+		return service.TransferOwnership(ctx, email, owner)
+	})
+}
+
+func init() {
+	usersCmd.AddCommand(transferOwnershipCmd)
+}
+
 var usersCmd = &cobra.Command{
 	Use:   "users",
 	Short: "User management commands",
@@ -58,17 +120,25 @@ var usersCmd = &cobra.Command{
 var createUserCmd = &cobra.Command{
 	Use:   "create <email> --role <role>",
 	Short: "Create a new user",
-	Long:  `Create a new user with the given email and role`,
+	Long: `Create a new user with the given email and role.
+With --owner, the user is a service account of an automation, owned by an existing user who claims
+its API key. Service accounts have their own API keys and budgets, and are listed separately.`,
 	Example: fmt.Sprintf(`  - %s users create alice@example.com --role viewer
-  - %s users create bob@another-example.com --role developer`, constants.ProjectName, constants.ProjectName),
+  - %s users create bob@another-example.com --role developer
+  - %s users create ci-deploy@example.com --role developer --owner alice@example.com`,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName),
 	Run:  runCreateUser,
 	Args: cobra.ExactArgs(1),
 }
 
-var userRole string
+var (
+	userRole  string
+	userOwner string
+)
 
 func init() {
 	createUserCmd.Flags().StringVar(&userRole, "role", "", "User role (admin, operator, developer, or viewer)")
+	createUserCmd.Flags().StringVar(&userOwner, "owner", "", "Create a service account owned by this user")
 	_ = createUserCmd.MarkFlagRequired("role")
 	usersCmd.AddCommand(createUserCmd)
 	rootCmd.AddCommand(usersCmd)
@@ -78,7 +148,7 @@ func runCreateUser(cmd *cobra.Command, args []string) {
 	email := args[0]
 	executeWithClient(cmd, func(ctx context.Context, c client.Interface) error {
 		service := NewUsersService(c, NewOutputWrapper())
-		return service.CreateUser(ctx, email, userRole)
+		return service.CreateUser(ctx, email, userRole, userOwner)
 	})
 }
 
@@ -96,21 +166,90 @@ func NewUsersService(apiClient client.Interface, outputter OutputInterface) *Use
 	}
 }
 
-// CreateUser creates a new user with the given email and role.
-func (s *UsersService) CreateUser(ctx context.Context, email, role string) error {
-	s.output.Infof("Creating user with email %s and role %s...", email, role)
+// CreateUser creates a new user with the given email and role, a service account of owner if it is set.
+func (s *UsersService) CreateUser(ctx context.Context, email, role, owner string) error {
+	if owner != "" {
+		s.output.Infof("Creating service account %s with role %s owned by %s...", email, role, owner)
+	} else {
+		s.output.Infof("Creating user with email %s and role %s...", email, role)
+	}
 
 	resp, err := s.client.CreateUser(ctx, api.CreateUserRequest{
-		Email: email,
-		Role:  role,
+		Email:          email,
+		Role:           role,
+		ServiceAccount: owner != "",
+		Owner:          owner,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
 	s.output.Successf("User created successfully")
+	s.displayClaimToken(resp)
+	return nil
+}
+
+// ListPendingUsers lists the invited users who have not claimed their API key yet.
+func (s *UsersService) ListPendingUsers(ctx context.Context) error {
+	s.output.Infof("Listing pending users…")
+
+	resp, err := s.client.ListPendingUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending users: %w", err)
+	}
+
+	if len(resp.Users) == 0 {
+		s.output.Blank()
+		s.output.Warningf("No pending users found")
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([][]string, 0, len(resp.Users))
+	for _, u := range resp.Users {
+		claimExpiresAt := "-"
+		if u.ClaimExpiresAt != nil {
+			claimExpiresAt = u.ClaimExpiresAt.UTC().Format(time.DateTime)
+			if !u.ClaimExpiresAt.After(now) {
+				claimExpiresAt += " (expired)"
+			}
+		}
+		rows = append(rows, []string{
+			s.output.Bold(u.Email),
+			u.Role,
+			u.CreatedAt.UTC().Format(time.DateTime),
+			claimExpiresAt,
+		})
+	}
+
+	s.output.Blank()
+	s.output.Table([]string{"Email", "Role", "Invited (UTC)", "Claim Token Expires (UTC)"}, rows)
+	s.output.Blank()
+	s.output.Infof("Issue a new claim token with %s users pending reissue <email>", constants.ProjectName)
+	return nil
+}
+
+// ReissueClaimToken issues a new claim token to an invited user who has not claimed their API key yet.
+func (s *UsersService) ReissueClaimToken(ctx context.Context, email string) error {
+	s.output.Infof("Issuing a new claim token to %s...", email)
+
+	resp, err := s.client.ReissueClaimToken(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to reissue claim token: %w", err)
+	}
+
+	s.output.Successf("Claim token issued successfully")
+	s.displayClaimToken(resp)
+	return nil
+}
+
+// displayClaimToken prints the claim token of an invited user with the command claiming it.
+func (s *UsersService) displayClaimToken(resp *api.CreateUserResponse) {
 	s.output.KeyValue("Email", resp.User.Email)
 	s.output.KeyValue("Role", resp.User.Role)
+	if resp.User.ServiceAccount {
+		s.output.KeyValue("Owner", resp.User.Owner)
+	}
 	s.output.KeyValue("Claim Token", resp.ClaimToken)
 	s.output.Blank()
 	s.output.Infof(
@@ -119,9 +258,12 @@ func (s *UsersService) CreateUser(ctx context.Context, email, role string) error
 		s.output.Bold(resp.ClaimToken),
 	)
 	s.output.Blank()
-	s.output.Warningf("⏱  Token expires in 15 minutes")
+	if resp.User.ClaimExpiresAt != nil {
+		s.output.Warningf("⏱  Token expires at %s UTC", resp.User.ClaimExpiresAt.UTC().Format(time.DateTime))
+	} else {
+		s.output.Warningf("⏱  Token expires in 15 minutes")
+	}
 	s.output.Warningf("👁  Can only be viewed once")
-	return nil
 }
 
 // ListUsers lists all users and displays them in a table format.
@@ -139,24 +281,64 @@ func (s *UsersService) ListUsers(ctx context.Context) error {
 		return nil
 	}
 
-	rows := s.formatUsers(resp.Users)
+	var humans, serviceAccounts []*api.User
+	for _, u := range resp.Users {
+		if u.ServiceAccount {
+			serviceAccounts = append(serviceAccounts, u)
+		} else {
+			humans = append(humans, u)
+		}
+	}
 
-	s.output.Blank()
-	s.output.Table(
-		[]string{
-			"Email",
-			"Role",
-			"Status",
-			"Created (UTC)",
-			"Last Used (UTC)",
-		},
-		rows,
-	)
+	if len(humans) > 0 {
+		s.output.Blank()
+		s.output.Table(
+			[]string{
+				"Email",
+				"Role",
+				"Status",
+				"Created (UTC)",
+				"Last Used (UTC)",
+			},
+			s.formatUsers(humans),
+		)
+	}
+	if len(serviceAccounts) > 0 {
+		s.output.Blank()
+		s.output.Infof("Service accounts")
+		s.output.Table(
+			[]string{
+				"Email",
+				"Role",
+				"Owner",
+				"Status",
+				"Created (UTC)",
+				"Last Used (UTC)",
+			},
+			s.formatServiceAccounts(serviceAccounts, resp.Users),
+		)
+	}
 	s.output.Blank()
 	s.output.Successf("Users listed successfully")
 	return nil
 }
 
+// TransferOwnership transfers a service account to another owner.
+func (s *UsersService) TransferOwnership(ctx context.Context, email, owner string) error {
+	s.output.Infof("Transferring service account %s to %s...", email, owner)
+
+	resp, err := s.client.TransferOwnership(ctx, email, api.TransferOwnershipRequest{Owner: owner})
+	if err != nil {
+		return fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	s.output.Successf("Ownership transferred successfully")
+	s.output.KeyValue("Service Account", resp.User.Email)
+	s.output.KeyValue("Previous Owner", resp.PreviousOwner)
+	s.output.KeyValue("Owner", resp.User.Owner)
+	return nil
+}
+
 // RevokeUser revokes a user's API key.
 func (s *UsersService) RevokeUser(ctx context.Context, email string) error {
 	s.output.Infof("Revoking user with email %s...", email)
@@ -177,25 +359,63 @@ func (s *UsersService) RevokeUser(ctx context.Context, email string) error {
 func (s *UsersService) formatUsers(users []*api.User) [][]string {
 	rows := make([][]string, 0, len(users))
 	for _, u := range users {
-		createdAt := u.CreatedAt.UTC().Format(time.DateTime)
+		rows = append(rows, []string{
+			s.output.Bold(u.Email),
+			u.Role,
+			userStatus(u),
+			u.CreatedAt.UTC().Format(time.DateTime),
+			userLastUsed(u),
+		})
+	}
+	return rows
+}
 
-		lastUsed := "Never"
-		if u.LastUsed != nil && !u.LastUsed.IsZero() {
-			lastUsed = u.LastUsed.UTC().Format(time.DateTime)
-		}
+// formatServiceAccounts formats service accounts into table rows, flagging the owners revoked among users.
+func (s *UsersService) formatServiceAccounts(serviceAccounts, users []*api.User) [][]string {
+	revoked := make(map[string]bool, len(users))
+	for _, u := range users {
+		revoked[u.Email] = u.Revoked
+	}
 
-		status := "Active"
-		if u.Revoked {
-			status = "Revoked"
+	rows := make([][]string, 0, len(serviceAccounts))
+	for _, u := range serviceAccounts {
+		owner := u.Owner
+		if revoked[owner] {
+			owner += " (revoked)"
 		}
-
 		rows = append(rows, []string{
 			s.output.Bold(u.Email),
 			u.Role,
-			status,
-			createdAt,
-			lastUsed,
+			owner,
+			userStatus(u),
+			u.CreatedAt.UTC().Format(time.DateTime),
+			userLastUsed(u),
 		})
 	}
 	return rows
 }
+
+// userStatus returns the status of a user shown in the users list.
+func userStatus(u *api.User) string {
+	status := "Active"
+	switch {
+	case u.Revoked:
+		status = "Revoked"
+	case u.PendingLogin:
+		status = "Pending login"
+	case u.ClaimExpiresAt != nil:
+		status = "Pending claim"
+	}
+	if u.Provisioned {
+		status += " (SSO)"
+	}
+	return status
+}
+
+// userLastUsed returns when a user last used their API key, for the users list.
+func userLastUsed(u *api.User) string {
+	if u.LastUsed != nil && !u.LastUsed.IsZero() {
+		return u.LastUsed.UTC().Format(time.DateTime)
+	}
+	return "Never"
+}
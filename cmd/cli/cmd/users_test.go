@@ -3,10 +3,12 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/runvoy/runvoy/internal/api"
 )
@@ -138,7 +140,7 @@ func TestUsersService_CreateUser(t *testing.T) {
 			mockOutput := &mockOutputInterface{}
 			service := NewUsersService(mockClient, mockOutput)
 
-			err := service.CreateUser(context.Background(), tt.email, "viewer")
+			err := service.CreateUser(context.Background(), tt.email, "viewer", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -416,3 +418,127 @@ func TestUsersService_RevokeUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUsersService_ListPendingUsers(t *testing.T) {
+	expired := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	valid := time.Now().Add(time.Hour)
+	mockClient := &mockClientInterface{
+		listPendingUsersFunc: func(_ context.Context) (*api.ListUsersResponse, error) {
+			return &api.ListUsersResponse{Users: []*api.User{
+				{Email: "alice@example.com", Role: "viewer", CreatedAt: expired, ClaimExpiresAt: &expired},
+				{Email: "bob@example.com", Role: "developer", CreatedAt: expired, ClaimExpiresAt: &valid},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	require.NoError(t, NewUsersService(mockClient, mockOutput).ListPendingUsers(context.Background()))
+
+	var rows [][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			rows = call.args[1].([][]string)
+		}
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, "2026-01-02 03:04:05 (expired)", rows[0][3])
+	assert.Equal(t, valid.UTC().Format(time.DateTime), rows[1][3])
+}
+
+func TestUsersService_ReissueClaimToken(t *testing.T) {
+	t.Run("shows the new claim token", func(t *testing.T) {
+		expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockClient := &mockClientInterface{
+			reissueClaimTokenFunc: func(_ context.Context, email string) (*api.CreateUserResponse, error) {
+				assert.Equal(t, "alice@example.com", email)
+				return &api.CreateUserResponse{
+					User:       &api.User{Email: email, Role: "viewer", ClaimExpiresAt: &expiresAt},
+					ClaimToken: "token-456",
+				}, nil
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+
+		require.NoError(t, NewUsersService(mockClient, mockOutput).ReissueClaimToken(
+			context.Background(), "alice@example.com"))
+
+		var keyValues, warnings []string
+		for _, call := range mockOutput.calls {
+			switch call.method {
+			case "KeyValue":
+				keyValues = append(keyValues, call.args[1].(string))
+			case "Warningf":
+				warnings = append(warnings, fmt.Sprintf(call.args[0].(string), call.args[1].([]any)...))
+			}
+		}
+		assert.Contains(t, keyValues, "token-456")
+		assert.Contains(t, warnings, "⏱  Token expires at 2026-01-02 03:04:05 UTC")
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		mockClient := &mockClientInterface{
+			reissueClaimTokenFunc: func(_ context.Context, _ string) (*api.CreateUserResponse, error) {
+				return nil, errors.New("user has already claimed their API key")
+			},
+		}
+
+		err := NewUsersService(mockClient, &mockOutputInterface{}).ReissueClaimToken(
+			context.Background(), "alice@example.com")
+		assert.ErrorContains(t, err, "failed to reissue claim token")
+	})
+}
+
+func TestUsersService_ListUsers_ServiceAccounts(t *testing.T) {
+	mockClient := &mockClientInterfaceForUsers{
+		mockClientInterface: &mockClientInterface{},
+		listUsersFunc: func(_ context.Context) (*api.ListUsersResponse, error) {
+			return &api.ListUsersResponse{Users: []*api.User{
+				{Email: "alice@example.com", Role: "developer", Revoked: true},
+				{Email: "bob@example.com", Role: "developer"},
+				{Email: "ci@example.com", Role: "developer", ServiceAccount: true, Owner: "alice@example.com"},
+			}}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	require.NoError(t, NewUsersService(mockClient, mockOutput).ListUsers(context.Background()))
+
+	var tables [][][]string
+	for _, call := range mockOutput.calls {
+		if call.method == "Table" {
+			tables = append(tables, call.args[1].([][]string))
+		}
+	}
+	require.Len(t, tables, 2)
+	assert.Len(t, tables[0], 2)
+	require.Len(t, tables[1], 1)
+	assert.Equal(t, "alice@example.com (revoked)", tables[1][0][2])
+	assert.Equal(t, "Active", tables[1][0][3])
+}
+
+func TestUsersService_TransferOwnership(t *testing.T) {
+	mockClient := &mockClientInterface{
+		transferOwnershipFunc: func(
+			_ context.Context, email string, req api.TransferOwnershipRequest,
+		) (*api.TransferOwnershipResponse, error) {
+			assert.Equal(t, "ci@example.com", email)
+			return &api.TransferOwnershipResponse{
+				User:          &api.User{Email: email, ServiceAccount: true, Owner: req.Owner},
+				PreviousOwner: "alice@example.com",
+			}, nil
+		},
+	}
+	mockOutput := &mockOutputInterface{}
+
+	require.NoError(t, NewUsersService(mockClient, mockOutput).TransferOwnership(
+		context.Background(), "ci@example.com", "bob@example.com"))
+
+	keyValues := map[string]string{}
+	for _, call := range mockOutput.calls {
+		if call.method == "KeyValue" {
+			keyValues[call.args[0].(string)] = call.args[1].(string)
+		}
+	}
+	assert.Equal(t, "alice@example.com", keyValues["Previous Owner"])
+	assert.Equal(t, "bob@example.com", keyValues["Owner"])
+}
@@ -29,6 +29,9 @@ var versionCmd = &cobra.Command{
 		}
 
 		output.KeyValue("Backend version", health.Version)
+		if health.MinClientVersion != "" {
+			output.KeyValue("Minimum CLI version", health.MinClientVersion)
+		}
 		output.KeyValue("Backend provider", string(health.Provider))
 		if health.Region != "" {
 			output.KeyValue("Backend region", health.Region)
@@ -36,6 +39,14 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// showVersionSkew warns when the versions of the CLI and of the backend of the API responses of the command
+// differ by more than their patch version.
+func showVersionSkew() {
+	if skew := client.LastVersionSkew(); skew != nil {
+		output.Warningf("Version skew: %s", skew.Message)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 }
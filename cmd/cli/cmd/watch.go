@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/client"
+	"github.com/runvoy/runvoy/internal/client/output"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch active executions",
+	Long: `Watch active executions in a continuously updating table, refreshing in place.
+
+By default the backend is polled at a fixed interval. Use --stream to subscribe to the
+executions Server-Sent Events stream instead (best suited to deployments supporting response streaming).
+
+NOTICE: the command timeout does not apply, press Ctrl+C to exit.`,
+	Example: fmt.Sprintf(`  # Watch STARTING, RUNNING and TERMINATING executions
+  - %s watch
+
+  # Watch only RUNNING executions, refreshing every 5 seconds
+  - %s watch --status RUNNING --interval 5s
+
+  # Subscribe to the executions stream
+  - %s watch --stream`,
+		constants.ProjectName, constants.ProjectName, constants.ProjectName),
+	Run: watchRun,
+}
+
+var (
+	watchStatusFlag   string
+	watchIntervalFlag time.Duration
+	watchStreamFlag   bool
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringVar(&watchStatusFlag, "status", "",
+		"comma-separated list of execution statuses to watch (default: STARTING,RUNNING,TERMINATING)")
+	watchCmd.Flags().DurationVar(&watchIntervalFlag, "interval", constants.WatchPollInterval,
+		"polling interval (ignored with --stream)")
+	watchCmd.Flags().BoolVar(&watchStreamFlag, "stream", false,
+		"subscribe to the executions stream instead of polling")
+}
+
+func watchRun(cmd *cobra.Command, _ []string) {
+	executeWithClient(cmd, func(_ context.Context, c client.Interface) error {
+		// Watching is long-lived, so it deliberately ignores the command timeout.
+		ctx, stop := signal.NotifyContext(context.WithoutCancel(cmd.Context()), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		statuses := strings.ToUpper(watchStatusFlag)
+		if statuses == "" {
+			statuses = activeStatusesFilter()
+		}
+
+		service := NewWatchService(c, NewOutputWrapper())
+		if watchStreamFlag {
+			return service.Stream(ctx, statuses)
+		}
+		return service.Poll(ctx, statuses, watchIntervalFlag)
+	})
+}
+
+// WatchService handles rendering a continuously updating view of executions.
+type WatchService struct {
+	client client.Interface
+	output OutputInterface
+	clear  func()
+	now    func() time.Time
+}
+
+// NewWatchService creates a new WatchService with the provided dependencies.
+func NewWatchService(apiClient client.Interface, outputter OutputInterface) *WatchService {
+	return &WatchService{
+		client: apiClient,
+		output: outputter,
+		clear: func() {
+			output.Printf("\033[H\033[2J")
+		},
+		now: time.Now,
+	}
+}
+
+// Poll refreshes the executions view every interval until the context is canceled.
+func (s *WatchService) Poll(ctx context.Context, statuses string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		execs, err := s.client.ListExecutions(ctx, constants.ExecutionsStreamLimit, statuses)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to list executions: %w", err)
+		}
+		s.render(execs, statuses)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stream subscribes to the executions stream and refreshes the view on every snapshot.
+// The stream is re-established whenever the server closes it, until the context is canceled.
+func (s *WatchService) Stream(ctx context.Context, statuses string) error {
+	for {
+		err := s.client.StreamExecutions(ctx, statuses, func(execs []api.Execution) error {
+			s.render(execs, statuses)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("failed to stream executions: %w", err)
+		}
+	}
+}
+
+// render redraws the executions table in place.
+func (s *WatchService) render(execs []api.Execution, statuses string) {
+	now := s.now()
+	rows := make([][]string, 0, len(execs))
+	for i := range execs {
+		e := &execs[i]
+		rows = append(rows, []string{
+			s.output.Bold(e.ExecutionID),
+			e.Status,
+			e.CreatedBy,
+			e.ImageID,
+			now.Sub(e.StartedAt).Truncate(time.Second).String(),
+			truncateCommand(e.Command),
+		})
+	}
+
+	s.clear()
+	s.output.Infof("Watching %s executions (updated %s), press Ctrl+C to exit",
+		s.output.Bold(statuses), now.UTC().Format(time.DateTime))
+	s.output.Blank()
+	s.output.Table([]string{"Execution ID", "Status", "User", "Image", "Duration", "Command"}, rows)
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// mockClientInterfaceForWatch extends mockClientInterfaceForList with StreamExecutions
+type mockClientInterfaceForWatch struct {
+	*mockClientInterfaceForList
+	streamExecutionsFunc func(ctx context.Context, statuses string, onSnapshot func([]api.Execution) error) error
+}
+
+func (m *mockClientInterfaceForWatch) StreamExecutions(
+	ctx context.Context,
+	statuses string,
+	onSnapshot func([]api.Execution) error,
+) error {
+	if m.streamExecutionsFunc != nil {
+		return m.streamExecutionsFunc(ctx, statuses, onSnapshot)
+	}
+	return errors.New("not implemented")
+}
+
+func newTestWatchService(mockClient *mockClientInterfaceForWatch, mockOutput *mockOutputInterface) (*WatchService, *int) {
+	clears := 0
+	service := NewWatchService(mockClient, mockOutput)
+	service.clear = func() { clears++ }
+	service.now = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return service, &clears
+}
+
+func TestWatchService_Poll(t *testing.T) {
+	t.Run("renders a table on every refresh until canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		mockClient := &mockClientInterfaceForWatch{
+			mockClientInterfaceForList: &mockClientInterfaceForList{
+				mockClientInterface: &mockClientInterface{},
+				listExecutionsFunc: func(_ context.Context, _ int, statuses string) ([]api.Execution, error) {
+					assert.Equal(t, "RUNNING", statuses)
+					calls++
+					if calls == 2 {
+						cancel()
+					}
+					return []api.Execution{{
+						ExecutionID: "exec-1",
+						Status:      "RUNNING",
+						Command:     "sleep 60",
+						CreatedBy:   "user@example.com",
+						ImageID:     "alpine:latest-abc123",
+						StartedAt:   time.Date(2025, 1, 1, 11, 58, 30, 0, time.UTC),
+					}}, nil
+				},
+			},
+		}
+		mockOutput := &mockOutputInterface{}
+		service, clears := newTestWatchService(mockClient, mockOutput)
+
+		err := service.Poll(ctx, "RUNNING", time.Millisecond)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, *clears)
+
+		var tables int
+		for _, call := range mockOutput.calls {
+			if call.method != "Table" {
+				continue
+			}
+			tables++
+			headers := call.args[0].([]string)
+			assert.Equal(t, []string{"Execution ID", "Status", "User", "Image", "Duration", "Command"}, headers)
+			rows := call.args[1].([][]string)
+			require.Len(t, rows, 1)
+			assert.Equal(t, "user@example.com", rows[0][2])
+			assert.Equal(t, "alpine:latest-abc123", rows[0][3])
+			assert.Equal(t, "1m30s", rows[0][4])
+		}
+		assert.Equal(t, 2, tables)
+	})
+
+	t.Run("returns client errors", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForWatch{
+			mockClientInterfaceForList: &mockClientInterfaceForList{
+				mockClientInterface: &mockClientInterface{},
+				listExecutionsFunc: func(_ context.Context, _ int, _ string) ([]api.Execution, error) {
+					return nil, errors.New("network error")
+				},
+			},
+		}
+		service, _ := newTestWatchService(mockClient, &mockOutputInterface{})
+
+		err := service.Poll(context.Background(), "RUNNING", time.Second)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "network error")
+	})
+
+	t.Run("rejects non-positive interval", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForWatch{
+			mockClientInterfaceForList: &mockClientInterfaceForList{mockClientInterface: &mockClientInterface{}},
+		}
+		service, _ := newTestWatchService(mockClient, &mockOutputInterface{})
+
+		err := service.Poll(context.Background(), "RUNNING", 0)
+
+		require.Error(t, err)
+	})
+}
+
+func TestWatchService_Stream(t *testing.T) {
+	t.Run("reconnects when the server closes the stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		connections := 0
+		mockClient := &mockClientInterfaceForWatch{
+			mockClientInterfaceForList: &mockClientInterfaceForList{mockClientInterface: &mockClientInterface{}},
+			streamExecutionsFunc: func(_ context.Context, statuses string, onSnapshot func([]api.Execution) error) error {
+				assert.Equal(t, "STARTING,RUNNING", statuses)
+				connections++
+				if connections == 3 {
+					cancel()
+				}
+				return onSnapshot([]api.Execution{{ExecutionID: "exec-1", Status: "RUNNING"}})
+			},
+		}
+		service, clears := newTestWatchService(mockClient, &mockOutputInterface{})
+
+		err := service.Stream(ctx, "STARTING,RUNNING")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, connections)
+		assert.Equal(t, 3, *clears)
+	})
+
+	t.Run("returns stream errors", func(t *testing.T) {
+		mockClient := &mockClientInterfaceForWatch{
+			mockClientInterfaceForList: &mockClientInterfaceForList{mockClientInterface: &mockClientInterface{}},
+			streamExecutionsFunc: func(_ context.Context, _ string, _ func([]api.Execution) error) error {
+				return errors.New("forbidden")
+			},
+		}
+		service, _ := newTestWatchService(mockClient, &mockOutputInterface{})
+
+		err := service.Stream(context.Background(), "RUNNING")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forbidden")
+	})
+}
@@ -7,6 +7,19 @@ set -e
 PROCESSOR_URL="${PROCESSOR_URL:-http://localhost:56213}"
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 
+# post_event posts an event to the processor, signed when RUNVOY_PROCESSOR_SIGNING_SECRET is set.
+post_event() {
+    local timestamp signature
+    local headers=(-H "Content-Type: application/json")
+    if [ -n "$RUNVOY_PROCESSOR_SIGNING_SECRET" ]; then
+        timestamp="$(date +%s)"
+        signature="$( (printf '%s.' "$timestamp"; cat "$1") \
+            | openssl dgst -sha256 -hmac "$RUNVOY_PROCESSOR_SIGNING_SECRET" | sed 's/^.* //')"
+        headers+=(-H "X-Runvoy-Timestamp: $timestamp" -H "X-Runvoy-Signature: $signature")
+    fi
+    curl -s -X POST "$PROCESSOR_URL/process" "${headers[@]}" --data-binary @"$1"
+}
+
 echo "Testing local development server at $PROCESSOR_URL"
 echo ""
 
@@ -18,18 +31,14 @@ echo ""
 # Test ECS task completion event
 if [ -f "$SCRIPT_DIR/ecs-task-completion.json" ]; then
     echo "2. Testing ECS task completion event..."
-    curl -s -X POST "$PROCESSOR_URL/process" \
-        -H "Content-Type: application/json" \
-        -d @"$SCRIPT_DIR/ecs-task-completion.json" | jq .
+    post_event "$SCRIPT_DIR/ecs-task-completion.json" | jq .
     echo ""
 fi
 
 # Test WebSocket event
 if [ -f "$SCRIPT_DIR/websocket-event.json" ]; then
     echo "3. Testing WebSocket connection event..."
-    curl -s -X POST "$PROCESSOR_URL/process" \
-        -H "Content-Type: application/json" \
-        -d @"$SCRIPT_DIR/websocket-event.json" | jq .
+    post_event "$SCRIPT_DIR/websocket-event.json" | jq .
     echo ""
 fi
 
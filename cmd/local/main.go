@@ -59,6 +59,7 @@ func startOrchestratorServer(log *slog.Logger, cfg *config.Config, svc *orchestr
 			ReadTimeout:  constants.ServerReadTimeout,
 			WriteTimeout: constants.ServerWriteTimeout,
 			IdleTimeout:  constants.ServerIdleTimeout,
+			Protocols:    orchestratorProtocols(),
 		}
 		if serveErr := srv.ListenAndServe(); serveErr != nil && serveErr != http.ErrServerClosed {
 			serverErrors <- fmt.Errorf("orchestrator server failed: %w", serveErr)
@@ -72,9 +73,19 @@ func startOrchestratorServer(log *slog.Logger, cfg *config.Config, svc *orchestr
 		ReadTimeout:  constants.ServerReadTimeout,
 		WriteTimeout: constants.ServerWriteTimeout,
 		IdleTimeout:  constants.ServerIdleTimeout,
+		Protocols:    orchestratorProtocols(),
 	}
 }
 
+// orchestratorProtocols are the protocols of the local orchestrator server: HTTP/1 and, as it serves plain
+// HTTP, HTTP/2 without TLS (h2c) for the clients and proxies speaking it.
+func orchestratorProtocols() *http.Protocols {
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	return protocols
+}
+
 func startAsyncProcessorServer(log *slog.Logger, cfg *config.Config, proc processor.Processor,
 	serverErrors chan error, wg *sync.WaitGroup) *http.Server {
 	wg.Go(func() {
@@ -87,8 +98,12 @@ func startAsyncProcessorServer(log *slog.Logger, cfg *config.Config, proc proces
 		log.Debug("async processor endpoint available",
 			"url", fmt.Sprintf("http://localhost:%d/process", port),
 		)
+		if cfg.ProcessorSigningSecret == "" {
+			log.Warn("async processor endpoint accepts unsigned requests from the loopback interface, " +
+				"set RUNVOY_PROCESSOR_SIGNING_SECRET to require signed ones")
+		}
 
-		router := server.NewRouter(proc, log)
+		router := server.NewRouter(proc, log, cfg.ProcessorSigningSecret)
 		srv := &http.Server{
 			Addr:         fmt.Sprintf(":%d", port),
 			Handler:      router,
@@ -101,7 +116,7 @@ func startAsyncProcessorServer(log *slog.Logger, cfg *config.Config, proc proces
 		}
 	})
 
-	router := server.NewRouter(proc, log)
+	router := server.NewRouter(proc, log, cfg.ProcessorSigningSecret)
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
 		Handler:      router,
@@ -2,20 +2,26 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/runvoy/runvoy/internal/auth"
 	"github.com/runvoy/runvoy/internal/backend/processor"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-// NewRouter creates a chi router for the async event processor.
-func NewRouter(proc processor.Processor, log *slog.Logger) *chi.Mux {
+// NewRouter creates a chi router for the async event processor. When signingSecret is set, the
+// requests to process events must be signed with it using auth.SignRequest, otherwise they are only
+// accepted from the loopback interface.
+func NewRouter(proc processor.Processor, log *slog.Logger, signingSecret string) *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
@@ -31,7 +37,7 @@ func NewRouter(proc processor.Processor, log *slog.Logger) *chi.Mux {
 	// Process raw Lambda event
 	// Accepts a JSON payload and processes it through the event processor
 	// Example: curl -X POST http://localhost:8081/process -d @event.json
-	r.Post("/process", func(w http.ResponseWriter, req *http.Request) {
+	r.With(requireSignature(signingSecret, log)).Post("/process", func(w http.ResponseWriter, req *http.Request) {
 		defer func() {
 			_ = req.Body.Close()
 		}()
@@ -72,6 +78,51 @@ func NewRouter(proc processor.Processor, log *slog.Logger) *chi.Mux {
 	return r
 }
 
+// requireSignature rejects the requests not signed with the secret. When the secret is empty,
+// only the requests from the loopback interface are let through.
+func requireSignature(secret string, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if secret == "" {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if !isLoopback(req.RemoteAddr) {
+					log.Warn("rejected unsigned event processor request from another host", "remote_addr", req.RemoteAddr)
+					writeErrorResponse(w, http.StatusUnauthorized, "unauthorized",
+						"unsigned requests are only accepted from the loopback interface")
+					return
+				}
+				next.ServeHTTP(w, req)
+			})
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			body, readErr := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if readErr != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "failed to read request body", readErr.Error())
+				return
+			}
+
+			if err := auth.VerifyRequestSignature(req.Header, body, secret, time.Now()); err != nil {
+				log.Warn("rejected unsigned event processor request", "error", err, "remote_addr", req.RemoteAddr)
+				writeErrorResponse(w, http.StatusUnauthorized, "unauthorized", err.Error())
+				return
+			}
+
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// isLoopback reports whether the remote address of a request is on the loopback interface.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func writeErrorResponse(w http.ResponseWriter, statusCode int, message, details string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/client/devstack"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningSecret = "0123456789abcdef0123456789abcdef"
+
+type recordingProcessor struct {
+	events []string
+}
+
+func (p *recordingProcessor) Handle(_ context.Context, rawEvent *json.RawMessage) (*json.RawMessage, error) {
+	p.events = append(p.events, string(*rawEvent))
+	return nil, nil
+}
+
+func (p *recordingProcessor) HandleEventJSON(ctx context.Context, eventJSON *json.RawMessage) error {
+	_, err := p.Handle(ctx, eventJSON)
+	return err
+}
+
+func TestRouterSignedRequests(t *testing.T) {
+	event := `{"source":"aws.ecs"}`
+	newRequest := func(t *testing.T, secret, remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/process", strings.NewReader(event))
+		if remoteAddr != "" {
+			req.RemoteAddr = remoteAddr
+		}
+		if secret != "" {
+			require.NoError(t, auth.SignRequest(req, secret))
+		}
+		return req
+	}
+
+	tests := []struct {
+		name          string
+		routerSecret  string
+		requestSecret string
+		remoteAddr    string
+		wantStatus    int
+	}{
+		{"processes signed requests", testSigningSecret, testSigningSecret, "", http.StatusOK},
+		{"rejects unsigned requests", testSigningSecret, "", "", http.StatusUnauthorized},
+		{"rejects unsigned loopback requests", testSigningSecret, "", "127.0.0.1:41000", http.StatusUnauthorized},
+		{"rejects requests signed with another secret", testSigningSecret, "another-secret", "",
+			http.StatusUnauthorized},
+		{"processes unsigned loopback requests without a secret", "", "", "127.0.0.1:41000", http.StatusOK},
+		{"processes unsigned IPv6 loopback requests without a secret", "", "", "[::1]:41000", http.StatusOK},
+		{"rejects unsigned remote requests without a secret", "", "", "203.0.113.7:41000", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proc := &recordingProcessor{}
+			router := NewRouter(proc, testutil.SilentLogger(), tt.routerSecret)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, newRequest(t, tt.requestSecret, tt.remoteAddr))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, []string{event}, proc.events)
+			} else {
+				assert.Empty(t, proc.events)
+			}
+		})
+	}
+
+	t.Run("leaves the health check open", func(t *testing.T) {
+		router := NewRouter(&recordingProcessor{}, testutil.SilentLogger(), testSigningSecret)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestRouterEventsSentByTheDevStack(t *testing.T) {
+	proc := &recordingProcessor{}
+	processor := httptest.NewServer(NewRouter(proc, testutil.SilentLogger(), testSigningSecret))
+	defer processor.Close()
+	event := []byte(`{"source":"aws.ecs","detail-type":"ECS Task State Change"}`)
+
+	resp, err := devstack.SendEvent(context.Background(), processor.URL, testSigningSecret, event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"processed"}`, string(resp))
+	assert.Equal(t, []string{string(event)}, proc.events)
+
+	_, err = devstack.SendEvent(context.Background(), processor.URL, "", event)
+	require.ErrorContains(t, err, "401")
+	_, err = devstack.SendEvent(context.Background(), processor.URL, "another-secret-another-secret-32", event)
+	require.ErrorContains(t, err, "401")
+	assert.Len(t, proc.events, 1, "the unsigned events must not be processed")
+}
@@ -0,0 +1,59 @@
+// Package main implements runvoy-init, the entrypoint of the runs.
+// The sidecar runs "runvoy-init prepare" to prepare the shared volume and the runner container runs
+// "runvoy-init run" to run the command, both reading the run from RUNVOY_INIT_SPEC.
+// It is built statically (CGO_ENABLED=0) so that it runs in any image.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/runnerinit"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s-init prepare|run|version\n", constants.ProjectName)
+		return 2
+	}
+
+	if args[0] == "version" {
+		fmt.Println(*constants.GetVersion())
+		return 0
+	}
+
+	spec, err := runnerinit.DecodeSpec(os.Getenv(runnerinit.SpecEnvVar))
+	if err != nil {
+		runnerinit.WriteMarker(os.Stdout, &runnerinit.Marker{Event: runnerinit.EventError, Message: err.Error()})
+		return runnerinit.ExitCodeInitFailure
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "prepare":
+		if err = runnerinit.Prepare(ctx, spec, os.Getenv, os.Stdout); err != nil {
+			runnerinit.WriteMarker(os.Stdout, &runnerinit.Marker{
+				Event: runnerinit.EventError, RequestID: spec.RequestID, Message: err.Error(),
+			})
+			return 1
+		}
+		return 0
+	case "run":
+		// runvoy-init runs as PID 1 of the runner container: the stop signals are forwarded to the command
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+		defer signal.Stop(stop)
+		return runnerinit.Run(ctx, spec, os.Getenv, stop, os.Stdout, os.Stderr)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		return 2
+	}
+}
@@ -0,0 +1,35 @@
+/// <reference types="vitest" />
+/// <reference types="@testing-library/jest-dom" />
+
+import { describe, it, expect } from 'vitest';
+import { render, screen } from '@testing-library/svelte';
+import ExecutionAnnotations from './ExecutionAnnotations.svelte';
+
+describe('ExecutionAnnotations', () => {
+    it('should display the notes with their author', () => {
+        render(ExecutionAnnotations, {
+            props: {
+                annotations: [
+                    {
+                        note: 'this failure was caused by an upstream outage',
+                        author: 'alice@example.com',
+                        created_at: '2026-10-18T12:00:00Z'
+                    }
+                ]
+            }
+        });
+
+        expect(
+            screen.getByText('this failure was caused by an upstream outage')
+        ).toBeInTheDocument();
+        expect(screen.getByText(/alice@example.com/)).toBeInTheDocument();
+    });
+
+    it('should render nothing without annotations', () => {
+        const { container } = render(ExecutionAnnotations, {
+            props: { annotations: [] }
+        });
+
+        expect(container.querySelector('.annotations')).not.toBeInTheDocument();
+    });
+});
@@ -32,6 +32,7 @@ describe('constants', () => {
             expect(TERMINAL_STATUSES).toContain(ExecutionStatus.SUCCEEDED);
             expect(TERMINAL_STATUSES).toContain(ExecutionStatus.FAILED);
             expect(TERMINAL_STATUSES).toContain(ExecutionStatus.STOPPED);
+            expect(TERMINAL_STATUSES).toContain(ExecutionStatus.TIMED_OUT);
         });
 
         it('should not include non-terminal statuses', () => {
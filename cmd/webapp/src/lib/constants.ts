@@ -10,7 +10,8 @@ export const ExecutionStatus = {
     SUCCEEDED: 'SUCCEEDED',
     FAILED: 'FAILED',
     STOPPED: 'STOPPED',
-    TERMINATING: 'TERMINATING'
+    TERMINATING: 'TERMINATING',
+    TIMED_OUT: 'TIMED_OUT'
 } as const;
 
 // Frontend-only status values
@@ -22,7 +23,8 @@ export const FrontendStatus = {
 export const TERMINAL_STATUSES = [
     ExecutionStatus.SUCCEEDED,
     ExecutionStatus.FAILED,
-    ExecutionStatus.STOPPED
+    ExecutionStatus.STOPPED,
+    ExecutionStatus.TIMED_OUT
 ] as const;
 
 import type { ExecutionStatusValue } from '../types/status';
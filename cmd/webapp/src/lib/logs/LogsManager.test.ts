@@ -387,6 +387,60 @@ describe('LogsManager', () => {
 
             expect(get(manager.stores.metadata)?.status).toBe('STARTING');
         });
+
+        it('should apply status changes pushed over the WebSocket', async () => {
+            vi.mocked(mockApiClient.getLogs).mockResolvedValue({
+                websocket_url: 'wss://example.com/logs',
+                status: 'RUNNING',
+                events: null
+            });
+
+            manager = new LogsManager({ apiClient: mockApiClient });
+            await manager.loadExecution('exec-123');
+            await new Promise((r) => setTimeout(r, 10));
+
+            const ws = (manager as unknown as { ws: MockWebSocket }).ws;
+            ws.simulateMessage(
+                JSON.stringify({
+                    version: 1,
+                    type: 'status',
+                    status: {
+                        execution_id: 'exec-123',
+                        previous_status: 'RUNNING',
+                        status: 'FAILED',
+                        exit_code: 2,
+                        timestamp: 1234567890
+                    }
+                })
+            );
+
+            expect(get(manager.stores.metadata)?.status).toBe('FAILED');
+            expect(get(manager.stores.metadata)?.exitCode).toBe(2);
+            expect(get(manager.stores.events)).toHaveLength(0);
+        });
+
+        it('should ignore status changes of other executions', async () => {
+            vi.mocked(mockApiClient.getLogs).mockResolvedValue({
+                websocket_url: 'wss://example.com/logs',
+                status: 'RUNNING',
+                events: null
+            });
+
+            manager = new LogsManager({ apiClient: mockApiClient });
+            await manager.loadExecution('exec-123');
+            await new Promise((r) => setTimeout(r, 10));
+
+            const ws = (manager as unknown as { ws: MockWebSocket }).ws;
+            ws.simulateMessage(
+                JSON.stringify({
+                    version: 1,
+                    type: 'status',
+                    status: { execution_id: 'exec-other', status: 'SUCCEEDED', timestamp: 1234567890 }
+                })
+            );
+
+            expect(get(manager.stores.metadata)?.status).toBe('RUNNING');
+        });
     });
 
     describe('pause and resume', () => {
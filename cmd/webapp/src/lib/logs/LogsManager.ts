@@ -9,7 +9,7 @@
 
 import { writable, derived, type Readable, type Writable } from 'svelte/store';
 import type { ConnectionStatus, ExecutionMetadata, ExecutionPhase } from './types';
-import type { LogEvent } from '../../types/logs';
+import type { ExecutionStatusChange, LogEvent, WebSocketLogMessage } from '../../types/logs';
 import type APIClient from '../api';
 import type { ApiError, ExecutionStatusResponse, LogsResponse } from '../../types/api';
 import { ExecutionStatus } from '../constants';
@@ -235,7 +235,12 @@ export class LogsManager {
 
     private handleWebSocketMessage(data: string): void {
         try {
-            const message = JSON.parse(data);
+            const message: WebSocketLogMessage = JSON.parse(data);
+
+            if (message.type === 'status') {
+                this.handleStatusChange(message.status);
+                return;
+            }
 
             if (message.type === 'disconnect') {
                 this.receivedDisconnectMessage = true;
@@ -278,6 +283,24 @@ export class LogsManager {
         }
     }
 
+    /**
+     * Apply a status change pushed by the server, so the status is current without polling.
+     * The full status is still fetched once the execution completes.
+     */
+    private handleStatusChange(change: ExecutionStatusChange | undefined): void {
+        if (!change || change.execution_id !== this.currentExecutionId) return;
+
+        this._metadata.update((m) =>
+            m
+                ? {
+                      ...m,
+                      status: change.status as ExecutionStatusValue,
+                      exitCode: change.exit_code ?? m.exitCode
+                  }
+                : m
+        );
+    }
+
     private async handleExecutionComplete(): Promise<void> {
         if (!this.currentExecutionId) return;
 
@@ -293,7 +316,8 @@ export class LogsManager {
                           completedAt: status.completed_at ?? null,
                           exitCode: status.exit_code ?? null,
                           command: status.command,
-                          imageId: status.image_id
+                          imageId: status.image_id,
+                          annotations: status.annotations ?? m.annotations
                       }
                     : m
             );
@@ -338,7 +362,8 @@ export class LogsManager {
             completedAt: status.completed_at ?? null,
             exitCode: status.exit_code ?? null,
             command: status.command,
-            imageId: status.image_id
+            imageId: status.image_id,
+            annotations: status.annotations ?? []
         });
     }
 
@@ -2,6 +2,7 @@
  * Types for the log viewing module
  */
 
+import type { ExecutionAnnotation } from '../../types/api';
 import type { LogEvent } from '../../types/logs';
 import type { ExecutionStatusValue } from '../../types/status';
 
@@ -25,6 +26,7 @@ export interface ExecutionMetadata {
     exitCode: number | null;
     command: string;
     imageId: string;
+    annotations: ExecutionAnnotation[];
 }
 
 export interface LogsState {
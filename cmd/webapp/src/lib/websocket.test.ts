@@ -81,6 +81,7 @@ function createMockCallbacks(): WebSocketCallbacks {
         onLogEvent: vi.fn(),
         onExecutionComplete: vi.fn(),
         onStatusRunning: vi.fn(),
+        onStatusChange: vi.fn(),
         onError: vi.fn()
     };
 }
@@ -498,6 +499,33 @@ describe('WebSocket Connection', () => {
             await new Promise((resolve) => setTimeout(resolve, 10));
             expect(mockCallbacks.onLogEvent).not.toHaveBeenCalled();
         });
+
+        it('should call onStatusChange callback with status messages', async () => {
+            const url = 'wss://localhost:8080/logs';
+            connectWebSocket(url, mockCallbacks);
+
+            await waitFor(() => {
+                const ws = get(websocketConnection);
+                expect(ws).not.toBeNull();
+            });
+
+            const ws = get(websocketConnection) as unknown as MockWebSocket;
+
+            const status = {
+                execution_id: 'exec-123',
+                previous_status: 'RUNNING',
+                status: 'FAILED',
+                exit_code: 2,
+                timestamp: 1234567890
+            };
+            ws.simulateMessage(JSON.stringify({ version: 1, type: 'status', status }));
+
+            await waitFor(() => {
+                expect(mockCallbacks.onStatusChange).toHaveBeenCalledWith(status);
+            });
+            expect(mockCallbacks.onLogEvent).not.toHaveBeenCalled();
+            expect(mockCallbacks.onStatusRunning).not.toHaveBeenCalled();
+        });
     });
 
     describe('WebSocket error handling', () => {
@@ -7,7 +7,7 @@ import {
     connectionError,
     isConnected
 } from '../stores/websocket';
-import type { LogEvent, WebSocketLogMessage } from '../types/logs';
+import type { ExecutionStatusChange, LogEvent, WebSocketLogMessage } from '../types/logs';
 
 let socket: WebSocket | null = null;
 let manuallyDisconnected = false;
@@ -17,6 +17,7 @@ export interface WebSocketCallbacks {
     onLogEvent: (event: LogEvent) => void;
     onExecutionComplete: () => void;
     onStatusRunning: () => void;
+    onStatusChange?: (change: ExecutionStatusChange) => void;
     onError: (error: string) => void;
 }
 
@@ -59,6 +60,14 @@ export function connectWebSocket(url: string, callbacks: WebSocketCallbacks): vo
         try {
             const message: WebSocketLogMessage = JSON.parse(event.data);
 
+            // Handle status change messages
+            if (message.type === 'status') {
+                if (message.status) {
+                    callbacks.onStatusChange?.(message.status);
+                }
+                return;
+            }
+
             // Handle disconnect messages
             if (message.type === 'disconnect') {
                 receivedDisconnectMessage = true;
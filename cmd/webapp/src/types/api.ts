@@ -27,6 +27,21 @@ export interface LogsResponse {
     events: ApiLogEvent[] | null;
     websocket_url?: string;
     status: ExecutionStatusValue;
+    resource_summary?: ResourceSummary;
+}
+
+/**
+ * Resource usage of a completed execution: CPU in CPU units (1024 per vCPU), memory in MiB
+ */
+export interface ResourceSummary {
+    samples: number;
+    average_cpu_utilized: number;
+    cpu_reserved: number;
+    peak_memory_utilized_mib: number;
+    memory_reserved_mib: number;
+    network_rx_bytes: number;
+    network_tx_bytes: number;
+    billed_duration_seconds: number;
 }
 
 export interface ExecutionStatusResponse {
@@ -37,9 +52,22 @@ export interface ExecutionStatusResponse {
     started_at?: string;
     completed_at?: string;
     exit_code?: number;
+    failure_reason?: string;
+    failure_message?: string;
+    resource_summary?: ResourceSummary;
+    annotations?: ExecutionAnnotation[];
     error?: string;
 }
 
+/**
+ * Note attached to an execution after the fact
+ */
+export interface ExecutionAnnotation {
+    note: string;
+    author: string;
+    created_at: string;
+}
+
 export interface KillExecutionResponse {
     execution_id: string;
     message: string;
@@ -53,6 +81,8 @@ export interface Execution {
     started_at: string;
     completed_at?: string;
     exit_code?: number;
+    failure_reason?: string;
+    failure_message?: string;
 }
 
 export interface ApiError extends Error {
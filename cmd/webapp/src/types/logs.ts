@@ -17,8 +17,28 @@ export interface LogEvent extends BaseLogEvent {
     line: number;
 }
 
+/**
+ * Status transition of an execution pushed over the WebSocket.
+ * exit_code and the failure fields are only set for terminal statuses.
+ */
+export interface ExecutionStatusChange {
+    execution_id: string;
+    previous_status?: string;
+    status: string;
+    exit_code?: number;
+    failure_reason?: string;
+    failure_message?: string;
+    timestamp: number;
+}
+
+/**
+ * Message received over the WebSocket, dispatched on `type`: 'log', 'status' or 'disconnect'.
+ * Log messages carry the log event fields at the top level.
+ */
 export interface WebSocketLogMessage extends Partial<ApiLogEvent> {
+    version?: number;
     type?: string;
     reason?: string;
+    status?: ExecutionStatusChange;
     [key: string]: unknown;
 }
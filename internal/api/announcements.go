@@ -0,0 +1,29 @@
+package api
+
+import (
+	"time"
+)
+
+// Announcement is an admin message, such as an outage notice or a deprecation, shown to the CLI users.
+type Announcement struct {
+	Message   string     `json:"message"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// AnnouncementRequest represents the request to set the announcement.
+type AnnouncementRequest struct {
+	Message string `json:"message"`
+}
+
+// AnnouncementResponse represents the response after setting or clearing the announcement.
+type AnnouncementResponse struct {
+	Announcement *Announcement `json:"announcement,omitempty"`
+	Message      string        `json:"message"`
+}
+
+// MetaResponse represents the metadata of the backend shared with its clients.
+type MetaResponse struct {
+	// Announcement is the current announcement, nil when there is none.
+	Announcement *Announcement `json:"announcement,omitempty"`
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"time"
+)
+
+// Budget is the monthly spend limit an admin set for a user. The spend is the estimated Fargate cost of the
+// executions the user started in the current calendar month, in UTC.
+type Budget struct {
+	UserEmail       string  `json:"user_email"`
+	MonthlyLimitUSD float64 `json:"monthly_limit_usd"`
+	// HardCap refuses the new executions of the user once the limit is reached, unless they may override it.
+	HardCap bool `json:"hard_cap"`
+	// NotifiedThreshold is the highest threshold, in percent of the limit, notified in NotifiedMonth.
+	NotifiedThreshold int        `json:"notified_threshold,omitempty"`
+	NotifiedMonth     string     `json:"notified_month,omitempty"`
+	UpdatedBy         string     `json:"updated_by,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// BudgetRequest represents the request to set the budget of a user.
+type BudgetRequest struct {
+	MonthlyLimitUSD float64 `json:"monthly_limit_usd"`
+	HardCap         bool    `json:"hard_cap,omitempty"`
+}
+
+// BudgetStatus is a budget with the spend of its user in the current month.
+type BudgetStatus struct {
+	Budget
+	// Month is the current month, e.g. "2026-10".
+	Month       string  `json:"month"`
+	SpentUSD    float64 `json:"spent_usd"`
+	PercentUsed float64 `json:"percent_used"`
+}
+
+// BudgetResponse represents the response after setting or removing a budget.
+type BudgetResponse struct {
+	Budget  *BudgetStatus `json:"budget,omitempty"`
+	Message string        `json:"message"`
+}
+
+// ListBudgetsResponse represents the response containing the budgets of all the users, sorted by email.
+type ListBudgetsResponse struct {
+	Budgets []BudgetStatus `json:"budgets"`
+}
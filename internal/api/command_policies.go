@@ -0,0 +1,55 @@
+package api
+
+import (
+	"time"
+)
+
+// CommandPolicyRule is an admin-configured rule the backend evaluates before starting an execution.
+// A deny rule refuses the executions it matches. Once a role has allow rules, the executions of its users
+// must match one of them.
+type CommandPolicyRule struct {
+	Name string `json:"name"`
+	// Effect is allow or deny.
+	Effect string `json:"effect"`
+	// MatchType is prefix or regex.
+	MatchType string `json:"match_type"`
+	Pattern   string `json:"pattern"`
+	// Roles are the roles the rule applies to, all roles when empty.
+	Roles []string `json:"roles,omitempty"`
+	// Images restricts the rule to the executions of these image IDs or names, all images when empty.
+	Images      []string  `json:"images,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CommandPolicyRuleRequest represents the request to create or replace a command policy rule.
+// Name is taken from the path when replacing a rule.
+type CommandPolicyRuleRequest struct {
+	Name        string   `json:"name,omitempty"`
+	Effect      string   `json:"effect"`
+	MatchType   string   `json:"match_type"`
+	Pattern     string   `json:"pattern"`
+	Roles       []string `json:"roles,omitempty"`
+	Images      []string `json:"images,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// CommandPolicyRuleResponse represents the response after creating or replacing a command policy rule.
+type CommandPolicyRuleResponse struct {
+	Rule    CommandPolicyRule `json:"rule"`
+	Message string            `json:"message"`
+}
+
+// ListCommandPolicyRulesResponse represents the response containing all command policy rules.
+type ListCommandPolicyRulesResponse struct {
+	Rules []CommandPolicyRule `json:"rules"`
+}
+
+// DeleteCommandPolicyRuleResponse represents the response after deleting a command policy rule.
+type DeleteCommandPolicyRuleResponse struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
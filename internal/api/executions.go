@@ -1,17 +1,88 @@
 package api
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // ExecutionRequest represents a request to execute a command.
 type ExecutionRequest struct {
-	Command string            `json:"command"`
+	// Command is the shell command line run with the Shell, "/bin/sh -c" by default, for the commands using
+	// shell features such as pipes, redirections or variable expansion. It cannot be combined with Args.
+	Command string `json:"command"`
+	// Args is the command as an argument vector: the program and its arguments, run as is without a shell,
+	// so nothing in them needs escaping. The backend sets Command to the equivalent shell command line, with
+	// each argument quoted, which is the command recorded on the execution and matched by the command
+	// policies.
+	Args    []string          `json:"args,omitempty"`
 	Image   string            `json:"image,omitempty"`
 	Env     map[string]string `json:"env,omitempty"`
-	Timeout int               `json:"timeout,omitempty"`
+	Timeout int               `json:"timeout,omitempty"` // Maximum run time in seconds, enforced by the backend
 	Secrets []string          `json:"secrets,omitempty"`
 
+	// WorkDir is the working directory of the command: relative to the default one, the Git repository path
+	// or the uploaded context when set, or absolute. The default of the image applies when empty.
+	WorkDir string `json:"workdir,omitempty"`
+	// Shell is the shell running the Command line: sh, bash or pwsh. The default of the image, or sh, applies
+	// when empty. It cannot be combined with Args, which run without a shell.
+	Shell string `json:"shell,omitempty"`
+
+	// LogRetentionDays is the number of days the logs of the execution are kept after it completes, within
+	// the bounds set by the backend. Zero keeps them for the retention of the log storage.
+	LogRetentionDays int `json:"log_retention_days,omitempty"`
+
+	// StopGracePeriod is the time in seconds the command is given to exit after receiving SIGTERM
+	// before being killed. Zero means the command is killed right away when the execution is stopped.
+	StopGracePeriod int `json:"stop_grace_period,omitempty"`
+
+	// Parallel starts that many executions of the command as the shards of a group, each with its
+	// zero-based index in RUNVOY_SHARD_INDEX and the number of shards in RUNVOY_SHARD_TOTAL.
+	// Zero starts a single execution outside of any group.
+	Parallel int `json:"parallel,omitempty"`
+
+	// Lock is the name of a lock the execution holds while it runs: the execution is refused with a
+	// LOCK_HELD error while another execution holds the lock. It cannot be combined with Parallel.
+	Lock string `json:"lock,omitempty"`
+
+	// Visibility controls who besides the owner may see the execution and read its logs:
+	// "private", "team" or "public". The backend's default visibility applies when it is empty.
+	Visibility string `json:"visibility,omitempty"`
+
+	// Stdin is fed to the command's standard input, base64-encoded. Only small payloads are sent inline,
+	// larger ones are uploaded beforehand (see InputUploadResponse) and referenced by StdinUploadID.
+	Stdin         string `json:"stdin,omitempty"`
+	StdinUploadID string `json:"stdin_upload_id,omitempty"`
+
+	// ContextUploadID references an archive of a local directory uploaded beforehand
+	// (see InputUploadResponse), extracted as the working directory of the command.
+	// It cannot be combined with GitRepo.
+	ContextUploadID string `json:"context_upload_id,omitempty"`
+
+	// DryRun validates and authorizes the request and resolves its image and secrets without
+	// starting a task or recording an execution, to load test the API without compute costs.
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// OnBehalfOf starts the execution on behalf of the user with this email, attributing it to that user.
+	// It requires the impersonate permission on the user, granted to admins.
+	OnBehalfOf string `json:"on_behalf_of,omitempty"`
+
+	// ImpersonatedBy is the email of the user starting the execution on behalf of OnBehalfOf.
+	// This is populated by the server once the impersonation is authorized.
+	ImpersonatedBy string `json:"-"`
+
+	// Playbook is the name of the playbook the command was started from, reported by SLO reports.
+	// ExpectedMaxDuration is the maximum run time in seconds the playbook expects: executions running
+	// longer are flagged as breaching their duration SLO, without being stopped. Zero sets no SLO.
+	Playbook            string `json:"playbook,omitempty"`
+	ExpectedMaxDuration int    `json:"expected_max_duration,omitempty"`
+
+	// EnvSchema declares the environment variables the command expects, see Playbook. Executions missing
+	// a required variable, or setting one to a value it doesn't accept, are rejected.
+	EnvSchema []EnvVarSpec `json:"env_schema,omitempty"`
+
+	// Provenance describes the client submitting the request, recorded on the execution.
+	Provenance *ExecutionProvenance `json:"provenance,omitempty"`
+
 	// Git repository configuration (optional sidecar pattern)
 	GitRepo string `json:"git_repo,omitempty"` // Git repository URL (e.g., "https://github.com/user/repo.git")
 	GitRef  string `json:"git_ref,omitempty"`  // Git branch, tag, or commit SHA (default: "main")
@@ -21,6 +92,37 @@ type ExecutionRequest struct {
 	// This is populated by the service layer after resolving secrets from the Secrets field.
 	// It includes both explicitly resolved secrets and pattern-detected sensitive variables.
 	SecretVarNames []string `json:"-"` // Not serialized in API responses
+
+	// LogLimits holds the log limits of the resolved image.
+	// This is populated by the service layer and recorded on the execution.
+	LogLimits *LogLimits `json:"-"`
+
+	// ImageAlias is the image alias the Image of the request was resolved from.
+	// This is populated by the service layer and recorded on the execution.
+	ImageAlias string `json:"-"`
+
+	// WarmPoolSize is the number of warm pool slots kept for the resolved image.
+	// This is populated by the service layer; executions only use warm slots when it is positive.
+	WarmPoolSize int `json:"-"`
+
+	// GroupID and ShardIndex identify the shard of a parallel run the request starts.
+	// They are populated by the service layer and recorded on the execution.
+	GroupID    string `json:"-"`
+	ShardIndex *int   `json:"-"`
+}
+
+// ExecutionProvenance describes the client that submitted an execution, so that operators can tell which
+// pipeline or laptop started it. It is reported by the client and not verified by the backend.
+type ExecutionProvenance struct {
+	ClientVersion string `json:"client_version,omitempty"`
+	// OS is the operating system and architecture of the client, e.g. "linux/amd64".
+	OS       string `json:"os,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	// CIProvider, CIRunID and CIRunURL identify the CI run the client ran in, e.g. "github-actions" and
+	// the value of GITHUB_RUN_ID. They are empty outside of CI.
+	CIProvider string `json:"ci_provider,omitempty"`
+	CIRunID    string `json:"ci_run_id,omitempty"`
+	CIRunURL   string `json:"ci_run_url,omitempty"`
 }
 
 // ExecutionResponse represents the response to an execution request.
@@ -30,7 +132,58 @@ type ExecutionResponse struct {
 	Status       string `json:"status"`
 	Command      string `json:"command"`
 	ImageID      string `json:"image_id"`
+	ImageAlias   string `json:"image_alias,omitempty"`
 	WebSocketURL string `json:"websocket_url,omitempty"`
+
+	// GroupID and Shards describe the executions started by a parallel run, ExecutionID is then empty.
+	GroupID string                `json:"group_id,omitempty"`
+	Shards  []ExecutionGroupShard `json:"shards,omitempty"`
+
+	// DryRun is true when the request was a dry run, ExecutionID and Status are then empty.
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// BudgetWarning is set when the user has used most or all of their monthly budget.
+	BudgetWarning string `json:"budget_warning,omitempty"`
+}
+
+// ExecutionGroupShard is an execution started as a shard of a parallel run.
+// Status and ExitCode are only set in the status of the group, ExitCode once the shard completed.
+type ExecutionGroupShard struct {
+	ShardIndex  int    `json:"shard_index"`
+	ExecutionID string `json:"execution_id"`
+	Status      string `json:"status,omitempty"`
+	ExitCode    *int   `json:"exit_code,omitempty"`
+}
+
+// ExecutionGroupStatusResponse represents the aggregated status of the shards of a parallel run.
+// Status is RUNNING while any shard is active, SUCCEEDED once all shards succeeded and FAILED otherwise.
+// ExitCode is set once all shards completed: 0 when all of them succeeded, otherwise the exit code
+// of the first failed shard.
+type ExecutionGroupStatusResponse struct {
+	GroupID     string                `json:"group_id"`
+	Status      string                `json:"status"`
+	Command     string                `json:"command"`
+	ImageID     string                `json:"image_id"`
+	StartedAt   time.Time             `json:"started_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+	ExitCode    *int                  `json:"exit_code"`
+	StatusCount map[string]int        `json:"status_count"`
+	Shards      []ExecutionGroupShard `json:"shards"`
+}
+
+// InputUploadRequest represents a request to upload an input of an upcoming execution,
+// its standard input or its working directory archive.
+type InputUploadRequest struct {
+	Size int64 `json:"size"` // Exact size in bytes of the data that will be uploaded
+}
+
+// InputUploadResponse describes where to upload an input of an upcoming execution.
+// The data must be sent with an HTTP PUT to UploadURL before ExpiresAt, then UploadID is passed
+// as ExecutionRequest.StdinUploadID or ExecutionRequest.ContextUploadID.
+type InputUploadResponse struct {
+	UploadID  string    `json:"upload_id"`
+	UploadURL string    `json:"upload_url"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // ExecutionStatusResponse represents the current status of an execution.
@@ -39,9 +192,120 @@ type ExecutionStatusResponse struct {
 	Status      string     `json:"status"`
 	Command     string     `json:"command"`
 	ImageID     string     `json:"image_id"`
+	ImageAlias  string     `json:"image_alias,omitempty"`
 	StartedAt   time.Time  `json:"started_at"`
 	ExitCode    *int       `json:"exit_code"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	FailureReason  string `json:"failure_reason,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+
+	// GroupID is the group of the parallel run the execution is a shard of.
+	GroupID string `json:"group_id,omitempty"`
+
+	// KillRequestedBy, KillRequestedAt and KillStalled describe the kill of the execution, see Execution.
+	KillRequestedBy string     `json:"kill_requested_by,omitempty"`
+	KillRequestedAt *time.Time `json:"kill_requested_at,omitempty"`
+	KillStalled     bool       `json:"kill_stalled,omitempty"`
+
+	ResourceSummary *ResourceSummary `json:"resource_summary,omitempty"`
+
+	Annotations []ExecutionAnnotation `json:"annotations,omitempty"`
+
+	Provenance *ExecutionProvenance `json:"provenance,omitempty"`
+}
+
+// ExecutionAnnotation is a note attached to an execution after the fact,
+// e.g. to record that a failure was caused by an upstream outage.
+type ExecutionAnnotation struct {
+	Note      string    `json:"note"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExecutionAnnotationRequest represents a request to attach a note to an execution.
+type ExecutionAnnotationRequest struct {
+	Note string `json:"note"`
+}
+
+// ExecutionEvent is a step of the lifecycle timeline of an execution.
+// Reason explains the step when the compute platform reports one, e.g. why a task stopped.
+type ExecutionEvent struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// ExecutionEventsResponse represents the lifecycle timeline of an execution, oldest event first.
+type ExecutionEventsResponse struct {
+	ExecutionID string           `json:"execution_id"`
+	Events      []ExecutionEvent `json:"events"`
+}
+
+// ExecutionResultResponse represents the JSON result file reported by an execution.
+type ExecutionResultResponse struct {
+	ExecutionID string          `json:"execution_id"`
+	Result      json.RawMessage `json:"result"`
+}
+
+// ResourceUsage is a resource utilization sample of a running execution.
+// CPU is expressed in CPU units (1024 units per vCPU) and memory in MiB.
+type ResourceUsage struct {
+	SampledAt         time.Time `json:"sampled_at"`
+	CPUUtilized       float64   `json:"cpu_utilized"`
+	CPUReserved       float64   `json:"cpu_reserved"`
+	MemoryUtilizedMiB float64   `json:"memory_utilized_mib"`
+	MemoryReservedMiB float64   `json:"memory_reserved_mib"`
+}
+
+// ResourceSummary is the resource usage of a completed execution, to right-size the resources of a command.
+// CPU is expressed in CPU units (1024 units per vCPU) and memory in MiB. The utilization fields summarize
+// the samples of the compute platform, they are zero when the execution stopped before the first one
+// (Samples is 0). BilledDurationSeconds is the duration the compute platform charges for.
+type ResourceSummary struct {
+	Samples               int     `json:"samples"`
+	AverageCPUUtilized    float64 `json:"average_cpu_utilized"`
+	CPUReserved           float64 `json:"cpu_reserved"`
+	PeakMemoryUtilizedMiB float64 `json:"peak_memory_utilized_mib"`
+	MemoryReservedMiB     float64 `json:"memory_reserved_mib"`
+	NetworkRxBytes        int64   `json:"network_rx_bytes"`
+	NetworkTxBytes        int64   `json:"network_tx_bytes"`
+	BilledDurationSeconds int     `json:"billed_duration_seconds"`
+}
+
+// ResourceRecommendation suggests lower CPU and memory for an image whose executions use far less than they
+// reserve. CPU is expressed in CPU units and memory in MiB. The percentiles are computed over the average CPU
+// and the peak memory of the recent succeeded executions of the image with its current resources.
+type ResourceRecommendation struct {
+	ImageID              string  `json:"image_id"`
+	Executions           int     `json:"executions"`
+	CPU                  int     `json:"cpu"`
+	Memory               int     `json:"memory"`
+	P95CPUUtilized       float64 `json:"p95_cpu_utilized"`
+	P95MemoryUtilizedMiB float64 `json:"p95_memory_utilized_mib"`
+	RecommendedCPU       int     `json:"recommended_cpu"`
+	RecommendedMemory    int     `json:"recommended_memory"`
+}
+
+// ResourceRecommendationsResponse represents the right-sizing recommendations of the images.
+type ResourceRecommendationsResponse struct {
+	Recommendations []ResourceRecommendation `json:"recommendations"`
+}
+
+// ExecutionResourceUsage is the latest resource usage of a running execution.
+// Usage is nil until the compute platform reported a first sample, usually a minute after the start.
+type ExecutionResourceUsage struct {
+	ExecutionID string         `json:"execution_id"`
+	Command     string         `json:"command"`
+	CreatedBy   string         `json:"created_by"`
+	ImageID     string         `json:"image_id"`
+	StartedAt   time.Time      `json:"started_at"`
+	Usage       *ResourceUsage `json:"usage,omitempty"`
+}
+
+// ExecutionResourcesResponse represents the resource usage of the running executions.
+type ExecutionResourcesResponse struct {
+	Executions []ExecutionResourceUsage `json:"executions"`
 }
 
 // KillExecutionResponse represents the response after killing an execution.
@@ -50,20 +314,196 @@ type KillExecutionResponse struct {
 	Message     string `json:"message"`
 }
 
+// KillExecutionsFilter selects the executions targeted by a bulk kill.
+type KillExecutionsFilter struct {
+	// Statuses restricts the kill to executions in any of these statuses (default: active statuses).
+	Statuses []string
+	// CreatedBy restricts the kill to executions created by this user email.
+	CreatedBy string
+	// OlderThan restricts the kill to executions started at least this long ago.
+	OlderThan time.Duration
+}
+
+// KillExecutionsResponse represents the response of a bulk kill.
+// When DryRun is true no execution was killed and ConfirmationToken must be sent back to proceed.
+type KillExecutionsResponse struct {
+	ExecutionIDs      []string               `json:"execution_ids"`
+	ConfirmationToken string                 `json:"confirmation_token,omitempty"`
+	DryRun            bool                   `json:"dry_run"`
+	Killed            []string               `json:"killed,omitempty"`
+	Skipped           []string               `json:"skipped,omitempty"`
+	Failed            []KillExecutionFailure `json:"failed,omitempty"`
+}
+
+// KillExecutionFailure describes an execution that could not be killed during a bulk kill.
+type KillExecutionFailure struct {
+	ExecutionID string `json:"execution_id"`
+	Error       string `json:"error"`
+}
+
+// ExecutionDiffResponse compares the snapshots of two executions.
+type ExecutionDiffResponse struct {
+	ExecutionA  ExecutionSnapshot    `json:"execution_a"`
+	ExecutionB  ExecutionSnapshot    `json:"execution_b"`
+	Differences []ExecutionFieldDiff `json:"differences"`
+
+	// LogDiff is a line diff of the final log lines of both executions, omitted unless requested.
+	// Each line is prefixed with "  " (in both), "- " (only in execution A) or "+ " (only in execution B).
+	LogDiff []string `json:"log_diff,omitempty"`
+}
+
+// ExecutionSnapshot holds the execution fields compared by an execution diff.
+type ExecutionSnapshot struct {
+	ExecutionID     string    `json:"execution_id"`
+	Status          string    `json:"status"`
+	ImageID         string    `json:"image_id"`
+	Command         string    `json:"command"`
+	EnvVarNames     []string  `json:"env_var_names"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds int       `json:"duration_seconds"`
+	ExitCode        int       `json:"exit_code"`
+}
+
+// ExecutionFieldDiff describes a snapshot field whose value differs between two executions.
+type ExecutionFieldDiff struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// StreamEventType identifies the type of a Server-Sent Event emitted by streaming endpoints.
+type StreamEventType string
+
+const (
+	// StreamEventExecutions carries a JSON array snapshot of executions.
+	StreamEventExecutions StreamEventType = "executions"
+	// StreamEventError carries an ErrorResponse and is the last event sent on a failing stream.
+	StreamEventError StreamEventType = "error"
+)
+
 // Execution represents an execution record.
 type Execution struct {
-	ExecutionID         string     `json:"execution_id"`
-	CreatedBy           string     `json:"created_by"`
-	OwnedBy             []string   `json:"owned_by"`
-	Command             string     `json:"command"`
-	ImageID             string     `json:"image_id"`
-	StartedAt           time.Time  `json:"started_at"`
-	CompletedAt         *time.Time `json:"completed_at,omitempty"`
-	Status              string     `json:"status"`
-	ExitCode            int        `json:"exit_code"`
-	DurationSeconds     int        `json:"duration_seconds,omitempty"`
-	LogStreamName       string     `json:"log_stream_name,omitempty"`
-	CreatedByRequestID  string     `json:"created_by_request_id"`
-	ModifiedByRequestID string     `json:"modified_by_request_id"`
-	ComputePlatform     string     `json:"cloud,omitempty"`
+	ExecutionID            string     `json:"execution_id"`
+	CreatedBy              string     `json:"created_by"`
+	OwnedBy                []string   `json:"owned_by"`
+	Command                string     `json:"command"`
+	ImageID                string     `json:"image_id"`
+	StartedAt              time.Time  `json:"started_at"`
+	CompletedAt            *time.Time `json:"completed_at,omitempty"`
+	Status                 string     `json:"status"`
+	ExitCode               int        `json:"exit_code"`
+	DurationSeconds        int        `json:"duration_seconds,omitempty"`
+	TimeoutSeconds         int        `json:"timeout_seconds,omitempty"`
+	StopGracePeriodSeconds int        `json:"stop_grace_period_seconds,omitempty"`
+	EnvVarNames            []string   `json:"env_var_names,omitempty"`
+	LogStreamName          string     `json:"log_stream_name,omitempty"`
+	CreatedByRequestID     string     `json:"created_by_request_id"`
+	ModifiedByRequestID    string     `json:"modified_by_request_id"`
+	ComputePlatform        string     `json:"cloud,omitempty"`
+	Visibility             string     `json:"visibility,omitempty"`
+	LogLimits              *LogLimits `json:"log_limits,omitempty"`
+	LogUsage               *LogUsage  `json:"log_usage,omitempty"`
+	// LogRetentionDays is the number of days the logs are kept after the execution completes, zero keeping
+	// them for the retention of the log storage.
+	LogRetentionDays int `json:"log_retention_days,omitempty"`
+	// FailureReason and FailureMessage explain failures the compute platform reports a cause for,
+	// such as a container killed for exceeding its memory or an image that could not be pulled.
+	FailureReason  string `json:"failure_reason,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+	// GroupID and ShardIndex identify the parallel run the execution is a shard of.
+	GroupID    string `json:"group_id,omitempty"`
+	ShardIndex *int   `json:"shard_index,omitempty"`
+	// Lock is the name of the lock the execution holds until it completes.
+	Lock string `json:"lock,omitempty"`
+	// ResourceSummary is attached once the execution completed.
+	ResourceSummary *ResourceSummary `json:"resource_summary,omitempty"`
+	// ImpersonatedBy is the admin who started the execution on behalf of CreatedBy.
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
+	// Annotations are the notes attached to the execution after the fact, oldest first.
+	Annotations []ExecutionAnnotation `json:"annotations,omitempty"`
+	// Starred is set when listing the executions the user listing them starred.
+	Starred bool `json:"starred,omitempty"`
+	// Playbook and ExpectedMaxDurationSeconds record the duration SLO of executions started from a playbook.
+	// SLOBreached is set once the execution ran longer than expected, while running or after completing.
+	Playbook                   string `json:"playbook,omitempty"`
+	ExpectedMaxDurationSeconds int    `json:"expected_max_duration_seconds,omitempty"`
+	SLOBreached                bool   `json:"slo_breached,omitempty"`
+	// ImageAlias is the image alias the execution was started with, ImageID being the image it resolved to.
+	ImageAlias string `json:"image_alias,omitempty"`
+	// Secrets are the names of the secrets the execution was started with, never their values.
+	Secrets []string `json:"secrets,omitempty"`
+	// Provenance describes the client that submitted the execution, when it reported it.
+	Provenance *ExecutionProvenance `json:"provenance,omitempty"`
+	// KillRequestedBy and KillRequestedAt record who asked to kill or stop the execution, and when.
+	// KillStalled is set when its task still hadn't stopped long after, see constants.KillStallSeconds.
+	KillRequestedBy string     `json:"kill_requested_by,omitempty"`
+	KillRequestedAt *time.Time `json:"kill_requested_at,omitempty"`
+	KillStalled     bool       `json:"kill_stalled,omitempty"`
+	// Result is the JSON result file the command wrote, served on its own endpoint to keep listings small.
+	Result json.RawMessage `json:"-"`
+	// Version is incremented by each update of the execution, an update of an execution modified since it
+	// was read failing with a conflict.
+	Version int64 `json:"version,omitempty"`
+}
+
+// ExecutionListFilter selects the executions listed, on top of the limit.
+// Saved under Name, it is reused by listing executions with that filter name.
+type ExecutionListFilter struct {
+	Name string `json:"name,omitempty"`
+	// Statuses restricts the list to executions in any of these statuses.
+	Statuses []string `json:"statuses,omitempty"`
+	// CreatedBy restricts the list to executions created by this user email, "me" being the user listing them.
+	CreatedBy string `json:"created_by,omitempty"`
+	// Since restricts the list to executions started within this Go duration, e.g. "168h".
+	Since string `json:"since,omitempty"`
+	// Starred restricts the list to the executions starred by the user listing them.
+	Starred bool `json:"starred,omitempty"`
+	// Search restricts the list to executions whose command, image or playbook contain each of its
+	// whitespace-separated terms, ignoring case.
+	Search string `json:"search,omitempty"`
+}
+
+// StarExecutionResponse represents the response after starring or unstarring an execution.
+type StarExecutionResponse struct {
+	ExecutionID string `json:"execution_id"`
+	Starred     bool   `json:"starred"`
+}
+
+// ListSavedFiltersResponse represents the execution list filters saved by the user.
+type ListSavedFiltersResponse struct {
+	Filters []ExecutionListFilter `json:"filters"`
+}
+
+// DeleteSavedFilterResponse represents the response after deleting a saved execution list filter.
+type DeleteSavedFilterResponse struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// ExecutionSLOReport represents the duration SLO breach rates of the playbooks over a period,
+// computed from the executions listed to the user. Executions still running within their
+// expected duration are not accounted for yet.
+type ExecutionSLOReport struct {
+	Since     time.Time           `json:"since"`
+	Playbooks []PlaybookSLOReport `json:"playbooks"`
+}
+
+// PlaybookSLOReport represents the duration SLO breaches of the executions of a playbook,
+// over the whole period of the report and per day (UTC), oldest first.
+type PlaybookSLOReport struct {
+	Playbook string `json:"playbook"`
+	// ExpectedMaxDurationSeconds is the expected duration of the latest execution of the playbook.
+	ExpectedMaxDurationSeconds int               `json:"expected_max_duration_seconds"`
+	Executions                 int               `json:"executions"`
+	Breaches                   int               `json:"breaches"`
+	BreachRate                 float64           `json:"breach_rate"`
+	Days                       []SLOReportPeriod `json:"days"`
+}
+
+// SLOReportPeriod represents the duration SLO breaches of the executions started on a day.
+type SLOReportPeriod struct {
+	Date       string  `json:"date"` // YYYY-MM-DD
+	Executions int     `json:"executions"`
+	Breaches   int     `json:"breaches"`
+	BreachRate float64 `json:"breach_rate"`
 }
@@ -0,0 +1,28 @@
+package api
+
+import "time"
+
+// GitHubTokenRequest exchanges the OIDC token of a GitHub Actions workflow for a run token.
+type GitHubTokenRequest struct {
+	IDToken string `json:"id_token"`
+}
+
+// GitHubTokenResponse carries the run token issued to a GitHub Actions workflow. The run token authenticates
+// as an API key until it expires.
+type GitHubTokenResponse struct {
+	Token      string    `json:"token"`
+	UserEmail  string    `json:"user_email"`
+	Repository string    `json:"repository"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// RunToken is a short-lived credential acting as a user, issued in exchange for a trusted OIDC token.
+// Only the hash of the token is stored.
+type RunToken struct {
+	TokenHash string `json:"token_hash"`
+	UserEmail string `json:"user_email"`
+	// Subject is the subject of the exchanged OIDC token, e.g. repo:acme/api:ref:refs/heads/main.
+	Subject   string `json:"subject"`
+	ExpiresAt int64  `json:"expires_at"`
+	CreatedAt int64  `json:"created_at"`
+}
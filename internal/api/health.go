@@ -18,6 +18,7 @@ type HealthReport struct {
 	Issues           []HealthIssue          `json:"issues"`
 	ReconciledCount  int                    `json:"reconciled_count"`
 	ErrorCount       int                    `json:"error_count"`
+	Canary           *CanaryReport          `json:"canary,omitempty"`
 }
 
 // ComputeHealthStatus contains the health status for compute resources (e.g., containers, task definitions).
@@ -68,3 +69,87 @@ type HealthIssue struct {
 	Message      string `json:"message"`
 	Action       string `json:"action"` // "recreated", "requires_manual_intervention", "reported", "tag_updated"
 }
+
+// CanaryReport contains the canary part of a health report, present when the reconciliation ran in canary mode.
+// A canary outlives the reconciliation that launches it, so it is evaluated by the next canary reconciliation.
+type CanaryReport struct {
+	// Result is the evaluation of the canary launched by an earlier reconciliation, if any.
+	Result *CanaryResult `json:"result,omitempty"`
+	// Launched is the canary started by this reconciliation, nil while the previous one is still pending.
+	Launched *CanaryResult `json:"launched,omitempty"`
+}
+
+// CanaryResult describes a canary execution and the outcome of its phases.
+type CanaryResult struct {
+	ExecutionID string        `json:"execution_id,omitempty"`
+	LaunchedAt  time.Time     `json:"launched_at"`
+	Status      string        `json:"status"` // "pending", "passed", "failed"
+	Phases      []CanaryPhase `json:"phases,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// CanaryPhase is the outcome of one phase of a canary execution ("run", "logs" or "completion").
+type CanaryPhase struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	DurationMs int64  `json:"duration_ms"`
+	Message    string `json:"message,omitempty"`
+}
+
+// HealthReportsResponse is returned by GET /api/v1/health/reports, most recent report first.
+type HealthReportsResponse struct {
+	Reports []HealthReport `json:"reports"`
+}
+
+// HealthCleanupResponse is returned by POST /api/v1/health/cleanup.
+type HealthCleanupResponse struct {
+	Status string         `json:"status"`
+	Report *CleanupReport `json:"report"`
+}
+
+// CleanupReport contains the results of a cleanup run removing the provider resources created by runvoy
+// that nothing references anymore.
+type CleanupReport struct {
+	Timestamp time.Time `json:"timestamp"`
+	// DryRun is true when the resources were only reported, not deleted.
+	DryRun       bool              `json:"dry_run"`
+	Resources    []CleanedResource `json:"resources"`
+	DeletedCount int               `json:"deleted_count"`
+	FailedCount  int               `json:"failed_count"`
+}
+
+// CleanedResource is a resource found unreferenced by a cleanup run.
+type CleanedResource struct {
+	// ResourceType is provider-specific resource type (e.g., "ecs_task_definition", "log_stream")
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	Reason       string `json:"reason"`
+	Action       string `json:"action"` // "deleted", "deregistered", "would_delete", "would_deregister", "failed"
+	Error        string `json:"error,omitempty"`
+}
+
+// HealthStatsResponse is returned by GET /api/v1/health/stats.
+type HealthStatsResponse struct {
+	Database *DatabaseStats `json:"database"`
+}
+
+// DatabaseStats aggregates the database queries run by the backend instance serving the request since it
+// started, by access pattern.
+type DatabaseStats struct {
+	Since                time.Time           `json:"since"`
+	SlowQueryThresholdMs int64               `json:"slow_query_threshold_ms"`
+	Queries              []QueryPatternStats `json:"queries"`
+}
+
+// QueryPatternStats aggregates the database queries of an access pattern.
+type QueryPatternStats struct {
+	// Pattern is the operation and the table it ran on, with the index queried if any,
+	// e.g. "Query runvoy-executions/all-started_at"
+	Pattern       string  `json:"pattern"`
+	Count         int64   `json:"count"`
+	Errors        int64   `json:"errors"`
+	Slow          int64   `json:"slow"`
+	Items         int64   `json:"items"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	MaxDurationMs float64 `json:"max_duration_ms"`
+}
@@ -13,6 +13,22 @@ type RegisterImageRequest struct {
 	CPU                   *int    `json:"cpu,omitempty"`
 	Memory                *int    `json:"memory,omitempty"`
 	RuntimePlatform       *string `json:"runtime_platform,omitempty"`
+
+	// LogLimits overrides the backend log limits for executions using the image.
+	LogLimits *LogLimits `json:"log_limits,omitempty"`
+
+	// WarmPoolSize sets the number of idle pre-provisioned slots kept for the image, 0 disables the warm pool.
+	WarmPoolSize *int `json:"warm_pool_size,omitempty"`
+
+	// RunDefaults sets the working directory and the shell of the executions using the image.
+	RunDefaults *RunDefaults `json:"run_defaults,omitempty"`
+}
+
+// RunDefaults are the working directory and the shell of the executions of an image, used when the run
+// request doesn't set them. See ExecutionRequest.
+type RunDefaults struct {
+	WorkDir string `json:"workdir,omitempty"`
+	Shell   string `json:"shell,omitempty"`
 }
 
 // RegisterImageResponse represents the response after registering an image.
@@ -30,30 +46,93 @@ type RemoveImageRequest struct {
 type RemoveImageResponse struct {
 	Image   string `json:"image"`
 	Message string `json:"message"`
+
+	// RestorableUntil is when the image is purged, unset if it was permanently removed.
+	RestorableUntil *time.Time `json:"restorable_until,omitempty"`
+}
+
+// RestoreImageRequest represents the request to restore a deleted image.
+type RestoreImageRequest struct {
+	Image string `json:"image"`
+}
+
+// RestoreImageResponse represents the response after restoring an image.
+type RestoreImageResponse struct {
+	Image   string `json:"image"`
+	Message string `json:"message"`
 }
 
 // ImageInfo represents information about a registered image.
 type ImageInfo struct {
-	ImageID               string    `json:"image_id"`
-	Image                 string    `json:"image"`
-	TaskDefinitionName    string    `json:"task_definition_name,omitempty"`
-	IsDefault             *bool     `json:"is_default,omitempty"`
-	TaskRoleName          *string   `json:"task_role_name,omitempty"`
-	TaskExecutionRoleName *string   `json:"task_execution_role_name,omitempty"`
-	CPU                   int       `json:"cpu,omitempty"`
-	Memory                int       `json:"memory,omitempty"`
-	RuntimePlatform       string    `json:"runtime_platform,omitempty"`
-	ImageRegistry         string    `json:"image_registry,omitempty"`
-	ImageName             string    `json:"image_name,omitempty"`
-	ImageTag              string    `json:"image_tag,omitempty"`
-	CreatedBy             string    `json:"created_by,omitempty"`
-	OwnedBy               []string  `json:"owned_by"`
-	CreatedAt             time.Time `json:"created_at"`
-	CreatedByRequestID    string    `json:"created_by_request_id"`
-	ModifiedByRequestID   string    `json:"modified_by_request_id"`
+	ImageID               string       `json:"image_id"`
+	Image                 string       `json:"image"`
+	TaskDefinitionName    string       `json:"task_definition_name,omitempty"`
+	IsDefault             *bool        `json:"is_default,omitempty"`
+	TaskRoleName          *string      `json:"task_role_name,omitempty"`
+	TaskExecutionRoleName *string      `json:"task_execution_role_name,omitempty"`
+	CPU                   int          `json:"cpu,omitempty"`
+	Memory                int          `json:"memory,omitempty"`
+	RuntimePlatform       string       `json:"runtime_platform,omitempty"`
+	LogLimits             *LogLimits   `json:"log_limits,omitempty"`
+	WarmPoolSize          int          `json:"warm_pool_size,omitempty"`
+	RunDefaults           *RunDefaults `json:"run_defaults,omitempty"`
+	ImageRegistry         string       `json:"image_registry,omitempty"`
+	ImageName             string       `json:"image_name,omitempty"`
+	ImageTag              string       `json:"image_tag,omitempty"`
+	CreatedBy             string       `json:"created_by,omitempty"`
+	OwnedBy               []string     `json:"owned_by"`
+	CreatedAt             time.Time    `json:"created_at"`
+	CreatedByRequestID    string       `json:"created_by_request_id"`
+	ModifiedByRequestID   string       `json:"modified_by_request_id"`
+
+	// DeletedAt is set when the image was deleted, it can be restored until the retention window expires.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+
+	// ResolvedFromAlias is the image alias the image was resolved from when resolving the image of an execution.
+	ResolvedFromAlias string `json:"resolved_from_alias,omitempty"`
+
+	// Version is incremented by each update of the settings of the image, an update of an image modified
+	// since it was read failing with a conflict.
+	Version int64 `json:"version,omitempty"`
 }
 
 // ListImagesResponse represents the response containing all registered images.
 type ListImagesResponse struct {
 	Images []ImageInfo `json:"images"`
 }
+
+// ImageAlias is a stable name, such as python:stable, pointing at a registered image.
+// Executions referencing the alias run the image it points at when they start.
+type ImageAlias struct {
+	Alias     string    `json:"alias"`
+	ImageID   string    `json:"image_id"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetImageAliasRequest represents the request to create an image alias or to point it at another image.
+// Image is a registered image ID or name.
+type SetImageAliasRequest struct {
+	Alias string `json:"alias"`
+	Image string `json:"image"`
+}
+
+// SetImageAliasResponse represents the response after creating or updating an image alias.
+// PreviousImageID is the image the alias pointed at before, empty when the alias was created.
+type SetImageAliasResponse struct {
+	Alias           ImageAlias `json:"alias"`
+	PreviousImageID string     `json:"previous_image_id,omitempty"`
+	Message         string     `json:"message"`
+}
+
+// ListImageAliasesResponse represents the response containing all image aliases.
+type ListImageAliasesResponse struct {
+	Aliases []ImageAlias `json:"aliases"`
+}
+
+// RemoveImageAliasResponse represents the response after removing an image alias.
+type RemoveImageAliasResponse struct {
+	Alias   string `json:"alias"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+)
+
+// ExecutionLock is a named lock held by an execution started with the lock, so that a single execution
+// holding it runs at a time. The lock is released when the execution completes, or once it expires when
+// the completion of the execution was never processed.
+type ExecutionLock struct {
+	Name string `json:"name"`
+	// ExecutionID is the execution holding the lock, empty while the execution is being started.
+	ExecutionID string    `json:"execution_id,omitempty"`
+	HeldBy      string    `json:"held_by"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Token identifies the request that acquired the lock, to release it before its execution is recorded.
+	Token string `json:"-"`
+}
+
+// ListLocksResponse represents the response containing the locks currently held.
+type ListLocksResponse struct {
+	Locks []ExecutionLock `json:"locks"`
+}
+
+// ReleaseLockResponse represents the response after releasing a lock.
+type ReleaseLockResponse struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
@@ -7,6 +7,25 @@ type LogEvent struct {
 	EventID   string `json:"event_id"`  // Unique identifier for the log event
 	Timestamp int64  `json:"timestamp"` // Unix timestamp in milliseconds
 	Message   string `json:"message"`   // The actual log message text
+	// Sequence is the position of the event in the log buffer of its execution, set once buffered
+	Sequence int64 `json:"sequence,omitempty"`
+}
+
+// LogLimits caps the log output ingested for an execution, protecting the log storage
+// and the WebSocket forwarder from commands printing megabytes per second.
+// Zero values fall back to the backend defaults.
+type LogLimits struct {
+	// MaxLinesPerSecond is the maximum number of log lines kept per second of log timestamps.
+	MaxLinesPerSecond int `json:"max_lines_per_second,omitempty"`
+	// MaxBytes is the maximum total size in bytes of the log messages kept for an execution.
+	MaxBytes int64 `json:"max_bytes,omitempty"`
+}
+
+// LogUsage accounts for the log output ingested and dropped for an execution.
+type LogUsage struct {
+	Bytes        int64 `json:"bytes"`
+	DroppedLines int64 `json:"dropped_lines,omitempty"`
+	DroppedBytes int64 `json:"dropped_bytes,omitempty"`
 }
 
 // LogsResponse contains all log events for an execution.
@@ -24,6 +43,9 @@ type LogsResponse struct {
 	// WebSocket URL for streaming logs (only provided when execution is running).
 	// Omitted for terminal executions.
 	WebSocketURL string `json:"websocket_url,omitempty"`
+
+	// Resource usage summary of the execution, only provided once it completed.
+	ResourceSummary *ResourceSummary `json:"resource_summary,omitempty"`
 }
 
 // TraceResponse contains logs and related resources for a request ID.
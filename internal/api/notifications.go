@@ -0,0 +1,58 @@
+package api
+
+import (
+	"time"
+)
+
+// NotificationSettings are the admin settings of the notifications the backend sends.
+type NotificationSettings struct {
+	UsageReport UsageReportSettings `json:"usage_report"`
+	UpdatedBy   string              `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time          `json:"updated_at,omitempty"`
+}
+
+// UsageReportSettings configure the weekly usage report emailed to the admins.
+type UsageReportSettings struct {
+	// Disabled opts out of the report.
+	Disabled bool `json:"disabled,omitempty"`
+	// Recipients receive the report instead of the admins when set.
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+// NotificationSettingsRequest represents the request to set the notification settings, replacing them.
+type NotificationSettingsRequest struct {
+	UsageReport UsageReportSettings `json:"usage_report"`
+}
+
+// NotificationSettingsResponse represents the notification settings, after setting them or not.
+type NotificationSettingsResponse struct {
+	Settings NotificationSettings `json:"settings"`
+	Message  string               `json:"message,omitempty"`
+}
+
+// UsageReport summarizes the executions started over a period by each user, busiest first.
+type UsageReport struct {
+	PeriodStart time.Time   `json:"period_start"`
+	PeriodEnd   time.Time   `json:"period_end"`
+	Users       []UserUsage `json:"users"`
+	Total       UserUsage   `json:"total"`
+}
+
+// UserUsage counts the executions a user started over the period of a usage report, their total having no email.
+type UserUsage struct {
+	UserEmail string `json:"user_email,omitempty"`
+	Runs      int    `json:"runs"`
+	// Completed counts the runs in a final status, the others still running at the end of the period.
+	Completed int `json:"completed"`
+	Succeeded int `json:"succeeded"`
+	// CostUSD is the estimated compute cost of the runs, see Budget.
+	CostUSD float64 `json:"cost_usd"`
+}
+
+// SuccessRate returns the percentage of the completed runs that succeeded, 0 when none completed.
+func (u *UserUsage) SuccessRate() float64 {
+	if u.Completed == 0 {
+		return 0
+	}
+	return float64(u.Succeeded) / float64(u.Completed) * 100
+}
@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // Playbook represents a reusable command execution configuration.
 type Playbook struct {
 	Description string            `yaml:"description,omitempty"`
@@ -7,7 +9,29 @@ type Playbook struct {
 	GitRepo     string            `yaml:"git_repo,omitempty"`
 	GitRef      string            `yaml:"git_ref,omitempty"`
 	GitPath     string            `yaml:"git_path,omitempty"`
+	WorkDir     string            `yaml:"workdir,omitempty"`
+	Shell       string            `yaml:"shell,omitempty"` // sh, bash or pwsh, running the joined commands
 	Secrets     []string          `yaml:"secrets,omitempty"`
 	Env         map[string]string `yaml:"env,omitempty"`
 	Commands    []string          `yaml:"commands"`
+	// MaxDuration is the duration the commands are expected to run at most, e.g. "15m".
+	// Executions running longer are flagged as breaching the playbook's duration SLO.
+	MaxDuration time.Duration `yaml:"max_duration,omitempty"`
+	// EnvSchema declares the environment variables the commands expect. The backend rejects the
+	// executions of the playbook missing a required variable or setting one to a value it doesn't accept.
+	EnvSchema []EnvVarSpec `yaml:"env_schema,omitempty"`
+}
+
+// EnvVarSpec declares an environment variable of an env schema, and the values it accepts.
+type EnvVarSpec struct {
+	Name string `yaml:"name" json:"name"`
+	// Type is string, url, int or enum, string when empty.
+	Type        string `yaml:"type,omitempty" json:"type,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Min and Max bound the values of int variables, inclusive, when set.
+	Min *int `yaml:"min,omitempty" json:"min,omitempty"`
+	Max *int `yaml:"max,omitempty" json:"max,omitempty"`
+	// Values are the values enum variables accept.
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
 }
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"time"
+)
+
+// RunFreeze is the admin switch rejecting new executions, e.g. during a planned backend upgrade.
+// Reads and the completion of the executions already running are not affected.
+type RunFreeze struct {
+	Frozen bool `json:"frozen"`
+	// Message is shown to the users whose runs are rejected.
+	Message   string     `json:"message,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// RunFreezeRequest represents the request to freeze the runs.
+type RunFreezeRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// RunFreezeResponse represents the response containing the run freeze state.
+type RunFreezeResponse struct {
+	Freeze  RunFreeze `json:"freeze"`
+	Message string    `json:"message,omitempty"`
+}
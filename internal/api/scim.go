@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schema URNs (RFC 7643 and RFC 7644).
+const (
+	SCIMUserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMPatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIMUser is the SCIM representation of a user, restricted to the attributes runvoy uses. The user name is the
+// email of the runvoy user, and the groups and roles sent by the identity provider select its runvoy role.
+type SCIMUser struct {
+	Schemas    []string         `json:"schemas"`
+	ID         string           `json:"id,omitempty"`
+	ExternalID string           `json:"externalId,omitempty"`
+	UserName   string           `json:"userName"`
+	Emails     []SCIMMultiValue `json:"emails,omitempty"`
+	Active     *SCIMBool        `json:"active,omitempty"`
+	Groups     []SCIMMultiValue `json:"groups,omitempty"`
+	Roles      []SCIMMultiValue `json:"roles,omitempty"`
+	Meta       *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMMultiValue is an item of a multi-valued SCIM attribute, such as an email, a group or a role.
+type SCIMMultiValue struct {
+	Value   string `json:"value,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMeta holds the metadata of a SCIM resource.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMBool is a SCIM boolean, also accepting the "True" and "False" strings some identity providers send.
+type SCIMBool bool
+
+// UnmarshalJSON accepts JSON booleans and strings.
+func (b *SCIMBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = SCIMBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid boolean: %w", err)
+	}
+	value, err := strconv.ParseBool(strings.ToLower(text))
+	if err != nil {
+		return fmt.Errorf("invalid boolean: %w", err)
+	}
+	*b = SCIMBool(value)
+	return nil
+}
+
+// IsActive reports whether the user is active, which it is unless set otherwise.
+func (u *SCIMUser) IsActive() bool {
+	return u.Active == nil || bool(*u.Active)
+}
+
+// SCIMListResponse is a page of SCIM resources.
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []*SCIMUser `json:"Resources"`
+}
+
+// SCIMPatchRequest is a SCIM PATCH request.
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is an operation of a SCIM PATCH request. The value is decoded according to the path.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMError is the body of SCIM error responses.
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
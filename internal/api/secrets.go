@@ -17,6 +17,13 @@ type Secret struct {
 	UpdatedBy           string    `json:"updated_by"`
 	CreatedByRequestID  string    `json:"created_by_request_id"`
 	ModifiedByRequestID string    `json:"modified_by_request_id"`
+	// LastUsedAt and LastUsedByExecutionID record the last execution started with the secret,
+	// both empty when the secret has never been used.
+	LastUsedAt            *time.Time `json:"last_used_at,omitempty"`
+	LastUsedByExecutionID string     `json:"last_used_by_execution_id,omitempty"`
+	// Version is incremented by each update of the metadata of the secret, an update of a secret modified
+	// since it was read failing with a conflict.
+	Version int64 `json:"version,omitempty"`
 }
 
 // CreateSecretRequest represents the request to create a new secret.
@@ -83,3 +90,33 @@ type DeleteSecretResponse struct {
 	Name    string `json:"name"`
 	Message string `json:"message"`
 }
+
+// ExportSecretsRequest represents the request to export secrets for another environment.
+// Only the secrets listed in Names are exported.
+type ExportSecretsRequest struct {
+	Names      []string `json:"names"`      // Explicit allowlist of the secrets to export
+	Passphrase string   `json:"passphrase"` // Encrypts the exported bundle
+}
+
+// ExportSecretsResponse represents the response containing the encrypted bundle of exported secrets.
+type ExportSecretsResponse struct {
+	Bundle  []byte   `json:"bundle"` // Encrypted with the request passphrase
+	Secrets []string `json:"secrets"`
+}
+
+// ImportSecretsRequest represents the request to import secrets from an exported bundle.
+// Only the secrets listed in Names are imported, and their values are encrypted with the
+// key of the destination environment.
+type ImportSecretsRequest struct {
+	Bundle     []byte   `json:"bundle"`
+	Passphrase string   `json:"passphrase"`
+	Names      []string `json:"names"`               // Explicit allowlist of the secrets to import
+	Overwrite  bool     `json:"overwrite,omitempty"` // Update existing secrets instead of skipping them
+}
+
+// ImportSecretsResponse represents the response after importing secrets.
+type ImportSecretsResponse struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Skipped []string `json:"skipped"` // Existing secrets, left unchanged
+}
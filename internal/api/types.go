@@ -15,4 +15,6 @@ type HealthResponse struct {
 	Version  string                    `json:"version"`
 	Provider constants.BackendProvider `json:"provider"`
 	Region   string                    `json:"region,omitempty"`
+	// MinClientVersion is the oldest CLI version the backend supports.
+	MinClientVersion string `json:"min_client_version,omitempty"`
 }
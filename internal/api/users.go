@@ -14,6 +14,29 @@ type User struct {
 	LastUsed            *time.Time `json:"last_used,omitempty"`
 	CreatedByRequestID  string     `json:"created_by_request_id"`
 	ModifiedByRequestID string     `json:"modified_by_request_id"`
+
+	// Provisioned users are managed by the identity provider through SCIM, ExternalID being their ID there and
+	// Groups the groups and roles it sent, which select the role of the user.
+	Provisioned bool     `json:"provisioned,omitempty"`
+	ExternalID  string   `json:"external_id,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+	// PendingLogin is set until a provisioned user logs in for the first time, which issues their API key.
+	PendingLogin bool `json:"pending_login,omitempty"`
+	// ClaimExpiresAt is set until an invited user claims their API key, when the claim token sent to them
+	// expires. Users whose claim token expired stay pending until a new token is issued to them.
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+	// ServiceAccount users are the non-human users of automations, owned by Owner, the human user
+	// responsible for them. They authenticate with their own API keys and have their own budgets, and keep
+	// working when their owner is revoked.
+	ServiceAccount bool   `json:"service_account,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+	// StarredExecutions and SavedFilters are the preferences of the user when listing executions,
+	// served by the executions endpoints.
+	StarredExecutions []string              `json:"-"`
+	SavedFilters      []ExecutionListFilter `json:"-"`
+	// Version is incremented by each update of the user, an update of a user modified since it was read
+	// failing with a conflict.
+	Version int64 `json:"version,omitempty"`
 }
 
 // CreateUserRequest represents the request to create a new user.
@@ -21,14 +44,30 @@ type CreateUserRequest struct {
 	Email  string `json:"email"`
 	APIKey string `json:"api_key,omitempty"` // Optional: if not provided, one will be generated
 	Role   string `json:"role"`              // Required: admin, operator, developer, or viewer
+	// ServiceAccount creates a non-human user owned by Owner, an existing user, who claims its API key.
+	ServiceAccount bool   `json:"service_account,omitempty"`
+	Owner          string `json:"owner,omitempty"`
 }
 
-// CreateUserResponse represents the response after creating a user.
+// CreateUserResponse represents the response after creating a user, or issuing a new claim token to a user
+// who has not claimed their API key.
 type CreateUserResponse struct {
 	User       *User  `json:"user"`
 	ClaimToken string `json:"claim_token"`
 }
 
+// TransferOwnershipRequest represents the request to transfer a service account to another owner.
+type TransferOwnershipRequest struct {
+	Owner string `json:"owner"`
+}
+
+// TransferOwnershipResponse represents the response after transferring a service account.
+type TransferOwnershipResponse struct {
+	User          *User  `json:"user"`
+	PreviousOwner string `json:"previous_owner"`
+	Message       string `json:"message"`
+}
+
 // PendingAPIKey represents a pending API key awaiting claim.
 type PendingAPIKey struct {
 	SecretToken  string     `json:"secret_token"`
@@ -64,3 +103,22 @@ type RevokeUserResponse struct {
 type ListUsersResponse struct {
 	Users []*User `json:"users"`
 }
+
+// LoginConfigResponse tells the CLI which identity provider to log in with.
+type LoginConfigResponse struct {
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"client_id"`
+}
+
+// LoginRequest exchanges an ID token of the identity provider for an API key.
+type LoginRequest struct {
+	IDToken string `json:"id_token"`
+}
+
+// LoginResponse carries the API key issued to a provisioned user logging in.
+type LoginResponse struct {
+	APIKey    string `json:"api_key"`
+	UserEmail string `json:"user_email"`
+	Role      string `json:"role"`
+	Message   string `json:"message,omitempty"`
+}
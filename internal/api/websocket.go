@@ -7,6 +7,7 @@ type WebSocketConnection struct {
 	Functionality string `json:"functionality"`
 	ExpiresAt     int64  `json:"expires_at"`
 	LastEventID   string `json:"last_event_id,omitempty"`
+	LastSequence  int64  `json:"last_sequence,omitempty"`
 	ClientIP      string `json:"client_ip,omitempty"`
 	Token         string `json:"token,omitempty"`
 	UserEmail     string `json:"user_email,omitempty"`
@@ -25,6 +26,10 @@ type WebSocketToken struct {
 	CreatedAt int64  `json:"created_at"`
 }
 
+// WebSocketProtocolVersion is the version of the message envelope sent to WebSocket clients.
+// New message types and fields are added without changing it, clients ignore the types they don't know.
+const WebSocketProtocolVersion = 1
+
 // WebSocketMessageType represents the type of WebSocket message.
 type WebSocketMessageType string
 
@@ -33,6 +38,8 @@ const (
 	WebSocketMessageTypeLog WebSocketMessageType = "log"
 	// WebSocketMessageTypeDisconnect represents a disconnect notification message.
 	WebSocketMessageTypeDisconnect WebSocketMessageType = "disconnect"
+	// WebSocketMessageTypeStatus represents an execution status change message.
+	WebSocketMessageTypeStatus WebSocketMessageType = "status"
 )
 
 // WebSocketDisconnectReason represents the reason for a disconnect.
@@ -44,9 +51,32 @@ const (
 )
 
 // WebSocketMessage represents a WebSocket message sent to clients.
+// Clients dispatch on Type, the other fields are set depending on it.
 type WebSocketMessage struct {
+	Version   int                        `json:"version"`
 	Type      WebSocketMessageType       `json:"type"`
 	Reason    *WebSocketDisconnectReason `json:"reason,omitempty"`
 	Message   *string                    `json:"message,omitempty"`
 	Timestamp *int64                     `json:"timestamp,omitempty"`
+	Status    *ExecutionStatusChange     `json:"status,omitempty"`
+}
+
+// WebSocketLogMessage represents a log event message sent to clients.
+// The log event fields stay at the top level of the message, next to the envelope fields.
+type WebSocketLogMessage struct {
+	Version int                  `json:"version"`
+	Type    WebSocketMessageType `json:"type"`
+	LogEvent
+}
+
+// ExecutionStatusChange represents a status transition of an execution pushed to WebSocket clients.
+// ExitCode, FailureReason and FailureMessage are only set for terminal statuses.
+type ExecutionStatusChange struct {
+	ExecutionID    string `json:"execution_id"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Status         string `json:"status"`
+	ExitCode       *int   `json:"exit_code,omitempty"`
+	FailureReason  string `json:"failure_reason,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+	Timestamp      int64  `json:"timestamp"`
 }
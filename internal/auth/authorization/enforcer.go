@@ -14,13 +14,15 @@ import (
 	"github.com/casbin/casbin/v2/model"
 	"github.com/casbin/casbin/v2/persist"
 
+	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/logger"
 )
 
 // Enforcer wraps the Casbin enforcer with additional functionality.
 type Enforcer struct {
-	enforcer casbin.IEnforcer
-	logger   *slog.Logger
+	enforcer  casbin.IEnforcer
+	logger    *slog.Logger
+	hydration *hydrationState // Set by SetHydrationSource, nil when the enforcer is hydrated by its owner
 }
 
 // embeddedAdapter is a custom Casbin adapter that reads from an embedded filesystem
@@ -121,6 +123,7 @@ func NewEnforcer(log *slog.Logger) (*Enforcer, error) {
 
 // Enforce checks if a subject (user) can perform an action on an object (resource).
 // Returns true if the action is allowed, false otherwise.
+// With a hydration source, the enforcer is hydrated first if it was not yet or its roles have expired.
 //
 // Example usage:
 //
@@ -128,6 +131,10 @@ func NewEnforcer(log *slog.Logger) (*Enforcer, error) {
 func (e *Enforcer) Enforce(ctx context.Context, subject, object string, action Action) (bool, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, e.logger)
 
+	if err := e.EnsureHydrated(ctx); err != nil {
+		return false, err
+	}
+
 	allowed, err := e.enforcer.Enforce(subject, object, string(action))
 	if err != nil {
 		reqLogger.Error("casbin enforcement error", "context", map[string]any{
@@ -258,6 +265,42 @@ func (e *Enforcer) HasOwnershipForResource(resourceID, ownerEmail string) (bool,
 	return hasOwnership, nil
 }
 
+// SetVisibilityForResource records the visibility of a resource, replacing the previous one.
+// The resource object is mapped to the "visibility:<name>" policy subject so that the policies
+// of that visibility apply to every user reading it, except admins. An empty visibility only
+// removes the previous mapping, leaving the resource to the role policies.
+//
+// Example usage:
+//
+//	err := e.SetVisibilityForResource(ctx, "/api/v1/executions/exec-123", "private")
+func (e *Enforcer) SetVisibilityForResource(ctx context.Context, resourceObject, visibility string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, e.logger)
+
+	if _, err := e.enforcer.RemoveFilteredNamedGroupingPolicy("g3", 0, resourceObject); err != nil {
+		return fmt.Errorf("failed to remove visibility for resource %s: %w", resourceObject, err)
+	}
+	if visibility == "" {
+		reqLogger.Debug("visibility removed for resource", "resource", resourceObject)
+		return nil
+	}
+
+	if _, err := e.enforcer.AddNamedGroupingPolicy("g3", resourceObject, FormatVisibility(visibility)); err != nil {
+		return fmt.Errorf("failed to add visibility for resource %s: %w", resourceObject, err)
+	}
+
+	reqLogger.Debug("visibility set for resource", "resource", resourceObject, "visibility", visibility)
+	return nil
+}
+
+// SetExecutionVisibility records the visibility of an execution. Team-visible executions,
+// and executions recorded before visibilities existed, follow the role policies.
+func (e *Enforcer) SetExecutionVisibility(ctx context.Context, executionID, visibility string) error {
+	if constants.ExecutionVisibility(visibility) == constants.ExecutionVisibilityTeam {
+		visibility = ""
+	}
+	return e.SetVisibilityForResource(ctx, FormatExecutionObject(executionID), visibility)
+}
+
 // LoadResourceOwnerships loads resource ownership mappings into the enforcer.
 func (e *Enforcer) LoadResourceOwnerships(ctx context.Context, ownerships map[string]string) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, e.logger)
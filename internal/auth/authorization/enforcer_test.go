@@ -687,6 +687,36 @@ func TestEnforce(t *testing.T) {
 			action:  ActionUpdate,
 			want:    false,
 		},
+		{
+			name: "viewer can read secret metadata",
+			setup: func() {
+				_ = e.AddRoleForUser(context.Background(), "viewer-secrets@example.com", RoleViewer)
+			},
+			subject: "viewer-secrets@example.com",
+			object:  "/api/v1/secrets/db-password",
+			action:  ActionRead,
+			want:    true,
+		},
+		{
+			name: "viewer cannot reveal secret values",
+			setup: func() {
+				_ = e.AddRoleForUser(context.Background(), "viewer-secrets@example.com", RoleViewer)
+			},
+			subject: "viewer-secrets@example.com",
+			object:  "/api/v1/secrets/db-password",
+			action:  ActionReveal,
+			want:    false,
+		},
+		{
+			name: "operator can reveal secret values",
+			setup: func() {
+				_ = e.AddRoleForUser(context.Background(), "operator-secrets@example.com", RoleOperator)
+			},
+			subject: "operator-secrets@example.com",
+			object:  "/api/v1/secrets/db-password",
+			action:  ActionReveal,
+			want:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -704,6 +734,85 @@ func TestEnforce(t *testing.T) {
 	}
 }
 
+func TestSetExecutionVisibility(t *testing.T) {
+	e := createTestEnforcer(t)
+	ctx := context.Background()
+
+	users := map[string]Role{
+		"admin@example.com":     RoleAdmin,
+		"operator@example.com":  RoleOperator,
+		"developer@example.com": RoleDeveloper,
+		"viewer@example.com":    RoleViewer,
+	}
+	for user, role := range users {
+		if err := e.AddRoleForUser(ctx, user, role); err != nil {
+			t.Fatalf("AddRoleForUser() failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		visibility string
+		allowed    []string
+	}{
+		{visibility: "private", allowed: []string{"admin@example.com"}},
+		{visibility: "team", allowed: []string{"admin@example.com", "operator@example.com"}},
+		{visibility: "", allowed: []string{"admin@example.com", "operator@example.com"}},
+		{
+			visibility: "public",
+			allowed: []string{
+				"admin@example.com", "operator@example.com", "developer@example.com", "viewer@example.com",
+			},
+		},
+	}
+
+	object := FormatExecutionObject("exec-visibility")
+	for _, tt := range tests {
+		t.Run("visibility "+tt.visibility, func(t *testing.T) {
+			if err := e.SetExecutionVisibility(ctx, "exec-visibility", tt.visibility); err != nil {
+				t.Fatalf("SetExecutionVisibility() error = %v, want nil", err)
+			}
+
+			for user := range users {
+				allowed, err := e.Enforce(ctx, user, object, ActionRead)
+				if err != nil {
+					t.Fatalf("Enforce() error = %v, want nil", err)
+				}
+				if want := slices.Contains(tt.allowed, user); allowed != want {
+					t.Errorf("Enforce(%s, %s, read) = %v, want %v", user, object, allowed, want)
+				}
+			}
+		})
+	}
+
+	t.Run("private visibility does not restrict other actions", func(t *testing.T) {
+		if err := e.SetExecutionVisibility(ctx, "exec-visibility", "private"); err != nil {
+			t.Fatalf("SetExecutionVisibility() error = %v, want nil", err)
+		}
+
+		allowed, err := e.Enforce(ctx, "operator@example.com", object, ActionDelete)
+		if err != nil {
+			t.Fatalf("Enforce() error = %v, want nil", err)
+		}
+		if !allowed {
+			t.Errorf("Enforce(operator, %s, delete) = false, want true", object)
+		}
+	})
+
+	t.Run("replaces the previous visibility", func(t *testing.T) {
+		if err := e.SetExecutionVisibility(ctx, "exec-visibility", "public"); err != nil {
+			t.Fatalf("SetExecutionVisibility() error = %v, want nil", err)
+		}
+
+		policies, err := e.GetAllNamedGroupingPolicies("g3")
+		if err != nil {
+			t.Fatalf("GetAllNamedGroupingPolicies() error = %v, want nil", err)
+		}
+		if len(policies) != 1 || policies[0][1] != "visibility:public" {
+			t.Errorf("g3 policies = %v, want a single public visibility", policies)
+		}
+	})
+}
+
 // Helper functions
 func contains(s, substr string) bool {
 	return s != "" && substr != "" && (s == substr || len(s) >= len(substr) && containsSubstring(s, substr))
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/logger"
 )
 
 // ImageRepository defines the interface for listing images.
@@ -19,6 +23,7 @@ type ImageRepository interface {
 
 // Hydrate loads all user roles and resource ownerships into the Casbin enforcer.
 // This should be called during initialization to populate the enforcer with current data.
+// The users and the executions, secrets and images are listed concurrently.
 func (e *Enforcer) Hydrate(
 	ctx context.Context,
 	userRepo database.UserRepository,
@@ -26,14 +31,88 @@ func (e *Enforcer) Hydrate(
 	secretsRepo database.SecretsRepository,
 	imageRepo ImageRepository,
 ) error {
-	if err := e.loadUserRoles(ctx, userRepo); err != nil {
-		return fmt.Errorf("failed to load user roles: %w", err)
+	g, egCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		if err := e.loadUserRoles(egCtx, userRepo); err != nil {
+			return fmt.Errorf("failed to load user roles: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := e.loadResourceOwnerships(egCtx, executionRepo, secretsRepo, imageRepo); err != nil {
+			return fmt.Errorf("failed to load resource ownerships: %w", err)
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// HydrationSource holds the repositories the enforcer is hydrated from.
+// Images is optional, image ownerships are not loaded when it is nil.
+type HydrationSource struct {
+	Users      database.UserRepository
+	Executions database.ExecutionRepository
+	Secrets    database.SecretsRepository
+	Images     ImageRepository
+}
+
+// hydrationState tracks when the enforcer was last hydrated from its source.
+type hydrationState struct {
+	mu              sync.Mutex
+	source          HydrationSource
+	refreshInterval time.Duration
+	hydratedAt      time.Time
+	nowFn           func() time.Time
+}
+
+// SetHydrationSource makes the enforcer hydrate itself from source on the first authorization check
+// instead of at initialization, keeping the database scans out of the cold start. With a positive
+// refreshInterval, the check following the expiry of the cached roles and ownerships hydrates them
+// again, picking up those added by the other instances of the backend. Hydration is additive: roles
+// and ownerships removed by another instance remain until the instance restarts.
+func (e *Enforcer) SetHydrationSource(source HydrationSource, refreshInterval time.Duration) {
+	e.hydration = &hydrationState{
+		source:          source,
+		refreshInterval: refreshInterval,
+		nowFn:           time.Now,
+	}
+}
+
+// EnsureHydrated hydrates the enforcer from its hydration source if it was not yet, or if the cached roles
+// and ownerships have expired. A failed refresh keeps the cached ones and is retried by the next call, only
+// the first hydration failing is an error. It does nothing when no hydration source is set.
+func (e *Enforcer) EnsureHydrated(ctx context.Context) error {
+	state := e.hydration
+	if state == nil {
+		return nil
 	}
 
-	if err := e.loadResourceOwnerships(ctx, executionRepo, secretsRepo, imageRepo); err != nil {
-		return fmt.Errorf("failed to load resource ownerships: %w", err)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	now := state.nowFn()
+	if !state.hydratedAt.IsZero() &&
+		(state.refreshInterval <= 0 || now.Sub(state.hydratedAt) < state.refreshInterval) {
+		return nil
 	}
 
+	reqLogger := logger.DeriveRequestLogger(ctx, e.logger)
+	// The roles loaded into the enforcer must not be disturbed by fault injection.
+	err := e.Hydrate(chaos.Suppress(ctx),
+		state.source.Users, state.source.Executions, state.source.Secrets, state.source.Images)
+	if err != nil {
+		if state.hydratedAt.IsZero() {
+			return fmt.Errorf("failed to hydrate enforcer: %w", err)
+		}
+		reqLogger.Warn("failed to refresh authorization enforcer, keeping cached roles and ownerships",
+			"error", err, "hydrated_at", state.hydratedAt.Format(time.RFC3339))
+		return nil
+	}
+
+	reqLogger.Debug("authorization enforcer hydrated", "duration_ms", state.nowFn().Sub(now).Milliseconds())
+	state.hydratedAt = now
 	return nil
 }
 
@@ -80,21 +159,30 @@ func (e *Enforcer) loadResourceOwnerships(
 	secretsRepo database.SecretsRepository,
 	imageRepo ImageRepository,
 ) error {
-	if err := e.loadSecretOwnerships(ctx, secretsRepo); err != nil {
-		return fmt.Errorf("failed to load secret ownerships: %w", err)
-	}
-
-	if err := e.loadExecutionOwnerships(ctx, executionRepo); err != nil {
-		return fmt.Errorf("failed to load execution ownerships: %w", err)
-	}
+	g, egCtx := errgroup.WithContext(ctx)
 
-	if imageRepo != nil {
-		if err := e.loadImageOwnerships(ctx, imageRepo); err != nil {
-			return fmt.Errorf("failed to load image ownerships: %w", err)
+	g.Go(func() error {
+		if err := e.loadSecretOwnerships(egCtx, secretsRepo); err != nil {
+			return fmt.Errorf("failed to load secret ownerships: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := e.loadExecutionOwnerships(egCtx, executionRepo); err != nil {
+			return fmt.Errorf("failed to load execution ownerships: %w", err)
 		}
+		return nil
+	})
+	if imageRepo != nil {
+		g.Go(func() error {
+			if err := e.loadImageOwnerships(egCtx, imageRepo); err != nil {
+				return fmt.Errorf("failed to load image ownerships: %w", err)
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
 func (e *Enforcer) loadSecretOwnerships(
@@ -145,6 +233,11 @@ func (e *Enforcer) loadExecutionOwnerships(
 					return fmt.Errorf("failed to add ownership for execution %s: %w", execution.ExecutionID, addErr)
 				}
 			}
+			if execution.Visibility != "" {
+				if setErr := e.SetExecutionVisibility(egCtx, execution.ExecutionID, execution.Visibility); setErr != nil {
+					return fmt.Errorf("failed to set visibility for execution %s: %w", execution.ExecutionID, setErr)
+				}
+			}
 			return nil
 		})
 	}
@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"os"
@@ -14,8 +15,9 @@ import (
 // Mock repositories for testing
 
 type mockUserRepository struct {
-	users []*api.User
-	err   error
+	users     []*api.User
+	err       error
+	listCalls int
 }
 
 func (m *mockUserRepository) CreateUser(_ context.Context, _ *api.User, _ string, _ int64) error {
@@ -42,7 +44,20 @@ func (m *mockUserRepository) RevokeUser(_ context.Context, _ string) error {
 	return errors.New("not implemented")
 }
 
+func (*mockUserRepository) UpdateUser(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*mockUserRepository) UpdateUserPreferences(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*mockUserRepository) ReplaceAPIKeyHash(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (m *mockUserRepository) ListUsers(_ context.Context) ([]*api.User, error) {
+	m.listCalls++
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -61,6 +76,14 @@ func (m *mockUserRepository) DeletePendingAPIKey(_ context.Context, _ string) er
 	return errors.New("not implemented")
 }
 
+func (m *mockUserRepository) ListPendingAPIKeys(_ context.Context) ([]*api.PendingAPIKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserRepository) DeleteUser(_ context.Context, _ string) error {
+	return errors.New("not implemented")
+}
+
 func (m *mockUserRepository) MarkAsViewed(_ context.Context, _, _ string) error {
 	return errors.New("not implemented")
 }
@@ -78,6 +101,10 @@ func (m *mockExecutionRepository) CreateExecution(_ context.Context, _ *api.Exec
 	return errors.New("not implemented")
 }
 
+func (m *mockExecutionRepository) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return errors.New("not implemented")
+}
+
 func (m *mockExecutionRepository) GetExecution(_ context.Context, _ string) (*api.Execution, error) {
 	return nil, errors.New("not implemented")
 }
@@ -93,10 +120,62 @@ func (m *mockExecutionRepository) ListExecutions(_ context.Context, _ int, _ []s
 	return m.executions, nil
 }
 
+func (m *mockExecutionRepository) ListExecutionsByCreator(
+	_ context.Context, _ string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockExecutionRepository) GetExecutionsByRequestID(_ context.Context, _ string) ([]*api.Execution, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockExecutionRepository) GetExecutionsByGroupID(_ context.Context, _ string) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepository) AddExecutionAnnotation(
+	_ context.Context, _ string, _ *api.ExecutionAnnotation,
+) error {
+	return errors.New("not implemented")
+}
+
 type mockSecretsRepository struct {
 	secrets []*api.Secret
 	err     error
@@ -129,6 +208,10 @@ func (m *mockSecretsRepository) GetSecretsByRequestID(_ context.Context, _ strin
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockSecretsRepository) RecordSecretUsage(_ context.Context, _, _ string, _ time.Time) error {
+	return errors.New("not implemented")
+}
+
 type mockImageRepository struct {
 	images []api.ImageInfo
 	err    error
@@ -286,6 +369,148 @@ func TestHydrate(t *testing.T) {
 	}
 }
 
+func TestEnsureHydrated(t *testing.T) {
+	newLazyEnforcer := func(
+		t *testing.T, userRepo *mockUserRepository, refreshInterval time.Duration,
+	) (*Enforcer, *time.Time) {
+		t.Helper()
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		e, err := NewEnforcer(logger)
+		if err != nil {
+			t.Fatalf("NewEnforcer() failed: %v", err)
+		}
+		e.SetHydrationSource(HydrationSource{
+			Users:      userRepo,
+			Executions: &mockExecutionRepository{},
+			Secrets:    &mockSecretsRepository{},
+		}, refreshInterval)
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		e.hydration.nowFn = func() time.Time { return now }
+		return e, &now
+	}
+	hasRole := func(t *testing.T, e *Enforcer, user string) bool {
+		t.Helper()
+		roles, err := e.GetRolesForUser(user)
+		if err != nil {
+			t.Fatalf("GetRolesForUser(%s) failed: %v", user, err)
+		}
+		return len(roles) > 0
+	}
+
+	t.Run("does nothing without a hydration source", func(t *testing.T) {
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		e, err := NewEnforcer(logger)
+		if err != nil {
+			t.Fatalf("NewEnforcer() failed: %v", err)
+		}
+
+		if err = e.EnsureHydrated(context.Background()); err != nil {
+			t.Errorf("EnsureHydrated() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("hydrates on the first authorization check", func(t *testing.T) {
+		userRepo := &mockUserRepository{users: []*api.User{{Email: "admin@example.com", Role: "admin"}}}
+		e, _ := newLazyEnforcer(t, userRepo, 0)
+
+		if userRepo.listCalls != 0 {
+			t.Fatalf("ListUsers() called %d times before the first check, want 0", userRepo.listCalls)
+		}
+		allowed, err := e.Enforce(context.Background(), "admin@example.com", "/api/v1/users", ActionRead)
+		if err != nil {
+			t.Fatalf("Enforce() error = %v, want nil", err)
+		}
+		if !allowed {
+			t.Error("Enforce() = false, want true for the hydrated admin")
+		}
+
+		for range 3 {
+			if err = e.EnsureHydrated(context.Background()); err != nil {
+				t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+			}
+		}
+		if userRepo.listCalls != 1 {
+			t.Errorf("ListUsers() called %d times, want 1 without a refresh interval", userRepo.listCalls)
+		}
+	})
+
+	t.Run("refreshes expired roles", func(t *testing.T) {
+		userRepo := &mockUserRepository{users: []*api.User{{Email: "admin@example.com", Role: "admin"}}}
+		e, now := newLazyEnforcer(t, userRepo, time.Minute)
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+		}
+		userRepo.users = append(userRepo.users, &api.User{Email: "dev@example.com", Role: "developer"})
+
+		*now = now.Add(30 * time.Second)
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+		}
+		if hasRole(t, e, "dev@example.com") {
+			t.Error("user added before the expiry was loaded, want the cached roles")
+		}
+
+		*now = now.Add(time.Minute)
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+		}
+		if !hasRole(t, e, "dev@example.com") {
+			t.Error("user added before the expiry was not loaded by the refresh")
+		}
+		if userRepo.listCalls != 2 {
+			t.Errorf("ListUsers() called %d times, want 2", userRepo.listCalls)
+		}
+	})
+
+	t.Run("keeps the cached roles when a refresh fails", func(t *testing.T) {
+		userRepo := &mockUserRepository{users: []*api.User{{Email: "admin@example.com", Role: "admin"}}}
+		e, now := newLazyEnforcer(t, userRepo, time.Minute)
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+		}
+		userRepo.err = errors.New("database unavailable")
+
+		*now = now.Add(2 * time.Minute)
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Errorf("EnsureHydrated() error = %v, want nil after a failed refresh", err)
+		}
+		if !hasRole(t, e, "admin@example.com") {
+			t.Error("cached role was lost by the failed refresh")
+		}
+
+		userRepo.err = nil
+		if err := e.EnsureHydrated(context.Background()); err != nil {
+			t.Fatalf("EnsureHydrated() error = %v, want nil", err)
+		}
+		if userRepo.listCalls != 3 {
+			t.Errorf("ListUsers() called %d times, want the failed refresh to be retried", userRepo.listCalls)
+		}
+	})
+
+	t.Run("denies when the first hydration fails", func(t *testing.T) {
+		userRepo := &mockUserRepository{err: errors.New("database unavailable")}
+		e, _ := newLazyEnforcer(t, userRepo, 0)
+
+		allowed, err := e.Enforce(context.Background(), "admin@example.com", "/api/v1/users", ActionRead)
+
+		if err == nil || !contains(err.Error(), "failed to hydrate enforcer") {
+			t.Errorf("Enforce() error = %v, want a hydration error", err)
+		}
+		if allowed {
+			t.Error("Enforce() = true, want false")
+		}
+
+		userRepo.err = nil
+		userRepo.users = []*api.User{{Email: "admin@example.com", Role: "admin"}}
+		if allowed, err = e.Enforce(context.Background(), "admin@example.com", "/api/v1/users", ActionRead); err != nil {
+			t.Fatalf("Enforce() error = %v, want the hydration to be retried", err)
+		}
+		if !allowed {
+			t.Error("Enforce() = false, want true once hydrated")
+		}
+	})
+}
+
 func TestLoadUserRoles(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -614,6 +839,44 @@ func TestLoadExecutionOwnerships(t *testing.T) {
 				verifyExecutionOwnerships(t, e, executions)
 			},
 		},
+		{
+			name:      "load execution visibilities",
+			repoError: nil,
+			wantError: false,
+			setup: func() (*Enforcer, error) {
+				e, err := newTestEnforcer()
+				if err != nil {
+					return nil, err
+				}
+				executionRepo := &mockExecutionRepository{
+					executions: []*api.Execution{
+						{ExecutionID: "exec-1", CreatedBy: "dev@example.com", Visibility: "private"},
+						{ExecutionID: "exec-2", CreatedBy: "dev@example.com", Visibility: "team"},
+						{ExecutionID: "exec-3", CreatedBy: "dev@example.com", Visibility: "public"},
+					},
+				}
+				loadErr := e.loadExecutionOwnerships(context.Background(), executionRepo)
+				return e, loadErr
+			},
+			verify: func(t *testing.T, e *Enforcer) {
+				policies, err := e.GetAllNamedGroupingPolicies("g3")
+				if err != nil {
+					t.Fatalf("GetAllNamedGroupingPolicies() error = %v, want nil", err)
+				}
+				want := map[string]string{
+					FormatExecutionObject("exec-1"): "visibility:private",
+					FormatExecutionObject("exec-3"): "visibility:public",
+				}
+				if len(policies) != len(want) {
+					t.Fatalf("g3 policies = %v, want %v", policies, want)
+				}
+				for _, policy := range policies {
+					if want[policy[0]] != policy[1] {
+						t.Errorf("visibility of %s = %s, want %s", policy[0], policy[1], want[policy[0]])
+					}
+				}
+			},
+		},
 		{
 			name:      "empty executions list",
 			repoError: nil,
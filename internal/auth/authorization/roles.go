@@ -23,7 +23,8 @@ const (
 	// RoleDeveloper can create and manage their own resources and execute commands.
 	RoleDeveloper Role = "developer"
 
-	// RoleViewer has read-only access to executions.
+	// RoleViewer has read-only access to executions, images and the metadata of secrets, for dashboards and
+	// auditors. It cannot run, kill or modify anything but its own starred executions and saved filters.
 	RoleViewer Role = "viewer"
 )
 
@@ -39,6 +40,12 @@ const (
 	ActionDelete Action = "delete"
 	ActionKill   Action = "kill"
 	ActionUse    Action = "use"
+	// ActionImpersonate allows starting executions on behalf of a user, checked on /api/v1/users/<email>.
+	ActionImpersonate Action = "impersonate"
+	// ActionOverride allows running past a hard-capped monthly budget, checked on /api/v1/budgets.
+	ActionOverride Action = "override"
+	// ActionReveal allows reading the value of a secret, not only its metadata, checked on /api/v1/secrets/<name>.
+	ActionReveal Action = "reveal"
 )
 
 // NewRole creates a new Role from a string, validating it against known roles.
@@ -71,6 +78,18 @@ func FormatRole(role Role) string {
 	return "role:" + role.String()
 }
 
+// FormatExecutionObject returns the API path of an execution, the object of execution policies.
+// Example: FormatExecutionObject("exec-123") returns "/api/v1/executions/exec-123".
+func FormatExecutionObject(executionID string) string {
+	return "/api/v1/executions/" + executionID
+}
+
+// FormatVisibility converts a visibility name to the Casbin policy subject of that visibility.
+// Example: FormatVisibility("private") returns "visibility:private".
+func FormatVisibility(visibility string) string {
+	return "visibility:" + visibility
+}
+
 // FormatResourceID converts a resource type and ID to the Casbin resource format.
 // Example: FormatResourceID("secret", "secret-123") returns "secret:secret-123".
 func FormatResourceID(resourceType, resourceID string) string {
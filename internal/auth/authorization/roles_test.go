@@ -404,6 +404,8 @@ func TestActionConstants(t *testing.T) {
 	assert.Equal(t, ActionUpdate, Action("update"))
 	assert.Equal(t, ActionDelete, Action("delete"))
 	assert.Equal(t, ActionKill, Action("kill"))
+	assert.Equal(t, ActionImpersonate, Action("impersonate"))
+	assert.Equal(t, ActionOverride, Action("override"))
 }
 
 // TestRoleCreationAndValidation is an integration test showing typical usage patterns
@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// deviceCodeGrantType is the grant type of the device authorization flow (RFC 8628).
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Default and added polling intervals of the device authorization flow (RFC 8628 section 3.5).
+const (
+	defaultDevicePollInterval = 5 * time.Second
+	slowDownInterval          = 5 * time.Second
+)
+
+// DeviceAuthorization is a pending device authorization: the user approves it by entering the user code at
+// the verification URI, in a browser on any device.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// DeviceFlow logs a user in with the device authorization flow of an issuer, as used by command line tools
+// which can't receive a browser redirect.
+type DeviceFlow struct {
+	issuer   string
+	clientID string
+	client   *http.Client
+
+	deviceAuthorizationEndpoint string
+	tokenEndpoint               string
+}
+
+// NewDeviceFlow creates a device authorization flow for the client ID registered with the issuer. A nil client
+// uses http.DefaultClient.
+func NewDeviceFlow(issuer, clientID string, client *http.Client) *DeviceFlow {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &DeviceFlow{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		clientID: clientID,
+		client:   client,
+	}
+}
+
+// Authorize starts a device authorization asking for the openid and email scopes.
+func (f *DeviceFlow) Authorize(ctx context.Context) (*DeviceAuthorization, error) {
+	if f.deviceAuthorizationEndpoint == "" {
+		var discovery struct {
+			DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+			TokenEndpoint               string `json:"token_endpoint"`
+		}
+		if err := getJSON(ctx, f.client, f.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, fmt.Errorf("failed to discover the issuer configuration: %w", err)
+		}
+		if discovery.DeviceAuthorizationEndpoint == "" || discovery.TokenEndpoint == "" {
+			return nil, errors.New("the identity provider does not support the device authorization flow")
+		}
+		f.deviceAuthorizationEndpoint = discovery.DeviceAuthorizationEndpoint
+		f.tokenEndpoint = discovery.TokenEndpoint
+	}
+
+	var authorization DeviceAuthorization
+	status, err := f.postForm(ctx, f.deviceAuthorizationEndpoint, url.Values{
+		"client_id": {f.clientID},
+		"scope":     {"openid email"},
+	}, &authorization)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || authorization.DeviceCode == "" {
+		return nil, fmt.Errorf("the device authorization was refused with status %d", status)
+	}
+	return &authorization, nil
+}
+
+// Wait polls the issuer until the user approves the device authorization, and returns the ID token issued.
+func (f *DeviceFlow) Wait(ctx context.Context, authorization *DeviceAuthorization) (string, error) {
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*time.Second)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("the device authorization was not approved in time: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		var token struct {
+			IDToken          string `json:"id_token"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		status, err := f.postForm(ctx, f.tokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {authorization.DeviceCode},
+			"client_id":   {f.clientID},
+		}, &token)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case status == http.StatusOK && token.IDToken != "":
+			return token.IDToken, nil
+		case status == http.StatusOK:
+			return "", errors.New("the identity provider issued no ID token")
+		case token.Error == "authorization_pending":
+		case token.Error == "slow_down":
+			interval += slowDownInterval
+		case token.Error != "":
+			return "", fmt.Errorf("the device authorization failed: %s %s", token.Error, token.ErrorDescription)
+		default:
+			return "", fmt.Errorf("unexpected status %d from %s", status, f.tokenEndpoint)
+		}
+	}
+}
+
+func (f *DeviceFlow) postForm(ctx context.Context, endpoint string, form url.Values, result any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post to %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode the response of %s: %w", endpoint, err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,80 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        server.URL,
+			"device_authorization_endpoint": server.URL + "/device",
+			"token_endpoint":                server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "runvoy-cli", r.PostFormValue("client_id"))
+		assert.Equal(t, "openid email", r.PostFormValue("scope"))
+		_ = json.NewEncoder(w).Encode(DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: server.URL + "/activate",
+			ExpiresIn:       60,
+			Interval:        1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, deviceCodeGrantType, r.PostFormValue("grant_type"))
+		assert.Equal(t, "device-code", r.PostFormValue("device_code"))
+		polls++
+		if polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": "id-token"})
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	flow := NewDeviceFlow(server.URL, "runvoy-cli", server.Client())
+	authorization, err := flow.Authorize(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH", authorization.UserCode)
+
+	idToken, err := flow.Wait(context.Background(), authorization)
+	require.NoError(t, err)
+	assert.Equal(t, "id-token", idToken)
+	assert.Equal(t, 2, polls)
+}
+
+func TestDeviceFlow_Denied(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "access_denied"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	flow := NewDeviceFlow(server.URL, "runvoy-cli", server.Client())
+	flow.tokenEndpoint = server.URL + "/token"
+	_, err := flow.Wait(context.Background(), &DeviceAuthorization{DeviceCode: "d", ExpiresIn: 10, Interval: 1})
+	require.ErrorContains(t, err, "access_denied")
+}
+
+func TestDeviceFlow_Unsupported(t *testing.T) {
+	issuer := newTestIssuer(t)
+	_, err := NewDeviceFlow(issuer.server.URL, "runvoy-cli", issuer.server.Client()).Authorize(context.Background())
+	require.ErrorContains(t, err, "does not support the device authorization flow")
+}
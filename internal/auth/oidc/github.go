@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Environment variables GitHub Actions sets in the jobs granted the id-token: write permission.
+const (
+	githubActionsTokenRequestURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubActionsTokenRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// ErrNotInGitHubActions is returned when requesting a GitHub Actions OIDC token outside of a job granted the
+// id-token: write permission.
+var ErrNotInGitHubActions = errors.New(
+	"no GitHub Actions OIDC token available, the job needs the id-token: write permission")
+
+// RequestGitHubActionsToken requests an OIDC token for the audience from GitHub Actions, for the job it runs in.
+// A nil client uses http.DefaultClient.
+func RequestGitHubActionsToken(ctx context.Context, client *http.Client, tokenAudience string) (string, error) {
+	requestURL := os.Getenv(githubActionsTokenRequestURLEnv)
+	requestToken := os.Getenv(githubActionsTokenRequestTokenEnv)
+	if requestURL == "" || requestToken == "" {
+		return "", ErrNotInGitHubActions
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	tokenURL, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubActionsTokenRequestURLEnv, err)
+	}
+	query := tokenURL.Query()
+	query.Set("audience", tokenAudience)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		Value string `json:"value"`
+	}
+	if err = doJSON(client, req, &token); err != nil {
+		return "", fmt.Errorf("failed to request the GitHub Actions OIDC token: %w", err)
+	}
+	if token.Value == "" {
+		return "", errors.New("GitHub Actions issued no OIDC token")
+	}
+	return token.Value, nil
+}
@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestGitHubActionsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "abc", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "runvoy", r.URL.Query().Get("audience"))
+		_, _ = w.Write([]byte(`{"count":1,"value":"github-id-token"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(githubActionsTokenRequestURLEnv, server.URL+"/token?api-version=abc")
+	t.Setenv(githubActionsTokenRequestTokenEnv, "request-token")
+
+	token, err := RequestGitHubActionsToken(context.Background(), server.Client(), "runvoy")
+	require.NoError(t, err)
+	assert.Equal(t, "github-id-token", token)
+}
+
+func TestRequestGitHubActionsToken_NotInGitHubActions(t *testing.T) {
+	t.Setenv(githubActionsTokenRequestURLEnv, "")
+	t.Setenv(githubActionsTokenRequestTokenEnv, "")
+
+	_, err := RequestGitHubActionsToken(context.Background(), nil, "runvoy")
+	require.ErrorIs(t, err, ErrNotInGitHubActions)
+}
+
+func TestRequestGitHubActionsToken_Refused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	t.Setenv(githubActionsTokenRequestURLEnv, server.URL)
+	t.Setenv(githubActionsTokenRequestTokenEnv, "request-token")
+
+	_, err := RequestGitHubActionsToken(context.Background(), server.Client(), "runvoy")
+	require.ErrorContains(t, err, "unexpected status 403")
+}
@@ -0,0 +1,259 @@
+// Package oidc verifies OpenID Connect ID tokens issued by an identity provider, such as Okta, Azure AD or GitHub
+// Actions, and obtains them for command line users with the device authorization flow or from GitHub Actions.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken is returned when a token is malformed, badly signed, expired or issued for someone else.
+var ErrInvalidToken = errors.New("invalid identity token")
+
+// clockSkew is the tolerance on the expiry and not-before times of a token.
+const clockSkew = time.Minute
+
+// Claims are the verified claims of an ID token.
+type Claims struct {
+	Issuer        string `json:"iss"`
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	ExpiresAt     int64  `json:"exp"`
+	NotBefore     int64  `json:"nbf,omitempty"`
+	IssuedAt      int64  `json:"iat"`
+
+	// Audience is the "aud" claim, which is either a string or an array of strings.
+	Audience audience `json:"aud"`
+
+	// Raw holds all the claims, including the provider specific ones.
+	Raw map[string]any `json:"-"`
+}
+
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("invalid audience: %w", err)
+	}
+	*a = multiple
+	return nil
+}
+
+// Verifier verifies the ID tokens of an issuer for an audience, checking their RS256 signature against the
+// keys the issuer publishes. The keys are fetched through OpenID Connect discovery and refreshed when a token
+// is signed by an unknown key.
+type Verifier struct {
+	issuer   string
+	audience string
+	client   *http.Client
+	now      func() time.Time
+
+	mu      sync.Mutex
+	jwksURI string
+	keys    map[string]*rsa.PublicKey
+}
+
+// NewVerifier creates a verifier of the tokens issued by the issuer URL for the audience, usually the client ID
+// of the application registered with the identity provider. A nil client uses http.DefaultClient.
+func NewVerifier(issuer, tokenAudience string, client *http.Client) *Verifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Verifier{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		audience: tokenAudience,
+		client:   client,
+		now:      time.Now,
+	}
+}
+
+// Issuer returns the issuer URL of the verified tokens.
+func (v *Verifier) Issuer() string {
+	return v.issuer
+}
+
+// Audience returns the audience of the verified tokens.
+func (v *Verifier) Audience() string {
+	return v.audience
+}
+
+// Verify checks the signature, issuer, audience and validity period of a raw ID token and returns its claims.
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (*Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 { //nolint:mnd // header, payload and signature
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported signing algorithm %q", ErrInvalidToken, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	claims := &Claims{}
+	if err = decodeSegment(parts[1], claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if err = decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if err = v.validate(claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+func (v *Verifier) validate(claims *Claims) error {
+	now := v.now()
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != v.issuer:
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	case !slices.Contains(claims.Audience, v.audience):
+		return fmt.Errorf("token not issued for %q", v.audience)
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)):
+		return errors.New("token expired")
+	case claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)):
+		return errors.New("token not valid yet")
+	}
+	return nil
+}
+
+// key returns the public key with the key ID, fetching the issuer keys again when it isn't known.
+func (v *Verifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	if v.jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := getJSON(ctx, v.client, v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, fmt.Errorf("failed to discover the issuer configuration: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("the issuer configuration has no jwks_uri")
+		}
+		v.jwksURI = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := getJSON(ctx, v.client, v.jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch the issuer keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	return doJSON(client, req, result)
+}
+
+func doJSON(client *http.Client, req *http.Request, result any) error {
+	url := req.URL.Redacted()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, result any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("malformed token segment")
+	}
+	if err = json.Unmarshal(data, result); err != nil {
+		return errors.New("malformed token segment")
+	}
+	return nil
+}
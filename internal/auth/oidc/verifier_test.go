@@ -0,0 +1,154 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer serves the discovery document and the keys of an identity provider signing with key.
+type testIssuer struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	kid        string
+	keyFetches int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &testIssuer{key: key, kid: "key-1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		issuer.keyFetches++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": issuer.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(issuer.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(issuer.key.E)).Bytes()),
+			}},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": i.kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *testIssuer) claims() map[string]any {
+	return map[string]any{
+		"iss":   i.server.URL,
+		"sub":   "00u1",
+		"aud":   "runvoy-cli",
+		"email": "alice@example.com",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewVerifier(issuer.server.URL+"/", "runvoy-cli", issuer.server.Client())
+
+	claims, err := verifier.Verify(context.Background(), issuer.sign(t, issuer.claims()))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.Equal(t, "00u1", claims.Subject)
+	assert.Equal(t, "alice@example.com", claims.Raw["email"])
+
+	_, err = verifier.Verify(context.Background(), issuer.sign(t, issuer.claims()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, issuer.keyFetches, "keys are cached")
+}
+
+func TestVerifier_Verify_Rejects(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewVerifier(issuer.server.URL, "runvoy-cli", issuer.server.Client())
+
+	tests := []struct {
+		name   string
+		modify func(map[string]any)
+	}{
+		{"other audience", func(c map[string]any) { c["aud"] = "other-app" }},
+		{"other issuer", func(c map[string]any) { c["iss"] = "https://evil.example.com" }},
+		{"expired", func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"not valid yet", func(c map[string]any) { c["nbf"] = time.Now().Add(time.Hour).Unix() }},
+		{"no expiry", func(c map[string]any) { delete(c, "exp") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := issuer.claims()
+			tt.modify(claims)
+			_, err := verifier.Verify(context.Background(), issuer.sign(t, claims))
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+
+	t.Run("audience list", func(t *testing.T) {
+		claims := issuer.claims()
+		claims["aud"] = []string{"other-app", "runvoy-cli"}
+		_, err := verifier.Verify(context.Background(), issuer.sign(t, claims))
+		require.NoError(t, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token := issuer.sign(t, issuer.claims())
+		parts := strings.Split(token, ".")
+		forged := issuer.claims()
+		forged["email"] = "mallory@example.com"
+		payload, err := json.Marshal(forged)
+		require.NoError(t, err)
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		_, err = verifier.Verify(context.Background(), strings.Join(parts, "."))
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		other := newTestIssuer(t)
+		other.server = issuer.server
+		other.kid = "key-2"
+		_, err := verifier.Verify(context.Background(), other.sign(t, issuer.claims()))
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), "not-a-token")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningSecret = "0123456789abcdef0123456789abcdef"
+
+func TestSignRequest(t *testing.T) {
+	body := `{"source":"aws.ecs"}`
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/process", strings.NewReader(body))
+	require.NoError(t, err)
+
+	require.NoError(t, SignRequest(req, testSigningSecret))
+
+	assert.NotEmpty(t, req.Header.Get(constants.RequestTimestampHeader))
+	assert.NotEmpty(t, req.Header.Get(constants.RequestSignatureHeader))
+
+	// The body can still be sent
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(sent))
+
+	assert.NoError(t, VerifyRequestSignature(req.Header, sent, testSigningSecret, time.Now()))
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"source":"aws.ecs"}`)
+	signed := func(timestamp int64, secret string, body []byte) http.Header {
+		header := http.Header{}
+		header.Set(constants.RequestTimestampHeader, strconv.FormatInt(timestamp, 10))
+		header.Set(constants.RequestSignatureHeader, SignRequestBody(secret, timestamp, body))
+		return header
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantErr string
+	}{
+		{
+			name:   "accepts a valid signature",
+			header: signed(now.Unix(), testSigningSecret, body),
+		},
+		{
+			name:   "accepts a slightly skewed clock",
+			header: signed(now.Add(time.Minute).Unix(), testSigningSecret, body),
+		},
+		{
+			name:    "rejects unsigned requests",
+			header:  http.Header{},
+			wantErr: "missing or malformed " + constants.RequestTimestampHeader,
+		},
+		{
+			name: "rejects a missing signature",
+			header: http.Header{
+				constants.RequestTimestampHeader: []string{strconv.FormatInt(now.Unix(), 10)},
+			},
+			wantErr: "missing or malformed " + constants.RequestSignatureHeader,
+		},
+		{
+			name:    "rejects another secret",
+			header:  signed(now.Unix(), "another-secret", body),
+			wantErr: "signature mismatch",
+		},
+		{
+			name:    "rejects another body",
+			header:  signed(now.Unix(), testSigningSecret, []byte(`{}`)),
+			wantErr: "signature mismatch",
+		},
+		{
+			name:    "rejects replayed requests",
+			header:  signed(now.Add(-10*time.Minute).Unix(), testSigningSecret, body),
+			wantErr: "request timestamp is more than",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRequestSignature(tt.header, body, testSigningSecret, now)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidRequestSignature)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
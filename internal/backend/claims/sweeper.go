@@ -0,0 +1,102 @@
+// Package claims cleans up the claim tokens of the invited users.
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// Sweeper deletes the expired claim tokens and, optionally, the users who never claimed their API key.
+type Sweeper struct {
+	users                database.UserRepository
+	deleteUnclaimedUsers bool
+	logger               *slog.Logger
+}
+
+// NewSweeper creates a Sweeper, which also deletes the unclaimed users whose claim token expired
+// when deleteUnclaimedUsers is set.
+func NewSweeper(users database.UserRepository, deleteUnclaimedUsers bool, log *slog.Logger) *Sweeper {
+	return &Sweeper{
+		users:                users,
+		deleteUnclaimedUsers: deleteUnclaimedUsers,
+		logger:               log,
+	}
+}
+
+// Sweep deletes the claim tokens expired at now, then the unclaimed users whose claim token expired at now
+// when configured to. It carries on past the failed deletions and returns them joined.
+func (s *Sweeper) Sweep(ctx context.Context, now time.Time) (deletedKeys, deletedUsers int, err error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.logger)
+
+	pendingKeys, err := s.users.ListPendingAPIKeys(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list pending API keys: %w", err)
+	}
+
+	var errs []error
+	// A claimed key marks its user as claimed even if removing the claim expiry of the user failed.
+	claimed := make(map[string]bool)
+	for _, pending := range pendingKeys {
+		if pending.Viewed {
+			claimed[pending.UserEmail] = true
+		}
+		if pending.ExpiresAt > now.Unix() {
+			continue
+		}
+		if deleteErr := s.users.DeletePendingAPIKey(ctx, pending.SecretToken); deleteErr != nil {
+			errs = append(errs, fmt.Errorf("failed to delete pending API key of %s: %w", pending.UserEmail, deleteErr))
+			continue
+		}
+		deletedKeys++
+	}
+
+	if s.deleteUnclaimedUsers {
+		var userErrs []error
+		deletedUsers, userErrs = s.deleteExpiredUsers(ctx, now, claimed, reqLogger)
+		errs = append(errs, userErrs...)
+	}
+
+	return deletedKeys, deletedUsers, errors.Join(errs...)
+}
+
+// deleteExpiredUsers deletes the users whose claim token expired at now and who never used an API key.
+func (s *Sweeper) deleteExpiredUsers(
+	ctx context.Context, now time.Time, claimed map[string]bool, reqLogger *slog.Logger,
+) (int, []error) {
+	users, err := s.users.ListUsers(ctx)
+	if err != nil {
+		return 0, []error{fmt.Errorf("failed to list users: %w", err)}
+	}
+
+	var deleted int
+	var errs []error
+	for _, user := range users {
+		if !isExpiredUnclaimed(user, now) || claimed[user.Email] {
+			continue
+		}
+		if deleteErr := s.users.DeleteUser(ctx, user.Email); deleteErr != nil {
+			errs = append(errs, fmt.Errorf("failed to delete unclaimed user %s: %w", user.Email, deleteErr))
+			continue
+		}
+		deleted++
+		reqLogger.Info("audit: unclaimed user deleted", "context", map[string]any{
+			"user":             user.Email,
+			"role":             user.Role,
+			"claim_expires_at": user.ClaimExpiresAt.Format(time.RFC3339),
+		})
+	}
+	return deleted, errs
+}
+
+// isExpiredUnclaimed reports whether the claim token of user expired at now without the user ever
+// authenticating.
+func isExpiredUnclaimed(user *api.User, now time.Time) bool {
+	return user.ClaimExpiresAt != nil && !user.ClaimExpiresAt.After(now) && user.LastUsed == nil && !user.Revoked
+}
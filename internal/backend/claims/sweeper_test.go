@@ -0,0 +1,84 @@
+package claims
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// newSweptUsers returns users with an unclaimed user whose claim token expired, an unclaimed user whose claim
+// token is still valid, a user who claimed their key and an expired user whose key was claimed without its
+// claim expiry being removed.
+func newSweptUsers(t *testing.T, now time.Time) *fake.UserRepository {
+	t.Helper()
+	ctx := context.Background()
+	users := fake.NewUserRepository()
+	expired := now.Add(-time.Minute)
+	valid := now.Add(time.Minute)
+	for _, user := range []*api.User{
+		{Email: "expired@example.com", Role: "viewer", ClaimExpiresAt: timePtr(expired)},
+		{Email: "invited@example.com", Role: "viewer", ClaimExpiresAt: timePtr(valid)},
+		{Email: "claimed@example.com", Role: "viewer"},
+		{Email: "viewed@example.com", Role: "viewer", ClaimExpiresAt: timePtr(expired)},
+	} {
+		require.NoError(t, users.CreateUser(ctx, user, "hash-"+user.Email, 0))
+	}
+	for _, pending := range []*api.PendingAPIKey{
+		{SecretToken: "expired-token", UserEmail: "expired@example.com", ExpiresAt: expired.Unix()},
+		{SecretToken: "invited-token", UserEmail: "invited@example.com", ExpiresAt: valid.Unix()},
+		{SecretToken: "viewed-token", UserEmail: "viewed@example.com", ExpiresAt: expired.Unix(), Viewed: true},
+	} {
+		require.NoError(t, users.CreatePendingAPIKey(ctx, pending))
+	}
+	return users
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deletes the expired claim tokens only", func(t *testing.T) {
+		users := newSweptUsers(t, now)
+		deletedKeys, deletedUsers, err := NewSweeper(users, false, testutil.SilentLogger()).Sweep(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, deletedKeys)
+		assert.Zero(t, deletedUsers)
+
+		remaining, err := users.ListPendingAPIKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, "invited-token", remaining[0].SecretToken)
+
+		all, err := users.ListUsers(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+	})
+
+	t.Run("deletes the unclaimed users when configured to", func(t *testing.T) {
+		users := newSweptUsers(t, now)
+		deletedKeys, deletedUsers, err := NewSweeper(users, true, testutil.SilentLogger()).Sweep(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, deletedKeys)
+		assert.Equal(t, 1, deletedUsers)
+
+		user, err := users.GetUserByEmail(ctx, "expired@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, user)
+		for _, email := range []string{"invited@example.com", "claimed@example.com", "viewed@example.com"} {
+			user, err = users.GetUserByEmail(ctx, email)
+			require.NoError(t, err)
+			assert.NotNil(t, user, email)
+		}
+	})
+}
@@ -24,8 +24,27 @@ type TaskManager interface {
 		userEmail string,
 		req *api.ExecutionRequest) (executionID string, createdAt *time.Time, err error)
 	// KillTask terminates a running task identified by executionID.
+	// The command receives SIGTERM and is killed once the stop grace period requested
+	// at start time has elapsed (immediately if none was requested).
 	// Returns an error if the task is already terminated or cannot be terminated.
 	KillTask(ctx context.Context, executionID string) error
+	// CreateStdinUpload returns a short-lived URL where exactly size bytes of standard input for an
+	// upcoming execution can be uploaded with an HTTP PUT, and the time the URL expires.
+	// The upload is then referenced by uploadID in ExecutionRequest.StdinUploadID.
+	CreateStdinUpload(
+		ctx context.Context,
+		uploadID string,
+		size int64,
+	) (uploadURL string, expiresAt time.Time, err error)
+	// CreateContextUpload returns a short-lived URL where a gzip-compressed tar archive of exactly
+	// size bytes, extracted as the working directory of an upcoming execution, can be uploaded with
+	// an HTTP PUT, and the time the URL expires.
+	// The upload is then referenced by uploadID in ExecutionRequest.ContextUploadID.
+	CreateContextUpload(
+		ctx context.Context,
+		uploadID string,
+		size int64,
+	) (uploadURL string, expiresAt time.Time, err error)
 }
 
 // ImageRegistry abstracts provider-specific image management.
@@ -38,6 +57,9 @@ type ImageRegistry interface {
 	// cpu: optional CPU value (e.g., 256, 1024). Defaults to 256 if nil.
 	// memory: optional Memory value in MB (e.g., 512, 2048). Defaults to 512 if nil.
 	// runtimePlatform: optional runtime platform (e.g., "Linux/ARM64", "Linux/X86_64"). Defaults to "Linux/ARM64" if nil.
+	// logLimits: optional log limits of executions using the image. Backend defaults apply if nil.
+	// warmPoolSize: optional number of idle pre-provisioned slots kept for the image, 0 disables them.
+	// runDefaults: optional working directory and shell of the executions using the image.
 	// createdBy: email of the user registering the image.
 	RegisterImage(
 		ctx context.Context,
@@ -46,6 +68,9 @@ type ImageRegistry interface {
 		taskRoleName, taskExecutionRoleName *string,
 		cpu, memory *int,
 		runtimePlatform *string,
+		logLimits *api.LogLimits,
+		warmPoolSize *int,
+		runDefaults *api.RunDefaults,
 		createdBy string,
 	) error
 	// ListImages lists all registered Docker images.
@@ -55,8 +80,28 @@ type ImageRegistry interface {
 	// Accepts either an ImageID (e.g., "alpine:latest-a1b2c3d4") or an image name (e.g., "alpine:latest").
 	GetImage(ctx context.Context, image string) (*api.ImageInfo, error)
 
-	// RemoveImage removes a Docker image and deregisters its task definitions.
+	// RemoveImage permanently removes a Docker image and deregisters its task definitions.
 	RemoveImage(ctx context.Context, image string) error
+
+	// SoftDeleteImage marks a Docker image as deleted by deletedBy. New runs of the image are refused,
+	// but it can be restored until it is purged after constants.ImageDeletionRetention.
+	SoftDeleteImage(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error)
+
+	// RestoreImage restores a Docker image deleted with SoftDeleteImage.
+	RestoreImage(ctx context.Context, image string) (*api.ImageInfo, error)
+
+	// SetImageAlias points alias.Alias at alias.ImageID in a single write, creating the alias if needed.
+	// Returns the image ID the alias pointed at before, empty when the alias was created.
+	SetImageAlias(ctx context.Context, alias *api.ImageAlias) (string, error)
+
+	// GetImageAlias retrieves an image alias. Returns nil if the alias doesn't exist.
+	GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error)
+
+	// ListImageAliases lists all image aliases, sorted by alias.
+	ListImageAliases(ctx context.Context) ([]api.ImageAlias, error)
+
+	// RemoveImageAlias removes an image alias, leaving the image it points at registered.
+	RemoveImageAlias(ctx context.Context, alias string) error
 }
 
 // LogManager abstracts provider-specific execution log retrieval.
@@ -74,6 +119,43 @@ type ObservabilityManager interface {
 	// FetchBackendLogs retrieves logs from the backend services for the provided requestID.
 	// Returns logs from the backend services for debugging and tracing.
 	FetchBackendLogs(ctx context.Context, requestID string) ([]api.LogEvent, error)
+
+	// FetchResourceUsage retrieves the latest resource usage sample of each of the running executions.
+	// Executions without a sample yet are missing from the returned map.
+	FetchResourceUsage(ctx context.Context, executionIDs []string) (map[string]*api.ResourceUsage, error)
+}
+
+// MetricUnit is the unit of a metric value.
+type MetricUnit string
+
+const (
+	// MetricUnitCount is the unit of counters.
+	MetricUnitCount MetricUnit = "Count"
+	// MetricUnitMilliseconds is the unit of latencies and lags.
+	MetricUnitMilliseconds MetricUnit = "Milliseconds"
+)
+
+// Metric is a single data point of an operational metric.
+type Metric struct {
+	Name       string
+	Value      float64
+	Unit       MetricUnit
+	Dimensions map[string]string
+}
+
+// MetricsRecorder is the metrics extension of ObservabilityManager.
+// Backend services record operational metrics through it and each provider publishes them
+// to its monitoring service (CloudWatch embedded metric format on AWS).
+type MetricsRecorder interface {
+	// RecordMetrics publishes the metrics. Recording is best-effort: failures are logged
+	// by the implementation and never reported to the caller.
+	RecordMetrics(ctx context.Context, metrics ...Metric)
+}
+
+// EmailSender sends the emails of the backend, such as the weekly usage reports.
+type EmailSender interface {
+	// SendEmail sends a plain text email to the recipients.
+	SendEmail(ctx context.Context, to []string, subject, body string) error
 }
 
 // WebSocketManager abstracts provider-specific WebSocket management.
@@ -87,6 +169,9 @@ type WebSocketManager interface {
 	// and removes the connections.
 	NotifyExecutionCompletion(ctx context.Context, executionID *string) error
 
+	// NotifyExecutionStatus sends a status change message to all connected clients for an execution.
+	NotifyExecutionStatus(ctx context.Context, change *api.ExecutionStatusChange) error
+
 	// SendLogsToExecution flushes buffered log events to all connected clients for an execution.
 	SendLogsToExecution(ctx context.Context, executionID *string) error
 
@@ -108,4 +193,8 @@ type HealthManager interface {
 	// It verifies compute resources (e.g., task definitions, containers), secrets, and identity/access resources.
 	// Returns a comprehensive health report with all issues found and actions taken.
 	Reconcile(ctx context.Context) (*api.HealthReport, error)
+	// Cleanup deletes the cloud resources created by runvoy that are no longer referenced
+	// (e.g., deregistered task definitions, log streams whose events all expired).
+	// With dryRun, the resources are only reported.
+	Cleanup(ctx context.Context, dryRun bool) (*api.CleanupReport, error)
 }
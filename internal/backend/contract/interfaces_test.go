@@ -46,6 +46,9 @@ func TestImageRegistry_Interface(t *testing.T) {
 		nil, nil,
 		&cpu, &memory,
 		&platform,
+		nil,
+		nil,
+		nil,
 		"user@example.com",
 	)
 	assert.NoError(t, err)
@@ -60,6 +63,12 @@ func TestImageRegistry_Interface(t *testing.T) {
 
 	err = registry.RemoveImage(context.Background(), "alpine:latest")
 	assert.NoError(t, err)
+
+	_, err = registry.SoftDeleteImage(context.Background(), "alpine:latest", "user@example.com")
+	assert.NoError(t, err)
+
+	_, err = registry.RestoreImage(context.Background(), "alpine:latest")
+	assert.NoError(t, err)
 }
 
 // TestLogManager_Interface verifies that the LogManager interface is properly defined.
@@ -126,6 +135,14 @@ func (t *testTaskManager) KillTask(_ context.Context, _ string) error {
 	return nil
 }
 
+func (t *testTaskManager) CreateStdinUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func (t *testTaskManager) CreateContextUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
 type testImageRegistry struct{}
 
 func (t *testImageRegistry) RegisterImage(
@@ -135,6 +152,9 @@ func (t *testImageRegistry) RegisterImage(
 	_, _ *string,
 	_, _ *int,
 	_ *string,
+	_ *api.LogLimits,
+	_ *int,
+	_ *api.RunDefaults,
 	_ string,
 ) error {
 	return nil
@@ -152,6 +172,30 @@ func (t *testImageRegistry) RemoveImage(_ context.Context, _ string) error {
 	return nil
 }
 
+func (t *testImageRegistry) SoftDeleteImage(_ context.Context, _, _ string) (*api.ImageInfo, error) {
+	return &api.ImageInfo{}, nil
+}
+
+func (t *testImageRegistry) RestoreImage(_ context.Context, _ string) (*api.ImageInfo, error) {
+	return &api.ImageInfo{}, nil
+}
+
+func (t *testImageRegistry) SetImageAlias(_ context.Context, _ *api.ImageAlias) (string, error) {
+	return "", nil
+}
+
+func (t *testImageRegistry) GetImageAlias(_ context.Context, _ string) (*api.ImageAlias, error) {
+	return nil, nil
+}
+
+func (t *testImageRegistry) ListImageAliases(_ context.Context) ([]api.ImageAlias, error) {
+	return []api.ImageAlias{}, nil
+}
+
+func (t *testImageRegistry) RemoveImageAlias(_ context.Context, _ string) error {
+	return nil
+}
+
 type testLogManager struct{}
 
 func (t *testLogManager) FetchLogsByExecutionID(_ context.Context, _ string) ([]api.LogEvent, error) {
@@ -164,6 +208,13 @@ func (t *testObservabilityManager) FetchBackendLogs(_ context.Context, _ string)
 	return []api.LogEvent{}, nil
 }
 
+func (t *testObservabilityManager) FetchResourceUsage(
+	_ context.Context,
+	_ []string,
+) (map[string]*api.ResourceUsage, error) {
+	return map[string]*api.ResourceUsage{}, nil
+}
+
 type testWebSocketManager struct{}
 
 func (t *testWebSocketManager) HandleRequest(_ context.Context, _ *json.RawMessage, _ *slog.Logger) (bool, error) {
@@ -174,6 +225,10 @@ func (t *testWebSocketManager) NotifyExecutionCompletion(_ context.Context, _ *s
 	return nil
 }
 
+func (t *testWebSocketManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (t *testWebSocketManager) SendLogsToExecution(_ context.Context, _ *string) error {
 	return nil
 }
@@ -192,3 +247,7 @@ type testHealthManager struct{}
 func (t *testHealthManager) Reconcile(_ context.Context) (*api.HealthReport, error) {
 	return &api.HealthReport{}, nil
 }
+
+func (t *testHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
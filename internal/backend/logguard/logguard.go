@@ -0,0 +1,108 @@
+// Package logguard caps the log output ingested for executions, dropping the lines that exceed
+// their rate or size limits and leaving truncation markers in their place.
+package logguard
+
+import (
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// EffectiveLimits returns the log limits of an execution, falling back to the backend defaults
+// for the limits left unset.
+func EffectiveLimits(limits *api.LogLimits) api.LogLimits {
+	effective := api.LogLimits{
+		MaxLinesPerSecond: constants.DefaultLogMaxLinesPerSecond,
+		MaxBytes:          constants.DefaultLogMaxBytes,
+	}
+	if limits == nil {
+		return effective
+	}
+	if limits.MaxLinesPerSecond > 0 {
+		effective.MaxLinesPerSecond = limits.MaxLinesPerSecond
+	}
+	if limits.MaxBytes > 0 {
+		effective.MaxBytes = limits.MaxBytes
+	}
+	return effective
+}
+
+// Apply applies the log limits of an execution to a batch of its log events, ordered by timestamp.
+// usage is the log output already ingested for the execution. Lines exceeding the per-second rate or
+// the total size are dropped, and a truncation marker event is added in their place so readers know
+// output is missing. It returns the events to store and the usage they add.
+//
+// The rate is counted per second of log timestamps within the batch: a second split across two
+// deliveries may keep up to twice the limit.
+func Apply(limits *api.LogLimits, usage *api.LogUsage, events []api.LogEvent) ([]api.LogEvent, api.LogUsage) {
+	effective := EffectiveLimits(limits)
+
+	var ingested int64
+	if usage != nil {
+		ingested = usage.Bytes
+	}
+	sizeLimitReached := ingested >= effective.MaxBytes
+
+	kept := make([]api.LogEvent, 0, len(events))
+	var added api.LogUsage
+	linesPerSecond := make(map[int64]int)
+	var rateDropped int64
+	rateDroppedSecond := int64(-1)
+	var lastRateDropped api.LogEvent
+
+	flushRateMarker := func() {
+		if rateDropped == 0 {
+			return
+		}
+		kept = append(kept, truncationMarker(lastRateDropped.Timestamp, fmt.Sprintf(
+			"%d log lines dropped: more than %d lines per second", rateDropped, effective.MaxLinesPerSecond)))
+		rateDropped = 0
+	}
+
+	for _, event := range events {
+		size := int64(len(event.Message))
+		second := event.Timestamp / constants.MillisecondsPerSecond
+		if second != rateDroppedSecond {
+			flushRateMarker()
+		}
+
+		if sizeLimitReached || ingested+added.Bytes+size > effective.MaxBytes {
+			if !sizeLimitReached {
+				sizeLimitReached = true
+				kept = append(kept, truncationMarker(event.Timestamp, fmt.Sprintf(
+					"log output truncated: more than %d bytes, further lines are dropped", effective.MaxBytes)))
+			}
+			added.DroppedLines++
+			added.DroppedBytes += size
+			continue
+		}
+
+		linesPerSecond[second]++
+		if linesPerSecond[second] > effective.MaxLinesPerSecond {
+			rateDropped++
+			rateDroppedSecond = second
+			lastRateDropped = event
+			added.DroppedLines++
+			added.DroppedBytes += size
+			continue
+		}
+
+		kept = append(kept, event)
+		added.Bytes += size
+	}
+	flushRateMarker()
+
+	return kept, added
+}
+
+// truncationMarker builds the log event standing in for dropped log lines.
+func truncationMarker(timestamp int64, reason string) api.LogEvent {
+	message := fmt.Sprintf("[%s] %s", constants.ProjectName, reason)
+	return api.LogEvent{
+		EventID:   auth.GenerateEventID(timestamp, message),
+		Timestamp: timestamp,
+		Message:   message,
+	}
+}
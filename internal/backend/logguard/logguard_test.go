@@ -0,0 +1,83 @@
+package logguard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveLimits(t *testing.T) {
+	assert.Equal(t, api.LogLimits{
+		MaxLinesPerSecond: constants.DefaultLogMaxLinesPerSecond,
+		MaxBytes:          constants.DefaultLogMaxBytes,
+	}, EffectiveLimits(nil))
+
+	assert.Equal(t, api.LogLimits{
+		MaxLinesPerSecond: 10,
+		MaxBytes:          constants.DefaultLogMaxBytes,
+	}, EffectiveLimits(&api.LogLimits{MaxLinesPerSecond: 10}))
+}
+
+func TestApply_WithinLimits(t *testing.T) {
+	events := []api.LogEvent{
+		{EventID: "1", Timestamp: 1000, Message: "hello"},
+		{EventID: "2", Timestamp: 1500, Message: "world"},
+	}
+
+	kept, added := Apply(nil, nil, events)
+
+	assert.Equal(t, events, kept)
+	assert.Equal(t, api.LogUsage{Bytes: 10}, added)
+}
+
+func TestApply_RateLimit(t *testing.T) {
+	events := []api.LogEvent{
+		{EventID: "1", Timestamp: 1000, Message: "a"},
+		{EventID: "2", Timestamp: 1100, Message: "b"},
+		{EventID: "3", Timestamp: 1200, Message: "c"},
+		{EventID: "4", Timestamp: 1300, Message: "d"},
+		{EventID: "5", Timestamp: 2000, Message: "e"},
+	}
+
+	kept, added := Apply(&api.LogLimits{MaxLinesPerSecond: 2}, nil, events)
+
+	require.Len(t, kept, 4)
+	assert.Equal(t, "1", kept[0].EventID)
+	assert.Equal(t, "2", kept[1].EventID)
+	assert.Equal(t, "[runvoy] 2 log lines dropped: more than 2 lines per second", kept[2].Message)
+	assert.Equal(t, int64(1300), kept[2].Timestamp)
+	assert.NotEmpty(t, kept[2].EventID)
+	assert.Equal(t, "5", kept[3].EventID)
+	assert.Equal(t, api.LogUsage{Bytes: 3, DroppedLines: 2, DroppedBytes: 2}, added)
+}
+
+func TestApply_SizeLimit(t *testing.T) {
+	line := strings.Repeat("x", 10)
+	events := []api.LogEvent{
+		{EventID: "1", Timestamp: 1000, Message: line},
+		{EventID: "2", Timestamp: 2000, Message: line},
+		{EventID: "3", Timestamp: 3000, Message: line},
+	}
+
+	kept, added := Apply(&api.LogLimits{MaxBytes: 25}, &api.LogUsage{Bytes: 5}, events)
+
+	require.Len(t, kept, 3)
+	assert.Equal(t, "1", kept[0].EventID)
+	assert.Equal(t, "2", kept[1].EventID)
+	assert.Equal(t, "[runvoy] log output truncated: more than 25 bytes, further lines are dropped", kept[2].Message)
+	assert.Equal(t, api.LogUsage{Bytes: 20, DroppedLines: 1, DroppedBytes: 10}, added)
+}
+
+func TestApply_SizeLimitAlreadyReached(t *testing.T) {
+	events := []api.LogEvent{{EventID: "1", Timestamp: 1000, Message: "late"}}
+
+	kept, added := Apply(&api.LogLimits{MaxBytes: 10}, &api.LogUsage{Bytes: 10, DroppedLines: 3}, events)
+
+	assert.Empty(t, kept)
+	assert.Equal(t, api.LogUsage{DroppedLines: 1, DroppedBytes: 4}, added)
+}
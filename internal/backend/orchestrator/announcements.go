@@ -0,0 +1,136 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// announcementCache keeps the announcement sent with every response in memory for constants.AnnouncementCacheTTL.
+// The repository is read outside of the lock so that the requests are not serialized behind it.
+type announcementCache struct {
+	mu         sync.Mutex
+	message    string
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// GetAnnouncement returns the current announcement, nil when there is none.
+func (s *Service) GetAnnouncement(ctx context.Context) (*api.Announcement, error) {
+	if s.repos.Config == nil {
+		return nil, nil
+	}
+	announcement, err := s.repos.Config.GetAnnouncement(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil || announcement.Message == "" {
+		return nil, nil
+	}
+	return announcement, nil
+}
+
+// SetAnnouncement sets the announcement shown to the CLI users, replacing the current one.
+// The message is a single line of at most constants.MaxAnnouncementLength characters, as it is sent in a header.
+func (s *Service) SetAnnouncement(ctx context.Context, message, userEmail string) (*api.Announcement, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, apperrors.ErrBadRequest("announcement message is required", nil)
+	}
+	if len(message) > constants.MaxAnnouncementLength {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("announcement message exceeds %d characters", constants.MaxAnnouncementLength), nil)
+	}
+	if strings.IndexFunc(message, unicode.IsControl) >= 0 {
+		return nil, apperrors.ErrBadRequest("announcement message must be a single line of text", nil)
+	}
+	return s.putAnnouncement(ctx, message, userEmail)
+}
+
+// ClearAnnouncement removes the announcement.
+func (s *Service) ClearAnnouncement(ctx context.Context, userEmail string) error {
+	_, err := s.putAnnouncement(ctx, "", userEmail)
+	return err
+}
+
+func (s *Service) putAnnouncement(ctx context.Context, message, userEmail string) (*api.Announcement, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("announcements are not configured", nil)
+	}
+
+	now := time.Now().UTC()
+	announcement := &api.Announcement{
+		Message:   message,
+		UpdatedBy: userEmail,
+		UpdatedAt: &now,
+	}
+	if err := s.repos.Config.PutAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	s.announcements.mu.Lock()
+	s.announcements.message = message
+	s.announcements.fetchedAt = now
+	s.announcements.mu.Unlock()
+
+	auditMessage := "audit: announcement cleared"
+	if message != "" {
+		auditMessage = "audit: announcement set"
+	}
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info(auditMessage, "context", map[string]string{
+		"user":    userEmail,
+		"message": message,
+	})
+	return announcement, nil
+}
+
+// CurrentAnnouncement returns the message of the current announcement, empty when there is none.
+// It is read at most once per constants.AnnouncementCacheTTL, by a single request while the others keep sending
+// the previous message. Failing to read it keeps the previous message, as the announcement must not fail the
+// request it is sent with.
+func (s *Service) CurrentAnnouncement(ctx context.Context) string {
+	if s.repos.Config == nil {
+		return ""
+	}
+
+	s.announcements.mu.Lock()
+	message := s.announcements.message
+	fetchedAt := s.announcements.fetchedAt
+	if s.announcements.refreshing ||
+		(!fetchedAt.IsZero() && time.Since(fetchedAt) < constants.AnnouncementCacheTTL) {
+		s.announcements.mu.Unlock()
+		return message
+	}
+	s.announcements.refreshing = true
+	s.announcements.mu.Unlock()
+
+	announcement, err := s.repos.Config.GetAnnouncement(ctx)
+
+	s.announcements.mu.Lock()
+	defer s.announcements.mu.Unlock()
+	s.announcements.refreshing = false
+	if s.announcements.fetchedAt.After(fetchedAt) {
+		// The announcement was set while it was being read, the read one may be outdated.
+		return s.announcements.message
+	}
+	s.announcements.fetchedAt = time.Now()
+	if err != nil {
+		reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+		reqLogger.Warn("failed to read the announcement", "error", err)
+		return s.announcements.message
+	}
+	s.announcements.message = ""
+	if announcement != nil {
+		s.announcements.message = announcement.Message
+	}
+	return s.announcements.message
+}
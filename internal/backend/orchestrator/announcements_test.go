@@ -0,0 +1,137 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingAnnouncementRepository fails to read the announcement.
+type failingAnnouncementRepository struct {
+	*fake.ConfigRepository
+}
+
+func (failingAnnouncementRepository) GetAnnouncement(context.Context) (*api.Announcement, error) {
+	return nil, apperrors.ErrDatabaseError("throughput exceeded", errors.New("boom"))
+}
+
+// blockingAnnouncementRepository blocks reading the announcement until released.
+type blockingAnnouncementRepository struct {
+	*fake.ConfigRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r blockingAnnouncementRepository) GetAnnouncement(ctx context.Context) (*api.Announcement, error) {
+	close(r.started)
+	<-r.release
+	return r.ConfigRepository.GetAnnouncement(ctx)
+}
+
+func TestAnnouncements(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+
+	announcement, err := svc.GetAnnouncement(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, announcement)
+	assert.Empty(t, svc.CurrentAnnouncement(ctx))
+
+	announcement, err = svc.SetAnnouncement(ctx, " Outage of the eu-west-1 runners until 3pm ", "admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Outage of the eu-west-1 runners until 3pm", announcement.Message)
+	assert.Equal(t, "admin@example.com", announcement.UpdatedBy)
+	assert.Equal(t, "Outage of the eu-west-1 runners until 3pm", svc.CurrentAnnouncement(ctx),
+		"setting the announcement refreshes the cache")
+
+	require.NoError(t, svc.ClearAnnouncement(ctx, "admin@example.com"))
+	announcement, err = svc.GetAnnouncement(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, announcement, "a cleared announcement is not returned")
+	assert.Empty(t, svc.CurrentAnnouncement(ctx))
+}
+
+func TestSetAnnouncement_Validation(t *testing.T) {
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+
+	for name, message := range map[string]string{
+		"empty":     " ",
+		"too long":  strings.Repeat("a", constants.MaxAnnouncementLength+1),
+		"multiline": "first line\nsecond line",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.SetAnnouncement(context.Background(), message, "admin@example.com")
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestCurrentAnnouncement_Cache(t *testing.T) {
+	ctx := context.Background()
+	repo := fake.NewConfigRepository()
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = repo
+
+	assert.Empty(t, svc.CurrentAnnouncement(ctx))
+
+	// Set by another instance of the orchestrator: served once the cached value expires.
+	require.NoError(t, repo.PutAnnouncement(ctx, &api.Announcement{Message: "Deprecation of the v1 routes"}))
+	assert.Empty(t, svc.CurrentAnnouncement(ctx))
+
+	svc.announcements.fetchedAt = time.Now().Add(-constants.AnnouncementCacheTTL)
+	assert.Equal(t, "Deprecation of the v1 routes", svc.CurrentAnnouncement(ctx))
+
+	svc.repos.Config = failingAnnouncementRepository{repo}
+	svc.announcements.fetchedAt = time.Now().Add(-constants.AnnouncementCacheTTL)
+	assert.Equal(t, "Deprecation of the v1 routes", svc.CurrentAnnouncement(ctx),
+		"a failed read keeps the previous announcement")
+}
+
+func TestCurrentAnnouncement_DoesNotWaitForRefresh(t *testing.T) {
+	ctx := context.Background()
+	repo := fake.NewConfigRepository()
+	require.NoError(t, repo.PutAnnouncement(ctx, &api.Announcement{Message: "Maintenance tonight"}))
+	blocking := blockingAnnouncementRepository{
+		ConfigRepository: repo,
+		started:          make(chan struct{}),
+		release:          make(chan struct{}),
+	}
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = blocking
+	svc.announcements.message = "Previous announcement"
+
+	refreshed := make(chan string)
+	go func() { refreshed <- svc.CurrentAnnouncement(ctx) }()
+	<-blocking.started
+
+	assert.Equal(t, "Previous announcement", svc.CurrentAnnouncement(ctx),
+		"requests keep the previous announcement while another one reads it")
+
+	close(blocking.release)
+	assert.Equal(t, "Maintenance tonight", <-refreshed)
+	assert.Equal(t, "Maintenance tonight", svc.CurrentAnnouncement(ctx))
+}
+
+func TestAnnouncements_NotConfigured(t *testing.T) {
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+
+	announcement, err := svc.GetAnnouncement(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, announcement)
+	assert.Empty(t, svc.CurrentAnnouncement(context.Background()))
+
+	_, err = svc.SetAnnouncement(context.Background(), "maintenance", "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+}
@@ -0,0 +1,265 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/backend/usage"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+const (
+	// budgetMonthLayout formats the month budgets are reset at, in UTC.
+	budgetMonthLayout = "2006-01"
+	// budgetsOverrideObject is the object the override permission of the hard caps is checked on.
+	budgetsOverrideObject = "/api/v1/budgets"
+)
+
+// budgetThresholds are the percentages of the limit notified once per month, in increasing order.
+var budgetThresholds = []int{80, 100}
+
+// ListBudgets returns the budgets of all the users with their spend in the current month, sorted by email.
+func (s *Service) ListBudgets(ctx context.Context) ([]api.BudgetStatus, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("budgets are not configured", nil)
+	}
+	budgets, err := s.repos.Config.GetBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	statuses := make([]api.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, statusErr := s.budgetStatus(ctx, budget, now)
+		if statusErr != nil {
+			return nil, statusErr
+		}
+		statuses = append(statuses, *status)
+	}
+	slices.SortFunc(statuses, func(a, b api.BudgetStatus) int {
+		return strings.Compare(a.UserEmail, b.UserEmail)
+	})
+	return statuses, nil
+}
+
+// SetBudget sets the monthly budget of the user, replacing the previous one. The thresholds already notified
+// this month are kept, so changing the limit doesn't notify them again.
+func (s *Service) SetBudget(
+	ctx context.Context, email string, req api.BudgetRequest, adminEmail string,
+) (*api.BudgetStatus, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("budgets are not configured", nil)
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if req.MonthlyLimitUSD <= 0 {
+		return nil, apperrors.ErrBadRequest("monthly limit must be greater than 0", nil)
+	}
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound(fmt.Sprintf("user %s not found", email), nil)
+	}
+
+	budgets, err := s.repos.Config.GetBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	budget := budgets[email]
+	budget.UserEmail = email
+	budget.MonthlyLimitUSD = req.MonthlyLimitUSD
+	budget.HardCap = req.HardCap
+	budget.UpdatedBy = adminEmail
+	budget.UpdatedAt = &now
+	budgets[email] = budget
+	if err = s.repos.Config.PutBudgets(ctx, budgets); err != nil {
+		return nil, err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("audit: budget set", "context", map[string]any{
+		"user":              email,
+		"admin":             adminEmail,
+		"monthly_limit_usd": req.MonthlyLimitUSD,
+		"hard_cap":          req.HardCap,
+	})
+	return s.budgetStatus(ctx, budget, now)
+}
+
+// RemoveBudget removes the monthly budget of the user.
+func (s *Service) RemoveBudget(ctx context.Context, email, adminEmail string) error {
+	if s.repos.Config == nil {
+		return apperrors.ErrServiceUnavailable("budgets are not configured", nil)
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	budgets, err := s.repos.Config.GetBudgets(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := budgets[email]; !ok {
+		return apperrors.ErrNotFound(fmt.Sprintf("user %s has no budget", email), nil)
+	}
+	delete(budgets, email)
+	if err = s.repos.Config.PutBudgets(ctx, budgets); err != nil {
+		return err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("audit: budget removed", "context", map[string]string{
+		"user":  email,
+		"admin": adminEmail,
+	})
+	return nil
+}
+
+// checkBudget compares the spend of the user this month with their budget, if they have one. It notifies the
+// thresholds reached for the first time this month and returns a warning for the user from 80% of the limit.
+// Once the limit is reached, a hard cap refuses the execution unless the user may override it.
+func (s *Service) checkBudget(ctx context.Context, userEmail string) (string, error) {
+	if s.repos.Config == nil {
+		return "", nil
+	}
+	budgets, err := s.repos.Config.GetBudgets(ctx)
+	if err != nil {
+		return "", err
+	}
+	budget, ok := budgets[userEmail]
+	if !ok {
+		return "", nil
+	}
+
+	now := time.Now().UTC()
+	status, err := s.budgetStatus(ctx, budget, now)
+	if err != nil {
+		return "", err
+	}
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	s.notifyBudgetThreshold(ctx, status, reqLogger)
+
+	spend := fmt.Sprintf("$%.2f of $%.2f", status.SpentUSD, status.MonthlyLimitUSD)
+	switch {
+	case status.PercentUsed < float64(budgetThresholds[0]):
+		return "", nil
+	case status.PercentUsed < 100:
+		return fmt.Sprintf("you have used %.0f%% of your monthly budget (%s)", status.PercentUsed, spend), nil
+	case !status.HardCap:
+		return fmt.Sprintf("you have exceeded your monthly budget (%s)", spend), nil
+	}
+
+	allowed, err := s.GetEnforcer().Enforce(ctx, userEmail, budgetsOverrideObject, authorization.ActionOverride)
+	if err != nil {
+		return "", apperrors.ErrInternalError(
+			"failed to validate budget override",
+			fmt.Errorf("enforcement error: %w", err),
+		)
+	}
+	if !allowed {
+		return "", apperrors.ErrBudgetExceeded(fmt.Sprintf(
+			"monthly budget exceeded (%s), ask an admin to raise it", spend), nil)
+	}
+	reqLogger.Info("audit: budget cap overridden", "context", map[string]any{
+		"user":              userEmail,
+		"spent_usd":         status.SpentUSD,
+		"monthly_limit_usd": status.MonthlyLimitUSD,
+	})
+	return fmt.Sprintf("you have exceeded your monthly budget (%s), running with your override permission",
+		spend), nil
+}
+
+// notifyBudgetThreshold logs the highest threshold reached by the user if it was not notified yet this month,
+// and counts it in the metric the budget alarm notifies the alarm topic from. Notifying is best-effort.
+func (s *Service) notifyBudgetThreshold(ctx context.Context, status *api.BudgetStatus, reqLogger *slog.Logger) {
+	reached := 0
+	for _, threshold := range budgetThresholds {
+		if status.PercentUsed >= float64(threshold) {
+			reached = threshold
+		}
+	}
+	notified := 0
+	if status.NotifiedMonth == status.Month {
+		notified = status.NotifiedThreshold
+	}
+	if reached <= notified {
+		return
+	}
+
+	reqLogger.Warn("audit: budget threshold reached", "context", map[string]any{
+		"user":              status.UserEmail,
+		"threshold_percent": reached,
+		"spent_usd":         status.SpentUSD,
+		"monthly_limit_usd": status.MonthlyLimitUSD,
+		"hard_cap":          status.HardCap,
+	})
+	if s.Metrics != nil {
+		s.Metrics.RecordMetrics(ctx, contract.Metric{
+			Name:  constants.MetricBudgetThresholdsReached,
+			Value: 1,
+			Unit:  contract.MetricUnitCount,
+		})
+	}
+
+	// Read again to keep the changes made since the budgets were read
+	budgets, err := s.repos.Config.GetBudgets(ctx)
+	if err != nil {
+		reqLogger.Error("failed to record the notified budget threshold", "error", err)
+		return
+	}
+	budget, ok := budgets[status.UserEmail]
+	if !ok {
+		return
+	}
+	budget.NotifiedThreshold = reached
+	budget.NotifiedMonth = status.Month
+	budgets[status.UserEmail] = budget
+	if err = s.repos.Config.PutBudgets(ctx, budgets); err != nil {
+		reqLogger.Error("failed to record the notified budget threshold", "error", err)
+	}
+}
+
+// budgetStatus returns the budget with the spend of its user in the month of now.
+func (s *Service) budgetStatus(ctx context.Context, budget api.Budget, now time.Time) (*api.BudgetStatus, error) {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	spent, err := s.monthlySpend(ctx, budget.UserEmail, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	return &api.BudgetStatus{
+		Budget:      budget,
+		Month:       monthStart.Format(budgetMonthLayout),
+		SpentUSD:    spent,
+		PercentUsed: spent / budget.MonthlyLimitUSD * 100,
+	}, nil
+}
+
+// monthlySpend returns the estimated cost of the executions the user started since monthStart, see
+// usage.ExecutionCostUSD.
+func (s *Service) monthlySpend(ctx context.Context, email string, monthStart time.Time) (float64, error) {
+	executions, err := s.repos.Execution.ListExecutionsByCreator(ctx, email, 0, nil)
+	if err != nil {
+		return 0, apperrors.ErrDatabaseError("failed to list executions", err)
+	}
+
+	spent := 0.0
+	for _, execution := range executions {
+		// Newest first, the rest started before the month
+		if execution.StartedAt.Before(monthStart) {
+			break
+		}
+		spent += usage.ExecutionCostUSD(execution.ResourceSummary)
+	}
+	return spent, nil
+}
@@ -0,0 +1,192 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/usage"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vCPUHourLimit is a monthly limit each execution reserving one vCPU for an hour spends 10% of.
+const vCPUHourLimit = usage.FargateVCPUHourPriceUSD * 10
+
+// spendingExecutions returns count executions started at startedAt, each reserving one vCPU for an hour.
+func spendingExecutions(count int, startedAt time.Time) []*api.Execution {
+	executions := make([]*api.Execution, count)
+	for i := range executions {
+		executions[i] = &api.Execution{
+			StartedAt: startedAt,
+			ResourceSummary: &api.ResourceSummary{
+				CPUReserved:           1024,
+				BilledDurationSeconds: 3600,
+			},
+		}
+	}
+	return executions
+}
+
+func newBudgetTestService(executions *[]*api.Execution) *Service {
+	execRepo := &mockExecutionRepository{
+		listByCreatorFunc: func(_ context.Context, _ string, limit int, _ []string) ([]*api.Execution, error) {
+			if limit != 0 {
+				return nil, assert.AnError
+			}
+			return *executions, nil
+		},
+	}
+	userRepo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, email string) (*api.User, error) {
+			if email == "unknown@example.com" {
+				return nil, nil
+			}
+			return &api.User{Email: email}, nil
+		},
+	}
+	svc := newTestService(userRepo, execRepo, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+	return svc
+}
+
+func TestBudgets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	executions := append(
+		spendingExecutions(3, now),
+		spendingExecutions(5, now.AddDate(0, -1, -now.Day()))...,
+	)
+	svc := newBudgetTestService(&executions)
+
+	_, err := svc.SetBudget(ctx, "unknown@example.com", api.BudgetRequest{MonthlyLimitUSD: 10}, "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+
+	_, err = svc.SetBudget(ctx, "user@example.com", api.BudgetRequest{}, "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+
+	status, err := svc.SetBudget(ctx, " User@Example.com ",
+		api.BudgetRequest{MonthlyLimitUSD: vCPUHourLimit, HardCap: true}, "admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", status.UserEmail)
+	assert.True(t, status.HardCap)
+	assert.Equal(t, "admin@example.com", status.UpdatedBy)
+	assert.Equal(t, now.Format("2006-01"), status.Month)
+	assert.InDelta(t, 30, status.PercentUsed, 1e-6, "the executions of the previous month are not counted")
+
+	_, err = svc.SetBudget(ctx, "other@example.com", api.BudgetRequest{MonthlyLimitUSD: 100}, "admin@example.com")
+	require.NoError(t, err)
+
+	statuses, err := svc.ListBudgets(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "other@example.com", statuses[0].UserEmail)
+	assert.Equal(t, "user@example.com", statuses[1].UserEmail)
+	assert.InDelta(t, 3*usage.FargateVCPUHourPriceUSD, statuses[1].SpentUSD, 1e-9)
+
+	require.NoError(t, svc.RemoveBudget(ctx, "user@example.com", "admin@example.com"))
+	err = svc.RemoveBudget(ctx, "user@example.com", "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+}
+
+func TestBudgets_NotConfigured(t *testing.T) {
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+
+	_, err := svc.ListBudgets(context.Background())
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+
+	warning, err := svc.checkBudget(context.Background(), "user@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+}
+
+func TestCheckBudget_Thresholds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	var executions []*api.Execution
+	svc := newBudgetTestService(&executions)
+	metrics := &recordingMetricsRecorder{}
+	svc.Metrics = metrics
+
+	warning, err := svc.checkBudget(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, warning, "users without a budget are not checked")
+
+	_, err = svc.SetBudget(ctx, "user@example.com", api.BudgetRequest{MonthlyLimitUSD: vCPUHourLimit}, "admin@example.com")
+	require.NoError(t, err)
+
+	executions = spendingExecutions(5, now)
+	warning, err = svc.checkBudget(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+	assert.Empty(t, metrics.metrics)
+
+	executions = spendingExecutions(8, now)
+	warning, err = svc.checkBudget(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "you have used 80% of your monthly budget ($0.32 of $0.40)", warning)
+	require.Len(t, metrics.metrics, 1)
+	assert.Equal(t, constants.MetricBudgetThresholdsReached, metrics.metrics[0].Name)
+
+	executions = spendingExecutions(9, now)
+	_, err = svc.checkBudget(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Len(t, metrics.metrics, 1, "a threshold is notified once a month")
+
+	executions = spendingExecutions(11, now)
+	warning, err = svc.checkBudget(ctx, "user@example.com")
+	require.NoError(t, err, "the budget is not capped")
+	assert.Equal(t, "you have exceeded your monthly budget ($0.45 of $0.40)", warning)
+	assert.Len(t, metrics.metrics, 2)
+
+	budgets, err := svc.repos.Config.GetBudgets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 100, budgets["user@example.com"].NotifiedThreshold)
+	assert.Equal(t, now.Format("2006-01"), budgets["user@example.com"].NotifiedMonth)
+}
+
+func TestRunCommand_BudgetHardCap(t *testing.T) {
+	ctx := context.Background()
+	executions := spendingExecutions(10, time.Now().UTC())
+	execRepo := &mockExecutionRepository{
+		listByCreatorFunc: func(_ context.Context, _ string, _ int, _ []string) ([]*api.Execution, error) {
+			return executions, nil
+		},
+	}
+	var started int
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			started++
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, runner, nil)
+	svc.repos.Config = fake.NewConfigRepository()
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "dev@example.com", authorization.RoleDeveloper))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "admin@example.com", authorization.RoleAdmin))
+	require.NoError(t, svc.repos.Config.PutBudgets(ctx, map[string]api.Budget{
+		"dev@example.com":   {UserEmail: "dev@example.com", MonthlyLimitUSD: vCPUHourLimit, HardCap: true},
+		"admin@example.com": {UserEmail: "admin@example.com", MonthlyLimitUSD: vCPUHourLimit, HardCap: true},
+	}))
+
+	_, err := svc.RunCommand(ctx, "dev@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+	assert.Equal(t, apperrors.ErrCodeBudgetExceeded, apperrors.GetErrorCode(err))
+	assert.Contains(t, err.Error(), "monthly budget exceeded ($0.40 of $0.40)")
+	assert.Zero(t, started)
+
+	resp, err := svc.RunCommand(ctx, "dev@example.com", nil,
+		&api.ExecutionRequest{Command: "echo hello", DryRun: true}, nil)
+	require.NoError(t, err, "dry runs start nothing and are still validated")
+	assert.Empty(t, resp.BudgetWarning)
+
+	resp, err = svc.RunCommand(ctx, "admin@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+	require.NoError(t, err, "admins may override the cap")
+	assert.Equal(t, 1, started)
+	assert.Contains(t, resp.BudgetWarning, "running with your override permission")
+}
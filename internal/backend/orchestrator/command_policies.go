@@ -0,0 +1,294 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// commandPolicyRuleNamePattern matches the names of command policy rules, e.g. deny-rm-root.
+var commandPolicyRuleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Audit trail messages of the command policy decisions.
+const (
+	commandPolicyAllowedMessage = "audit: command policy allowed execution"
+	commandPolicyDeniedMessage  = "audit: command policy denied execution"
+)
+
+// ListCommandPolicyRules returns all command policy rules, sorted by name.
+func (s *Service) ListCommandPolicyRules(ctx context.Context) ([]api.CommandPolicyRule, error) {
+	if s.repos.CommandPolicy == nil {
+		return nil, appErrors.ErrServiceUnavailable("command policy is not configured", nil)
+	}
+	return s.repos.CommandPolicy.ListCommandPolicyRules(ctx)
+}
+
+// GetCommandPolicyRule returns a command policy rule by name.
+func (s *Service) GetCommandPolicyRule(ctx context.Context, name string) (*api.CommandPolicyRule, error) {
+	if s.repos.CommandPolicy == nil {
+		return nil, appErrors.ErrServiceUnavailable("command policy is not configured", nil)
+	}
+	rule, err := s.repos.CommandPolicy.GetCommandPolicyRule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, appErrors.ErrNotFound(fmt.Sprintf("command policy rule %q not found", name), nil)
+	}
+	return rule, nil
+}
+
+// CreateCommandPolicyRule creates a command policy rule. It fails with a conflict if the rule already exists.
+func (s *Service) CreateCommandPolicyRule(
+	ctx context.Context, req *api.CommandPolicyRuleRequest, userEmail string,
+) (*api.CommandPolicyRule, error) {
+	if s.repos.CommandPolicy == nil {
+		return nil, appErrors.ErrServiceUnavailable("command policy is not configured", nil)
+	}
+	rule, err := newCommandPolicyRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repos.CommandPolicy.GetCommandPolicyRule(ctx, rule.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, appErrors.ErrConflict(fmt.Sprintf("command policy rule %q already exists", rule.Name), nil)
+	}
+
+	now := time.Now().UTC()
+	rule.CreatedBy = userEmail
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	if err = s.repos.CommandPolicy.PutCommandPolicyRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logCommandPolicyRuleChange(ctx, "audit: command policy rule created", rule, userEmail)
+	return rule, nil
+}
+
+// UpdateCommandPolicyRule replaces an existing command policy rule, keeping its creation attributes.
+func (s *Service) UpdateCommandPolicyRule(
+	ctx context.Context, name string, req *api.CommandPolicyRuleRequest, userEmail string,
+) (*api.CommandPolicyRule, error) {
+	if req == nil {
+		return nil, appErrors.ErrBadRequest("request is required", nil)
+	}
+	if req.Name != "" && req.Name != name {
+		return nil, appErrors.ErrBadRequest("rule name cannot be changed", nil)
+	}
+	existing, err := s.GetCommandPolicyRule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := *req
+	replacement.Name = name
+	rule, err := newCommandPolicyRule(&replacement)
+	if err != nil {
+		return nil, err
+	}
+	rule.CreatedBy = existing.CreatedBy
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedBy = userEmail
+	rule.UpdatedAt = time.Now().UTC()
+	if err = s.repos.CommandPolicy.PutCommandPolicyRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logCommandPolicyRuleChange(ctx, "audit: command policy rule updated", rule, userEmail)
+	return rule, nil
+}
+
+// DeleteCommandPolicyRule deletes a command policy rule.
+func (s *Service) DeleteCommandPolicyRule(ctx context.Context, name, userEmail string) error {
+	if s.repos.CommandPolicy == nil {
+		return appErrors.ErrServiceUnavailable("command policy is not configured", nil)
+	}
+	if err := s.repos.CommandPolicy.DeleteCommandPolicyRule(ctx, name); err != nil {
+		return err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("audit: command policy rule deleted", "context", map[string]string{
+		"user": userEmail,
+		"rule": name,
+	})
+	return nil
+}
+
+// EnforceCommandPolicy evaluates the command policy rules applying to the role of the user before an
+// execution starts. Any matching deny rule refuses the execution. When the role has allow rules, the command
+// and image of the execution must match one of them. Decisions are logged to the audit trail.
+func (s *Service) EnforceCommandPolicy(
+	ctx context.Context, user *api.User, req *api.ExecutionRequest, resolvedImage *api.ImageInfo,
+) error {
+	if s.repos.CommandPolicy == nil {
+		return nil
+	}
+	rules, err := s.repos.CommandPolicy.ListCommandPolicyRules(ctx)
+	if err != nil {
+		return appErrors.ErrInternalError("failed to load command policy", err)
+	}
+
+	images := []string{req.Image}
+	imageRef := req.Image
+	if resolvedImage != nil {
+		images = append(images, resolvedImage.ImageID, resolvedImage.Image)
+		imageRef = resolvedImage.ImageID
+	}
+
+	var allowRules int
+	var allowedBy *api.CommandPolicyRule
+	for i := range rules {
+		rule := &rules[i]
+		if len(rule.Roles) > 0 && !slices.Contains(rule.Roles, user.Role) {
+			continue
+		}
+		if constants.CommandPolicyEffect(rule.Effect) == constants.CommandPolicyEffectAllow {
+			allowRules++
+		}
+		if !commandPolicyRuleMatches(rule, req.Command, images) {
+			continue
+		}
+		if constants.CommandPolicyEffect(rule.Effect) == constants.CommandPolicyEffectDeny {
+			s.logCommandPolicyDecision(ctx, commandPolicyDeniedMessage, user, req.Command, imageRef, rule.Name)
+			return appErrors.ErrCommandDenied(
+				fmt.Sprintf("command denied by policy rule %q", rule.Name), nil)
+		}
+		if allowedBy == nil {
+			allowedBy = rule
+		}
+	}
+
+	switch {
+	case allowedBy != nil:
+		s.logCommandPolicyDecision(ctx, commandPolicyAllowedMessage, user, req.Command, imageRef, allowedBy.Name)
+	case allowRules > 0:
+		s.logCommandPolicyDecision(ctx, commandPolicyDeniedMessage, user, req.Command, imageRef, "")
+		return appErrors.ErrCommandDenied(
+			fmt.Sprintf("command and image do not match any policy rule allowed for the %s role", user.Role), nil)
+	}
+	return nil
+}
+
+// newCommandPolicyRule validates a request and returns the rule it describes, without its audit attributes.
+func newCommandPolicyRule(req *api.CommandPolicyRuleRequest) (*api.CommandPolicyRule, error) {
+	if req == nil {
+		return nil, appErrors.ErrBadRequest("request is required", nil)
+	}
+	if len(req.Name) > constants.MaxCommandPolicyRuleNameLength ||
+		!commandPolicyRuleNamePattern.MatchString(req.Name) {
+		return nil, appErrors.ErrBadRequest(fmt.Sprintf(
+			"invalid rule name %q, expected at most %d lowercase letters, digits and dashes",
+			req.Name, constants.MaxCommandPolicyRuleNameLength), nil)
+	}
+	if !constants.CommandPolicyEffect(req.Effect).Valid() {
+		return nil, appErrors.ErrBadRequest(
+			fmt.Sprintf("invalid effect %q, expected allow or deny", req.Effect), nil)
+	}
+	if !constants.CommandPolicyMatch(req.MatchType).Valid() {
+		return nil, appErrors.ErrBadRequest(
+			fmt.Sprintf("invalid match type %q, expected prefix or regex", req.MatchType), nil)
+	}
+	if strings.TrimSpace(req.Pattern) == "" {
+		return nil, appErrors.ErrBadRequest("pattern is required", nil)
+	}
+	if len(req.Pattern) > constants.MaxCommandPolicyPatternLength {
+		return nil, appErrors.ErrBadRequest(
+			fmt.Sprintf("pattern must be at most %d characters", constants.MaxCommandPolicyPatternLength), nil)
+	}
+	if constants.CommandPolicyMatch(req.MatchType) == constants.CommandPolicyMatchRegex {
+		if _, err := regexp.Compile(req.Pattern); err != nil {
+			return nil, appErrors.ErrBadRequest(fmt.Sprintf("invalid regular expression: %v", err), err)
+		}
+	}
+
+	roles := normalizeCommandPolicyList(req.Roles)
+	for _, role := range roles {
+		if !authorization.IsValidRole(role) {
+			return nil, appErrors.ErrBadRequest(fmt.Sprintf("invalid role %q, valid roles: %s",
+				role, strings.Join(authorization.ValidRoles(), ", ")), nil)
+		}
+	}
+
+	return &api.CommandPolicyRule{
+		Name:        req.Name,
+		Effect:      req.Effect,
+		MatchType:   req.MatchType,
+		Pattern:     req.Pattern,
+		Roles:       roles,
+		Images:      normalizeCommandPolicyList(req.Images),
+		Description: strings.TrimSpace(req.Description),
+	}, nil
+}
+
+// normalizeCommandPolicyList trims the values of a list, dropping the empty ones and the duplicates.
+func normalizeCommandPolicyList(values []string) []string {
+	var normalized []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
+
+// commandPolicyRuleMatches reports whether a rule matches the command and one of the names of its image.
+// Prefix patterns match the command without its leading spaces.
+func commandPolicyRuleMatches(rule *api.CommandPolicyRule, command string, images []string) bool {
+	if len(rule.Images) > 0 && !slices.ContainsFunc(rule.Images, func(image string) bool {
+		return image != "" && slices.Contains(images, image)
+	}) {
+		return false
+	}
+
+	switch constants.CommandPolicyMatch(rule.MatchType) {
+	case constants.CommandPolicyMatchPrefix:
+		return strings.HasPrefix(strings.TrimLeft(command, " \t\n"), rule.Pattern)
+	case constants.CommandPolicyMatchRegex:
+		pattern, err := regexp.Compile(rule.Pattern)
+		return err == nil && pattern.MatchString(command)
+	default:
+		return false
+	}
+}
+
+func (s *Service) logCommandPolicyDecision(
+	ctx context.Context, message string, user *api.User, command, image, ruleName string,
+) {
+	logger.DeriveRequestLogger(ctx, s.Logger).Info(message, "context", map[string]any{
+		"user":    user.Email,
+		"role":    user.Role,
+		"command": command,
+		"image":   image,
+		"rule":    ruleName,
+	})
+}
+
+func (s *Service) logCommandPolicyRuleChange(
+	ctx context.Context, message string, rule *api.CommandPolicyRule, userEmail string,
+) {
+	logger.DeriveRequestLogger(ctx, s.Logger).Info(message, "context", map[string]any{
+		"user":       userEmail,
+		"rule":       rule.Name,
+		"effect":     rule.Effect,
+		"match_type": rule.MatchType,
+		"pattern":    rule.Pattern,
+		"roles":      rule.Roles,
+		"images":     rule.Images,
+	})
+}
@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCommandPolicyTestService(t *testing.T, rules ...api.CommandPolicyRuleRequest) *Service {
+	t.Helper()
+	svc := newTestServiceWithConnRepo(
+		&mockUserRepository{}, &mockExecutionRepository{}, nil, &mockRunner{}, &mockRunner{}, &mockRunner{}, &mockRunner{})
+	svc.repos.CommandPolicy = fake.NewCommandPolicyRepository()
+	for i := range rules {
+		_, err := svc.CreateCommandPolicyRule(context.Background(), &rules[i], "admin@example.com")
+		require.NoError(t, err)
+	}
+	return svc
+}
+
+func TestCommandPolicyRuleCRUD(t *testing.T) {
+	ctx := context.Background()
+	svc := newCommandPolicyTestService(t)
+
+	rule, err := svc.CreateCommandPolicyRule(ctx, &api.CommandPolicyRuleRequest{
+		Name: "deny-rm-root", Effect: "deny", MatchType: "regex", Pattern: `rm\s+-rf\s+/(\s|$)`,
+		Roles: []string{" developer ", "developer"},
+	}, "admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"developer"}, rule.Roles)
+	assert.Equal(t, "admin@example.com", rule.CreatedBy)
+
+	_, err = svc.CreateCommandPolicyRule(ctx, &api.CommandPolicyRuleRequest{
+		Name: "deny-rm-root", Effect: "deny", MatchType: "prefix", Pattern: "rm",
+	}, "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+
+	updated, err := svc.UpdateCommandPolicyRule(ctx, "deny-rm-root", &api.CommandPolicyRuleRequest{
+		Effect: "deny", MatchType: "prefix", Pattern: "rm -rf /",
+	}, "other@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", updated.CreatedBy)
+	assert.Equal(t, "other@example.com", updated.UpdatedBy)
+	assert.Empty(t, updated.Roles)
+
+	_, err = svc.UpdateCommandPolicyRule(ctx, "missing", &api.CommandPolicyRuleRequest{
+		Effect: "deny", MatchType: "prefix", Pattern: "rm",
+	}, "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+
+	rules, err := svc.ListCommandPolicyRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "rm -rf /", rules[0].Pattern)
+
+	require.NoError(t, svc.DeleteCommandPolicyRule(ctx, "deny-rm-root", "admin@example.com"))
+	err = svc.DeleteCommandPolicyRule(ctx, "deny-rm-root", "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+}
+
+func TestCommandPolicyRuleValidation(t *testing.T) {
+	svc := newCommandPolicyTestService(t)
+
+	tests := []struct {
+		name string
+		req  api.CommandPolicyRuleRequest
+	}{
+		{"invalid name", api.CommandPolicyRuleRequest{Name: "Deny RM", Effect: "deny", MatchType: "prefix", Pattern: "rm"}},
+		{"invalid effect", api.CommandPolicyRuleRequest{Name: "r", Effect: "block", MatchType: "prefix", Pattern: "rm"}},
+		{"invalid match type", api.CommandPolicyRuleRequest{Name: "r", Effect: "deny", MatchType: "glob", Pattern: "rm"}},
+		{"missing pattern", api.CommandPolicyRuleRequest{Name: "r", Effect: "deny", MatchType: "prefix", Pattern: " "}},
+		{"invalid regex", api.CommandPolicyRuleRequest{Name: "r", Effect: "deny", MatchType: "regex", Pattern: "("}},
+		{"invalid role", api.CommandPolicyRuleRequest{
+			Name: "r", Effect: "deny", MatchType: "prefix", Pattern: "rm", Roles: []string{"contractor"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateCommandPolicyRule(context.Background(), &tt.req, "admin@example.com")
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestCommandPolicyRuleNotConfigured(t *testing.T) {
+	svc := newTestServiceWithConnRepo(
+		&mockUserRepository{}, &mockExecutionRepository{}, nil, &mockRunner{}, &mockRunner{}, &mockRunner{}, &mockRunner{})
+
+	_, err := svc.ListCommandPolicyRules(context.Background())
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+
+	err = svc.EnforceCommandPolicy(context.Background(), &api.User{Role: "developer"},
+		&api.ExecutionRequest{Command: "rm -rf /"}, nil)
+	assert.NoError(t, err)
+}
+
+func TestEnforceCommandPolicy(t *testing.T) {
+	svc := newCommandPolicyTestService(t,
+		api.CommandPolicyRuleRequest{Name: "deny-rm-root", Effect: "deny", MatchType: "regex", Pattern: `rm\s+-rf\s+/(\s|$)`},
+		api.CommandPolicyRuleRequest{
+			Name: "viewer-terraform-plan", Effect: "allow", MatchType: "prefix", Pattern: "terraform plan",
+			Roles: []string{"viewer"}, Images: []string{"hashicorp/terraform:1.9"},
+		},
+	)
+	terraform := &api.ImageInfo{ImageID: "hashicorp/terraform:1.9-a1b2c3d4", Image: "hashicorp/terraform:1.9"}
+	alpine := &api.ImageInfo{ImageID: "alpine:latest-e5f6a7b8", Image: "alpine:latest"}
+
+	tests := []struct {
+		name    string
+		role    string
+		command string
+		image   *api.ImageInfo
+		denied  bool
+	}{
+		{"deny rule applies to every role", "admin", "rm -rf / --no-preserve-root", alpine, true},
+		{"deny rule does not match other paths", "developer", "rm -rf /tmp/build", alpine, false},
+		{"role without allow rules runs anything else", "developer", "make test", alpine, false},
+		{"allow rule matches command and image", "viewer", "  terraform plan -out plan.tfplan", terraform, false},
+		{"allow rule requires its image", "viewer", "terraform plan", alpine, true},
+		{"allow rule requires its command", "viewer", "terraform apply", terraform, true},
+		{"deny rule wins over allow rule", "viewer", "terraform plan; rm -rf /", terraform, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.EnforceCommandPolicy(context.Background(),
+				&api.User{Email: "user@example.com", Role: tt.role},
+				&api.ExecutionRequest{Command: tt.command}, tt.image)
+			if tt.denied {
+				assert.Equal(t, apperrors.ErrCodeCommandDenied, apperrors.GetErrorCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +63,48 @@ func TestRunCommand(t *testing.T) {
 			expectErr:     true,
 			expectedError: apperrors.ErrCodeInvalidRequest,
 		},
+		{
+			name:          "stop grace period too long",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Command: "echo hello", StopGracePeriod: 600},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name:          "invalid visibility",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Command: "echo hello", Visibility: "hidden"},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name:          "invalid shell",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Command: "echo hello", Shell: "zsh"},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name:          "shell with arguments",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Args: []string{"echo", "hello"}, Shell: "bash"},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name:          "negative timeout",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Command: "echo hello", Timeout: -1},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name:          "negative expected max duration",
+			userEmail:     "user@example.com",
+			req:           api.ExecutionRequest{Command: "echo hello", Playbook: "deploy", ExpectedMaxDuration: -1},
+			expectErr:     true,
+			expectedError: apperrors.ErrCodeInvalidRequest,
+		},
 		{
 			name:      "runner error",
 			userEmail: "user@example.com",
@@ -80,7 +125,7 @@ func TestRunCommand(t *testing.T) {
 			createExecErr: errors.New("database error"),
 			expectErr:     true,
 			expectedError: "failed to record execution: " +
-				"failed to create execution record, but task has been accepted by the provider",
+				"failed to create execution record of the task accepted by the provider",
 		},
 	}
 
@@ -130,6 +175,32 @@ func TestRunCommand(t *testing.T) {
 	}
 }
 
+func TestRunCommand_StopsTaskWhenRecordFails(t *testing.T) {
+	ctx := context.Background()
+
+	var killed []string
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-123", timePtr(time.Now()), nil
+		},
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return nil
+		},
+	}
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			return apperrors.ErrDatabaseError("throughput exceeded", nil)
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"exec-123"}, killed, "the task without a record is stopped")
+}
+
 func TestRunCommand_UsesRequestImageWhenResolvedImageNil(t *testing.T) {
 	ctx := context.Background()
 
@@ -158,6 +229,401 @@ func TestRunCommand_UsesRequestImageWhenResolvedImageNil(t *testing.T) {
 	assert.Equal(t, "cli-image:latest", resp.ImageID)
 }
 
+func TestResolveOnBehalfOf(t *testing.T) {
+	ctx := context.Background()
+	users := map[string]*api.User{
+		"dev@example.com":     {Email: "dev@example.com", Role: "developer"},
+		"revoked@example.com": {Email: "revoked@example.com", Role: "developer", Revoked: true},
+	}
+	userRepo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, email string) (*api.User, error) {
+			return users[email], nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(userRepo, nil, nil, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "admin@example.com", authorization.RoleAdmin))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+
+	tests := []struct {
+		name       string
+		actor      string
+		email      string
+		wantStatus int
+	}{
+		{name: "admin", actor: "admin@example.com", email: "Dev@Example.com"},
+		{name: "operator", actor: "operator@example.com", email: "dev@example.com", wantStatus: http.StatusForbidden},
+		{name: "unknown user", actor: "admin@example.com", email: "nobody@example.com", wantStatus: http.StatusNotFound},
+		{name: "revoked user", actor: "admin@example.com", email: "revoked@example.com", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := svc.ResolveOnBehalfOf(ctx, tt.actor, tt.email)
+			if tt.wantStatus != 0 {
+				assert.Equal(t, tt.wantStatus, apperrors.GetStatusCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "dev@example.com", user.Email)
+		})
+	}
+}
+
+func TestRunCommand_OnBehalfOf(t *testing.T) {
+	ctx := context.Background()
+
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+
+	svc := newTestService(nil, execRepo, runner)
+	req := api.ExecutionRequest{Command: "echo hi", Image: "cli-image:latest", ImpersonatedBy: "admin@example.com"}
+
+	_, err := svc.RunCommand(ctx, "dev@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "dev@example.com", recorded.CreatedBy)
+	assert.Equal(t, "admin@example.com", recorded.ImpersonatedBy)
+}
+
+func TestRunCommand_DryRun(t *testing.T) {
+	ctx := context.Background()
+
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			t.Fatal("a dry run must not start a task")
+			return "", nil, nil
+		},
+	}
+
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			t.Fatal("a dry run must not record an execution")
+			return nil
+		},
+	}
+
+	svc := newTestService(nil, execRepo, runner)
+	req := api.ExecutionRequest{Command: "true", Image: "alpine:latest", Parallel: 4, DryRun: true}
+
+	resp, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Empty(t, resp.ExecutionID)
+	assert.Equal(t, "alpine:latest", resp.ImageID)
+
+	_, err = svc.RunCommand(ctx, "user@example.com", nil, &api.ExecutionRequest{DryRun: true}, nil)
+	assert.Error(t, err, "dry runs are validated")
+}
+
+func TestRunCommand_SetsRequestIDEnv(t *testing.T) {
+	ctx := logger.WithRequestID(context.Background(), "cli-request-id")
+
+	var started *api.ExecutionRequest
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			started = req
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+
+	svc := newTestService(nil, nil, runner)
+	req := api.ExecutionRequest{Command: "true", Image: "alpine:latest"}
+
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, started)
+	assert.Equal(t, "cli-request-id", started.Env[constants.RequestIDEnvVar])
+}
+
+func TestRunCommand_RecordsTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+
+	svc := newTestService(nil, execRepo, runner)
+	req := api.ExecutionRequest{Command: "sleep 600", Image: "alpine:latest", Timeout: 300, StopGracePeriod: 20}
+
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, 300, recorded.TimeoutSeconds)
+	assert.Equal(t, 20, recorded.StopGracePeriodSeconds)
+}
+
+func TestRunCommand_RecordsProvenance(t *testing.T) {
+	ctx := context.Background()
+
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+
+	svc := newTestService(nil, execRepo, runner)
+	provenance := &api.ExecutionProvenance{
+		ClientVersion: "v1.2.3", OS: "linux/amd64", Hostname: "runner-1", CIProvider: "github-actions", CIRunID: "42",
+	}
+	req := api.ExecutionRequest{Command: "make deploy", Image: "alpine:latest", Provenance: provenance}
+
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, provenance, recorded.Provenance)
+
+	req = api.ExecutionRequest{
+		Command:    "make deploy",
+		Image:      "alpine:latest",
+		Provenance: &api.ExecutionProvenance{Hostname: strings.Repeat("h", constants.MaxProvenanceFieldLength+1)},
+	}
+	_, err = svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provenance hostname")
+}
+
+func TestRunCommand_RecordsVisibility(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		requested         string
+		defaultVisibility constants.ExecutionVisibility
+		want              string
+		wantReadable      bool
+	}{
+		{name: "defaults to team", want: "team", wantReadable: true},
+		{
+			name:              "uses the service default",
+			defaultVisibility: constants.ExecutionVisibilityPrivate,
+			want:              "private",
+		},
+		{
+			name:              "requested visibility overrides the default",
+			requested:         "public",
+			defaultVisibility: constants.ExecutionVisibilityPrivate,
+			want:              "public",
+			wantReadable:      true,
+		},
+		{name: "private", requested: "private", want: "private"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+					return "exec-visibility", timePtr(time.Now()), nil
+				},
+			}
+			var recorded *api.Execution
+			execRepo := &mockExecutionRepository{
+				createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+					recorded = execution
+					return nil
+				},
+			}
+
+			svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, runner, nil)
+			svc.DefaultExecutionVisibility = tt.defaultVisibility
+			require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+
+			req := api.ExecutionRequest{Command: "echo hello", Visibility: tt.requested}
+			_, err := svc.RunCommand(ctx, "owner@example.com", nil, &req, nil)
+			require.NoError(t, err)
+			require.NotNil(t, recorded)
+			assert.Equal(t, tt.want, recorded.Visibility)
+
+			readable, err := canAccessExecution(
+				ctx, enforcer, "operator@example.com", "exec-visibility", authorization.ActionRead)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReadable, readable)
+
+			readable, err = canAccessExecution(
+				ctx, enforcer, "owner@example.com", "exec-visibility", authorization.ActionRead)
+			require.NoError(t, err)
+			assert.True(t, readable, "owners can always read their executions")
+		})
+	}
+}
+
+func TestRunCommand_RecordsImageLogLimits(t *testing.T) {
+	ctx := context.Background()
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-log-limits", timePtr(time.Now()), nil
+		},
+	}
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	limits := &api.LogLimits{MaxLinesPerSecond: 10, MaxBytes: 2048}
+	req := api.ExecutionRequest{Command: "yes"}
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, &api.ImageInfo{
+		ImageID:   "busybox:latest-a1b2c3d4",
+		LogLimits: limits,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, limits, recorded.LogLimits)
+}
+
+func TestRunCommand_AppliesImageRunDefaults(t *testing.T) {
+	defaults := &api.RunDefaults{WorkDir: "/app", Shell: "bash"}
+	tests := []struct {
+		name        string
+		req         api.ExecutionRequest
+		wantWorkDir string
+		wantShell   string
+	}{
+		{name: "applies the defaults", req: api.ExecutionRequest{Command: "npm test"},
+			wantWorkDir: "/app", wantShell: "bash"},
+		{name: "keeps the values of the request", req: api.ExecutionRequest{Command: "npm test", WorkDir: "web",
+			Shell: "sh"}, wantWorkDir: "web", wantShell: "sh"},
+		{name: "runs arguments without the default shell", req: api.ExecutionRequest{Args: []string{"npm", "test"}},
+			wantWorkDir: "/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started *api.ExecutionRequest
+			runner := &mockRunner{
+				startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+					started = req
+					return "exec-run-defaults", timePtr(time.Now()), nil
+				},
+			}
+			svc := newTestService(nil, &mockExecutionRepository{}, runner)
+
+			_, err := svc.RunCommand(context.Background(), "user@example.com", nil, &tt.req, &api.ImageInfo{
+				ImageID:     "node:22-a1b2c3d4",
+				RunDefaults: defaults,
+			})
+
+			require.NoError(t, err)
+			require.NotNil(t, started)
+			assert.Equal(t, tt.wantWorkDir, started.WorkDir)
+			assert.Equal(t, tt.wantShell, started.Shell)
+		})
+	}
+}
+
+func TestRunCommand_RecordsImageAlias(t *testing.T) {
+	ctx := context.Background()
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-image-alias", timePtr(time.Now()), nil
+		},
+	}
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	req := api.ExecutionRequest{Command: "python --version", Image: "python:stable"}
+	resp, err := svc.RunCommand(ctx, "user@example.com", nil, &req, &api.ImageInfo{
+		ImageID:           "python:3.12-a1b2c3d4",
+		ResolvedFromAlias: "python:stable",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "python:3.12-a1b2c3d4", recorded.ImageID)
+	assert.Equal(t, "python:stable", recorded.ImageAlias)
+	assert.Equal(t, "python:stable", resp.ImageAlias)
+}
+
+func TestRunCommand_LogRetention(t *testing.T) {
+	tests := []struct {
+		name    string
+		days    int
+		minDays int
+		maxDays int
+		wantErr bool
+	}{
+		{name: "no retention requested"},
+		{name: "within the default bounds", days: 90},
+		{name: "over the default maximum", days: constants.DefaultLogRetentionMaxDays + 1, wantErr: true},
+		{name: "below the configured minimum", days: 1, minDays: 7, maxDays: 30, wantErr: true},
+		{name: "over the configured maximum", days: 90, minDays: 7, maxDays: 30, wantErr: true},
+		{name: "negative", days: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+					return "exec-log-retention", timePtr(time.Now()), nil
+				},
+			}
+			var recorded *api.Execution
+			execRepo := &mockExecutionRepository{
+				createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+					recorded = execution
+					return nil
+				},
+			}
+			svc := newTestService(nil, execRepo, runner)
+			svc.LogRetentionMinDays, svc.LogRetentionMaxDays = tt.minDays, tt.maxDays
+
+			req := api.ExecutionRequest{Command: "make load-test", LogRetentionDays: tt.days}
+			_, err := svc.RunCommand(context.Background(), "user@example.com", nil, &req, nil)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+				assert.Contains(t, err.Error(), "log retention must be between")
+				assert.Nil(t, recorded)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, recorded)
+			assert.Equal(t, tt.days, recorded.LogRetentionDays)
+		})
+	}
+}
+
 func TestRunCommand_WithSecrets(t *testing.T) {
 	ctx := context.Background()
 	dbSecretValue := "super-secret"
@@ -213,12 +679,131 @@ func TestRunCommand_WithSecrets(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 
-	require.NotNil(t, capturedEnv)
-	assert.Equal(t, "user-override", capturedEnv["GITHUB_TOKEN"], "user env should take precedence over secret")
-	assert.NotEqual(t, githubSecretValue, capturedEnv["GITHUB_TOKEN"])
-	assert.Equal(t, dbSecretValue, capturedEnv["DB_PASSWORD"])
-	assert.Equal(t, "value", capturedEnv["USER_DEFINED"])
-	assert.Equal(t, string(constants.ExecutionStarting), resp.Status)
+	require.NotNil(t, capturedEnv)
+	assert.Equal(t, "user-override", capturedEnv["GITHUB_TOKEN"], "user env should take precedence over secret")
+	assert.NotEqual(t, githubSecretValue, capturedEnv["GITHUB_TOKEN"])
+	assert.Equal(t, dbSecretValue, capturedEnv["DB_PASSWORD"])
+	assert.Equal(t, "value", capturedEnv["USER_DEFINED"])
+	assert.Equal(t, string(constants.ExecutionStarting), resp.Status)
+}
+
+func TestRunCommand_RecordsSecretUsage(t *testing.T) {
+	ctx := context.Background()
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	usedAt := map[string]time.Time{}
+	var usedBy []string
+	secretsRepo := &mockSecretsRepository{
+		getSecretFunc: func(_ context.Context, name string, _ bool) (*api.Secret, error) {
+			return &api.Secret{Name: name, KeyName: strings.ToUpper(name), Value: "value"}, nil
+		},
+		recordSecretUsageFunc: func(_ context.Context, name, executionID string, at time.Time) error {
+			usedAt[name] = at
+			usedBy = append(usedBy, executionID)
+			if name == "broken" {
+				return errors.New("update failed")
+			}
+			return nil
+		},
+	}
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "exec-secret-usage", &startedAt, nil
+		},
+	}
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+	svc := newTestServiceWithSecretsRepo(nil, execRepo, runner, secretsRepo)
+
+	req := api.ExecutionRequest{Command: "deploy", Secrets: []string{"token", " broken", "token"}}
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err, "failing to record usage must not fail the execution")
+	require.NotNil(t, recorded)
+	assert.Equal(t, []string{"token", "broken"}, recorded.Secrets)
+	assert.Equal(t, map[string]time.Time{"token": startedAt, "broken": startedAt}, usedAt)
+	assert.Equal(t, []string{"exec-secret-usage", "exec-secret-usage"}, usedBy)
+}
+
+func TestRunCommand_DryRunDoesNotRecordSecretUsage(t *testing.T) {
+	secretsRepo := &mockSecretsRepository{
+		getSecretFunc: func(_ context.Context, name string, _ bool) (*api.Secret, error) {
+			return &api.Secret{Name: name, KeyName: "TOKEN", Value: "value"}, nil
+		},
+		recordSecretUsageFunc: func(_ context.Context, _, _ string, _ time.Time) error {
+			t.Fatal("secret usage recorded for a dry run")
+			return nil
+		},
+	}
+	svc := newTestServiceWithSecretsRepo(nil, &mockExecutionRepository{}, &mockRunner{}, secretsRepo)
+
+	req := api.ExecutionRequest{Command: "deploy", Secrets: []string{"token"}, DryRun: true}
+	_, err := svc.RunCommand(context.Background(), "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+}
+
+func TestRunCommand_ValidatesEnvSchema(t *testing.T) {
+	schema := []api.EnvVarSpec{
+		{Name: "GITHUB_TOKEN", Required: true},
+		{Name: "REGION", Type: "enum", Values: []string{"eu-west-1", "us-east-1"}, Required: true},
+	}
+	secretsRepo := &mockSecretsRepository{
+		getSecretFunc: func(_ context.Context, name string, _ bool) (*api.Secret, error) {
+			return &api.Secret{Name: name, KeyName: "GITHUB_TOKEN", Value: "ghp_secret"}, nil
+		},
+	}
+
+	tests := []struct {
+		name    string
+		req     api.ExecutionRequest
+		wantErr string
+	}{
+		{
+			name: "variables set by env and secrets",
+			req: api.ExecutionRequest{
+				Command: "deploy", Env: map[string]string{"REGION": "eu-west-1"},
+				Secrets: []string{"github-token"}, EnvSchema: schema,
+			},
+		},
+		{
+			name:    "missing variables",
+			req:     api.ExecutionRequest{Command: "deploy", EnvSchema: schema},
+			wantErr: "environment variable GITHUB_TOKEN is required; environment variable REGION is required",
+		},
+		{
+			name: "invalid schema",
+			req: api.ExecutionRequest{
+				Command: "deploy", EnvSchema: []api.EnvVarSpec{{Name: "REGION", Type: "enum"}},
+			},
+			wantErr: "env schema variable REGION is an enum without values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+					return "exec-env-schema", timePtr(time.Now()), nil
+				},
+			}
+			svc := newTestServiceWithSecretsRepo(nil, &mockExecutionRepository{}, runner, secretsRepo)
+
+			_, err := svc.RunCommand(context.Background(), "user@example.com", nil, &tt.req, nil)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetStatusCode(err))
+			assert.Equal(t, tt.wantErr, apperrors.GetErrorMessage(err))
+		})
+	}
 }
 
 func TestRunCommand_AddsExecutionOwnership(t *testing.T) {
@@ -372,7 +957,7 @@ func TestGetExecutionStatus(t *testing.T) {
 			}
 
 			svc := newTestService(nil, execRepo, nil)
-			resp, err := svc.GetExecutionStatus(ctx, tt.executionID)
+			resp, err := svc.GetExecutionStatus(ctx, "user@example.com", tt.executionID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -401,6 +986,173 @@ func TestGetExecutionStatus(t *testing.T) {
 	}
 }
 
+func TestGetExecutionEvents(t *testing.T) {
+	ctx := context.Background()
+	submittedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("prepends the submission to the recorded events", func(t *testing.T) {
+		recorded := []api.ExecutionEvent{
+			{Type: string(constants.ExecutionEventProvisioning), Timestamp: submittedAt.Add(time.Second)},
+			{Type: string(constants.ExecutionEventRunning), Timestamp: submittedAt.Add(40 * time.Second)},
+		}
+		execRepo := &mockExecutionRepository{
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return &api.Execution{ExecutionID: "exec-123", StartedAt: submittedAt}, nil
+			},
+			listEventsFunc: func(_ context.Context, executionID string) ([]api.ExecutionEvent, error) {
+				assert.Equal(t, "exec-123", executionID)
+				return recorded, nil
+			},
+		}
+		svc := newTestService(nil, execRepo, nil)
+
+		resp, err := svc.GetExecutionEvents(ctx, "user@example.com", "exec-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "exec-123", resp.ExecutionID)
+		assert.Equal(t, append([]api.ExecutionEvent{
+			{Type: string(constants.ExecutionEventSubmitted), Timestamp: submittedAt},
+		}, recorded...), resp.Events)
+	})
+
+	t.Run("execution not found", func(t *testing.T) {
+		execRepo := &mockExecutionRepository{}
+		svc := newTestService(nil, execRepo, nil)
+
+		_, err := svc.GetExecutionEvents(ctx, "user@example.com", "exec-404")
+
+		assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		execRepo := &mockExecutionRepository{
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return &api.Execution{ExecutionID: "exec-123"}, nil
+			},
+			listEventsFunc: func(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+				return nil, apperrors.ErrDatabaseError("boom", nil)
+			},
+		}
+		svc := newTestService(nil, execRepo, nil)
+
+		_, err := svc.GetExecutionEvents(ctx, "user@example.com", "exec-123")
+
+		assert.Equal(t, apperrors.ErrCodeDatabaseError, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("private executions are only readable by their owners and admins", func(t *testing.T) {
+		execution := &api.Execution{
+			ExecutionID: "exec-private",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionSucceeded),
+			Visibility:  "private",
+			StartedAt:   submittedAt,
+		}
+		listed := false
+		execRepo := &mockExecutionRepository{
+			listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+				return []*api.Execution{execution}, nil
+			},
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return execution, nil
+			},
+			listEventsFunc: func(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+				listed = true
+				return nil, nil
+			},
+		}
+		svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "viewer@example.com", authorization.RoleViewer))
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "admin@example.com", authorization.RoleAdmin))
+
+		_, err := svc.GetExecutionEvents(ctx, "viewer@example.com", "exec-private")
+		assert.Equal(t, apperrors.ErrCodeForbidden, apperrors.GetErrorCode(err))
+		assert.False(t, listed, "the events of a forbidden execution must not be read")
+
+		for _, user := range []string{"owner@example.com", "admin@example.com"} {
+			resp, err := svc.GetExecutionEvents(ctx, user, "exec-private")
+			require.NoError(t, err, user)
+			assert.Equal(t, "exec-private", resp.ExecutionID)
+		}
+	})
+}
+
+func TestAnnotateExecution(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(annotations int, added *[]*api.ExecutionAnnotation) *Service {
+		execRepo := &mockExecutionRepository{
+			getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+				if executionID != "exec-123" {
+					return nil, nil
+				}
+				return &api.Execution{
+					ExecutionID: executionID,
+					Annotations: make([]api.ExecutionAnnotation, annotations),
+				}, nil
+			},
+			addAnnotationFunc: func(_ context.Context, _ string, annotation *api.ExecutionAnnotation) error {
+				*added = append(*added, annotation)
+				return nil
+			},
+		}
+		svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, nil, nil)
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "viewer@example.com", authorization.RoleViewer))
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "owner@example.com", authorization.RoleDeveloper))
+		require.NoError(t, enforcer.AddOwnershipForResource(
+			ctx, authorization.FormatResourceID("execution", "exec-123"), "owner@example.com"))
+		return svc
+	}
+
+	for _, author := range []string{"operator@example.com", "owner@example.com"} {
+		t.Run("annotated by "+author, func(t *testing.T) {
+			var added []*api.ExecutionAnnotation
+			svc := newService(0, &added)
+
+			annotation, err := svc.AnnotateExecution(ctx, author, "exec-123",
+				&api.ExecutionAnnotationRequest{Note: "  caused by an upstream outage\n"})
+
+			require.NoError(t, err)
+			assert.Equal(t, "caused by an upstream outage", annotation.Note)
+			assert.Equal(t, author, annotation.Author)
+			assert.WithinDuration(t, time.Now(), annotation.CreatedAt, time.Minute)
+			assert.Equal(t, []*api.ExecutionAnnotation{annotation}, added)
+		})
+	}
+
+	tests := []struct {
+		name        string
+		author      string
+		executionID string
+		note        string
+		annotations int
+		wantCode    string
+	}{
+		{name: "empty note", author: "operator@example.com", executionID: "exec-123", note: " ",
+			wantCode: apperrors.ErrCodeInvalidRequest},
+		{name: "execution not found", author: "operator@example.com", executionID: "exec-404", note: "note",
+			wantCode: apperrors.ErrCodeNotFound},
+		{name: "not allowed to read the execution", author: "stranger@example.com", executionID: "exec-123",
+			note: "note", wantCode: apperrors.ErrCodeForbidden},
+		{name: "too many annotations", author: "operator@example.com", executionID: "exec-123", note: "note",
+			annotations: constants.MaxExecutionAnnotations, wantCode: apperrors.ErrCodeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added []*api.ExecutionAnnotation
+			svc := newService(tt.annotations, &added)
+
+			_, err := svc.AnnotateExecution(ctx, tt.author, tt.executionID,
+				&api.ExecutionAnnotationRequest{Note: tt.note})
+
+			assert.Equal(t, tt.wantCode, apperrors.GetErrorCode(err))
+			assert.Empty(t, added)
+		})
+	}
+}
+
 func TestListExecutions(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -581,7 +1333,7 @@ func TestKillExecution(t *testing.T) {
 			},
 			killTaskErr:  errors.New("failed to stop task"),
 			expectErr:    true,
-			expectUpdate: false,
+			expectUpdate: true,
 		},
 		{
 			name:        "update execution fails",
@@ -607,18 +1359,26 @@ func TestKillExecution(t *testing.T) {
 				updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
 					updateCalled = true
 					assert.Equal(t, string(constants.ExecutionTerminating), execution.Status)
+					assert.Equal(t, "user@example.com", execution.KillRequestedBy)
+					assert.NotNil(t, execution.KillRequestedAt)
 					return tt.updateErr
 				},
 			}
 
+			killCalled := false
 			runner := &mockRunner{
 				killTaskFunc: func(_ context.Context, _ string) error {
+					killCalled = true
+					assert.True(t, updateCalled, "the execution is marked TERMINATING before its task is killed")
 					return tt.killTaskErr
 				},
 			}
 
 			svc := newTestService(nil, execRepo, runner)
-			resp, err := svc.KillExecution(ctx, tt.executionID)
+			resp, err := svc.KillExecution(ctx, "user@example.com", tt.executionID)
+			if tt.updateErr != nil {
+				assert.False(t, killCalled, "the task is not killed unless the kill was recorded")
+			}
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -645,6 +1405,280 @@ func TestKillExecution(t *testing.T) {
 	}
 }
 
+func TestStopExecution(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		mockExecution   *api.Execution
+		expectErrCode   string
+		expectKill      bool
+		expectResponse  bool
+		expectedMessage string
+	}{
+		{
+			name: "graceful stop",
+			mockExecution: &api.Execution{
+				ExecutionID:            "exec-123",
+				Status:                 string(constants.ExecutionRunning),
+				StartedAt:              now,
+				StopGracePeriodSeconds: 30,
+			},
+			expectKill:      true,
+			expectResponse:  true,
+			expectedMessage: "Graceful stop initiated, the command has 30 seconds to exit",
+		},
+		{
+			name: "execution started without grace period",
+			mockExecution: &api.Execution{
+				ExecutionID: "exec-123",
+				Status:      string(constants.ExecutionRunning),
+				StartedAt:   now,
+			},
+			expectErrCode: apperrors.ErrCodeInvalidRequest,
+		},
+		{
+			name: "execution already terminated",
+			mockExecution: &api.Execution{
+				ExecutionID: "exec-123",
+				Status:      string(constants.ExecutionSucceeded),
+				StartedAt:   now,
+			},
+		},
+		{
+			name:          "execution not found",
+			expectErrCode: apperrors.ErrCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execRepo := &mockExecutionRepository{
+				getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+					return tt.mockExecution, nil
+				},
+				updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+					assert.Equal(t, string(constants.ExecutionTerminating), execution.Status)
+					return nil
+				},
+			}
+
+			killCalled := false
+			runner := &mockRunner{
+				killTaskFunc: func(_ context.Context, _ string) error {
+					killCalled = true
+					return nil
+				},
+			}
+
+			svc := newTestService(nil, execRepo, runner)
+			resp, err := svc.StopExecution(ctx, "user@example.com", "exec-123")
+
+			if tt.expectErrCode != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectErrCode, apperrors.GetErrorCode(err))
+				assert.False(t, killCalled)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectKill, killCalled)
+			if tt.expectResponse {
+				require.NotNil(t, resp)
+				assert.Equal(t, tt.expectedMessage, resp.Message)
+			} else {
+				assert.Nil(t, resp)
+			}
+		})
+	}
+}
+
+func TestKillExecution_ConcurrentModification(t *testing.T) {
+	ctx := context.Background()
+	conflict := apperrors.ErrConcurrentModification("execution was modified concurrently", nil)
+
+	tests := []struct {
+		name          string
+		currentStatus constants.ExecutionStatus
+		expectErr     bool
+	}{
+		{name: "execution completed while being killed", currentStatus: constants.ExecutionStopped},
+		{name: "execution updated otherwise", currentStatus: constants.ExecutionRunning, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reads := 0
+			execRepo := &mockExecutionRepository{
+				getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+					reads++
+					status := constants.ExecutionRunning
+					if reads > 1 {
+						status = tt.currentStatus
+					}
+					return &api.Execution{ExecutionID: executionID, Status: string(status), Version: int64(reads)}, nil
+				},
+				updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+					return conflict
+				},
+			}
+			svc := newTestService(nil, execRepo, &mockRunner{})
+
+			resp, err := svc.KillExecution(ctx, "user@example.com", "exec-123")
+
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, "exec-123", resp.ExecutionID)
+		})
+	}
+}
+
+func TestKillExecutions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	executions := []*api.Execution{
+		{ExecutionID: "exec-b", Status: string(constants.ExecutionRunning), CreatedBy: "alice@example.com",
+			StartedAt: now.Add(-3 * time.Hour)},
+		{ExecutionID: "exec-a", Status: string(constants.ExecutionRunning), CreatedBy: "bob@example.com",
+			StartedAt: now.Add(-3 * time.Hour)},
+		{ExecutionID: "exec-c", Status: string(constants.ExecutionStarting), CreatedBy: "alice@example.com",
+			StartedAt: now.Add(-time.Minute)},
+	}
+
+	newExecRepo := func(killed map[string]bool) *mockExecutionRepository {
+		return &mockExecutionRepository{
+			listExecutionsFunc: func(_ context.Context, limit int, statuses []string) ([]*api.Execution, error) {
+				assert.Equal(t, 0, limit)
+				if statuses != nil {
+					assert.Equal(t, []string{"STARTING", "RUNNING"}, statuses)
+				}
+				return executions, nil
+			},
+			getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+				for _, e := range executions {
+					if e.ExecutionID == executionID {
+						copied := *e
+						if executionID == "exec-c" {
+							copied.Status = string(constants.ExecutionSucceeded)
+						}
+						return &copied, nil
+					}
+				}
+				return nil, nil
+			},
+			updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+				killed[execution.ExecutionID] = true
+				return nil
+			},
+		}
+	}
+
+	t.Run("dry run returns matching executions and token", func(t *testing.T) {
+		killed := map[string]bool{}
+		svc := newTestService(nil, newExecRepo(killed), nil)
+
+		resp, err := svc.KillExecutions(ctx, "admin@example.com", &api.KillExecutionsFilter{}, "")
+
+		require.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, []string{"exec-a", "exec-b", "exec-c"}, resp.ExecutionIDs)
+		assert.Len(t, resp.ConfirmationToken, constants.BulkKillConfirmationTokenLength)
+		assert.Empty(t, killed)
+	})
+
+	t.Run("filters by user and age", func(t *testing.T) {
+		svc := newTestService(nil, newExecRepo(map[string]bool{}), nil)
+
+		resp, err := svc.KillExecutions(ctx, "admin@example.com", &api.KillExecutionsFilter{
+			CreatedBy: "alice@example.com",
+			OlderThan: 2 * time.Hour,
+		}, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-b"}, resp.ExecutionIDs)
+	})
+
+	t.Run("confirmed kill reports killed and skipped executions", func(t *testing.T) {
+		killed := map[string]bool{}
+		svc := newTestService(nil, newExecRepo(killed), nil)
+
+		preview, err := svc.KillExecutions(ctx, "admin@example.com", nil, "")
+		require.NoError(t, err)
+
+		resp, err := svc.KillExecutions(ctx, "admin@example.com", nil, preview.ConfirmationToken)
+
+		require.NoError(t, err)
+		assert.False(t, resp.DryRun)
+		assert.Empty(t, resp.ConfirmationToken)
+		assert.Equal(t, []string{"exec-a", "exec-b"}, resp.Killed)
+		assert.Equal(t, []string{"exec-c"}, resp.Skipped)
+		assert.Empty(t, resp.Failed)
+		assert.Equal(t, map[string]bool{"exec-a": true, "exec-b": true}, killed)
+	})
+
+	t.Run("reports individual kill failures", func(t *testing.T) {
+		runner := &mockRunner{
+			killTaskFunc: func(_ context.Context, executionID string) error {
+				if executionID == "exec-a" {
+					return errors.New("task not found")
+				}
+				return nil
+			},
+		}
+		svc := newTestService(nil, newExecRepo(map[string]bool{}), runner)
+
+		preview, err := svc.KillExecutions(ctx, "admin@example.com", nil, "")
+		require.NoError(t, err)
+		resp, err := svc.KillExecutions(ctx, "admin@example.com", nil, preview.ConfirmationToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-b"}, resp.Killed)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, "exec-a", resp.Failed[0].ExecutionID)
+	})
+
+	t.Run("rejects stale confirmation token", func(t *testing.T) {
+		killed := map[string]bool{}
+		svc := newTestService(nil, newExecRepo(killed), nil)
+
+		resp, err := svc.KillExecutions(ctx, "admin@example.com", nil, "0000000000000000")
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+		assert.Empty(t, killed)
+	})
+
+	t.Run("only matches executions the user may kill", func(t *testing.T) {
+		svc, enforcer := newTestServiceWithEnforcer(nil, newExecRepo(map[string]bool{}), nil, nil)
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "alice@example.com", authorization.RoleDeveloper))
+		require.NoError(t, enforcer.AddOwnershipForResource(
+			ctx, authorization.FormatResourceID("execution", "exec-b"), "alice@example.com"))
+
+		resp, err := svc.KillExecutions(ctx, "alice@example.com", nil, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-b"}, resp.ExecutionIDs)
+	})
+
+	t.Run("rejects negative age", func(t *testing.T) {
+		svc := newTestService(nil, newExecRepo(map[string]bool{}), nil)
+
+		_, err := svc.KillExecutions(ctx, "admin@example.com", &api.KillExecutionsFilter{OlderThan: -time.Hour}, "")
+
+		require.Error(t, err)
+		assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+	})
+}
+
 func TestGetLogsByExecutionID(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -755,6 +1789,7 @@ func TestGetLogsByExecutionID(t *testing.T) {
 			svc := newTestService(nil, execRepo, runner)
 			email := "test@example.com"
 			clientIP := "127.0.0.1"
+			require.NoError(t, svc.GetEnforcer().AddRoleForUser(ctx, email, authorization.RoleOperator))
 			resp, err := svc.GetLogsByExecutionID(ctx, tt.executionID, &email, &clientIP)
 
 			if tt.expectErr {
@@ -803,6 +1838,148 @@ func TestGetLogsByExecutionID(t *testing.T) {
 	}
 }
 
+func TestGetLogsByExecutionID_Visibility(t *testing.T) {
+	ctx := context.Background()
+	executions := []*api.Execution{
+		{
+			ExecutionID: "exec-private",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionSucceeded),
+			Visibility:  "private",
+		},
+		{
+			ExecutionID: "exec-team",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionSucceeded),
+			Visibility:  "team",
+		},
+		{
+			ExecutionID: "exec-public",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionSucceeded),
+			Visibility:  "public",
+		},
+	}
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return executions, nil
+		},
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			for _, execution := range executions {
+				if execution.ExecutionID == executionID {
+					return execution, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "admin@example.com", authorization.RoleAdmin))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "developer@example.com", authorization.RoleDeveloper))
+
+	tests := []struct {
+		user        string
+		executionID string
+		allowed     bool
+	}{
+		{user: "owner@example.com", executionID: "exec-private", allowed: true},
+		{user: "admin@example.com", executionID: "exec-private", allowed: true},
+		{user: "operator@example.com", executionID: "exec-private", allowed: false},
+		{user: "operator@example.com", executionID: "exec-team", allowed: true},
+		{user: "developer@example.com", executionID: "exec-team", allowed: false},
+		{user: "developer@example.com", executionID: "exec-public", allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user+" reads "+tt.executionID, func(t *testing.T) {
+			user := tt.user
+			resp, err := svc.GetLogsByExecutionID(ctx, tt.executionID, &user, nil)
+			if !tt.allowed {
+				require.Error(t, err)
+				assert.Equal(t, apperrors.ErrCodeForbidden, apperrors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.executionID, resp.ExecutionID)
+		})
+	}
+}
+
+func TestListVisibleExecutions(t *testing.T) {
+	ctx := context.Background()
+	executions := []*api.Execution{
+		{ExecutionID: "exec-1", CreatedBy: "owner@example.com", OwnedBy: []string{"owner@example.com"}},
+		{
+			ExecutionID: "exec-2",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Visibility:  "private",
+		},
+		{
+			ExecutionID: "exec-3",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Visibility:  "public",
+		},
+		{ExecutionID: "exec-4", CreatedBy: "owner@example.com", OwnedBy: []string{"owner@example.com"}},
+	}
+	var limits []int
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, limit int, _ []string) ([]*api.Execution, error) {
+			limits = append(limits, limit)
+			if limit > 0 && limit < len(executions) {
+				return executions[:limit], nil
+			}
+			return executions, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "admin@example.com", authorization.RoleAdmin))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "viewer@example.com", authorization.RoleViewer))
+
+	executionIDs := func(list []*api.Execution) []string {
+		ids := make([]string, 0, len(list))
+		for _, execution := range list {
+			ids = append(ids, execution.ExecutionID)
+		}
+		return ids
+	}
+
+	t.Run("private executions are hidden from other users", func(t *testing.T) {
+		list, err := svc.ListVisibleExecutions(ctx, "viewer@example.com", 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-1", "exec-3", "exec-4"}, executionIDs(list))
+	})
+
+	t.Run("owners and admins see private executions", func(t *testing.T) {
+		for _, user := range []string{"owner@example.com", "admin@example.com"} {
+			list, err := svc.ListVisibleExecutions(ctx, user, 0, nil)
+			require.NoError(t, err)
+			assert.Len(t, list, len(executions), user)
+		}
+	})
+
+	t.Run("limit applies to the visible executions", func(t *testing.T) {
+		limits = nil
+		list, err := svc.ListVisibleExecutions(ctx, "viewer@example.com", 2, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-1", "exec-3"}, executionIDs(list))
+		assert.Equal(t, []int{2, 0}, limits)
+	})
+
+	t.Run("first page is kept when nothing is hidden", func(t *testing.T) {
+		limits = nil
+		list, err := svc.ListVisibleExecutions(ctx, "admin@example.com", 2, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-1", "exec-2"}, executionIDs(list))
+		assert.Equal(t, []int{2}, limits)
+	})
+}
+
 func TestGetLogsByExecutionID_WebSocketToken(t *testing.T) {
 	ctx := context.Background()
 
@@ -941,6 +2118,7 @@ func TestGetLogsByExecutionID_WebSocketToken(t *testing.T) {
 
 			email := "test@example.com"
 			clientIP := "192.168.1.1"
+			require.NoError(t, svc.GetEnforcer().AddRoleForUser(ctx, email, authorization.RoleOperator))
 			resp, err := svc.GetLogsByExecutionID(ctx, tt.executionID, &email, &clientIP)
 
 			if tt.expectErr {
@@ -1049,6 +2227,7 @@ func TestGetLogsByExecutionID_TokenUniqueness(t *testing.T) {
 	for range 3 {
 		email := "test@example.com"
 		clientIP := "10.0.0.1"
+		require.NoError(t, svc.GetEnforcer().AddRoleForUser(ctx, email, authorization.RoleOperator))
 		resp, err := svc.GetLogsByExecutionID(ctx, execution.ExecutionID, &email, &clientIP)
 		require.NoError(t, err)
 		assert.NotEmpty(t, resp.WebSocketURL)
@@ -1276,3 +2455,25 @@ func TestValidateExecutionResourceAccess_EnforcerError(t *testing.T) {
 	// Should fail due to no access, not due to enforcer error
 	assert.Error(t, err)
 }
+
+func TestRunCommand_PassesImageWarmPoolSize(t *testing.T) {
+	ctx := context.Background()
+	var started *api.ExecutionRequest
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			started = req
+			return "exec-warm", timePtr(time.Now()), nil
+		},
+	}
+	svc := newTestService(nil, &mockExecutionRepository{}, runner)
+
+	req := api.ExecutionRequest{Command: "make test"}
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, &api.ImageInfo{
+		ImageID:      "busybox:latest-a1b2c3d4",
+		WarmPoolSize: 3,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, started)
+	assert.Equal(t, 3, started.WarmPoolSize)
+}
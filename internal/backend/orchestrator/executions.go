@@ -1,7 +1,10 @@
 package orchestrator
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -16,6 +19,7 @@ import (
 	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
+	"github.com/runvoy/runvoy/internal/validation"
 )
 
 // ValidateExecutionResourceAccess checks if a user can access all resources required for execution.
@@ -79,11 +83,45 @@ func (s *Service) ValidateExecutionResourceAccess(
 	return nil
 }
 
+// ResolveOnBehalfOf authorizes the actor to start executions on behalf of the user with the email, and returns
+// that user. The actor needs the impersonate permission on the user, and the user must be active.
+func (s *Service) ResolveOnBehalfOf(ctx context.Context, actorEmail, email string) (*api.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	allowed, err := s.GetEnforcer().Enforce(ctx, actorEmail, "/api/v1/users/"+email, authorization.ActionImpersonate)
+	if err != nil {
+		return nil, apperrors.ErrInternalError(
+			"failed to validate impersonation",
+			fmt.Errorf("enforcement error: %w", err),
+		)
+	}
+	if !allowed {
+		return nil, apperrors.ErrForbidden(
+			fmt.Sprintf("you do not have permission to run commands on behalf of %s", email), nil)
+	}
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound(fmt.Sprintf("user %s not found", email), nil)
+	}
+	if user.Revoked {
+		return nil, apperrors.ErrBadRequest(fmt.Sprintf("user %s is revoked", email), nil)
+	}
+	return user, nil
+}
+
 // RunCommand starts a provider-specific task and records the execution.
 // The resolvedImage parameter contains the validated image that will be used for execution.
 // The request's Image field is replaced with the imageID before passing to the runner.
 // Secret references are resolved to environment variables before starting the task.
 // Execution status is set to STARTING after the task has been accepted by the provider.
+// A positive request timeout is recorded on the execution and enforced by the event processor,
+// which terminates the execution and marks it TIMED_OUT once exceeded.
+// New executions are refused while an admin has frozen the runs; dry runs are still validated.
+// Users close to or past their monthly budget get a warning in the response, see checkBudget.
+// A request with a lock is refused while another execution holds the lock, see acquireExecutionLock.
 func (s *Service) RunCommand(
 	ctx context.Context,
 	userEmail string,
@@ -91,45 +129,273 @@ func (s *Service) RunCommand(
 	req *api.ExecutionRequest,
 	resolvedImage *api.ImageInfo,
 ) (*api.ExecutionResponse, error) {
-	if req.Command == "" {
-		return nil, apperrors.ErrBadRequest("command is required", nil)
+	if err := validateExecutionRequest(req); err != nil {
+		return nil, err
+	}
+	if err := s.validateLogRetention(req.LogRetentionDays); err != nil {
+		return nil, err
+	}
+	budgetWarning := ""
+	if !req.DryRun {
+		if err := s.checkRunFreeze(ctx); err != nil {
+			return nil, err
+		}
+		warning, err := s.checkBudget(ctx, userEmail)
+		if err != nil {
+			return nil, err
+		}
+		budgetWarning = warning
 	}
 
+	req.Visibility = string(s.executionVisibility(req.Visibility))
+
 	// Always pass and store the resolved image ID when available
 	if resolvedImage != nil && resolvedImage.ImageID != "" {
 		req.Image = resolvedImage.ImageID
 	}
+	if resolvedImage != nil {
+		req.LogLimits = resolvedImage.LogLimits
+		req.WarmPoolSize = resolvedImage.WarmPoolSize
+		req.ImageAlias = resolvedImage.ResolvedFromAlias
+		applyRunDefaults(req, resolvedImage.RunDefaults)
+	}
 
 	secretEnvVars, err := s.resolveSecretsForExecution(ctx, req.Secrets)
 	if err != nil {
 		return nil, err
 	}
 	s.applyResolvedSecrets(req, secretEnvVars)
+	// Variables declared by the env schema may be provided by the secrets
+	if err = validation.EnvAgainstSchema(req.EnvSchema, req.Env); err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		return &api.ExecutionResponse{
+			Command: req.Command, ImageID: req.Image, ImageAlias: req.ImageAlias, DryRun: true,
+		}, nil
+	}
+
+	setRequestIDEnv(ctx, req)
+
+	if req.Parallel > 0 {
+		resp, groupErr := s.runExecutionGroup(ctx, userEmail, req)
+		if groupErr != nil {
+			return nil, groupErr
+		}
+		resp.BudgetWarning = budgetWarning
+		return resp, nil
+	}
+
+	executionID, err := s.startLockedTask(ctx, userEmail, req)
+	if err != nil {
+		return nil, err
+	}
+
+	websocketURL := s.wsManager.GenerateWebSocketURL(ctx, executionID, &userEmail, clientIPAtCreationTime)
+
+	imageID := req.Image
+
+	return &api.ExecutionResponse{
+		ExecutionID:   executionID,
+		Status:        string(constants.ExecutionStarting),
+		Command:       req.Command,
+		ImageID:       imageID,
+		ImageAlias:    req.ImageAlias,
+		WebSocketURL:  websocketURL,
+		BudgetWarning: budgetWarning,
+	}, nil
+}
+
+// startLockedTask starts the task of the request and records its execution, holding the lock of the request
+// when it has one. The lock is released when the execution could not be started, otherwise once it completes.
+func (s *Service) startLockedTask(
+	ctx context.Context,
+	userEmail string,
+	req *api.ExecutionRequest,
+) (string, error) {
+	lockToken := ""
+	if req.Lock != "" {
+		token, err := s.acquireExecutionLock(ctx, userEmail, req)
+		if err != nil {
+			return "", err
+		}
+		lockToken = token
+	}
 
 	executionID, createdAt, err := s.taskManager.StartTask(ctx, userEmail, req)
 	if err != nil {
-		return nil, apperrors.ErrInternalError("failed to start task", fmt.Errorf("start task: %w", err))
+		if lockToken != "" {
+			s.releaseExecutionLock(ctx, req.Lock, lockToken)
+		}
+		return "", apperrors.ErrInternalError("failed to start task", fmt.Errorf("start task: %w", err))
+	}
+	if lockToken != "" {
+		s.setLockExecution(ctx, req.Lock, lockToken, executionID)
 	}
 
 	if execErr := s.recordExecution(
 		ctx, userEmail, req, executionID, createdAt, constants.ExecutionStarting,
 	); execErr != nil {
-		return nil, fmt.Errorf("failed to record execution: %w", execErr)
+		s.abortExecutions(ctx, []string{executionID}, "execution could not be recorded")
+		if lockToken != "" {
+			s.releaseExecutionLock(ctx, req.Lock, lockToken)
+		}
+		return "", fmt.Errorf("failed to record execution: %w", execErr)
 	}
+	return executionID, nil
+}
 
-	websocketURL := s.wsManager.GenerateWebSocketURL(ctx, executionID, &userEmail, clientIPAtCreationTime)
+// setRequestIDEnv exposes the ID of the API request starting an execution to its command in RUNVOY_REQUEST_ID,
+// so that the command can tag what it does with it and `runvoy trace` ties it back to the request.
+func setRequestIDEnv(ctx context.Context, req *api.ExecutionRequest) {
+	requestID := logger.GetRequestID(ctx)
+	if requestID == "" {
+		return
+	}
+	if req.Env == nil {
+		req.Env = make(map[string]string)
+	}
+	req.Env[constants.RequestIDEnvVar] = requestID
+}
 
-	imageID := req.Image
+// envVarNames returns the sorted names of the environment variables of an execution request.
+// Only names are recorded on the execution: values may hold secrets.
+func envVarNames(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
 
-	return &api.ExecutionResponse{
-		ExecutionID:  executionID,
-		Status:       string(constants.ExecutionStarting),
-		Command:      req.Command,
-		ImageID:      imageID,
-		WebSocketURL: websocketURL,
-	}, nil
+// validateExecutionRequest checks the request fields that do not depend on the caller or stored resources.
+func validateExecutionRequest(req *api.ExecutionRequest) error {
+	if err := resolveCommandArgs(req); err != nil {
+		return err
+	}
+	if req.Command == "" {
+		return apperrors.ErrBadRequest("command is required", nil)
+	}
+	if req.Timeout < 0 {
+		return apperrors.ErrBadRequest("timeout must not be negative", nil)
+	}
+	if req.StopGracePeriod < 0 || req.StopGracePeriod > constants.MaxStopGracePeriodSeconds {
+		return apperrors.ErrBadRequest(
+			fmt.Sprintf("stop grace period must be between 0 and %d seconds", constants.MaxStopGracePeriodSeconds),
+			nil,
+		)
+	}
+	if err := validateShell(req.Shell); err != nil {
+		return err
+	}
+	if req.Shell != "" && len(req.Args) > 0 {
+		return apperrors.ErrBadRequest("shell only applies to a command line, args run without a shell", nil)
+	}
+	if req.Visibility != "" && !constants.ExecutionVisibility(req.Visibility).Valid() {
+		return apperrors.ErrBadRequest(
+			fmt.Sprintf("invalid visibility %q (valid visibilities: %s)", req.Visibility, executionVisibilityNames()),
+			nil,
+		)
+	}
+	if req.Parallel < 0 || req.Parallel > constants.MaxExecutionGroupSize {
+		return apperrors.ErrBadRequest(
+			fmt.Sprintf("parallel must be between 0 and %d", constants.MaxExecutionGroupSize), nil)
+	}
+	if req.Lock != "" {
+		if err := validateLockName(req.Lock); err != nil {
+			return err
+		}
+		if req.Parallel > 0 {
+			return apperrors.ErrBadRequest("lock cannot be combined with parallel", nil)
+		}
+	}
+	if req.ExpectedMaxDuration < 0 {
+		return apperrors.ErrBadRequest("expected max duration must not be negative", nil)
+	}
+	if len(req.Playbook) > constants.MaxPlaybookNameLength {
+		return apperrors.ErrBadRequest(
+			fmt.Sprintf("playbook name must be at most %d characters", constants.MaxPlaybookNameLength), nil)
+	}
+	if err := validateStdin(req); err != nil {
+		return err
+	}
+	if err := validation.EnvSchema(req.EnvSchema); err != nil {
+		return err
+	}
+	if err := validation.Provenance(req.Provenance); err != nil {
+		return err
+	}
+	return validateContext(req)
+}
+
+// validateShell checks the shell of a request or of the run defaults of an image is supported.
+func validateShell(shell string) error {
+	if shell == "" || constants.ExecutionShell(shell).Valid() {
+		return nil
+	}
+	names := make([]string, 0, len(constants.ExecutionShells()))
+	for _, s := range constants.ExecutionShells() {
+		names = append(names, string(s))
+	}
+	return apperrors.ErrBadRequest(
+		fmt.Sprintf("invalid shell %q (valid shells: %s)", shell, strings.Join(names, ", ")), nil)
+}
+
+// applyRunDefaults sets the working directory and the shell the request doesn't set to the run defaults of its
+// image. The default shell doesn't apply to the requests giving their command as arguments.
+func applyRunDefaults(req *api.ExecutionRequest, defaults *api.RunDefaults) {
+	if defaults == nil {
+		return
+	}
+	if req.WorkDir == "" {
+		req.WorkDir = defaults.WorkDir
+	}
+	if req.Shell == "" && len(req.Args) == 0 {
+		req.Shell = defaults.Shell
+	}
 }
 
+// validateLogRetention checks the log retention requested by a run is within the bounds of the service.
+// Zero requests none, the logs are then kept for the retention of the log storage.
+func (s *Service) validateLogRetention(days int) error {
+	if days == 0 {
+		return nil
+	}
+	minDays := cmp.Or(s.LogRetentionMinDays, constants.DefaultLogRetentionMinDays)
+	maxDays := cmp.Or(s.LogRetentionMaxDays, constants.DefaultLogRetentionMaxDays)
+	if days < minDays || days > maxDays {
+		return apperrors.ErrBadRequest(
+			fmt.Sprintf("log retention must be between %d and %d days", minDays, maxDays), nil)
+	}
+	return nil
+}
+
+// executionVisibility returns the requested visibility, or the service's default one when empty.
+func (s *Service) executionVisibility(requested string) constants.ExecutionVisibility {
+	if requested != "" {
+		return constants.ExecutionVisibility(requested)
+	}
+	if s.DefaultExecutionVisibility != "" {
+		return s.DefaultExecutionVisibility
+	}
+	return constants.DefaultExecutionVisibility
+}
+
+// executionVisibilityNames returns the valid execution visibilities as a comma-separated list.
+func executionVisibilityNames() string {
+	names := make([]string, 0, len(constants.ExecutionVisibilities()))
+	for _, visibility := range constants.ExecutionVisibilities() {
+		names = append(names, string(visibility))
+	}
+	return strings.Join(names, ", ")
+}
+
+// recordExecution stores the record of an execution whose task was started and registers it.
 func (s *Service) recordExecution(
 	ctx context.Context,
 	userEmail string,
@@ -139,6 +405,30 @@ func (s *Service) recordExecution(
 	status constants.ExecutionStatus,
 ) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	execution := s.newExecutionRecord(ctx, userEmail, req, executionID, createdAt, status)
+
+	if err := s.repos.Execution.CreateExecution(ctx, execution); err != nil {
+		reqLogger.Error("failed to create execution record of the task accepted by the provider",
+			"context", map[string]string{
+				"execution_id": executionID,
+				"error":        err.Error(),
+			},
+		)
+		return fmt.Errorf("failed to create execution record of the task accepted by the provider: %w", err)
+	}
+	return s.registerExecution(ctx, execution)
+}
+
+// newExecutionRecord returns the record of an execution whose task was started for the request.
+func (s *Service) newExecutionRecord(
+	ctx context.Context,
+	userEmail string,
+	req *api.ExecutionRequest,
+	executionID string,
+	createdAt *time.Time,
+	status constants.ExecutionStatus,
+) *api.Execution {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
 
 	startedAt := time.Now().UTC()
 	if createdAt != nil {
@@ -147,16 +437,31 @@ func (s *Service) recordExecution(
 
 	requestID := logger.ExtractRequestIDFromContext(ctx)
 	execution := &api.Execution{
-		ExecutionID:         executionID,
-		CreatedBy:           userEmail,
-		OwnedBy:             []string{userEmail},
-		Command:             req.Command,
-		ImageID:             req.Image,
-		StartedAt:           startedAt,
-		Status:              string(status),
-		CreatedByRequestID:  requestID,
-		ModifiedByRequestID: requestID,
-		ComputePlatform:     string(s.Provider),
+		ExecutionID:                executionID,
+		CreatedBy:                  userEmail,
+		OwnedBy:                    []string{userEmail},
+		Command:                    req.Command,
+		ImageID:                    req.Image,
+		StartedAt:                  startedAt,
+		Status:                     string(status),
+		TimeoutSeconds:             req.Timeout,
+		StopGracePeriodSeconds:     req.StopGracePeriod,
+		EnvVarNames:                envVarNames(req.Env),
+		CreatedByRequestID:         requestID,
+		ModifiedByRequestID:        requestID,
+		ComputePlatform:            string(s.Provider),
+		Visibility:                 req.Visibility,
+		LogLimits:                  req.LogLimits,
+		LogRetentionDays:           req.LogRetentionDays,
+		GroupID:                    req.GroupID,
+		ShardIndex:                 req.ShardIndex,
+		Lock:                       req.Lock,
+		ImpersonatedBy:             req.ImpersonatedBy,
+		Playbook:                   req.Playbook,
+		ExpectedMaxDurationSeconds: req.ExpectedMaxDuration,
+		ImageAlias:                 req.ImageAlias,
+		Secrets:                    executionSecretNames(req.Secrets),
+		Provenance:                 req.Provenance,
 	}
 
 	if requestID == "" {
@@ -165,29 +470,71 @@ func (s *Service) recordExecution(
 		)
 	}
 
-	if err := s.repos.Execution.CreateExecution(ctx, execution); err != nil {
-		reqLogger.Error("failed to create execution record, but task has been accepted by the provider",
-			"context", map[string]string{
-				"execution_id": executionID,
-				"error":        err.Error(),
-			},
-		)
-		return fmt.Errorf("failed to create execution record, but task has been accepted by the provider: %w", err)
-	}
+	return execution
+}
+
+// registerExecution records the usage of the secrets of a stored execution and synchronizes its ownership and
+// visibility with the enforcer.
+func (s *Service) registerExecution(ctx context.Context, execution *api.Execution) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	executionID := execution.ExecutionID
+
+	s.recordSecretsUsage(ctx, execution)
 
 	if err := s.addExecutionOwnershipToEnforcer(ctx, executionID, execution.OwnedBy); err != nil {
 		reqLogger.Error("failed to synchronize execution ownership with enforcer", "context", map[string]string{
 			"execution_id": executionID,
-			"user":         userEmail,
+			"user":         execution.CreatedBy,
 			"error":        err.Error(),
 		})
 		return fmt.Errorf("failed to synchronize execution ownership: %w", err)
 	}
 
+	if err := s.enforcer.SetExecutionVisibility(ctx, executionID, execution.Visibility); err != nil {
+		reqLogger.Error("failed to synchronize execution visibility with enforcer", "context", map[string]string{
+			"execution_id": executionID,
+			"visibility":   execution.Visibility,
+			"error":        err.Error(),
+		})
+		return fmt.Errorf("failed to synchronize execution visibility: %w", err)
+	}
+
+	if execution.ImpersonatedBy != "" {
+		reqLogger.Info("audit: execution started on behalf of user", "context", map[string]string{
+			"execution_id": executionID,
+			"actor":        execution.ImpersonatedBy,
+			"on_behalf_of": execution.CreatedBy,
+		})
+	}
+
 	return nil
 }
 
+// abortExecutions stops the executions started by a request that failed, so that a failure never leaves
+// a running task behind a missing or half-registered record. Executions without a record only have their
+// task stopped. Failures are logged, the error that aborted the request is the one returned to the caller.
+func (s *Service) abortExecutions(ctx context.Context, executionIDs []string, reason string) {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+
+	for _, executionID := range executionIDs {
+		execution, err := s.repos.Execution.GetExecution(ctx, executionID)
+		if err == nil && execution == nil {
+			err = s.taskManager.KillTask(ctx, executionID)
+		} else if err == nil {
+			_, err = s.terminateExecution(ctx, execution, constants.SystemActor, reason)
+		}
+		if err != nil {
+			reqLogger.Warn("failed to stop execution of aborted request", "context", map[string]any{
+				"execution_id": executionID,
+				"reason":       reason,
+				"error":        err.Error(),
+			})
+		}
+	}
+}
+
 // GetLogsByExecutionID returns aggregated Cloud logs for a given execution.
+// When userEmail is set, the user must be allowed to read the execution given its visibility.
 // WebSocket endpoint is stored without protocol (normalized in config).
 // Always use wss:// for production WebSocket connections.
 // userEmail: authenticated user email for audit trail.
@@ -211,6 +558,11 @@ func (s *Service) GetLogsByExecutionID(
 	if execution == nil {
 		return nil, apperrors.ErrNotFound("execution not found", nil)
 	}
+	if userEmail != nil {
+		if authErr := s.authorizeExecutionRead(ctx, *userEmail, executionID); authErr != nil {
+			return nil, authErr
+		}
+	}
 
 	isTerminal := slices.ContainsFunc(constants.TerminalExecutionStatuses(), func(status constants.ExecutionStatus) bool {
 		return execution.Status == string(status)
@@ -227,10 +579,11 @@ func (s *Service) GetLogsByExecutionID(
 			logEvents = []api.LogEvent{}
 		}
 		return &api.LogsResponse{
-			ExecutionID:  executionID,
-			Status:       execution.Status,
-			Events:       logEvents,
-			WebSocketURL: "", // Empty string will be omitted due to omitempty tag
+			ExecutionID:     executionID,
+			Status:          execution.Status,
+			Events:          logEvents,
+			WebSocketURL:    "", // Empty string will be omitted due to omitempty tag
+			ResourceSummary: execution.ResourceSummary,
 		}, nil
 	}
 
@@ -382,8 +735,11 @@ func fetchResourceByRequestID[T any](
 	})
 }
 
-// GetExecutionStatus returns the current status and metadata for a given execution ID.
-func (s *Service) GetExecutionStatus(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error) {
+// GetExecutionStatus returns the current status and metadata for a given execution ID,
+// if userEmail may read the execution.
+func (s *Service) GetExecutionStatus(
+	ctx context.Context, userEmail, executionID string,
+) (*api.ExecutionStatusResponse, error) {
 	if executionID == "" {
 		return nil, apperrors.ErrBadRequest("executionID is required", nil)
 	}
@@ -396,6 +752,9 @@ func (s *Service) GetExecutionStatus(ctx context.Context, executionID string) (*
 	if execution == nil {
 		return nil, apperrors.ErrNotFound("execution not found", nil)
 	}
+	if err = s.authorizeExecutionRead(ctx, userEmail, executionID); err != nil {
+		return nil, err
+	}
 
 	var exitCodePtr *int
 	if execution.CompletedAt != nil {
@@ -409,15 +768,148 @@ func (s *Service) GetExecutionStatus(ctx context.Context, executionID string) (*
 		Status:      execution.Status,
 		Command:     execution.Command,
 		ImageID:     execution.ImageID,
+		ImageAlias:  execution.ImageAlias,
 		ExitCode:    exitCodePtr,
 		StartedAt:   execution.StartedAt,
 		CompletedAt: execution.CompletedAt,
+
+		FailureReason:   execution.FailureReason,
+		FailureMessage:  execution.FailureMessage,
+		GroupID:         execution.GroupID,
+		KillRequestedBy: execution.KillRequestedBy,
+		KillRequestedAt: execution.KillRequestedAt,
+		KillStalled:     execution.KillStalled,
+		ResourceSummary: execution.ResourceSummary,
+		Annotations:     execution.Annotations,
+		Provenance:      execution.Provenance,
+	}, nil
+}
+
+// GetExecutionEvents returns the lifecycle timeline of an execution, oldest event first.
+// The SUBMITTED event is derived from the execution start time; the following events are recorded
+// by the event processor from the compute platform task state changes. The user must be allowed to read the execution.
+func (s *Service) GetExecutionEvents(
+	ctx context.Context, userEmail, executionID string,
+) (*api.ExecutionEventsResponse, error) {
+	if executionID == "" {
+		return nil, apperrors.ErrBadRequest("executionID is required", nil)
+	}
+
+	execution, err := s.repos.Execution.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("get execution: %w", err)
+	}
+	if execution == nil {
+		return nil, apperrors.ErrNotFound("execution not found", nil)
+	}
+	if err = s.authorizeExecutionRead(ctx, userEmail, executionID); err != nil {
+		return nil, err
+	}
+
+	recorded, err := s.repos.Execution.ListExecutionEvents(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("list execution events: %w", err)
+	}
+
+	events := make([]api.ExecutionEvent, 0, len(recorded)+1)
+	events = append(events, api.ExecutionEvent{
+		Type:      string(constants.ExecutionEventSubmitted),
+		Timestamp: execution.StartedAt,
+	})
+	for i := range recorded {
+		if recorded[i].Type != string(constants.ExecutionEventSubmitted) {
+			events = append(events, recorded[i])
+		}
+	}
+
+	return &api.ExecutionEventsResponse{
+		ExecutionID: execution.ExecutionID,
+		Events:      events,
+	}, nil
+}
+
+// GetExecutionResult returns the JSON result file written by the command of an execution, recorded
+// by the event processor from its logs. The user must be allowed to read the execution.
+// Returns a not found error until the execution reports a result.
+func (s *Service) GetExecutionResult(
+	ctx context.Context, userEmail, executionID string,
+) (*api.ExecutionResultResponse, error) {
+	if executionID == "" {
+		return nil, apperrors.ErrBadRequest("executionID is required", nil)
+	}
+
+	execution, err := s.repos.Execution.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("get execution: %w", err)
+	}
+	if execution == nil {
+		return nil, apperrors.ErrNotFound("execution not found", nil)
+	}
+	if err = s.authorizeExecutionRead(ctx, userEmail, executionID); err != nil {
+		return nil, err
+	}
+	if len(execution.Result) == 0 {
+		return nil, apperrors.ErrNotFound(fmt.Sprintf("execution %s has not reported a result", executionID), nil)
+	}
+
+	return &api.ExecutionResultResponse{
+		ExecutionID: execution.ExecutionID,
+		Result:      execution.Result,
 	}, nil
 }
 
-// KillExecution terminates a running execution identified by executionID.
-// It verifies the execution exists in the database and checks task status before termination.
-// Updates the execution status to TERMINATING after successful task stop.
+// AnnotateExecution attaches a note to an execution after the fact, recording its author and time.
+// The author must be allowed to read the execution, through their role or ownership.
+func (s *Service) AnnotateExecution(
+	ctx context.Context,
+	userEmail, executionID string,
+	req *api.ExecutionAnnotationRequest,
+) (*api.ExecutionAnnotation, error) {
+	if executionID == "" {
+		return nil, apperrors.ErrBadRequest("executionID is required", nil)
+	}
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, apperrors.ErrBadRequest("note is required", nil)
+	}
+
+	execution, err := s.repos.Execution.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("get execution: %w", err)
+	}
+	if execution == nil {
+		return nil, apperrors.ErrNotFound("execution not found", nil)
+	}
+	if err = s.authorizeExecutionRead(ctx, userEmail, executionID); err != nil {
+		return nil, err
+	}
+	if len(execution.Annotations) >= constants.MaxExecutionAnnotations {
+		return nil, apperrors.ErrConflict(
+			fmt.Sprintf("execution %s already has %d annotations, the maximum", executionID, len(execution.Annotations)),
+			nil)
+	}
+
+	annotation := &api.ExecutionAnnotation{
+		Note:      note,
+		Author:    userEmail,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = s.repos.Execution.AddExecutionAnnotation(ctx, executionID, annotation); err != nil {
+		return nil, fmt.Errorf("add execution annotation: %w", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("execution annotated", "context", map[string]string{
+		"execution_id": executionID,
+		"author":       userEmail,
+	})
+
+	return annotation, nil
+}
+
+// KillExecution terminates a running execution identified by executionID, on behalf of userEmail.
+// It verifies the execution exists in the database and checks its status before termination.
+// The kill is recorded with the user who requested it and the execution marked as TERMINATING before
+// its task is stopped, the event processor recording the STOPPED status once the task stopped.
 //
 // This operation is idempotent: if the execution is already in a terminal state (SUCCEEDED, FAILED,
 // STOPPED, TERMINATING), it returns nil, nil (which results in HTTP 204 No Content), indicating
@@ -425,13 +917,47 @@ func (s *Service) GetExecutionStatus(ctx context.Context, executionID string) (*
 // If termination is initiated, returns a KillExecutionResponse with the execution ID and a success message.
 //
 // Returns an error if the execution is not found or termination fails.
-func (s *Service) KillExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error) {
+func (s *Service) KillExecution(
+	ctx context.Context,
+	userEmail, executionID string,
+) (*api.KillExecutionResponse, error) {
+	execution, err := s.getExecutionToTerminate(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.terminateExecution(ctx, execution, userEmail, "Execution termination initiated")
+}
+
+// StopExecution gracefully stops a running execution identified by executionID.
+// The command receives SIGTERM and is given the grace period requested when the execution was started
+// to run its cleanup before being killed, so it is only allowed for executions started with a grace period.
+//
+// Like KillExecution, it returns nil, nil if the execution is already in a terminal state.
+func (s *Service) StopExecution(
+	ctx context.Context,
+	userEmail, executionID string,
+) (*api.KillExecutionResponse, error) {
+	execution, err := s.getExecutionToTerminate(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if execution.StopGracePeriodSeconds <= 0 &&
+		constants.CanTransition(constants.ExecutionStatus(execution.Status), constants.ExecutionTerminating) {
+		return nil, apperrors.ErrBadRequest(
+			"execution was started without a stop grace period, use kill instead", nil)
+	}
+
+	return s.terminateExecution(ctx, execution, userEmail,
+		fmt.Sprintf("Graceful stop initiated, the command has %d seconds to exit", execution.StopGracePeriodSeconds))
+}
+
+// getExecutionToTerminate loads the execution targeted by a kill or stop request.
+func (s *Service) getExecutionToTerminate(ctx context.Context, executionID string) (*api.Execution, error) {
 	if executionID == "" {
 		return nil, apperrors.ErrBadRequest("executionID is required", nil)
 	}
 
-	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
-
 	execution, err := s.repos.Execution.GetExecution(ctx, executionID)
 	if err != nil {
 		// Wrap the error - AppError types will still be found via errors.As() in the chain
@@ -440,6 +966,20 @@ func (s *Service) KillExecution(ctx context.Context, executionID string) (*api.K
 	if execution == nil {
 		return nil, apperrors.ErrNotFound("execution not found", nil)
 	}
+	return execution, nil
+}
+
+// terminateExecution records the kill requested by requestedBy, marks the execution as TERMINATING and
+// stops its task. It returns nil, nil if the execution is already in a terminal state.
+// A task failing to stop leaves the execution TERMINATING, the event processor kills it again once the
+// kill stalled.
+func (s *Service) terminateExecution(
+	ctx context.Context,
+	execution *api.Execution,
+	requestedBy, message string,
+) (*api.KillExecutionResponse, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	executionID := execution.ExecutionID
 
 	currentStatus := constants.ExecutionStatus(execution.Status)
 	targetStatus := constants.ExecutionTerminating
@@ -453,26 +993,219 @@ func (s *Service) KillExecution(ctx context.Context, executionID string) (*api.K
 		return nil, nil
 	}
 
-	if killErr := s.taskManager.KillTask(ctx, executionID); killErr != nil {
-		return nil, apperrors.ErrInternalError("failed to kill task", fmt.Errorf("kill task: %w", killErr))
-	}
-
+	requestedAt := time.Now().UTC()
+	execution.KillRequestedBy = requestedBy
+	execution.KillRequestedAt = &requestedAt
 	if updateErr := s.updateExecutionStatus(ctx, execution, targetStatus, reqLogger); updateErr != nil {
-		return nil, updateErr
+		if apperrors.GetErrorCode(updateErr) != apperrors.ErrCodeConcurrentModification {
+			return nil, updateErr
+		}
+		// The processor recorded a status of the execution since it was read, the task is killed if that
+		// status is final, otherwise the kill races with another update and fails with the conflict
+		current, getErr := s.repos.Execution.GetExecution(ctx, executionID)
+		if getErr != nil || current == nil ||
+			constants.CanTransition(constants.ExecutionStatus(current.Status), targetStatus) {
+			return nil, updateErr
+		}
+		reqLogger.Info("execution completed while being killed", "context", map[string]any{
+			"execution_id": executionID,
+			"status":       current.Status,
+		})
+		return &api.KillExecutionResponse{ExecutionID: executionID, Message: message}, nil
 	}
 
 	reqLogger.Info("execution updated successfully", "context", map[string]any{
-		"execution_id": executionID,
-		"status":       execution.Status,
-		"started_at":   execution.StartedAt.String(),
+		"execution_id":      executionID,
+		"status":            execution.Status,
+		"started_at":        execution.StartedAt.String(),
+		"kill_requested_by": requestedBy,
 	})
 
+	if killErr := s.taskManager.KillTask(ctx, executionID); killErr != nil {
+		return nil, apperrors.ErrInternalError("failed to kill task, it will be killed again",
+			fmt.Errorf("kill task: %w", killErr))
+	}
+
 	return &api.KillExecutionResponse{
 		ExecutionID: executionID,
-		Message:     "Execution termination initiated",
+		Message:     message,
 	}, nil
 }
 
+// KillExecutions terminates every execution matching the filter that the user is allowed to kill.
+//
+// Without a confirmationToken this is a dry run: nothing is killed and the response lists the matching
+// execution IDs along with the token required to confirm the operation. The token is derived from the
+// matching execution IDs, so if the set of matching executions changes before confirmation the request
+// is rejected with a conflict error and must be previewed again.
+//
+// Each matching execution is killed with the same semantics as KillExecution; executions already in a
+// terminal state are reported as skipped and individual failures do not abort the remaining kills.
+func (s *Service) KillExecutions(
+	ctx context.Context,
+	userEmail string,
+	filter *api.KillExecutionsFilter,
+	confirmationToken string,
+) (*api.KillExecutionsResponse, error) {
+	if userEmail == "" {
+		return nil, apperrors.ErrBadRequest("user email is required", nil)
+	}
+	if filter == nil {
+		filter = &api.KillExecutionsFilter{}
+	}
+	if filter.OlderThan < 0 {
+		return nil, apperrors.ErrBadRequest("older than must not be negative", nil)
+	}
+
+	executionIDs, err := s.matchExecutionsToKill(ctx, userEmail, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(executionIDs) > constants.MaxBulkKillExecutions {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("%d executions match, at most %d can be killed at once: narrow the filters",
+				len(executionIDs), constants.MaxBulkKillExecutions),
+			nil,
+		)
+	}
+
+	resp := &api.KillExecutionsResponse{
+		ExecutionIDs: executionIDs,
+		DryRun:       confirmationToken == "",
+	}
+	if len(executionIDs) == 0 {
+		return resp, nil
+	}
+
+	expectedToken := bulkKillConfirmationToken(executionIDs)
+	if resp.DryRun {
+		resp.ConfirmationToken = expectedToken
+		return resp, nil
+	}
+	if confirmationToken != expectedToken {
+		return nil, apperrors.ErrConflict(
+			"matching executions changed since the confirmation token was issued, preview the kill again",
+			nil,
+		)
+	}
+
+	s.killMatchedExecutions(ctx, userEmail, resp)
+	return resp, nil
+}
+
+// killMatchedExecutions kills every execution listed in resp and records the outcome of each kill in resp.
+func (s *Service) killMatchedExecutions(ctx context.Context, userEmail string, resp *api.KillExecutionsResponse) {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	for _, executionID := range resp.ExecutionIDs {
+		killResp, killErr := s.KillExecution(ctx, userEmail, executionID)
+		switch {
+		case killErr != nil:
+			reqLogger.Error("failed to kill execution during bulk kill", "context", map[string]any{
+				"execution_id": executionID,
+				"error":        killErr,
+			})
+			resp.Failed = append(resp.Failed, api.KillExecutionFailure{
+				ExecutionID: executionID,
+				Error:       killErr.Error(),
+			})
+		case killResp == nil:
+			resp.Skipped = append(resp.Skipped, executionID)
+		default:
+			resp.Killed = append(resp.Killed, executionID)
+		}
+	}
+
+	reqLogger.Info("bulk kill completed", "context", map[string]any{
+		"user":    userEmail,
+		"matched": len(resp.ExecutionIDs),
+		"killed":  len(resp.Killed),
+		"skipped": len(resp.Skipped),
+		"failed":  len(resp.Failed),
+	})
+}
+
+// matchExecutionsToKill returns the sorted IDs of the executions matching the filter
+// that the user is authorized to kill.
+func (s *Service) matchExecutionsToKill(
+	ctx context.Context,
+	userEmail string,
+	filter *api.KillExecutionsFilter,
+) ([]string, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		for _, status := range constants.KillableExecutionStatuses() {
+			statuses = append(statuses, string(status))
+		}
+	}
+
+	executions, err := s.ListExecutions(ctx, 0, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-filter.OlderThan)
+	enforcer := s.GetEnforcer()
+	executionIDs := make([]string, 0, len(executions))
+	for _, execution := range executions {
+		if filter.CreatedBy != "" && execution.CreatedBy != filter.CreatedBy {
+			continue
+		}
+		if filter.OlderThan > 0 && execution.StartedAt.After(cutoff) {
+			continue
+		}
+
+		allowed, enforceErr := canAccessExecution(
+			ctx, enforcer, userEmail, execution.ExecutionID, authorization.ActionDelete)
+		if enforceErr != nil {
+			return nil, apperrors.ErrInternalError(
+				"failed to validate execution access",
+				fmt.Errorf("enforcement error: %w", enforceErr),
+			)
+		}
+		if !allowed {
+			continue
+		}
+		executionIDs = append(executionIDs, execution.ExecutionID)
+	}
+
+	slices.Sort(executionIDs)
+	return executionIDs, nil
+}
+
+// canAccessExecution reports whether the user may perform the action on the execution, either through
+// a role granting that access to executions or through ownership of the execution.
+func canAccessExecution(
+	ctx context.Context,
+	enforcer *authorization.Enforcer,
+	userEmail, executionID string,
+	action authorization.Action,
+) (bool, error) {
+	allowed, err := enforcer.Enforce(ctx, userEmail, authorization.FormatExecutionObject(executionID), action)
+	if err != nil || allowed {
+		return allowed, err
+	}
+	return enforcer.HasOwnershipForResource(authorization.FormatResourceID("execution", executionID), userEmail)
+}
+
+// authorizeExecutionRead returns a forbidden error unless the user may read the execution,
+// taking its visibility into account.
+func (s *Service) authorizeExecutionRead(ctx context.Context, userEmail, executionID string) error {
+	allowed, err := canAccessExecution(ctx, s.GetEnforcer(), userEmail, executionID, authorization.ActionRead)
+	if err != nil {
+		return apperrors.ErrInternalError("failed to check execution access", err)
+	}
+	if !allowed {
+		return apperrors.ErrForbidden(fmt.Sprintf("not allowed to read execution %s", executionID), nil)
+	}
+	return nil
+}
+
+// bulkKillConfirmationToken derives the token confirming a bulk kill of the given sorted execution IDs.
+func bulkKillConfirmationToken(executionIDs []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(executionIDs, "\n")))
+	return hex.EncodeToString(sum[:])[:constants.BulkKillConfirmationTokenLength]
+}
+
 // updateExecutionStatus updates an execution's status and persists it to the database.
 func (s *Service) updateExecutionStatus(
 	ctx context.Context,
@@ -494,6 +1227,9 @@ func (s *Service) updateExecutionStatus(
 			"status":       execution.Status,
 			"error":        updateErr.Error(),
 		})
+		if apperrors.GetErrorCode(updateErr) == apperrors.ErrCodeConcurrentModification {
+			return fmt.Errorf("update execution: %w", updateErr)
+		}
 		return apperrors.ErrDatabaseError("failed to update execution", fmt.Errorf("update execution: %w", updateErr))
 	}
 
@@ -523,6 +1259,123 @@ func (s *Service) ListExecutions(ctx context.Context, limit int, statuses []stri
 	return executions, nil
 }
 
+// listExecutions returns the executions of ListExecutions, only those created by createdBy when set.
+// Without createdBy, search terms are matched by the database, only returning the executions containing them;
+// the executions of a creator are few enough to be matched by the caller.
+func (s *Service) listExecutions(
+	ctx context.Context,
+	createdBy string,
+	terms []string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	if createdBy == "" && len(terms) > 0 {
+		executions, err := s.repos.Execution.SearchExecutions(ctx, terms, limit, statuses)
+		if err != nil {
+			var appErr *apperrors.AppError
+			if errors.As(err, &appErr) {
+				return nil, fmt.Errorf("search executions: %w", err)
+			}
+			return nil, apperrors.ErrInternalError(
+				"failed to list executions", fmt.Errorf("search executions: %w", err))
+		}
+		return executions, nil
+	}
+	if createdBy == "" {
+		return s.ListExecutions(ctx, limit, statuses)
+	}
+	executions, err := s.repos.Execution.ListExecutionsByCreator(ctx, createdBy, limit, statuses)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, fmt.Errorf("list executions by creator: %w", err)
+		}
+		return nil, apperrors.ErrInternalError(
+			"failed to list executions", fmt.Errorf("list executions by creator: %w", err))
+	}
+	return executions, nil
+}
+
+// ListVisibleExecutions returns the executions listed to the user, with the same filtering and
+// ordering as ListExecutions. Private executions are only listed to users allowed to read them.
+// The limit applies to the listed executions: when hidden executions are filtered out of the
+// first page, all executions matching the statuses are listed to fill it.
+func (s *Service) ListVisibleExecutions(
+	ctx context.Context,
+	userEmail string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	return s.listMatchingExecutions(ctx, userEmail, limit, statuses, "", nil, nil)
+}
+
+// listMatchingExecutions lists the executions visible to the user for which match returns true,
+// all visible executions when match is nil, filling the limit like ListVisibleExecutions.
+// A non-empty createdBy only lists the executions created by that user email, from the index of their creator,
+// and search terms only list the executions matching them, see listExecutions.
+func (s *Service) listMatchingExecutions(
+	ctx context.Context,
+	userEmail string,
+	limit int,
+	statuses []string,
+	createdBy string,
+	terms []string,
+	match func(*api.Execution) bool,
+) ([]*api.Execution, error) {
+	executions, err := s.listExecutions(ctx, createdBy, terms, limit, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := s.filterVisibleExecutions(ctx, userEmail, executions, match)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || len(visible) == len(executions) || len(executions) < limit {
+		return visible, nil
+	}
+
+	executions, err = s.listExecutions(ctx, createdBy, terms, 0, statuses)
+	if err != nil {
+		return nil, err
+	}
+	visible, err = s.filterVisibleExecutions(ctx, userEmail, executions, match)
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) > limit {
+		visible = visible[:limit]
+	}
+	return visible, nil
+}
+
+func (s *Service) filterVisibleExecutions(
+	ctx context.Context,
+	userEmail string,
+	executions []*api.Execution,
+	match func(*api.Execution) bool,
+) ([]*api.Execution, error) {
+	enforcer := s.GetEnforcer()
+	visible := make([]*api.Execution, 0, len(executions))
+	for _, execution := range executions {
+		if match != nil && !match(execution) {
+			continue
+		}
+		if execution.Visibility != string(constants.ExecutionVisibilityPrivate) {
+			visible = append(visible, execution)
+			continue
+		}
+		allowed, err := canAccessExecution(ctx, enforcer, userEmail, execution.ExecutionID, authorization.ActionRead)
+		if err != nil {
+			return nil, apperrors.ErrInternalError("failed to check execution access", err)
+		}
+		if allowed {
+			visible = append(visible, execution)
+		}
+	}
+	return visible, nil
+}
+
 func (s *Service) addExecutionOwnershipToEnforcer(ctx context.Context, executionID string, ownedBy []string) error {
 	resourceID := authorization.FormatResourceID("execution", executionID)
 	for _, owner := range ownedBy {
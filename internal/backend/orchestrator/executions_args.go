@@ -0,0 +1,31 @@
+package orchestrator
+
+import (
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/shellquote"
+)
+
+// ResolveCommandArgs sets the command of a request giving it as arguments to the equivalent shell command
+// line, before the command is matched by the command policies, recorded and shown. The runners still start
+// the arguments without a shell.
+func (*Service) ResolveCommandArgs(req *api.ExecutionRequest) error {
+	return resolveCommandArgs(req)
+}
+
+// resolveCommandArgs checks the arguments of a request and sets its command from them. Running it again on
+// the resolved request keeps it as is.
+func resolveCommandArgs(req *api.ExecutionRequest) error {
+	if len(req.Args) == 0 {
+		return nil
+	}
+	if req.Args[0] == "" {
+		return apperrors.ErrBadRequest("the first argument must be the program to run", nil)
+	}
+	commandLine := shellquote.Join(req.Args)
+	if req.Command != "" && req.Command != commandLine {
+		return apperrors.ErrBadRequest("command and args are mutually exclusive", nil)
+	}
+	req.Command = commandLine
+	return nil
+}
@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestResolveCommandArgs(t *testing.T) {
+	t.Run("sets the command to the quoted arguments", func(t *testing.T) {
+		req := &api.ExecutionRequest{Args: []string{"grep", "-r", "it's here", "$HOME"}}
+
+		require.NoError(t, resolveCommandArgs(req))
+		assert.Equal(t, `grep -r 'it'\''s here' '$HOME'`, req.Command)
+		assert.Equal(t, []string{"grep", "-r", "it's here", "$HOME"}, req.Args)
+
+		require.NoError(t, resolveCommandArgs(req), "resolving again keeps the request")
+		assert.Equal(t, `grep -r 'it'\''s here' '$HOME'`, req.Command)
+	})
+
+	t.Run("keeps a shell command", func(t *testing.T) {
+		req := &api.ExecutionRequest{Command: "make build && make test"}
+
+		require.NoError(t, resolveCommandArgs(req))
+		assert.Equal(t, "make build && make test", req.Command)
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		for _, req := range []*api.ExecutionRequest{
+			{Args: []string{"", "make"}},
+			{Command: "make test", Args: []string{"make", "build"}},
+		} {
+			err := resolveCommandArgs(req)
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err), "request %+v", req)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// CreateContextUpload prepares the upload of a local directory archive,
+// extracted as the working directory of an upcoming execution.
+func (s *Service) CreateContextUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error) {
+	if size <= 0 || size > constants.MaxContextBytes {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("context size must be between 1 and %d bytes", constants.MaxContextBytes), nil)
+	}
+
+	uploadID := auth.GenerateUUID()
+	uploadURL, expiresAt, err := s.taskManager.CreateContextUpload(ctx, uploadID, size)
+	if err != nil {
+		// Wrap the error - AppError types will still be found via errors.As() in the chain
+		return nil, fmt.Errorf("create context upload: %w", err)
+	}
+
+	return &api.InputUploadResponse{
+		UploadID:  uploadID,
+		UploadURL: uploadURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// validateContext checks the working directory archive referenced by an execution request.
+// Both the archive and a git repository would provide the working directory, so they are exclusive.
+func validateContext(req *api.ExecutionRequest) error {
+	if req.ContextUploadID == "" {
+		return nil
+	}
+	if req.GitRepo != "" {
+		return apperrors.ErrBadRequest("context_upload_id and git_repo are mutually exclusive", nil)
+	}
+	if !inputUploadIDPattern.MatchString(req.ContextUploadID) {
+		return apperrors.ErrBadRequest("invalid context upload ID", nil)
+	}
+	return nil
+}
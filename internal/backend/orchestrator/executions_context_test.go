@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestCreateContextUpload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the upload URL from the task manager", func(t *testing.T) {
+		runner := &mockRunner{
+			createContextUploadFunc: func(_ context.Context, _ string, size int64) (string, time.Time, error) {
+				assert.Equal(t, int64(2048), size)
+				return "https://uploads.example.com/context", time.Now().Add(15 * time.Minute), nil
+			},
+		}
+		svc := newTestService(nil, nil, runner)
+
+		resp, err := svc.CreateContextUpload(ctx, 2048)
+
+		require.NoError(t, err)
+		assert.Regexp(t, `^[0-9a-f]{32}$`, resp.UploadID)
+		assert.Equal(t, "https://uploads.example.com/context", resp.UploadURL)
+	})
+
+	t.Run("rejects invalid sizes", func(t *testing.T) {
+		svc := newTestService(nil, nil, nil)
+
+		for _, size := range []int64{0, constants.MaxContextBytes + 1} {
+			_, err := svc.CreateContextUpload(ctx, size)
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err), "size %d", size)
+		}
+	})
+}
+
+func TestValidateContext(t *testing.T) {
+	uploadID := strings.Repeat("ab", 16)
+
+	tests := []struct {
+		name    string
+		req     api.ExecutionRequest
+		wantErr string
+	}{
+		{name: "no context"},
+		{name: "uploaded context", req: api.ExecutionRequest{ContextUploadID: uploadID}},
+		{
+			name:    "context with git repository",
+			req:     api.ExecutionRequest{ContextUploadID: uploadID, GitRepo: "https://github.com/runvoy/runvoy.git"},
+			wantErr: "mutually exclusive",
+		},
+		{name: "invalid upload ID", req: api.ExecutionRequest{ContextUploadID: "../other"}, wantErr: "invalid context upload ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateContext(&tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+		})
+	}
+}
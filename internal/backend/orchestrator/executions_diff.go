@@ -0,0 +1,199 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DiffExecutions compares the snapshots of two executions: image, command, environment variable names,
+// status, duration and exit code. When logLines is positive, the final logLines log lines of both
+// executions are also compared line by line.
+// The user must be allowed to read both executions, through their role or ownership.
+func (s *Service) DiffExecutions(
+	ctx context.Context,
+	userEmail string,
+	executionIDA, executionIDB string,
+	logLines int,
+) (*api.ExecutionDiffResponse, error) {
+	if executionIDA == "" || executionIDB == "" {
+		return nil, apperrors.ErrBadRequest("two execution IDs are required", nil)
+	}
+	if logLines < 0 || logLines > constants.MaxExecutionDiffLogLines {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("log lines must be between 0 and %d", constants.MaxExecutionDiffLogLines), nil)
+	}
+
+	executionA, err := s.getExecutionForDiff(ctx, userEmail, executionIDA)
+	if err != nil {
+		return nil, err
+	}
+	executionB, err := s.getExecutionForDiff(ctx, userEmail, executionIDB)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotA := toExecutionSnapshot(executionA)
+	snapshotB := toExecutionSnapshot(executionB)
+	resp := &api.ExecutionDiffResponse{
+		ExecutionA:  snapshotA,
+		ExecutionB:  snapshotB,
+		Differences: diffExecutionSnapshots(&snapshotA, &snapshotB),
+	}
+
+	if logLines > 0 {
+		resp.LogDiff, err = s.diffExecutionLogs(ctx, executionIDA, executionIDB, logLines)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Service) getExecutionForDiff(
+	ctx context.Context,
+	userEmail, executionID string,
+) (*api.Execution, error) {
+	if err := s.authorizeExecutionRead(ctx, userEmail, executionID); err != nil {
+		return nil, err
+	}
+
+	execution, err := s.repos.Execution.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("get execution: %w", err)
+	}
+	if execution == nil {
+		return nil, apperrors.ErrNotFound(fmt.Sprintf("execution %s not found", executionID), nil)
+	}
+	return execution, nil
+}
+
+func toExecutionSnapshot(execution *api.Execution) api.ExecutionSnapshot {
+	envVarNames := execution.EnvVarNames
+	if envVarNames == nil {
+		envVarNames = []string{}
+	}
+	return api.ExecutionSnapshot{
+		ExecutionID:     execution.ExecutionID,
+		Status:          execution.Status,
+		ImageID:         execution.ImageID,
+		Command:         execution.Command,
+		EnvVarNames:     envVarNames,
+		StartedAt:       execution.StartedAt,
+		DurationSeconds: execution.DurationSeconds,
+		ExitCode:        execution.ExitCode,
+	}
+}
+
+// diffExecutionSnapshots returns the compared fields whose values differ, in a stable order.
+func diffExecutionSnapshots(a, b *api.ExecutionSnapshot) []api.ExecutionFieldDiff {
+	fields := []api.ExecutionFieldDiff{
+		{Field: "image_id", A: a.ImageID, B: b.ImageID},
+		{Field: "command", A: a.Command, B: b.Command},
+		{Field: "env_var_names", A: strings.Join(a.EnvVarNames, ","), B: strings.Join(b.EnvVarNames, ",")},
+		{Field: "status", A: a.Status, B: b.Status},
+		{Field: "exit_code", A: strconv.Itoa(a.ExitCode), B: strconv.Itoa(b.ExitCode)},
+		{Field: "duration_seconds", A: strconv.Itoa(a.DurationSeconds), B: strconv.Itoa(b.DurationSeconds)},
+	}
+
+	differences := make([]api.ExecutionFieldDiff, 0, len(fields))
+	for _, field := range fields {
+		if field.A != field.B {
+			differences = append(differences, field)
+		}
+	}
+	return differences
+}
+
+// diffExecutionLogs fetches the logs of both executions and diffs their final lines.
+func (s *Service) diffExecutionLogs(
+	ctx context.Context,
+	executionIDA, executionIDB string,
+	logLines int,
+) ([]string, error) {
+	var linesA, linesB []string
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		var fetchErr error
+		linesA, fetchErr = s.fetchFinalLogLines(egCtx, executionIDA, logLines)
+		return fetchErr
+	})
+	eg.Go(func() error {
+		var fetchErr error
+		linesB, fetchErr = s.fetchFinalLogLines(egCtx, executionIDB, logLines)
+		return fetchErr
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return diffLines(linesA, linesB), nil
+}
+
+func (s *Service) fetchFinalLogLines(ctx context.Context, executionID string, count int) ([]string, error) {
+	events, err := s.logManager.FetchLogsByExecutionID(ctx, executionID)
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to fetch logs", fmt.Errorf("fetch logs: %w", err))
+	}
+
+	lines := make([]string, 0, len(events))
+	for i := range events {
+		lines = append(lines, events[i].Message)
+	}
+	if len(lines) > count {
+		lines = lines[len(lines)-count:]
+	}
+	return lines, nil
+}
+
+// diffLines computes a line diff of a and b based on their longest common subsequence.
+// Lines are prefixed with "  " when present in both, "- " when only in a and "+ " when only in b.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]string, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, "+ "+b[j])
+	}
+	return diff
+}
@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func newDiffExecRepo(executions map[string]*api.Execution) *mockExecutionRepository {
+	return &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return executions[executionID], nil
+		},
+	}
+}
+
+func TestDiffExecutions(t *testing.T) {
+	ctx := context.Background()
+	executions := map[string]*api.Execution{
+		"exec-a": {
+			ExecutionID:     "exec-a",
+			Status:          "SUCCEEDED",
+			ImageID:         "alpine-1",
+			Command:         "make test",
+			EnvVarNames:     []string{"CI", "TOKEN"},
+			DurationSeconds: 30,
+		},
+		"exec-b": {
+			ExecutionID:     "exec-b",
+			Status:          "FAILED",
+			ImageID:         "alpine-1",
+			Command:         "make test",
+			EnvVarNames:     []string{"CI"},
+			DurationSeconds: 45,
+			ExitCode:        2,
+		},
+	}
+
+	t.Run("reports differing fields only", func(t *testing.T) {
+		svc := newTestService(nil, newDiffExecRepo(executions), nil)
+
+		resp, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "exec-b", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, "exec-a", resp.ExecutionA.ExecutionID)
+		assert.Equal(t, "exec-b", resp.ExecutionB.ExecutionID)
+		assert.Equal(t, []api.ExecutionFieldDiff{
+			{Field: "env_var_names", A: "CI,TOKEN", B: "CI"},
+			{Field: "status", A: "SUCCEEDED", B: "FAILED"},
+			{Field: "exit_code", A: "0", B: "2"},
+			{Field: "duration_seconds", A: "30", B: "45"},
+		}, resp.Differences)
+		assert.Nil(t, resp.LogDiff)
+	})
+
+	t.Run("identical executions have no differences", func(t *testing.T) {
+		svc := newTestService(nil, newDiffExecRepo(executions), nil)
+
+		resp, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "exec-a", 0)
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Differences)
+	})
+
+	t.Run("diffs the final log lines", func(t *testing.T) {
+		logs := map[string][]string{
+			"exec-a": {"setup", "compile", "ok"},
+			"exec-b": {"setup", "compile", "FAIL: TestX"},
+		}
+		runner := &mockRunner{
+			fetchLogsByExecutionIDFunc: func(_ context.Context, executionID string) ([]api.LogEvent, error) {
+				events := make([]api.LogEvent, 0, len(logs[executionID]))
+				for _, line := range logs[executionID] {
+					events = append(events, api.LogEvent{Message: line})
+				}
+				return events, nil
+			},
+		}
+		svc := newTestService(nil, newDiffExecRepo(executions), runner)
+
+		resp, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "exec-b", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"  compile", "- ok", "+ FAIL: TestX"}, resp.LogDiff)
+	})
+
+	t.Run("log fetch failure", func(t *testing.T) {
+		runner := &mockRunner{
+			fetchLogsByExecutionIDFunc: func(_ context.Context, _ string) ([]api.LogEvent, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		svc := newTestService(nil, newDiffExecRepo(executions), runner)
+
+		_, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "exec-b", 10)
+
+		require.Error(t, err)
+		assert.Equal(t, apperrors.ErrCodeInternalError, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("execution not found", func(t *testing.T) {
+		svc := newTestService(nil, newDiffExecRepo(executions), nil)
+
+		_, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "missing", 0)
+
+		require.Error(t, err)
+		assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		svc := newTestService(nil, newDiffExecRepo(executions), nil)
+
+		_, err := svc.DiffExecutions(ctx, "user@example.com", "exec-a", "", 0)
+		assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+
+		_, err = svc.DiffExecutions(ctx, "user@example.com", "exec-a", "exec-b", 501)
+		assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("forbids executions the user cannot read", func(t *testing.T) {
+		svc, enforcer := newTestServiceWithEnforcer(nil, newDiffExecRepo(executions), nil, nil)
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "alice@example.com", authorization.RoleDeveloper))
+		require.NoError(t, enforcer.AddOwnershipForResource(
+			ctx, authorization.FormatResourceID("execution", "exec-a"), "alice@example.com"))
+
+		_, err := svc.DiffExecutions(ctx, "alice@example.com", "exec-a", "exec-b", 0)
+
+		require.Error(t, err)
+		assert.Equal(t, apperrors.ErrCodeForbidden, apperrors.GetErrorCode(err))
+	})
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{name: "both empty", want: []string{}},
+		{name: "identical", a: []string{"x", "y"}, b: []string{"x", "y"}, want: []string{"  x", "  y"}},
+		{name: "only in a", a: []string{"x"}, want: []string{"- x"}},
+		{name: "only in b", b: []string{"y"}, want: []string{"+ y"}},
+		{
+			name: "insertion and removal",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "c", "d"},
+			want: []string{"  a", "- b", "  c", "+ d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffLines(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// currentUserAlias is the CreatedBy value of an execution list filter standing for the user listing executions,
+// so that saved filters such as "my failed runs" apply to whoever uses them.
+const currentUserAlias = "me"
+
+// ListFilteredExecutions returns the executions listed to the user matching the filter, with the same
+// visibility rules and ordering as ListVisibleExecutions, the executions starred by the user being marked.
+// A filter Name selects one of the filters saved by the user, combined with the other criteria of the filter:
+// those set take precedence over the saved ones.
+func (s *Service) ListFilteredExecutions(
+	ctx context.Context,
+	userEmail string,
+	limit int,
+	filter *api.ExecutionListFilter,
+) ([]*api.Execution, error) {
+	user, err := s.repos.User.GetUserByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	var starred []string
+	var savedFilters []api.ExecutionListFilter
+	if user != nil {
+		starred = user.StarredExecutions
+		savedFilters = user.SavedFilters
+	}
+
+	criteria := *filter
+	if criteria.Name != "" {
+		index := slices.IndexFunc(savedFilters, func(saved api.ExecutionListFilter) bool {
+			return saved.Name == criteria.Name
+		})
+		if index < 0 {
+			return nil, apperrors.ErrNotFound(fmt.Sprintf("saved filter %q not found", criteria.Name), nil)
+		}
+		criteria = mergeListFilters(&savedFilters[index], filter)
+	}
+
+	match, err := listFilterMatcher(userEmail, starred, &criteria)
+	if err != nil {
+		return nil, err
+	}
+	createdBy := resolveCreatedBy(userEmail, criteria.CreatedBy)
+	terms := database.ExecutionSearchTerms(criteria.Search)
+	executions, err := s.listMatchingExecutions(ctx, userEmail, limit, criteria.Statuses, createdBy, terms, match)
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range executions {
+		execution.Starred = slices.Contains(starred, execution.ExecutionID)
+	}
+	return executions, nil
+}
+
+// mergeListFilters returns the saved filter with the criteria set in override replacing its own.
+func mergeListFilters(saved, override *api.ExecutionListFilter) api.ExecutionListFilter {
+	merged := *saved
+	if len(override.Statuses) > 0 {
+		merged.Statuses = override.Statuses
+	}
+	if override.CreatedBy != "" {
+		merged.CreatedBy = override.CreatedBy
+	}
+	if override.Since != "" {
+		merged.Since = override.Since
+	}
+	if override.Search != "" {
+		merged.Search = override.Search
+	}
+	merged.Starred = merged.Starred || override.Starred
+	return merged
+}
+
+// listFilterMatcher returns the function matching the executions selected by the filter, besides their statuses,
+// nil when all executions match.
+func listFilterMatcher(
+	userEmail string,
+	starred []string,
+	filter *api.ExecutionListFilter,
+) (func(*api.Execution) bool, error) {
+	createdBy := resolveCreatedBy(userEmail, filter.CreatedBy)
+	var startedAfter time.Time
+	if filter.Since != "" {
+		since, err := time.ParseDuration(filter.Since)
+		if err != nil || since <= 0 {
+			return nil, apperrors.ErrBadRequest(fmt.Sprintf("invalid since duration %q", filter.Since), err)
+		}
+		startedAfter = time.Now().Add(-since)
+	}
+	if len(filter.Search) > constants.MaxExecutionSearchLength {
+		return nil, apperrors.ErrBadRequest(fmt.Sprintf("search is %d characters long, the maximum is %d",
+			len(filter.Search), constants.MaxExecutionSearchLength), nil)
+	}
+	terms := database.ExecutionSearchTerms(filter.Search)
+	if createdBy == "" && startedAfter.IsZero() && !filter.Starred && len(terms) == 0 {
+		return nil, nil
+	}
+
+	return func(execution *api.Execution) bool {
+		if createdBy != "" && execution.CreatedBy != createdBy {
+			return false
+		}
+		if !startedAfter.IsZero() && execution.StartedAt.Before(startedAfter) {
+			return false
+		}
+		if !database.MatchesExecutionSearch(execution, terms) {
+			return false
+		}
+		return !filter.Starred || slices.Contains(starred, execution.ExecutionID)
+	}, nil
+}
+
+// resolveCreatedBy returns the user email a filter CreatedBy stands for, the user listing executions for "me".
+func resolveCreatedBy(userEmail, createdBy string) string {
+	if createdBy == currentUserAlias {
+		return userEmail
+	}
+	return createdBy
+}
+
+// SetExecutionStar stars or unstars an execution for the user, starred executions being listed with
+// the starred filter. Users can only star executions listed to them.
+func (s *Service) SetExecutionStar(
+	ctx context.Context,
+	userEmail, executionID string,
+	starred bool,
+) (*api.StarExecutionResponse, error) {
+	if executionID == "" {
+		return nil, apperrors.ErrBadRequest("executionID is required", nil)
+	}
+	user, err := s.getPreferencesUser(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.Index(user.StarredExecutions, executionID)
+	if starred {
+		if index >= 0 {
+			return &api.StarExecutionResponse{ExecutionID: executionID, Starred: true}, nil
+		}
+		execution, getErr := s.repos.Execution.GetExecution(ctx, executionID)
+		if getErr != nil {
+			return nil, fmt.Errorf("get execution: %w", getErr)
+		}
+		if execution == nil {
+			return nil, apperrors.ErrNotFound("execution not found", nil)
+		}
+		visible, visibleErr := s.filterVisibleExecutions(ctx, userEmail, []*api.Execution{execution}, nil)
+		if visibleErr != nil {
+			return nil, visibleErr
+		}
+		if len(visible) == 0 {
+			return nil, apperrors.ErrForbidden(fmt.Sprintf("not allowed to star execution %s", executionID), nil)
+		}
+		if len(user.StarredExecutions) >= constants.MaxStarredExecutions {
+			return nil, apperrors.ErrConflict(
+				fmt.Sprintf("%d executions already starred, the maximum, unstar some first", len(user.StarredExecutions)),
+				nil)
+		}
+		user.StarredExecutions = append(user.StarredExecutions, executionID)
+	} else {
+		if index < 0 {
+			return &api.StarExecutionResponse{ExecutionID: executionID}, nil
+		}
+		user.StarredExecutions = slices.Delete(user.StarredExecutions, index, index+1)
+	}
+
+	if err = s.repos.User.UpdateUserPreferences(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user preferences: %w", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Debug("execution star updated", "context", map[string]any{
+		"execution_id": executionID,
+		"user":         userEmail,
+		"starred":      starred,
+	})
+
+	return &api.StarExecutionResponse{ExecutionID: executionID, Starred: starred}, nil
+}
+
+// ListSavedFilters returns the execution list filters saved by the user.
+func (s *Service) ListSavedFilters(ctx context.Context, userEmail string) ([]api.ExecutionListFilter, error) {
+	user, err := s.getPreferencesUser(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+	if user.SavedFilters == nil {
+		return []api.ExecutionListFilter{}, nil
+	}
+	return user.SavedFilters, nil
+}
+
+// SaveFilter saves the execution list filter of the user under its name, replacing the filter
+// previously saved under that name.
+func (s *Service) SaveFilter(
+	ctx context.Context,
+	userEmail string,
+	filter *api.ExecutionListFilter,
+) (*api.ExecutionListFilter, error) {
+	if filter.Name == "" {
+		return nil, apperrors.ErrBadRequest("filter name is required", nil)
+	}
+	for i, status := range filter.Statuses {
+		filter.Statuses[i] = strings.ToUpper(strings.TrimSpace(status))
+	}
+	if _, err := listFilterMatcher(userEmail, nil, filter); err != nil {
+		return nil, err
+	}
+	user, err := s.getPreferencesUser(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(user.SavedFilters, func(saved api.ExecutionListFilter) bool {
+		return saved.Name == filter.Name
+	})
+	switch {
+	case index >= 0:
+		user.SavedFilters[index] = *filter
+	case len(user.SavedFilters) >= constants.MaxSavedFilters:
+		return nil, apperrors.ErrConflict(
+			fmt.Sprintf("%d filters already saved, the maximum, delete some first", len(user.SavedFilters)), nil)
+	default:
+		user.SavedFilters = append(user.SavedFilters, *filter)
+	}
+
+	if err = s.repos.User.UpdateUserPreferences(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user preferences: %w", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("execution list filter saved", "context", map[string]string{
+		"name": filter.Name,
+		"user": userEmail,
+	})
+
+	return filter, nil
+}
+
+// DeleteSavedFilter deletes the execution list filter saved by the user under the name.
+func (s *Service) DeleteSavedFilter(ctx context.Context, userEmail, name string) error {
+	user, err := s.getPreferencesUser(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(user.SavedFilters, func(saved api.ExecutionListFilter) bool {
+		return saved.Name == name
+	})
+	if index < 0 {
+		return apperrors.ErrNotFound(fmt.Sprintf("saved filter %q not found", name), nil)
+	}
+	user.SavedFilters = slices.Delete(user.SavedFilters, index, index+1)
+
+	if err = s.repos.User.UpdateUserPreferences(ctx, user); err != nil {
+		return fmt.Errorf("update user preferences: %w", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("execution list filter deleted", "context", map[string]string{
+		"name": name,
+		"user": userEmail,
+	})
+
+	return nil
+}
+
+// getPreferencesUser returns the user whose preferences are read or updated.
+func (s *Service) getPreferencesUser(ctx context.Context, userEmail string) (*api.User, error) {
+	user, err := s.repos.User.GetUserByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound("user not found", nil)
+	}
+	return user, nil
+}
@@ -0,0 +1,288 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPreferencesService returns a service whose user repository stores the preferences of the user.
+func newPreferencesService(t *testing.T, user *api.User, executions []*api.Execution) *Service {
+	t.Helper()
+	userRepo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, email string) (*api.User, error) {
+			if email != user.Email {
+				return nil, nil
+			}
+			stored := *user
+			return &stored, nil
+		},
+		updateUserPreferencesFunc: func(_ context.Context, updated *api.User) error {
+			*user = *updated
+			return nil
+		},
+	}
+	execRepo := &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			for _, execution := range executions {
+				if execution.ExecutionID == executionID {
+					return execution, nil
+				}
+			}
+			return nil, nil
+		},
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return executions, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(userRepo, execRepo, nil, nil)
+	require.NoError(t, enforcer.AddRoleForUser(context.Background(), user.Email, authorization.RoleViewer))
+	return svc
+}
+
+func TestSetExecutionStar(t *testing.T) {
+	ctx := context.Background()
+	executions := []*api.Execution{
+		{ExecutionID: "exec-public", CreatedBy: "owner@example.com"},
+		{
+			ExecutionID: "exec-private",
+			CreatedBy:   "owner@example.com",
+			Visibility:  string(constants.ExecutionVisibilityPrivate),
+		},
+	}
+
+	t.Run("stars and unstars", func(t *testing.T) {
+		user := &api.User{Email: "viewer@example.com"}
+		svc := newPreferencesService(t, user, executions)
+
+		resp, err := svc.SetExecutionStar(ctx, user.Email, "exec-public", true)
+		require.NoError(t, err)
+		assert.True(t, resp.Starred)
+		_, err = svc.SetExecutionStar(ctx, user.Email, "exec-public", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-public"}, user.StarredExecutions)
+
+		resp, err = svc.SetExecutionStar(ctx, user.Email, "exec-public", false)
+		require.NoError(t, err)
+		assert.False(t, resp.Starred)
+		assert.Empty(t, user.StarredExecutions)
+	})
+
+	tests := []struct {
+		name        string
+		executionID string
+		starred     []string
+		wantStatus  int
+	}{
+		{"missing execution", "exec-missing", nil, http.StatusNotFound},
+		{"private execution", "exec-private", nil, http.StatusForbidden},
+		{"too many starred executions", "exec-public", make([]string, constants.MaxStarredExecutions),
+			http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &api.User{Email: "viewer@example.com", StarredExecutions: tt.starred}
+			svc := newPreferencesService(t, user, executions)
+
+			_, err := svc.SetExecutionStar(ctx, user.Email, tt.executionID, true)
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantStatus, apperrors.GetStatusCode(err))
+		})
+	}
+}
+
+func TestSaveFilter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces the filter saved under the name", func(t *testing.T) {
+		user := &api.User{
+			Email:        "viewer@example.com",
+			SavedFilters: []api.ExecutionListFilter{{Name: "failures", Statuses: []string{"FAILED"}}},
+		}
+		svc := newPreferencesService(t, user, nil)
+
+		_, err := svc.SaveFilter(ctx, user.Email,
+			&api.ExecutionListFilter{Name: "failures", Statuses: []string{" failed "}, CreatedBy: "me"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []api.ExecutionListFilter{
+			{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me"},
+		}, user.SavedFilters)
+	})
+
+	t.Run("rejects too many filters", func(t *testing.T) {
+		user := &api.User{Email: "viewer@example.com"}
+		for i := range constants.MaxSavedFilters {
+			user.SavedFilters = append(user.SavedFilters, api.ExecutionListFilter{Name: fmt.Sprintf("filter-%d", i)})
+		}
+		svc := newPreferencesService(t, user, nil)
+
+		_, err := svc.SaveFilter(ctx, user.Email, &api.ExecutionListFilter{Name: "failures"})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("rejects an invalid duration", func(t *testing.T) {
+		user := &api.User{Email: "viewer@example.com"}
+		svc := newPreferencesService(t, user, nil)
+
+		_, err := svc.SaveFilter(ctx, user.Email, &api.ExecutionListFilter{Name: "failures", Since: "a week"})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+	})
+}
+
+func TestListFilteredExecutions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	executions := []*api.Execution{
+		{ExecutionID: "exec-1", CreatedBy: "viewer@example.com", StartedAt: now},
+		{ExecutionID: "exec-2", CreatedBy: "other@example.com", StartedAt: now},
+		{ExecutionID: "exec-3", CreatedBy: "viewer@example.com", StartedAt: now.Add(-30 * 24 * time.Hour)},
+	}
+	user := &api.User{
+		Email:             "viewer@example.com",
+		StarredExecutions: []string{"exec-2"},
+		SavedFilters: []api.ExecutionListFilter{
+			{Name: "mine-this-week", CreatedBy: "me", Since: "168h"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter *api.ExecutionListFilter
+		want   []string
+	}{
+		{"no filter", &api.ExecutionListFilter{}, []string{"exec-1", "exec-2", "exec-3"}},
+		{"created by me", &api.ExecutionListFilter{CreatedBy: "me"}, []string{"exec-1", "exec-3"}},
+		{"starred", &api.ExecutionListFilter{Starred: true}, []string{"exec-2"}},
+		{"saved filter", &api.ExecutionListFilter{Name: "mine-this-week"}, []string{"exec-1"}},
+		{"saved filter overridden", &api.ExecutionListFilter{Name: "mine-this-week", Since: "1000h"},
+			[]string{"exec-1", "exec-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPreferencesService(t, user, executions)
+
+			listed, err := svc.ListFilteredExecutions(ctx, user.Email, 0, tt.filter)
+
+			require.NoError(t, err)
+			ids := make([]string, 0, len(listed))
+			for _, execution := range listed {
+				ids = append(ids, execution.ExecutionID)
+				assert.Equal(t, execution.ExecutionID == "exec-2", execution.Starred)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	t.Run("unknown saved filter", func(t *testing.T) {
+		svc := newPreferencesService(t, user, executions)
+
+		_, err := svc.ListFilteredExecutions(ctx, user.Email, 0, &api.ExecutionListFilter{Name: "unknown"})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("search too long", func(t *testing.T) {
+		svc := newPreferencesService(t, user, executions)
+
+		_, err := svc.ListFilteredExecutions(ctx, user.Email, 0,
+			&api.ExecutionListFilter{Search: strings.Repeat("x", constants.MaxExecutionSearchLength+1)})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+	})
+}
+
+func TestListFilteredExecutions_Search(t *testing.T) {
+	ctx := context.Background()
+	user := &api.User{Email: "viewer@example.com"}
+	userRepo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*api.User, error) { return user, nil },
+	}
+	var searched []string
+	execRepo := &mockExecutionRepository{
+		searchFunc: func(_ context.Context, terms []string, _ int, _ []string) ([]*api.Execution, error) {
+			searched = terms
+			return []*api.Execution{{ExecutionID: "exec-1", Command: "pytest -x", StartedAt: time.Now()}}, nil
+		},
+		listByCreatorFunc: func(_ context.Context, email string, _ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{
+				{ExecutionID: "exec-2", CreatedBy: email, Command: "make lint", StartedAt: time.Now()},
+				{ExecutionID: "exec-3", CreatedBy: email, Command: "PYTEST -x", StartedAt: time.Now()},
+			}, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(userRepo, execRepo, nil, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, user.Email, authorization.RoleViewer))
+
+	t.Run("searched by the database", func(t *testing.T) {
+		listed, err := svc.ListFilteredExecutions(ctx, user.Email, 10, &api.ExecutionListFilter{Search: "-x  PyTest"})
+
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "exec-1", listed[0].ExecutionID)
+		assert.Equal(t, []string{"-x", "pytest"}, searched)
+	})
+
+	t.Run("executions of a creator matched in memory", func(t *testing.T) {
+		searched = nil
+
+		listed, err := svc.ListFilteredExecutions(ctx, user.Email, 10,
+			&api.ExecutionListFilter{CreatedBy: "me", Search: "pytest"})
+
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "exec-3", listed[0].ExecutionID)
+		assert.Nil(t, searched)
+	})
+}
+
+func TestListFilteredExecutions_QueriesCreator(t *testing.T) {
+	ctx := context.Background()
+	user := &api.User{Email: "viewer@example.com"}
+	userRepo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, _ string) (*api.User, error) { return user, nil },
+	}
+	var createdBy string
+	var statuses []string
+	listAllCalls := 0
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			listAllCalls++
+			return nil, nil
+		},
+		listByCreatorFunc: func(_ context.Context, email string, _ int, s []string) ([]*api.Execution, error) {
+			createdBy, statuses = email, s
+			return []*api.Execution{{ExecutionID: "exec-1", CreatedBy: email, StartedAt: time.Now()}}, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(userRepo, execRepo, nil, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, user.Email, authorization.RoleViewer))
+	listAllCalls = 0 // Listed when hydrating the enforcer
+
+	listed, err := svc.ListFilteredExecutions(ctx, user.Email, 10,
+		&api.ExecutionListFilter{CreatedBy: "me", Statuses: []string{"FAILED"}})
+
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "viewer@example.com", createdBy)
+	assert.Equal(t, []string{"FAILED"}, statuses)
+	assert.Zero(t, listAllCalls)
+}
@@ -0,0 +1,202 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// executionGroupAbortedReason is the reason the shards of a group that could not be started entirely are stopped.
+const executionGroupAbortedReason = "execution group aborted"
+
+// runExecutionGroup starts req.Parallel executions of the command as the shards of a new group.
+// Each shard gets its index and the number of shards in its environment. The records of the shards are
+// created in a single transaction once all their tasks started. When a shard fails to start or the group
+// can't be recorded, the shards already started are stopped so that a parallel run never runs partially.
+func (s *Service) runExecutionGroup(
+	ctx context.Context,
+	userEmail string,
+	req *api.ExecutionRequest,
+) (*api.ExecutionResponse, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+
+	groupID := constants.ExecutionGroupIDPrefix + auth.GenerateUUID()
+	shards := make([]api.ExecutionGroupShard, 0, req.Parallel)
+	executions := make([]*api.Execution, 0, req.Parallel)
+	executionIDs := make([]string, 0, req.Parallel)
+
+	for shardIndex := range req.Parallel {
+		shardReq := *req
+		shardReq.Env = maps.Clone(req.Env)
+		if shardReq.Env == nil {
+			shardReq.Env = make(map[string]string)
+		}
+		shardReq.Env[constants.ShardIndexEnvVar] = strconv.Itoa(shardIndex)
+		shardReq.Env[constants.ShardTotalEnvVar] = strconv.Itoa(req.Parallel)
+		shardReq.GroupID = groupID
+		shardReq.ShardIndex = &shardIndex
+
+		executionID, createdAt, err := s.taskManager.StartTask(ctx, userEmail, &shardReq)
+		if err != nil {
+			s.abortExecutions(ctx, executionIDs, executionGroupAbortedReason)
+			return nil, apperrors.ErrInternalError(
+				fmt.Sprintf("failed to start shard %d of %d", shardIndex, req.Parallel),
+				fmt.Errorf("start task: %w", err))
+		}
+		shards = append(shards, api.ExecutionGroupShard{ShardIndex: shardIndex, ExecutionID: executionID})
+		executionIDs = append(executionIDs, executionID)
+		executions = append(executions,
+			s.newExecutionRecord(ctx, userEmail, &shardReq, executionID, createdAt, constants.ExecutionStarting))
+	}
+
+	if err := s.repos.Execution.CreateExecutions(ctx, executions); err != nil {
+		reqLogger.Error("failed to create execution records of the group, stopping its shards", "context", map[string]any{
+			"group_id": groupID,
+			"error":    err.Error(),
+		})
+		s.abortExecutions(ctx, executionIDs, executionGroupAbortedReason)
+		return nil, fmt.Errorf("failed to record executions: %w", err)
+	}
+	for _, execution := range executions {
+		if err := s.registerExecution(ctx, execution); err != nil {
+			s.abortExecutions(ctx, executionIDs, executionGroupAbortedReason)
+			return nil, fmt.Errorf("failed to record execution: %w", err)
+		}
+	}
+
+	reqLogger.Info("execution group started", "context", map[string]any{
+		"group_id":    groupID,
+		"shard_count": len(shards),
+	})
+
+	return &api.ExecutionResponse{
+		GroupID: groupID,
+		Status:  string(constants.ExecutionStarting),
+		Command: req.Command,
+		ImageID: req.Image,
+		Shards:  shards,
+	}, nil
+}
+
+// GetExecutionGroupStatus returns the aggregated status of the shards of a parallel run.
+// The user must be allowed to read every shard of the group.
+func (s *Service) GetExecutionGroupStatus(
+	ctx context.Context,
+	userEmail string,
+	groupID string,
+) (*api.ExecutionGroupStatusResponse, error) {
+	if groupID == "" {
+		return nil, apperrors.ErrBadRequest("groupID is required", nil)
+	}
+
+	executions, err := s.repos.Execution.GetExecutionsByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("get executions by group ID: %w", err)
+	}
+	if len(executions) == 0 {
+		return nil, apperrors.ErrNotFound("execution group not found", nil)
+	}
+
+	for _, execution := range executions {
+		if err = s.authorizeExecutionRead(ctx, userEmail, execution.ExecutionID); err != nil {
+			return nil, err
+		}
+	}
+
+	return aggregateExecutionGroupStatus(groupID, executions), nil
+}
+
+// aggregateExecutionGroupStatus combines the statuses of the shards of a group, ordered by shard index.
+func aggregateExecutionGroupStatus(groupID string, executions []*api.Execution) *api.ExecutionGroupStatusResponse {
+	slices.SortFunc(executions, func(a, b *api.Execution) int {
+		return shardIndexOf(a) - shardIndexOf(b)
+	})
+
+	resp := &api.ExecutionGroupStatusResponse{
+		GroupID:     groupID,
+		Command:     executions[0].Command,
+		ImageID:     executions[0].ImageID,
+		StartedAt:   executions[0].StartedAt,
+		StatusCount: make(map[string]int),
+		Shards:      make([]api.ExecutionGroupShard, 0, len(executions)),
+	}
+
+	var completedAt time.Time
+	completed := true
+	for _, execution := range executions {
+		shard := api.ExecutionGroupShard{
+			ShardIndex:  shardIndexOf(execution),
+			ExecutionID: execution.ExecutionID,
+			Status:      execution.Status,
+		}
+		if execution.CompletedAt != nil {
+			exitCode := execution.ExitCode
+			shard.ExitCode = &exitCode
+			if execution.CompletedAt.After(completedAt) {
+				completedAt = *execution.CompletedAt
+			}
+		}
+		if slices.Contains(constants.ActiveExecutionStatuses(), constants.ExecutionStatus(execution.Status)) {
+			completed = false
+		}
+		if execution.StartedAt.Before(resp.StartedAt) {
+			resp.StartedAt = execution.StartedAt
+		}
+		resp.StatusCount[execution.Status]++
+		resp.Shards = append(resp.Shards, shard)
+	}
+
+	if !completed {
+		resp.Status = string(constants.ExecutionRunning)
+		if resp.StatusCount[string(constants.ExecutionStarting)] == len(executions) {
+			resp.Status = string(constants.ExecutionStarting)
+		}
+		return resp
+	}
+
+	resp.CompletedAt = &completedAt
+	resp.Status, resp.ExitCode = completedExecutionGroupResult(resp)
+	return resp
+}
+
+// completedExecutionGroupResult returns the status and the exit code of a group whose shards all completed:
+// SUCCEEDED with exit code 0 when every shard succeeded, otherwise FAILED, or STOPPED when shards were only
+// stopped, with the exit code of the first shard that did not succeed (1 when it has none).
+func completedExecutionGroupResult(resp *api.ExecutionGroupStatusResponse) (string, *int) {
+	exitCode := 0
+	status := constants.ExecutionSucceeded
+	for _, shard := range resp.Shards {
+		if shard.Status == string(constants.ExecutionSucceeded) {
+			continue
+		}
+		if exitCode == 0 {
+			exitCode = 1
+			if shard.ExitCode != nil && *shard.ExitCode != 0 {
+				exitCode = *shard.ExitCode
+			}
+		}
+		if shard.Status == string(constants.ExecutionStopped) && status == constants.ExecutionSucceeded {
+			status = constants.ExecutionStopped
+		} else if shard.Status != string(constants.ExecutionStopped) {
+			status = constants.ExecutionFailed
+		}
+	}
+	return string(status), &exitCode
+}
+
+// shardIndexOf returns the shard index of an execution of a group.
+func shardIndexOf(execution *api.Execution) int {
+	if execution.ShardIndex == nil {
+		return 0
+	}
+	return *execution.ShardIndex
+}
@@ -0,0 +1,313 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestRunCommand_Parallel(t *testing.T) {
+	ctx := context.Background()
+
+	var started []*api.ExecutionRequest
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			started = append(started, req)
+			return fmt.Sprintf("exec-%d", len(started)-1), timePtr(time.Now()), nil
+		},
+	}
+	var recorded []*api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = append(recorded, execution)
+			return nil
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	req := api.ExecutionRequest{
+		Command:  "./test-shard.sh",
+		Image:    "alpine:latest",
+		Env:      map[string]string{"A": "1"},
+		Parallel: 3,
+	}
+	resp, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.GroupID, constants.ExecutionGroupIDPrefix))
+	assert.Empty(t, resp.ExecutionID)
+	assert.Equal(t, string(constants.ExecutionStarting), resp.Status)
+	require.Len(t, resp.Shards, 3)
+	require.Len(t, started, 3)
+	require.Len(t, recorded, 3)
+
+	for i := range 3 {
+		assert.Equal(t, i, resp.Shards[i].ShardIndex)
+		assert.Equal(t, fmt.Sprintf("exec-%d", i), resp.Shards[i].ExecutionID)
+		assert.Equal(t, fmt.Sprint(i), started[i].Env[constants.ShardIndexEnvVar])
+		assert.Equal(t, "3", started[i].Env[constants.ShardTotalEnvVar])
+		assert.Equal(t, "1", started[i].Env["A"])
+		assert.Equal(t, resp.GroupID, recorded[i].GroupID)
+		require.NotNil(t, recorded[i].ShardIndex)
+		assert.Equal(t, i, *recorded[i].ShardIndex)
+	}
+	assert.Equal(t, map[string]string{"A": "1"}, req.Env, "the request environment is not shared by the shards")
+}
+
+func TestRunCommand_ParallelAbortsOnStartFailure(t *testing.T) {
+	ctx := context.Background()
+
+	var killed []string
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			if *req.ShardIndex == 2 {
+				return "", nil, errors.New("capacity unavailable")
+			}
+			return fmt.Sprintf("exec-%d", *req.ShardIndex), timePtr(time.Now()), nil
+		},
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return nil
+		},
+	}
+	svc := newTestService(nil, nil, runner)
+
+	req := api.ExecutionRequest{Command: "./test-shard.sh", Image: "alpine:latest", Parallel: 4}
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start shard 2 of 4")
+	assert.Equal(t, []string{"exec-0", "exec-1"}, killed)
+}
+
+func TestRunCommand_ParallelAbortsOnRecordFailure(t *testing.T) {
+	ctx := context.Background()
+
+	var killed []string
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			return fmt.Sprintf("exec-%d", *req.ShardIndex), timePtr(time.Now()), nil
+		},
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return nil
+		},
+	}
+	var created int
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			created++
+			return nil
+		},
+		createAllFunc: func(_ context.Context, executions []*api.Execution) error {
+			assert.Len(t, executions, 3)
+			return apperrors.ErrDatabaseError("transaction canceled", nil)
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	req := api.ExecutionRequest{Command: "./test-shard.sh", Image: "alpine:latest", Parallel: 3}
+	_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record executions")
+	assert.Zero(t, created, "the shards are recorded in a single transaction")
+	assert.Equal(t, []string{"exec-0", "exec-1", "exec-2"}, killed)
+}
+
+func TestAbortExecutions_KilledBySystem(t *testing.T) {
+	ctx := context.Background()
+
+	var killed []string
+	runner := &mockRunner{
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return nil
+		},
+	}
+	var updated *api.Execution
+	execRepo := &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID: executionID,
+				CreatedBy:   "user@example.com",
+				Status:      string(constants.ExecutionStarting),
+			}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			updated = execution
+			return nil
+		},
+	}
+	svc := newTestService(nil, execRepo, runner)
+
+	svc.abortExecutions(ctx, []string{"exec-0"}, executionGroupAbortedReason)
+
+	require.NotNil(t, updated)
+	assert.Equal(t, constants.SystemActor, updated.KillRequestedBy, "the user who started the run did not kill it")
+	assert.Equal(t, string(constants.ExecutionTerminating), updated.Status)
+	assert.Equal(t, []string{"exec-0"}, killed)
+}
+
+func TestRunCommand_ParallelValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(nil, nil, nil)
+
+	for _, parallel := range []int{-1, constants.MaxExecutionGroupSize + 1} {
+		req := api.ExecutionRequest{Command: "echo hi", Parallel: parallel}
+		_, err := svc.RunCommand(ctx, "user@example.com", nil, &req, nil)
+		assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err), "parallel %d", parallel)
+	}
+}
+
+func TestGetExecutionGroupStatus(t *testing.T) {
+	ctx := context.Background()
+	startedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(time.Minute)
+
+	shard := func(index int, status constants.ExecutionStatus, exitCode int) *api.Execution {
+		execution := &api.Execution{
+			ExecutionID: fmt.Sprintf("exec-%d", index),
+			GroupID:     "group-1",
+			ShardIndex:  &index,
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Command:     "./test-shard.sh",
+			StartedAt:   startedAt.Add(time.Duration(index) * time.Second),
+			Status:      string(status),
+			ExitCode:    exitCode,
+		}
+		if !slices.Contains(constants.ActiveExecutionStatuses(), status) {
+			done := completedAt.Add(time.Duration(index) * time.Second)
+			execution.CompletedAt = &done
+		}
+		return execution
+	}
+
+	tests := []struct {
+		name         string
+		shards       []*api.Execution
+		wantStatus   string
+		wantExitCode *int
+	}{
+		{
+			name: "starting",
+			shards: []*api.Execution{
+				shard(1, constants.ExecutionStarting, 0),
+				shard(0, constants.ExecutionStarting, 0),
+			},
+			wantStatus: string(constants.ExecutionStarting),
+		},
+		{
+			name: "running",
+			shards: []*api.Execution{
+				shard(0, constants.ExecutionSucceeded, 0),
+				shard(1, constants.ExecutionRunning, 0),
+			},
+			wantStatus: string(constants.ExecutionRunning),
+		},
+		{
+			name: "succeeded",
+			shards: []*api.Execution{
+				shard(1, constants.ExecutionSucceeded, 0),
+				shard(0, constants.ExecutionSucceeded, 0),
+			},
+			wantStatus:   string(constants.ExecutionSucceeded),
+			wantExitCode: intPtr(0),
+		},
+		{
+			name: "failed",
+			shards: []*api.Execution{
+				shard(0, constants.ExecutionSucceeded, 0),
+				shard(1, constants.ExecutionFailed, 3),
+				shard(2, constants.ExecutionStopped, 130),
+			},
+			wantStatus:   string(constants.ExecutionFailed),
+			wantExitCode: intPtr(3),
+		},
+		{
+			name: "stopped",
+			shards: []*api.Execution{
+				shard(0, constants.ExecutionSucceeded, 0),
+				shard(1, constants.ExecutionStopped, 0),
+			},
+			wantStatus:   string(constants.ExecutionStopped),
+			wantExitCode: intPtr(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execRepo := &mockExecutionRepository{
+				listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+					return tt.shards, nil
+				},
+				getByGroupIDFunc: func(_ context.Context, groupID string) ([]*api.Execution, error) {
+					assert.Equal(t, "group-1", groupID)
+					return tt.shards, nil
+				},
+			}
+			svc, _ := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+
+			resp, err := svc.GetExecutionGroupStatus(ctx, "owner@example.com", "group-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantExitCode, resp.ExitCode)
+			assert.Equal(t, startedAt, resp.StartedAt)
+			assert.Equal(t, tt.wantExitCode != nil, resp.CompletedAt != nil)
+			require.Len(t, resp.Shards, len(tt.shards))
+			for i, s := range resp.Shards {
+				assert.Equal(t, i, s.ShardIndex, "shards are ordered by index")
+			}
+		})
+	}
+}
+
+func TestGetExecutionGroupStatus_Errors(t *testing.T) {
+	ctx := context.Background()
+	index := 0
+	private := []*api.Execution{{
+		ExecutionID: "exec-0",
+		GroupID:     "group-1",
+		ShardIndex:  &index,
+		CreatedBy:   "owner@example.com",
+		OwnedBy:     []string{"owner@example.com"},
+		Status:      string(constants.ExecutionRunning),
+		Visibility:  "private",
+	}}
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return private, nil
+		},
+		getByGroupIDFunc: func(_ context.Context, groupID string) ([]*api.Execution, error) {
+			if groupID == "group-1" {
+				return private, nil
+			}
+			return []*api.Execution{}, nil
+		},
+	}
+	svc, enforcer := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+
+	_, err := svc.GetExecutionGroupStatus(ctx, "owner@example.com", "")
+	assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+
+	_, err = svc.GetExecutionGroupStatus(ctx, "owner@example.com", "group-unknown")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+
+	_, err = svc.GetExecutionGroupStatus(ctx, "operator@example.com", "group-1")
+	assert.Equal(t, apperrors.ErrCodeForbidden, apperrors.GetErrorCode(err))
+}
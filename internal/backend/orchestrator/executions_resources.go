@@ -0,0 +1,48 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// GetExecutionResources returns the latest resource usage of the running executions listed to the user.
+// Executions the compute platform did not report a sample for yet are returned without usage.
+func (s *Service) GetExecutionResources(
+	ctx context.Context,
+	userEmail string,
+) (*api.ExecutionResourcesResponse, error) {
+	executions, err := s.ListVisibleExecutions(ctx, userEmail, 0, []string{string(constants.ExecutionRunning)})
+	if err != nil {
+		return nil, err
+	}
+
+	executionIDs := make([]string, 0, len(executions))
+	for _, execution := range executions {
+		executionIDs = append(executionIDs, execution.ExecutionID)
+	}
+
+	usage, err := s.observabilityManager.FetchResourceUsage(ctx, executionIDs)
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to fetch resource usage",
+			fmt.Errorf("fetch resource usage: %w", err))
+	}
+
+	resp := &api.ExecutionResourcesResponse{
+		Executions: make([]api.ExecutionResourceUsage, 0, len(executions)),
+	}
+	for _, execution := range executions {
+		resp.Executions = append(resp.Executions, api.ExecutionResourceUsage{
+			ExecutionID: execution.ExecutionID,
+			Command:     execution.Command,
+			CreatedBy:   execution.CreatedBy,
+			ImageID:     execution.ImageID,
+			StartedAt:   execution.StartedAt,
+			Usage:       usage[execution.ExecutionID],
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestGetExecutionResources(t *testing.T) {
+	ctx := context.Background()
+	sampledAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	executions := []*api.Execution{
+		{
+			ExecutionID: "exec-team",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionRunning),
+			Command:     "make build",
+		},
+		{
+			ExecutionID: "exec-private",
+			CreatedBy:   "owner@example.com",
+			OwnedBy:     []string{"owner@example.com"},
+			Status:      string(constants.ExecutionRunning),
+			Visibility:  "private",
+		},
+	}
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return executions, nil
+		},
+	}
+
+	t.Run("returns the usage of the running executions listed to the user", func(t *testing.T) {
+		var statuses []string
+		runningRepo := &mockExecutionRepository{
+			listExecutionsFunc: func(_ context.Context, _ int, s []string) ([]*api.Execution, error) {
+				if s != nil {
+					statuses = s
+				}
+				return executions, nil
+			},
+		}
+		runner := &mockRunner{
+			fetchResourceUsageFunc: func(_ context.Context, executionIDs []string) (map[string]*api.ResourceUsage, error) {
+				assert.Equal(t, []string{"exec-team"}, executionIDs)
+				return map[string]*api.ResourceUsage{
+					"exec-team": {SampledAt: sampledAt, CPUUtilized: 512, CPUReserved: 1024},
+				}, nil
+			},
+		}
+		svc, enforcer := newTestServiceWithEnforcer(nil, runningRepo, runner, nil)
+		require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+
+		resp, err := svc.GetExecutionResources(ctx, "operator@example.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{string(constants.ExecutionRunning)}, statuses)
+		require.Len(t, resp.Executions, 1)
+		assert.Equal(t, "exec-team", resp.Executions[0].ExecutionID)
+		assert.Equal(t, "make build", resp.Executions[0].Command)
+		require.NotNil(t, resp.Executions[0].Usage)
+		assert.Equal(t, sampledAt, resp.Executions[0].Usage.SampledAt)
+	})
+
+	t.Run("returns executions without a sample yet without usage", func(t *testing.T) {
+		svc, _ := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+
+		resp, err := svc.GetExecutionResources(ctx, "owner@example.com")
+
+		require.NoError(t, err)
+		require.Len(t, resp.Executions, 2)
+		assert.Nil(t, resp.Executions[0].Usage)
+		assert.Nil(t, resp.Executions[1].Usage)
+	})
+
+	t.Run("wraps provider errors", func(t *testing.T) {
+		runner := &mockRunner{
+			fetchResourceUsageFunc: func(_ context.Context, _ []string) (map[string]*api.ResourceUsage, error) {
+				return nil, errors.New("throttled")
+			},
+		}
+		svc, _ := newTestServiceWithEnforcer(nil, execRepo, runner, nil)
+
+		_, err := svc.GetExecutionResources(ctx, "owner@example.com")
+
+		assert.Equal(t, apperrors.ErrCodeInternalError, apperrors.GetErrorCode(err))
+	})
+}
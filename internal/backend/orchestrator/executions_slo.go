@@ -0,0 +1,105 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// sloReportDateLayout is the layout of the days of an execution SLO report.
+const sloReportDateLayout = "2006-01-02"
+
+// GetExecutionSLOReport returns the duration SLO breach rates per playbook over the period, from the executions
+// listed to the user that were started from a playbook declaring a max duration. A playbook name restricts
+// the report to that playbook. Executions still running within their expected duration are left out
+// until they complete or breach it.
+func (s *Service) GetExecutionSLOReport(
+	ctx context.Context,
+	userEmail string,
+	period time.Duration,
+	playbook string,
+) (*api.ExecutionSLOReport, error) {
+	if period <= 0 || period > constants.MaxSLOReportPeriod {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("period must be positive and at most %s", constants.MaxSLOReportPeriod), nil)
+	}
+	since := time.Now().UTC().Add(-period)
+
+	executions, err := s.listMatchingExecutions(ctx, userEmail, 0, nil, "", nil, func(execution *api.Execution) bool {
+		return execution.Playbook != "" &&
+			execution.ExpectedMaxDurationSeconds > 0 &&
+			!execution.StartedAt.Before(since) &&
+			(playbook == "" || execution.Playbook == playbook) &&
+			(execution.CompletedAt != nil || execution.SLOBreached)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSLOReport(since, executions), nil
+}
+
+// buildSLOReport aggregates the SLO breaches of the executions, listed newest first, per playbook and day.
+func buildSLOReport(since time.Time, executions []*api.Execution) *api.ExecutionSLOReport {
+	playbooks := map[string]*api.PlaybookSLOReport{}
+	days := map[string]map[string]*api.SLOReportPeriod{}
+	for _, execution := range executions {
+		report, ok := playbooks[execution.Playbook]
+		if !ok {
+			report = &api.PlaybookSLOReport{
+				Playbook:                   execution.Playbook,
+				ExpectedMaxDurationSeconds: execution.ExpectedMaxDurationSeconds,
+			}
+			playbooks[execution.Playbook] = report
+			days[execution.Playbook] = map[string]*api.SLOReportPeriod{}
+		}
+		date := execution.StartedAt.UTC().Format(sloReportDateLayout)
+		day, ok := days[execution.Playbook][date]
+		if !ok {
+			day = &api.SLOReportPeriod{Date: date}
+			days[execution.Playbook][date] = day
+		}
+
+		report.Executions++
+		day.Executions++
+		if execution.SLOBreached {
+			report.Breaches++
+			day.Breaches++
+		}
+	}
+
+	resp := &api.ExecutionSLOReport{
+		Since:     since,
+		Playbooks: make([]api.PlaybookSLOReport, 0, len(playbooks)),
+	}
+	for name, report := range playbooks {
+		report.BreachRate = breachRate(report.Breaches, report.Executions)
+		report.Days = make([]api.SLOReportPeriod, 0, len(days[name]))
+		for _, day := range days[name] {
+			day.BreachRate = breachRate(day.Breaches, day.Executions)
+			report.Days = append(report.Days, *day)
+		}
+		slices.SortFunc(report.Days, func(a, b api.SLOReportPeriod) int {
+			return strings.Compare(a.Date, b.Date)
+		})
+		resp.Playbooks = append(resp.Playbooks, *report)
+	}
+	slices.SortFunc(resp.Playbooks, func(a, b api.PlaybookSLOReport) int {
+		return strings.Compare(a.Playbook, b.Playbook)
+	})
+	return resp
+}
+
+// breachRate returns the fraction of the executions that breached their SLO, 0 without executions.
+func breachRate(breaches, executions int) float64 {
+	if executions == 0 {
+		return 0
+	}
+	return float64(breaches) / float64(executions)
+}
@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExecutionSLOReport(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().UTC()
+	yesterday := today.Add(-24 * time.Hour)
+	completed := func(startedAt time.Time) *time.Time {
+		completedAt := startedAt.Add(time.Minute)
+		return &completedAt
+	}
+	execution := func(id, playbook string, startedAt time.Time, breached bool) *api.Execution {
+		return &api.Execution{
+			ExecutionID:                id,
+			CreatedBy:                  "viewer@example.com",
+			Playbook:                   playbook,
+			ExpectedMaxDurationSeconds: 300,
+			StartedAt:                  startedAt,
+			CompletedAt:                completed(startedAt),
+			SLOBreached:                breached,
+		}
+	}
+	running := execution("exec-running", "deploy", today, false)
+	running.CompletedAt = nil
+	noSLO := execution("exec-no-slo", "deploy", today, false)
+	noSLO.ExpectedMaxDurationSeconds = 0
+	executions := []*api.Execution{
+		execution("exec-1", "deploy", today, true),
+		execution("exec-2", "deploy", today, false),
+		execution("exec-3", "deploy", yesterday, false),
+		execution("exec-4", "backup", yesterday, true),
+		execution("exec-old", "deploy", today.Add(-60*24*time.Hour), true),
+		running,
+		noSLO,
+		{ExecutionID: "exec-adhoc", CreatedBy: "viewer@example.com", StartedAt: today, CompletedAt: completed(today)},
+	}
+	user := &api.User{Email: "viewer@example.com"}
+
+	t.Run("aggregates breaches per playbook and day", func(t *testing.T) {
+		svc := newPreferencesService(t, user, executions)
+
+		report, err := svc.GetExecutionSLOReport(ctx, user.Email, constants.DefaultSLOReportPeriod, "")
+
+		require.NoError(t, err)
+		require.Len(t, report.Playbooks, 2)
+		assert.Equal(t, "backup", report.Playbooks[0].Playbook)
+		assert.InDelta(t, 1.0, report.Playbooks[0].BreachRate, 0.001)
+		deploy := report.Playbooks[1]
+		assert.Equal(t, 3, deploy.Executions)
+		assert.Equal(t, 1, deploy.Breaches)
+		assert.Equal(t, 300, deploy.ExpectedMaxDurationSeconds)
+		assert.Equal(t, []api.SLOReportPeriod{
+			{Date: yesterday.Format("2006-01-02"), Executions: 1},
+			{Date: today.Format("2006-01-02"), Executions: 2, Breaches: 1, BreachRate: 0.5},
+		}, deploy.Days)
+	})
+
+	t.Run("restricts the report to a playbook", func(t *testing.T) {
+		svc := newPreferencesService(t, user, executions)
+
+		report, err := svc.GetExecutionSLOReport(ctx, user.Email, constants.DefaultSLOReportPeriod, "backup")
+
+		require.NoError(t, err)
+		require.Len(t, report.Playbooks, 1)
+		assert.Equal(t, "backup", report.Playbooks[0].Playbook)
+	})
+
+	t.Run("rejects a period too long", func(t *testing.T) {
+		svc := newPreferencesService(t, user, executions)
+
+		_, err := svc.GetExecutionSLOReport(ctx, user.Email, constants.MaxSLOReportPeriod+time.Hour, "")
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+	})
+}
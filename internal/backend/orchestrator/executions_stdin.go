@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// inputUploadIDPattern matches the identifiers generated by CreateStdinUpload and CreateContextUpload.
+var inputUploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// CreateStdinUpload prepares the upload of the standard input of an upcoming execution,
+// for payloads too large to be sent inline with the execution request.
+func (s *Service) CreateStdinUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error) {
+	if size <= 0 || size > constants.MaxStdinBytes {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("stdin size must be between 1 and %d bytes", constants.MaxStdinBytes), nil)
+	}
+
+	uploadID := auth.GenerateUUID()
+	uploadURL, expiresAt, err := s.taskManager.CreateStdinUpload(ctx, uploadID, size)
+	if err != nil {
+		// Wrap the error - AppError types will still be found via errors.As() in the chain
+		return nil, fmt.Errorf("create stdin upload: %w", err)
+	}
+
+	return &api.InputUploadResponse{
+		UploadID:  uploadID,
+		UploadURL: uploadURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// validateStdin checks the standard input of an execution request: either a small inline payload
+// or a reference to a previous stdin upload.
+func validateStdin(req *api.ExecutionRequest) error {
+	if req.Stdin != "" && req.StdinUploadID != "" {
+		return apperrors.ErrBadRequest("stdin and stdin_upload_id are mutually exclusive", nil)
+	}
+
+	if req.Stdin != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.Stdin)
+		if err != nil {
+			return apperrors.ErrBadRequest("stdin must be base64-encoded", err)
+		}
+		if len(decoded) > constants.MaxInlineStdinBytes {
+			return apperrors.ErrBadRequest(
+				fmt.Sprintf("inline stdin must not exceed %d bytes, upload it instead", constants.MaxInlineStdinBytes),
+				nil,
+			)
+		}
+	}
+
+	if req.StdinUploadID != "" && !inputUploadIDPattern.MatchString(req.StdinUploadID) {
+		return apperrors.ErrBadRequest("invalid stdin upload ID", nil)
+	}
+
+	return nil
+}
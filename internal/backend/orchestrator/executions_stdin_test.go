@@ -0,0 +1,118 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestCreateStdinUpload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the upload URL from the task manager", func(t *testing.T) {
+		expiresAt := time.Now().Add(15 * time.Minute)
+		var gotUploadID string
+		runner := &mockRunner{
+			createStdinUploadFunc: func(_ context.Context, uploadID string, size int64) (string, time.Time, error) {
+				gotUploadID = uploadID
+				assert.Equal(t, int64(4096), size)
+				return "https://uploads.example.com/stdin", expiresAt, nil
+			},
+		}
+		svc := newTestService(nil, nil, runner)
+
+		resp, err := svc.CreateStdinUpload(ctx, 4096)
+
+		require.NoError(t, err)
+		assert.Equal(t, gotUploadID, resp.UploadID)
+		assert.Regexp(t, `^[0-9a-f]{32}$`, resp.UploadID)
+		assert.Equal(t, "https://uploads.example.com/stdin", resp.UploadURL)
+		assert.Equal(t, expiresAt, resp.ExpiresAt)
+	})
+
+	t.Run("rejects invalid sizes", func(t *testing.T) {
+		svc := newTestService(nil, nil, nil)
+
+		for _, size := range []int64{0, -1, constants.MaxStdinBytes + 1} {
+			_, err := svc.CreateStdinUpload(ctx, size)
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err), "size %d", size)
+		}
+	})
+
+	t.Run("keeps task manager error codes", func(t *testing.T) {
+		runner := &mockRunner{
+			createStdinUploadFunc: func(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+				return "", time.Time{}, apperrors.ErrServiceUnavailable("stdin uploads are not configured", nil)
+			},
+		}
+		svc := newTestService(nil, nil, runner)
+
+		_, err := svc.CreateStdinUpload(ctx, 10)
+
+		assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("wraps task manager errors", func(t *testing.T) {
+		runner := &mockRunner{
+			createStdinUploadFunc: func(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+				return "", time.Time{}, errors.New("presign failed")
+			},
+		}
+		svc := newTestService(nil, nil, runner)
+
+		_, err := svc.CreateStdinUpload(ctx, 10)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "presign failed")
+	})
+}
+
+func TestValidateStdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     api.ExecutionRequest
+		wantErr string
+	}{
+		{name: "no stdin"},
+		{name: "inline stdin", req: api.ExecutionRequest{Stdin: base64.StdEncoding.EncodeToString([]byte("a,b\n"))}},
+		{name: "uploaded stdin", req: api.ExecutionRequest{StdinUploadID: strings.Repeat("ab", 16)}},
+		{
+			name: "both inline and uploaded stdin",
+			req: api.ExecutionRequest{
+				Stdin:         base64.StdEncoding.EncodeToString([]byte("x")),
+				StdinUploadID: strings.Repeat("ab", 16),
+			},
+			wantErr: "mutually exclusive",
+		},
+		{name: "inline stdin not base64", req: api.ExecutionRequest{Stdin: "not base64!"}, wantErr: "base64"},
+		{
+			name:    "inline stdin too large",
+			req:     api.ExecutionRequest{Stdin: base64.StdEncoding.EncodeToString(make([]byte, constants.MaxInlineStdinBytes+1))},
+			wantErr: "upload it instead",
+		},
+		{name: "invalid upload ID", req: api.ExecutionRequest{StdinUploadID: "../other-key"}, wantErr: "invalid stdin upload ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStdin(&tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+		})
+	}
+}
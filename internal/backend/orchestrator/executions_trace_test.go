@@ -137,8 +137,17 @@ func (m *traceMinimalRunner) KillTask(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *traceMinimalRunner) CreateStdinUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func (m *traceMinimalRunner) CreateContextUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
 func (m *traceMinimalRunner) RegisterImage(
-	_ context.Context, _ string, _ *bool, _, _ *string, _, _ *int, _ *string, _ string,
+	_ context.Context, _ string, _ *bool, _, _ *string, _, _ *int, _ *string, _ *api.LogLimits, _ *int,
+	_ *api.RunDefaults, _ string,
 ) error {
 	return nil
 }
@@ -155,6 +164,30 @@ func (m *traceMinimalRunner) RemoveImage(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *traceMinimalRunner) SoftDeleteImage(_ context.Context, _, _ string) (*api.ImageInfo, error) {
+	return nil, nil
+}
+
+func (m *traceMinimalRunner) RestoreImage(_ context.Context, _ string) (*api.ImageInfo, error) {
+	return nil, nil
+}
+
+func (m *traceMinimalRunner) SetImageAlias(_ context.Context, _ *api.ImageAlias) (string, error) {
+	return "", nil
+}
+
+func (m *traceMinimalRunner) GetImageAlias(_ context.Context, _ string) (*api.ImageAlias, error) {
+	return nil, nil
+}
+
+func (m *traceMinimalRunner) ListImageAliases(_ context.Context) ([]api.ImageAlias, error) {
+	return nil, nil
+}
+
+func (m *traceMinimalRunner) RemoveImageAlias(_ context.Context, _ string) error {
+	return nil
+}
+
 func (m *traceMinimalRunner) FetchLogsByExecutionID(_ context.Context, _ string) ([]api.LogEvent, error) {
 	return nil, nil
 }
@@ -169,6 +202,10 @@ func (m *traceMinimalRunner) FetchBackendLogs(_ context.Context, _ string) ([]ap
 	return m.logs, nil
 }
 
+func (m *traceMinimalRunner) FetchResourceUsage(_ context.Context, _ []string) (map[string]*api.ResourceUsage, error) {
+	return nil, nil
+}
+
 func (m *traceMinimalRunner) GetImagesByRequestID(_ context.Context, _ string) ([]api.ImageInfo, error) {
 	return nil, nil
 }
@@ -199,6 +236,18 @@ func (r *minimalUserRepository) RevokeUser(_ context.Context, _ string) error {
 	return nil
 }
 
+func (*minimalUserRepository) UpdateUser(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*minimalUserRepository) UpdateUserPreferences(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*minimalUserRepository) ReplaceAPIKeyHash(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (r *minimalUserRepository) CreatePendingAPIKey(_ context.Context, _ *api.PendingAPIKey) error {
 	return nil
 }
@@ -215,6 +264,14 @@ func (r *minimalUserRepository) DeletePendingAPIKey(_ context.Context, _ string)
 	return nil
 }
 
+func (r *minimalUserRepository) ListPendingAPIKeys(_ context.Context) ([]*api.PendingAPIKey, error) {
+	return nil, nil
+}
+
+func (r *minimalUserRepository) DeleteUser(_ context.Context, _ string) error {
+	return nil
+}
+
 func (r *minimalUserRepository) GetUsersByRequestID(_ context.Context, _ string) ([]*api.User, error) {
 	return nil, nil
 }
@@ -233,6 +290,10 @@ func (r *minimalExecutionRepository) CreateExecution(_ context.Context, _ *api.E
 	return nil
 }
 
+func (r *minimalExecutionRepository) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return nil
+}
+
 func (r *minimalExecutionRepository) GetExecution(_ context.Context, _ string) (*api.Execution, error) {
 	return nil, nil
 }
@@ -245,10 +306,62 @@ func (r *minimalExecutionRepository) ListExecutions(_ context.Context, _ int, _
 	return nil, nil
 }
 
+func (r *minimalExecutionRepository) ListExecutionsByCreator(
+	_ context.Context, _ string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
 func (r *minimalExecutionRepository) GetExecutionsByRequestID(_ context.Context, _ string) ([]*api.Execution, error) {
 	return nil, nil
 }
 
+func (r *minimalExecutionRepository) GetExecutionsByGroupID(_ context.Context, _ string) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *minimalExecutionRepository) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return nil
+}
+
+func (m *minimalExecutionRepository) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *minimalExecutionRepository) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *minimalExecutionRepository) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *minimalExecutionRepository) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *minimalExecutionRepository) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+func (m *minimalExecutionRepository) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return nil
+}
+
+func (m *minimalExecutionRepository) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, nil
+}
+
+func (m *minimalExecutionRepository) AddExecutionAnnotation(
+	_ context.Context, _ string, _ *api.ExecutionAnnotation,
+) error {
+	return nil
+}
+
 type minimalExecutionRepositoryWithDelay struct {
 	minimalExecutionRepository
 	delay time.Duration
@@ -291,7 +404,7 @@ func (r *minimalConnectionRepository) GetConnectionsByExecutionID(
 	return nil, nil
 }
 
-func (r *minimalConnectionRepository) UpdateLastEventID(context.Context, string, string) error {
+func (r *minimalConnectionRepository) UpdateLastEventID(context.Context, string, string, int64) error {
 	return nil
 }
 
@@ -301,7 +414,7 @@ func (r *minimalTokenRepository) CreateToken(_ context.Context, _ *api.WebSocket
 	return nil
 }
 
-func (r *minimalTokenRepository) GetToken(_ context.Context, _ string) (*api.WebSocketToken, error) {
+func (r *minimalTokenRepository) ClaimToken(_ context.Context, _ string) (*api.WebSocketToken, error) {
 	return nil, nil
 }
 
@@ -309,6 +422,18 @@ func (r *minimalTokenRepository) DeleteToken(_ context.Context, _ string) error
 	return nil
 }
 
+func (r *minimalTokenRepository) DeleteTokensByExecutionID(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
+func (r *minimalTokenRepository) CreateRunToken(_ context.Context, _ *api.RunToken) error {
+	return nil
+}
+
+func (r *minimalTokenRepository) GetRunToken(_ context.Context, _ string) (*api.RunToken, error) {
+	return nil, nil
+}
+
 type minimalImageRepository struct{}
 
 func (r *minimalImageRepository) GetImagesByRequestID(_ context.Context, _ string) ([]api.ImageInfo, error) {
@@ -341,6 +466,10 @@ func (r *minimalSecretsRepository) GetSecretsByRequestID(_ context.Context, _ st
 	return nil, nil
 }
 
+func (r *minimalSecretsRepository) RecordSecretUsage(_ context.Context, _, _ string, _ time.Time) error {
+	return nil
+}
+
 type minimalWebSocketManager struct{}
 
 func (m *minimalWebSocketManager) GenerateWebSocketURL(
@@ -359,6 +488,10 @@ func (m *minimalWebSocketManager) NotifyExecutionCompletion(_ context.Context, _
 	return nil
 }
 
+func (m *minimalWebSocketManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (m *minimalWebSocketManager) SendLogsToExecution(
 	_ context.Context, _ *string,
 ) error {
@@ -371,6 +504,10 @@ func (m *minimalHealthManager) Reconcile(_ context.Context) (*api.HealthReport,
 	return &api.HealthReport{}, nil
 }
 
+func (m *minimalHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
 // newTraceTestService creates a Service for trace testing with minimal mocks.
 // The runner parameter implements all 4 interfaces (TaskManager, ImageRegistry, LogManager, ObservabilityManager).
 func newTraceTestService(t *testing.T) *Service {
@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// ExchangeGitHubToken exchanges the OIDC token of a GitHub Actions workflow for a short-lived run token, so
+// that CI jobs run commands without storing an API key as a secret. The repository of the workflow must be
+// trusted, and the run token acts as the user the repository is mapped to.
+func (s *Service) ExchangeGitHubToken(
+	ctx context.Context,
+	req api.GitHubTokenRequest,
+) (*api.GitHubTokenResponse, error) {
+	if s.GitHubVerifier == nil || len(s.GitHubTrusts) == 0 || s.repos.Token == nil {
+		return nil, apperrors.ErrNotFound("the GitHub Actions integration is not configured", nil)
+	}
+	if req.IDToken == "" {
+		return nil, apperrors.ErrBadRequest("id_token is required", nil)
+	}
+
+	claims, err := s.GitHubVerifier.Verify(ctx, req.IDToken)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized("invalid GitHub Actions OIDC token", err)
+	}
+	repository := stringClaim(claims, "repository")
+	email, ok := s.trustedGitHubUser(claims)
+	if !ok {
+		return nil, apperrors.ErrForbidden(fmt.Sprintf("repository %q is not trusted", repository), nil)
+	}
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to get user", err)
+	}
+	if user == nil || user.Revoked {
+		return nil, apperrors.ErrForbidden(
+			fmt.Sprintf("the user %s trusted for repository %q is not active", email, repository), nil)
+	}
+
+	secret, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to generate run token", err)
+	}
+	token := constants.RunTokenPrefix + secret
+	now := time.Now()
+	expiresAt := now.Add(constants.RunTokenTTL)
+	if err = s.repos.Token.CreateRunToken(ctx, &api.RunToken{
+		TokenHash: auth.HashAPIKey(token),
+		UserEmail: email,
+		Subject:   claims.Subject,
+		ExpiresAt: expiresAt.Unix(),
+		CreatedAt: now.Unix(),
+	}); err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to store run token", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("run token issued to GitHub Actions workflow", "context",
+		map[string]string{
+			"email":      email,
+			"repository": repository,
+			"subject":    claims.Subject,
+			"workflow":   stringClaim(claims, "workflow_ref"),
+			"run_id":     stringClaim(claims, "run_id"),
+		})
+
+	return &api.GitHubTokenResponse{
+		Token:      token,
+		UserEmail:  email,
+		Repository: repository,
+		ExpiresAt:  expiresAt.UTC(),
+	}, nil
+}
+
+// trustedGitHubUser returns the user the repository of the workflow is trusted to act as, preferring a trust
+// of the repository over a trust of all the repositories of its owner.
+func (s *Service) trustedGitHubUser(claims *oidc.Claims) (string, bool) {
+	repository := strings.ToLower(stringClaim(claims, "repository"))
+	owner := strings.ToLower(stringClaim(claims, "repository_owner"))
+	if repository == "" || owner == "" || !strings.HasPrefix(repository, owner+"/") {
+		return "", false
+	}
+	if email, ok := s.GitHubTrusts[repository]; ok {
+		return email, true
+	}
+	email, ok := s.GitHubTrusts[owner+"/*"]
+	return email, ok
+}
+
+// getRunTokenUser returns the user a run token acts as, or nil if the run token doesn't exist or has expired.
+func (s *Service) getRunTokenUser(ctx context.Context, tokenHash string) (*api.User, error) {
+	if s.repos.Token == nil {
+		return nil, nil
+	}
+	token, err := s.repos.Token.GetRunToken(ctx, tokenHash)
+	if err != nil || token == nil {
+		return nil, err
+	}
+	return s.repos.User.GetUserByEmail(ctx, token.UserEmail)
+}
+
+func stringClaim(claims *oidc.Claims, name string) string {
+	value, _ := claims.Raw[name].(string)
+	return value
+}
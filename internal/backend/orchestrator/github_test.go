@@ -0,0 +1,128 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRunTokenRepository returns a token repository storing the run tokens in the map, keyed by their hash.
+func newRunTokenRepository(runTokens map[string]*api.RunToken) *mockTokenRepository {
+	return &mockTokenRepository{
+		createRunTokenFunc: func(_ context.Context, token *api.RunToken) error {
+			runTokens[token.TokenHash] = token
+			return nil
+		},
+		getRunTokenFunc: func(_ context.Context, tokenHash string) (*api.RunToken, error) {
+			token, ok := runTokens[tokenHash]
+			if !ok || token.ExpiresAt <= time.Now().Unix() {
+				return nil, nil
+			}
+			return token, nil
+		},
+	}
+}
+
+func githubClaims(repository string) *oidc.Claims {
+	owner, _, _ := strings.Cut(repository, "/")
+	return &oidc.Claims{
+		Subject: "repo:" + repository + ":ref:refs/heads/main",
+		Raw: map[string]any{
+			"repository":       repository,
+			"repository_owner": owner,
+			"workflow_ref":     repository + "/.github/workflows/ci.yml@refs/heads/main",
+		},
+	}
+}
+
+func TestExchangeGitHubToken(t *testing.T) {
+	users := map[string]*api.User{
+		"ci@example.com":      {Email: "ci@example.com", Role: "operator"},
+		"ci-acme@example.com": {Email: "ci-acme@example.com", Role: "viewer"},
+		"revoked@example.com": {Email: "revoked@example.com", Role: "viewer", Revoked: true},
+	}
+	runTokens := make(map[string]*api.RunToken)
+	repo, _ := newSCIMUserRepository(users)
+	service := newTestService(repo, nil, nil)
+	service.repos.Token = newRunTokenRepository(runTokens)
+	service.GitHubTrusts = map[string]string{
+		"acme/api":  "ci@example.com",
+		"acme/*":    "ci-acme@example.com",
+		"acme/prod": "revoked@example.com",
+	}
+
+	tests := []struct {
+		repository string
+		wantEmail  string
+		wantStatus int
+	}{
+		{repository: "acme/api", wantEmail: "ci@example.com"},
+		{repository: "Acme/API", wantEmail: "ci@example.com"},
+		{repository: "acme/web", wantEmail: "ci-acme@example.com"},
+		{repository: "other/api", wantStatus: http.StatusForbidden},
+		{repository: "acme/prod", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repository, func(t *testing.T) {
+			service.GitHubVerifier = &stubIdentityVerifier{claims: githubClaims(tt.repository)}
+
+			resp, err := service.ExchangeGitHubToken(context.Background(), api.GitHubTokenRequest{IDToken: "valid-token"})
+			if tt.wantStatus != 0 {
+				assert.Equal(t, tt.wantStatus, apperrors.GetStatusCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEmail, resp.UserEmail)
+			assert.True(t, strings.HasPrefix(resp.Token, constants.RunTokenPrefix))
+			assert.WithinDuration(t, time.Now().Add(constants.RunTokenTTL), resp.ExpiresAt, time.Minute)
+
+			user, err := service.AuthenticateUser(context.Background(), resp.Token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEmail, user.Email)
+		})
+	}
+}
+
+func TestExchangeGitHubToken_Rejects(t *testing.T) {
+	repo, _ := newSCIMUserRepository(map[string]*api.User{})
+
+	t.Run("not configured", func(t *testing.T) {
+		service := newTestService(repo, nil, nil)
+		_, err := service.ExchangeGitHubToken(context.Background(), api.GitHubTokenRequest{IDToken: "valid-token"})
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		service := newTestService(repo, nil, nil)
+		service.repos.Token = newRunTokenRepository(map[string]*api.RunToken{})
+		service.GitHubTrusts = map[string]string{"acme/*": "ci@example.com"}
+		service.GitHubVerifier = &stubIdentityVerifier{claims: githubClaims("acme/api")}
+
+		_, err := service.ExchangeGitHubToken(context.Background(), api.GitHubTokenRequest{IDToken: "forged-token"})
+		assert.Equal(t, http.StatusUnauthorized, apperrors.GetStatusCode(err))
+	})
+}
+
+func TestAuthenticateUser_RunToken(t *testing.T) {
+	users := map[string]*api.User{"ci@example.com": {Email: "ci@example.com", Role: "operator"}}
+	repo, _ := newSCIMUserRepository(users)
+	service := newTestService(repo, nil, nil)
+	service.repos.Token = newRunTokenRepository(map[string]*api.RunToken{})
+
+	_, err := service.AuthenticateUser(context.Background(), constants.RunTokenPrefix+"unknown")
+	assert.Equal(t, http.StatusUnauthorized, apperrors.GetStatusCode(err))
+
+	service.repos.Token = nil
+	_, err = service.AuthenticateUser(context.Background(), constants.RunTokenPrefix+"unknown")
+	assert.Equal(t, http.StatusUnauthorized, apperrors.GetStatusCode(err))
+}
@@ -5,15 +5,73 @@ import (
 	"fmt"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
 )
 
+// ReconcileOptions configures a health reconciliation run.
+type ReconcileOptions struct {
+	// CanaryUserEmail enables the canary mode when set: the canary launched by the previous
+	// canary reconciliation is evaluated and a new one is launched as this user.
+	CanaryUserEmail string
+}
+
 // ReconcileResources performs health reconciliation for all resources.
 // This method allows synchronous execution via API.
-func (s *Service) ReconcileResources(ctx context.Context) (*api.HealthReport, error) {
+// The report is also stored in the health report history when a repository is configured.
+func (s *Service) ReconcileResources(ctx context.Context, opts ReconcileOptions) (*api.HealthReport, error) {
 	report, err := s.healthManager.Reconcile(ctx)
 	if err != nil {
 		return nil, apperrors.ErrInternalError("failed to reconcile resources", fmt.Errorf("reconcile: %w", err))
 	}
+
+	if report != nil && opts.CanaryUserEmail != "" {
+		report.Canary = s.runCanary(ctx, opts.CanaryUserEmail)
+	}
+
+	if report != nil && s.repos.HealthReport != nil {
+		if saveErr := s.repos.HealthReport.CreateHealthReport(ctx, report); saveErr != nil {
+			// The reconciliation itself succeeded, losing its history entry is not worth failing the request.
+			logger.DeriveRequestLogger(ctx, s.Logger).Warn("failed to store health report", "error", saveErr)
+		}
+	}
+
+	return report, nil
+}
+
+// CleanupResources deletes the provider resources created by runvoy that are no longer referenced,
+// or only reports them when dryRun is true.
+func (s *Service) CleanupResources(ctx context.Context, dryRun bool) (*api.CleanupReport, error) {
+	report, err := s.healthManager.Cleanup(ctx, dryRun)
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to clean up resources", fmt.Errorf("cleanup: %w", err))
+	}
 	return report, nil
 }
+
+// ListHealthReports returns the most recent stored health reports, newest first.
+// limit must be between 1 and constants.MaxHealthReportListLimit.
+func (s *Service) ListHealthReports(ctx context.Context, limit int) ([]api.HealthReport, error) {
+	if s.repos.HealthReport == nil {
+		return nil, apperrors.ErrServiceUnavailable("health report history is not configured", nil)
+	}
+	if limit < 1 || limit > constants.MaxHealthReportListLimit {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("limit must be between 1 and %d", constants.MaxHealthReportListLimit), nil)
+	}
+
+	reports, err := s.repos.HealthReport.ListHealthReports(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetDatabaseStats returns the database queries aggregated by access pattern since the service started.
+func (s *Service) GetDatabaseStats() (*api.DatabaseStats, error) {
+	if s.QueryStats == nil {
+		return nil, apperrors.ErrServiceUnavailable("database query statistics are not available for this provider", nil)
+	}
+	return s.QueryStats.Snapshot(), nil
+}
@@ -0,0 +1,199 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// Canary phase names, in execution order.
+const (
+	canaryPhaseRun        = "run"
+	canaryPhaseLogs       = "logs"
+	canaryPhaseCompletion = "completion"
+)
+
+// runCanary evaluates the canary launched by an earlier reconciliation and launches a new one as userEmail.
+// No new canary is launched while the previous one is still pending, so canaries never pile up.
+func (s *Service) runCanary(ctx context.Context, userEmail string) *api.CanaryReport {
+	report := &api.CanaryReport{}
+
+	previous, err := s.lastLaunchedCanary(ctx)
+	if err != nil {
+		logger.DeriveRequestLogger(ctx, s.Logger).Warn("failed to look up the previous canary", "error", err)
+	}
+	if previous != nil {
+		report.Result = s.evaluateCanary(ctx, previous)
+		if report.Result.Status == string(constants.CanaryPending) {
+			return report
+		}
+	}
+
+	report.Launched = s.launchCanary(ctx, userEmail)
+	return report
+}
+
+// lastLaunchedCanary returns the most recent canary recorded in the health report history
+// that has not been evaluated to a final status yet, or nil if there is none.
+func (s *Service) lastLaunchedCanary(ctx context.Context) (*api.CanaryResult, error) {
+	if s.repos.HealthReport == nil {
+		return nil, nil
+	}
+
+	reports, err := s.repos.HealthReport.ListHealthReports(ctx, constants.CanaryHistoryLookback)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reports {
+		canary := reports[i].Canary
+		switch {
+		case canary == nil:
+			continue
+		case canary.Launched != nil:
+			if canary.Launched.Status != string(constants.CanaryPending) {
+				return nil, nil // the launch itself failed, there is nothing to evaluate
+			}
+			return canary.Launched, nil
+		case canary.Result != nil && canary.Result.Status == string(constants.CanaryPending):
+			return canary.Result, nil
+		default:
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// launchCanary starts a canary execution on the default image and times the run phase,
+// which covers resolving the image, starting the task and recording the execution.
+func (s *Service) launchCanary(ctx context.Context, userEmail string) *api.CanaryResult {
+	launchedAt := time.Now().UTC()
+	result := &api.CanaryResult{
+		LaunchedAt: launchedAt,
+		Status:     string(constants.CanaryPending),
+	}
+
+	fail := func(err error) *api.CanaryResult {
+		result.Status = string(constants.CanaryFailed)
+		result.Error = err.Error()
+		result.Phases = []api.CanaryPhase{{
+			Name:       canaryPhaseRun,
+			DurationMs: time.Since(launchedAt).Milliseconds(),
+			Message:    err.Error(),
+		}}
+		return result
+	}
+
+	image, err := s.ResolveImage(ctx, "")
+	if err != nil {
+		return fail(fmt.Errorf("failed to resolve the default image: %w", err))
+	}
+
+	resp, err := s.RunCommand(ctx, userEmail, nil, &api.ExecutionRequest{
+		Command: constants.CanaryCommand,
+		Timeout: constants.CanaryTimeoutSeconds,
+	}, image)
+	if err != nil {
+		return fail(fmt.Errorf("failed to start the canary execution: %w", err))
+	}
+
+	result.ExecutionID = resp.ExecutionID
+	result.Phases = []api.CanaryPhase{{
+		Name:       canaryPhaseRun,
+		Passed:     true,
+		DurationMs: time.Since(launchedAt).Milliseconds(),
+	}}
+	return result
+}
+
+// evaluateCanary checks the logs and the completion of a launched canary execution.
+// The logs phase lasts from the execution start to the expected output line, and the completion
+// phase from that line (or the execution start) to the completion recorded by the event processor.
+func (s *Service) evaluateCanary(ctx context.Context, launched *api.CanaryResult) *api.CanaryResult {
+	result := &api.CanaryResult{
+		ExecutionID: launched.ExecutionID,
+		LaunchedAt:  launched.LaunchedAt,
+		Status:      string(constants.CanaryPending),
+		Phases:      slices.Clone(launched.Phases),
+	}
+
+	execution, err := s.repos.Execution.GetExecution(ctx, launched.ExecutionID)
+	if err == nil && execution == nil {
+		err = errors.New("execution not found")
+	}
+	if err != nil {
+		result.Status = string(constants.CanaryFailed)
+		result.Error = fmt.Sprintf("failed to get canary execution: %v", err)
+		return result
+	}
+
+	completed := slices.Contains(constants.TerminalExecutionStatuses(), constants.ExecutionStatus(execution.Status))
+	if !completed && time.Since(launched.LaunchedAt) < constants.CanaryCompletionTimeout {
+		return result
+	}
+
+	logsPhase, outputAt := s.evaluateCanaryLogs(ctx, execution)
+	result.Phases = append(result.Phases, logsPhase, evaluateCanaryCompletion(execution, outputAt))
+
+	result.Status = string(constants.CanaryPassed)
+	for _, phase := range result.Phases {
+		if !phase.Passed {
+			result.Status = string(constants.CanaryFailed)
+			break
+		}
+	}
+	return result
+}
+
+// evaluateCanaryLogs looks for the expected output in the canary logs.
+// It returns the phase and the time of the output line, or the execution start if it is missing.
+func (s *Service) evaluateCanaryLogs(ctx context.Context, execution *api.Execution) (api.CanaryPhase, time.Time) {
+	phase := api.CanaryPhase{Name: canaryPhaseLogs}
+
+	events, err := s.logManager.FetchLogsByExecutionID(ctx, execution.ExecutionID)
+	if err != nil {
+		phase.Message = fmt.Sprintf("failed to fetch logs: %v", err)
+		return phase, execution.StartedAt
+	}
+
+	for _, event := range events {
+		if strings.TrimSpace(event.Message) == constants.CanaryExpectedOutput {
+			outputAt := time.UnixMilli(event.Timestamp)
+			phase.Passed = true
+			phase.DurationMs = outputAt.Sub(execution.StartedAt).Milliseconds()
+			return phase, outputAt
+		}
+	}
+
+	phase.Message = fmt.Sprintf("expected output %q not found in %d log lines",
+		constants.CanaryExpectedOutput, len(events))
+	return phase, execution.StartedAt
+}
+
+// evaluateCanaryCompletion checks that the completion event of the canary was processed successfully.
+func evaluateCanaryCompletion(execution *api.Execution, since time.Time) api.CanaryPhase {
+	phase := api.CanaryPhase{Name: canaryPhaseCompletion}
+
+	if execution.CompletedAt == nil {
+		phase.Message = fmt.Sprintf("no completion event received within %s (status %s)",
+			constants.CanaryCompletionTimeout, execution.Status)
+		return phase
+	}
+
+	phase.DurationMs = execution.CompletedAt.Sub(since).Milliseconds()
+	if execution.Status != string(constants.ExecutionSucceeded) {
+		phase.Message = fmt.Sprintf("execution ended with status %s and exit code %d",
+			execution.Status, execution.ExitCode)
+		return phase
+	}
+
+	phase.Passed = true
+	return phase
+}
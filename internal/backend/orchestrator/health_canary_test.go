@@ -0,0 +1,166 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanaryTestService(
+	execRepo *mockExecutionRepository,
+	runner *mockRunner,
+	reportRepo *mockHealthReportRepository,
+) *Service {
+	svc := newTestServiceWithConnRepo(&mockUserRepository{}, execRepo, nil, runner, runner, runner, runner)
+	svc.repos.HealthReport = reportRepo
+	return svc
+}
+
+func canaryRunner(logs []api.LogEvent) *mockRunner {
+	return &mockRunner{
+		getImageFunc: func(_ context.Context, _ string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{ImageID: "alpine:latest-a1b2c3d4"}, nil
+		},
+		startTaskFunc: func(_ context.Context, _ string, req *api.ExecutionRequest) (string, *time.Time, error) {
+			if req.Command != constants.CanaryCommand {
+				return "", nil, assert.AnError
+			}
+			return "canary-2", nil, nil
+		},
+		fetchLogsByExecutionIDFunc: func(_ context.Context, _ string) ([]api.LogEvent, error) {
+			return logs, nil
+		},
+	}
+}
+
+func pendingCanaryReport(executionID string, launchedAt time.Time) api.HealthReport {
+	return api.HealthReport{Canary: &api.CanaryReport{Launched: &api.CanaryResult{
+		ExecutionID: executionID,
+		LaunchedAt:  launchedAt,
+		Status:      string(constants.CanaryPending),
+		Phases:      []api.CanaryPhase{{Name: "run", Passed: true, DurationMs: 120}},
+	}}}
+}
+
+func TestReconcileResources_CanaryFirstRunLaunches(t *testing.T) {
+	var recorded *api.Execution
+	execRepo := &mockExecutionRepository{
+		createExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			recorded = execution
+			return nil
+		},
+	}
+	svc := newCanaryTestService(execRepo, canaryRunner(nil), &mockHealthReportRepository{})
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{CanaryUserEmail: "ops@example.com"})
+
+	require.NoError(t, err)
+	require.NotNil(t, report.Canary)
+	assert.Nil(t, report.Canary.Result)
+	require.NotNil(t, report.Canary.Launched)
+	assert.Equal(t, "canary-2", report.Canary.Launched.ExecutionID)
+	assert.Equal(t, string(constants.CanaryPending), report.Canary.Launched.Status)
+	require.Len(t, report.Canary.Launched.Phases, 1)
+	assert.True(t, report.Canary.Launched.Phases[0].Passed)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "ops@example.com", recorded.CreatedBy)
+	assert.Equal(t, constants.CanaryTimeoutSeconds, recorded.TimeoutSeconds)
+}
+
+func TestReconcileResources_CanaryPasses(t *testing.T) {
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	completedAt := startedAt.Add(40 * time.Second)
+	execRepo := &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			assert.Equal(t, "canary-1", executionID)
+			return &api.Execution{
+				ExecutionID: "canary-1",
+				StartedAt:   startedAt,
+				CompletedAt: &completedAt,
+				Status:      string(constants.ExecutionSucceeded),
+			}, nil
+		},
+	}
+	logs := []api.LogEvent{{Message: "ok\n", Timestamp: startedAt.Add(30 * time.Second).UnixMilli()}}
+	reportRepo := &mockHealthReportRepository{created: []api.HealthReport{
+		{}, pendingCanaryReport("canary-1", startedAt),
+	}}
+	svc := newCanaryTestService(execRepo, canaryRunner(logs), reportRepo)
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{CanaryUserEmail: "ops@example.com"})
+
+	require.NoError(t, err)
+	result := report.Canary.Result
+	require.NotNil(t, result)
+	assert.Equal(t, string(constants.CanaryPassed), result.Status)
+	assert.Equal(t, []api.CanaryPhase{
+		{Name: "run", Passed: true, DurationMs: 120},
+		{Name: "logs", Passed: true, DurationMs: 30000},
+		{Name: "completion", Passed: true, DurationMs: 10000},
+	}, result.Phases)
+	require.NotNil(t, report.Canary.Launched)
+	assert.Equal(t, "canary-2", report.Canary.Launched.ExecutionID)
+}
+
+func TestReconcileResources_CanaryPendingDoesNotLaunch(t *testing.T) {
+	launchedAt := time.Now().Add(-time.Minute)
+	execRepo := &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: "canary-1", StartedAt: launchedAt, Status: "RUNNING"}, nil
+		},
+	}
+	reportRepo := &mockHealthReportRepository{created: []api.HealthReport{pendingCanaryReport("canary-1", launchedAt)}}
+	svc := newCanaryTestService(execRepo, canaryRunner(nil), reportRepo)
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{CanaryUserEmail: "ops@example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constants.CanaryPending), report.Canary.Result.Status)
+	assert.Nil(t, report.Canary.Launched)
+}
+
+func TestReconcileResources_CanaryMissingCompletionFails(t *testing.T) {
+	launchedAt := time.Now().Add(-time.Hour)
+	execRepo := &mockExecutionRepository{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: "canary-1", StartedAt: launchedAt, Status: "RUNNING"}, nil
+		},
+	}
+	logs := []api.LogEvent{{Message: "ok", Timestamp: launchedAt.Add(20 * time.Second).UnixMilli()}}
+	reportRepo := &mockHealthReportRepository{created: []api.HealthReport{pendingCanaryReport("canary-1", launchedAt)}}
+	svc := newCanaryTestService(execRepo, canaryRunner(logs), reportRepo)
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{CanaryUserEmail: "ops@example.com"})
+
+	require.NoError(t, err)
+	result := report.Canary.Result
+	assert.Equal(t, string(constants.CanaryFailed), result.Status)
+	require.Len(t, result.Phases, 3)
+	assert.True(t, result.Phases[1].Passed)
+	assert.False(t, result.Phases[2].Passed)
+	assert.Contains(t, result.Phases[2].Message, "no completion event received")
+	assert.NotNil(t, report.Canary.Launched)
+}
+
+func TestReconcileResources_CanaryLaunchFailure(t *testing.T) {
+	runner := canaryRunner(nil)
+	runner.getImageFunc = func(_ context.Context, _ string) (*api.ImageInfo, error) {
+		return nil, nil
+	}
+	svc := newCanaryTestService(&mockExecutionRepository{}, runner, &mockHealthReportRepository{})
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{CanaryUserEmail: "ops@example.com"})
+
+	require.NoError(t, err)
+	launched := report.Canary.Launched
+	assert.Equal(t, string(constants.CanaryFailed), launched.Status)
+	assert.Contains(t, launched.Error, "no default image")
+	require.Len(t, launched.Phases, 1)
+	assert.False(t, launched.Phases[0].Passed)
+}
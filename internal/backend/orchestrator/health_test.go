@@ -0,0 +1,85 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockHealthReportRepository struct {
+	created   []api.HealthReport
+	createErr error
+	listLimit int
+}
+
+func (m *mockHealthReportRepository) CreateHealthReport(_ context.Context, report *api.HealthReport) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.created = append(m.created, *report)
+	return nil
+}
+
+func (m *mockHealthReportRepository) ListHealthReports(_ context.Context, limit int) ([]api.HealthReport, error) {
+	m.listLimit = limit
+	return m.created, nil
+}
+
+func newHealthTestService(repo *mockHealthReportRepository) *Service {
+	svc := newTestServiceWithConnRepo(
+		&mockUserRepository{}, &mockExecutionRepository{}, nil, &mockRunner{}, &mockRunner{}, &mockRunner{}, &mockRunner{})
+	if repo != nil {
+		svc.repos.HealthReport = repo
+	}
+	return svc
+}
+
+func TestReconcileResources_StoresReport(t *testing.T) {
+	repo := &mockHealthReportRepository{}
+	svc := newHealthTestService(repo)
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{})
+
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.Len(t, repo.created, 1)
+}
+
+func TestReconcileResources_StoreFailureDoesNotFail(t *testing.T) {
+	svc := newHealthTestService(&mockHealthReportRepository{createErr: errors.New("boom")})
+
+	report, err := svc.ReconcileResources(context.Background(), ReconcileOptions{})
+
+	require.NoError(t, err)
+	assert.NotNil(t, report)
+}
+
+func TestListHealthReports(t *testing.T) {
+	now := time.Now()
+	repo := &mockHealthReportRepository{created: []api.HealthReport{{Timestamp: now, ErrorCount: 1}}}
+	svc := newHealthTestService(repo)
+
+	reports, err := svc.ListHealthReports(context.Background(), 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, repo.listLimit)
+	require.Len(t, reports, 1)
+	assert.Equal(t, 1, reports[0].ErrorCount)
+}
+
+func TestListHealthReports_Errors(t *testing.T) {
+	svc := newHealthTestService(&mockHealthReportRepository{})
+	_, err := svc.ListHealthReports(context.Background(), 0)
+	assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+
+	svc = newHealthTestService(nil)
+	_, err = svc.ListHealthReports(context.Background(), 5)
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+}
@@ -0,0 +1,159 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+var (
+	// imageAliasPattern matches aliases in the name:channel form of image references, e.g. python:stable.
+	imageAliasPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._/-]*:[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	// imageIDSuffixPattern matches the configuration hash ending image IDs, which aliases must not end with.
+	imageIDSuffixPattern = regexp.MustCompile(`-[0-9a-fA-F]{8}$`)
+)
+
+// validateImageAlias checks that an alias has the name:channel form and cannot be mistaken for an image ID.
+func validateImageAlias(alias string) error {
+	if alias == "" {
+		return appErrors.ErrBadRequest("alias is required", nil)
+	}
+	if len(alias) > constants.MaxImageAliasLength {
+		return appErrors.ErrBadRequest(
+			fmt.Sprintf("alias must be at most %d characters", constants.MaxImageAliasLength), nil)
+	}
+	if !imageAliasPattern.MatchString(alias) || imageIDSuffixPattern.MatchString(alias) {
+		return appErrors.ErrBadRequest(
+			fmt.Sprintf("invalid alias %q, expected a name and a channel such as python:stable", alias), nil)
+	}
+	return nil
+}
+
+// SetImageAlias creates an image alias or points it at another image. The target image is resolved to its
+// image ID when the alias is set, so executions referencing the alias pick up the new image from then on.
+func (s *Service) SetImageAlias(
+	ctx context.Context, req *api.SetImageAliasRequest, updatedBy string,
+) (*api.SetImageAliasResponse, error) {
+	if req == nil {
+		return nil, appErrors.ErrBadRequest("request is required", nil)
+	}
+	if err := validateImageAlias(req.Alias); err != nil {
+		return nil, err
+	}
+	if req.Image == "" {
+		return nil, appErrors.ErrBadRequest("image is required", nil)
+	}
+
+	registered, err := s.imageRegistry.GetImage(ctx, req.Alias)
+	if err != nil {
+		return nil, wrapImageAliasError(err, "failed to check image alias", "get image")
+	}
+	if registered != nil {
+		return nil, appErrors.ErrConflict(
+			fmt.Sprintf("alias %q conflicts with the registered image %s", req.Alias, registered.ImageID), nil)
+	}
+
+	target, err := s.imageRegistry.GetImage(ctx, req.Image)
+	if err != nil {
+		return nil, wrapImageAliasError(err, "failed to resolve image", "get image")
+	}
+	if target == nil {
+		return nil, appErrors.ErrNotFound(fmt.Sprintf("image %q not found", req.Image), nil)
+	}
+	if target.DeletedAt != nil {
+		return nil, appErrors.ErrImageDeleted(
+			fmt.Sprintf("image %s was deleted, restore it before pointing an alias at it", target.ImageID), nil)
+	}
+
+	alias := &api.ImageAlias{
+		Alias:     req.Alias,
+		ImageID:   target.ImageID,
+		UpdatedBy: updatedBy,
+		UpdatedAt: time.Now().UTC(),
+	}
+	previous, err := s.imageRegistry.SetImageAlias(ctx, alias)
+	if err != nil {
+		return nil, wrapImageAliasError(err, "failed to set image alias", "set image alias")
+	}
+
+	message := fmt.Sprintf("Alias %s now points at %s", alias.Alias, alias.ImageID)
+	if previous != "" && previous != alias.ImageID {
+		message = fmt.Sprintf("Alias %s moved from %s to %s", alias.Alias, previous, alias.ImageID)
+	}
+	return &api.SetImageAliasResponse{
+		Alias:           *alias,
+		PreviousImageID: previous,
+		Message:         message,
+	}, nil
+}
+
+// ListImageAliases lists all image aliases.
+func (s *Service) ListImageAliases(ctx context.Context) (*api.ListImageAliasesResponse, error) {
+	aliases, err := s.imageRegistry.ListImageAliases(ctx)
+	if err != nil {
+		return nil, wrapImageAliasError(err, "failed to list image aliases", "list image aliases")
+	}
+
+	return &api.ListImageAliasesResponse{
+		Aliases: aliases,
+	}, nil
+}
+
+// RemoveImageAlias removes an image alias. Executions referencing it fail to resolve their image afterwards.
+func (s *Service) RemoveImageAlias(ctx context.Context, alias string) (*api.RemoveImageAliasResponse, error) {
+	if alias == "" {
+		return nil, appErrors.ErrBadRequest("alias is required", nil)
+	}
+
+	if err := s.imageRegistry.RemoveImageAlias(ctx, alias); err != nil {
+		return nil, wrapImageAliasError(err, "failed to remove image alias", "remove image alias")
+	}
+
+	return &api.RemoveImageAliasResponse{
+		Alias:   alias,
+		Message: "Image alias removed successfully",
+	}, nil
+}
+
+// resolveImageAlias returns the image an alias points at, with ResolvedFromAlias set, or nil when image is
+// not an alias.
+func (s *Service) resolveImageAlias(ctx context.Context, image string) (*api.ImageInfo, error) {
+	if validateImageAlias(image) != nil {
+		return nil, nil
+	}
+
+	alias, err := s.imageRegistry.GetImageAlias(ctx, image)
+	if err != nil {
+		return nil, appErrors.ErrInternalError("failed to resolve image alias", fmt.Errorf("get image alias: %w", err))
+	}
+	if alias == nil {
+		return nil, nil
+	}
+
+	imageInfo, err := s.imageRegistry.GetImage(ctx, alias.ImageID)
+	if err != nil {
+		return nil, appErrors.ErrInternalError("failed to resolve image", fmt.Errorf("resolve image: %w", err))
+	}
+	if imageInfo == nil {
+		return nil, appErrors.ErrBadRequest(
+			fmt.Sprintf("image alias %s points at %s, which is no longer registered", alias.Alias, alias.ImageID), nil)
+	}
+
+	imageInfo.ResolvedFromAlias = alias.Alias
+	return imageInfo, nil
+}
+
+// wrapImageAliasError wraps registry errors, keeping their status when they already are AppErrors.
+func wrapImageAliasError(err error, message, operation string) error {
+	var appErr *appErrors.AppError
+	if errors.As(err, &appErr) {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	return appErrors.ErrInternalError(message, fmt.Errorf("%s: %w", operation, err))
+}
@@ -0,0 +1,180 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateImageAlias(t *testing.T) {
+	for _, alias := range []string{"python:stable", "python:latest", "team/python:3.12-stable", "node:lts"} {
+		assert.NoError(t, validateImageAlias(alias), alias)
+	}
+	for _, alias := range []string{"", "python", "Python:stable", "python:", ":stable", "python:3.12-a1b2c3d4",
+		"python:stable channel"} {
+		assert.Error(t, validateImageAlias(alias), alias)
+	}
+}
+
+func TestSetImageAlias(t *testing.T) {
+	ctx := context.Background()
+	images := map[string]*api.ImageInfo{
+		"python:3.12":          {ImageID: "python:3.12-a1b2c3d4", Image: "python:3.12"},
+		"python:3.12-a1b2c3d4": {ImageID: "python:3.12-a1b2c3d4", Image: "python:3.12"},
+	}
+	getImage := func(_ context.Context, image string) (*api.ImageInfo, error) {
+		return images[image], nil
+	}
+
+	t.Run("creates the alias pointing at the image ID", func(t *testing.T) {
+		var stored *api.ImageAlias
+		runner := &mockRunner{
+			getImageFunc: getImage,
+			setImageAliasFunc: func(_ context.Context, alias *api.ImageAlias) (string, error) {
+				stored = alias
+				return "", nil
+			},
+		}
+		service := newImageTestService(t, runner)
+
+		resp, err := service.SetImageAlias(ctx,
+			&api.SetImageAliasRequest{Alias: "python:stable", Image: "python:3.12"}, "user@example.com")
+
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "python:3.12-a1b2c3d4", stored.ImageID)
+		assert.Equal(t, "user@example.com", stored.UpdatedBy)
+		assert.Equal(t, "python:stable", resp.Alias.Alias)
+		assert.Empty(t, resp.PreviousImageID)
+		assert.Equal(t, "Alias python:stable now points at python:3.12-a1b2c3d4", resp.Message)
+	})
+
+	t.Run("reports the previous image", func(t *testing.T) {
+		runner := &mockRunner{
+			getImageFunc: getImage,
+			setImageAliasFunc: func(_ context.Context, _ *api.ImageAlias) (string, error) {
+				return "python:3.11-e5f6a7b8", nil
+			},
+		}
+		service := newImageTestService(t, runner)
+
+		resp, err := service.SetImageAlias(ctx,
+			&api.SetImageAliasRequest{Alias: "python:stable", Image: "python:3.12-a1b2c3d4"}, "user@example.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, "python:3.11-e5f6a7b8", resp.PreviousImageID)
+		assert.Equal(t, "Alias python:stable moved from python:3.11-e5f6a7b8 to python:3.12-a1b2c3d4", resp.Message)
+	})
+
+	t.Run("refuses invalid requests", func(t *testing.T) {
+		deletedAt := time.Now().UTC()
+		runner := &mockRunner{
+			getImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
+				if image == "python:3.10" {
+					return &api.ImageInfo{ImageID: "python:3.10-c9d0e1f2", DeletedAt: &deletedAt}, nil
+				}
+				return images[image], nil
+			},
+			setImageAliasFunc: func(_ context.Context, _ *api.ImageAlias) (string, error) {
+				t.Fatal("alias must not be set")
+				return "", nil
+			},
+		}
+		service := newImageTestService(t, runner)
+
+		tests := []struct {
+			name       string
+			req        *api.SetImageAliasRequest
+			statusCode int
+		}{
+			{"invalid alias", &api.SetImageAliasRequest{Alias: "stable", Image: "python:3.12"}, http.StatusBadRequest},
+			{"missing image", &api.SetImageAliasRequest{Alias: "python:stable"}, http.StatusBadRequest},
+			{"alias names a registered image", &api.SetImageAliasRequest{Alias: "python:3.12", Image: "python:3.12"},
+				http.StatusConflict},
+			{"image not registered", &api.SetImageAliasRequest{Alias: "python:stable", Image: "python:3.13"},
+				http.StatusNotFound},
+			{"image deleted", &api.SetImageAliasRequest{Alias: "python:stable", Image: "python:3.10"},
+				http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := service.SetImageAlias(ctx, tt.req, "user@example.com")
+				assert.Equal(t, tt.statusCode, apperrors.GetStatusCode(err))
+			})
+		}
+	})
+}
+
+func TestListImageAliases(t *testing.T) {
+	runner := &mockRunner{
+		listImageAliasesFunc: func(_ context.Context) ([]api.ImageAlias, error) {
+			return []api.ImageAlias{{Alias: "python:stable", ImageID: "python:3.12-a1b2c3d4"}}, nil
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	resp, err := service.ListImageAliases(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Aliases, 1)
+}
+
+func TestRemoveImageAlias(t *testing.T) {
+	runner := &mockRunner{
+		removeImageAliasFunc: func(_ context.Context, alias string) error {
+			if alias == "python:stable" {
+				return nil
+			}
+			return apperrors.ErrNotFound("image alias not found", nil)
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	resp, err := service.RemoveImageAlias(context.Background(), "python:stable")
+	require.NoError(t, err)
+	assert.Equal(t, "python:stable", resp.Alias)
+
+	_, err = service.RemoveImageAlias(context.Background(), "node:lts")
+	assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+}
+
+func TestResolveImage_Alias(t *testing.T) {
+	ctx := context.Background()
+	runner := &mockRunner{
+		getImageAliasFunc: func(_ context.Context, alias string) (*api.ImageAlias, error) {
+			switch alias {
+			case "python:stable":
+				return &api.ImageAlias{Alias: alias, ImageID: "python:3.12-a1b2c3d4"}, nil
+			case "python:old":
+				return &api.ImageAlias{Alias: alias, ImageID: "python:3.9-e5f6a7b8"}, nil
+			}
+			return nil, nil
+		},
+		getImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
+			if image == "python:3.12-a1b2c3d4" || image == "python:3.12" {
+				return &api.ImageInfo{ImageID: "python:3.12-a1b2c3d4", Image: "python:3.12"}, nil
+			}
+			return nil, nil
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	imageInfo, err := service.ResolveImage(ctx, "python:stable")
+	require.NoError(t, err)
+	assert.Equal(t, "python:3.12-a1b2c3d4", imageInfo.ImageID)
+	assert.Equal(t, "python:stable", imageInfo.ResolvedFromAlias)
+
+	imageInfo, err = service.ResolveImage(ctx, "python:3.12")
+	require.NoError(t, err)
+	assert.Empty(t, imageInfo.ResolvedFromAlias)
+
+	_, err = service.ResolveImage(ctx, "python:old")
+	assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+}
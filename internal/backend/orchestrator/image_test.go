@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,8 @@ func TestRegisterImage(t *testing.T) {
 		name          string
 		image         string
 		isDefault     *bool
+		logLimits     *api.LogLimits
+		warmPoolSize  *int
 		runnerErr     error
 		expectErr     bool
 		expectedError string
@@ -59,6 +63,30 @@ func TestRegisterImage(t *testing.T) {
 			expectErr:     true,
 			expectedError: "failed to register image",
 		},
+		{
+			name:      "successful registration with log limits",
+			image:     "busybox:latest",
+			logLimits: &api.LogLimits{MaxLinesPerSecond: 100, MaxBytes: 1024},
+		},
+		{
+			name:          "negative log limits",
+			image:         "busybox:latest",
+			logLimits:     &api.LogLimits{MaxLinesPerSecond: -1},
+			expectErr:     true,
+			expectedError: "log limits must not be negative",
+		},
+		{
+			name:         "successful registration with warm pool",
+			image:        "busybox:latest",
+			warmPoolSize: intPtr(2),
+		},
+		{
+			name:          "warm pool size too large",
+			image:         "busybox:latest",
+			warmPoolSize:  intPtr(constants.MaxWarmPoolSize + 1),
+			expectErr:     true,
+			expectedError: "warm pool size must be between 0 and",
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,8 +103,10 @@ func TestRegisterImage(t *testing.T) {
 			resp, err := svc.RegisterImage(
 				ctx,
 				&api.RegisterImageRequest{
-					Image:     tt.image,
-					IsDefault: tt.isDefault,
+					Image:        tt.image,
+					IsDefault:    tt.isDefault,
+					LogLimits:    tt.logLimits,
+					WarmPoolSize: tt.warmPoolSize,
 				},
 				"test@example.com",
 			)
@@ -223,6 +253,8 @@ func TestResolveImage(t *testing.T) {
 
 	defaultImage := &api.ImageInfo{Image: "default"}
 	providedImage := &api.ImageInfo{Image: "custom"}
+	deletedAt := time.Now().UTC()
+	deletedImage := &api.ImageInfo{ImageID: "custom-a1b2c3d4", Image: "custom", DeletedAt: &deletedAt}
 
 	tests := []struct {
 		name            string
@@ -262,7 +294,13 @@ func TestResolveImage(t *testing.T) {
 			name:            "provided image not registered",
 			image:           "custom",
 			providedImage:   nil,
-			expectedErrCode: apperrors.ErrCodeInvalidRequest,
+			expectedErrCode: apperrors.ErrCodeImageNotRegistered,
+		},
+		{
+			name:            "provided image deleted",
+			image:           "custom",
+			providedImage:   deletedImage,
+			expectedErrCode: apperrors.ErrCodeImageDeleted,
 		},
 		{
 			name:            "error resolving provided image",
@@ -300,7 +338,90 @@ func TestResolveImage(t *testing.T) {
 	}
 }
 
+func TestResolveRunImage(t *testing.T) {
+	ctx := context.Background()
+	developer := &api.User{Email: "dev@example.com", Role: "developer"}
+
+	newRunner := func(registered map[string]*api.ImageInfo) *mockRunner {
+		return &mockRunner{
+			getImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
+				return registered[image], nil
+			},
+			registerImageFunc: func(
+				_ context.Context, image string, _ *bool, _, _ *string, _, _ *int, _ *string, createdBy string,
+			) error {
+				registered[image] = &api.ImageInfo{ImageID: image + "-a1b2c3d4", Image: image, CreatedBy: createdBy}
+				return nil
+			},
+		}
+	}
+
+	t.Run("resolves registered images under any policy", func(t *testing.T) {
+		svc := newTestService(nil, nil, newRunner(map[string]*api.ImageInfo{
+			"python:3.12": {ImageID: "python:3.12-a1b2c3d4", Image: "python:3.12"},
+		}))
+
+		imageInfo, err := svc.ResolveRunImage(ctx, developer, "python:3.12")
+
+		require.NoError(t, err)
+		assert.Equal(t, "python:3.12-a1b2c3d4", imageInfo.ImageID)
+	})
+
+	t.Run("rejects unregistered images by default", func(t *testing.T) {
+		registered := map[string]*api.ImageInfo{}
+		svc := newTestService(nil, nil, newRunner(registered))
+
+		_, err := svc.ResolveRunImage(ctx, developer, "python:3.13")
+
+		assert.Equal(t, apperrors.ErrCodeImageNotRegistered, apperrors.GetErrorCode(err))
+		assert.Empty(t, registered)
+	})
+
+	t.Run("registers unregistered images on first use", func(t *testing.T) {
+		registered := map[string]*api.ImageInfo{}
+		svc := newTestService(nil, nil, newRunner(registered))
+		svc.UnregisteredImagePolicy = constants.UnregisteredImagePolicyRegister
+
+		imageInfo, err := svc.ResolveRunImage(ctx, developer, "python:3.13")
+
+		require.NoError(t, err)
+		assert.Equal(t, "python:3.13-a1b2c3d4", imageInfo.ImageID)
+		assert.Equal(t, "dev@example.com", imageInfo.CreatedBy)
+	})
+
+	t.Run("does not register image IDs", func(t *testing.T) {
+		registered := map[string]*api.ImageInfo{}
+		svc := newTestService(nil, nil, newRunner(registered))
+		svc.UnregisteredImagePolicy = constants.UnregisteredImagePolicyRegister
+
+		_, err := svc.ResolveRunImage(ctx, developer, "python:3.13-e5f6a7b8")
+
+		assert.Equal(t, apperrors.ErrCodeImageNotRegistered, apperrors.GetErrorCode(err))
+		assert.Empty(t, registered)
+	})
+
+	t.Run("forbids the roles not allowed to register images", func(t *testing.T) {
+		registered := map[string]*api.ImageInfo{}
+		svc := newTestService(nil, nil, newRunner(registered))
+		svc.UnregisteredImagePolicy = constants.UnregisteredImagePolicyRegister
+		svc.UnregisteredImageRoles = []string{"admin", "operator"}
+
+		_, err := svc.ResolveRunImage(ctx, developer, "python:3.13")
+
+		assert.Equal(t, apperrors.ErrCodeImageRegistrationForbidden, apperrors.GetErrorCode(err))
+		assert.Empty(t, registered)
+
+		_, err = svc.ResolveRunImage(ctx, &api.User{Email: "ops@example.com", Role: "operator"}, "python:3.13")
+		require.NoError(t, err)
+	})
+}
+
 // Helper function to create bool pointer
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// Helper function to create int pointer
+func intPtr(i int) *int {
+	return &i
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 )
@@ -33,6 +37,21 @@ func (s *Service) RegisterImage(
 		return nil, appErrors.ErrBadRequest("createdBy is required", nil)
 	}
 
+	if req.LogLimits != nil && (req.LogLimits.MaxLinesPerSecond < 0 || req.LogLimits.MaxBytes < 0) {
+		return nil, appErrors.ErrBadRequest("log limits must not be negative", nil)
+	}
+
+	if req.WarmPoolSize != nil && (*req.WarmPoolSize < 0 || *req.WarmPoolSize > constants.MaxWarmPoolSize) {
+		return nil, appErrors.ErrBadRequest(
+			fmt.Sprintf("warm pool size must be between 0 and %d", constants.MaxWarmPoolSize), nil)
+	}
+
+	if req.RunDefaults != nil {
+		if err := validateShell(req.RunDefaults.Shell); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.imageRegistry.RegisterImage(
 		ctx,
 		req.Image,
@@ -42,6 +61,9 @@ func (s *Service) RegisterImage(
 		req.CPU,
 		req.Memory,
 		req.RuntimePlatform,
+		req.LogLimits,
+		req.WarmPoolSize,
+		req.RunDefaults,
 		createdBy,
 	); err != nil {
 		return nil, appErrors.ErrInternalError("failed to register image", fmt.Errorf("register image: %w", err))
@@ -110,7 +132,61 @@ func (s *Service) GetImage(ctx context.Context, image string) (*api.ImageInfo, e
 	return imageInfo, nil
 }
 
-// RemoveImage removes a Docker image and deregisters its task definitions.
+// DeleteImage marks a Docker image as deleted by deletedBy. Its executions are refused, but it is kept
+// and can be restored with RestoreImage until it is purged after constants.ImageDeletionRetention.
+func (s *Service) DeleteImage(ctx context.Context, image, deletedBy string) (*api.RemoveImageResponse, error) {
+	if image == "" {
+		return nil, appErrors.ErrBadRequest("image is required", nil)
+	}
+
+	imageInfo, err := s.imageRegistry.SoftDeleteImage(ctx, image, deletedBy)
+	if err != nil {
+		// Check if it's already an AppError - if so, wrap it to satisfy wrapcheck
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, fmt.Errorf("delete image: %w", err)
+		}
+		// Otherwise, wrap the external error with an AppError
+		return nil, appErrors.ErrInternalError("failed to delete image", fmt.Errorf("delete image: %w", err))
+	}
+
+	resp := &api.RemoveImageResponse{
+		Image:   image,
+		Message: "Image deleted successfully",
+	}
+	if imageInfo != nil && imageInfo.DeletedAt != nil {
+		restorableUntil := imageInfo.DeletedAt.Add(constants.ImageDeletionRetention)
+		resp.RestorableUntil = &restorableUntil
+		resp.Message = fmt.Sprintf("Image deleted successfully, restore it with 'runvoy images restore %s' before %s",
+			imageInfo.ImageID, restorableUntil.Format(time.RFC3339))
+	}
+	return resp, nil
+}
+
+// RestoreImage restores a Docker image deleted with DeleteImage.
+func (s *Service) RestoreImage(ctx context.Context, image string) (*api.RestoreImageResponse, error) {
+	if image == "" {
+		return nil, appErrors.ErrBadRequest("image is required", nil)
+	}
+
+	if _, err := s.imageRegistry.RestoreImage(ctx, image); err != nil {
+		// Check if it's already an AppError - if so, wrap it to satisfy wrapcheck
+		var appErr *appErrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, fmt.Errorf("restore image: %w", err)
+		}
+		// Otherwise, wrap the external error with an AppError
+		return nil, appErrors.ErrInternalError("failed to restore image", fmt.Errorf("restore image: %w", err))
+	}
+
+	return &api.RestoreImageResponse{
+		Image:   image,
+		Message: "Image restored successfully",
+	}, nil
+}
+
+// RemoveImage permanently removes a Docker image and deregisters its task definitions, without the
+// retention window of DeleteImage.
 func (s *Service) RemoveImage(ctx context.Context, image string) error {
 	if image == "" {
 		return appErrors.ErrBadRequest("image is required", nil)
@@ -130,7 +206,8 @@ func (s *Service) RemoveImage(ctx context.Context, image string) error {
 }
 
 // ResolveImage resolves a user-provided image string to a specific ImageInfo.
-// If image string is empty, returns the default image.
+// If image string is empty, returns the default image. Image aliases resolve to the image they point at,
+// with ResolvedFromAlias set.
 // This centralizes image resolution logic for authorization and execution.
 func (s *Service) ResolveImage(ctx context.Context, image string) (*api.ImageInfo, error) {
 	// If no image specified, use default
@@ -145,15 +222,72 @@ func (s *Service) ResolveImage(ctx context.Context, image string) (*api.ImageInf
 		return imageInfo, nil
 	}
 
-	// Resolve the provided image string
-	imageInfo, err := s.imageRegistry.GetImage(ctx, image)
+	// Aliases take precedence, SetImageAlias refusing aliases that name a registered image
+	imageInfo, err := s.resolveImageAlias(ctx, image)
 	if err != nil {
-		return nil, appErrors.ErrInternalError("failed to resolve image", fmt.Errorf("resolve image: %w", err))
+		return nil, err
 	}
 
+	// Resolve the provided image string
 	if imageInfo == nil {
-		return nil, appErrors.ErrBadRequest("image not registered", nil)
+		imageInfo, err = s.imageRegistry.GetImage(ctx, image)
+		if err != nil {
+			return nil, appErrors.ErrInternalError("failed to resolve image", fmt.Errorf("resolve image: %w", err))
+		}
+	}
+
+	if imageInfo == nil {
+		return nil, appErrors.ErrImageNotRegistered(fmt.Sprintf("image %s is not registered", image), nil)
+	}
+
+	if imageInfo.DeletedAt != nil {
+		return nil, appErrors.ErrImageDeleted(fmt.Sprintf(
+			"image %s was deleted by %s on %s, restore it with 'runvoy images restore %s'",
+			imageInfo.ImageID, imageInfo.DeletedBy, imageInfo.DeletedAt.Format(time.RFC3339), imageInfo.ImageID), nil)
 	}
 
 	return imageInfo, nil
 }
+
+// ResolveRunImage resolves the image of an execution started by the user, applying the unregistered image
+// policy to the images that are not registered. Under the reject policy they fail with IMAGE_NOT_REGISTERED,
+// under the register policy they are registered with the default resources on behalf of the user, provided
+// the user's role is allowed to, and fail with IMAGE_REGISTRATION_FORBIDDEN otherwise.
+// Image IDs are never registered, they only name images that were registered before.
+func (s *Service) ResolveRunImage(ctx context.Context, user *api.User, image string) (*api.ImageInfo, error) {
+	imageInfo, err := s.ResolveImage(ctx, image)
+	if appErrors.GetErrorCode(err) != appErrors.ErrCodeImageNotRegistered || image == "" {
+		return imageInfo, err
+	}
+
+	if s.unregisteredImagePolicy() != constants.UnregisteredImagePolicyRegister ||
+		imageIDSuffixPattern.MatchString(image) {
+		return nil, err
+	}
+
+	if len(s.UnregisteredImageRoles) > 0 && !slices.Contains(s.UnregisteredImageRoles, user.Role) {
+		return nil, appErrors.ErrImageRegistrationForbidden(fmt.Sprintf(
+			"image %s is not registered and the %s role may not register images by running them, allowed roles: %s",
+			image, user.Role, strings.Join(s.UnregisteredImageRoles, ", ")), nil)
+	}
+
+	if _, regErr := s.RegisterImage(ctx, &api.RegisterImageRequest{Image: image}, user.Email); regErr != nil {
+		return nil, regErr
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("registered image on first use", "context", map[string]string{
+		"image":         image,
+		"registered_by": user.Email,
+	})
+
+	return s.ResolveImage(ctx, image)
+}
+
+// unregisteredImagePolicy returns the policy applied to the executions of unregistered images.
+func (s *Service) unregisteredImagePolicy() constants.UnregisteredImagePolicy {
+	if s.UnregisteredImagePolicy == "" {
+		return constants.DefaultUnregisteredImagePolicy
+	}
+	return s.UnregisteredImagePolicy
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth/authorization"
@@ -69,6 +70,10 @@ func (m *mockHealthManager) Reconcile(_ context.Context) (*api.HealthReport, err
 	return &api.HealthReport{}, nil
 }
 
+func (m *mockHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
 func TestGetImage_Success(t *testing.T) {
 	runner := &mockRunner{
 		getImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
@@ -199,6 +204,57 @@ func TestRemoveImage_RunnerGenericError(t *testing.T) {
 	assert.ErrorAs(t, removeErr, &appErr)
 }
 
+func TestDeleteImage_Success(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	runner := &mockRunner{
+		softDeleteImageFunc: func(_ context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+			assert.Equal(t, "alpine:latest-a1b2c3d4", image)
+			assert.Equal(t, "user@example.com", deletedBy)
+			return &api.ImageInfo{ImageID: image, DeletedAt: &deletedAt, DeletedBy: deletedBy}, nil
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	resp, err := service.DeleteImage(context.Background(), "alpine:latest-a1b2c3d4", "user@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, deletedAt.Add(constants.ImageDeletionRetention), *resp.RestorableUntil)
+	assert.Contains(t, resp.Message, "runvoy images restore alpine:latest-a1b2c3d4")
+}
+
+func TestDeleteImage_AlreadyDeleted(t *testing.T) {
+	runner := &mockRunner{
+		softDeleteImageFunc: func(_ context.Context, _, _ string) (*api.ImageInfo, error) {
+			return nil, apperrors.ErrConflict("image is already deleted", nil)
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	_, err := service.DeleteImage(context.Background(), "alpine:latest-a1b2c3d4", "user@example.com")
+
+	assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+}
+
+func TestRestoreImage(t *testing.T) {
+	var restored string
+	runner := &mockRunner{
+		restoreImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
+			restored = image
+			return &api.ImageInfo{ImageID: image}, nil
+		},
+	}
+	service := newImageTestService(t, runner)
+
+	resp, err := service.RestoreImage(context.Background(), "alpine:latest-a1b2c3d4")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alpine:latest-a1b2c3d4", restored)
+	assert.Equal(t, "alpine:latest-a1b2c3d4", resp.Image)
+
+	_, err = service.RestoreImage(context.Background(), "")
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+}
+
 func TestListImages_Success(t *testing.T) {
 	runner := &mockRunner{
 		listImagesFunc: func(_ context.Context) ([]api.ImageInfo, error) {
@@ -297,6 +353,29 @@ func TestRegisterImage_Success(t *testing.T) {
 	assert.NotNil(t, resp)
 }
 
+func TestRegisterImage_RunDefaults(t *testing.T) {
+	runner := &mockRunner{}
+	service := newImageTestService(t, runner)
+	defaults := &api.RunDefaults{WorkDir: "/app", Shell: "pwsh"}
+
+	_, registerErr := service.RegisterImage(
+		context.Background(),
+		&api.RegisterImageRequest{Image: "mcr.microsoft.com/powershell:latest", RunDefaults: defaults},
+		"test@example.com",
+	)
+
+	assert.NoError(t, registerErr)
+	assert.Equal(t, defaults, runner.registeredRunDefaults)
+
+	_, registerErr = service.RegisterImage(
+		context.Background(),
+		&api.RegisterImageRequest{Image: "alpine:latest", RunDefaults: &api.RunDefaults{Shell: "fish"}},
+		"test@example.com",
+	)
+
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(registerErr))
+}
+
 func TestRegisterImage_EmptyImageName(t *testing.T) {
 	runner := &mockRunner{
 		registerImageFunc: func(
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
 	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
@@ -25,6 +29,10 @@ type ProviderDependencies struct {
 	ObservabilityManager contract.ObservabilityManager
 	WebSocketManager     contract.WebSocketManager
 	HealthManager        contract.HealthManager
+	QueryStats           *database.QueryStats // Optional, nil when the provider does not instrument its queries
+
+	// Metrics records the orchestrator metrics, such as its initialization duration. Optional.
+	Metrics contract.MetricsRecorder
 }
 
 // ProviderInitializer constructs provider dependencies given configuration and an enforcer instance.
@@ -53,10 +61,13 @@ func WithProviderInitializer(initializer ProviderInitializer) InitializeOption {
 // Initialize creates a new Service configured for the specified backend provider.
 // It returns an error if the context is canceled, timed out, or if an unknown provider is specified.
 // Callers should handle errors and potentially panic if initialization fails during startup.
-// Also initializes the Casbin enforcer for authorization.
+// Also initializes the Casbin enforcer for authorization, hydrating it with the user roles and resource
+// ownerships right away, or on the first authorization check with cfg.LazyEnforcerHydration.
+// The initialization duration is recorded as the InitDuration metric when the provider records metrics.
 //
 // Supported cloud providers:
 //   - "aws": Uses DynamoDB for storage, Fargate for execution
+//   - "fake": Keeps everything in memory and runs commands locally, in builds with the fake tag
 //   - "gcp": (future) E.g. using Google Cloud Run and Firestore for storage
 func Initialize(
 	ctx context.Context,
@@ -64,6 +75,7 @@ func Initialize(
 	baseLogger *slog.Logger,
 	opts ...InitializeOption,
 ) (*Service, error) {
+	startedAt := time.Now()
 	options := initializeOptions{}
 	for _, opt := range opts {
 		opt(&options)
@@ -91,8 +103,7 @@ func Initialize(
 		return nil, fmt.Errorf("failed to initialize %s dependencies: %w", cfg.BackendProvider, initErr)
 	}
 
-	svc, svcErr := NewService(
-		ctx,
+	svc, svcErr := newService(
 		deps.Region,
 		&deps.Repositories,
 		deps.TaskManager,
@@ -108,9 +119,81 @@ func Initialize(
 	if svcErr != nil {
 		return nil, fmt.Errorf("failed to initialize service: %w", svcErr)
 	}
+
+	enforcer.SetHydrationSource(authorization.HydrationSource{
+		Users:      deps.Repositories.User,
+		Executions: deps.Repositories.Execution,
+		Secrets:    deps.Repositories.Secrets,
+		Images:     deps.ImageRegistry,
+	}, cfg.EnforcerRefreshInterval)
+	if !cfg.LazyEnforcerHydration {
+		if err = enforcer.EnsureHydrated(ctx); err != nil {
+			return nil, fmt.Errorf("failed to initialize service: %w", err)
+		}
+	}
+	svc.DefaultExecutionVisibility = constants.ExecutionVisibility(cfg.DefaultExecutionVisibility)
+	svc.LogRetentionMinDays = cfg.LogRetentionMinDays
+	svc.LogRetentionMaxDays = cfg.LogRetentionMaxDays
+	svc.ClaimTokenTTL = cfg.ClaimTokenTTL
+	svc.UnregisteredImagePolicy = constants.UnregisteredImagePolicy(cfg.UnregisteredImagePolicy)
+	svc.UnregisteredImageRoles = cfg.UnregisteredImageRoles
+	svc.Chaos = chaos.New(cfg.Chaos)
+	svc.SCIMGroupRoles = cfg.SCIMGroupRoles
+	svc.QueryStats = deps.QueryStats
+	svc.Metrics = deps.Metrics
+	svc.DisableLegacyRoutes = cfg.DisableLegacyRoutes
+	svc.RequestBodyLogSampleRate = cfg.RequestBodyLogSampleRate
+	if cfg.SSO.Enabled() {
+		svc.IdentityVerifier = oidc.NewVerifier(cfg.SSO.Issuer, cfg.SSO.ClientID,
+			&http.Client{Timeout: constants.IdentityProviderTimeout})
+	}
+	if len(cfg.GitHubTrusts) > 0 {
+		svc.GitHubTrusts = cfg.GitHubTrusts
+		svc.GitHubVerifier = oidc.NewVerifier(constants.GitHubActionsIssuer, cfg.GitHubOIDCAudience,
+			&http.Client{Timeout: constants.IdentityProviderTimeout})
+	}
+	if svc.Chaos != nil {
+		baseLogger.Warn("chaos fault injection is enabled, do not use in production", "context", map[string]any{
+			"latency":      cfg.Chaos.Latency.String(),
+			"latency_rate": cfg.Chaos.LatencyRate,
+			"error_rate":   cfg.Chaos.ErrorRate,
+			"drop_rate":    cfg.Chaos.DropRate,
+		})
+	}
+
+	recordInitDuration(ctx, deps.Metrics, time.Since(startedAt), cfg.LazyEnforcerHydration)
+	reqLogger.Debug(fmt.Sprintf("%s %s orchestrator initialized successfully", constants.ProjectName, svc.Provider),
+		"duration_ms", time.Since(startedAt).Milliseconds(),
+		"lazy_enforcer_hydration", cfg.LazyEnforcerHydration,
+	)
 	return svc, nil
 }
 
+// recordInitDuration records the duration of the orchestrator initialization, the cold start
+// overhead of the serverless functions, when a metrics recorder is configured.
+func recordInitDuration(
+	ctx context.Context,
+	metrics contract.MetricsRecorder,
+	duration time.Duration,
+	lazyHydration bool,
+) {
+	if metrics == nil {
+		return
+	}
+	hydration := constants.MetricHydrationEager
+	if lazyHydration {
+		hydration = constants.MetricHydrationLazy
+	}
+	metrics.RecordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricInitDuration,
+		Value: float64(duration.Milliseconds()),
+		Unit:  contract.MetricUnitMilliseconds,
+		Dimensions: map[string]string{
+			constants.MetricDimensionHydration: hydration,
+		},
+	})
+}
+
 func selectProviderInitializer(
 	provider constants.BackendProvider,
 	override ProviderInitializer,
@@ -122,6 +205,11 @@ func selectProviderInitializer(
 	switch provider {
 	case constants.AWS:
 		return awsProviderInitializer, nil
+	case constants.Fake:
+		if fakeProviderInitializer == nil {
+			return nil, fmt.Errorf("the %s backend provider requires a build with the fake tag", provider)
+		}
+		return fakeProviderInitializer, nil
 	default:
 		return nil, fmt.Errorf("unknown backend provider: %s (supported: %s)", provider, constants.AWS)
 	}
@@ -139,12 +227,16 @@ func awsProviderInitializer(
 	}
 
 	repos := database.Repositories{
-		User:       awsDeps.UserRepo,
-		Execution:  awsDeps.ExecutionRepo,
-		Connection: awsDeps.ConnectionRepo,
-		Token:      awsDeps.TokenRepo,
-		Image:      awsDeps.ImageRepo,
-		Secrets:    awsDeps.SecretsRepo,
+		User:          awsDeps.UserRepo,
+		Execution:     awsDeps.ExecutionRepo,
+		Connection:    awsDeps.ConnectionRepo,
+		Token:         awsDeps.TokenRepo,
+		Image:         awsDeps.ImageRepo,
+		Secrets:       awsDeps.SecretsRepo,
+		HealthReport:  awsDeps.HealthReportRepo,
+		CommandPolicy: awsDeps.CommandPolicyRepo,
+		Config:        awsDeps.ConfigRepo,
+		Lock:          awsDeps.LockRepo,
 	}
 
 	return &ProviderDependencies{
@@ -156,5 +248,7 @@ func awsProviderInitializer(
 		ObservabilityManager: awsDeps.ObservabilityManager,
 		WebSocketManager:     awsDeps.WebSocketManager,
 		HealthManager:        awsDeps.HealthManager,
+		QueryStats:           awsDeps.QueryStats,
+		Metrics:              awsDeps.Metrics,
 	}, nil
 }
@@ -0,0 +1,40 @@
+//go:build fake
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/chaos"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+)
+
+// fakeProviderInitializer wires the in-memory backend, only in builds with the fake tag.
+var fakeProviderInitializer ProviderInitializer = func(
+	ctx context.Context,
+	cfg *config.Config,
+	log *slog.Logger,
+	_ *authorization.Enforcer,
+) (*ProviderDependencies, error) {
+	provider, err := fake.Initialize(ctx, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize fake backend: %w", err)
+	}
+
+	inj := chaos.New(cfg.Chaos)
+	provider.WebSocket.SetChaos(inj)
+
+	return &ProviderDependencies{
+		Repositories:         chaos.WrapRepositories(provider.Repositories(), inj),
+		TaskManager:          provider.Tasks,
+		ImageRegistry:        provider.Images,
+		LogManager:           provider.Logs,
+		ObservabilityManager: provider.Observability,
+		WebSocketManager:     provider.WebSocket,
+		HealthManager:        provider.Health,
+	}, nil
+}
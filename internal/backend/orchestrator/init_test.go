@@ -8,6 +8,7 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/contract"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
@@ -59,6 +60,80 @@ func TestInitialize_UsesCustomInitializer(t *testing.T) {
 	assert.True(t, called, "custom initializer should be invoked")
 }
 
+type recordingMetricsRecorder struct {
+	metrics []contract.Metric
+}
+
+func (r *recordingMetricsRecorder) RecordMetrics(_ context.Context, metrics ...contract.Metric) {
+	r.metrics = append(r.metrics, metrics...)
+}
+
+func TestInitialize_EnforcerHydration(t *testing.T) {
+	initialize := func(t *testing.T, cfg *config.Config) (*Service, *int, *recordingMetricsRecorder) {
+		t.Helper()
+		var listUsersCalls int
+		runner := &mockRunner{}
+		metrics := &recordingMetricsRecorder{}
+		deps := &ProviderDependencies{
+			Repositories: database.Repositories{
+				User: &mockUserRepository{
+					listUsersFunc: func(_ context.Context) ([]*api.User, error) {
+						listUsersCalls++
+						return []*api.User{{Email: "admin@example.com", Role: "admin"}}, nil
+					},
+				},
+				Execution: &mockExecutionRepository{},
+				Secrets:   &mockSecretsRepository{},
+			},
+			TaskManager:          runner,
+			ImageRegistry:        runner,
+			LogManager:           runner,
+			ObservabilityManager: runner,
+			WebSocketManager:     &mockWebSocketManager{},
+			HealthManager:        &stubHealthManager{},
+			Metrics:              metrics,
+		}
+		initializer := func(
+			_ context.Context, _ *config.Config, _ *slog.Logger, _ *authorization.Enforcer,
+		) (*ProviderDependencies, error) {
+			return deps, nil
+		}
+
+		svc, err := Initialize(context.Background(), cfg, testutil.SilentLogger(), WithProviderInitializer(initializer))
+		require.NoError(t, err)
+		return svc, &listUsersCalls, metrics
+	}
+
+	t.Run("hydrates during the initialization by default", func(t *testing.T) {
+		_, listUsersCalls, metrics := initialize(t, &config.Config{BackendProvider: constants.AWS})
+
+		assert.Equal(t, 1, *listUsersCalls)
+		require.Len(t, metrics.metrics, 1)
+		assert.Equal(t, constants.MetricInitDuration, metrics.metrics[0].Name)
+		assert.Equal(t, contract.MetricUnitMilliseconds, metrics.metrics[0].Unit)
+		assert.Equal(t, map[string]string{constants.MetricDimensionHydration: constants.MetricHydrationEager},
+			metrics.metrics[0].Dimensions)
+	})
+
+	t.Run("defers the hydration to the first authorization check", func(t *testing.T) {
+		svc, listUsersCalls, metrics := initialize(t,
+			&config.Config{BackendProvider: constants.AWS, LazyEnforcerHydration: true})
+
+		assert.Equal(t, 0, *listUsersCalls)
+		require.Len(t, metrics.metrics, 1)
+		assert.Equal(t, constants.MetricHydrationLazy, metrics.metrics[0].Dimensions[constants.MetricDimensionHydration])
+
+		allowed, err := svc.GetEnforcer().Enforce(context.Background(), "admin@example.com", "/api/v1/users",
+			authorization.ActionRead)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		_, err = svc.GetEnforcer().Enforce(context.Background(), "admin@example.com", "/api/v1/users",
+			authorization.ActionRead)
+		require.NoError(t, err)
+		assert.Equal(t, 1, *listUsersCalls)
+	})
+}
+
 func TestSelectProviderInitializer_DefaultAWS(t *testing.T) {
 	initializer, err := selectProviderInitializer(constants.AWS, nil)
 
@@ -70,6 +145,19 @@ func TestSelectProviderInitializer_DefaultAWS(t *testing.T) {
 	)
 }
 
+func TestSelectProviderInitializer_Fake(t *testing.T) {
+	initializer, err := selectProviderInitializer(constants.Fake, nil)
+
+	if fakeProviderInitializer == nil {
+		require.Error(t, err)
+		assert.Nil(t, initializer)
+		assert.Contains(t, err.Error(), "requires a build with the fake tag")
+		return
+	}
+	require.NoError(t, err)
+	assert.NotNil(t, initializer)
+}
+
 func TestSelectProviderInitializer_UnknownProvider(t *testing.T) {
 	initializer, err := selectProviderInitializer("gcp", nil)
 
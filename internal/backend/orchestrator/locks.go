@@ -0,0 +1,182 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// lockNamePattern matches the names of the execution locks, such as deploy-prod.
+var lockNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// validateLockName checks the name of the lock requested by a run.
+func validateLockName(name string) error {
+	if len(name) > constants.MaxLockNameLength || !lockNamePattern.MatchString(name) {
+		return apperrors.ErrBadRequest(fmt.Sprintf(
+			"invalid lock name %q, expected at most %d lowercase letters, digits, dots, underscores and dashes",
+			name, constants.MaxLockNameLength), nil)
+	}
+	return nil
+}
+
+// executionLockTTL returns how long the lock of an execution is held before it expires: its timeout and
+// the time its task is given to stop once killed, or constants.DefaultExecutionLockTTL without a timeout.
+func executionLockTTL(req *api.ExecutionRequest) time.Duration {
+	if req.Timeout <= 0 {
+		return constants.DefaultExecutionLockTTL
+	}
+	return time.Duration(req.Timeout+req.StopGracePeriod+constants.KillStallSeconds) * time.Second
+}
+
+// acquireExecutionLock acquires the lock of the request for the user starting it, returning the token the lock
+// is held with. A lock held by an execution that completed, or by a run that failed before recording its
+// execution, is taken over: its release was missed, as when the backend crashed. Other held locks refuse the
+// run with a LOCK_HELD error.
+func (s *Service) acquireExecutionLock(
+	ctx context.Context,
+	userEmail string,
+	req *api.ExecutionRequest,
+) (string, error) {
+	if s.repos.Lock == nil {
+		return "", apperrors.ErrServiceUnavailable("execution locks are not configured", nil)
+	}
+
+	now := time.Now().UTC()
+	lock := &api.ExecutionLock{
+		Name:       req.Lock,
+		HeldBy:     userEmail,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(executionLockTTL(req)),
+		Token:      auth.GenerateUUID(),
+	}
+	acquired, err := s.repos.Lock.AcquireLock(ctx, lock, now)
+	if err != nil {
+		return "", err
+	}
+	if acquired {
+		return lock.Token, nil
+	}
+
+	held, err := s.repos.Lock.GetLock(ctx, req.Lock)
+	if err != nil {
+		return "", err
+	}
+	if held != nil {
+		stale, staleErr := s.isStaleLock(ctx, held, now)
+		if staleErr != nil {
+			return "", staleErr
+		}
+		if !stale {
+			return "", lockHeldError(held)
+		}
+		err = s.repos.Lock.ReleaseLock(ctx, held.Name, held.Token)
+		if err != nil && apperrors.GetErrorCode(err) != apperrors.ErrCodeNotFound {
+			return "", err
+		}
+		logger.DeriveRequestLogger(ctx, s.Logger).Warn("took over stale execution lock", "context", map[string]string{
+			"lock":         held.Name,
+			"held_by":      held.HeldBy,
+			"execution_id": held.ExecutionID,
+		})
+	}
+
+	// A run racing this one may have acquired the lock in the meantime
+	if acquired, err = s.repos.Lock.AcquireLock(ctx, lock, now); err != nil {
+		return "", err
+	}
+	if !acquired {
+		return "", apperrors.ErrLockHeld(fmt.Sprintf("lock %s is held by another execution", req.Lock), nil)
+	}
+	return lock.Token, nil
+}
+
+// isStaleLock reports whether a held lock was left behind: its execution completed or doesn't exist, or it
+// has no execution long after it was acquired.
+func (s *Service) isStaleLock(ctx context.Context, lock *api.ExecutionLock, now time.Time) (bool, error) {
+	if lock.ExecutionID == "" {
+		return now.Sub(lock.AcquiredAt) > constants.ExecutionLockStartTimeout, nil
+	}
+	execution, err := s.repos.Execution.GetExecution(ctx, lock.ExecutionID)
+	if err != nil {
+		return false, apperrors.ErrDatabaseError("failed to get execution holding the lock", err)
+	}
+	return execution == nil || constants.ExecutionStatus(execution.Status).IsFinal(), nil
+}
+
+// lockHeldError returns the error refusing a run whose lock is held by another execution.
+func lockHeldError(lock *api.ExecutionLock) error {
+	holder := "an execution being started"
+	if lock.ExecutionID != "" {
+		holder = "execution " + lock.ExecutionID
+	}
+	return apperrors.ErrLockHeld(fmt.Sprintf("lock %s is held by %s of %s since %s",
+		lock.Name, holder, lock.HeldBy, lock.AcquiredAt.Format(time.RFC3339)), nil)
+}
+
+// setLockExecution records the execution holding the lock acquired with token. A failure is only logged: the
+// lock is then taken over once constants.ExecutionLockStartTimeout elapsed.
+func (s *Service) setLockExecution(ctx context.Context, name, token, executionID string) {
+	if err := s.repos.Lock.SetLockExecution(ctx, name, token, executionID); err != nil {
+		logger.DeriveRequestLogger(ctx, s.Logger).Error("failed to record the execution holding its lock",
+			"context", map[string]string{
+				"lock":         name,
+				"execution_id": executionID,
+				"error":        err.Error(),
+			})
+	}
+}
+
+// releaseExecutionLock releases the lock acquired with token by a run that failed to start its execution.
+func (s *Service) releaseExecutionLock(ctx context.Context, name, token string) {
+	err := s.repos.Lock.ReleaseLock(ctx, name, token)
+	if err != nil && apperrors.GetErrorCode(err) != apperrors.ErrCodeNotFound {
+		logger.DeriveRequestLogger(ctx, s.Logger).Error("failed to release the lock of a failed run",
+			"context", map[string]string{
+				"lock":  name,
+				"error": err.Error(),
+			})
+	}
+}
+
+// ListLocks returns the execution locks currently held, sorted by name.
+func (s *Service) ListLocks(ctx context.Context) ([]api.ExecutionLock, error) {
+	if s.repos.Lock == nil {
+		return nil, apperrors.ErrServiceUnavailable("execution locks are not configured", nil)
+	}
+	return s.repos.Lock.ListLocks(ctx, time.Now().UTC())
+}
+
+// ReleaseLock releases an execution lock whatever holds it, so that another execution may take it while the
+// one holding it still runs. It is meant for locks a user knows are no longer needed.
+func (s *Service) ReleaseLock(ctx context.Context, name, userEmail string) error {
+	if s.repos.Lock == nil {
+		return apperrors.ErrServiceUnavailable("execution locks are not configured", nil)
+	}
+
+	lock, err := s.repos.Lock.GetLock(ctx, name)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return apperrors.ErrNotFound(fmt.Sprintf("lock %s is not held", name), nil)
+	}
+	if err = s.repos.Lock.ReleaseLock(ctx, name, ""); err != nil {
+		return err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("audit: execution lock released", "context", map[string]string{
+		"released_by":  userEmail,
+		"lock":         name,
+		"held_by":      lock.HeldBy,
+		"execution_id": lock.ExecutionID,
+	})
+	return nil
+}
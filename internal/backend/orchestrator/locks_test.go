@@ -0,0 +1,197 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLockTestService returns a service starting the executions exec-1, exec-2... with the locks in memory.
+func newLockTestService() (*Service, *fake.LockRepository, *fake.ExecutionRepository) {
+	var started int
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			started++
+			return fmt.Sprintf("exec-%d", started), timePtr(time.Now()), nil
+		},
+	}
+	executions := fake.NewExecutionRepository()
+	locks := fake.NewLockRepository()
+	svc := newTestService(nil, nil, runner)
+	svc.repos.Execution = executions
+	svc.repos.Lock = locks
+	return svc, locks, executions
+}
+
+func TestRunCommand_Lock(t *testing.T) {
+	ctx := context.Background()
+	svc, locks, executions := newLockTestService()
+
+	resp, err := svc.RunCommand(ctx, "alice@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod", Timeout: 600}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "exec-1", resp.ExecutionID)
+
+	lock, err := locks.GetLock(ctx, "deploy-prod")
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, "exec-1", lock.ExecutionID)
+	assert.Equal(t, "alice@example.com", lock.HeldBy)
+	assert.Equal(t, 600+constants.KillStallSeconds, int(lock.ExpiresAt.Sub(lock.AcquiredAt).Seconds()))
+	execution, err := executions.GetExecution(ctx, "exec-1")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-prod", execution.Lock)
+
+	_, err = svc.RunCommand(ctx, "bob@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+	assert.Equal(t, apperrors.ErrCodeLockHeld, apperrors.GetErrorCode(err))
+	assert.Contains(t, err.Error(), "lock deploy-prod is held by execution exec-1 of alice@example.com")
+
+	resp, err = svc.RunCommand(ctx, "bob@example.com", nil,
+		&api.ExecutionRequest{Command: "./migrate.sh", Lock: "migrate"}, nil)
+	require.NoError(t, err, "other locks are not held")
+	assert.Equal(t, "exec-2", resp.ExecutionID)
+
+	require.NoError(t, locks.ReleaseLock(ctx, "deploy-prod", "exec-1"))
+	resp, err = svc.RunCommand(ctx, "bob@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "exec-3", resp.ExecutionID)
+}
+
+func TestRunCommand_LockTakeover(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	t.Run("takes over the lock of a completed execution", func(t *testing.T) {
+		svc, locks, executions := newLockTestService()
+		require.NoError(t, executions.CreateExecution(ctx, &api.Execution{
+			ExecutionID: "crashed", Status: string(constants.ExecutionSucceeded),
+		}))
+		_, err := locks.AcquireLock(ctx, &api.ExecutionLock{
+			Name: "deploy-prod", ExecutionID: "crashed", Token: "old", AcquiredAt: now, ExpiresAt: now.Add(time.Hour),
+		}, now)
+		require.NoError(t, err)
+
+		resp, err := svc.RunCommand(ctx, "alice@example.com", nil,
+			&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+
+		require.NoError(t, err)
+		lock, _ := locks.GetLock(ctx, "deploy-prod")
+		assert.Equal(t, resp.ExecutionID, lock.ExecutionID)
+	})
+
+	t.Run("takes over the lock of a run that never recorded its execution", func(t *testing.T) {
+		svc, locks, _ := newLockTestService()
+		acquiredAt := now.Add(-constants.ExecutionLockStartTimeout - time.Minute)
+		_, err := locks.AcquireLock(ctx, &api.ExecutionLock{
+			Name: "deploy-prod", Token: "old", AcquiredAt: acquiredAt, ExpiresAt: now.Add(time.Hour),
+		}, acquiredAt)
+		require.NoError(t, err)
+
+		_, err = svc.RunCommand(ctx, "alice@example.com", nil,
+			&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps the lock of an execution being started", func(t *testing.T) {
+		svc, locks, _ := newLockTestService()
+		_, err := locks.AcquireLock(ctx, &api.ExecutionLock{
+			Name: "deploy-prod", HeldBy: "bob@example.com", Token: "old", AcquiredAt: now, ExpiresAt: now.Add(time.Hour),
+		}, now)
+		require.NoError(t, err)
+
+		_, err = svc.RunCommand(ctx, "alice@example.com", nil,
+			&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+
+		assert.Equal(t, apperrors.ErrCodeLockHeld, apperrors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "held by an execution being started of bob@example.com")
+	})
+}
+
+func TestRunCommand_LockReleasedOnStartFailure(t *testing.T) {
+	ctx := context.Background()
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			return "", nil, errors.New("capacity unavailable")
+		},
+	}
+	svc := newTestService(nil, nil, runner)
+	locks := fake.NewLockRepository()
+	svc.repos.Lock = locks
+
+	_, err := svc.RunCommand(ctx, "alice@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+
+	require.Error(t, err)
+	lock, err := locks.GetLock(ctx, "deploy-prod")
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+func TestRunCommand_LockValidation(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		req     *api.ExecutionRequest
+		wantErr string
+	}{
+		{
+			name:    "invalid name",
+			req:     &api.ExecutionRequest{Command: "./deploy.sh", Lock: "Deploy Prod"},
+			wantErr: `invalid lock name "Deploy Prod"`,
+		},
+		{
+			name:    "combined with parallel",
+			req:     &api.ExecutionRequest{Command: "./test.sh", Lock: "tests", Parallel: 2},
+			wantErr: "lock cannot be combined with parallel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newLockTestService()
+
+			_, err := svc.RunCommand(ctx, "alice@example.com", nil, tt.req, nil)
+
+			assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err))
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	svc := newTestService(nil, nil, &mockRunner{})
+	_, err := svc.RunCommand(ctx, "alice@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+}
+
+func TestListAndReleaseLocks(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newLockTestService()
+	_, err := svc.RunCommand(ctx, "alice@example.com", nil,
+		&api.ExecutionRequest{Command: "./deploy.sh", Lock: "deploy-prod"}, nil)
+	require.NoError(t, err)
+
+	locks, err := svc.ListLocks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "deploy-prod", locks[0].Name)
+
+	require.NoError(t, svc.ReleaseLock(ctx, "deploy-prod", "admin@example.com"))
+	locks, err = svc.ListLocks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+
+	err = svc.ReleaseLock(ctx, "deploy-prod", "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+}
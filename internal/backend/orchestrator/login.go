@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// IdentityVerifier verifies the ID tokens issued by the identity provider to the runvoy application.
+type IdentityVerifier interface {
+	Issuer() string
+	Audience() string
+	Verify(ctx context.Context, rawToken string) (*oidc.Claims, error)
+}
+
+// GetLoginConfig returns the identity provider the provisioned users log in with.
+func (s *Service) GetLoginConfig() (*api.LoginConfigResponse, error) {
+	if s.IdentityVerifier == nil {
+		return nil, apperrors.ErrNotFound("logging in with an identity provider is not configured", nil)
+	}
+	return &api.LoginConfigResponse{
+		Issuer:   s.IdentityVerifier.Issuer(),
+		ClientID: s.IdentityVerifier.Audience(),
+	}, nil
+}
+
+// Login exchanges an ID token of the identity provider for an API key. The users provisioned through SCIM get
+// their API key on their first login, and a new one replacing it on each following login, e.g. on a new machine.
+func (s *Service) Login(ctx context.Context, req api.LoginRequest) (*api.LoginResponse, error) {
+	if s.IdentityVerifier == nil {
+		return nil, apperrors.ErrNotFound("logging in with an identity provider is not configured", nil)
+	}
+	if req.IDToken == "" {
+		return nil, apperrors.ErrBadRequest("id_token is required", nil)
+	}
+
+	claims, err := s.IdentityVerifier.Verify(ctx, req.IDToken)
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized("invalid ID token", err)
+	}
+	if claims.Email == "" || (claims.EmailVerified != nil && !*claims.EmailVerified) {
+		return nil, apperrors.ErrUnauthorized("the ID token has no verified email", nil)
+	}
+	email := strings.ToLower(claims.Email)
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to get user", err)
+	}
+	if user == nil || !user.Provisioned {
+		return nil, apperrors.ErrForbidden("user is not provisioned by the identity provider", nil)
+	}
+	if user.Revoked {
+		return nil, apperrors.ErrForbidden("user is deactivated in the identity provider", nil)
+	}
+
+	apiKey, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to generate API key", err)
+	}
+	if err = s.repos.User.ReplaceAPIKeyHash(ctx, email, auth.HashAPIKey(apiKey)); err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to issue API key", err)
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	if user.PendingLogin {
+		user.PendingLogin = false
+		if err = s.repos.User.UpdateUser(ctx, user); err != nil {
+			// The API key is issued, only the user listing still shows the first login as pending.
+			reqLogger.Error("failed to record the first login", "email", email, "error", err)
+		}
+	}
+	reqLogger.Info("provisioned user logged in", "context", map[string]string{
+		"email":   email,
+		"subject": claims.Subject,
+	})
+
+	return &api.LoginResponse{
+		APIKey:    apiKey,
+		UserEmail: email,
+		Role:      user.Role,
+		Message:   "logged in successfully",
+	}, nil
+}
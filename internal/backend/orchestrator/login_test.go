@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/oidc"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubIdentityVerifier struct {
+	claims *oidc.Claims
+}
+
+func (v *stubIdentityVerifier) Issuer() string   { return "https://idp.example.com" }
+func (v *stubIdentityVerifier) Audience() string { return "runvoy" }
+
+func (v *stubIdentityVerifier) Verify(_ context.Context, rawToken string) (*oidc.Claims, error) {
+	if rawToken != "valid-token" {
+		return nil, oidc.ErrInvalidToken
+	}
+	return v.claims, nil
+}
+
+func TestLogin(t *testing.T) {
+	users := map[string]*api.User{
+		"alice@example.com": {Email: "alice@example.com", Role: "developer", Provisioned: true, PendingLogin: true},
+	}
+	repo, hashes := newSCIMUserRepository(users)
+	service := newTestService(repo, nil, nil)
+	service.IdentityVerifier = &stubIdentityVerifier{claims: &oidc.Claims{Email: "Alice@example.com"}}
+
+	resp, err := service.Login(context.Background(), api.LoginRequest{IDToken: "valid-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", resp.UserEmail)
+	assert.Equal(t, "developer", resp.Role)
+	assert.Equal(t, auth.HashAPIKey(resp.APIKey), hashes["alice@example.com"])
+	assert.False(t, users["alice@example.com"].PendingLogin)
+
+	config, err := service.GetLoginConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com", config.Issuer)
+	assert.Equal(t, "runvoy", config.ClientID)
+}
+
+func TestLogin_Rejects(t *testing.T) {
+	unverified := false
+	tests := []struct {
+		name       string
+		claims     *oidc.Claims
+		token      string
+		wantStatus int
+	}{
+		{"invalid token", &oidc.Claims{Email: "alice@example.com"}, "forged-token", http.StatusUnauthorized},
+		{"no email", &oidc.Claims{}, "valid-token", http.StatusUnauthorized},
+		{
+			"unverified email",
+			&oidc.Claims{Email: "alice@example.com", EmailVerified: &unverified},
+			"valid-token",
+			http.StatusUnauthorized,
+		},
+		{"not provisioned", &oidc.Claims{Email: "admin@example.com"}, "valid-token", http.StatusForbidden},
+		{"deactivated", &oidc.Claims{Email: "bob@example.com"}, "valid-token", http.StatusForbidden},
+		{"unknown", &oidc.Claims{Email: "eve@example.com"}, "valid-token", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := map[string]*api.User{
+				"alice@example.com": {Email: "alice@example.com", Role: "viewer", Provisioned: true},
+				"admin@example.com": {Email: "admin@example.com", Role: "admin"},
+				"bob@example.com":   {Email: "bob@example.com", Role: "viewer", Provisioned: true, Revoked: true},
+			}
+			repo, hashes := newSCIMUserRepository(users)
+			service := newTestService(repo, nil, nil)
+			service.IdentityVerifier = &stubIdentityVerifier{claims: tt.claims}
+
+			_, err := service.Login(context.Background(), api.LoginRequest{IDToken: tt.token})
+			assert.Equal(t, tt.wantStatus, apperrors.GetStatusCode(err))
+			assert.Empty(t, hashes, "no API key is issued")
+		})
+	}
+}
+
+func TestLogin_NotConfigured(t *testing.T) {
+	service := newTestService(&mockUserRepository{}, nil, nil)
+
+	_, err := service.GetLoginConfig()
+	assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	_, err = service.Login(context.Background(), api.LoginRequest{IDToken: "valid-token"})
+	assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+}
+
+func TestLogin_ReplaceKeyFailure(t *testing.T) {
+	repo := &mockUserRepository{
+		getUserByEmailFunc: func(_ context.Context, email string) (*api.User, error) {
+			return &api.User{Email: email, Role: "viewer", Provisioned: true}, nil
+		},
+		replaceAPIKeyHashFunc: func(_ context.Context, _, _ string) error {
+			return errors.New("conditional check failed")
+		},
+	}
+	service := newTestService(repo, nil, nil)
+	service.IdentityVerifier = &stubIdentityVerifier{claims: &oidc.Claims{Email: "alice@example.com"}}
+
+	_, err := service.Login(context.Background(), api.LoginRequest{IDToken: "valid-token"})
+	assert.Equal(t, http.StatusServiceUnavailable, apperrors.GetStatusCode(err))
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/auth/authorization"
 	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
 )
@@ -25,6 +27,54 @@ type Service struct {
 	wsManager            contract.WebSocketManager // WebSocket manager for generating URLs and managing connections
 	healthManager        contract.HealthManager    // Health manager for resource reconciliation
 	enforcer             *authorization.Enforcer   // Enforcer for authorization
+	announcements        announcementCache         // Announcement sent with every response
+
+	// DefaultExecutionVisibility is the visibility of executions started without one.
+	// The zero value stands for constants.DefaultExecutionVisibility.
+	DefaultExecutionVisibility constants.ExecutionVisibility
+
+	// LogRetentionMinDays and LogRetentionMaxDays bound the log retention a run may request, in days.
+	// Zero values stand for constants.DefaultLogRetentionMinDays and constants.DefaultLogRetentionMaxDays.
+	LogRetentionMinDays int
+	LogRetentionMaxDays int
+
+	// ClaimTokenTTL is how long the claim token of an invited user is valid.
+	// The zero value stands for constants.DefaultClaimTokenTTL.
+	ClaimTokenTTL time.Duration
+
+	// UnregisteredImagePolicy is applied to the executions of unregistered images.
+	// The zero value stands for constants.DefaultUnregisteredImagePolicy.
+	UnregisteredImagePolicy constants.UnregisteredImagePolicy
+
+	// UnregisteredImageRoles restricts the roles allowed to register an image by running it, empty allows all.
+	UnregisteredImageRoles []string
+
+	// Chaos injects latency and errors in the API requests for resilience testing, nil disables it.
+	Chaos *chaos.Injector
+
+	// IdentityVerifier verifies the ID tokens of the provisioned users logging in, nil disables logging in.
+	IdentityVerifier IdentityVerifier
+
+	// SCIMGroupRoles maps the identity provider groups to the roles of the users provisioned through SCIM.
+	SCIMGroupRoles map[string]string
+
+	// GitHubVerifier verifies the OIDC tokens of the GitHub Actions workflows, nil disables exchanging them.
+	GitHubVerifier IdentityVerifier
+
+	// GitHubTrusts maps the trusted GitHub repositories, owner/name or owner/*, to the user they act as.
+	GitHubTrusts map[string]string
+
+	// QueryStats aggregates the database queries of the repositories, nil when they are not instrumented.
+	QueryStats *database.QueryStats
+
+	// Metrics records the API metrics, such as the calls to the legacy routes, nil disables them.
+	Metrics contract.MetricsRecorder
+
+	// DisableLegacyRoutes removes the deprecated action-based v1 routes.
+	DisableLegacyRoutes bool
+
+	// RequestBodyLogSampleRate is the probability of logging the scrubbed bodies of a request at debug level.
+	RequestBodyLogSampleRate float64
 }
 
 // NOTE: provider-specific configuration has been moved to sub packages (e.g., providers/aws/app).
@@ -35,9 +85,47 @@ type Service struct {
 // WebSocket manager is required for log streaming token generation.
 // If repos.Secrets is nil, secrets operations will not be available.
 // If repos.Image is nil, image-by-request-ID queries will not be available.
+// If repos.HealthReport is nil, health reports are not stored and their history is unavailable.
+// If repos.CommandPolicy is nil, no command policy is enforced and its rules cannot be managed.
+// If repos.Config is nil, runs are never frozen, there is no announcement and no budget is enforced.
 // healthManager is required; initialization fails if it is nil.
 func NewService(
 	ctx context.Context,
+	region string,
+	repos *database.Repositories,
+	taskManager contract.TaskManager,
+	imageRegistry contract.ImageRegistry,
+	logManager contract.LogManager,
+	observabilityManager contract.ObservabilityManager,
+	log *slog.Logger,
+	provider constants.BackendProvider,
+	wsManager contract.WebSocketManager,
+	healthManager contract.HealthManager,
+	enforcer *authorization.Enforcer) (*Service, error) {
+	svc, err := newService(region, repos, taskManager, imageRegistry, logManager, observabilityManager,
+		log, provider, wsManager, healthManager, enforcer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = enforcer.Hydrate(
+		ctx,
+		repos.User,
+		repos.Execution,
+		repos.Secrets,
+		imageRegistry,
+	); err != nil {
+		return nil, fmt.Errorf("failed to hydrate enforcer: %w", err)
+	}
+
+	log.Debug("casbin authorization enforcer initialized successfully")
+	log.Debug(fmt.Sprintf("%s %s orchestrator initialized successfully",
+		constants.ProjectName, svc.Provider))
+	return svc, nil
+}
+
+// newService validates the dependencies and creates the service, leaving the enforcer to be hydrated by the caller.
+func newService(
 	region string,
 	repos *database.Repositories,
 	taskManager contract.TaskManager,
@@ -62,7 +150,7 @@ func NewService(
 		return nil, errors.New("wsManager is required")
 	}
 
-	svc := &Service{
+	return &Service{
 		Region:               region,
 		repos:                *repos,
 		taskManager:          taskManager,
@@ -74,22 +162,7 @@ func NewService(
 		wsManager:            wsManager,
 		healthManager:        healthManager,
 		enforcer:             enforcer,
-	}
-
-	if err := enforcer.Hydrate(
-		ctx,
-		repos.User,
-		repos.Execution,
-		repos.Secrets,
-		imageRegistry,
-	); err != nil {
-		return nil, fmt.Errorf("failed to hydrate enforcer: %w", err)
-	}
-
-	log.Debug("casbin authorization enforcer initialized successfully")
-	log.Debug(fmt.Sprintf("%s %s orchestrator initialized successfully",
-		constants.ProjectName, svc.Provider))
-	return svc, nil
+	}, nil
 }
 
 // GetEnforcer returns the Casbin enforcer for authorization checks.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
@@ -18,17 +19,22 @@ const testRegion = "us-east-1"
 
 // mockUserRepository implements database.UserRepository for testing
 type mockUserRepository struct {
-	createUserFunc          func(ctx context.Context, user *api.User, apiKeyHash string, expiresAtUnix int64) error
-	removeExpirationFunc    func(ctx context.Context, email string) error
-	getUserByEmailFunc      func(ctx context.Context, email string) (*api.User, error)
-	getUserByAPIKeyHashFunc func(ctx context.Context, apiKeyHash string) (*api.User, error)
-	updateLastUsedFunc      func(ctx context.Context, email string) (*time.Time, error)
-	revokeUserFunc          func(ctx context.Context, email string) error
-	createPendingAPIKeyFunc func(ctx context.Context, pending *api.PendingAPIKey) error
-	getPendingAPIKeyFunc    func(ctx context.Context, secretToken string) (*api.PendingAPIKey, error)
-	markAsViewedFunc        func(ctx context.Context, secretToken string, ipAddress string) error
-	deletePendingAPIKeyFunc func(ctx context.Context, secretToken string) error
-	listUsersFunc           func(ctx context.Context) ([]*api.User, error)
+	createUserFunc            func(ctx context.Context, user *api.User, apiKeyHash string, expiresAtUnix int64) error
+	removeExpirationFunc      func(ctx context.Context, email string) error
+	getUserByEmailFunc        func(ctx context.Context, email string) (*api.User, error)
+	getUserByAPIKeyHashFunc   func(ctx context.Context, apiKeyHash string) (*api.User, error)
+	updateLastUsedFunc        func(ctx context.Context, email string) (*time.Time, error)
+	revokeUserFunc            func(ctx context.Context, email string) error
+	updateUserFunc            func(ctx context.Context, user *api.User) error
+	updateUserPreferencesFunc func(ctx context.Context, user *api.User) error
+	replaceAPIKeyHashFunc     func(ctx context.Context, email, apiKeyHash string) error
+	createPendingAPIKeyFunc   func(ctx context.Context, pending *api.PendingAPIKey) error
+	getPendingAPIKeyFunc      func(ctx context.Context, secretToken string) (*api.PendingAPIKey, error)
+	markAsViewedFunc          func(ctx context.Context, secretToken string, ipAddress string) error
+	deletePendingAPIKeyFunc   func(ctx context.Context, secretToken string) error
+	listPendingAPIKeysFunc    func(ctx context.Context) ([]*api.PendingAPIKey, error)
+	deleteUserFunc            func(ctx context.Context, email string) error
+	listUsersFunc             func(ctx context.Context) ([]*api.User, error)
 }
 
 func (m *mockUserRepository) CreateUser(
@@ -79,6 +85,27 @@ func (m *mockUserRepository) RevokeUser(ctx context.Context, email string) error
 	return nil
 }
 
+func (m *mockUserRepository) UpdateUser(ctx context.Context, user *api.User) error {
+	if m.updateUserFunc != nil {
+		return m.updateUserFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) UpdateUserPreferences(ctx context.Context, user *api.User) error {
+	if m.updateUserPreferencesFunc != nil {
+		return m.updateUserPreferencesFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error {
+	if m.replaceAPIKeyHashFunc != nil {
+		return m.replaceAPIKeyHashFunc(ctx, email, apiKeyHash)
+	}
+	return nil
+}
+
 func (m *mockUserRepository) CreatePendingAPIKey(ctx context.Context, pending *api.PendingAPIKey) error {
 	if m.createPendingAPIKeyFunc != nil {
 		return m.createPendingAPIKeyFunc(ctx, pending)
@@ -107,6 +134,20 @@ func (m *mockUserRepository) DeletePendingAPIKey(ctx context.Context, secretToke
 	return nil
 }
 
+func (m *mockUserRepository) ListPendingAPIKeys(ctx context.Context) ([]*api.PendingAPIKey, error) {
+	if m.listPendingAPIKeysFunc != nil {
+		return m.listPendingAPIKeysFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockUserRepository) DeleteUser(ctx context.Context, email string) error {
+	if m.deleteUserFunc != nil {
+		return m.deleteUserFunc(ctx, email)
+	}
+	return nil
+}
+
 func (m *mockUserRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
 	if m.listUsersFunc != nil {
 		return m.listUsersFunc(ctx)
@@ -121,9 +162,15 @@ func (m *mockUserRepository) GetUsersByRequestID(_ context.Context, _ string) ([
 // mockExecutionRepository implements database.ExecutionRepository for testing
 type mockExecutionRepository struct {
 	createExecutionFunc func(ctx context.Context, execution *api.Execution) error
+	createAllFunc       func(ctx context.Context, executions []*api.Execution) error
 	getExecutionFunc    func(ctx context.Context, executionID string) (*api.Execution, error)
 	updateExecutionFunc func(ctx context.Context, execution *api.Execution) error
 	listExecutionsFunc  func(ctx context.Context, limit int, statuses []string) ([]*api.Execution, error)
+	listByCreatorFunc   func(ctx context.Context, createdBy string, limit int, statuses []string) ([]*api.Execution, error)
+	searchFunc          func(ctx context.Context, terms []string, limit int, statuses []string) ([]*api.Execution, error)
+	listEventsFunc      func(ctx context.Context, executionID string) ([]api.ExecutionEvent, error)
+	getByGroupIDFunc    func(ctx context.Context, groupID string) ([]*api.Execution, error)
+	addAnnotationFunc   func(ctx context.Context, executionID string, annotation *api.ExecutionAnnotation) error
 }
 
 func (m *mockExecutionRepository) CreateExecution(ctx context.Context, execution *api.Execution) error {
@@ -133,6 +180,18 @@ func (m *mockExecutionRepository) CreateExecution(ctx context.Context, execution
 	return nil
 }
 
+func (m *mockExecutionRepository) CreateExecutions(ctx context.Context, executions []*api.Execution) error {
+	if m.createAllFunc != nil {
+		return m.createAllFunc(ctx, executions)
+	}
+	for _, execution := range executions {
+		if err := m.CreateExecution(ctx, execution); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *mockExecutionRepository) GetExecution(ctx context.Context, executionID string) (*api.Execution, error) {
 	if m.getExecutionFunc != nil {
 		return m.getExecutionFunc(ctx, executionID)
@@ -158,16 +217,102 @@ func (m *mockExecutionRepository) ListExecutions(
 	return []*api.Execution{}, nil
 }
 
+// ListExecutionsByCreator calls listByCreatorFunc, or filters the executions of ListExecutions by creator.
+func (m *mockExecutionRepository) ListExecutionsByCreator(
+	ctx context.Context,
+	createdBy string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	if m.listByCreatorFunc != nil {
+		return m.listByCreatorFunc(ctx, createdBy, limit, statuses)
+	}
+	executions, err := m.ListExecutions(ctx, 0, statuses)
+	if err != nil {
+		return nil, err
+	}
+	executions = slices.DeleteFunc(slices.Clone(executions), func(e *api.Execution) bool {
+		return e.CreatedBy != createdBy
+	})
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
 func (m *mockExecutionRepository) GetExecutionsByRequestID(_ context.Context, _ string) ([]*api.Execution, error) {
 	return []*api.Execution{}, nil
 }
 
+func (m *mockExecutionRepository) GetExecutionsByGroupID(
+	ctx context.Context,
+	groupID string,
+) ([]*api.Execution, error) {
+	if m.getByGroupIDFunc != nil {
+		return m.getByGroupIDFunc(ctx, groupID)
+	}
+	return []*api.Execution{}, nil
+}
+
+func (m *mockExecutionRepository) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return nil
+}
+
+func (m *mockExecutionRepository) SearchExecutions(
+	ctx context.Context, terms []string, limit int, statuses []string,
+) ([]*api.Execution, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, terms, limit, statuses)
+	}
+	return nil, nil
+}
+
+func (m *mockExecutionRepository) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepository) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockExecutionRepository) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockExecutionRepository) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+func (m *mockExecutionRepository) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return nil
+}
+
+func (m *mockExecutionRepository) ListExecutionEvents(
+	ctx context.Context, executionID string,
+) ([]api.ExecutionEvent, error) {
+	if m.listEventsFunc != nil {
+		return m.listEventsFunc(ctx, executionID)
+	}
+	return nil, nil
+}
+
+func (m *mockExecutionRepository) AddExecutionAnnotation(
+	ctx context.Context, executionID string, annotation *api.ExecutionAnnotation,
+) error {
+	if m.addAnnotationFunc != nil {
+		return m.addAnnotationFunc(ctx, executionID, annotation)
+	}
+	return nil
+}
+
 // mockConnectionRepository implements database.ConnectionRepository for testing
 type mockConnectionRepository struct {
 	createConnectionFunc            func(ctx context.Context, conn *api.WebSocketConnection) error
 	deleteConnectionsFunc           func(ctx context.Context, connIDs []string) (int, error)
 	getConnectionsByExecutionIDFunc func(ctx context.Context, executionID string) ([]*api.WebSocketConnection, error)
-	updateLastEventIDFunc           func(ctx context.Context, connectionID, lastEventID string) error
+	updateLastEventIDFunc           func(ctx context.Context, connectionID, lastEventID string, lastSequence int64) error
 }
 
 func (m *mockConnectionRepository) CreateConnection(ctx context.Context, conn *api.WebSocketConnection) error {
@@ -193,18 +338,23 @@ func (m *mockConnectionRepository) GetConnectionsByExecutionID(
 	return nil, nil
 }
 
-func (m *mockConnectionRepository) UpdateLastEventID(ctx context.Context, connectionID, lastEventID string) error {
+func (m *mockConnectionRepository) UpdateLastEventID(
+	ctx context.Context, connectionID, lastEventID string, lastSequence int64,
+) error {
 	if m.updateLastEventIDFunc != nil {
-		return m.updateLastEventIDFunc(ctx, connectionID, lastEventID)
+		return m.updateLastEventIDFunc(ctx, connectionID, lastEventID, lastSequence)
 	}
 	return nil
 }
 
 // mockTokenRepository implements database.TokenRepository for testing
 type mockTokenRepository struct {
-	createTokenFunc func(ctx context.Context, token *api.WebSocketToken) error
-	getTokenFunc    func(ctx context.Context, tokenValue string) (*api.WebSocketToken, error)
-	deleteTokenFunc func(ctx context.Context, tokenValue string) error
+	createTokenFunc               func(ctx context.Context, token *api.WebSocketToken) error
+	claimTokenFunc                func(ctx context.Context, tokenValue string) (*api.WebSocketToken, error)
+	deleteTokenFunc               func(ctx context.Context, tokenValue string) error
+	deleteTokensByExecutionIDFunc func(ctx context.Context, executionID string) (int, error)
+	createRunTokenFunc            func(ctx context.Context, token *api.RunToken) error
+	getRunTokenFunc               func(ctx context.Context, tokenHash string) (*api.RunToken, error)
 }
 
 func (m *mockTokenRepository) CreateToken(ctx context.Context, token *api.WebSocketToken) error {
@@ -214,9 +364,9 @@ func (m *mockTokenRepository) CreateToken(ctx context.Context, token *api.WebSoc
 	return nil
 }
 
-func (m *mockTokenRepository) GetToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error) {
-	if m.getTokenFunc != nil {
-		return m.getTokenFunc(ctx, tokenValue)
+func (m *mockTokenRepository) ClaimToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error) {
+	if m.claimTokenFunc != nil {
+		return m.claimTokenFunc(ctx, tokenValue)
 	}
 	return nil, nil
 }
@@ -228,6 +378,27 @@ func (m *mockTokenRepository) DeleteToken(ctx context.Context, tokenValue string
 	return nil
 }
 
+func (m *mockTokenRepository) DeleteTokensByExecutionID(ctx context.Context, executionID string) (int, error) {
+	if m.deleteTokensByExecutionIDFunc != nil {
+		return m.deleteTokensByExecutionIDFunc(ctx, executionID)
+	}
+	return 0, nil
+}
+
+func (m *mockTokenRepository) CreateRunToken(ctx context.Context, token *api.RunToken) error {
+	if m.createRunTokenFunc != nil {
+		return m.createRunTokenFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *mockTokenRepository) GetRunToken(ctx context.Context, tokenHash string) (*api.RunToken, error) {
+	if m.getRunTokenFunc != nil {
+		return m.getRunTokenFunc(ctx, tokenHash)
+	}
+	return nil, nil
+}
+
 // mockRunner implements TaskManager, ImageRegistry, LogManager, and ObservabilityManager interfaces for testing
 type mockRunner struct {
 	startTaskFunc func(
@@ -235,8 +406,10 @@ type mockRunner struct {
 		userEmail string,
 		req *api.ExecutionRequest,
 	) (string, *time.Time, error)
-	killTaskFunc      func(ctx context.Context, executionID string) error
-	registerImageFunc func(
+	killTaskFunc            func(ctx context.Context, executionID string) error
+	createStdinUploadFunc   func(ctx context.Context, uploadID string, size int64) (string, time.Time, error)
+	createContextUploadFunc func(ctx context.Context, uploadID string, size int64) (string, time.Time, error)
+	registerImageFunc       func(
 		ctx context.Context,
 		image string,
 		isDefault *bool,
@@ -248,8 +421,16 @@ type mockRunner struct {
 	listImagesFunc             func(ctx context.Context) ([]api.ImageInfo, error)
 	getImageFunc               func(ctx context.Context, image string) (*api.ImageInfo, error)
 	removeImageFunc            func(ctx context.Context, image string) error
+	softDeleteImageFunc        func(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error)
+	restoreImageFunc           func(ctx context.Context, image string) (*api.ImageInfo, error)
+	setImageAliasFunc          func(ctx context.Context, alias *api.ImageAlias) (string, error)
+	getImageAliasFunc          func(ctx context.Context, alias string) (*api.ImageAlias, error)
+	listImageAliasesFunc       func(ctx context.Context) ([]api.ImageAlias, error)
+	removeImageAliasFunc       func(ctx context.Context, alias string) error
 	fetchLogsByExecutionIDFunc func(ctx context.Context, executionID string) ([]api.LogEvent, error)
 	fetchBackendLogsFunc       func(ctx context.Context, requestID string) ([]api.LogEvent, error)
+	fetchResourceUsageFunc     func(ctx context.Context, executionIDs []string) (map[string]*api.ResourceUsage, error)
+	registeredRunDefaults      *api.RunDefaults
 }
 
 func (m *mockRunner) StartTask(
@@ -270,6 +451,20 @@ func (m *mockRunner) KillTask(ctx context.Context, executionID string) error {
 	return nil
 }
 
+func (m *mockRunner) CreateStdinUpload(ctx context.Context, uploadID string, size int64) (string, time.Time, error) {
+	if m.createStdinUploadFunc != nil {
+		return m.createStdinUploadFunc(ctx, uploadID, size)
+	}
+	return "", time.Time{}, nil
+}
+
+func (m *mockRunner) CreateContextUpload(ctx context.Context, uploadID string, size int64) (string, time.Time, error) {
+	if m.createContextUploadFunc != nil {
+		return m.createContextUploadFunc(ctx, uploadID, size)
+	}
+	return "", time.Time{}, nil
+}
+
 func (m *mockRunner) RegisterImage(
 	ctx context.Context,
 	image string,
@@ -277,8 +472,12 @@ func (m *mockRunner) RegisterImage(
 	taskRoleName, taskExecutionRoleName *string,
 	cpu, memory *int,
 	runtimePlatform *string,
+	_ *api.LogLimits,
+	_ *int,
+	runDefaults *api.RunDefaults,
 	createdBy string,
 ) error {
+	m.registeredRunDefaults = runDefaults
 	if m.registerImageFunc != nil {
 		return m.registerImageFunc(
 			ctx, image, isDefault, taskRoleName, taskExecutionRoleName,
@@ -309,6 +508,48 @@ func (m *mockRunner) RemoveImage(ctx context.Context, image string) error {
 	return nil
 }
 
+func (m *mockRunner) SoftDeleteImage(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+	if m.softDeleteImageFunc != nil {
+		return m.softDeleteImageFunc(ctx, image, deletedBy)
+	}
+	return &api.ImageInfo{ImageID: image, DeletedBy: deletedBy}, nil
+}
+
+func (m *mockRunner) RestoreImage(ctx context.Context, image string) (*api.ImageInfo, error) {
+	if m.restoreImageFunc != nil {
+		return m.restoreImageFunc(ctx, image)
+	}
+	return &api.ImageInfo{ImageID: image}, nil
+}
+
+func (m *mockRunner) SetImageAlias(ctx context.Context, alias *api.ImageAlias) (string, error) {
+	if m.setImageAliasFunc != nil {
+		return m.setImageAliasFunc(ctx, alias)
+	}
+	return "", nil
+}
+
+func (m *mockRunner) GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error) {
+	if m.getImageAliasFunc != nil {
+		return m.getImageAliasFunc(ctx, alias)
+	}
+	return nil, nil
+}
+
+func (m *mockRunner) ListImageAliases(ctx context.Context) ([]api.ImageAlias, error) {
+	if m.listImageAliasesFunc != nil {
+		return m.listImageAliasesFunc(ctx)
+	}
+	return []api.ImageAlias{}, nil
+}
+
+func (m *mockRunner) RemoveImageAlias(ctx context.Context, alias string) error {
+	if m.removeImageAliasFunc != nil {
+		return m.removeImageAliasFunc(ctx, alias)
+	}
+	return nil
+}
+
 func (m *mockRunner) FetchLogsByExecutionID(ctx context.Context, executionID string) ([]api.LogEvent, error) {
 	if m.fetchLogsByExecutionIDFunc != nil {
 		return m.fetchLogsByExecutionIDFunc(ctx, executionID)
@@ -323,6 +564,16 @@ func (m *mockRunner) FetchBackendLogs(ctx context.Context, requestID string) ([]
 	return []api.LogEvent{}, nil
 }
 
+func (m *mockRunner) FetchResourceUsage(
+	ctx context.Context,
+	executionIDs []string,
+) (map[string]*api.ResourceUsage, error) {
+	if m.fetchResourceUsageFunc != nil {
+		return m.fetchResourceUsageFunc(ctx, executionIDs)
+	}
+	return map[string]*api.ResourceUsage{}, nil
+}
+
 func (m *mockRunner) GetImagesByRequestID(_ context.Context, _ string) ([]api.ImageInfo, error) {
 	return []api.ImageInfo{}, nil
 }
@@ -334,6 +585,10 @@ func (m *stubHealthManager) Reconcile(_ context.Context) (*api.HealthReport, err
 	return &api.HealthReport{}, nil
 }
 
+func (m *stubHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
 // newPermissiveEnforcer creates a test enforcer that allows all access.
 // This is useful for tests that need authorization to pass but don't test authorization logic.
 func newPermissiveEnforcer() *authorization.Enforcer {
@@ -558,6 +813,8 @@ type mockSecretsRepository struct {
 	listSecretsFunc  func(ctx context.Context, includeValue bool) ([]*api.Secret, error)
 	updateSecretFunc func(ctx context.Context, secret *api.Secret) error
 	deleteSecretFunc func(ctx context.Context, name string) error
+
+	recordSecretUsageFunc func(ctx context.Context, name, executionID string, usedAt time.Time) error
 }
 
 func (m *mockSecretsRepository) CreateSecret(ctx context.Context, secret *api.Secret) error {
@@ -606,6 +863,17 @@ func (m *mockSecretsRepository) GetSecretsByRequestID(_ context.Context, _ strin
 	return []*api.Secret{}, nil
 }
 
+func (m *mockSecretsRepository) RecordSecretUsage(
+	ctx context.Context,
+	name, executionID string,
+	usedAt time.Time,
+) error {
+	if m.recordSecretUsageFunc != nil {
+		return m.recordSecretUsageFunc(ctx, name, executionID, usedAt)
+	}
+	return nil
+}
+
 // mockImageRepository implements database.ImageRepository for testing
 type mockImageRepository struct{}
 
@@ -722,6 +990,10 @@ func (m *mockWebSocketManager) NotifyExecutionCompletion(ctx context.Context, ex
 	return nil
 }
 
+func (m *mockWebSocketManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (m *mockWebSocketManager) SendLogsToExecution(
 	ctx context.Context,
 	executionID *string,
@@ -0,0 +1,84 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// GetNotificationSettings returns the notification settings, the zero settings when the admins never set them.
+func (s *Service) GetNotificationSettings(ctx context.Context) (*api.NotificationSettings, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("notification settings are not configured", nil)
+	}
+	return s.repos.Config.GetNotificationSettings(ctx)
+}
+
+// SetNotificationSettings replaces the notification settings. The custom recipients of the usage report are
+// normalized to lowercase and deduplicated, the report going to the admins when there are none.
+func (s *Service) SetNotificationSettings(
+	ctx context.Context,
+	req *api.NotificationSettingsRequest,
+	adminEmail string,
+) (*api.NotificationSettings, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("notification settings are not configured", nil)
+	}
+
+	recipients, err := normalizeRecipients(req.UsageReport.Recipients)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	settings := &api.NotificationSettings{
+		UsageReport: api.UsageReportSettings{
+			Disabled:   req.UsageReport.Disabled,
+			Recipients: recipients,
+		},
+		UpdatedBy: adminEmail,
+		UpdatedAt: &now,
+	}
+	if err = s.repos.Config.PutNotificationSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info("audit: notification settings set", "context", map[string]any{
+		"admin":                   adminEmail,
+		"usage_report_disabled":   settings.UsageReport.Disabled,
+		"usage_report_recipients": settings.UsageReport.Recipients,
+	})
+	return settings, nil
+}
+
+// normalizeRecipients validates the email addresses of the recipients, returning them lowercase, sorted and
+// without duplicates.
+func normalizeRecipients(recipients []string) ([]string, error) {
+	normalized := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		recipient = strings.ToLower(strings.TrimSpace(recipient))
+		address, err := mail.ParseAddress(recipient)
+		if err != nil || address.Address != recipient {
+			return nil, apperrors.ErrBadRequest(fmt.Sprintf("invalid recipient email address: %q", recipient), err)
+		}
+		normalized = append(normalized, recipient)
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+	if len(normalized) > constants.MaxUsageReportRecipients {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("usage report recipients exceed %d addresses", constants.MaxUsageReportRecipients), nil)
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
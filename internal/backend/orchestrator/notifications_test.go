@@ -0,0 +1,71 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettings(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+
+	settings, err := svc.GetNotificationSettings(ctx)
+	require.NoError(t, err)
+	assert.False(t, settings.UsageReport.Disabled, "the usage report is sent by default")
+	assert.Empty(t, settings.UsageReport.Recipients)
+
+	settings, err = svc.SetNotificationSettings(ctx, &api.NotificationSettingsRequest{
+		UsageReport: api.UsageReportSettings{
+			Recipients: []string{" Finance@Example.com ", "ops@example.com", "finance@example.com"},
+		},
+	}, "admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"finance@example.com", "ops@example.com"}, settings.UsageReport.Recipients)
+	assert.Equal(t, "admin@example.com", settings.UpdatedBy)
+	assert.NotNil(t, settings.UpdatedAt)
+
+	_, err = svc.SetNotificationSettings(ctx, &api.NotificationSettingsRequest{
+		UsageReport: api.UsageReportSettings{Disabled: true},
+	}, "admin@example.com")
+	require.NoError(t, err)
+	settings, err = svc.GetNotificationSettings(ctx)
+	require.NoError(t, err)
+	assert.True(t, settings.UsageReport.Disabled)
+	assert.Empty(t, settings.UsageReport.Recipients, "the settings are replaced")
+}
+
+func TestSetNotificationSettingsValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+
+	for _, recipient := range []string{"finance", "Finance <finance@example.com>", ""} {
+		_, err := svc.SetNotificationSettings(ctx, &api.NotificationSettingsRequest{
+			UsageReport: api.UsageReportSettings{Recipients: []string{recipient}},
+		}, "admin@example.com")
+		assert.Equal(t, apperrors.ErrCodeInvalidRequest, apperrors.GetErrorCode(err), recipient)
+	}
+
+	recipients := make([]string, constants.MaxUsageReportRecipients+1)
+	for i := range recipients {
+		recipients[i] = fmt.Sprintf("user%d@example.com", i)
+	}
+	_, err := svc.SetNotificationSettings(ctx, &api.NotificationSettingsRequest{
+		UsageReport: api.UsageReportSettings{Recipients: recipients},
+	}, "admin@example.com")
+	assert.ErrorContains(t, err, "usage report recipients exceed 50 addresses")
+
+	svc.repos.Config = nil
+	_, err = svc.GetNotificationSettings(ctx)
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+}
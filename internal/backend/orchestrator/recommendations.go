@@ -0,0 +1,122 @@
+package orchestrator
+
+import (
+	"context"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// cpuTiers are the CPU sizes, in CPU units, recommended for an image. The memory of a size ranges from
+// twice to eight times its CPU units in MiB, at most 30 GiB, in steps of 1 GiB or 512 MiB for the
+// smallest size, matching the task sizes supported by Fargate.
+var cpuTiers = []int{256, 512, 1024, 2048, 4096}
+
+const (
+	mibPerGiB           = 1024
+	minMemoryMiB        = 512
+	maxMemoryMiB        = 30 * mibPerGiB
+	minMemoryPerCPUUnit = 2
+	maxMemoryPerCPUUnit = 8
+	percentile95        = 0.95
+)
+
+// GetResourceRecommendations recommends lower CPU and memory for the images whose recent succeeded executions,
+// among those listed to the user, use far less than they reserve. Only the executions of an image with its
+// current resources, those of its latest execution, are compared, and an image needs
+// constants.MinRecommendationExecutions of them with sampled usage to get a recommendation.
+func (s *Service) GetResourceRecommendations(
+	ctx context.Context,
+	userEmail string,
+) (*api.ResourceRecommendationsResponse, error) {
+	executions, err := s.ListVisibleExecutions(
+		ctx, userEmail, constants.RecommendationExecutionsLimit, []string{string(constants.ExecutionSucceeded)},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]*api.Execution)
+	for _, execution := range executions {
+		if execution.ResourceSummary == nil || execution.ResourceSummary.Samples == 0 {
+			continue
+		}
+		if previous, ok := latest[execution.ImageID]; !ok || execution.StartedAt.After(previous.StartedAt) {
+			latest[execution.ImageID] = execution
+		}
+	}
+
+	cpuUsage := make(map[string][]float64)
+	memoryUsage := make(map[string][]float64)
+	for _, execution := range executions {
+		summary := execution.ResourceSummary
+		if summary == nil || summary.Samples == 0 {
+			continue
+		}
+		if current := latest[execution.ImageID].ResourceSummary; summary.CPUReserved != current.CPUReserved ||
+			summary.MemoryReservedMiB != current.MemoryReservedMiB {
+			continue
+		}
+		cpuUsage[execution.ImageID] = append(cpuUsage[execution.ImageID], summary.AverageCPUUtilized)
+		memoryUsage[execution.ImageID] = append(memoryUsage[execution.ImageID], summary.PeakMemoryUtilizedMiB)
+	}
+
+	resp := &api.ResourceRecommendationsResponse{Recommendations: []api.ResourceRecommendation{}}
+	for _, imageID := range slices.Sorted(maps.Keys(latest)) {
+		if len(cpuUsage[imageID]) < constants.MinRecommendationExecutions {
+			continue
+		}
+		recommendation := api.ResourceRecommendation{
+			ImageID:              imageID,
+			Executions:           len(cpuUsage[imageID]),
+			CPU:                  int(latest[imageID].ResourceSummary.CPUReserved),
+			Memory:               int(latest[imageID].ResourceSummary.MemoryReservedMiB),
+			P95CPUUtilized:       percentile(cpuUsage[imageID], percentile95),
+			P95MemoryUtilizedMiB: percentile(memoryUsage[imageID], percentile95),
+		}
+		recommendation.RecommendedCPU, recommendation.RecommendedMemory = rightSize(
+			recommendation.CPU, recommendation.Memory,
+			recommendation.P95CPUUtilized, recommendation.P95MemoryUtilizedMiB,
+		)
+		if recommendation.RecommendedCPU < recommendation.CPU || recommendation.RecommendedMemory < recommendation.Memory {
+			resp.Recommendations = append(resp.Recommendations, recommendation)
+		}
+	}
+	return resp, nil
+}
+
+// rightSize returns the smallest supported CPU and memory fitting the usage with constants.RecommendationHeadroom,
+// never above the current resources. Sizes outside of cpuTiers are left as they are.
+func rightSize(cpu, memory int, cpuUsage, memoryUsage float64) (recommendedCPU, recommendedMemory int) {
+	if !slices.Contains(cpuTiers, cpu) {
+		return cpu, memory
+	}
+
+	tier := slices.IndexFunc(cpuTiers, func(tier int) bool {
+		return float64(tier) >= cpuUsage*constants.RecommendationHeadroom
+	})
+	if tier < 0 || cpuTiers[tier] > cpu {
+		tier = slices.Index(cpuTiers, cpu)
+	}
+
+	recommendedMemory = minMemoryMiB
+	if needed := memoryUsage * constants.RecommendationHeadroom; needed > minMemoryMiB {
+		recommendedMemory = int(math.Ceil(needed/mibPerGiB)) * mibPerGiB
+	}
+	recommendedMemory = max(min(recommendedMemory, memory), cpuTiers[tier]*minMemoryPerCPUUnit)
+
+	// A size only supports so much memory, keep the CPU large enough for the memory.
+	for recommendedMemory > min(cpuTiers[tier]*maxMemoryPerCPUUnit, maxMemoryMiB) && tier < len(cpuTiers)-1 {
+		tier++
+	}
+	return cpuTiers[tier], recommendedMemory
+}
+
+// percentile returns the nearest-rank percentile p, between 0 and 1, of values.
+func percentile(values []float64, p float64) float64 {
+	sorted := slices.Sorted(slices.Values(values))
+	return sorted[max(int(math.Ceil(p*float64(len(sorted))))-1, 0)]
+}
@@ -0,0 +1,121 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func TestGetResourceRecommendations(t *testing.T) {
+	ctx := context.Background()
+	startedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	var executions []*api.Execution
+	add := func(imageID string, count int, summary func(i int) *api.ResourceSummary) {
+		for i := range count {
+			executions = append(executions, &api.Execution{
+				ExecutionID:     fmt.Sprintf("%s-%d", imageID, len(executions)),
+				CreatedBy:       "owner@example.com",
+				OwnedBy:         []string{"owner@example.com"},
+				ImageID:         imageID,
+				Status:          string(constants.ExecutionSucceeded),
+				StartedAt:       startedAt.Add(time.Duration(len(executions)) * time.Minute),
+				ResourceSummary: summary(i),
+			})
+		}
+	}
+	// Oversized image, also run with larger resources before they were lowered.
+	add("oversized", 1, func(_ int) *api.ResourceSummary {
+		return &api.ResourceSummary{Samples: 3, AverageCPUUtilized: 2000, CPUReserved: 2048, MemoryReservedMiB: 8192}
+	})
+	add("oversized", 6, func(i int) *api.ResourceSummary {
+		return &api.ResourceSummary{
+			Samples:               3,
+			AverageCPUUtilized:    float64(100 + 10*i),
+			CPUReserved:           1024,
+			PeakMemoryUtilizedMiB: float64(600 + 50*i),
+			MemoryReservedMiB:     4096,
+		}
+	})
+	// Right-sized image.
+	add("right-sized", 5, func(_ int) *api.ResourceSummary {
+		return &api.ResourceSummary{
+			Samples:               3,
+			AverageCPUUtilized:    200,
+			CPUReserved:           256,
+			PeakMemoryUtilizedMiB: 400,
+			MemoryReservedMiB:     512,
+		}
+	})
+	// Image with too few sampled executions.
+	add("rarely-run", 3, func(_ int) *api.ResourceSummary {
+		return &api.ResourceSummary{Samples: 1, AverageCPUUtilized: 10, CPUReserved: 1024, MemoryReservedMiB: 2048}
+	})
+	add("rarely-run", 3, func(_ int) *api.ResourceSummary { return &api.ResourceSummary{} })
+	add("rarely-run", 1, func(_ int) *api.ResourceSummary { return nil })
+
+	var statuses []string
+	var limit int
+	execRepo := &mockExecutionRepository{
+		listExecutionsFunc: func(_ context.Context, l int, s []string) ([]*api.Execution, error) {
+			if s != nil {
+				statuses, limit = s, l
+			}
+			return executions, nil
+		},
+	}
+	svc, _ := newTestServiceWithEnforcer(nil, execRepo, &mockRunner{}, nil)
+
+	resp, err := svc.GetResourceRecommendations(ctx, "owner@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{string(constants.ExecutionSucceeded)}, statuses)
+	assert.Equal(t, constants.RecommendationExecutionsLimit, limit)
+	assert.Equal(t, []api.ResourceRecommendation{{
+		ImageID:              "oversized",
+		Executions:           6,
+		CPU:                  1024,
+		Memory:               4096,
+		P95CPUUtilized:       150,
+		P95MemoryUtilizedMiB: 850,
+		RecommendedCPU:       256,
+		RecommendedMemory:    2048,
+	}}, resp.Recommendations)
+}
+
+func TestRightSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpu         int
+		memory      int
+		cpuUsage    float64
+		memoryUsage float64
+		wantCPU     int
+		wantMemory  int
+	}{
+		{name: "smallest size", cpu: 1024, memory: 2048, cpuUsage: 100, memoryUsage: 300, wantCPU: 256, wantMemory: 512},
+		{name: "never above the current size", cpu: 256, memory: 512, cpuUsage: 250, memoryUsage: 400,
+			wantCPU: 256, wantMemory: 512},
+		{name: "CPU large enough for the memory", cpu: 1024, memory: 8192, cpuUsage: 100, memoryUsage: 3000,
+			wantCPU: 1024, wantMemory: 5120},
+		{name: "memory large enough for the CPU", cpu: 4096, memory: 16384, cpuUsage: 3500, memoryUsage: 4000,
+			wantCPU: 4096, wantMemory: 8192},
+		{name: "size outside of the tiers", cpu: 8192, memory: 16384, cpuUsage: 10, memoryUsage: 10,
+			wantCPU: 8192, wantMemory: 16384},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory := rightSize(tt.cpu, tt.memory, tt.cpuUsage, tt.memoryUsage)
+			assert.Equal(t, tt.wantCPU, cpu)
+			assert.Equal(t, tt.wantMemory, memory)
+		})
+	}
+}
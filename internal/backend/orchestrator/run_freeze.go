@@ -0,0 +1,88 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// defaultRunFreezeMessage is shown to the users whose runs are rejected when the admin gave no message.
+const defaultRunFreezeMessage = "the backend is under maintenance, please try again later"
+
+// GetRunFreeze returns the run freeze switch. Runs are not frozen when it was never set.
+func (s *Service) GetRunFreeze(ctx context.Context) (*api.RunFreeze, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("run freeze is not configured", nil)
+	}
+	freeze, err := s.repos.Config.GetRunFreeze(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if freeze == nil {
+		return &api.RunFreeze{}, nil
+	}
+	return freeze, nil
+}
+
+// FreezeRuns rejects the new executions with the message until UnfreezeRuns is called.
+// Reads and the executions already running are not affected.
+func (s *Service) FreezeRuns(ctx context.Context, message, userEmail string) (*api.RunFreeze, error) {
+	return s.setRunFreeze(ctx, true, strings.TrimSpace(message), userEmail)
+}
+
+// UnfreezeRuns accepts the new executions again.
+func (s *Service) UnfreezeRuns(ctx context.Context, userEmail string) (*api.RunFreeze, error) {
+	return s.setRunFreeze(ctx, false, "", userEmail)
+}
+
+func (s *Service) setRunFreeze(ctx context.Context, frozen bool, message, userEmail string) (*api.RunFreeze, error) {
+	if s.repos.Config == nil {
+		return nil, apperrors.ErrServiceUnavailable("run freeze is not configured", nil)
+	}
+
+	now := time.Now().UTC()
+	freeze := &api.RunFreeze{
+		Frozen:    frozen,
+		Message:   message,
+		UpdatedBy: userEmail,
+		UpdatedAt: &now,
+	}
+	if err := s.repos.Config.PutRunFreeze(ctx, freeze); err != nil {
+		return nil, err
+	}
+
+	auditMessage := "audit: runs unfrozen"
+	if frozen {
+		auditMessage = "audit: runs frozen"
+	}
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	reqLogger.Info(auditMessage, "context", map[string]string{
+		"user":    userEmail,
+		"message": message,
+	})
+	return freeze, nil
+}
+
+// checkRunFreeze refuses a new execution while the runs are frozen, with the message set by the admin.
+func (s *Service) checkRunFreeze(ctx context.Context) error {
+	if s.repos.Config == nil {
+		return nil
+	}
+	freeze, err := s.repos.Config.GetRunFreeze(ctx)
+	if err != nil {
+		return err
+	}
+	if freeze == nil || !freeze.Frozen {
+		return nil
+	}
+
+	message := freeze.Message
+	if message == "" {
+		message = defaultRunFreezeMessage
+	}
+	return apperrors.ErrRunsFrozen("runs are frozen: "+message, nil)
+}
@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFreeze(t *testing.T) {
+	ctx := context.Background()
+	var started int
+	runner := &mockRunner{
+		startTaskFunc: func(_ context.Context, _ string, _ *api.ExecutionRequest) (string, *time.Time, error) {
+			started++
+			return "exec-123", timePtr(time.Now()), nil
+		},
+	}
+	svc := newTestService(nil, &mockExecutionRepository{}, runner)
+	svc.repos.Config = fake.NewConfigRepository()
+
+	freeze, err := svc.GetRunFreeze(ctx)
+	require.NoError(t, err)
+	assert.False(t, freeze.Frozen)
+
+	freeze, err = svc.FreezeRuns(ctx, " maintenance until 5pm ", "admin@example.com")
+	require.NoError(t, err)
+	assert.True(t, freeze.Frozen)
+	assert.Equal(t, "maintenance until 5pm", freeze.Message)
+	assert.Equal(t, "admin@example.com", freeze.UpdatedBy)
+
+	_, err = svc.RunCommand(ctx, "user@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+	assert.Equal(t, apperrors.ErrCodeRunsFrozen, apperrors.GetErrorCode(err))
+	assert.Contains(t, err.Error(), "runs are frozen: maintenance until 5pm")
+	assert.Zero(t, started)
+
+	resp, err := svc.RunCommand(ctx, "user@example.com", nil,
+		&api.ExecutionRequest{Command: "echo hello", DryRun: true}, nil)
+	require.NoError(t, err, "dry runs start nothing and are still validated")
+	assert.True(t, resp.DryRun)
+
+	freeze, err = svc.UnfreezeRuns(ctx, "admin@example.com")
+	require.NoError(t, err)
+	assert.False(t, freeze.Frozen)
+
+	_, err = svc.RunCommand(ctx, "user@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+}
+
+func TestRunFreeze_DefaultMessage(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+	svc.repos.Config = fake.NewConfigRepository()
+
+	_, err := svc.FreezeRuns(ctx, "", "admin@example.com")
+	require.NoError(t, err)
+
+	_, err = svc.RunCommand(ctx, "user@example.com", nil, &api.ExecutionRequest{Command: "echo hello"}, nil)
+	assert.Contains(t, err.Error(), defaultRunFreezeMessage)
+}
+
+func TestRunFreeze_NotConfigured(t *testing.T) {
+	svc := newTestService(nil, &mockExecutionRepository{}, &mockRunner{})
+
+	_, err := svc.GetRunFreeze(context.Background())
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+
+	_, err = svc.FreezeRuns(context.Background(), "maintenance", "admin@example.com")
+	assert.Equal(t, apperrors.ErrCodeServiceUnavailable, apperrors.GetErrorCode(err))
+
+	assert.NoError(t, svc.checkRunFreeze(context.Background()))
+}
@@ -0,0 +1,438 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// scimFilterPattern matches the SCIM filters identity providers use to look a user up before provisioning it.
+var scimFilterPattern = regexp.MustCompile(`(?i)^\s*(userName|externalId)\s+eq\s+"([^"]*)"\s*$`)
+
+// scimValuePathPattern matches the PATCH paths selecting a group or a role, e.g. groups[value eq "Engineering"].
+var scimValuePathPattern = regexp.MustCompile(`(?i)^(groups|roles)\[(value|display)\s+eq\s+"([^"]*)"\]$`)
+
+// CreateSCIMUser provisions a user sent by the identity provider. The user gets the runvoy role mapped to its
+// groups or roles, and no API key: the key is issued when the user logs in for the first time, see Login.
+func (s *Service) CreateSCIMUser(ctx context.Context, scimUser *api.SCIMUser) (*api.SCIMUser, error) {
+	email, err := scimUserEmail(scimUser)
+	if err != nil {
+		return nil, err
+	}
+	groups := scimGroupNames(scimUser)
+	role, err := s.scimRole(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to check if user exists", err)
+	}
+	if existing != nil {
+		return nil, apperrors.ErrConflict("user with this email already exists", nil)
+	}
+
+	unusedKey, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to generate API key", err)
+	}
+	requestID := logger.GetRequestID(ctx)
+	user := &api.User{
+		Email:               email,
+		Role:                role,
+		CreatedAt:           time.Now().UTC(),
+		CreatedByRequestID:  requestID,
+		ModifiedByRequestID: requestID,
+		Provisioned:         true,
+		ExternalID:          scimUser.ExternalID,
+		PendingLogin:        true,
+		Groups:              groups,
+	}
+	// The API key of the record is never handed out, the user gets one by logging in.
+	if err = s.repos.User.CreateUser(ctx, user, auth.HashAPIKey(unusedKey), 0); err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to create user", err)
+	}
+
+	if !scimUser.IsActive() {
+		if err = s.repos.User.RevokeUser(ctx, email); err != nil {
+			return nil, apperrors.ErrDatabaseError("failed to deactivate user", err)
+		}
+		user.Revoked = true
+		return toSCIMUser(user), nil
+	}
+	if err = s.syncUserRoleAfterCreate(ctx, email, role); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+// GetSCIMUser returns a provisioned user by its SCIM ID, its email.
+func (s *Service) GetSCIMUser(ctx context.Context, id string) (*api.SCIMUser, error) {
+	user, err := s.getProvisionedUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user), nil
+}
+
+// ListSCIMUsers returns a page of the provisioned users matching the filter, which can only select a user by
+// userName or externalId. startIndex is 1-based as in SCIM.
+func (s *Service) ListSCIMUsers(
+	ctx context.Context,
+	filter string,
+	startIndex, count int,
+) (*api.SCIMListResponse, error) {
+	var attribute, value string
+	if filter != "" {
+		match := scimFilterPattern.FindStringSubmatch(filter)
+		if match == nil {
+			return nil, apperrors.ErrBadRequest("unsupported filter, only userName eq and externalId eq are", nil)
+		}
+		attribute, value = strings.ToLower(match[1]), match[2]
+	}
+
+	users, err := s.repos.User.ListUsers(ctx)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to list users", err)
+	}
+	matching := make([]*api.SCIMUser, 0, len(users))
+	for _, user := range users {
+		switch {
+		case !user.Provisioned:
+			continue
+		case attribute == "username" && !strings.EqualFold(user.Email, value):
+			continue
+		case attribute == "externalid" && user.ExternalID != value:
+			continue
+		}
+		matching = append(matching, toSCIMUser(user))
+	}
+
+	startIndex = max(startIndex, 1)
+	if count <= 0 || count > constants.SCIMMaxPageSize {
+		count = constants.SCIMMaxPageSize
+	}
+	page := matching[min(startIndex-1, len(matching)):min(startIndex-1+count, len(matching))]
+	return &api.SCIMListResponse{
+		Schemas:      []string{api.SCIMListResponseSchema},
+		TotalResults: len(matching),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}, nil
+}
+
+// ReplaceSCIMUser updates a provisioned user with the state sent by the identity provider: its external ID,
+// its groups or roles, and whether it is active. The user name can't change, as the email identifies the user.
+func (s *Service) ReplaceSCIMUser(ctx context.Context, id string, scimUser *api.SCIMUser) (*api.SCIMUser, error) {
+	user, err := s.getProvisionedUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	email, err := scimUserEmail(scimUser)
+	if err != nil {
+		return nil, err
+	}
+	if email != user.Email {
+		return nil, apperrors.ErrBadRequest("userName can't be changed", nil)
+	}
+
+	updated := *user
+	updated.ExternalID = scimUser.ExternalID
+	updated.Groups = scimGroupNames(scimUser)
+	updated.Revoked = !scimUser.IsActive()
+	if err = s.applySCIMUpdate(ctx, user, &updated); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(&updated), nil
+}
+
+// PatchSCIMUser applies the operations of a SCIM PATCH request to a provisioned user. The operations can
+// activate or deactivate the user, set its external ID, and add, replace or remove its groups or roles.
+func (s *Service) PatchSCIMUser(ctx context.Context, id string, patch *api.SCIMPatchRequest) (*api.SCIMUser, error) {
+	user, err := s.getProvisionedUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *user
+	updated.Groups = slices.Clone(user.Groups)
+	for _, op := range patch.Operations {
+		if err = applySCIMPatchOperation(&updated, op); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.applySCIMUpdate(ctx, user, &updated); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(&updated), nil
+}
+
+// DeleteSCIMUser deprovisions a user: like revoking it, it is deactivated and kept for the audit trail.
+func (s *Service) DeleteSCIMUser(ctx context.Context, id string) error {
+	user, err := s.getProvisionedUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	updated := *user
+	updated.Revoked = true
+	return s.applySCIMUpdate(ctx, user, &updated)
+}
+
+func (s *Service) getProvisionedUser(ctx context.Context, id string) (*api.User, error) {
+	user, err := s.repos.User.GetUserByEmail(ctx, strings.ToLower(id))
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to get user", err)
+	}
+	if user == nil || !user.Provisioned {
+		return nil, apperrors.ErrNotFound("user not found", nil)
+	}
+	return user, nil
+}
+
+// applySCIMUpdate stores the updated state of a provisioned user and updates its role in the enforcer. A
+// reactivated user gets a new API key record, so the key it had before being deactivated stays revoked, and
+// has to log in again.
+func (s *Service) applySCIMUpdate(ctx context.Context, user, updated *api.User) error {
+	active := !updated.Revoked
+	if active {
+		role, err := s.scimRole(updated.Groups)
+		if err != nil {
+			return err
+		}
+		updated.Role = role
+	}
+
+	if user.Revoked && active {
+		unusedKey, err := auth.GenerateSecretToken()
+		if err != nil {
+			return apperrors.ErrInternalError("failed to generate API key", err)
+		}
+		if err = s.repos.User.ReplaceAPIKeyHash(ctx, user.Email, auth.HashAPIKey(unusedKey)); err != nil {
+			return apperrors.ErrDatabaseError("failed to reset the API key of the user", err)
+		}
+		updated.PendingLogin = true
+	}
+	updated.ModifiedByRequestID = logger.GetRequestID(ctx)
+	if err := s.repos.User.UpdateUser(ctx, updated); err != nil {
+		if apperrors.GetErrorCode(err) == apperrors.ErrCodeConcurrentModification {
+			return fmt.Errorf("update user: %w", err)
+		}
+		return apperrors.ErrDatabaseError("failed to update user", err)
+	}
+
+	if !user.Revoked && user.Role != "" && (!active || updated.Role != user.Role) {
+		if err := s.removeRoleForUserFromEnforcer(ctx, user.Email, user.Role); err != nil {
+			return apperrors.ErrInternalError("failed to remove user role from authorization enforcer", err)
+		}
+	}
+	if active {
+		if err := s.addRoleForUserToEnforcer(ctx, user.Email, updated.Role); err != nil {
+			return apperrors.ErrInternalError("failed to add user role to authorization enforcer", err)
+		}
+	}
+	return nil
+}
+
+// syncProvisionedUserRole updates the role of an authenticated provisioned user in the enforcer, which may
+// have been changed by the identity provider through another instance of the service.
+func (s *Service) syncProvisionedUserRole(ctx context.Context, user *api.User) error {
+	role, err := authorization.NewRole(user.Role)
+	if err != nil {
+		return fmt.Errorf("invalid role %q for user %s: %w", user.Role, user.Email, err)
+	}
+	roles, err := s.enforcer.GetRolesForUser(user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to get roles for user %s: %w", user.Email, err)
+	}
+	formattedRole := authorization.FormatRole(role)
+	if len(roles) == 1 && roles[0] == formattedRole {
+		return nil
+	}
+	for _, stale := range roles {
+		if stale == formattedRole {
+			continue
+		}
+		if err = s.enforcer.RemoveRoleForUser(ctx, user.Email, stale); err != nil {
+			return fmt.Errorf("failed to remove role %q for user %s: %w", stale, user.Email, err)
+		}
+	}
+	if err = s.enforcer.AddRoleForUser(ctx, user.Email, role); err != nil {
+		return fmt.Errorf("failed to add role %q for user %s: %w", user.Role, user.Email, err)
+	}
+	return nil
+}
+
+// scimRole returns the most privileged role mapped to the groups or roles of a provisioned user. Roles named
+// like a runvoy role map to it, other names go through the configured group mapping.
+func (s *Service) scimRole(groups []string) (string, error) {
+	var mapped []string
+	for _, group := range groups {
+		if authorization.IsValidRole(group) {
+			mapped = append(mapped, group)
+		}
+		if role, ok := s.SCIMGroupRoles[group]; ok {
+			mapped = append(mapped, role)
+		}
+	}
+	for _, role := range authorization.ValidRoles() {
+		if slices.Contains(mapped, role) {
+			return role, nil
+		}
+	}
+	return "", apperrors.ErrBadRequest(
+		"none of the user groups or roles is mapped to a runvoy role: "+strings.Join(groups, ", "), nil)
+}
+
+// applySCIMPatchOperation applies a PATCH operation to the state of a provisioned user.
+func applySCIMPatchOperation(user *api.User, op api.SCIMPatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return apperrors.ErrBadRequest("unsupported patch operation: "+op.Op, nil)
+	}
+
+	if op.Path == "" {
+		if operation == "remove" {
+			return apperrors.ErrBadRequest("remove operations require a path", nil)
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attributes); err != nil {
+			return apperrors.ErrBadRequest("patch value without path must be an object", err)
+		}
+		for path, value := range attributes {
+			err := applySCIMPatchOperation(user, api.SCIMPatchOperation{Op: op.Op, Path: path, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if match := scimValuePathPattern.FindStringSubmatch(op.Path); match != nil {
+		if operation != "remove" {
+			return apperrors.ErrBadRequest("unsupported patch path: "+op.Path, nil)
+		}
+		user.Groups = slices.DeleteFunc(user.Groups, func(group string) bool { return group == match[3] })
+		return nil
+	}
+
+	switch strings.ToLower(op.Path) {
+	case "active":
+		var active api.SCIMBool
+		if err := json.Unmarshal(op.Value, &active); err != nil || operation == "remove" {
+			return apperrors.ErrBadRequest("active must be a boolean", err)
+		}
+		user.Revoked = !bool(active)
+	case "externalid":
+		var externalID string
+		if operation != "remove" {
+			if err := json.Unmarshal(op.Value, &externalID); err != nil {
+				return apperrors.ErrBadRequest("externalId must be a string", err)
+			}
+		}
+		user.ExternalID = externalID
+	case "groups", "roles":
+		return applySCIMGroupsPatch(user, operation, op.Value)
+	case "username":
+		return apperrors.ErrBadRequest("userName can't be changed", nil)
+	}
+	// Other attributes, such as the name of the user, aren't used by runvoy.
+	return nil
+}
+
+func applySCIMGroupsPatch(user *api.User, operation string, value json.RawMessage) error {
+	var values []api.SCIMMultiValue
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &values); err != nil {
+			return apperrors.ErrBadRequest("groups and roles must be arrays", err)
+		}
+	}
+	names := multiValueNames(values)
+	switch operation {
+	case "add":
+		for _, name := range names {
+			if !slices.Contains(user.Groups, name) {
+				user.Groups = append(user.Groups, name)
+			}
+		}
+	case "replace":
+		user.Groups = names
+	case "remove":
+		if len(names) == 0 {
+			user.Groups = nil
+			return nil
+		}
+		user.Groups = slices.DeleteFunc(user.Groups, func(group string) bool {
+			return slices.Contains(names, group)
+		})
+	}
+	return nil
+}
+
+// scimUserEmail returns the email of a SCIM user, its user name or else its primary email.
+func scimUserEmail(scimUser *api.SCIMUser) (string, error) {
+	email := scimUser.UserName
+	if email == "" {
+		for _, e := range scimUser.Emails {
+			if e.Primary || email == "" {
+				email = e.Value
+			}
+		}
+	}
+	if email == "" {
+		return "", apperrors.ErrBadRequest("userName is required", nil)
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", apperrors.ErrBadRequest("userName must be an email address", err)
+	}
+	return strings.ToLower(email), nil
+}
+
+// scimGroupNames returns the names of the groups and roles of a SCIM user.
+func scimGroupNames(scimUser *api.SCIMUser) []string {
+	return multiValueNames(slices.Concat(scimUser.Groups, scimUser.Roles))
+}
+
+func multiValueNames(values []api.SCIMMultiValue) []string {
+	var names []string
+	for _, v := range values {
+		for _, name := range []string{v.Display, v.Value} {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// toSCIMUser converts a provisioned user to its SCIM representation.
+func toSCIMUser(user *api.User) *api.SCIMUser {
+	active := api.SCIMBool(!user.Revoked)
+	createdAt := user.CreatedAt
+	scimUser := &api.SCIMUser{
+		Schemas:    []string{api.SCIMUserSchema},
+		ID:         user.Email,
+		ExternalID: user.ExternalID,
+		UserName:   user.Email,
+		Emails:     []api.SCIMMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:     &active,
+		Meta:       &api.SCIMMeta{ResourceType: "User", Created: &createdAt},
+	}
+	for _, group := range user.Groups {
+		scimUser.Roles = append(scimUser.Roles, api.SCIMMultiValue{Value: group})
+	}
+	return scimUser
+}
@@ -0,0 +1,269 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSCIMUserRepository returns a user repository storing the users in memory, and the API key hashes they got.
+func newSCIMUserRepository(users map[string]*api.User) (*mockUserRepository, map[string]string) {
+	hashes := make(map[string]string)
+	return &mockUserRepository{
+		createUserFunc: func(_ context.Context, user *api.User, apiKeyHash string, _ int64) error {
+			stored := *user
+			users[user.Email] = &stored
+			hashes[user.Email] = apiKeyHash
+			return nil
+		},
+		getUserByEmailFunc: func(_ context.Context, email string) (*api.User, error) {
+			if user, ok := users[email]; ok {
+				copied := *user
+				return &copied, nil
+			}
+			return nil, nil
+		},
+		listUsersFunc: func(_ context.Context) ([]*api.User, error) {
+			list := make([]*api.User, 0, len(users))
+			for _, user := range users {
+				list = append(list, user)
+			}
+			return list, nil
+		},
+		revokeUserFunc: func(_ context.Context, email string) error {
+			users[email].Revoked = true
+			return nil
+		},
+		updateUserFunc: func(_ context.Context, user *api.User) error {
+			stored := *user
+			users[user.Email] = &stored
+			return nil
+		},
+		replaceAPIKeyHashFunc: func(_ context.Context, email, apiKeyHash string) error {
+			hashes[email] = apiKeyHash
+			return nil
+		},
+	}, hashes
+}
+
+func roleOf(t *testing.T, enforcer *authorization.Enforcer, email string) []string {
+	t.Helper()
+	roles, err := enforcer.GetRolesForUser(email)
+	require.NoError(t, err)
+	return roles
+}
+
+func TestCreateSCIMUser(t *testing.T) {
+	users := map[string]*api.User{}
+	repo, hashes := newSCIMUserRepository(users)
+	service, enforcer := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	service.SCIMGroupRoles = map[string]string{"Engineering": "developer", "Platform": "admin"}
+
+	scimUser, err := service.CreateSCIMUser(context.Background(), &api.SCIMUser{
+		UserName:   "Alice@Example.com",
+		ExternalID: "00u1",
+		Groups:     []api.SCIMMultiValue{{Value: "g1", Display: "Engineering"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", scimUser.ID)
+	assert.True(t, scimUser.IsActive())
+
+	stored := users["alice@example.com"]
+	require.NotNil(t, stored)
+	assert.Equal(t, "developer", stored.Role)
+	assert.True(t, stored.Provisioned)
+	assert.True(t, stored.PendingLogin)
+	assert.Equal(t, "00u1", stored.ExternalID)
+	assert.NotEmpty(t, hashes["alice@example.com"])
+	assert.Equal(t, []string{"role:developer"}, roleOf(t, enforcer, "alice@example.com"))
+
+	_, err = service.CreateSCIMUser(context.Background(), &api.SCIMUser{
+		UserName: "alice@example.com",
+		Roles:    []api.SCIMMultiValue{{Value: "viewer"}},
+	})
+	assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+}
+
+func TestCreateSCIMUser_Rejects(t *testing.T) {
+	service, _ := newTestServiceWithEnforcer(&mockUserRepository{}, nil, nil, nil)
+	service.SCIMGroupRoles = map[string]string{"Engineering": "developer"}
+
+	tests := []struct {
+		name string
+		user *api.SCIMUser
+	}{
+		{"no user name", &api.SCIMUser{Roles: []api.SCIMMultiValue{{Value: "viewer"}}}},
+		{"not an email", &api.SCIMUser{UserName: "alice", Roles: []api.SCIMMultiValue{{Value: "viewer"}}}},
+		{"unmapped group", &api.SCIMUser{UserName: "a@example.com", Groups: []api.SCIMMultiValue{{Value: "Sales"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateSCIMUser(context.Background(), tt.user)
+			assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+		})
+	}
+}
+
+func TestCreateSCIMUser_MostPrivilegedRole(t *testing.T) {
+	users := map[string]*api.User{}
+	repo, _ := newSCIMUserRepository(users)
+	service, _ := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	service.SCIMGroupRoles = map[string]string{"Engineering": "developer", "Platform": "admin"}
+
+	_, err := service.CreateSCIMUser(context.Background(), &api.SCIMUser{
+		UserName: "bob@example.com",
+		Groups:   []api.SCIMMultiValue{{Display: "Engineering"}, {Display: "Platform"}},
+		Roles:    []api.SCIMMultiValue{{Value: "viewer"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", users["bob@example.com"].Role)
+}
+
+func TestPatchSCIMUser(t *testing.T) {
+	users := map[string]*api.User{}
+	repo, hashes := newSCIMUserRepository(users)
+	service, enforcer := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	service.SCIMGroupRoles = map[string]string{"Engineering": "developer", "Platform": "admin"}
+	ctx := context.Background()
+
+	_, err := service.CreateSCIMUser(ctx, &api.SCIMUser{
+		UserName: "alice@example.com",
+		Groups:   []api.SCIMMultiValue{{Value: "Engineering"}},
+	})
+	require.NoError(t, err)
+	users["alice@example.com"].PendingLogin = false
+
+	patch := func(ops ...api.SCIMPatchOperation) {
+		t.Helper()
+		_, patchErr := service.PatchSCIMUser(ctx, "alice@example.com", &api.SCIMPatchRequest{Operations: ops})
+		require.NoError(t, patchErr)
+	}
+
+	patch(api.SCIMPatchOperation{Op: "add", Path: "groups", Value: json.RawMessage(`[{"value":"Platform"}]`)})
+	assert.Equal(t, "admin", users["alice@example.com"].Role)
+	assert.Equal(t, []string{"role:admin"}, roleOf(t, enforcer, "alice@example.com"))
+
+	patch(api.SCIMPatchOperation{Op: "remove", Path: `groups[value eq "Platform"]`})
+	assert.Equal(t, "developer", users["alice@example.com"].Role)
+	assert.Equal(t, []string{"role:developer"}, roleOf(t, enforcer, "alice@example.com"))
+
+	// Azure AD sends the attributes without path, and booleans as strings.
+	patch(api.SCIMPatchOperation{Op: "Replace", Value: json.RawMessage(`{"active":"False"}`)})
+	assert.True(t, users["alice@example.com"].Revoked)
+	assert.Empty(t, roleOf(t, enforcer, "alice@example.com"))
+
+	hashBefore := hashes["alice@example.com"]
+	patch(api.SCIMPatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`true`)})
+	assert.False(t, users["alice@example.com"].Revoked)
+	assert.True(t, users["alice@example.com"].PendingLogin, "reactivated users log in again")
+	assert.NotEqual(t, hashBefore, hashes["alice@example.com"], "the API key of a reactivated user is replaced")
+	assert.Equal(t, []string{"role:developer"}, roleOf(t, enforcer, "alice@example.com"))
+
+	_, err = service.PatchSCIMUser(ctx, "alice@example.com", &api.SCIMPatchRequest{
+		Operations: []api.SCIMPatchOperation{{Op: "remove", Path: "groups"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err), "users need a mapped group")
+}
+
+func TestReplaceAndDeleteSCIMUser(t *testing.T) {
+	users := map[string]*api.User{}
+	repo, _ := newSCIMUserRepository(users)
+	service, enforcer := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := service.CreateSCIMUser(ctx, &api.SCIMUser{
+		UserName: "alice@example.com",
+		Roles:    []api.SCIMMultiValue{{Value: "viewer"}},
+	})
+	require.NoError(t, err)
+
+	_, err = service.ReplaceSCIMUser(ctx, "alice@example.com", &api.SCIMUser{
+		UserName:   "alice@example.com",
+		ExternalID: "00u2",
+		Roles:      []api.SCIMMultiValue{{Value: "operator"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "operator", users["alice@example.com"].Role)
+	assert.Equal(t, "00u2", users["alice@example.com"].ExternalID)
+	assert.Equal(t, []string{"role:operator"}, roleOf(t, enforcer, "alice@example.com"))
+
+	_, err = service.ReplaceSCIMUser(ctx, "alice@example.com", &api.SCIMUser{
+		UserName: "alice.smith@example.com",
+		Roles:    []api.SCIMMultiValue{{Value: "operator"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+
+	require.NoError(t, service.DeleteSCIMUser(ctx, "alice@example.com"))
+	assert.True(t, users["alice@example.com"].Revoked, "deprovisioned users are kept for the audit trail")
+	assert.Empty(t, roleOf(t, enforcer, "alice@example.com"))
+}
+
+func TestSCIMUsers_NotProvisioned(t *testing.T) {
+	users := map[string]*api.User{"admin@example.com": {Email: "admin@example.com", Role: "admin"}}
+	repo, _ := newSCIMUserRepository(users)
+	service, _ := newTestServiceWithEnforcer(repo, nil, nil, nil)
+
+	_, err := service.GetSCIMUser(context.Background(), "admin@example.com")
+	assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	err = service.DeleteSCIMUser(context.Background(), "admin@example.com")
+	assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	assert.False(t, users["admin@example.com"].Revoked)
+}
+
+func TestListSCIMUsers(t *testing.T) {
+	users := map[string]*api.User{
+		"admin@example.com": {Email: "admin@example.com", Role: "admin"},
+		"alice@example.com": {Email: "alice@example.com", Role: "viewer", Provisioned: true, ExternalID: "00u1"},
+		"bob@example.com":   {Email: "bob@example.com", Role: "viewer", Provisioned: true, ExternalID: "00u2"},
+	}
+	repo, _ := newSCIMUserRepository(users)
+	service, _ := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	ctx := context.Background()
+
+	all, err := service.ListSCIMUsers(ctx, "", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, all.TotalResults, "only provisioned users are listed")
+
+	byName, err := service.ListSCIMUsers(ctx, `userName eq "Alice@example.com"`, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, byName.Resources, 1)
+	assert.Equal(t, "alice@example.com", byName.Resources[0].UserName)
+
+	byExternalID, err := service.ListSCIMUsers(ctx, `externalId eq "00u2"`, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, byExternalID.Resources, 1)
+	assert.Equal(t, "bob@example.com", byExternalID.Resources[0].UserName)
+
+	page, err := service.ListSCIMUsers(ctx, "", 3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.TotalResults)
+	assert.Empty(t, page.Resources)
+
+	_, err = service.ListSCIMUsers(ctx, `displayName co "Al"`, 1, 10)
+	assert.Equal(t, http.StatusBadRequest, apperrors.GetStatusCode(err))
+}
+
+func TestAuthenticateUser_SyncsProvisionedUserRole(t *testing.T) {
+	user := &api.User{Email: "alice@example.com", Role: "operator", Provisioned: true}
+	repo := &mockUserRepository{
+		getUserByAPIKeyHashFunc: func(_ context.Context, _ string) (*api.User, error) {
+			return user, nil
+		},
+	}
+	service, enforcer := newTestServiceWithEnforcer(repo, nil, nil, nil)
+	viewer, err := authorization.NewRole("viewer")
+	require.NoError(t, err)
+	require.NoError(t, enforcer.AddRoleForUser(context.Background(), user.Email, viewer))
+
+	_, err = service.AuthenticateUser(context.Background(), "api-key")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"role:operator"}, roleOf(t, enforcer, user.Email))
+}
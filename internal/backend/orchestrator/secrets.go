@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -58,9 +59,13 @@ func (s *Service) CreateSecret(
 	return nil
 }
 
-// GetSecret retrieves a secret's metadata and value by name.
-func (s *Service) GetSecret(ctx context.Context, name string) (*api.Secret, error) {
-	secret, err := s.repos.Secrets.GetSecret(ctx, name, true)
+// GetSecret retrieves a secret's metadata by name, with its value when the user may reveal it.
+func (s *Service) GetSecret(ctx context.Context, name, userEmail string) (*api.Secret, error) {
+	reveal, err := s.canRevealSecret(ctx, userEmail, name)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.repos.Secrets.GetSecret(ctx, name, reveal)
 	if err != nil {
 		// Wrap the error - AppError types will still be found via errors.As() in the chain
 		return nil, fmt.Errorf("get secret: %w", err)
@@ -68,15 +73,37 @@ func (s *Service) GetSecret(ctx context.Context, name string) (*api.Secret, erro
 	return secret, nil
 }
 
-// ListSecrets retrieves all secrets with values.
-func (s *Service) ListSecrets(ctx context.Context) ([]*api.Secret, error) {
+// ListSecrets retrieves all secrets, with the values of those the user may reveal.
+func (s *Service) ListSecrets(ctx context.Context, userEmail string) ([]*api.Secret, error) {
 	secretList, err := s.repos.Secrets.ListSecrets(ctx, true)
 	if err != nil {
 		return nil, apperrors.ErrDatabaseError("failed to list secrets", fmt.Errorf("list secrets: %w", err))
 	}
+	for _, secret := range secretList {
+		reveal, revealErr := s.canRevealSecret(ctx, userEmail, secret.Name)
+		if revealErr != nil {
+			return nil, revealErr
+		}
+		if !reveal {
+			secret.Value = ""
+		}
+	}
 	return secretList, nil
 }
 
+// canRevealSecret reports whether the user may read the value of a secret, not only its metadata.
+// Viewers read the metadata of the secrets only.
+func (s *Service) canRevealSecret(ctx context.Context, userEmail, name string) (bool, error) {
+	allowed, err := s.GetEnforcer().Enforce(ctx, userEmail, "/api/v1/secrets/"+name, authorization.ActionReveal)
+	if err != nil {
+		return false, apperrors.ErrInternalError(
+			"failed to validate secret access",
+			fmt.Errorf("enforcement error for secret %q: %w", name, err),
+		)
+	}
+	return allowed, nil
+}
+
 // UpdateSecret updates a secret (metadata and/or value).
 func (s *Service) UpdateSecret(
 	ctx context.Context,
@@ -230,3 +257,34 @@ func (s *Service) applyResolvedSecrets(req *api.ExecutionRequest, secretEnvVars
 	detectedSecretVarNames := secrets.GetSecretVariableNames(req.Env)
 	req.SecretVarNames = secrets.MergeSecretVarNames(knownSecretVarNames, detectedSecretVarNames)
 }
+
+// executionSecretNames returns the trimmed, deduplicated names of the secrets an execution is started with.
+func executionSecretNames(names []string) []string {
+	var unique []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// recordSecretsUsage records the execution as the last one to have used each of its secrets.
+// Failures are logged only: the execution has already been started.
+func (s *Service) recordSecretsUsage(ctx context.Context, execution *api.Execution) {
+	if len(execution.Secrets) == 0 {
+		return
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	for _, name := range execution.Secrets {
+		if err := s.repos.Secrets.RecordSecretUsage(ctx, name, execution.ExecutionID, execution.StartedAt); err != nil {
+			reqLogger.Warn("failed to record secret usage", "context", map[string]string{
+				"execution_id": execution.ExecutionID,
+				"secret":       name,
+				"error":        err.Error(),
+			})
+		}
+	}
+}
@@ -127,7 +127,7 @@ func TestGetSecret_Success(t *testing.T) {
 	runner := &mockRunner{}
 	service := newSecretsTestService(t, runner, secretsRepo)
 
-	secret, err := service.GetSecret(context.Background(), "test-secret")
+	secret, err := service.GetSecret(context.Background(), "test-secret", "user@example.com")
 
 	assert.NoError(t, err)
 	require.NotNil(t, secret)
@@ -144,7 +144,7 @@ func TestGetSecret_NotFound(t *testing.T) {
 	runner := &mockRunner{}
 	service := newSecretsTestService(t, runner, secretsRepo)
 
-	secret, err := service.GetSecret(context.Background(), "nonexistent")
+	secret, err := service.GetSecret(context.Background(), "nonexistent", "user@example.com")
 
 	assert.NoError(t, err)
 	assert.Nil(t, secret)
@@ -179,7 +179,7 @@ func TestListSecrets_Success(t *testing.T) {
 	runner := &mockRunner{}
 	service := newSecretsTestService(t, runner, secretsRepo)
 
-	secrets, err := service.ListSecrets(context.Background())
+	secrets, err := service.ListSecrets(context.Background(), "user@example.com")
 
 	assert.NoError(t, err)
 	assert.Len(t, secrets, 2)
@@ -196,12 +196,48 @@ func TestListSecrets_Empty(t *testing.T) {
 	runner := &mockRunner{}
 	service := newSecretsTestService(t, runner, secretsRepo)
 
-	secrets, err := service.ListSecrets(context.Background())
+	secrets, err := service.ListSecrets(context.Background(), "user@example.com")
 
 	assert.NoError(t, err)
 	assert.Empty(t, secrets)
 }
 
+func TestSecrets_ViewerReadsMetadataOnly(t *testing.T) {
+	ctx := context.Background()
+	var includedValue bool
+	secretsRepo := &mockSecretsRepository{
+		getSecretFunc: func(_ context.Context, name string, includeValue bool) (*api.Secret, error) {
+			includedValue = includeValue
+			secret := &api.Secret{Name: name, KeyName: "KEY_1"}
+			if includeValue {
+				secret.Value = "value1"
+			}
+			return secret, nil
+		},
+		listSecretsFunc: func(_ context.Context, _ bool) ([]*api.Secret, error) {
+			return []*api.Secret{{Name: "secret-1", KeyName: "KEY_1", Value: "value1", CreatedBy: "user@example.com"}}, nil
+		},
+	}
+	service := newSecretsTestService(t, &mockRunner{}, secretsRepo)
+	require.NoError(t, service.GetEnforcer().AddRoleForUser(ctx, "viewer@example.com", authorization.RoleViewer))
+
+	secret, err := service.GetSecret(ctx, "secret-1", "viewer@example.com")
+	require.NoError(t, err)
+	assert.False(t, includedValue, "the value of the secret is not even read for a viewer")
+	assert.Empty(t, secret.Value)
+	assert.Equal(t, "KEY_1", secret.KeyName)
+
+	secrets, err := service.ListSecrets(ctx, "viewer@example.com")
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	assert.Empty(t, secrets[0].Value)
+	assert.Equal(t, "KEY_1", secrets[0].KeyName)
+
+	secret, err = service.GetSecret(ctx, "secret-1", "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "value1", secret.Value)
+}
+
 func TestUpdateSecret_Success(t *testing.T) {
 	secretsRepo := &mockSecretsRepository{}
 	runner := &mockRunner{}
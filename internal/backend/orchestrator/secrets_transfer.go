@@ -0,0 +1,181 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database/backup"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// ExportSecrets exports the allowlisted secrets with their values in a bundle encrypted with the
+// request passphrase. The bundle is a backup archive holding only secrets.
+func (s *Service) ExportSecrets(
+	ctx context.Context,
+	req *api.ExportSecretsRequest,
+	userEmail string,
+) (*api.ExportSecretsResponse, error) {
+	names, err := validateSecretsAllowlist(req.Names)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Passphrase) < backup.MinPassphraseLength {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf("passphrase must be at least %d characters", backup.MinPassphraseLength), nil)
+	}
+
+	secretList := make([]api.Secret, 0, len(names))
+	for _, name := range names {
+		secret, getErr := s.repos.Secrets.GetSecret(ctx, name, true)
+		if getErr != nil {
+			return nil, fmt.Errorf("get secret: %w", getErr)
+		}
+		secretList = append(secretList, *secret)
+	}
+
+	bundle, err := backup.Seal(&backup.Archive{
+		FormatVersion: backup.FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Provider:      string(s.Provider),
+		Secrets:       secretList,
+	}, req.Passphrase)
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to encrypt secrets bundle", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("audit: secrets exported", "context", map[string]any{
+		"user":    userEmail,
+		"secrets": names,
+	})
+
+	return &api.ExportSecretsResponse{
+		Bundle:  bundle,
+		Secrets: names,
+	}, nil
+}
+
+// ImportSecrets imports the allowlisted secrets of a bundle created by ExportSecrets. Values are
+// stored through the secrets repository, which encrypts them with this environment's key.
+// Existing secrets are skipped unless the request asks to overwrite them.
+func (s *Service) ImportSecrets(
+	ctx context.Context,
+	req *api.ImportSecretsRequest,
+	userEmail string,
+) (*api.ImportSecretsResponse, error) {
+	names, err := validateSecretsAllowlist(req.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := backup.Open(req.Bundle, req.Passphrase)
+	if err != nil {
+		return nil, apperrors.ErrBadRequest("failed to decrypt secrets bundle", err)
+	}
+	bundled := make(map[string]*api.Secret, len(archive.Secrets))
+	for i := range archive.Secrets {
+		bundled[archive.Secrets[i].Name] = &archive.Secrets[i]
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := bundled[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.ErrBadRequest(
+			"secrets not found in bundle: "+strings.Join(missing, ", "), nil)
+	}
+
+	existing, err := s.repos.Secrets.ListSecrets(ctx, false)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to list secrets", fmt.Errorf("list secrets: %w", err))
+	}
+	existingNames := make(map[string]struct{}, len(existing))
+	for _, secret := range existing {
+		existingNames[secret.Name] = struct{}{}
+	}
+
+	resp := &api.ImportSecretsResponse{Created: []string{}, Updated: []string{}, Skipped: []string{}}
+	importErr := s.importBundledSecrets(ctx, names, bundled, existingNames, req.Overwrite, userEmail, resp)
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("audit: secrets imported", "context", map[string]any{
+		"user":           userEmail,
+		"source":         archive.Provider,
+		"bundle_created": archive.CreatedAt.Format(time.RFC3339),
+		"created":        resp.Created,
+		"updated":        resp.Updated,
+		"skipped":        resp.Skipped,
+		"failed":         importErr != nil,
+	})
+
+	if importErr != nil {
+		return nil, importErr
+	}
+	return resp, nil
+}
+
+func (s *Service) importBundledSecrets(
+	ctx context.Context,
+	names []string,
+	bundled map[string]*api.Secret,
+	existingNames map[string]struct{},
+	overwrite bool,
+	userEmail string,
+	resp *api.ImportSecretsResponse,
+) error {
+	for _, name := range names {
+		secret := bundled[name]
+		if _, exists := existingNames[name]; !exists {
+			if err := s.CreateSecret(ctx, &api.CreateSecretRequest{
+				Name:        secret.Name,
+				KeyName:     secret.KeyName,
+				Description: secret.Description,
+				Value:       secret.Value,
+			}, userEmail); err != nil {
+				return fmt.Errorf("import secret %s: %w", name, err)
+			}
+			resp.Created = append(resp.Created, name)
+			continue
+		}
+
+		if !overwrite {
+			resp.Skipped = append(resp.Skipped, name)
+			continue
+		}
+		if err := s.UpdateSecret(ctx, name, &api.UpdateSecretRequest{
+			KeyName:     secret.KeyName,
+			Description: secret.Description,
+			Value:       secret.Value,
+		}, userEmail); err != nil {
+			return fmt.Errorf("import secret %s: %w", name, err)
+		}
+		resp.Updated = append(resp.Updated, name)
+	}
+	return nil
+}
+
+// validateSecretsAllowlist checks that secrets are explicitly listed and returns them without duplicates.
+func validateSecretsAllowlist(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, apperrors.ErrBadRequest("secret names are required", errors.New("empty allowlist"))
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			return nil, apperrors.ErrBadRequest("secret names cannot be empty", nil)
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		unique = append(unique, name)
+	}
+	return unique, nil
+}
@@ -0,0 +1,183 @@
+package orchestrator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTransferPassphrase = "correct horse battery staple"
+	testOwner              = "owner@example.com"
+)
+
+// memorySecretsRepository returns a mockSecretsRepository backed by the given secrets.
+func memorySecretsRepository(secrets map[string]*api.Secret) *mockSecretsRepository {
+	return &mockSecretsRepository{
+		createSecretFunc: func(_ context.Context, secret *api.Secret) error {
+			secrets[secret.Name] = secret
+			return nil
+		},
+		getSecretFunc: func(_ context.Context, name string, _ bool) (*api.Secret, error) {
+			secret, ok := secrets[name]
+			if !ok {
+				return nil, appErrors.ErrSecretNotFound("secret not found", nil)
+			}
+			return secret, nil
+		},
+		listSecretsFunc: func(_ context.Context, _ bool) ([]*api.Secret, error) {
+			list := make([]*api.Secret, 0, len(secrets))
+			for _, secret := range secrets {
+				list = append(list, secret)
+			}
+			return list, nil
+		},
+		updateSecretFunc: func(_ context.Context, secret *api.Secret) error {
+			secrets[secret.Name].Value = secret.Value
+			secrets[secret.Name].UpdatedBy = secret.UpdatedBy
+			return nil
+		},
+	}
+}
+
+func exportTestBundle(t *testing.T) []byte {
+	t.Helper()
+	source := newSecretsTestService(t, &mockRunner{}, memorySecretsRepository(map[string]*api.Secret{
+		"github-token": {
+			Name: "github-token", KeyName: "GITHUB_TOKEN", Description: "CI token", Value: "ghp_new",
+			CreatedBy: testOwner, OwnedBy: []string{testOwner},
+		},
+		"db-password": {
+			Name: "db-password", KeyName: "DB_PASSWORD", Value: "s3cret", CreatedBy: testOwner, OwnedBy: []string{testOwner},
+		},
+		"unlisted": {
+			Name: "unlisted", KeyName: "UNLISTED", Value: "private", CreatedBy: testOwner, OwnedBy: []string{testOwner},
+		},
+	}))
+
+	resp, err := source.ExportSecrets(context.Background(), &api.ExportSecretsRequest{
+		Names:      []string{"github-token", "db-password", "github-token"},
+		Passphrase: testTransferPassphrase,
+	}, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github-token", "db-password"}, resp.Secrets)
+	assert.NotContains(t, string(resp.Bundle), "ghp_new")
+	return resp.Bundle
+}
+
+func TestExportImportSecrets(t *testing.T) {
+	bundle := exportTestBundle(t)
+	destSecrets := map[string]*api.Secret{
+		"github-token": {
+			Name: "github-token", KeyName: "GITHUB_TOKEN", Value: "ghp_old", CreatedBy: testOwner, OwnedBy: []string{testOwner},
+		},
+	}
+	dest := newSecretsTestService(t, &mockRunner{}, memorySecretsRepository(destSecrets))
+
+	resp, err := dest.ImportSecrets(context.Background(), &api.ImportSecretsRequest{
+		Bundle:     bundle,
+		Passphrase: testTransferPassphrase,
+		Names:      []string{"github-token", "db-password"},
+	}, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db-password"}, resp.Created)
+	assert.Empty(t, resp.Updated)
+	assert.Equal(t, []string{"github-token"}, resp.Skipped)
+	assert.Equal(t, "s3cret", destSecrets["db-password"].Value)
+	assert.Equal(t, "DB_PASSWORD", destSecrets["db-password"].KeyName)
+	assert.Equal(t, "admin@example.com", destSecrets["db-password"].CreatedBy)
+	assert.Equal(t, "ghp_old", destSecrets["github-token"].Value)
+}
+
+func TestImportSecrets_Overwrite(t *testing.T) {
+	bundle := exportTestBundle(t)
+	destSecrets := map[string]*api.Secret{
+		"github-token": {
+			Name: "github-token", KeyName: "GITHUB_TOKEN", Value: "ghp_old", CreatedBy: testOwner, OwnedBy: []string{testOwner},
+		},
+	}
+	dest := newSecretsTestService(t, &mockRunner{}, memorySecretsRepository(destSecrets))
+
+	resp, err := dest.ImportSecrets(context.Background(), &api.ImportSecretsRequest{
+		Bundle:     bundle,
+		Passphrase: testTransferPassphrase,
+		Names:      []string{"github-token"},
+		Overwrite:  true,
+	}, "admin@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github-token"}, resp.Updated)
+	assert.Equal(t, "ghp_new", destSecrets["github-token"].Value)
+	assert.NotContains(t, destSecrets, "db-password", "only allowlisted secrets are imported")
+}
+
+func TestImportSecrets_Errors(t *testing.T) {
+	bundle := exportTestBundle(t)
+
+	tests := []struct {
+		name    string
+		req     api.ImportSecretsRequest
+		wantMsg string
+	}{
+		{
+			name:    "empty allowlist",
+			req:     api.ImportSecretsRequest{Bundle: bundle, Passphrase: testTransferPassphrase},
+			wantMsg: "secret names are required",
+		},
+		{
+			name: "wrong passphrase",
+			req: api.ImportSecretsRequest{
+				Bundle: bundle, Passphrase: "wrong passphrase!", Names: []string{"db-password"},
+			},
+			wantMsg: "failed to decrypt secrets bundle",
+		},
+		{
+			name: "secret missing from bundle",
+			req: api.ImportSecretsRequest{
+				Bundle: bundle, Passphrase: testTransferPassphrase, Names: []string{"unlisted"},
+			},
+			wantMsg: "secrets not found in bundle: unlisted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := newSecretsTestService(t, &mockRunner{}, memorySecretsRepository(map[string]*api.Secret{}))
+
+			_, err := dest.ImportSecrets(context.Background(), &tt.req, "admin@example.com")
+
+			require.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, appErrors.GetStatusCode(err))
+			assert.Equal(t, tt.wantMsg, appErrors.GetErrorMessage(err))
+		})
+	}
+}
+
+func TestExportSecrets_Errors(t *testing.T) {
+	service := newSecretsTestService(t, &mockRunner{}, memorySecretsRepository(map[string]*api.Secret{}))
+
+	_, err := service.ExportSecrets(context.Background(), &api.ExportSecretsRequest{
+		Passphrase: testTransferPassphrase,
+	}, "admin@example.com")
+	assert.Equal(t, http.StatusBadRequest, appErrors.GetStatusCode(err))
+
+	_, err = service.ExportSecrets(context.Background(), &api.ExportSecretsRequest{
+		Names:      []string{"db-password"},
+		Passphrase: "short",
+	}, "admin@example.com")
+	assert.Equal(t, http.StatusBadRequest, appErrors.GetStatusCode(err))
+
+	_, err = service.ExportSecrets(context.Background(), &api.ExportSecretsRequest{
+		Names:      []string{"missing"},
+		Passphrase: testTransferPassphrase,
+	}, "admin@example.com")
+	assert.Equal(t, http.StatusNotFound, appErrors.GetStatusCode(err))
+}
@@ -94,6 +94,13 @@ func (s *Service) CreateUser(
 	if err := s.validateCreateUserRequest(ctx, req.Email, req.Role); err != nil {
 		return nil, err
 	}
+	if req.ServiceAccount {
+		if err := s.validateServiceAccountOwner(ctx, req.Email, req.Owner); err != nil {
+			return nil, err
+		}
+	} else if req.Owner != "" {
+		return nil, apperrors.ErrBadRequest("owner can only be set for service accounts", nil)
+	}
 
 	apiKey, err := generateOrUseAPIKey(req.APIKey)
 	if err != nil {
@@ -105,6 +112,7 @@ func (s *Service) CreateUser(
 	// Extract request ID from context
 	requestID := logger.GetRequestID(ctx)
 
+	claimExpiresAt := time.Now().UTC().Add(s.claimTokenTTL()).Truncate(time.Second)
 	user := &api.User{
 		Email:               req.Email,
 		Role:                req.Role,
@@ -112,11 +120,14 @@ func (s *Service) CreateUser(
 		Revoked:             false,
 		CreatedByRequestID:  requestID,
 		ModifiedByRequestID: requestID,
+		ClaimExpiresAt:      &claimExpiresAt,
+		ServiceAccount:      req.ServiceAccount,
+		Owner:               req.Owner,
 	}
 
-	expiresAt := time.Now().Add(constants.ClaimURLExpirationMinutes * time.Minute).Unix()
-
-	if err = s.repos.User.CreateUser(ctx, user, apiKeyHash, expiresAt); err != nil {
+	// The user has no TTL: it stays pending until its key is claimed, the claim token can be re-issued
+	// in the meantime and the claim tokens sweep deletes it when configured to.
+	if err = s.repos.User.CreateUser(ctx, user, apiKeyHash, 0); err != nil {
 		return nil, apperrors.ErrDatabaseError("failed to create user", err)
 	}
 
@@ -124,7 +135,7 @@ func (s *Service) CreateUser(
 		return nil, syncErr
 	}
 
-	secretToken, err := s.createPendingClaim(ctx, apiKey, req.Email, createdByEmail, expiresAt)
+	secretToken, err := s.createPendingClaim(ctx, apiKey, req.Email, createdByEmail, claimExpiresAt.Unix())
 	if err != nil {
 		if removeErr := s.removeRoleForUserFromEnforcer(ctx, req.Email, req.Role); removeErr != nil {
 			reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
@@ -150,6 +161,180 @@ func (s *Service) CreateUser(
 	}, nil
 }
 
+// validateServiceAccountOwner checks that the owner of a service account is an active human user.
+func (s *Service) validateServiceAccountOwner(ctx context.Context, email, owner string) error {
+	if owner == "" {
+		return apperrors.ErrBadRequest("owner is required for service accounts", nil)
+	}
+	if owner == email {
+		return apperrors.ErrBadRequest("a service account cannot own itself", nil)
+	}
+
+	ownerUser, err := s.repos.User.GetUserByEmail(ctx, owner)
+	if err != nil {
+		return apperrors.ErrDatabaseError("failed to get owner", fmt.Errorf("get user by email: %w", err))
+	}
+	if ownerUser == nil {
+		return apperrors.ErrBadRequest("owner not found: "+owner, nil)
+	}
+	if ownerUser.Revoked {
+		return apperrors.ErrBadRequest("owner is revoked: "+owner, nil)
+	}
+	if ownerUser.ServiceAccount {
+		return apperrors.ErrBadRequest("owner must not be a service account: "+owner, nil)
+	}
+	return nil
+}
+
+// TransferOwnership transfers a service account to another owner, e.g. before revoking the user who owned it.
+// The service account keeps its API key, role and budget.
+func (s *Service) TransferOwnership(
+	ctx context.Context, email string, req *api.TransferOwnershipRequest, transferredByEmail string,
+) (*api.TransferOwnershipResponse, error) {
+	if email == "" {
+		return nil, apperrors.ErrBadRequest("email is required", nil)
+	}
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound("user not found", nil)
+	}
+	if !user.ServiceAccount {
+		return nil, apperrors.ErrBadRequest("user is not a service account", nil)
+	}
+	if user.Owner == req.Owner {
+		return nil, apperrors.ErrConflict("service account is already owned by "+req.Owner, nil)
+	}
+	if err = s.validateServiceAccountOwner(ctx, email, req.Owner); err != nil {
+		return nil, err
+	}
+
+	previousOwner := user.Owner
+	user.Owner = req.Owner
+	user.ModifiedByRequestID = logger.GetRequestID(ctx)
+	if err = s.repos.User.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	logger.DeriveRequestLogger(ctx, s.Logger).Info("audit: service account ownership transferred",
+		"context", map[string]string{
+			"service_account": email,
+			"previous_owner":  previousOwner,
+			"owner":           req.Owner,
+			"transferred_by":  transferredByEmail,
+		})
+
+	return &api.TransferOwnershipResponse{
+		User:          user,
+		PreviousOwner: previousOwner,
+		Message:       "service account ownership transferred successfully",
+	}, nil
+}
+
+// claimTokenTTL returns how long the claim tokens of the invited users stay valid.
+func (s *Service) claimTokenTTL() time.Duration {
+	if s.ClaimTokenTTL > 0 {
+		return s.ClaimTokenTTL
+	}
+	return constants.DefaultClaimTokenTTL
+}
+
+// ListPendingUsers returns the invited users who have not claimed their API key yet, sorted by email.
+func (s *Service) ListPendingUsers(ctx context.Context) (*api.ListUsersResponse, error) {
+	users, err := s.repos.User.ListUsers(ctx)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to list users", fmt.Errorf("list users: %w", err))
+	}
+
+	pending := make([]*api.User, 0)
+	for _, user := range users {
+		if user.ClaimExpiresAt != nil && !user.Revoked {
+			pending = append(pending, user)
+		}
+	}
+
+	return &api.ListUsersResponse{
+		Users: pending,
+	}, nil
+}
+
+// ReissueClaimToken issues a new claim token to an invited user who has not claimed their API key yet.
+// The user gets a new API key, the previous claim tokens of the user no longer being claimable.
+func (s *Service) ReissueClaimToken(
+	ctx context.Context, email, issuedByEmail string,
+) (*api.CreateUserResponse, error) {
+	if email == "" {
+		return nil, apperrors.ErrBadRequest("email is required", nil)
+	}
+
+	user, err := s.repos.User.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+	if user == nil {
+		return nil, apperrors.ErrNotFound("user not found", nil)
+	}
+	if user.Revoked {
+		return nil, apperrors.ErrConflict("user is revoked", nil)
+	}
+	if user.ClaimExpiresAt == nil {
+		return nil, apperrors.ErrConflict("user has already claimed their API key", nil)
+	}
+
+	pendingKeys, err := s.repos.User.ListPendingAPIKeys(ctx)
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError("failed to list pending API keys", err)
+	}
+
+	apiKey, err := generateOrUseAPIKey("")
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repos.User.ReplaceAPIKeyHash(ctx, email, auth.HashAPIKey(apiKey)); err != nil {
+		return nil, fmt.Errorf("replace API key hash: %w", err)
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, s.Logger)
+	for _, pending := range pendingKeys {
+		if pending.UserEmail != email {
+			continue
+		}
+		if deleteErr := s.repos.User.DeletePendingAPIKey(ctx, pending.SecretToken); deleteErr != nil {
+			// The previous token claims the replaced API key, which no longer authenticates.
+			reqLogger.Warn("failed to delete previous claim token", "context", map[string]string{
+				"user":  email,
+				"error": deleteErr.Error(),
+			})
+		}
+	}
+
+	claimExpiresAt := time.Now().UTC().Add(s.claimTokenTTL()).Truncate(time.Second)
+	secretToken, err := s.createPendingClaim(ctx, apiKey, email, issuedByEmail, claimExpiresAt.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	user.ClaimExpiresAt = &claimExpiresAt
+	user.ModifiedByRequestID = logger.GetRequestID(ctx)
+	if err = s.repos.User.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	reqLogger.Info("audit: claim token reissued", "context", map[string]any{
+		"user":             email,
+		"issued_by":        issuedByEmail,
+		"claim_expires_at": claimExpiresAt.Format(time.RFC3339),
+	})
+
+	return &api.CreateUserResponse{
+		User:       user,
+		ClaimToken: secretToken,
+	}, nil
+}
+
 // ClaimAPIKey retrieves and claims a pending API key by its secret token.
 func (s *Service) ClaimAPIKey(
 	ctx context.Context,
@@ -212,7 +397,13 @@ func (s *Service) AuthenticateUser(ctx context.Context, apiKey string) (*api.Use
 
 	apiKeyHash := auth.HashAPIKey(apiKey)
 
-	user, err := s.repos.User.GetUserByAPIKeyHash(ctx, apiKeyHash)
+	var user *api.User
+	var err error
+	if strings.HasPrefix(apiKey, constants.RunTokenPrefix) {
+		user, err = s.getRunTokenUser(ctx, apiKeyHash)
+	} else {
+		user, err = s.repos.User.GetUserByAPIKeyHash(ctx, apiKeyHash)
+	}
 	if err != nil {
 		// Wrap the error - AppError types will still be found via errors.As() in the chain
 		return nil, fmt.Errorf("get user by API key hash: %w", err)
@@ -226,6 +417,14 @@ func (s *Service) AuthenticateUser(ctx context.Context, apiKey string) (*api.Use
 		return nil, apperrors.ErrAPIKeyRevoked(nil)
 	}
 
+	// The role of a provisioned user changes when the identity provider updates it, possibly on another
+	// instance whose enforcer was updated instead of this one.
+	if user.Provisioned {
+		if syncErr := s.syncProvisionedUserRole(ctx, user); syncErr != nil {
+			return nil, apperrors.ErrInternalError("failed to synchronize user role with authorization enforcer", syncErr)
+		}
+	}
+
 	return user, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/runvoy/runvoy/internal/auth/authorization"
 	"github.com/runvoy/runvoy/internal/database"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -1051,3 +1052,131 @@ func TestListUsers_SortingByEmail(t *testing.T) {
 	assert.Equal(t, "charlie@example.com", resp.Users[2].Email)
 	assert.Equal(t, "zebra@example.com", resp.Users[3].Email)
 }
+
+func TestCreateUser_PendingUntilClaimed(t *testing.T) {
+	users := fake.NewUserRepository()
+	svc := newTestService(nil, nil, nil)
+	svc.repos.User = users
+	svc.ClaimTokenTTL = time.Hour
+	ctx := context.Background()
+
+	resp, err := svc.CreateUser(ctx,
+		api.CreateUserRequest{Email: "user@example.com", Role: "viewer"}, "admin@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, resp.User.ClaimExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *resp.User.ClaimExpiresAt, time.Minute)
+
+	pending, err := svc.ListPendingUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending.Users, 1)
+	assert.Equal(t, "user@example.com", pending.Users[0].Email)
+
+	_, err = svc.ClaimAPIKey(ctx, resp.ClaimToken, "127.0.0.1")
+	require.NoError(t, err)
+
+	pending, err = svc.ListPendingUsers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending.Users)
+}
+
+func TestReissueClaimToken(t *testing.T) {
+	users := fake.NewUserRepository()
+	svc := newTestService(nil, nil, nil)
+	svc.repos.User = users
+	ctx := context.Background()
+
+	created, err := svc.CreateUser(ctx,
+		api.CreateUserRequest{Email: "user@example.com", Role: "viewer"}, "admin@example.com")
+	require.NoError(t, err)
+
+	reissued, err := svc.ReissueClaimToken(ctx, "user@example.com", "admin@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, created.ClaimToken, reissued.ClaimToken)
+	require.NotNil(t, reissued.User.ClaimExpiresAt)
+
+	previous, err := users.GetPendingAPIKey(ctx, created.ClaimToken)
+	require.NoError(t, err)
+	assert.Nil(t, previous, "the previous claim token must no longer be claimable")
+
+	claimed, err := svc.ClaimAPIKey(ctx, reissued.ClaimToken, "127.0.0.1")
+	require.NoError(t, err)
+	user, err := svc.AuthenticateUser(ctx, claimed.APIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", user.Email)
+	assert.Nil(t, user.ClaimExpiresAt)
+
+	_, err = svc.ReissueClaimToken(ctx, "user@example.com", "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeConflict, appErrors.GetErrorCode(err))
+
+	_, err = svc.ReissueClaimToken(ctx, "missing@example.com", "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeNotFound, appErrors.GetErrorCode(err))
+
+	require.NoError(t, users.RevokeUser(ctx, "user@example.com"))
+	_, err = svc.ReissueClaimToken(ctx, "user@example.com", "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeConflict, appErrors.GetErrorCode(err))
+}
+
+func TestServiceAccounts(t *testing.T) {
+	users := fake.NewUserRepository()
+	svc := newTestService(nil, nil, nil)
+	svc.repos.User = users
+	ctx := context.Background()
+
+	for _, email := range []string{"alice@example.com", "bob@example.com", "carol@example.com"} {
+		_, err := svc.CreateUser(ctx, api.CreateUserRequest{Email: email, Role: "developer"}, "admin@example.com")
+		require.NoError(t, err)
+	}
+	require.NoError(t, users.RevokeUser(ctx, "carol@example.com"))
+
+	created, err := svc.CreateUser(ctx, api.CreateUserRequest{
+		Email: "ci@example.com", Role: "developer", ServiceAccount: true, Owner: "alice@example.com",
+	}, "admin@example.com")
+	require.NoError(t, err)
+	assert.True(t, created.User.ServiceAccount)
+	assert.Equal(t, "alice@example.com", created.User.Owner)
+
+	tests := []struct {
+		name string
+		req  api.CreateUserRequest
+	}{
+		{"owner required", api.CreateUserRequest{Email: "a@example.com", Role: "viewer", ServiceAccount: true}},
+		{"owner without service account", api.CreateUserRequest{
+			Email: "b@example.com", Role: "viewer", Owner: "alice@example.com"}},
+		{"missing owner", api.CreateUserRequest{
+			Email: "c@example.com", Role: "viewer", ServiceAccount: true, Owner: "missing@example.com"}},
+		{"revoked owner", api.CreateUserRequest{
+			Email: "d@example.com", Role: "viewer", ServiceAccount: true, Owner: "carol@example.com"}},
+		{"service account owner", api.CreateUserRequest{
+			Email: "e@example.com", Role: "viewer", ServiceAccount: true, Owner: "ci@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, createErr := svc.CreateUser(ctx, tt.req, "admin@example.com")
+			assert.Equal(t, appErrors.ErrCodeInvalidRequest, appErrors.GetErrorCode(createErr))
+		})
+	}
+
+	transferred, err := svc.TransferOwnership(ctx, "ci@example.com",
+		&api.TransferOwnershipRequest{Owner: "bob@example.com"}, "admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", transferred.PreviousOwner)
+	stored, err := users.GetUserByEmail(ctx, "ci@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", stored.Owner)
+
+	_, err = svc.TransferOwnership(ctx, "ci@example.com",
+		&api.TransferOwnershipRequest{Owner: "bob@example.com"}, "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeConflict, appErrors.GetErrorCode(err))
+
+	_, err = svc.TransferOwnership(ctx, "ci@example.com",
+		&api.TransferOwnershipRequest{Owner: "carol@example.com"}, "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeInvalidRequest, appErrors.GetErrorCode(err))
+
+	_, err = svc.TransferOwnership(ctx, "alice@example.com",
+		&api.TransferOwnershipRequest{Owner: "bob@example.com"}, "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeInvalidRequest, appErrors.GetErrorCode(err))
+
+	_, err = svc.TransferOwnership(ctx, "missing@example.com",
+		&api.TransferOwnershipRequest{Owner: "bob@example.com"}, "admin@example.com")
+	assert.Equal(t, appErrors.ErrCodeNotFound, appErrors.GetErrorCode(err))
+}
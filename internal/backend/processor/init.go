@@ -41,6 +41,7 @@ func WithProviderInitializer(initializer ProviderInitializer) InitializeOption {
 //
 // Supported cloud providers:
 //   - "aws": Uses CloudWatch events for ECS task state changes and API Gateway for WebSocket events
+//   - "fake": Processes nothing, the in-memory backend records its own events, in builds with the fake tag
 //   - "gcp": (future) Google Cloud Pub/Sub and Cloud Tasks for event processing
 func Initialize(
 	ctx context.Context,
@@ -87,6 +88,11 @@ func selectProviderInitializer(
 	switch provider {
 	case constants.AWS:
 		return awsProviderInitializer, nil
+	case constants.Fake:
+		if fakeProviderInitializer == nil {
+			return nil, fmt.Errorf("the %s backend provider requires a build with the fake tag", provider)
+		}
+		return fakeProviderInitializer, nil
 	default:
 		return nil, fmt.Errorf("unknown backend provider: %s (supported: %s)", provider, constants.AWS)
 	}
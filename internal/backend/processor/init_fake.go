@@ -0,0 +1,22 @@
+//go:build fake
+
+package processor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+)
+
+// fakeProviderInitializer returns the processor of the in-memory backend, only in builds with the fake tag.
+var fakeProviderInitializer ProviderInitializer = func(
+	context.Context,
+	*config.Config,
+	*slog.Logger,
+	*authorization.Enforcer,
+) (Processor, error) {
+	return fake.Processor{}, nil
+}
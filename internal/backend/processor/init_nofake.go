@@ -0,0 +1,6 @@
+//go:build !fake
+
+package processor
+
+// fakeProviderInitializer is nil without the fake tag, keeping the in-memory backend out of the services.
+var fakeProviderInitializer ProviderInitializer
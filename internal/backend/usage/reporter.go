@@ -0,0 +1,98 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// reportPeriod is the period covered by the reports, which are sent every week.
+const reportPeriod = 7 * 24 * time.Hour
+
+// Reporter emails the weekly usage report to the admins, or to the recipients set in the notification settings.
+type Reporter struct {
+	executions database.ExecutionRepository
+	users      database.UserRepository
+	config     database.ConfigRepository
+	sender     contract.EmailSender
+	logger     *slog.Logger
+}
+
+// NewReporter creates a Reporter sending the reports with sender.
+func NewReporter(
+	executions database.ExecutionRepository,
+	users database.UserRepository,
+	config database.ConfigRepository,
+	sender contract.EmailSender,
+	log *slog.Logger,
+) *Reporter {
+	return &Reporter{
+		executions: executions,
+		users:      users,
+		config:     config,
+		sender:     sender,
+		logger:     log,
+	}
+}
+
+// SendWeeklyReport emails the report of the executions started in the 7 days before the start of the day of now,
+// in UTC, and returns the recipients it was sent to. No report is sent when the admins opted out of it.
+func (r *Reporter) SendWeeklyReport(ctx context.Context, now time.Time) ([]string, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	settings, err := r.config.GetNotificationSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	if settings.UsageReport.Disabled {
+		reqLogger.Info("usage report disabled in the notification settings, skipping it")
+		return nil, nil
+	}
+	recipients := settings.UsageReport.Recipients
+	if len(recipients) == 0 {
+		if recipients, err = r.adminEmails(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(recipients) == 0 {
+		reqLogger.Warn("no recipients for the usage report, skipping it")
+		return nil, nil
+	}
+
+	end := now.UTC().Truncate(24 * time.Hour)
+	start := end.Add(-reportPeriod)
+	executions, err := r.executions.ListExecutions(ctx, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	report := Compile(executions, start, end)
+
+	subject, body := FormatEmail(report)
+	if err = r.sender.SendEmail(ctx, recipients, subject, body); err != nil {
+		return nil, fmt.Errorf("failed to send usage report: %w", err)
+	}
+	return recipients, nil
+}
+
+// adminEmails returns the emails of the admins whose access is not revoked, sorted.
+func (r *Reporter) adminEmails(ctx context.Context) ([]string, error) {
+	users, err := r.users.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	var emails []string
+	for _, user := range users {
+		if user.Role == string(authorization.RoleAdmin) && !user.Revoked {
+			emails = append(emails, user.Email)
+		}
+	}
+	slices.Sort(emails)
+	return emails, nil
+}
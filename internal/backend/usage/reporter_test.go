@@ -0,0 +1,84 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingEmailSender struct {
+	to      []string
+	subject string
+	body    string
+	err     error
+}
+
+func (s *recordingEmailSender) SendEmail(_ context.Context, to []string, subject, body string) error {
+	s.to, s.subject, s.body = to, subject, body
+	return s.err
+}
+
+func newTestReporter(t *testing.T, sender *recordingEmailSender) (*Reporter, *fake.ConfigRepository) {
+	t.Helper()
+	ctx := context.Background()
+	users := fake.NewUserRepository()
+	for _, user := range []*api.User{
+		{Email: "zoe@example.com", Role: "admin"},
+		{Email: "admin@example.com", Role: "admin"},
+		{Email: "revoked@example.com", Role: "admin", Revoked: true},
+		{Email: "dev@example.com", Role: "developer"},
+	} {
+		require.NoError(t, users.CreateUser(ctx, user, "hash-"+user.Email, 0))
+	}
+	executions := fake.NewExecutionRepository()
+	require.NoError(t, executions.CreateExecutions(ctx, usageExecutions(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC))))
+	config := fake.NewConfigRepository()
+	return NewReporter(executions, users, config, sender, testutil.SilentLogger()), config
+}
+
+func TestReporterSendWeeklyReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	sender := &recordingEmailSender{}
+	reporter, config := newTestReporter(t, sender)
+	recipients, err := reporter.SendWeeklyReport(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin@example.com", "zoe@example.com"}, recipients,
+		"the report goes to the admins whose access is not revoked by default")
+	assert.Equal(t, recipients, sender.to)
+	assert.Equal(t, "runvoy usage report, 2026-10-12 to 2026-10-18", sender.subject)
+	assert.Contains(t, sender.body, "alice@example.com")
+
+	require.NoError(t, config.PutNotificationSettings(ctx, &api.NotificationSettings{
+		UsageReport: api.UsageReportSettings{Recipients: []string{"finance@example.com"}},
+	}))
+	recipients, err = reporter.SendWeeklyReport(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"finance@example.com"}, recipients)
+
+	require.NoError(t, config.PutNotificationSettings(ctx, &api.NotificationSettings{
+		UsageReport: api.UsageReportSettings{Disabled: true},
+	}))
+	sender.to = nil
+	recipients, err = reporter.SendWeeklyReport(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, recipients)
+	assert.Nil(t, sender.to, "no report is sent when opted out")
+}
+
+func TestReporterSendWeeklyReportError(t *testing.T) {
+	reporter, _ := newTestReporter(t, &recordingEmailSender{err: errors.New("connection refused")})
+
+	_, err := reporter.SendWeeklyReport(context.Background(), time.Now())
+
+	assert.ErrorContains(t, err, "failed to send usage report: connection refused")
+}
@@ -0,0 +1,112 @@
+// Package usage estimates the compute cost of the executions and compiles the usage reports emailed to the
+// admins every week.
+package usage
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+const (
+	// FargateVCPUHourPriceUSD and FargateGBHourPriceUSD are the Fargate list prices in us-east-1, the cost of
+	// the executions is an estimate of their compute cost.
+	FargateVCPUHourPriceUSD = 0.04048
+	FargateGBHourPriceUSD   = 0.004445
+
+	cpuUnitsPerVCPU = 1024
+	mibPerGB        = 1024
+	secondsPerHour  = 3600
+
+	// reportDateLayout formats the days of the period of a report.
+	reportDateLayout = "2006-01-02"
+)
+
+// ExecutionCostUSD estimates the Fargate cost of an execution from the resources it reserved for its billed
+// duration. Executions still running have no resource summary yet and cost nothing until they complete.
+func ExecutionCostUSD(summary *api.ResourceSummary) float64 {
+	if summary == nil {
+		return 0
+	}
+	hours := float64(summary.BilledDurationSeconds) / secondsPerHour
+	vCPUs := summary.CPUReserved / cpuUnitsPerVCPU
+	memoryGB := summary.MemoryReservedMiB / mibPerGB
+	return hours * (vCPUs*FargateVCPUHourPriceUSD + memoryGB*FargateGBHourPriceUSD)
+}
+
+// Compile returns the usage report of the executions started from start until end, excluded, the users with
+// the most runs first.
+func Compile(executions []*api.Execution, start, end time.Time) *api.UsageReport {
+	byUser := map[string]*api.UserUsage{}
+	report := &api.UsageReport{PeriodStart: start, PeriodEnd: end}
+	for _, execution := range executions {
+		if execution.StartedAt.Before(start) || !execution.StartedAt.Before(end) {
+			continue
+		}
+		usage, ok := byUser[execution.CreatedBy]
+		if !ok {
+			usage = &api.UserUsage{UserEmail: execution.CreatedBy}
+			byUser[execution.CreatedBy] = usage
+		}
+		for _, counted := range []*api.UserUsage{usage, &report.Total} {
+			counted.Runs++
+			if constants.ExecutionStatus(execution.Status).IsFinal() {
+				counted.Completed++
+			}
+			if execution.Status == string(constants.ExecutionSucceeded) {
+				counted.Succeeded++
+			}
+			counted.CostUSD += ExecutionCostUSD(execution.ResourceSummary)
+		}
+	}
+
+	report.Users = make([]api.UserUsage, 0, len(byUser))
+	for _, usage := range byUser {
+		report.Users = append(report.Users, *usage)
+	}
+	slices.SortFunc(report.Users, func(a, b api.UserUsage) int {
+		return cmp.Or(cmp.Compare(b.Runs, a.Runs), strings.Compare(a.UserEmail, b.UserEmail))
+	})
+	return report
+}
+
+// FormatEmail returns the subject and the plain text body of the email of a usage report.
+func FormatEmail(report *api.UsageReport) (subject, body string) {
+	// The period ends at midnight, the last day of the period is the day before
+	period := fmt.Sprintf("%s to %s",
+		report.PeriodStart.UTC().Format(reportDateLayout),
+		report.PeriodEnd.UTC().Add(-time.Nanosecond).Format(reportDateLayout))
+	subject = fmt.Sprintf("%s usage report, %s", constants.ProjectName, period)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Executions started from %s (UTC).\n\n", period)
+	if len(report.Users) == 0 {
+		b.WriteString("No executions were started.\n")
+		return subject, b.String()
+	}
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "User\tRuns\tSuccess rate\tEstimated cost")
+	for i := range report.Users {
+		writeUsageRow(w, &report.Users[i], report.Users[i].UserEmail)
+	}
+	writeUsageRow(w, &report.Total, "Total")
+	_ = w.Flush()
+
+	b.WriteString("\nThe success rate is that of the completed runs, the cost an estimate of their compute cost.\n")
+	return subject, b.String()
+}
+
+func writeUsageRow(w *tabwriter.Writer, usage *api.UserUsage, label string) {
+	successRate := "-"
+	if usage.Completed > 0 {
+		successRate = fmt.Sprintf("%.0f%%", usage.SuccessRate())
+	}
+	fmt.Fprintf(w, "%s\t%d\t%s\t$%.2f\n", label, usage.Runs, successRate, usage.CostUSD)
+}
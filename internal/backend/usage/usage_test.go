@@ -0,0 +1,87 @@
+package usage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionCostUSD(t *testing.T) {
+	assert.Zero(t, ExecutionCostUSD(nil), "running executions cost nothing until they complete")
+	cost := ExecutionCostUSD(&api.ResourceSummary{
+		CPUReserved:           512,
+		MemoryReservedMiB:     2048,
+		BilledDurationSeconds: 7200,
+	})
+	assert.InDelta(t, 2*(0.5*FargateVCPUHourPriceUSD+2*FargateGBHourPriceUSD), cost, 1e-9)
+}
+
+// hourSummary reserves one vCPU and one GB for an hour.
+var hourSummary = &api.ResourceSummary{CPUReserved: 1024, MemoryReservedMiB: 1024, BilledDurationSeconds: 3600}
+
+func usageExecutions(start time.Time) []*api.Execution {
+	executions := []*api.Execution{
+		{CreatedBy: "bob@example.com", StartedAt: start, Status: string(constants.ExecutionSucceeded),
+			ResourceSummary: hourSummary},
+		{CreatedBy: "alice@example.com", StartedAt: start.Add(time.Hour), Status: string(constants.ExecutionSucceeded),
+			ResourceSummary: hourSummary},
+		{CreatedBy: "alice@example.com", StartedAt: start.Add(2 * time.Hour), Status: string(constants.ExecutionFailed),
+			ResourceSummary: hourSummary},
+		{CreatedBy: "alice@example.com", StartedAt: start.Add(3 * time.Hour), Status: string(constants.ExecutionRunning)},
+		// Outside of the period
+		{CreatedBy: "carol@example.com", StartedAt: start.Add(-time.Second), Status: string(constants.ExecutionSucceeded)},
+		{CreatedBy: "carol@example.com", StartedAt: start.Add(reportPeriod), Status: string(constants.ExecutionSucceeded)},
+	}
+	for i, execution := range executions {
+		execution.ExecutionID = fmt.Sprintf("exec-%d", i)
+	}
+	return executions
+}
+
+func TestCompile(t *testing.T) {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	end := start.Add(reportPeriod)
+	hourCost := FargateVCPUHourPriceUSD + FargateGBHourPriceUSD
+
+	report := Compile(usageExecutions(start), start, end)
+
+	assert.Equal(t, start, report.PeriodStart)
+	assert.Equal(t, end, report.PeriodEnd)
+	require.Len(t, report.Users, 2)
+	alice, bob := report.Users[0], report.Users[1]
+	assert.Equal(t, "alice@example.com", alice.UserEmail, "the users with the most runs come first")
+	assert.Equal(t, 3, alice.Runs)
+	assert.Equal(t, 2, alice.Completed)
+	assert.Equal(t, 1, alice.Succeeded)
+	assert.InDelta(t, 50, alice.SuccessRate(), 1e-9)
+	assert.InDelta(t, 2*hourCost, alice.CostUSD, 1e-9)
+	assert.Equal(t, "bob@example.com", bob.UserEmail)
+	assert.Equal(t, 1, bob.Runs)
+	assert.Equal(t, 4, report.Total.Runs)
+	assert.Equal(t, 3, report.Total.Completed)
+	assert.Equal(t, 2, report.Total.Succeeded)
+	assert.InDelta(t, 3*hourCost, report.Total.CostUSD, 1e-9)
+}
+
+func TestFormatEmail(t *testing.T) {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	end := start.Add(reportPeriod)
+
+	subject, body := FormatEmail(Compile(usageExecutions(start), start, end))
+
+	assert.Equal(t, "runvoy usage report, 2026-10-12 to 2026-10-18", subject)
+	assert.Contains(t, body, "Executions started from 2026-10-12 to 2026-10-18 (UTC).")
+	assert.Regexp(t, `(?m)^User\s+Runs\s+Success rate\s+Estimated cost$`, body)
+	assert.Regexp(t, `(?m)^alice@example\.com\s+3\s+50%\s+\$0\.09$`, body)
+	assert.Regexp(t, `(?m)^bob@example\.com\s+1\s+100%\s+\$0\.04$`, body)
+	assert.Regexp(t, `(?m)^Total\s+4\s+67%\s+\$0\.13$`, body)
+
+	_, body = FormatEmail(Compile(nil, start, end))
+	assert.Contains(t, body, "No executions were started.")
+}
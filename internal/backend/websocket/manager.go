@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/api"
 )
 
 // Manager exposes the subset of WebSocket manager functionality used by the event processor.
@@ -17,6 +19,9 @@ type Manager interface {
 	// and removes the connections.
 	NotifyExecutionCompletion(ctx context.Context, executionID *string) error
 
+	// NotifyExecutionStatus sends a status change message to all connected clients for an execution.
+	NotifyExecutionStatus(ctx context.Context, change *api.ExecutionStatusChange) error
+
 	// SendLogsToExecution flushes buffered log events to all connected clients for an execution.
 	SendLogsToExecution(ctx context.Context, executionID *string) error
 
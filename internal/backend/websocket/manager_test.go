@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/runvoy/runvoy/internal/api"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +45,10 @@ func (t *testManager) NotifyExecutionCompletion(_ context.Context, _ *string) er
 	return nil
 }
 
+func (t *testManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (t *testManager) SendLogsToExecution(_ context.Context, _ *string) error {
 	return nil
 }
@@ -0,0 +1,140 @@
+// Package chaos injects faults for resilience testing: latency and 5xx errors in the API and the
+// repositories, and dropped WebSocket messages, each with a configured probability. It is disabled
+// unless one of the RUNVOY_CHAOS_* environment variables sets a probability, never enable it in
+// production.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// ErrInjected is the cause of the errors injected in the repositories.
+var ErrInjected = errors.New("chaos: injected fault")
+
+type suppressedKey struct{}
+
+// Suppress returns a context whose repository calls are never disturbed, for the calls made while
+// starting a service, which would otherwise fail to start.
+func Suppress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, suppressedKey{}, true)
+}
+
+func suppressed(ctx context.Context) bool {
+	s, _ := ctx.Value(suppressedKey{}).(bool)
+	return s
+}
+
+// Config sets the probability, between 0 and 1, of each fault.
+type Config struct {
+	// Latency is added to the requests and repository calls picked with LatencyRate.
+	Latency     time.Duration `mapstructure:"latency" yaml:"latency,omitempty"`
+	LatencyRate float64       `mapstructure:"latency_rate" yaml:"latency_rate,omitempty"`
+	// ErrorRate is the probability of failing a request or repository call with a 503 error.
+	ErrorRate float64 `mapstructure:"error_rate" yaml:"error_rate,omitempty"`
+	// DropRate is the probability of silently dropping a WebSocket message.
+	DropRate float64 `mapstructure:"drop_rate" yaml:"drop_rate,omitempty"`
+}
+
+// Enabled reports whether any fault may be injected.
+func (c *Config) Enabled() bool {
+	return (c.LatencyRate > 0 && c.Latency > 0) || c.ErrorRate > 0 || c.DropRate > 0
+}
+
+// Validate checks the probabilities are between 0 and 1 and the latency is not negative.
+func (c *Config) Validate() error {
+	for name, rate := range map[string]float64{
+		"latency rate": c.LatencyRate,
+		"error rate":   c.ErrorRate,
+		"drop rate":    c.DropRate,
+	} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("chaos %s must be between 0 and 1, got %g", name, rate)
+		}
+	}
+	if c.Latency < 0 {
+		return fmt.Errorf("chaos latency cannot be negative, got %s", c.Latency)
+	}
+	return nil
+}
+
+// Injector decides which calls to disturb. A nil Injector never injects anything, so callers can
+// use the result of New without checking whether chaos is enabled.
+type Injector struct {
+	cfg Config
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New returns an Injector for the configuration, nil when no fault is enabled.
+func New(cfg Config) *Injector {
+	if !cfg.Enabled() {
+		return nil
+	}
+	//nolint:gosec // G404: fault injection does not need a cryptographically secure generator
+	return &Injector{cfg: cfg, rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+}
+
+// pick reports whether a call is picked for a fault of probability rate.
+func (i *Injector) pick(rate float64) bool {
+	if i == nil || rate <= 0 {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rand.Float64() < rate
+}
+
+// Delay waits for the configured latency when the call is picked, or until ctx is done.
+func (i *Injector) Delay(ctx context.Context) error {
+	if !i.pick(i.latencyRate()) {
+		return nil
+	}
+	timer := time.NewTimer(i.cfg.Latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("chaos: injected latency interrupted: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Fail reports whether the call is picked to fail.
+func (i *Injector) Fail() bool {
+	return i != nil && i.pick(i.cfg.ErrorRate)
+}
+
+// Drop reports whether the message is picked to be dropped.
+func (i *Injector) Drop() bool {
+	return i != nil && i.pick(i.cfg.DropRate)
+}
+
+// Inject delays the operation and fails it with a 503 error wrapping ErrInjected when picked,
+// unless ctx was returned by Suppress.
+func (i *Injector) Inject(ctx context.Context, operation string) error {
+	if i == nil || suppressed(ctx) {
+		return nil
+	}
+	if err := i.Delay(ctx); err != nil {
+		return err
+	}
+	if i.Fail() {
+		return apperrors.ErrDatabaseError("injected fault in "+operation, ErrInjected)
+	}
+	return nil
+}
+
+func (i *Injector) latencyRate() float64 {
+	if i == nil || i.cfg.Latency <= 0 {
+		return 0
+	}
+	return i.cfg.LatencyRate
+}
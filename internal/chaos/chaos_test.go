@@ -0,0 +1,152 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "zero value", cfg: Config{}},
+		{name: "all rates set", cfg: Config{Latency: time.Second, LatencyRate: 1, ErrorRate: 0.5, DropRate: 0.1}},
+		{name: "rate above one", cfg: Config{ErrorRate: 1.5}, wantErr: "chaos error rate must be between 0 and 1"},
+		{name: "negative rate", cfg: Config{DropRate: -0.1}, wantErr: "chaos drop rate must be between 0 and 1"},
+		{name: "negative latency", cfg: Config{Latency: -time.Second}, wantErr: "chaos latency cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(Config{}))
+	assert.Nil(t, New(Config{LatencyRate: 1}), "a latency rate without latency injects nothing")
+	assert.NotNil(t, New(Config{ErrorRate: 0.1}))
+	assert.NotNil(t, New(Config{Latency: time.Second, LatencyRate: 0.1}))
+}
+
+func TestNilInjector(t *testing.T) {
+	var inj *Injector
+
+	assert.NoError(t, inj.Delay(context.Background()))
+	assert.False(t, inj.Fail())
+	assert.False(t, inj.Drop())
+	assert.NoError(t, inj.Inject(context.Background(), "GetExecution"))
+}
+
+func TestInjector(t *testing.T) {
+	t.Run("fails with a service unavailable error", func(t *testing.T) {
+		err := New(Config{ErrorRate: 1}).Inject(context.Background(), "GetExecution")
+
+		require.ErrorIs(t, err, ErrInjected)
+		assert.Equal(t, http.StatusServiceUnavailable, apperrors.GetStatusCode(err))
+		assert.Contains(t, err.Error(), "GetExecution")
+	})
+
+	t.Run("spares suppressed contexts", func(t *testing.T) {
+		err := New(Config{ErrorRate: 1}).Inject(Suppress(context.Background()), "ListUsers")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("drops messages", func(t *testing.T) {
+		assert.True(t, New(Config{DropRate: 1}).Drop())
+		assert.False(t, New(Config{ErrorRate: 1}).Drop())
+	})
+
+	t.Run("delays calls", func(t *testing.T) {
+		inj := New(Config{Latency: 20 * time.Millisecond, LatencyRate: 1})
+
+		start := time.Now()
+		require.NoError(t, inj.Inject(context.Background(), "GetExecution"))
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("stops delaying when the context is done", func(t *testing.T) {
+		inj := New(Config{Latency: time.Hour, LatencyRate: 1})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, inj.Delay(ctx), context.Canceled)
+	})
+
+	t.Run("picks calls with the configured probability", func(t *testing.T) {
+		inj := New(Config{ErrorRate: 0.5})
+
+		failed := 0
+		for range 1000 {
+			if inj.Fail() {
+				failed++
+			}
+		}
+		assert.InDelta(t, 500, failed, 100)
+	})
+}
+
+// stubExecutionRepository returns an execution for any ID, its other methods are not implemented.
+type stubExecutionRepository struct {
+	database.ExecutionRepository
+}
+
+func (stubExecutionRepository) GetExecution(_ context.Context, executionID string) (*api.Execution, error) {
+	return &api.Execution{ExecutionID: executionID}, nil
+}
+
+type stubUserRepository struct {
+	database.UserRepository
+}
+
+func (stubUserRepository) ListUsers(context.Context) ([]*api.User, error) {
+	return nil, nil
+}
+
+func TestWrapRepositories(t *testing.T) {
+	ctx := context.Background()
+	executions := &stubExecutionRepository{}
+	repos := database.Repositories{Execution: executions, User: &stubUserRepository{}}
+
+	t.Run("returns the repositories as is when disabled", func(t *testing.T) {
+		wrapped := WrapRepositories(repos, nil)
+
+		assert.Same(t, executions, wrapped.Execution)
+	})
+
+	t.Run("injects faults in the calls", func(t *testing.T) {
+		wrapped := WrapRepositories(repos, New(Config{ErrorRate: 1}))
+
+		_, err := wrapped.Execution.GetExecution(ctx, "exec-1")
+		assert.ErrorIs(t, err, ErrInjected)
+		_, err = wrapped.User.ListUsers(ctx)
+		assert.ErrorIs(t, err, ErrInjected)
+		assert.Nil(t, wrapped.Connection, "missing repositories stay nil")
+	})
+
+	t.Run("calls the repository when not picked", func(t *testing.T) {
+		wrapped := WrapRepositories(repos, New(Config{DropRate: 1}))
+
+		execution, err := wrapped.Execution.GetExecution(ctx, "exec-1")
+		require.NoError(t, err)
+		assert.Equal(t, "exec-1", execution.ExecutionID)
+	})
+}
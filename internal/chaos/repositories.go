@@ -0,0 +1,703 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+)
+
+// WrapRepositories wraps each repository so its calls are delayed and failed as configured.
+// The image repository is left as is since it usually also implements the image registry.
+// It returns repos unchanged when inj is nil.
+func WrapRepositories(repos database.Repositories, inj *Injector) database.Repositories {
+	if inj == nil {
+		return repos
+	}
+	repos.User = WrapUserRepository(repos.User, inj)
+	repos.Execution = WrapExecutionRepository(repos.Execution, inj)
+	repos.Connection = WrapConnectionRepository(repos.Connection, inj)
+	repos.LogEvent = WrapLogEventRepository(repos.LogEvent, inj)
+	repos.Token = WrapTokenRepository(repos.Token, inj)
+	repos.Secrets = WrapSecretsRepository(repos.Secrets, inj)
+	repos.HealthReport = WrapHealthReportRepository(repos.HealthReport, inj)
+	repos.CommandPolicy = WrapCommandPolicyRepository(repos.CommandPolicy, inj)
+	repos.Config = WrapConfigRepository(repos.Config, inj)
+	repos.Lock = WrapLockRepository(repos.Lock, inj)
+	return repos
+}
+
+// WrapUserRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapUserRepository(repo database.UserRepository, inj *Injector) database.UserRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &userRepository{UserRepository: repo, inj: inj}
+}
+
+type userRepository struct {
+	database.UserRepository
+	inj *Injector
+}
+
+func (r *userRepository) CreateUser(
+	ctx context.Context, user *api.User, apiKeyHash string, expiresAtUnix int64,
+) error {
+	if err := r.inj.Inject(ctx, "CreateUser"); err != nil {
+		return err
+	}
+	return r.UserRepository.CreateUser(ctx, user, apiKeyHash, expiresAtUnix)
+}
+
+func (r *userRepository) RemoveExpiration(ctx context.Context, email string) error {
+	if err := r.inj.Inject(ctx, "RemoveExpiration"); err != nil {
+		return err
+	}
+	return r.UserRepository.RemoveExpiration(ctx, email)
+}
+
+func (r *userRepository) DeleteUser(ctx context.Context, email string) error {
+	if err := r.inj.Inject(ctx, "DeleteUser"); err != nil {
+		return err
+	}
+	return r.UserRepository.DeleteUser(ctx, email)
+}
+
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*api.User, error) {
+	if err := r.inj.Inject(ctx, "GetUserByEmail"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.GetUserByEmail(ctx, email)
+}
+
+func (r *userRepository) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash string) (*api.User, error) {
+	if err := r.inj.Inject(ctx, "GetUserByAPIKeyHash"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.GetUserByAPIKeyHash(ctx, apiKeyHash)
+}
+
+func (r *userRepository) UpdateLastUsed(ctx context.Context, email string) (*time.Time, error) {
+	if err := r.inj.Inject(ctx, "UpdateLastUsed"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.UpdateLastUsed(ctx, email)
+}
+
+func (r *userRepository) RevokeUser(ctx context.Context, email string) error {
+	if err := r.inj.Inject(ctx, "RevokeUser"); err != nil {
+		return err
+	}
+	return r.UserRepository.RevokeUser(ctx, email)
+}
+
+func (r *userRepository) UpdateUser(ctx context.Context, user *api.User) error {
+	if err := r.inj.Inject(ctx, "UpdateUser"); err != nil {
+		return err
+	}
+	return r.UserRepository.UpdateUser(ctx, user)
+}
+
+func (r *userRepository) UpdateUserPreferences(ctx context.Context, user *api.User) error {
+	if err := r.inj.Inject(ctx, "UpdateUserPreferences"); err != nil {
+		return err
+	}
+	return r.UserRepository.UpdateUserPreferences(ctx, user)
+}
+
+func (r *userRepository) ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error {
+	if err := r.inj.Inject(ctx, "ReplaceAPIKeyHash"); err != nil {
+		return err
+	}
+	return r.UserRepository.ReplaceAPIKeyHash(ctx, email, apiKeyHash)
+}
+
+func (r *userRepository) CreatePendingAPIKey(ctx context.Context, pending *api.PendingAPIKey) error {
+	if err := r.inj.Inject(ctx, "CreatePendingAPIKey"); err != nil {
+		return err
+	}
+	return r.UserRepository.CreatePendingAPIKey(ctx, pending)
+}
+
+func (r *userRepository) GetPendingAPIKey(ctx context.Context, secretToken string) (*api.PendingAPIKey, error) {
+	if err := r.inj.Inject(ctx, "GetPendingAPIKey"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.GetPendingAPIKey(ctx, secretToken)
+}
+
+func (r *userRepository) MarkAsViewed(ctx context.Context, secretToken, ipAddress string) error {
+	if err := r.inj.Inject(ctx, "MarkAsViewed"); err != nil {
+		return err
+	}
+	return r.UserRepository.MarkAsViewed(ctx, secretToken, ipAddress)
+}
+
+func (r *userRepository) DeletePendingAPIKey(ctx context.Context, secretToken string) error {
+	if err := r.inj.Inject(ctx, "DeletePendingAPIKey"); err != nil {
+		return err
+	}
+	return r.UserRepository.DeletePendingAPIKey(ctx, secretToken)
+}
+
+func (r *userRepository) ListPendingAPIKeys(ctx context.Context) ([]*api.PendingAPIKey, error) {
+	if err := r.inj.Inject(ctx, "ListPendingAPIKeys"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.ListPendingAPIKeys(ctx)
+}
+
+func (r *userRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
+	if err := r.inj.Inject(ctx, "ListUsers"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.ListUsers(ctx)
+}
+
+func (r *userRepository) GetUsersByRequestID(ctx context.Context, requestID string) ([]*api.User, error) {
+	if err := r.inj.Inject(ctx, "GetUsersByRequestID"); err != nil {
+		return nil, err
+	}
+	return r.UserRepository.GetUsersByRequestID(ctx, requestID)
+}
+
+// WrapExecutionRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapExecutionRepository(repo database.ExecutionRepository, inj *Injector) database.ExecutionRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &executionRepository{ExecutionRepository: repo, inj: inj}
+}
+
+type executionRepository struct {
+	database.ExecutionRepository
+	inj *Injector
+}
+
+func (r *executionRepository) CreateExecution(ctx context.Context, execution *api.Execution) error {
+	if err := r.inj.Inject(ctx, "CreateExecution"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.CreateExecution(ctx, execution)
+}
+
+func (r *executionRepository) CreateExecutions(ctx context.Context, executions []*api.Execution) error {
+	if err := r.inj.Inject(ctx, "CreateExecutions"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.CreateExecutions(ctx, executions)
+}
+
+func (r *executionRepository) GetExecution(ctx context.Context, executionID string) (*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "GetExecution"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.GetExecution(ctx, executionID)
+}
+
+func (r *executionRepository) UpdateExecution(ctx context.Context, execution *api.Execution) error {
+	if err := r.inj.Inject(ctx, "UpdateExecution"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.UpdateExecution(ctx, execution)
+}
+
+func (r *executionRepository) ListExecutions(
+	ctx context.Context, limit int, statuses []string,
+) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "ListExecutions"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.ListExecutions(ctx, limit, statuses)
+}
+
+func (r *executionRepository) SearchExecutions(
+	ctx context.Context, terms []string, limit int, statuses []string,
+) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "SearchExecutions"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.SearchExecutions(ctx, terms, limit, statuses)
+}
+
+func (r *executionRepository) ListExecutionsByCreator(
+	ctx context.Context, createdBy string, limit int, statuses []string,
+) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "ListExecutionsByCreator"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.ListExecutionsByCreator(ctx, createdBy, limit, statuses)
+}
+
+func (r *executionRepository) GetExecutionsByRequestID(
+	ctx context.Context, requestID string,
+) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "GetExecutionsByRequestID"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.GetExecutionsByRequestID(ctx, requestID)
+}
+
+func (r *executionRepository) GetExecutionsByGroupID(ctx context.Context, groupID string) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "GetExecutionsByGroupID"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.GetExecutionsByGroupID(ctx, groupID)
+}
+
+func (r *executionRepository) AddLogUsage(ctx context.Context, executionID string, usage *api.LogUsage) error {
+	if err := r.inj.Inject(ctx, "AddLogUsage"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.AddLogUsage(ctx, executionID, usage)
+}
+
+func (r *executionRepository) ListExecutionsWithExpiredLogs(
+	ctx context.Context, before time.Time, limit int,
+) ([]*api.Execution, error) {
+	if err := r.inj.Inject(ctx, "ListExecutionsWithExpiredLogs"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.ListExecutionsWithExpiredLogs(ctx, before, limit)
+}
+
+func (r *executionRepository) ClearExecutionLogsExpiry(ctx context.Context, executionID string) error {
+	if err := r.inj.Inject(ctx, "ClearExecutionLogsExpiry"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.ClearExecutionLogsExpiry(ctx, executionID)
+}
+
+func (r *executionRepository) MarkExecutionSLOBreached(ctx context.Context, executionID string) error {
+	if err := r.inj.Inject(ctx, "MarkExecutionSLOBreached"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.MarkExecutionSLOBreached(ctx, executionID)
+}
+
+func (r *executionRepository) SetExecutionResult(
+	ctx context.Context, executionID string, result json.RawMessage,
+) error {
+	if err := r.inj.Inject(ctx, "SetExecutionResult"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.SetExecutionResult(ctx, executionID, result)
+}
+
+func (r *executionRepository) AddExecutionEvents(
+	ctx context.Context, executionID string, events []api.ExecutionEvent,
+) error {
+	if err := r.inj.Inject(ctx, "AddExecutionEvents"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.AddExecutionEvents(ctx, executionID, events)
+}
+
+func (r *executionRepository) ListExecutionEvents(
+	ctx context.Context, executionID string,
+) ([]api.ExecutionEvent, error) {
+	if err := r.inj.Inject(ctx, "ListExecutionEvents"); err != nil {
+		return nil, err
+	}
+	return r.ExecutionRepository.ListExecutionEvents(ctx, executionID)
+}
+
+func (r *executionRepository) AddExecutionAnnotation(
+	ctx context.Context, executionID string, annotation *api.ExecutionAnnotation,
+) error {
+	if err := r.inj.Inject(ctx, "AddExecutionAnnotation"); err != nil {
+		return err
+	}
+	return r.ExecutionRepository.AddExecutionAnnotation(ctx, executionID, annotation)
+}
+
+// WrapConnectionRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapConnectionRepository(repo database.ConnectionRepository, inj *Injector) database.ConnectionRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &connectionRepository{ConnectionRepository: repo, inj: inj}
+}
+
+type connectionRepository struct {
+	database.ConnectionRepository
+	inj *Injector
+}
+
+func (r *connectionRepository) CreateConnection(ctx context.Context, connection *api.WebSocketConnection) error {
+	if err := r.inj.Inject(ctx, "CreateConnection"); err != nil {
+		return err
+	}
+	return r.ConnectionRepository.CreateConnection(ctx, connection)
+}
+
+func (r *connectionRepository) DeleteConnections(ctx context.Context, connectionIDs []string) (int, error) {
+	if err := r.inj.Inject(ctx, "DeleteConnections"); err != nil {
+		return 0, err
+	}
+	return r.ConnectionRepository.DeleteConnections(ctx, connectionIDs)
+}
+
+func (r *connectionRepository) GetConnectionsByExecutionID(
+	ctx context.Context, executionID string,
+) ([]*api.WebSocketConnection, error) {
+	if err := r.inj.Inject(ctx, "GetConnectionsByExecutionID"); err != nil {
+		return nil, err
+	}
+	return r.ConnectionRepository.GetConnectionsByExecutionID(ctx, executionID)
+}
+
+func (r *connectionRepository) UpdateLastEventID(
+	ctx context.Context, connectionID, lastEventID string, lastSequence int64,
+) error {
+	if err := r.inj.Inject(ctx, "UpdateLastEventID"); err != nil {
+		return err
+	}
+	return r.ConnectionRepository.UpdateLastEventID(ctx, connectionID, lastEventID, lastSequence)
+}
+
+// WrapLogEventRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapLogEventRepository(repo database.LogEventRepository, inj *Injector) database.LogEventRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &logEventRepository{LogEventRepository: repo, inj: inj}
+}
+
+type logEventRepository struct {
+	database.LogEventRepository
+	inj *Injector
+}
+
+func (r *logEventRepository) SaveLogEvents(ctx context.Context, executionID string, logEvents []api.LogEvent) error {
+	if err := r.inj.Inject(ctx, "SaveLogEvents"); err != nil {
+		return err
+	}
+	return r.LogEventRepository.SaveLogEvents(ctx, executionID, logEvents)
+}
+
+func (r *logEventRepository) ListLogEvents(ctx context.Context, executionID string) ([]api.LogEvent, error) {
+	if err := r.inj.Inject(ctx, "ListLogEvents"); err != nil {
+		return nil, err
+	}
+	return r.LogEventRepository.ListLogEvents(ctx, executionID)
+}
+
+func (r *logEventRepository) DeleteLogEvents(ctx context.Context, executionID string) error {
+	if err := r.inj.Inject(ctx, "DeleteLogEvents"); err != nil {
+		return err
+	}
+	return r.LogEventRepository.DeleteLogEvents(ctx, executionID)
+}
+
+// WrapTokenRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapTokenRepository(repo database.TokenRepository, inj *Injector) database.TokenRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &tokenRepository{TokenRepository: repo, inj: inj}
+}
+
+type tokenRepository struct {
+	database.TokenRepository
+	inj *Injector
+}
+
+func (r *tokenRepository) CreateToken(ctx context.Context, token *api.WebSocketToken) error {
+	if err := r.inj.Inject(ctx, "CreateToken"); err != nil {
+		return err
+	}
+	return r.TokenRepository.CreateToken(ctx, token)
+}
+
+func (r *tokenRepository) ClaimToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error) {
+	if err := r.inj.Inject(ctx, "ClaimToken"); err != nil {
+		return nil, err
+	}
+	return r.TokenRepository.ClaimToken(ctx, tokenValue)
+}
+
+func (r *tokenRepository) DeleteToken(ctx context.Context, tokenValue string) error {
+	if err := r.inj.Inject(ctx, "DeleteToken"); err != nil {
+		return err
+	}
+	return r.TokenRepository.DeleteToken(ctx, tokenValue)
+}
+
+func (r *tokenRepository) DeleteTokensByExecutionID(ctx context.Context, executionID string) (int, error) {
+	if err := r.inj.Inject(ctx, "DeleteTokensByExecutionID"); err != nil {
+		return 0, err
+	}
+	return r.TokenRepository.DeleteTokensByExecutionID(ctx, executionID)
+}
+
+func (r *tokenRepository) CreateRunToken(ctx context.Context, token *api.RunToken) error {
+	if err := r.inj.Inject(ctx, "CreateRunToken"); err != nil {
+		return err
+	}
+	return r.TokenRepository.CreateRunToken(ctx, token)
+}
+
+func (r *tokenRepository) GetRunToken(ctx context.Context, tokenHash string) (*api.RunToken, error) {
+	if err := r.inj.Inject(ctx, "GetRunToken"); err != nil {
+		return nil, err
+	}
+	return r.TokenRepository.GetRunToken(ctx, tokenHash)
+}
+
+// WrapSecretsRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapSecretsRepository(repo database.SecretsRepository, inj *Injector) database.SecretsRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &secretsRepository{SecretsRepository: repo, inj: inj}
+}
+
+type secretsRepository struct {
+	database.SecretsRepository
+	inj *Injector
+}
+
+func (r *secretsRepository) CreateSecret(ctx context.Context, secret *api.Secret) error {
+	if err := r.inj.Inject(ctx, "CreateSecret"); err != nil {
+		return err
+	}
+	return r.SecretsRepository.CreateSecret(ctx, secret)
+}
+
+func (r *secretsRepository) GetSecret(ctx context.Context, name string, includeValue bool) (*api.Secret, error) {
+	if err := r.inj.Inject(ctx, "GetSecret"); err != nil {
+		return nil, err
+	}
+	return r.SecretsRepository.GetSecret(ctx, name, includeValue)
+}
+
+func (r *secretsRepository) ListSecrets(ctx context.Context, includeValue bool) ([]*api.Secret, error) {
+	if err := r.inj.Inject(ctx, "ListSecrets"); err != nil {
+		return nil, err
+	}
+	return r.SecretsRepository.ListSecrets(ctx, includeValue)
+}
+
+func (r *secretsRepository) UpdateSecret(ctx context.Context, secret *api.Secret) error {
+	if err := r.inj.Inject(ctx, "UpdateSecret"); err != nil {
+		return err
+	}
+	return r.SecretsRepository.UpdateSecret(ctx, secret)
+}
+
+func (r *secretsRepository) DeleteSecret(ctx context.Context, name string) error {
+	if err := r.inj.Inject(ctx, "DeleteSecret"); err != nil {
+		return err
+	}
+	return r.SecretsRepository.DeleteSecret(ctx, name)
+}
+
+func (r *secretsRepository) GetSecretsByRequestID(ctx context.Context, requestID string) ([]*api.Secret, error) {
+	if err := r.inj.Inject(ctx, "GetSecretsByRequestID"); err != nil {
+		return nil, err
+	}
+	return r.SecretsRepository.GetSecretsByRequestID(ctx, requestID)
+}
+
+func (r *secretsRepository) RecordSecretUsage(
+	ctx context.Context, name, executionID string, usedAt time.Time,
+) error {
+	if err := r.inj.Inject(ctx, "RecordSecretUsage"); err != nil {
+		return err
+	}
+	return r.SecretsRepository.RecordSecretUsage(ctx, name, executionID, usedAt)
+}
+
+// WrapHealthReportRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapHealthReportRepository(
+	repo database.HealthReportRepository, inj *Injector,
+) database.HealthReportRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &healthReportRepository{HealthReportRepository: repo, inj: inj}
+}
+
+type healthReportRepository struct {
+	database.HealthReportRepository
+	inj *Injector
+}
+
+func (r *healthReportRepository) CreateHealthReport(ctx context.Context, report *api.HealthReport) error {
+	if err := r.inj.Inject(ctx, "CreateHealthReport"); err != nil {
+		return err
+	}
+	return r.HealthReportRepository.CreateHealthReport(ctx, report)
+}
+
+func (r *healthReportRepository) ListHealthReports(ctx context.Context, limit int) ([]api.HealthReport, error) {
+	if err := r.inj.Inject(ctx, "ListHealthReports"); err != nil {
+		return nil, err
+	}
+	return r.HealthReportRepository.ListHealthReports(ctx, limit)
+}
+
+// WrapCommandPolicyRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapCommandPolicyRepository(
+	repo database.CommandPolicyRepository, inj *Injector,
+) database.CommandPolicyRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &commandPolicyRepository{CommandPolicyRepository: repo, inj: inj}
+}
+
+type commandPolicyRepository struct {
+	database.CommandPolicyRepository
+	inj *Injector
+}
+
+func (r *commandPolicyRepository) PutCommandPolicyRule(ctx context.Context, rule *api.CommandPolicyRule) error {
+	if err := r.inj.Inject(ctx, "PutCommandPolicyRule"); err != nil {
+		return err
+	}
+	return r.CommandPolicyRepository.PutCommandPolicyRule(ctx, rule)
+}
+
+func (r *commandPolicyRepository) GetCommandPolicyRule(
+	ctx context.Context, name string,
+) (*api.CommandPolicyRule, error) {
+	if err := r.inj.Inject(ctx, "GetCommandPolicyRule"); err != nil {
+		return nil, err
+	}
+	return r.CommandPolicyRepository.GetCommandPolicyRule(ctx, name)
+}
+
+func (r *commandPolicyRepository) ListCommandPolicyRules(ctx context.Context) ([]api.CommandPolicyRule, error) {
+	if err := r.inj.Inject(ctx, "ListCommandPolicyRules"); err != nil {
+		return nil, err
+	}
+	return r.CommandPolicyRepository.ListCommandPolicyRules(ctx)
+}
+
+func (r *commandPolicyRepository) DeleteCommandPolicyRule(ctx context.Context, name string) error {
+	if err := r.inj.Inject(ctx, "DeleteCommandPolicyRule"); err != nil {
+		return err
+	}
+	return r.CommandPolicyRepository.DeleteCommandPolicyRule(ctx, name)
+}
+
+// WrapConfigRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapConfigRepository(repo database.ConfigRepository, inj *Injector) database.ConfigRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &configRepository{ConfigRepository: repo, inj: inj}
+}
+
+type configRepository struct {
+	database.ConfigRepository
+	inj *Injector
+}
+
+func (r *configRepository) GetRunFreeze(ctx context.Context) (*api.RunFreeze, error) {
+	if err := r.inj.Inject(ctx, "GetRunFreeze"); err != nil {
+		return nil, err
+	}
+	return r.ConfigRepository.GetRunFreeze(ctx)
+}
+
+func (r *configRepository) PutRunFreeze(ctx context.Context, freeze *api.RunFreeze) error {
+	if err := r.inj.Inject(ctx, "PutRunFreeze"); err != nil {
+		return err
+	}
+	return r.ConfigRepository.PutRunFreeze(ctx, freeze)
+}
+
+func (r *configRepository) GetAnnouncement(ctx context.Context) (*api.Announcement, error) {
+	if err := r.inj.Inject(ctx, "GetAnnouncement"); err != nil {
+		return nil, err
+	}
+	return r.ConfigRepository.GetAnnouncement(ctx)
+}
+
+func (r *configRepository) PutAnnouncement(ctx context.Context, announcement *api.Announcement) error {
+	if err := r.inj.Inject(ctx, "PutAnnouncement"); err != nil {
+		return err
+	}
+	return r.ConfigRepository.PutAnnouncement(ctx, announcement)
+}
+
+func (r *configRepository) GetBudgets(ctx context.Context) (map[string]api.Budget, error) {
+	if err := r.inj.Inject(ctx, "GetBudgets"); err != nil {
+		return nil, err
+	}
+	return r.ConfigRepository.GetBudgets(ctx)
+}
+
+func (r *configRepository) PutBudgets(ctx context.Context, budgets map[string]api.Budget) error {
+	if err := r.inj.Inject(ctx, "PutBudgets"); err != nil {
+		return err
+	}
+	return r.ConfigRepository.PutBudgets(ctx, budgets)
+}
+
+func (r *configRepository) GetNotificationSettings(ctx context.Context) (*api.NotificationSettings, error) {
+	if err := r.inj.Inject(ctx, "GetNotificationSettings"); err != nil {
+		return nil, err
+	}
+	return r.ConfigRepository.GetNotificationSettings(ctx)
+}
+
+func (r *configRepository) PutNotificationSettings(ctx context.Context, settings *api.NotificationSettings) error {
+	if err := r.inj.Inject(ctx, "PutNotificationSettings"); err != nil {
+		return err
+	}
+	return r.ConfigRepository.PutNotificationSettings(ctx, settings)
+}
+
+// WrapLockRepository returns repo with faults injected, or repo itself when either is nil.
+func WrapLockRepository(repo database.LockRepository, inj *Injector) database.LockRepository {
+	if repo == nil || inj == nil {
+		return repo
+	}
+	return &lockRepository{LockRepository: repo, inj: inj}
+}
+
+type lockRepository struct {
+	database.LockRepository
+	inj *Injector
+}
+
+func (r *lockRepository) AcquireLock(ctx context.Context, lock *api.ExecutionLock, now time.Time) (bool, error) {
+	if err := r.inj.Inject(ctx, "AcquireLock"); err != nil {
+		return false, err
+	}
+	return r.LockRepository.AcquireLock(ctx, lock, now)
+}
+
+func (r *lockRepository) GetLock(ctx context.Context, name string) (*api.ExecutionLock, error) {
+	if err := r.inj.Inject(ctx, "GetLock"); err != nil {
+		return nil, err
+	}
+	return r.LockRepository.GetLock(ctx, name)
+}
+
+func (r *lockRepository) ListLocks(ctx context.Context, now time.Time) ([]api.ExecutionLock, error) {
+	if err := r.inj.Inject(ctx, "ListLocks"); err != nil {
+		return nil, err
+	}
+	return r.LockRepository.ListLocks(ctx, now)
+}
+
+func (r *lockRepository) SetLockExecution(ctx context.Context, name, token, executionID string) error {
+	if err := r.inj.Inject(ctx, "SetLockExecution"); err != nil {
+		return err
+	}
+	return r.LockRepository.SetLockExecution(ctx, name, token, executionID)
+}
+
+func (r *lockRepository) ReleaseLock(ctx context.Context, name, holder string) error {
+	if err := r.inj.Inject(ctx, "ReleaseLock"); err != nil {
+		return err
+	}
+	return r.LockRepository.ReleaseLock(ctx, name, holder)
+}
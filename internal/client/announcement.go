@@ -0,0 +1,29 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+var (
+	announcementMu sync.Mutex
+	// lastAnnouncement is the announcement of the last API response received by the clients of the process.
+	lastAnnouncement string
+)
+
+// recordAnnouncement keeps the announcement sent with an API response, empty when there is none.
+func recordAnnouncement(header http.Header) {
+	announcementMu.Lock()
+	defer announcementMu.Unlock()
+	lastAnnouncement = header.Get(constants.AnnouncementHeader)
+}
+
+// LastAnnouncement returns the announcement sent with the last API response received by the clients of the
+// process, empty when there is none or no request was made.
+func LastAnnouncement() string {
+	announcementMu.Lock()
+	defer announcementMu.Unlock()
+	return lastAnnouncement
+}
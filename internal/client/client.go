@@ -1,9 +1,11 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -11,24 +13,30 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/logger"
+	"github.com/runvoy/runvoy/internal/validation"
 )
 
 // Client provides a generic HTTP client for API operations.
 type Client struct {
-	config *config.Config
-	logger *slog.Logger
+	config     *config.Config
+	logger     *slog.Logger
+	httpClient *http.Client
 }
 
 // New creates a new API client.
+// The clients of a process share their transport, reusing their connections to the API.
 func New(cfg *config.Config, log *slog.Logger) *Client {
 	return &Client{
-		config: cfg,
-		logger: log,
+		config:     cfg,
+		logger:     log,
+		httpClient: &http.Client{Transport: sharedTransport(cfg.HTTPClient.GetDNSCacheTTL())},
 	}
 }
 
@@ -37,12 +45,27 @@ type Request struct {
 	Method string
 	Path   string
 	Body   any
+	Class  OperationClass // Selects the timeout of the call, OperationShort by default
+}
+
+// withTimeout derives the context of a call of the operation class, limited by its timeout.
+func (c *Client) withTimeout(ctx context.Context, class OperationClass) (context.Context, context.CancelFunc) {
+	switch class {
+	case OperationStream:
+		return context.WithCancel(ctx)
+	case OperationLong:
+		return context.WithTimeout(ctx, c.config.HTTPClient.GetLongTimeout())
+	default:
+		return context.WithTimeout(ctx, c.config.HTTPClient.GetShortTimeout())
+	}
 }
 
 // Response represents an API response.
 type Response struct {
 	StatusCode int
 	Body       []byte
+	// RequestID is the request ID the orchestrator echoed, empty when it echoed none.
+	RequestID string
 }
 
 // buildURL constructs the full API URL from path and query string.
@@ -69,19 +92,27 @@ func (c *Client) buildURL(path string) (string, error) {
 	return apiURL, nil
 }
 
-// prepareRequestBody prepares the request body as an io.Reader if Body is provided.
-func (c *Client) prepareRequestBody(body any) (io.Reader, error) {
+// prepareRequestBody prepares the request body as an io.Reader if Body is provided, along with its content
+// encoding, empty unless it is compressed. The size limit applies to the uncompressed body, as in the backend.
+func (c *Client) prepareRequestBody(body any) (io.Reader, string, error) {
 	if body == nil {
-		return nil, nil
+		return nil, "", nil
 	}
 	jsonData, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
 	}
-	return bytes.NewBuffer(jsonData), nil
+	if len(jsonData) > constants.MaxRequestBodySize {
+		return nil, "", fmt.Errorf(
+			"request body is %d bytes, the maximum is %d", len(jsonData), constants.MaxRequestBodySize)
+	}
+	jsonData, encoding := compressRequestBody(jsonData)
+	return bytes.NewBuffer(jsonData), encoding, nil
 }
 
 // createHTTPRequest creates an http.Request with headers set.
+// The request carries the request ID of the context, or a new one, so that `runvoy trace` can find
+// everything it caused in the backend.
 func (c *Client) createHTTPRequest(
 	ctx context.Context, method, apiURL string, bodyReader io.Reader,
 ) (*http.Request, error) {
@@ -89,17 +120,25 @@ func (c *Client) createHTTPRequest(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	requestID := logger.GetRequestID(ctx)
+	if requestID == "" {
+		requestID = auth.GenerateUUID()
+	}
 	httpReq.Header.Set(constants.ContentTypeHeader, "application/json")
 	httpReq.Header.Set(constants.APIKeyHeader, c.config.APIKey)
+	httpReq.Header.Set(constants.RequestIDHeader, requestID)
+	httpReq.Header.Set(constants.ClientVersionHeader, clientVersion)
 	return httpReq, nil
 }
 
 // logRequest logs the outgoing HTTP request with relevant details.
-func (c *Client) logRequest(ctx context.Context, reqLogger *slog.Logger, method, apiURL string, body any) {
+func (c *Client) logRequest(ctx context.Context, reqLogger *slog.Logger, httpReq *http.Request, body any) {
+	method, apiURL, requestID := httpReq.Method, httpReq.URL.String(), httpReq.Header.Get(constants.RequestIDHeader)
 	logArgs := []any{
 		"operation", "HTTP.Request",
 		"method", method,
 		"url", apiURL,
+		"request_id", requestID,
 	}
 	if body != nil {
 		bodyBytes, _ := json.Marshal(body)
@@ -115,7 +154,14 @@ func (c *Client) logRequest(ctx context.Context, reqLogger *slog.Logger, method,
 func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, c.logger)
 
-	bodyReader, err := c.prepareRequestBody(req.Body)
+	// Once the backend is known to be incompatible, the requests changing anything are not sent
+	if req.Method != http.MethodGet {
+		if err := versionSkewError(); err != nil {
+			return nil, err
+		}
+	}
+
+	bodyReader, contentEncoding, err := c.prepareRequestBody(req.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -125,15 +171,20 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 		return nil, fmt.Errorf("invalid API endpoint: %w", err)
 	}
 
+	ctx, cancel := c.withTimeout(ctx, req.Class)
+	defer cancel()
+
 	httpReq, err := c.createHTTPRequest(ctx, req.Method, apiURL, bodyReader)
 	if err != nil {
 		return nil, err
 	}
+	if contentEncoding != "" {
+		httpReq.Header.Set(constants.ContentEncodingHeader, contentEncoding)
+	}
 
-	c.logRequest(ctx, reqLogger, req.Method, apiURL, req.Body)
+	c.logRequest(ctx, reqLogger, httpReq, req.Body)
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(httpReq)
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -146,15 +197,27 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	requestID := resp.Header.Get(constants.RequestIDHeader)
 	reqLogger.Debug("received HTTP response",
 		"status", resp.StatusCode,
 		"bodySize", len(body),
 		"method", req.Method,
-		"url", apiURL)
+		"url", apiURL,
+		"request_id", requestID)
+	if resp.Header.Get(constants.DeprecationHeader) != "" {
+		reqLogger.Debug("API route is deprecated",
+			"url", apiURL,
+			"sunset", resp.Header.Get(constants.SunsetHeader),
+			"successor", resp.Header.Get("Link"))
+	}
+	recordAnnouncement(resp.Header)
+	recordVersions(resp.Header)
+	recordRequestEncodings(resp.Header)
 
 	return &Response{
 		StatusCode: resp.StatusCode,
 		Body:       body,
+		RequestID:  requestID,
 	}, nil
 }
 
@@ -168,11 +231,12 @@ func (c *Client) DoJSON(ctx context.Context, req Request, result any) error {
 	}
 
 	if resp.StatusCode >= constants.HTTPStatusBadRequest {
-		var errorResp api.ErrorResponse
-		if err = json.Unmarshal(resp.Body, &errorResp); err != nil {
-			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(resp.Body))
+		apiErr := withTraceHint(parseErrorResponse(resp.StatusCode, resp.Body), resp.RequestID)
+		// The errors of an incompatible backend are explained by the versions rather than by its response
+		if skewErr := versionSkewError(); skewErr != nil {
+			return fmt.Errorf("%w (the backend answered: %w)", skewErr, apiErr)
 		}
-		return fmt.Errorf("[%d] %s: %s", resp.StatusCode, errorResp.Error, errorResp.Details)
+		return apiErr
 	}
 
 	if resp.StatusCode == http.StatusNoContent {
@@ -180,7 +244,7 @@ func (c *Client) DoJSON(ctx context.Context, req Request, result any) error {
 	}
 
 	if err = json.Unmarshal(resp.Body, result); err != nil {
-		reqLogger.Debug("response body", "body", string(resp.Body))
+		reqLogger.Debug("response body", "body", logger.ScrubJSON(resp.Body))
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -231,12 +295,12 @@ func (c *Client) ListUsers(ctx context.Context) (*api.ListUsersResponse, error)
 	return &resp, nil
 }
 
-// GetHealth checks the API health status.
-func (c *Client) GetHealth(ctx context.Context) (*api.HealthResponse, error) {
-	var resp api.HealthResponse
+// ListPendingUsers lists the invited users who have not claimed their API key yet.
+func (c *Client) ListPendingUsers(ctx context.Context) (*api.ListUsersResponse, error) {
+	var resp api.ListUsersResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   "/api/v1/health",
+		Path:   "/api/v1/users/pending",
 	}, &resp)
 	if err != nil {
 		return nil, err
@@ -245,54 +309,65 @@ func (c *Client) GetHealth(ctx context.Context) (*api.HealthResponse, error) {
 	return &resp, nil
 }
 
-// RunCommand executes a command remotely via the runvoy API.
-func (c *Client) RunCommand(ctx context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
-	var resp api.ExecutionResponse
+// ReissueClaimToken issues a new claim token to an invited user who has not claimed their API key yet.
+func (c *Client) ReissueClaimToken(ctx context.Context, email string) (*api.CreateUserResponse, error) {
+	var resp api.CreateUserResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "POST",
-		Path:   "/api/v1/run",
-		Body:   req,
+		Path:   "/api/v1/users/" + url.PathEscape(email) + "/claim-token",
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// ReconcileHealth triggers a full health reconciliation on the server.
-// Requires authentication and returns a reconciliation report.
-func (c *Client) ReconcileHealth(ctx context.Context) (*api.HealthReconcileResponse, error) {
-	var resp api.HealthReconcileResponse
+// TransferOwnership transfers a service account to another owner.
+func (c *Client) TransferOwnership(
+	ctx context.Context, email string, req api.TransferOwnershipRequest,
+) (*api.TransferOwnershipResponse, error) {
+	var resp api.TransferOwnershipResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "POST",
-		Path:   "/api/v1/health/reconcile",
+		Method: "PUT",
+		Path:   "/api/v1/users/" + url.PathEscape(email) + "/owner",
+		Body:   req,
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// GetLogs gets the logs for an execution
-// The response includes a WebSocketURL field for streaming logs if WebSocket is configured.
-func (c *Client) GetLogs(ctx context.Context, executionID string) (*api.LogsResponse, error) {
-	var resp api.LogsResponse
+// GetHealth checks the API health status.
+func (c *Client) GetHealth(ctx context.Context) (*api.HealthResponse, error) {
+	var resp api.HealthResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   fmt.Sprintf("/api/v1/executions/%s/logs", executionID),
+		Path:   "/api/v1/health",
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// FetchBackendLogs fetches backend infrastructure logs and related resources for a request ID.
-func (c *Client) FetchBackendLogs(ctx context.Context, requestID string) (*api.TraceResponse, error) {
-	var resp api.TraceResponse
+// RunCommand executes a command remotely via the runvoy API.
+// Requests without a provenance are sent with the provenance of this process, see newProvenance.
+func (c *Client) RunCommand(ctx context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error) {
+	if req.Provenance == nil {
+		req.Provenance = processProvenance()
+	}
+	if err := validation.ExecutionRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid execution request: %w", err)
+	}
+	var resp api.ExecutionResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "GET",
-		Path:   "/api/v1/trace/" + requestID,
+		Method: "POST",
+		Path:   "/api/v1/run",
+		Body:   req,
 	}, &resp)
 	if err != nil {
 		return nil, err
@@ -300,93 +375,140 @@ func (c *Client) FetchBackendLogs(ctx context.Context, requestID string) (*api.T
 	return &resp, nil
 }
 
-// GetExecutionStatus gets the status of an execution.
-func (c *Client) GetExecutionStatus(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error) {
-	var resp api.ExecutionStatusResponse
+// CreateStdinUpload requests a URL to upload size bytes of standard input for an upcoming execution.
+func (c *Client) CreateStdinUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error) {
+	var resp api.InputUploadResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "GET",
-		Path:   fmt.Sprintf("/api/v1/executions/%s/status", executionID),
+		Method: "POST",
+		Path:   "/api/v1/run/stdin",
+		Body:   api.InputUploadRequest{Size: size},
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+	return &resp, nil
+}
 
+// CreateContextUpload requests a URL to upload a working directory archive of size bytes for an upcoming execution.
+func (c *Client) CreateContextUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error) {
+	var resp api.InputUploadResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/run/context",
+		Body:   api.InputUploadRequest{Size: size},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
 	return &resp, nil
 }
 
-// KillExecution stops a running execution by its ID
-// Returns nil response if the execution was already terminated (204 No Content).
-func (c *Client) KillExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error) {
-	httpReq := Request{
-		Method: "DELETE",
-		Path:   "/api/v1/executions/" + executionID,
+// UploadInput uploads an execution input to the presigned URL returned by CreateStdinUpload
+// or CreateContextUpload.
+// The URL grants access on its own, so the API key is not sent.
+func (c *Client) UploadInput(ctx context.Context, uploadURL string, data []byte) error {
+	ctx, cancel := c.withTimeout(ctx, OperationLong)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
 	}
+	httpReq.ContentLength = int64(len(data))
 
-	httpResp, err := c.Do(ctx, httpReq)
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to upload input: %w", err)
 	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
 
-	if httpResp.StatusCode == http.StatusNoContent {
-		return nil, nil
+	if resp.StatusCode >= constants.HTTPStatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload input: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
 	}
+	return nil
+}
 
-	if httpResp.StatusCode >= constants.HTTPStatusBadRequest {
-		var errorResp api.ErrorResponse
-		if err = json.Unmarshal(httpResp.Body, &errorResp); err != nil {
-			return nil, fmt.Errorf("request failed with status %d: %s", httpResp.StatusCode, string(httpResp.Body))
-		}
-		return nil, fmt.Errorf("[%d] %s: %s", httpResp.StatusCode, errorResp.Error, errorResp.Details)
+// ReconcileHealth triggers a full health reconciliation on the server.
+// Requires authentication and returns a reconciliation report. When canary is true, the server also
+// evaluates the previous canary execution and launches a new one.
+func (c *Client) ReconcileHealth(ctx context.Context, canary bool) (*api.HealthReconcileResponse, error) {
+	path := "/api/v1/health/reconcile"
+	if canary {
+		path += "?canary=true"
 	}
 
-	var resp api.KillExecutionResponse
-	if err = json.Unmarshal(httpResp.Body, &resp); err != nil {
-		c.logger.Debug("response body", "body", string(httpResp.Body))
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	var resp api.HealthReconcileResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   path,
+		Class:  OperationLong,
+	}, &resp)
+	if err != nil {
+		return nil, err
 	}
 	return &resp, nil
 }
 
-// ListExecutions fetches executions with optional filtering and pagination.
-// Parameters:
-//   - limit: maximum number of executions to return (0 returns all)
-//   - statuses: comma-separated list of execution statuses to filter by (e.g., "RUNNING,TERMINATING")
-func (c *Client) ListExecutions(ctx context.Context, limit int, statuses string) ([]api.Execution, error) {
-	var resp []api.Execution
+// CleanupHealth deletes the provider resources created by runvoy that are no longer referenced.
+// When dryRun is true, the server only reports them.
+func (c *Client) CleanupHealth(ctx context.Context, dryRun bool) (*api.HealthCleanupResponse, error) {
+	path := "/api/v1/health/cleanup"
+	if dryRun {
+		path += "?dry_run=true"
+	}
 
-	// Build the URL properly with query parameters
-	u, err := url.Parse("/api/v1/executions")
+	var resp api.HealthCleanupResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   path,
+		Class:  OperationLong,
+	}, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL: %w", err)
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	params := url.Values{}
-	if limit >= 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	}
-	if statuses != "" {
-		params.Set("status", statuses)
+// GetHealthStats gets the database query statistics of the backend instance serving the request.
+func (c *Client) GetHealthStats(ctx context.Context) (*api.HealthStatsResponse, error) {
+	var resp api.HealthStatsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/health/stats",
+	}, &resp)
+	if err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	u.RawQuery = params.Encode()
-	path := u.String()
+// ListHealthReports lists up to limit stored health reconciliation reports, most recent first.
+func (c *Client) ListHealthReports(ctx context.Context, limit int) (*api.HealthReportsResponse, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
 
-	err = c.DoJSON(ctx, Request{
+	var resp api.HealthReportsResponse
+	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   path,
+		Path:   "/api/v1/health/reports?" + params.Encode(),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return &resp, nil
 }
 
-// ClaimAPIKey claims a user's API key.
-func (c *Client) ClaimAPIKey(ctx context.Context, token string) (*api.ClaimAPIKeyResponse, error) {
-	var resp api.ClaimAPIKeyResponse
+// GetLogs gets the logs for an execution
+// The response includes a WebSocketURL field for streaming logs if WebSocket is configured.
+func (c *Client) GetLogs(ctx context.Context, executionID string) (*api.LogsResponse, error) {
+	var resp api.LogsResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   "/api/v1/claim/" + token,
+		Path:   fmt.Sprintf("/api/v1/executions/%s/logs", executionID),
+		Class:  OperationLong,
 	}, &resp)
 	if err != nil {
 		return nil, err
@@ -394,28 +516,13 @@ func (c *Client) ClaimAPIKey(ctx context.Context, token string) (*api.ClaimAPIKe
 	return &resp, nil
 }
 
-// RegisterImage registers a new container image for execution, optionally marking it as the default.
-func (c *Client) RegisterImage(
-	ctx context.Context,
-	image string,
-	isDefault *bool,
-	taskRoleName, taskExecutionRoleName *string,
-	cpu, memory *int,
-	runtimePlatform *string,
-) (*api.RegisterImageResponse, error) {
-	var resp api.RegisterImageResponse
+// FetchBackendLogs fetches backend infrastructure logs and related resources for a request ID.
+func (c *Client) FetchBackendLogs(ctx context.Context, requestID string) (*api.TraceResponse, error) {
+	var resp api.TraceResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "POST",
-		Path:   "/api/v1/images/register",
-		Body: api.RegisterImageRequest{
-			Image:                 image,
-			IsDefault:             isDefault,
-			TaskRoleName:          taskRoleName,
-			TaskExecutionRoleName: taskExecutionRoleName,
-			CPU:                   cpu,
-			Memory:                memory,
-			RuntimePlatform:       runtimePlatform,
-		},
+		Method: "GET",
+		Path:   "/api/v1/trace/" + requestID,
+		Class:  OperationLong,
 	}, &resp)
 	if err != nil {
 		return nil, err
@@ -423,110 +530,1065 @@ func (c *Client) RegisterImage(
 	return &resp, nil
 }
 
-// ListImages retrieves all registered container images.
-func (c *Client) ListImages(ctx context.Context) (*api.ListImagesResponse, error) {
-	var resp api.ListImagesResponse
+// GetExecutionStatus gets the status of an execution.
+func (c *Client) GetExecutionStatus(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error) {
+	var resp api.ExecutionStatusResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   "/api/v1/images",
+		Path:   fmt.Sprintf("/api/v1/executions/%s/status", executionID),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// GetImage retrieves a single container image by ID or name.
-func (c *Client) GetImage(ctx context.Context, image string) (*api.ImageInfo, error) {
-	var resp api.ImageInfo
-	encodedImage := url.PathEscape(image)
+// GetExecutionGroupStatus gets the aggregated status of the shards of a parallel run.
+func (c *Client) GetExecutionGroupStatus(
+	ctx context.Context, groupID string,
+) (*api.ExecutionGroupStatusResponse, error) {
+	var resp api.ExecutionGroupStatusResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   "/api/v1/images/" + encodedImage,
+		Path:   fmt.Sprintf("/api/v1/executions/groups/%s/status", groupID),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// UnregisterImage removes a container image from the registry.
-func (c *Client) UnregisterImage(ctx context.Context, image string) (*api.RemoveImageResponse, error) {
-	var resp api.RemoveImageResponse
+// GetExecutionEvents gets the lifecycle timeline of an execution.
+func (c *Client) GetExecutionEvents(ctx context.Context, executionID string) (*api.ExecutionEventsResponse, error) {
+	var resp api.ExecutionEventsResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "DELETE",
-		Path:   "/api/v1/images/" + image,
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/v1/executions/%s/events", executionID),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// CreateSecret creates a new secret.
-func (c *Client) CreateSecret(ctx context.Context, req api.CreateSecretRequest) (*api.CreateSecretResponse, error) {
-	var resp api.CreateSecretResponse
+// GetExecutionResult gets the JSON result file reported by an execution.
+func (c *Client) GetExecutionResult(ctx context.Context, executionID string) (*api.ExecutionResultResponse, error) {
+	var resp api.ExecutionResultResponse
 	err := c.DoJSON(ctx, Request{
-		Method: "POST",
-		Path:   "/api/v1/secrets",
-		Body:   req,
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/v1/executions/%s/result", executionID),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// GetSecret retrieves a secret by name.
-func (c *Client) GetSecret(ctx context.Context, name string) (*api.GetSecretResponse, error) {
-	var resp api.GetSecretResponse
+// AnnotateExecution attaches a note to an execution.
+func (c *Client) AnnotateExecution(ctx context.Context, executionID, note string) (*api.ExecutionAnnotation, error) {
+	var resp api.ExecutionAnnotation
 	err := c.DoJSON(ctx, Request{
-		Method: "GET",
-		Path:   "/api/v1/secrets/" + name,
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/v1/executions/%s/annotations", executionID),
+		Body:   api.ExecutionAnnotationRequest{Note: note},
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// ListSecrets lists all secrets.
-func (c *Client) ListSecrets(ctx context.Context) (*api.ListSecretsResponse, error) {
-	var resp api.ListSecretsResponse
+// GetExecutionResources gets the latest resource usage of the running executions.
+func (c *Client) GetExecutionResources(ctx context.Context) (*api.ExecutionResourcesResponse, error) {
+	var resp api.ExecutionResourcesResponse
 	err := c.DoJSON(ctx, Request{
 		Method: "GET",
-		Path:   "/api/v1/secrets",
+		Path:   "/api/v1/executions/resources",
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// UpdateSecret updates a secret by name.
-func (c *Client) UpdateSecret(
+// GetExecutionSLOReport gets the duration SLO breach rates of the playbooks over the period counted back
+// from now, the server default when zero, restricted to a playbook when not empty.
+func (c *Client) GetExecutionSLOReport(
 	ctx context.Context,
-	name string,
-	req api.UpdateSecretRequest,
-) (*api.UpdateSecretResponse, error) {
-	var resp api.UpdateSecretResponse
-	err := c.DoJSON(ctx, Request{
-		Method: "PUT",
-		Path:   "/api/v1/secrets/" + name,
-		Body:   req,
+	since time.Duration,
+	playbook string,
+) (*api.ExecutionSLOReport, error) {
+	u, err := url.Parse("/api/v1/executions/slo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	params := url.Values{}
+	if since > 0 {
+		params.Set("since", since.String())
+	}
+	if playbook != "" {
+		params.Set("playbook", playbook)
+	}
+	u.RawQuery = params.Encode()
+
+	var resp api.ExecutionSLOReport
+	err = c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   u.String(),
 	}, &resp)
 	if err != nil {
 		return nil, err
 	}
+
 	return &resp, nil
 }
 
-// DeleteSecret deletes a secret by name.
-func (c *Client) DeleteSecret(ctx context.Context, name string) (*api.DeleteSecretResponse, error) {
-	var resp api.DeleteSecretResponse
-	err := c.DoJSON(ctx, Request{
+// KillExecution stops a running execution by its ID
+// Returns nil response if the execution was already terminated (204 No Content).
+func (c *Client) KillExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error) {
+	return c.terminateExecution(ctx, Request{
 		Method: "DELETE",
-		Path:   "/api/v1/secrets/" + name,
+		Path:   "/api/v1/executions/" + executionID,
+	})
+}
+
+// StopExecution gracefully stops a running execution by its ID, giving the command its stop grace period
+// to clean up before being killed.
+// Returns nil response if the execution was already terminated (204 No Content).
+func (c *Client) StopExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error) {
+	return c.terminateExecution(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/executions/" + executionID + "/stop",
+	})
+}
+
+// terminateExecution sends a kill or stop request and parses the response.
+func (c *Client) terminateExecution(ctx context.Context, httpReq Request) (*api.KillExecutionResponse, error) {
+	httpResp, err := c.Do(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if httpResp.StatusCode >= constants.HTTPStatusBadRequest {
+		return nil, withTraceHint(parseErrorResponse(httpResp.StatusCode, httpResp.Body), httpResp.RequestID)
+	}
+
+	var resp api.KillExecutionResponse
+	if err = json.Unmarshal(httpResp.Body, &resp); err != nil {
+		c.logger.Debug("response body", "body", logger.ScrubJSON(httpResp.Body))
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp, nil
+}
+
+// KillExecutions stops all executions matching the filter.
+// With an empty confirmationToken nothing is killed: the response lists the matching executions
+// along with the token to pass back to confirm the kill.
+func (c *Client) KillExecutions(
+	ctx context.Context,
+	filter api.KillExecutionsFilter,
+	confirmationToken string,
+) (*api.KillExecutionsResponse, error) {
+	params := url.Values{}
+	if len(filter.Statuses) > 0 {
+		params.Set("status", strings.Join(filter.Statuses, ","))
+	}
+	if filter.CreatedBy != "" {
+		params.Set("user", filter.CreatedBy)
+	}
+	if filter.OlderThan > 0 {
+		params.Set("older_than", filter.OlderThan.String())
+	}
+	if confirmationToken != "" {
+		params.Set("confirm", confirmationToken)
+	}
+
+	path := "/api/v1/executions"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp api.KillExecutionsResponse
+	if err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   path,
+	}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListExecutions fetches executions with optional filtering and pagination.
+// Parameters:
+//   - limit: maximum number of executions to return (0 returns all)
+//   - statuses: comma-separated list of execution statuses to filter by (e.g., "RUNNING,TERMINATING")
+func (c *Client) ListExecutions(ctx context.Context, limit int, statuses string) ([]api.Execution, error) {
+	var resp []api.Execution
+
+	// Build the URL properly with query parameters
+	u, err := url.Parse("/api/v1/executions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	params := url.Values{}
+	if limit >= 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if statuses != "" {
+		params.Set("status", statuses)
+	}
+
+	u.RawQuery = params.Encode()
+	path := u.String()
+
+	err = c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   path,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListFilteredExecutions lists executions matching the filter, its Name selecting a filter saved by the user.
+// Parameters:
+//   - limit: maximum number of executions to return (0 returns all)
+func (c *Client) ListFilteredExecutions(
+	ctx context.Context,
+	limit int,
+	filter api.ExecutionListFilter,
+) ([]api.Execution, error) {
+	var resp []api.Execution
+
+	u, err := url.Parse("/api/v1/executions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	params := url.Values{}
+	if limit >= 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if len(filter.Statuses) > 0 {
+		params.Set("status", strings.Join(filter.Statuses, ","))
+	}
+	if filter.CreatedBy != "" {
+		params.Set("user", filter.CreatedBy)
+	}
+	if filter.Since != "" {
+		params.Set("since", filter.Since)
+	}
+	if filter.Starred {
+		params.Set("starred", "true")
+	}
+	if filter.Search != "" {
+		params.Set("search", filter.Search)
+	}
+	if filter.Name != "" {
+		params.Set("filter", filter.Name)
+	}
+
+	u.RawQuery = params.Encode()
+
+	err = c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   u.String(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StarExecution stars the execution for the user, or unstars it when starred is false.
+func (c *Client) StarExecution(
+	ctx context.Context,
+	executionID string,
+	starred bool,
+) (*api.StarExecutionResponse, error) {
+	method := "POST"
+	if !starred {
+		method = "DELETE"
+	}
+	var resp api.StarExecutionResponse
+	err := c.DoJSON(ctx, Request{
+		Method: method,
+		Path:   fmt.Sprintf("/api/v1/executions/%s/star", executionID),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListSavedFilters lists the execution list filters saved by the user.
+func (c *Client) ListSavedFilters(ctx context.Context) (*api.ListSavedFiltersResponse, error) {
+	var resp api.ListSavedFiltersResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/executions/filters",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SaveFilter saves the execution list filter under its name, replacing any filter saved under that name.
+func (c *Client) SaveFilter(ctx context.Context, filter api.ExecutionListFilter) (*api.ExecutionListFilter, error) {
+	if err := validation.SavedFilter(&filter); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	var resp api.ExecutionListFilter
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/executions/filters",
+		Body:   filter,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteSavedFilter deletes the execution list filter saved under the name.
+func (c *Client) DeleteSavedFilter(ctx context.Context, name string) (*api.DeleteSavedFilterResponse, error) {
+	var resp api.DeleteSavedFilterResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/executions/filters/" + url.PathEscape(name),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DiffExecutions compares two executions and returns the fields that differ between them.
+// Parameters:
+//   - logLines: number of final log lines to diff (0 skips the log diff)
+func (c *Client) DiffExecutions(
+	ctx context.Context,
+	executionIDA, executionIDB string,
+	logLines int,
+) (*api.ExecutionDiffResponse, error) {
+	var resp api.ExecutionDiffResponse
+
+	u, err := url.Parse("/api/v1/executions/diff")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("a", executionIDA)
+	params.Set("b", executionIDB)
+	if logLines > 0 {
+		params.Set("log_lines", strconv.Itoa(logLines))
+	}
+	u.RawQuery = params.Encode()
+
+	err = c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   u.String(),
+		Class:  OperationLong,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StreamExecutions subscribes to the executions Server-Sent Events stream and invokes onSnapshot
+// for every snapshot received, until the server closes the stream, the context is canceled
+// or onSnapshot returns an error.
+// Parameters:
+//   - statuses: comma-separated list of execution statuses to filter by (empty uses the server default)
+func (c *Client) StreamExecutions(
+	ctx context.Context,
+	statuses string,
+	onSnapshot func([]api.Execution) error,
+) error {
+	path := "/api/v1/executions/stream"
+	if statuses != "" {
+		path += "?" + url.Values{"status": []string{statuses}}.Encode()
+	}
+
+	apiURL, err := c.buildURL(path)
+	if err != nil {
+		return fmt.Errorf("invalid API endpoint: %w", err)
+	}
+
+	httpReq, err := c.createHTTPRequest(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", constants.EventStreamContentType)
+
+	reqLogger := logger.DeriveRequestLogger(ctx, c.logger)
+	c.logRequest(ctx, reqLogger, httpReq, nil)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= constants.HTTPStatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		return withTraceHint(parseErrorResponse(resp.StatusCode, body), resp.Header.Get(constants.RequestIDHeader))
+	}
+
+	return readSSEEvents(resp.Body, func(event api.StreamEventType, data []byte) error {
+		switch event {
+		case api.StreamEventExecutions:
+			var executions []api.Execution
+			if err = json.Unmarshal(data, &executions); err != nil {
+				return fmt.Errorf("failed to parse executions event: %w", err)
+			}
+			return onSnapshot(executions)
+		case api.StreamEventError:
+			return parseErrorResponse(http.StatusInternalServerError, data)
+		default:
+			reqLogger.Debug("ignoring unknown stream event", "event", event)
+			return nil
+		}
+	})
+}
+
+// readSSEEvents parses a Server-Sent Events stream and invokes handle for every complete event.
+func readSSEEvents(r io.Reader, handle func(event api.StreamEventType, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), constants.MaxSSEEventSize)
+
+	var (
+		event api.StreamEventType
+		data  []byte
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := handle(event, data); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			event = api.StreamEventType(strings.TrimSpace(strings.TrimPrefix(line, "event:")))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:"))...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+	return nil
+}
+
+// parseErrorResponse builds an error from an API error response body.
+func parseErrorResponse(statusCode int, body []byte) error {
+	var errorResp api.ErrorResponse
+	if err := json.Unmarshal(body, &errorResp); err != nil {
+		return fmt.Errorf("request failed with status %d: %s", statusCode, string(body))
+	}
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       errorResp.Code,
+		Message:    errorResp.Error,
+		Details:    errorResp.Details,
+	}
+}
+
+// APIError is the error response of a request the API refused or failed to serve.
+type APIError struct {
+	StatusCode int
+	// Code is the error code of the response, such as LOCK_HELD, empty for the responses without one.
+	Code    string
+	Message string
+	Details string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("[%d] %s: %s", e.StatusCode, e.Message, e.Details)
+}
+
+// HasErrorCode reports whether a request failed with an error response of the API with the error code.
+func HasErrorCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// withTraceHint adds to the error of a failed request how to trace it in the backend,
+// when the orchestrator echoed the request ID.
+func withTraceHint(err error, requestID string) error {
+	if requestID == "" {
+		return err
+	}
+	return fmt.Errorf("%w (request ID: %s, run `runvoy trace %s` for details)", err, requestID, requestID)
+}
+
+// ClaimAPIKey claims a user's API key.
+func (c *Client) ClaimAPIKey(ctx context.Context, token string) (*api.ClaimAPIKeyResponse, error) {
+	var resp api.ClaimAPIKeyResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/claim/" + token,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLoginConfig gets the identity provider users log in with.
+func (c *Client) GetLoginConfig(ctx context.Context) (*api.LoginConfigResponse, error) {
+	var resp api.LoginConfigResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/login",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Login exchanges an ID token of the identity provider for an API key.
+func (c *Client) Login(ctx context.Context, req api.LoginRequest) (*api.LoginResponse, error) {
+	var resp api.LoginResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/login",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExchangeGitHubToken exchanges the OIDC token of a GitHub Actions workflow for a short-lived run token.
+func (c *Client) ExchangeGitHubToken(
+	ctx context.Context,
+	req api.GitHubTokenRequest,
+) (*api.GitHubTokenResponse, error) {
+	var resp api.GitHubTokenResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/github/token",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RegisterImage registers a new container image for execution, optionally marking it as the default.
+func (c *Client) RegisterImage(
+	ctx context.Context,
+	image string,
+	isDefault *bool,
+	taskRoleName, taskExecutionRoleName *string,
+	cpu, memory *int,
+	runtimePlatform *string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
+) (*api.RegisterImageResponse, error) {
+	if err := validation.ImageReference(image); err != nil {
+		return nil, err
+	}
+	var resp api.RegisterImageResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/images/register",
+		Body: api.RegisterImageRequest{
+			Image:                 image,
+			IsDefault:             isDefault,
+			TaskRoleName:          taskRoleName,
+			TaskExecutionRoleName: taskExecutionRoleName,
+			CPU:                   cpu,
+			Memory:                memory,
+			RuntimePlatform:       runtimePlatform,
+			LogLimits:             logLimits,
+			WarmPoolSize:          warmPoolSize,
+			RunDefaults:           runDefaults,
+		},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListImages retrieves all registered container images.
+func (c *Client) ListImages(ctx context.Context) (*api.ListImagesResponse, error) {
+	var resp api.ListImagesResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/images",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetImage retrieves a single container image by ID or name.
+func (c *Client) GetImage(ctx context.Context, image string) (*api.ImageInfo, error) {
+	var resp api.ImageInfo
+	encodedImage := url.PathEscape(image)
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/images/" + encodedImage,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetResourceRecommendations gets the CPU and memory right-sizing recommendations of the images.
+func (c *Client) GetResourceRecommendations(ctx context.Context) (*api.ResourceRecommendationsResponse, error) {
+	var resp api.ResourceRecommendationsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/recommendations",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UnregisterImage deletes a container image from the registry. It can be restored with RestoreImage until
+// its retention window expires.
+func (c *Client) UnregisterImage(ctx context.Context, image string) (*api.RemoveImageResponse, error) {
+	var resp api.RemoveImageResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/images/" + image,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RestoreImage restores a container image deleted with UnregisterImage.
+func (c *Client) RestoreImage(ctx context.Context, image string) (*api.RestoreImageResponse, error) {
+	var resp api.RestoreImageResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/images/restore",
+		Body:   api.RestoreImageRequest{Image: image},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetImageAlias creates an image alias pointing at a registered image, or points an existing alias at it.
+func (c *Client) SetImageAlias(ctx context.Context, alias, image string) (*api.SetImageAliasResponse, error) {
+	var resp api.SetImageAliasResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/images/aliases",
+		Body:   api.SetImageAliasRequest{Alias: alias, Image: image},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListImageAliases lists all image aliases.
+func (c *Client) ListImageAliases(ctx context.Context) (*api.ListImageAliasesResponse, error) {
+	var resp api.ListImageAliasesResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/images/aliases",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveImageAlias removes an image alias, leaving the image it points at registered.
+func (c *Client) RemoveImageAlias(ctx context.Context, alias string) (*api.RemoveImageAliasResponse, error) {
+	var resp api.RemoveImageAliasResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/images/aliases/" + alias,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PurgeImage permanently removes a container image and its task definitions, without a retention window.
+// It requires the admin role.
+func (c *Client) PurgeImage(ctx context.Context, image string) (*api.RemoveImageResponse, error) {
+	var resp api.RemoveImageResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/admin/images/" + image,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateSecret creates a new secret.
+func (c *Client) CreateSecret(ctx context.Context, req api.CreateSecretRequest) (*api.CreateSecretResponse, error) {
+	var resp api.CreateSecretResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/secrets",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSecret retrieves a secret by name.
+func (c *Client) GetSecret(ctx context.Context, name string) (*api.GetSecretResponse, error) {
+	var resp api.GetSecretResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/secrets/" + name,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListSecrets lists all secrets.
+func (c *Client) ListSecrets(ctx context.Context) (*api.ListSecretsResponse, error) {
+	var resp api.ListSecretsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/secrets",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateSecret updates a secret by name.
+func (c *Client) UpdateSecret(
+	ctx context.Context,
+	name string,
+	req api.UpdateSecretRequest,
+) (*api.UpdateSecretResponse, error) {
+	var resp api.UpdateSecretResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/secrets/" + name,
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteSecret deletes a secret by name.
+func (c *Client) DeleteSecret(ctx context.Context, name string) (*api.DeleteSecretResponse, error) {
+	var resp api.DeleteSecretResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/secrets/" + name,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExportSecrets exports the listed secrets in an encrypted bundle.
+func (c *Client) ExportSecrets(ctx context.Context, req api.ExportSecretsRequest) (*api.ExportSecretsResponse, error) {
+	var resp api.ExportSecretsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/admin/secrets/export",
+		Class:  OperationLong,
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportSecrets imports the listed secrets of an encrypted bundle.
+func (c *Client) ImportSecrets(ctx context.Context, req api.ImportSecretsRequest) (*api.ImportSecretsResponse, error) {
+	var resp api.ImportSecretsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/admin/secrets/import",
+		Class:  OperationLong,
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListCommandPolicyRules lists the command policy rules. It requires the admin role.
+func (c *Client) ListCommandPolicyRules(ctx context.Context) (*api.ListCommandPolicyRulesResponse, error) {
+	var resp api.ListCommandPolicyRulesResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/admin/command-policies",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateCommandPolicyRule creates a command policy rule. It requires the admin role.
+func (c *Client) CreateCommandPolicyRule(
+	ctx context.Context, req api.CommandPolicyRuleRequest,
+) (*api.CommandPolicyRuleResponse, error) {
+	var resp api.CommandPolicyRuleResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "POST",
+		Path:   "/api/v1/admin/command-policies",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateCommandPolicyRule replaces a command policy rule. It requires the admin role.
+func (c *Client) UpdateCommandPolicyRule(
+	ctx context.Context, name string, req api.CommandPolicyRuleRequest,
+) (*api.CommandPolicyRuleResponse, error) {
+	var resp api.CommandPolicyRuleResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/admin/command-policies/" + name,
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteCommandPolicyRule deletes a command policy rule. It requires the admin role.
+func (c *Client) DeleteCommandPolicyRule(
+	ctx context.Context, name string,
+) (*api.DeleteCommandPolicyRuleResponse, error) {
+	var resp api.DeleteCommandPolicyRuleResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/admin/command-policies/" + name,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMeta gets the metadata of the backend, such as the announcement.
+func (c *Client) GetMeta(ctx context.Context) (*api.MetaResponse, error) {
+	var resp api.MetaResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/meta",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetAnnouncement sets the announcement shown to the CLI users. It requires the admin role.
+func (c *Client) SetAnnouncement(ctx context.Context, req api.AnnouncementRequest) (*api.AnnouncementResponse, error) {
+	var resp api.AnnouncementResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/admin/announcement",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClearAnnouncement removes the announcement. It requires the admin role.
+func (c *Client) ClearAnnouncement(ctx context.Context) (*api.AnnouncementResponse, error) {
+	var resp api.AnnouncementResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/admin/announcement",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRunFreeze gets the run freeze switch. It requires the admin role.
+func (c *Client) GetRunFreeze(ctx context.Context) (*api.RunFreezeResponse, error) {
+	var resp api.RunFreezeResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/admin/freeze",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FreezeRuns rejects the new executions with the message of the request. It requires the admin role.
+func (c *Client) FreezeRuns(ctx context.Context, req api.RunFreezeRequest) (*api.RunFreezeResponse, error) {
+	var resp api.RunFreezeResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/admin/freeze",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UnfreezeRuns accepts the new executions again. It requires the admin role.
+func (c *Client) UnfreezeRuns(ctx context.Context) (*api.RunFreezeResponse, error) {
+	var resp api.RunFreezeResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/admin/freeze",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListBudgets lists the monthly budgets of the users with their spend this month. It requires the admin role.
+func (c *Client) ListBudgets(ctx context.Context) (*api.ListBudgetsResponse, error) {
+	var resp api.ListBudgetsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/admin/budgets",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetBudget sets the monthly budget of the user with the email. It requires the admin role.
+func (c *Client) SetBudget(ctx context.Context, email string, req api.BudgetRequest) (*api.BudgetResponse, error) {
+	var resp api.BudgetResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/admin/budgets/" + url.PathEscape(email),
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveBudget removes the monthly budget of the user with the email. It requires the admin role.
+func (c *Client) RemoveBudget(ctx context.Context, email string) (*api.BudgetResponse, error) {
+	var resp api.BudgetResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/admin/budgets/" + url.PathEscape(email),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetNotificationSettings gets the notification settings, such as the recipients of the weekly usage report.
+// It requires the admin role.
+func (c *Client) GetNotificationSettings(ctx context.Context) (*api.NotificationSettingsResponse, error) {
+	var resp api.NotificationSettingsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/admin/notifications",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetNotificationSettings replaces the notification settings. It requires the admin role.
+func (c *Client) SetNotificationSettings(
+	ctx context.Context, req api.NotificationSettingsRequest,
+) (*api.NotificationSettingsResponse, error) {
+	var resp api.NotificationSettingsResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "PUT",
+		Path:   "/api/v1/admin/notifications",
+		Body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListLocks lists the execution locks currently held.
+func (c *Client) ListLocks(ctx context.Context) (*api.ListLocksResponse, error) {
+	var resp api.ListLocksResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "GET",
+		Path:   "/api/v1/locks",
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReleaseLock releases an execution lock, whatever holds it.
+func (c *Client) ReleaseLock(ctx context.Context, name string) (*api.ReleaseLockResponse, error) {
+	var resp api.ReleaseLockResponse
+	err := c.DoJSON(ctx, Request{
+		Method: "DELETE",
+		Path:   "/api/v1/locks/" + name,
 	}, &resp)
 	if err != nil {
 		return nil, err
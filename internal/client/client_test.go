@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/logger"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -147,6 +149,62 @@ func TestClient_Do(t *testing.T) {
 	}
 }
 
+func TestClient_Do_Timeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		APIEndpoint: server.URL,
+		APIKey:      "test-api-key",
+		HTTPClient: config.HTTPClientConfig{
+			ShortTimeout: 20 * time.Millisecond,
+			LongTimeout:  time.Second,
+		},
+	}
+	c := New(cfg, testutil.SilentLogger())
+
+	_, err := c.Do(context.Background(), Request{Method: "GET", Path: "/api/v1/test"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	resp, err := c.Do(context.Background(), Request{Method: "GET", Path: "/api/v1/test", Class: OperationLong})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestClient_Do_RequestID(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(constants.RequestIDHeader)
+		sent = append(sent, requestID)
+		w.Header().Set(constants.RequestIDHeader, requestID)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "Not found", "details": "no such execution"}`))
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.Do(context.Background(), Request{Method: "GET", Path: "/api/v1/test"})
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.NotEmpty(t, sent[0], "a request ID is generated")
+	assert.Equal(t, sent[0], resp.RequestID)
+
+	ctx := logger.WithRequestID(context.Background(), "cli-request-id")
+	err = c.DoJSON(ctx, Request{Method: "GET", Path: "/api/v1/test"}, &struct{}{})
+	require.Error(t, err)
+	assert.Equal(t, "cli-request-id", sent[1], "the request ID of the context is sent")
+	assert.Equal(t,
+		"[404] Not found: no such execution (request ID: cli-request-id, run `runvoy trace cli-request-id` for details)",
+		err.Error())
+}
+
 func TestClient_DoJSON(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -391,6 +449,61 @@ func TestClient_ListUsers(t *testing.T) {
 	})
 }
 
+func TestClient_PendingUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/users/pending":
+			_ = json.NewEncoder(w).Encode(api.ListUsersResponse{
+				Users: []*api.User{{Email: "alice@example.com"}},
+			})
+		case "POST /api/v1/users/alice@example.com/claim-token":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(api.CreateUserResponse{
+				User:       &api.User{Email: "alice@example.com"},
+				ClaimToken: "token-456",
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	pending, err := c.ListPendingUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending.Users, 1)
+	assert.Equal(t, "alice@example.com", pending.Users[0].Email)
+
+	reissued, err := c.ReissueClaimToken(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "token-456", reissued.ClaimToken)
+}
+
+func TestClient_TransferOwnership(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/users/ci@example.com/owner", r.URL.Path)
+		var req api.TransferOwnershipRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "bob@example.com", req.Owner)
+		_ = json.NewEncoder(w).Encode(api.TransferOwnershipResponse{
+			User:          &api.User{Email: "ci@example.com", ServiceAccount: true, Owner: req.Owner},
+			PreviousOwner: "alice@example.com",
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.TransferOwnership(context.Background(), "ci@example.com",
+		api.TransferOwnershipRequest{Owner: "bob@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", resp.PreviousOwner)
+	assert.Equal(t, "bob@example.com", resp.User.Owner)
+}
+
 func TestClient_GetHealth(t *testing.T) {
 	t.Run("successful health check", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -462,6 +575,27 @@ func TestClient_RunCommand(t *testing.T) {
 		assert.Equal(t, "RUNNING", resp.Status)
 		assert.Equal(t, "wss://example.com/ws/exec-123", resp.WebSocketURL)
 	})
+
+	t.Run("rejects out of bounds request before sending it", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			t.Error("request should not be sent")
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		resp, err := c.RunCommand(context.Background(), &api.ExecutionRequest{
+			Command: strings.Repeat("a", constants.MaxCommandLength+1),
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "command is 4097 bytes long")
+	})
 }
 
 func TestClient_GetLogs(t *testing.T) {
@@ -533,6 +667,266 @@ func TestClient_GetExecutionStatus(t *testing.T) {
 	})
 }
 
+func TestClient_GetExecutionEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/events", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ExecutionEventsResponse{
+			ExecutionID: "exec-123",
+			Events: []api.ExecutionEvent{
+				{Type: "SUBMITTED"},
+				{Type: "STOPPED", Reason: "TaskFailedToStart"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetExecutionEvents(context.Background(), "exec-123")
+
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.Equal(t, "TaskFailedToStart", resp.Events[1].Reason)
+}
+
+func TestClient_GetExecutionResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/result", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ExecutionResultResponse{
+			ExecutionID: "exec-123",
+			Result:      json.RawMessage(`{"summary":"ok"}`),
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetExecutionResult(context.Background(), "exec-123")
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"summary":"ok"}`, string(resp.Result))
+}
+
+func TestClient_AnnotateExecution(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/executions/exec-123/annotations", r.URL.Path)
+
+		var req api.ExecutionAnnotationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "caused by an upstream outage", req.Note)
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(api.ExecutionAnnotation{Note: req.Note, Author: "user@example.com"})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.AnnotateExecution(context.Background(), "exec-123", "caused by an upstream outage")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", resp.Author)
+}
+
+func TestClient_ListFilteredExecutions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "20", query.Get("limit"))
+		assert.Equal(t, "FAILED,TERMINATED", query.Get("status"))
+		assert.Equal(t, "me", query.Get("user"))
+		assert.Equal(t, "168h", query.Get("since"))
+		assert.Equal(t, "true", query.Get("starred"))
+		assert.Equal(t, "pytest tests/", query.Get("search"))
+		assert.Equal(t, "failures", query.Get("filter"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]api.Execution{{ExecutionID: "exec-1", Starred: true}})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	executions, err := c.ListFilteredExecutions(context.Background(), 20, api.ExecutionListFilter{
+		Name:      "failures",
+		Statuses:  []string{"FAILED", "TERMINATED"},
+		CreatedBy: "me",
+		Since:     "168h",
+		Starred:   true,
+		Search:    "pytest tests/",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.True(t, executions[0].Starred)
+}
+
+func TestClient_GetExecutionSLOReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions/slo", r.URL.Path)
+		assert.Equal(t, "168h0m0s", r.URL.Query().Get("since"))
+		assert.Equal(t, "deploy", r.URL.Query().Get("playbook"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ExecutionSLOReport{
+			Playbooks: []api.PlaybookSLOReport{{Playbook: "deploy", Executions: 4, Breaches: 1, BreachRate: 0.25}},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	report, err := c.GetExecutionSLOReport(context.Background(), 168*time.Hour, "deploy")
+
+	require.NoError(t, err)
+	require.Len(t, report.Playbooks, 1)
+	assert.InDelta(t, 0.25, report.Playbooks[0].BreachRate, 0.001)
+}
+
+func TestClient_StarExecution(t *testing.T) {
+	for method, starred := range map[string]bool{"POST": true, "DELETE": false} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, method, r.Method)
+			assert.Equal(t, "/api/v1/executions/exec-123/star", r.URL.Path)
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.StarExecutionResponse{ExecutionID: "exec-123", Starred: starred})
+		}))
+
+		c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+		resp, err := c.StarExecution(context.Background(), "exec-123", starred)
+
+		require.NoError(t, err)
+		assert.Equal(t, starred, resp.Starred)
+		server.Close()
+	}
+}
+
+func TestClient_SavedFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/executions/filters":
+			var filter api.ExecutionListFilter
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&filter))
+			assert.Equal(t, "failures", filter.Name)
+			_ = json.NewEncoder(w).Encode(filter)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/executions/filters":
+			_ = json.NewEncoder(w).Encode(api.ListSavedFiltersResponse{
+				Filters: []api.ExecutionListFilter{{Name: "failures"}},
+			})
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/executions/filters/failures":
+			_ = json.NewEncoder(w).Encode(api.DeleteSavedFilterResponse{Name: "failures"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	saved, err := c.SaveFilter(ctx, api.ExecutionListFilter{Name: "failures", Statuses: []string{"FAILED"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FAILED"}, saved.Statuses)
+
+	list, err := c.ListSavedFilters(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list.Filters, 1)
+
+	deleted, err := c.DeleteSavedFilter(ctx, "failures")
+	require.NoError(t, err)
+	assert.Equal(t, "failures", deleted.Name)
+
+	_, err = c.SaveFilter(ctx, api.ExecutionListFilter{Name: "my failures"})
+	require.Error(t, err)
+}
+
+func TestClient_GetExecutionGroupStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions/groups/group-123/status", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ExecutionGroupStatusResponse{
+			GroupID: "group-123",
+			Status:  "RUNNING",
+			Shards: []api.ExecutionGroupShard{
+				{ShardIndex: 0, ExecutionID: "exec-0", Status: "RUNNING"},
+				{ShardIndex: 1, ExecutionID: "exec-1", Status: "STARTING"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetExecutionGroupStatus(context.Background(), "group-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "RUNNING", resp.Status)
+	require.Len(t, resp.Shards, 2)
+	assert.Equal(t, "exec-1", resp.Shards[1].ExecutionID)
+}
+
+func TestClient_GetExecutionResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/executions/resources", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ExecutionResourcesResponse{
+			Executions: []api.ExecutionResourceUsage{
+				{ExecutionID: "exec-1", Usage: &api.ResourceUsage{CPUUtilized: 256, CPUReserved: 1024}},
+				{ExecutionID: "exec-2"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetExecutionResources(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, resp.Executions, 2)
+	require.NotNil(t, resp.Executions[0].Usage)
+	assert.InDelta(t, 256, resp.Executions[0].Usage.CPUUtilized, 0)
+	assert.Nil(t, resp.Executions[1].Usage)
+}
+
+func TestClient_GetResourceRecommendations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/recommendations", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.ResourceRecommendationsResponse{
+			Recommendations: []api.ResourceRecommendation{
+				{ImageID: "alpine:latest", CPU: 1024, Memory: 2048, RecommendedCPU: 256, RecommendedMemory: 512},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetResourceRecommendations(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, resp.Recommendations, 1)
+	assert.Equal(t, 256, resp.Recommendations[0].RecommendedCPU)
+}
+
 func TestClient_KillExecution(t *testing.T) {
 	t.Run("successful execution kill", func(t *testing.T) {
 		handler := func(w http.ResponseWriter, r *http.Request) {
@@ -561,6 +955,278 @@ func TestClient_KillExecution(t *testing.T) {
 	})
 }
 
+func TestClient_DiffExecutions(t *testing.T) {
+	t.Run("sends both execution IDs and log lines", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "/api/v1/executions/diff", r.URL.Path)
+			assert.Equal(t, "exec-a", r.URL.Query().Get("a"))
+			assert.Equal(t, "exec-b", r.URL.Query().Get("b"))
+			assert.Equal(t, "20", r.URL.Query().Get("log_lines"))
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.ExecutionDiffResponse{
+				ExecutionA:  api.ExecutionSnapshot{ExecutionID: "exec-a"},
+				ExecutionB:  api.ExecutionSnapshot{ExecutionID: "exec-b"},
+				Differences: []api.ExecutionFieldDiff{{Field: "exit_code", A: "0", B: "1"}},
+				LogDiff:     []string{"- ok", "+ FAIL"},
+			})
+		}
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := New(&config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}, testutil.SilentLogger())
+
+		resp, err := c.DiffExecutions(context.Background(), "exec-a", "exec-b", 20)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Len(t, resp.Differences, 1)
+		assert.Equal(t, []string{"- ok", "+ FAIL"}, resp.LogDiff)
+	})
+
+	t.Run("omits log lines when zero", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.False(t, r.URL.Query().Has("log_lines"))
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.ExecutionDiffResponse{})
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}, testutil.SilentLogger())
+
+		_, err := c.DiffExecutions(context.Background(), "exec-a", "exec-b", 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("returns server errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "failed to diff executions"})
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}, testutil.SilentLogger())
+
+		_, err := c.DiffExecutions(context.Background(), "exec-a", "missing", 0)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_CreateStdinUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/run/stdin", r.URL.Path)
+		var req api.InputUploadRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, int64(4096), req.Size)
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(api.InputUploadResponse{
+			UploadID:  "0123456789abcdef0123456789abcdef",
+			UploadURL: "https://uploads.example.com/stdin",
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{
+		APIEndpoint: server.URL,
+		APIKey:      "test-api-key",
+	}, testutil.SilentLogger())
+
+	resp, err := c.CreateStdinUpload(context.Background(), 4096)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", resp.UploadID)
+	assert.Equal(t, "https://uploads.example.com/stdin", resp.UploadURL)
+}
+
+func TestClient_CreateContextUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/run/context", r.URL.Path)
+		var req api.InputUploadRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, int64(2048), req.Size)
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(api.InputUploadResponse{UploadID: "upload-123", UploadURL: "https://upload"})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{
+		APIEndpoint: server.URL,
+		APIKey:      "test-api-key",
+	}, testutil.SilentLogger())
+
+	resp, err := c.CreateContextUpload(context.Background(), 2048)
+	require.NoError(t, err)
+	assert.Equal(t, "upload-123", resp.UploadID)
+}
+
+func TestClient_UploadInput(t *testing.T) {
+	t.Run("puts the data without the API key", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Empty(t, r.Header.Get(constants.APIKeyHeader))
+			assert.Equal(t, int64(8), r.ContentLength)
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "a,b\n1,2\n", string(body))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{APIKey: "test-api-key"}, testutil.SilentLogger())
+
+		err := c.UploadInput(context.Background(), server.URL+"/stdin/abc", []byte("a,b\n1,2\n"))
+		require.NoError(t, err)
+	})
+
+	t.Run("returns upload errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("SignatureDoesNotMatch"))
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{APIKey: "test-api-key"}, testutil.SilentLogger())
+
+		err := c.UploadInput(context.Background(), server.URL, []byte("data"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+	})
+}
+
+func TestClient_StopExecution(t *testing.T) {
+	t.Run("successful execution stop", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "/api/v1/executions/exec-123/stop", r.URL.Path)
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.KillExecutionResponse{
+				ExecutionID: "exec-123",
+				Message:     "Graceful stop initiated, the command has 30 seconds to exit",
+			})
+		}
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		defer server.Close()
+
+		c := New(&config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}, testutil.SilentLogger())
+
+		resp, err := c.StopExecution(context.Background(), "exec-123")
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, "exec-123", resp.ExecutionID)
+	})
+
+	t.Run("execution already terminated returns nil", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}, testutil.SilentLogger())
+
+		resp, err := c.StopExecution(context.Background(), "exec-done")
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestClient_KillExecutions(t *testing.T) {
+	t.Run("sends filters and confirmation token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "DELETE", r.Method)
+			assert.Equal(t, "/api/v1/executions", r.URL.Path)
+			assert.Equal(t, "RUNNING,STARTING", r.URL.Query().Get("status"))
+			assert.Equal(t, "me", r.URL.Query().Get("user"))
+			assert.Equal(t, "2h0m0s", r.URL.Query().Get("older_than"))
+			assert.Equal(t, "abc123", r.URL.Query().Get("confirm"))
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.KillExecutionsResponse{
+				ExecutionIDs: []string{"exec-1"},
+				Killed:       []string{"exec-1"},
+			})
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		resp, err := c.KillExecutions(context.Background(), api.KillExecutionsFilter{
+			Statuses:  []string{"RUNNING", "STARTING"},
+			CreatedBy: "me",
+			OlderThan: 2 * time.Hour,
+		}, "abc123")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exec-1"}, resp.Killed)
+	})
+
+	t.Run("omits empty filters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.KillExecutionsResponse{
+				ExecutionIDs:      []string{"exec-1"},
+				ConfirmationToken: "abc123",
+				DryRun:            true,
+			})
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		resp, err := c.KillExecutions(context.Background(), api.KillExecutionsFilter{}, "")
+
+		require.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, "abc123", resp.ConfirmationToken)
+	})
+
+	t.Run("returns conflict error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "failed to kill executions", Details: "changed"})
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		resp, err := c.KillExecutions(context.Background(), api.KillExecutionsFilter{}, "stale")
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+	})
+}
+
 func TestClient_ListExecutions(t *testing.T) {
 	t.Run("successful list executions with limit", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -647,6 +1313,82 @@ func TestClient_ListExecutions(t *testing.T) {
 	})
 }
 
+func TestClient_StreamExecutions(t *testing.T) {
+	t.Run("delivers every executions snapshot", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "/api/v1/executions/stream", r.URL.Path)
+			assert.Equal(t, "RUNNING,TERMINATING", r.URL.Query().Get("status"))
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("event: executions\ndata: [{\"execution_id\":\"exec-1\"}]\n\n"))
+			_, _ = w.Write([]byte(": keep-alive\n\n"))
+			_, _ = w.Write([]byte("event: executions\ndata: []\n\n"))
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		var snapshots [][]api.Execution
+		err := c.StreamExecutions(context.Background(), "RUNNING,TERMINATING", func(execs []api.Execution) error {
+			snapshots = append(snapshots, execs)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		require.Len(t, snapshots[0], 1)
+		assert.Equal(t, "exec-1", snapshots[0][0].ExecutionID)
+		assert.Empty(t, snapshots[1])
+	})
+
+	t.Run("returns error events", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("event: error\ndata: {\"error\":\"failed to list executions\",\"details\":\"boom\"}\n\n"))
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		err := c.StreamExecutions(context.Background(), "", func(_ []api.Execution) error {
+			t.Fatal("unexpected snapshot")
+			return nil
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list executions")
+	})
+
+	t.Run("returns HTTP errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "Forbidden", Details: "access denied"})
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			APIEndpoint: server.URL,
+			APIKey:      "test-api-key",
+		}
+		c := New(cfg, testutil.SilentLogger())
+
+		err := c.StreamExecutions(context.Background(), "", func(_ []api.Execution) error { return nil })
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
+
 func TestClient_ClaimAPIKey(t *testing.T) {
 	t.Run("successful API key claim", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -677,6 +1419,51 @@ func TestClient_ClaimAPIKey(t *testing.T) {
 	})
 }
 
+func TestClient_Login(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/login", r.URL.Path)
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(api.LoginConfigResponse{Issuer: "https://idp.example.com", ClientID: "runvoy"})
+			return
+		}
+		var req api.LoginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "id-token", req.IDToken)
+		_ = json.NewEncoder(w).Encode(api.LoginResponse{APIKey: "new-api-key", UserEmail: "user@example.com"})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL}, testutil.SilentLogger())
+
+	loginConfig, err := c.GetLoginConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com", loginConfig.Issuer)
+	assert.Equal(t, "runvoy", loginConfig.ClientID)
+
+	resp, err := c.Login(context.Background(), api.LoginRequest{IDToken: "id-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "new-api-key", resp.APIKey)
+}
+
+func TestClient_ExchangeGitHubToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/github/token", r.URL.Path)
+		var req api.GitHubTokenRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "github-token", req.IDToken)
+		_ = json.NewEncoder(w).Encode(api.GitHubTokenResponse{Token: "rvrt_token", UserEmail: "ci@example.com"})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL}, testutil.SilentLogger())
+
+	resp, err := c.ExchangeGitHubToken(context.Background(), api.GitHubTokenRequest{IDToken: "github-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "rvrt_token", resp.Token)
+	assert.Equal(t, "ci@example.com", resp.UserEmail)
+}
+
 func TestClient_RegisterImage(t *testing.T) {
 	t.Run("successful image registration", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -704,7 +1491,7 @@ func TestClient_RegisterImage(t *testing.T) {
 		c := New(cfg, testutil.SilentLogger())
 
 		isDefault := true
-		resp, err := c.RegisterImage(context.Background(), "ubuntu:22.04", &isDefault, nil, nil, nil, nil, nil)
+		resp, err := c.RegisterImage(context.Background(), "ubuntu:22.04", &isDefault, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -733,7 +1520,7 @@ func TestClient_RegisterImage(t *testing.T) {
 		}
 		c := New(cfg, testutil.SilentLogger())
 
-		resp, err := c.RegisterImage(context.Background(), "ubuntu:22.04", nil, nil, nil, nil, nil, nil)
+		resp, err := c.RegisterImage(context.Background(), "ubuntu:22.04", nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -749,6 +1536,7 @@ func TestClient_RegisterImage(t *testing.T) {
 			assert.Equal(t, "my-task-role", *req.TaskRoleName)
 			assert.NotNil(t, req.TaskExecutionRoleName)
 			assert.Equal(t, "my-exec-role", *req.TaskExecutionRoleName)
+			assert.Equal(t, &api.RunDefaults{WorkDir: "/app", Shell: "bash"}, req.RunDefaults)
 
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(api.RegisterImageResponse{
@@ -766,7 +1554,9 @@ func TestClient_RegisterImage(t *testing.T) {
 
 		taskRole := "my-task-role"
 		taskExecRole := "my-exec-role"
-		resp, err := c.RegisterImage(context.Background(), "alpine:latest", nil, &taskRole, &taskExecRole, nil, nil, nil)
+		resp, err := c.RegisterImage(
+			context.Background(), "alpine:latest", nil, &taskRole, &taskExecRole, nil, nil, nil, nil, nil,
+			&api.RunDefaults{WorkDir: "/app", Shell: "bash"})
 
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -837,6 +1627,331 @@ func TestClient_UnregisterImage(t *testing.T) {
 	})
 }
 
+func TestClient_RestoreImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/images/restore", r.URL.Path)
+
+		var req api.RestoreImageRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "ubuntu:22.04-a1b2c3d4", req.Image)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.RestoreImageResponse{
+			Image:   req.Image,
+			Message: "Image restored successfully",
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.RestoreImage(context.Background(), "ubuntu:22.04-a1b2c3d4")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ubuntu:22.04-a1b2c3d4", resp.Image)
+}
+
+func TestClient_SetImageAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/images/aliases", r.URL.Path)
+
+		var req api.SetImageAliasRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "python:stable", req.Alias)
+		assert.Equal(t, "python:3.12", req.Image)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.SetImageAliasResponse{
+			Alias: api.ImageAlias{Alias: req.Alias, ImageID: "python:3.12-a1b2c3d4"},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.SetImageAlias(context.Background(), "python:stable", "python:3.12")
+
+	require.NoError(t, err)
+	assert.Equal(t, "python:3.12-a1b2c3d4", resp.Alias.ImageID)
+}
+
+func TestClient_ImageAliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "/api/v1/images/aliases", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(api.ListImageAliasesResponse{
+				Aliases: []api.ImageAlias{{Alias: "python:stable", ImageID: "python:3.12-a1b2c3d4"}},
+			})
+		case "DELETE":
+			assert.Equal(t, "/api/v1/images/aliases/python:stable", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(api.RemoveImageAliasResponse{Alias: "python:stable"})
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	listResp, err := c.ListImageAliases(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, listResp.Aliases, 1)
+
+	removeResp, err := c.RemoveImageAlias(context.Background(), "python:stable")
+	require.NoError(t, err)
+	assert.Equal(t, "python:stable", removeResp.Alias)
+}
+
+func TestClient_PurgeImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/v1/admin/images/ubuntu:22.04-a1b2c3d4", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.RemoveImageResponse{
+			Image:   "ubuntu:22.04-a1b2c3d4",
+			Message: "Image removed successfully",
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.PurgeImage(context.Background(), "ubuntu:22.04-a1b2c3d4")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ubuntu:22.04-a1b2c3d4", resp.Image)
+}
+
+func TestClient_CommandPolicyRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/admin/command-policies":
+			var req api.CommandPolicyRuleRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "deny-rm-root", req.Name)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(api.CommandPolicyRuleResponse{Rule: api.CommandPolicyRule{Name: req.Name}})
+		case "PUT /api/v1/admin/command-policies/deny-rm-root":
+			_ = json.NewEncoder(w).Encode(api.CommandPolicyRuleResponse{Rule: api.CommandPolicyRule{Name: "deny-rm-root"}})
+		case "GET /api/v1/admin/command-policies":
+			_ = json.NewEncoder(w).Encode(api.ListCommandPolicyRulesResponse{
+				Rules: []api.CommandPolicyRule{{Name: "deny-rm-root"}},
+			})
+		case "DELETE /api/v1/admin/command-policies/deny-rm-root":
+			_ = json.NewEncoder(w).Encode(api.DeleteCommandPolicyRuleResponse{Name: "deny-rm-root"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+	req := api.CommandPolicyRuleRequest{Name: "deny-rm-root", Effect: "deny", MatchType: "prefix", Pattern: "rm -rf /"}
+
+	created, err := c.CreateCommandPolicyRule(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "deny-rm-root", created.Rule.Name)
+
+	_, err = c.UpdateCommandPolicyRule(ctx, "deny-rm-root", req)
+	require.NoError(t, err)
+
+	list, err := c.ListCommandPolicyRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list.Rules, 1)
+
+	deleted, err := c.DeleteCommandPolicyRule(ctx, "deny-rm-root")
+	require.NoError(t, err)
+	assert.Equal(t, "deny-rm-root", deleted.Name)
+}
+
+func TestClient_Announcements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/v1/admin/announcement":
+			var req api.AnnouncementRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			w.Header().Set(constants.AnnouncementHeader, req.Message)
+			_ = json.NewEncoder(w).Encode(api.AnnouncementResponse{Announcement: &api.Announcement{Message: req.Message}})
+		case "GET /api/v1/meta":
+			w.Header().Set(constants.AnnouncementHeader, "Outage until 3pm")
+			_ = json.NewEncoder(w).Encode(api.MetaResponse{Announcement: &api.Announcement{Message: "Outage until 3pm"}})
+		case "DELETE /api/v1/admin/announcement":
+			_ = json.NewEncoder(w).Encode(api.AnnouncementResponse{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	set, err := c.SetAnnouncement(ctx, api.AnnouncementRequest{Message: "Outage until 3pm"})
+	require.NoError(t, err)
+	assert.Equal(t, "Outage until 3pm", set.Announcement.Message)
+
+	meta, err := c.GetMeta(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, meta.Announcement)
+	assert.Equal(t, "Outage until 3pm", LastAnnouncement(), "the announcement header of the last response is kept")
+
+	_, err = c.ClearAnnouncement(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, LastAnnouncement())
+}
+
+func TestClient_RunFreeze(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/admin/freeze", r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			var req api.RunFreezeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "maintenance until 5pm", req.Message)
+			_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{
+				Freeze: api.RunFreeze{Frozen: true, Message: req.Message},
+			})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{Freeze: api.RunFreeze{Frozen: true}})
+		case http.MethodDelete:
+			_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	frozen, err := c.FreezeRuns(ctx, api.RunFreezeRequest{Message: "maintenance until 5pm"})
+	require.NoError(t, err)
+	assert.Equal(t, "maintenance until 5pm", frozen.Freeze.Message)
+
+	current, err := c.GetRunFreeze(ctx)
+	require.NoError(t, err)
+	assert.True(t, current.Freeze.Frozen)
+
+	unfrozen, err := c.UnfreezeRuns(ctx)
+	require.NoError(t, err)
+	assert.False(t, unfrozen.Freeze.Frozen)
+}
+
+func TestClient_Budgets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/v1/admin/budgets/user@example.com":
+			var req api.BudgetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.InDelta(t, 50, req.MonthlyLimitUSD, 0)
+			assert.True(t, req.HardCap)
+			_ = json.NewEncoder(w).Encode(api.BudgetResponse{Budget: &api.BudgetStatus{
+				Budget: api.Budget{UserEmail: "user@example.com", MonthlyLimitUSD: req.MonthlyLimitUSD},
+			}})
+		case "GET /api/v1/admin/budgets":
+			_ = json.NewEncoder(w).Encode(api.ListBudgetsResponse{Budgets: []api.BudgetStatus{
+				{Budget: api.Budget{UserEmail: "user@example.com"}},
+			}})
+		case "DELETE /api/v1/admin/budgets/user@example.com":
+			_ = json.NewEncoder(w).Encode(api.BudgetResponse{Message: "Budget removed successfully"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	set, err := c.SetBudget(ctx, "user@example.com", api.BudgetRequest{MonthlyLimitUSD: 50, HardCap: true})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", set.Budget.UserEmail)
+
+	list, err := c.ListBudgets(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list.Budgets, 1)
+
+	removed, err := c.RemoveBudget(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Budget removed successfully", removed.Message)
+}
+
+func TestClient_NotificationSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/v1/admin/notifications":
+			var req api.NotificationSettingsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.UsageReport.Disabled)
+			_ = json.NewEncoder(w).Encode(api.NotificationSettingsResponse{
+				Settings: api.NotificationSettings{UsageReport: req.UsageReport},
+			})
+		case "GET /api/v1/admin/notifications":
+			_ = json.NewEncoder(w).Encode(api.NotificationSettingsResponse{Settings: api.NotificationSettings{
+				UsageReport: api.UsageReportSettings{Recipients: []string{"finance@example.com"}},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	set, err := c.SetNotificationSettings(ctx, api.NotificationSettingsRequest{
+		UsageReport: api.UsageReportSettings{Disabled: true},
+	})
+	require.NoError(t, err)
+	assert.True(t, set.Settings.UsageReport.Disabled)
+
+	current, err := c.GetNotificationSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"finance@example.com"}, current.Settings.UsageReport.Recipients)
+}
+
+func TestClient_Locks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/locks":
+			_ = json.NewEncoder(w).Encode(api.ListLocksResponse{
+				Locks: []api.ExecutionLock{{Name: "deploy-prod", ExecutionID: "abc123"}},
+			})
+		case "DELETE /api/v1/locks/deploy-prod":
+			_ = json.NewEncoder(w).Encode(api.ReleaseLockResponse{Name: "deploy-prod"})
+		case "DELETE /api/v1/locks/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.ErrorResponse{
+				Error: "Not found", Code: "NOT_FOUND", Details: "lock missing is not held",
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	locks, err := c.ListLocks(ctx)
+	require.NoError(t, err)
+	require.Len(t, locks.Locks, 1)
+	assert.Equal(t, "abc123", locks.Locks[0].ExecutionID)
+
+	released, err := c.ReleaseLock(ctx, "deploy-prod")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-prod", released.Name)
+
+	_, err = c.ReleaseLock(ctx, "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[404] Not found: lock missing is not held")
+	assert.True(t, HasErrorCode(err, "NOT_FOUND"))
+	assert.False(t, HasErrorCode(err, "LOCK_HELD"))
+}
+
 func TestClient_CreateSecret(t *testing.T) {
 	t.Run("successful secret creation", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1157,6 +2272,7 @@ func TestClient_ReconcileHealth(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, "POST", r.Method)
 			assert.Equal(t, "/api/v1/health/reconcile", r.URL.Path)
+			assert.Empty(t, r.URL.RawQuery)
 
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(api.HealthReconcileResponse{
@@ -1182,7 +2298,7 @@ func TestClient_ReconcileHealth(t *testing.T) {
 		}
 		c := New(cfg, testutil.SilentLogger())
 
-		resp, err := c.ReconcileHealth(context.Background())
+		resp, err := c.ReconcileHealth(context.Background(), false)
 
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -1209,12 +2325,106 @@ func TestClient_ReconcileHealth(t *testing.T) {
 		}
 		c := New(cfg, testutil.SilentLogger())
 
-		resp, err := c.ReconcileHealth(context.Background())
+		resp, err := c.ReconcileHealth(context.Background(), false)
 
 		require.Error(t, err)
 		assert.Nil(t, resp)
 		assert.Contains(t, err.Error(), "Unauthorized")
 	})
+
+	t.Run("canary", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("canary"))
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(api.HealthReconcileResponse{
+				Status: "ok",
+				Report: &api.HealthReport{Canary: &api.CanaryReport{
+					Launched: &api.CanaryResult{ExecutionID: "canary-1", Status: "pending"},
+				}},
+			})
+		}))
+		defer server.Close()
+
+		c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+		resp, err := c.ReconcileHealth(context.Background(), true)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.Report.Canary)
+		assert.Equal(t, "canary-1", resp.Report.Canary.Launched.ExecutionID)
+	})
+}
+
+func TestClient_CleanupHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/health/cleanup", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.HealthCleanupResponse{
+			Status: "ok",
+			Report: &api.CleanupReport{DryRun: true, Resources: []api.CleanedResource{
+				{ResourceType: "log_stream", ResourceID: "task/runner/old", Action: "would_delete"},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.CleanupHealth(context.Background(), true)
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.Report)
+	assert.True(t, resp.Report.DryRun)
+	assert.Len(t, resp.Report.Resources, 1)
+}
+
+func TestClient_GetHealthStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/health/stats", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.HealthStatsResponse{Database: &api.DatabaseStats{
+			Queries: []api.QueryPatternStats{{Pattern: "GetItem runvoy-api-keys", Count: 3}},
+		}})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.GetHealthStats(context.Background())
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.Database)
+	require.Len(t, resp.Database.Queries, 1)
+	assert.Equal(t, int64(3), resp.Database.Queries[0].Count)
+}
+
+func TestClient_ListHealthReports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/health/reports", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("limit"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.HealthReportsResponse{
+			Reports: []api.HealthReport{{ReconciledCount: 2, ErrorCount: 1}},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.ListHealthReports(context.Background(), 5)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Reports, 1)
+	assert.Equal(t, 2, resp.Reports[0].ReconciledCount)
+	assert.Equal(t, 1, resp.Reports[0].ErrorCount)
 }
 
 func TestClient_GetImage(t *testing.T) {
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+var (
+	compressionMu sync.Mutex
+	// gzipRequestsAccepted is set once an API response announced that the backend accepts gzip request
+	// bodies. The backends older than the announcement would fail to decode them.
+	gzipRequestsAccepted bool
+)
+
+// recordRequestEncodings keeps whether the backend accepts gzip request bodies, from the Accept-Encoding
+// header of an API response (RFC 7694). The responses without it, such as the errors of a proxy, keep the
+// last known support.
+func recordRequestEncodings(header http.Header) {
+	acceptEncoding := header.Get(constants.AcceptEncodingHeader)
+	if acceptEncoding == "" {
+		return
+	}
+	accepted := false
+	for coding := range strings.SplitSeq(acceptEncoding, ",") {
+		coding, _, _ = strings.Cut(coding, ";")
+		if strings.EqualFold(strings.TrimSpace(coding), constants.GzipContentEncoding) {
+			accepted = true
+		}
+	}
+
+	compressionMu.Lock()
+	defer compressionMu.Unlock()
+	gzipRequestsAccepted = accepted
+}
+
+// compressRequestBody gzips a request body when the backend accepts it and the body is large enough to
+// be worth it, returning the body to send and its content encoding, empty when it is sent as is.
+func compressRequestBody(body []byte) ([]byte, string) {
+	compressionMu.Lock()
+	accepted := gzipRequestsAccepted
+	compressionMu.Unlock()
+	if !accepted || len(body) < constants.MinCompressedRequestBodySize {
+		return body, ""
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return body, ""
+	}
+	if err := writer.Close(); err != nil {
+		return body, ""
+	}
+	if compressed.Len() >= len(body) {
+		return body, ""
+	}
+	return compressed.Bytes(), constants.GzipContentEncoding
+}
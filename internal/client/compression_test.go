@@ -0,0 +1,77 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CompressesRequestsOnceAccepted(t *testing.T) {
+	recordRequestEncodings(http.Header{constants.AcceptEncodingHeader: {"identity"}})
+	t.Cleanup(func() {
+		recordRequestEncodings(http.Header{constants.AcceptEncodingHeader: {"identity"}})
+	})
+
+	var encodings []string
+	var bodies []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get(constants.ContentEncodingHeader)
+		encodings = append(encodings, encoding)
+		body := io.Reader(r.Body)
+		if encoding == constants.GzipContentEncoding {
+			reader, err := gzip.NewReader(r.Body)
+			if !assert.NoError(t, err) {
+				return
+			}
+			body = reader
+		}
+		var decoded map[string]string
+		assert.NoError(t, json.NewDecoder(body).Decode(&decoded))
+		bodies = append(bodies, decoded)
+
+		w.Header().Set(constants.AcceptEncodingHeader, constants.GzipContentEncoding)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	large := map[string]string{"script": strings.Repeat("echo hello\n", 200)}
+	small := map[string]string{"script": "echo hello"}
+	for _, body := range []map[string]string{large, large, small} {
+		_, err := c.Do(ctx, Request{Method: http.MethodPost, Path: "/api/v1/run", Body: body})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"", constants.GzipContentEncoding, ""}, encodings,
+		"only the large bodies are compressed, once the backend announced it accepts them")
+	assert.Equal(t, []map[string]string{large, large, small}, bodies)
+}
+
+func TestClient_DecompressesResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get(constants.AcceptEncodingHeader), constants.GzipContentEncoding)
+		w.Header().Set(constants.ContentEncodingHeader, constants.GzipContentEncoding)
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`{"status":"ok"}`))
+		_ = writer.Close()
+	}))
+	defer server.Close()
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+
+	resp, err := c.Do(context.Background(), Request{Method: http.MethodGet, Path: "/api/v1/health"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"ok"}`, string(resp.Body))
+}
@@ -0,0 +1,433 @@
+// Package devstack runs a local backend for development: the orchestrator and the event processor
+// of the local server on the in-memory fake backend, with its WebSocket hub streaming the logs, and
+// optionally DynamoDB Local to keep the users and the settings in.
+package devstack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// Environment variables of the local server wired by Start. They mirror the ones read by the
+// config package and the fake provider, which the CLI doesn't link.
+const (
+	backendProviderEnvVar  = "RUNVOY_BACKEND_PROVIDER"
+	portEnvVar             = "RUNVOY_PORT"
+	fakeRunnerEnvVar       = "RUNVOY_FAKE_RUNNER"
+	fakeAPIKeyEnvVar       = "RUNVOY_FAKE_API_KEY" //nolint:gosec // G101: Environment variable name
+	fakeWebSocketEnvVar    = "RUNVOY_FAKE_WEBSOCKET_ADDR"
+	dynamoDBEndpointEnvVar = "DYNAMODB_ENDPOINT"
+	// SigningSecretEnvVar is the secret the requests to the event processor are signed with.
+	SigningSecretEnvVar = "RUNVOY_PROCESSOR_SIGNING_SECRET" //nolint:gosec // G101: Environment variable name
+)
+
+const (
+	// AdminEmail is the email of the admin seeded by the fake backend.
+	AdminEmail = "admin@localhost"
+	// DefaultPort is the port of the orchestrator, the processor and the WebSocket hub use the next two.
+	DefaultPort = 56212
+	// DefaultReadyTimeout is the time given to the local server to answer its health checks.
+	DefaultReadyTimeout = 2 * time.Minute
+
+	modulePath        = "github.com/runvoy/runvoy"
+	localServerPkg    = "./cmd/local"
+	readyPollInterval = 250 * time.Millisecond
+	stopTimeout       = 10 * time.Second
+	maxResponseSize   = 1 << 20
+
+	// dynamoDBLocalImage is run in memory, its tables are gone once the local backend stops.
+	dynamoDBLocalImage = "amazon/dynamodb-local"
+	dynamoDBLocalPort  = 8000
+)
+
+// Options configures the local backend.
+type Options struct {
+	// Port is the port of the orchestrator, DefaultPort when zero.
+	Port int
+	// Runner is how the fake backend runs the commands: "script" (default) or "exec".
+	Runner string
+	// ServerBinary is the local server binary, built with the fake tag. When empty, the server is built
+	// from the sources of the runvoy module containing WorkDir.
+	ServerBinary string
+	// WorkDir is the directory the local server runs in.
+	WorkDir string
+	// ReadyTimeout is the time given to the server to be ready, DefaultReadyTimeout when zero.
+	ReadyTimeout time.Duration
+	// DynamoDBLocal runs DynamoDB Local in a Docker container, where the server keeps the users and the
+	// settings to exercise the DynamoDB repositories.
+	DynamoDBLocal bool
+	// Output receives the logs of the local server.
+	Output io.Writer
+}
+
+// Endpoints are the URLs of the local backend.
+type Endpoints struct {
+	API       string
+	Processor string
+	WebSocket string
+	// DynamoDB is the endpoint of DynamoDB Local, empty when it isn't run.
+	DynamoDB string
+}
+
+// Stack is a running local backend.
+type Stack struct {
+	Endpoints  Endpoints
+	AdminEmail string
+	APIKey     string
+	// SigningSecret signs the events sent to the processor, which rejects the unsigned ones.
+	SigningSecret string
+
+	cmd     *exec.Cmd
+	exited  chan struct{}
+	exitErr error
+	// stopErr is why DynamoDB Local couldn't be removed once the server exited.
+	stopErr error
+}
+
+// ports returns the ports of the orchestrator, the processor, the WebSocket hub and DynamoDB Local.
+func (o *Options) ports() (api, processor, webSocket, dynamoDB int) {
+	port := o.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	return port, port + 1, port + 2, port + 3
+}
+
+// Env returns the environment variables wiring the local server to the fake backend, with an admin
+// authenticating with the API key and a processor only accepting the events signed with the secret.
+func (o *Options) Env(apiKey, signingSecret string) []string {
+	api, _, webSocket, _ := o.ports()
+	env := []string{
+		backendProviderEnvVar + "=" + strings.ToLower(string(constants.Fake)),
+		portEnvVar + "=" + strconv.Itoa(api),
+		fakeAPIKeyEnvVar + "=" + apiKey,
+		fakeWebSocketEnvVar + "=127.0.0.1:" + strconv.Itoa(webSocket),
+		SigningSecretEnvVar + "=" + signingSecret,
+	}
+	if o.Runner != "" {
+		env = append(env, fakeRunnerEnvVar+"="+o.Runner)
+	}
+	if o.DynamoDBLocal {
+		env = append(env, dynamoDBEndpointEnvVar+"="+o.Endpoints().DynamoDB)
+	}
+	return env
+}
+
+// Endpoints returns the URLs of the local backend.
+func (o *Options) Endpoints() Endpoints {
+	api, processor, webSocket, dynamoDB := o.ports()
+	endpoints := Endpoints{
+		API:       fmt.Sprintf("http://localhost:%d", api),
+		Processor: fmt.Sprintf("http://localhost:%d", processor),
+		WebSocket: fmt.Sprintf("ws://127.0.0.1:%d", webSocket),
+	}
+	if o.DynamoDBLocal {
+		endpoints.DynamoDB = fmt.Sprintf("http://127.0.0.1:%d", dynamoDB)
+	}
+	return endpoints
+}
+
+// serverBinary returns the local server binary, building it from the sources when none is given.
+// The returned cleanup function removes the built binary.
+func (o *Options) serverBinary(ctx context.Context) (binary string, cleanup func(), err error) {
+	if o.ServerBinary != "" {
+		return o.ServerBinary, func() {}, nil
+	}
+	root, err := FindModuleRoot(o.WorkDir)
+	if err != nil {
+		return "", nil, err
+	}
+	dir, err := os.MkdirTemp("", constants.ProjectName+"-dev-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create the build directory: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	binary = filepath.Join(dir, "local")
+	build := exec.CommandContext(ctx, "go", "build", "-tags", "fake", "-o", binary, localServerPkg)
+	build.Dir = root
+	build.Stdout, build.Stderr = o.Output, o.Output
+	if err = build.Run(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to build the local server: %w", err)
+	}
+	return binary, cleanup, nil
+}
+
+// Start starts the local server with a new admin API key and processor signing secret, and waits for
+// its services to answer their health checks. The server is stopped when ctx is canceled or Stop is called.
+// DynamoDB Local, when run, is started first and removed once the server exits.
+func Start(ctx context.Context, opts *Options) (*Stack, error) {
+	apiKey, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the admin API key: %w", err)
+	}
+	signingSecret, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the processor signing secret: %w", err)
+	}
+
+	readyTimeout := opts.ReadyTimeout
+	if readyTimeout == 0 {
+		readyTimeout = DefaultReadyTimeout
+	}
+	stopDynamoDB := func() error { return nil }
+	if opts.DynamoDBLocal {
+		if stopDynamoDB, err = opts.startDynamoDBLocal(ctx, readyTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	binary, cleanup, err := opts.serverBinary(ctx)
+	if err != nil {
+		return nil, errors.Join(err, stopDynamoDB())
+	}
+	cmd := exec.CommandContext(ctx, binary) //nolint:gosec // G204: Binary from a CLI flag or just built
+	cmd.Dir = opts.WorkDir
+	cmd.Env = append(os.Environ(), opts.Env(apiKey, signingSecret)...)
+	cmd.Stdout, cmd.Stderr = opts.Output, opts.Output
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = stopTimeout
+	if err = cmd.Start(); err != nil {
+		cleanup()
+		return nil, errors.Join(fmt.Errorf("failed to start the local server: %w", err), stopDynamoDB())
+	}
+
+	stack := &Stack{
+		Endpoints:     opts.Endpoints(),
+		AdminEmail:    AdminEmail,
+		APIKey:        apiKey,
+		SigningSecret: signingSecret,
+		cmd:           cmd,
+		exited:        make(chan struct{}),
+	}
+	go func() {
+		stack.exitErr = cmd.Wait()
+		cleanup()
+		stack.stopErr = stopDynamoDB()
+		close(stack.exited)
+	}()
+
+	if err = stack.waitReady(ctx, readyTimeout); err != nil {
+		return nil, errors.Join(err, stack.Stop())
+	}
+	return stack, nil
+}
+
+// waitReady polls the health checks of the orchestrator and the processor until both answer.
+func (s *Stack) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	healthURLs := []string{s.Endpoints.API + "/api/v1/health", s.Endpoints.Processor + "/health"}
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	for {
+		ready := true
+		for _, url := range healthURLs {
+			if !healthy(ctx, url) {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-s.exited:
+			return fmt.Errorf("the local server exited before being ready: %w", s.Err())
+		case <-ctx.Done():
+			return fmt.Errorf("the local server wasn't ready after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// startDynamoDBLocal runs DynamoDB Local in a Docker container published on the loopback interface, and
+// waits for it to answer. The returned function removes the container.
+func (o *Options) startDynamoDBLocal(ctx context.Context, timeout time.Duration) (func() error, error) {
+	_, _, _, port := o.ports()
+	run := exec.CommandContext(ctx, "docker", "run", "--rm", "--detach", //nolint:gosec // G204: Fixed image
+		"--publish", fmt.Sprintf("127.0.0.1:%d:%d", port, dynamoDBLocalPort),
+		dynamoDBLocalImage, "-jar", "DynamoDBLocal.jar", "-inMemory")
+	run.Stderr = o.Output
+	out, err := run.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start DynamoDB Local with docker: %w", err)
+	}
+	container := strings.TrimSpace(string(out))
+	stop := func() error {
+		// ctx is canceled when the local backend is interrupted, the container is still to be removed
+		stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stopTimeout)
+		defer cancel()
+		if stopErr := exec.CommandContext(stopCtx, "docker", "stop", container).Run(); stopErr != nil {
+			return fmt.Errorf("failed to stop the DynamoDB Local container %s: %w", container, stopErr)
+		}
+		return nil
+	}
+
+	if err = waitAnswering(ctx, o.Endpoints().DynamoDB, timeout); err != nil {
+		return nil, errors.Join(fmt.Errorf("DynamoDB Local: %w", err), stop())
+	}
+	return stop, nil
+}
+
+// waitAnswering polls the URL until it answers, whatever the status.
+func waitAnswering(ctx context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return err
+		}
+		if resp, doErr := http.DefaultClient.Do(req); doErr == nil {
+			_ = resp.Body.Close()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s didn't answer after %s", url, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func healthy(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// SendEvent sends a raw event to the processor of the local backend.
+func (s *Stack) SendEvent(ctx context.Context, event []byte) ([]byte, error) {
+	return SendEvent(ctx, s.Endpoints.Processor, s.SigningSecret, event)
+}
+
+// SendEvent posts a raw event to the processor at processorURL, signed with the secret when one is given,
+// and returns the response of the processor.
+func SendEvent(ctx context.Context, processorURL, signingSecret string, event []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(processorURL, "/")+"/process", bytes.NewReader(event))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signingSecret != "" {
+		if err = auth.SignRequest(req, signingSecret); err != nil {
+			return nil, fmt.Errorf("failed to sign the request: %w", err)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the event: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the processor answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// Done returns a channel closed when the local server exits.
+func (s *Stack) Done() <-chan struct{} {
+	return s.exited
+}
+
+// Err returns why the local server exited, once Done is closed.
+func (s *Stack) Err() error {
+	if s.exitErr == nil {
+		return errors.New("exit status 0")
+	}
+	return s.exitErr
+}
+
+// Stop interrupts the local server and waits for it to exit, killing it after a timeout, and for
+// DynamoDB Local to be removed.
+func (s *Stack) Stop() error {
+	select {
+	case <-s.exited:
+		return s.stopErr
+	default:
+	}
+	// Interrupts aren't delivered on every platform, the server is killed then
+	if err := s.cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		if killErr := s.cmd.Process.Kill(); killErr != nil && !errors.Is(killErr, os.ErrProcessDone) {
+			return fmt.Errorf("failed to stop the local server: %w", killErr)
+		}
+	}
+	select {
+	case <-s.exited:
+	case <-time.After(stopTimeout):
+		_ = s.cmd.Process.Kill()
+		<-s.exited
+	}
+	return s.stopErr
+}
+
+// FindModuleRoot returns the root directory of the runvoy module containing dir.
+func FindModuleRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		if isRunvoyModule(filepath.Join(current, "go.mod")) {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("%s is not in a %s checkout, pass the local server binary built with the fake tag",
+				dir, constants.ProjectName)
+		}
+	}
+}
+
+func isRunvoyModule(goMod string) bool {
+	file, err := os.Open(goMod) //nolint:gosec // G304: go.mod of a parent directory
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")) == modulePath
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package devstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Env(t *testing.T) {
+	opts := &Options{Port: 9000, Runner: "exec"}
+
+	assert.Equal(t, []string{
+		"RUNVOY_BACKEND_PROVIDER=fake",
+		"RUNVOY_PORT=9000",
+		"RUNVOY_FAKE_API_KEY=key",
+		"RUNVOY_FAKE_WEBSOCKET_ADDR=127.0.0.1:9002",
+		"RUNVOY_PROCESSOR_SIGNING_SECRET=secret",
+		"RUNVOY_FAKE_RUNNER=exec",
+	}, opts.Env("key", "secret"))
+}
+
+func TestOptions_Env_DynamoDBLocal(t *testing.T) {
+	opts := &Options{Port: 9000, DynamoDBLocal: true}
+
+	assert.Contains(t, opts.Env("key", "secret"), "DYNAMODB_ENDPOINT=http://127.0.0.1:9003")
+}
+
+func TestOptions_Endpoints(t *testing.T) {
+	assert.Equal(t, Endpoints{
+		API:       "http://localhost:56212",
+		Processor: "http://localhost:56213",
+		WebSocket: "ws://127.0.0.1:56214",
+	}, (&Options{}).Endpoints())
+	assert.Equal(t, "http://127.0.0.1:56215", (&Options{DynamoDBLocal: true}).Endpoints().DynamoDB)
+}
+
+func TestFindModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module "+modulePath+"\n\ngo 1.25\n"), 0o600))
+	nested := filepath.Join(root, "cmd", "cli")
+	require.NoError(t, os.MkdirAll(nested, 0o750))
+
+	found, err := FindModuleRoot(nested)
+	require.NoError(t, err)
+	assert.Equal(t, root, found)
+
+	other := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(other, "go.mod"), []byte("module example.com/other\n"), 0o600))
+	_, err = FindModuleRoot(other)
+	require.ErrorContains(t, err, "is not in a runvoy checkout")
+}
+
+func TestStart_DynamoDBLocalWithoutDocker(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := Start(context.Background(), &Options{
+		ServerBinary: "true", WorkDir: t.TempDir(), Port: 1, DynamoDBLocal: true,
+	})
+
+	require.ErrorContains(t, err, "failed to start DynamoDB Local with docker")
+}
+
+func TestWaitAnswering(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	require.NoError(t, waitAnswering(context.Background(), server.URL, time.Second),
+		"any answer means DynamoDB Local is up")
+
+	err := waitAnswering(context.Background(), "http://127.0.0.1:1", 100*time.Millisecond)
+	require.ErrorContains(t, err, "didn't answer after 100ms")
+}
+
+func TestStart_ServerExitsBeforeReady(t *testing.T) {
+	_, err := Start(context.Background(), &Options{ServerBinary: "true", WorkDir: t.TempDir(), Port: 1})
+
+	require.ErrorContains(t, err, "exited before being ready")
+}
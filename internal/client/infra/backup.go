@@ -0,0 +1,91 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/crypto"
+	"github.com/runvoy/runvoy/internal/database/backup"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	awsDatabase "github.com/runvoy/runvoy/internal/providers/aws/database"
+	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+	"github.com/runvoy/runvoy/internal/providers/aws/keys"
+	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// NewBackupStore creates the backup store of the backend described by the stack outputs.
+// Currently supports: "aws".
+func NewBackupStore(ctx context.Context, provider, region string, outputs map[string]string) (backup.Store, error) {
+	providerLower := strings.ToLower(provider)
+	awsProvider := strings.ToLower(string(constants.AWS))
+	switch providerLower {
+	case awsProvider:
+		return newAWSBackupStore(ctx, region, outputs)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", provider, awsProvider)
+	}
+}
+
+func newAWSBackupStore(ctx context.Context, region string, outputs map[string]string) (backup.Store, error) {
+	required := []string{
+		"APIKeysTableName",
+		"ImageTaskDefinitionsTableName",
+		"SecretsMetadataTableName",
+		"SecretsKmsKeyArn",
+	}
+	for _, key := range required {
+		if outputs[key] == "" {
+			return nil, fmt.Errorf("stack output %s not found", key)
+		}
+	}
+
+	var awsOpts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		awsOpts = append(awsOpts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	records := dynamodb.NewBackupRepository(dynamodb.NewSDKClient(awsCfg), dynamodb.BackupTables{
+		APIKeys:         outputs["APIKeysTableName"],
+		ImageTaskDefs:   outputs["ImageTaskDefinitionsTableName"],
+		SecretsMetadata: outputs["SecretsMetadataTableName"],
+	})
+	valueStore, err := newAWSSecretsValueStore(&awsCfg, outputs)
+	if err != nil {
+		return nil, err
+	}
+	return awsDatabase.NewBackupStore(records, valueStore), nil
+}
+
+// newAWSSecretsValueStore creates the store of the secret values, encrypted with the stack's secrets key and
+// tagged with the stack's resource tags.
+func newAWSSecretsValueStore(awsCfg *aws.Config, outputs map[string]string) (*secrets.ParameterStoreManager, error) {
+	resourceTags, err := config.ParseResourceTags(outputs["ResourceTags"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid stack resource tags: %w", err)
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	envelope := crypto.NewEnvelope(
+		keys.NewKMSKeyManager(keys.NewClientAdapter(kms.NewFromConfig(*awsCfg)), outputs["SecretsKmsKeyArn"]),
+	)
+	return secrets.NewParameterStoreManager(
+		secrets.NewClientAdapter(ssm.NewFromConfig(*awsCfg)),
+		awsConstants.SecretsPrefix,
+		envelope,
+		resourceTags,
+		logger,
+	), nil
+}
@@ -0,0 +1,240 @@
+package infra
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// BillingModeOnDemand is the billing mode charging each request.
+	BillingModeOnDemand = "PAY_PER_REQUEST"
+	// BillingModeProvisioned is the billing mode charging the capacity provisioned each hour.
+	BillingModeProvisioned = "PROVISIONED"
+
+	// DefaultCapacityPeriod is the usage period capacity recommendations are based on.
+	DefaultCapacityPeriod = 14 * 24 * time.Hour
+
+	// capacityTargetUtilization is the utilization the table autoscaling tracks in provisioned mode.
+	capacityTargetUtilization = 0.7
+	// capacityMinSavings is the share of the cost switching billing modes must save to be recommended,
+	// so that recommendations don't flip-flop on small usage variations.
+	capacityMinSavings = 0.2
+	// capacitySpikeRatio is the ratio between the peak and the average hourly usage above which traffic is
+	// considered too spiky for autoscaling, which takes minutes to add capacity.
+	capacitySpikeRatio = 4
+	// capacityMaxHeadroom is the factor applied to the peak capacity to get the maximum capacity to scale up to.
+	capacityMaxHeadroom = 2
+
+	// List prices in us-east-1, only their ratios matter to compare the billing modes.
+	onDemandReadUnitPrice      = 0.125 / 1e6
+	onDemandWriteUnitPrice     = 0.625 / 1e6
+	provisionedReadHourPrice   = 0.00013
+	provisionedWriteHourPrice  = 0.00065
+	secondsPerHour             = 3600
+	minimumProvisionedCapacity = 1
+)
+
+// CapacityOptions contains all options for analyzing the capacity of the execution logs table.
+type CapacityOptions struct {
+	StackName string
+	Period    time.Duration // Usage period the recommendation is based on, DefaultCapacityPeriod if zero
+}
+
+// CapacitySettings are the billing mode and capacity settings of a table.
+// The capacities only apply to the provisioned mode, where autoscaling keeps them between the minimum and maximum.
+type CapacitySettings struct {
+	BillingMode      string
+	ReadCapacity     int
+	WriteCapacity    int
+	MaxReadCapacity  int
+	MaxWriteCapacity int
+}
+
+// CapacityUsage is the hourly usage of a table over a period, in consumed capacity units.
+// Hours without usage are left out.
+type CapacityUsage struct {
+	Period     time.Duration
+	ReadUnits  []float64
+	WriteUnits []float64
+	Throttles  int64 // Read and write requests throttled over the period
+}
+
+// CapacityReport contains the current settings of the execution logs table, its usage and the recommendation.
+type CapacityReport struct {
+	StackName      string
+	TableName      string
+	Current        CapacitySettings
+	Usage          CapacityUsage
+	Recommendation CapacityRecommendation
+}
+
+// CapacityRecommendation is the billing mode and capacity settings recommended for a table.
+type CapacityRecommendation struct {
+	Settings      CapacitySettings
+	Change        bool   // True if the settings differ from the current ones
+	Reason        string // Human readable explanation of the recommendation
+	CurrentCost   float64
+	EstimatedCost float64 // Estimated cost of the recommended settings over the usage period, in USD
+}
+
+// RecommendCapacity recommends the billing mode of a table from its usage.
+// Provisioned tables that throttled or cost more than on-demand are switched to on-demand, and on-demand tables
+// with steady traffic that would be significantly cheaper with provisioned capacity and autoscaling are switched
+// to provisioned.
+func RecommendCapacity(current CapacitySettings, usage *CapacityUsage) CapacityRecommendation {
+	onDemandCost := estimateOnDemandCost(usage)
+
+	if current.BillingMode == BillingModeProvisioned {
+		currentCost := estimateProvisionedCost(current, usage)
+		onDemand := CapacityRecommendation{
+			Settings:      CapacitySettings{BillingMode: BillingModeOnDemand},
+			Change:        true,
+			CurrentCost:   currentCost,
+			EstimatedCost: onDemandCost,
+		}
+		switch {
+		case usage.Throttles > 0:
+			onDemand.Reason = fmt.Sprintf(
+				"%d requests were throttled, on-demand capacity absorbs traffic peaks autoscaling is too slow for",
+				usage.Throttles)
+			return onDemand
+		case onDemandCost < currentCost*(1-capacityMinSavings):
+			onDemand.Reason = "the provisioned capacity is mostly unused, on-demand is cheaper"
+			return onDemand
+		}
+		return CapacityRecommendation{
+			Settings:      current,
+			Reason:        "no requests were throttled and provisioned capacity is cheaper than on-demand",
+			CurrentCost:   currentCost,
+			EstimatedCost: currentCost,
+		}
+	}
+
+	keep := CapacityRecommendation{
+		Settings:      CapacitySettings{BillingMode: BillingModeOnDemand},
+		CurrentCost:   onDemandCost,
+		EstimatedCost: onDemandCost,
+	}
+	if len(usage.ReadUnits) == 0 && len(usage.WriteUnits) == 0 {
+		keep.Reason = "the table was not used over the period"
+		return keep
+	}
+	if isSpiky(usage.ReadUnits, usage.Period) || isSpiky(usage.WriteUnits, usage.Period) {
+		keep.Reason = "traffic is too spiky for provisioned capacity, autoscaling would throttle requests"
+		return keep
+	}
+
+	provisioned := suggestProvisionedSettings(usage)
+	provisionedCost := estimateProvisionedCost(provisioned, usage)
+	if provisionedCost >= onDemandCost*(1-capacityMinSavings) {
+		keep.Reason = "provisioned capacity would not be significantly cheaper than on-demand"
+		return keep
+	}
+	return CapacityRecommendation{
+		Settings:      provisioned,
+		Change:        true,
+		Reason:        "traffic is steady, provisioned capacity with autoscaling is cheaper than on-demand",
+		CurrentCost:   onDemandCost,
+		EstimatedCost: provisionedCost,
+	}
+}
+
+// suggestProvisionedSettings returns the provisioned settings keeping the average usage at the target
+// utilization, and leaving headroom above the peak usage.
+func suggestProvisionedSettings(usage *CapacityUsage) CapacitySettings {
+	readCapacity, maxReadCapacity := suggestCapacity(usage.ReadUnits, usage.Period)
+	writeCapacity, maxWriteCapacity := suggestCapacity(usage.WriteUnits, usage.Period)
+	return CapacitySettings{
+		BillingMode:      BillingModeProvisioned,
+		ReadCapacity:     readCapacity,
+		WriteCapacity:    writeCapacity,
+		MaxReadCapacity:  maxReadCapacity,
+		MaxWriteCapacity: maxWriteCapacity,
+	}
+}
+
+func suggestCapacity(hourlyUnits []float64, period time.Duration) (minCapacity, maxCapacity int) {
+	average, peak := averagePerSecond(hourlyUnits, period), peakPerSecond(hourlyUnits)
+	minCapacity = max(minimumProvisionedCapacity, int(math.Ceil(average/capacityTargetUtilization)))
+	maxCapacity = max(minCapacity, int(math.Ceil(capacityMaxHeadroom*peak/capacityTargetUtilization)))
+	return minCapacity, maxCapacity
+}
+
+// estimateOnDemandCost returns the on-demand cost of the usage.
+func estimateOnDemandCost(usage *CapacityUsage) float64 {
+	return sum(usage.ReadUnits)*onDemandReadUnitPrice + sum(usage.WriteUnits)*onDemandWriteUnitPrice
+}
+
+// estimateProvisionedCost returns the cost of the usage with the provisioned settings, assuming autoscaling
+// keeps each hour at the target utilization within the capacity bounds.
+func estimateProvisionedCost(settings CapacitySettings, usage *CapacityUsage) float64 {
+	hours := periodHours(usage.Period)
+	return provisionedHours(usage.ReadUnits, hours, settings.ReadCapacity, settings.MaxReadCapacity)*
+		provisionedReadHourPrice +
+		provisionedHours(usage.WriteUnits, hours, settings.WriteCapacity, settings.MaxWriteCapacity)*
+			provisionedWriteHourPrice
+}
+
+// provisionedHours returns the capacity unit hours provisioned over the period. Hours without usage are
+// provisioned at the minimum capacity.
+func provisionedHours(hourlyUnits []float64, hours, minCapacity, maxCapacity int) float64 {
+	minCapacity = max(minCapacity, minimumProvisionedCapacity)
+	maxCapacity = max(maxCapacity, minCapacity)
+	total := float64(max(hours-len(hourlyUnits), 0) * minCapacity)
+	for _, units := range hourlyUnits {
+		needed := math.Ceil(units / secondsPerHour / capacityTargetUtilization)
+		total += math.Min(math.Max(needed, float64(minCapacity)), float64(maxCapacity))
+	}
+	return total
+}
+
+// isSpiky reports whether the peak hourly usage is far above the average.
+func isSpiky(hourlyUnits []float64, period time.Duration) bool {
+	average := averagePerSecond(hourlyUnits, period)
+	return average > 0 && peakPerSecond(hourlyUnits) > capacitySpikeRatio*average
+}
+
+// AverageReadPerSecond returns the average consumed read capacity units per second over the period.
+func (u *CapacityUsage) AverageReadPerSecond() float64 {
+	return averagePerSecond(u.ReadUnits, u.Period)
+}
+
+// AverageWritePerSecond returns the average consumed write capacity units per second over the period.
+func (u *CapacityUsage) AverageWritePerSecond() float64 {
+	return averagePerSecond(u.WriteUnits, u.Period)
+}
+
+// PeakReadPerSecond returns the consumed read capacity units per second of the busiest hour.
+func (u *CapacityUsage) PeakReadPerSecond() float64 {
+	return peakPerSecond(u.ReadUnits)
+}
+
+// PeakWritePerSecond returns the consumed write capacity units per second of the busiest hour.
+func (u *CapacityUsage) PeakWritePerSecond() float64 {
+	return peakPerSecond(u.WriteUnits)
+}
+
+func averagePerSecond(hourlyUnits []float64, period time.Duration) float64 {
+	return sum(hourlyUnits) / float64(periodHours(period)*secondsPerHour)
+}
+
+func peakPerSecond(hourlyUnits []float64) float64 {
+	peak := 0.0
+	for _, units := range hourlyUnits {
+		peak = math.Max(peak, units)
+	}
+	return peak / secondsPerHour
+}
+
+func periodHours(period time.Duration) int {
+	return max(int(period/time.Hour), 1)
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,234 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+)
+
+const (
+	dynamoDBMetricsNamespace = "AWS/DynamoDB"
+	capacityMetricPeriod     = 3600 // Seconds of each usage datapoint
+)
+
+// CloudWatchClient defines the interface for the CloudWatch operations reading the table usage metrics.
+// This interface enables mocking for unit tests.
+type CloudWatchClient interface {
+	GetMetricData(
+		ctx context.Context,
+		params *cloudwatch.GetMetricDataInput,
+		optFns ...func(*cloudwatch.Options),
+	) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// capacityMetrics maps the GetMetricData query IDs to the DynamoDB metrics the usage is made of.
+var capacityMetrics = map[string]string{
+	"reads":          "ConsumedReadCapacityUnits",
+	"writes":         "ConsumedWriteCapacityUnits",
+	"readThrottles":  "ReadThrottleEvents",
+	"writeThrottles": "WriteThrottleEvents",
+}
+
+// AnalyzeCapacity reads the capacity settings of the execution logs table from the stack parameters and its
+// usage from CloudWatch, and recommends its billing mode.
+func (d *AWSDeployer) AnalyzeCapacity(ctx context.Context, opts *CapacityOptions) (*CapacityReport, error) {
+	if d.metrics == nil {
+		return nil, errors.New("CloudWatch client is not configured")
+	}
+
+	stacks, err := d.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(opts.StackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stacks: %w", err)
+	}
+	if len(stacks.Stacks) == 0 {
+		return nil, errors.New("stack not found")
+	}
+	stack := stacks.Stacks[0]
+
+	tableName := stackOutput(stack.Outputs, awsConstants.ExecutionLogsTableOutput)
+	if tableName == "" {
+		return nil, fmt.Errorf("stack %s has no %s output, upgrade it first",
+			opts.StackName, awsConstants.ExecutionLogsTableOutput)
+	}
+
+	period := opts.Period
+	if period <= 0 {
+		period = DefaultCapacityPeriod
+	}
+	usage, err := d.getCapacityUsage(ctx, tableName, period)
+	if err != nil {
+		return nil, err
+	}
+
+	current := capacitySettingsFromParameters(stack.Parameters)
+	return &CapacityReport{
+		StackName:      opts.StackName,
+		TableName:      tableName,
+		Current:        current,
+		Usage:          *usage,
+		Recommendation: RecommendCapacity(current, usage),
+	}, nil
+}
+
+// ApplyCapacity updates the capacity settings of the execution logs table, keeping the stack template and
+// the other parameters.
+func (d *AWSDeployer) ApplyCapacity(
+	ctx context.Context, stackName string, settings CapacitySettings, wait bool,
+) (*DeployResult, error) {
+	stacks, err := d.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stacks: %w", err)
+	}
+	if len(stacks.Stacks) == 0 {
+		return nil, errors.New("stack not found")
+	}
+	stack := stacks.Stacks[0]
+
+	overrides := capacityParameters(settings)
+	params := make([]cfnTypes.Parameter, 0, len(stack.Parameters)+len(overrides))
+	for _, param := range stack.Parameters {
+		key := aws.ToString(param.ParameterKey)
+		if _, ok := overrides[key]; ok {
+			continue
+		}
+		params = append(params, cfnTypes.Parameter{ParameterKey: aws.String(key), UsePreviousValue: aws.Bool(true)})
+	}
+	for _, key := range capacityParameterKeys {
+		if value, ok := overrides[key]; ok {
+			params = append(params, cfnTypes.Parameter{ParameterKey: aws.String(key), ParameterValue: aws.String(value)})
+		}
+	}
+
+	result := &DeployResult{StackName: stackName, OperationType: "UPDATE", Outputs: make(map[string]string)}
+	_, err = d.client.UpdateStack(ctx, &cloudformation.UpdateStackInput{
+		StackName:           aws.String(stackName),
+		UsePreviousTemplate: aws.Bool(true),
+		Parameters:          params,
+		Capabilities:        []cfnTypes.Capability{cfnTypes.CapabilityCapabilityNamedIam},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stack: %w", err)
+	}
+
+	if !wait {
+		result.Status = stackStatusInProgress
+		return result, nil
+	}
+	if result.Status, err = d.waitForStackOperation(ctx, stackName); err != nil {
+		return nil, fmt.Errorf("stack operation failed: %w", err)
+	}
+	return result, nil
+}
+
+// capacityParameterKeys are the stack parameters holding the capacity settings, in the order they are passed.
+var capacityParameterKeys = []string{
+	awsConstants.ExecutionLogsBillingModeParameter,
+	awsConstants.ExecutionLogsReadCapacityParameter,
+	awsConstants.ExecutionLogsWriteCapacityParameter,
+	awsConstants.ExecutionLogsMaxReadCapacityParameter,
+	awsConstants.ExecutionLogsMaxWriteCapacityParameter,
+}
+
+// capacityParameters returns the stack parameters of the settings. The capacities are left out in on-demand
+// mode, where they don't apply, so that they keep their previous value.
+func capacityParameters(settings CapacitySettings) map[string]string {
+	params := map[string]string{awsConstants.ExecutionLogsBillingModeParameter: settings.BillingMode}
+	if settings.BillingMode != BillingModeProvisioned {
+		return params
+	}
+	params[awsConstants.ExecutionLogsReadCapacityParameter] = strconv.Itoa(settings.ReadCapacity)
+	params[awsConstants.ExecutionLogsWriteCapacityParameter] = strconv.Itoa(settings.WriteCapacity)
+	params[awsConstants.ExecutionLogsMaxReadCapacityParameter] = strconv.Itoa(settings.MaxReadCapacity)
+	params[awsConstants.ExecutionLogsMaxWriteCapacityParameter] = strconv.Itoa(settings.MaxWriteCapacity)
+	return params
+}
+
+// capacitySettingsFromParameters returns the capacity settings of the stack parameters.
+// Stacks deployed before the settings were introduced are on-demand.
+func capacitySettingsFromParameters(params []cfnTypes.Parameter) CapacitySettings {
+	settings := CapacitySettings{BillingMode: stackParameter(params, awsConstants.ExecutionLogsBillingModeParameter)}
+	if settings.BillingMode == "" {
+		settings.BillingMode = BillingModeOnDemand
+	}
+	settings.ReadCapacity, _ = strconv.Atoi(stackParameter(params, awsConstants.ExecutionLogsReadCapacityParameter))
+	settings.WriteCapacity, _ = strconv.Atoi(stackParameter(params, awsConstants.ExecutionLogsWriteCapacityParameter))
+	settings.MaxReadCapacity, _ = strconv.Atoi(
+		stackParameter(params, awsConstants.ExecutionLogsMaxReadCapacityParameter))
+	settings.MaxWriteCapacity, _ = strconv.Atoi(
+		stackParameter(params, awsConstants.ExecutionLogsMaxWriteCapacityParameter))
+	return settings
+}
+
+// getCapacityUsage reads the hourly consumed capacity and throttled requests of the table over the period.
+func (d *AWSDeployer) getCapacityUsage(
+	ctx context.Context, tableName string, period time.Duration,
+) (*CapacityUsage, error) {
+	end := time.Now().Truncate(time.Hour)
+	input := &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(end.Add(-period)),
+		EndTime:   aws.Time(end),
+	}
+	for _, id := range slices.Sorted(maps.Keys(capacityMetrics)) {
+		metricName := capacityMetrics[id]
+		input.MetricDataQueries = append(input.MetricDataQueries, cwTypes.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cwTypes.MetricStat{
+				Metric: &cwTypes.Metric{
+					Namespace:  aws.String(dynamoDBMetricsNamespace),
+					MetricName: aws.String(metricName),
+					Dimensions: []cwTypes.Dimension{{Name: aws.String("TableName"), Value: aws.String(tableName)}},
+				},
+				Period: aws.Int32(capacityMetricPeriod),
+				Stat:   aws.String("Sum"),
+			},
+		})
+	}
+
+	usage := &CapacityUsage{Period: period}
+	for {
+		out, err := d.metrics.GetMetricData(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metrics: %w", err)
+		}
+		for i := range out.MetricDataResults {
+			result := &out.MetricDataResults[i]
+			switch aws.ToString(result.Id) {
+			case "reads":
+				usage.ReadUnits = append(usage.ReadUnits, result.Values...)
+			case "writes":
+				usage.WriteUnits = append(usage.WriteUnits, result.Values...)
+			case "readThrottles", "writeThrottles":
+				usage.Throttles += int64(sum(result.Values))
+			}
+		}
+		if out.NextToken == nil {
+			return usage, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func stackOutput(outputs []cfnTypes.Output, key string) string {
+	for _, out := range outputs {
+		if aws.ToString(out.OutputKey) == key {
+			return aws.ToString(out.OutputValue)
+		}
+	}
+	return ""
+}
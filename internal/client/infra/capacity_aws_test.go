@@ -0,0 +1,126 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCloudWatchClient struct {
+	results []cwTypes.MetricDataResult
+	inputs  []*cloudwatch.GetMetricDataInput
+}
+
+func (m *mockCloudWatchClient) GetMetricData(
+	_ context.Context,
+	params *cloudwatch.GetMetricDataInput,
+	_ ...func(*cloudwatch.Options),
+) (*cloudwatch.GetMetricDataOutput, error) {
+	m.inputs = append(m.inputs, params)
+	return &cloudwatch.GetMetricDataOutput{MetricDataResults: m.results}, nil
+}
+
+func capacityTestStack(billingMode string) *mockCloudFormationClient {
+	return &mockCloudFormationClient{
+		describeStacksFunc: func(
+			_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options),
+		) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{
+					StackStatus: types.StackStatusUpdateComplete,
+					Parameters: []types.Parameter{
+						{ParameterKey: aws.String("ProjectName"), ParameterValue: aws.String("runvoy")},
+						{ParameterKey: aws.String("ExecutionLogsBillingMode"), ParameterValue: aws.String(billingMode)},
+						{ParameterKey: aws.String("ExecutionLogsReadCapacity"), ParameterValue: aws.String("5")},
+						{ParameterKey: aws.String("ExecutionLogsWriteCapacity"), ParameterValue: aws.String("5")},
+						{ParameterKey: aws.String("ExecutionLogsMaxReadCapacity"), ParameterValue: aws.String("100")},
+						{ParameterKey: aws.String("ExecutionLogsMaxWriteCapacity"), ParameterValue: aws.String("100")},
+					},
+					Outputs: []types.Output{
+						{OutputKey: aws.String("ExecutionLogsTableName"), OutputValue: aws.String("runvoy-execution-logs")},
+					},
+				}},
+			}, nil
+		},
+	}
+}
+
+func TestAWSDeployer_AnalyzeCapacity(t *testing.T) {
+	metrics := &mockCloudWatchClient{results: []cwTypes.MetricDataResult{
+		{Id: aws.String("reads"), Values: []float64{3600, 7200}},
+		{Id: aws.String("writes"), Values: []float64{36000}},
+		{Id: aws.String("readThrottles"), Values: []float64{2}},
+		{Id: aws.String("writeThrottles"), Values: []float64{3, 1}},
+	}}
+	deployer := &AWSDeployer{client: capacityTestStack(BillingModeProvisioned), metrics: metrics, region: "us-east-1"}
+
+	report, err := deployer.AnalyzeCapacity(context.Background(), &CapacityOptions{StackName: "runvoy-backend"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "runvoy-execution-logs", report.TableName)
+	assert.Equal(t, CapacitySettings{
+		BillingMode:      BillingModeProvisioned,
+		ReadCapacity:     5,
+		WriteCapacity:    5,
+		MaxReadCapacity:  100,
+		MaxWriteCapacity: 100,
+	}, report.Current)
+	assert.Equal(t, []float64{3600, 7200}, report.Usage.ReadUnits)
+	assert.Equal(t, int64(6), report.Usage.Throttles)
+	assert.Equal(t, DefaultCapacityPeriod, report.Usage.Period)
+	assert.Equal(t, BillingModeOnDemand, report.Recommendation.Settings.BillingMode)
+
+	require.Len(t, metrics.inputs, 1)
+	assert.Len(t, metrics.inputs[0].MetricDataQueries, len(capacityMetrics))
+	assert.Equal(t, "runvoy-execution-logs",
+		aws.ToString(metrics.inputs[0].MetricDataQueries[0].MetricStat.Metric.Dimensions[0].Value))
+}
+
+func TestAWSDeployer_ApplyCapacity(t *testing.T) {
+	var update *cloudformation.UpdateStackInput
+	client := capacityTestStack(BillingModeOnDemand)
+	client.updateStackFunc = func(
+		_ context.Context, params *cloudformation.UpdateStackInput, _ ...func(*cloudformation.Options),
+	) (*cloudformation.UpdateStackOutput, error) {
+		update = params
+		return &cloudformation.UpdateStackOutput{}, nil
+	}
+	deployer := NewAWSDeployerWithClient(client, "us-east-1")
+
+	result, err := deployer.ApplyCapacity(context.Background(), "runvoy-backend", CapacitySettings{
+		BillingMode:      BillingModeProvisioned,
+		ReadCapacity:     10,
+		WriteCapacity:    20,
+		MaxReadCapacity:  40,
+		MaxWriteCapacity: 80,
+	}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, stackStatusInProgress, result.Status)
+	require.NotNil(t, update)
+	assert.True(t, aws.ToBool(update.UsePreviousTemplate))
+
+	values := make(map[string]string)
+	for _, param := range update.Parameters {
+		if aws.ToBool(param.UsePreviousValue) {
+			values[aws.ToString(param.ParameterKey)] = "previous"
+			continue
+		}
+		values[aws.ToString(param.ParameterKey)] = aws.ToString(param.ParameterValue)
+	}
+	assert.Equal(t, map[string]string{
+		"ProjectName":                   "previous",
+		"ExecutionLogsBillingMode":      BillingModeProvisioned,
+		"ExecutionLogsReadCapacity":     "10",
+		"ExecutionLogsWriteCapacity":    "20",
+		"ExecutionLogsMaxReadCapacity":  "40",
+		"ExecutionLogsMaxWriteCapacity": "80",
+	}, values)
+}
@@ -0,0 +1,106 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// steadyUsage returns a usage consuming the same capacity units every hour of a week.
+func steadyUsage(readUnits, writeUnits float64) *CapacityUsage {
+	period := 7 * 24 * time.Hour
+	usage := &CapacityUsage{Period: period}
+	for range periodHours(period) {
+		usage.ReadUnits = append(usage.ReadUnits, readUnits)
+		usage.WriteUnits = append(usage.WriteUnits, writeUnits)
+	}
+	return usage
+}
+
+func TestRecommendCapacity(t *testing.T) {
+	provisioned := CapacitySettings{
+		BillingMode:      BillingModeProvisioned,
+		ReadCapacity:     5,
+		WriteCapacity:    5,
+		MaxReadCapacity:  100,
+		MaxWriteCapacity: 100,
+	}
+
+	t.Run("switches steady on-demand traffic to provisioned", func(t *testing.T) {
+		// 50 write units per second and 20 read units per second around the clock
+		usage := steadyUsage(20*secondsPerHour, 50*secondsPerHour)
+
+		rec := RecommendCapacity(CapacitySettings{BillingMode: BillingModeOnDemand}, usage)
+
+		assert.True(t, rec.Change)
+		assert.Equal(t, BillingModeProvisioned, rec.Settings.BillingMode)
+		assert.Equal(t, 29, rec.Settings.ReadCapacity)
+		assert.Equal(t, 72, rec.Settings.WriteCapacity)
+		assert.Equal(t, 58, rec.Settings.MaxReadCapacity)
+		assert.Equal(t, 143, rec.Settings.MaxWriteCapacity)
+		assert.Less(t, rec.EstimatedCost, rec.CurrentCost)
+	})
+
+	t.Run("keeps spiky traffic on-demand", func(t *testing.T) {
+		usage := steadyUsage(0, 0)
+		usage.WriteUnits = []float64{1000 * secondsPerHour}
+
+		rec := RecommendCapacity(CapacitySettings{BillingMode: BillingModeOnDemand}, usage)
+
+		assert.False(t, rec.Change)
+		assert.Equal(t, BillingModeOnDemand, rec.Settings.BillingMode)
+		assert.Contains(t, rec.Reason, "spiky")
+	})
+
+	t.Run("keeps unused table on-demand", func(t *testing.T) {
+		rec := RecommendCapacity(CapacitySettings{BillingMode: BillingModeOnDemand}, &CapacityUsage{Period: time.Hour})
+
+		assert.False(t, rec.Change)
+		assert.Contains(t, rec.Reason, "not used")
+	})
+
+	t.Run("switches throttled provisioned table to on-demand", func(t *testing.T) {
+		usage := steadyUsage(20*secondsPerHour, 50*secondsPerHour)
+		usage.Throttles = 42
+
+		rec := RecommendCapacity(provisioned, usage)
+
+		assert.True(t, rec.Change)
+		assert.Equal(t, BillingModeOnDemand, rec.Settings.BillingMode)
+		assert.Contains(t, rec.Reason, "42 requests were throttled")
+	})
+
+	t.Run("switches mostly unused provisioned table to on-demand", func(t *testing.T) {
+		usage := steadyUsage(0, 0)
+		usage.WriteUnits = []float64{100}
+
+		rec := RecommendCapacity(provisioned, usage)
+
+		assert.True(t, rec.Change)
+		assert.Equal(t, BillingModeOnDemand, rec.Settings.BillingMode)
+		assert.Less(t, rec.EstimatedCost, rec.CurrentCost)
+	})
+
+	t.Run("keeps busy provisioned table", func(t *testing.T) {
+		usage := steadyUsage(20*secondsPerHour, 50*secondsPerHour)
+
+		rec := RecommendCapacity(provisioned, usage)
+
+		assert.False(t, rec.Change)
+		assert.Equal(t, provisioned, rec.Settings)
+	})
+}
+
+func TestCapacityUsage_PerSecond(t *testing.T) {
+	usage := &CapacityUsage{
+		Period:     4 * time.Hour,
+		ReadUnits:  []float64{3600, 7200},
+		WriteUnits: []float64{14400},
+	}
+
+	assert.InDelta(t, 0.75, usage.AverageReadPerSecond(), 1e-9)
+	assert.InDelta(t, 2, usage.PeakReadPerSecond(), 1e-9)
+	assert.InDelta(t, 1, usage.AverageWritePerSecond(), 1e-9)
+	assert.InDelta(t, 4, usage.PeakWritePerSecond(), 1e-9)
+}
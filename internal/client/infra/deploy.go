@@ -24,6 +24,25 @@ type DeployOptions struct {
 	Parameters []string // KEY=VALUE format
 	Wait       bool     // Wait for completion
 	Region     string   // Provider region (optional)
+	AlarmTopic string   // Notification target of the backend alarms, e.g. an SNS topic ARN on AWS (optional)
+	Domain     string   // Custom domain of the API, e.g. api.mycompany.com (optional)
+	DNSZone    string   // DNS zone of the custom domain, e.g. a Route53 hosted zone ID on AWS (optional)
+	IPv6       bool     // Dual-stack endpoints and execution networking
+	KMSKey     string   // Customer managed key encrypting the backend data, e.g. a KMS key ARN on AWS (optional)
+
+	// Tags are applied to the stack resources and to the resources the backend creates at runtime (optional)
+	Tags map[string]string
+
+	SSOIssuer  string            // Issuer URL of the OpenID Connect identity provider users log in with (optional)
+	SSOClient  string            // Client ID of the runvoy application registered with the identity provider
+	GroupRoles map[string]string // Identity provider groups mapped to the roles of provisioned users (optional)
+
+	// GitHubTrusts maps the GitHub repositories trusted to run jobs from GitHub Actions to the user they act as
+	GitHubTrusts map[string]string
+
+	// ProvisionedConcurrency is the number of orchestrator instances kept initialized, 0 for none.
+	// The current setting is kept when nil.
+	ProvisionedConcurrency *int
 }
 
 // DeployResult contains the result of a deployment operation.
@@ -49,6 +68,49 @@ type DestroyResult struct {
 	NotFound  bool // True if stack was already deleted
 }
 
+// DriftOptions contains all options for detecting configuration drift.
+type DriftOptions struct {
+	StackName       string
+	ExpectedVersion string // Release version the stack is expected to run
+}
+
+// DriftResult contains the result of a drift detection.
+type DriftResult struct {
+	StackName       string
+	StackStatus     string
+	DeployedVersion string
+	ExpectedVersion string
+	DriftStatus     string // Provider drift status, e.g. "IN_SYNC" or "DRIFTED"
+	Resources       []ResourceDrift
+}
+
+// VersionMatches reports whether the deployed release version is the expected one.
+func (r *DriftResult) VersionMatches() bool {
+	return r.ExpectedVersion == "" || r.DeployedVersion == r.ExpectedVersion
+}
+
+// HasDrift reports whether the deployed backend differs from its expected configuration.
+func (r *DriftResult) HasDrift() bool {
+	return !r.VersionMatches() || len(r.Resources) > 0
+}
+
+// ResourceDrift describes a resource whose live configuration differs from the deployed template.
+type ResourceDrift struct {
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string
+	Status       string // "MODIFIED" or "DELETED"
+	Differences  []PropertyDrift
+}
+
+// PropertyDrift describes a resource property whose live value differs from the expected one.
+type PropertyDrift struct {
+	Path     string
+	Type     string // "ADD", "REMOVE" or "NOT_EQUAL"
+	Expected string
+	Actual   string
+}
+
 // TemplateSource represents the resolved template source.
 type TemplateSource struct {
 	URL  string // For remote templates (S3/HTTPS)
@@ -66,6 +128,12 @@ type Deployer interface {
 	CheckStackExists(ctx context.Context, stackName string) (bool, error)
 	// GetStackOutputs retrieves outputs from a deployed stack
 	GetStackOutputs(ctx context.Context, stackName string) (map[string]string, error)
+	// DetectDrift compares the live resources of a stack with its expected configuration
+	DetectDrift(ctx context.Context, opts *DriftOptions) (*DriftResult, error)
+	// AnalyzeCapacity reports the capacity settings and usage of the execution logs table with a recommendation
+	AnalyzeCapacity(ctx context.Context, opts *CapacityOptions) (*CapacityReport, error)
+	// ApplyCapacity updates the capacity settings of the execution logs table
+	ApplyCapacity(ctx context.Context, stackName string, settings CapacitySettings, wait bool) (*DeployResult, error)
 	// GetRegion returns the region being used
 	GetRegion() string
 }
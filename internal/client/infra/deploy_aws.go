@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,7 +14,9 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 
+	"github.com/runvoy/runvoy/internal/config"
 	awscfg "github.com/runvoy/runvoy/internal/config/aws"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 )
@@ -52,12 +57,28 @@ type CloudFormationClient interface {
 		params *cloudformation.DeleteStackInput,
 		optFns ...func(*cloudformation.Options),
 	) (*cloudformation.DeleteStackOutput, error)
+	DetectStackDrift(
+		ctx context.Context,
+		params *cloudformation.DetectStackDriftInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DetectStackDriftOutput, error)
+	DescribeStackDriftDetectionStatus(
+		ctx context.Context,
+		params *cloudformation.DescribeStackDriftDetectionStatusInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+	DescribeStackResourceDrifts(
+		ctx context.Context,
+		params *cloudformation.DescribeStackResourceDriftsInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DescribeStackResourceDriftsOutput, error)
 }
 
 // AWSDeployer implements Deployer for AWS CloudFormation.
 type AWSDeployer struct {
-	client CloudFormationClient
-	region string
+	client  CloudFormationClient
+	metrics CloudWatchClient
+	region  string
 }
 
 // NewAWSDeployer creates a new AWS deployer with the given region.
@@ -76,8 +97,9 @@ func NewAWSDeployer(ctx context.Context, region string) (*AWSDeployer, error) {
 	cfnClient := cloudformation.NewFromConfig(awsCfg)
 
 	return &AWSDeployer{
-		client: cfnClient,
-		region: awsCfg.Region,
+		client:  cfnClient,
+		metrics: cloudwatch.NewFromConfig(awsCfg),
+		region:  awsCfg.Region,
 	}, nil
 }
 
@@ -108,17 +130,21 @@ func (d *AWSDeployer) validateRegionForDefaultTemplate(template string) error {
 func (d *AWSDeployer) executeStackOperation(
 	ctx context.Context,
 	stackExists bool,
-	stackName string,
+	opts *DeployOptions,
 	templateSource *TemplateSource,
 	cfnParams []types.Parameter,
 	result *DeployResult,
 ) error {
 	if stackExists {
 		result.OperationType = "UPDATE"
-		return d.updateStack(ctx, stackName, templateSource, cfnParams)
+		params, err := d.keepPreviousParameters(ctx, opts.StackName, cfnParams)
+		if err != nil {
+			return err
+		}
+		return d.updateStack(ctx, opts.StackName, templateSource, params, opts.Tags)
 	}
 	result.OperationType = "CREATE"
-	return d.createStack(ctx, stackName, templateSource, cfnParams)
+	return d.createStack(ctx, opts.StackName, templateSource, cfnParams, opts.Tags)
 }
 
 // Deploy deploys or updates the CloudFormation stack.
@@ -132,7 +158,7 @@ func (d *AWSDeployer) Deploy(ctx context.Context, opts *DeployOptions) (*DeployR
 		return nil, fmt.Errorf("failed to resolve template: %w", err)
 	}
 
-	cfnParams, err := d.parseParametersToCFN(opts.Parameters, opts.Version)
+	cfnParams, err := d.parseParametersToCFN(opts.Parameters, opts.Version, optionParameters(opts))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse parameters: %w", err)
 	}
@@ -147,7 +173,7 @@ func (d *AWSDeployer) Deploy(ctx context.Context, opts *DeployOptions) (*DeployR
 		Outputs:   make(map[string]string),
 	}
 
-	err = d.executeStackOperation(ctx, stackExists, opts.StackName, templateSource, cfnParams, result)
+	err = d.executeStackOperation(ctx, stackExists, opts, templateSource, cfnParams, result)
 	if err != nil {
 		if strings.Contains(err.Error(), "No updates are to be performed") {
 			result.NoChanges = true
@@ -177,8 +203,41 @@ func (d *AWSDeployer) Deploy(ctx context.Context, opts *DeployOptions) (*DeployR
 	return result, nil
 }
 
+// optionParameters maps the deploy options with a stack parameter to that parameter, leaving out unset options.
+func optionParameters(opts *DeployOptions) map[string]string {
+	optionParams := make(map[string]string)
+	for key, value := range map[string]string{
+		awsConstants.AlarmTopicParameter:   opts.AlarmTopic,
+		awsConstants.DomainNameParameter:   opts.Domain,
+		awsConstants.HostedZoneParameter:   opts.DNSZone,
+		awsConstants.KMSKeyParameter:       opts.KMSKey,
+		awsConstants.ResourceTagsParameter: config.FormatResourceTags(opts.Tags),
+		awsConstants.SSOIssuerParameter:    opts.SSOIssuer,
+		awsConstants.SSOClientIDParameter:  opts.SSOClient,
+		awsConstants.GroupRolesParameter:   config.FormatGroupRoles(opts.GroupRoles),
+		awsConstants.GitHubTrustsParameter: config.FormatGitHubTrusts(opts.GitHubTrusts),
+	} {
+		if value != "" {
+			optionParams[key] = value
+		}
+	}
+	if opts.IPv6 {
+		optionParams[awsConstants.IPv6Parameter] = "true"
+	}
+	if opts.ProvisionedConcurrency != nil {
+		optionParams[awsConstants.ProvisionedConcurrencyParameter] = strconv.Itoa(*opts.ProvisionedConcurrency)
+	}
+	return optionParams
+}
+
 // parseParametersToCFN converts string parameters to CloudFormation parameter types.
-func (d *AWSDeployer) parseParametersToCFN(params []string, version string) ([]types.Parameter, error) {
+// The parameters set from deploy options, such as the alarm topic or the custom domain,
+// apply unless they are passed explicitly.
+func (d *AWSDeployer) parseParametersToCFN(
+	params []string,
+	version string,
+	optionParams map[string]string,
+) ([]types.Parameter, error) {
 	paramMap := make(map[string]string)
 
 	for _, param := range params {
@@ -198,6 +257,12 @@ func (d *AWSDeployer) parseParametersToCFN(params []string, version string) ([]t
 		paramMap["ReleaseVersion"] = awscfg.NormalizeVersion(version)
 	}
 
+	for key, value := range optionParams {
+		if _, exists := paramMap[key]; !exists {
+			paramMap[key] = value
+		}
+	}
+
 	cfnParams := make([]types.Parameter, 0, len(paramMap))
 	for key, value := range paramMap {
 		cfnParams = append(cfnParams, types.Parameter{
@@ -209,6 +274,52 @@ func (d *AWSDeployer) parseParametersToCFN(params []string, version string) ([]t
 	return cfnParams, nil
 }
 
+// keptParameters are the stack parameters keeping their current value when left out of an update, instead of
+// reverting to their default. Switching the encryption key requires rotating the secrets encrypted with it,
+// the resource tags are kept like the stack tags, and the identity provider settings and the trusted GitHub
+// repositories so that upgrades don't lock the provisioned users and the CI jobs out. The execution logs table
+// capacity settings are kept so that upgrades don't undo a switch made with the capacity command, and the
+// orchestrator provisioned concurrency so that upgrades don't bring back its cold starts.
+var keptParameters = []string{
+	awsConstants.KMSKeyParameter,
+	awsConstants.ResourceTagsParameter,
+	awsConstants.SSOIssuerParameter,
+	awsConstants.SSOClientIDParameter,
+	awsConstants.GroupRolesParameter,
+	awsConstants.GitHubTrustsParameter,
+	awsConstants.ExecutionLogsBillingModeParameter,
+	awsConstants.ExecutionLogsReadCapacityParameter,
+	awsConstants.ExecutionLogsWriteCapacityParameter,
+	awsConstants.ExecutionLogsMaxReadCapacityParameter,
+	awsConstants.ExecutionLogsMaxWriteCapacityParameter,
+	awsConstants.ProvisionedConcurrencyParameter,
+}
+
+// keepPreviousParameters adds the kept parameters the stack has and the update leaves out, with their previous value.
+func (d *AWSDeployer) keepPreviousParameters(
+	ctx context.Context,
+	stackName string,
+	params []types.Parameter,
+) ([]types.Parameter, error) {
+	output, err := d.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stacks: %w", err)
+	}
+	if len(output.Stacks) == 0 {
+		return params, nil
+	}
+
+	for _, key := range keptParameters {
+		hasKey := func(p types.Parameter) bool { return aws.ToString(p.ParameterKey) == key }
+		if !slices.ContainsFunc(params, hasKey) && slices.ContainsFunc(output.Stacks[0].Parameters, hasKey) {
+			params = append(params, types.Parameter{ParameterKey: aws.String(key), UsePreviousValue: aws.Bool(true)})
+		}
+	}
+	return params, nil
+}
+
 // CheckStackExists checks if a CloudFormation stack exists.
 func (d *AWSDeployer) CheckStackExists(ctx context.Context, stackName string) (bool, error) {
 	_, err := d.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
@@ -229,17 +340,13 @@ func (d *AWSDeployer) createStack(
 	stackName string,
 	template *TemplateSource,
 	params []types.Parameter,
+	tags map[string]string,
 ) error {
 	input := &cloudformation.CreateStackInput{
 		StackName:    aws.String(stackName),
 		Parameters:   params,
 		Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
-		Tags: []types.Tag{
-			{
-				Key:   aws.String("ManagedBy"),
-				Value: aws.String("runvoy-cli"),
-			},
-		},
+		Tags:         stackTags(tags),
 	}
 
 	if template.URL != "" {
@@ -255,18 +362,22 @@ func (d *AWSDeployer) createStack(
 	return nil
 }
 
-// updateStack updates an existing CloudFormation stack.
+// updateStack updates an existing CloudFormation stack. The stack tags are only replaced when tags are given.
 func (d *AWSDeployer) updateStack(
 	ctx context.Context,
 	stackName string,
 	template *TemplateSource,
 	params []types.Parameter,
+	tags map[string]string,
 ) error {
 	input := &cloudformation.UpdateStackInput{
 		StackName:    aws.String(stackName),
 		Parameters:   params,
 		Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
 	}
+	if len(tags) > 0 {
+		input.Tags = stackTags(tags)
+	}
 
 	if template.URL != "" {
 		input.TemplateURL = aws.String(template.URL)
@@ -281,6 +392,20 @@ func (d *AWSDeployer) updateStack(
 	return nil
 }
 
+// stackTags returns the stack tags, which CloudFormation propagates to the stack resources.
+func stackTags(tags map[string]string) []types.Tag {
+	stackTags := []types.Tag{
+		{
+			Key:   aws.String("ManagedBy"),
+			Value: aws.String("runvoy-cli"),
+		},
+	}
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		stackTags = append(stackTags, types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	return stackTags
+}
+
 // waitForStackOperation waits for a stack create/update to complete.
 func (d *AWSDeployer) waitForStackOperation(ctx context.Context, stackName string) (string, error) {
 	ticker := time.NewTicker(awsStackPollInterval)
@@ -41,6 +41,21 @@ type mockCloudFormationClient struct {
 		params *cloudformation.DeleteStackInput,
 		optFns ...func(*cloudformation.Options),
 	) (*cloudformation.DeleteStackOutput, error)
+	detectStackDriftFunc func(
+		ctx context.Context,
+		params *cloudformation.DetectStackDriftInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DetectStackDriftOutput, error)
+	describeStackDriftDetectionStatusFunc func(
+		ctx context.Context,
+		params *cloudformation.DescribeStackDriftDetectionStatusInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+	describeStackResourceDriftsFunc func(
+		ctx context.Context,
+		params *cloudformation.DescribeStackResourceDriftsInput,
+		optFns ...func(*cloudformation.Options),
+	) (*cloudformation.DescribeStackResourceDriftsOutput, error)
 }
 
 func (m *mockCloudFormationClient) DescribeStacks(
@@ -98,6 +113,39 @@ func (m *mockCloudFormationClient) DeleteStack(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockCloudFormationClient) DetectStackDrift(
+	ctx context.Context,
+	params *cloudformation.DetectStackDriftInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.DetectStackDriftOutput, error) {
+	if m.detectStackDriftFunc != nil {
+		return m.detectStackDriftFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCloudFormationClient) DescribeStackDriftDetectionStatus(
+	ctx context.Context,
+	params *cloudformation.DescribeStackDriftDetectionStatusInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	if m.describeStackDriftDetectionStatusFunc != nil {
+		return m.describeStackDriftDetectionStatusFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCloudFormationClient) DescribeStackResourceDrifts(
+	ctx context.Context,
+	params *cloudformation.DescribeStackResourceDriftsInput,
+	optFns ...func(*cloudformation.Options),
+) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+	if m.describeStackResourceDriftsFunc != nil {
+		return m.describeStackResourceDriftsFunc(ctx, params, optFns...)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestNewAWSDeployerWithClient(t *testing.T) {
 	t.Run("creates deployer with custom client", func(t *testing.T) {
 		mockClient := &mockCloudFormationClient{}
@@ -287,7 +335,7 @@ func TestAWSDeployer_parseParametersToCFN(t *testing.T) {
 			"Key2=Value2",
 		}
 
-		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0")
+		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0", nil)
 
 		require.NoError(t, err)
 		assert.Len(t, cfnParams, 4) // 2 provided + LambdaCodeBucket + ReleaseVersion
@@ -310,7 +358,7 @@ func TestAWSDeployer_parseParametersToCFN(t *testing.T) {
 			"LambdaCodeBucket=my-custom-bucket",
 		}
 
-		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0")
+		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0", nil)
 
 		require.NoError(t, err)
 
@@ -328,7 +376,7 @@ func TestAWSDeployer_parseParametersToCFN(t *testing.T) {
 			"ReleaseVersion=v2.0.0",
 		}
 
-		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0")
+		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0", nil)
 
 		require.NoError(t, err)
 
@@ -346,7 +394,7 @@ func TestAWSDeployer_parseParametersToCFN(t *testing.T) {
 			"Key1=Value1",
 		}
 
-		cfnParams, err := deployer.parseParametersToCFN(params, "")
+		cfnParams, err := deployer.parseParametersToCFN(params, "", nil)
 
 		require.NoError(t, err)
 
@@ -360,13 +408,117 @@ func TestAWSDeployer_parseParametersToCFN(t *testing.T) {
 		assert.False(t, hasReleaseVersion)
 	})
 
+	t.Run("alarm topic", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+		topic := "arn:aws:sns:us-east-1:123456789012:oncall"
+
+		optionParams := map[string]string{"AlarmTopicArn": topic}
+		cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0", optionParams)
+		require.NoError(t, err)
+		paramMap := make(map[string]string)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, topic, paramMap["AlarmTopicArn"])
+
+		cfnParams, err = deployer.parseParametersToCFN([]string{"AlarmTopicArn=explicit"}, "v1.0.0", optionParams)
+		require.NoError(t, err)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, "explicit", paramMap["AlarmTopicArn"])
+	})
+
+	t.Run("custom domain", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+		optionParams := optionParameters(&DeployOptions{Domain: "api.mycompany.com", DNSZone: "Z123456"})
+
+		cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0", optionParams)
+		require.NoError(t, err)
+		paramMap := make(map[string]string)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, "api.mycompany.com", paramMap["DomainName"])
+		assert.Equal(t, "Z123456", paramMap["HostedZoneId"])
+		_, hasAlarmTopic := paramMap["AlarmTopicArn"]
+		assert.False(t, hasAlarmTopic, "unset options are left to the template defaults")
+		_, hasIPv6 := paramMap["EnableIPv6"]
+		assert.False(t, hasIPv6)
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+
+		cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0", optionParameters(&DeployOptions{IPv6: true}))
+		require.NoError(t, err)
+		paramMap := make(map[string]string)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, "true", paramMap["EnableIPv6"])
+	})
+
+	t.Run("provisioned concurrency", func(t *testing.T) {
+		for _, tt := range []struct {
+			name        string
+			concurrency *int
+			want        string
+		}{
+			{name: "kept when unset"},
+			{name: "set", concurrency: aws.Int(2), want: "2"},
+			{name: "disabled", concurrency: aws.Int(0), want: "0"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+
+				cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0",
+					optionParameters(&DeployOptions{ProvisionedConcurrency: tt.concurrency}))
+				require.NoError(t, err)
+				paramMap := make(map[string]string)
+				for _, p := range cfnParams {
+					paramMap[*p.ParameterKey] = *p.ParameterValue
+				}
+				assert.Equal(t, tt.want, paramMap["OrchestratorProvisionedConcurrency"])
+			})
+		}
+	})
+
+	t.Run("resource tags", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+		optionParams := optionParameters(&DeployOptions{Tags: map[string]string{"team": "data", "env": "prod"}})
+
+		cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0", optionParams)
+		require.NoError(t, err)
+		paramMap := make(map[string]string)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, "env=prod,team=data", paramMap["ResourceTags"])
+	})
+
+	t.Run("github trusts", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
+		optionParams := optionParameters(&DeployOptions{
+			GitHubTrusts: map[string]string{"acme/api": "ci@acme.com", "acme/*": "ci-readonly@acme.com"},
+		})
+
+		cfnParams, err := deployer.parseParametersToCFN(nil, "v1.0.0", optionParams)
+		require.NoError(t, err)
+		paramMap := make(map[string]string)
+		for _, p := range cfnParams {
+			paramMap[*p.ParameterKey] = *p.ParameterValue
+		}
+		assert.Equal(t, "acme/*=ci-readonly@acme.com,acme/api=ci@acme.com", paramMap["GitHubTrusts"])
+	})
+
 	t.Run("invalid parameter format", func(t *testing.T) {
 		deployer := NewAWSDeployerWithClient(&mockCloudFormationClient{}, "us-east-1")
 		params := []string{
 			"InvalidParameter",
 		}
 
-		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0")
+		cfnParams, err := deployer.parseParametersToCFN(params, "v1.0.0", nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid parameter format")
@@ -503,6 +655,62 @@ func TestAWSDeployer_Deploy_NoWait(t *testing.T) {
 		assert.Equal(t, "NO_CHANGES", result.Status)
 		assert.True(t, result.NoChanges)
 	})
+
+	t.Run("update keeps the encryption key", func(t *testing.T) {
+		var updateInput *cloudformation.UpdateStackInput
+		mockClient := &mockCloudFormationClient{
+			describeStacksFunc: func(
+				_ context.Context,
+				params *cloudformation.DescribeStacksInput,
+				_ ...func(*cloudformation.Options),
+			) (*cloudformation.DescribeStacksOutput, error) {
+				return &cloudformation.DescribeStacksOutput{
+					Stacks: []types.Stack{
+						{
+							StackName:   params.StackName,
+							StackStatus: types.StackStatusCreateComplete,
+							Parameters: []types.Parameter{
+								{ParameterKey: aws.String("KmsKeyArn"), ParameterValue: aws.String("arn:aws:kms:key")},
+							},
+						},
+					},
+				}, nil
+			},
+			updateStackFunc: func(
+				_ context.Context,
+				params *cloudformation.UpdateStackInput,
+				_ ...func(*cloudformation.Options),
+			) (*cloudformation.UpdateStackOutput, error) {
+				updateInput = params
+				return &cloudformation.UpdateStackOutput{}, nil
+			},
+		}
+
+		deployer := NewAWSDeployerWithClient(mockClient, "us-east-1")
+		_, err := deployer.Deploy(context.Background(), &DeployOptions{
+			StackName: "test-stack",
+			Template:  "https://example.com/template.yaml",
+			Version:   "v1.0.0",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, updateInput)
+		assert.Contains(t, updateInput.Parameters, types.Parameter{
+			ParameterKey:     aws.String("KmsKeyArn"),
+			UsePreviousValue: aws.Bool(true),
+		})
+
+		_, err = deployer.Deploy(context.Background(), &DeployOptions{
+			StackName: "test-stack",
+			Template:  "https://example.com/template.yaml",
+			Version:   "v1.0.0",
+			KMSKey:    "arn:aws:kms:new-key",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, updateInput.Parameters, types.Parameter{
+			ParameterKey:   aws.String("KmsKeyArn"),
+			ParameterValue: aws.String("arn:aws:kms:new-key"),
+		})
+	})
 }
 
 func TestAWSDeployer_Destroy(t *testing.T) {
@@ -633,7 +841,7 @@ func TestAWSDeployer_CreateUpdateStack(t *testing.T) {
 			{ParameterKey: aws.String("Key1"), ParameterValue: aws.String("Value1")},
 		}
 
-		err := deployer.createStack(context.Background(), "test-stack", template, params)
+		err := deployer.createStack(context.Background(), "test-stack", template, params, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, capturedInput)
@@ -660,7 +868,7 @@ func TestAWSDeployer_CreateUpdateStack(t *testing.T) {
 
 		deployer := NewAWSDeployerWithClient(mockClient, "us-east-1")
 		template := &TemplateSource{Body: "template body content"}
-		err := deployer.createStack(context.Background(), "test-stack", template, []types.Parameter{})
+		err := deployer.createStack(context.Background(), "test-stack", template, []types.Parameter{}, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, capturedInput)
@@ -690,7 +898,7 @@ func TestAWSDeployer_CreateUpdateStack(t *testing.T) {
 			{ParameterKey: aws.String("Key1"), ParameterValue: aws.String("Value1")},
 		}
 
-		err := deployer.updateStack(context.Background(), "test-stack", template, params)
+		err := deployer.updateStack(context.Background(), "test-stack", template, params, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, capturedInput)
@@ -716,7 +924,7 @@ func TestAWSDeployer_CreateUpdateStack(t *testing.T) {
 
 		deployer := NewAWSDeployerWithClient(mockClient, "us-east-1")
 		template := &TemplateSource{Body: "updated template body"}
-		err := deployer.updateStack(context.Background(), "test-stack", template, []types.Parameter{})
+		err := deployer.updateStack(context.Background(), "test-stack", template, []types.Parameter{}, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, capturedInput)
@@ -724,6 +932,44 @@ func TestAWSDeployer_CreateUpdateStack(t *testing.T) {
 		assert.Equal(t, "updated template body", *capturedInput.TemplateBody)
 		assert.Nil(t, capturedInput.TemplateURL)
 	})
+
+	t.Run("stack tags", func(t *testing.T) {
+		var createInput *cloudformation.CreateStackInput
+		var updateInput *cloudformation.UpdateStackInput
+		mockClient := &mockCloudFormationClient{
+			createStackFunc: func(
+				_ context.Context,
+				params *cloudformation.CreateStackInput,
+				_ ...func(*cloudformation.Options),
+			) (*cloudformation.CreateStackOutput, error) {
+				createInput = params
+				return &cloudformation.CreateStackOutput{StackId: aws.String("stack-id")}, nil
+			},
+			updateStackFunc: func(
+				_ context.Context,
+				params *cloudformation.UpdateStackInput,
+				_ ...func(*cloudformation.Options),
+			) (*cloudformation.UpdateStackOutput, error) {
+				updateInput = params
+				return &cloudformation.UpdateStackOutput{StackId: aws.String("stack-id")}, nil
+			},
+		}
+
+		deployer := NewAWSDeployerWithClient(mockClient, "us-east-1")
+		template := &TemplateSource{Body: "template body content"}
+		tags := map[string]string{"team": "data"}
+		require.NoError(t, deployer.createStack(context.Background(), "test-stack", template, nil, tags))
+		assert.Equal(t, []types.Tag{
+			{Key: aws.String("ManagedBy"), Value: aws.String("runvoy-cli")},
+			{Key: aws.String("team"), Value: aws.String("data")},
+		}, createInput.Tags)
+
+		require.NoError(t, deployer.updateStack(context.Background(), "test-stack", template, nil, nil))
+		assert.Nil(t, updateInput.Tags, "the stack tags are kept when no tags are given")
+
+		require.NoError(t, deployer.updateStack(context.Background(), "test-stack", template, nil, tags))
+		assert.Equal(t, createInput.Tags, updateInput.Tags)
+	})
 }
 
 func TestAWSDeployer_GetStackStatus(t *testing.T) {
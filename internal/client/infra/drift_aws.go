@@ -0,0 +1,152 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	awscfg "github.com/runvoy/runvoy/internal/config/aws"
+)
+
+const (
+	awsDriftPollInterval     = 2 * time.Second
+	awsDriftDetectionTimeout = 5 * time.Minute
+	releaseVersionParameter  = "ReleaseVersion"
+)
+
+// DetectDrift runs CloudFormation drift detection on the stack and compares its ReleaseVersion
+// parameter with the expected version.
+func (d *AWSDeployer) DetectDrift(ctx context.Context, opts *DriftOptions) (*DriftResult, error) {
+	stacks, err := d.client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(opts.StackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stacks: %w", err)
+	}
+	if len(stacks.Stacks) == 0 {
+		return nil, errors.New("stack not found")
+	}
+	stack := stacks.Stacks[0]
+
+	result := &DriftResult{
+		StackName:       opts.StackName,
+		StackStatus:     string(stack.StackStatus),
+		DeployedVersion: stackParameter(stack.Parameters, releaseVersionParameter),
+	}
+	if opts.ExpectedVersion != "" {
+		result.ExpectedVersion = awscfg.NormalizeVersion(opts.ExpectedVersion)
+	}
+
+	detection, err := d.client.DetectStackDrift(ctx, &cloudformation.DetectStackDriftInput{
+		StackName: aws.String(opts.StackName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start drift detection: %w", err)
+	}
+
+	status, err := d.waitForDriftDetection(ctx, aws.ToString(detection.StackDriftDetectionId))
+	if err != nil {
+		return nil, err
+	}
+	result.DriftStatus = string(status.StackDriftStatus)
+
+	if result.Resources, err = d.listResourceDrifts(ctx, opts.StackName); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// waitForDriftDetection polls a drift detection operation until it is no longer in progress.
+// A failed detection still returns the results of the resources that could be checked.
+func (d *AWSDeployer) waitForDriftDetection(
+	ctx context.Context, detectionID string,
+) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	ticker := time.NewTicker(awsDriftPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.After(awsDriftDetectionTimeout)
+
+	for {
+		status, err := d.client.DescribeStackDriftDetectionStatus(ctx,
+			&cloudformation.DescribeStackDriftDetectionStatusInput{
+				StackDriftDetectionId: aws.String(detectionID),
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get drift detection status: %w", err)
+		}
+		if status.DetectionStatus != types.StackDriftDetectionStatusDetectionInProgress {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context canceled: %w", ctx.Err())
+		case <-timeout:
+			return nil, errors.New("timeout waiting for drift detection")
+		case <-ticker.C:
+		}
+	}
+}
+
+// listResourceDrifts returns the modified and deleted resources found by the last drift detection.
+func (d *AWSDeployer) listResourceDrifts(ctx context.Context, stackName string) ([]ResourceDrift, error) {
+	var drifts []ResourceDrift
+	var nextToken *string
+
+	for {
+		out, err := d.client.DescribeStackResourceDrifts(ctx, &cloudformation.DescribeStackResourceDriftsInput{
+			StackName: aws.String(stackName),
+			StackResourceDriftStatusFilters: []types.StackResourceDriftStatus{
+				types.StackResourceDriftStatusModified,
+				types.StackResourceDriftStatusDeleted,
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe resource drifts: %w", err)
+		}
+
+		for i := range out.StackResourceDrifts {
+			drifts = append(drifts, toResourceDrift(&out.StackResourceDrifts[i]))
+		}
+
+		if out.NextToken == nil {
+			return drifts, nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+func toResourceDrift(drift *types.StackResourceDrift) ResourceDrift {
+	differences := make([]PropertyDrift, 0, len(drift.PropertyDifferences))
+	for _, diff := range drift.PropertyDifferences {
+		differences = append(differences, PropertyDrift{
+			Path:     aws.ToString(diff.PropertyPath),
+			Type:     string(diff.DifferenceType),
+			Expected: aws.ToString(diff.ExpectedValue),
+			Actual:   aws.ToString(diff.ActualValue),
+		})
+	}
+
+	return ResourceDrift{
+		LogicalID:    aws.ToString(drift.LogicalResourceId),
+		PhysicalID:   aws.ToString(drift.PhysicalResourceId),
+		ResourceType: aws.ToString(drift.ResourceType),
+		Status:       string(drift.StackResourceDriftStatus),
+		Differences:  differences,
+	}
+}
+
+func stackParameter(params []types.Parameter, key string) string {
+	for _, param := range params {
+		if aws.ToString(param.ParameterKey) == key {
+			return aws.ToString(param.ParameterValue)
+		}
+	}
+	return ""
+}
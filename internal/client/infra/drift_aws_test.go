@@ -0,0 +1,150 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDriftMockClient(version string, drifts ...[]types.StackResourceDrift) *mockCloudFormationClient {
+	page := 0
+	return &mockCloudFormationClient{
+		describeStacksFunc: func(
+			_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options),
+		) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{
+				Stacks: []types.Stack{{
+					StackStatus: types.StackStatusUpdateComplete,
+					Parameters: []types.Parameter{
+						{ParameterKey: aws.String("ProjectName"), ParameterValue: aws.String("runvoy")},
+						{ParameterKey: aws.String("ReleaseVersion"), ParameterValue: aws.String(version)},
+					},
+				}},
+			}, nil
+		},
+		detectStackDriftFunc: func(
+			_ context.Context, _ *cloudformation.DetectStackDriftInput, _ ...func(*cloudformation.Options),
+		) (*cloudformation.DetectStackDriftOutput, error) {
+			return &cloudformation.DetectStackDriftOutput{StackDriftDetectionId: aws.String("detection-1")}, nil
+		},
+		describeStackDriftDetectionStatusFunc: func(
+			_ context.Context, params *cloudformation.DescribeStackDriftDetectionStatusInput,
+			_ ...func(*cloudformation.Options),
+		) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+			status := types.StackDriftStatusInSync
+			if len(drifts) > 0 && len(drifts[0]) > 0 {
+				status = types.StackDriftStatusDrifted
+			}
+			return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+				StackDriftDetectionId: params.StackDriftDetectionId,
+				DetectionStatus:       types.StackDriftDetectionStatusDetectionComplete,
+				StackDriftStatus:      status,
+			}, nil
+		},
+		describeStackResourceDriftsFunc: func(
+			_ context.Context, params *cloudformation.DescribeStackResourceDriftsInput,
+			_ ...func(*cloudformation.Options),
+		) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+			out := &cloudformation.DescribeStackResourceDriftsOutput{}
+			if page < len(drifts) {
+				out.StackResourceDrifts = drifts[page]
+			}
+			page++
+			if page < len(drifts) {
+				out.NextToken = aws.String("next")
+			}
+			return out, nil
+		},
+	}
+}
+
+func TestAWSDeployer_DetectDrift(t *testing.T) {
+	t.Run("in sync", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(newDriftMockClient("1.2.3"), "us-east-1")
+
+		result, err := deployer.DetectDrift(context.Background(), &DriftOptions{
+			StackName:       "runvoy-backend",
+			ExpectedVersion: "v1.2.3",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE_COMPLETE", result.StackStatus)
+		assert.Equal(t, "1.2.3", result.DeployedVersion)
+		assert.Equal(t, "1.2.3", result.ExpectedVersion)
+		assert.Equal(t, "IN_SYNC", result.DriftStatus)
+		assert.False(t, result.HasDrift())
+	})
+
+	t.Run("drifted resources across pages", func(t *testing.T) {
+		client := newDriftMockClient("1.2.3",
+			[]types.StackResourceDrift{{
+				LogicalResourceId:        aws.String("ExecutionsTable"),
+				PhysicalResourceId:       aws.String("runvoy-executions"),
+				ResourceType:             aws.String("AWS::DynamoDB::Table"),
+				StackResourceDriftStatus: types.StackResourceDriftStatusModified,
+				PropertyDifferences: []types.PropertyDifference{{
+					PropertyPath:   aws.String("/TimeToLiveSpecification/Enabled"),
+					DifferenceType: types.DifferenceTypeNotEqual,
+					ExpectedValue:  aws.String("true"),
+					ActualValue:    aws.String("false"),
+				}},
+			}},
+			[]types.StackResourceDrift{{
+				LogicalResourceId:        aws.String("LambdaLogGroup"),
+				ResourceType:             aws.String("AWS::Logs::LogGroup"),
+				StackResourceDriftStatus: types.StackResourceDriftStatusDeleted,
+			}},
+		)
+		deployer := NewAWSDeployerWithClient(client, "us-east-1")
+
+		result, err := deployer.DetectDrift(context.Background(), &DriftOptions{StackName: "runvoy-backend"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "DRIFTED", result.DriftStatus)
+		require.Len(t, result.Resources, 2)
+		assert.Equal(t, "ExecutionsTable", result.Resources[0].LogicalID)
+		assert.Equal(t, "MODIFIED", result.Resources[0].Status)
+		assert.Equal(t, []PropertyDrift{{
+			Path:     "/TimeToLiveSpecification/Enabled",
+			Type:     "NOT_EQUAL",
+			Expected: "true",
+			Actual:   "false",
+		}}, result.Resources[0].Differences)
+		assert.Equal(t, "DELETED", result.Resources[1].Status)
+		assert.True(t, result.HasDrift())
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		deployer := NewAWSDeployerWithClient(newDriftMockClient("1.2.0"), "us-east-1")
+
+		result, err := deployer.DetectDrift(context.Background(), &DriftOptions{
+			StackName:       "runvoy-backend",
+			ExpectedVersion: "1.2.3",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.VersionMatches())
+		assert.True(t, result.HasDrift())
+	})
+
+	t.Run("drift detection error", func(t *testing.T) {
+		client := newDriftMockClient("1.2.3")
+		client.detectStackDriftFunc = func(
+			_ context.Context, _ *cloudformation.DetectStackDriftInput, _ ...func(*cloudformation.Options),
+		) (*cloudformation.DetectStackDriftOutput, error) {
+			return nil, errors.New("stack is being updated")
+		}
+		deployer := NewAWSDeployerWithClient(client, "us-east-1")
+
+		_, err := deployer.DetectDrift(context.Background(), &DriftOptions{StackName: "runvoy-backend"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to start drift detection")
+	})
+}
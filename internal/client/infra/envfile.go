@@ -0,0 +1,177 @@
+package infra
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// envLineRegex matches a key-value pair in .env format: KEY=VALUE or KEY="VALUE".
+var envLineRegex = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// EnvFileMerge is the result of merging environment variables into a .env file.
+type EnvFileMerge struct {
+	// Content is the merged content of the file.
+	Content string
+	// Updated are the names of the variables already in the file with another value, sorted.
+	Updated []string
+	// Added are the names of the variables missing from the file, sorted.
+	Added []string
+	// Unchanged is the number of variables already in the file with the same value.
+	Unchanged int
+}
+
+// Drifted reports whether the file differed from the merged variables.
+func (m *EnvFileMerge) Drifted() bool {
+	return len(m.Updated) > 0 || len(m.Added) > 0
+}
+
+// MergeEnvFile merges the variables into the .env file at path, which may not exist yet, and returns the merged
+// content without writing it. Comments, blank lines and formatting are preserved, the values of the variables
+// already in the file are replaced and the other variables are appended in name order. The variables of the file
+// missing from vars are kept, as local settings.
+func MergeEnvFile(path string, vars map[string]string) (*EnvFileMerge, error) {
+	lines, err := readEnvFileLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := maps.Clone(vars)
+
+	merge := &EnvFileMerge{}
+	var result strings.Builder
+	for _, line := range lines {
+		matches := envLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			result.WriteString(line + "\n")
+			continue
+		}
+		key := matches[1]
+		value, ok := pending[key]
+		if !ok {
+			result.WriteString(line + "\n")
+			continue
+		}
+		formatted := formatEnvValue(value)
+		if strings.TrimSpace(matches[2]) == formatted {
+			result.WriteString(line + "\n")
+			merge.Unchanged++
+		} else {
+			fmt.Fprintf(&result, "%s=%s\n", key, formatted)
+			merge.Updated = append(merge.Updated, key)
+		}
+		delete(pending, key)
+	}
+
+	if len(pending) > 0 && len(lines) > 0 {
+		result.WriteString("\n# Synced from the backend\n")
+	}
+	keys := make([]string, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&result, "%s=%s\n", key, formatEnvValue(pending[key]))
+	}
+	slices.Sort(merge.Updated)
+	merge.Added = keys
+
+	merge.Content = result.String()
+	return merge, nil
+}
+
+// ParseEnvFile returns the variables of the .env file at path, in the format MergeEnvFile writes: KEY=VALUE
+// lines, the values optionally in double quotes with \" \n and \t escapes or in single quotes taken as is,
+// comments starting with # and blank lines. The lines in another format are reported with their number.
+func ParseEnvFile(path string) (map[string]string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	lines, err := readEnvFileLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]string, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		matches := envLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, i+1)
+		}
+		value, valueErr := parseEnvValue(matches[2])
+		if valueErr != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, valueErr)
+		}
+		vars[matches[1]] = value
+	}
+	return vars, nil
+}
+
+// parseEnvValue parses a value of a .env file, the reverse of formatEnvValue. The unquoted values end at
+// a " #" comment.
+func parseEnvValue(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		unquoted, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted value %s", raw)
+		}
+		return strings.NewReplacer(`\"`, `"`, `\n`, "\n", `\t`, "\t").Replace(unquoted), nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", fmt.Errorf("invalid single-quoted value %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	default:
+		value, _, _ := strings.Cut(raw, " #")
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// readEnvFileLines returns the lines of the .env file, or none if it doesn't exist.
+func readEnvFileLines(path string) ([]string, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: File path from CLI flag is intentional
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// formatEnvValue formats a value for a .env file, quoting the values with quotes, spaces or special characters.
+func formatEnvValue(value string) string {
+	if !strings.ContainsAny(value, "\" #\n\t") && !strings.HasPrefix(value, "'") {
+		return value
+	}
+	escaped := strings.ReplaceAll(value, "\"", "\\\"")
+	escaped = strings.ReplaceAll(escaped, "\n", "\\n")
+	escaped = strings.ReplaceAll(escaped, "\t", "\\t")
+	return fmt.Sprintf("%q", escaped)
+}
@@ -0,0 +1,127 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := "# Local settings\nRUNVOY_LOG_LEVEL=DEBUG\nKEEP=me\nSAME = \"a b\"\n\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	merge, err := MergeEnvFile(path, map[string]string{
+		"RUNVOY_LOG_LEVEL": "INFO",
+		"SAME":             "a b",
+		"B_VAR":            "two words",
+		"A_VAR":            "1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RUNVOY_LOG_LEVEL"}, merge.Updated)
+	assert.Equal(t, []string{"A_VAR", "B_VAR"}, merge.Added)
+	assert.Equal(t, 1, merge.Unchanged)
+	assert.True(t, merge.Drifted())
+	assert.Equal(t,
+		"# Local settings\nRUNVOY_LOG_LEVEL=INFO\nKEEP=me\nSAME = \"a b\"\n\n\n# Synced from the backend\n"+
+			"A_VAR=1\nB_VAR=\"two words\"\n",
+		merge.Content)
+}
+
+func TestMergeEnvFile_InSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("A=1\nLOCAL=only\n"), 0o600))
+
+	merge, err := MergeEnvFile(path, map[string]string{"A": "1"})
+
+	require.NoError(t, err)
+	assert.False(t, merge.Drifted())
+	assert.Equal(t, "A=1\nLOCAL=only\n", merge.Content)
+}
+
+func TestMergeEnvFile_MissingFile(t *testing.T) {
+	merge, err := MergeEnvFile(filepath.Join(t.TempDir(), ".env"), map[string]string{"A": "1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "A=1\n", merge.Content)
+	assert.Empty(t, merge.Updated)
+	assert.Equal(t, []string{"A"}, merge.Added)
+}
+
+func TestParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env.staging")
+	content := "# Staging\nREGION=eu-west-1\n  SPACED = \"a b\"\nLITERAL='$HOME \"x\"'\nCOMMENTED=value # note\nEMPTY=\n\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	vars, err := ParseEnvFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"REGION":    "eu-west-1",
+		"SPACED":    "a b",
+		"LITERAL":   `$HOME "x"`,
+		"COMMENTED": "value",
+		"EMPTY":     "",
+	}, vars)
+}
+
+func TestParseEnvFile_ReadsMergedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	want := map[string]string{
+		"PLAIN":   "plain",
+		"QUOTES":  `say "hi"`,
+		"LINES":   "one\ntwo\tthree",
+		"HASH":    "a #b",
+		"SINGLE":  "'quoted'",
+		"NOTHING": "",
+	}
+	merge, err := MergeEnvFile(path, want)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(merge.Content), 0o600))
+
+	vars, err := ParseEnvFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, want, vars)
+}
+
+func TestParseEnvFile_Errors(t *testing.T) {
+	_, err := ParseEnvFile(filepath.Join(t.TempDir(), ".env"))
+	assert.ErrorContains(t, err, "failed to read")
+
+	tests := []struct {
+		content string
+		wantErr string
+	}{
+		{content: "A=1\nexport B=2\n", wantErr: ".env:2: expected KEY=VALUE"},
+		{content: "A=\"unterminated\n", wantErr: ".env:1: invalid double-quoted value"},
+		{content: "A='unterminated\n", wantErr: ".env:1: invalid single-quoted value"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+		_, err = ParseEnvFile(path)
+		assert.ErrorContains(t, err, tt.wantErr, tt.content)
+	}
+}
+
+func TestFormatEnvValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "plain", want: "plain"},
+		{value: "", want: ""},
+		{value: "with space", want: `"with space"`},
+		{value: "with#hash", want: `"with#hash"`},
+		{value: "'quoted'", want: `"'quoted'"`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatEnvValue(tt.value), tt.value)
+	}
+}
@@ -0,0 +1,42 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/crypto"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// NewKeyRotator creates the rotator of the encrypted values of the backend described by the stack outputs,
+// re-encrypting them under the current key of the stack.
+// Currently supports: "aws".
+func NewKeyRotator(ctx context.Context, provider, region string, outputs map[string]string) (crypto.Rotator, error) {
+	providerLower := strings.ToLower(provider)
+	awsProvider := strings.ToLower(string(constants.AWS))
+	switch providerLower {
+	case awsProvider:
+		return newAWSKeyRotator(ctx, region, outputs)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", provider, awsProvider)
+	}
+}
+
+func newAWSKeyRotator(ctx context.Context, region string, outputs map[string]string) (crypto.Rotator, error) {
+	if outputs["SecretsKmsKeyArn"] == "" {
+		return nil, fmt.Errorf("stack output %s not found", "SecretsKmsKeyArn")
+	}
+
+	var awsOpts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		awsOpts = append(awsOpts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return newAWSSecretsValueStore(&awsCfg, outputs)
+}
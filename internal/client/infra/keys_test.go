@@ -0,0 +1,18 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKeyRotator_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewKeyRotator(ctx, "azure", "", map[string]string{"SecretsKmsKeyArn": "arn:aws:kms:key"})
+	assert.ErrorContains(t, err, "unsupported provider: azure")
+
+	_, err = NewKeyRotator(ctx, "aws", "us-east-1", map[string]string{})
+	assert.ErrorContains(t, err, "stack output SecretsKmsKeyArn not found")
+}
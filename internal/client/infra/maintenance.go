@@ -0,0 +1,59 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// Maintainer runs the maintenance operations of the operators on the resources of a backend provider.
+type Maintainer interface {
+	// DeleteBucket empties and deletes a storage bucket. It returns the number of objects and object versions
+	// deleted, or that would be deleted on a dry run.
+	DeleteBucket(ctx context.Context, bucket string, dryRun bool) (int, error)
+	// TruncateTable deletes all the items of a database table. It returns the number of items deleted,
+	// or that would be deleted on a dry run.
+	TruncateTable(ctx context.Context, table string, dryRun bool) (int, error)
+	// GetComponentEnv returns the environment variables of a backend component of the stack with the outputs.
+	GetComponentEnv(ctx context.Context, component BackendComponent, outputs map[string]string) (map[string]string, error)
+}
+
+// BackendComponent is a deployed component of the backend.
+type BackendComponent string
+
+const (
+	// ComponentOrchestrator is the component serving the API.
+	ComponentOrchestrator BackendComponent = "orchestrator"
+	// ComponentProcessor is the component processing the asynchronous events.
+	ComponentProcessor BackendComponent = "processor"
+)
+
+// BackendComponents returns the deployed components of the backend.
+func BackendComponents() []BackendComponent {
+	return []BackendComponent{ComponentOrchestrator, ComponentProcessor}
+}
+
+// NewMaintainer creates the maintainer of the resources of the provider in the region.
+// Currently supports: "aws".
+func NewMaintainer(ctx context.Context, provider, region string) (Maintainer, error) {
+	providerLower := strings.ToLower(provider)
+	awsProvider := strings.ToLower(string(constants.AWS))
+	switch providerLower {
+	case awsProvider:
+		var awsOpts []func(*awsconfig.LoadOptions) error
+		if region != "" {
+			awsOpts = append(awsOpts, awsconfig.WithRegion(region))
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		return NewAWSMaintainer(&awsCfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", provider, awsProvider)
+	}
+}
@@ -0,0 +1,362 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamoTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// s3DeleteObjectsLimit is the maximum number of objects of a DeleteObjects call.
+	s3DeleteObjectsLimit = 1000
+	// s3DefaultRegion is the region of the buckets without location constraint.
+	s3DefaultRegion = "us-east-1"
+	// truncateScanPageSize is the number of items read by each scan of a table truncation.
+	truncateScanPageSize = 100
+)
+
+// S3BucketClient defines the interface for the S3 operations emptying and deleting a bucket.
+// This interface enables mocking for unit tests.
+type S3BucketClient interface {
+	GetBucketLocation(
+		ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options),
+	) (*s3.GetBucketLocationOutput, error)
+	ListObjectVersions(
+		ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options),
+	) (*s3.ListObjectVersionsOutput, error)
+	ListObjectsV2(
+		ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options),
+	) (*s3.ListObjectsV2Output, error)
+	DeleteObjects(
+		ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options),
+	) (*s3.DeleteObjectsOutput, error)
+	DeleteBucket(
+		ctx context.Context, params *s3.DeleteBucketInput, optFns ...func(*s3.Options),
+	) (*s3.DeleteBucketOutput, error)
+}
+
+// DynamoDBTruncateClient defines the interface for the DynamoDB operations truncating a table.
+// This interface enables mocking for unit tests.
+type DynamoDBTruncateClient interface {
+	DescribeTable(
+		ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options),
+	) (*dynamodb.DescribeTableOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	BatchWriteItem(
+		ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options),
+	) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// LambdaConfigurationClient defines the interface for the Lambda operation reading a function configuration.
+// This interface enables mocking for unit tests.
+type LambdaConfigurationClient interface {
+	GetFunctionConfiguration(
+		ctx context.Context, params *lambda.GetFunctionConfigurationInput, optFns ...func(*lambda.Options),
+	) (*lambda.GetFunctionConfigurationOutput, error)
+}
+
+// AWSMaintainer implements Maintainer for AWS.
+type AWSMaintainer struct {
+	dynamo DynamoDBTruncateClient
+	lambda LambdaConfigurationClient
+	// s3ForRegion returns the S3 client of a region, buckets are emptied through the client of their region.
+	s3ForRegion func(region string) S3BucketClient
+}
+
+// NewAWSMaintainer creates an AWS maintainer from the AWS configuration.
+func NewAWSMaintainer(awsCfg *aws.Config) *AWSMaintainer {
+	return &AWSMaintainer{
+		dynamo: dynamoRepo.NewSDKClient(*awsCfg),
+		lambda: lambda.NewFromConfig(*awsCfg),
+		s3ForRegion: func(region string) S3BucketClient {
+			return s3.NewFromConfig(*awsCfg, func(o *s3.Options) {
+				o.Region = region
+			})
+		},
+	}
+}
+
+// DeleteBucket deletes all the object versions, delete markers and objects of the bucket, then the bucket.
+func (m *AWSMaintainer) DeleteBucket(ctx context.Context, bucket string, dryRun bool) (int, error) {
+	location, err := m.s3ForRegion(s3DefaultRegion).GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the location of bucket %s: %w", bucket, err)
+	}
+	region := string(location.LocationConstraint)
+	if region == "" || region == "None" {
+		region = s3DefaultRegion
+	}
+	client := m.s3ForRegion(region)
+
+	versions, err := deleteBucketObjectVersions(ctx, client, bucket, dryRun)
+	// The version listing includes the objects of unversioned buckets, so a dry run has nothing more to count
+	if err != nil || dryRun {
+		return versions, err
+	}
+	objects, err := deleteBucketObjects(ctx, client, bucket)
+	deleted := versions + objects
+	if err != nil {
+		return deleted, err
+	}
+
+	if _, err = client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		return deleted, fmt.Errorf("failed to delete bucket %s: %w", bucket, err)
+	}
+	return deleted, nil
+}
+
+// deleteBucketObjectVersions deletes the object versions and delete markers of a versioned bucket.
+func deleteBucketObjectVersions(ctx context.Context, client S3BucketClient, bucket string, dryRun bool) (int, error) {
+	deleted := 0
+	var keyMarker, versionIDMarker *string
+	for {
+		page, err := client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+			Bucket:          aws.String(bucket),
+			KeyMarker:       keyMarker,
+			VersionIdMarker: versionIDMarker,
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list object versions: %w", err)
+		}
+
+		var objects []s3Types.ObjectIdentifier
+		for i := range page.Versions {
+			if page.Versions[i].Key != nil && page.Versions[i].VersionId != nil {
+				objects = append(objects, s3Types.ObjectIdentifier{
+					Key: page.Versions[i].Key, VersionId: page.Versions[i].VersionId,
+				})
+			}
+		}
+		for i := range page.DeleteMarkers {
+			if page.DeleteMarkers[i].Key != nil && page.DeleteMarkers[i].VersionId != nil {
+				objects = append(objects, s3Types.ObjectIdentifier{
+					Key: page.DeleteMarkers[i].Key, VersionId: page.DeleteMarkers[i].VersionId,
+				})
+			}
+		}
+		count, err := deleteObjectsInBatches(ctx, client, bucket, objects, dryRun)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+
+		if !aws.ToBool(page.IsTruncated) || (page.NextKeyMarker == nil && page.NextVersionIdMarker == nil) {
+			return deleted, nil
+		}
+		keyMarker, versionIDMarker = page.NextKeyMarker, page.NextVersionIdMarker
+	}
+}
+
+// deleteBucketObjects deletes the objects left in a bucket after its versions.
+func deleteBucketObjects(ctx context.Context, client S3BucketClient, bucket string) (int, error) {
+	deleted := 0
+	var continuationToken *string
+	for {
+		page, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(bucket),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		objects := make([]s3Types.ObjectIdentifier, 0, len(page.Contents))
+		for i := range page.Contents {
+			if page.Contents[i].Key != nil {
+				objects = append(objects, s3Types.ObjectIdentifier{Key: page.Contents[i].Key})
+			}
+		}
+		count, err := deleteObjectsInBatches(ctx, client, bucket, objects, false)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+
+		if !aws.ToBool(page.IsTruncated) || page.NextContinuationToken == nil {
+			return deleted, nil
+		}
+		continuationToken = page.NextContinuationToken
+	}
+}
+
+// deleteObjectsInBatches deletes the objects by batches of the DeleteObjects limit, and returns the number of
+// objects deleted. On a dry run, it only counts them.
+func deleteObjectsInBatches(
+	ctx context.Context,
+	client S3BucketClient,
+	bucket string,
+	objects []s3Types.ObjectIdentifier,
+	dryRun bool,
+) (int, error) {
+	if dryRun {
+		return len(objects), nil
+	}
+
+	deleted := 0
+	for batch := range slices.Chunk(objects, s3DeleteObjectsLimit) {
+		output, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3Types.Delete{Objects: batch, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete objects: %w", err)
+		}
+		if len(output.Errors) > 0 {
+			first := output.Errors[0]
+			return deleted, fmt.Errorf("failed to delete %d objects, first %s: %s",
+				len(output.Errors), aws.ToString(first.Key), aws.ToString(first.Message))
+		}
+		deleted += len(batch)
+	}
+	return deleted, nil
+}
+
+// TruncateTable scans the keys of the table and deletes its items by batches.
+func (m *AWSMaintainer) TruncateTable(ctx context.Context, table string, dryRun bool) (int, error) {
+	keyNames, err := m.tableKeyNames(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	projection := make([]string, 0, len(keyNames))
+	names := make(map[string]string, len(keyNames))
+	for i, name := range keyNames {
+		placeholder := fmt.Sprintf("#k%d", i)
+		projection = append(projection, placeholder)
+		names[placeholder] = name
+	}
+
+	deleted := 0
+	var startKey map[string]dynamoTypes.AttributeValue
+	for {
+		page, scanErr := m.dynamo.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                aws.String(table),
+			ProjectionExpression:     aws.String(strings.Join(projection, ", ")),
+			ExpressionAttributeNames: names,
+			ExclusiveStartKey:        startKey,
+			Limit:                    aws.Int32(truncateScanPageSize),
+		})
+		if scanErr != nil {
+			return deleted, fmt.Errorf("failed to scan table %s: %w", table, scanErr)
+		}
+
+		if dryRun {
+			deleted += len(page.Items)
+		} else {
+			count, deleteErr := m.deleteItems(ctx, table, page.Items)
+			deleted += count
+			if deleteErr != nil {
+				return deleted, deleteErr
+			}
+		}
+
+		if len(page.LastEvaluatedKey) == 0 {
+			return deleted, nil
+		}
+		startKey = page.LastEvaluatedKey
+	}
+}
+
+// tableKeyNames returns the names of the key attributes of the table, hash key first.
+func (m *AWSMaintainer) tableKeyNames(ctx context.Context, table string) ([]string, error) {
+	output, err := m.dynamo.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+	if output.Table == nil || len(output.Table.KeySchema) == 0 {
+		return nil, fmt.Errorf("table %s has no key schema", table)
+	}
+
+	var hashKey, rangeKey string
+	for _, key := range output.Table.KeySchema {
+		switch key.KeyType {
+		case dynamoTypes.KeyTypeHash:
+			hashKey = aws.ToString(key.AttributeName)
+		case dynamoTypes.KeyTypeRange:
+			rangeKey = aws.ToString(key.AttributeName)
+		}
+	}
+	if hashKey == "" {
+		return nil, fmt.Errorf("table %s has no hash key", table)
+	}
+	if rangeKey == "" {
+		return []string{hashKey}, nil
+	}
+	return []string{hashKey, rangeKey}, nil
+}
+
+// deleteItems deletes the items, whose attributes are their keys, by batches of the BatchWriteItem limit.
+// The unprocessed items are retried until none is left.
+func (m *AWSMaintainer) deleteItems(
+	ctx context.Context,
+	table string,
+	items []map[string]dynamoTypes.AttributeValue,
+) (int, error) {
+	deleted := 0
+	for batch := range slices.Chunk(items, awsConstants.DynamoDBBatchWriteLimit) {
+		requests := make([]dynamoTypes.WriteRequest, 0, len(batch))
+		for _, key := range batch {
+			requests = append(requests, dynamoTypes.WriteRequest{DeleteRequest: &dynamoTypes.DeleteRequest{Key: key}})
+		}
+		for len(requests) > 0 {
+			output, err := m.dynamo.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]dynamoTypes.WriteRequest{table: requests},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete items of table %s: %w", table, err)
+			}
+			requests = output.UnprocessedItems[table]
+		}
+		deleted += len(batch)
+	}
+	return deleted, nil
+}
+
+// componentFunctionOutputs are the stack outputs naming the Lambda function of each backend component.
+var componentFunctionOutputs = map[BackendComponent]string{
+	ComponentOrchestrator: "LambdaFunctionName",
+	ComponentProcessor:    "EventProcessorFunctionName",
+}
+
+// GetComponentEnv returns the environment variables of the Lambda function of the component.
+func (m *AWSMaintainer) GetComponentEnv(
+	ctx context.Context,
+	component BackendComponent,
+	outputs map[string]string,
+) (map[string]string, error) {
+	outputKey, ok := componentFunctionOutputs[component]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend component: %s", component)
+	}
+	function := outputs[outputKey]
+	if function == "" {
+		return nil, fmt.Errorf("%s not found in stack outputs", outputKey)
+	}
+
+	output, err := m.lambda.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(function),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the configuration of function %s: %w", function, err)
+	}
+
+	env := make(map[string]string)
+	if output.Environment != nil {
+		maps.Copy(env, output.Environment.Variables)
+	}
+	return env, nil
+}
@@ -0,0 +1,226 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamoTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockS3BucketClient struct {
+	location      s3Types.BucketLocationConstraint
+	versions      []s3Types.ObjectVersion
+	objects       []s3Types.Object
+	deleted       []s3Types.ObjectIdentifier
+	deletedBucket bool
+}
+
+func (m *mockS3BucketClient) GetBucketLocation(
+	_ context.Context, _ *s3.GetBucketLocationInput, _ ...func(*s3.Options),
+) (*s3.GetBucketLocationOutput, error) {
+	return &s3.GetBucketLocationOutput{LocationConstraint: m.location}, nil
+}
+
+func (m *mockS3BucketClient) ListObjectVersions(
+	_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options),
+) (*s3.ListObjectVersionsOutput, error) {
+	return &s3.ListObjectVersionsOutput{Versions: m.versions}, nil
+}
+
+func (m *mockS3BucketClient) ListObjectsV2(
+	_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options),
+) (*s3.ListObjectsV2Output, error) {
+	return &s3.ListObjectsV2Output{Contents: m.objects}, nil
+}
+
+func (m *mockS3BucketClient) DeleteObjects(
+	_ context.Context, params *s3.DeleteObjectsInput, _ ...func(*s3.Options),
+) (*s3.DeleteObjectsOutput, error) {
+	m.deleted = append(m.deleted, params.Delete.Objects...)
+	m.versions, m.objects = nil, nil
+	return &s3.DeleteObjectsOutput{}, nil
+}
+
+func (m *mockS3BucketClient) DeleteBucket(
+	_ context.Context, _ *s3.DeleteBucketInput, _ ...func(*s3.Options),
+) (*s3.DeleteBucketOutput, error) {
+	m.deletedBucket = true
+	return &s3.DeleteBucketOutput{}, nil
+}
+
+func newMockS3Maintainer(client *mockS3BucketClient, regions *[]string) *AWSMaintainer {
+	return &AWSMaintainer{s3ForRegion: func(region string) S3BucketClient {
+		*regions = append(*regions, region)
+		return client
+	}}
+}
+
+func TestAWSMaintainer_DeleteBucket(t *testing.T) {
+	client := &mockS3BucketClient{
+		location: s3Types.BucketLocationConstraintEuWest1,
+		versions: []s3Types.ObjectVersion{
+			{Key: aws.String("a"), VersionId: aws.String("v1")},
+			{Key: aws.String("a"), VersionId: aws.String("v2")},
+		},
+	}
+	var regions []string
+
+	deleted, err := newMockS3Maintainer(client, &regions).DeleteBucket(context.Background(), "bucket", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Len(t, client.deleted, 2)
+	assert.True(t, client.deletedBucket)
+	assert.Equal(t, []string{s3DefaultRegion, "eu-west-1"}, regions)
+}
+
+func TestAWSMaintainer_DeleteBucket_DryRun(t *testing.T) {
+	client := &mockS3BucketClient{
+		versions: []s3Types.ObjectVersion{{Key: aws.String("a"), VersionId: aws.String("null")}},
+		objects:  []s3Types.Object{{Key: aws.String("a")}},
+	}
+	var regions []string
+
+	deleted, err := newMockS3Maintainer(client, &regions).DeleteBucket(context.Background(), "bucket", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, client.deleted)
+	assert.False(t, client.deletedBucket)
+	assert.Equal(t, []string{s3DefaultRegion, s3DefaultRegion}, regions)
+}
+
+type mockDynamoDBTruncateClient struct {
+	pages       []*dynamodb.ScanOutput
+	scans       []*dynamodb.ScanInput
+	unprocessed int
+	deleted     int
+}
+
+func (m *mockDynamoDBTruncateClient) DescribeTable(
+	_ context.Context, _ *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.DescribeTableOutput, error) {
+	return &dynamodb.DescribeTableOutput{Table: &dynamoTypes.TableDescription{
+		KeySchema: []dynamoTypes.KeySchemaElement{
+			{AttributeName: aws.String("timestamp"), KeyType: dynamoTypes.KeyTypeRange},
+			{AttributeName: aws.String("execution_id"), KeyType: dynamoTypes.KeyTypeHash},
+		},
+	}}, nil
+}
+
+func (m *mockDynamoDBTruncateClient) Scan(
+	_ context.Context, params *dynamodb.ScanInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	m.scans = append(m.scans, params)
+	page := m.pages[0]
+	m.pages = m.pages[1:]
+	return page, nil
+}
+
+func (m *mockDynamoDBTruncateClient) BatchWriteItem(
+	_ context.Context, params *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.BatchWriteItemOutput, error) {
+	requests := params.RequestItems["table"]
+	if m.unprocessed > 0 {
+		m.unprocessed--
+		m.deleted += len(requests) - 1
+		return &dynamodb.BatchWriteItemOutput{
+			UnprocessedItems: map[string][]dynamoTypes.WriteRequest{"table": requests[:1]},
+		}, nil
+	}
+	m.deleted += len(requests)
+	return &dynamodb.BatchWriteItemOutput{}, nil
+}
+
+func truncateTestItems(count int) []map[string]dynamoTypes.AttributeValue {
+	items := make([]map[string]dynamoTypes.AttributeValue, count)
+	for i := range items {
+		items[i] = map[string]dynamoTypes.AttributeValue{
+			"execution_id": &dynamoTypes.AttributeValueMemberS{Value: "exec"},
+		}
+	}
+	return items
+}
+
+func TestAWSMaintainer_TruncateTable(t *testing.T) {
+	client := &mockDynamoDBTruncateClient{
+		pages: []*dynamodb.ScanOutput{
+			{Items: truncateTestItems(30), LastEvaluatedKey: truncateTestItems(1)[0]},
+			{Items: truncateTestItems(5)},
+		},
+		unprocessed: 1,
+	}
+	maintainer := &AWSMaintainer{dynamo: client}
+
+	deleted, err := maintainer.TruncateTable(context.Background(), "table", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 35, deleted)
+	assert.Equal(t, 35, client.deleted)
+	require.Len(t, client.scans, 2)
+	assert.Equal(t, "#k0, #k1", aws.ToString(client.scans[0].ProjectionExpression))
+	assert.Equal(t, map[string]string{"#k0": "execution_id", "#k1": "timestamp"},
+		client.scans[0].ExpressionAttributeNames)
+	assert.NotNil(t, client.scans[1].ExclusiveStartKey)
+}
+
+func TestAWSMaintainer_TruncateTable_DryRun(t *testing.T) {
+	client := &mockDynamoDBTruncateClient{pages: []*dynamodb.ScanOutput{{Items: truncateTestItems(7)}}}
+	maintainer := &AWSMaintainer{dynamo: client}
+
+	deleted, err := maintainer.TruncateTable(context.Background(), "table", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, deleted)
+	assert.Equal(t, 0, client.deleted)
+}
+
+type mockLambdaConfigurationClient struct {
+	env       map[string]string
+	functions []string
+}
+
+func (m *mockLambdaConfigurationClient) GetFunctionConfiguration(
+	_ context.Context, params *lambda.GetFunctionConfigurationInput, _ ...func(*lambda.Options),
+) (*lambda.GetFunctionConfigurationOutput, error) {
+	m.functions = append(m.functions, aws.ToString(params.FunctionName))
+	return &lambda.GetFunctionConfigurationOutput{
+		Environment: &lambdaTypes.EnvironmentResponse{Variables: m.env},
+	}, nil
+}
+
+func TestAWSMaintainer_GetComponentEnv(t *testing.T) {
+	client := &mockLambdaConfigurationClient{env: map[string]string{"A": "1"}}
+	maintainer := &AWSMaintainer{lambda: client}
+	outputs := map[string]string{
+		"LambdaFunctionName":         "runvoy-orchestrator",
+		"EventProcessorFunctionName": "runvoy-event-processor",
+	}
+
+	env, err := maintainer.GetComponentEnv(context.Background(), ComponentProcessor, outputs)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "1"}, env)
+	assert.Equal(t, []string{"runvoy-event-processor"}, client.functions)
+
+	_, err = maintainer.GetComponentEnv(context.Background(), "webapp", outputs)
+	require.ErrorContains(t, err, "unknown backend component: webapp")
+
+	_, err = maintainer.GetComponentEnv(context.Background(), ComponentOrchestrator, nil)
+	require.ErrorContains(t, err, "LambdaFunctionName not found in stack outputs")
+}
+
+func TestNewMaintainer_UnsupportedProvider(t *testing.T) {
+	_, err := NewMaintainer(context.Background(), "gcp", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported provider: gcp")
+}
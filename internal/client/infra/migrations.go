@@ -0,0 +1,75 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database/migrations"
+	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// awsMigrationTableOutputs maps the backend collections to the stack outputs holding their table names.
+var awsMigrationTableOutputs = map[migrations.Collection]string{
+	migrations.CollectionAPIKeys:         "APIKeysTableName",
+	migrations.CollectionExecutions:      "ExecutionsTableName",
+	migrations.CollectionSecretsMetadata: "SecretsMetadataTableName",
+	migrations.CollectionImageTaskDefs:   "ImageTaskDefinitionsTableName",
+}
+
+// NewMigrationRunner creates a migrations runner for the backend described by the stack outputs.
+// Currently supports: "aws".
+func NewMigrationRunner(
+	ctx context.Context,
+	provider, region string,
+	outputs map[string]string,
+) (*migrations.Runner, error) {
+	providerLower := strings.ToLower(provider)
+	awsProvider := strings.ToLower(string(constants.AWS))
+	switch providerLower {
+	case awsProvider:
+		return newAWSMigrationRunner(ctx, region, outputs)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", provider, awsProvider)
+	}
+}
+
+func newAWSMigrationRunner(ctx context.Context, region string, outputs map[string]string) (*migrations.Runner, error) {
+	ledgerTable := outputs["MigrationsTableName"]
+	if ledgerTable == "" {
+		return nil, fmt.Errorf("stack has no migrations ledger, apply the current release with %s infra apply first",
+			constants.ProjectName)
+	}
+
+	tables := make(map[migrations.Collection]string, len(awsMigrationTableOutputs))
+	for collection, outputKey := range awsMigrationTableOutputs {
+		if outputs[outputKey] == "" {
+			return nil, fmt.Errorf("stack output %s not found", outputKey)
+		}
+		tables[collection] = outputs[outputKey]
+	}
+
+	registry, err := migrations.NewRegistry(dynamodb.Migrations()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var awsOpts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		awsOpts = append(awsOpts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := dynamodb.NewSDKClient(awsCfg)
+	return migrations.NewRunner(
+		registry,
+		dynamodb.NewMigrationExecutor(client, tables),
+		dynamodb.NewMigrationLedger(client, ledgerTable),
+	), nil
+}
@@ -0,0 +1,29 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMigrationRunner_Validation(t *testing.T) {
+	ctx := context.Background()
+	outputs := map[string]string{
+		"MigrationsTableName":      "runvoy-migrations",
+		"APIKeysTableName":         "runvoy-api-keys",
+		"ExecutionsTableName":      "runvoy-executions",
+		"SecretsMetadataTableName": "runvoy-secrets-metadata",
+	}
+
+	_, err := NewMigrationRunner(ctx, "azure", "", outputs)
+	assert.ErrorContains(t, err, "unsupported provider: azure")
+
+	_, err = NewMigrationRunner(ctx, "aws", "us-east-1", map[string]string{})
+	assert.ErrorContains(t, err, "stack has no migrations ledger")
+
+	_, err = NewMigrationRunner(ctx, "aws", "us-east-1", outputs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stack output ImageTaskDefinitionsTableName not found")
+}
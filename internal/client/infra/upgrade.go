@@ -0,0 +1,41 @@
+package infra
+
+import (
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/semver"
+)
+
+// UpgradePlan describes a backend upgrade between two releases.
+type UpgradePlan struct {
+	CurrentVersion semver.Version
+	TargetVersion  semver.Version
+}
+
+// UpToDate reports whether the backend already runs the target version.
+func (p *UpgradePlan) UpToDate() bool {
+	return p.CurrentVersion.Compare(p.TargetVersion) == 0
+}
+
+// MajorUpgrade reports whether the upgrade crosses a major version, which may include breaking changes.
+func (p *UpgradePlan) MajorUpgrade() bool {
+	return p.TargetVersion.Major > p.CurrentVersion.Major
+}
+
+// PlanUpgrade checks that the backend can be upgraded from current to target.
+// Downgrades are refused unless force is set.
+func PlanUpgrade(current, target string, force bool) (*UpgradePlan, error) {
+	currentVersion, err := semver.Parse(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployed version: %w", err)
+	}
+	targetVersion, err := semver.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse target version: %w", err)
+	}
+
+	if targetVersion.Compare(currentVersion) < 0 && !force {
+		return nil, fmt.Errorf("downgrading the backend from %s to %s is not supported", currentVersion, targetVersion)
+	}
+	return &UpgradePlan{CurrentVersion: currentVersion, TargetVersion: targetVersion}, nil
+}
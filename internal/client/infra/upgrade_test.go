@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanUpgrade(t *testing.T) {
+	t.Run("minor upgrade", func(t *testing.T) {
+		plan, err := PlanUpgrade("v0.4.2", "v0.7.0", false)
+
+		require.NoError(t, err)
+		assert.False(t, plan.UpToDate())
+		assert.False(t, plan.MajorUpgrade())
+		assert.Equal(t, "v0.4.2", plan.CurrentVersion.String())
+		assert.Equal(t, "v0.7.0", plan.TargetVersion.String())
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		plan, err := PlanUpgrade("0.6.0", "v0.6.0", false)
+
+		require.NoError(t, err)
+		assert.True(t, plan.UpToDate())
+	})
+
+	t.Run("major upgrade", func(t *testing.T) {
+		plan, err := PlanUpgrade("v0.9.0", "v1.0.0", false)
+
+		require.NoError(t, err)
+		assert.True(t, plan.MajorUpgrade())
+	})
+
+	t.Run("refuses downgrade", func(t *testing.T) {
+		_, err := PlanUpgrade("v0.7.0", "v0.6.0", false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "downgrading the backend from v0.7.0 to v0.6.0 is not supported")
+	})
+
+	t.Run("forced downgrade", func(t *testing.T) {
+		plan, err := PlanUpgrade("v0.7.0", "v0.4.0", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "v0.4.0", plan.TargetVersion.String())
+	})
+
+	t.Run("invalid versions", func(t *testing.T) {
+		_, err := PlanUpgrade("unknown", "v0.6.0", false)
+		assert.ErrorContains(t, err, "failed to parse deployed version")
+
+		_, err = PlanUpgrade("v0.6.0", "main", false)
+		assert.ErrorContains(t, err, "failed to parse target version")
+	})
+}
@@ -6,39 +6,77 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
-	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
+// AdminBootstrap is the outcome of BootstrapAdmin.
+type AdminBootstrap struct {
+	User    *api.User
+	APIKey  string // Generated API key, only set when the user was created
+	Created bool
+}
+
+// BootstrapAdmin creates the admin user of the backend described by the stack outputs.
+// It is safe to re-run: when a user with the email already exists, it is returned unchanged
+// and no API key is generated.
+// Currently supports: "aws".
+func BootstrapAdmin(
+	ctx context.Context,
+	provider, region, adminEmail string,
+	outputs map[string]string,
+) (*AdminBootstrap, error) {
+	if adminEmail == "" {
+		return nil, errors.New("admin email is required")
+	}
+
+	providerLower := strings.ToLower(provider)
+	awsProvider := strings.ToLower(string(constants.AWS))
+	switch providerLower {
+	case awsProvider:
+		tableName := outputs["APIKeysTableName"]
+		if tableName == "" {
+			return nil, errors.New("APIKeysTableName not found in stack outputs")
+		}
+		repo, err := createUserRepository(ctx, tableName, region)
+		if err != nil {
+			return nil, err
+		}
+		return bootstrapAdminUser(ctx, repo, adminEmail)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s (supported: %s)", provider, awsProvider)
+	}
+}
+
 // SeedAdminUser seeds an admin user into the database and returns the generated API key.
+// Unlike BootstrapAdmin, it fails when the user already exists.
 // This function hides the DynamoDB implementation details from callers.
 func SeedAdminUser(ctx context.Context, adminEmail, region, tableName string) (string, error) {
 	if tableName == "" {
 		return "", errors.New("table name is required")
 	}
 
-	apiKey, err := auth.GenerateSecretToken()
-	if err != nil {
-		return "", fmt.Errorf("failed to generate API key: %w", err)
-	}
-
 	repo, err := createUserRepository(ctx, tableName, region)
 	if err != nil {
 		return "", err
 	}
 
-	err = checkAndCreateUser(ctx, repo, adminEmail, auth.HashAPIKey(apiKey))
+	result, err := bootstrapAdminUser(ctx, repo, adminEmail)
 	if err != nil {
 		return "", err
 	}
+	if !result.Created {
+		return "", fmt.Errorf("admin user %s already exists in database", adminEmail)
+	}
 
-	return apiKey, nil
+	return result.APIKey, nil
 }
 
 // createUserRepository creates a DynamoDB user repository.
@@ -53,21 +91,32 @@ func createUserRepository(ctx context.Context, tableName, region string) (*dynam
 		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
 	}
 
-	dynamoClient := awsdynamodb.NewFromConfig(awsCfg)
+	dynamoClient := dynamodb.NewSDKClient(awsCfg)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	repo := dynamodb.NewUserRepository(dynamoClient, tableName, "", logger)
 
 	return repo, nil
 }
 
-// checkAndCreateUser checks if user exists and creates it if not.
-func checkAndCreateUser(ctx context.Context, repo *dynamodb.UserRepository, adminEmail, apiKeyHash string) error {
+// adminUserRepository is the subset of database.UserRepository used to bootstrap the admin user.
+type adminUserRepository interface {
+	GetUserByEmail(ctx context.Context, email string) (*api.User, error)
+	CreateUser(ctx context.Context, user *api.User, apiKeyHash string, expiresAtUnix int64) error
+}
+
+// bootstrapAdminUser creates the admin user with a new API key, unless a user with the email exists.
+func bootstrapAdminUser(ctx context.Context, repo adminUserRepository, adminEmail string) (*AdminBootstrap, error) {
 	existingUser, err := repo.GetUserByEmail(ctx, adminEmail)
 	if err != nil {
-		return fmt.Errorf("failed to check if admin user exists: %w", err)
+		return nil, fmt.Errorf("failed to check if admin user exists: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("admin user %s already exists in database", adminEmail)
+		return &AdminBootstrap{User: existingUser}, nil
+	}
+
+	apiKey, err := auth.GenerateSecretToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
 	}
 
 	user := &api.User{
@@ -80,10 +129,9 @@ func checkAndCreateUser(ctx context.Context, repo *dynamodb.UserRepository, admi
 		ModifiedByRequestID: "",
 	}
 
-	createErr := repo.CreateUser(ctx, user, apiKeyHash, 0)
-	if createErr != nil {
-		return fmt.Errorf("failed to seed admin user: %w", createErr)
+	if createErr := repo.CreateUser(ctx, user, auth.HashAPIKey(apiKey), 0); createErr != nil {
+		return nil, fmt.Errorf("failed to seed admin user: %w", createErr)
 	}
 
-	return nil
+	return &AdminBootstrap{User: user, APIKey: apiKey, Created: true}, nil
 }
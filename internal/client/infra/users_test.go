@@ -2,8 +2,12 @@ package infra
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -331,3 +335,74 @@ func TestSeedAdminUser_IntegrationFlow(t *testing.T) {
 		}
 	})
 }
+
+type fakeAdminUserRepository struct {
+	users     map[string]*api.User
+	hashes    map[string]string
+	getErr    error
+	createErr error
+}
+
+func (r *fakeAdminUserRepository) GetUserByEmail(_ context.Context, email string) (*api.User, error) {
+	return r.users[email], r.getErr
+}
+
+func (r *fakeAdminUserRepository) CreateUser(_ context.Context, user *api.User, apiKeyHash string, _ int64) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.users[user.Email] = user
+	r.hashes[user.Email] = apiKeyHash
+	return nil
+}
+
+func TestBootstrapAdminUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates admin user", func(t *testing.T) {
+		repo := &fakeAdminUserRepository{users: map[string]*api.User{}, hashes: map[string]string{}}
+
+		result, err := bootstrapAdminUser(ctx, repo, "admin@example.com")
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+		assert.NotEmpty(t, result.APIKey)
+		assert.Equal(t, "admin", result.User.Role)
+		assert.Equal(t, auth.HashAPIKey(result.APIKey), repo.hashes["admin@example.com"])
+	})
+
+	t.Run("returns existing user", func(t *testing.T) {
+		existing := &api.User{Email: "admin@example.com", Role: "admin"}
+		repo := &fakeAdminUserRepository{users: map[string]*api.User{"admin@example.com": existing}}
+
+		result, err := bootstrapAdminUser(ctx, repo, "admin@example.com")
+
+		require.NoError(t, err)
+		assert.False(t, result.Created)
+		assert.Empty(t, result.APIKey)
+		assert.Same(t, existing, result.User)
+	})
+
+	t.Run("repository errors", func(t *testing.T) {
+		_, err := bootstrapAdminUser(ctx, &fakeAdminUserRepository{getErr: errors.New("throttled")}, "admin@example.com")
+		assert.ErrorContains(t, err, "failed to check if admin user exists: throttled")
+
+		repo := &fakeAdminUserRepository{users: map[string]*api.User{}, createErr: errors.New("denied")}
+		_, err = bootstrapAdminUser(ctx, repo, "admin@example.com")
+		assert.ErrorContains(t, err, "failed to seed admin user: denied")
+	})
+}
+
+func TestBootstrapAdmin_Validation(t *testing.T) {
+	ctx := context.Background()
+	outputs := map[string]string{"APIKeysTableName": "runvoy-api-keys"}
+
+	_, err := BootstrapAdmin(ctx, "aws", "us-east-1", "", outputs)
+	assert.ErrorContains(t, err, "admin email is required")
+
+	_, err = BootstrapAdmin(ctx, "aws", "us-east-1", "admin@example.com", map[string]string{})
+	assert.ErrorContains(t, err, "APIKeysTableName not found in stack outputs")
+
+	_, err = BootstrapAdmin(ctx, "gcp", "us-east-1", "admin@example.com", outputs)
+	assert.ErrorContains(t, err, "unsupported provider: gcp")
+}
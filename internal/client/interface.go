@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 )
@@ -9,17 +10,54 @@ import (
 // Interface defines the API client interface for dependency injection and testing.
 type Interface interface {
 	// Health
-	ReconcileHealth(ctx context.Context) (*api.HealthReconcileResponse, error)
+	ReconcileHealth(ctx context.Context, canary bool) (*api.HealthReconcileResponse, error)
+	CleanupHealth(ctx context.Context, dryRun bool) (*api.HealthCleanupResponse, error)
+	ListHealthReports(ctx context.Context, limit int) (*api.HealthReportsResponse, error)
+	GetHealthStats(ctx context.Context) (*api.HealthStatsResponse, error)
 	GetLogs(ctx context.Context, executionID string) (*api.LogsResponse, error)
 	FetchBackendLogs(ctx context.Context, requestID string) (*api.TraceResponse, error)
 	GetExecutionStatus(ctx context.Context, executionID string) (*api.ExecutionStatusResponse, error)
+	GetExecutionEvents(ctx context.Context, executionID string) (*api.ExecutionEventsResponse, error)
+	GetExecutionResult(ctx context.Context, executionID string) (*api.ExecutionResultResponse, error)
+	AnnotateExecution(ctx context.Context, executionID, note string) (*api.ExecutionAnnotation, error)
+	GetExecutionGroupStatus(ctx context.Context, groupID string) (*api.ExecutionGroupStatusResponse, error)
+	GetExecutionResources(ctx context.Context) (*api.ExecutionResourcesResponse, error)
+	GetExecutionSLOReport(ctx context.Context, since time.Duration, playbook string) (*api.ExecutionSLOReport, error)
 	RunCommand(ctx context.Context, req *api.ExecutionRequest) (*api.ExecutionResponse, error)
+	CreateStdinUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error)
+	CreateContextUpload(ctx context.Context, size int64) (*api.InputUploadResponse, error)
+	UploadInput(ctx context.Context, uploadURL string, data []byte) error
 	KillExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error)
+	StopExecution(ctx context.Context, executionID string) (*api.KillExecutionResponse, error)
+	KillExecutions(
+		ctx context.Context,
+		filter api.KillExecutionsFilter,
+		confirmationToken string,
+	) (*api.KillExecutionsResponse, error)
 	ListExecutions(ctx context.Context, limit int, statuses string) ([]api.Execution, error)
+	ListFilteredExecutions(ctx context.Context, limit int, filter api.ExecutionListFilter) ([]api.Execution, error)
+	StarExecution(ctx context.Context, executionID string, starred bool) (*api.StarExecutionResponse, error)
+	ListSavedFilters(ctx context.Context) (*api.ListSavedFiltersResponse, error)
+	SaveFilter(ctx context.Context, filter api.ExecutionListFilter) (*api.ExecutionListFilter, error)
+	DeleteSavedFilter(ctx context.Context, name string) (*api.DeleteSavedFilterResponse, error)
+	StreamExecutions(ctx context.Context, statuses string, onSnapshot func([]api.Execution) error) error
+	DiffExecutions(
+		ctx context.Context,
+		executionIDA, executionIDB string,
+		logLines int,
+	) (*api.ExecutionDiffResponse, error)
 	ClaimAPIKey(ctx context.Context, token string) (*api.ClaimAPIKeyResponse, error)
+	GetLoginConfig(ctx context.Context) (*api.LoginConfigResponse, error)
+	Login(ctx context.Context, req api.LoginRequest) (*api.LoginResponse, error)
+	ExchangeGitHubToken(ctx context.Context, req api.GitHubTokenRequest) (*api.GitHubTokenResponse, error)
 	CreateUser(ctx context.Context, req api.CreateUserRequest) (*api.CreateUserResponse, error)
 	RevokeUser(ctx context.Context, req api.RevokeUserRequest) (*api.RevokeUserResponse, error)
 	ListUsers(ctx context.Context) (*api.ListUsersResponse, error)
+	ListPendingUsers(ctx context.Context) (*api.ListUsersResponse, error)
+	ReissueClaimToken(ctx context.Context, email string) (*api.CreateUserResponse, error)
+	TransferOwnership(
+		ctx context.Context, email string, req api.TransferOwnershipRequest,
+	) (*api.TransferOwnershipResponse, error)
 	RegisterImage(
 		ctx context.Context,
 		image string,
@@ -27,15 +65,49 @@ type Interface interface {
 		taskRoleName, taskExecutionRoleName *string,
 		cpu, memory *int,
 		runtimePlatform *string,
+		logLimits *api.LogLimits,
+		warmPoolSize *int,
+		runDefaults *api.RunDefaults,
 	) (*api.RegisterImageResponse, error)
 	ListImages(ctx context.Context) (*api.ListImagesResponse, error)
 	GetImage(ctx context.Context, image string) (*api.ImageInfo, error)
 	UnregisterImage(ctx context.Context, image string) (*api.RemoveImageResponse, error)
+	RestoreImage(ctx context.Context, image string) (*api.RestoreImageResponse, error)
+	PurgeImage(ctx context.Context, image string) (*api.RemoveImageResponse, error)
+	SetImageAlias(ctx context.Context, alias, image string) (*api.SetImageAliasResponse, error)
+	ListImageAliases(ctx context.Context) (*api.ListImageAliasesResponse, error)
+	RemoveImageAlias(ctx context.Context, alias string) (*api.RemoveImageAliasResponse, error)
+	GetResourceRecommendations(ctx context.Context) (*api.ResourceRecommendationsResponse, error)
 	CreateSecret(ctx context.Context, req api.CreateSecretRequest) (*api.CreateSecretResponse, error)
 	GetSecret(ctx context.Context, name string) (*api.GetSecretResponse, error)
 	ListSecrets(ctx context.Context) (*api.ListSecretsResponse, error)
 	UpdateSecret(ctx context.Context, name string, req api.UpdateSecretRequest) (*api.UpdateSecretResponse, error)
 	DeleteSecret(ctx context.Context, name string) (*api.DeleteSecretResponse, error)
+	ExportSecrets(ctx context.Context, req api.ExportSecretsRequest) (*api.ExportSecretsResponse, error)
+	ImportSecrets(ctx context.Context, req api.ImportSecretsRequest) (*api.ImportSecretsResponse, error)
+	ListCommandPolicyRules(ctx context.Context) (*api.ListCommandPolicyRulesResponse, error)
+	CreateCommandPolicyRule(
+		ctx context.Context, req api.CommandPolicyRuleRequest,
+	) (*api.CommandPolicyRuleResponse, error)
+	UpdateCommandPolicyRule(
+		ctx context.Context, name string, req api.CommandPolicyRuleRequest,
+	) (*api.CommandPolicyRuleResponse, error)
+	DeleteCommandPolicyRule(ctx context.Context, name string) (*api.DeleteCommandPolicyRuleResponse, error)
+	GetRunFreeze(ctx context.Context) (*api.RunFreezeResponse, error)
+	FreezeRuns(ctx context.Context, req api.RunFreezeRequest) (*api.RunFreezeResponse, error)
+	UnfreezeRuns(ctx context.Context) (*api.RunFreezeResponse, error)
+	GetMeta(ctx context.Context) (*api.MetaResponse, error)
+	SetAnnouncement(ctx context.Context, req api.AnnouncementRequest) (*api.AnnouncementResponse, error)
+	ClearAnnouncement(ctx context.Context) (*api.AnnouncementResponse, error)
+	ListBudgets(ctx context.Context) (*api.ListBudgetsResponse, error)
+	SetBudget(ctx context.Context, email string, req api.BudgetRequest) (*api.BudgetResponse, error)
+	RemoveBudget(ctx context.Context, email string) (*api.BudgetResponse, error)
+	GetNotificationSettings(ctx context.Context) (*api.NotificationSettingsResponse, error)
+	SetNotificationSettings(
+		ctx context.Context, req api.NotificationSettingsRequest,
+	) (*api.NotificationSettingsResponse, error)
+	ListLocks(ctx context.Context) (*api.ListLocksResponse, error)
+	ReleaseLock(ctx context.Context, name string) (*api.ReleaseLockResponse, error)
 }
 
 // Compile-time check to ensure Client implements Interface.
@@ -14,6 +14,7 @@ import (
 	"github.com/runvoy/runvoy/internal/constants"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 )
 
 var (
@@ -30,9 +31,10 @@ var (
 	// Stderr is the output writer for error output (can be overridden for testing).
 	Stderr io.Writer = os.Stderr
 
-	// Disable colors if not TTY or NO_COLOR is set.
+	// Disable colors if not TTY or NO_COLOR is set, or if the Windows console can't render them.
 	noColor = func() bool {
-		disable := os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout)
+		disable := os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout) ||
+			!enableVirtualTerminal(os.Stdout) || !enableVirtualTerminal(os.Stderr)
 		if disable {
 			color.NoColor = true
 		}
@@ -506,11 +508,16 @@ func Bytes(b int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
-// isTerminal checks if the writer is a terminal.
+// IsInteractive reports whether the standard input is a terminal the user can answer prompts from.
+func IsInteractive() bool {
+	return isTerminal(os.Stdin)
+}
+
+// isTerminal checks if the writer is a terminal, including the Cygwin and MSYS terminals of Windows
+// such as Git Bash.
 func isTerminal(w io.Writer) bool {
 	if f, ok := w.(*os.File); ok {
-		fileInfo, _ := f.Stat()
-		return (fileInfo.Mode() & os.ModeCharDevice) != 0
+		return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
 	}
 	return false
 }
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package output
+
+import "os"
+
+// enableVirtualTerminal reports whether the terminal of f renders the ANSI escape sequences, which all
+// the terminals do outside of Windows.
+func enableVirtualTerminal(_ *os.File) bool {
+	return true
+}
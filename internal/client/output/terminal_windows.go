@@ -0,0 +1,20 @@
+package output
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal enables the processing of the ANSI escape sequences by the Windows console of f.
+// It reports whether they are rendered: always for the files that aren't consoles, such as the pipes and
+// the Cygwin and MSYS terminals, and for the consoles older than Windows 10 only when it succeeded.
+func enableVirtualTerminal(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return true
+	}
+	mode |= windows.ENABLE_PROCESSED_OUTPUT | windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	return windows.SetConsoleMode(handle, mode) == nil
+}
@@ -17,6 +17,8 @@ func NewPlaybookExecutor() *PlaybookExecutor {
 
 // ToExecutionRequest converts a Playbook to an ExecutionRequest.
 // Combines multiple commands with && operator and merges env vars and secrets.
+// The max duration of the playbook becomes the expected max duration of the execution, in seconds,
+// and its env schema is sent for the backend to validate the environment variables against.
 func (e *PlaybookExecutor) ToExecutionRequest(
 	playbook *api.Playbook,
 	userEnv map[string]string,
@@ -38,7 +40,12 @@ func (e *PlaybookExecutor) ToExecutionRequest(
 		GitRepo: playbook.GitRepo,
 		GitRef:  playbook.GitRef,
 		GitPath: playbook.GitPath,
+		WorkDir: playbook.WorkDir,
+		Shell:   playbook.Shell,
 		Env:     env,
 		Secrets: secrets,
+
+		ExpectedMaxDuration: int(playbook.MaxDuration.Seconds()),
+		EnvSchema:           playbook.EnvSchema,
 	}
 }
@@ -2,6 +2,7 @@ package playbooks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 
@@ -16,12 +17,16 @@ func TestPlaybookExecutor_ToExecutionRequest(t *testing.T) {
 			GitRepo:     "https://github.com/test/repo.git",
 			GitRef:      "main",
 			GitPath:     "/path",
+			WorkDir:     "web",
+			Shell:       "bash",
 			Secrets:     []string{"secret1", "secret2"},
 			Env: map[string]string{
 				"KEY1": "value1",
 				"KEY2": "value2",
 			},
-			Commands: []string{"echo hello", "echo world", "echo test"},
+			Commands:    []string{"echo hello", "echo world", "echo test"},
+			MaxDuration: 10 * time.Minute,
+			EnvSchema:   []api.EnvVarSpec{{Name: "KEY1", Required: true}},
 		}
 
 		userEnv := map[string]string{
@@ -38,12 +43,16 @@ func TestPlaybookExecutor_ToExecutionRequest(t *testing.T) {
 		assert.Equal(t, "https://github.com/test/repo.git", req.GitRepo)
 		assert.Equal(t, "main", req.GitRef)
 		assert.Equal(t, "/path", req.GitPath)
+		assert.Equal(t, "web", req.WorkDir)
+		assert.Equal(t, "bash", req.Shell)
 		assert.Equal(t, []string{"secret1", "secret2", "secret3"}, req.Secrets)
 		assert.Equal(t, map[string]string{
 			"KEY1": "value1",
 			"KEY2": "user_value2", // user env takes precedence
 			"KEY3": "value3",
 		}, req.Env)
+		assert.Equal(t, 600, req.ExpectedMaxDuration)
+		assert.Equal(t, pb.EnvSchema, req.EnvSchema)
 	})
 
 	t.Run("handles empty playbook fields", func(t *testing.T) {
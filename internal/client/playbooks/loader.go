@@ -121,5 +121,8 @@ func (l *PlaybookLoader) validatePlaybook(p *api.Playbook) error {
 	if len(p.Commands) == 0 {
 		return errors.New("commands must not be empty")
 	}
+	if p.MaxDuration < 0 {
+		return errors.New("max_duration must not be negative")
+	}
 	return nil
 }
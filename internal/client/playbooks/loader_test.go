@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -102,6 +103,7 @@ env:
 commands:
   - echo hello
   - echo world
+max_duration: 15m
 `
 		yamlFile := filepath.Join(playbookDir, "test.yaml")
 		err = os.WriteFile(yamlFile, []byte(yamlContent), 0o600)
@@ -126,6 +128,7 @@ commands:
 		assert.Equal(t, []string{"secret1", "secret2"}, pb.Secrets)
 		assert.Equal(t, map[string]string{"KEY1": "value1", "KEY2": "value2"}, pb.Env)
 		assert.Equal(t, []string{"echo hello", "echo world"}, pb.Commands)
+		assert.Equal(t, 15*time.Minute, pb.MaxDuration)
 	})
 
 	t.Run("returns error for missing playbook", func(t *testing.T) {
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// ciProvider identifies the runs of a CI provider from the environment variables it sets.
+type ciProvider struct {
+	name string
+	// detectEnv is set, to any value, in the runs of the provider.
+	detectEnv string
+	runIDEnv  string
+	runURL    func(getenv func(string) string) string
+}
+
+// ciProviders are the CI providers detected by the clients, in the order they are looked for.
+var ciProviders = []ciProvider{
+	{
+		name:      "github-actions",
+		detectEnv: "GITHUB_ACTIONS",
+		runIDEnv:  "GITHUB_RUN_ID",
+		runURL: func(getenv func(string) string) string {
+			server, repository, runID := getenv("GITHUB_SERVER_URL"), getenv("GITHUB_REPOSITORY"), getenv("GITHUB_RUN_ID")
+			if server == "" || repository == "" || runID == "" {
+				return ""
+			}
+			return server + "/" + repository + "/actions/runs/" + runID
+		},
+	},
+	{name: "gitlab-ci", detectEnv: "GITLAB_CI", runIDEnv: "CI_JOB_ID", runURL: envValue("CI_JOB_URL")},
+	{name: "circleci", detectEnv: "CIRCLECI", runIDEnv: "CIRCLE_WORKFLOW_ID", runURL: envValue("CIRCLE_BUILD_URL")},
+	{name: "buildkite", detectEnv: "BUILDKITE", runIDEnv: "BUILDKITE_BUILD_ID", runURL: envValue("BUILDKITE_BUILD_URL")},
+	{name: "jenkins", detectEnv: "JENKINS_URL", runIDEnv: "BUILD_TAG", runURL: envValue("BUILD_URL")},
+}
+
+// envValue returns a function reading the environment variable name.
+func envValue(name string) func(getenv func(string) string) string {
+	return func(getenv func(string) string) string {
+		return getenv(name)
+	}
+}
+
+// newProvenance describes the client submitting an execution: its version, its platform, the host it runs on
+// and the CI run it runs in, if any. Runs of an unknown CI provider setting CI only get the "unknown" provider.
+// Fields are truncated to the length the backend accepts.
+func newProvenance(getenv func(string) string, hostname func() (string, error)) *api.ExecutionProvenance {
+	provenance := &api.ExecutionProvenance{
+		ClientVersion: clientVersion,
+		OS:            runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if host, err := hostname(); err == nil {
+		provenance.Hostname = host
+	}
+
+	for _, provider := range ciProviders {
+		if getenv(provider.detectEnv) == "" {
+			continue
+		}
+		provenance.CIProvider = provider.name
+		provenance.CIRunID = getenv(provider.runIDEnv)
+		provenance.CIRunURL = provider.runURL(getenv)
+		break
+	}
+	if provenance.CIProvider == "" && strings.EqualFold(getenv("CI"), "true") {
+		provenance.CIProvider = "unknown"
+	}
+
+	for _, field := range []*string{
+		&provenance.ClientVersion, &provenance.OS, &provenance.Hostname,
+		&provenance.CIProvider, &provenance.CIRunID, &provenance.CIRunURL,
+	} {
+		if len(*field) > constants.MaxProvenanceFieldLength {
+			*field = (*field)[:constants.MaxProvenanceFieldLength]
+		}
+	}
+	return provenance
+}
+
+// processProvenance describes the client running in this process.
+func processProvenance() *api.ExecutionProvenance {
+	return newProvenance(os.Getenv, os.Hostname)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProvenance(t *testing.T) {
+	hostname := func() (string, error) { return "laptop", nil }
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantProvider string
+		wantRunID    string
+		wantRunURL   string
+	}{
+		{name: "outside of CI"},
+		{
+			name: "github actions",
+			env: map[string]string{
+				"GITHUB_ACTIONS": "true", "GITHUB_RUN_ID": "42",
+				"GITHUB_SERVER_URL": "https://github.com", "GITHUB_REPOSITORY": "acme/app",
+			},
+			wantProvider: "github-actions",
+			wantRunID:    "42",
+			wantRunURL:   "https://github.com/acme/app/actions/runs/42",
+		},
+		{
+			name: "gitlab ci",
+			env: map[string]string{
+				"GITLAB_CI": "true", "CI": "true", "CI_JOB_ID": "7", "CI_JOB_URL": "https://gitlab.com/acme/app/-/jobs/7",
+			},
+			wantProvider: "gitlab-ci",
+			wantRunID:    "7",
+			wantRunURL:   "https://gitlab.com/acme/app/-/jobs/7",
+		},
+		{name: "unknown CI", env: map[string]string{"CI": "true"}, wantProvider: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := newProvenance(func(key string) string { return tt.env[key] }, hostname)
+
+			assert.Equal(t, clientVersion, provenance.ClientVersion)
+			assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, provenance.OS)
+			assert.Equal(t, "laptop", provenance.Hostname)
+			assert.Equal(t, tt.wantProvider, provenance.CIProvider)
+			assert.Equal(t, tt.wantRunID, provenance.CIRunID)
+			assert.Equal(t, tt.wantRunURL, provenance.CIRunURL)
+		})
+	}
+}
+
+func TestNewProvenance_TruncatesAndSkipsUnknownHostname(t *testing.T) {
+	longID := strings.Repeat("x", constants.MaxProvenanceFieldLength+10)
+	env := map[string]string{"BUILDKITE": "true", "BUILDKITE_BUILD_ID": longID}
+
+	provenance := newProvenance(func(key string) string { return env[key] },
+		func() (string, error) { return "", errors.New("no hostname") })
+
+	assert.Empty(t, provenance.Hostname)
+	assert.Equal(t, "buildkite", provenance.CIProvider)
+	assert.Len(t, provenance.CIRunID, constants.MaxProvenanceFieldLength)
+}
@@ -0,0 +1,3 @@
+// Package selfupdate finds the releases of the CLI on GitHub, downloads and verifies the archive of the
+// platform and replaces the running binary with the one it contains.
+package selfupdate
@@ -0,0 +1,339 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/semver"
+)
+
+// Release channels: stable only installs the releases, beta the pre-releases too.
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+const (
+	// DefaultReleasesURL lists the releases of the repository in the GitHub API.
+	DefaultReleasesURL = "https://api.github.com/repos/runvoy/runvoy/releases"
+
+	checksumsSuffix = "_checksums.txt"
+	signatureSuffix = ".sig"
+	maxAssetSize    = 256 << 20
+	releasesPerPage = 50
+	windowsOS       = "windows"
+)
+
+// Release is a published release of the CLI.
+type Release struct {
+	Version    semver.Version
+	Tag        string
+	Prerelease bool
+
+	// assets maps the names of the release assets to their download URLs.
+	assets map[string]string
+}
+
+// Updater finds, downloads and verifies the releases.
+type Updater struct {
+	// ReleasesURL is the GitHub API endpoint listing the releases, DefaultReleasesURL by default.
+	ReleasesURL string
+	HTTPClient  *http.Client
+	// PublicKey verifies the signature of the checksums file. Without one only the checksum of the
+	// archive is verified.
+	PublicKey ed25519.PublicKey
+	// GOOS and GOARCH select the archive, the platform of the running binary by default.
+	GOOS   string
+	GOARCH string
+}
+
+// New creates an Updater for the running platform, verifying the releases with the base64 ed25519
+// public key, which may be empty.
+func New(publicKey string) (*Updater, error) {
+	u := &Updater{
+		ReleasesURL: DefaultReleasesURL,
+		HTTPClient:  http.DefaultClient,
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+	}
+	if publicKey == "" {
+		return u, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid release signing key: expected a base64 ed25519 public key")
+	}
+	u.PublicKey = key
+	return u, nil
+}
+
+// ParseChannel validates a release channel, stable when it is empty.
+func ParseChannel(channel string) (string, error) {
+	switch channel {
+	case "", ChannelStable:
+		return ChannelStable, nil
+	case ChannelBeta:
+		return ChannelBeta, nil
+	default:
+		return "", fmt.Errorf("invalid update channel %q: expected %s or %s", channel, ChannelStable, ChannelBeta)
+	}
+}
+
+// ArchiveName returns the name of the release archive of the platform, a zip archive on Windows and a
+// gzip-compressed tar archive elsewhere.
+func ArchiveName(goos, goarch string) string {
+	if goos == windowsOS {
+		return fmt.Sprintf("%s_%s_%s.zip", constants.ProjectName, goos, goarch)
+	}
+	return fmt.Sprintf("%s_%s_%s.tar.gz", constants.ProjectName, goos, goarch)
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Latest returns the highest release of the channel. The drafts and the tags that are not semantic
+// versions are ignored.
+func (u *Updater) Latest(ctx context.Context, channel string) (*Release, error) {
+	body, err := u.get(ctx, fmt.Sprintf("%s?per_page=%d", u.ReleasesURL, releasesPerPage))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the releases: %w", err)
+	}
+	var releases []githubRelease
+	if err = json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse the releases: %w", err)
+	}
+
+	var latest *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		version, parseErr := semver.Parse(r.TagName)
+		if parseErr != nil {
+			continue
+		}
+		if latest != nil && version.Compare(latest.Version) <= 0 {
+			continue
+		}
+		latest = &Release{Version: version, Tag: r.TagName, Prerelease: r.Prerelease, assets: map[string]string{}}
+		for _, asset := range r.Assets {
+			latest.assets[asset.Name] = asset.URL
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no %s release found", channel)
+	}
+	return latest, nil
+}
+
+// Download downloads the archive of the platform from the release, verifies its checksum and, when the
+// Updater has a public key, the signature of the checksums, and returns the binary it contains.
+func (u *Updater) Download(ctx context.Context, release *Release) ([]byte, error) {
+	archiveName := ArchiveName(u.GOOS, u.GOARCH)
+	archiveURL, ok := release.assets[archiveName]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no archive for %s/%s", release.Tag, u.GOOS, u.GOARCH)
+	}
+	checksumsName, checksumsURL := release.checksums()
+	if checksumsURL == "" {
+		return nil, fmt.Errorf("release %s has no checksums file", release.Tag)
+	}
+
+	checksums, err := u.get(ctx, checksumsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", checksumsName, err)
+	}
+	if err = u.verifySignature(ctx, release, checksumsName, checksums); err != nil {
+		return nil, err
+	}
+	expected, err := findChecksum(checksums, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := u.get(ctx, archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", archiveName, err)
+	}
+	sum := sha256.Sum256(archive)
+	if hex.EncodeToString(sum[:]) != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: the download is corrupted or was tampered with", archiveName)
+	}
+
+	if u.GOOS == windowsOS {
+		return extractZipBinary(archive, u.binaryName())
+	}
+	return extractBinary(archive, u.binaryName())
+}
+
+func (r *Release) checksums() (name, url string) {
+	for name, url = range r.assets {
+		if strings.HasSuffix(name, checksumsSuffix) {
+			return name, url
+		}
+	}
+	return "", ""
+}
+
+func (u *Updater) verifySignature(ctx context.Context, release *Release, checksumsName string, checksums []byte) error {
+	if u.PublicKey == nil {
+		return nil
+	}
+	signatureURL, ok := release.assets[checksumsName+signatureSuffix]
+	if !ok {
+		return fmt.Errorf("release %s is not signed", release.Tag)
+	}
+	encoded, err := u.get(ctx, signatureURL)
+	if err != nil {
+		return fmt.Errorf("failed to download the signature of %s: %w", checksumsName, err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(u.PublicKey, checksums, signature) {
+		return fmt.Errorf("invalid signature for %s: the release was not signed with the release key", checksumsName)
+	}
+	return nil
+}
+
+// findChecksum returns the SHA-256 of the file in a checksums file, made of "<sha256>  <name>" lines.
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", name)
+}
+
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil, fmt.Errorf("the archive has no %s binary", name)
+		}
+		if nextErr != nil {
+			return nil, fmt.Errorf("failed to read the archive: %w", nextErr)
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != name {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(tr, maxAssetSize))
+	}
+}
+
+func extractZipBinary(archive []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the archive: %w", err)
+	}
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || filepath.Base(file.Name) != name {
+			continue
+		}
+		rc, openErr := file.Open()
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to read the archive: %w", openErr)
+		}
+		defer func() { _ = rc.Close() }()
+		return io.ReadAll(io.LimitReader(rc, maxAssetSize))
+	}
+	return nil, fmt.Errorf("the archive has no %s binary", name)
+}
+
+func (u *Updater) binaryName() string {
+	if u.GOOS == windowsOS {
+		return constants.ProjectName + ".exe"
+	}
+	return constants.ProjectName
+}
+
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json, application/octet-stream")
+	req.Header.Set("User-Agent", constants.ProjectName+"/"+*constants.GetVersion())
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxAssetSize))
+}
+
+// Replace atomically replaces the binary at path, keeping its permissions: the new binary is written next
+// to it and renamed over it, so the path always holds a complete binary. On Windows, where a running
+// binary cannot be overwritten, the old one is moved aside to path.old first.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat the binary: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(binary)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+
+	if runtime.GOOS == windowsOS {
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err = os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move the old binary aside: %w", err)
+		}
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace the binary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseServer serves a GitHub releases feed and the assets of its releases.
+type releaseServer struct {
+	*httptest.Server
+	releases []map[string]any
+	assets   map[string][]byte
+}
+
+func newReleaseServer(t *testing.T) *releaseServer {
+	t.Helper()
+	s := &releaseServer{assets: map[string][]byte{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/releases" {
+			_ = json.NewEncoder(w).Encode(s.releases)
+			return
+		}
+		asset, ok := s.assets[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(asset)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// addRelease publishes a release with the given assets, served under /<tag>/<name>.
+func (s *releaseServer) addRelease(tag string, prerelease bool, assets map[string][]byte) {
+	list := []map[string]any{}
+	for name, content := range assets {
+		path := "/" + tag + "/" + name
+		s.assets[path] = content
+		list = append(list, map[string]any{"name": name, "browser_download_url": s.URL + path})
+	}
+	s.releases = append(s.releases, map[string]any{"tag_name": tag, "prerelease": prerelease, "assets": list})
+}
+
+func (s *releaseServer) updater() *Updater {
+	return &Updater{ReleasesURL: s.URL + "/releases", HTTPClient: s.Client(), GOOS: "linux", GOARCH: "amd64"}
+}
+
+func buildArchive(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{{"README.md", []byte("readme")}, {name, content}} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: file.name, Mode: 0o755, Size: int64(len(file.content)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(file.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// releaseAssets returns the archive, the checksums and, with a private key, the signature of a release.
+func releaseAssets(t *testing.T, version string, binary []byte, key ed25519.PrivateKey) map[string][]byte {
+	t.Helper()
+	archive := buildArchive(t, "runvoy", binary)
+	sum := sha256.Sum256(archive)
+	checksumsName := fmt.Sprintf("runvoy_%s_checksums.txt", version)
+	checksums := []byte(fmt.Sprintf("%s  runvoy_linux_amd64.tar.gz\n%s  runvoy_darwin_arm64.tar.gz\n",
+		hex.EncodeToString(sum[:]), hex.EncodeToString(make([]byte, sha256.Size))))
+
+	assets := map[string][]byte{"runvoy_linux_amd64.tar.gz": archive, checksumsName: checksums}
+	if key != nil {
+		assets[checksumsName+".sig"] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, checksums)))
+	}
+	return assets
+}
+
+func TestNew(t *testing.T) {
+	u, err := New("")
+	require.NoError(t, err)
+	assert.Nil(t, u.PublicKey)
+	assert.Equal(t, DefaultReleasesURL, u.ReleasesURL)
+
+	public, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	u, err = New(base64.StdEncoding.EncodeToString(public))
+	require.NoError(t, err)
+	assert.Equal(t, public, u.PublicKey)
+
+	_, err = New("bm90IGEga2V5")
+	assert.ErrorContains(t, err, "invalid release signing key")
+}
+
+func TestParseChannel(t *testing.T) {
+	for input, expected := range map[string]string{"": ChannelStable, "stable": ChannelStable, "beta": ChannelBeta} {
+		channel, err := ParseChannel(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, channel)
+	}
+
+	_, err := ParseChannel("nightly")
+	assert.ErrorContains(t, err, `invalid update channel "nightly"`)
+}
+
+func TestUpdater_Latest(t *testing.T) {
+	server := newReleaseServer(t)
+	server.addRelease("v1.2.0", false, nil)
+	server.addRelease("v1.10.0", false, nil)
+	server.addRelease("v1.11.0-rc.1", true, nil)
+	server.addRelease("nightly", true, nil)
+	server.releases = append(server.releases, map[string]any{"tag_name": "v2.0.0", "draft": true})
+
+	t.Run("stable skips the pre-releases and the drafts", func(t *testing.T) {
+		release, err := server.updater().Latest(context.Background(), ChannelStable)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.10.0", release.Tag)
+		assert.False(t, release.Prerelease)
+	})
+
+	t.Run("beta includes the pre-releases", func(t *testing.T) {
+		release, err := server.updater().Latest(context.Background(), ChannelBeta)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.11.0-rc.1", release.Tag)
+		assert.True(t, release.Prerelease)
+	})
+
+	t.Run("fails without a release in the channel", func(t *testing.T) {
+		empty := newReleaseServer(t)
+		empty.addRelease("v1.0.0-beta.1", true, nil)
+
+		_, err := empty.updater().Latest(context.Background(), ChannelStable)
+		assert.ErrorContains(t, err, "no stable release found")
+	})
+}
+
+func TestUpdater_Download(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	binary := []byte("#!new binary")
+
+	download := func(t *testing.T, assets map[string][]byte, key ed25519.PublicKey) ([]byte, error) {
+		t.Helper()
+		server := newReleaseServer(t)
+		server.addRelease("v1.2.0", false, assets)
+		u := server.updater()
+		u.PublicKey = key
+		release, latestErr := u.Latest(context.Background(), ChannelStable)
+		require.NoError(t, latestErr)
+		return u.Download(context.Background(), release)
+	}
+
+	t.Run("verifies the signature and the checksum", func(t *testing.T) {
+		got, downloadErr := download(t, releaseAssets(t, "1.2.0", binary, private), public)
+		require.NoError(t, downloadErr)
+		assert.Equal(t, binary, got)
+	})
+
+	t.Run("only verifies the checksum without a public key", func(t *testing.T) {
+		got, downloadErr := download(t, releaseAssets(t, "1.2.0", binary, nil), nil)
+		require.NoError(t, downloadErr)
+		assert.Equal(t, binary, got)
+	})
+
+	t.Run("rejects an unsigned release", func(t *testing.T) {
+		_, downloadErr := download(t, releaseAssets(t, "1.2.0", binary, nil), public)
+		assert.ErrorContains(t, downloadErr, "release v1.2.0 is not signed")
+	})
+
+	t.Run("rejects a release signed with another key", func(t *testing.T) {
+		_, downloadErr := download(t, releaseAssets(t, "1.2.0", binary, otherKey), public)
+		assert.ErrorContains(t, downloadErr, "invalid signature for runvoy_1.2.0_checksums.txt")
+	})
+
+	t.Run("rejects a tampered archive", func(t *testing.T) {
+		assets := releaseAssets(t, "1.2.0", binary, private)
+		assets["runvoy_linux_amd64.tar.gz"] = buildArchive(t, "runvoy", []byte("#!evil binary"))
+
+		_, downloadErr := download(t, assets, public)
+		assert.ErrorContains(t, downloadErr, "checksum mismatch for runvoy_linux_amd64.tar.gz")
+	})
+
+	t.Run("extracts the binary from the zip archive on Windows", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, createErr := zw.Create("runvoy.exe")
+		require.NoError(t, createErr)
+		_, createErr = w.Write(binary)
+		require.NoError(t, createErr)
+		require.NoError(t, zw.Close())
+		sum := sha256.Sum256(buf.Bytes())
+		checksums := []byte(hex.EncodeToString(sum[:]) + "  runvoy_windows_arm64.zip\n")
+
+		server := newReleaseServer(t)
+		server.addRelease("v1.2.0", false, map[string][]byte{
+			"runvoy_windows_arm64.zip":       buf.Bytes(),
+			"runvoy_1.2.0_checksums.txt":     checksums,
+			"runvoy_1.2.0_checksums.txt.sig": []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, checksums))),
+		})
+		u := server.updater()
+		u.PublicKey, u.GOOS, u.GOARCH = public, "windows", "arm64"
+		release, latestErr := u.Latest(context.Background(), ChannelStable)
+		require.NoError(t, latestErr)
+
+		got, downloadErr := u.Download(context.Background(), release)
+		require.NoError(t, downloadErr)
+		assert.Equal(t, binary, got)
+	})
+
+	t.Run("fails without an archive for the platform", func(t *testing.T) {
+		assets := releaseAssets(t, "1.2.0", binary, private)
+		delete(assets, "runvoy_linux_amd64.tar.gz")
+
+		_, downloadErr := download(t, assets, public)
+		assert.ErrorContains(t, downloadErr, "release v1.2.0 has no archive for linux/amd64")
+	})
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runvoy")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o700))
+
+	require.NoError(t, Replace(path, []byte("new")))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file is renamed over the binary")
+
+	assert.ErrorContains(t, Replace(filepath.Join(t.TempDir(), "missing"), []byte("new")), "failed to stat the binary")
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// OperationClass selects the timeout of an API call.
+type OperationClass int
+
+const (
+	// OperationShort is a quick API call, such as a status check, limited by the short timeout.
+	OperationShort OperationClass = iota
+	// OperationLong is an API call transferring logs, secrets or uploads, limited by the long timeout.
+	OperationLong
+	// OperationStream is a streamed API call, only limited by its context.
+	OperationStream
+)
+
+var (
+	transportsMu sync.Mutex
+	// transports are shared by the clients of the process by DNS cache TTL, so that the clients created
+	// by the commands reuse the connections of each other.
+	transports = map[time.Duration]*http.Transport{}
+)
+
+// sharedTransport returns the transport of the clients caching the resolved addresses for dnsCacheTTL,
+// creating it on first use. It keeps connections alive for reuse and negotiates HTTP/2 when the server
+// supports it, so that scripts making many calls pay the connection setup once. The transport asks for
+// gzip responses and decompresses them transparently, as long as the requests set no Accept-Encoding.
+func sharedTransport(dnsCacheTTL time.Duration) *http.Transport {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if transport, ok := transports[dnsCacheTTL]; ok {
+		return transport
+	}
+
+	dialer := &net.Dialer{Timeout: constants.ClientDialTimeout, KeepAlive: constants.ClientKeepAlive}
+	dialContext := dialer.DialContext
+	if dnsCacheTTL > 0 {
+		dialContext = newDNSCache(dnsCacheTTL, net.DefaultResolver.LookupHost).dialContext(dialer.DialContext)
+	}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          constants.ClientMaxIdleConns,
+		MaxIdleConnsPerHost:   constants.ClientMaxIdleConnsPerHost,
+		IdleConnTimeout:       constants.ClientIdleConnTimeout,
+		TLSHandshakeTimeout:   constants.ClientTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	transports[dnsCacheTTL] = transport
+	return transport
+}
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// dnsCache caches the addresses hosts resolve to, saving a lookup for each new connection.
+type dnsCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, host string) ([]string, error)
+	nowFn  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsCacheEntry
+}
+
+type dnsCacheEntry struct {
+	addrs     []string
+	expiresAt time.Time
+}
+
+func newDNSCache(ttl time.Duration, lookup func(ctx context.Context, host string) ([]string, error)) *dnsCache {
+	return &dnsCache{
+		ttl:     ttl,
+		lookup:  lookup,
+		nowFn:   time.Now,
+		entries: map[string]dnsCacheEntry{},
+	}
+}
+
+// resolve returns the cached addresses of host, looking them up when they are missing or expired.
+func (c *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && c.nowFn().Before(entry.expiresAt) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsCacheEntry{addrs: addrs, expiresAt: c.nowFn().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// forget drops the cached addresses of host, e.g. once none of them accepts connections.
+func (c *dnsCache) forget(host string) {
+	c.mu.Lock()
+	delete(c.entries, host)
+	c.mu.Unlock()
+}
+
+// dialContext wraps dial to connect to the cached addresses of the host, trying them in order.
+// Addresses that are IPs already are dialed as is.
+func (c *dnsCache) dialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, addr)
+		}
+
+		addrs, err := c.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var dialErrs []error
+		for _, ip := range addrs {
+			conn, dialErr := dial(ctx, network, net.JoinHostPort(ip, port))
+			if dialErr == nil {
+				return conn, nil
+			}
+			dialErrs = append(dialErrs, dialErr)
+		}
+		// The host may have moved, the next connection looks it up again.
+		c.forget(host)
+		return nil, errors.Join(dialErrs...)
+	}
+}
+
+// IsUnreachable reports whether a request failed before reaching the API: its host could not be resolved
+// or no connection could be established, so the request was certainly not served.
+func IsUnreachable(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
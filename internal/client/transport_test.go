@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	addrs   []string
+	err     error
+	lookups int
+}
+
+func (r *fakeResolver) lookup(_ context.Context, _ string) ([]string, error) {
+	r.lookups++
+	return r.addrs, r.err
+}
+
+func TestDNSCache_Resolve(t *testing.T) {
+	t.Run("caches addresses until they expire", func(t *testing.T) {
+		resolver := &fakeResolver{addrs: []string{"10.0.0.1"}}
+		cache := newDNSCache(time.Minute, resolver.lookup)
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		cache.nowFn = func() time.Time { return now }
+
+		for range 3 {
+			addrs, err := cache.resolve(context.Background(), "api.example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"10.0.0.1"}, addrs)
+		}
+		assert.Equal(t, 1, resolver.lookups)
+
+		now = now.Add(time.Minute)
+		_, err := cache.resolve(context.Background(), "api.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 2, resolver.lookups)
+	})
+
+	t.Run("does not cache failed lookups", func(t *testing.T) {
+		resolver := &fakeResolver{err: errors.New("no such host")}
+		cache := newDNSCache(time.Minute, resolver.lookup)
+
+		_, err := cache.resolve(context.Background(), "api.example.com")
+		require.Error(t, err)
+		_, err = cache.resolve(context.Background(), "api.example.com")
+		require.Error(t, err)
+		assert.Equal(t, 2, resolver.lookups)
+	})
+
+	t.Run("rejects empty lookups", func(t *testing.T) {
+		cache := newDNSCache(time.Minute, (&fakeResolver{}).lookup)
+
+		_, err := cache.resolve(context.Background(), "api.example.com")
+		var dnsErr *net.DNSError
+		require.ErrorAs(t, err, &dnsErr)
+		assert.True(t, dnsErr.IsNotFound)
+	})
+}
+
+func TestDNSCache_DialContext(t *testing.T) {
+	t.Run("dials the cached addresses in order", func(t *testing.T) {
+		resolver := &fakeResolver{addrs: []string{"10.0.0.1", "10.0.0.2"}}
+		cache := newDNSCache(time.Minute, resolver.lookup)
+		var dialed []string
+		dial := cache.dialContext(func(_ context.Context, _, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			if addr == "10.0.0.1:443" {
+				return nil, errors.New("connection refused")
+			}
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		})
+
+		conn, err := dial(context.Background(), "tcp", "api.example.com:443")
+		require.NoError(t, err)
+		_ = conn.Close()
+		assert.Equal(t, []string{"10.0.0.1:443", "10.0.0.2:443"}, dialed)
+		assert.Equal(t, 1, resolver.lookups)
+	})
+
+	t.Run("forgets the host when no address accepts connections", func(t *testing.T) {
+		resolver := &fakeResolver{addrs: []string{"10.0.0.1"}}
+		cache := newDNSCache(time.Minute, resolver.lookup)
+		dial := cache.dialContext(func(_ context.Context, _, _ string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		})
+
+		_, err := dial(context.Background(), "tcp", "api.example.com:443")
+		require.ErrorContains(t, err, "connection refused")
+		_, err = dial(context.Background(), "tcp", "api.example.com:443")
+		require.Error(t, err)
+		assert.Equal(t, 2, resolver.lookups)
+	})
+
+	t.Run("dials IP addresses as is", func(t *testing.T) {
+		resolver := &fakeResolver{addrs: []string{"10.0.0.1"}}
+		cache := newDNSCache(time.Minute, resolver.lookup)
+		var dialed string
+		dial := cache.dialContext(func(_ context.Context, _, addr string) (net.Conn, error) {
+			dialed = addr
+			return nil, errors.New("connection refused")
+		})
+
+		_, _ = dial(context.Background(), "tcp", "127.0.0.1:8080")
+		assert.Equal(t, "127.0.0.1:8080", dialed)
+		assert.Zero(t, resolver.lookups)
+	})
+}
+
+func TestSharedTransport(t *testing.T) {
+	transport := sharedTransport(time.Minute)
+
+	assert.Same(t, transport, sharedTransport(time.Minute))
+	assert.NotSame(t, transport, sharedTransport(0))
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.Positive(t, transport.MaxIdleConnsPerHost)
+}
+
+func TestIsUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedEndpoint := "http://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	c := New(&config.Config{APIEndpoint: closedEndpoint}, testutil.SilentLogger())
+	_, err = c.GetHealth(context.Background())
+	require.Error(t, err)
+	assert.True(t, IsUnreachable(err), "the connection was refused")
+
+	assert.True(t, IsUnreachable(fmt.Errorf("failed: %w", &net.DNSError{Err: "no such host", IsNotFound: true})))
+	assert.False(t, IsUnreachable(&net.OpError{Op: "read", Err: errors.New("connection reset")}),
+		"the request may have been served")
+	assert.False(t, IsUnreachable(errors.New("invalid request")))
+}
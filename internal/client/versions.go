@@ -0,0 +1,98 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/semver"
+)
+
+// ErrVersionSkew is returned for the requests refused because the CLI and the backend versions are too far
+// apart, with a pointer to the command upgrading the older one.
+var ErrVersionSkew = errors.New("incompatible CLI and backend versions")
+
+var (
+	versionsMu sync.Mutex
+	// clientVersion is the version of the CLI sent with the requests.
+	clientVersion = *constants.GetVersion()
+	// lastBackendVersion and lastMinClientVersion are the versions sent with the last API response received
+	// by the clients of the process.
+	lastBackendVersion   string
+	lastMinClientVersion string
+)
+
+// recordVersions keeps the backend versions sent with an API response. The responses without them, such as
+// those of the backends older than the headers, keep the last known versions.
+func recordVersions(header http.Header) {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	if backendVersion := header.Get(constants.BackendVersionHeader); backendVersion != "" {
+		lastBackendVersion = backendVersion
+		lastMinClientVersion = header.Get(constants.MinClientVersionHeader)
+	}
+}
+
+// VersionSkew is a difference between the versions of the CLI and of the backend.
+type VersionSkew struct {
+	// Incompatible is set when one of them is older than the other supports, the requests changing
+	// anything are then refused.
+	Incompatible bool
+	Message      string
+}
+
+// CheckVersionSkew compares the version of the CLI with the version of the backend and the oldest CLI
+// version the backend supports. It returns nil when the versions only differ by their patch version, or
+// when one of them is unknown or of a development build.
+func CheckVersionSkew(cliVersion, backendVersion, minClientVersion string) *VersionSkew {
+	cli, err := semver.Parse(cliVersion)
+	if err != nil || cli.IsDevelopment() {
+		return nil
+	}
+	backend, err := semver.Parse(backendVersion)
+	if err != nil || backend.IsDevelopment() {
+		return nil
+	}
+
+	if minClient, parseErr := semver.Parse(minClientVersion); parseErr == nil && cli.Compare(minClient) < 0 {
+		return &VersionSkew{Incompatible: true, Message: fmt.Sprintf(
+			"the backend %s requires %s %s or later, run %s self-update to upgrade this CLI from %s",
+			backend, constants.ProjectName, minClient, constants.ProjectName, cli)}
+	}
+	if minBackend, parseErr := semver.Parse(constants.MinBackendVersion); parseErr == nil &&
+		backend.Compare(minBackend) < 0 {
+		return &VersionSkew{Incompatible: true, Message: fmt.Sprintf(
+			"this CLI requires a backend %s or later, run %s infra upgrade to upgrade the backend from %s",
+			minBackend, constants.ProjectName, backend)}
+	}
+
+	switch {
+	case cli.Major == backend.Major && cli.Minor == backend.Minor:
+		return nil
+	case cli.Compare(backend) > 0:
+		return &VersionSkew{Message: fmt.Sprintf(
+			"the backend %s is older than this CLI %s and may not support all its features, run %s infra upgrade",
+			backend, cli, constants.ProjectName)}
+	default:
+		return &VersionSkew{Message: fmt.Sprintf(
+			"this CLI %s is older than the backend %s, run %s self-update", cli, backend, constants.ProjectName)}
+	}
+}
+
+// LastVersionSkew returns the skew between the CLI and the backend of the last API response received by the
+// clients of the process, nil when there is none or no request was made.
+func LastVersionSkew() *VersionSkew {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	return CheckVersionSkew(clientVersion, lastBackendVersion, lastMinClientVersion)
+}
+
+// versionSkewError returns ErrVersionSkew when the last API response showed incompatible versions.
+func versionSkewError() error {
+	if skew := LastVersionSkew(); skew != nil && skew.Incompatible {
+		return fmt.Errorf("%w: %s", ErrVersionSkew, skew.Message)
+	}
+	return nil
+}
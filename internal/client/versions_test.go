@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersionSkew(t *testing.T) {
+	tests := []struct {
+		name             string
+		cli              string
+		backend          string
+		minClient        string
+		wantNil          bool
+		wantIncompatible bool
+		wantMessage      string
+	}{
+		{name: "same minor version", cli: "v1.2.0", backend: "v1.2.5", minClient: "v1.0.0", wantNil: true},
+		{name: "development CLI", cli: "0.0.0-development", backend: "v1.2.0", wantNil: true},
+		{name: "development backend", cli: "v1.2.0", backend: "0.0.0-development", wantNil: true},
+		{name: "unknown backend", cli: "v1.2.0", backend: "", wantNil: true},
+		{
+			name: "CLI older than the backend supports", cli: "v1.0.0", backend: "v2.0.0", minClient: "v1.5.0",
+			wantIncompatible: true, wantMessage: "requires runvoy v1.5.0 or later, run runvoy self-update",
+		},
+		{
+			name: "backend older than the CLI supports", cli: "v1.0.0", backend: "v0.4.0",
+			wantIncompatible: true, wantMessage: "run runvoy infra upgrade to upgrade the backend from v0.4.0",
+		},
+		{
+			name: "newer CLI", cli: "v1.3.0", backend: "v1.2.0",
+			wantMessage: "the backend v1.2.0 is older than this CLI v1.3.0",
+		},
+		{
+			name: "older CLI", cli: "v1.1.0", backend: "v1.2.0", minClient: "v1.0.0",
+			wantMessage: "this CLI v1.1.0 is older than the backend v1.2.0, run runvoy self-update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew := CheckVersionSkew(tt.cli, tt.backend, tt.minClient)
+
+			if tt.wantNil {
+				assert.Nil(t, skew)
+				return
+			}
+			require.NotNil(t, skew)
+			assert.Equal(t, tt.wantIncompatible, skew.Incompatible)
+			assert.Contains(t, skew.Message, tt.wantMessage)
+		})
+	}
+}
+
+func TestClient_VersionSkew(t *testing.T) {
+	originalVersion := clientVersion
+	clientVersion = "v1.0.0"
+	t.Cleanup(func() {
+		clientVersion = originalVersion
+		recordVersions(http.Header{constants.BackendVersionHeader: {"0.0.0-development"}})
+	})
+
+	backendVersion := "v1.0.0"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "v1.0.0", r.Header.Get(constants.ClientVersionHeader))
+		w.Header().Set(constants.BackendVersionHeader, backendVersion)
+		w.Header().Set(constants.MinClientVersionHeader, "v1.0.0")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(api.HealthResponse{Status: "ok", Version: backendVersion})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(api.ErrorResponse{Error: "unknown field"})
+	}))
+	defer server.Close()
+	c := New(&config.Config{APIEndpoint: server.URL, APIKey: "test-api-key"}, testutil.SilentLogger())
+	ctx := context.Background()
+
+	_, err := c.GetHealth(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, LastVersionSkew())
+
+	backendVersion = "v0.1.0"
+	_, err = c.GetHealth(ctx)
+	require.NoError(t, err, "reads are still sent to an incompatible backend")
+	require.NotNil(t, LastVersionSkew())
+	assert.True(t, LastVersionSkew().Incompatible)
+
+	_, err = c.SetAnnouncement(ctx, api.AnnouncementRequest{Message: "hello"})
+	require.ErrorIs(t, err, ErrVersionSkew)
+	assert.Contains(t, err.Error(), "run runvoy infra upgrade")
+	assert.Equal(t, 2, requests, "changes are not sent to an incompatible backend")
+}
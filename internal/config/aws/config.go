@@ -19,9 +19,13 @@ import (
 type Config struct {
 	// DynamoDB Tables
 	APIKeysTable              string `mapstructure:"api_keys_table"`
+	CommandPoliciesTable      string `mapstructure:"command_policies_table"`
+	ConfigTable               string `mapstructure:"config_table"`
 	ExecutionsTable           string `mapstructure:"executions_table"`
 	ExecutionLogsTable        string `mapstructure:"execution_logs_table"`
+	HealthReportsTable        string `mapstructure:"health_reports_table"`
 	ImageTaskDefsTable        string `mapstructure:"image_taskdefs_table"`
+	LocksTable                string `mapstructure:"locks_table"`
 	PendingAPIKeysTable       string `mapstructure:"pending_api_keys_table"`
 	SecretsMetadataTable      string `mapstructure:"secrets_metadata_table"`
 	WebSocketConnectionsTable string `mapstructure:"websocket_connections_table"`
@@ -36,11 +40,21 @@ type Config struct {
 	Subnet2                string `mapstructure:"subnet_2"`
 	TaskDefinition         string `mapstructure:"task_definition"`
 
+	// S3 bucket for execution inputs (e.g. uploaded stdin), stdin uploads are unavailable when empty
+	InputsBucket string `mapstructure:"inputs_bucket"`
+
+	// URL of the runvoy-init binary the runs download, {arch} standing for amd64 or arm64.
+	// The runs use the generated shell scripts when empty.
+	RunnerInitURL string `mapstructure:"runner_init_url"`
+
 	// CloudWatch Logs
 	LogGroup               string `mapstructure:"log_group"`
 	OrchestratorLogGroup   string `mapstructure:"orchestrator_log_group"`
 	EventProcessorLogGroup string `mapstructure:"event_processor_log_group"`
 
+	// CloudWatch namespace of the metrics emitted by the backend services
+	MetricsNamespace string `mapstructure:"metrics_namespace"`
+
 	// API Gateway WebSocket
 	WebSocketAPIEndpoint string `mapstructure:"websocket_api_endpoint"`
 
@@ -59,6 +73,7 @@ type Config struct {
 func BindEnvVars(v *viper.Viper) {
 	v.SetDefault("aws.secrets_prefix", awsConstants.SecretsPrefix)
 	v.SetDefault("aws.infra_default_stack_name", awsConstants.DefaultInfraStackName)
+	v.SetDefault("aws.metrics_namespace", awsConstants.DefaultMetricsNamespace)
 
 	_ = v.BindEnv("aws.api_keys_table", "RUNVOY_AWS_API_KEYS_TABLE")
 	_ = v.BindEnv("aws.default_task_exec_role_arn", "RUNVOY_AWS_DEFAULT_TASK_EXEC_ROLE_ARN")
@@ -66,10 +81,17 @@ func BindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("aws.ecs_cluster", "RUNVOY_AWS_ECS_CLUSTER")
 	_ = v.BindEnv("aws.executions_table", "RUNVOY_AWS_EXECUTIONS_TABLE")
 	_ = v.BindEnv("aws.execution_logs_table", "RUNVOY_AWS_EXECUTION_LOGS_TABLE")
+	_ = v.BindEnv("aws.health_reports_table", "RUNVOY_AWS_HEALTH_REPORTS_TABLE")
+	_ = v.BindEnv("aws.command_policies_table", "RUNVOY_AWS_COMMAND_POLICIES_TABLE")
+	_ = v.BindEnv("aws.config_table", "RUNVOY_AWS_CONFIG_TABLE")
 	_ = v.BindEnv("aws.image_taskdefs_table", "RUNVOY_AWS_IMAGE_TASKDEFS_TABLE")
+	_ = v.BindEnv("aws.locks_table", "RUNVOY_AWS_LOCKS_TABLE")
+	_ = v.BindEnv("aws.inputs_bucket", "RUNVOY_AWS_INPUTS_BUCKET")
 	_ = v.BindEnv("aws.log_group", "RUNVOY_AWS_LOG_GROUP")
+	_ = v.BindEnv("aws.runner_init_url", "RUNVOY_AWS_RUNNER_INIT_URL")
 	_ = v.BindEnv("aws.orchestrator_log_group", "RUNVOY_AWS_ORCHESTRATOR_LOG_GROUP")
 	_ = v.BindEnv("aws.event_processor_log_group", "RUNVOY_AWS_EVENT_PROCESSOR_LOG_GROUP")
+	_ = v.BindEnv("aws.metrics_namespace", "RUNVOY_AWS_METRICS_NAMESPACE")
 	_ = v.BindEnv("aws.pending_api_keys_table", "RUNVOY_AWS_PENDING_API_KEYS_TABLE")
 	_ = v.BindEnv("aws.secrets_kms_key_arn", "RUNVOY_AWS_SECRETS_KMS_KEY_ARN")
 	_ = v.BindEnv("aws.secrets_metadata_table", "RUNVOY_AWS_SECRETS_METADATA_TABLE")
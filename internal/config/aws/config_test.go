@@ -276,10 +276,13 @@ func TestBindEnvVars(t *testing.T) {
 		"RUNVOY_AWS_ECS_CLUSTER":               os.Getenv("RUNVOY_AWS_ECS_CLUSTER"),
 		"RUNVOY_AWS_EXECUTIONS_TABLE":          os.Getenv("RUNVOY_AWS_EXECUTIONS_TABLE"),
 		"RUNVOY_AWS_EXECUTION_LOGS_TABLE":      os.Getenv("RUNVOY_AWS_EXECUTION_LOGS_TABLE"),
+		"RUNVOY_AWS_HEALTH_REPORTS_TABLE":      os.Getenv("RUNVOY_AWS_HEALTH_REPORTS_TABLE"),
 		"RUNVOY_AWS_IMAGE_TASKDEFS_TABLE":      os.Getenv("RUNVOY_AWS_IMAGE_TASKDEFS_TABLE"),
+		"RUNVOY_AWS_INPUTS_BUCKET":             os.Getenv("RUNVOY_AWS_INPUTS_BUCKET"),
 		"RUNVOY_AWS_LOG_GROUP":                 os.Getenv("RUNVOY_AWS_LOG_GROUP"),
 		"RUNVOY_AWS_ORCHESTRATOR_LOG_GROUP":    os.Getenv("RUNVOY_AWS_ORCHESTRATOR_LOG_GROUP"),
 		"RUNVOY_AWS_EVENT_PROCESSOR_LOG_GROUP": os.Getenv("RUNVOY_AWS_EVENT_PROCESSOR_LOG_GROUP"),
+		"RUNVOY_AWS_METRICS_NAMESPACE":         os.Getenv("RUNVOY_AWS_METRICS_NAMESPACE"),
 		"RUNVOY_AWS_SECURITY_GROUP":            os.Getenv("RUNVOY_AWS_SECURITY_GROUP"),
 		"RUNVOY_AWS_SUBNET_1":                  os.Getenv("RUNVOY_AWS_SUBNET_1"),
 		"RUNVOY_AWS_SUBNET_2":                  os.Getenv("RUNVOY_AWS_SUBNET_2"),
@@ -308,6 +311,8 @@ func TestBindEnvVars(t *testing.T) {
 	_ = os.Setenv("RUNVOY_AWS_API_KEYS_TABLE", "test-api-keys")
 	_ = os.Setenv("RUNVOY_AWS_ECS_CLUSTER", "test-cluster")
 	_ = os.Setenv("RUNVOY_AWS_EXECUTION_LOGS_TABLE", "test-execution-logs")
+	_ = os.Setenv("RUNVOY_AWS_HEALTH_REPORTS_TABLE", "test-health-reports")
+	_ = os.Setenv("RUNVOY_AWS_INPUTS_BUCKET", "test-inputs")
 	_ = os.Setenv("RUNVOY_AWS_LOG_GROUP", "/aws/ecs/test")
 	_ = os.Setenv("RUNVOY_AWS_ORCHESTRATOR_LOG_GROUP", "/aws/lambda/orchestrator")
 	_ = os.Setenv("RUNVOY_AWS_EVENT_PROCESSOR_LOG_GROUP", "/aws/lambda/event-processor")
@@ -322,6 +327,9 @@ func TestBindEnvVars(t *testing.T) {
 	assert.Equal(t, "test-api-keys", v.GetString("aws.api_keys_table"))
 	assert.Equal(t, "test-cluster", v.GetString("aws.ecs_cluster"))
 	assert.Equal(t, "test-execution-logs", v.GetString("aws.execution_logs_table"))
+	assert.Equal(t, "test-health-reports", v.GetString("aws.health_reports_table"))
+	assert.Equal(t, "runvoy", v.GetString("aws.metrics_namespace"))
+	assert.Equal(t, "test-inputs", v.GetString("aws.inputs_bucket"))
 	assert.Equal(t, "/aws/ecs/test", v.GetString("aws.log_group"))
 	assert.Equal(t, "/aws/lambda/orchestrator", v.GetString("aws.orchestrator_log_group"))
 	assert.Equal(t, "/aws/lambda/event-processor", v.GetString("aws.event_processor_log_group"))
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// HTTPClientConfig tunes the HTTP client the CLI calls the API with.
+// Zero values stand for the defaults of the constants package.
+type HTTPClientConfig struct {
+	// ShortTimeout limits the quick API calls, such as status checks.
+	ShortTimeout time.Duration `mapstructure:"short_timeout" yaml:"short_timeout,omitempty"`
+	// LongTimeout limits the API calls transferring logs, secrets or uploads.
+	LongTimeout time.Duration `mapstructure:"long_timeout" yaml:"long_timeout,omitempty"`
+	// DNSCacheTTL is how long the resolved addresses of the API host are reused, a negative value
+	// resolves it for every connection.
+	DNSCacheTTL time.Duration `mapstructure:"dns_cache_ttl" yaml:"dns_cache_ttl,omitempty"`
+}
+
+// GetShortTimeout returns the timeout of the quick API calls.
+func (c *HTTPClientConfig) GetShortTimeout() time.Duration {
+	if c.ShortTimeout == 0 {
+		return constants.DefaultClientShortTimeout
+	}
+	return c.ShortTimeout
+}
+
+// GetLongTimeout returns the timeout of the API calls transferring logs, secrets or uploads.
+func (c *HTTPClientConfig) GetLongTimeout() time.Duration {
+	if c.LongTimeout == 0 {
+		return constants.DefaultClientLongTimeout
+	}
+	return c.LongTimeout
+}
+
+// GetDNSCacheTTL returns how long the resolved addresses are reused, 0 when they are not cached.
+func (c *HTTPClientConfig) GetDNSCacheTTL() time.Duration {
+	switch {
+	case c.DNSCacheTTL == 0:
+		return constants.DefaultClientDNSCacheTTL
+	case c.DNSCacheTTL < 0:
+		return 0
+	default:
+		return c.DNSCacheTTL
+	}
+}
+
+// Validate checks that the timeouts are not negative.
+func (c *HTTPClientConfig) Validate() error {
+	if c.ShortTimeout < 0 || c.LongTimeout < 0 {
+		return errors.New("the HTTP client timeouts must not be negative")
+	}
+	return nil
+}
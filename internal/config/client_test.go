@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClientConfig_Getters(t *testing.T) {
+	var defaults HTTPClientConfig
+	assert.Equal(t, constants.DefaultClientShortTimeout, defaults.GetShortTimeout())
+	assert.Equal(t, constants.DefaultClientLongTimeout, defaults.GetLongTimeout())
+	assert.Equal(t, constants.DefaultClientDNSCacheTTL, defaults.GetDNSCacheTTL())
+
+	custom := HTTPClientConfig{ShortTimeout: 5 * time.Second, LongTimeout: time.Hour, DNSCacheTTL: 10 * time.Second}
+	assert.Equal(t, 5*time.Second, custom.GetShortTimeout())
+	assert.Equal(t, time.Hour, custom.GetLongTimeout())
+	assert.Equal(t, 10*time.Second, custom.GetDNSCacheTTL())
+
+	disabled := HTTPClientConfig{DNSCacheTTL: -1}
+	assert.Zero(t, disabled.GetDNSCacheTTL())
+}
+
+func TestHTTPClientConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&HTTPClientConfig{}).Validate())
+	assert.NoError(t, (&HTTPClientConfig{DNSCacheTTL: -1}).Validate())
+	assert.Error(t, (&HTTPClientConfig{ShortTimeout: -time.Second}).Validate())
+	assert.Error(t, (&HTTPClientConfig{LongTimeout: -time.Second}).Validate())
+}
@@ -9,9 +9,12 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/chaos"
 	awsconfig "github.com/runvoy/runvoy/internal/config/aws"
 	"github.com/runvoy/runvoy/internal/constants"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
@@ -29,6 +32,12 @@ type Config struct {
 	APIKey      string `mapstructure:"api_key" yaml:"api_key"`
 	WebURL      string `mapstructure:"web_url" yaml:"web_url" validate:"omitempty,url"`
 
+	// UpdateChannel is the release channel self-update installs from: stable, the default, or beta.
+	UpdateChannel string `mapstructure:"update_channel" yaml:"update_channel,omitempty"`
+
+	// HTTPClient tunes the connections and the timeouts of the API calls made by the CLI.
+	HTTPClient HTTPClientConfig `mapstructure:"http_client" yaml:"http_client,omitempty"`
+
 	// Backend Service Configuration
 	BackendProvider    constants.BackendProvider `mapstructure:"backend_provider" yaml:"backend_provider"`
 	InitTimeout        time.Duration             `mapstructure:"init_timeout"`
@@ -37,6 +46,78 @@ type Config struct {
 	RequestTimeout     time.Duration             `mapstructure:"request_timeout"`
 	CORSAllowedOrigins []string                  `mapstructure:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
+	// SlowQueryThreshold is the duration above which database queries are logged as slow,
+	// a negative value disables the slow query log.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold" yaml:"slow_query_threshold,omitempty"`
+
+	// LazyEnforcerHydration defers loading the user roles and resource ownerships into the authorization
+	// enforcer from the orchestrator initialization to its first authorization check.
+	LazyEnforcerHydration bool `mapstructure:"lazy_enforcer_hydration"`
+
+	// EnforcerRefreshInterval is how long the orchestrator keeps the roles and ownerships loaded into the
+	// authorization enforcer before loading them again, zero keeps them until the instance restarts.
+	EnforcerRefreshInterval time.Duration `mapstructure:"enforcer_refresh_interval"`
+
+	// DisableLegacyRoutes removes the deprecated action-based v1 routes, which then answer 410 Gone.
+	DisableLegacyRoutes bool `mapstructure:"disable_legacy_routes"`
+
+	// RequestBodyLogSampleRate is the probability of logging the scrubbed bodies of a request and its response
+	// at debug level, to capture them safely during incidents. Zero disables it.
+	RequestBodyLogSampleRate float64 `mapstructure:"request_body_log_sample_rate"`
+
+	// DefaultExecutionVisibility is the visibility of executions started without one: private, team or public.
+	DefaultExecutionVisibility string `mapstructure:"default_execution_visibility" yaml:"default_execution_visibility"`
+
+	// LogRetentionMinDays and LogRetentionMaxDays bound the log retention, in days, a run may request. Runs
+	// requesting none keep their logs for the retention of the log storage, which the maximum should not
+	// exceed: a run can only keep its logs shorter than that.
+	LogRetentionMinDays int `mapstructure:"log_retention_min_days" yaml:"log_retention_min_days"`
+	LogRetentionMaxDays int `mapstructure:"log_retention_max_days" yaml:"log_retention_max_days"`
+
+	// ClaimTokenTTL is how long the claim token of an invited user is valid, the user then staying pending
+	// until a new token is issued to them.
+	ClaimTokenTTL time.Duration `mapstructure:"claim_token_ttl" yaml:"claim_token_ttl,omitempty"`
+
+	// DeleteUnclaimedUsers makes the event processor delete the invited users whose claim token expired
+	// without being claimed, instead of keeping them pending.
+	DeleteUnclaimedUsers bool `mapstructure:"delete_unclaimed_users" yaml:"delete_unclaimed_users,omitempty"`
+
+	// UnregisteredImagePolicy is applied to the executions of images that are not registered: reject or
+	// register them on their first execution.
+	UnregisteredImagePolicy string `mapstructure:"unregistered_image_policy" yaml:"unregistered_image_policy"`
+
+	// UnregisteredImageRoles restricts the roles whose executions may register an image under the register
+	// policy, all roles may when it is empty.
+	UnregisteredImageRoles []string `mapstructure:"unregistered_image_roles" yaml:"unregistered_image_roles,omitempty"`
+
+	// ProcessorSigningSecret is shared by the callers of the event processor HTTP endpoint, which rejects the
+	// requests not signed with it. The endpoint is unauthenticated when it is empty.
+	ProcessorSigningSecret string `mapstructure:"processor_signing_secret" yaml:"processor_signing_secret,omitempty"`
+
+	// ResourceTags are applied to the resources the backend creates, e.g. for cost allocation. Read from
+	// RUNVOY_RESOURCE_TAGS as comma-separated key=value pairs.
+	ResourceTags map[string]string `mapstructure:"-" yaml:"-"`
+
+	// SSO is the identity provider the users provisioned through SCIM log in with to get their API key.
+	SSO SSOConfig `mapstructure:"sso" yaml:"sso,omitempty"`
+
+	// SMTP is the server the event processor emails the weekly usage report through.
+	SMTP SMTPConfig `mapstructure:"smtp" yaml:"smtp,omitempty"`
+
+	// SCIMGroupRoles maps the identity provider groups to the roles of the users provisioned through SCIM.
+	// Read from RUNVOY_SCIM_GROUP_ROLES as comma-separated group=role pairs.
+	SCIMGroupRoles map[string]string `mapstructure:"-" yaml:"-"`
+
+	// GitHubTrusts maps the GitHub repositories whose Actions workflows may exchange their OIDC token for a
+	// run token to the runvoy user they act as. Read from RUNVOY_GITHUB_TRUSTS as repository=email pairs.
+	GitHubTrusts map[string]string `mapstructure:"-" yaml:"-"`
+
+	// GitHubOIDCAudience is the audience the GitHub Actions OIDC tokens must be requested for.
+	GitHubOIDCAudience string `mapstructure:"github_oidc_audience" yaml:"github_oidc_audience,omitempty"`
+
+	// Chaos injects faults for resilience testing, it is disabled unless a RUNVOY_CHAOS_* rate is set.
+	Chaos chaos.Config `mapstructure:"chaos" yaml:"chaos,omitempty"`
+
 	// Provider-specific configurations
 	AWS *awsconfig.Config `mapstructure:"aws" yaml:"aws,omitempty"`
 	// Future providers can be added here:
@@ -90,6 +171,9 @@ func Load() (*Config, error) {
 	if err = validate.Struct(&cfg); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
+	if err = cfg.HTTPClient.Validate(); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
 
 	// Normalize backend provider
 	cfg.BackendProvider = normalizeBackendProvider(cfg.BackendProvider)
@@ -130,12 +214,29 @@ func LoadOrchestrator() (*Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling orchestrator config: %w", err)
 	}
+	tags, err := ParseResourceTags(v.GetString("resource_tags"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.ResourceTags = tags
+	groupRoles, err := ParseGroupRoles(v.GetString("scim_group_roles"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.SCIMGroupRoles = groupRoles
+	githubTrusts, err := ParseGitHubTrusts(v.GetString("github_trusts"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.GitHubTrusts = githubTrusts
 
 	// Handle comma-separated string slices from environment variables
 	normalizeStringSlice(&cfg.CORSAllowedOrigins)
+	normalizeStringSlice(&cfg.UnregisteredImageRoles)
 
 	// Apply defaults for empty values
 	applyDefaults(&cfg)
+	cfg.BackendProvider = normalizeBackendProvider(cfg.BackendProvider)
 
 	if err := validateOrchestratorConfig(&cfg); err != nil {
 		return nil, err
@@ -159,12 +260,18 @@ func LoadEventProcessor() (*Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling event processor config: %w", err)
 	}
+	tags, err := ParseResourceTags(v.GetString("resource_tags"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.ResourceTags = tags
 
 	// Handle comma-separated string slices from environment variables
 	normalizeStringSlice(&cfg.CORSAllowedOrigins)
 
 	// Apply defaults for empty values
 	applyDefaults(&cfg)
+	cfg.BackendProvider = normalizeBackendProvider(cfg.BackendProvider)
 
 	if err := validateEventProcessorConfig(&cfg); err != nil {
 		return nil, err
@@ -195,16 +302,14 @@ func MustLoadEventProcessor() *Config {
 	return cfg
 }
 
-// Save saves the configuration to the user's home directory.
+// Save saves the configuration to the configuration directory, see GetConfigPath.
 // Overwrites the existing config file if it exists.
 func Save(config *Config) error {
-	currentUser, err := user.Current()
+	configDir, err := configDirPath()
 	if err != nil {
-		return fmt.Errorf("error getting current user: %w", err)
+		return err
 	}
 
-	configDir := constants.ConfigDirPath(currentUser.HomeDir)
-
 	if err = os.MkdirAll(configDir, constants.ConfigDirPermissions); err != nil {
 		return fmt.Errorf("error creating config directory: %w", err)
 	}
@@ -213,6 +318,12 @@ func Save(config *Config) error {
 	return saveToPath(config, configFilePath)
 }
 
+// SaveToDir saves the configuration to the config file of the directory, for example a configuration
+// used through RUNVOY_CONFIG_DIR next to the default one.
+func SaveToDir(config *Config, dir string) error {
+	return saveToPath(config, filepath.Join(dir, constants.ConfigFileName))
+}
+
 // saveToPath saves the configuration to the specified file path.
 // Creates the directory if it doesn't exist and sets appropriate file permissions.
 func saveToPath(config *Config, configFilePath string) error {
@@ -226,6 +337,18 @@ func saveToPath(config *Config, configFilePath string) error {
 	v.Set("api_endpoint", config.APIEndpoint)
 	v.Set("api_key", config.APIKey)
 	v.Set("web_url", config.WebURL)
+	if config.UpdateChannel != "" {
+		v.Set("update_channel", config.UpdateChannel)
+	}
+	for key, value := range map[string]time.Duration{
+		"http_client.short_timeout": config.HTTPClient.ShortTimeout,
+		"http_client.long_timeout":  config.HTTPClient.LongTimeout,
+		"http_client.dns_cache_ttl": config.HTTPClient.DNSCacheTTL,
+	} {
+		if value != 0 {
+			v.Set(key, value.String())
+		}
+	}
 
 	if err := v.WriteConfigAs(configFilePath); err != nil {
 		return fmt.Errorf("error writing config file: %w", err)
@@ -238,15 +361,49 @@ func saveToPath(config *Config, configFilePath string) error {
 	return nil
 }
 
-// GetConfigPath returns the path to the config file.
+// GetConfigPath returns the path to the config file, in the directory set by the
+// RUNVOY_CONFIG_DIR environment variable or ~/.runvoy by default.
 func GetConfigPath() (string, error) {
+	configDir, err := configDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, constants.ConfigFileName), nil
+}
+
+// GetConfigDir returns the configuration directory, set by the RUNVOY_CONFIG_DIR environment variable
+// or ~/.runvoy by default. The CLI also keeps its state files there.
+func GetConfigDir() (string, error) {
+	return configDirPath()
+}
+
+// configDirPath returns the configuration directory.
+func configDirPath() (string, error) {
+	if dir := os.Getenv(constants.ConfigDirEnvVar); dir != "" {
+		return dir, nil
+	}
+
 	currentUser, err := user.Current()
 	if err != nil {
 		return "", fmt.Errorf("error getting current user: %w", err)
 	}
+	homeDir := constants.ConfigDirPath(currentUser.HomeDir)
+	if runtime.GOOS == "windows" {
+		return windowsConfigDir(homeDir, os.Getenv("APPDATA")), nil
+	}
+	return homeDir, nil
+}
 
-	configDir := constants.ConfigDirPath(currentUser.HomeDir)
-	return filepath.Join(configDir, constants.ConfigFileName), nil
+// windowsConfigDir returns the configuration directory on Windows: runvoy in the roaming application data
+// directory, unless the configuration directory of the home directory was already created.
+func windowsConfigDir(homeDir, appDataDir string) string {
+	if appDataDir == "" {
+		return homeDir
+	}
+	if info, err := os.Stat(homeDir); err == nil && info.IsDir() {
+		return homeDir
+	}
+	return filepath.Join(appDataDir, constants.ProjectName)
 }
 
 // GetLogLevel returns the slog.Level from the string configuration.
@@ -283,6 +440,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("web_url", constants.DefaultWebURL)
 	v.SetDefault("backend_provider", string(constants.AWS))
 	v.SetDefault("cors_allowed_origins", constants.DefaultCORSAllowedOrigins)
+	v.SetDefault("default_execution_visibility", string(constants.DefaultExecutionVisibility))
+	v.SetDefault("unregistered_image_policy", string(constants.DefaultUnregisteredImagePolicy))
+	v.SetDefault("log_retention_min_days", constants.DefaultLogRetentionMinDays)
+	v.SetDefault("log_retention_max_days", constants.DefaultLogRetentionMaxDays)
+	v.SetDefault("claim_token_ttl", constants.DefaultClaimTokenTTL)
 	// TODO: we set DEBUG for development, we should update this to use INFO
 	v.SetDefault("log_level", "DEBUG")
 }
@@ -306,20 +468,38 @@ func applyDefaults(cfg *Config) {
 	if cfg.InitTimeout == 0 {
 		cfg.InitTimeout = constants.DefaultContextTimeout
 	}
+	if cfg.SlowQueryThreshold == 0 {
+		cfg.SlowQueryThreshold = constants.DefaultSlowQueryThreshold
+	}
 	if len(cfg.CORSAllowedOrigins) == 0 {
 		cfg.CORSAllowedOrigins = constants.DefaultCORSAllowedOrigins
 	}
+	if cfg.DefaultExecutionVisibility == "" {
+		cfg.DefaultExecutionVisibility = string(constants.DefaultExecutionVisibility)
+	}
+	if cfg.UnregisteredImagePolicy == "" {
+		cfg.UnregisteredImagePolicy = string(constants.DefaultUnregisteredImagePolicy)
+	}
+	if cfg.LogRetentionMinDays == 0 {
+		cfg.LogRetentionMinDays = constants.DefaultLogRetentionMinDays
+	}
+	if cfg.LogRetentionMaxDays == 0 {
+		cfg.LogRetentionMaxDays = constants.DefaultLogRetentionMaxDays
+	}
+	if cfg.ClaimTokenTTL == 0 {
+		cfg.ClaimTokenTTL = constants.DefaultClaimTokenTTL
+	}
+	if cfg.GitHubOIDCAudience == "" {
+		cfg.GitHubOIDCAudience = constants.DefaultGitHubOIDCAudience
+	}
 }
 
 func loadConfigFile(v *viper.Viper) error {
-	currentUser, err := user.Current()
+	configFile, err := GetConfigPath()
 	if err != nil {
-		return fmt.Errorf("error getting current user: %w", err)
+		return err
 	}
 
-	configDir := constants.ConfigDirPath(currentUser.HomeDir)
-	configFile := filepath.Join(configDir, constants.ConfigFileName)
-
 	v.SetConfigFile(configFile)
 	v.SetConfigType("yaml")
 
@@ -337,32 +517,121 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("init_timeout", "RUNVOY_INIT_TIMEOUT")
 	_ = v.BindEnv("log_level", "RUNVOY_LOG_LEVEL")
 	_ = v.BindEnv("request_timeout", "RUNVOY_REQUEST_TIMEOUT")
+	_ = v.BindEnv("slow_query_threshold", "RUNVOY_SLOW_QUERY_THRESHOLD")
+	_ = v.BindEnv("lazy_enforcer_hydration", "RUNVOY_LAZY_ENFORCER_HYDRATION")
+	_ = v.BindEnv("enforcer_refresh_interval", "RUNVOY_ENFORCER_REFRESH_INTERVAL")
+	_ = v.BindEnv("disable_legacy_routes", "RUNVOY_DISABLE_LEGACY_ROUTES")
+	_ = v.BindEnv("request_body_log_sample_rate", "RUNVOY_REQUEST_BODY_LOG_SAMPLE_RATE")
 	_ = v.BindEnv("web_url", "RUNVOY_WEB_URL")
+	_ = v.BindEnv("http_client.short_timeout", "RUNVOY_HTTP_CLIENT_SHORT_TIMEOUT")
+	_ = v.BindEnv("http_client.long_timeout", "RUNVOY_HTTP_CLIENT_LONG_TIMEOUT")
+	_ = v.BindEnv("http_client.dns_cache_ttl", "RUNVOY_HTTP_CLIENT_DNS_CACHE_TTL")
 	_ = v.BindEnv("cors_allowed_origins", "RUNVOY_CORS_ALLOWED_ORIGINS")
+	_ = v.BindEnv("default_execution_visibility", "RUNVOY_DEFAULT_EXECUTION_VISIBILITY")
+	_ = v.BindEnv("log_retention_min_days", "RUNVOY_LOG_RETENTION_MIN_DAYS")
+	_ = v.BindEnv("log_retention_max_days", "RUNVOY_LOG_RETENTION_MAX_DAYS")
+	_ = v.BindEnv("claim_token_ttl", "RUNVOY_CLAIM_TOKEN_TTL")
+	_ = v.BindEnv("delete_unclaimed_users", "RUNVOY_DELETE_UNCLAIMED_USERS")
+	_ = v.BindEnv("unregistered_image_policy", "RUNVOY_UNREGISTERED_IMAGE_POLICY")
+	_ = v.BindEnv("unregistered_image_roles", "RUNVOY_UNREGISTERED_IMAGE_ROLES")
+	_ = v.BindEnv("processor_signing_secret", "RUNVOY_PROCESSOR_SIGNING_SECRET")
+	_ = v.BindEnv("resource_tags", "RUNVOY_RESOURCE_TAGS")
+	_ = v.BindEnv("sso.issuer", "RUNVOY_SSO_ISSUER")
+	_ = v.BindEnv("sso.client_id", "RUNVOY_SSO_CLIENT_ID")
+	_ = v.BindEnv("smtp.addr", "RUNVOY_SMTP_ADDR")
+	_ = v.BindEnv("smtp.username", "RUNVOY_SMTP_USERNAME")
+	_ = v.BindEnv("smtp.password", "RUNVOY_SMTP_PASSWORD")
+	_ = v.BindEnv("smtp.from", "RUNVOY_SMTP_FROM")
+	_ = v.BindEnv("scim_group_roles", "RUNVOY_SCIM_GROUP_ROLES")
+	_ = v.BindEnv("github_trusts", "RUNVOY_GITHUB_TRUSTS")
+	_ = v.BindEnv("github_oidc_audience", "RUNVOY_GITHUB_OIDC_AUDIENCE")
+	_ = v.BindEnv("chaos.latency", "RUNVOY_CHAOS_LATENCY")
+	_ = v.BindEnv("chaos.latency_rate", "RUNVOY_CHAOS_LATENCY_RATE")
+	_ = v.BindEnv("chaos.error_rate", "RUNVOY_CHAOS_ERROR_RATE")
+	_ = v.BindEnv("chaos.drop_rate", "RUNVOY_CHAOS_DROP_RATE")
 
 	// Bind provider-specific environment variables
 	awsconfig.BindEnvVars(v)
 }
 
 func validateOrchestratorConfig(cfg *Config) error {
+	if cfg.DefaultExecutionVisibility != "" &&
+		!constants.ExecutionVisibility(cfg.DefaultExecutionVisibility).Valid() {
+		return fmt.Errorf("invalid default execution visibility: %s", cfg.DefaultExecutionVisibility)
+	}
+
+	if cfg.LogRetentionMinDays < 0 || cfg.LogRetentionMaxDays < cfg.LogRetentionMinDays {
+		return fmt.Errorf("invalid log retention bounds: %d to %d days, the minimum must not be negative "+
+			"nor exceed the maximum", cfg.LogRetentionMinDays, cfg.LogRetentionMaxDays)
+	}
+
+	if cfg.ClaimTokenTTL < 0 {
+		return fmt.Errorf("invalid claim token TTL: %s, it must not be negative", cfg.ClaimTokenTTL)
+	}
+
+	if cfg.UnregisteredImagePolicy != "" &&
+		!constants.UnregisteredImagePolicy(cfg.UnregisteredImagePolicy).Valid() {
+		return fmt.Errorf("invalid unregistered image policy: %s", cfg.UnregisteredImagePolicy)
+	}
+	for _, role := range cfg.UnregisteredImageRoles {
+		if !authorization.IsValidRole(role) {
+			return fmt.Errorf("invalid unregistered image role %q, must be one of: %s",
+				role, strings.Join(authorization.ValidRoles(), ", "))
+		}
+	}
+
+	if cfg.EnforcerRefreshInterval < 0 {
+		return fmt.Errorf("invalid enforcer refresh interval: %s, must not be negative", cfg.EnforcerRefreshInterval)
+	}
+
+	if cfg.RequestBodyLogSampleRate < 0 || cfg.RequestBodyLogSampleRate > 1 {
+		return fmt.Errorf("invalid request body log sample rate: %v, must be between 0 and 1",
+			cfg.RequestBodyLogSampleRate)
+	}
+
+	if err := cfg.Chaos.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.SSO.Validate(); err != nil {
+		return err
+	}
+
 	switch cfg.BackendProvider {
 	case constants.AWS:
 		if err := awsconfig.ValidateOrchestrator(cfg.AWS); err != nil {
 			return fmt.Errorf("failed to validate orchestrator config: %w", err)
 		}
 		return nil
+	case constants.Fake:
+		return nil
 	default:
 		return fmt.Errorf("unsupported backend provider: %s", cfg.BackendProvider)
 	}
 }
 
 func validateEventProcessorConfig(cfg *Config) error {
+	if err := cfg.Chaos.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.ProcessorSigningSecret != "" && len(cfg.ProcessorSigningSecret) < constants.MinRequestSigningSecretLength {
+		return fmt.Errorf("processor signing secret must be at least %d characters long",
+			constants.MinRequestSigningSecretLength)
+	}
+
+	if err := cfg.SMTP.Validate(); err != nil {
+		return err
+	}
+
 	switch cfg.BackendProvider {
 	case constants.AWS:
 		if err := awsconfig.ValidateEventProcessor(cfg.AWS); err != nil {
 			return fmt.Errorf("failed to validate event processor config: %w", err)
 		}
 		return nil
+	case constants.Fake:
+		return nil
 	default:
 		return fmt.Errorf("unsupported backend provider: %s", cfg.BackendProvider)
 	}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	awsconfig "github.com/runvoy/runvoy/internal/config/aws"
 	"github.com/runvoy/runvoy/internal/constants"
@@ -101,6 +102,70 @@ func TestValidateOrchestrator(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid default execution visibility",
+			cfg: &Config{
+				BackendProvider:            constants.AWS,
+				DefaultExecutionVisibility: "hidden",
+			},
+			wantErr: true,
+			errMsg:  "invalid default execution visibility",
+		},
+		{
+			name: "log retention minimum over the maximum",
+			cfg: &Config{
+				BackendProvider:     constants.AWS,
+				LogRetentionMinDays: 30,
+				LogRetentionMaxDays: 7,
+			},
+			wantErr: true,
+			errMsg:  "invalid log retention bounds",
+		},
+		{
+			name: "negative claim token TTL",
+			cfg: &Config{
+				BackendProvider: constants.AWS,
+				ClaimTokenTTL:   -time.Hour,
+			},
+			wantErr: true,
+			errMsg:  "invalid claim token TTL",
+		},
+		{
+			name: "invalid unregistered image policy",
+			cfg: &Config{
+				BackendProvider:         constants.AWS,
+				UnregisteredImagePolicy: "allow",
+			},
+			wantErr: true,
+			errMsg:  "invalid unregistered image policy",
+		},
+		{
+			name: "invalid unregistered image role",
+			cfg: &Config{
+				BackendProvider:        constants.AWS,
+				UnregisteredImageRoles: []string{"operator", "owner"},
+			},
+			wantErr: true,
+			errMsg:  "invalid unregistered image role",
+		},
+		{
+			name: "negative enforcer refresh interval",
+			cfg: &Config{
+				BackendProvider:         constants.AWS,
+				EnforcerRefreshInterval: -time.Minute,
+			},
+			wantErr: true,
+			errMsg:  "invalid enforcer refresh interval",
+		},
+		{
+			name: "request body log sample rate above 1",
+			cfg: &Config{
+				BackendProvider:          constants.AWS,
+				RequestBodyLogSampleRate: 1.5,
+			},
+			wantErr: true,
+			errMsg:  "invalid request body log sample rate",
+		},
 		{
 			name: "missing AWS config",
 			cfg: &Config{
@@ -487,6 +552,13 @@ func TestValidateOrchestrator(t *testing.T) {
 			wantErr: true,
 			errMsg:  "SecretsKMSKeyARN cannot be empty",
 		},
+		{
+			name: "fake provider needs no configuration",
+			cfg: &Config{
+				BackendProvider: constants.Fake,
+			},
+			wantErr: false,
+		},
 		{
 			name: "unsupported provider",
 			cfg: &Config{
@@ -877,6 +949,32 @@ func TestGetConfigPath(t *testing.T) {
 		assert.Contains(t, path, ".runvoy")
 		assert.Contains(t, path, "config.yaml")
 	})
+
+	t.Run("uses the directory set in the environment", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv(constants.ConfigDirEnvVar, dir)
+
+		path, err := GetConfigPath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, constants.ConfigFileName), path)
+
+		require.NoError(t, Save(&Config{APIEndpoint: "https://api.example.com", APIKey: "key"}))
+		cfg, err := LoadCLI()
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.example.com", cfg.APIEndpoint)
+	})
+}
+
+func TestWindowsConfigDir(t *testing.T) {
+	home := t.TempDir()
+	homeDir := constants.ConfigDirPath(home)
+	appData := t.TempDir()
+
+	assert.Equal(t, filepath.Join(appData, "runvoy"), windowsConfigDir(homeDir, appData))
+	assert.Equal(t, homeDir, windowsConfigDir(homeDir, ""), "without APPDATA")
+
+	require.NoError(t, os.Mkdir(homeDir, constants.ConfigDirPermissions))
+	assert.Equal(t, homeDir, windowsConfigDir(homeDir, appData), "an existing home configuration is kept")
 }
 
 func TestNormalizeWebSocketEndpoint(t *testing.T) {
@@ -1167,6 +1265,44 @@ func TestSaveToPath(t *testing.T) {
 		assert.Equal(t, testConfig.WebURL, loadedConfig.WebURL)
 	})
 
+	t.Run("saves the HTTP client settings that are set", func(t *testing.T) {
+		tempDir := t.TempDir()
+		configFilePath := filepath.Join(tempDir, constants.ConfigFileName)
+
+		testConfig := &Config{
+			APIEndpoint: "https://api.example.com",
+			APIKey:      "secret-key-123",
+			HTTPClient:  HTTPClientConfig{LongTimeout: 15 * time.Minute, DNSCacheTTL: -time.Second},
+		}
+
+		err := saveToPath(testConfig, configFilePath)
+		require.NoError(t, err)
+
+		v := viper.New()
+		v.SetConfigFile(configFilePath)
+		v.SetConfigType("yaml")
+		require.NoError(t, v.ReadInConfig())
+		assert.False(t, v.IsSet("http_client.short_timeout"))
+
+		var loadedConfig Config
+		require.NoError(t, v.Unmarshal(&loadedConfig))
+		assert.Equal(t, testConfig.HTTPClient, loadedConfig.HTTPClient)
+	})
+
+	t.Run("saves the update channel when it is set", func(t *testing.T) {
+		configFilePath := filepath.Join(t.TempDir(), constants.ConfigFileName)
+
+		require.NoError(t, saveToPath(&Config{APIEndpoint: "https://api.example.com"}, configFilePath))
+		v := viper.New()
+		v.SetConfigFile(configFilePath)
+		require.NoError(t, v.ReadInConfig())
+		assert.False(t, v.IsSet("update_channel"))
+
+		require.NoError(t, saveToPath(&Config{UpdateChannel: "beta"}, configFilePath))
+		require.NoError(t, v.ReadInConfig())
+		assert.Equal(t, "beta", v.GetString("update_channel"))
+	})
+
 	t.Run("overwrites existing file", func(t *testing.T) {
 		tempDir := t.TempDir()
 		configFilePath := filepath.Join(tempDir, constants.ConfigFileName)
@@ -1442,6 +1578,72 @@ func TestLoadOrchestratorMissingRequiredFields(t *testing.T) {
 	assert.Nil(t, cfg)
 }
 
+// TestLoadOrchestratorFakeProvider tests the fake provider is selected case-insensitively without AWS config
+func TestLoadOrchestratorFakeProvider(t *testing.T) {
+	t.Setenv("RUNVOY_BACKEND_PROVIDER", "fake")
+
+	cfg, err := LoadOrchestrator()
+	require.NoError(t, err)
+	assert.Equal(t, constants.Fake, cfg.BackendProvider)
+
+	cfg, err = LoadEventProcessor()
+	require.NoError(t, err)
+	assert.Equal(t, constants.Fake, cfg.BackendProvider)
+}
+
+func TestLoadOrchestratorChaos(t *testing.T) {
+	t.Setenv("RUNVOY_BACKEND_PROVIDER", "fake")
+	t.Setenv("RUNVOY_CHAOS_LATENCY", "250ms")
+	t.Setenv("RUNVOY_CHAOS_LATENCY_RATE", "0.5")
+	t.Setenv("RUNVOY_CHAOS_ERROR_RATE", "0.1")
+	t.Setenv("RUNVOY_CHAOS_DROP_RATE", "0.2")
+
+	cfg, err := LoadOrchestrator()
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, cfg.Chaos.Latency)
+	assert.InDelta(t, 0.5, cfg.Chaos.LatencyRate, 0)
+	assert.InDelta(t, 0.1, cfg.Chaos.ErrorRate, 0)
+	assert.InDelta(t, 0.2, cfg.Chaos.DropRate, 0)
+
+	t.Setenv("RUNVOY_CHAOS_ERROR_RATE", "1.5")
+	_, err = LoadEventProcessor()
+	assert.ErrorContains(t, err, "chaos error rate must be between 0 and 1")
+}
+
+func TestLoadEventProcessorSigningSecret(t *testing.T) {
+	t.Setenv("RUNVOY_BACKEND_PROVIDER", "fake")
+	t.Setenv("RUNVOY_PROCESSOR_SIGNING_SECRET", "0123456789abcdef0123456789abcdef")
+
+	cfg, err := LoadEventProcessor()
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", cfg.ProcessorSigningSecret)
+
+	t.Setenv("RUNVOY_PROCESSOR_SIGNING_SECRET", "too-short")
+	_, err = LoadEventProcessor()
+	assert.ErrorContains(t, err, "processor signing secret must be at least 32 characters long")
+}
+
+func TestLoadEventProcessorSMTP(t *testing.T) {
+	t.Setenv("RUNVOY_BACKEND_PROVIDER", "fake")
+	t.Setenv("RUNVOY_SMTP_ADDR", "email-smtp.us-east-1.amazonaws.com:587")
+	t.Setenv("RUNVOY_SMTP_USERNAME", "smtp-user")
+	t.Setenv("RUNVOY_SMTP_PASSWORD", "smtp-password")
+	t.Setenv("RUNVOY_SMTP_FROM", "runvoy@example.com")
+
+	cfg, err := LoadEventProcessor()
+	require.NoError(t, err)
+	assert.Equal(t, SMTPConfig{
+		Addr:     "email-smtp.us-east-1.amazonaws.com:587",
+		Username: "smtp-user",
+		Password: "smtp-password",
+		From:     "runvoy@example.com",
+	}, cfg.SMTP)
+
+	t.Setenv("RUNVOY_SMTP_FROM", "")
+	_, err = LoadEventProcessor()
+	assert.ErrorContains(t, err, "the SMTP address and sender must be set together")
+}
+
 // TestLoadEventProcessorMissingRequiredFields tests validation fails with missing fields
 func TestLoadEventProcessorMissingRequiredFields(t *testing.T) {
 	// Save original env vars
@@ -1567,3 +1769,13 @@ func TestConfig_GetDefaultStackName(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveToDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dev")
+
+	require.NoError(t, SaveToDir(&Config{APIEndpoint: "http://localhost:56212", APIKey: "dev-key"}, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, constants.ConfigFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "api_key: dev-key")
+}
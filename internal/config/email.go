@@ -0,0 +1,42 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+)
+
+// SMTPConfig configures the SMTP server the event processor sends its emails through, e.g. the SES SMTP
+// interface.
+type SMTPConfig struct {
+	// Addr is the host:port of the SMTP server, e.g. email-smtp.us-east-1.amazonaws.com:587.
+	Addr string `mapstructure:"addr" yaml:"addr,omitempty"`
+	// Username and Password authenticate with the SMTP server, which is used anonymously when they are empty.
+	Username string `mapstructure:"username" yaml:"username,omitempty"`
+	Password string `mapstructure:"password" yaml:"password,omitempty"`
+	// From is the sender address of the emails.
+	From string `mapstructure:"from" yaml:"from,omitempty"`
+}
+
+// Enabled reports whether sending emails is configured.
+func (c *SMTPConfig) Enabled() bool {
+	return c.Addr != "" && c.From != ""
+}
+
+// Validate checks that the address and the sender are set together and well formed.
+func (c *SMTPConfig) Validate() error {
+	if c.Addr == "" && c.From == "" {
+		return nil
+	}
+	if c.Addr == "" || c.From == "" {
+		return errors.New("the SMTP address and sender must be set together")
+	}
+	if _, _, err := net.SplitHostPort(c.Addr); err != nil {
+		return fmt.Errorf("the SMTP address must be host:port: %s", c.Addr)
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid SMTP sender address %q: %w", c.From, err)
+	}
+	return nil
+}
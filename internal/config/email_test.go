@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPConfigValidate(t *testing.T) {
+	require.NoError(t, (&SMTPConfig{}).Validate())
+	assert.False(t, (&SMTPConfig{}).Enabled())
+
+	smtp := &SMTPConfig{Addr: "email-smtp.us-east-1.amazonaws.com:587", From: "runvoy@example.com"}
+	require.NoError(t, smtp.Validate())
+	assert.True(t, smtp.Enabled())
+
+	require.ErrorContains(t, (&SMTPConfig{From: "runvoy@example.com"}).Validate(), "set together")
+	require.ErrorContains(t, (&SMTPConfig{Addr: "smtp.example.com", From: "runvoy@example.com"}).Validate(),
+		"host:port")
+	require.ErrorContains(t, (&SMTPConfig{Addr: "smtp.example.com:587", From: "runvoy"}).Validate(),
+		"invalid SMTP sender address")
+}
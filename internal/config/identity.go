@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+)
+
+// SSOConfig configures the OpenID Connect identity provider the provisioned users log in with.
+type SSOConfig struct {
+	// Issuer is the issuer URL of the identity provider, e.g. https://mycompany.okta.com.
+	Issuer string `mapstructure:"issuer" yaml:"issuer,omitempty"`
+	// ClientID is the ID of the runvoy application registered with the identity provider, the audience of
+	// its ID tokens.
+	ClientID string `mapstructure:"client_id" yaml:"client_id,omitempty"`
+}
+
+// Enabled reports whether logging in with the identity provider is configured.
+func (c *SSOConfig) Enabled() bool {
+	return c.Issuer != "" && c.ClientID != ""
+}
+
+// Validate checks that the issuer and the client ID are set together.
+func (c *SSOConfig) Validate() error {
+	if (c.Issuer == "") != (c.ClientID == "") {
+		return errors.New("the SSO issuer and client ID must be set together")
+	}
+	if c.Issuer != "" && !strings.HasPrefix(c.Issuer, "https://") {
+		return fmt.Errorf("the SSO issuer must be an https URL: %s", c.Issuer)
+	}
+	return nil
+}
+
+// ParseGroupRoles parses the mapping of identity provider groups to runvoy roles, written as comma-separated
+// group=role pairs, e.g. "Platform Admins=admin,Engineering=developer".
+func ParseGroupRoles(spec string) (map[string]string, error) {
+	groupRoles := make(map[string]string)
+	for pair := range strings.SplitSeq(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid group role %q, expected group=role", pair)
+		}
+		if !authorization.IsValidRole(role) {
+			return nil, fmt.Errorf("invalid role %q for group %q, must be one of: %s",
+				role, group, strings.Join(authorization.ValidRoles(), ", "))
+		}
+		groupRoles[group] = role
+	}
+	return groupRoles, nil
+}
+
+// FormatGroupRoles formats the mapping of groups to roles as ParseGroupRoles parses it, sorted by group.
+func FormatGroupRoles(groupRoles map[string]string) string {
+	return formatPairs(groupRoles)
+}
+
+// ParseGitHubTrusts parses the GitHub repositories trusted to run jobs, written as comma-separated
+// repository=email pairs, e.g. "acme/api=ci@acme.com,acme/*=ci-readonly@acme.com". The repository is either
+// owner/name or owner/* for all the repositories of the owner, and the email is the runvoy user the
+// workflows of the repository act as. Repositories are lowercased, as GitHub matches them case-insensitively.
+func ParseGitHubTrusts(spec string) (map[string]string, error) {
+	trusts := make(map[string]string)
+	for pair := range strings.SplitSeq(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		repository, email, ok := strings.Cut(pair, "=")
+		repository = strings.ToLower(strings.TrimSpace(repository))
+		email = strings.ToLower(strings.TrimSpace(email))
+		if !ok {
+			return nil, fmt.Errorf("invalid GitHub trust %q, expected owner/repository=email", pair)
+		}
+		if !validGitHubRepositoryPattern(repository) {
+			return nil, fmt.Errorf("invalid repository %q, expected owner/repository or owner/*", repository)
+		}
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, fmt.Errorf("invalid email %q for repository %q: %w", email, repository, err)
+		}
+		trusts[repository] = email
+	}
+	return trusts, nil
+}
+
+func validGitHubRepositoryPattern(repository string) bool {
+	owner, name, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || name == "" || strings.ContainsAny(owner, "*") || strings.Contains(name, "/") {
+		return false
+	}
+	return name == "*" || !strings.Contains(name, "*")
+}
+
+// FormatGitHubTrusts formats the trusted GitHub repositories as ParseGitHubTrusts parses them, sorted by
+// repository.
+func FormatGitHubTrusts(trusts map[string]string) string {
+	return formatPairs(trusts)
+}
+
+func formatPairs(values map[string]string) string {
+	pairs := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return strings.Join(pairs, ",")
+}
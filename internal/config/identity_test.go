@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupRoles(t *testing.T) {
+	groupRoles, err := ParseGroupRoles(" Platform Admins=admin, Engineering = developer ,,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Platform Admins": "admin", "Engineering": "developer"}, groupRoles)
+	assert.Equal(t, "Engineering=developer,Platform Admins=admin", FormatGroupRoles(groupRoles))
+
+	_, err = ParseGroupRoles("Engineering")
+	require.ErrorContains(t, err, "expected group=role")
+	_, err = ParseGroupRoles("Engineering=superuser")
+	require.ErrorContains(t, err, "invalid role")
+}
+
+func TestSSOConfigValidate(t *testing.T) {
+	require.NoError(t, (&SSOConfig{}).Validate())
+	require.NoError(t, (&SSOConfig{Issuer: "https://idp.example.com", ClientID: "runvoy"}).Validate())
+	require.ErrorContains(t, (&SSOConfig{Issuer: "https://idp.example.com"}).Validate(), "set together")
+	require.ErrorContains(t, (&SSOConfig{Issuer: "http://idp.example.com", ClientID: "runvoy"}).Validate(), "https")
+}
+
+func TestParseGitHubTrusts(t *testing.T) {
+	trusts, err := ParseGitHubTrusts(" Acme/API = CI@acme.com, acme/*=ci-readonly@acme.com,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"acme/api": "ci@acme.com", "acme/*": "ci-readonly@acme.com"}, trusts)
+	assert.Equal(t, "acme/*=ci-readonly@acme.com,acme/api=ci@acme.com", FormatGitHubTrusts(trusts))
+
+	_, err = ParseGitHubTrusts("acme/api")
+	require.ErrorContains(t, err, "expected owner/repository=email")
+	for _, repository := range []string{"acme", "acme/", "/api", "acme/api/x", "acme/api-*", "*/api"} {
+		_, err = ParseGitHubTrusts(repository + "=ci@acme.com")
+		require.Error(t, err, repository)
+	}
+	_, err = ParseGitHubTrusts("acme/api=not-an-email")
+	require.ErrorContains(t, err, "invalid email")
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// resourceTagPattern matches the characters allowed in tag keys and values by every provider.
+var resourceTagPattern = regexp.MustCompile(`^[\p{L}\p{N} _.:/=+\-@]*$`)
+
+// ParseResourceTags parses resource tags written as comma-separated key=value pairs, e.g. "team=data,env=prod".
+func ParseResourceTags(spec string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for pair := range strings.SplitSeq(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid resource tag %q, expected key=value", pair)
+		}
+		tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := ValidateResourceTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FormatResourceTags writes resource tags as comma-separated key=value pairs sorted by key, the format read
+// by ParseResourceTags.
+func FormatResourceTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, key+"="+tags[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ValidateResourceTags checks that resource tags can be applied on every provider and don't override the
+// tags runvoy sets itself.
+func ValidateResourceTags(tags map[string]string) error {
+	if len(tags) > constants.MaxResourceTags {
+		return fmt.Errorf("too many resource tags: %d (maximum %d)", len(tags), constants.MaxResourceTags)
+	}
+	for key, value := range tags {
+		switch {
+		case key == "":
+			return errors.New("resource tag key cannot be empty")
+		case len(key) > constants.MaxResourceTagKeyLength:
+			return fmt.Errorf("resource tag key %q is longer than %d characters", key, constants.MaxResourceTagKeyLength)
+		case len(value) > constants.MaxResourceTagValueLength:
+			return fmt.Errorf("value of resource tag %q is longer than %d characters",
+				key, constants.MaxResourceTagValueLength)
+		case !resourceTagPattern.MatchString(key) || strings.Contains(key, "=") || !resourceTagPattern.MatchString(value):
+			return fmt.Errorf("resource tag %q can only contain letters, digits, spaces and _.:/+-@ (and = in values)", key)
+		case strings.HasPrefix(strings.ToLower(key), "aws:"):
+			return fmt.Errorf("resource tag key %q uses the reserved aws: prefix", key)
+		case key == constants.ResourceApplicationTagKey || key == constants.ResourceManagedByTagKey:
+			return fmt.Errorf("resource tag key %q is set by %s", key, constants.ProjectName)
+		}
+	}
+	return nil
+}
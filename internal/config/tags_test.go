@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceTags(t *testing.T) {
+	tags, err := ParseResourceTags(" team=data, cost-center = 42 ,,query=a=b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "data", "cost-center": "42", "query": "a=b"}, tags)
+	assert.Equal(t, "cost-center=42,query=a=b,team=data", FormatResourceTags(tags))
+
+	tags, err = ParseResourceTags("")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"team", "expected key=value"},
+		{"=data", "cannot be empty"},
+		{"aws:team=data", "reserved aws: prefix"},
+		{"Application=other", "is set by runvoy"},
+		{"team=data;prod", "can only contain"},
+		{strings.Repeat("k", 64) + "=v", "longer than 63"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseResourceTags(tt.spec)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadOrchestratorResourceTags(t *testing.T) {
+	t.Setenv("RUNVOY_BACKEND_PROVIDER", "fake")
+	t.Setenv("RUNVOY_RESOURCE_TAGS", "team=data,env=prod")
+
+	cfg, err := LoadOrchestrator()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "data", "env": "prod"}, cfg.ResourceTags)
+
+	t.Setenv("RUNVOY_RESOURCE_TAGS", "ManagedBy=me")
+	_, err = LoadOrchestrator()
+	assert.ErrorContains(t, err, "is set by runvoy")
+}
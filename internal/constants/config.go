@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 // DefaultWebURL is the default URL of the web application HTML site.
 // This can be overridden via configuration (RUNVOY_WEB_URL env var or config file).
 const DefaultWebURL = "https://web.runvoy.site/"
@@ -24,6 +26,10 @@ const ConfigDirName = "." + ProjectName
 // ConfigFileName is the name of the global configuration file.
 const ConfigFileName = "config.yaml"
 
+// ConfigDirEnvVar is the environment variable overriding the configuration directory,
+// e.g. to keep separate configurations for several backends or in tests.
+const ConfigDirEnvVar = "RUNVOY_CONFIG_DIR"
+
 // ConfigDirPath returns the full path to the global configuration directory.
 func ConfigDirPath(homeDir string) string {
 	return homeDir + "/" + ConfigDirName
@@ -34,6 +40,17 @@ func ConfigFilePath(homeDir string) string {
 	return ConfigDirPath(homeDir) + "/" + ConfigFileName
 }
 
+// AnnouncementsStateFileName is the file of the configuration directory recording when the CLI last showed
+// each announcement.
+const AnnouncementsStateFileName = "announcements.json"
+
+// AnnouncementShowInterval is how often the CLI shows the same announcement.
+const AnnouncementShowInterval = 24 * time.Hour
+
+// OfflineQueueDirName is the directory of the configuration directory keeping the runs queued while the API
+// was unreachable, one file per run.
+const OfflineQueueDirName = "queue"
+
 // ConfigDirPermissions is the file system permissions for config directory (0750).
 const ConfigDirPermissions = 0o750
 
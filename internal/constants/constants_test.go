@@ -120,18 +120,61 @@ func TestExecutionStatus(t *testing.T) {
 		assert.Equal(t, ExecutionFailed, ExecutionStatus("FAILED"))
 		assert.Equal(t, ExecutionStopped, ExecutionStatus("STOPPED"))
 		assert.Equal(t, ExecutionTerminating, ExecutionStatus("TERMINATING"))
+		assert.Equal(t, ExecutionTimedOut, ExecutionStatus("TIMED_OUT"))
 	})
 }
 
+func TestExecutionVisibility(t *testing.T) {
+	for _, visibility := range ExecutionVisibilities() {
+		assert.True(t, visibility.Valid(), visibility)
+	}
+	assert.True(t, DefaultExecutionVisibility.Valid())
+	assert.False(t, ExecutionVisibility("").Valid())
+	assert.False(t, ExecutionVisibility("Private").Valid())
+}
+
+func TestExecutionShell(t *testing.T) {
+	for _, shell := range ExecutionShells() {
+		assert.True(t, shell.Valid(), shell)
+	}
+	assert.False(t, ExecutionShell("").Valid())
+	assert.False(t, ExecutionShell("zsh").Valid())
+	assert.Equal(t, []string{"/bin/sh", "-c", "ls"}, ExecutionShell("").Args("ls"))
+	assert.Equal(t, []string{"bash", "-c", "ls"}, ExecutionShellBash.Args("ls"))
+	assert.Equal(t, []string{"pwsh", "-NoLogo", "-NoProfile", "-NonInteractive", "-Command", "ls"},
+		ExecutionShellPwsh.Args("ls"))
+}
+
+func TestUnregisteredImagePolicy(t *testing.T) {
+	assert.True(t, UnregisteredImagePolicyReject.Valid())
+	assert.True(t, UnregisteredImagePolicyRegister.Valid())
+	assert.True(t, DefaultUnregisteredImagePolicy.Valid())
+	assert.False(t, UnregisteredImagePolicy("").Valid())
+	assert.False(t, UnregisteredImagePolicy("allow").Valid())
+}
+
+func TestCommandPolicyEffectAndMatch(t *testing.T) {
+	assert.True(t, CommandPolicyEffectAllow.Valid())
+	assert.True(t, CommandPolicyEffectDeny.Valid())
+	assert.False(t, CommandPolicyEffect("").Valid())
+	assert.False(t, CommandPolicyEffect("reject").Valid())
+
+	assert.True(t, CommandPolicyMatchPrefix.Valid())
+	assert.True(t, CommandPolicyMatchRegex.Valid())
+	assert.False(t, CommandPolicyMatch("").Valid())
+	assert.False(t, CommandPolicyMatch("glob").Valid())
+}
+
 func TestTerminalExecutionStatuses(t *testing.T) {
 	t.Run("returns all terminal statuses", func(t *testing.T) {
 		statuses := TerminalExecutionStatuses()
 
-		assert.Len(t, statuses, 4, "Should have 4 terminal statuses")
+		assert.Len(t, statuses, 5, "Should have 5 terminal statuses")
 		assert.Contains(t, statuses, ExecutionSucceeded)
 		assert.Contains(t, statuses, ExecutionFailed)
 		assert.Contains(t, statuses, ExecutionStopped)
 		assert.Contains(t, statuses, ExecutionTerminating)
+		assert.Contains(t, statuses, ExecutionTimedOut)
 		assert.NotContains(t, statuses, ExecutionRunning, "RUNNING should not be terminal")
 	})
 
@@ -146,6 +189,25 @@ func TestTerminalExecutionStatuses(t *testing.T) {
 	})
 }
 
+func TestPreviousStatuses(t *testing.T) {
+	assert.Empty(t, PreviousStatuses(ExecutionStarting))
+	assert.Equal(t, []ExecutionStatus{ExecutionStarting}, PreviousStatuses(ExecutionRunning))
+	assert.Equal(t, []ExecutionStatus{ExecutionRunning, ExecutionTerminating}, PreviousStatuses(ExecutionStopped))
+	assert.Equal(t, []ExecutionStatus{ExecutionRunning, ExecutionStarting}, PreviousStatuses(ExecutionTimedOut))
+	for _, status := range PreviousStatuses(ExecutionSucceeded) {
+		assert.True(t, CanTransition(status, ExecutionSucceeded))
+	}
+}
+
+func TestExecutionStatus_IsFinal(t *testing.T) {
+	for _, status := range []ExecutionStatus{ExecutionSucceeded, ExecutionFailed, ExecutionStopped, ExecutionTimedOut} {
+		assert.True(t, status.IsFinal(), status)
+	}
+	for _, status := range []ExecutionStatus{ExecutionStarting, ExecutionRunning, ExecutionTerminating, "UNKNOWN"} {
+		assert.False(t, status.IsFinal(), status)
+	}
+}
+
 func TestCanTransition(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -210,6 +272,18 @@ func TestCanTransition(t *testing.T) {
 			to:       ExecutionTerminating,
 			expected: true,
 		},
+		{
+			name:     "RUNNING to TIMED_OUT",
+			from:     ExecutionRunning,
+			to:       ExecutionTimedOut,
+			expected: true,
+		},
+		{
+			name:     "STARTING to TIMED_OUT",
+			from:     ExecutionStarting,
+			to:       ExecutionTimedOut,
+			expected: true,
+		},
 		// Invalid transitions from RUNNING
 		{
 			name:     "RUNNING to STARTING",
@@ -256,6 +330,12 @@ func TestCanTransition(t *testing.T) {
 			to:       ExecutionRunning,
 			expected: false,
 		},
+		{
+			name:     "TIMED_OUT to any status",
+			from:     ExecutionTimedOut,
+			to:       ExecutionStopped,
+			expected: false,
+		},
 		// Same status (no-op transitions)
 		{
 			name:     "STARTING to STARTING",
@@ -14,3 +14,11 @@ const StartTimeCtxKey StartTimeCtxKeyType = "startTime"
 
 // RequestIDLogField is the field name used for request ID in log entries.
 const RequestIDLogField = "request_id"
+
+// OriginRequestIDLogField is the field name used in the event processor log entries for the ID of the API request
+// that started the execution an event relates to.
+const OriginRequestIDLogField = "origin_request_id"
+
+// ProviderRequestIDLogField is the field name used for the request ID assigned by the provider, e.g. the Lambda
+// request ID, when the request carries its own ID.
+const ProviderRequestIDLogField = "provider_request_id"
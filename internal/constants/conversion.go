@@ -11,3 +11,6 @@ const SecondsPerMinute = 60
 
 // MinutesPerHour is the number of minutes in an hour.
 const MinutesPerHour = 60
+
+// BytesPerMB is the number of bytes in a megabyte (MiB).
+const BytesPerMB = 1024 * 1024
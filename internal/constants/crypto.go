@@ -9,3 +9,6 @@ const SecretTokenByteSize = 24
 // UUIDByteSize is the number of random bytes used to generate UUIDs
 // 16 bytes = 128 bits, same as a UUID.
 const UUIDByteSize = 16
+
+// MinRequestSigningSecretLength is the minimum length of the secret signing internal requests.
+const MinRequestSigningSecretLength = 32
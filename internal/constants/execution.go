@@ -20,11 +20,272 @@ const (
 	ExecutionStopped ExecutionStatus = "STOPPED"
 	// ExecutionTerminating indicates a stop request is in progress.
 	ExecutionTerminating ExecutionStatus = "TERMINATING"
+	// ExecutionTimedOut indicates the command was terminated by the backend for exceeding its timeout.
+	ExecutionTimedOut ExecutionStatus = "TIMED_OUT"
 
 	// DefaultExecutionListLimit is the default number of executions returned by the list endpoint.
 	DefaultExecutionListLimit = 10
+
+	// ExecutionsStreamLimit is the maximum number of executions included in each executions stream snapshot.
+	ExecutionsStreamLimit = 100
+
+	// MaxBulkKillExecutions is the maximum number of executions a single bulk kill request may terminate.
+	MaxBulkKillExecutions = 50
+
+	// BulkKillConfirmationTokenLength is the length of the token confirming a bulk kill.
+	BulkKillConfirmationTokenLength = 16
+
+	// MaxExecutionGroupSize is the maximum number of shards of a parallel run.
+	MaxExecutionGroupSize = 50
+
+	// RecommendationExecutionsLimit is the number of most recent succeeded executions analyzed
+	// for resource right-sizing recommendations.
+	RecommendationExecutionsLimit = 1000
+
+	// MinRecommendationExecutions is the number of sampled executions an image needs for its usage
+	// to be representative enough to recommend resources.
+	MinRecommendationExecutions = 5
+
+	// RecommendationHeadroom is the margin kept over the 95th percentile of the usage of an image
+	// when recommending its resources.
+	RecommendationHeadroom = 1.5
+
+	// ExecutionGroupIDPrefix starts the IDs of execution groups, telling them apart from execution IDs.
+	ExecutionGroupIDPrefix = "group-"
+
+	// ShardIndexEnvVar is the environment variable holding the zero-based index of the shard
+	// of a parallel run an execution is.
+	ShardIndexEnvVar = "RUNVOY_SHARD_INDEX"
+
+	// ShardTotalEnvVar is the environment variable holding the number of shards of a parallel run.
+	ShardTotalEnvVar = "RUNVOY_SHARD_TOTAL"
+
+	// RequestIDEnvVar is the environment variable holding the ID of the API request that started an execution.
+	RequestIDEnvVar = "RUNVOY_REQUEST_ID"
+
+	// TimedOutExitCode is the exit code recorded for executions terminated for exceeding their timeout,
+	// matching the convention of the coreutils timeout command.
+	TimedOutExitCode = 124
+
+	// MaxStopGracePeriodSeconds is the maximum grace period, in seconds, a command may request to clean up
+	// after a graceful stop before being killed. It stays below the 120 seconds ECS allows between
+	// SIGTERM and SIGKILL.
+	MaxStopGracePeriodSeconds = 110
+
+	// KillStallSeconds is the time, in seconds, the task of a killed execution is given to stop on top of
+	// its stop grace period. Past it, the event processor flags the kill as stalled and kills the task again.
+	KillStallSeconds = 300
+
+	// DefaultLogRetentionMinDays and DefaultLogRetentionMaxDays bound the log retention a run may request,
+	// in days, when the backend does not configure other bounds. The maximum matches the default retention
+	// of the runner log group, which keeps the logs of the runs not requesting one.
+	DefaultLogRetentionMinDays = 1
+	DefaultLogRetentionMaxDays = 365
+
+	// SystemActor is recorded as the requester of the actions the backend takes on its own, such as the
+	// kill of the executions of a run request that failed.
+	SystemActor = "system"
+
+	// MaxExecutionDiffLogLines is the maximum number of final log lines compared by an execution diff.
+	MaxExecutionDiffLogLines = 500
+
+	// MaxAnnotationLength is the maximum length in bytes of a note attached to an execution.
+	MaxAnnotationLength = 1024
+
+	// MaxExecutionAnnotations is the maximum number of notes attached to an execution,
+	// which keeps the execution record well below the item size limit of the database.
+	MaxExecutionAnnotations = 100
+
+	// MaxStarredExecutions is the maximum number of executions a user can star.
+	MaxStarredExecutions = 100
+
+	// MaxSavedFilters is the maximum number of execution list filters a user can save.
+	MaxSavedFilters = 50
+
+	// MaxSavedFilterNameLength is the maximum length of the name of a saved execution list filter.
+	MaxSavedFilterNameLength = 64
+
+	// MaxExecutionSearchLength is the maximum length of the search of an execution list filter.
+	MaxExecutionSearchLength = 256
+
+	// MaxPlaybookNameLength is the maximum length of the playbook name recorded on an execution.
+	MaxPlaybookNameLength = 128
+
+	// MaxInlineStdinBytes is the maximum size of the standard input sent inline with an execution request.
+	// It is kept small because the payload is passed to the task through container overrides,
+	// which are limited to 8 KiB in total on ECS.
+	MaxInlineStdinBytes = 2 * 1024
+
+	// MaxStdinBytes is the maximum size of the standard input uploaded to object storage for an execution.
+	MaxStdinBytes = 100 * 1024 * 1024
+
+	// MaxContextBytes is the maximum size of the compressed working directory archive uploaded for an execution.
+	MaxContextBytes = 100 * 1024 * 1024
+
+	// MaxExecutionResultBytes is the maximum size of the JSON result file an execution can report.
+	// The result travels in a single log line, it must stay well under the log event size limits.
+	MaxExecutionResultBytes = 16 * 1024
+
+	// DefaultLogMaxLinesPerSecond is the maximum number of log lines per second ingested for an execution
+	// whose image does not configure another limit. Lines above the limit are dropped.
+	DefaultLogMaxLinesPerSecond = 1000
+
+	// DefaultLogMaxBytes is the maximum total size of the log output ingested for an execution
+	// whose image does not configure another limit. Lines past the limit are dropped.
+	DefaultLogMaxBytes = 100 * 1024 * 1024
+)
+
+// FailureReason classifies why an execution failed when the compute platform reports a cause
+// beyond the exit code of the command. It is empty for commands that simply exited with an error.
+type FailureReason string
+
+const (
+	// FailureReasonOOMKilled indicates the container was killed for exceeding its memory limit.
+	FailureReasonOOMKilled FailureReason = "OOM_KILLED"
+	// FailureReasonImagePullFailed indicates the container image could not be pulled.
+	FailureReasonImagePullFailed FailureReason = "IMAGE_PULL_FAILED"
+	// FailureReasonCapacityUnavailable indicates the compute platform had no capacity to place the task.
+	FailureReasonCapacityUnavailable FailureReason = "CAPACITY_UNAVAILABLE"
+	// FailureReasonStartFailed indicates the task failed to start for another reason,
+	// e.g. secrets or the log configuration that could not be set up.
+	FailureReasonStartFailed FailureReason = "START_FAILED"
+)
+
+// ExecutionVisibility controls which users may list an execution and read its logs,
+// besides its owners and admins.
+type ExecutionVisibility string
+
+const (
+	// ExecutionVisibilityPrivate hides the execution and its logs from everyone but its owners and admins.
+	ExecutionVisibilityPrivate ExecutionVisibility = "private"
+	// ExecutionVisibilityTeam lists the execution to every user and lets the users whose role grants
+	// read access to all executions read its logs.
+	ExecutionVisibilityTeam ExecutionVisibility = "team"
+	// ExecutionVisibilityPublic lets every user of the workspace list the execution and read its logs.
+	ExecutionVisibilityPublic ExecutionVisibility = "public"
+
+	// DefaultExecutionVisibility is the visibility of executions started without one
+	// when the backend does not configure another default.
+	DefaultExecutionVisibility = ExecutionVisibilityTeam
 )
 
+// ExecutionVisibilities returns all valid execution visibilities.
+func ExecutionVisibilities() []ExecutionVisibility {
+	return []ExecutionVisibility{
+		ExecutionVisibilityPrivate,
+		ExecutionVisibilityTeam,
+		ExecutionVisibilityPublic,
+	}
+}
+
+// Valid reports whether the visibility is one of the supported execution visibilities.
+func (v ExecutionVisibility) Valid() bool {
+	return slices.Contains(ExecutionVisibilities(), v)
+}
+
+// ExecutionShell is the shell running the command line of an execution.
+type ExecutionShell string
+
+const (
+	// ExecutionShellSh runs the command line with /bin/sh, the default.
+	ExecutionShellSh ExecutionShell = "sh"
+	// ExecutionShellBash runs the command line with bash, which the image must provide.
+	ExecutionShellBash ExecutionShell = "bash"
+	// ExecutionShellPwsh runs the command line with PowerShell, which the image must provide.
+	ExecutionShellPwsh ExecutionShell = "pwsh"
+)
+
+// ExecutionShells returns all valid execution shells.
+func ExecutionShells() []ExecutionShell {
+	return []ExecutionShell{ExecutionShellSh, ExecutionShellBash, ExecutionShellPwsh}
+}
+
+// Valid reports whether the shell is one of the supported execution shells.
+func (s ExecutionShell) Valid() bool {
+	return slices.Contains(ExecutionShells(), s)
+}
+
+// Args returns the program and the arguments running the command line with the shell, sh when empty.
+func (s ExecutionShell) Args(command string) []string {
+	switch s {
+	case ExecutionShellBash:
+		return []string{"bash", "-c", command}
+	case ExecutionShellPwsh:
+		return []string{"pwsh", "-NoLogo", "-NoProfile", "-NonInteractive", "-Command", command}
+	default:
+		return []string{"/bin/sh", "-c", command}
+	}
+}
+
+// UnregisteredImagePolicy controls what happens when an execution requests an image that is not registered.
+type UnregisteredImagePolicy string
+
+const (
+	// UnregisteredImagePolicyReject refuses the executions of unregistered images.
+	UnregisteredImagePolicyReject UnregisteredImagePolicy = "reject"
+	// UnregisteredImagePolicyRegister registers the image on its first execution, with the default
+	// resources, on behalf of the user running it.
+	UnregisteredImagePolicyRegister UnregisteredImagePolicy = "register"
+
+	// DefaultUnregisteredImagePolicy is the policy applied when the backend does not configure another.
+	DefaultUnregisteredImagePolicy = UnregisteredImagePolicyReject
+)
+
+// Valid reports whether the policy is one of the supported unregistered image policies.
+func (p UnregisteredImagePolicy) Valid() bool {
+	return p == UnregisteredImagePolicyReject || p == UnregisteredImagePolicyRegister
+}
+
+// CommandPolicyEffect is the decision a command policy rule takes on the executions it matches.
+type CommandPolicyEffect string
+
+const (
+	// CommandPolicyEffectAllow lets the matching executions start. Once a role has allow rules,
+	// its executions must match one of them.
+	CommandPolicyEffectAllow CommandPolicyEffect = "allow"
+	// CommandPolicyEffectDeny refuses the matching executions, whatever the allow rules.
+	CommandPolicyEffectDeny CommandPolicyEffect = "deny"
+)
+
+// Valid reports whether the effect is one of the supported command policy effects.
+func (e CommandPolicyEffect) Valid() bool {
+	return e == CommandPolicyEffectAllow || e == CommandPolicyEffectDeny
+}
+
+// CommandPolicyMatch is how the pattern of a command policy rule is matched against the command.
+type CommandPolicyMatch string
+
+const (
+	// CommandPolicyMatchPrefix matches the commands starting with the pattern, leading spaces ignored.
+	CommandPolicyMatchPrefix CommandPolicyMatch = "prefix"
+	// CommandPolicyMatchRegex matches the commands the pattern, a Go regular expression, finds a match in.
+	CommandPolicyMatchRegex CommandPolicyMatch = "regex"
+)
+
+// Valid reports whether the match type is one of the supported command policy match types.
+func (m CommandPolicyMatch) Valid() bool {
+	return m == CommandPolicyMatchPrefix || m == CommandPolicyMatchRegex
+}
+
+// EnvVarType is the type of the values an environment variable declared by an env schema accepts.
+type EnvVarType string
+
+const (
+	// EnvVarTypeString accepts any value.
+	EnvVarTypeString EnvVarType = "string"
+	// EnvVarTypeURL accepts absolute URLs with a scheme and a host.
+	EnvVarTypeURL EnvVarType = "url"
+	// EnvVarTypeInt accepts integers, within the min and max of the variable when set.
+	EnvVarTypeInt EnvVarType = "int"
+	// EnvVarTypeEnum accepts one of the values of the variable.
+	EnvVarTypeEnum EnvVarType = "enum"
+)
+
+// Valid reports whether the type is one of the supported env var types.
+func (t EnvVarType) Valid() bool {
+	return t == EnvVarTypeString || t == EnvVarTypeURL || t == EnvVarTypeInt || t == EnvVarTypeEnum
+}
+
 // TerminalExecutionStatuses returns all statuses that represent completed executions.
 func TerminalExecutionStatuses() []ExecutionStatus {
 	return []ExecutionStatus{
@@ -32,19 +293,41 @@ func TerminalExecutionStatuses() []ExecutionStatus {
 		ExecutionStopped,
 		ExecutionSucceeded,
 		ExecutionTerminating,
+		ExecutionTimedOut,
+	}
+}
+
+// ActiveExecutionStatuses returns all statuses that represent executions that have not completed yet.
+func ActiveExecutionStatuses() []ExecutionStatus {
+	return []ExecutionStatus{
+		ExecutionStarting,
+		ExecutionRunning,
+		ExecutionTerminating,
+	}
+}
+
+// KillableExecutionStatuses returns all statuses from which an execution can be killed.
+func KillableExecutionStatuses() []ExecutionStatus {
+	return []ExecutionStatus{
+		ExecutionStarting,
+		ExecutionRunning,
 	}
 }
 
-// validTransitions defines the allowed state transitions for execution statuses.
-// Each key represents a source status, and the value is a slice of allowed destination statuses.
+// validTransitions defines the allowed state transitions for execution statuses, the state machine both
+// the orchestrator and the event processor check the status updates against. Executions are created
+// STARTING. Each key represents a source status, and the value is a slice of allowed destination statuses.
 var validTransitions = map[ExecutionStatus][]ExecutionStatus{
-	ExecutionStarting:    {ExecutionRunning, ExecutionFailed, ExecutionTerminating},
-	ExecutionRunning:     {ExecutionSucceeded, ExecutionFailed, ExecutionStopped, ExecutionTerminating},
+	ExecutionStarting: {ExecutionRunning, ExecutionFailed, ExecutionTerminating, ExecutionTimedOut},
+	ExecutionRunning: {
+		ExecutionSucceeded, ExecutionFailed, ExecutionStopped, ExecutionTerminating, ExecutionTimedOut,
+	},
 	ExecutionTerminating: {ExecutionStopped},
-	// Terminal states (SUCCEEDED, FAILED, STOPPED) have no valid transitions
+	// Terminal states (SUCCEEDED, FAILED, STOPPED, TIMED_OUT) have no valid transitions
 	ExecutionSucceeded: {},
 	ExecutionFailed:    {},
 	ExecutionStopped:   {},
+	ExecutionTimedOut:  {},
 }
 
 // CanTransition checks if a status transition from 'from' to 'to' is valid.
@@ -57,3 +340,54 @@ func CanTransition(from, to ExecutionStatus) bool {
 	}
 	return slices.Contains(allowed, to)
 }
+
+// PreviousStatuses returns the statuses an execution can move to the status from, sorted.
+// It is empty for STARTING, which executions are only created in.
+func PreviousStatuses(to ExecutionStatus) []ExecutionStatus {
+	var previous []ExecutionStatus
+	for from, allowed := range validTransitions {
+		if slices.Contains(allowed, to) {
+			previous = append(previous, from)
+		}
+	}
+	slices.Sort(previous)
+	return previous
+}
+
+// IsFinal reports whether an execution never leaves the status, once completed.
+func (s ExecutionStatus) IsFinal() bool {
+	allowed, ok := validTransitions[s]
+	return ok && len(allowed) == 0
+}
+
+// ExecutionEventType identifies a step of the execution lifecycle timeline.
+// Unlike ExecutionStatus, which only tracks the business-level status, event types follow the
+// compute platform lifecycle closely enough to tell where an execution spent its time.
+type ExecutionEventType string
+
+const (
+	// ExecutionEventSubmitted is recorded when the execution request is accepted.
+	ExecutionEventSubmitted ExecutionEventType = "SUBMITTED"
+	// ExecutionEventProvisioning is recorded when the compute platform starts allocating the task resources.
+	ExecutionEventProvisioning ExecutionEventType = "PROVISIONING"
+	// ExecutionEventPullingImage is recorded when the container image starts being pulled.
+	ExecutionEventPullingImage ExecutionEventType = "PULLING_IMAGE"
+	// ExecutionEventRunning is recorded when the command starts running.
+	ExecutionEventRunning ExecutionEventType = "RUNNING"
+	// ExecutionEventStopping is recorded when the task starts shutting down.
+	ExecutionEventStopping ExecutionEventType = "STOPPING"
+	// ExecutionEventStopped is recorded when the task has stopped.
+	ExecutionEventStopped ExecutionEventType = "STOPPED"
+)
+
+// ExecutionEventTypes returns all execution event types in lifecycle order.
+func ExecutionEventTypes() []ExecutionEventType {
+	return []ExecutionEventType{
+		ExecutionEventSubmitted,
+		ExecutionEventProvisioning,
+		ExecutionEventPullingImage,
+		ExecutionEventRunning,
+		ExecutionEventStopping,
+		ExecutionEventStopped,
+	}
+}
@@ -0,0 +1,41 @@
+package constants
+
+import "time"
+
+const (
+	// DefaultHealthReportListLimit is the default number of reports returned by the health reports endpoint.
+	DefaultHealthReportListLimit = 20
+
+	// MaxHealthReportListLimit is the maximum number of reports returned by the health reports endpoint.
+	MaxHealthReportListLimit = 500
+)
+
+// CanaryStatus is the outcome of a canary execution run by the health reconciliation.
+type CanaryStatus string
+
+const (
+	// CanaryPending indicates the canary execution has not completed yet.
+	CanaryPending CanaryStatus = "pending"
+	// CanaryPassed indicates every phase of the canary execution succeeded.
+	CanaryPassed CanaryStatus = "passed"
+	// CanaryFailed indicates at least one phase of the canary execution failed.
+	CanaryFailed CanaryStatus = "failed"
+)
+
+const (
+	// CanaryCommand is the command run by canary executions.
+	CanaryCommand = "echo ok"
+
+	// CanaryExpectedOutput is the log line a canary execution must produce.
+	CanaryExpectedOutput = "ok"
+
+	// CanaryTimeoutSeconds is the execution timeout requested for canary executions.
+	CanaryTimeoutSeconds = 300
+
+	// CanaryCompletionTimeout is how long after its launch a canary may remain incomplete
+	// before its missing completion event is reported as a failure.
+	CanaryCompletionTimeout = 15 * time.Minute
+
+	// CanaryHistoryLookback is the number of recent health reports searched for the last launched canary.
+	CanaryHistoryLookback = 50
+)
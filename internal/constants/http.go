@@ -10,12 +10,50 @@ const APIKeyHeader = "X-API-Key"
 // ContentTypeHeader is the HTTP Content-Type header name.
 const ContentTypeHeader = "Content-Type"
 
+// RequestIDHeader is the HTTP header carrying the ID of a request, sent by the CLI and echoed by the orchestrator
+// so that `runvoy trace` finds everything the request caused by that ID.
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength is the maximum length of the request IDs the orchestrator accepts from clients.
+const MaxRequestIDLength = 128
+
+// EventStreamContentType is the Content-Type of Server-Sent Events responses.
+const EventStreamContentType = "text/event-stream"
+
 // HTTPStatusBadRequest is the HTTP status code for bad requests (400).
 const HTTPStatusBadRequest = 400
 
 // HTTPStatusServerError is the HTTP status code for server errors (500).
 const HTTPStatusServerError = 500
 
+// DefaultClientShortTimeout limits the quick API calls of the CLI, such as status checks.
+// It matches the timeout of the orchestrator function, which no call can outlast.
+const DefaultClientShortTimeout = 30 * time.Second
+
+// DefaultClientLongTimeout limits the API calls of the CLI transferring logs, secrets or uploads.
+const DefaultClientLongTimeout = 5 * time.Minute
+
+// DefaultClientDNSCacheTTL is how long the CLI reuses the resolved addresses of a host.
+const DefaultClientDNSCacheTTL = time.Minute
+
+// ClientMaxIdleConns is the number of idle connections the CLI keeps open to all hosts, e.g. the API and S3.
+const ClientMaxIdleConns = 64
+
+// ClientMaxIdleConnsPerHost is the number of idle connections the CLI keeps open to a host for reuse.
+const ClientMaxIdleConnsPerHost = 16
+
+// ClientIdleConnTimeout is how long an idle connection of the CLI is kept open.
+const ClientIdleConnTimeout = 90 * time.Second
+
+// ClientDialTimeout limits the establishment of a connection by the CLI.
+const ClientDialTimeout = 10 * time.Second
+
+// ClientKeepAlive is the interval between the TCP keep-alive probes of the CLI connections.
+const ClientKeepAlive = 30 * time.Second
+
+// ClientTLSHandshakeTimeout limits the TLS handshake of a connection by the CLI.
+const ClientTLSHandshakeTimeout = 10 * time.Second
+
 // ServerReadTimeout is the HTTP server read timeout.
 const ServerReadTimeout = 15 * time.Second
 
@@ -27,3 +65,111 @@ const ServerIdleTimeout = 60 * time.Second
 
 // ServerShutdownTimeout is the timeout for graceful server shutdown.
 const ServerShutdownTimeout = 5 * time.Second
+
+// ExecutionsStreamInterval is the interval between snapshots sent on the executions stream.
+const ExecutionsStreamInterval = 2 * time.Second
+
+// ExecutionsStreamMaxDuration is the maximum lifetime of an executions stream before the server closes it
+// and clients are expected to reconnect. It is kept below the orchestrator Lambda timeout.
+const ExecutionsStreamMaxDuration = 25 * time.Second
+
+// MaxSSEEventSize is the maximum size of a single Server-Sent Events line accepted by the client.
+const MaxSSEEventSize = 4 * 1024 * 1024
+
+// RequestTimestampHeader is the HTTP header carrying the Unix time a signed internal request was sent at.
+const RequestTimestampHeader = "X-Runvoy-Timestamp"
+
+// RequestSignatureHeader is the HTTP header carrying the HMAC-SHA256 signature of a signed internal request.
+const RequestSignatureHeader = "X-Runvoy-Signature"
+
+// MaxRequestSignatureAge is how old a signed internal request can be before it is rejected as a replay.
+const MaxRequestSignatureAge = 5 * time.Minute
+
+// SCIMContentType is the Content-Type of SCIM responses (RFC 7644).
+const SCIMContentType = "application/scim+json"
+
+// SCIMMaxPageSize is the maximum number of resources returned in a page of a SCIM list.
+const SCIMMaxPageSize = 100
+
+// IdentityProviderTimeout is the timeout of the requests to the identity provider, e.g. to fetch its keys.
+const IdentityProviderTimeout = 10 * time.Second
+
+// GitHubActionsIssuer is the issuer of the OIDC tokens of the GitHub Actions workflows.
+const GitHubActionsIssuer = "https://token.actions.githubusercontent.com"
+
+// DefaultGitHubOIDCAudience is the default audience of the GitHub Actions OIDC tokens exchanged for run tokens.
+const DefaultGitHubOIDCAudience = "runvoy"
+
+// RunTokenPrefix prefixes the short-lived run tokens, telling them apart from the API keys.
+const RunTokenPrefix = "rvrt_"
+
+// RunTokenTTL is the lifetime of a run token issued to a GitHub Actions workflow.
+const RunTokenTTL = time.Hour
+
+// API versions served under /api/<version>. v1 is kept stable, breaking changes land in the next version.
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+// DefaultAPIVersion is the API version of the requests that do not select one.
+const DefaultAPIVersion = APIVersionV1
+
+// APIVersionHeader is the HTTP response header naming the API version that served the request.
+const APIVersionHeader = "Runvoy-API-Version"
+
+// APIMediaTypePrefix starts the vendor media types selecting an API version in the Accept header,
+// e.g. "application/vnd.runvoy.v2+json".
+const APIMediaTypePrefix = "application/vnd.runvoy."
+
+// DeprecationHeader is the HTTP response header (RFC 9745) marking a deprecated route, with the date it was
+// deprecated on.
+const DeprecationHeader = "Deprecation"
+
+// SunsetHeader is the HTTP response header (RFC 8594) with the date a deprecated route is removed on.
+const SunsetHeader = "Sunset"
+
+// LegacyRoutesDeprecation is the Deprecation header value of the legacy action-based routes, 2026-10-18.
+const LegacyRoutesDeprecation = "@1792281600"
+
+// LegacyRoutesSunset is the Sunset header value of the legacy action-based routes.
+const LegacyRoutesSunset = "Fri, 30 Apr 2027 00:00:00 GMT"
+
+// BackendVersionHeader is the HTTP response header with the version of the backend.
+const BackendVersionHeader = "Runvoy-Backend-Version"
+
+// MinClientVersionHeader is the HTTP response header with the oldest CLI version the backend supports.
+const MinClientVersionHeader = "Runvoy-Min-Client-Version"
+
+// ClientVersionHeader is the HTTP request header with the version of the CLI sending the request.
+const ClientVersionHeader = "Runvoy-Client-Version"
+
+// AnnouncementHeader is the HTTP response header carrying the current announcement of the admins, if any.
+const AnnouncementHeader = "Runvoy-Announcement"
+
+// AnnouncementCacheTTL is how long the orchestrator serves the announcement from memory before reading it again,
+// as it is sent with every response.
+const AnnouncementCacheTTL = time.Minute
+
+// MaxAnnouncementLength is the maximum length of an announcement, which must fit in a response header.
+const MaxAnnouncementLength = 500
+
+// MaxLoggedBodySize is the number of bytes of a request or response body logged when its bodies are sampled.
+const MaxLoggedBodySize = 4 * 1024
+
+// AcceptEncodingHeader is the HTTP Accept-Encoding header name. The orchestrator also sends it with its
+// responses (RFC 7694) to announce the request content codings it accepts.
+const AcceptEncodingHeader = "Accept-Encoding"
+
+// ContentEncodingHeader is the HTTP Content-Encoding header name.
+const ContentEncodingHeader = "Content-Encoding"
+
+// GzipContentEncoding is the gzip content coding, the only one of the requests the orchestrator accepts.
+const GzipContentEncoding = "gzip"
+
+// ResponseCompressionLevel is the gzip level of the compressed responses, trading a little ratio for speed.
+const ResponseCompressionLevel = 5
+
+// MinCompressedRequestBodySize is the size from which the CLI compresses its request bodies, smaller bodies
+// are not worth the CPU.
+const MinCompressedRequestBodySize = 1024
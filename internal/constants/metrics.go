@@ -0,0 +1,78 @@
+package constants
+
+// Names of the metrics recorded by the event processor.
+// They are shared by the providers so dashboards and alarms use the same names everywhere.
+const (
+	// MetricEventsProcessed counts the events handled by the event processor.
+	MetricEventsProcessed = "EventsProcessed"
+	// MetricCompletionLatency is the time from the start of a task to the processing of its completion event.
+	MetricCompletionLatency = "CompletionLatency"
+	// MetricLogBufferingLag is the age of the oldest log line of a batch when the batch is buffered.
+	MetricLogBufferingLag = "LogBufferingLag"
+	// MetricWebSocketFanOut counts the WebSocket connections a message is sent to.
+	MetricWebSocketFanOut = "WebSocketFanOut"
+	// MetricWebSocketFanOutLatency is the time taken to send a message to all the connections it is fanned out to.
+	MetricWebSocketFanOutLatency = "WebSocketFanOutLatency"
+	// MetricWebSocketConnectionsPruned counts the connections deleted because the client was gone.
+	MetricWebSocketConnectionsPruned = "WebSocketConnectionsPruned"
+	// MetricStartLatency is the time from the submission of an execution to its command starting to run.
+	MetricStartLatency = "StartLatency"
+	// MetricExecutionSLOBreaches counts the executions that ran longer than their playbook expects.
+	MetricExecutionSLOBreaches = "ExecutionSLOBreaches"
+	// MetricStalledKills counts the killed executions whose task still hasn't stopped, at every timeouts sweep.
+	MetricStalledKills = "StalledKills"
+)
+
+// Names of the metrics recorded by the orchestrator.
+const (
+	// MetricInitDuration is the time taken to initialize the orchestrator, the overhead of its cold starts.
+	MetricInitDuration = "InitDuration"
+	// MetricLegacyAPICalls counts the calls to the deprecated action-based routes, to find their remaining callers.
+	MetricLegacyAPICalls = "LegacyAPICalls"
+	// MetricBudgetThresholdsReached counts the users reaching 80% or 100% of their monthly budget, once per month.
+	MetricBudgetThresholdsReached = "BudgetThresholdsReached"
+)
+
+// Dimensions of the event processor and orchestrator metrics.
+const (
+	// MetricDimensionEventType is the kind of event, see the MetricEventType values.
+	MetricDimensionEventType = "EventType"
+	// MetricDimensionOutcome is MetricOutcomeSuccess or MetricOutcomeError.
+	MetricDimensionOutcome = "Outcome"
+	// MetricDimensionMessage is the kind of WebSocket message fanned out: "logs" or "disconnect".
+	MetricDimensionMessage = "Message"
+	// MetricDimensionStartType is MetricStartTypeWarm or MetricStartTypeCold.
+	MetricDimensionStartType = "StartType"
+	// MetricDimensionRoute is the method and path pattern of a legacy route, e.g. "POST /api/v1/users/create".
+	MetricDimensionRoute = "Route"
+	// MetricDimensionHydration is MetricHydrationEager or MetricHydrationLazy.
+	MetricDimensionHydration = "Hydration"
+)
+
+// Values of the EventType dimension.
+const (
+	MetricEventTypeTaskStateChange = "task_state_change"
+	MetricEventTypeScheduled       = "scheduled"
+	MetricEventTypeLogs            = "logs"
+	MetricEventTypeWebSocket       = "websocket"
+)
+
+// Values of the Outcome dimension.
+const (
+	MetricOutcomeSuccess = "success"
+	MetricOutcomeError   = "error"
+)
+
+// Values of the StartType dimension: whether an execution ran on a pre-provisioned warm pool slot
+// or on a task started for it.
+const (
+	MetricStartTypeWarm = "warm"
+	MetricStartTypeCold = "cold"
+)
+
+// Values of the Hydration dimension: whether the authorization enforcer was hydrated during the
+// initialization or is left to the first authorization check.
+const (
+	MetricHydrationEager = "eager"
+	MetricHydrationLazy  = "lazy"
+)
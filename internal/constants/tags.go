@@ -7,3 +7,13 @@ const ResourceApplicationTagKey = "Application"
 // ResourceManagedByTagKey is the tag key for ManagedBy.
 // This tag is used to identify what system or tool manages a resource.
 const ResourceManagedByTagKey = "ManagedBy"
+
+// MaxResourceTags is the maximum number of custom tags applied to resources, leaving room under the
+// provider limits (50 tags per resource on AWS) for the tags runvoy sets itself.
+const MaxResourceTags = 30
+
+// MaxResourceTagKeyLength and MaxResourceTagValueLength are the limits of the custom tags common to the providers.
+const (
+	MaxResourceTagKeyLength   = 63
+	MaxResourceTagValueLength = 63
+)
@@ -5,6 +5,10 @@ import "time"
 // ClaimURLExpirationMinutes is the number of minutes after which a claim URL expires.
 const ClaimURLExpirationMinutes = 15
 
+// DefaultClaimTokenTTL is how long the claim token of an invited user is valid when RUNVOY_CLAIM_TOKEN_TTL
+// is not set.
+const DefaultClaimTokenTTL = ClaimURLExpirationMinutes * time.Minute
+
 // DefaultContextTimeout is the default timeout for context operations.
 const DefaultContextTimeout = 10 * time.Second
 
@@ -19,3 +23,23 @@ const TestContextTimeout = 5 * time.Second
 
 // SpinnerTickerInterval is the interval between spinner frame updates.
 const SpinnerTickerInterval = 80 * time.Millisecond
+
+// DefaultSLOReportPeriod is the period covered by execution SLO reports when none is requested.
+const DefaultSLOReportPeriod = 30 * 24 * time.Hour
+
+// MaxSLOReportPeriod is the longest period an execution SLO report can cover.
+const MaxSLOReportPeriod = 90 * 24 * time.Hour
+
+// DefaultSlowQueryThreshold is the duration above which database queries are logged as slow.
+const DefaultSlowQueryThreshold = 500 * time.Millisecond
+
+// ImageDeletionRetention is how long deleted images can be restored before they are purged.
+const ImageDeletionRetention = 7 * 24 * time.Hour
+
+// DefaultExecutionLockTTL is how long the lock of an execution without a timeout is held before it expires,
+// so the lock of an execution whose completion was never processed is eventually released.
+const DefaultExecutionLockTTL = 24 * time.Hour
+
+// ExecutionLockStartTimeout is how long a lock acquired for an execution that was never recorded, the backend
+// having failed while starting it, is kept before another execution may take it over.
+const ExecutionLockStartTimeout = 5 * time.Minute
@@ -6,6 +6,9 @@ type BackendProvider string
 const (
 	// AWS is the Amazon Web Services backend provider.
 	AWS BackendProvider = "AWS"
+	// Fake is the in-memory backend provider for local development, demos and tests.
+	// Only builds with the fake tag support it.
+	Fake BackendProvider = "FAKE"
 	// Example: GCP BackendProvider = "GCP".
 )
 
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 // HeaderSeparatorLength is the length of the header separator line.
 const HeaderSeparatorLength = 50
 
@@ -8,3 +10,39 @@ const ProgressBarWidth = 40
 
 // BoxBorderPadding is the padding used in box borders.
 const BoxBorderPadding = 2
+
+// CompletionExecutionLimit is the number of recent executions fetched for shell completions.
+const CompletionExecutionLimit = 50
+
+// CompletionRequestTimeout is the maximum time spent fetching dynamic shell completions.
+const CompletionRequestTimeout = 5 * time.Second
+
+// UIRefreshInterval is the interval between executions refreshes in the interactive UI.
+const UIRefreshInterval = 3 * time.Second
+
+// UIExecutionLimit is the maximum number of active executions displayed in the interactive UI.
+const UIExecutionLimit = 50
+
+// UILogStreamBufferSize is the buffer size of the log events channel in the interactive UI.
+const UILogStreamBufferSize = 100
+
+// UIDefaultLogLines is the number of log lines displayed before the terminal size is known.
+const UIDefaultLogLines = 20
+
+// UIReservedLines is the number of screen lines reserved for headers and help in the interactive UI.
+const UIReservedLines = 8
+
+// WatchPollInterval is the default polling interval of the watch command.
+const WatchPollInterval = 2 * time.Second
+
+// TopPollInterval is the default polling interval of the top command.
+// The compute platforms report resource usage samples about every minute.
+const TopPollInterval = 10 * time.Second
+
+// TopMemoryWarningPercent is the memory utilization above which the top command warns that
+// an execution is about to run out of memory.
+const TopMemoryWarningPercent = 90
+
+// LockWaitPollInterval is the interval between the attempts of the run command to start an execution whose lock
+// is held, when waiting for the lock.
+const LockWaitPollInterval = 5 * time.Second
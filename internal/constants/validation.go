@@ -6,21 +6,53 @@ const ExecutionsSliceInitialCapacity = 64
 // EnvVarSplitLimit is the limit for splitting environment variable strings (KEY=VALUE).
 const EnvVarSplitLimit = 2
 
-// RegexMatchCountEnvVar is the expected number of regex matches for environment variable parsing.
-const RegexMatchCountEnvVar = 3
+// MinimumArgsUpdateReadmeHelp is the minimum number of arguments for update-readme-help script.
+const MinimumArgsUpdateReadmeHelp = 2
 
-// ExpectedArgsCreateConfigFile is the expected number of arguments for create-config-file script.
-const ExpectedArgsCreateConfigFile = 2
+// MaxRequestBodySize is the maximum size in bytes of an API request body, kept below
+// the 6 MB payload limit of synchronous Lambda invocations.
+const MaxRequestBodySize = 5 * 1024 * 1024
 
-// ExpectedArgsSeedAdminUser is the expected number of arguments for seed-admin-user script.
-const ExpectedArgsSeedAdminUser = 3
+// MaxCommandLength is the maximum length in bytes of an execution command.
+// Container overrides of an ECS task are limited to 8 KiB in total.
+const MaxCommandLength = 4096
 
-// ExpectedArgsTruncateDynamoDBTable is the expected number of arguments for truncate-dynamodb-table script.
-const ExpectedArgsTruncateDynamoDBTable = 2
+// MaxProvenanceFieldLength is the maximum length in bytes of each field of the provenance of an execution.
+const MaxProvenanceFieldLength = 256
 
-// MinimumArgsDeleteS3Buckets is the minimum number of arguments for delete-s3-buckets script
-// (script name + at least 1 bucket).
-const MinimumArgsDeleteS3Buckets = 2
+// MaxEnvVars is the maximum number of environment variables of an execution.
+const MaxEnvVars = 64
 
-// MinimumArgsUpdateReadmeHelp is the minimum number of arguments for update-readme-help script.
-const MinimumArgsUpdateReadmeHelp = 2
+// MaxEnvVarNameLength is the maximum length in bytes of an environment variable name.
+const MaxEnvVarNameLength = 128
+
+// MaxEnvVarValueLength is the maximum length in bytes of an environment variable value.
+const MaxEnvVarValueLength = 4096
+
+// MaxEnvBytes is the maximum total length in bytes of the names and values of the environment variables of an
+// execution. They are passed in the container overrides of the ECS task, limited to 8 KiB in total.
+const MaxEnvBytes = 8 * 1024
+
+// MaxWorkDirLength is the maximum length in bytes of the working directory of an execution.
+const MaxWorkDirLength = 1024
+
+// MaxImageReferenceLength is the maximum length of an image reference or image ID.
+const MaxImageReferenceLength = 255
+
+// MaxWarmPoolSize is the maximum number of idle pre-provisioned slots kept for an image.
+const MaxWarmPoolSize = 20
+
+// MaxImageAliasLength is the maximum length of an image alias.
+const MaxImageAliasLength = 128
+
+// MaxCommandPolicyRuleNameLength is the maximum length of a command policy rule name.
+const MaxCommandPolicyRuleNameLength = 64
+
+// MaxCommandPolicyPatternLength is the maximum length of the pattern of a command policy rule.
+const MaxCommandPolicyPatternLength = 1024
+
+// MaxUsageReportRecipients is the maximum number of custom recipients of the weekly usage report.
+const MaxUsageReportRecipients = 50
+
+// MaxLockNameLength is the maximum length of the name of an execution lock.
+const MaxLockNameLength = 64
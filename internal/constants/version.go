@@ -7,5 +7,21 @@ func GetVersion() *string {
 	return &version
 }
 
+// MinClientVersion is the oldest CLI release the backend supports, raised when a backend release breaks
+// the older CLIs. The backend refuses the requests of older CLIs.
+const MinClientVersion = "v0.5.0"
+
+// MinBackendVersion is the oldest backend release the CLI supports, raised when a CLI release relies on
+// a backend change. The CLI refuses to send changes to older backends.
+const MinBackendVersion = "v0.5.0"
+
+// releaseSigningKey is the base64 ed25519 public key the checksums of the releases are signed with.
+var releaseSigningKey = "" // Updated by the release pipeline at build time
+
+// GetReleaseSigningKey returns the public key verifying the releases, empty in the builds without one.
+func GetReleaseSigningKey() string {
+	return releaseSigningKey
+}
+
 // ProjectName is the name of the CLI tool and application.
 const ProjectName = "runvoy"
@@ -1,10 +1,21 @@
 package constants
 
+import "time"
+
 // ConnectionTTLHours is the time-to-live for connection records in the database (24 hours).
 const ConnectionTTLHours = 24
 
+// WebSocketTokenTTL is how long a log streaming token can be claimed after it was issued.
+// Tokens are single-use, clients request a new URL to reconnect.
+const WebSocketTokenTTL = 5 * time.Minute
+
 // FunctionalityLogStreaming identifies connections used for streaming execution logs.
 const FunctionalityLogStreaming = "log_streaming"
 
 // MaxConcurrentSends is the maximum number of concurrent sends to WebSocket connections.
 const MaxConcurrentSends = 10
+
+// MaxLogEventsPerConnectionSend is the maximum number of log events sent to a WebSocket connection by a single
+// fan-out. A connection lagging further behind gets the rest with the next fan-out, so it does not hold back
+// the connections that are up to date.
+const MaxLogEventsPerConnectionSend = 1000
@@ -0,0 +1,6 @@
+// Package crypto encrypts the values runvoy stores, such as secret values, with envelope encryption:
+// each value is encrypted with its own data key (DEK) using AES-256-GCM, and the data key is wrapped by
+// a KeyManager holding the key encryption key, e.g. a KMS key on AWS or local AES keys for development.
+// Ciphertexts record the format version and the ID of the key wrapping their data key, so values can be
+// decrypted after the key is rotated and re-encrypted under the current key.
+package crypto
@@ -0,0 +1,245 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	envelopeMagic = "RVENV"
+	// envelopeVersion is the version of the ciphertext format written by Encrypt.
+	// Format: magic | version | key ID length (uint16) | key ID | wrapped data key length (uint16) |
+	// wrapped data key | nonce | AES-256-GCM ciphertext, the fields before the nonce being authenticated.
+	envelopeVersion = byte(1)
+	lengthSize      = 2
+
+	// StringPrefix starts the strings returned by EncryptString.
+	StringPrefix = "enc:"
+)
+
+// ErrInvalidCiphertext is returned when a ciphertext is malformed, was tampered with or doesn't match
+// its additional data.
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Envelope encrypts values with envelope encryption, using a new data key from its KeyManager for
+// each value.
+type Envelope struct {
+	keys KeyManager
+}
+
+// NewEnvelope creates an Envelope wrapping its data keys with the keys of the manager.
+func NewEnvelope(keys KeyManager) *Envelope {
+	return &Envelope{keys: keys}
+}
+
+// header is the unencrypted part of a ciphertext.
+type header struct {
+	version    byte
+	keyID      string
+	wrappedKey []byte
+	raw        []byte
+}
+
+// Encrypt encrypts the plaintext with a new data key wrapped by the current key. The additional data,
+// e.g. the name of a secret, isn't stored but must be given to decrypt the ciphertext, so that a
+// ciphertext can't be swapped with another one.
+func (e *Envelope) Encrypt(ctx context.Context, plaintext, additionalData []byte) ([]byte, error) {
+	dataKey, err := e.keys.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	defer clear(dataKey.Plaintext)
+
+	if len(dataKey.KeyID) > math.MaxUint16 || len(dataKey.Wrapped) > math.MaxUint16 {
+		return nil, errors.New("key ID or wrapped data key too long")
+	}
+
+	raw := make([]byte, 0, len(envelopeMagic)+1+lengthSize*2+len(dataKey.KeyID)+len(dataKey.Wrapped))
+	raw = append(raw, envelopeMagic...)
+	raw = append(raw, envelopeVersion)
+	raw = binary.BigEndian.AppendUint16(raw, uint16(len(dataKey.KeyID)))
+	raw = append(raw, dataKey.KeyID...)
+	raw = binary.BigEndian.AppendUint16(raw, uint16(len(dataKey.Wrapped)))
+	raw = append(raw, dataKey.Wrapped...)
+
+	aead, err := newDataCipher(dataKey.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(raw)+len(nonce)+len(plaintext)+aead.Overhead())
+	sealed = append(sealed, raw...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, plaintext, authenticatedData(raw, additionalData)), nil
+}
+
+// Decrypt decrypts a ciphertext written by Encrypt with the same additional data, whichever key of
+// the manager wrapped its data key.
+func (e *Envelope) Decrypt(ctx context.Context, ciphertext, additionalData []byte) ([]byte, error) {
+	h, err := parseHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := e.keys.DecryptDataKey(ctx, h.keyID, h.wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	defer clear(dataKey)
+
+	aead, err := newDataCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	rest := ciphertext[len(h.raw):]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():],
+		authenticatedData(h.raw, additionalData))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+// KeyID returns the ID of the key that wrapped the data key of a ciphertext.
+func KeyID(ciphertext []byte) (string, error) {
+	h, err := parseHeader(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return h.keyID, nil
+}
+
+// NeedsRotation reports whether the data key of a ciphertext was wrapped by another key than the
+// current one.
+func (e *Envelope) NeedsRotation(ciphertext []byte) (bool, error) {
+	h, err := parseHeader(ciphertext)
+	if err != nil {
+		return false, err
+	}
+	return h.keyID != e.keys.CurrentKeyID(), nil
+}
+
+// Rotate re-encrypts a ciphertext with a new data key wrapped by the current key, in the current format.
+func (e *Envelope) Rotate(ctx context.Context, ciphertext, additionalData []byte) ([]byte, error) {
+	plaintext, err := e.Decrypt(ctx, ciphertext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+	return e.Encrypt(ctx, plaintext, additionalData)
+}
+
+// EncryptString encrypts a string value into a printable string starting with StringPrefix, to be stored
+// where only text is supported.
+func (e *Envelope) EncryptString(ctx context.Context, plaintext, additionalData string) (string, error) {
+	ciphertext, err := e.Encrypt(ctx, []byte(plaintext), []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+	return encodeString(ciphertext), nil
+}
+
+// DecryptString decrypts a string written by EncryptString with the same additional data.
+func (e *Envelope) DecryptString(ctx context.Context, ciphertext, additionalData string) (string, error) {
+	raw, err := DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := e.Decrypt(ctx, raw, []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedString reports whether a string was written by EncryptString.
+func IsEncryptedString(s string) bool {
+	return strings.HasPrefix(s, StringPrefix)
+}
+
+func encodeString(ciphertext []byte) string {
+	return StringPrefix + base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+// DecodeString returns the ciphertext of a string written by EncryptString.
+func DecodeString(s string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(s, StringPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q prefix", ErrInvalidCiphertext, StringPrefix)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+	return raw, nil
+}
+
+func parseHeader(ciphertext []byte) (*header, error) {
+	if !bytes.HasPrefix(ciphertext, []byte(envelopeMagic)) || len(ciphertext) < len(envelopeMagic)+1 {
+		return nil, fmt.Errorf("%w: not an envelope ciphertext", ErrInvalidCiphertext)
+	}
+	h := &header{version: ciphertext[len(envelopeMagic)]}
+	if h.version != envelopeVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidCiphertext, h.version)
+	}
+
+	offset := len(envelopeMagic) + 1
+	keyID, offset, ok := readField(ciphertext, offset)
+	if !ok {
+		return nil, fmt.Errorf("%w: truncated key ID", ErrInvalidCiphertext)
+	}
+	wrappedKey, offset, ok := readField(ciphertext, offset)
+	if !ok {
+		return nil, fmt.Errorf("%w: truncated data key", ErrInvalidCiphertext)
+	}
+
+	h.keyID = string(keyID)
+	h.wrappedKey = wrappedKey
+	h.raw = ciphertext[:offset]
+	return h, nil
+}
+
+// readField reads a field prefixed by its uint16 length at offset, and returns the offset following it.
+func readField(data []byte, offset int) ([]byte, int, bool) {
+	if len(data) < offset+lengthSize {
+		return nil, 0, false
+	}
+	length := int(binary.BigEndian.Uint16(data[offset:]))
+	offset += lengthSize
+	if len(data) < offset+length {
+		return nil, 0, false
+	}
+	return data[offset : offset+length], offset + length, true
+}
+
+// authenticatedData binds the header and the additional data of the caller. The header fields are
+// length-prefixed, so the concatenation is unambiguous.
+func authenticatedData(rawHeader, additionalData []byte) []byte {
+	data := make([]byte, 0, len(rawHeader)+len(additionalData))
+	data = append(data, rawHeader...)
+	return append(data, additionalData...)
+}
+
+func newDataCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
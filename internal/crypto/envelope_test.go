@@ -0,0 +1,155 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, DataKeySize)
+}
+
+func newTestEnvelope(t *testing.T, current string) *Envelope {
+	t.Helper()
+	keys, err := NewLocalKeyManager(current, map[string][]byte{"v1": testKey(1), "v2": testKey(2)})
+	require.NoError(t, err)
+	return NewEnvelope(keys)
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t, "v1")
+
+	ciphertext, err := envelope.Encrypt(ctx, []byte("s3cr3t"), []byte("github-token"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "s3cr3t")
+
+	plaintext, err := envelope.Decrypt(ctx, ciphertext, []byte("github-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(plaintext))
+
+	keyID, err := KeyID(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "local:v1", keyID)
+}
+
+func TestEnvelopeUsesADataKeyPerValue(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t, "v1")
+
+	first, err := envelope.Encrypt(ctx, []byte("s3cr3t"), nil)
+	require.NoError(t, err)
+	second, err := envelope.Encrypt(ctx, []byte("s3cr3t"), nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestEnvelopeDecryptErrors(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t, "v1")
+	ciphertext, err := envelope.Encrypt(ctx, []byte("s3cr3t"), []byte("github-token"))
+	require.NoError(t, err)
+
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 0xff
+
+	unsupported := bytes.Clone(ciphertext)
+	unsupported[len(envelopeMagic)] = 2
+
+	tests := []struct {
+		name           string
+		ciphertext     []byte
+		additionalData string
+	}{
+		{"other additional data", ciphertext, "other-secret"},
+		{"tampered ciphertext", tampered, "github-token"},
+		{"unsupported version", unsupported, "github-token"},
+		{"truncated header", ciphertext[:len(envelopeMagic)+3], "github-token"},
+		{"not an envelope", []byte("s3cr3t"), "github-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := envelope.Decrypt(ctx, tt.ciphertext, []byte(tt.additionalData))
+			assert.ErrorIs(t, err, ErrInvalidCiphertext)
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		keys, err := NewLocalKeyManager("v3", map[string][]byte{"v3": testKey(3)})
+		require.NoError(t, err)
+
+		_, err = NewEnvelope(keys).Decrypt(ctx, ciphertext, []byte("github-token"))
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+}
+
+func TestEnvelopeRotation(t *testing.T) {
+	ctx := context.Background()
+	ciphertext, err := newTestEnvelope(t, "v1").Encrypt(ctx, []byte("s3cr3t"), []byte("github-token"))
+	require.NoError(t, err)
+
+	rotated := newTestEnvelope(t, "v2")
+	needsRotation, err := rotated.NeedsRotation(ciphertext)
+	require.NoError(t, err)
+	assert.True(t, needsRotation)
+
+	// The previous key still decrypts the values it encrypted
+	plaintext, err := rotated.Decrypt(ctx, ciphertext, []byte("github-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(plaintext))
+
+	reencrypted, err := rotated.Rotate(ctx, ciphertext, []byte("github-token"))
+	require.NoError(t, err)
+	keyID, err := KeyID(reencrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "local:v2", keyID)
+
+	needsRotation, err = rotated.NeedsRotation(reencrypted)
+	require.NoError(t, err)
+	assert.False(t, needsRotation)
+
+	plaintext, err = rotated.Decrypt(ctx, reencrypted, []byte("github-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(plaintext))
+}
+
+func TestEnvelopeStrings(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t, "v1")
+
+	encrypted, err := envelope.EncryptString(ctx, "s3cr3t", "github-token")
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedString(encrypted))
+	assert.False(t, IsEncryptedString("s3cr3t"))
+
+	decrypted, err := envelope.DecryptString(ctx, encrypted, "github-token")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", decrypted)
+
+	_, err = envelope.DecryptString(ctx, "s3cr3t", "github-token")
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, err = envelope.DecryptString(ctx, StringPrefix+"not base64!", "github-token")
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestParseLocalKeys(t *testing.T) {
+	v1 := base64.StdEncoding.EncodeToString(testKey(1))
+	v2 := base64.StdEncoding.EncodeToString(testKey(2))
+
+	keys, err := ParseLocalKeys("v2=" + v2 + ", v1=" + v1)
+	require.NoError(t, err)
+	assert.Equal(t, "local:v2", keys.CurrentKeyID())
+
+	for _, spec := range []string{"", "v1", "v1=not base64!", "v1=" + base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err = ParseLocalKeys(spec)
+		assert.Error(t, err, spec)
+	}
+}
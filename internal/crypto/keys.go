@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+)
+
+// DataKeySize is the size in bytes of the data keys, for AES-256.
+const DataKeySize = 32
+
+// ErrUnknownKey is returned when a data key was wrapped by a key the KeyManager doesn't hold.
+var ErrUnknownKey = errors.New("unknown key encryption key")
+
+// DataKey is a data key, in clear to encrypt a value and wrapped to be stored along the ciphertext.
+type DataKey struct {
+	// KeyID identifies the key encryption key that wrapped the data key.
+	KeyID     string
+	Plaintext []byte
+	Wrapped   []byte
+}
+
+// KeyManager generates data keys wrapped by a key encryption key it holds, and unwraps them.
+type KeyManager interface {
+	// CurrentKeyID returns the ID of the key wrapping the new data keys.
+	CurrentKeyID() string
+
+	// GenerateDataKey returns a new data key wrapped by the current key.
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+
+	// DecryptDataKey unwraps a data key wrapped by the key keyID, which may be a previous key.
+	// Returns an error wrapping ErrUnknownKey if the manager can't use the key.
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// localKeyIDPrefix starts the IDs of the local keys, to tell them from the keys of other managers.
+const localKeyIDPrefix = "local:"
+
+// LocalKeyManager is a KeyManager holding AES-256 keys in memory, for development and tests. The data
+// keys are wrapped with AES-256-GCM by the current key.
+type LocalKeyManager struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewLocalKeyManager creates a LocalKeyManager wrapping the data keys with the key named current.
+// The other keys can only unwrap the data keys they wrapped before being rotated out.
+func NewLocalKeyManager(current string, keys map[string][]byte) (*LocalKeyManager, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current key %q is not in the keys", current)
+	}
+	m := &LocalKeyManager{current: current, keys: make(map[string][]byte, len(keys))}
+	for name, key := range keys {
+		if len(key) != DataKeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes long, got %d", name, DataKeySize, len(key))
+		}
+		m.keys[name] = key
+	}
+	return m, nil
+}
+
+// ParseLocalKeys parses keys written as comma-separated name=base64 pairs, the first key being the
+// current one, e.g. "v2=...,v1=...".
+func ParseLocalKeys(spec string) (*LocalKeyManager, error) {
+	var current string
+	keys := make(map[string][]byte)
+	for pair := range strings.SplitSeq(spec, ",") {
+		name, encoded, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid local key %q, expected name=base64", pair)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local key %q: %w", name, err)
+		}
+		if current == "" {
+			current = name
+		}
+		keys[name] = key
+	}
+	return NewLocalKeyManager(current, keys)
+}
+
+// NewEphemeralKeyManager creates a LocalKeyManager with a random key, the values encrypted with it
+// can't be decrypted once the process exits.
+func NewEphemeralKeyManager() (*LocalKeyManager, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return NewLocalKeyManager("ephemeral", map[string][]byte{"ephemeral": key})
+}
+
+// CurrentKeyID returns the ID of the current key.
+func (m *LocalKeyManager) CurrentKeyID() string {
+	return localKeyIDPrefix + m.current
+}
+
+// GenerateDataKey returns a random data key wrapped by the current key.
+func (m *LocalKeyManager) GenerateDataKey(_ context.Context) (*DataKey, error) {
+	plaintext := make([]byte, DataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newDataCipher(m.keys[m.current])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	keyID := m.CurrentKeyID()
+	return &DataKey{
+		KeyID:     keyID,
+		Plaintext: plaintext,
+		Wrapped:   aead.Seal(nonce, nonce, plaintext, []byte(keyID)),
+	}, nil
+}
+
+// DecryptDataKey unwraps a data key wrapped by one of the keys of the manager.
+func (m *LocalKeyManager) DecryptDataKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	name, ok := strings.CutPrefix(keyID, localKeyIDPrefix)
+	key, known := m.keys[name]
+	if !ok || !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	aead, err := newDataCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
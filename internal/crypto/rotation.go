@@ -0,0 +1,129 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// RotationOutcome is what rotating a stored value did.
+type RotationOutcome string
+
+const (
+	// RotationCurrent means the value was already encrypted under the current key and was left unchanged.
+	RotationCurrent RotationOutcome = "current"
+	// RotationRotated means the value was re-encrypted under the current key.
+	RotationRotated RotationOutcome = "rotated"
+	// RotationEncrypted means the value wasn't envelope encrypted and was encrypted under the current key.
+	RotationEncrypted RotationOutcome = "encrypted"
+)
+
+// RotateString re-encrypts a string written by EncryptString under the current key when its data key was wrapped
+// by another key, or whatever the key with force, e.g. after the key material was rotated under the same key ID.
+// Strings not written by EncryptString are values stored before envelope encryption, and are encrypted.
+// The returned string is only to be stored when the outcome isn't RotationCurrent.
+func (e *Envelope) RotateString(
+	ctx context.Context,
+	value, additionalData string,
+	force bool,
+) (string, RotationOutcome, error) {
+	if !IsEncryptedString(value) {
+		encrypted, err := e.EncryptString(ctx, value, additionalData)
+		if err != nil {
+			return "", "", err
+		}
+		return encrypted, RotationEncrypted, nil
+	}
+
+	ciphertext, err := DecodeString(value)
+	if err != nil {
+		return "", "", err
+	}
+	needsRotation, err := e.NeedsRotation(ciphertext)
+	if err != nil {
+		return "", "", err
+	}
+	if !needsRotation && !force {
+		return value, RotationCurrent, nil
+	}
+
+	rotated, err := e.Rotate(ctx, ciphertext, []byte(additionalData))
+	if err != nil {
+		return "", "", err
+	}
+	return encodeString(rotated), RotationRotated, nil
+}
+
+// Rotator lists the encrypted values of a store and rotates them one at a time.
+type Rotator interface {
+	// RotationNames returns the names of the stored encrypted values.
+	RotationNames(ctx context.Context) ([]string, error)
+
+	// RotateValue re-encrypts a stored value under the current key, see Envelope.RotateString.
+	RotateValue(ctx context.Context, name string, force bool) (RotationOutcome, error)
+}
+
+// RotateOptions configures RotateAll.
+type RotateOptions struct {
+	// Force re-encrypts the values already encrypted under the current key.
+	Force bool
+	// After resumes an interrupted rotation, skipping the values up to this name included.
+	After string
+	// Progress is called after each value, if set.
+	Progress func(RotationProgress)
+}
+
+// RotationProgress reports the rotation of a value.
+type RotationProgress struct {
+	Name    string
+	Outcome RotationOutcome
+	// Done counts the values handled so far out of Total, including the ones skipped by RotateOptions.After.
+	Done  int
+	Total int
+}
+
+// RotationSummary counts the values by outcome.
+type RotationSummary struct {
+	Total    int
+	Skipped  int
+	Outcomes map[RotationOutcome]int
+	// Last is the name of the last value handled, to resume from after a failure.
+	Last string
+}
+
+// RotateAll rotates the values of the store in name order, stopping at the first failure. Values are rotated
+// independently, so an interrupted rotation can be resumed from RotationSummary.Last, or simply run again as
+// the values already under the current key are left unchanged unless forced.
+func RotateAll(ctx context.Context, rotator Rotator, opts RotateOptions) (*RotationSummary, error) {
+	names, err := rotator.RotationNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list values: %w", err)
+	}
+	slices.Sort(names)
+
+	summary := &RotationSummary{
+		Total:    len(names),
+		Outcomes: make(map[RotationOutcome]int),
+		Last:     opts.After,
+	}
+	for i, name := range names {
+		if opts.After != "" && name <= opts.After {
+			summary.Skipped++
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		outcome, rotateErr := rotator.RotateValue(ctx, name, opts.Force)
+		if rotateErr != nil {
+			return summary, fmt.Errorf("failed to rotate %s: %w", name, rotateErr)
+		}
+		summary.Outcomes[outcome]++
+		summary.Last = name
+		if opts.Progress != nil {
+			opts.Progress(RotationProgress{Name: name, Outcome: outcome, Done: i + 1, Total: len(names)})
+		}
+	}
+	return summary, nil
+}
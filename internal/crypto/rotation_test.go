@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRotator struct {
+	names   []string
+	fail    string
+	rotated []string
+}
+
+func (r *fakeRotator) RotationNames(_ context.Context) ([]string, error) {
+	return r.names, nil
+}
+
+func (r *fakeRotator) RotateValue(_ context.Context, name string, _ bool) (RotationOutcome, error) {
+	if name == r.fail {
+		return "", errors.New("access denied")
+	}
+	r.rotated = append(r.rotated, name)
+	return RotationRotated, nil
+}
+
+func TestRotateString(t *testing.T) {
+	ctx := context.Background()
+	encrypted, err := newTestEnvelope(t, "v1").EncryptString(ctx, "s3cr3t", "github-token")
+	require.NoError(t, err)
+	envelope := newTestEnvelope(t, "v2")
+
+	rotated, outcome, err := envelope.RotateString(ctx, encrypted, "github-token", false)
+	require.NoError(t, err)
+	assert.Equal(t, RotationRotated, outcome)
+
+	unchanged, outcome, err := envelope.RotateString(ctx, rotated, "github-token", false)
+	require.NoError(t, err)
+	assert.Equal(t, RotationCurrent, outcome)
+	assert.Equal(t, rotated, unchanged)
+
+	forced, outcome, err := envelope.RotateString(ctx, rotated, "github-token", true)
+	require.NoError(t, err)
+	assert.Equal(t, RotationRotated, outcome)
+	assert.NotEqual(t, rotated, forced)
+
+	legacy, outcome, err := envelope.RotateString(ctx, "s3cr3t", "github-token", false)
+	require.NoError(t, err)
+	assert.Equal(t, RotationEncrypted, outcome)
+
+	for _, value := range []string{forced, legacy} {
+		decrypted, decryptErr := envelope.DecryptString(ctx, value, "github-token")
+		require.NoError(t, decryptErr)
+		assert.Equal(t, "s3cr3t", decrypted)
+	}
+
+	_, _, err = envelope.RotateString(ctx, encrypted, "other-secret", false)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestRotateAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates in name order and reports progress", func(t *testing.T) {
+		rotator := &fakeRotator{names: []string{"c", "a", "b"}}
+		var progress []RotationProgress
+
+		summary, err := RotateAll(ctx, rotator, RotateOptions{
+			Progress: func(p RotationProgress) { progress = append(progress, p) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, rotator.rotated)
+		assert.Equal(t, 3, summary.Outcomes[RotationRotated])
+		assert.Equal(t, "c", summary.Last)
+		require.Len(t, progress, 3)
+		assert.Equal(t, RotationProgress{Name: "c", Outcome: RotationRotated, Done: 3, Total: 3}, progress[2])
+	})
+
+	t.Run("stops at the first failure and resumes after the last value", func(t *testing.T) {
+		rotator := &fakeRotator{names: []string{"a", "b", "c"}, fail: "b"}
+
+		summary, err := RotateAll(ctx, rotator, RotateOptions{})
+		require.ErrorContains(t, err, "failed to rotate b")
+		assert.Equal(t, "a", summary.Last)
+
+		rotator.fail = ""
+		summary, err = RotateAll(ctx, rotator, RotateOptions{After: summary.Last})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, rotator.rotated)
+		assert.Equal(t, 1, summary.Skipped)
+		assert.Equal(t, 2, summary.Outcomes[RotationRotated])
+	})
+}
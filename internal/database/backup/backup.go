@@ -0,0 +1,188 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// FormatVersion is the version of the archive format written by Create.
+const FormatVersion = 1
+
+// ErrExists is returned by Store imports when the record already exists in the backend.
+var ErrExists = errors.New("record already exists")
+
+// Archive is the content of a backup.
+type Archive struct {
+	FormatVersion int             `json:"format_version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Provider      string          `json:"provider"`
+	Users         []User          `json:"users"`
+	Images        []api.ImageInfo `json:"images"`
+	Secrets       []api.Secret    `json:"secrets"` // Including their values
+}
+
+// User is a user with the hash of its API key, so the key remains valid once restored.
+type User struct {
+	api.User
+	APIKeyHash string `json:"api_key_hash"`
+	// ExpiresAt is the Unix timestamp after which a not yet claimed user is deleted, 0 if permanent.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+// Store reads and writes the control-plane records of a backend.
+type Store interface {
+	ExportUsers(ctx context.Context) ([]User, error)
+	ExportImages(ctx context.Context) ([]api.ImageInfo, error)
+	// ExportSecrets returns the secrets, with their decrypted values when includeValues is set.
+	ExportSecrets(ctx context.Context, includeValues bool) ([]api.Secret, error)
+
+	// Import methods create a record, preserving its timestamps and audit fields.
+	// They return ErrExists when the record already exists and never overwrite it.
+	ImportUser(ctx context.Context, user *User) error
+	// ImportImage assigns the image an ID when it has none, as the ones of converted archives.
+	ImportImage(ctx context.Context, image *api.ImageInfo) error
+	// ImportSecret stores the secret value encrypted with the backend's own key.
+	ImportSecret(ctx context.Context, secret *api.Secret) error
+}
+
+// Create exports the control-plane data of the backend.
+func Create(ctx context.Context, store Store, provider string, now time.Time) (*Archive, error) {
+	users, err := store.ExportUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export users: %w", err)
+	}
+	images, err := store.ExportImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export images: %w", err)
+	}
+	secrets, err := store.ExportSecrets(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export secrets: %w", err)
+	}
+
+	return &Archive{
+		FormatVersion: FormatVersion,
+		CreatedAt:     now.UTC(),
+		Provider:      provider,
+		Users:         users,
+		Images:        images,
+		Secrets:       secrets,
+	}, nil
+}
+
+// RestoreReport counts the records restored and the ones skipped because they already existed.
+type RestoreReport struct {
+	Users   Counts
+	Images  Counts
+	Secrets Counts
+}
+
+// Counts is the outcome of restoring one kind of record.
+type Counts struct {
+	Restored int
+	Skipped  int
+}
+
+// Restore imports the archive records missing from the backend. Existing records, matched by
+// user email, image ID and secret name, are skipped, so an interrupted restore can be run again.
+func Restore(ctx context.Context, store Store, archive *Archive) (*RestoreReport, error) {
+	if archive.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d (supported: %d)",
+			archive.FormatVersion, FormatVersion)
+	}
+
+	report := &RestoreReport{}
+	if err := restoreUsers(ctx, store, archive.Users, &report.Users); err != nil {
+		return report, err
+	}
+	if err := restoreImages(ctx, store, archive.Images, &report.Images); err != nil {
+		return report, err
+	}
+	if err := restoreSecrets(ctx, store, archive.Secrets, &report.Secrets); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func restoreUsers(ctx context.Context, store Store, users []User, counts *Counts) error {
+	existing, err := store.ExportUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list existing users: %w", err)
+	}
+	emails := make(map[string]struct{}, len(existing))
+	for i := range existing {
+		emails[existing[i].Email] = struct{}{}
+	}
+
+	for i := range users {
+		if _, ok := emails[users[i].Email]; ok {
+			counts.Skipped++
+			continue
+		}
+		if err = countImport(store.ImportUser(ctx, &users[i]), counts); err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", users[i].Email, err)
+		}
+	}
+	return nil
+}
+
+func restoreImages(ctx context.Context, store Store, images []api.ImageInfo, counts *Counts) error {
+	existing, err := store.ExportImages(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list existing images: %w", err)
+	}
+	ids := make(map[string]struct{}, len(existing))
+	for i := range existing {
+		ids[existing[i].ImageID] = struct{}{}
+	}
+
+	for i := range images {
+		if _, ok := ids[images[i].ImageID]; ok {
+			counts.Skipped++
+			continue
+		}
+		if err = countImport(store.ImportImage(ctx, &images[i]), counts); err != nil {
+			return fmt.Errorf("failed to restore image %s: %w", images[i].Image, err)
+		}
+	}
+	return nil
+}
+
+func restoreSecrets(ctx context.Context, store Store, secrets []api.Secret, counts *Counts) error {
+	existing, err := store.ExportSecrets(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to list existing secrets: %w", err)
+	}
+	names := make(map[string]struct{}, len(existing))
+	for i := range existing {
+		names[existing[i].Name] = struct{}{}
+	}
+
+	for i := range secrets {
+		if _, ok := names[secrets[i].Name]; ok {
+			counts.Skipped++
+			continue
+		}
+		if err = countImport(store.ImportSecret(ctx, &secrets[i]), counts); err != nil {
+			return fmt.Errorf("failed to restore secret %s: %w", secrets[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// countImport counts an import, treating records created concurrently as skipped.
+func countImport(err error, counts *Counts) error {
+	switch {
+	case err == nil:
+		counts.Restored++
+	case errors.Is(err, ErrExists):
+		counts.Skipped++
+	default:
+		return err
+	}
+	return nil
+}
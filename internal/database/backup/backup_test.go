@@ -0,0 +1,153 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+type fakeStore struct {
+	users      []User
+	images     []api.ImageInfo
+	secrets    []api.Secret
+	exportErr  error
+	importErr  error
+	existsOnce bool // Report the next import as a concurrent duplicate
+}
+
+func (s *fakeStore) ExportUsers(context.Context) ([]User, error) {
+	return append([]User(nil), s.users...), s.exportErr
+}
+
+func (s *fakeStore) ExportImages(context.Context) ([]api.ImageInfo, error) {
+	return append([]api.ImageInfo(nil), s.images...), s.exportErr
+}
+
+func (s *fakeStore) ExportSecrets(_ context.Context, includeValues bool) ([]api.Secret, error) {
+	secrets := append([]api.Secret(nil), s.secrets...)
+	if !includeValues {
+		for i := range secrets {
+			secrets[i].Value = ""
+		}
+	}
+	return secrets, s.exportErr
+}
+
+func (s *fakeStore) imported() error {
+	if s.existsOnce {
+		s.existsOnce = false
+		return ErrExists
+	}
+	return s.importErr
+}
+
+func (s *fakeStore) ImportUser(_ context.Context, user *User) error {
+	if err := s.imported(); err != nil {
+		return err
+	}
+	s.users = append(s.users, *user)
+	return nil
+}
+
+func (s *fakeStore) ImportImage(_ context.Context, image *api.ImageInfo) error {
+	if err := s.imported(); err != nil {
+		return err
+	}
+	s.images = append(s.images, *image)
+	return nil
+}
+
+func (s *fakeStore) ImportSecret(_ context.Context, secret *api.Secret) error {
+	if err := s.imported(); err != nil {
+		return err
+	}
+	s.secrets = append(s.secrets, *secret)
+	return nil
+}
+
+func TestCreate(t *testing.T) {
+	store := &fakeStore{
+		users:   []User{{User: api.User{Email: "admin@example.com"}, APIKeyHash: "hash"}},
+		images:  []api.ImageInfo{{ImageID: "alpine:latest-a1b2c3d4"}},
+		secrets: []api.Secret{{Name: "github-token", Value: "ghp_secret"}},
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	archive, err := Create(context.Background(), store, "aws", now)
+
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, archive.FormatVersion)
+	assert.Equal(t, now.UTC(), archive.CreatedAt)
+	assert.Equal(t, "aws", archive.Provider)
+	assert.Equal(t, store.users, archive.Users)
+	assert.Equal(t, store.images, archive.Images)
+	assert.Equal(t, "ghp_secret", archive.Secrets[0].Value)
+}
+
+func TestCreate_ExportError(t *testing.T) {
+	_, err := Create(context.Background(), &fakeStore{exportErr: errors.New("denied")}, "aws", time.Now())
+
+	assert.ErrorContains(t, err, "failed to export users: denied")
+}
+
+func TestRestore(t *testing.T) {
+	archive := &Archive{
+		FormatVersion: FormatVersion,
+		Users: []User{
+			{User: api.User{Email: "admin@example.com"}, APIKeyHash: "hash-1"},
+			{User: api.User{Email: "dev@example.com"}, APIKeyHash: "hash-2"},
+		},
+		Images:  []api.ImageInfo{{ImageID: "alpine:latest-a1b2c3d4"}},
+		Secrets: []api.Secret{{Name: "github-token", Value: "ghp_secret"}},
+	}
+
+	t.Run("restores missing records", func(t *testing.T) {
+		store := &fakeStore{users: []User{{User: api.User{Email: "admin@example.com"}, APIKeyHash: "other"}}}
+
+		report, err := Restore(context.Background(), store, archive)
+
+		require.NoError(t, err)
+		assert.Equal(t, Counts{Restored: 1, Skipped: 1}, report.Users)
+		assert.Equal(t, Counts{Restored: 1}, report.Images)
+		assert.Equal(t, Counts{Restored: 1}, report.Secrets)
+		assert.Equal(t, "ghp_secret", store.secrets[0].Value)
+
+		report, err = Restore(context.Background(), store, archive)
+
+		require.NoError(t, err)
+		assert.Equal(t, Counts{Skipped: 2}, report.Users)
+		assert.Equal(t, Counts{Skipped: 1}, report.Images)
+		assert.Equal(t, Counts{Skipped: 1}, report.Secrets)
+	})
+
+	t.Run("counts concurrent duplicates as skipped", func(t *testing.T) {
+		store := &fakeStore{existsOnce: true}
+
+		report, err := Restore(context.Background(), store, archive)
+
+		require.NoError(t, err)
+		assert.Equal(t, Counts{Restored: 1, Skipped: 1}, report.Users)
+	})
+
+	t.Run("import error", func(t *testing.T) {
+		store := &fakeStore{importErr: errors.New("throttled")}
+
+		report, err := Restore(context.Background(), store, archive)
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to restore user admin@example.com: throttled", err.Error())
+		assert.Equal(t, Counts{}, report.Users)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Restore(context.Background(), &fakeStore{}, &Archive{FormatVersion: 2})
+
+		assert.ErrorContains(t, err, "unsupported archive format version 2")
+	})
+}
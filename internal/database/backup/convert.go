@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// providerProfile describes how a provider represents the provider-specific fields of an image.
+type providerProfile struct {
+	// cpuUnitsPerVCPU is the CPU amount of one vCPU: ECS CPU units on AWS, millicores on GCP Cloud Run.
+	cpuUnitsPerVCPU int
+	// usesExecutionRole is set when tasks are started with a separate infrastructure role.
+	usesExecutionRole bool
+}
+
+var providerProfiles = map[string]providerProfile{
+	"aws": {cpuUnitsPerVCPU: 1024, usesExecutionRole: true},
+	"gcp": {cpuUnitsPerVCPU: 1000},
+}
+
+// ConvertOptions configures the conversion of an archive for another backend.
+type ConvertOptions struct {
+	// Identities maps the task and execution roles of the source backend to the identities
+	// of the destination: IAM role names on AWS, service account emails on GCP.
+	Identities map[string]string
+}
+
+// Convert returns a copy of the archive with its provider-specific fields remapped for a backend
+// of the given provider, along with warnings about the values that could not be carried over.
+// Users and secrets are provider-neutral: secret values are stored in plain text in the archive
+// and encrypted with the destination's key on restore.
+//
+// Images keep their ID unless their configuration changes, in which case the ID and task definition
+// name are cleared for the destination store to assign them. Across providers, task definition names
+// are always cleared, CPU amounts are converted, and roles without a mapping are dropped in favor of
+// the destination defaults. The destination registers the runtime definitions when reconciling its health.
+func Convert(archive *Archive, provider string, opts *ConvertOptions) (*Archive, []string, error) {
+	source := strings.ToLower(archive.Provider)
+	target := strings.ToLower(provider)
+	sourceProfile, ok := providerProfiles[source]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported source provider: %s", archive.Provider)
+	}
+	targetProfile, ok := providerProfiles[target]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported destination provider: %s", provider)
+	}
+	if opts == nil {
+		opts = &ConvertOptions{}
+	}
+
+	converted := *archive
+	converted.Provider = provider
+	converted.Images = make([]api.ImageInfo, 0, len(archive.Images))
+	var warnings []string
+	for i := range archive.Images {
+		image, imageWarnings := convertImage(&archive.Images[i], source != target, sourceProfile, targetProfile, opts)
+		converted.Images = append(converted.Images, *image)
+		warnings = append(warnings, imageWarnings...)
+	}
+	return &converted, warnings, nil
+}
+
+func convertImage(
+	image *api.ImageInfo,
+	crossProvider bool,
+	source, target providerProfile,
+	opts *ConvertOptions,
+) (*api.ImageInfo, []string) {
+	converted := *image
+	var warnings []string
+
+	converted.TaskRoleName = mapIdentity(image.TaskRoleName, crossProvider, opts, func(role string) {
+		warnings = append(warnings, fmt.Sprintf(
+			"image %s: task role %s has no mapping, using the default identity", image.ImageID, role))
+	})
+	converted.TaskExecutionRoleName = mapIdentity(image.TaskExecutionRoleName, crossProvider, opts, func(role string) {
+		warnings = append(warnings, fmt.Sprintf(
+			"image %s: execution role %s has no mapping, using the default role", image.ImageID, role))
+	})
+	if !target.usesExecutionRole && converted.TaskExecutionRoleName != nil {
+		warnings = append(warnings, fmt.Sprintf(
+			"image %s: execution role %s is not used by the destination, dropped",
+			image.ImageID, *converted.TaskExecutionRoleName))
+		converted.TaskExecutionRoleName = nil
+	}
+
+	if crossProvider {
+		converted.TaskDefinitionName = ""
+		if image.CPU > 0 {
+			converted.CPU = int(math.Round(float64(image.CPU) * float64(target.cpuUnitsPerVCPU) /
+				float64(source.cpuUnitsPerVCPU)))
+		}
+	}
+
+	if !sameImageConfig(image, &converted) {
+		converted.ImageID = ""
+		converted.TaskDefinitionName = ""
+	}
+	return &converted, warnings
+}
+
+// mapIdentity returns the destination identity of a role. Unmapped roles are kept within a provider
+// and dropped across providers, where they cannot exist.
+func mapIdentity(role *string, crossProvider bool, opts *ConvertOptions, onDropped func(string)) *string {
+	if role == nil || *role == "" {
+		return role
+	}
+	if mapped, ok := opts.Identities[*role]; ok {
+		return &mapped
+	}
+	if crossProvider {
+		onDropped(*role)
+		return nil
+	}
+	return role
+}
+
+// sameImageConfig reports whether the fields identifying an image configuration are unchanged.
+func sameImageConfig(a, b *api.ImageInfo) bool {
+	return a.CPU == b.CPU &&
+		a.Memory == b.Memory &&
+		a.RuntimePlatform == b.RuntimePlatform &&
+		stringValue(a.TaskRoleName) == stringValue(b.TaskRoleName) &&
+		stringValue(a.TaskExecutionRoleName) == stringValue(b.TaskExecutionRoleName)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
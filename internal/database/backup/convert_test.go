@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func convertTestArchive() *Archive {
+	return &Archive{
+		FormatVersion: FormatVersion,
+		Provider:      "aws",
+		Users:         []User{{User: api.User{Email: "admin@example.com"}, APIKeyHash: "hash"}},
+		Secrets:       []api.Secret{{Name: "db-password", Value: "s3cret"}},
+		Images: []api.ImageInfo{
+			{
+				ImageID:            "alpine:latest-a1b2c3d4",
+				Image:              "alpine:latest",
+				TaskDefinitionName: "runvoy-alpine-latest-a1b2c3d4",
+				CPU:                512,
+				Memory:             1024,
+			},
+			{
+				ImageID:               "terraform:1.6-e5f6a7b8",
+				Image:                 "hashicorp/terraform:1.6",
+				TaskDefinitionName:    "runvoy-terraform-1-6-e5f6a7b8",
+				TaskRoleName:          stringPtr("terraform-role"),
+				TaskExecutionRoleName: stringPtr("terraform-exec-role"),
+				CPU:                   1024,
+				Memory:                2048,
+			},
+		},
+	}
+}
+
+func TestConvert_SameProviderKeepsImages(t *testing.T) {
+	archive := convertTestArchive()
+
+	converted, warnings, err := Convert(archive, "AWS", nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, archive.Images, converted.Images)
+	assert.Equal(t, archive.Users, converted.Users)
+	assert.Equal(t, archive.Secrets, converted.Secrets)
+}
+
+func TestConvert_SameProviderMapsIdentities(t *testing.T) {
+	archive := convertTestArchive()
+
+	converted, warnings, err := Convert(archive, "aws", &ConvertOptions{
+		Identities: map[string]string{"terraform-role": "deployer-role"},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, archive.Images[0], converted.Images[0])
+	image := converted.Images[1]
+	assert.Equal(t, "deployer-role", *image.TaskRoleName)
+	assert.Equal(t, "terraform-exec-role", *image.TaskExecutionRoleName)
+	assert.Empty(t, image.ImageID, "a changed configuration gets a new ID")
+	assert.Empty(t, image.TaskDefinitionName)
+	assert.Equal(t, "terraform-role", *archive.Images[1].TaskRoleName, "the source archive is unchanged")
+}
+
+func TestConvert_CrossProvider(t *testing.T) {
+	archive := convertTestArchive()
+
+	converted, warnings, err := Convert(archive, "gcp", &ConvertOptions{
+		Identities: map[string]string{"terraform-role": "terraform@project.iam.gserviceaccount.com"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "gcp", converted.Provider)
+	assert.Equal(t, archive.Users, converted.Users)
+	assert.Equal(t, archive.Secrets, converted.Secrets)
+
+	plain := converted.Images[0]
+	assert.Empty(t, plain.ImageID)
+	assert.Empty(t, plain.TaskDefinitionName)
+	assert.Equal(t, 500, plain.CPU)
+	assert.Equal(t, 1024, plain.Memory)
+
+	withRoles := converted.Images[1]
+	assert.Equal(t, 1000, withRoles.CPU)
+	assert.Equal(t, "terraform@project.iam.gserviceaccount.com", *withRoles.TaskRoleName)
+	assert.Nil(t, withRoles.TaskExecutionRoleName)
+	assert.Equal(t, []string{
+		"image terraform:1.6-e5f6a7b8: execution role terraform-exec-role has no mapping, using the default role",
+	}, warnings)
+}
+
+func TestConvert_CrossProviderMappedExecutionRoleIsDropped(t *testing.T) {
+	archive := convertTestArchive()
+
+	_, warnings, err := Convert(archive, "gcp", &ConvertOptions{
+		Identities: map[string]string{"terraform-exec-role": "runner@project.iam.gserviceaccount.com"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"image terraform:1.6-e5f6a7b8: task role terraform-role has no mapping, using the default identity",
+		"image terraform:1.6-e5f6a7b8: execution role runner@project.iam.gserviceaccount.com " +
+			"is not used by the destination, dropped",
+	}, warnings)
+}
+
+func TestConvert_UnsupportedProvider(t *testing.T) {
+	archive := convertTestArchive()
+
+	_, _, err := Convert(archive, "azure", nil)
+	assert.ErrorContains(t, err, "unsupported destination provider: azure")
+
+	archive.Provider = "azure"
+	_, _, err = Convert(archive, "aws", nil)
+	assert.ErrorContains(t, err, "unsupported source provider: azure")
+}
@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinPassphraseLength is the minimum length of archive passphrases.
+	MinPassphraseLength = 12
+
+	archiveMagic     = "RUNVOYBK"
+	archiveVersion   = byte(1)
+	saltSize         = 16
+	keySize          = 32
+	kdfIterations    = 600_000
+	archiveHeaderLen = len(archiveMagic) + 1 + saltSize
+)
+
+// ErrInvalidPassphrase is returned by Open when the archive cannot be decrypted.
+var ErrInvalidPassphrase = errors.New("invalid passphrase or corrupted archive")
+
+// Seal serializes and compresses the archive, then encrypts it with AES-256-GCM using a key
+// derived from the passphrase with PBKDF2-SHA256.
+// Format: magic | version | salt | nonce | ciphertext, the header being authenticated.
+func Seal(archive *Archive, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	}
+
+	var plaintext bytes.Buffer
+	gzipWriter := gzip.NewWriter(&plaintext)
+	if err := json.NewEncoder(gzipWriter).Encode(archive); err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+
+	header := make([]byte, 0, archiveHeaderLen)
+	header = append(header, archiveMagic...)
+	header = append(header, archiveVersion)
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	header = append(header, salt...)
+
+	aead, err := newArchiveCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(header)+len(nonce)+plaintext.Len()+aead.Overhead())
+	sealed = append(sealed, header...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, plaintext.Bytes(), header), nil
+}
+
+// Open decrypts and decodes an archive written by Seal.
+func Open(data []byte, passphrase string) (*Archive, error) {
+	if len(data) < archiveHeaderLen || string(data[:len(archiveMagic)]) != archiveMagic {
+		return nil, errors.New("not a backup archive")
+	}
+	if version := data[len(archiveMagic)]; version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive encryption version %d", version)
+	}
+
+	header := data[:archiveHeaderLen]
+	salt := header[len(archiveMagic)+1:]
+	aead, err := newArchiveCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	rest := data[archiveHeaderLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrInvalidPassphrase
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	var archive Archive
+	if err = json.NewDecoder(gzipReader).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+	return &archive, nil
+}
+
+func newArchiveCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+func testArchive() *Archive {
+	return &Archive{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Provider:      "aws",
+		Users: []User{{
+			User:       api.User{Email: "admin@example.com", Role: "admin", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			APIKeyHash: "hash",
+		}},
+		Secrets: []api.Secret{{Name: "github-token", KeyName: "GITHUB_TOKEN", Value: "ghp_secret"}},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	archive := testArchive()
+
+	sealed, err := Seal(archive, testPassphrase)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "ghp_secret")
+	assert.NotContains(t, string(sealed), "admin@example.com")
+
+	opened, err := Open(sealed, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, archive, opened)
+}
+
+func TestSeal_ShortPassphrase(t *testing.T) {
+	_, err := Seal(testArchive(), "short")
+
+	assert.ErrorContains(t, err, "passphrase must be at least 12 characters")
+}
+
+func TestOpen_Errors(t *testing.T) {
+	sealed, err := Seal(testArchive(), testPassphrase)
+	require.NoError(t, err)
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, openErr := Open(sealed, "wrong passphrase!")
+		assert.ErrorIs(t, openErr, ErrInvalidPassphrase)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[len(tampered)-1] ^= 0xff
+		_, openErr := Open(tampered, testPassphrase)
+		assert.ErrorIs(t, openErr, ErrInvalidPassphrase)
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[len(archiveMagic)+1] ^= 0xff
+		_, openErr := Open(tampered, testPassphrase)
+		assert.ErrorIs(t, openErr, ErrInvalidPassphrase)
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		_, openErr := Open([]byte(`{"users": []}`), testPassphrase)
+		assert.ErrorContains(t, openErr, "not a backup archive")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		future := append([]byte(nil), sealed...)
+		future[len(archiveMagic)] = 9
+		_, openErr := Open(future, testPassphrase)
+		assert.ErrorContains(t, openErr, "unsupported archive encryption version 9")
+	})
+}
@@ -0,0 +1,6 @@
+// Package backup exports the control-plane data of a backend (users, image configurations and
+// secrets) to a portable archive, encrypted with a passphrase, and restores it into a backend.
+//
+// Providers implement the Store interface on top of their repositories; archives only contain
+// provider-neutral records, so they can be restored into a fresh stack.
+package backup
@@ -0,0 +1,6 @@
+// Package migrations runs versioned schema and data migrations against the backend database.
+//
+// Migrations are registered in Go with a unique, increasing version and applied in order by a Runner.
+// Applied migrations are recorded in a ledger, so each one runs once per backend. Provider packages
+// implement the Executor (data changes) and Ledger (bookkeeping) interfaces for their database.
+package migrations
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Collection identifies a backend table or collection independently of its deployed name.
+type Collection string
+
+// Backend collections.
+const (
+	CollectionAPIKeys         Collection = "api-keys"
+	CollectionExecutions      Collection = "executions"
+	CollectionSecretsMetadata Collection = "secrets-metadata"
+	CollectionImageTaskDefs   Collection = "image-taskdefs"
+)
+
+// ErrAlreadyApplied is returned by Ledger.Record when the migration version is already recorded.
+var ErrAlreadyApplied = errors.New("migration already applied")
+
+// Executor applies data changes to the backend database.
+type Executor interface {
+	// BackfillField sets field to value on the documents of collection that lack it
+	// and returns the number of documents changed.
+	BackfillField(ctx context.Context, collection Collection, field, value string) (int, error)
+
+	// BackfillDerivedField sets field on the documents of collection that lack it to the value derived from
+	// their string source fields, missing ones being empty, and returns the number of documents changed.
+	// Documents for which derive returns an empty value are left unchanged.
+	BackfillDerivedField(
+		ctx context.Context,
+		collection Collection,
+		field string,
+		sources []string,
+		derive func(values map[string]string) string,
+	) (int, error)
+
+	// DryRun returns an executor that counts the documents it would change without writing them.
+	DryRun() Executor
+}
+
+// Ledger records the migrations applied to the backend database.
+type Ledger interface {
+	// Applied returns the recorded migrations.
+	Applied(ctx context.Context) ([]Record, error)
+
+	// Record stores an applied migration.
+	// Returns ErrAlreadyApplied if the version is already recorded.
+	Record(ctx context.Context, record *Record) error
+}
+
+// Record is a ledger entry.
+type Record struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+	Changes   int // Number of documents changed by the migration
+}
+
+// Migration is a versioned change of the backend database.
+// Up must be idempotent: a migration interrupted before being recorded runs again.
+type Migration struct {
+	Version     int
+	Name        string
+	Description string
+	Up          func(ctx context.Context, exec Executor) (int, error)
+}
+
+// Registry holds migrations ordered by version.
+type Registry struct {
+	migrations []Migration
+}
+
+// NewRegistry creates a registry, checking that versions are positive and unique.
+func NewRegistry(migrations ...Migration) (*Registry, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration %s: version must be positive", migration.Name)
+		}
+		if migration.Name == "" || migration.Up == nil {
+			return nil, fmt.Errorf("migration %d: name and up function are required", migration.Version)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("migrations %s and %s share version %d",
+				sorted[i-1].Name, migration.Name, migration.Version)
+		}
+	}
+
+	return &Registry{migrations: sorted}, nil
+}
+
+// Migrations returns the registered migrations ordered by version.
+func (r *Registry) Migrations() []Migration {
+	return append([]Migration(nil), r.migrations...)
+}
+
+// Latest returns the highest registered version, or 0 if the registry is empty.
+func (r *Registry) Latest() int {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noopUp(context.Context, Executor) (int, error) {
+	return 0, nil
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Run("orders migrations by version", func(t *testing.T) {
+		registry, err := NewRegistry(
+			Migration{Version: 3, Name: "third", Up: noopUp},
+			Migration{Version: 1, Name: "first", Up: noopUp},
+			Migration{Version: 2, Name: "second", Up: noopUp},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, registry.Latest())
+		migrations := registry.Migrations()
+		require.Len(t, migrations, 3)
+		assert.Equal(t, "first", migrations[0].Name)
+		assert.Equal(t, "third", migrations[2].Name)
+	})
+
+	t.Run("empty registry", func(t *testing.T) {
+		registry, err := NewRegistry()
+
+		require.NoError(t, err)
+		assert.Zero(t, registry.Latest())
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		_, err := NewRegistry(
+			Migration{Version: 1, Name: "first", Up: noopUp},
+			Migration{Version: 1, Name: "other", Up: noopUp},
+		)
+
+		assert.ErrorContains(t, err, "migrations first and other share version 1")
+	})
+
+	t.Run("invalid migrations", func(t *testing.T) {
+		_, err := NewRegistry(Migration{Version: 0, Name: "zero", Up: noopUp})
+		assert.ErrorContains(t, err, "version must be positive")
+
+		_, err = NewRegistry(Migration{Version: 1, Name: "no-up"})
+		assert.ErrorContains(t, err, "name and up function are required")
+	})
+}
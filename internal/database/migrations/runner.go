@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Runner applies the registered migrations missing from the ledger.
+type Runner struct {
+	registry *Registry
+	executor Executor
+	ledger   Ledger
+	now      func() time.Time
+}
+
+// NewRunner creates a new migrations runner.
+func NewRunner(registry *Registry, executor Executor, ledger Ledger) *Runner {
+	return &Runner{
+		registry: registry,
+		executor: executor,
+		ledger:   ledger,
+		now:      time.Now,
+	}
+}
+
+// Status is the state of a registered migration.
+type Status struct {
+	Migration
+	Applied bool
+	Record  *Record // Ledger entry, set when applied
+}
+
+// Status returns the registered migrations with their ledger state.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.registry.migrations))
+	for _, migration := range r.registry.migrations {
+		record, ok := applied[migration.Version]
+		statuses = append(statuses, Status{Migration: migration, Applied: ok, Record: record})
+	}
+	return statuses, nil
+}
+
+// UpOptions configures Runner.Up.
+type UpOptions struct {
+	// Target is the last version to apply. Zero applies all pending migrations.
+	Target int
+	// DryRun counts the changes of pending migrations without applying or recording them.
+	DryRun bool
+}
+
+// Result is the outcome of a migration run by Runner.Up.
+type Result struct {
+	Migration
+	Changes  int
+	Duration time.Duration
+}
+
+// Up applies the pending migrations up to the target version, in order.
+// It stops at the first failure and returns the results of the migrations applied before it.
+func (r *Runner) Up(ctx context.Context, opts UpOptions) ([]Result, error) {
+	applied, err := r.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := r.executor
+	if opts.DryRun {
+		executor = executor.DryRun()
+	}
+
+	var results []Result
+	for _, migration := range r.registry.migrations {
+		if opts.Target > 0 && migration.Version > opts.Target {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		startedAt := r.now()
+		changes, upErr := migration.Up(ctx, executor)
+		if upErr != nil {
+			return results, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, upErr)
+		}
+		result := Result{Migration: migration, Changes: changes, Duration: r.now().Sub(startedAt)}
+
+		if !opts.DryRun {
+			recordErr := r.ledger.Record(ctx, &Record{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: startedAt.UTC(),
+				Changes:   changes,
+			})
+			if errors.Is(recordErr, ErrAlreadyApplied) {
+				// Applied concurrently by another run, which recorded it first
+				continue
+			}
+			if recordErr != nil {
+				return results, fmt.Errorf("failed to record migration %d (%s): %w",
+					migration.Version, migration.Name, recordErr)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (r *Runner) appliedRecords(ctx context.Context) (map[int]*Record, error) {
+	records, err := r.ledger.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations ledger: %w", err)
+	}
+
+	applied := make(map[int]*Record, len(records))
+	for i := range records {
+		applied[records[i].Version] = &records[i]
+	}
+	return applied, nil
+}
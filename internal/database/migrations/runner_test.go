@@ -0,0 +1,188 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExecutor struct {
+	dryRun   bool
+	backfill []string
+}
+
+func (e *fakeExecutor) BackfillField(_ context.Context, collection Collection, field, _ string) (int, error) {
+	e.backfill = append(e.backfill, string(collection)+"."+field)
+	return 2, nil
+}
+
+func (e *fakeExecutor) BackfillDerivedField(
+	_ context.Context, collection Collection, field string, _ []string, _ func(map[string]string) string,
+) (int, error) {
+	e.backfill = append(e.backfill, string(collection)+"."+field)
+	return 2, nil
+}
+
+func (e *fakeExecutor) DryRun() Executor {
+	return &fakeExecutor{dryRun: true}
+}
+
+type fakeLedger struct {
+	records   []Record
+	readErr   error
+	recordErr error
+}
+
+func (l *fakeLedger) Applied(context.Context) ([]Record, error) {
+	return l.records, l.readErr
+}
+
+func (l *fakeLedger) Record(_ context.Context, record *Record) error {
+	if l.recordErr != nil {
+		return l.recordErr
+	}
+	l.records = append(l.records, *record)
+	return nil
+}
+
+func backfillMigration(version int, name string, collection Collection) Migration {
+	return Migration{
+		Version: version,
+		Name:    name,
+		Up: func(ctx context.Context, exec Executor) (int, error) {
+			return exec.BackfillField(ctx, collection, "_all", "ALL")
+		},
+	}
+}
+
+func newTestRunner(t *testing.T, ledger *fakeLedger, migrations ...Migration) (*Runner, *fakeExecutor) {
+	t.Helper()
+	registry, err := NewRegistry(migrations...)
+	require.NoError(t, err)
+	executor := &fakeExecutor{}
+	runner := NewRunner(registry, executor, ledger)
+	runner.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return runner, executor
+}
+
+func TestRunner_Up(t *testing.T) {
+	t.Run("applies pending migrations in order", func(t *testing.T) {
+		ledger := &fakeLedger{records: []Record{{Version: 1, Name: "first"}}}
+		runner, executor := newTestRunner(t, ledger,
+			backfillMigration(3, "third", CollectionSecretsMetadata),
+			backfillMigration(1, "first", CollectionAPIKeys),
+			backfillMigration(2, "second", CollectionExecutions),
+		)
+
+		results, err := runner.Up(context.Background(), UpOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "second", results[0].Name)
+		assert.Equal(t, 2, results[0].Changes)
+		assert.Equal(t, "third", results[1].Name)
+		assert.Equal(t, []string{"executions._all", "secrets-metadata._all"}, executor.backfill)
+		require.Len(t, ledger.records, 3)
+		assert.Equal(t, Record{
+			Version:   2,
+			Name:      "second",
+			AppliedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Changes:   2,
+		}, ledger.records[1])
+	})
+
+	t.Run("stops at target version", func(t *testing.T) {
+		ledger := &fakeLedger{}
+		runner, _ := newTestRunner(t, ledger,
+			backfillMigration(1, "first", CollectionAPIKeys),
+			backfillMigration(2, "second", CollectionExecutions),
+		)
+
+		results, err := runner.Up(context.Background(), UpOptions{Target: 1})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Len(t, ledger.records, 1)
+	})
+
+	t.Run("dry run does not record", func(t *testing.T) {
+		ledger := &fakeLedger{}
+		runner, executor := newTestRunner(t, ledger, backfillMigration(1, "first", CollectionAPIKeys))
+
+		results, err := runner.Up(context.Background(), UpOptions{DryRun: true})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 2, results[0].Changes)
+		assert.Empty(t, executor.backfill, "dry run must use the dry-run executor")
+		assert.Empty(t, ledger.records)
+	})
+
+	t.Run("stops at first failure", func(t *testing.T) {
+		ledger := &fakeLedger{}
+		failing := Migration{
+			Version: 2,
+			Name:    "failing",
+			Up: func(context.Context, Executor) (int, error) {
+				return 0, errors.New("throttled")
+			},
+		}
+		runner, _ := newTestRunner(t, ledger,
+			backfillMigration(1, "first", CollectionAPIKeys),
+			failing,
+			backfillMigration(3, "third", CollectionExecutions),
+		)
+
+		results, err := runner.Up(context.Background(), UpOptions{})
+
+		require.Error(t, err)
+		assert.Equal(t, "migration 2 (failing) failed: throttled", err.Error())
+		require.Len(t, results, 1)
+		assert.Len(t, ledger.records, 1)
+	})
+
+	t.Run("skips migrations recorded concurrently", func(t *testing.T) {
+		ledger := &fakeLedger{recordErr: ErrAlreadyApplied}
+		runner, _ := newTestRunner(t, ledger, backfillMigration(1, "first", CollectionAPIKeys))
+
+		results, err := runner.Up(context.Background(), UpOptions{})
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("ledger errors", func(t *testing.T) {
+		runner, _ := newTestRunner(t, &fakeLedger{readErr: errors.New("access denied")},
+			backfillMigration(1, "first", CollectionAPIKeys))
+		_, err := runner.Up(context.Background(), UpOptions{})
+		assert.ErrorContains(t, err, "failed to read migrations ledger: access denied")
+
+		runner, _ = newTestRunner(t, &fakeLedger{recordErr: errors.New("throttled")},
+			backfillMigration(1, "first", CollectionAPIKeys))
+		_, err = runner.Up(context.Background(), UpOptions{})
+		assert.ErrorContains(t, err, "failed to record migration 1 (first): throttled")
+	})
+}
+
+func TestRunner_Status(t *testing.T) {
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ledger := &fakeLedger{records: []Record{{Version: 1, Name: "first", AppliedAt: appliedAt, Changes: 7}}}
+	runner, _ := newTestRunner(t, ledger,
+		backfillMigration(1, "first", CollectionAPIKeys),
+		backfillMigration(2, "second", CollectionExecutions),
+	)
+
+	statuses, err := runner.Status(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, 7, statuses[0].Record.Changes)
+	assert.Equal(t, appliedAt, statuses[0].Record.AppliedAt)
+	assert.False(t, statuses[1].Applied)
+	assert.Nil(t, statuses[1].Record)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
@@ -17,9 +18,13 @@ type UserRepository interface {
 	// Returns an error if the user already exists or if the operation fails.
 	CreateUser(ctx context.Context, user *api.User, apiKeyHash string, expiresAtUnix int64) error
 
-	// RemoveExpiration removes the expires_at field from a user record, making them permanent.
+	// RemoveExpiration removes the expires_at field and the claim expiry from a user record once they claimed
+	// their API key, making them permanent.
 	RemoveExpiration(ctx context.Context, email string) error
 
+	// DeleteUser removes a user record. Returns a not found error if the user doesn't exist.
+	DeleteUser(ctx context.Context, email string) error
+
 	// GetUserByEmail retrieves a user by their email address.
 	// Returns nil if the user doesn't exist.
 	GetUserByEmail(ctx context.Context, email string) (*api.User, error)
@@ -36,6 +41,16 @@ type UserRepository interface {
 	// Useful for audit trails.
 	RevokeUser(ctx context.Context, email string) error
 
+	// UpdateUser stores the role, revocation, provisioning, claim expiry and ownership attributes of an
+	// existing user, by email.
+	UpdateUser(ctx context.Context, user *api.User) error
+
+	// UpdateUserPreferences stores the starred executions and saved list filters of an existing user, by email.
+	UpdateUserPreferences(ctx context.Context, user *api.User) error
+
+	// ReplaceAPIKeyHash replaces the API key of a user, the previous key no longer authenticating.
+	ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error
+
 	// Pending API key operations
 
 	// CreatePendingAPIKey stores a pending API key with a secret token.
@@ -51,6 +66,9 @@ type UserRepository interface {
 	// DeletePendingAPIKey removes a pending API key from the database.
 	DeletePendingAPIKey(ctx context.Context, secretToken string) error
 
+	// ListPendingAPIKeys returns all the pending API keys, the expired ones not deleted yet included.
+	ListPendingAPIKeys(ctx context.Context) ([]*api.PendingAPIKey, error)
+
 	// ListUsers returns all users in the system (excluding API key hashes for security).
 	// Used by admins to view all users and their basic information.
 	ListUsers(ctx context.Context) ([]*api.User, error)
@@ -64,6 +82,10 @@ type ExecutionRepository interface {
 	// CreateExecution stores a new execution record in the database.
 	CreateExecution(ctx context.Context, execution *api.Execution) error
 
+	// CreateExecutions stores new execution records atomically: either all of them are stored, or none
+	// when one already exists or the write fails.
+	CreateExecutions(ctx context.Context, executions []*api.Execution) error
+
 	// GetExecution retrieves an execution by its execution ID.
 	GetExecution(ctx context.Context, executionID string) (*api.Execution, error)
 
@@ -78,8 +100,53 @@ type ExecutionRepository interface {
 	// Results are ordered newest first.
 	ListExecutions(ctx context.Context, limit int, statuses []string) ([]*api.Execution, error)
 
+	// ListExecutionsByCreator returns the executions created by the user email, with the same filtering,
+	// limit and ordering as ListExecutions.
+	ListExecutionsByCreator(
+		ctx context.Context, createdBy string, limit int, statuses []string,
+	) ([]*api.Execution, error)
+
+	// SearchExecutions returns the executions whose search text, see ExecutionSearchText, contains all the
+	// lowercased terms, with the same filtering, limit and ordering as ListExecutions. The search is run by
+	// the database on the denormalized search text stored with each execution.
+	SearchExecutions(ctx context.Context, terms []string, limit int, statuses []string) ([]*api.Execution, error)
+
 	// GetExecutionsByRequestID retrieves all executions created or modified by a specific request ID.
 	GetExecutionsByRequestID(ctx context.Context, requestID string) ([]*api.Execution, error)
+
+	// GetExecutionsByGroupID retrieves all executions started as the shards of a parallel run.
+	GetExecutionsByGroupID(ctx context.Context, groupID string) ([]*api.Execution, error)
+
+	// ListExecutionsWithExpiredLogs returns the completed executions requesting a log retention that expired
+	// before the time and whose logs expiry was not cleared yet, up to limit, 0 returning them all.
+	ListExecutionsWithExpiredLogs(ctx context.Context, before time.Time, limit int) ([]*api.Execution, error)
+
+	// ClearExecutionLogsExpiry records that the logs of an execution whose log retention expired were deleted,
+	// leaving it out of ListExecutionsWithExpiredLogs. Returns a not found error if the execution does not exist.
+	ClearExecutionLogsExpiry(ctx context.Context, executionID string) error
+
+	// AddLogUsage atomically adds usage to the log usage recorded on an execution.
+	AddLogUsage(ctx context.Context, executionID string, usage *api.LogUsage) error
+
+	// MarkExecutionSLOBreached flags an execution as having run longer than its expected max duration,
+	// leaving its other attributes untouched. Returns a not found error if the execution does not exist.
+	MarkExecutionSLOBreached(ctx context.Context, executionID string) error
+
+	// SetExecutionResult stores the JSON result file reported by an execution, replacing any previous one.
+	// Returns a not found error if the execution does not exist.
+	SetExecutionResult(ctx context.Context, executionID string, result json.RawMessage) error
+
+	// AddExecutionEvents records lifecycle events on an execution.
+	// Events whose type is already recorded are ignored, so the first occurrence of each step is kept
+	// and the same event may be delivered several times.
+	AddExecutionEvents(ctx context.Context, executionID string, events []api.ExecutionEvent) error
+
+	// ListExecutionEvents returns the lifecycle events recorded on an execution, oldest first.
+	ListExecutionEvents(ctx context.Context, executionID string) ([]api.ExecutionEvent, error)
+
+	// AddExecutionAnnotation appends a note to the annotations of an execution.
+	// Returns a not found error if the execution does not exist.
+	AddExecutionAnnotation(ctx context.Context, executionID string, annotation *api.ExecutionAnnotation) error
 }
 
 // ConnectionRepository defines the interface for WebSocket connection-related database operations.
@@ -94,16 +161,20 @@ type ConnectionRepository interface {
 	// Returns the complete connection objects including token and other metadata.
 	GetConnectionsByExecutionID(ctx context.Context, executionID string) ([]*api.WebSocketConnection, error)
 
-	// UpdateLastEventID stores the last delivered log event identifier for a connection.
-	UpdateLastEventID(ctx context.Context, connectionID, lastEventID string) error
+	// UpdateLastEventID stores the last log event delivered to a connection, along with its sequence.
+	// The progress of a connection never moves backwards: the update is ignored when a later sequence
+	// was stored already by a concurrent delivery, or when the connection was deleted.
+	UpdateLastEventID(ctx context.Context, connectionID, lastEventID string, lastSequence int64) error
 }
 
 // LogEventRepository defines the interface for storing and deleting execution log events.
 type LogEventRepository interface {
-	// SaveLogEvents stores new log events for an execution.
+	// SaveLogEvents stores a batch of new log events for an execution, numbering them after the events
+	// of the batches saved before. Saving a batch again, as when its delivery is retried, keeps its numbers.
 	SaveLogEvents(ctx context.Context, executionID string, logEvents []api.LogEvent) error
 
-	// ListLogEvents retrieves all buffered log events for an execution ordered by timestamp and event ID.
+	// ListLogEvents retrieves the buffered log events of an execution ready to be delivered, ordered by
+	// sequence. The events of a batch still being saved, and those after it, are left out until it is saved.
 	ListLogEvents(ctx context.Context, executionID string) ([]api.LogEvent, error)
 
 	// DeleteLogEvents schedules removal of all log events for an execution. This is typically invoked when
@@ -111,17 +182,27 @@ type LogEventRepository interface {
 	DeleteLogEvents(ctx context.Context, executionID string) error
 }
 
-// TokenRepository defines the interface for WebSocket token validation operations.
+// TokenRepository defines the interface for WebSocket token validation and run token operations.
 type TokenRepository interface {
 	// CreateToken stores a new WebSocket authentication token with metadata.
 	CreateToken(ctx context.Context, token *api.WebSocketToken) error
 
-	// GetToken retrieves a token by its value and validates it hasn't expired.
-	// Returns nil if the token doesn't exist or has expired (DynamoDB TTL handles expiration).
-	GetToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error)
+	// ClaimToken atomically removes a token and returns it, so each token authenticates a single connection.
+	// Returns nil if the token doesn't exist, has expired or was already claimed.
+	ClaimToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error)
 
-	// DeleteToken removes a token from the database (used after validation or explicit cleanup).
+	// DeleteToken removes a token from the database (used for explicit cleanup).
 	DeleteToken(ctx context.Context, tokenValue string) error
+
+	// DeleteTokensByExecutionID revokes the unclaimed tokens of an execution, once it completed.
+	// Returns the number of tokens deleted.
+	DeleteTokensByExecutionID(ctx context.Context, executionID string) (int, error)
+
+	// CreateRunToken stores a run token, keyed by its hash.
+	CreateRunToken(ctx context.Context, token *api.RunToken) error
+
+	// GetRunToken returns the run token with the hash, or nil if it doesn't exist or has expired.
+	GetRunToken(ctx context.Context, tokenHash string) (*api.RunToken, error)
 }
 
 // ImageRepository defines the interface for image metadata storage operations.
@@ -130,6 +211,78 @@ type ImageRepository interface {
 	GetImagesByRequestID(ctx context.Context, requestID string) ([]api.ImageInfo, error)
 }
 
+// HealthReportRepository defines the interface for storing health reconciliation reports.
+type HealthReportRepository interface {
+	// CreateHealthReport stores the report of a health reconciliation run.
+	CreateHealthReport(ctx context.Context, report *api.HealthReport) error
+
+	// ListHealthReports returns up to limit stored reports, most recent first.
+	ListHealthReports(ctx context.Context, limit int) ([]api.HealthReport, error)
+}
+
+// CommandPolicyRepository defines the interface for storing the command policy rules.
+type CommandPolicyRepository interface {
+	// PutCommandPolicyRule creates or replaces a rule, by name.
+	PutCommandPolicyRule(ctx context.Context, rule *api.CommandPolicyRule) error
+
+	// GetCommandPolicyRule retrieves a rule by name. Returns nil if the rule doesn't exist.
+	GetCommandPolicyRule(ctx context.Context, name string) (*api.CommandPolicyRule, error)
+
+	// ListCommandPolicyRules returns all rules, sorted by name.
+	ListCommandPolicyRules(ctx context.Context) ([]api.CommandPolicyRule, error)
+
+	// DeleteCommandPolicyRule removes a rule. Returns a not found error if the rule doesn't exist.
+	DeleteCommandPolicyRule(ctx context.Context, name string) error
+}
+
+// LockRepository defines the interface for storing the named locks held by executions.
+type LockRepository interface {
+	// AcquireLock stores the lock unless another lock of the same name is held and not expired at now.
+	// Returns false when the lock is held.
+	AcquireLock(ctx context.Context, lock *api.ExecutionLock, now time.Time) (bool, error)
+
+	// GetLock retrieves a lock by name, expired or not. Returns nil if the lock doesn't exist.
+	GetLock(ctx context.Context, name string) (*api.ExecutionLock, error)
+
+	// ListLocks returns the locks not expired at now, sorted by name.
+	ListLocks(ctx context.Context, now time.Time) ([]api.ExecutionLock, error)
+
+	// SetLockExecution records the execution holding the lock acquired with token.
+	// Returns a not found error if the lock is no longer held with the token.
+	SetLockExecution(ctx context.Context, name, token, executionID string) error
+
+	// ReleaseLock removes a lock held with holder, either the token it was acquired with or the ID of its
+	// execution, or any lock of that name when holder is empty. Returns a not found error otherwise.
+	ReleaseLock(ctx context.Context, name, holder string) error
+}
+
+// ConfigRepository defines the interface for storing the backend settings changed at runtime by the admins.
+type ConfigRepository interface {
+	// GetRunFreeze retrieves the run freeze switch. Returns nil if it was never set.
+	GetRunFreeze(ctx context.Context) (*api.RunFreeze, error)
+
+	// PutRunFreeze stores the run freeze switch.
+	PutRunFreeze(ctx context.Context, freeze *api.RunFreeze) error
+
+	// GetAnnouncement retrieves the announcement. Returns nil if it was never set.
+	GetAnnouncement(ctx context.Context) (*api.Announcement, error)
+
+	// PutAnnouncement stores the announcement, an empty message clearing it.
+	PutAnnouncement(ctx context.Context, announcement *api.Announcement) error
+
+	// GetBudgets retrieves the budgets by user email. Returns an empty map if none was set.
+	GetBudgets(ctx context.Context) (map[string]api.Budget, error)
+
+	// PutBudgets stores the budgets by user email, replacing all of them.
+	PutBudgets(ctx context.Context, budgets map[string]api.Budget) error
+
+	// GetNotificationSettings retrieves the notification settings. Returns the zero settings if none were set.
+	GetNotificationSettings(ctx context.Context) (*api.NotificationSettings, error)
+
+	// PutNotificationSettings stores the notification settings, replacing them.
+	PutNotificationSettings(ctx context.Context, settings *api.NotificationSettings) error
+}
+
 // Repositories groups all database repository interfaces together.
 // This struct is used to pass repositories as a cohesive unit while maintaining
 // explicit access to individual repositories in service methods.
@@ -141,4 +294,16 @@ type Repositories struct {
 	Token      TokenRepository
 	Image      ImageRepository
 	Secrets    SecretsRepository
+
+	// HealthReport is optional; health reports are not persisted when it is nil.
+	HealthReport HealthReportRepository
+
+	// CommandPolicy is optional; no command policy is enforced when it is nil.
+	CommandPolicy CommandPolicyRepository
+
+	// Config is optional; runs are never frozen and there is no announcement when it is nil.
+	Config ConfigRepository
+
+	// Lock is optional; executions cannot be started with a lock when it is nil.
+	Lock LockRepository
 }
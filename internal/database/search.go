@@ -0,0 +1,39 @@
+package database
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// ExecutionSearchText returns the search text of an execution: its command, image, image alias and playbook,
+// lowercased and one per line. Repositories store it with the execution, denormalized, so that searches are
+// run by the database rather than on the listed pages.
+func ExecutionSearchText(command, imageID, imageAlias, playbook string) string {
+	var fields []string
+	for _, field := range []string{command, imageID, imageAlias, playbook} {
+		if field != "" {
+			fields = append(fields, strings.ToLower(field))
+		}
+	}
+	return strings.Join(fields, "\n")
+}
+
+// ExecutionSearchTerms splits a search into its lowercased whitespace-separated terms, without duplicates.
+func ExecutionSearchTerms(search string) []string {
+	terms := strings.Fields(strings.ToLower(search))
+	slices.Sort(terms)
+	return slices.Compact(terms)
+}
+
+// MatchesExecutionSearch reports whether the search text of the execution contains all the terms.
+func MatchesExecutionSearch(execution *api.Execution, terms []string) bool {
+	text := ExecutionSearchText(execution.Command, execution.ImageID, execution.ImageAlias, execution.Playbook)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
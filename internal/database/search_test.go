@@ -0,0 +1,28 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionSearchText(t *testing.T) {
+	assert.Equal(t, "pytest -k smoke\npython:3.12-a1b2c3d4\npython:stable",
+		ExecutionSearchText("pytest -k Smoke", "python:3.12-a1b2c3d4", "Python:stable", ""))
+}
+
+func TestExecutionSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"pytest", "smoke"}, ExecutionSearchTerms("  Smoke pytest\tsmoke "))
+	assert.Empty(t, ExecutionSearchTerms(" "))
+}
+
+func TestMatchesExecutionSearch(t *testing.T) {
+	execution := &api.Execution{Command: "pytest -k smoke", ImageID: "python:3.12", Playbook: "nightly-tests"}
+
+	assert.True(t, MatchesExecutionSearch(execution, []string{"pytest", "python"}))
+	assert.True(t, MatchesExecutionSearch(execution, []string{"nightly"}), "the playbook is searched")
+	assert.True(t, MatchesExecutionSearch(execution, nil))
+	assert.False(t, MatchesExecutionSearch(execution, []string{"pytest", "node"}), "all terms must match")
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
@@ -44,4 +45,8 @@ type SecretsRepository interface {
 
 	// GetSecretsByRequestID retrieves all secrets created or modified by a specific request ID.
 	GetSecretsByRequestID(ctx context.Context, requestID string) ([]*api.Secret, error)
+
+	// RecordSecretUsage records that an execution was started with the secret at usedAt.
+	// Returns an error if the secret is not found.
+	RecordSecretUsage(ctx context.Context, name, executionID string, usedAt time.Time) error
 }
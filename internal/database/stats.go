@@ -0,0 +1,93 @@
+package database
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// QueryStats aggregates the latency and item counts of the database queries by access pattern.
+// Repository implementations record each query they run through it. It is safe for concurrent use.
+type QueryStats struct {
+	mu            sync.Mutex
+	since         time.Time
+	slowThreshold time.Duration
+	patterns      map[string]*patternStats
+}
+
+type patternStats struct {
+	count, errors, slow, items int64
+	total, max                 time.Duration
+}
+
+// NewQueryStats creates a QueryStats counting the queries taking longer than slowThreshold as slow.
+func NewQueryStats(slowThreshold time.Duration) *QueryStats {
+	return &QueryStats{
+		since:         time.Now().UTC(),
+		slowThreshold: slowThreshold,
+		patterns:      make(map[string]*patternStats),
+	}
+}
+
+// Record adds a query of the access pattern that took duration and read or wrote items,
+// and reports whether it was slow.
+func (s *QueryStats) Record(pattern string, duration time.Duration, items int, err error) (slow bool) {
+	slow = s.slowThreshold > 0 && duration > s.slowThreshold
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.patterns[pattern]
+	if !ok {
+		stats = &patternStats{}
+		s.patterns[pattern] = stats
+	}
+	stats.count++
+	stats.items += int64(items)
+	stats.total += duration
+	stats.max = max(stats.max, duration)
+	if err != nil {
+		stats.errors++
+	}
+	if slow {
+		stats.slow++
+	}
+	return slow
+}
+
+// Snapshot returns the aggregated queries, the access patterns taking the most time overall first.
+func (s *QueryStats) Snapshot() *api.DatabaseStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]api.QueryPatternStats, 0, len(s.patterns))
+	totals := make(map[string]time.Duration, len(s.patterns))
+	for pattern, stats := range s.patterns {
+		totals[pattern] = stats.total
+		queries = append(queries, api.QueryPatternStats{
+			Pattern:       pattern,
+			Count:         stats.count,
+			Errors:        stats.errors,
+			Slow:          stats.slow,
+			Items:         stats.items,
+			AvgDurationMs: milliseconds(stats.total) / float64(stats.count),
+			MaxDurationMs: milliseconds(stats.max),
+		})
+	}
+	slices.SortFunc(queries, func(a, b api.QueryPatternStats) int {
+		return cmp.Or(cmp.Compare(totals[b.Pattern], totals[a.Pattern]), cmp.Compare(a.Pattern, b.Pattern))
+	})
+
+	return &api.DatabaseStats{
+		Since:                s.since,
+		SlowQueryThresholdMs: s.slowThreshold.Milliseconds(),
+		Queries:              queries,
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
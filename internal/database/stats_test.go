@@ -0,0 +1,56 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStats(t *testing.T) {
+	stats := NewQueryStats(100 * time.Millisecond)
+
+	assert.False(t, stats.Record("GetItem runvoy-api-keys", 10*time.Millisecond, 1, nil))
+	assert.True(t, stats.Record("Query runvoy-executions/all-started_at", 150*time.Millisecond, 40, nil))
+	assert.False(t, stats.Record("Query runvoy-executions/all-started_at", 50*time.Millisecond, 10, errors.New("boom")))
+
+	snapshot := stats.Snapshot()
+
+	assert.Equal(t, int64(100), snapshot.SlowQueryThresholdMs)
+	require.Len(t, snapshot.Queries, 2)
+	query := snapshot.Queries[0]
+	assert.Equal(t, "Query runvoy-executions/all-started_at", query.Pattern)
+	assert.Equal(t, int64(2), query.Count)
+	assert.Equal(t, int64(1), query.Errors)
+	assert.Equal(t, int64(1), query.Slow)
+	assert.Equal(t, int64(50), query.Items)
+	assert.InDelta(t, 100, query.AvgDurationMs, 1e-9)
+	assert.InDelta(t, 150, query.MaxDurationMs, 1e-9)
+	assert.Equal(t, "GetItem runvoy-api-keys", snapshot.Queries[1].Pattern)
+}
+
+func TestQueryStats_NoThreshold(t *testing.T) {
+	stats := NewQueryStats(0)
+
+	assert.False(t, stats.Record("GetItem runvoy-api-keys", time.Hour, 1, nil))
+	assert.Zero(t, stats.Snapshot().Queries[0].Slow)
+}
+
+func TestQueryStats_Concurrent(t *testing.T) {
+	stats := NewQueryStats(time.Second)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			for range 100 {
+				stats.Record("PutItem runvoy-execution-logs", time.Millisecond, 1, nil)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1000), stats.Snapshot().Queries[0].Count)
+}
@@ -0,0 +1,4 @@
+// Package e2e runs the CLI end to end against the orchestrator HTTP server, started in-process
+// on the in-memory backend of the fake provider, to catch provider-neutral regressions
+// without cloud accounts. The tests build the CLI once and are skipped with -short.
+package e2e
@@ -0,0 +1,212 @@
+package e2e
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+func TestImages(t *testing.T) {
+	h := newHarness(t)
+
+	result := h.runCLI("images", "register", "alpine:latest")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/images/register", http.StatusCreated)
+
+	result = h.runCLI("images", "list")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "alpine:latest")
+	exchanges := h.requireExchange(http.MethodGet, "/api/v1/images", http.StatusOK)
+	assert.Equal(t, "application/json", exchanges[0].ContentType)
+
+	result = h.runCLI("images", "unregister", "alpine:latest")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodDelete, "/api/v1/images/alpine:latest", http.StatusOK)
+
+	result = h.runCLI("images", "show", "alpine:latest")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "Deleted At")
+	h.requireExchange(http.MethodGet, "/api/v1/images/alpine:latest", http.StatusOK)
+
+	result = h.runCLI("images", "restore", "alpine:latest")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/images/restore", http.StatusOK)
+
+	result = h.runCLI("images", "unregister", "alpine:latest", "--purge")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodDelete, "/api/v1/admin/images/alpine:latest", http.StatusOK)
+
+	result = h.runCLI("images", "show", "alpine:latest")
+	assert.Contains(t, result.Stderr, "image not found")
+	h.requireExchange(http.MethodGet, "/api/v1/images/alpine:latest", http.StatusNotFound)
+}
+
+func TestImageAliases(t *testing.T) {
+	h := newHarness(t)
+	require.Zero(t, h.runCLI("images", "register", "alpine:latest").ExitCode)
+	h.takeExchanges()
+
+	result := h.runCLI("images", "alias", "set", "alpine:stable", "alpine:latest")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/images/aliases", http.StatusOK)
+
+	result = h.runCLI("images", "alias", "list")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "alpine:stable")
+	h.requireExchange(http.MethodGet, "/api/v1/images/aliases", http.StatusOK)
+
+	result = h.runCLI("run", "--image", "alpine:stable", "echo aliased")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/run", http.StatusAccepted)
+
+	executionID := h.latestExecutionID()
+	h.waitForStatus(executionID, constants.ExecutionSucceeded)
+	result = h.runCLI("status", executionID)
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "alpine:stable")
+	h.takeExchanges()
+
+	result = h.runCLI("images", "alias", "remove", "alpine:stable")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodDelete, "/api/v1/images/aliases/alpine:stable", http.StatusOK)
+}
+
+func TestRunAndLogs(t *testing.T) {
+	h := newHarness(t)
+	require.Zero(t, h.runCLI("images", "register", "alpine:latest").ExitCode)
+	h.takeExchanges()
+
+	result := h.runCLI("run", "echo hello from e2e; echo second line")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "hello from e2e")
+	assert.Contains(t, result.Output(), "second line")
+	h.requireExchange(http.MethodPost, "/api/v1/run", http.StatusAccepted)
+
+	executionID := h.latestExecutionID()
+	h.waitForStatus(executionID, constants.ExecutionSucceeded)
+
+	result = h.runCLI("logs", executionID)
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "hello from e2e")
+	assert.Contains(t, result.Output(), string(constants.ExecutionSucceeded))
+	h.requireExchange(http.MethodGet, "/api/v1/executions/"+executionID+"/logs", http.StatusOK)
+
+	result = h.runCLI("status", executionID)
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), string(constants.ExecutionSucceeded))
+	h.requireExchange(http.MethodGet, "/api/v1/executions/"+executionID+"/status", http.StatusOK)
+
+	result = h.runCLI("run", "exit 3")
+	require.Zero(t, result.ExitCode, result.Output())
+	failedID := h.latestExecutionID()
+	h.waitForStatus(failedID, constants.ExecutionFailed)
+
+	result = h.runCLI("list")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), executionID)
+	assert.Contains(t, result.Output(), failedID)
+}
+
+func TestLogsFollowAndKill(t *testing.T) {
+	h := newHarness(t)
+	require.Zero(t, h.runCLI("images", "register", "alpine:latest").ExitCode)
+
+	runDone := make(chan cliResult, 1)
+	go func() { runDone <- h.runCLI("run", "echo started; sleep") }()
+
+	var executionID string
+	require.Eventually(t, func() bool {
+		executionID = h.latestExecutionID()
+		return executionID != ""
+	}, requestTimeout, pollInterval)
+	h.waitForStatus(executionID, constants.ExecutionRunning)
+
+	followDone := make(chan cliResult, 1)
+	go func() { followDone <- h.runCLI("logs", executionID) }()
+	require.Eventually(t, func() bool { return h.provider.WebSocket.StreamsOpened() == 2 }, requestTimeout, pollInterval,
+		"both run and logs should follow the execution")
+	h.takeExchanges()
+
+	result := h.runCLI("kill", executionID)
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodDelete, "/api/v1/executions/"+executionID, http.StatusOK)
+	h.waitForStatus(executionID, constants.ExecutionStopped)
+
+	for _, done := range []chan cliResult{runDone, followDone} {
+		result = <-done
+		require.Zero(t, result.ExitCode, result.Output())
+		assert.Contains(t, result.Output(), "started")
+		assert.Contains(t, result.Output(), "Execution completed")
+	}
+
+	result = h.runCLI("kill", executionID)
+	assert.Contains(t, result.Output(), executionID)
+	assert.NotContains(t, strings.ToLower(result.Stderr), "failed to kill task")
+}
+
+func TestSecrets(t *testing.T) {
+	h := newHarness(t)
+	require.Zero(t, h.runCLI("images", "register", "alpine:latest").ExitCode)
+	h.takeExchanges()
+
+	result := h.runCLI("secrets", "create", "api-token", "API_TOKEN", "s3cr3t-value")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/secrets", http.StatusCreated)
+
+	result = h.runCLI("secrets", "list")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "api-token")
+	assert.NotContains(t, result.Output(), "s3cr3t-value", "secret values are not listed")
+
+	result = h.runCLI("secrets", "get", "api-token")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "API_TOKEN")
+	h.requireExchange(http.MethodGet, "/api/v1/secrets/api-token", http.StatusOK)
+
+	result = h.runCLI("run", "--secret", "api-token", "echo token=$API_TOKEN")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "token=s3cr3t-value")
+
+	result = h.runCLI("secrets", "delete", "api-token")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodDelete, "/api/v1/secrets/api-token", http.StatusOK)
+
+	result = h.runCLI("secrets", "get", "api-token")
+	assert.NotEmpty(t, result.Stderr)
+	h.requireExchange(http.MethodGet, "/api/v1/secrets/api-token", http.StatusNotFound)
+}
+
+func TestUsers(t *testing.T) {
+	h := newHarness(t)
+
+	result := h.runCLI("users", "create", "dev@example.com", "--role", "developer")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/users/create", http.StatusCreated)
+
+	result = h.runCLI("users", "list")
+	require.Zero(t, result.ExitCode, result.Output())
+	assert.Contains(t, result.Output(), "dev@example.com")
+	assert.Contains(t, result.Output(), adminEmail)
+
+	result = h.runCLI("users", "revoke", "dev@example.com")
+	require.Zero(t, result.ExitCode, result.Output())
+	h.requireExchange(http.MethodPost, "/api/v1/users/revoke", http.StatusOK)
+
+	result = h.runCLI("users", "create", "dev@example.com", "--role", "developer")
+	assert.NotEmpty(t, result.Stderr)
+	h.requireExchange(http.MethodPost, "/api/v1/users/create", http.StatusConflict)
+}
+
+func TestUnauthenticated(t *testing.T) {
+	h := newHarness(t)
+	require.NoError(t, h.writeConfig("not-a-valid-key"))
+
+	result := h.runCLI("list")
+	assert.NotEmpty(t, result.Stderr)
+	h.requireExchange(http.MethodGet, "/api/v1/executions", http.StatusUnauthorized)
+}
@@ -0,0 +1,232 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/server"
+)
+
+const (
+	adminEmail     = "admin@example.com"
+	requestTimeout = 10 * time.Second
+	pollInterval   = 20 * time.Millisecond
+	cliTimeout     = "30s"
+)
+
+// cliBinary is the CLI built once for all the tests.
+var cliBinary string
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if testing.Short() {
+		os.Exit(m.Run())
+	}
+
+	dir, err := os.MkdirTemp("", "runvoy-e2e-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to create the CLI build directory:", err)
+		os.Exit(1)
+	}
+	cliBinary = filepath.Join(dir, constants.ProjectName)
+	build := exec.Command("go", "build", "-o", cliBinary, "github.com/runvoy/runvoy/cmd/cli")
+	build.Stdout, build.Stderr = os.Stderr, os.Stderr
+	if err = build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to build the CLI:", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// exchange is an HTTP request served by the orchestrator, as seen on the wire.
+type exchange struct {
+	Method      string
+	Path        string
+	Status      int
+	ContentType string
+}
+
+// harness runs the orchestrator HTTP server in-process on the fake backend and
+// the CLI against it, with the configuration of the seeded admin user.
+type harness struct {
+	t         *testing.T
+	configDir string
+	apiURL    string
+	provider  *fake.Provider
+
+	mu        sync.Mutex
+	exchanges []exchange
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("end-to-end tests build and run the CLI")
+	}
+
+	provider, err := fake.New(fake.ScriptRunner)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	h := &harness{t: t, configDir: t.TempDir(), provider: provider}
+
+	apiKey, err := auth.GenerateSecretToken()
+	require.NoError(t, err)
+	require.NoError(t, provider.CreateAdmin(context.Background(), adminEmail, apiKey))
+	deps := &orchestrator.ProviderDependencies{
+		Repositories:         provider.Repositories(),
+		TaskManager:          provider.Tasks,
+		ImageRegistry:        provider.Images,
+		LogManager:           provider.Logs,
+		ObservabilityManager: provider.Observability,
+		WebSocketManager:     provider.WebSocket,
+		HealthManager:        provider.Health,
+	}
+
+	svc, err := orchestrator.Initialize(
+		context.Background(),
+		&config.Config{BackendProvider: constants.Fake, InitTimeout: requestTimeout},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		orchestrator.WithProviderInitializer(func(
+			context.Context, *config.Config, *slog.Logger, *authorization.Enforcer,
+		) (*orchestrator.ProviderDependencies, error) {
+			return deps, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	apiServer := httptest.NewServer(h.record(server.NewRouter(svc, requestTimeout, nil).Handler()))
+	t.Cleanup(apiServer.Close)
+	h.apiURL = apiServer.URL
+
+	require.NoError(t, h.writeConfig(apiKey))
+	return h
+}
+
+// writeConfig writes the CLI configuration with the harness server endpoint and the API key.
+func (h *harness) writeConfig(apiKey string) error {
+	return os.WriteFile(filepath.Join(h.configDir, constants.ConfigFileName),
+		fmt.Appendf(nil, "api_endpoint: %s\napi_key: %s\n", h.apiURL, apiKey),
+		constants.ConfigFilePermissions)
+}
+
+// record keeps the method, path and response status of every request served by next.
+func (h *harness) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.exchanges = append(h.exchanges, exchange{
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Status:      rw.status,
+			ContentType: rw.Header().Get("Content-Type"),
+		})
+	})
+}
+
+// takeExchanges returns the requests served since the previous call.
+func (h *harness) takeExchanges() []exchange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	exchanges := h.exchanges
+	h.exchanges = nil
+	return exchanges
+}
+
+// requireExchange fails the test unless a request with that method and path was served with that status
+// since the previous call, and returns the requests served in the meantime.
+func (h *harness) requireExchange(method, path string, status int) []exchange {
+	h.t.Helper()
+	exchanges := h.takeExchanges()
+	require.True(h.t, slices.ContainsFunc(exchanges, func(e exchange) bool {
+		return e.Method == method && e.Path == path && e.Status == status
+	}), "expected %s %s answered with %d, got %+v", method, path, status, exchanges)
+	return exchanges
+}
+
+// cliResult is the outcome of a CLI invocation.
+type cliResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Output returns everything the CLI printed.
+func (r cliResult) Output() string {
+	return r.Stdout + r.Stderr
+}
+
+// runCLI runs the CLI with the arguments against the harness server.
+func (h *harness) runCLI(args ...string) cliResult {
+	h.t.Helper()
+	cmd := exec.Command(cliBinary, append([]string{"--timeout", cliTimeout}, args...)...)
+	cmd.Env = append(os.Environ(), constants.ConfigDirEnvVar+"="+h.configDir, "NO_COLOR=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	result := cliResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		require.ErrorAs(h.t, err, &exitErr, "failed to run the CLI")
+		result.ExitCode = exitErr.ExitCode()
+	}
+	result.Stdout, result.Stderr = stdout.String(), stderr.String()
+	return result
+}
+
+// waitForStatus waits for the execution to reach the status.
+func (h *harness) waitForStatus(executionID string, status constants.ExecutionStatus) {
+	h.t.Helper()
+	require.Eventually(h.t, func() bool {
+		execution, _ := h.provider.Executions.GetExecution(context.Background(), executionID)
+		return execution != nil && execution.Status == string(status)
+	}, requestTimeout, pollInterval, "execution %s never reached %s", executionID, status)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// latestExecutionID returns the ID of the most recently started execution, empty when there is none.
+func (h *harness) latestExecutionID() string {
+	executions, _ := h.provider.Executions.ListExecutions(context.Background(), 1, nil)
+	if len(executions) == 0 {
+		return ""
+	}
+	return executions[0].ExecutionID
+}
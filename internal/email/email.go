@@ -0,0 +1,103 @@
+// Package email sends the emails of the backend through an SMTP server, such as the SES SMTP interface.
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPSender implements the contract.EmailSender interface with an SMTP server. The connection is upgraded
+// with STARTTLS when the server supports it, which the credentials are only sent over, unless to localhost.
+type SMTPSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	now      func() time.Time
+}
+
+// NewSMTPSender creates an SMTPSender sending from the address through the server at addr, host:port.
+// The username and password authenticate to the server when set.
+func NewSMTPSender(addr, username, password, from string) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+	return &SMTPSender{
+		addr:     addr,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		now:      time.Now,
+	}, nil
+}
+
+// SendEmail sends a plain text email to the recipients, in a single message they are all addressed in.
+func (s *SMTPSender) SendEmail(ctx context.Context, to []string, subject, body string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to greet SMTP server: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate to SMTP server: %w", err)
+		}
+	}
+	if err = client.Mail(s.from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, recipient := range to {
+		if err = client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err = writer.Write(s.message(to, subject, body)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return client.Quit()
+}
+
+// message returns the headers and the body of the email, with CRLF line endings.
+func (s *SMTPSender) message(to []string, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + s.now().UTC().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
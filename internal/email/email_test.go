@@ -0,0 +1,97 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single SMTP session on a local port, recording its commands and message.
+type fakeSMTPServer struct {
+	listener net.Listener
+	commands []string
+	message  string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { _ = listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ready")
+	for {
+		line, readErr := text.ReadLine()
+		if readErr != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+		switch verb, _, _ := strings.Cut(line, " "); strings.ToUpper(verb) {
+		case "EHLO":
+			_ = text.PrintfLine("250 localhost")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			data, _ := text.ReadDotLines()
+			s.message = strings.Join(data, "\n")
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSMTPSender_SendEmail(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	sender, err := NewSMTPSender(server.listener.Addr().String(), "", "", "runvoy@example.com")
+	require.NoError(t, err)
+	sender.now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+
+	err = sender.SendEmail(context.Background(), []string{"alice@example.com", "bob@example.com"},
+		"runvoy usage report", "line one\nline two\n")
+
+	require.NoError(t, err)
+	<-server.done
+	assert.Equal(t, []string{
+		"MAIL FROM:<runvoy@example.com>",
+		"RCPT TO:<alice@example.com>",
+		"RCPT TO:<bob@example.com>",
+		"DATA",
+		"QUIT",
+	}, server.commands[1:])
+	message := bufio.NewReader(strings.NewReader(server.message))
+	headers, err := textproto.NewReader(message).ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com, bob@example.com", headers.Get("To"))
+	assert.Equal(t, "runvoy usage report", headers.Get("Subject"))
+	assert.Equal(t, "Mon, 19 Oct 2026 08:00:00 +0000", headers.Get("Date"))
+	assert.Contains(t, server.message, "line one\nline two")
+}
+
+func TestNewSMTPSender_InvalidAddress(t *testing.T) {
+	_, err := NewSMTPSender("smtp.example.com", "", "", "runvoy@example.com")
+
+	assert.ErrorContains(t, err, `invalid SMTP address "smtp.example.com"`)
+}
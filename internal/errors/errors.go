@@ -44,20 +44,36 @@ func (e *AppError) Is(target error) bool {
 // Predefined error codes.
 const (
 	// Client error codes.
-	ErrCodeInvalidRequest = "INVALID_REQUEST"
-	ErrCodeUnauthorized   = "UNAUTHORIZED"
-	ErrCodeForbidden      = "FORBIDDEN"
-	ErrCodeNotFound       = "NOT_FOUND"
-	ErrCodeConflict       = "CONFLICT"
-	ErrCodeSecretNotFound = "SECRET_NOT_FOUND"
-	ErrCodeSecretExists   = "SECRET_ALREADY_EXISTS"
-	ErrCodeInvalidAPIKey  = "INVALID_API_KEY" //nolint:gosec // this is not an API key, it's a request error code
-	ErrCodeAPIKeyRevoked  = "API_KEY_REVOKED" //nolint:gosec // this is not an API key, it's a request error code
+	ErrCodeInvalidRequest             = "INVALID_REQUEST"
+	ErrCodeUnauthorized               = "UNAUTHORIZED"
+	ErrCodeForbidden                  = "FORBIDDEN"
+	ErrCodeNotFound                   = "NOT_FOUND"
+	ErrCodeConflict                   = "CONFLICT"
+	ErrCodeConcurrentModification     = "CONCURRENT_MODIFICATION"
+	ErrCodeSecretNotFound             = "SECRET_NOT_FOUND"
+	ErrCodeSecretExists               = "SECRET_ALREADY_EXISTS"
+	ErrCodeImageDeleted               = "IMAGE_DELETED"
+	ErrCodeImageNotRegistered         = "IMAGE_NOT_REGISTERED"
+	ErrCodeImageRegistrationForbidden = "IMAGE_REGISTRATION_FORBIDDEN"
+	ErrCodeCommandDenied              = "COMMAND_DENIED"
+	ErrCodeInvalidAPIKey              = "INVALID_API_KEY" //nolint:gosec // this is not an API key, it's a request error code
+	ErrCodeAPIKeyRevoked              = "API_KEY_REVOKED" //nolint:gosec // this is not an API key, it's a request error code
+	ErrCodePayloadTooLarge            = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupportedContentEncoding = "UNSUPPORTED_CONTENT_ENCODING"
+	ErrCodeValidationFailed           = "VALIDATION_FAILED"
+	ErrCodeUnsupportedAPIVersion      = "UNSUPPORTED_API_VERSION"
+	ErrCodeLegacyRouteRemoved         = "LEGACY_ROUTE_REMOVED"
+	ErrCodeThrottled                  = "THROTTLED"
+	ErrCodeQuotaExceeded              = "QUOTA_EXCEEDED"
+	ErrCodeBudgetExceeded             = "BUDGET_EXCEEDED"
+	ErrCodeClientTooOld               = "CLIENT_TOO_OLD"
+	ErrCodeLockHeld                   = "LOCK_HELD"
 
 	// Server error codes.
 	ErrCodeInternalError      = "INTERNAL_ERROR"
 	ErrCodeDatabaseError      = "DATABASE_ERROR"
 	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	ErrCodeRunsFrozen         = "RUNS_FROZEN"
 )
 
 // NewClientError creates a new client error (4xx status codes).
@@ -118,11 +134,28 @@ func ErrConflict(message string, cause error) *AppError {
 	return NewClientError(http.StatusConflict, ErrCodeConflict, message, cause)
 }
 
+// ErrConcurrentModification creates a conflict error (409) for an update of a record modified since it was read.
+// The update can be retried on the current record.
+func ErrConcurrentModification(message string, cause error) *AppError {
+	return NewClientError(http.StatusConflict, ErrCodeConcurrentModification, message, cause)
+}
+
 // ErrBadRequest creates a bad request error (400).
 func ErrBadRequest(message string, cause error) *AppError {
 	return NewClientError(http.StatusBadRequest, ErrCodeInvalidRequest, message, cause)
 }
 
+// ErrPayloadTooLarge creates a payload too large error (413).
+func ErrPayloadTooLarge(message string, cause error) *AppError {
+	return NewClientError(http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge, message, cause)
+}
+
+// ErrValidationFailed creates a validation failed error (422) for well-formed requests
+// whose content is out of bounds.
+func ErrValidationFailed(message string, cause error) *AppError {
+	return NewClientError(http.StatusUnprocessableEntity, ErrCodeValidationFailed, message, cause)
+}
+
 // ErrSecretNotFound creates a secret not found error (404).
 func ErrSecretNotFound(message string, cause error) *AppError {
 	return NewClientError(http.StatusNotFound, ErrCodeSecretNotFound, message, cause)
@@ -133,6 +166,48 @@ func ErrSecretAlreadyExists(message string, cause error) *AppError {
 	return NewClientError(http.StatusConflict, ErrCodeSecretExists, message, cause)
 }
 
+// ErrImageDeleted creates an error for an image that was deleted and awaits its purge (400).
+func ErrImageDeleted(message string, cause error) *AppError {
+	return NewClientError(http.StatusBadRequest, ErrCodeImageDeleted, message, cause)
+}
+
+// ErrImageNotRegistered creates an error for an image that is not registered (400).
+func ErrImageNotRegistered(message string, cause error) *AppError {
+	return NewClientError(http.StatusBadRequest, ErrCodeImageNotRegistered, message, cause)
+}
+
+// ErrImageRegistrationForbidden creates an error for an unregistered image the user may not register
+// by running it (403).
+func ErrImageRegistrationForbidden(message string, cause error) *AppError {
+	return NewClientError(http.StatusForbidden, ErrCodeImageRegistrationForbidden, message, cause)
+}
+
+// ErrCommandDenied creates an error for an execution refused by the command policy (403).
+func ErrCommandDenied(message string, cause error) *AppError {
+	return NewClientError(http.StatusForbidden, ErrCodeCommandDenied, message, cause)
+}
+
+// ErrThrottled creates an error for a request throttled by the provider (429).
+func ErrThrottled(message string, cause error) *AppError {
+	return NewClientError(http.StatusTooManyRequests, ErrCodeThrottled, message, cause)
+}
+
+// ErrQuotaExceeded creates an error for a request exceeding a quota of the provider (429).
+func ErrQuotaExceeded(message string, cause error) *AppError {
+	return NewClientError(http.StatusTooManyRequests, ErrCodeQuotaExceeded, message, cause)
+}
+
+// ErrBudgetExceeded creates an error for an execution refused because the user spent their monthly budget (403).
+func ErrBudgetExceeded(message string, cause error) *AppError {
+	return NewClientError(http.StatusForbidden, ErrCodeBudgetExceeded, message, cause)
+}
+
+// ErrLockHeld creates an error for an execution refused because another execution holds its lock (409).
+// The execution can be retried once the lock is released.
+func ErrLockHeld(message string, cause error) *AppError {
+	return NewClientError(http.StatusConflict, ErrCodeLockHeld, message, cause)
+}
+
 // ErrInternalError creates an internal server error (500).
 func ErrInternalError(message string, cause error) *AppError {
 	return NewServerError(http.StatusInternalServerError, ErrCodeInternalError, message, cause)
@@ -150,6 +225,11 @@ func ErrServiceUnavailable(message string, cause error) *AppError {
 	return NewServerError(http.StatusServiceUnavailable, ErrCodeServiceUnavailable, message, cause)
 }
 
+// ErrRunsFrozen creates an error for an execution refused while an admin has frozen the runs (503).
+func ErrRunsFrozen(message string, cause error) *AppError {
+	return NewServerError(http.StatusServiceUnavailable, ErrCodeRunsFrozen, message, cause)
+}
+
 // GetStatusCode extracts the HTTP status code from an error.
 // Returns 500 if the error is not an AppError.
 func GetStatusCode(err error) int {
@@ -179,6 +259,21 @@ func GetErrorMessage(err error) string {
 	return err.Error()
 }
 
+// GetPublicErrorDetails extracts the error details that can be returned to the clients.
+// The causes of the client errors are returned, while the server errors and the provider errors only
+// return their message and the other errors a generic one, their causes being internal.
+func GetPublicErrorDetails(err error) string {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return "internal server error"
+	}
+	var provErr providerError
+	if appErr.StatusCode >= http.StatusInternalServerError || errors.As(appErr.Cause, &provErr) {
+		return appErr.Message
+	}
+	return GetErrorDetails(err)
+}
+
 // GetErrorDetails extracts detailed error information including the underlying cause.
 // Returns the underlying error message if available, otherwise returns the main error message.
 func GetErrorDetails(err error) string {
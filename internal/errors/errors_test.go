@@ -211,6 +211,13 @@ func TestErrConflict(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, err.StatusCode)
 }
 
+func TestErrConcurrentModification(t *testing.T) {
+	err := ErrConcurrentModification("execution was modified concurrently", nil)
+	assert.Equal(t, ErrCodeConcurrentModification, err.Code)
+	assert.Equal(t, "execution was modified concurrently", err.Message)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
 func TestErrBadRequest(t *testing.T) {
 	err := ErrBadRequest("invalid input", nil)
 	assert.Equal(t, ErrCodeInvalidRequest, err.Code)
@@ -218,6 +225,27 @@ func TestErrBadRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
 }
 
+func TestErrImageDeleted(t *testing.T) {
+	err := ErrImageDeleted("image was deleted", nil)
+	assert.Equal(t, ErrCodeImageDeleted, err.Code)
+	assert.Equal(t, "image was deleted", err.Message)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
+
+func TestErrPayloadTooLarge(t *testing.T) {
+	err := ErrPayloadTooLarge("request body too large", nil)
+	assert.Equal(t, ErrCodePayloadTooLarge, err.Code)
+	assert.Equal(t, "request body too large", err.Message)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, err.StatusCode)
+}
+
+func TestErrValidationFailed(t *testing.T) {
+	err := ErrValidationFailed("command too long", nil)
+	assert.Equal(t, ErrCodeValidationFailed, err.Code)
+	assert.Equal(t, "command too long", err.Message)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+}
+
 func TestErrInternalError(t *testing.T) {
 	err := ErrInternalError("internal server error", nil)
 	assert.Equal(t, ErrCodeInternalError, err.Code)
@@ -239,6 +267,27 @@ func TestErrServiceUnavailable(t *testing.T) {
 	assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
 }
 
+func TestErrRunsFrozen(t *testing.T) {
+	err := ErrRunsFrozen("runs are frozen: maintenance until 5pm", nil)
+	assert.Equal(t, ErrCodeRunsFrozen, err.Code)
+	assert.Equal(t, "runs are frozen: maintenance until 5pm", err.Message)
+	assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+}
+
+func TestErrBudgetExceeded(t *testing.T) {
+	err := ErrBudgetExceeded("monthly budget exceeded", nil)
+	assert.Equal(t, ErrCodeBudgetExceeded, err.Code)
+	assert.Equal(t, "monthly budget exceeded", err.Message)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+}
+
+func TestErrLockHeld(t *testing.T) {
+	err := ErrLockHeld("lock deploy-prod is held by execution abc123", nil)
+	assert.Equal(t, ErrCodeLockHeld, err.Code)
+	assert.Equal(t, "lock deploy-prod is held by execution abc123", err.Message)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
 func TestErrSecretNotFound(t *testing.T) {
 	err := ErrSecretNotFound("secret not found", nil)
 	assert.Equal(t, ErrCodeSecretNotFound, err.Code)
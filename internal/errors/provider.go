@@ -0,0 +1,54 @@
+package errors
+
+// ProviderErrorKind classifies the errors of the provider SDKs, for them to be reported to the clients
+// with the status code of their cause instead of as internal errors.
+type ProviderErrorKind string
+
+const (
+	// ProviderErrorUnknown is an error without a more specific kind.
+	ProviderErrorUnknown ProviderErrorKind = ""
+	// ProviderErrorNotFound is a missing item or object, not a missing resource of the backend.
+	ProviderErrorNotFound ProviderErrorKind = "not_found"
+	// ProviderErrorConflict is a failed condition or a concurrent modification.
+	ProviderErrorConflict ProviderErrorKind = "conflict"
+	// ProviderErrorThrottled is a request throttled by the provider.
+	ProviderErrorThrottled ProviderErrorKind = "throttled"
+	// ProviderErrorQuotaExceeded is a request exceeding a quota of the provider.
+	ProviderErrorQuotaExceeded ProviderErrorKind = "quota_exceeded"
+	// ProviderErrorUnavailable is a transient failure of the provider.
+	ProviderErrorUnavailable ProviderErrorKind = "unavailable"
+)
+
+// providerError is the cause of the errors created by FromProvider. Its details are internal to the
+// backend and never returned to the clients, see GetPublicErrorDetails.
+type providerError struct {
+	error
+}
+
+func (e providerError) Unwrap() error {
+	return e.error
+}
+
+// FromProvider returns the error of a kind of provider SDK error with the message. The errors of
+// unknown kind are created by fallback, e.g. ErrInternalError or ErrDatabaseError.
+func FromProvider(
+	kind ProviderErrorKind, message string, cause error, fallback func(string, error) *AppError,
+) *AppError {
+	if cause != nil {
+		cause = providerError{cause}
+	}
+	switch kind {
+	case ProviderErrorNotFound:
+		return ErrNotFound(message, cause)
+	case ProviderErrorConflict:
+		return ErrConflict(message, cause)
+	case ProviderErrorThrottled:
+		return ErrThrottled(message, cause)
+	case ProviderErrorQuotaExceeded:
+		return ErrQuotaExceeded(message, cause)
+	case ProviderErrorUnavailable:
+		return ErrServiceUnavailable(message, cause)
+	default:
+		return fallback(message, cause)
+	}
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromProvider(t *testing.T) {
+	cause := errors.New("operation error DynamoDB: PutItem, ConditionalCheckFailedException")
+	tests := []struct {
+		name       string
+		kind       ProviderErrorKind
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", ProviderErrorNotFound, http.StatusNotFound, ErrCodeNotFound},
+		{"conflict", ProviderErrorConflict, http.StatusConflict, ErrCodeConflict},
+		{"throttled", ProviderErrorThrottled, http.StatusTooManyRequests, ErrCodeThrottled},
+		{"quota exceeded", ProviderErrorQuotaExceeded, http.StatusTooManyRequests, ErrCodeQuotaExceeded},
+		{"unavailable", ProviderErrorUnavailable, http.StatusServiceUnavailable, ErrCodeServiceUnavailable},
+		{"unknown uses the fallback", ProviderErrorUnknown, http.StatusServiceUnavailable, ErrCodeDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromProvider(tt.kind, "failed to save", cause, ErrDatabaseError)
+
+			assert.Equal(t, tt.wantStatus, err.StatusCode)
+			assert.Equal(t, tt.wantCode, err.Code)
+			assert.ErrorIs(t, err, cause)
+			assert.Equal(t, "failed to save", GetPublicErrorDetails(err))
+		})
+	}
+}
+
+func TestGetPublicErrorDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "client error returns its cause",
+			err:      ErrBadRequest("invalid input", errors.New("name is required")),
+			expected: "name is required",
+		},
+		{
+			name:     "server error hides its cause",
+			err:      ErrInternalError("failed to start task", errors.New("https response error StatusCode: 400")),
+			expected: "failed to start task",
+		},
+		{
+			name:     "non-AppError is generic",
+			err:      errors.New("operation error ECS: RunTask"),
+			expected: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetPublicErrorDetails(tt.err))
+		})
+	}
+}
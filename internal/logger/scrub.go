@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RedactedValue replaces the sensitive values in the logs.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys are the normalized names of the fields and headers always holding a credential or a secret value.
+var sensitiveKeys = map[string]bool{
+	"value":         true,
+	"passphrase":    true,
+	"password":      true,
+	"bundle":        true,
+	"authorization": true,
+	"cookie":        true,
+}
+
+// sensitiveKeySuffixes end the normalized names of the fields and headers holding credentials,
+// e.g. api_key, X-API-Key, id_token or secret_token.
+var sensitiveKeySuffixes = []string{"apikey", "token", "secret", "password", "privatekey"}
+
+// envKeys are the normalized names of the objects mapping environment variable names to their values.
+var envKeys = map[string]bool{"env": true, "environment": true}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// IsSensitiveKey reports whether the field or header named key holds a credential or a secret value.
+func IsSensitiveKey(key string) bool {
+	normalized := normalizeKey(key)
+	if sensitiveKeys[normalized] {
+		return true
+	}
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScrubJSON returns the JSON document data for logging, with the values of its sensitive fields redacted.
+// The environment objects keep their variable names but not their values, which may be secrets.
+// Data that is not a JSON document is replaced by its size, since its content can't be scrubbed.
+func ScrubJSON(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Sprintf("[non-JSON body of %d bytes]", len(data))
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(document))
+	if err != nil {
+		return fmt.Sprintf("[unscrubbable body of %d bytes]", len(data))
+	}
+	return string(scrubbed)
+}
+
+// ScrubMap returns a copy of fields with the values of its sensitive keys redacted.
+func ScrubMap(fields map[string]string) map[string]string {
+	scrubbed := make(map[string]string, len(fields))
+	for key, value := range fields {
+		if IsSensitiveKey(key) {
+			value = RedactedValue
+		}
+		scrubbed[key] = value
+	}
+	return scrubbed
+}
+
+func scrubValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		scrubbed := make(map[string]any, len(typed))
+		for key, field := range typed {
+			switch {
+			case IsSensitiveKey(key) && !isObject(field):
+				scrubbed[key] = RedactedValue
+			case envKeys[normalizeKey(key)]:
+				scrubbed[key] = redactEnv(field)
+			default:
+				scrubbed[key] = scrubValue(field)
+			}
+		}
+		return scrubbed
+	case []any:
+		scrubbed := make([]any, len(typed))
+		for i, item := range typed {
+			scrubbed[i] = scrubValue(item)
+		}
+		return scrubbed
+	default:
+		return value
+	}
+}
+
+// isObject reports whether value is an object, which is scrubbed field by field even under a sensitive key,
+// e.g. the secret object of a response.
+func isObject(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+// redactEnv redacts the values of an environment object, keeping the variable names.
+func redactEnv(value any) any {
+	env, ok := value.(map[string]any)
+	if !ok {
+		return RedactedValue
+	}
+	redacted := make(map[string]any, len(env))
+	for name := range env {
+		redacted[name] = RedactedValue
+	}
+	return redacted
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{
+		"api_key", "X-API-Key", "apiKey", "token", "id_token", "claim_token", "secret_token",
+		"value", "passphrase", "Authorization", "client_secret",
+	} {
+		assert.True(t, IsSensitiveKey(key), key)
+	}
+	for _, key := range []string{"email", "key_name", "token_hash", "command", "name", "secrets", "Content-Type"} {
+		assert.False(t, IsSensitiveKey(key), key)
+	}
+}
+
+func TestScrubJSON(t *testing.T) {
+	t.Run("redacts sensitive fields at any depth", func(t *testing.T) {
+		scrubbed := ScrubJSON([]byte(`{
+			"email": "alice@example.com",
+			"api_key": "rk_live_123",
+			"user": {"claim_token": "abc"},
+			"secrets": [{"name": "db", "value": "hunter2"}],
+			"tokens_secret": ["a", "b"]
+		}`))
+
+		var document map[string]any
+		require.NoError(t, json.Unmarshal([]byte(scrubbed), &document))
+		assert.Equal(t, "alice@example.com", document["email"])
+		assert.Equal(t, RedactedValue, document["api_key"])
+		assert.Equal(t, RedactedValue, document["user"].(map[string]any)["claim_token"])
+		secret := document["secrets"].([]any)[0].(map[string]any)
+		assert.Equal(t, "db", secret["name"])
+		assert.Equal(t, RedactedValue, secret["value"])
+		assert.Equal(t, RedactedValue, document["tokens_secret"])
+	})
+
+	t.Run("keeps the names of the environment variables", func(t *testing.T) {
+		scrubbed := ScrubJSON([]byte(`{"command": "make deploy", "env": {"REGION": "eu-west-1", "TOKEN": "t"}}`))
+
+		assert.JSONEq(t,
+			`{"command": "make deploy", "env": {"REGION": "[REDACTED]", "TOKEN": "[REDACTED]"}}`, scrubbed)
+	})
+
+	t.Run("replaces other data by its size", func(t *testing.T) {
+		assert.Equal(t, "[non-JSON body of 12 bytes]", ScrubJSON([]byte("api_key=abcd")))
+		assert.Empty(t, ScrubJSON(nil))
+	})
+}
+
+func TestScrubMap(t *testing.T) {
+	fields := map[string]string{"X-Api-Key": "rk_live_123", "Content-Type": "application/json"}
+
+	scrubbed := ScrubMap(fields)
+
+	assert.Equal(t, map[string]string{"X-Api-Key": RedactedValue, "Content-Type": "application/json"}, scrubbed)
+	assert.Equal(t, "rk_live_123", fields["X-Api-Key"])
+}
@@ -20,6 +20,16 @@ type CloudWatchLogsClient interface {
 		params *cloudwatchlogs.FilterLogEventsInput,
 		optFns ...func(*cloudwatchlogs.Options),
 	) (*cloudwatchlogs.FilterLogEventsOutput, error)
+	DescribeLogGroups(
+		ctx context.Context,
+		params *cloudwatchlogs.DescribeLogGroupsInput,
+		optFns ...func(*cloudwatchlogs.Options),
+	) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	DeleteLogStream(
+		ctx context.Context,
+		params *cloudwatchlogs.DeleteLogStreamInput,
+		optFns ...func(*cloudwatchlogs.Options),
+	) (*cloudwatchlogs.DeleteLogStreamOutput, error)
 }
 
 // CloudWatchLogsClientAdapter wraps the AWS SDK CloudWatch Logs client to implement CloudWatchLogsClient interface.
@@ -58,3 +68,29 @@ func (a *CloudWatchLogsClientAdapter) FilterLogEvents(
 	}
 	return result, nil
 }
+
+// DescribeLogGroups wraps the AWS SDK DescribeLogGroups operation.
+func (a *CloudWatchLogsClientAdapter) DescribeLogGroups(
+	ctx context.Context,
+	params *cloudwatchlogs.DescribeLogGroupsInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	result, err := a.client.DescribeLogGroups(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe log groups: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteLogStream wraps the AWS SDK DeleteLogStream operation.
+func (a *CloudWatchLogsClientAdapter) DeleteLogStream(
+	ctx context.Context,
+	params *cloudwatchlogs.DeleteLogStreamInput,
+	optFns ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	result, err := a.client.DeleteLogStream(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete log stream: %w", err)
+	}
+	return result, nil
+}
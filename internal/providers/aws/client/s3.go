@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3PresignClient defines the interface for S3 presigning operations used across AWS provider packages.
+// This interface makes the code easier to test by allowing mock implementations.
+type S3PresignClient interface {
+	PresignPutObject(
+		ctx context.Context,
+		params *s3.PutObjectInput,
+		optFns ...func(*s3.PresignOptions),
+	) (*v4.PresignedHTTPRequest, error)
+	PresignGetObject(
+		ctx context.Context,
+		params *s3.GetObjectInput,
+		optFns ...func(*s3.PresignOptions),
+	) (*v4.PresignedHTTPRequest, error)
+}
+
+// S3PresignClientAdapter wraps the AWS SDK S3 presign client to implement S3PresignClient interface.
+// This allows us to use the real AWS client in production while maintaining testability.
+type S3PresignClientAdapter struct {
+	client *s3.PresignClient
+}
+
+// NewS3PresignClientAdapter creates a new adapter wrapping the AWS SDK S3 presign client.
+func NewS3PresignClientAdapter(client *s3.PresignClient) *S3PresignClientAdapter {
+	return &S3PresignClientAdapter{client: client}
+}
+
+// PresignPutObject wraps the AWS SDK PresignPutObject operation.
+func (a *S3PresignClientAdapter) PresignPutObject(
+	ctx context.Context,
+	params *s3.PutObjectInput,
+	optFns ...func(*s3.PresignOptions),
+) (*v4.PresignedHTTPRequest, error) {
+	result, err := a.client.PresignPutObject(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign put object: %w", err)
+	}
+	return result, nil
+}
+
+// PresignGetObject wraps the AWS SDK PresignGetObject operation.
+func (a *S3PresignClientAdapter) PresignGetObject(
+	ctx context.Context,
+	params *s3.GetObjectInput,
+	optFns ...func(*s3.PresignOptions),
+) (*v4.PresignedHTTPRequest, error) {
+	result, err := a.client.PresignGetObject(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign get object: %w", err)
+	}
+	return result, nil
+}
+
+// S3Client defines the interface for the S3 object operations used across AWS provider packages.
+// This interface makes the code easier to test by allowing mock implementations.
+type S3Client interface {
+	PutObject(
+		ctx context.Context,
+		params *s3.PutObjectInput,
+		optFns ...func(*s3.Options),
+	) (*s3.PutObjectOutput, error)
+}
+
+// S3ClientAdapter wraps the AWS SDK S3 client to implement S3Client interface.
+// This allows us to use the real AWS client in production while maintaining testability.
+type S3ClientAdapter struct {
+	client *s3.Client
+}
+
+// NewS3ClientAdapter creates a new adapter wrapping the AWS SDK S3 client.
+func NewS3ClientAdapter(client *s3.Client) *S3ClientAdapter {
+	return &S3ClientAdapter{client: client}
+}
+
+// PutObject wraps the AWS SDK PutObject operation.
+// The SDK error stays in the chain so callers can inspect the API error code,
+// e.g. the PreconditionFailed of a conditional write.
+func (a *S3ClientAdapter) PutObject(
+	ctx context.Context,
+	params *s3.PutObjectInput,
+	optFns ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	result, err := a.client.PutObject(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put object: %w", err)
+	}
+	return result, nil
+}
@@ -14,5 +14,49 @@ const CloudWatchLogsEventsLimit = int32(10000)
 const CloudWatchLogsObservabilityLookback time.Duration = 0
 
 // ScheduledEventHealthReconcile is the expected runvoy_event payload value
-// for EventBridge scheduled events that trigger health reconciliation.
+// for EventBridge scheduled events that trigger health reconciliation, followed by the cleanup
+// of the resources no longer referenced.
 const ScheduledEventHealthReconcile = "health_reconcile"
+
+// CleanupMaxDeletionsPerResourceType caps the resources of each type deleted by a cleanup run,
+// keeping a run within the Lambda timeout: the rest is deleted by the next runs.
+const CleanupMaxDeletionsPerResourceType = 200
+
+// ScheduledEventExecutionTimeouts is the expected runvoy_event payload value
+// for EventBridge scheduled events that trigger the execution timeout sweep.
+const ScheduledEventExecutionTimeouts = "execution_timeouts"
+
+// ScheduledEventWarmPools is the expected runvoy_event payload value
+// for EventBridge scheduled events that trigger the warm pool replenishment.
+const ScheduledEventWarmPools = "warm_pools"
+
+// ScheduledEventUsageReport is the expected runvoy_event payload value
+// for EventBridge scheduled events that trigger the weekly usage report email.
+const ScheduledEventUsageReport = "usage_report"
+
+// ScheduledEventClaimTokens is the expected runvoy_event payload value
+// for EventBridge scheduled events that trigger the sweep of the expired claim tokens.
+const ScheduledEventClaimTokens = "claim_tokens"
+
+// DefaultMetricsNamespace is the CloudWatch namespace of the backend metrics
+// when RUNVOY_AWS_METRICS_NAMESPACE is not set.
+const DefaultMetricsNamespace = "runvoy"
+
+// ContainerInsightsPerformanceLogGroupFormat is the log group Container Insights writes the performance
+// events of the tasks of a cluster to, formatted with the cluster name.
+const ContainerInsightsPerformanceLogGroupFormat = "/aws/ecs/containerinsights/%s/performance"
+
+// ContainerInsightsTaskEventType is the type of the Container Insights performance events
+// reporting the utilization of a whole task.
+const ContainerInsightsTaskEventType = "Task"
+
+// ContainerInsightsSampleInterval is the interval Container Insights reports the task metrics at.
+const ContainerInsightsSampleInterval = time.Minute
+
+// ResourceUsageLookback bounds the search for the latest resource usage sample of a running task.
+// Container Insights reports the task metrics every minute.
+const ResourceUsageLookback = 5 * time.Minute
+
+// ResourceUsageFilterBatchSize is the number of task IDs matched by a single filter pattern,
+// keeping the pattern under the 1024 characters limit of CloudWatch Logs.
+const ResourceUsageFilterBatchSize = 10
@@ -10,9 +10,16 @@ const DefaultExecutionListCapacity = 16
 // marked for deletion via TTL.
 const LogEventExpirationDelay = 10 * time.Minute
 
+// LogBatchCommitTimeout is how long a batch of log events being buffered holds back the delivery of
+// the batches buffered after it. A batch not saved by then, its processor having crashed, is skipped.
+const LogBatchCommitTimeout = 30 * time.Second
+
 // DynamoDBBatchWriteLimit is the maximum number of items DynamoDB allows per BatchWriteItem call.
 const DynamoDBBatchWriteLimit = 25
 
+// DynamoDBTransactWriteLimit is the maximum number of items DynamoDB allows per TransactWriteItems call.
+const DynamoDBTransactWriteLimit = 100
+
 // DynamoDBExpiresAtAttribute is the attribute name used for TTL (expires_at) columns.
 const DynamoDBExpiresAtAttribute = "expires_at"
 
@@ -21,3 +28,10 @@ const DynamoDBAllAttribute = "_all"
 
 // DynamoDBAllValue is the constant value stored in the _all attribute for all tables.
 const DynamoDBAllValue = "ALL"
+
+// HealthReportRetention is the duration after which stored health reports are
+// marked for deletion via TTL.
+const HealthReportRetention = 90 * 24 * time.Hour
+
+// DynamoDBEndpointEnvVar overrides the DynamoDB endpoint, to run against DynamoDB Local.
+const DynamoDBEndpointEnvVar = "DYNAMODB_ENDPOINT"
@@ -1,6 +1,10 @@
 package constants
 
-import "github.com/runvoy/runvoy/internal/constants"
+import (
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
 
 // RunnerContainerName is the ECS container name used for task execution.
 // Must match the container override name passed in the ECS RunTask call.
@@ -18,6 +22,9 @@ const SharedVolumeName = "workspace"
 // SharedVolumePath is the mount path for the shared volume in both containers.
 const SharedVolumePath = "/workspace"
 
+// RunnerInitPath is where the sidecar downloads the runvoy-init binary the runner container runs.
+const RunnerInitPath = SharedVolumePath + "/.runvoy-init"
+
 // EcsStatus represents the AWS ECS Task LastStatus lifecycle values.
 // These are string statuses returned by ECS DescribeTasks for Task.LastStatus.
 type EcsStatus string
@@ -51,6 +58,17 @@ const LogStreamPartsCount = 3
 // ECSTaskDefinitionMaxResults is the maximum number of results for ECS ListTaskDefinitions.
 const ECSTaskDefinitionMaxResults = int32(100)
 
+// ECSDeleteTaskDefinitionsMaxBatch is the maximum number of task definitions a single ECS DeleteTaskDefinitions
+// call can delete.
+const ECSDeleteTaskDefinitionsMaxBatch = 10
+
+// ECSDescribeTasksMaxTasks is the maximum number of tasks a single ECS DescribeTasks call can describe.
+const ECSDescribeTasksMaxTasks = 100
+
+// RunnerStopTimeoutSeconds is the time ECS waits after SIGTERM before sending SIGKILL to the runner container.
+// It is the maximum allowed by Fargate, so the runner script can enforce the execution's own grace period.
+const RunnerStopTimeoutSeconds = int32(120)
+
 // ECSEphemeralStorageSizeGiB is the ECS ephemeral storage size in GiB.
 const ECSEphemeralStorageSizeGiB = 21
 
@@ -96,3 +114,7 @@ const TaskDefinitionDockerImageTagKey = "DockerImage"
 
 // TaskDefinitionIsDefaultTagValue is the tag value used to mark a task definition as the default image.
 const TaskDefinitionIsDefaultTagValue = "true"
+
+// FargateMinimumBilledDuration is the minimum duration Fargate charges a task for,
+// from the start of its image pull to its stop, rounded up to the second.
+const FargateMinimumBilledDuration = time.Minute
@@ -68,4 +68,60 @@ const (
 
 	// CloudFormationTemplateFile is the filename of the CloudFormation template in releases.
 	CloudFormationTemplateFile = "cloudformation-backend.yaml"
+
+	// AlarmTopicParameter is the stack parameter holding the SNS topic notified by the backend alarms.
+	AlarmTopicParameter = "AlarmTopicArn"
+
+	// DomainNameParameter is the stack parameter holding the custom domain of the API.
+	DomainNameParameter = "DomainName"
+
+	// HostedZoneParameter is the stack parameter holding the Route53 hosted zone of the custom domain.
+	HostedZoneParameter = "HostedZoneId"
+
+	// IPv6Parameter is the stack parameter enabling dual-stack networking.
+	IPv6Parameter = "EnableIPv6"
+
+	// KMSKeyParameter is the stack parameter holding the customer managed KMS key encrypting the backend data.
+	KMSKeyParameter = "KmsKeyArn"
+
+	// ResourceTagsParameter is the stack parameter holding the tags of the resources created at runtime.
+	ResourceTagsParameter = "ResourceTags"
+
+	// SSOIssuerParameter is the stack parameter holding the issuer URL of the identity provider.
+	SSOIssuerParameter = "SSOIssuer"
+
+	// SSOClientIDParameter is the stack parameter holding the client ID registered with the identity provider.
+	SSOClientIDParameter = "SSOClientId"
+
+	// GroupRolesParameter is the stack parameter holding the roles of the identity provider groups.
+	GroupRolesParameter = "SCIMGroupRoles"
+
+	// GitHubTrustsParameter is the stack parameter holding the GitHub repositories trusted to run jobs.
+	GitHubTrustsParameter = "GitHubTrusts"
+
+	// ExecutionLogsBillingModeParameter is the stack parameter holding the billing mode of the execution logs table.
+	ExecutionLogsBillingModeParameter = "ExecutionLogsBillingMode"
+
+	// ExecutionLogsReadCapacityParameter is the stack parameter holding the minimum read capacity of the
+	// execution logs table in provisioned mode.
+	ExecutionLogsReadCapacityParameter = "ExecutionLogsReadCapacity"
+
+	// ExecutionLogsWriteCapacityParameter is the stack parameter holding the minimum write capacity of the
+	// execution logs table in provisioned mode.
+	ExecutionLogsWriteCapacityParameter = "ExecutionLogsWriteCapacity"
+
+	// ExecutionLogsMaxReadCapacityParameter is the stack parameter holding the read capacity the execution logs
+	// table scales up to in provisioned mode.
+	ExecutionLogsMaxReadCapacityParameter = "ExecutionLogsMaxReadCapacity"
+
+	// ExecutionLogsMaxWriteCapacityParameter is the stack parameter holding the write capacity the execution logs
+	// table scales up to in provisioned mode.
+	ExecutionLogsMaxWriteCapacityParameter = "ExecutionLogsMaxWriteCapacity"
+
+	// ProvisionedConcurrencyParameter is the stack parameter holding the number of orchestrator instances
+	// kept initialized.
+	ProvisionedConcurrencyParameter = "OrchestratorProvisionedConcurrency"
+
+	// ExecutionLogsTableOutput is the stack output holding the name of the execution logs table.
+	ExecutionLogsTableOutput = "ExecutionLogsTableName"
 )
@@ -11,6 +11,12 @@ func BuildLogStreamName(executionID string) string {
 	return "task/" + RunnerContainerName + "/" + executionID
 }
 
+// BuildSidecarLogStreamName constructs the CloudWatch Logs stream name of the sidecar container of an execution.
+// Format: task/sidecar/{execution_id}.
+func BuildSidecarLogStreamName(executionID string) string {
+	return "task/" + SidecarContainerName + "/" + executionID
+}
+
 // ExtractExecutionIDFromLogStream extracts the execution ID from a CloudWatch Logs stream name.
 // Expected format: task/{container}/{execution_id}
 // Returns empty string if the format is not recognized.
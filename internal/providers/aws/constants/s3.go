@@ -0,0 +1,24 @@
+package constants
+
+import "time"
+
+// StdinObjectKeyPrefix is the S3 key prefix of the standard input uploaded for executions.
+// The inputs bucket expires these objects after a day.
+const StdinObjectKeyPrefix = "stdin/"
+
+// ContextObjectKeyPrefix is the S3 key prefix of the working directory archives uploaded for executions.
+// The inputs bucket expires these objects after a day.
+const ContextObjectKeyPrefix = "context/"
+
+// InputUploadURLExpiry is how long a presigned execution input upload URL remains valid.
+const InputUploadURLExpiry = 15 * time.Minute
+
+// InputDownloadURLExpiry is how long the presigned URL the sidecar downloads an execution input from remains valid.
+// It covers the time the task may spend provisioning before the sidecar starts.
+const InputDownloadURLExpiry = 1 * time.Hour
+
+// StdinFilePath is the path on the shared volume where the sidecar writes the standard input of the command.
+const StdinFilePath = SharedVolumePath + "/.stdin"
+
+// ContextDirPath is the path on the shared volume where the sidecar extracts the working directory archive.
+const ContextDirPath = SharedVolumePath + "/context"
@@ -0,0 +1,33 @@
+package constants
+
+import "time"
+
+// WarmPoolStartedBy is the ECS startedBy value of the tasks launched as warm pool slots.
+// It tells warm slots apart from the tasks started for an execution.
+const WarmPoolStartedBy = "runvoy-warm-pool"
+
+// WarmPoolSlotTagKey is the ECS tag key holding the ID of a warm pool slot.
+// The slot ID names the S3 object the slot waits for its assignment on.
+const WarmPoolSlotTagKey = "WarmPoolSlot"
+
+// WarmPoolImageIDTagKey is the ECS tag key holding the ImageID a warm pool slot was started for.
+const WarmPoolImageIDTagKey = "WarmPoolImageID"
+
+// WarmPoolObjectKeyPrefix is the S3 key prefix of the assignments written to warm pool slots.
+// The inputs bucket expires these objects after a day.
+const WarmPoolObjectKeyPrefix = "warm-pool/"
+
+// WarmPoolSlotMaxIdle is how long a warm pool slot waits for an assignment before stopping.
+// It is also the expiry of the presigned URL the slot polls, bounded by the Lambda role session.
+const WarmPoolSlotMaxIdle = 1 * time.Hour
+
+// WarmPoolClaimMargin is how long before WarmPoolSlotMaxIdle a slot stops being assigned executions,
+// so an assignment is never written to a slot that already gave up waiting.
+const WarmPoolClaimMargin = 5 * time.Minute
+
+// WarmPoolPollIntervalSeconds is how often an idle warm pool slot checks for its assignment.
+const WarmPoolPollIntervalSeconds = 2
+
+// WarmPoolAssignmentPath is the path on the shared volume where a warm pool slot writes its assignment,
+// the script the runner container executes.
+const WarmPoolAssignmentPath = SharedVolumePath + "/.assignment"
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database/backup"
+	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
+)
+
+// BackupStore implements backup.Store for AWS.
+// Records are read and written in DynamoDB, and secret values in Parameter Store, where
+// restored values are encrypted with the KMS key of the destination stack.
+type BackupStore struct {
+	records    *dynamoRepo.BackupRepository
+	valueStore secrets.ValueStore
+}
+
+// Ensure BackupStore implements backup.Store.
+var _ backup.Store = (*BackupStore)(nil)
+
+// NewBackupStore creates a new AWS backup store.
+func NewBackupStore(records *dynamoRepo.BackupRepository, valueStore secrets.ValueStore) *BackupStore {
+	return &BackupStore{
+		records:    records,
+		valueStore: valueStore,
+	}
+}
+
+// ExportUsers returns all users with their API key hashes.
+func (s *BackupStore) ExportUsers(ctx context.Context) ([]backup.User, error) {
+	return s.records.ExportUsers(ctx)
+}
+
+// ExportImages returns all image configurations.
+func (s *BackupStore) ExportImages(ctx context.Context) ([]api.ImageInfo, error) {
+	return s.records.ExportImages(ctx)
+}
+
+// ExportSecrets returns all secrets, with their decrypted values when includeValues is set.
+func (s *BackupStore) ExportSecrets(ctx context.Context, includeValues bool) ([]api.Secret, error) {
+	secretsList, err := s.records.ExportSecretsMetadata(ctx)
+	if err != nil || !includeValues {
+		return secretsList, err
+	}
+
+	for i := range secretsList {
+		value, retrieveErr := s.valueStore.RetrieveSecret(ctx, secretsList[i].Name)
+		if retrieveErr != nil {
+			return nil, fmt.Errorf("failed to retrieve value of secret %s: %w", secretsList[i].Name, retrieveErr)
+		}
+		secretsList[i].Value = value
+	}
+	return secretsList, nil
+}
+
+// ImportUser creates a user with its API key hash.
+func (s *BackupStore) ImportUser(ctx context.Context, user *backup.User) error {
+	return s.records.ImportUser(ctx, user)
+}
+
+// ImportImage creates an image configuration.
+func (s *BackupStore) ImportImage(ctx context.Context, image *api.ImageInfo) error {
+	return s.records.ImportImage(ctx, image)
+}
+
+// ImportSecret creates a secret. The metadata is written first, so an existing secret's value is
+// never overwritten, and removed again if the value cannot be stored.
+func (s *BackupStore) ImportSecret(ctx context.Context, secret *api.Secret) error {
+	if err := s.records.ImportSecretMetadata(ctx, secret); err != nil {
+		return err
+	}
+
+	if err := s.valueStore.StoreSecret(ctx, secret.Name, secret.Value); err != nil {
+		if deleteErr := s.records.DeleteSecretMetadata(ctx, secret.Name); deleteErr != nil {
+			return fmt.Errorf("failed to store secret value: %w (metadata cleanup failed: %v)", err, deleteErr)
+		}
+		return fmt.Errorf("failed to store secret value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSecretsTableClient keeps the items of the secrets metadata table keyed by secret name.
+type mockSecretsTableClient struct {
+	items map[string]map[string]types.AttributeValue
+}
+
+func newMockSecretsTableClient() *mockSecretsTableClient {
+	return &mockSecretsTableClient{items: make(map[string]map[string]types.AttributeValue)}
+}
+
+func (m *mockSecretsTableClient) Scan(
+	_ context.Context, _ *dynamodb.ScanInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	items := make([]map[string]types.AttributeValue, 0, len(m.items))
+	for _, item := range m.items {
+		items = append(items, item)
+	}
+	return &dynamodb.ScanOutput{Items: items}, nil
+}
+
+func (m *mockSecretsTableClient) PutItem(
+	_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.PutItemOutput, error) {
+	name := params.Item["secret_name"].(*types.AttributeValueMemberS).Value
+	if _, ok := m.items[name]; ok {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.items[name] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *mockSecretsTableClient) DeleteItem(
+	_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.DeleteItemOutput, error) {
+	delete(m.items, params.Key["secret_name"].(*types.AttributeValueMemberS).Value)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func newTestBackupStore(client *mockSecretsTableClient, valueStore *mockValueStore) *BackupStore {
+	records := dynamoRepo.NewBackupRepository(client, dynamoRepo.BackupTables{
+		APIKeys:         "api-keys",
+		ImageTaskDefs:   "image-taskdefs",
+		SecretsMetadata: "secrets-metadata",
+	})
+	return NewBackupStore(records, valueStore)
+}
+
+func TestBackupStore_ExportSecrets(t *testing.T) {
+	ctx := context.Background()
+	valueStore := newMockValueStore()
+	store := newTestBackupStore(newMockSecretsTableClient(), valueStore)
+	require.NoError(t, store.ImportSecret(ctx, &api.Secret{
+		Name:      "db-password",
+		KeyName:   "DB_PASSWORD",
+		CreatedBy: "admin@example.com",
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Value:     "s3cret",
+	}))
+	assert.Equal(t, "s3cret", valueStore.values["db-password"])
+
+	withoutValues, err := store.ExportSecrets(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, withoutValues, 1)
+	assert.Empty(t, withoutValues[0].Value)
+
+	withValues, err := store.ExportSecrets(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, withValues, 1)
+	assert.Equal(t, "DB_PASSWORD", withValues[0].KeyName)
+	assert.Equal(t, "s3cret", withValues[0].Value)
+}
+
+func TestBackupStore_ExportSecretsRetrieveError(t *testing.T) {
+	ctx := context.Background()
+	valueStore := newMockValueStore()
+	store := newTestBackupStore(newMockSecretsTableClient(), valueStore)
+	require.NoError(t, store.ImportSecret(ctx, &api.Secret{Name: "db-password", Value: "s3cret"}))
+	valueStore.retrieveErr = errors.New("access denied")
+
+	_, err := store.ExportSecrets(ctx, true)
+
+	assert.ErrorContains(t, err, "failed to retrieve value of secret db-password: access denied")
+}
+
+func TestBackupStore_ImportSecretRemovesMetadataWhenValueFails(t *testing.T) {
+	ctx := context.Background()
+	client := newMockSecretsTableClient()
+	valueStore := newMockValueStore()
+	valueStore.storeErr = errors.New("kms key disabled")
+	store := newTestBackupStore(client, valueStore)
+
+	err := store.ImportSecret(ctx, &api.Secret{Name: "db-password", Value: "s3cret"})
+
+	assert.ErrorContains(t, err, "failed to store secret value: kms key disabled")
+	assert.Empty(t, client.items)
+}
+
+func TestBackupStore_ImportSecretKeepsExistingValue(t *testing.T) {
+	ctx := context.Background()
+	valueStore := newMockValueStore()
+	store := newTestBackupStore(newMockSecretsTableClient(), valueStore)
+	require.NoError(t, store.ImportSecret(ctx, &api.Secret{Name: "db-password", Value: "current"}))
+
+	err := store.ImportSecret(ctx, &api.Secret{Name: "db-password", Value: "from-backup"})
+
+	require.Error(t, err)
+	assert.Equal(t, "current", valueStore.values["db-password"])
+}
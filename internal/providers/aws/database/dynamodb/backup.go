@@ -0,0 +1,264 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database/backup"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// BackupClient defines the DynamoDB operations used to back up and restore records.
+type BackupClient interface {
+	Scan(
+		ctx context.Context,
+		params *dynamodb.ScanInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.ScanOutput, error)
+	PutItem(
+		ctx context.Context,
+		params *dynamodb.PutItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.PutItemOutput, error)
+	DeleteItem(
+		ctx context.Context,
+		params *dynamodb.DeleteItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.DeleteItemOutput, error)
+}
+
+// BackupTables holds the names of the tables included in backups.
+type BackupTables struct {
+	APIKeys         string
+	ImageTaskDefs   string
+	SecretsMetadata string
+}
+
+// BackupRepository reads and writes whole records of the control-plane tables, preserving
+// the timestamps and audit fields the repositories set themselves.
+type BackupRepository struct {
+	client BackupClient
+	tables BackupTables
+}
+
+// NewBackupRepository creates a new DynamoDB backup repository.
+func NewBackupRepository(client BackupClient, tables BackupTables) *BackupRepository {
+	return &BackupRepository{
+		client: client,
+		tables: tables,
+	}
+}
+
+// ExportUsers returns all users with their API key hashes.
+func (r *BackupRepository) ExportUsers(ctx context.Context) ([]backup.User, error) {
+	var items []userItem
+	if err := r.scanAll(ctx, r.tables.APIKeys, &items); err != nil {
+		return nil, err
+	}
+
+	users := make([]backup.User, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		user := backup.User{
+			User: api.User{
+				Email:               item.UserEmail,
+				Role:                item.Role,
+				CreatedAt:           item.CreatedAt,
+				Revoked:             item.Revoked,
+				CreatedByRequestID:  item.CreatedByRequestID,
+				ModifiedByRequestID: item.ModifiedByRequestID,
+				ServiceAccount:      item.ServiceAccount,
+				Owner:               item.Owner,
+			},
+			APIKeyHash: item.APIKeyHash,
+			ExpiresAt:  item.ExpiresAt,
+		}
+		if !item.LastUsed.IsZero() {
+			lastUsed := item.LastUsed
+			user.LastUsed = &lastUsed
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// ImportUser creates a user with its API key hash, failing with backup.ErrExists if the hash is taken.
+func (r *BackupRepository) ImportUser(ctx context.Context, user *backup.User) error {
+	item := userItem{
+		APIKeyHash:          user.APIKeyHash,
+		UserEmail:           user.Email,
+		Role:                user.Role,
+		CreatedAt:           user.CreatedAt,
+		Revoked:             user.Revoked,
+		ExpiresAt:           user.ExpiresAt,
+		CreatedByRequestID:  user.CreatedByRequestID,
+		ModifiedByRequestID: user.ModifiedByRequestID,
+		ServiceAccount:      user.ServiceAccount,
+		Owner:               user.Owner,
+		All:                 awsConstants.DynamoDBAllValue,
+	}
+	if user.LastUsed != nil {
+		item.LastUsed = *user.LastUsed
+	}
+	return r.putNew(ctx, r.tables.APIKeys, "api_key_hash", item)
+}
+
+// ExportImages returns all image configurations.
+func (r *BackupRepository) ExportImages(ctx context.Context) ([]api.ImageInfo, error) {
+	var items []imageTaskDefItem
+	if err := r.scanAll(ctx, r.tables.ImageTaskDefs, &items); err != nil {
+		return nil, err
+	}
+
+	converter := &ImageTaskDefRepository{}
+	images := make([]api.ImageInfo, 0, len(items))
+	for i := range items {
+		image, err := converter.convertItemToImageInfo(&items[i])
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", items[i].ImageID, err)
+		}
+		images = append(images, *image)
+	}
+	return images, nil
+}
+
+// ImportImage creates an image configuration, failing with backup.ErrExists if its ID is taken.
+// Images converted from another configuration are given the ID and task definition family of their
+// configuration. Its task definition is registered again by the health reconciliation when missing.
+func (r *BackupRepository) ImportImage(ctx context.Context, image *api.ImageInfo) error {
+	if image.ImageID == "" {
+		image.ImageID = GenerateImageID(image.ImageName, image.ImageTag, image.CPU, image.Memory,
+			image.RuntimePlatform, image.TaskRoleName, image.TaskExecutionRoleName)
+	}
+	if image.TaskDefinitionName == "" {
+		image.TaskDefinitionName = TaskDefinitionFamilyForImageID(image.ImageID)
+	}
+	item := imageTaskDefItem{
+		ImageID:               image.ImageID,
+		Image:                 image.Image,
+		TaskRoleName:          image.TaskRoleName,
+		TaskExecutionRoleName: image.TaskExecutionRoleName,
+		Cpu:                   strconv.Itoa(image.CPU),
+		Memory:                strconv.Itoa(image.Memory),
+		RuntimePlatform:       image.RuntimePlatform,
+		TaskDefinitionFamily:  image.TaskDefinitionName,
+		ImageRegistry:         image.ImageRegistry,
+		ImageName:             image.ImageName,
+		ImageTag:              image.ImageTag,
+		CreatedBy:             image.CreatedBy,
+		OwnedBy:               image.OwnedBy,
+		CreatedAt:             image.CreatedAt.Unix(),
+		UpdatedAt:             time.Now().Unix(),
+		CreatedByRequestID:    image.CreatedByRequestID,
+		ModifiedByRequestID:   image.ModifiedByRequestID,
+		All:                   awsConstants.DynamoDBAllValue,
+	}
+	if image.IsDefault != nil && *image.IsDefault {
+		placeholder := defaultPlaceholderValue
+		item.IsDefaultPlaceholder = &placeholder
+	}
+	return r.putNew(ctx, r.tables.ImageTaskDefs, "image_id", item)
+}
+
+// ExportSecretsMetadata returns the metadata of all secrets.
+func (r *BackupRepository) ExportSecretsMetadata(ctx context.Context) ([]api.Secret, error) {
+	var items []secretItem
+	if err := r.scanAll(ctx, r.tables.SecretsMetadata, &items); err != nil {
+		return nil, err
+	}
+
+	secrets := make([]api.Secret, 0, len(items))
+	for i := range items {
+		secrets = append(secrets, *items[i].toAPISecret())
+	}
+	return secrets, nil
+}
+
+// ImportSecretMetadata creates the metadata of a secret, failing with backup.ErrExists if its name is taken.
+func (r *BackupRepository) ImportSecretMetadata(ctx context.Context, secret *api.Secret) error {
+	return r.putNew(ctx, r.tables.SecretsMetadata, "secret_name", secretItem{
+		SecretName:          secret.Name,
+		KeyName:             secret.KeyName,
+		Description:         secret.Description,
+		CreatedBy:           secret.CreatedBy,
+		OwnedBy:             secret.OwnedBy,
+		CreatedAt:           secret.CreatedAt,
+		UpdatedAt:           secret.UpdatedAt,
+		UpdatedBy:           secret.UpdatedBy,
+		CreatedByRequestID:  secret.CreatedByRequestID,
+		ModifiedByRequestID: secret.ModifiedByRequestID,
+		All:                 awsConstants.DynamoDBAllValue,
+	})
+}
+
+// DeleteSecretMetadata removes the metadata of a secret whose value could not be restored.
+func (r *BackupRepository) DeleteSecretMetadata(ctx context.Context, name string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tables.SecretsMetadata),
+		Key: map[string]types.AttributeValue{
+			"secret_name": &types.AttributeValueMemberS{Value: name},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete secret metadata: %w", err)
+	}
+	return nil
+}
+
+// scanAll reads every item of the table into out, a pointer to a slice of items.
+func (r *BackupRepository) scanAll(ctx context.Context, tableName string, out any) error {
+	var all []map[string]types.AttributeValue
+	var startKey map[string]types.AttributeValue
+	for {
+		page, err := r.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(tableName),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scan table %s: %w", tableName, err)
+		}
+		all = append(all, page.Items...)
+
+		if len(page.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = page.LastEvaluatedKey
+	}
+
+	if err := attributevalue.UnmarshalListOfMaps(all, out); err != nil {
+		return fmt.Errorf("failed to unmarshal items of table %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// putNew writes an item unless one with the same key exists.
+func (r *BackupRepository) putNew(ctx context.Context, tableName, keyAttribute string, item any) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(tableName),
+		Item:                     av,
+		ConditionExpression:      aws.String("attribute_not_exists(#key)"),
+		ExpressionAttributeNames: map[string]string{"#key": keyAttribute},
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return backup.ErrExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to put item in table %s: %w", tableName, err)
+	}
+	return nil
+}
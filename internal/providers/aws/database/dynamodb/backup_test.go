@@ -0,0 +1,217 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database/backup"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackupClient stores items per table, keyed by the attribute named in put conditions.
+type memoryBackupClient struct {
+	tables map[string][]map[string]types.AttributeValue
+	putErr error
+}
+
+func newMemoryBackupClient() *memoryBackupClient {
+	return &memoryBackupClient{tables: map[string][]map[string]types.AttributeValue{}}
+}
+
+func (c *memoryBackupClient) Scan(
+	_ context.Context, params *dynamodb.ScanInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	return &dynamodb.ScanOutput{Items: c.tables[aws.ToString(params.TableName)]}, nil
+}
+
+func (c *memoryBackupClient) PutItem(
+	_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.PutItemOutput, error) {
+	if c.putErr != nil {
+		return nil, c.putErr
+	}
+	tableName := aws.ToString(params.TableName)
+	keyAttribute := params.ExpressionAttributeNames["#key"]
+	for _, item := range c.tables[tableName] {
+		if attributeValuesEqual(item[keyAttribute], params.Item[keyAttribute]) {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+	}
+	c.tables[tableName] = append(c.tables[tableName], params.Item)
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (c *memoryBackupClient) DeleteItem(
+	_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.DeleteItemOutput, error) {
+	tableName := aws.ToString(params.TableName)
+	kept := c.tables[tableName][:0]
+	for _, item := range c.tables[tableName] {
+		matches := true
+		for attribute, value := range params.Key {
+			matches = matches && attributeValuesEqual(item[attribute], value)
+		}
+		if !matches {
+			kept = append(kept, item)
+		}
+	}
+	c.tables[tableName] = kept
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func attributeValuesEqual(a, b types.AttributeValue) bool {
+	as, aOK := a.(*types.AttributeValueMemberS)
+	bs, bOK := b.(*types.AttributeValueMemberS)
+	return aOK && bOK && as.Value == bs.Value
+}
+
+var testBackupTables = BackupTables{
+	APIKeys:         "runvoy-api-keys",
+	ImageTaskDefs:   "runvoy-image-taskdefs",
+	SecretsMetadata: "runvoy-secrets-metadata",
+}
+
+func TestBackupRepository_Users(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBackupRepository(newMemoryBackupClient(), testBackupTables)
+	lastUsed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := &backup.User{
+		User: api.User{
+			Email:              "admin@example.com",
+			Role:               "admin",
+			CreatedAt:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			LastUsed:           &lastUsed,
+			CreatedByRequestID: "req-1",
+		},
+		APIKeyHash: "hash-1",
+	}
+
+	require.NoError(t, repo.ImportUser(ctx, user))
+	assert.ErrorIs(t, repo.ImportUser(ctx, user), backup.ErrExists)
+
+	users, err := repo.ExportUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, *user, users[0])
+}
+
+func TestBackupRepository_UserWithoutLastUsed(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBackupRepository(newMemoryBackupClient(), testBackupTables)
+
+	require.NoError(t, repo.ImportUser(ctx, &backup.User{
+		User:       api.User{Email: "dev@example.com", Role: "developer"},
+		APIKeyHash: "hash-2",
+		ExpiresAt:  1767225600,
+	}))
+
+	users, err := repo.ExportUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Nil(t, users[0].LastUsed)
+	assert.Equal(t, int64(1767225600), users[0].ExpiresAt)
+}
+
+func TestBackupRepository_Images(t *testing.T) {
+	ctx := context.Background()
+	client := newMemoryBackupClient()
+	repo := NewBackupRepository(client, testBackupTables)
+	isDefault := true
+	taskRole := "my-task-role"
+	image := &api.ImageInfo{
+		ImageID:            "alpine:latest-a1b2c3d4",
+		Image:              "alpine:latest",
+		TaskDefinitionName: "runvoy-image-alpine-latest",
+		IsDefault:          &isDefault,
+		TaskRoleName:       &taskRole,
+		CPU:                256,
+		Memory:             512,
+		RuntimePlatform:    "Linux/X86_64",
+		ImageName:          "alpine",
+		ImageTag:           "latest",
+		CreatedBy:          "admin@example.com",
+		OwnedBy:            []string{"admin@example.com"},
+		CreatedAt:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, repo.ImportImage(ctx, image))
+	assert.ErrorIs(t, repo.ImportImage(ctx, image), backup.ErrExists)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "ALL"},
+		client.tables["runvoy-image-taskdefs"][0]["_all"])
+
+	images, err := repo.ExportImages(ctx)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, *image, images[0])
+}
+
+func TestBackupRepository_SecretsMetadata(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBackupRepository(newMemoryBackupClient(), testBackupTables)
+	secret := &api.Secret{
+		Name:      "github-token",
+		KeyName:   "GITHUB_TOKEN",
+		CreatedBy: "admin@example.com",
+		OwnedBy:   []string{"admin@example.com"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedBy: "admin@example.com",
+		Value:     "not stored in DynamoDB",
+	}
+
+	require.NoError(t, repo.ImportSecretMetadata(ctx, secret))
+	assert.ErrorIs(t, repo.ImportSecretMetadata(ctx, secret), backup.ErrExists)
+
+	secrets, err := repo.ExportSecretsMetadata(ctx)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	expected := *secret
+	expected.Value = ""
+	assert.Equal(t, expected, secrets[0])
+
+	require.NoError(t, repo.DeleteSecretMetadata(ctx, "github-token"))
+	secrets, err = repo.ExportSecretsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+}
+
+func TestBackupRepository_PutError(t *testing.T) {
+	client := newMemoryBackupClient()
+	client.putErr = errors.New("throttled")
+	repo := NewBackupRepository(client, testBackupTables)
+
+	err := repo.ImportUser(context.Background(), &backup.User{APIKeyHash: "hash"})
+
+	assert.ErrorContains(t, err, "failed to put item in table runvoy-api-keys: throttled")
+}
+
+func TestBackupRepository_ImportConvertedImage(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBackupRepository(newMemoryBackupClient(), testBackupTables)
+	taskRole := "deployer-role"
+	image := &api.ImageInfo{
+		Image:        "alpine:latest",
+		ImageName:    "alpine",
+		ImageTag:     "latest",
+		TaskRoleName: &taskRole,
+		CPU:          256,
+		Memory:       512,
+	}
+
+	require.NoError(t, repo.ImportImage(ctx, image))
+
+	expectedID := GenerateImageID("alpine", "latest", 256, 512, "", &taskRole, nil)
+	images, err := repo.ExportImages(ctx)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, expectedID, images[0].ImageID)
+	assert.Equal(t, TaskDefinitionFamilyForImageID(expectedID), images[0].TaskDefinitionName)
+}
@@ -25,6 +25,11 @@ type Client interface {
 		params *dynamodb.QueryInput,
 		optFns ...func(*dynamodb.Options),
 	) (*dynamodb.QueryOutput, error)
+	Scan(
+		ctx context.Context,
+		params *dynamodb.ScanInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.ScanOutput, error)
 	UpdateItem(
 		ctx context.Context,
 		params *dynamodb.UpdateItemInput,
@@ -40,6 +45,11 @@ type Client interface {
 		params *dynamodb.BatchWriteItemInput,
 		optFns ...func(*dynamodb.Options),
 	) (*dynamodb.BatchWriteItemOutput, error)
+	TransactWriteItems(
+		ctx context.Context,
+		params *dynamodb.TransactWriteItemsInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.TransactWriteItemsOutput, error)
 }
 
 // ClientAdapter wraps the AWS SDK DynamoDB client to implement Client interface.
@@ -92,6 +102,19 @@ func (a *ClientAdapter) Query(
 	return result, nil
 }
 
+// Scan wraps the AWS SDK Scan operation.
+func (a *ClientAdapter) Scan(
+	ctx context.Context,
+	params *dynamodb.ScanInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	result, err := a.client.Scan(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan: %w", err)
+	}
+	return result, nil
+}
+
 // UpdateItem wraps the AWS SDK UpdateItem operation.
 func (a *ClientAdapter) UpdateItem(
 	ctx context.Context,
@@ -130,3 +153,16 @@ func (a *ClientAdapter) BatchWriteItem(
 	}
 	return result, nil
 }
+
+// TransactWriteItems wraps the AWS SDK TransactWriteItems operation.
+func (a *ClientAdapter) TransactWriteItems(
+	ctx context.Context,
+	params *dynamodb.TransactWriteItemsInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	result, err := a.client.TransactWriteItems(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transact write items: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,183 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// CommandPolicyRepository implements the database.CommandPolicyRepository interface using DynamoDB.
+// Rules share the constant _all partition and are sorted by name, so they are listed with a single query,
+// and are stored with their API field names.
+type CommandPolicyRepository struct {
+	client    Client
+	tableName string
+	logger    *slog.Logger
+}
+
+// NewCommandPolicyRepository creates a new DynamoDB-backed command policy repository.
+func NewCommandPolicyRepository(
+	client Client,
+	tableName string,
+	log *slog.Logger,
+) database.CommandPolicyRepository {
+	return &CommandPolicyRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    log,
+	}
+}
+
+// PutCommandPolicyRule creates or replaces a rule, by name.
+func (r *CommandPolicyRepository) PutCommandPolicyRule(ctx context.Context, rule *api.CommandPolicyRule) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	item, err := attributevalue.MarshalMapWithOptions(rule, encodeWithJSONTags)
+	if err != nil {
+		return sdkerrors.Map(err, "failed to marshal command policy rule", apperrors.ErrDatabaseError)
+	}
+	item[awsConstants.DynamoDBAllAttribute] = &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"name", rule.Name,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	if _, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      item,
+	}); err != nil {
+		return sdkerrors.Map(err, "failed to store command policy rule", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// GetCommandPolicyRule retrieves a rule by name. Returns nil if the rule doesn't exist.
+func (r *CommandPolicyRepository) GetCommandPolicyRule(
+	ctx context.Context, name string,
+) (*api.CommandPolicyRule, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+		"name", name,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       commandPolicyRuleKey(name),
+	})
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to get command policy rule", apperrors.ErrDatabaseError)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var rule api.CommandPolicyRule
+	if err = attributevalue.UnmarshalMapWithOptions(result.Item, &rule, decodeWithJSONTags); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal command policy rule", apperrors.ErrDatabaseError)
+	}
+
+	return &rule, nil
+}
+
+// ListCommandPolicyRules returns all rules, sorted by name.
+func (r *CommandPolicyRepository) ListCommandPolicyRules(ctx context.Context) ([]api.CommandPolicyRule, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	rules := []api.CommandPolicyRule{}
+	var lastKey map[string]types.AttributeValue
+	for {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.tableName),
+			KeyConditionExpression: aws.String("#all = :all"),
+			ExpressionAttributeNames: map[string]string{
+				"#all": awsConstants.DynamoDBAllAttribute,
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":all": &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+			},
+			ExclusiveStartKey: lastKey,
+		})
+		if err != nil {
+			return nil, sdkerrors.Map(err, "failed to query command policy rules", apperrors.ErrDatabaseError)
+		}
+
+		var page []api.CommandPolicyRule
+		if err = attributevalue.UnmarshalListOfMapsWithOptions(out.Items, &page, decodeWithJSONTags); err != nil {
+			return nil, sdkerrors.Map(err, "failed to unmarshal command policy rules", apperrors.ErrDatabaseError)
+		}
+		rules = append(rules, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastKey = out.LastEvaluatedKey
+	}
+
+	return rules, nil
+}
+
+// DeleteCommandPolicyRule removes a rule. Returns ErrNotFound if the rule doesn't exist.
+func (r *CommandPolicyRepository) DeleteCommandPolicyRule(ctx context.Context, name string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.DeleteItem",
+		"table", r.tableName,
+		"name", name,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:                aws.String(r.tableName),
+		Key:                      commandPolicyRuleKey(name),
+		ConditionExpression:      aws.String("attribute_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("command policy rule not found", err)
+		}
+		return sdkerrors.Map(err, "failed to delete command policy rule", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+func commandPolicyRuleKey(name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		awsConstants.DynamoDBAllAttribute: &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+		"name":                            &types.AttributeValueMemberS{Value: name},
+	}
+}
@@ -0,0 +1,128 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPolicyRepository_PutGetList(t *testing.T) {
+	var stored map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "command-policies", aws.ToString(params.TableName))
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "no-rm-root"}, params.Key["name"])
+			assert.Equal(t, &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+				params.Key[awsConstants.DynamoDBAllAttribute])
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		},
+		queryFunc: func(_ context.Context, _ *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{stored}}, nil
+		},
+	}
+	repo := NewCommandPolicyRepository(client, "command-policies", testutil.SilentLogger())
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	rule := &api.CommandPolicyRule{
+		Name:      "no-rm-root",
+		Effect:    "deny",
+		MatchType: "regex",
+		Pattern:   `rm\s+-rf\s+/(\s|$)`,
+		Roles:     []string{"developer"},
+		CreatedBy: "admin@example.com",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	require.NoError(t, repo.PutCommandPolicyRule(context.Background(), rule))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "no-rm-root"}, stored["name"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "regex"}, stored["match_type"])
+	assert.Contains(t, stored, awsConstants.DynamoDBAllAttribute)
+
+	got, err := repo.GetCommandPolicyRule(context.Background(), "no-rm-root")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, rule.Pattern, got.Pattern)
+	assert.Equal(t, rule.Roles, got.Roles)
+	assert.True(t, now.Equal(got.CreatedAt))
+
+	rules, err := repo.ListCommandPolicyRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "no-rm-root", rules[0].Name)
+}
+
+func TestCommandPolicyRepository_GetMissing(t *testing.T) {
+	client := &mockImageClient{
+		getItemFunc: func(_ context.Context, _ *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			return &dynamodb.GetItemOutput{}, nil
+		},
+	}
+	repo := NewCommandPolicyRepository(client, "command-policies", testutil.SilentLogger())
+
+	rule, err := repo.GetCommandPolicyRule(context.Background(), "missing")
+
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestCommandPolicyRepository_Delete(t *testing.T) {
+	t.Run("deletes the rule", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				assert.NotNil(t, params.ConditionExpression)
+				return &dynamodb.DeleteItemOutput{}, nil
+			},
+		}
+		repo := NewCommandPolicyRepository(client, "command-policies", testutil.SilentLogger())
+
+		assert.NoError(t, repo.DeleteCommandPolicyRule(context.Background(), "no-rm-root"))
+	})
+
+	t.Run("returns not found for a missing rule", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, _ *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				return nil, &types.ConditionalCheckFailedException{}
+			},
+		}
+		repo := NewCommandPolicyRepository(client, "command-policies", testutil.SilentLogger())
+
+		err := repo.DeleteCommandPolicyRule(context.Background(), "missing")
+		assert.Equal(t, appErrors.ErrCodeNotFound, appErrors.GetErrorCode(err))
+	})
+
+	t.Run("returns a database error on failure", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, _ *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		repo := NewCommandPolicyRepository(client, "command-policies", testutil.SilentLogger())
+
+		err := repo.DeleteCommandPolicyRule(context.Background(), "no-rm-root")
+		assert.Equal(t, appErrors.ErrCodeDatabaseError, appErrors.GetErrorCode(err))
+	})
+}
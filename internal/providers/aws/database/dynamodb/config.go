@@ -0,0 +1,177 @@
+package dynamodb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// configKeyAttribute is the partition key of the config table, naming the setting stored in the item.
+const configKeyAttribute = "key"
+
+// Keys of the settings in the config table.
+const (
+	runFreezeConfigKey     = "run_freeze"
+	announcementConfigKey  = "announcement"
+	budgetsConfigKey       = "budgets"
+	notificationsConfigKey = "notifications"
+)
+
+// ConfigRepository implements the database.ConfigRepository interface using DynamoDB.
+// Each setting is a single item keyed by its name and stored with its API field names.
+type ConfigRepository struct {
+	client    Client
+	tableName string
+	logger    *slog.Logger
+}
+
+// NewConfigRepository creates a new DynamoDB-backed config repository.
+func NewConfigRepository(client Client, tableName string, log *slog.Logger) database.ConfigRepository {
+	return &ConfigRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    log,
+	}
+}
+
+// GetRunFreeze retrieves the run freeze switch. Returns nil if it was never set.
+func (r *ConfigRepository) GetRunFreeze(ctx context.Context) (*api.RunFreeze, error) {
+	var freeze api.RunFreeze
+	found, err := r.getSetting(ctx, runFreezeConfigKey, &freeze)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &freeze, nil
+}
+
+// PutRunFreeze stores the run freeze switch.
+func (r *ConfigRepository) PutRunFreeze(ctx context.Context, freeze *api.RunFreeze) error {
+	return r.putSetting(ctx, runFreezeConfigKey, freeze)
+}
+
+// GetAnnouncement retrieves the announcement. Returns nil if it was never set.
+func (r *ConfigRepository) GetAnnouncement(ctx context.Context) (*api.Announcement, error) {
+	var announcement api.Announcement
+	found, err := r.getSetting(ctx, announcementConfigKey, &announcement)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// PutAnnouncement stores the announcement, an empty message clearing it.
+func (r *ConfigRepository) PutAnnouncement(ctx context.Context, announcement *api.Announcement) error {
+	return r.putSetting(ctx, announcementConfigKey, announcement)
+}
+
+// budgetsSetting nests the budgets in an attribute, the emails they are keyed by could not be attribute names
+// next to the setting key.
+type budgetsSetting struct {
+	Budgets map[string]api.Budget `json:"budgets"`
+}
+
+// GetBudgets retrieves the budgets by user email. Returns an empty map if none was set.
+func (r *ConfigRepository) GetBudgets(ctx context.Context) (map[string]api.Budget, error) {
+	var setting budgetsSetting
+	if _, err := r.getSetting(ctx, budgetsConfigKey, &setting); err != nil {
+		return nil, err
+	}
+	if setting.Budgets == nil {
+		return map[string]api.Budget{}, nil
+	}
+	return setting.Budgets, nil
+}
+
+// PutBudgets stores the budgets by user email, replacing all of them.
+func (r *ConfigRepository) PutBudgets(ctx context.Context, budgets map[string]api.Budget) error {
+	return r.putSetting(ctx, budgetsConfigKey, budgetsSetting{Budgets: budgets})
+}
+
+// GetNotificationSettings retrieves the notification settings. Returns the zero settings if none were set.
+func (r *ConfigRepository) GetNotificationSettings(ctx context.Context) (*api.NotificationSettings, error) {
+	var settings api.NotificationSettings
+	if _, err := r.getSetting(ctx, notificationsConfigKey, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// PutNotificationSettings stores the notification settings, replacing them.
+func (r *ConfigRepository) PutNotificationSettings(ctx context.Context, settings *api.NotificationSettings) error {
+	return r.putSetting(ctx, notificationsConfigKey, settings)
+}
+
+// getSetting unmarshals the setting stored under key into out. It reports whether the setting exists.
+func (r *ConfigRepository) getSetting(ctx context.Context, key string, out any) (bool, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+		"key", key,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       configKey(key),
+	})
+	if err != nil {
+		return false, sdkerrors.Map(err, "failed to get "+key, apperrors.ErrDatabaseError)
+	}
+
+	if result.Item == nil {
+		return false, nil
+	}
+
+	if err = attributevalue.UnmarshalMapWithOptions(result.Item, out, decodeWithJSONTags); err != nil {
+		return false, sdkerrors.Map(err, "failed to unmarshal "+key, apperrors.ErrDatabaseError)
+	}
+
+	return true, nil
+}
+
+// putSetting stores value under key, replacing the previous value.
+func (r *ConfigRepository) putSetting(ctx context.Context, key string, value any) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	item, err := attributevalue.MarshalMapWithOptions(value, encodeWithJSONTags)
+	if err != nil {
+		return sdkerrors.Map(err, "failed to marshal "+key, apperrors.ErrDatabaseError)
+	}
+	item[configKeyAttribute] = &types.AttributeValueMemberS{Value: key}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"key", key,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	if _, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      item,
+	}); err != nil {
+		return sdkerrors.Map(err, "failed to store "+key, apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+func configKey(key string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		configKeyAttribute: &types.AttributeValueMemberS{Value: key},
+	}
+}
@@ -0,0 +1,142 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRepository_RunFreeze(t *testing.T) {
+	var stored map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "config", aws.ToString(params.TableName))
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "run_freeze"}, params.Key["key"])
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		},
+	}
+	repo := NewConfigRepository(client, "config", testutil.SilentLogger())
+
+	freeze, err := repo.GetRunFreeze(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, freeze)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.PutRunFreeze(context.Background(), &api.RunFreeze{
+		Frozen:    true,
+		Message:   "maintenance until 5pm",
+		UpdatedBy: "admin@example.com",
+		UpdatedAt: &now,
+	}))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "run_freeze"}, stored["key"])
+	assert.Equal(t, &types.AttributeValueMemberBOOL{Value: true}, stored["frozen"])
+
+	freeze, err = repo.GetRunFreeze(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, freeze)
+	assert.True(t, freeze.Frozen)
+	assert.Equal(t, "maintenance until 5pm", freeze.Message)
+	assert.Equal(t, "admin@example.com", freeze.UpdatedBy)
+	require.NotNil(t, freeze.UpdatedAt)
+	assert.True(t, now.Equal(*freeze.UpdatedAt))
+}
+
+func TestConfigRepository_Errors(t *testing.T) {
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, _ *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			return nil, errors.New("boom")
+		},
+		getItemFunc: func(_ context.Context, _ *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	repo := NewConfigRepository(client, "config", testutil.SilentLogger())
+
+	_, err := repo.GetRunFreeze(context.Background())
+	assert.Equal(t, appErrors.ErrCodeDatabaseError, appErrors.GetErrorCode(err))
+
+	err = repo.PutRunFreeze(context.Background(), &api.RunFreeze{})
+	assert.Equal(t, appErrors.ErrCodeDatabaseError, appErrors.GetErrorCode(err))
+}
+
+func TestConfigRepository_Announcement(t *testing.T) {
+	var stored map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "announcement"}, params.Key["key"])
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		},
+	}
+	repo := NewConfigRepository(client, "config", testutil.SilentLogger())
+
+	announcement, err := repo.GetAnnouncement(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, announcement)
+
+	require.NoError(t, repo.PutAnnouncement(context.Background(), &api.Announcement{
+		Message: "API keys created before 2026 expire on 2026-11-01", UpdatedBy: "admin@example.com",
+	}))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "announcement"}, stored["key"])
+
+	announcement, err = repo.GetAnnouncement(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, announcement)
+	assert.Equal(t, "API keys created before 2026 expire on 2026-11-01", announcement.Message)
+}
+
+func TestConfigRepository_Budgets(t *testing.T) {
+	var stored map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "budgets"}, params.Key["key"])
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		},
+	}
+	repo := NewConfigRepository(client, "config", testutil.SilentLogger())
+
+	budgets, err := repo.GetBudgets(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, budgets)
+
+	require.NoError(t, repo.PutBudgets(context.Background(), map[string]api.Budget{
+		"user@example.com": {UserEmail: "user@example.com", MonthlyLimitUSD: 50, HardCap: true},
+	}))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "budgets"}, stored["key"])
+
+	budgets, err = repo.GetBudgets(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, budgets, "user@example.com")
+	assert.InDelta(t, 50, budgets["user@example.com"].MonthlyLimitUSD, 0)
+	assert.True(t, budgets["user@example.com"].HardCap)
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/database"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -46,6 +48,7 @@ type connectionItem struct {
 	Functionality        string `dynamodbav:"functionality"`
 	ExpiresAt            int64  `dynamodbav:"expires_at"`
 	LastEventID          string `dynamodbav:"last_event_id,omitempty"`
+	LastSequence         int64  `dynamodbav:"last_sequence,omitempty"`
 	ClientIP             string `dynamodbav:"client_ip,omitempty"`
 	Token                string `dynamodbav:"token,omitempty"`
 	UserEmail            string `dynamodbav:"user_email,omitempty"`
@@ -60,6 +63,7 @@ func toConnectionItem(conn *api.WebSocketConnection) *connectionItem {
 		Functionality:        conn.Functionality,
 		ExpiresAt:            conn.ExpiresAt,
 		LastEventID:          conn.LastEventID,
+		LastSequence:         conn.LastSequence,
 		ClientIP:             conn.ClientIP,
 		Token:                conn.Token,
 		UserEmail:            conn.UserEmail,
@@ -78,7 +82,7 @@ func (r *ConnectionRepository) CreateConnection(
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to marshal connection item", err)
+		return sdkerrors.Map(err, "failed to marshal connection item", appErrors.ErrDatabaseError)
 	}
 
 	logArgs := []any{
@@ -96,7 +100,7 @@ func (r *ConnectionRepository) CreateConnection(
 		Item:      av,
 	})
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to store connection", err)
+		return sdkerrors.Map(err, "failed to store connection", appErrors.ErrDatabaseError)
 	}
 
 	reqLogger.Debug("connection stored successfully", "context", map[string]string{
@@ -169,7 +173,7 @@ func (r *ConnectionRepository) GetConnectionsByExecutionID(
 		},
 	})
 	if err != nil {
-		return nil, appErrors.ErrDatabaseError("failed to query connections by execution ID", err)
+		return nil, sdkerrors.Map(err, "failed to query connections by execution ID", appErrors.ErrDatabaseError)
 	}
 
 	if len(result.Items) == 0 {
@@ -191,6 +195,7 @@ func (r *ConnectionRepository) GetConnectionsByExecutionID(
 			Functionality:        connItem.Functionality,
 			ExpiresAt:            connItem.ExpiresAt,
 			LastEventID:          connItem.LastEventID,
+			LastSequence:         connItem.LastSequence,
 			ClientIP:             connItem.ClientIP,
 			Token:                connItem.Token,
 			UserEmail:            connItem.UserEmail,
@@ -207,8 +212,14 @@ func (r *ConnectionRepository) GetConnectionsByExecutionID(
 	return connections, nil
 }
 
-// UpdateLastEventID persists the last delivered event ID for a connection.
-func (r *ConnectionRepository) UpdateLastEventID(ctx context.Context, connectionID, lastEventID string) error {
+// UpdateLastEventID persists the last delivered event ID and sequence for a connection.
+// The write is conditioned on the stored sequence being lower, so that overlapping fan-outs of several
+// processor instances cannot move the progress of the connection backwards.
+func (r *ConnectionRepository) UpdateLastEventID(
+	ctx context.Context,
+	connectionID, lastEventID string,
+	lastSequence int64,
+) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
 	if connectionID == "" {
@@ -217,7 +228,7 @@ func (r *ConnectionRepository) UpdateLastEventID(ctx context.Context, connection
 
 	keyAV, err := attributevalue.MarshalMap(map[string]string{"connection_id": connectionID})
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to marshal connection key", err)
+		return sdkerrors.Map(err, "failed to marshal connection key", appErrors.ErrDatabaseError)
 	}
 
 	logArgs := []any{
@@ -231,18 +242,32 @@ func (r *ConnectionRepository) UpdateLastEventID(ctx context.Context, connection
 	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName:        aws.String(r.tableName),
 		Key:              keyAV,
-		UpdateExpression: aws.String("SET last_event_id = :last_event_id"),
+		UpdateExpression: aws.String("SET last_event_id = :last_event_id, last_sequence = :last_sequence"),
+		ConditionExpression: aws.String(
+			"attribute_exists(connection_id) AND " +
+				"(attribute_not_exists(last_sequence) OR last_sequence < :last_sequence)"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":last_event_id": &types.AttributeValueMemberS{Value: lastEventID},
+			":last_sequence": &types.AttributeValueMemberN{Value: strconv.FormatInt(lastSequence, 10)},
 		},
 	})
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to update last event ID", err)
+		var condErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condErr) {
+			reqLogger.Debug("last event ID not updated, connection gone or further along", "context",
+				map[string]any{
+					"connection_id": connectionID,
+					"last_sequence": lastSequence,
+				})
+			return nil
+		}
+		return sdkerrors.Map(err, "failed to update last event ID", appErrors.ErrDatabaseError)
 	}
 
 	reqLogger.Debug("last event ID updated", "context", map[string]any{
 		"connection_id": connectionID,
 		"last_event_id": lastEventID,
+		"last_sequence": lastSequence,
 	})
 
 	return nil
@@ -258,7 +283,7 @@ func (r *ConnectionRepository) buildDeleteRequests(connectionIDs []string) ([]ty
 		}
 		keyAV, err := attributevalue.MarshalMap(key)
 		if err != nil {
-			return nil, appErrors.ErrDatabaseError("failed to marshal connection key", err)
+			return nil, sdkerrors.Map(err, "failed to marshal connection key", appErrors.ErrDatabaseError)
 		}
 
 		deleteRequests = append(deleteRequests, types.WriteRequest{
@@ -289,7 +314,7 @@ func (r *ConnectionRepository) executeBatchDeletes(
 			},
 		})
 		if err != nil {
-			return deletedCount, appErrors.ErrDatabaseError("failed to delete connections batch", err)
+			return deletedCount, sdkerrors.Map(err, "failed to delete connections batch", appErrors.ErrDatabaseError)
 		}
 
 		deletedCount += len(batchRequests)
@@ -11,6 +11,7 @@ import (
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -504,3 +505,48 @@ func TestConnectionRepository_GetConnectionsByExecutionID_ErrorHandling(t *testi
 
 // Note: buildDeleteRequests and executeBatchDeletes are private methods,
 // so we test them indirectly through DeleteConnections
+
+func TestConnectionRepository_UpdateLastEventID(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	t.Run("only moves the progress of the connection forward", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewConnectionRepository(client, "connections-table", logger)
+
+		require.NoError(t, repo.UpdateLastEventID(ctx, "conn-1", "evt-7", 7))
+
+		require.NotNil(t, input)
+		assert.Equal(t, "SET last_event_id = :last_event_id, last_sequence = :last_sequence", *input.UpdateExpression)
+		assert.Contains(t, *input.ConditionExpression, "last_sequence < :last_sequence")
+		assert.Contains(t, *input.ConditionExpression, "attribute_exists(connection_id)")
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "7"}, input.ExpressionAttributeValues[":last_sequence"])
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "evt-7"}, input.ExpressionAttributeValues[":last_event_id"])
+	})
+
+	t.Run("ignores stale progress", func(t *testing.T) {
+		client := NewMockDynamoDBClient()
+		client.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewConnectionRepository(client, "connections-table", logger)
+
+		assert.NoError(t, repo.UpdateLastEventID(ctx, "conn-1", "evt-3", 3))
+	})
+
+	t.Run("returns other errors", func(t *testing.T) {
+		client := NewMockDynamoDBClient()
+		client.UpdateItemError = errors.New("throttled")
+		repo := NewConnectionRepository(client, "connections-table", logger)
+
+		err := repo.UpdateLastEventID(ctx, "conn-1", "evt-3", 3)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update last event ID")
+	})
+}
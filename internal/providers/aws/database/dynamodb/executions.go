@@ -1,19 +1,26 @@
 package dynamodb
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -24,8 +31,16 @@ import (
 const (
 	createdByRequestIDIndexName  = "created_by_request_id-index"
 	modifiedByRequestIDIndexName = "modified_by_request_id-index"
+	groupIDIndexName             = "group_id-index"
+	allStartedAtIndexName        = "all-started_at"
+	statusStartedAtIndexName     = "status-started_at"
+	createdByStartedAtIndexName  = "created_by-started_at"
+	allLogsExpireAtIndexName     = "all-logs_expire_at"
 	createdByRequestIDAttrName   = "created_by_request_id"
 	modifiedByRequestIDAttrName  = "modified_by_request_id"
+	groupIDAttrName              = "group_id"
+	createdByAttrName            = "created_by"
+	searchTextAttrName           = "search_text"
 )
 
 // ExecutionRepository implements the database.ExecutionRepository interface using DynamoDB.
@@ -59,10 +74,72 @@ type executionItem struct {
 	CompletedAt         *int64   `dynamodbav:"completed_at,omitempty"`
 	ExitCode            int      `dynamodbav:"exit_code,omitempty"`
 	DurationSecs        int      `dynamodbav:"duration_seconds,omitempty"`
+	TimeoutSecs         int      `dynamodbav:"timeout_seconds,omitempty"`
+	StopGracePeriodSecs int      `dynamodbav:"stop_grace_period_seconds,omitempty"`
+	EnvVarNames         []string `dynamodbav:"env_var_names,omitempty"`
 	LogStreamName       string   `dynamodbav:"log_stream_name,omitempty"`
 	CreatedByRequestID  string   `dynamodbav:"created_by_request_id,omitempty"`
 	ModifiedByRequestID string   `dynamodbav:"modified_by_request_id,omitempty"`
 	ComputePlatform     string   `dynamodbav:"compute_platform,omitempty"`
+	Visibility          string   `dynamodbav:"visibility,omitempty"`
+	LogMaxLinesPerSec   int      `dynamodbav:"log_max_lines_per_second,omitempty"`
+	LogMaxBytes         int64    `dynamodbav:"log_max_bytes,omitempty"`
+	LogBytes            int64    `dynamodbav:"log_bytes,omitempty"`
+	LogDroppedLines     int64    `dynamodbav:"log_dropped_lines,omitempty"`
+	LogDroppedBytes     int64    `dynamodbav:"log_dropped_bytes,omitempty"`
+	LogRetentionDays    int      `dynamodbav:"log_retention_days,omitempty"`
+	LogsExpireAt        *int64   `dynamodbav:"logs_expire_at,omitempty"`
+	SearchText          string   `dynamodbav:"search_text,omitempty"`
+	FailureReason       string   `dynamodbav:"failure_reason,omitempty"`
+	FailureMessage      string   `dynamodbav:"failure_message,omitempty"`
+	GroupID             string   `dynamodbav:"group_id,omitempty"`
+	ShardIndex          *int     `dynamodbav:"shard_index,omitempty"`
+	Lock                string   `dynamodbav:"lock,omitempty"`
+	ImpersonatedBy      string   `dynamodbav:"impersonated_by,omitempty"`
+	Playbook            string   `dynamodbav:"playbook,omitempty"`
+	ExpectedMaxDuration int      `dynamodbav:"expected_max_duration_seconds,omitempty"`
+	SLOBreached         bool     `dynamodbav:"slo_breached,omitempty"`
+	ImageAlias          string   `dynamodbav:"image_alias,omitempty"`
+	Secrets             []string `dynamodbav:"secrets,omitempty"`
+	Result              string   `dynamodbav:"result,omitempty"`
+	KillRequestedBy     string   `dynamodbav:"kill_requested_by,omitempty"`
+	KillRequestedAt     *int64   `dynamodbav:"kill_requested_at,omitempty"`
+	KillStalled         bool     `dynamodbav:"kill_stalled,omitempty"`
+	Version             int64    `dynamodbav:"version,omitempty"`
+
+	ResourceSummary *resourceSummaryItem `dynamodbav:"resource_summary,omitempty"`
+	Annotations     []annotationItem     `dynamodbav:"annotations,omitempty"`
+	Provenance      *provenanceItem      `dynamodbav:"provenance,omitempty"`
+}
+
+// provenanceItem describes the client that submitted an execution, stored as a map attribute.
+type provenanceItem struct {
+	ClientVersion string `dynamodbav:"client_version,omitempty"`
+	OS            string `dynamodbav:"os,omitempty"`
+	Hostname      string `dynamodbav:"hostname,omitempty"`
+	CIProvider    string `dynamodbav:"ci_provider,omitempty"`
+	CIRunID       string `dynamodbav:"ci_run_id,omitempty"`
+	CIRunURL      string `dynamodbav:"ci_run_url,omitempty"`
+}
+
+// annotationItem is a note attached to an execution, stored in the annotations list attribute.
+// CreatedAt is stored as a Unix timestamp like the other times of the execution.
+type annotationItem struct {
+	Note      string `dynamodbav:"note"`
+	Author    string `dynamodbav:"author"`
+	CreatedAt int64  `dynamodbav:"created_at"`
+}
+
+// resourceSummaryItem is the resource usage summary of a completed execution, stored as a map attribute.
+type resourceSummaryItem struct {
+	Samples               int     `dynamodbav:"samples"`
+	AverageCPUUtilized    float64 `dynamodbav:"average_cpu_utilized"`
+	CPUReserved           float64 `dynamodbav:"cpu_reserved"`
+	PeakMemoryUtilizedMiB float64 `dynamodbav:"peak_memory_utilized_mib"`
+	MemoryReservedMiB     float64 `dynamodbav:"memory_reserved_mib"`
+	NetworkRxBytes        int64   `dynamodbav:"network_rx_bytes"`
+	NetworkTxBytes        int64   `dynamodbav:"network_tx_bytes"`
+	BilledDurationSeconds int     `dynamodbav:"billed_duration_seconds"`
 }
 
 // toExecutionItem converts an api.Execution to an executionItem.
@@ -77,39 +154,144 @@ func toExecutionItem(e *api.Execution) *executionItem {
 		Status:              e.Status,
 		ExitCode:            e.ExitCode,
 		DurationSecs:        e.DurationSeconds,
+		TimeoutSecs:         e.TimeoutSeconds,
+		StopGracePeriodSecs: e.StopGracePeriodSeconds,
+		EnvVarNames:         e.EnvVarNames,
 		LogStreamName:       e.LogStreamName,
 		CreatedByRequestID:  e.CreatedByRequestID,
 		ModifiedByRequestID: e.ModifiedByRequestID,
 		ComputePlatform:     e.ComputePlatform,
+		Visibility:          e.Visibility,
+		FailureReason:       e.FailureReason,
+		FailureMessage:      e.FailureMessage,
+		GroupID:             e.GroupID,
+		ShardIndex:          e.ShardIndex,
+		Lock:                e.Lock,
+		ImpersonatedBy:      e.ImpersonatedBy,
+		Playbook:            e.Playbook,
+		ExpectedMaxDuration: e.ExpectedMaxDurationSeconds,
+		SLOBreached:         e.SLOBreached,
+		ImageAlias:          e.ImageAlias,
+		Secrets:             e.Secrets,
+		Result:              string(e.Result),
+		KillRequestedBy:     e.KillRequestedBy,
+		KillStalled:         e.KillStalled,
+		Version:             e.Version,
+		LogRetentionDays:    e.LogRetentionDays,
+		LogsExpireAt:        logsExpireAt(e),
+		SearchText:          database.ExecutionSearchText(e.Command, e.ImageID, e.ImageAlias, e.Playbook),
 	}
 	if e.CompletedAt != nil {
 		completedAt := e.CompletedAt.Unix()
 		item.CompletedAt = &completedAt
 	}
+	if e.KillRequestedAt != nil {
+		killRequestedAt := e.KillRequestedAt.Unix()
+		item.KillRequestedAt = &killRequestedAt
+	}
+	if e.LogLimits != nil {
+		item.LogMaxLinesPerSec = e.LogLimits.MaxLinesPerSecond
+		item.LogMaxBytes = e.LogLimits.MaxBytes
+	}
+	if e.LogUsage != nil {
+		item.LogBytes = e.LogUsage.Bytes
+		item.LogDroppedLines = e.LogUsage.DroppedLines
+		item.LogDroppedBytes = e.LogUsage.DroppedBytes
+	}
+	if e.ResourceSummary != nil {
+		summary := resourceSummaryItem(*e.ResourceSummary)
+		item.ResourceSummary = &summary
+	}
+	if e.Provenance != nil {
+		provenance := provenanceItem(*e.Provenance)
+		item.Provenance = &provenance
+	}
 	return item
 }
 
+// logsExpireAt returns the time the logs of a completed execution requesting a log retention expire at,
+// indexed by the all-logs_expire_at GSI for the cleanup to delete them. It is nil for the other executions,
+// which are left out of that sparse index.
+func logsExpireAt(e *api.Execution) *int64 {
+	if e.LogRetentionDays <= 0 || e.CompletedAt == nil {
+		return nil
+	}
+	expireAt := e.CompletedAt.AddDate(0, 0, e.LogRetentionDays).Unix()
+	return &expireAt
+}
+
 // toAPIExecution converts an executionItem to an api.Execution.
 func (e *executionItem) toAPIExecution() *api.Execution {
 	exec := &api.Execution{
-		ExecutionID:         e.ExecutionID,
-		StartedAt:           time.Unix(e.StartedAt, 0).UTC(),
-		CreatedBy:           e.CreatedBy,
-		OwnedBy:             e.OwnedBy,
-		Command:             e.Command,
-		ImageID:             e.ImageID,
-		Status:              e.Status,
-		ExitCode:            e.ExitCode,
-		DurationSeconds:     e.DurationSecs,
-		LogStreamName:       e.LogStreamName,
-		CreatedByRequestID:  e.CreatedByRequestID,
-		ModifiedByRequestID: e.ModifiedByRequestID,
-		ComputePlatform:     e.ComputePlatform,
+		ExecutionID:                e.ExecutionID,
+		StartedAt:                  time.Unix(e.StartedAt, 0).UTC(),
+		CreatedBy:                  e.CreatedBy,
+		OwnedBy:                    e.OwnedBy,
+		Command:                    e.Command,
+		ImageID:                    e.ImageID,
+		Status:                     e.Status,
+		ExitCode:                   e.ExitCode,
+		DurationSeconds:            e.DurationSecs,
+		TimeoutSeconds:             e.TimeoutSecs,
+		StopGracePeriodSeconds:     e.StopGracePeriodSecs,
+		EnvVarNames:                e.EnvVarNames,
+		LogStreamName:              e.LogStreamName,
+		CreatedByRequestID:         e.CreatedByRequestID,
+		ModifiedByRequestID:        e.ModifiedByRequestID,
+		ComputePlatform:            e.ComputePlatform,
+		Visibility:                 e.Visibility,
+		FailureReason:              e.FailureReason,
+		FailureMessage:             e.FailureMessage,
+		GroupID:                    e.GroupID,
+		ShardIndex:                 e.ShardIndex,
+		Lock:                       e.Lock,
+		ImpersonatedBy:             e.ImpersonatedBy,
+		Playbook:                   e.Playbook,
+		ExpectedMaxDurationSeconds: e.ExpectedMaxDuration,
+		SLOBreached:                e.SLOBreached,
+		ImageAlias:                 e.ImageAlias,
+		Secrets:                    e.Secrets,
+		KillRequestedBy:            e.KillRequestedBy,
+		KillStalled:                e.KillStalled,
+		Version:                    e.Version,
+		LogRetentionDays:           e.LogRetentionDays,
+	}
+	if e.Result != "" {
+		exec.Result = json.RawMessage(e.Result)
 	}
 	if e.CompletedAt != nil {
 		completedAt := time.Unix(*e.CompletedAt, 0).UTC()
 		exec.CompletedAt = &completedAt
 	}
+	if e.KillRequestedAt != nil {
+		killRequestedAt := time.Unix(*e.KillRequestedAt, 0).UTC()
+		exec.KillRequestedAt = &killRequestedAt
+	}
+	if e.LogMaxLinesPerSec > 0 || e.LogMaxBytes > 0 {
+		exec.LogLimits = &api.LogLimits{MaxLinesPerSecond: e.LogMaxLinesPerSec, MaxBytes: e.LogMaxBytes}
+	}
+	if e.LogBytes > 0 || e.LogDroppedLines > 0 {
+		exec.LogUsage = &api.LogUsage{
+			Bytes:        e.LogBytes,
+			DroppedLines: e.LogDroppedLines,
+			DroppedBytes: e.LogDroppedBytes,
+		}
+	}
+	if e.ResourceSummary != nil {
+		summary := api.ResourceSummary(*e.ResourceSummary)
+		exec.ResourceSummary = &summary
+	}
+	if e.Provenance != nil {
+		provenance := api.ExecutionProvenance(*e.Provenance)
+		exec.Provenance = &provenance
+	}
+	for _, annotation := range e.Annotations {
+		exec.Annotations = append(exec.Annotations, api.ExecutionAnnotation{
+			Note:      annotation.Note,
+			Author:    annotation.Author,
+			CreatedAt: time.Unix(annotation.CreatedAt, 0).UTC(),
+		})
+	}
 	return exec
 }
 
@@ -121,7 +303,7 @@ func (r *ExecutionRepository) CreateExecution(ctx context.Context, execution *ap
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to marshal execution", err)
+		return sdkerrors.Map(err, "failed to marshal execution", apperrors.ErrDatabaseError)
 	}
 
 	// Add _all field for the all-started_at GSI (sparse index pattern)
@@ -150,7 +332,7 @@ func (r *ExecutionRepository) CreateExecution(ctx context.Context, execution *ap
 		if errors.As(err, &ccfe) {
 			return apperrors.ErrConflict("execution already exists", err)
 		}
-		return apperrors.ErrDatabaseError("failed to create execution", err)
+		return sdkerrors.Map(err, "failed to create execution", apperrors.ErrDatabaseError)
 	}
 
 	reqLogger.Debug("execution stored successfully", "execution_id", execution.ExecutionID)
@@ -158,6 +340,58 @@ func (r *ExecutionRepository) CreateExecution(ctx context.Context, execution *ap
 	return nil
 }
 
+// CreateExecutions stores new execution records in a single DynamoDB transaction, so that either all of
+// them are stored or none. At most DynamoDBTransactWriteLimit executions can be created at once.
+func (r *ExecutionRepository) CreateExecutions(ctx context.Context, executions []*api.Execution) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	if len(executions) == 0 {
+		return nil
+	}
+	if len(executions) > awsconstants.DynamoDBTransactWriteLimit {
+		return apperrors.ErrInternalError(fmt.Sprintf("cannot create more than %d executions at once",
+			awsconstants.DynamoDBTransactWriteLimit), nil)
+	}
+
+	transactItems := make([]types.TransactWriteItem, 0, len(executions))
+	for _, execution := range executions {
+		av, err := attributevalue.MarshalMap(toExecutionItem(execution))
+		if err != nil {
+			return apperrors.ErrDatabaseError("failed to marshal execution", err)
+		}
+		av[awsconstants.DynamoDBAllAttribute] = &types.AttributeValueMemberS{Value: awsconstants.DynamoDBAllValue}
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:           aws.String(r.tableName),
+				Item:                av,
+				ConditionExpression: aws.String("attribute_not_exists(execution_id)"),
+			},
+		})
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":       "DynamoDB.TransactWriteItems",
+		"table":           r.tableName,
+		"execution_count": len(executions),
+	})
+
+	_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) && slices.ContainsFunc(canceled.CancellationReasons,
+			func(reason types.CancellationReason) bool {
+				return aws.ToString(reason.Code) == "ConditionalCheckFailed"
+			}) {
+			return apperrors.ErrConflict("execution already exists", err)
+		}
+		return sdkerrors.Map(err, "failed to create executions", apperrors.ErrDatabaseError)
+	}
+
+	reqLogger.Debug("executions stored successfully", "execution_count", len(executions))
+
+	return nil
+}
+
 // GetExecution retrieves an execution by its execution ID.
 func (r *ExecutionRepository) GetExecution(ctx context.Context, executionID string) (*api.Execution, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
@@ -179,7 +413,7 @@ func (r *ExecutionRepository) GetExecution(ctx context.Context, executionID stri
 	})
 
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to get execution", err)
+		return nil, sdkerrors.Map(err, "failed to get execution", apperrors.ErrDatabaseError)
 	}
 
 	if len(result.Item) == 0 {
@@ -188,7 +422,7 @@ func (r *ExecutionRepository) GetExecution(ctx context.Context, executionID stri
 
 	var item executionItem
 	if err = attributevalue.UnmarshalMap(result.Item, &item); err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to unmarshal execution", err)
+		return nil, sdkerrors.Map(err, "failed to unmarshal execution", apperrors.ErrDatabaseError)
 	}
 
 	return item.toAPIExecution(), nil
@@ -215,6 +449,11 @@ func buildUpdateExpression(
 	updateExpr += ", exit_code = :exit_code"
 	exprAttrValues[":exit_code"] = &types.AttributeValueMemberN{Value: strconv.Itoa(execution.ExitCode)}
 
+	if expireAt := logsExpireAt(execution); expireAt != nil {
+		updateExpr += ", logs_expire_at = :logs_expire_at"
+		exprAttrValues[":logs_expire_at"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(*expireAt, 10)}
+	}
+
 	if execution.DurationSeconds > 0 {
 		updateExpr += ", duration_seconds = :duration_seconds"
 		exprAttrValues[":duration_seconds"] = &types.AttributeValueMemberN{
@@ -231,22 +470,73 @@ func buildUpdateExpression(
 		exprAttrValues[":modified_by_request_id"] = &types.AttributeValueMemberS{Value: execution.ModifiedByRequestID}
 	}
 
+	if execution.FailureReason != "" {
+		updateExpr += ", failure_reason = :failure_reason, failure_message = :failure_message"
+		exprAttrValues[":failure_reason"] = &types.AttributeValueMemberS{Value: execution.FailureReason}
+		exprAttrValues[":failure_message"] = &types.AttributeValueMemberS{Value: execution.FailureMessage}
+	}
+
+	if execution.SLOBreached {
+		updateExpr += ", slo_breached = :slo_breached"
+		exprAttrValues[":slo_breached"] = &types.AttributeValueMemberBOOL{Value: true}
+	}
+
+	if execution.KillRequestedAt != nil {
+		updateExpr += ", kill_requested_by = :kill_requested_by, kill_requested_at = :kill_requested_at"
+		exprAttrValues[":kill_requested_by"] = &types.AttributeValueMemberS{Value: execution.KillRequestedBy}
+		exprAttrValues[":kill_requested_at"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(execution.KillRequestedAt.Unix(), 10)}
+	}
+
+	if execution.KillStalled {
+		updateExpr += ", kill_stalled = :kill_stalled"
+		exprAttrValues[":kill_stalled"] = &types.AttributeValueMemberBOOL{Value: true}
+	}
+
+	if execution.ResourceSummary != nil {
+		// A struct of numbers always marshals.
+		summary, err := attributevalue.MarshalMap(resourceSummaryItem(*execution.ResourceSummary))
+		if err == nil {
+			updateExpr += ", resource_summary = :resource_summary"
+			exprAttrValues[":resource_summary"] = &types.AttributeValueMemberM{Value: summary}
+		}
+	}
+
 	return updateExpr, exprNames, exprAttrValues
 }
 
-// UpdateExecution updates an existing execution record.
+// buildStatusTransitionCondition returns the condition of an update of an execution to its status: the
+// stored status is either the same or one the execution can move to the status from, see constants.CanTransition.
+func buildStatusTransitionCondition(status string, exprValues map[string]types.AttributeValue) string {
+	previous := constants.PreviousStatuses(constants.ExecutionStatus(status))
+	if len(previous) == 0 {
+		return "#status = :status"
+	}
+	placeholders := make([]string, 0, len(previous))
+	for i, from := range previous {
+		placeholder := ":from_status_" + strconv.Itoa(i)
+		exprValues[placeholder] = &types.AttributeValueMemberS{Value: string(from)}
+		placeholders = append(placeholders, placeholder)
+	}
+	return "(#status = :status OR #status IN (" + strings.Join(placeholders, ", ") + "))"
+}
+
+// UpdateExecution updates an existing execution record, read at execution.Version. It fails with a
+// concurrent modification error if the execution was updated since, and increments execution.Version.
+// Updates moving the execution to a status it can't move to from its stored status fail with a conflict.
 func (r *ExecutionRepository) UpdateExecution(ctx context.Context, execution *api.Execution) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
-	const conditionExpr = "attribute_exists(execution_id)"
-
 	updateExpr, exprNames, exprValues := buildUpdateExpression(execution)
+	conditionExpr := "attribute_exists(execution_id) AND " +
+		buildStatusTransitionCondition(execution.Status, exprValues)
 
 	updateLogArgs := []any{
 		"operation", "DynamoDB.UpdateItem",
 		"table", r.tableName,
 		"execution_id", execution.ExecutionID,
 		"status", execution.Status,
+		"version", execution.Version,
 		"update_expression", updateExpr,
 	}
 	updateLogArgs = append(updateLogArgs, logger.GetDeadlineInfo(ctx)...)
@@ -262,13 +552,14 @@ func (r *ExecutionRepository) UpdateExecution(ctx context.Context, execution *ap
 		ExpressionAttributeValues: exprValues,
 		ConditionExpression:       aws.String(conditionExpr),
 	}
+	withVersionCondition(input, execution.Version)
 
 	_, updateErr := r.client.UpdateItem(ctx, input)
 
 	if updateErr != nil {
 		var ccfe *types.ConditionalCheckFailedException
 		if errors.As(updateErr, &ccfe) {
-			return apperrors.ErrNotFound("execution not found", updateErr)
+			return r.mapConditionalUpdateError(ctx, execution, ccfe)
 		}
 		reqLogger.Error("update item failed", "context", map[string]any{
 			"error":        updateErr.Error(),
@@ -276,10 +567,381 @@ func (r *ExecutionRepository) UpdateExecution(ctx context.Context, execution *ap
 		})
 		return apperrors.ErrDatabaseError("failed to update execution", updateErr)
 	}
+	execution.Version++
+
+	return nil
+}
+
+// mapConditionalUpdateError maps the failed condition of UpdateExecution, from the stored execution returned
+// with it: a missing execution, an execution updated since it was read or an invalid status transition.
+func (r *ExecutionRepository) mapConditionalUpdateError(
+	ctx context.Context,
+	execution *api.Execution,
+	ccfe *types.ConditionalCheckFailedException,
+) error {
+	if len(ccfe.Item) == 0 {
+		return apperrors.ErrNotFound("execution not found", ccfe)
+	}
+	var stored executionItem
+	if err := attributevalue.UnmarshalMap(ccfe.Item, &stored); err != nil {
+		return apperrors.ErrDatabaseError("failed to unmarshal execution", err)
+	}
+	if stored.Version != execution.Version {
+		return apperrors.ErrConcurrentModification("execution was modified concurrently", nil)
+	}
+	logger.DeriveRequestLogger(ctx, r.logger).Warn("rejected invalid execution status transition", "context",
+		map[string]string{
+			"execution_id":   execution.ExecutionID,
+			"current_status": stored.Status,
+			"target_status":  execution.Status,
+		})
+	return apperrors.ErrConflict(fmt.Sprintf("execution status cannot change from %s to %s",
+		stored.Status, execution.Status), nil)
+}
+
+// AddLogUsage atomically adds the log output ingested and dropped by a batch to an execution's log usage.
+func (r *ExecutionRepository) AddLogUsage(ctx context.Context, executionID string, usage *api.LogUsage) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression: aws.String(
+			"ADD log_bytes :bytes, log_dropped_lines :dropped_lines, log_dropped_bytes :dropped_bytes"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":bytes":         &types.AttributeValueMemberN{Value: strconv.FormatInt(usage.Bytes, 10)},
+			":dropped_lines": &types.AttributeValueMemberN{Value: strconv.FormatInt(usage.DroppedLines, 10)},
+			":dropped_bytes": &types.AttributeValueMemberN{Value: strconv.FormatInt(usage.DroppedBytes, 10)},
+		},
+		ConditionExpression: aws.String("attribute_exists(execution_id)"),
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+	})
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("execution not found", err)
+		}
+		return sdkerrors.Map(err, "failed to update execution log usage", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// MarkExecutionSLOBreached sets the slo_breached attribute of an execution alone, so that flagging a running
+// execution cannot overwrite the status its completion is recorded with concurrently.
+func (r *ExecutionRepository) MarkExecutionSLOBreached(ctx context.Context, executionID string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression: aws.String("SET slo_breached = :slo_breached"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slo_breached": &types.AttributeValueMemberBOOL{Value: true},
+		},
+		ConditionExpression: aws.String("attribute_exists(execution_id)"),
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+	})
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("execution not found", err)
+		}
+		return sdkerrors.Map(err, "failed to flag execution SLO breach", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// SetExecutionResult stores the JSON result reported by an execution as a string attribute.
+func (r *ExecutionRepository) SetExecutionResult(
+	ctx context.Context, executionID string, result json.RawMessage,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression: aws.String("SET #result = :result"),
+		ExpressionAttributeNames: map[string]string{
+			"#result": "result",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":result": &types.AttributeValueMemberS{Value: string(result)},
+		},
+		ConditionExpression: aws.String("attribute_exists(execution_id)"),
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+		"result_bytes": len(result),
+	})
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("execution not found", err)
+		}
+		return sdkerrors.Map(err, "failed to store execution result", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// AddExecutionAnnotation appends a note to the annotations list attribute of an execution,
+// creating the list with the first note.
+func (r *ExecutionRepository) AddExecutionAnnotation(
+	ctx context.Context, executionID string, annotation *api.ExecutionAnnotation,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	av, err := attributevalue.Marshal(annotationItem{
+		Note:      annotation.Note,
+		Author:    annotation.Author,
+		CreatedAt: annotation.CreatedAt.Unix(),
+	})
+	if err != nil {
+		return sdkerrors.Map(err, "failed to marshal execution annotation", apperrors.ErrDatabaseError)
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+	})
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression: aws.String(
+			"SET #annotations = list_append(if_not_exists(#annotations, :empty), :annotation)"),
+		ConditionExpression:      aws.String("attribute_exists(execution_id)"),
+		ExpressionAttributeNames: map[string]string{"#annotations": "annotations"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":empty":      &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+			":annotation": &types.AttributeValueMemberL{Value: []types.AttributeValue{av}},
+		},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("execution not found", err)
+		}
+		return sdkerrors.Map(err, "failed to add execution annotation", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// lifecycleEventsAttrName is the map attribute holding the lifecycle events of an execution, keyed by event type.
+const lifecycleEventsAttrName = "lifecycle_events"
+
+// executionEventItem is the stored form of a lifecycle event, the value of its type in lifecycle_events.
+// The timestamp is stored in Unix milliseconds, as the steps of a fast start are often less than a second apart.
+type executionEventItem struct {
+	Timestamp int64  `dynamodbav:"timestamp"`
+	Reason    string `dynamodbav:"reason,omitempty"`
+}
+
+// AddExecutionEvents records lifecycle events on an execution.
+// Each event is set with if_not_exists on its type, so redelivered events do not overwrite the first occurrence.
+// Nested attributes can only be set once the lifecycle_events map exists: the first events of an execution
+// create the map instead, and a concurrent creation is handled by retrying the nested update.
+func (r *ExecutionRepository) AddExecutionEvents(
+	ctx context.Context, executionID string, events []api.ExecutionEvent,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventItems := make(map[string]types.AttributeValue, len(events))
+	for i := range events {
+		if _, ok := eventItems[events[i].Type]; ok {
+			continue
+		}
+		av, err := attributevalue.Marshal(executionEventItem{
+			Timestamp: events[i].Timestamp.UnixMilli(),
+			Reason:    events[i].Reason,
+		})
+		if err != nil {
+			return sdkerrors.Map(err, "failed to marshal execution event", apperrors.ErrDatabaseError)
+		}
+		eventItems[events[i].Type] = av
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+		"events":       len(eventItems),
+	})
+
+	added, err := r.setExecutionEvents(ctx, executionID, eventItems)
+	if err == nil && !added {
+		added, err = r.createExecutionEvents(ctx, executionID, eventItems)
+		if err == nil && !added {
+			added, err = r.setExecutionEvents(ctx, executionID, eventItems)
+		}
+	}
+	if err != nil {
+		return sdkerrors.Map(err, "failed to add execution events", apperrors.ErrDatabaseError)
+	}
+	if !added {
+		return apperrors.ErrNotFound("execution not found", nil)
+	}
 
 	return nil
 }
 
+// setExecutionEvents adds events to an existing lifecycle_events map.
+// It returns false if the execution or its map does not exist.
+func (r *ExecutionRepository) setExecutionEvents(
+	ctx context.Context, executionID string, eventItems map[string]types.AttributeValue,
+) (bool, error) {
+	assignments := make([]string, 0, len(eventItems))
+	exprNames := map[string]string{"#events": lifecycleEventsAttrName}
+	exprValues := make(map[string]types.AttributeValue, len(eventItems))
+	for _, eventType := range slices.Sorted(maps.Keys(eventItems)) {
+		i := len(assignments)
+		name, value := fmt.Sprintf("#type%d", i), fmt.Sprintf(":event%d", i)
+		assignments = append(assignments, fmt.Sprintf("#events.%s = if_not_exists(#events.%s, %s)", name, name, value))
+		exprNames[name] = eventType
+		exprValues[value] = eventItems[eventType]
+	}
+
+	return r.updateExecutionEvents(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression:          aws.String("SET " + strings.Join(assignments, ", ")),
+		ConditionExpression:       aws.String("attribute_exists(#events)"),
+		ExpressionAttributeNames:  exprNames,
+		ExpressionAttributeValues: exprValues,
+	})
+}
+
+// createExecutionEvents creates the lifecycle_events map of an execution with the given events.
+// It returns false if the execution does not exist or already has the map.
+func (r *ExecutionRepository) createExecutionEvents(
+	ctx context.Context, executionID string, eventItems map[string]types.AttributeValue,
+) (bool, error) {
+	return r.updateExecutionEvents(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression:         aws.String("SET #events = :events"),
+		ConditionExpression:      aws.String("attribute_exists(execution_id) AND attribute_not_exists(#events)"),
+		ExpressionAttributeNames: map[string]string{"#events": lifecycleEventsAttrName},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":events": &types.AttributeValueMemberM{Value: eventItems},
+		},
+	})
+}
+
+// updateExecutionEvents runs a conditional update of the lifecycle events, reporting a failed condition as false.
+func (r *ExecutionRepository) updateExecutionEvents(
+	ctx context.Context, input *dynamodb.UpdateItemInput,
+) (bool, error) {
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ListExecutionEvents returns the lifecycle events recorded on an execution, oldest first.
+// Events recorded at the same time are ordered by their place in the lifecycle.
+func (r *ExecutionRepository) ListExecutionEvents(
+	ctx context.Context, executionID string,
+) ([]api.ExecutionEvent, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+		"execution_id", executionID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.tableName),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		ProjectionExpression:     aws.String("#events"),
+		ExpressionAttributeNames: map[string]string{"#events": lifecycleEventsAttrName},
+	})
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to get execution events", apperrors.ErrDatabaseError)
+	}
+
+	eventsAV, ok := result.Item[lifecycleEventsAttrName]
+	if !ok {
+		return []api.ExecutionEvent{}, nil
+	}
+
+	var eventItems map[string]executionEventItem
+	if err = attributevalue.Unmarshal(eventsAV, &eventItems); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal execution events", apperrors.ErrDatabaseError)
+	}
+
+	events := make([]api.ExecutionEvent, 0, len(eventItems))
+	for eventType, item := range eventItems {
+		events = append(events, api.ExecutionEvent{
+			Type:      eventType,
+			Timestamp: time.UnixMilli(item.Timestamp).UTC(),
+			Reason:    item.Reason,
+		})
+	}
+	slices.SortFunc(events, func(a, b api.ExecutionEvent) int {
+		if c := a.Timestamp.Compare(b.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(lifecycleOrder(a.Type), lifecycleOrder(b.Type))
+	})
+
+	return events, nil
+}
+
+// lifecycleOrder returns the place of an event type in the lifecycle, unknown types last.
+func lifecycleOrder(eventType string) int {
+	i := slices.Index(constants.ExecutionEventTypes(), constants.ExecutionEventType(eventType))
+	if i < 0 {
+		return math.MaxInt
+	}
+	return i
+}
+
 const statusAttrName = "status"
 
 // buildStatusFilterExpression builds a DynamoDB FilterExpression for status filtering.
@@ -320,7 +982,7 @@ func processQueryResults(
 	for _, it := range items {
 		var item executionItem
 		if err := attributevalue.UnmarshalMap(it, &item); err != nil {
-			return nil, false, apperrors.ErrDatabaseError("failed to unmarshal execution", err)
+			return nil, false, sdkerrors.Map(err, "failed to unmarshal execution", apperrors.ErrDatabaseError)
 		}
 
 		executions = append(executions, item.toAPIExecution())
@@ -351,8 +1013,11 @@ func buildQueryLimit(limit int) int32 {
 	}
 }
 
-// buildQueryInput constructs a DynamoDB QueryInput for listing executions.
+// buildQueryInput constructs a DynamoDB QueryInput for listing executions from one of the GSIs
+// sorted by started_at.
 func (r *ExecutionRepository) buildQueryInput(
+	indexName string,
+	keyExpr string,
 	filterExpr string,
 	exprNames map[string]string,
 	exprValues map[string]types.AttributeValue,
@@ -361,8 +1026,8 @@ func (r *ExecutionRepository) buildQueryInput(
 ) *dynamodb.QueryInput {
 	queryInput := &dynamodb.QueryInput{
 		TableName:                 aws.String(r.tableName),
-		IndexName:                 aws.String("all-started_at"),
-		KeyConditionExpression:    aws.String("#all = :all"),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    aws.String(keyExpr),
 		ExpressionAttributeNames:  exprNames,
 		ExpressionAttributeValues: exprValues,
 		ScanIndexForward:          aws.Bool(false), // Sort descending by started_at (newest first)
@@ -380,10 +1045,10 @@ func (r *ExecutionRepository) buildQueryInput(
 	return queryInput
 }
 
-// ListExecutions queries the executions table using the all-started_at GSI
-// to return execution records sorted by StartedAt descending (newest first).
-// This uses Query instead of Scan for better performance and native sorting by DynamoDB.
-// Status filtering and limiting are handled natively by DynamoDB using FilterExpression and Limit.
+// ListExecutions queries the executions table to return execution records sorted by StartedAt descending
+// (newest first). Without statuses it queries the all-started_at GSI. With statuses it queries the
+// status-started_at GSI once per status and merges the results, so that listing the few running executions
+// does not read through the whole history.
 //
 // Parameters:
 //   - limit: maximum number of executions to return. Use 0 to return all executions.
@@ -395,12 +1060,24 @@ func (r *ExecutionRepository) ListExecutions(
 	statuses []string,
 ) ([]*api.Execution, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
-	initialCapacity := limit
-	if initialCapacity <= 0 {
-		initialCapacity = awsconstants.DefaultExecutionListCapacity
+
+	if len(statuses) > 0 {
+		reqLogger.Debug("calling external service", "context", map[string]any{
+			"operation": "DynamoDB.Query",
+			"table":     r.tableName,
+			"index":     statusStartedAtIndexName,
+			"statuses":  statuses,
+			"paginated": "true",
+		})
+		return r.listExecutionsByStatus(ctx, limit, statuses, "", nil, nil)
 	}
-	executions := make([]*api.Execution, 0, initialCapacity)
-	var lastKey map[string]types.AttributeValue
+
+	reqLogger.Debug("calling external service", "context", map[string]string{
+		"operation": "DynamoDB.Query",
+		"table":     r.tableName,
+		"index":     allStartedAtIndexName,
+		"paginated": "true",
+	})
 
 	exprNames := map[string]string{
 		"#all": awsconstants.DynamoDBAllAttribute,
@@ -408,22 +1085,202 @@ func (r *ExecutionRepository) ListExecutions(
 	exprValues := map[string]types.AttributeValue{
 		":all": &types.AttributeValueMemberS{Value: awsconstants.DynamoDBAllValue},
 	}
+	return r.queryExecutions(ctx, allStartedAtIndexName, "#all = :all", "", exprNames, exprValues, limit)
+}
 
+// ListExecutionsByCreator queries the created_by-started_at GSI to return the executions created by the user,
+// sorted by StartedAt descending (newest first). Statuses are filtered with a FilterExpression, as the
+// executions of a single user are few compared to the whole history.
+func (r *ExecutionRepository) ListExecutionsByCreator(
+	ctx context.Context,
+	createdBy string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":  "DynamoDB.Query",
+		"table":      r.tableName,
+		"index":      createdByStartedAtIndexName,
+		"created_by": createdBy,
+		"statuses":   statuses,
+		"paginated":  "true",
+	})
+
+	exprNames := map[string]string{
+		"#key": createdByAttrName,
+	}
+	exprValues := map[string]types.AttributeValue{
+		":key": &types.AttributeValueMemberS{Value: createdBy},
+	}
 	filterExpr := buildStatusFilterExpression(statuses, exprNames, exprValues)
+	return r.queryExecutions(ctx, createdByStartedAtIndexName, "#key = :key", filterExpr, exprNames, exprValues, limit)
+}
 
-	reqLogger.Debug("calling external service", "context", map[string]string{
+// ListExecutionsWithExpiredLogs queries the sparse all-logs_expire_at GSI for the completed executions whose
+// log retention expired before the time, up to limit, 0 returning them all.
+func (r *ExecutionRepository) ListExecutionsWithExpiredLogs(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]*api.Execution, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+	reqLogger.Debug("calling external service", "context", map[string]any{
 		"operation": "DynamoDB.Query",
 		"table":     r.tableName,
-		"index":     "all-started_at",
+		"index":     allLogsExpireAtIndexName,
+		"before":    before.Unix(),
 		"paginated": "true",
 	})
 
+	exprNames := map[string]string{
+		"#all":       awsconstants.DynamoDBAllAttribute,
+		"#expire_at": "logs_expire_at",
+	}
+	exprValues := map[string]types.AttributeValue{
+		":all":    &types.AttributeValueMemberS{Value: awsconstants.DynamoDBAllValue},
+		":before": &types.AttributeValueMemberN{Value: strconv.FormatInt(before.Unix(), 10)},
+	}
+	return r.queryExecutions(ctx, allLogsExpireAtIndexName, "#all = :all AND #expire_at < :before", "",
+		exprNames, exprValues, limit)
+}
+
+// ClearExecutionLogsExpiry removes the logs_expire_at attribute of an execution whose logs were deleted,
+// taking it out of the all-logs_expire_at GSI.
+func (r *ExecutionRepository) ClearExecutionLogsExpiry(ctx context.Context, executionID string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		UpdateExpression:    aws.String("REMOVE logs_expire_at"),
+		ConditionExpression: aws.String("attribute_exists(execution_id)"),
+	}
+
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation":    "DynamoDB.UpdateItem",
+		"table":        r.tableName,
+		"execution_id": executionID,
+	})
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("execution not found", err)
+		}
+		return sdkerrors.Map(err, "failed to clear execution logs expiry", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// SearchExecutions queries the GSIs of ListExecutions, filtering the executions with a contains condition on
+// their search_text attribute per term. DynamoDB applies the filter to each page it reads, so that only the
+// matching executions are returned, though filling the limit may read through the whole index.
+func (r *ExecutionRepository) SearchExecutions(
+	ctx context.Context,
+	terms []string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	if len(terms) == 0 {
+		return r.ListExecutions(ctx, limit, statuses)
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+	reqLogger.Debug("calling external service", "context", map[string]any{
+		"operation": "DynamoDB.Query",
+		"table":     r.tableName,
+		"terms":     terms,
+		"statuses":  statuses,
+		"paginated": "true",
+	})
+
+	exprNames := map[string]string{
+		"#search": searchTextAttrName,
+	}
+	exprValues := make(map[string]types.AttributeValue, len(terms))
+	conditions := make([]string, 0, len(terms))
+	for i, term := range terms {
+		placeholder := ":term" + strconv.Itoa(i)
+		exprValues[placeholder] = &types.AttributeValueMemberS{Value: term}
+		conditions = append(conditions, "contains(#search, "+placeholder+")")
+	}
+	filterExpr := strings.Join(conditions, " AND ")
+
+	if len(statuses) > 0 {
+		return r.listExecutionsByStatus(ctx, limit, statuses, filterExpr, exprNames, exprValues)
+	}
+	exprNames["#all"] = awsconstants.DynamoDBAllAttribute
+	exprValues[":all"] = &types.AttributeValueMemberS{Value: awsconstants.DynamoDBAllValue}
+	return r.queryExecutions(ctx, allStartedAtIndexName, "#all = :all", filterExpr, exprNames, exprValues, limit)
+}
+
+// listExecutionsByStatus queries the status-started_at GSI for each status and merges the results newest first.
+// Each query stops at the limit, the newest executions of all statuses being among the newest of each.
+// A non-empty filterExpr filters each query, with the filterNames and filterValues it refers to.
+func (r *ExecutionRepository) listExecutionsByStatus(
+	ctx context.Context,
+	limit int,
+	statuses []string,
+	filterExpr string,
+	filterNames map[string]string,
+	filterValues map[string]types.AttributeValue,
+) ([]*api.Execution, error) {
+	var executions []*api.Execution
+	for _, status := range slices.Compact(slices.Sorted(slices.Values(statuses))) {
+		exprNames := map[string]string{
+			"#key": statusAttrName,
+		}
+		exprValues := map[string]types.AttributeValue{
+			":key": &types.AttributeValueMemberS{Value: status},
+		}
+		maps.Copy(exprNames, filterNames)
+		maps.Copy(exprValues, filterValues)
+		byStatus, err := r.queryExecutions(
+			ctx, statusStartedAtIndexName, "#key = :key", filterExpr, exprNames, exprValues, limit)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, byStatus...)
+	}
+
+	slices.SortStableFunc(executions, func(a, b *api.Execution) int { return b.StartedAt.Compare(a.StartedAt) })
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	if executions == nil {
+		executions = []*api.Execution{}
+	}
+	return executions, nil
+}
+
+// queryExecutions pages through a GSI sorted by started_at until the limit is reached, all matching executions
+// being returned when limit is 0.
+func (r *ExecutionRepository) queryExecutions(
+	ctx context.Context,
+	indexName string,
+	keyExpr string,
+	filterExpr string,
+	exprNames map[string]string,
+	exprValues map[string]types.AttributeValue,
+	limit int,
+) ([]*api.Execution, error) {
+	initialCapacity := limit
+	if initialCapacity <= 0 {
+		initialCapacity = awsconstants.DefaultExecutionListCapacity
+	}
+	executions := make([]*api.Execution, 0, initialCapacity)
+	var lastKey map[string]types.AttributeValue
+
 	for {
-		queryInput := r.buildQueryInput(filterExpr, exprNames, exprValues, lastKey, limit)
+		queryInput := r.buildQueryInput(indexName, keyExpr, filterExpr, exprNames, exprValues, lastKey, limit)
 
 		out, err := r.client.Query(ctx, queryInput)
 		if err != nil {
-			return nil, apperrors.ErrDatabaseError("failed to query executions", err)
+			return nil, sdkerrors.Map(err, "failed to query executions", apperrors.ErrDatabaseError)
 		}
 
 		var reachedLimit bool
@@ -445,38 +1302,40 @@ func (r *ExecutionRepository) ListExecutions(
 	return executions, nil
 }
 
-// queryExecutionsByRequestIDIndex queries a GSI by request ID and returns all matching executions.
-func (r *ExecutionRepository) queryExecutionsByRequestIDIndex(
+// queryExecutionsByIndex queries a GSI by its partition key and returns all matching executions.
+func (r *ExecutionRepository) queryExecutionsByIndex(
 	ctx context.Context,
 	indexName string,
-	requestID string,
+	key string,
 ) ([]*api.Execution, error) {
 	executions := make([]*api.Execution, 0)
 	var lastKey map[string]types.AttributeValue
 
-	var attributeName string
+	var attributeName, keyDescription string
 	switch indexName {
 	case createdByRequestIDIndexName:
-		attributeName = createdByRequestIDAttrName
+		attributeName, keyDescription = createdByRequestIDAttrName, "request ID"
 	case modifiedByRequestIDIndexName:
-		attributeName = modifiedByRequestIDAttrName
+		attributeName, keyDescription = modifiedByRequestIDAttrName, "request ID"
+	case groupIDIndexName:
+		attributeName, keyDescription = groupIDAttrName, "group ID"
 	default:
 		return nil, apperrors.ErrDatabaseError(
 			"unknown index name: "+indexName, nil)
 	}
 
 	exprNames := map[string]string{
-		"#request_id": attributeName,
+		"#key": attributeName,
 	}
 	exprValues := map[string]types.AttributeValue{
-		":request_id": &types.AttributeValueMemberS{Value: requestID},
+		":key": &types.AttributeValueMemberS{Value: key},
 	}
 
 	for {
 		queryInput := &dynamodb.QueryInput{
 			TableName:                 aws.String(r.tableName),
 			IndexName:                 aws.String(indexName),
-			KeyConditionExpression:    aws.String("#request_id = :request_id"),
+			KeyConditionExpression:    aws.String("#key = :key"),
 			ExpressionAttributeNames:  exprNames,
 			ExpressionAttributeValues: exprValues,
 			ScanIndexForward:          aws.Bool(false),
@@ -486,13 +1345,13 @@ func (r *ExecutionRepository) queryExecutionsByRequestIDIndex(
 		result, err := r.client.Query(ctx, queryInput)
 		if err != nil {
 			return nil, apperrors.ErrDatabaseError(
-				"failed to query executions by request ID from "+indexName, err)
+				"failed to query executions by "+keyDescription+" from "+indexName, err)
 		}
 
 		for _, item := range result.Items {
 			var execItem executionItem
 			if err = attributevalue.UnmarshalMap(item, &execItem); err != nil {
-				return nil, apperrors.ErrDatabaseError("failed to unmarshal execution item", err)
+				return nil, sdkerrors.Map(err, "failed to unmarshal execution item", apperrors.ErrDatabaseError)
 			}
 			executions = append(executions, execItem.toAPIExecution())
 		}
@@ -523,12 +1382,12 @@ func (r *ExecutionRepository) GetExecutionsByRequestID(
 	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
 	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
 
-	createdExecutions, err := r.queryExecutionsByRequestIDIndex(ctx, createdByRequestIDIndexName, requestID)
+	createdExecutions, err := r.queryExecutionsByIndex(ctx, createdByRequestIDIndexName, requestID)
 	if err != nil {
 		return nil, err
 	}
 
-	modifiedExecutions, err := r.queryExecutionsByRequestIDIndex(ctx, modifiedByRequestIDIndexName, requestID)
+	modifiedExecutions, err := r.queryExecutionsByIndex(ctx, modifiedByRequestIDIndexName, requestID)
 	if err != nil {
 		return nil, err
 	}
@@ -548,3 +1407,22 @@ func (r *ExecutionRepository) GetExecutionsByRequestID(
 
 	return executions, nil
 }
+
+// GetExecutionsByGroupID retrieves all executions started as the shards of a parallel run,
+// using a Query on the group_id-index GSI. Executions outside of any group are not in the index.
+func (r *ExecutionRepository) GetExecutionsByGroupID(
+	ctx context.Context, groupID string,
+) ([]*api.Execution, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+		"group_id", groupID,
+		"index", groupIDIndexName,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	return r.queryExecutionsByIndex(ctx, groupIDIndexName, groupID)
+}
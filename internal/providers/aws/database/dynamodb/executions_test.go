@@ -2,16 +2,22 @@ package dynamodb
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -163,6 +169,27 @@ func TestRoundTripConversion(t *testing.T) {
 		CreatedByRequestID:  "req-789",
 		ModifiedByRequestID: "req-abc",
 		ComputePlatform:     "AWS",
+		Visibility:          "private",
+		LogLimits:           &api.LogLimits{MaxLinesPerSecond: 50, MaxBytes: 1024},
+		LogUsage:            &api.LogUsage{Bytes: 512, DroppedLines: 3, DroppedBytes: 30},
+		ResourceSummary: &api.ResourceSummary{
+			Samples:               5,
+			AverageCPUUtilized:    312.5,
+			CPUReserved:           1024,
+			PeakMemoryUtilizedMiB: 1800,
+			MemoryReservedMiB:     2048,
+			NetworkRxBytes:        4096,
+			NetworkTxBytes:        1024,
+			BilledDurationSeconds: 305,
+		},
+		Provenance: &api.ExecutionProvenance{
+			ClientVersion: "v1.2.3",
+			OS:            "linux/amd64",
+			Hostname:      "runner-1",
+			CIProvider:    "github-actions",
+			CIRunID:       "42",
+			CIRunURL:      "https://github.com/acme/app/actions/runs/42",
+		},
 	}
 
 	// Convert to item and back
@@ -180,6 +207,11 @@ func TestRoundTripConversion(t *testing.T) {
 	assert.Equal(t, original.CreatedByRequestID, result.CreatedByRequestID)
 	assert.Equal(t, original.ModifiedByRequestID, result.ModifiedByRequestID)
 	assert.Equal(t, original.ComputePlatform, result.ComputePlatform)
+	assert.Equal(t, original.Visibility, result.Visibility)
+	assert.Equal(t, original.LogLimits, result.LogLimits)
+	assert.Equal(t, original.LogUsage, result.LogUsage)
+	assert.Equal(t, original.ResourceSummary, result.ResourceSummary)
+	assert.Equal(t, original.Provenance, result.Provenance)
 
 	require.NotNil(t, result.CompletedAt)
 	assert.Equal(t, completed.Unix(), result.CompletedAt.Unix())
@@ -430,6 +462,44 @@ func TestBuildUpdateExpression(t *testing.T) {
 			expectedExprValueKeys: []string{":status", ":completed_at", ":exit_code"},
 			wantErr:               false,
 		},
+		{
+			name: "failed execution with failure reason",
+			execution: &api.Execution{
+				ExecutionID:    "exec-oom",
+				Status:         "FAILED",
+				CompletedAt:    &completed,
+				ExitCode:       137,
+				FailureReason:  "OOM_KILLED",
+				FailureMessage: "OutOfMemoryError: Container killed due to memory usage",
+			},
+			expectedUpdateExpr: "SET #status = :status, completed_at = :completed_at, exit_code = :exit_code, " +
+				"failure_reason = :failure_reason, failure_message = :failure_message",
+			expectedExprNames: map[string]string{
+				"#status": "status",
+			},
+			expectedExprValueKeys: []string{":status", ":completed_at", ":exit_code", ":failure_reason", ":failure_message"},
+			wantErr:               false,
+		},
+		{
+			name: "completed execution with resource summary",
+			execution: &api.Execution{
+				ExecutionID: "exec-summary",
+				Status:      "SUCCEEDED",
+				CompletedAt: &completed,
+				ResourceSummary: &api.ResourceSummary{
+					Samples:               2,
+					PeakMemoryUtilizedMiB: 900,
+					BilledDurationSeconds: 120,
+				},
+			},
+			expectedUpdateExpr: "SET #status = :status, completed_at = :completed_at, exit_code = :exit_code, " +
+				"resource_summary = :resource_summary",
+			expectedExprNames: map[string]string{
+				"#status": "status",
+			},
+			expectedExprValueKeys: []string{":status", ":completed_at", ":exit_code", ":resource_summary"},
+			wantErr:               false,
+		},
 		{
 			name: "execution with DurationSeconds but no CompletedAt",
 			execution: &api.Execution{
@@ -634,6 +704,70 @@ func TestExecutionRepository_CreateExecution(t *testing.T) {
 	})
 }
 
+func TestExecutionRepository_CreateExecutions(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	tableName := "test-executions-table"
+	newExecution := func(executionID string) *api.Execution {
+		return &api.Execution{
+			ExecutionID: executionID,
+			StartedAt:   time.Now(),
+			CreatedBy:   "user@example.com",
+			OwnedBy:     []string{"user@example.com"},
+			Command:     "echo hello",
+			Status:      "STARTING",
+		}
+	}
+
+	t.Run("creates the executions in a single transaction", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		err := repo.CreateExecutions(ctx, []*api.Execution{newExecution("exec-1"), newExecution("exec-2")})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockClient.TransactWriteCalls)
+		assert.Zero(t, mockClient.PutItemCalls)
+		assert.Len(t, mockClient.collectTableItems(tableName), 2)
+	})
+
+	t.Run("creates none when one already exists", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+		require.NoError(t, repo.CreateExecution(ctx, newExecution("exec-2")))
+
+		err := repo.CreateExecutions(ctx, []*api.Execution{newExecution("exec-1"), newExecution("exec-2")})
+
+		require.Error(t, err)
+		assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+		assert.Len(t, mockClient.collectTableItems(tableName), 1)
+	})
+
+	t.Run("maps the transaction errors", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.TransactWriteError = &types.ProvisionedThroughputExceededException{}
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		err := repo.CreateExecutions(ctx, []*api.Execution{newExecution("exec-1")})
+
+		assert.Equal(t, apperrors.ErrCodeThrottled, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("refuses more executions than a transaction holds", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+		executions := make([]*api.Execution, awsconstants.DynamoDBTransactWriteLimit+1)
+		for i := range executions {
+			executions[i] = newExecution(fmt.Sprintf("exec-%d", i))
+		}
+
+		err := repo.CreateExecutions(ctx, executions)
+
+		require.Error(t, err)
+		assert.Zero(t, mockClient.TransactWriteCalls)
+	})
+}
+
 func TestExecutionRepository_GetExecution(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
@@ -751,6 +885,65 @@ func TestExecutionRepository_UpdateExecution(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.Equal(t, 1, mockClient.UpdateItemCalls)
+		assert.Equal(t, int64(1), execution.Version)
+	})
+
+	t.Run("updates the execution at the version read", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, tableName, logger)
+		execution := &api.Execution{ExecutionID: "exec-123", Status: "TERMINATING", Version: 2}
+
+		require.NoError(t, repo.UpdateExecution(ctx, execution))
+
+		require.NotNil(t, input)
+		assert.Equal(t, "attribute_exists(execution_id) AND (#status = :status OR #status IN (:from_status_0, "+
+			":from_status_1)) AND #version = :version", *input.ConditionExpression)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "RUNNING"}, input.ExpressionAttributeValues[":from_status_0"])
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "STARTING"}, input.ExpressionAttributeValues[":from_status_1"])
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "2"}, input.ExpressionAttributeValues[":version"])
+		assert.Equal(t, int64(3), execution.Version)
+	})
+
+	t.Run("fails when the execution was modified concurrently", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{
+			Item: map[string]types.AttributeValue{
+				"execution_id": &types.AttributeValueMemberS{Value: "exec-123"},
+				"version":      &types.AttributeValueMemberN{Value: "3"},
+			},
+		}
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+		execution := &api.Execution{ExecutionID: "exec-123", Status: "TERMINATING", Version: 2}
+
+		err := repo.UpdateExecution(ctx, execution)
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+		assert.Equal(t, apperrors.ErrCodeConcurrentModification, apperrors.GetErrorCode(err))
+		assert.Equal(t, int64(2), execution.Version)
+	})
+
+	t.Run("rejects an invalid status transition", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{
+			Item: map[string]types.AttributeValue{
+				"execution_id": &types.AttributeValueMemberS{Value: "exec-123"},
+				"status":       &types.AttributeValueMemberS{Value: "SUCCEEDED"},
+				"version":      &types.AttributeValueMemberN{Value: "2"},
+			},
+		}
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		err := repo.UpdateExecution(ctx, &api.Execution{ExecutionID: "exec-123", Status: "RUNNING", Version: 2})
+
+		assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+		assert.Contains(t, apperrors.GetErrorMessage(err), "from SUCCEEDED to RUNNING")
 	})
 
 	t.Run("handles execution not found", func(t *testing.T) {
@@ -788,6 +981,438 @@ func TestExecutionRepository_UpdateExecution(t *testing.T) {
 	})
 }
 
+func TestBuildStatusTransitionCondition(t *testing.T) {
+	t.Run("only the same status for STARTING", func(t *testing.T) {
+		values := map[string]types.AttributeValue{}
+
+		condition := buildStatusTransitionCondition("STARTING", values)
+
+		assert.Equal(t, "#status = :status", condition)
+		assert.Empty(t, values)
+	})
+
+	t.Run("the same status or a previous status", func(t *testing.T) {
+		values := map[string]types.AttributeValue{}
+
+		condition := buildStatusTransitionCondition("RUNNING", values)
+
+		assert.Equal(t, "(#status = :status OR #status IN (:from_status_0))", condition)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "STARTING"}, values[":from_status_0"])
+	})
+}
+
+func TestExecutionRepository_AddLogUsage(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	tableName := "test-executions-table"
+
+	t.Run("adds usage atomically", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.Tables[tableName] = map[string]map[string]map[string]types.AttributeValue{
+			"exec-123": {"": {"execution_id": &types.AttributeValueMemberS{Value: "exec-123"}}},
+		}
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		err := repo.AddLogUsage(ctx, "exec-123", &api.LogUsage{Bytes: 100, DroppedLines: 2, DroppedBytes: 20})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockClient.UpdateItemCalls)
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		err := repo.AddLogUsage(ctx, "exec-123", &api.LogUsage{Bytes: 100})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "execution not found")
+	})
+}
+
+func TestExecutionRepository_AddExecutionAnnotation(t *testing.T) {
+	ctx := context.Background()
+	annotation := &api.ExecutionAnnotation{
+		Note:      "caused by an upstream outage",
+		Author:    "alice@example.com",
+		CreatedAt: time.Unix(1700000000, 0),
+	}
+
+	t.Run("appends the annotation", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.AddExecutionAnnotation(ctx, "exec-123", annotation))
+
+		require.NotNil(t, input)
+		assert.Contains(t, *input.UpdateExpression, "list_append(if_not_exists(#annotations, :empty), :annotation)")
+		assert.Equal(t, "attribute_exists(execution_id)", *input.ConditionExpression)
+		var stored []annotationItem
+		require.NoError(t, attributevalue.Unmarshal(input.ExpressionAttributeValues[":annotation"], &stored))
+		assert.Equal(t, []annotationItem{
+			{Note: "caused by an upstream outage", Author: "alice@example.com", CreatedAt: 1700000000},
+		}, stored)
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewExecutionRepository(mockClient, "executions", testutil.SilentLogger())
+
+		err := repo.AddExecutionAnnotation(ctx, "exec-123", annotation)
+
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("returns annotations with the execution", func(t *testing.T) {
+		item := &executionItem{
+			ExecutionID: "exec-123",
+			Annotations: []annotationItem{{Note: "flaky", Author: "bob@example.com", CreatedAt: 1700000000}},
+		}
+
+		execution := item.toAPIExecution()
+
+		require.Len(t, execution.Annotations, 1)
+		assert.Equal(t, "flaky", execution.Annotations[0].Note)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), execution.Annotations[0].CreatedAt)
+	})
+}
+
+func TestExecutionRepository_MarkExecutionSLOBreached(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sets the flag alone", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.MarkExecutionSLOBreached(ctx, "exec-123"))
+
+		require.NotNil(t, input)
+		assert.Equal(t, "SET slo_breached = :slo_breached", *input.UpdateExpression)
+		assert.Equal(t, "attribute_exists(execution_id)", *input.ConditionExpression)
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewExecutionRepository(mockClient, "executions", testutil.SilentLogger())
+
+		err := repo.MarkExecutionSLOBreached(ctx, "exec-123")
+
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("round trips the SLO of the execution", func(t *testing.T) {
+		execution := &api.Execution{
+			ExecutionID:                "exec-123",
+			Playbook:                   "deploy",
+			ExpectedMaxDurationSeconds: 600,
+			SLOBreached:                true,
+		}
+
+		stored := toExecutionItem(execution).toAPIExecution()
+
+		assert.Equal(t, "deploy", stored.Playbook)
+		assert.Equal(t, 600, stored.ExpectedMaxDurationSeconds)
+		assert.True(t, stored.SLOBreached)
+		updateExpr, _, _ := buildUpdateExpression(execution)
+		assert.Contains(t, updateExpr, "slo_breached = :slo_breached")
+	})
+}
+
+func TestExecutionRepository_LogsExpiry(t *testing.T) {
+	ctx := context.Background()
+	completedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("indexes the logs expiry of completed executions", func(t *testing.T) {
+		execution := &api.Execution{
+			ExecutionID:      "exec-123",
+			Status:           "SUCCEEDED",
+			CompletedAt:      &completedAt,
+			LogRetentionDays: 7,
+		}
+
+		item := toExecutionItem(execution)
+
+		require.NotNil(t, item.LogsExpireAt)
+		assert.Equal(t, completedAt.AddDate(0, 0, 7).Unix(), *item.LogsExpireAt)
+		assert.Equal(t, 7, item.toAPIExecution().LogRetentionDays)
+		updateExpr, _, exprValues := buildUpdateExpression(execution)
+		assert.Contains(t, updateExpr, "logs_expire_at = :logs_expire_at")
+		assert.Contains(t, exprValues, ":logs_expire_at")
+
+		execution.CompletedAt = nil
+		assert.Nil(t, toExecutionItem(execution).LogsExpireAt, "running executions are not indexed")
+		execution.CompletedAt, execution.LogRetentionDays = &completedAt, 0
+		assert.Nil(t, toExecutionItem(execution).LogsExpireAt, "executions without retention are not indexed")
+	})
+
+	t.Run("queries the logs expiry index", func(t *testing.T) {
+		var input *dynamodb.QueryInput
+		client := &queryCapturingClient{Client: NewMockDynamoDBClient(), capture: func(in *dynamodb.QueryInput) {
+			input = in
+		}}
+		_, err := NewExecutionRepository(client, "executions", testutil.SilentLogger()).
+			ListExecutionsWithExpiredLogs(ctx, completedAt, 100)
+
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, allLogsExpireAtIndexName, aws.ToString(input.IndexName))
+		assert.Equal(t, "#all = :all AND #expire_at < :before", aws.ToString(input.KeyConditionExpression))
+		assert.Equal(t, &types.AttributeValueMemberN{Value: strconv.FormatInt(completedAt.Unix(), 10)},
+			input.ExpressionAttributeValues[":before"])
+	})
+
+	t.Run("clears the logs expiry", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.ClearExecutionLogsExpiry(ctx, "exec-123"))
+
+		require.NotNil(t, input)
+		assert.Equal(t, "REMOVE logs_expire_at", aws.ToString(input.UpdateExpression))
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewExecutionRepository(mockClient, "executions", testutil.SilentLogger())
+
+		err := repo.ClearExecutionLogsExpiry(ctx, "exec-123")
+
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+}
+
+func TestExecutionRepository_KillRequest(t *testing.T) {
+	t.Run("round trips the kill of the execution", func(t *testing.T) {
+		killRequestedAt := time.Unix(1700000000, 0).UTC()
+		execution := &api.Execution{
+			ExecutionID:     "exec-123",
+			Status:          "TERMINATING",
+			KillRequestedBy: "alice@example.com",
+			KillRequestedAt: &killRequestedAt,
+			KillStalled:     true,
+		}
+
+		stored := toExecutionItem(execution).toAPIExecution()
+
+		assert.Equal(t, "alice@example.com", stored.KillRequestedBy)
+		assert.Equal(t, &killRequestedAt, stored.KillRequestedAt)
+		assert.True(t, stored.KillStalled)
+	})
+
+	t.Run("updates the kill with the status", func(t *testing.T) {
+		killRequestedAt := time.Unix(1700000000, 0).UTC()
+		updateExpr, _, values := buildUpdateExpression(&api.Execution{
+			ExecutionID:     "exec-123",
+			Status:          "TERMINATING",
+			KillRequestedBy: "alice@example.com",
+			KillRequestedAt: &killRequestedAt,
+		})
+
+		assert.Contains(t, updateExpr, "kill_requested_by = :kill_requested_by, kill_requested_at = :kill_requested_at")
+		assert.NotContains(t, updateExpr, "kill_stalled")
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "alice@example.com"}, values[":kill_requested_by"])
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "1700000000"}, values[":kill_requested_at"])
+	})
+
+	t.Run("leaves the kill of executions not killed", func(t *testing.T) {
+		updateExpr, _, _ := buildUpdateExpression(&api.Execution{ExecutionID: "exec-123", Status: "RUNNING"})
+
+		assert.NotContains(t, updateExpr, "kill_")
+	})
+}
+
+func TestExecutionRepository_SetExecutionResult(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the result as a string attribute", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.SetExecutionResult(ctx, "exec-123", json.RawMessage(`{"summary":"ok"}`)))
+
+		require.NotNil(t, input)
+		assert.Equal(t, "SET #result = :result", *input.UpdateExpression)
+		assert.Equal(t, "attribute_exists(execution_id)", *input.ConditionExpression)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: `{"summary":"ok"}`}, input.ExpressionAttributeValues[":result"])
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewExecutionRepository(mockClient, "executions", testutil.SilentLogger())
+
+		err := repo.SetExecutionResult(ctx, "exec-123", json.RawMessage(`{}`))
+
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+
+	t.Run("round trips the result of the execution", func(t *testing.T) {
+		execution := &api.Execution{ExecutionID: "exec-123", Result: json.RawMessage(`[1,2]`)}
+
+		stored := toExecutionItem(execution).toAPIExecution()
+
+		assert.JSONEq(t, `[1,2]`, string(stored.Result))
+		assert.Nil(t, toExecutionItem(&api.Execution{}).toAPIExecution().Result)
+	})
+}
+
+func TestExecutionRepository_AddExecutionEvents(t *testing.T) {
+	ctx := context.Background()
+	events := []api.ExecutionEvent{
+		{Type: "PROVISIONING", Timestamp: time.UnixMilli(1000)},
+		{Type: "STOPPED", Timestamp: time.UnixMilli(3000), Reason: "Essential container in task exited"},
+		{Type: "PROVISIONING", Timestamp: time.UnixMilli(2000)},
+	}
+
+	t.Run("sets events on the existing map", func(t *testing.T) {
+		var inputs []*dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				inputs = append(inputs, params)
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.AddExecutionEvents(ctx, "exec-123", events))
+
+		require.Len(t, inputs, 1)
+		assert.Equal(t,
+			"SET #events.#type0 = if_not_exists(#events.#type0, :event0), "+
+				"#events.#type1 = if_not_exists(#events.#type1, :event1)",
+			aws.ToString(inputs[0].UpdateExpression))
+		assert.Equal(t, "PROVISIONING", inputs[0].ExpressionAttributeNames["#type0"])
+		assert.Equal(t, &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"timestamp": &types.AttributeValueMemberN{Value: "1000"},
+		}}, inputs[0].ExpressionAttributeValues[":event0"])
+	})
+
+	t.Run("creates the map for the first events", func(t *testing.T) {
+		var expressions []string
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				expressions = append(expressions, aws.ToString(params.UpdateExpression))
+				if len(expressions) == 1 {
+					return nil, &types.ConditionalCheckFailedException{}
+				}
+				events := params.ExpressionAttributeValues[":events"].(*types.AttributeValueMemberM)
+				assert.Len(t, events.Value, 2)
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		require.NoError(t, repo.AddExecutionEvents(ctx, "exec-123", events))
+
+		require.Len(t, expressions, 2)
+		assert.Equal(t, "SET #events = :events", expressions[1])
+	})
+
+	t.Run("handles execution not found", func(t *testing.T) {
+		calls := 0
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, _ *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				calls++
+				return nil, &types.ConditionalCheckFailedException{}
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		err := repo.AddExecutionEvents(ctx, "exec-123", events)
+
+		assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+		assert.Equal(t, 3, calls)
+	})
+}
+
+func TestExecutionRepository_ListExecutionEvents(t *testing.T) {
+	ctx := context.Background()
+	event := func(ts int64, reason string) types.AttributeValue {
+		item := map[string]types.AttributeValue{"timestamp": &types.AttributeValueMemberN{Value: strconv.FormatInt(ts, 10)}}
+		if reason != "" {
+			item["reason"] = &types.AttributeValueMemberS{Value: reason}
+		}
+		return &types.AttributeValueMemberM{Value: item}
+	}
+
+	t.Run("returns events oldest first", func(t *testing.T) {
+		client := &mockImageClient{
+			getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.GetItemOutput, error) {
+				assert.Equal(t, "#events", aws.ToString(params.ProjectionExpression))
+				return &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+					"lifecycle_events": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+						"STOPPED":       event(5000, "Task stopped by user"),
+						"PULLING_IMAGE": event(2000, ""),
+						"PROVISIONING":  event(1000, ""),
+						"STOPPING":      event(5000, ""),
+					}},
+				}}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		events, err := repo.ListExecutionEvents(ctx, "exec-123")
+
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+		assert.Equal(t, []string{"PROVISIONING", "PULLING_IMAGE", "STOPPING", "STOPPED"},
+			[]string{events[0].Type, events[1].Type, events[2].Type, events[3].Type})
+		assert.Equal(t, "Task stopped by user", events[3].Reason)
+		assert.True(t, time.UnixMilli(2000).Equal(events[1].Timestamp))
+	})
+
+	t.Run("returns no events for executions without any", func(t *testing.T) {
+		client := &mockImageClient{
+			getItemFunc: func(_ context.Context, _ *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.GetItemOutput, error) {
+				return &dynamodb.GetItemOutput{}, nil
+			},
+		}
+		repo := NewExecutionRepository(client, "executions", testutil.SilentLogger())
+
+		events, err := repo.ListExecutionEvents(ctx, "exec-123")
+
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
 func TestExecutionRepository_ListExecutions(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
@@ -839,7 +1464,7 @@ func TestBuildQueryInput_NoLimitWhenZero(t *testing.T) {
 		":all": &types.AttributeValueMemberS{Value: awsconstants.DynamoDBAllValue},
 	}
 
-	input := repo.buildQueryInput("", exprNames, exprValues, nil, 0)
+	input := repo.buildQueryInput(allStartedAtIndexName, "#all = :all", "", exprNames, exprValues, nil, 0)
 
 	require.NotNil(t, input)
 	assert.Nil(t, input.Limit)
@@ -991,6 +1616,60 @@ func TestRequestIDTracking(t *testing.T) {
 	})
 }
 
+func TestExecutionRepository_GetExecutionsByGroupID(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	tableName := "test-executions-table"
+
+	t.Run("retrieves the shards of a group", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		now := time.Now()
+		for i, executionID := range []string{"exec-shard-0", "exec-shard-1"} {
+			shardIndex := i
+			require.NoError(t, repo.CreateExecution(ctx, &api.Execution{
+				ExecutionID: executionID,
+				CreatedBy:   "user@example.com",
+				Command:     "./test-shard.sh",
+				StartedAt:   now,
+				Status:      "RUNNING",
+				GroupID:     "group-123",
+				ShardIndex:  &shardIndex,
+			}))
+		}
+		require.NoError(t, repo.CreateExecution(ctx, &api.Execution{
+			ExecutionID: "exec-single",
+			CreatedBy:   "user@example.com",
+			Command:     "./test-shard.sh",
+			StartedAt:   now,
+			Status:      "RUNNING",
+		}))
+
+		executions, err := repo.GetExecutionsByGroupID(ctx, "group-123")
+
+		require.NoError(t, err)
+		require.Len(t, executions, 2)
+		shardIndexes := make(map[string]int)
+		for _, execution := range executions {
+			assert.Equal(t, "group-123", execution.GroupID)
+			require.NotNil(t, execution.ShardIndex)
+			shardIndexes[execution.ExecutionID] = *execution.ShardIndex
+		}
+		assert.Equal(t, map[string]int{"exec-shard-0": 0, "exec-shard-1": 1}, shardIndexes)
+	})
+
+	t.Run("handles query error", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		mockClient.QueryError = errors.New("query failed")
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+
+		_, err := repo.GetExecutionsByGroupID(ctx, "group-123")
+
+		assert.ErrorContains(t, err, "failed to query executions by group ID from group_id-index")
+	})
+}
+
 func TestExecutionRepository_GetExecutionsByRequestID(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
@@ -1158,7 +1837,31 @@ func TestExecutionRepository_ListExecutions_WithStatusFilter(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.NotNil(t, executions)
-		assert.Equal(t, 1, mockClient.QueryCalls)
+		// One query of the status-started_at index per status
+		assert.Equal(t, 3, mockClient.QueryCalls)
+	})
+
+	t.Run("merges statuses newest first from the status index", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewExecutionRepository(mockClient, tableName, logger)
+		start := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for i, status := range []string{"RUNNING", "SUCCEEDED", "FAILED", "RUNNING"} {
+			require.NoError(t, repo.CreateExecution(ctx, &api.Execution{
+				ExecutionID: fmt.Sprintf("exec-%d", i),
+				CreatedBy:   "user@example.com",
+				Command:     "echo hello",
+				Status:      status,
+				StartedAt:   start.Add(time.Duration(i) * time.Minute),
+			}))
+		}
+
+		executions, err := repo.ListExecutions(ctx, 2, []string{"RUNNING", "FAILED", "RUNNING"})
+
+		require.NoError(t, err)
+		require.Len(t, executions, 2)
+		assert.Equal(t, "exec-3", executions[0].ExecutionID)
+		assert.Equal(t, "exec-2", executions[1].ExecutionID)
+		assert.Equal(t, 2, mockClient.QueryCalls)
 	})
 
 	t.Run("handles pagination with status filter", func(t *testing.T) {
@@ -1175,6 +1878,150 @@ func TestExecutionRepository_ListExecutions_WithStatusFilter(t *testing.T) {
 	})
 }
 
+func TestExecutionRepository_ListExecutionsByCreator(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	tableName := "test-executions-table"
+
+	mockClient := NewMockDynamoDBClient()
+	repo := NewExecutionRepository(mockClient, tableName, logger)
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, creator := range []string{"alice@example.com", "bob@example.com", "alice@example.com"} {
+		require.NoError(t, repo.CreateExecution(ctx, &api.Execution{
+			ExecutionID: fmt.Sprintf("exec-%d", i),
+			CreatedBy:   creator,
+			Command:     "echo hello",
+			Status:      []string{"RUNNING", "SUCCEEDED"}[i%2],
+			StartedAt:   start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	t.Run("lists the executions of the creator newest first", func(t *testing.T) {
+		executions, err := repo.ListExecutionsByCreator(ctx, "alice@example.com", 0, nil)
+
+		require.NoError(t, err)
+		require.Len(t, executions, 2)
+		assert.Equal(t, "exec-2", executions[0].ExecutionID)
+		assert.Equal(t, "exec-0", executions[1].ExecutionID)
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		executions, err := repo.ListExecutionsByCreator(ctx, "bob@example.com", 10, []string{"RUNNING"})
+
+		require.NoError(t, err)
+		assert.Empty(t, executions)
+	})
+
+	t.Run("queries the creator index", func(t *testing.T) {
+		var input *dynamodb.QueryInput
+		client := &queryCapturingClient{Client: mockClient, capture: func(in *dynamodb.QueryInput) { input = in }}
+		_, err := NewExecutionRepository(client, tableName, logger).
+			ListExecutionsByCreator(ctx, "alice@example.com", 5, []string{"RUNNING"})
+
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, createdByStartedAtIndexName, aws.ToString(input.IndexName))
+		assert.Equal(t, "#key = :key", aws.ToString(input.KeyConditionExpression))
+		assert.Equal(t, "#status = :status", aws.ToString(input.FilterExpression))
+		assert.False(t, aws.ToBool(input.ScanIndexForward))
+	})
+
+	t.Run("handles database error", func(t *testing.T) {
+		failing := NewMockDynamoDBClient()
+		failing.QueryError = errors.New("database error")
+
+		_, err := NewExecutionRepository(failing, tableName, logger).
+			ListExecutionsByCreator(ctx, "alice@example.com", 10, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to query executions")
+	})
+}
+
+func TestExecutionRepository_SearchExecutions(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	tableName := "test-executions-table"
+
+	t.Run("stores the search text with the execution", func(t *testing.T) {
+		item := toExecutionItem(&api.Execution{
+			ExecutionID: "exec-1",
+			Command:     "PYTEST -x tests/",
+			ImageID:     "python-3.12",
+			Playbook:    "ci",
+		})
+
+		assert.Equal(t, "pytest -x tests/\npython-3.12\nci", item.SearchText)
+	})
+
+	t.Run("filters the all index on the search text", func(t *testing.T) {
+		var input *dynamodb.QueryInput
+		client := &queryCapturingClient{
+			Client:  NewMockDynamoDBClient(),
+			capture: func(in *dynamodb.QueryInput) { input = in },
+		}
+
+		_, err := NewExecutionRepository(client, tableName, logger).
+			SearchExecutions(ctx, []string{"pytest", "python"}, 10, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, allStartedAtIndexName, aws.ToString(input.IndexName))
+		assert.Equal(t, "contains(#search, :term0) AND contains(#search, :term1)",
+			aws.ToString(input.FilterExpression))
+		assert.Equal(t, searchTextAttrName, input.ExpressionAttributeNames["#search"])
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "python"}, input.ExpressionAttributeValues[":term1"])
+	})
+
+	t.Run("filters the status index per status", func(t *testing.T) {
+		var inputs []*dynamodb.QueryInput
+		client := &queryCapturingClient{
+			Client:  NewMockDynamoDBClient(),
+			capture: func(in *dynamodb.QueryInput) { inputs = append(inputs, in) },
+		}
+
+		_, err := NewExecutionRepository(client, tableName, logger).
+			SearchExecutions(ctx, []string{"pytest"}, 10, []string{"RUNNING", "FAILED"})
+
+		require.NoError(t, err)
+		require.Len(t, inputs, 2)
+		for _, input := range inputs {
+			assert.Equal(t, statusStartedAtIndexName, aws.ToString(input.IndexName))
+			assert.Equal(t, "contains(#search, :term0)", aws.ToString(input.FilterExpression))
+			assert.Contains(t, input.ExpressionAttributeValues, ":key")
+		}
+	})
+
+	t.Run("lists all executions without terms", func(t *testing.T) {
+		var input *dynamodb.QueryInput
+		client := &queryCapturingClient{
+			Client:  NewMockDynamoDBClient(),
+			capture: func(in *dynamodb.QueryInput) { input = in },
+		}
+
+		_, err := NewExecutionRepository(client, tableName, logger).SearchExecutions(ctx, nil, 10, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Empty(t, aws.ToString(input.FilterExpression))
+	})
+}
+
+// queryCapturingClient passes the operations through to the wrapped client, capturing the Query inputs.
+type queryCapturingClient struct {
+	Client
+	capture func(*dynamodb.QueryInput)
+}
+
+func (c *queryCapturingClient) Query(
+	ctx context.Context,
+	params *dynamodb.QueryInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.QueryOutput, error) {
+	c.capture(params)
+	return c.Client.Query(ctx, params, optFns...)
+}
+
 func TestExecutionRepository_ListExecutions_EdgeCases(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
@@ -0,0 +1,163 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// HealthReportRepository implements the database.HealthReportRepository interface using DynamoDB.
+// Reports share the constant _all partition and are sorted by timestamp, so the latest
+// reports are read with a single descending query.
+type HealthReportRepository struct {
+	client    Client
+	tableName string
+	logger    *slog.Logger
+}
+
+// NewHealthReportRepository creates a new DynamoDB-backed health report repository.
+func NewHealthReportRepository(
+	client Client,
+	tableName string,
+	log *slog.Logger,
+) database.HealthReportRepository {
+	return &HealthReportRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    log,
+	}
+}
+
+// encodeWithJSONTags and decodeWithJSONTags make the attributevalue codec follow
+// the json tags of the api types, so reports are stored with their API field names.
+func encodeWithJSONTags(opts *attributevalue.EncoderOptions) {
+	opts.TagKey = "json"
+}
+
+func decodeWithJSONTags(opts *attributevalue.DecoderOptions) {
+	opts.TagKey = "json"
+}
+
+// CreateHealthReport stores the report of a health reconciliation run.
+// Reports expire after awsConstants.HealthReportRetention.
+func (r *HealthReportRepository) CreateHealthReport(ctx context.Context, report *api.HealthReport) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	reportAV, err := attributevalue.MarshalWithOptions(report, encodeWithJSONTags)
+	if err != nil {
+		return sdkerrors.Map(err, "failed to marshal health report", apperrors.ErrDatabaseError)
+	}
+	reportMap, ok := reportAV.(*types.AttributeValueMemberM)
+	if !ok {
+		return apperrors.ErrDatabaseError("failed to marshal health report", fmt.Errorf("unexpected type %T", reportAV))
+	}
+
+	item := map[string]types.AttributeValue{
+		awsConstants.DynamoDBAllAttribute: &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+		"timestamp": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(report.Timestamp.UnixMilli(), 10),
+		},
+		"report": reportMap,
+		awsConstants.DynamoDBExpiresAtAttribute: &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(report.Timestamp.Add(awsConstants.HealthReportRetention).Unix(), 10),
+		},
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"timestamp", report.Timestamp.Format(time.RFC3339),
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	if _, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      item,
+	}); err != nil {
+		return sdkerrors.Map(err, "failed to store health report", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// ListHealthReports returns up to limit stored reports, most recent first.
+func (r *HealthReportRepository) ListHealthReports(ctx context.Context, limit int) ([]api.HealthReport, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+		"limit", limit,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	reports := make([]api.HealthReport, 0, limit)
+	var lastKey map[string]types.AttributeValue
+	for len(reports) < limit {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.tableName),
+			KeyConditionExpression: aws.String("#all = :all"),
+			ExpressionAttributeNames: map[string]string{
+				"#all": awsConstants.DynamoDBAllAttribute,
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":all": &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+			},
+			ScanIndexForward:  aws.Bool(false),
+			Limit:             aws.Int32(int32(limit - len(reports))), //nolint:gosec // bounded by the list limit
+			ExclusiveStartKey: lastKey,
+		})
+		if err != nil {
+			return nil, sdkerrors.Map(err, "failed to query health reports", apperrors.ErrDatabaseError)
+		}
+
+		for _, rawItem := range out.Items {
+			report, err := unmarshalHealthReport(rawItem)
+			if err != nil {
+				return nil, err
+			}
+			reports = append(reports, *report)
+			if len(reports) == limit {
+				break
+			}
+		}
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastKey = out.LastEvaluatedKey
+	}
+
+	return reports, nil
+}
+
+// unmarshalHealthReport converts a stored item back into an api.HealthReport.
+func unmarshalHealthReport(rawItem map[string]types.AttributeValue) (*api.HealthReport, error) {
+	reportAV, ok := rawItem["report"]
+	if !ok {
+		return nil, apperrors.ErrDatabaseError("health report item has no report attribute", nil)
+	}
+
+	var report api.HealthReport
+	if err := attributevalue.UnmarshalWithOptions(reportAV, &report, decodeWithJSONTags); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal health report", apperrors.ErrDatabaseError)
+	}
+
+	return &report, nil
+}
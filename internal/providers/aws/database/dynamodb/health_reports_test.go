@@ -0,0 +1,119 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthReportRepository_CreateAndList(t *testing.T) {
+	var stored []map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "health-reports", aws.ToString(params.TableName))
+			stored = append(stored, params.Item)
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		queryFunc: func(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			assert.False(t, aws.ToBool(params.ScanIndexForward))
+			assert.Equal(t, int32(1), aws.ToInt32(params.Limit))
+			return &dynamodb.QueryOutput{Items: stored[len(stored)-1:]}, nil
+		},
+	}
+	repo := NewHealthReportRepository(client, "health-reports", testutil.SilentLogger())
+
+	timestamp := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	report := &api.HealthReport{
+		Timestamp:       timestamp,
+		ComputeStatus:   api.ComputeHealthStatus{TotalResources: 3, RecreatedCount: 1},
+		ReconciledCount: 1,
+		ErrorCount:      2,
+		Issues: []api.HealthIssue{
+			{ResourceType: "ecs_task_definition", ResourceID: "td-1", Severity: "error", Action: "recreated"},
+		},
+	}
+
+	require.NoError(t, repo.CreateHealthReport(context.Background(), report))
+	require.Len(t, stored, 1)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+		stored[0][awsConstants.DynamoDBAllAttribute])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "1792324800000"}, stored[0]["timestamp"])
+	assert.Contains(t, stored[0], awsConstants.DynamoDBExpiresAtAttribute)
+
+	reports, err := repo.ListHealthReports(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.True(t, timestamp.Equal(reports[0].Timestamp))
+	assert.Equal(t, 1, reports[0].ComputeStatus.RecreatedCount)
+	assert.Equal(t, 2, reports[0].ErrorCount)
+	assert.Equal(t, report.Issues, reports[0].Issues)
+}
+
+func TestHealthReportRepository_ListPaginates(t *testing.T) {
+	item := func(ts int64) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"report": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"error_count": &types.AttributeValueMemberN{Value: "0"},
+			}},
+			"timestamp": &types.AttributeValueMemberN{Value: strconv.FormatInt(ts, 10)},
+		}
+	}
+	calls := 0
+	client := &mockImageClient{
+		queryFunc: func(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			calls++
+			if calls == 1 {
+				assert.Nil(t, params.ExclusiveStartKey)
+				return &dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{item(3)},
+					LastEvaluatedKey: item(3),
+				}, nil
+			}
+			assert.Equal(t, int32(2), aws.ToInt32(params.Limit))
+			return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item(2), item(1)}}, nil
+		},
+	}
+	repo := NewHealthReportRepository(client, "health-reports", testutil.SilentLogger())
+
+	reports, err := repo.ListHealthReports(context.Background(), 3)
+
+	require.NoError(t, err)
+	assert.Len(t, reports, 3)
+	assert.Equal(t, 2, calls)
+}
+
+func TestHealthReportRepository_Errors(t *testing.T) {
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, _ *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			return nil, errors.New("boom")
+		},
+		queryFunc: func(_ context.Context, _ *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	repo := NewHealthReportRepository(client, "health-reports", testutil.SilentLogger())
+
+	err := repo.CreateHealthReport(context.Background(), &api.HealthReport{Timestamp: time.Now()})
+	assert.Equal(t, appErrors.ErrCodeDatabaseError, appErrors.GetErrorCode(err))
+
+	_, err = repo.ListHealthReports(context.Background(), 5)
+	assert.Equal(t, appErrors.ErrCodeDatabaseError, appErrors.GetErrorCode(err))
+}
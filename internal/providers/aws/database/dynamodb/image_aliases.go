@@ -0,0 +1,198 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// imageAliasKeyPrefix prefixes the image_id key of alias items, which share the image-taskdefs table.
+	imageAliasKeyPrefix = "alias#"
+	// imageAliasAllValue is the _all value of alias items, keeping them apart from the images in the
+	// all-image_id index while letting them be listed through it.
+	imageAliasAllValue = "ALIAS"
+)
+
+// imageAliasItem represents an image alias stored in the image-taskdefs table.
+type imageAliasItem struct {
+	ImageID       string `dynamodbav:"image_id"` // alias# followed by the alias
+	Alias         string `dynamodbav:"alias"`
+	TargetImageID string `dynamodbav:"target_image_id"`
+	UpdatedBy     string `dynamodbav:"updated_by"`
+	UpdatedAt     int64  `dynamodbav:"updated_at"`
+	All           string `dynamodbav:"_all"`
+}
+
+func (item *imageAliasItem) toAPIImageAlias() *api.ImageAlias {
+	return &api.ImageAlias{
+		Alias:     item.Alias,
+		ImageID:   item.TargetImageID,
+		UpdatedBy: item.UpdatedBy,
+		UpdatedAt: time.Unix(item.UpdatedAt, 0).UTC(),
+	}
+}
+
+// PutImageAlias points an image alias at an image ID, creating the alias if needed, in a single write.
+// It returns the image ID the alias pointed at before, empty when the alias was created.
+func (r *ImageTaskDefRepository) PutImageAlias(
+	ctx context.Context, alias, imageID, updatedBy string, updatedAt time.Time,
+) (string, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	item := imageAliasItem{
+		ImageID:       imageAliasKeyPrefix + alias,
+		Alias:         alias,
+		TargetImageID: imageID,
+		UpdatedBy:     updatedBy,
+		UpdatedAt:     updatedAt.Unix(),
+		All:           imageAliasAllValue,
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return "", apperrors.ErrInternalError("failed to marshal image alias", err)
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"alias", alias,
+		"image_id", imageID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:    aws.String(r.tableName),
+		Item:         av,
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		return "", apperrors.ErrInternalError("failed to put image alias", err)
+	}
+
+	if len(result.Attributes) == 0 {
+		return "", nil
+	}
+	var previous imageAliasItem
+	if unmarshalErr := attributevalue.UnmarshalMap(result.Attributes, &previous); unmarshalErr != nil {
+		return "", apperrors.ErrInternalError("failed to unmarshal image alias", unmarshalErr)
+	}
+	return previous.TargetImageID, nil
+}
+
+// GetImageAlias retrieves an image alias. Returns nil if the alias doesn't exist.
+func (r *ImageTaskDefRepository) GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+		"alias", alias,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageAliasKeyPrefix + alias},
+		},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to get image alias", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item imageAliasItem
+	if unmarshalErr := attributevalue.UnmarshalMap(result.Item, &item); unmarshalErr != nil {
+		return nil, apperrors.ErrInternalError("failed to unmarshal image alias", unmarshalErr)
+	}
+
+	return item.toAPIImageAlias(), nil
+}
+
+// ListImageAliases retrieves all image aliases, sorted by alias.
+func (r *ImageTaskDefRepository) ListImageAliases(ctx context.Context) ([]api.ImageAlias, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+		"index", "all-image_id",
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		IndexName:              aws.String("all-image_id"),
+		KeyConditionExpression: aws.String("#all = :all"),
+		ExpressionAttributeNames: map[string]string{
+			"#all": awsConstants.DynamoDBAllAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":all": &types.AttributeValueMemberS{Value: imageAliasAllValue},
+		},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternalError("failed to list image aliases", err)
+	}
+
+	var items []imageAliasItem
+	if unmarshalErr := attributevalue.UnmarshalListOfMaps(result.Items, &items); unmarshalErr != nil {
+		return nil, apperrors.ErrInternalError("failed to unmarshal image aliases", unmarshalErr)
+	}
+
+	aliases := make([]api.ImageAlias, 0, len(items))
+	for i := range items {
+		aliases = append(aliases, *items[i].toAPIImageAlias())
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	return aliases, nil
+}
+
+// DeleteImageAlias removes an image alias, leaving the image it points at untouched.
+// Returns ErrNotFound if the alias doesn't exist.
+func (r *ImageTaskDefRepository) DeleteImageAlias(ctx context.Context, alias string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.DeleteItem",
+		"table", r.tableName,
+		"alias", alias,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageAliasKeyPrefix + alias},
+		},
+		ConditionExpression: aws.String("attribute_exists(image_id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image alias not found", err)
+		}
+		return apperrors.ErrInternalError("failed to delete image alias", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package dynamodb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutImageAlias(t *testing.T) {
+	ctx := testutil.TestContext()
+	updatedAt := time.Unix(1234567899, 0)
+
+	t.Run("creates the alias", func(t *testing.T) {
+		var input *dynamodb.PutItemInput
+		client := &mockImageClient{
+			putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.PutItemOutput, error) {
+				input = params
+				return &dynamodb.PutItemOutput{}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		previous, err := repo.PutImageAlias(ctx, "python:stable", "python:3.12-a1b2c3d4", "user@example.com", updatedAt)
+
+		require.NoError(t, err)
+		assert.Empty(t, previous)
+		require.NotNil(t, input)
+		assert.Equal(t, types.ReturnValueAllOld, input.ReturnValues)
+
+		var item imageAliasItem
+		require.NoError(t, attributevalue.UnmarshalMap(input.Item, &item))
+		assert.Equal(t, "alias#python:stable", item.ImageID)
+		assert.Equal(t, "python:3.12-a1b2c3d4", item.TargetImageID)
+		assert.Equal(t, imageAliasAllValue, item.All)
+		assert.NotEqual(t, awsConstants.DynamoDBAllValue, item.All)
+		assert.Equal(t, int64(1234567899), item.UpdatedAt)
+	})
+
+	t.Run("returns the previous target", func(t *testing.T) {
+		client := &mockImageClient{
+			putItemFunc: func(_ context.Context, _ *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.PutItemOutput, error) {
+				old, _ := attributevalue.MarshalMap(imageAliasItem{
+					ImageID: "alias#python:stable", Alias: "python:stable", TargetImageID: "python:3.11-e5f6a7b8",
+				})
+				return &dynamodb.PutItemOutput{Attributes: old}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		previous, err := repo.PutImageAlias(ctx, "python:stable", "python:3.12-a1b2c3d4", "user@example.com", updatedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, "python:3.11-e5f6a7b8", previous)
+	})
+}
+
+func TestGetImageAlias(t *testing.T) {
+	ctx := testutil.TestContext()
+
+	t.Run("found", func(t *testing.T) {
+		client := &mockImageClient{
+			getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.GetItemOutput, error) {
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "alias#python:stable"}, params.Key["image_id"])
+				item, _ := attributevalue.MarshalMap(imageAliasItem{
+					ImageID: "alias#python:stable", Alias: "python:stable", TargetImageID: "python:3.12-a1b2c3d4",
+					UpdatedBy: "user@example.com", UpdatedAt: 1234567899,
+				})
+				return &dynamodb.GetItemOutput{Item: item}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		alias, err := repo.GetImageAlias(ctx, "python:stable")
+
+		require.NoError(t, err)
+		require.NotNil(t, alias)
+		assert.Equal(t, "python:stable", alias.Alias)
+		assert.Equal(t, "python:3.12-a1b2c3d4", alias.ImageID)
+		assert.Equal(t, time.Unix(1234567899, 0).UTC(), alias.UpdatedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := &mockImageClient{
+			getItemFunc: func(_ context.Context, _ *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.GetItemOutput, error) {
+				return &dynamodb.GetItemOutput{}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		alias, err := repo.GetImageAlias(ctx, "python:stable")
+
+		require.NoError(t, err)
+		assert.Nil(t, alias)
+	})
+}
+
+func TestListImageAliases(t *testing.T) {
+	ctx := testutil.TestContext()
+
+	var input *dynamodb.QueryInput
+	client := &mockImageClient{
+		queryFunc: func(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			input = params
+			items, _ := attributevalue.MarshalList([]imageAliasItem{
+				{ImageID: "alias#python:stable", Alias: "python:stable", TargetImageID: "python:3.12-a1b2c3d4"},
+				{ImageID: "alias#node:lts", Alias: "node:lts", TargetImageID: "node:22-e5f6a7b8"},
+			})
+			output := &dynamodb.QueryOutput{}
+			for _, item := range items {
+				output.Items = append(output.Items, item.(*types.AttributeValueMemberM).Value)
+			}
+			return output, nil
+		},
+	}
+	repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+	aliases, err := repo.ListImageAliases(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, "node:lts", aliases[0].Alias)
+	assert.Equal(t, "python:stable", aliases[1].Alias)
+	assert.Equal(t, "all-image_id", aws.ToString(input.IndexName))
+	assert.Equal(t, &types.AttributeValueMemberS{Value: imageAliasAllValue}, input.ExpressionAttributeValues[":all"])
+}
+
+func TestDeleteImageAlias(t *testing.T) {
+	ctx := testutil.TestContext()
+
+	t.Run("deletes the alias", func(t *testing.T) {
+		var input *dynamodb.DeleteItemInput
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				input = params
+				return &dynamodb.DeleteItemOutput{}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		require.NoError(t, repo.DeleteImageAlias(ctx, "python:stable"))
+		require.NotNil(t, input)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "alias#python:stable"}, input.Key["image_id"])
+	})
+
+	t.Run("alias not found", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, _ *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				return nil, &types.ConditionalCheckFailedException{}
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		err := repo.DeleteImageAlias(ctx, "python:stable")
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+}
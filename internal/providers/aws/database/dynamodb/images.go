@@ -1,12 +1,15 @@
 package dynamodb
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -60,6 +63,14 @@ type imageTaskDefItem struct {
 	UpdatedAt             int64    `dynamodbav:"updated_at"`
 	CreatedByRequestID    string   `dynamodbav:"created_by_request_id,omitempty"`
 	ModifiedByRequestID   string   `dynamodbav:"modified_by_request_id,omitempty"`
+	LogMaxLinesPerSec     int      `dynamodbav:"log_max_lines_per_second,omitempty"`
+	LogMaxBytes           int64    `dynamodbav:"log_max_bytes,omitempty"`
+	WarmPoolSize          int      `dynamodbav:"warm_pool_size,omitempty"`
+	RunWorkDir            string   `dynamodbav:"run_workdir,omitempty"`
+	RunShell              string   `dynamodbav:"run_shell,omitempty"`
+	DeletedAt             int64    `dynamodbav:"deleted_at,omitempty"`
+	DeletedBy             string   `dynamodbav:"deleted_by,omitempty"`
+	Version               int64    `dynamodbav:"version,omitempty"`
 	All                   string   `dynamodbav:"_all"` // Constant partition key for listing all images
 }
 
@@ -73,6 +84,11 @@ func (item *imageTaskDefItem) isDefault() bool {
 	return item.IsDefaultPlaceholder != nil && *item.IsDefaultPlaceholder == defaultPlaceholderValue
 }
 
+// isDeleted reports whether the image configuration was deleted and awaits its purge.
+func (item *imageTaskDefItem) isDeleted() bool {
+	return item.DeletedAt > 0
+}
+
 // GenerateImageID generates a unique, human-readable ID for an image configuration.
 // Format: {imageName}:{tag}-{first-8-chars-of-hash}
 // Example: alpine:latest-a1b2c3d4 or golang:1.24.5-bookworm-19884ca2.
@@ -101,6 +117,17 @@ func GenerateImageID(
 	return imageID
 }
 
+// TaskDefinitionFamilyForImageID returns the ECS task definition family registered for an image ID.
+// Example: alpine:latest-a1b2c3d4 -> runvoy-alpine-latest-a1b2c3d4.
+func TaskDefinitionFamilyForImageID(imageID string) string {
+	re := regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+	sanitized := re.ReplaceAllString(imageID, "-")
+	re2 := regexp.MustCompile(`-+`)
+	sanitized = re2.ReplaceAllString(sanitized, "-")
+	sanitized = strings.Trim(sanitized, "-")
+	return "runvoy-" + sanitized
+}
+
 // PutImageTaskDef stores or updates an image-taskdef mapping.
 //
 //nolint:funlen // Complex item construction with multiple fields
@@ -119,6 +146,7 @@ func (r *ImageTaskDefRepository) PutImageTaskDef(
 	taskDefFamily string,
 	isDefault bool,
 	createdBy string,
+	logLimits *api.LogLimits,
 ) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
@@ -175,6 +203,11 @@ func (r *ImageTaskDefRepository) PutImageTaskDef(
 		item.IsDefaultPlaceholder = &placeholder
 	}
 
+	if logLimits != nil {
+		item.LogMaxLinesPerSec = logLimits.MaxLinesPerSecond
+		item.LogMaxBytes = logLimits.MaxBytes
+	}
+
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return apperrors.ErrInternalError("failed to marshal image-taskdef item", err)
@@ -345,6 +378,19 @@ func (r *ImageTaskDefRepository) convertItemToImageInfo(item *imageTaskDefItem)
 
 	isDefault := item.isDefault()
 	createdAt := time.Unix(item.CreatedAt, 0).UTC()
+	var logLimits *api.LogLimits
+	if item.LogMaxLinesPerSec > 0 || item.LogMaxBytes > 0 {
+		logLimits = &api.LogLimits{MaxLinesPerSecond: item.LogMaxLinesPerSec, MaxBytes: item.LogMaxBytes}
+	}
+	var runDefaults *api.RunDefaults
+	if item.RunWorkDir != "" || item.RunShell != "" {
+		runDefaults = &api.RunDefaults{WorkDir: item.RunWorkDir, Shell: item.RunShell}
+	}
+	var deletedAt *time.Time
+	if item.isDeleted() {
+		deleted := time.Unix(item.DeletedAt, 0).UTC()
+		deletedAt = &deleted
+	}
 	return &api.ImageInfo{
 		ImageID:               item.ImageID,
 		Image:                 item.Image,
@@ -363,6 +409,12 @@ func (r *ImageTaskDefRepository) convertItemToImageInfo(item *imageTaskDefItem)
 		CreatedAt:             createdAt,
 		CreatedByRequestID:    item.CreatedByRequestID,
 		ModifiedByRequestID:   item.ModifiedByRequestID,
+		LogLimits:             logLimits,
+		WarmPoolSize:          item.WarmPoolSize,
+		RunDefaults:           runDefaults,
+		Version:               item.Version,
+		DeletedAt:             deletedAt,
+		DeletedBy:             item.DeletedBy,
 	}, nil
 }
 
@@ -494,38 +546,11 @@ func (r *ImageTaskDefRepository) GetImagesByRequestID(ctx context.Context, reque
 func (r *ImageTaskDefRepository) convertItemsToImageInfo(items []imageTaskDefItem) ([]api.ImageInfo, error) {
 	allImages := make([]api.ImageInfo, 0, len(items))
 	for i := range items {
-		item := &items[i]
-		isDefault := item.isDefault()
-
-		cpuInt, parseErr := strconv.Atoi(item.Cpu)
-		if parseErr != nil {
-			return nil, apperrors.ErrInternalError("failed to parse CPU value", parseErr)
-		}
-		memoryInt, parseErr := strconv.Atoi(item.Memory)
-		if parseErr != nil {
-			return nil, apperrors.ErrInternalError("failed to parse Memory value", parseErr)
+		imageInfo, err := r.convertItemToImageInfo(&items[i])
+		if err != nil {
+			return nil, err
 		}
-
-		createdAt := time.Unix(item.CreatedAt, 0).UTC()
-		allImages = append(allImages, api.ImageInfo{
-			ImageID:               item.ImageID,
-			Image:                 item.Image,
-			TaskDefinitionName:    item.TaskDefinitionFamily,
-			IsDefault:             &isDefault,
-			TaskRoleName:          item.TaskRoleName,
-			TaskExecutionRoleName: item.TaskExecutionRoleName,
-			CPU:                   cpuInt,
-			Memory:                memoryInt,
-			RuntimePlatform:       item.RuntimePlatform,
-			ImageRegistry:         item.ImageRegistry,
-			ImageName:             item.ImageName,
-			ImageTag:              item.ImageTag,
-			CreatedBy:             item.CreatedBy,
-			OwnedBy:               item.OwnedBy,
-			CreatedAt:             createdAt,
-			CreatedByRequestID:    item.CreatedByRequestID,
-			ModifiedByRequestID:   item.ModifiedByRequestID,
-		})
+		allImages = append(allImages, *imageInfo)
 	}
 	return allImages, nil
 }
@@ -860,8 +885,13 @@ func (r *ImageTaskDefRepository) GetAnyImageTaskDef(ctx context.Context, image s
 		}
 	}
 
+	// Deleted configurations are only returned when no other configuration of the image is left,
+	// so that callers can tell the image was deleted rather than never registered.
+	slices.SortStableFunc(items, func(a, b imageTaskDefItem) int {
+		return cmp.Compare(min(a.DeletedAt, 1), min(b.DeletedAt, 1))
+	})
 	for i := range items {
-		if items[i].isDefault() {
+		if items[i].isDefault() && !items[i].isDeleted() {
 			return r.convertItemToImageInfo(&items[i])
 		}
 	}
@@ -973,3 +1003,242 @@ func (r *ImageTaskDefRepository) SetImageAsOnlyDefault(
 
 	return nil
 }
+
+// SetImageLogLimits replaces the log limits of an image configuration read at version.
+// Nil limits remove the image's limits, so the backend defaults apply.
+// It fails with a concurrent modification error if the settings of the image were updated since.
+func (r *ImageTaskDefRepository) SetImageLogLimits(
+	ctx context.Context, imageID string, version int64, limits *api.LogLimits,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"image_id", imageID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageID},
+		},
+		UpdateExpression: aws.String("SET updated_at = :now REMOVE log_max_lines_per_second, log_max_bytes"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		ConditionExpression: aws.String("attribute_exists(image_id)"),
+	}
+	if limits != nil {
+		input.UpdateExpression = aws.String(
+			"SET updated_at = :now, log_max_lines_per_second = :max_lines, log_max_bytes = :max_bytes")
+		input.ExpressionAttributeValues[":max_lines"] = &types.AttributeValueMemberN{
+			Value: strconv.Itoa(limits.MaxLinesPerSecond)}
+		input.ExpressionAttributeValues[":max_bytes"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(limits.MaxBytes, 10)}
+	}
+	withVersionCondition(input, version)
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		if isVersionConflict(err) {
+			return apperrors.ErrConcurrentModification("image was modified concurrently", nil)
+		}
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image not found", err)
+		}
+		return apperrors.ErrInternalError("failed to set image log limits", err)
+	}
+
+	return nil
+}
+
+// SetImageWarmPoolSize sets the number of warm pool slots kept for an image read at version.
+// A size of 0 removes the setting, disabling the warm pool of the image.
+// It fails with a concurrent modification error if the settings of the image were updated since.
+func (r *ImageTaskDefRepository) SetImageWarmPoolSize(
+	ctx context.Context, imageID string, version int64, size int,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"image_id", imageID,
+		"warm_pool_size", size,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageID},
+		},
+		UpdateExpression: aws.String("SET updated_at = :now REMOVE warm_pool_size"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		ConditionExpression: aws.String("attribute_exists(image_id)"),
+	}
+	if size > 0 {
+		input.UpdateExpression = aws.String("SET updated_at = :now, warm_pool_size = :size")
+		input.ExpressionAttributeValues[":size"] = &types.AttributeValueMemberN{Value: strconv.Itoa(size)}
+	}
+	withVersionCondition(input, version)
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		if isVersionConflict(err) {
+			return apperrors.ErrConcurrentModification("image was modified concurrently", nil)
+		}
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image not found", err)
+		}
+		return apperrors.ErrInternalError("failed to set image warm pool size", err)
+	}
+
+	return nil
+}
+
+// SetImageRunDefaults replaces the working directory and shell the runs of an image configuration read at
+// version default to. Nil defaults remove them.
+// It fails with a concurrent modification error if the settings of the image were updated since.
+func (r *ImageTaskDefRepository) SetImageRunDefaults(
+	ctx context.Context, imageID string, version int64, defaults *api.RunDefaults,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"image_id", imageID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	var workDir, shell string
+	if defaults != nil {
+		workDir, shell = defaults.WorkDir, defaults.Shell
+	}
+	var set, remove []string
+	values := map[string]types.AttributeValue{
+		":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+	for _, attr := range []struct{ name, value string }{
+		{"run_workdir", workDir},
+		{"run_shell", shell},
+	} {
+		if attr.value == "" {
+			remove = append(remove, attr.name)
+			continue
+		}
+		set = append(set, attr.name+" = :"+attr.name)
+		values[":"+attr.name] = &types.AttributeValueMemberS{Value: attr.value}
+	}
+	expression := "SET " + strings.Join(append([]string{"updated_at = :now"}, set...), ", ")
+	if len(remove) > 0 {
+		expression += " REMOVE " + strings.Join(remove, ", ")
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageID},
+		},
+		UpdateExpression:          aws.String(expression),
+		ExpressionAttributeValues: values,
+		ConditionExpression:       aws.String("attribute_exists(image_id)"),
+	}
+	withVersionCondition(input, version)
+
+	if _, err := r.client.UpdateItem(ctx, input); err != nil {
+		if isVersionConflict(err) {
+			return apperrors.ErrConcurrentModification("image was modified concurrently", nil)
+		}
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image not found", err)
+		}
+		return apperrors.ErrInternalError("failed to set image run defaults", err)
+	}
+
+	return nil
+}
+
+// MarkImageDeleted marks an image configuration as deleted by a user, which can be undone with RestoreImage
+// until it is purged. The configuration loses its default flag.
+func (r *ImageTaskDefRepository) MarkImageDeleted(
+	ctx context.Context, imageID, deletedBy string, deletedAt time.Time,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"image_id", imageID,
+		"deleted_by", deletedBy,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageID},
+		},
+		UpdateExpression: aws.String(
+			"SET deleted_at = :deleted_at, deleted_by = :deleted_by, updated_at = :now REMOVE is_default_placeholder"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":deleted_at": &types.AttributeValueMemberN{Value: strconv.FormatInt(deletedAt.Unix(), 10)},
+			":deleted_by": &types.AttributeValueMemberS{Value: deletedBy},
+			":now":        &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		ConditionExpression: aws.String("attribute_exists(image_id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image not found", err)
+		}
+		return apperrors.ErrInternalError("failed to mark image as deleted", err)
+	}
+
+	return nil
+}
+
+// RestoreImage clears the deletion mark of an image configuration.
+func (r *ImageTaskDefRepository) RestoreImage(ctx context.Context, imageID string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"image_id", imageID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"image_id": &types.AttributeValueMemberS{Value: imageID},
+		},
+		UpdateExpression: aws.String("SET updated_at = :now REMOVE deleted_at, deleted_by"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		ConditionExpression: aws.String("attribute_exists(image_id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("image not found", err)
+		}
+		return apperrors.ErrInternalError("failed to restore image", err)
+	}
+
+	return nil
+}
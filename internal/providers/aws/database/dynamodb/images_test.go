@@ -3,9 +3,12 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
@@ -92,6 +95,12 @@ func (m *mockImageClient) BatchWriteItem(
 	return &dynamodb.BatchWriteItemOutput{}, nil
 }
 
+func (m *mockImageClient) TransactWriteItems(
+	_ context.Context, _ *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (
+	*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
 func (m *mockImageClient) Scan(
 	ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (
 	*dynamodb.ScanOutput, error) {
@@ -281,6 +290,7 @@ func TestPutImageTaskDef(t *testing.T) {
 				tt.taskDefFamily,
 				tt.isDefault,
 				"test@example.com",
+				nil,
 			)
 
 			if tt.expectError {
@@ -1053,6 +1063,65 @@ func TestGetAnyImageTaskDef(t *testing.T) {
 				assert.True(t, *info.IsDefault)
 			},
 		},
+		{
+			name:  "prefers configuration that was not deleted",
+			image: "alpine:latest",
+			mockSetup: func(m *mockImageClient) {
+				m.queryFunc = func(_ context.Context, _ *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+					*dynamodb.QueryOutput, error) {
+					items := []imageTaskDefItem{
+						{
+							ImageID:   "alpine:latest-aabbccdd",
+							Image:     "alpine:latest",
+							Cpu:       "256",
+							Memory:    "512",
+							DeletedAt: 1234567899,
+							DeletedBy: "user@example.com",
+						},
+						{
+							ImageID: "alpine:latest-f755d736",
+							Image:   "alpine:latest",
+							Cpu:     "1024",
+							Memory:  "2048",
+						},
+					}
+					var av []map[string]types.AttributeValue
+					for _, item := range items {
+						itemMap, _ := attributevalue.MarshalMap(&item)
+						av = append(av, itemMap)
+					}
+					return &dynamodb.QueryOutput{Items: av}, nil
+				}
+			},
+			validateFn: func(t *testing.T, info *api.ImageInfo) {
+				assert.Equal(t, "alpine:latest-f755d736", info.ImageID)
+				assert.Nil(t, info.DeletedAt)
+			},
+		},
+		{
+			name:  "returns deleted configuration when it is the only one",
+			image: "alpine:latest",
+			mockSetup: func(m *mockImageClient) {
+				m.queryFunc = func(_ context.Context, _ *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+					*dynamodb.QueryOutput, error) {
+					item := &imageTaskDefItem{
+						ImageID:   "alpine:latest-aabbccdd",
+						Image:     "alpine:latest",
+						Cpu:       "256",
+						Memory:    "512",
+						DeletedAt: 1234567899,
+						DeletedBy: "user@example.com",
+					}
+					av, _ := attributevalue.MarshalMap(item)
+					return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{av}}, nil
+				}
+			},
+			validateFn: func(t *testing.T, info *api.ImageInfo) {
+				require.NotNil(t, info.DeletedAt)
+				assert.Equal(t, int64(1234567899), info.DeletedAt.Unix())
+				assert.Equal(t, "user@example.com", info.DeletedBy)
+			},
+		},
 		{
 			name:  "image not found",
 			image: "nonexistent:latest",
@@ -1173,3 +1242,62 @@ func TestSetImageAsOnlyDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkImageDeleted(t *testing.T) {
+	ctx := testutil.TestContext()
+	deletedAt := time.Unix(1234567899, 0)
+
+	var input *dynamodb.UpdateItemInput
+	client := &mockImageClient{
+		updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.UpdateItemOutput, error) {
+			input = params
+			return &dynamodb.UpdateItemOutput{}, nil
+		},
+	}
+	repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+	err := repo.MarkImageDeleted(ctx, "alpine:latest-a1b2c3d4", "user@example.com", deletedAt)
+
+	require.NoError(t, err)
+	require.NotNil(t, input)
+	assert.Contains(t, aws.ToString(input.UpdateExpression), "deleted_at = :deleted_at")
+	assert.Contains(t, aws.ToString(input.UpdateExpression), "REMOVE is_default_placeholder")
+	assert.Equal(t, "attribute_exists(image_id)", aws.ToString(input.ConditionExpression))
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "1234567899"}, input.ExpressionAttributeValues[":deleted_at"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "user@example.com"},
+		input.ExpressionAttributeValues[":deleted_by"])
+}
+
+func TestRestoreImage(t *testing.T) {
+	ctx := testutil.TestContext()
+
+	t.Run("removes the deletion mark", func(t *testing.T) {
+		var input *dynamodb.UpdateItemInput
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				input = params
+				return &dynamodb.UpdateItemOutput{}, nil
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		require.NoError(t, repo.RestoreImage(ctx, "alpine:latest-a1b2c3d4"))
+		require.NotNil(t, input)
+		assert.Contains(t, aws.ToString(input.UpdateExpression), "REMOVE deleted_at, deleted_by")
+	})
+
+	t.Run("image not found", func(t *testing.T) {
+		client := &mockImageClient{
+			updateItemFunc: func(_ context.Context, _ *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.UpdateItemOutput, error) {
+				return nil, &types.ConditionalCheckFailedException{}
+			},
+		}
+		repo := NewImageTaskDefRepository(client, "test-table", testutil.SilentLogger())
+
+		err := repo.RestoreImage(ctx, "alpine:latest-a1b2c3d4")
+		assert.Equal(t, http.StatusNotFound, apperrors.GetStatusCode(err))
+	})
+}
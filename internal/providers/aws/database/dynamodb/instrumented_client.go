@@ -0,0 +1,198 @@
+package dynamodb
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// InstrumentedClient wraps a Client to time each operation and count the items it read or wrote.
+// The operations are aggregated by access pattern (operation, table and index) in a database.QueryStats,
+// and those slower than its threshold are logged, to find hot partitions and missing indexes.
+type InstrumentedClient struct {
+	client Client
+	stats  *database.QueryStats
+	logger *slog.Logger
+}
+
+// NewInstrumentedClient creates a new InstrumentedClient recording the operations of client in stats.
+func NewInstrumentedClient(client Client, stats *database.QueryStats, log *slog.Logger) *InstrumentedClient {
+	return &InstrumentedClient{client: client, stats: stats, logger: log}
+}
+
+// PutItem wraps the PutItem operation of the client.
+func (c *InstrumentedClient) PutItem(
+	ctx context.Context,
+	params *dynamodb.PutItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.PutItemOutput, error) {
+	start := time.Now()
+	result, err := c.client.PutItem(ctx, params, optFns...)
+	c.record(ctx, "PutItem", aws.ToString(params.TableName), "", start, 1, 0, err)
+	return result, err
+}
+
+// GetItem wraps the GetItem operation of the client.
+func (c *InstrumentedClient) GetItem(
+	ctx context.Context,
+	params *dynamodb.GetItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.GetItemOutput, error) {
+	start := time.Now()
+	result, err := c.client.GetItem(ctx, params, optFns...)
+	items := 0
+	if result != nil && result.Item != nil {
+		items = 1
+	}
+	c.record(ctx, "GetItem", aws.ToString(params.TableName), "", start, items, items, err)
+	return result, err
+}
+
+// Query wraps the Query operation of the client. Queries scanning far more items than they return
+// are filtering on attributes an index should cover.
+func (c *InstrumentedClient) Query(
+	ctx context.Context,
+	params *dynamodb.QueryInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.QueryOutput, error) {
+	start := time.Now()
+	result, err := c.client.Query(ctx, params, optFns...)
+	items, scanned := 0, 0
+	if result != nil {
+		items, scanned = int(result.Count), int(result.ScannedCount)
+	}
+	c.record(ctx, "Query", aws.ToString(params.TableName), aws.ToString(params.IndexName), start, items, scanned, err)
+	return result, err
+}
+
+// Scan wraps the Scan operation of the client, only used on small tables.
+func (c *InstrumentedClient) Scan(
+	ctx context.Context,
+	params *dynamodb.ScanInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	start := time.Now()
+	result, err := c.client.Scan(ctx, params, optFns...)
+	items, scanned := 0, 0
+	if result != nil {
+		items, scanned = int(result.Count), int(result.ScannedCount)
+	}
+	c.record(ctx, "Scan", aws.ToString(params.TableName), aws.ToString(params.IndexName), start, items, scanned, err)
+	return result, err
+}
+
+// UpdateItem wraps the UpdateItem operation of the client.
+func (c *InstrumentedClient) UpdateItem(
+	ctx context.Context,
+	params *dynamodb.UpdateItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.UpdateItemOutput, error) {
+	start := time.Now()
+	result, err := c.client.UpdateItem(ctx, params, optFns...)
+	c.record(ctx, "UpdateItem", aws.ToString(params.TableName), "", start, 1, 0, err)
+	return result, err
+}
+
+// DeleteItem wraps the DeleteItem operation of the client.
+func (c *InstrumentedClient) DeleteItem(
+	ctx context.Context,
+	params *dynamodb.DeleteItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.DeleteItemOutput, error) {
+	start := time.Now()
+	result, err := c.client.DeleteItem(ctx, params, optFns...)
+	c.record(ctx, "DeleteItem", aws.ToString(params.TableName), "", start, 1, 0, err)
+	return result, err
+}
+
+// BatchWriteItem wraps the BatchWriteItem operation of the client. The batch is recorded on its first table,
+// the repositories only batching writes to a single table.
+func (c *InstrumentedClient) BatchWriteItem(
+	ctx context.Context,
+	params *dynamodb.BatchWriteItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.BatchWriteItemOutput, error) {
+	start := time.Now()
+	result, err := c.client.BatchWriteItem(ctx, params, optFns...)
+	tables := slices.Sorted(maps.Keys(params.RequestItems))
+	table, items := "", 0
+	if len(tables) > 0 {
+		table = tables[0]
+	}
+	for _, requests := range params.RequestItems {
+		items += len(requests)
+	}
+	c.record(ctx, "BatchWriteItem", table, "", start, items, 0, err)
+	return result, err
+}
+
+// TransactWriteItems wraps the TransactWriteItems operation of the client. The transaction is recorded on
+// the table of its first item, the repositories only writing transactions to a single table.
+func (c *InstrumentedClient) TransactWriteItems(
+	ctx context.Context,
+	params *dynamodb.TransactWriteItemsInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	start := time.Now()
+	result, err := c.client.TransactWriteItems(ctx, params, optFns...)
+	table := ""
+	if len(params.TransactItems) > 0 {
+		table = transactItemTable(params.TransactItems[0])
+	}
+	c.record(ctx, "TransactWriteItems", table, "", start, len(params.TransactItems), 0, err)
+	return result, err
+}
+
+// transactItemTable returns the table written by an item of a transaction.
+func transactItemTable(item types.TransactWriteItem) string {
+	switch {
+	case item.Put != nil:
+		return aws.ToString(item.Put.TableName)
+	case item.Update != nil:
+		return aws.ToString(item.Update.TableName)
+	case item.Delete != nil:
+		return aws.ToString(item.Delete.TableName)
+	case item.ConditionCheck != nil:
+		return aws.ToString(item.ConditionCheck.TableName)
+	default:
+		return ""
+	}
+}
+
+func (c *InstrumentedClient) record(
+	ctx context.Context,
+	operation, table, index string,
+	start time.Time,
+	items, scanned int,
+	err error,
+) {
+	duration := time.Since(start)
+	pattern := accessPattern(operation, table, index)
+	if !c.stats.Record(pattern, duration, items, err) {
+		return
+	}
+
+	logger.DeriveRequestLogger(ctx, c.logger).Warn("slow database query", "context", map[string]any{
+		"pattern":     pattern,
+		"duration_ms": duration.Milliseconds(),
+		"items":       items,
+		"scanned":     scanned,
+	})
+}
+
+// accessPattern returns the pattern the operations are aggregated by, e.g. "Query runvoy-executions/all-started_at".
+func accessPattern(operation, table, index string) string {
+	if index == "" {
+		return operation + " " + table
+	}
+	return operation + " " + table + "/" + index
+}
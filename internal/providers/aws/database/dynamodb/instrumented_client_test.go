@@ -0,0 +1,74 @@
+package dynamodb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedClient(t *testing.T) {
+	mock := NewMockDynamoDBClient()
+	stats := database.NewQueryStats(time.Hour)
+	client := NewInstrumentedClient(mock, stats, testutil.SilentLogger())
+	ctx := context.Background()
+
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String("runvoy-executions"),
+		Item:      map[string]types.AttributeValue{"execution_id": &types.AttributeValueMemberS{Value: "exec-1"}},
+	})
+	require.NoError(t, err)
+	_, err = client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("runvoy-executions"),
+		Key:       map[string]types.AttributeValue{"execution_id": &types.AttributeValueMemberS{Value: "exec-1"}},
+	})
+	require.NoError(t, err)
+	_, err = client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{"runvoy-execution-logs": make([]types.WriteRequest, 3)},
+	})
+	require.NoError(t, err)
+
+	mock.QueryError = errors.New("throttled")
+	_, err = client.Query(ctx, &dynamodb.QueryInput{
+		TableName: aws.String("runvoy-executions"),
+		IndexName: aws.String("all-started_at"),
+	})
+	require.Error(t, err)
+
+	queries := make(map[string][2]int64)
+	for _, query := range stats.Snapshot().Queries {
+		queries[query.Pattern] = [2]int64{query.Items, query.Errors}
+	}
+	assert.Equal(t, map[string][2]int64{
+		"PutItem runvoy-executions":              {1, 0},
+		"GetItem runvoy-executions":              {1, 0},
+		"BatchWriteItem runvoy-execution-logs":   {3, 0},
+		"Query runvoy-executions/all-started_at": {0, 1},
+	}, queries)
+}
+
+func TestInstrumentedClient_LogsSlowQueries(t *testing.T) {
+	var logs bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&logs, nil))
+	client := NewInstrumentedClient(NewMockDynamoDBClient(), database.NewQueryStats(time.Nanosecond), log)
+
+	_, err := client.Query(context.Background(), &dynamodb.QueryInput{
+		TableName: aws.String("runvoy-executions"),
+		IndexName: aws.String("all-started_at"),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "slow database query")
+	assert.Contains(t, logs.String(), `"pattern":"Query runvoy-executions/all-started_at"`)
+}
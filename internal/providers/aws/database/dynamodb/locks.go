@@ -0,0 +1,281 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// LockRepository implements the database.LockRepository interface using DynamoDB.
+// Locks share the constant _all partition and are sorted by name, so they are listed with a single query.
+// Their expires_at attribute is the TTL attribute of the table, which deletes the expired locks eventually:
+// until then, expired locks are ignored and taken over by conditional writes.
+type LockRepository struct {
+	client    Client
+	tableName string
+	logger    *slog.Logger
+}
+
+// NewLockRepository creates a new DynamoDB-backed lock repository.
+func NewLockRepository(
+	client Client,
+	tableName string,
+	log *slog.Logger,
+) database.LockRepository {
+	return &LockRepository{
+		client:    client,
+		tableName: tableName,
+		logger:    log,
+	}
+}
+
+// lockItem represents the structure stored in DynamoDB, with the timestamps in Unix seconds.
+type lockItem struct {
+	All         string `dynamodbav:"_all"`
+	Name        string `dynamodbav:"name"`
+	Token       string `dynamodbav:"token"`
+	ExecutionID string `dynamodbav:"execution_id,omitempty"`
+	HeldBy      string `dynamodbav:"held_by"`
+	AcquiredAt  int64  `dynamodbav:"acquired_at"`
+	ExpiresAt   int64  `dynamodbav:"expires_at"`
+}
+
+func toLockItem(lock *api.ExecutionLock) *lockItem {
+	return &lockItem{
+		All:         awsConstants.DynamoDBAllValue,
+		Name:        lock.Name,
+		Token:       lock.Token,
+		ExecutionID: lock.ExecutionID,
+		HeldBy:      lock.HeldBy,
+		AcquiredAt:  lock.AcquiredAt.Unix(),
+		ExpiresAt:   lock.ExpiresAt.Unix(),
+	}
+}
+
+func (item *lockItem) toAPI() api.ExecutionLock {
+	return api.ExecutionLock{
+		Name:        item.Name,
+		Token:       item.Token,
+		ExecutionID: item.ExecutionID,
+		HeldBy:      item.HeldBy,
+		AcquiredAt:  time.Unix(item.AcquiredAt, 0).UTC(),
+		ExpiresAt:   time.Unix(item.ExpiresAt, 0).UTC(),
+	}
+}
+
+// AcquireLock stores the lock unless a lock of the same name is held and not expired at now.
+func (r *LockRepository) AcquireLock(ctx context.Context, lock *api.ExecutionLock, now time.Time) (bool, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	item, err := attributevalue.MarshalMap(toLockItem(lock))
+	if err != nil {
+		return false, sdkerrors.Map(err, "failed to marshal lock", apperrors.ErrDatabaseError)
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"name", lock.Name,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.tableName),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#name) OR expires_at <= :now"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return false, nil
+		}
+		return false, sdkerrors.Map(err, "failed to acquire lock", apperrors.ErrDatabaseError)
+	}
+
+	return true, nil
+}
+
+// GetLock retrieves a lock by name, expired or not. Returns nil if the lock doesn't exist.
+func (r *LockRepository) GetLock(ctx context.Context, name string) (*api.ExecutionLock, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+		"name", name,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.tableName),
+		Key:            lockKey(name),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to get lock", apperrors.ErrDatabaseError)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item lockItem
+	if err = attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal lock", apperrors.ErrDatabaseError)
+	}
+
+	lock := item.toAPI()
+	return &lock, nil
+}
+
+// ListLocks returns the locks not expired at now, sorted by name.
+func (r *LockRepository) ListLocks(ctx context.Context, now time.Time) ([]api.ExecutionLock, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	locks := []api.ExecutionLock{}
+	var lastKey map[string]types.AttributeValue
+	for {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.tableName),
+			KeyConditionExpression: aws.String("#all = :all"),
+			FilterExpression:       aws.String("expires_at > :now"),
+			ExpressionAttributeNames: map[string]string{
+				"#all": awsConstants.DynamoDBAllAttribute,
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":all": &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+				":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+			},
+			ExclusiveStartKey: lastKey,
+		})
+		if err != nil {
+			return nil, sdkerrors.Map(err, "failed to query locks", apperrors.ErrDatabaseError)
+		}
+
+		var items []lockItem
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &items); err != nil {
+			return nil, sdkerrors.Map(err, "failed to unmarshal locks", apperrors.ErrDatabaseError)
+		}
+		for i := range items {
+			locks = append(locks, items[i].toAPI())
+		}
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastKey = out.LastEvaluatedKey
+	}
+
+	return locks, nil
+}
+
+// SetLockExecution records the execution holding the lock acquired with token.
+// Returns ErrNotFound if the lock is no longer held with the token.
+func (r *LockRepository) SetLockExecution(ctx context.Context, name, token, executionID string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"name", name,
+		"execution_id", executionID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.tableName),
+		Key:                 lockKey(name),
+		UpdateExpression:    aws.String("SET execution_id = :execution_id"),
+		ConditionExpression: aws.String("#token = :token"),
+		ExpressionAttributeNames: map[string]string{
+			"#token": "token",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":execution_id": &types.AttributeValueMemberS{Value: executionID},
+			":token":        &types.AttributeValueMemberS{Value: token},
+		},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("lock not found", err)
+		}
+		return sdkerrors.Map(err, "failed to update lock", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// ReleaseLock removes a lock held with holder, either the token it was acquired with or the ID of its
+// execution, or any lock of that name when holder is empty. Returns ErrNotFound otherwise.
+func (r *LockRepository) ReleaseLock(ctx context.Context, name, holder string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.DeleteItem",
+		"table", r.tableName,
+		"name", name,
+		"holder", holder,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	input := &dynamodb.DeleteItemInput{
+		TableName:                aws.String(r.tableName),
+		Key:                      lockKey(name),
+		ConditionExpression:      aws.String("attribute_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+	}
+	if holder != "" {
+		input.ConditionExpression = aws.String("#token = :holder OR execution_id = :holder")
+		input.ExpressionAttributeNames = map[string]string{"#token": "token"}
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":holder": &types.AttributeValueMemberS{Value: holder},
+		}
+	}
+
+	if _, err := r.client.DeleteItem(ctx, input); err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return apperrors.ErrNotFound("lock not found", err)
+		}
+		return sdkerrors.Map(err, "failed to release lock", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+func lockKey(name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		awsConstants.DynamoDBAllAttribute: &types.AttributeValueMemberS{Value: awsConstants.DynamoDBAllValue},
+		"name":                            &types.AttributeValueMemberS{Value: name},
+	}
+}
@@ -0,0 +1,124 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockRepository_AcquireGetList(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	var stored map[string]types.AttributeValue
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "locks", aws.ToString(params.TableName))
+			assert.Contains(t, aws.ToString(params.ConditionExpression), "expires_at <= :now")
+			assert.Equal(t, &types.AttributeValueMemberN{Value: "1792324800"}, params.ExpressionAttributeValues[":now"])
+			stored = params.Item
+			return &dynamodb.PutItemOutput{}, nil
+		},
+		getItemFunc: func(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "deploy-prod"}, params.Key["name"])
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		},
+		queryFunc: func(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.QueryOutput, error) {
+			assert.Equal(t, "expires_at > :now", aws.ToString(params.FilterExpression))
+			return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{stored}}, nil
+		},
+	}
+	repo := NewLockRepository(client, "locks", testutil.SilentLogger())
+
+	lock := &api.ExecutionLock{
+		Name:       "deploy-prod",
+		Token:      "token-1",
+		HeldBy:     "alice@example.com",
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	acquired, err := repo.AcquireLock(context.Background(), lock, now)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "1792328400"}, stored["expires_at"])
+
+	got, err := repo.GetLock(context.Background(), "deploy-prod")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, *lock, *got)
+
+	locks, err := repo.ListLocks(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "alice@example.com", locks[0].HeldBy)
+}
+
+func TestLockRepository_AcquireHeld(t *testing.T) {
+	client := &mockImageClient{
+		putItemFunc: func(_ context.Context, _ *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error) {
+			return nil, &types.ConditionalCheckFailedException{}
+		},
+	}
+	repo := NewLockRepository(client, "locks", testutil.SilentLogger())
+
+	now := time.Now()
+	acquired, err := repo.AcquireLock(context.Background(),
+		&api.ExecutionLock{Name: "deploy-prod", AcquiredAt: now, ExpiresAt: now.Add(time.Hour)}, now)
+
+	require.NoError(t, err)
+	assert.False(t, acquired)
+}
+
+func TestLockRepository_Release(t *testing.T) {
+	t.Run("releases the lock of its holder", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				assert.Equal(t, "#token = :holder OR execution_id = :holder", aws.ToString(params.ConditionExpression))
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "exec-1"}, params.ExpressionAttributeValues[":holder"])
+				return &dynamodb.DeleteItemOutput{}, nil
+			},
+		}
+		repo := NewLockRepository(client, "locks", testutil.SilentLogger())
+
+		assert.NoError(t, repo.ReleaseLock(context.Background(), "deploy-prod", "exec-1"))
+	})
+
+	t.Run("releases any lock without a holder", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				assert.Equal(t, "attribute_exists(#name)", aws.ToString(params.ConditionExpression))
+				return &dynamodb.DeleteItemOutput{}, nil
+			},
+		}
+		repo := NewLockRepository(client, "locks", testutil.SilentLogger())
+
+		assert.NoError(t, repo.ReleaseLock(context.Background(), "deploy-prod", ""))
+	})
+
+	t.Run("returns not found for a lock held by another", func(t *testing.T) {
+		client := &mockImageClient{
+			deleteItemFunc: func(_ context.Context, _ *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+				*dynamodb.DeleteItemOutput, error) {
+				return nil, &types.ConditionalCheckFailedException{}
+			},
+		}
+		repo := NewLockRepository(client, "locks", testutil.SilentLogger())
+
+		err := repo.ReleaseLock(context.Background(), "deploy-prod", "exec-2")
+		assert.Equal(t, appErrors.ErrCodeNotFound, appErrors.GetErrorCode(err))
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
@@ -13,6 +15,7 @@ import (
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -36,12 +39,25 @@ func NewLogEventRepository(client Client, tableName string, log *slog.Logger) da
 	}
 }
 
+// The log events of an execution share its partition, under three kinds of sort keys:
+//   - the sequence counter of the execution, handing out the sequence numbers of its events;
+//   - one batch item per batch of events saved, recording the sequence numbers reserved for it;
+//   - one item per event, keyed by its zero-padded sequence number so events sort in sequence order.
+//
+// Processor instances saving batches of the same execution concurrently each reserve a distinct
+// range of numbers, so their writes never collide and the events keep one order for every reader.
+const (
+	logSequenceCounterKey = "#sequence"
+	logBatchKeyPrefix     = "#batch#"
+)
+
 type logEventItem struct {
 	ExecutionID string `dynamodbav:"execution_id"`
 	EventKey    string `dynamodbav:"event_key"`
 	EventID     string `dynamodbav:"event_id"`
 	Timestamp   int64  `dynamodbav:"timestamp"`
 	Message     string `dynamodbav:"message"`
+	Sequence    int64  `dynamodbav:"sequence,omitempty"`
 }
 
 func (i *logEventItem) toAPILogEvent() api.LogEvent {
@@ -49,10 +65,28 @@ func (i *logEventItem) toAPILogEvent() api.LogEvent {
 		EventID:   i.EventID,
 		Timestamp: i.Timestamp,
 		Message:   i.Message,
+		Sequence:  i.Sequence,
 	}
 }
 
-// SaveLogEvents writes all provided log events for an execution.
+// logBatchItem records the sequence numbers reserved for a batch of log events and whether
+// the batch was saved. ReservedAt is in Unix milliseconds.
+type logBatchItem struct {
+	ExecutionID   string `dynamodbav:"execution_id"`
+	EventKey      string `dynamodbav:"event_key"`
+	FirstSequence int64  `dynamodbav:"first_sequence"`
+	EventCount    int    `dynamodbav:"event_count"`
+	ReservedAt    int64  `dynamodbav:"reserved_at"`
+	Committed     bool   `dynamodbav:"committed,omitempty"`
+}
+
+// pending reports whether the batch is still being saved and holds back the delivery of the batches after it.
+func (b *logBatchItem) pending(now time.Time) bool {
+	return !b.Committed && now.Sub(time.UnixMilli(b.ReservedAt)) < awsconstants.LogBatchCommitTimeout
+}
+
+// SaveLogEvents writes a batch of log events for an execution, numbered with the sequence numbers
+// reserved for the batch, then marks the batch saved. A batch saved already is not written again.
 func (r *LogEventRepository) SaveLogEvents(ctx context.Context, executionID string, logEvents []api.LogEvent) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
@@ -64,19 +98,33 @@ func (r *LogEventRepository) SaveLogEvents(ctx context.Context, executionID stri
 		return nil
 	}
 
+	batch, err := r.reserveSequences(ctx, executionID, logEvents)
+	if err != nil {
+		return err
+	}
+	if batch.Committed {
+		reqLogger.Debug("log events already stored", "context", map[string]any{
+			"execution_id": executionID,
+			"batch_key":    batch.EventKey,
+		})
+		return nil
+	}
+
 	requests := make([]types.WriteRequest, 0, len(logEvents))
 	for i, event := range logEvents {
+		sequence := batch.FirstSequence + int64(i)
 		item := &logEventItem{
 			ExecutionID: executionID,
-			EventKey:    buildEventKey(event, i),
+			EventKey:    buildEventKey(sequence),
 			EventID:     event.EventID,
 			Timestamp:   event.Timestamp,
 			Message:     event.Message,
+			Sequence:    sequence,
 		}
 
-		av, err := attributevalue.MarshalMap(item)
-		if err != nil {
-			return appErrors.ErrDatabaseError("failed to marshal log event", err)
+		av, marshalErr := attributevalue.MarshalMap(item)
+		if marshalErr != nil {
+			return appErrors.ErrDatabaseError("failed to marshal log event", marshalErr)
 		}
 
 		requests = append(requests, types.WriteRequest{
@@ -84,19 +132,109 @@ func (r *LogEventRepository) SaveLogEvents(ctx context.Context, executionID stri
 		})
 	}
 
-	if err := r.batchWrite(ctx, requests); err != nil {
+	if err = r.batchWrite(ctx, requests); err != nil {
 		return err
 	}
 
+	if _, err = r.updateLogItem(ctx, executionID, batch.EventKey, "SET committed = :committed",
+		map[string]types.AttributeValue{":committed": &types.AttributeValueMemberBOOL{Value: true}},
+		types.ReturnValueNone,
+	); err != nil {
+		return sdkerrors.Map(err, "failed to mark log events batch as stored", appErrors.ErrDatabaseError)
+	}
+
 	reqLogger.Debug("log events stored", "context", map[string]any{
-		"execution_id": executionID,
-		"event_count":  len(logEvents),
+		"execution_id":   executionID,
+		"event_count":    len(logEvents),
+		"first_sequence": batch.FirstSequence,
 	})
 
 	return nil
 }
 
-// ListLogEvents retrieves all buffered log events for an execution ordered by event key.
+// reserveSequences reserves the sequence numbers of a batch of log events. It takes the next numbers of
+// the execution's counter, then records them on the batch item with a conditional write keeping the
+// numbers recorded first: a batch delivered again gets the numbers of its first delivery back.
+func (r *LogEventRepository) reserveSequences(
+	ctx context.Context,
+	executionID string,
+	logEvents []api.LogEvent,
+) (*logBatchItem, error) {
+	count := strconv.Itoa(len(logEvents))
+	counter, err := r.updateLogItem(ctx, executionID, logSequenceCounterKey, "ADD last_sequence :count",
+		map[string]types.AttributeValue{":count": &types.AttributeValueMemberN{Value: count}},
+		types.ReturnValueUpdatedNew,
+	)
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to reserve log event sequence numbers", appErrors.ErrDatabaseError)
+	}
+	var reserved struct {
+		LastSequence int64 `dynamodbav:"last_sequence"`
+	}
+	if err = attributevalue.UnmarshalMap(counter, &reserved); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal log event sequence counter", appErrors.ErrDatabaseError)
+	}
+	firstSequence := reserved.LastSequence - int64(len(logEvents)) + 1
+
+	attributes, err := r.updateLogItem(ctx, executionID, buildBatchKey(logEvents),
+		"SET first_sequence = if_not_exists(first_sequence, :first_sequence), "+
+			"event_count = if_not_exists(event_count, :event_count), "+
+			"reserved_at = if_not_exists(reserved_at, :reserved_at)",
+		map[string]types.AttributeValue{
+			":first_sequence": &types.AttributeValueMemberN{Value: strconv.FormatInt(firstSequence, 10)},
+			":event_count":    &types.AttributeValueMemberN{Value: count},
+			":reserved_at":    &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().UnixMilli(), 10)},
+		},
+		types.ReturnValueAllNew,
+	)
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to reserve log events batch", appErrors.ErrDatabaseError)
+	}
+
+	var batch logBatchItem
+	if err = attributevalue.UnmarshalMap(attributes, &batch); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal log events batch", appErrors.ErrDatabaseError)
+	}
+	return &batch, nil
+}
+
+// updateLogItem applies an update expression to an item of the execution's partition and returns
+// the attributes requested by returnValues.
+func (r *LogEventRepository) updateLogItem(
+	ctx context.Context,
+	executionID, eventKey, updateExpression string,
+	values map[string]types.AttributeValue,
+	returnValues types.ReturnValue,
+) (map[string]types.AttributeValue, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"execution_id", executionID,
+		"event_key", eventKey,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	output, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"execution_id": &types.AttributeValueMemberS{Value: executionID},
+			"event_key":    &types.AttributeValueMemberS{Value: eventKey},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeValues: values,
+		ReturnValues:              returnValues,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Attributes, nil
+}
+
+// ListLogEvents retrieves the buffered log events of an execution ordered by sequence, up to the
+// first batch still being saved: delivering the events after it would skip the batch's events.
 func (r *LogEventRepository) ListLogEvents(ctx context.Context, executionID string) ([]api.LogEvent, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
@@ -110,6 +248,8 @@ func (r *LogEventRepository) ListLogEvents(ctx context.Context, executionID stri
 
 	var startKey map[string]types.AttributeValue
 	results := make([]api.LogEvent, 0)
+	now := time.Now()
+	var pendingSequence int64
 
 	for {
 		queryOutput, err := r.client.Query(ctx, &dynamodb.QueryInput{
@@ -118,30 +258,59 @@ func (r *LogEventRepository) ListLogEvents(ctx context.Context, executionID stri
 			ExpressionAttributeValues: exprValues,
 			ExclusiveStartKey:         startKey,
 			ScanIndexForward:          aws.Bool(true),
+			ConsistentRead:            aws.Bool(true),
 		})
 		if err != nil {
-			return nil, appErrors.ErrDatabaseError("failed to query log events", err)
+			return nil, sdkerrors.Map(err, "failed to query log events", appErrors.ErrDatabaseError)
 		}
 
 		for _, item := range queryOutput.Items {
-			var logItem logEventItem
-			if unmarshalErr := attributevalue.UnmarshalMap(item, &logItem); unmarshalErr != nil {
-				return nil, fmt.Errorf("failed to unmarshal log event: %w", unmarshalErr)
+			eventKey := ""
+			if key, ok := item["event_key"].(*types.AttributeValueMemberS); ok {
+				eventKey = key.Value
 			}
 
-			results = append(results, logItem.toAPILogEvent())
+			switch {
+			case eventKey == logSequenceCounterKey:
+				continue
+			case strings.HasPrefix(eventKey, logBatchKeyPrefix):
+				var batch logBatchItem
+				if unmarshalErr := attributevalue.UnmarshalMap(item, &batch); unmarshalErr != nil {
+					return nil, fmt.Errorf("failed to unmarshal log events batch: %w", unmarshalErr)
+				}
+				if batch.pending(now) && (pendingSequence == 0 || batch.FirstSequence < pendingSequence) {
+					pendingSequence = batch.FirstSequence
+				}
+			default:
+				var logItem logEventItem
+				if unmarshalErr := attributevalue.UnmarshalMap(item, &logItem); unmarshalErr != nil {
+					return nil, fmt.Errorf("failed to unmarshal log event: %w", unmarshalErr)
+				}
+
+				results = append(results, logItem.toAPILogEvent())
+			}
 		}
 
 		if len(queryOutput.LastEvaluatedKey) == 0 {
-			reqLogger.Debug("log events retrieved", "context", map[string]any{
-				"execution_id": executionID,
-				"event_count":  len(results),
-			})
-			return results, nil
+			break
 		}
 
 		startKey = queryOutput.LastEvaluatedKey
 	}
+
+	if pendingSequence > 0 {
+		ready := slices.IndexFunc(results, func(event api.LogEvent) bool { return event.Sequence >= pendingSequence })
+		if ready >= 0 {
+			results = results[:ready]
+		}
+	}
+
+	reqLogger.Debug("log events retrieved", "context", map[string]any{
+		"execution_id":     executionID,
+		"event_count":      len(results),
+		"pending_sequence": pendingSequence,
+	})
+	return results, nil
 }
 
 // DeleteLogEvents schedules stored events for TTL-based deletion.
@@ -168,7 +337,7 @@ func (r *LogEventRepository) DeleteLogEvents(ctx context.Context, executionID st
 			ExclusiveStartKey:         startKey,
 		})
 		if err != nil {
-			return appErrors.ErrDatabaseError("failed to query log events for TTL marking", err)
+			return sdkerrors.Map(err, "failed to query log events for TTL marking", appErrors.ErrDatabaseError)
 		}
 
 		if len(queryOutput.Items) == 0 {
@@ -177,10 +346,21 @@ func (r *LogEventRepository) DeleteLogEvents(ctx context.Context, executionID st
 
 		writeRequests := make([]types.WriteRequest, 0, len(queryOutput.Items))
 		for _, item := range queryOutput.Items {
-			item[awsconstants.DynamoDBExpiresAtAttribute] = &types.AttributeValueMemberN{
-				Value: strconv.FormatInt(expiryTimestamp, 10),
+			expiresAt := &types.AttributeValueMemberN{Value: strconv.FormatInt(expiryTimestamp, 10)}
+
+			// The counter is updated in place: putting it back would undo the reservations made meanwhile.
+			if key, ok := item["event_key"].(*types.AttributeValueMemberS); ok && key.Value == logSequenceCounterKey {
+				if _, err = r.updateLogItem(ctx, executionID, logSequenceCounterKey,
+					"SET "+awsconstants.DynamoDBExpiresAtAttribute+" = :expires_at",
+					map[string]types.AttributeValue{":expires_at": expiresAt}, types.ReturnValueNone,
+				); err != nil {
+					return sdkerrors.Map(err, "failed to mark log event sequence counter for TTL", appErrors.ErrDatabaseError)
+				}
+				continue
 			}
 
+			item[awsconstants.DynamoDBExpiresAtAttribute] = expiresAt
+
 			writeRequests = append(writeRequests, types.WriteRequest{
 				PutRequest: &types.PutRequest{Item: item},
 			})
@@ -223,20 +403,25 @@ func (r *LogEventRepository) batchWrite(ctx context.Context, requests []types.Wr
 			RequestItems: map[string][]types.WriteRequest{r.tableName: batch},
 		})
 		if err != nil {
-			return appErrors.ErrDatabaseError("failed to write log events batch", err)
+			return sdkerrors.Map(err, "failed to write log events batch", appErrors.ErrDatabaseError)
 		}
 	}
 
 	return nil
 }
 
-// buildEventKey derives the DynamoDB range key combining the millisecond
-// timestamp with a deterministic suffix so multiple events at the same moment
-// stay ordered and unique (event ID when present, otherwise a zero-padded index).
-func buildEventKey(event api.LogEvent, index int) string {
-	if event.EventID != "" {
-		return fmt.Sprintf("%013d#%s", event.Timestamp, event.EventID)
-	}
+// buildEventKey derives the DynamoDB range key of a log event from its sequence number, zero-padded
+// so that the keys sort in sequence order.
+func buildEventKey(sequence int64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
 
-	return fmt.Sprintf("%013d#%06d", event.Timestamp, index)
+// buildBatchKey derives the DynamoDB range key of a batch of log events from its first event and
+// its size, which identify a batch delivered again.
+func buildBatchKey(logEvents []api.LogEvent) string {
+	first := logEvents[0].EventID
+	if first == "" {
+		first = strconv.FormatInt(logEvents[0].Timestamp, 10)
+	}
+	return fmt.Sprintf("%s%s#%d", logBatchKeyPrefix, first, len(logEvents))
 }
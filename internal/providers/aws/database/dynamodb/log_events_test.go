@@ -2,7 +2,13 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,13 +16,93 @@ import (
 	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// logTableClient is an in-memory log events table applying the update expressions of the repository
+// atomically, as DynamoDB does for single item writes. beforeBatchWrite, when set, runs before each
+// batch write, outside of the table lock.
+type logTableClient struct {
+	*MockDynamoDBClient
+	beforeBatchWrite func(*dynamodb.BatchWriteItemInput)
+}
+
+func newLogTableClient() *logTableClient {
+	return &logTableClient{MockDynamoDBClient: NewMockDynamoDBClient()}
+}
+
+var setClausePattern = regexp.MustCompile(`(\w+) = (?:if_not_exists\(\w+, (:\w+)\)|(:\w+))`)
+
+func (c *logTableClient) UpdateItem(
+	_ context.Context,
+	params *dynamodb.UpdateItemInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.UpdateItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	partition := c.Tables[*params.TableName]
+	if partition == nil {
+		partition = make(map[string]map[string]map[string]types.AttributeValue)
+		c.Tables[*params.TableName] = partition
+	}
+	executionID := getStringValue(params.Key["execution_id"])
+	eventKey := getStringValue(params.Key["event_key"])
+	if partition[executionID] == nil {
+		partition[executionID] = make(map[string]map[string]types.AttributeValue)
+	}
+	item := maps.Clone(partition[executionID][eventKey])
+	if item == nil {
+		item = maps.Clone(params.Key)
+	}
+
+	expression := *params.UpdateExpression
+	values := params.ExpressionAttributeValues
+	if name, placeholder, ok := strings.Cut(strings.TrimPrefix(expression, "ADD "), " "); ok &&
+		strings.HasPrefix(expression, "ADD ") {
+		current, _ := strconv.ParseInt(getStringValue(item[name]), 10, 64)
+		added, err := strconv.ParseInt(getStringValue(values[placeholder]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		item[name] = &types.AttributeValueMemberN{Value: strconv.FormatInt(current+added, 10)}
+	} else {
+		for _, clause := range setClausePattern.FindAllStringSubmatch(expression, -1) {
+			name, ifNotExists, value := clause[1], clause[2], clause[3]
+			if ifNotExists != "" {
+				if _, exists := item[name]; !exists {
+					item[name] = values[ifNotExists]
+				}
+				continue
+			}
+			item[name] = values[value]
+		}
+	}
+	partition[executionID][eventKey] = item
+
+	if params.ReturnValues == types.ReturnValueNone || params.ReturnValues == "" {
+		return &dynamodb.UpdateItemOutput{}, nil
+	}
+	return &dynamodb.UpdateItemOutput{Attributes: maps.Clone(item)}, nil
+}
+
+func (c *logTableClient) BatchWriteItem(
+	ctx context.Context,
+	params *dynamodb.BatchWriteItemInput,
+	optFns ...func(*dynamodb.Options),
+) (*dynamodb.BatchWriteItemOutput, error) {
+	if c.beforeBatchWrite != nil {
+		c.beforeBatchWrite(params)
+	}
+	return c.MockDynamoDBClient.BatchWriteItem(ctx, params, optFns...)
+}
+
 func TestLogEventRepository_DeleteLogEventsSetsTTL(t *testing.T) {
 	ctx := context.Background()
-	client := NewMockDynamoDBClient()
+	client := newLogTableClient()
 	repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
 
 	executionID := "exec-1"
@@ -27,8 +113,9 @@ func TestLogEventRepository_DeleteLogEventsSetsTTL(t *testing.T) {
 
 	require.NoError(t, repo.SaveLogEvents(ctx, executionID, logEvents))
 
+	// The events, the batch item and the sequence counter
 	items := client.collectTableItems("log-events")
-	require.Len(t, items, len(logEvents))
+	require.Len(t, items, len(logEvents)+2)
 
 	for _, item := range items {
 		_, hasTTL := item[awsconstants.DynamoDBExpiresAtAttribute]
@@ -40,7 +127,7 @@ func TestLogEventRepository_DeleteLogEventsSetsTTL(t *testing.T) {
 	after := time.Now()
 
 	items = client.collectTableItems("log-events")
-	require.Len(t, items, len(logEvents))
+	require.Len(t, items, len(logEvents)+2)
 
 	minTTL := before.Add(9 * time.Minute).Unix()
 	maxTTL := after.Add(11 * time.Minute).Unix()
@@ -60,7 +147,7 @@ func TestLogEventRepository_DeleteLogEventsSetsTTL(t *testing.T) {
 
 func TestLogEventRepository_ListLogEvents(t *testing.T) {
 	ctx := context.Background()
-	client := NewMockDynamoDBClient()
+	client := newLogTableClient()
 	repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
 
 	executionID := "exec-2"
@@ -80,5 +167,135 @@ func TestLogEventRepository_ListLogEvents(t *testing.T) {
 		assert.Equal(t, logEvents[i].EventID, event.EventID)
 		assert.Equal(t, logEvents[i].Timestamp, event.Timestamp)
 		assert.Equal(t, logEvents[i].Message, event.Message)
+		assert.Equal(t, int64(i+1), event.Sequence)
 	}
 }
+
+func TestLogEventRepository_InterleavedBatches(t *testing.T) {
+	ctx := context.Background()
+	executionID := "exec-3"
+	batchA := []api.LogEvent{{EventID: "a-1", Timestamp: 10, Message: "a1"}, {EventID: "a-2", Timestamp: 11}}
+	batchB := []api.LogEvent{{EventID: "b-1", Timestamp: 12, Message: "b1"}, {EventID: "b-2", Timestamp: 13}}
+	eventIDs := func(logEvents []api.LogEvent) []string {
+		ids := make([]string, 0, len(logEvents))
+		for _, event := range logEvents {
+			ids = append(ids, event.EventID)
+		}
+		return ids
+	}
+
+	t.Run("holds back the batches saved after a batch still being saved", func(t *testing.T) {
+		client := newLogTableClient()
+		repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
+		writingA := make(chan struct{})
+		releaseA := make(chan struct{})
+		client.beforeBatchWrite = func(params *dynamodb.BatchWriteItemInput) {
+			if getStringValue(params.RequestItems["log-events"][0].PutRequest.Item["event_id"]) == "a-1" {
+				close(writingA)
+				<-releaseA
+			}
+		}
+
+		savedA := make(chan error, 1)
+		go func() { savedA <- repo.SaveLogEvents(ctx, executionID, batchA) }()
+		<-writingA
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchB))
+
+		pending, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Empty(t, pending, "batch B must wait for batch A, reserved before it")
+
+		close(releaseA)
+		require.NoError(t, <-savedA)
+
+		fetched, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a-1", "a-2", "b-1", "b-2"}, eventIDs(fetched))
+		for i, event := range fetched {
+			assert.Equal(t, int64(i+1), event.Sequence)
+		}
+	})
+
+	t.Run("keeps the sequence numbers of a batch saved again", func(t *testing.T) {
+		client := newLogTableClient()
+		repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
+
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchA))
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchB))
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchA))
+
+		fetched, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a-1", "a-2", "b-1", "b-2"}, eventIDs(fetched))
+	})
+
+	t.Run("resumes a batch whose save failed", func(t *testing.T) {
+		client := newLogTableClient()
+		repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
+
+		client.BatchWriteItemError = errors.New("throttled")
+		require.Error(t, repo.SaveLogEvents(ctx, executionID, batchA))
+		client.BatchWriteItemError = nil
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchB))
+
+		pending, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchA))
+
+		fetched, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a-1", "a-2", "b-1", "b-2"}, eventIDs(fetched))
+	})
+
+	t.Run("skips batches not saved in time", func(t *testing.T) {
+		client := newLogTableClient()
+		repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
+
+		client.BatchWriteItemError = errors.New("throttled")
+		require.Error(t, repo.SaveLogEvents(ctx, executionID, batchA))
+		client.BatchWriteItemError = nil
+		require.NoError(t, repo.SaveLogEvents(ctx, executionID, batchB))
+		expired := time.Now().Add(-awsconstants.LogBatchCommitTimeout - time.Second).UnixMilli()
+		batchKey := buildBatchKey(batchA)
+		client.Tables["log-events"][executionID][batchKey]["reserved_at"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(expired, 10),
+		}
+
+		fetched, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b-1", "b-2"}, eventIDs(fetched))
+	})
+
+	t.Run("numbers the batches of concurrent processors without gaps or collisions", func(t *testing.T) {
+		client := newLogTableClient()
+		repo := NewLogEventRepository(client, "log-events", testutil.SilentLogger())
+
+		const batches = 20
+		var wg sync.WaitGroup
+		for b := range batches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				batch := []api.LogEvent{
+					{EventID: fmt.Sprintf("%02d-1", b), Timestamp: int64(b)},
+					{EventID: fmt.Sprintf("%02d-2", b), Timestamp: int64(b)},
+				}
+				assert.NoError(t, repo.SaveLogEvents(ctx, executionID, batch))
+			}()
+		}
+		wg.Wait()
+
+		fetched, err := repo.ListLogEvents(ctx, executionID)
+		require.NoError(t, err)
+		require.Len(t, fetched, 2*batches)
+		for i, event := range fetched {
+			assert.Equal(t, int64(i+1), event.Sequence)
+		}
+		// The events of a batch stay together, in order
+		for i := 0; i < len(fetched); i += 2 {
+			assert.Equal(t, strings.TrimSuffix(fetched[i].EventID, "-1")+"-2", fetched[i+1].EventID)
+		}
+	})
+}
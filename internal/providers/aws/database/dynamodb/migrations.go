@@ -0,0 +1,305 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/database/migrations"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MigrationClient defines the DynamoDB operations used by the migrations executor and ledger.
+// Migrations scan whole tables, which repositories never do, hence the separate interface.
+type MigrationClient interface {
+	Scan(
+		ctx context.Context,
+		params *dynamodb.ScanInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.ScanOutput, error)
+	UpdateItem(
+		ctx context.Context,
+		params *dynamodb.UpdateItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.UpdateItemOutput, error)
+	PutItem(
+		ctx context.Context,
+		params *dynamodb.PutItemInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.PutItemOutput, error)
+}
+
+// collectionKeyAttributes maps the backend collections to the partition key of their table.
+var collectionKeyAttributes = map[migrations.Collection]string{
+	migrations.CollectionAPIKeys:         "api_key_hash",
+	migrations.CollectionExecutions:      "execution_id",
+	migrations.CollectionSecretsMetadata: "secret_name",
+	migrations.CollectionImageTaskDefs:   "image_id",
+}
+
+// Migrations returns the migrations of the DynamoDB backend.
+func Migrations() []migrations.Migration {
+	return []migrations.Migration{
+		{
+			Version:     1,
+			Name:        "backfill-all-field",
+			Description: "Add the _all attribute used by the list indexes to existing items",
+			Up: func(ctx context.Context, exec migrations.Executor) (int, error) {
+				total := 0
+				for _, collection := range []migrations.Collection{
+					migrations.CollectionAPIKeys,
+					migrations.CollectionExecutions,
+					migrations.CollectionSecretsMetadata,
+					migrations.CollectionImageTaskDefs,
+				} {
+					changed, err := exec.BackfillField(
+						ctx, collection, awsConstants.DynamoDBAllAttribute, awsConstants.DynamoDBAllValue)
+					if err != nil {
+						return total, err
+					}
+					total += changed
+				}
+				return total, nil
+			},
+		},
+		{
+			Version:     2,
+			Name:        "backfill-execution-search-text",
+			Description: "Add the search_text attribute matched by execution searches to existing executions",
+			Up: func(ctx context.Context, exec migrations.Executor) (int, error) {
+				return exec.BackfillDerivedField(
+					ctx,
+					migrations.CollectionExecutions,
+					searchTextAttrName,
+					[]string{"command", "image_id", "image_alias", "playbook"},
+					func(values map[string]string) string {
+						return database.ExecutionSearchText(
+							values["command"], values["image_id"], values["image_alias"], values["playbook"])
+					},
+				)
+			},
+		},
+	}
+}
+
+// MigrationExecutor implements the migrations.Executor interface using DynamoDB.
+type MigrationExecutor struct {
+	client MigrationClient
+	tables map[migrations.Collection]string
+	dryRun bool
+}
+
+// NewMigrationExecutor creates a new DynamoDB migrations executor.
+// tables maps the backend collections to their table names.
+func NewMigrationExecutor(client MigrationClient, tables map[migrations.Collection]string) *MigrationExecutor {
+	return &MigrationExecutor{
+		client: client,
+		tables: tables,
+	}
+}
+
+// DryRun returns a copy of the executor that scans tables without updating items.
+func (e *MigrationExecutor) DryRun() migrations.Executor {
+	dryRun := *e
+	dryRun.dryRun = true
+	return &dryRun
+}
+
+// BackfillField sets field to value on every item of the collection's table missing it.
+// Items deleted while the table is scanned are skipped.
+func (e *MigrationExecutor) BackfillField(
+	ctx context.Context,
+	collection migrations.Collection,
+	field, value string,
+) (int, error) {
+	return e.BackfillDerivedField(ctx, collection, field, nil, func(map[string]string) string { return value })
+}
+
+// BackfillDerivedField sets field on every item of the collection's table missing it to the value derived
+// from the string source attributes of the item, which are read along with its key by the scan.
+// Items deleted while the table is scanned are skipped, as are those derived an empty value.
+func (e *MigrationExecutor) BackfillDerivedField(
+	ctx context.Context,
+	collection migrations.Collection,
+	field string,
+	sources []string,
+	derive func(values map[string]string) string,
+) (int, error) {
+	tableName := e.tables[collection]
+	keyAttribute, ok := collectionKeyAttributes[collection]
+	if tableName == "" || !ok {
+		return 0, fmt.Errorf("unknown collection %s", collection)
+	}
+
+	projection := []string{"#key"}
+	exprNames := map[string]string{"#field": field, "#key": keyAttribute}
+	for i, source := range sources {
+		placeholder := "#source" + strconv.Itoa(i)
+		projection = append(projection, placeholder)
+		exprNames[placeholder] = source
+	}
+
+	changed := 0
+	var startKey map[string]types.AttributeValue
+	for {
+		page, err := e.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                aws.String(tableName),
+			FilterExpression:         aws.String("attribute_not_exists(#field)"),
+			ProjectionExpression:     aws.String(strings.Join(projection, ", ")),
+			ExpressionAttributeNames: exprNames,
+			ExclusiveStartKey:        startKey,
+		})
+		if err != nil {
+			return changed, fmt.Errorf("failed to scan table %s: %w", tableName, err)
+		}
+
+		for _, item := range page.Items {
+			key, hasKey := item[keyAttribute]
+			if !hasKey {
+				continue
+			}
+			values := make(map[string]string, len(sources))
+			for _, source := range sources {
+				if s, isString := item[source].(*types.AttributeValueMemberS); isString {
+					values[source] = s.Value
+				}
+			}
+			value := derive(values)
+			if value == "" {
+				continue
+			}
+			updated, updateErr := e.setField(ctx, tableName, keyAttribute, key, field, value)
+			if updateErr != nil {
+				return changed, updateErr
+			}
+			if updated {
+				changed++
+			}
+		}
+
+		if len(page.LastEvaluatedKey) == 0 {
+			return changed, nil
+		}
+		startKey = page.LastEvaluatedKey
+	}
+}
+
+func (e *MigrationExecutor) setField(
+	ctx context.Context,
+	tableName, keyAttribute string,
+	key types.AttributeValue,
+	field, value string,
+) (bool, error) {
+	if e.dryRun {
+		return true, nil
+	}
+
+	_, err := e.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       map[string]types.AttributeValue{keyAttribute: key},
+		UpdateExpression:          aws.String("SET #field = :value"),
+		ConditionExpression:       aws.String("attribute_exists(#key)"),
+		ExpressionAttributeNames:  map[string]string{"#field": field, "#key": keyAttribute},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":value": &types.AttributeValueMemberS{Value: value}},
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update item in table %s: %w", tableName, err)
+	}
+	return true, nil
+}
+
+// MigrationLedger implements the migrations.Ledger interface using DynamoDB.
+type MigrationLedger struct {
+	client    MigrationClient
+	tableName string
+}
+
+// NewMigrationLedger creates a new DynamoDB-backed migrations ledger.
+func NewMigrationLedger(client MigrationClient, tableName string) *MigrationLedger {
+	return &MigrationLedger{
+		client:    client,
+		tableName: tableName,
+	}
+}
+
+// migrationItem represents a ledger entry stored in DynamoDB.
+type migrationItem struct {
+	Version   int       `dynamodbav:"version"` // Partition key
+	Name      string    `dynamodbav:"name"`
+	AppliedAt time.Time `dynamodbav:"applied_at"`
+	Changes   int       `dynamodbav:"changes"`
+}
+
+// Applied returns the recorded migrations.
+func (l *MigrationLedger) Applied(ctx context.Context) ([]migrations.Record, error) {
+	var records []migrations.Record
+	var startKey map[string]types.AttributeValue
+	for {
+		page, err := l.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(l.tableName),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan migrations table: %w", err)
+		}
+
+		var items []migrationItem
+		if err = attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal migrations: %w", err)
+		}
+		for _, item := range items {
+			records = append(records, migrations.Record{
+				Version:   item.Version,
+				Name:      item.Name,
+				AppliedAt: item.AppliedAt,
+				Changes:   item.Changes,
+			})
+		}
+
+		if len(page.LastEvaluatedKey) == 0 {
+			return records, nil
+		}
+		startKey = page.LastEvaluatedKey
+	}
+}
+
+// Record stores an applied migration, failing with migrations.ErrAlreadyApplied if its version is recorded.
+func (l *MigrationLedger) Record(ctx context.Context, record *migrations.Record) error {
+	item, err := attributevalue.MarshalMap(migrationItem{
+		Version:   record.Version,
+		Name:      record.Name,
+		AppliedAt: record.AppliedAt,
+		Changes:   record.Changes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal migration: %w", err)
+	}
+
+	_, err = l.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(l.tableName),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#version)"),
+		ExpressionAttributeNames: map[string]string{"#version": "version"},
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return migrations.ErrAlreadyApplied
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/database/migrations"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMigrationClient struct {
+	pages      []*dynamodb.ScanOutput
+	scans      []*dynamodb.ScanInput
+	updates    []*dynamodb.UpdateItemInput
+	puts       []*dynamodb.PutItemInput
+	updateErrs map[string]error
+	putErr     error
+}
+
+func (m *mockMigrationClient) Scan(
+	_ context.Context, params *dynamodb.ScanInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	m.scans = append(m.scans, params)
+	if len(m.pages) == 0 {
+		return &dynamodb.ScanOutput{}, nil
+	}
+	page := m.pages[0]
+	m.pages = m.pages[1:]
+	return page, nil
+}
+
+func (m *mockMigrationClient) UpdateItem(
+	_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.UpdateItemOutput, error) {
+	m.updates = append(m.updates, params)
+	for _, value := range params.Key {
+		if s, ok := value.(*types.AttributeValueMemberS); ok && m.updateErrs[s.Value] != nil {
+			return nil, m.updateErrs[s.Value]
+		}
+	}
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (m *mockMigrationClient) PutItem(
+	_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.PutItemOutput, error) {
+	m.puts = append(m.puts, params)
+	return &dynamodb.PutItemOutput{}, m.putErr
+}
+
+func keyItem(attribute, value string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{attribute: &types.AttributeValueMemberS{Value: value}}
+}
+
+var testMigrationTables = map[migrations.Collection]string{
+	migrations.CollectionAPIKeys:         "runvoy-api-keys",
+	migrations.CollectionExecutions:      "runvoy-executions",
+	migrations.CollectionSecretsMetadata: "runvoy-secrets-metadata",
+	migrations.CollectionImageTaskDefs:   "runvoy-image-taskdefs",
+}
+
+func TestMigrationExecutor_BackfillField(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("updates items across pages", func(t *testing.T) {
+		client := &mockMigrationClient{
+			pages: []*dynamodb.ScanOutput{
+				{
+					Items:            []map[string]types.AttributeValue{keyItem("execution_id", "exec-1")},
+					LastEvaluatedKey: keyItem("execution_id", "exec-1"),
+				},
+				{Items: []map[string]types.AttributeValue{keyItem("execution_id", "exec-2")}},
+			},
+		}
+		executor := NewMigrationExecutor(client, testMigrationTables)
+
+		changed, err := executor.BackfillField(ctx, migrations.CollectionExecutions, "_all", "ALL")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, changed)
+		require.Len(t, client.scans, 2)
+		assert.Equal(t, "runvoy-executions", aws.ToString(client.scans[0].TableName))
+		assert.Equal(t, "attribute_not_exists(#field)", aws.ToString(client.scans[0].FilterExpression))
+		assert.Nil(t, client.scans[0].ExclusiveStartKey)
+		assert.Equal(t, keyItem("execution_id", "exec-1"), client.scans[1].ExclusiveStartKey)
+		require.Len(t, client.updates, 2)
+		assert.Equal(t, keyItem("execution_id", "exec-2"), client.updates[1].Key)
+		assert.Equal(t, "SET #field = :value", aws.ToString(client.updates[0].UpdateExpression))
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "ALL"}, client.updates[0].ExpressionAttributeValues[":value"])
+	})
+
+	t.Run("skips items deleted during the scan", func(t *testing.T) {
+		client := &mockMigrationClient{
+			pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{
+				keyItem("secret_name", "deleted"),
+				keyItem("secret_name", "kept"),
+			}}},
+			updateErrs: map[string]error{"deleted": &types.ConditionalCheckFailedException{}},
+		}
+		executor := NewMigrationExecutor(client, testMigrationTables)
+
+		changed, err := executor.BackfillField(ctx, migrations.CollectionSecretsMetadata, "_all", "ALL")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, changed)
+	})
+
+	t.Run("dry run counts without updating", func(t *testing.T) {
+		client := &mockMigrationClient{
+			pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{
+				keyItem("image_id", "img-1"),
+				keyItem("image_id", "img-2"),
+			}}},
+		}
+		executor := NewMigrationExecutor(client, testMigrationTables).DryRun()
+
+		changed, err := executor.BackfillField(ctx, migrations.CollectionImageTaskDefs, "_all", "ALL")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, changed)
+		assert.Empty(t, client.updates)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		client := &mockMigrationClient{
+			pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{
+				keyItem("api_key_hash", "hash-1"),
+			}}},
+			updateErrs: map[string]error{"hash-1": errors.New("throttled")},
+		}
+		executor := NewMigrationExecutor(client, testMigrationTables)
+
+		_, err := executor.BackfillField(ctx, migrations.CollectionAPIKeys, "_all", "ALL")
+
+		assert.ErrorContains(t, err, "failed to update item in table runvoy-api-keys: throttled")
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		executor := NewMigrationExecutor(&mockMigrationClient{}, map[migrations.Collection]string{})
+
+		_, err := executor.BackfillField(ctx, migrations.CollectionAPIKeys, "_all", "ALL")
+
+		assert.ErrorContains(t, err, "unknown collection api-keys")
+	})
+}
+
+func TestMigrationExecutor_BackfillDerivedField(t *testing.T) {
+	ctx := context.Background()
+	derive := func(values map[string]string) string { return values["command"] }
+
+	t.Run("derives the field from the scanned sources", func(t *testing.T) {
+		item := keyItem("execution_id", "exec-1")
+		item["command"] = &types.AttributeValueMemberS{Value: "pytest"}
+		client := &mockMigrationClient{
+			pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{
+				item,
+				keyItem("execution_id", "exec-2"),
+			}}},
+		}
+		executor := NewMigrationExecutor(client, testMigrationTables)
+
+		changed, err := executor.BackfillDerivedField(
+			ctx, migrations.CollectionExecutions, "search_text", []string{"command"}, derive)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, changed)
+		require.Len(t, client.scans, 1)
+		assert.Equal(t, "#key, #source0", aws.ToString(client.scans[0].ProjectionExpression))
+		assert.Equal(t, "command", client.scans[0].ExpressionAttributeNames["#source0"])
+		require.Len(t, client.updates, 1)
+		assert.Equal(t, keyItem("execution_id", "exec-1"), client.updates[0].Key)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: "pytest"},
+			client.updates[0].ExpressionAttributeValues[":value"])
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		executor := NewMigrationExecutor(&mockMigrationClient{}, map[migrations.Collection]string{})
+
+		_, err := executor.BackfillDerivedField(ctx, migrations.CollectionExecutions, "search_text", nil, derive)
+
+		assert.ErrorContains(t, err, "unknown collection executions")
+	})
+}
+
+func TestMigrations_BackfillExecutionSearchText(t *testing.T) {
+	migration := Migrations()[1]
+	item := keyItem("execution_id", "exec-1")
+	item["command"] = &types.AttributeValueMemberS{Value: "PYTEST tests/"}
+	item["image_id"] = &types.AttributeValueMemberS{Value: "python-3.12"}
+	client := &mockMigrationClient{
+		pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{item}}},
+	}
+
+	changed, err := migration.Up(context.Background(), NewMigrationExecutor(client, testMigrationTables))
+
+	require.NoError(t, err)
+	assert.Equal(t, "backfill-execution-search-text", migration.Name)
+	assert.Equal(t, 1, changed)
+	require.Len(t, client.updates, 1)
+	assert.Equal(t, searchTextAttrName, client.updates[0].ExpressionAttributeNames["#field"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "pytest tests/\npython-3.12"},
+		client.updates[0].ExpressionAttributeValues[":value"])
+}
+
+func TestMigrations_BackfillAllField(t *testing.T) {
+	registry, err := migrations.NewRegistry(Migrations()...)
+	require.NoError(t, err)
+	client := &mockMigrationClient{}
+	runner := migrations.NewRunner(
+		registry,
+		NewMigrationExecutor(client, testMigrationTables),
+		NewMigrationLedger(client, "runvoy-migrations"),
+	)
+
+	results, err := runner.Up(context.Background(), migrations.UpOptions{})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "backfill-all-field", results[0].Name)
+	scannedTables := make([]string, 0, len(client.scans))
+	for _, scan := range client.scans {
+		scannedTables = append(scannedTables, aws.ToString(scan.TableName))
+	}
+	assert.ElementsMatch(t, []string{
+		"runvoy-migrations",
+		"runvoy-api-keys",
+		"runvoy-executions",
+		"runvoy-secrets-metadata",
+		"runvoy-image-taskdefs",
+		"runvoy-executions",
+	}, scannedTables)
+	require.Len(t, client.puts, 2)
+	assert.Equal(t, "runvoy-migrations", aws.ToString(client.puts[0].TableName))
+}
+
+func TestMigrationLedger(t *testing.T) {
+	ctx := context.Background()
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("applied", func(t *testing.T) {
+		item, err := attributevalue.MarshalMap(migrationItem{
+			Version: 1, Name: "backfill-all-field", AppliedAt: appliedAt, Changes: 3,
+		})
+		require.NoError(t, err)
+		client := &mockMigrationClient{pages: []*dynamodb.ScanOutput{{Items: []map[string]types.AttributeValue{item}}}}
+
+		records, err := NewMigrationLedger(client, "runvoy-migrations").Applied(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []migrations.Record{
+			{Version: 1, Name: "backfill-all-field", AppliedAt: appliedAt, Changes: 3},
+		}, records)
+	})
+
+	t.Run("record", func(t *testing.T) {
+		client := &mockMigrationClient{}
+
+		err := NewMigrationLedger(client, "runvoy-migrations").Record(ctx, &migrations.Record{
+			Version: 2, Name: "second", AppliedAt: appliedAt,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, client.puts, 1)
+		assert.Equal(t, "attribute_not_exists(#version)", aws.ToString(client.puts[0].ConditionExpression))
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "2"}, client.puts[0].Item["version"])
+	})
+
+	t.Run("record already applied", func(t *testing.T) {
+		client := &mockMigrationClient{putErr: &types.ConditionalCheckFailedException{}}
+
+		err := NewMigrationLedger(client, "runvoy-migrations").Record(ctx, &migrations.Record{Version: 1})
+
+		assert.ErrorIs(t, err, migrations.ErrAlreadyApplied)
+	})
+}
@@ -17,7 +17,7 @@ import (
 const executionIDIndexName = "execution_id-index"
 
 // MockDynamoDBClient is a simple in-memory mock implementation of Client for testing.
-// It provides basic support for Put, Get, Query, Update, Delete, and BatchWrite operations.
+// It provides basic support for Put, Get, Query, Scan, Update, Delete, BatchWrite and TransactWrite operations.
 type MockDynamoDBClient struct {
 	mu sync.RWMutex
 
@@ -36,17 +36,21 @@ type MockDynamoDBClient struct {
 	PutItemError        error
 	GetItemError        error
 	QueryError          error
+	ScanError           error
 	UpdateItemError     error
 	DeleteItemError     error
 	BatchWriteItemError error
+	TransactWriteError  error
 
 	// Call tracking for test assertions
 	PutItemCalls        int
 	GetItemCalls        int
 	QueryCalls          int
+	ScanCalls           int
 	UpdateItemCalls     int
 	DeleteItemCalls     int
 	BatchWriteItemCalls int
+	TransactWriteCalls  int
 }
 
 // NewMockDynamoDBClient creates a new mock DynamoDB client for testing.
@@ -82,7 +86,14 @@ func (m *MockDynamoDBClient) PutItem(
 		return nil, m.PutItemError
 	}
 
-	tableName := *params.TableName
+	if err := m.putItem(*params.TableName, params.Item); err != nil {
+		return nil, err
+	}
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+// putItem stores an item in the mock table, the caller holding the lock.
+func (m *MockDynamoDBClient) putItem(tableName string, item map[string]types.AttributeValue) error {
 	if m.Tables[tableName] == nil {
 		m.Tables[tableName] = make(map[string]map[string]map[string]types.AttributeValue)
 	}
@@ -90,12 +101,12 @@ func (m *MockDynamoDBClient) PutItem(
 		m.Indexes[tableName] = make(map[string]map[string][]map[string]types.AttributeValue)
 	}
 
-	partitionKey := m.getPartitionKeyFromAttributes(params.Item)
+	partitionKey := m.getPartitionKeyFromAttributes(item)
 	if partitionKey == "" {
-		return nil, errors.New("failed to extract partition key from item")
+		return errors.New("failed to extract partition key from item")
 	}
 
-	sortKey := getSortKeyFromAttributes(params.Item)
+	sortKey := getSortKeyFromAttributes(item)
 
 	if m.Tables[tableName][partitionKey] == nil {
 		m.Tables[tableName][partitionKey] = make(map[string]map[string]types.AttributeValue)
@@ -107,15 +118,15 @@ func (m *MockDynamoDBClient) PutItem(
 		oldItem = m.Tables[tableName][partitionKey][sortKey]
 	}
 
-	m.Tables[tableName][partitionKey][sortKey] = params.Item
+	m.Tables[tableName][partitionKey][sortKey] = item
 
 	if oldItem != nil {
 		m.removeItemFromIndexes(tableName, oldItem)
 	}
 
-	m.addItemToIndexes(tableName, params.Item)
+	m.addItemToIndexes(tableName, item)
 
-	return &dynamodb.PutItemOutput{}, nil
+	return nil
 }
 
 // GetItem retrieves an item from the mock table.
@@ -207,6 +218,29 @@ func (m *MockDynamoDBClient) Query(
 	}, nil
 }
 
+// Scan returns all the items of the mock table, in a single page.
+func (m *MockDynamoDBClient) Scan(
+	_ context.Context,
+	params *dynamodb.ScanInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.ScanCalls++
+
+	if m.ScanError != nil {
+		return nil, m.ScanError
+	}
+
+	items := m.collectTableItems(aws.ToString(params.TableName))
+	return &dynamodb.ScanOutput{
+		Items:        items,
+		Count:        safeInt32Count(len(items)),
+		ScannedCount: safeInt32Count(len(items)),
+	}, nil
+}
+
 // queryIndex queries items from an index.
 func (m *MockDynamoDBClient) queryIndex(
 	tableName, indexName string,
@@ -367,6 +401,7 @@ func (m *MockDynamoDBClient) extractKeyValue(
 		":execution_id",
 		":all",
 		":user",
+		":key",
 	}
 
 	for _, candidate := range keyCandidates {
@@ -535,6 +570,54 @@ func (m *MockDynamoDBClient) BatchWriteItem(
 	return &dynamodb.BatchWriteItemOutput{}, nil
 }
 
+// TransactWriteItems stores the Put items of the transaction, none of them when an attribute_not_exists
+// condition fails. The other transaction items are not supported.
+func (m *MockDynamoDBClient) TransactWriteItems(
+	_ context.Context,
+	params *dynamodb.TransactWriteItemsInput,
+	_ ...func(*dynamodb.Options),
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.TransactWriteCalls++
+
+	if m.TransactWriteError != nil {
+		return nil, m.TransactWriteError
+	}
+
+	reasons := make([]types.CancellationReason, len(params.TransactItems))
+	canceled := false
+	for i, transactItem := range params.TransactItems {
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+		put := transactItem.Put
+		if put == nil {
+			return nil, errors.New("mock TransactWriteItems only supports Put items")
+		}
+		if !strings.HasPrefix(aws.ToString(put.ConditionExpression), "attribute_not_exists(") {
+			continue
+		}
+		partitionKey := m.getPartitionKeyFromAttributes(put.Item)
+		if _, exists := m.Tables[aws.ToString(put.TableName)][partitionKey][getSortKeyFromAttributes(put.Item)]; exists {
+			reasons[i] = types.CancellationReason{Code: aws.String("ConditionalCheckFailed")}
+			canceled = true
+		}
+	}
+	if canceled {
+		return nil, &types.TransactionCanceledException{
+			Message:             aws.String("Transaction cancelled"),
+			CancellationReasons: reasons,
+		}
+	}
+
+	for _, transactItem := range params.TransactItems {
+		if err := m.putItem(aws.ToString(transactItem.Put.TableName), transactItem.Put.Item); err != nil {
+			return nil, err
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
 // ResetCallCounts resets all call counters to zero.
 func (m *MockDynamoDBClient) ResetCallCounts() {
 	m.mu.Lock()
@@ -546,6 +629,7 @@ func (m *MockDynamoDBClient) ResetCallCounts() {
 	m.UpdateItemCalls = 0
 	m.DeleteItemCalls = 0
 	m.BatchWriteItemCalls = 0
+	m.TransactWriteCalls = 0
 }
 
 // ClearTables removes all data from the mock tables.
@@ -581,6 +665,9 @@ func getSortKeyFromAttributes(attrs map[string]types.AttributeValue) string {
 	if sortVal, ok := attrs["event_key"]; ok {
 		return getStringValue(sortVal)
 	}
+	if sortVal, ok := attrs["started_at"]; ok {
+		return getStringValue(sortVal)
+	}
 
 	return ""
 }
@@ -709,6 +796,33 @@ func (m *MockDynamoDBClient) addItemToIndexes(tableName string, item map[string]
 			modifiedIndex[modifiedByRequestID] = append(modifiedIndex[modifiedByRequestID], item)
 		}
 	}
+
+	// For status-started_at and created_by-started_at: index executions by status and by creator
+	for indexName, attrName := range map[string]string{
+		statusStartedAtIndexName:    statusAttrName,
+		createdByStartedAtIndexName: createdByAttrName,
+	} {
+		if keyVal, hasKey := item[attrName]; hasKey {
+			if key := getStringValue(keyVal); key != "" {
+				if m.Indexes[tableName][indexName] == nil {
+					m.Indexes[tableName][indexName] = make(map[string][]map[string]types.AttributeValue)
+				}
+				m.Indexes[tableName][indexName][key] = append(m.Indexes[tableName][indexName][key], item)
+			}
+		}
+	}
+
+	// For group_id-index: index by group_id (sparse index)
+	if groupIDVal, hasGroupID := item["group_id"]; hasGroupID {
+		groupID := getStringValue(groupIDVal)
+		if groupID != "" {
+			if m.Indexes[tableName][groupIDIndexName] == nil {
+				m.Indexes[tableName][groupIDIndexName] = make(map[string][]map[string]types.AttributeValue)
+			}
+			groupIndex := m.Indexes[tableName][groupIDIndexName]
+			groupIndex[groupID] = append(groupIndex[groupID], item)
+		}
+	}
 }
 
 // removeItemFromIndexes removes an item from all indexes for a table.
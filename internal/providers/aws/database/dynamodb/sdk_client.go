@@ -0,0 +1,22 @@
+package dynamodb
+
+import (
+	"os"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// NewSDKClient creates the AWS SDK DynamoDB client of the configuration. When the
+// DYNAMODB_ENDPOINT environment variable is set, the requests are sent to that endpoint
+// instead, e.g. http://localhost:8000 for DynamoDB Local.
+func NewSDKClient(cfg aws.Config, optFns ...func(*dynamodb.Options)) *dynamodb.Client {
+	if endpoint := os.Getenv(awsConstants.DynamoDBEndpointEnvVar); endpoint != "" {
+		optFns = append([]func(*dynamodb.Options){func(o *dynamodb.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		}}, optFns...)
+	}
+	return dynamodb.NewFromConfig(cfg, optFns...)
+}
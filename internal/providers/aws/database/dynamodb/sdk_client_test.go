@@ -0,0 +1,41 @@
+package dynamodb
+
+import (
+	"testing"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSDKClient(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+
+	t.Run("uses the default endpoint", func(t *testing.T) {
+		t.Setenv(awsConstants.DynamoDBEndpointEnvVar, "")
+
+		client := NewSDKClient(cfg)
+
+		assert.Nil(t, client.Options().BaseEndpoint)
+	})
+
+	t.Run("overrides the endpoint from the environment", func(t *testing.T) {
+		t.Setenv(awsConstants.DynamoDBEndpointEnvVar, "http://localhost:8000")
+
+		client := NewSDKClient(cfg)
+
+		assert.Equal(t, "http://localhost:8000", aws.ToString(client.Options().BaseEndpoint))
+	})
+
+	t.Run("applies the options after the override", func(t *testing.T) {
+		t.Setenv(awsConstants.DynamoDBEndpointEnvVar, "http://localhost:8000")
+
+		client := NewSDKClient(cfg, func(o *dynamodb.Options) {
+			o.BaseEndpoint = aws.String("http://localhost:9000")
+		})
+
+		assert.Equal(t, "http://localhost:9000", aws.ToString(client.Options().BaseEndpoint))
+	})
+}
@@ -50,6 +50,10 @@ type secretItem struct {
 	CreatedByRequestID  string    `dynamodbav:"created_by_request_id,omitempty"`
 	ModifiedByRequestID string    `dynamodbav:"modified_by_request_id,omitempty"`
 	All                 string    `dynamodbav:"_all"`
+
+	LastUsedAt            *time.Time `dynamodbav:"last_used_at,omitempty"`
+	LastUsedByExecutionID string     `dynamodbav:"last_used_by_execution_id,omitempty"`
+	Version               int64      `dynamodbav:"version,omitempty"`
 }
 
 // toAPISecret converts a secretItem to an API Secret.
@@ -65,6 +69,10 @@ func (si *secretItem) toAPISecret() *api.Secret {
 		UpdatedBy:           si.UpdatedBy,
 		CreatedByRequestID:  si.CreatedByRequestID,
 		ModifiedByRequestID: si.ModifiedByRequestID,
+
+		LastUsedAt:            si.LastUsedAt,
+		LastUsedByExecutionID: si.LastUsedByExecutionID,
+		Version:               si.Version,
 	}
 }
 
@@ -271,10 +279,12 @@ func (r *SecretsRepository) buildUpdateExpression(
 	return expr, nil
 }
 
-// UpdateSecretMetadata updates a secret's metadata (description and keyName) in DynamoDB.
+// UpdateSecretMetadata updates a secret's metadata (description and keyName) in DynamoDB, read at version.
+// It fails with a concurrent modification error if the metadata was updated since.
 func (r *SecretsRepository) UpdateSecretMetadata(
 	ctx context.Context,
 	name, keyName, description, updatedBy string,
+	version int64,
 ) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
@@ -287,7 +297,7 @@ func (r *SecretsRepository) UpdateSecretMetadata(
 		return appErrors.ErrInternalError("failed to build update", err)
 	}
 
-	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(r.tableName),
 		Key: map[string]types.AttributeValue{
 			"secret_name": &types.AttributeValueMemberS{Value: name},
@@ -297,9 +307,14 @@ func (r *SecretsRepository) UpdateSecretMetadata(
 		ExpressionAttributeValues: expr.Values(),
 		// Ensure the secret exists before updating
 		ConditionExpression: aws.String("attribute_exists(secret_name)"),
-	})
+	}
+	withVersionCondition(input, version)
+	_, err = r.client.UpdateItem(ctx, input)
 
 	if err != nil {
+		if isVersionConflict(err) {
+			return appErrors.ErrConcurrentModification("secret was modified concurrently", nil)
+		}
 		var ccf *types.ConditionalCheckFailedException
 		if errors.As(err, &ccf) {
 			return database.ErrSecretNotFound
@@ -338,6 +353,55 @@ func (r *SecretsRepository) DeleteSecret(ctx context.Context, name string) error
 	return nil
 }
 
+// RecordSecretUsage records the last execution started with the secret in DynamoDB.
+// Usage is recorded without touching updated_at, which tracks changes to the secret itself.
+func (r *SecretsRepository) RecordSecretUsage(
+	ctx context.Context,
+	name, executionID string,
+	usedAt time.Time,
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	usedAtValue, err := attributevalue.Marshal(usedAt.UTC())
+	if err != nil {
+		return appErrors.ErrInternalError("failed to marshal secret usage", err)
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"name", name,
+		"execution_id", executionID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"secret_name": &types.AttributeValueMemberS{Value: name},
+		},
+		UpdateExpression: aws.String("SET last_used_at = :used_at, last_used_by_execution_id = :execution_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":used_at":      usedAtValue,
+			":execution_id": &types.AttributeValueMemberS{Value: executionID},
+		},
+		// Ensure the secret exists before recording its usage
+		ConditionExpression: aws.String("attribute_exists(secret_name)"),
+	})
+
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return database.ErrSecretNotFound
+		}
+		reqLogger.Error("failed to record secret usage", "error", err, "name", name)
+		return appErrors.ErrInternalError("failed to record secret usage", err)
+	}
+
+	return nil
+}
+
 // SecretExists checks if a secret with the given name exists in DynamoDB.
 func (r *SecretsRepository) SecretExists(ctx context.Context, name string) (bool, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
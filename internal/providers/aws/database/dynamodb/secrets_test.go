@@ -3,13 +3,16 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/database"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/testutil"
 
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -229,12 +232,45 @@ func TestUpdateSecretMetadata_NotFound(t *testing.T) {
 		"KEY",
 		"description",
 		"user@example.com",
+		0,
 	)
 
 	assert.Equal(t, database.ErrSecretNotFound, err)
 	client.UpdateItemError = nil
 }
 
+func TestUpdateSecretMetadata_Version(t *testing.T) {
+	var input *dynamodb.UpdateItemInput
+	client := &mockImageClient{
+		updateItemFunc: func(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.UpdateItemOutput, error) {
+			input = params
+			return &dynamodb.UpdateItemOutput{}, nil
+		},
+	}
+	repo := NewSecretsRepository(client, "secrets-table", testutil.SilentLogger())
+
+	err := repo.UpdateSecretMetadata(context.Background(), "db-password", "KEY", "description", "user@example.com", 4)
+
+	require.NoError(t, err)
+	require.NotNil(t, input)
+	assert.True(t, strings.HasPrefix(*input.UpdateExpression, "SET #version = :next_version, "))
+	assert.Equal(t, "attribute_exists(secret_name) AND #version = :version", *input.ConditionExpression)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "5"}, input.ExpressionAttributeValues[":next_version"])
+}
+
+func TestUpdateSecretMetadata_ConcurrentModification(t *testing.T) {
+	client := NewMockDynamoDBClient()
+	client.UpdateItemError = &types.ConditionalCheckFailedException{
+		Item: map[string]types.AttributeValue{"secret_name": &types.AttributeValueMemberS{Value: "db-password"}},
+	}
+	repo := NewSecretsRepository(client, "secrets-table", testutil.SilentLogger())
+
+	err := repo.UpdateSecretMetadata(context.Background(), "db-password", "KEY", "description", "user@example.com", 1)
+
+	assert.Equal(t, appErrors.ErrCodeConcurrentModification, appErrors.GetErrorCode(err))
+}
+
 func TestUpdateSecretMetadata_ClientError(t *testing.T) {
 	client := NewMockDynamoDBClient()
 	logger := testutil.SilentLogger()
@@ -250,12 +286,57 @@ func TestUpdateSecretMetadata_ClientError(t *testing.T) {
 		"KEY",
 		"description",
 		"user@example.com",
+		0,
 	)
 
 	assert.Error(t, err)
 	assert.NotEqual(t, database.ErrSecretNotFound, err)
 }
 
+func TestRecordSecretUsage(t *testing.T) {
+	usedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("updates the last usage", func(t *testing.T) {
+		client := NewMockDynamoDBClient()
+		repo := NewSecretsRepository(client, "secrets-table", testutil.SilentLogger())
+		require.NoError(t, repo.CreateSecret(context.Background(), &api.Secret{
+			Name:      "github-token",
+			KeyName:   "GITHUB_TOKEN",
+			CreatedBy: "admin@example.com",
+		}))
+
+		err := repo.RecordSecretUsage(context.Background(), "github-token", "exec-123", usedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, client.UpdateItemCalls)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := NewMockDynamoDBClient()
+		client.UpdateItemError = &types.ConditionalCheckFailedException{}
+		repo := NewSecretsRepository(client, "secrets-table", testutil.SilentLogger())
+
+		err := repo.RecordSecretUsage(context.Background(), "nonexistent", "exec-123", usedAt)
+
+		assert.Equal(t, database.ErrSecretNotFound, err)
+	})
+
+	t.Run("reads back the last usage", func(t *testing.T) {
+		item := secretItem{
+			SecretName:            "github-token",
+			KeyName:               "GITHUB_TOKEN",
+			LastUsedAt:            &usedAt,
+			LastUsedByExecutionID: "exec-123",
+		}
+
+		secret := item.toAPISecret()
+
+		require.NotNil(t, secret.LastUsedAt)
+		assert.True(t, usedAt.Equal(*secret.LastUsedAt))
+		assert.Equal(t, "exec-123", secret.LastUsedByExecutionID)
+	})
+}
+
 func TestDeleteSecret_Success(t *testing.T) {
 	client := NewMockDynamoDBClient()
 	logger := testutil.SilentLogger()
@@ -2,13 +2,18 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/database"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
+	awsconstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -46,6 +51,19 @@ type tokenItem struct {
 	CreatedAt   int64  `dynamodbav:"created_at"`
 }
 
+// runTokenKeyPrefix prefixes the keys of the run tokens, keeping them apart from the WebSocket tokens.
+const runTokenKeyPrefix = "run#"
+
+// runTokenItem represents a run token stored in DynamoDB. It has no execution_id attribute, as the
+// execution_id-index GSI rejects empty key values.
+type runTokenItem struct {
+	Token     string `dynamodbav:"token"`
+	UserEmail string `dynamodbav:"user_email"`
+	Subject   string `dynamodbav:"subject,omitempty"`
+	ExpiresAt int64  `dynamodbav:"expires_at"`
+	CreatedAt int64  `dynamodbav:"created_at"`
+}
+
 // toTokenItem converts an api.WebSocketToken to a tokenItem.
 func toTokenItem(token *api.WebSocketToken) *tokenItem {
 	return &tokenItem{
@@ -69,7 +87,7 @@ func (r *TokenRepository) CreateToken(
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to marshal token item", err)
+		return sdkerrors.Map(err, "failed to marshal token item", appErrors.ErrDatabaseError)
 	}
 
 	logArgs := []any{
@@ -86,7 +104,7 @@ func (r *TokenRepository) CreateToken(
 		Item:      av,
 	})
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to store token", err)
+		return sdkerrors.Map(err, "failed to store token", appErrors.ErrDatabaseError)
 	}
 
 	reqLogger.Debug("token stored successfully", "context", map[string]string{
@@ -96,38 +114,45 @@ func (r *TokenRepository) CreateToken(
 	return nil
 }
 
-// GetToken retrieves a token by its value.
-// Returns nil if the token doesn't exist (DynamoDB TTL automatically removes expired tokens).
-func (r *TokenRepository) GetToken(
+// ClaimToken atomically deletes a token and returns it, so that it authenticates a single connection.
+// The deletion is conditioned on the token not having expired, since DynamoDB TTL removes expired items
+// lazily. Returns nil if the token doesn't exist, has expired or was already claimed.
+func (r *TokenRepository) ClaimToken(
 	ctx context.Context,
 	tokenValue string,
 ) (*api.WebSocketToken, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
 	logArgs := []any{
-		"operation", "DynamoDB.GetItem",
+		"operation", "DynamoDB.DeleteItem",
 		"table", r.tableName,
 		"token", tokenValue,
 	}
 	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
 	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
 
-	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+	result, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(r.tableName),
 		Key: map[string]types.AttributeValue{
 			"token": &types.AttributeValueMemberS{Value: tokenValue},
 		},
+		ConditionExpression:      aws.String("attribute_exists(#token) AND expires_at > :now"),
+		ExpressionAttributeNames: map[string]string{"#token": "token"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		ReturnValues: types.ReturnValueAllOld,
 	})
 	if err != nil {
-		return nil, appErrors.ErrDatabaseError("failed to retrieve token", err)
-	}
-
-	if result.Item == nil {
-		return nil, nil // Token doesn't exist (either never existed or expired)
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			return nil, nil // Token doesn't exist, has expired or was claimed by another connection
+		}
+		return nil, sdkerrors.Map(err, "failed to claim token", appErrors.ErrDatabaseError)
 	}
 
 	var item tokenItem
-	if unmarshalErr := attributevalue.UnmarshalMap(result.Item, &item); unmarshalErr != nil {
+	if unmarshalErr := attributevalue.UnmarshalMap(result.Attributes, &item); unmarshalErr != nil {
 		return nil, fmt.Errorf("failed to unmarshal token item: %w", unmarshalErr)
 	}
 
@@ -140,7 +165,7 @@ func (r *TokenRepository) GetToken(
 		CreatedAt:   item.CreatedAt,
 	}
 
-	reqLogger.Debug("token retrieved successfully", "context", map[string]string{
+	reqLogger.Debug("token claimed successfully", "context", map[string]string{
 		"token":        token.Token,
 		"execution_id": token.ExecutionID,
 	})
@@ -170,7 +195,7 @@ func (r *TokenRepository) DeleteToken(
 		},
 	})
 	if err != nil {
-		return appErrors.ErrDatabaseError("failed to delete token", err)
+		return sdkerrors.Map(err, "failed to delete token", appErrors.ErrDatabaseError)
 	}
 
 	reqLogger.Debug("token deleted successfully", "context", map[string]string{
@@ -179,3 +204,141 @@ func (r *TokenRepository) DeleteToken(
 
 	return nil
 }
+
+// DeleteTokensByExecutionID deletes the unclaimed tokens of an execution using the execution_id-index GSI.
+func (r *TokenRepository) DeleteTokensByExecutionID(ctx context.Context, executionID string) (int, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Query",
+		"table", r.tableName,
+		"index", "execution_id-index",
+		"execution_id", executionID,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	var deleteRequests []types.WriteRequest
+	var startKey map[string]types.AttributeValue
+	for {
+		result, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.tableName),
+			IndexName:              aws.String("execution_id-index"),
+			KeyConditionExpression: aws.String("execution_id = :execution_id"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":execution_id": &types.AttributeValueMemberS{Value: executionID},
+			},
+			ProjectionExpression:     aws.String("#token"),
+			ExpressionAttributeNames: map[string]string{"#token": "token"},
+			ExclusiveStartKey:        startKey,
+		})
+		if err != nil {
+			return 0, sdkerrors.Map(err, "failed to query tokens by execution ID", appErrors.ErrDatabaseError)
+		}
+		for _, item := range result.Items {
+			deleteRequests = append(deleteRequests, types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{"token": item["token"]}},
+			})
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = result.LastEvaluatedKey
+	}
+
+	deletedCount := 0
+	for i := 0; i < len(deleteRequests); i += awsconstants.DynamoDBBatchWriteLimit {
+		batchRequests := deleteRequests[i:min(i+awsconstants.DynamoDBBatchWriteLimit, len(deleteRequests))]
+		_, err := r.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				r.tableName: batchRequests,
+			},
+		})
+		if err != nil {
+			return deletedCount, sdkerrors.Map(err, "failed to delete tokens batch", appErrors.ErrDatabaseError)
+		}
+		deletedCount += len(batchRequests)
+	}
+
+	reqLogger.Debug("execution tokens deleted", "context", map[string]any{
+		"execution_id":  executionID,
+		"deleted_count": deletedCount,
+	})
+
+	return deletedCount, nil
+}
+
+// CreateRunToken stores a run token, keyed by its hash. DynamoDB TTL deletes it once expired.
+func (r *TokenRepository) CreateRunToken(ctx context.Context, token *api.RunToken) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	av, err := attributevalue.MarshalMap(&runTokenItem{
+		Token:     runTokenKeyPrefix + token.TokenHash,
+		UserEmail: token.UserEmail,
+		Subject:   token.Subject,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return sdkerrors.Map(err, "failed to marshal run token item", appErrors.ErrDatabaseError)
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"user_email", token.UserEmail,
+		"subject", token.Subject,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	if _, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	}); err != nil {
+		return sdkerrors.Map(err, "failed to store run token", appErrors.ErrDatabaseError)
+	}
+	return nil
+}
+
+// GetRunToken returns the run token with the hash. Returns nil if it doesn't exist or has expired, since
+// DynamoDB TTL removes expired items lazily.
+func (r *TokenRepository) GetRunToken(ctx context.Context, tokenHash string) (*api.RunToken, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.GetItem",
+		"table", r.tableName,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"token": &types.AttributeValueMemberS{Value: runTokenKeyPrefix + tokenHash},
+		},
+	})
+	if err != nil {
+		return nil, sdkerrors.Map(err, "failed to get run token", appErrors.ErrDatabaseError)
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var item runTokenItem
+	if err = attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, sdkerrors.Map(err, "failed to unmarshal run token item", appErrors.ErrDatabaseError)
+	}
+	if item.ExpiresAt <= time.Now().Unix() {
+		return nil, nil
+	}
+
+	return &api.RunToken{
+		TokenHash: tokenHash,
+		UserEmail: item.UserEmail,
+		Subject:   item.Subject,
+		ExpiresAt: item.ExpiresAt,
+		CreatedAt: item.CreatedAt,
+	}, nil
+}
@@ -3,6 +3,7 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/testutil"
 
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -67,30 +70,61 @@ func TestCreateToken_ClientError(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestGetToken_NotFound(t *testing.T) {
-	client := NewMockDynamoDBClient()
-	logger := testutil.SilentLogger()
-	repo := NewTokenRepository(client, "tokens-table", logger)
+func TestClaimToken_Success(t *testing.T) {
+	expiresAt := time.Now().Add(5 * time.Minute).Unix()
+	var input *dynamodb.DeleteItemInput
+	client := &mockImageClient{
+		deleteItemFunc: func(_ context.Context, params *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.DeleteItemOutput, error) {
+			input = params
+			return &dynamodb.DeleteItemOutput{Attributes: map[string]types.AttributeValue{
+				"token":        &types.AttributeValueMemberS{Value: "ws_token_123"},
+				"execution_id": &types.AttributeValueMemberS{Value: "exec-456"},
+				"user_email":   &types.AttributeValueMemberS{Value: "user@example.com"},
+				"expires_at":   &types.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt, 10)},
+			}}, nil
+		},
+	}
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
 
-	// Token doesn't exist, should return nil without error
-	retrieved, err := repo.GetToken(context.Background(), "nonexistent_token")
+	token, err := repo.ClaimToken(context.Background(), "ws_token_123")
 
-	assert.NoError(t, err)
-	assert.Nil(t, retrieved)
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, "exec-456", token.ExecutionID)
+	assert.Equal(t, "user@example.com", token.UserEmail)
+	assert.Equal(t, expiresAt, token.ExpiresAt)
+	require.NotNil(t, input)
+	assert.Equal(t, types.ReturnValueAllOld, input.ReturnValues)
+	assert.Contains(t, *input.ConditionExpression, "expires_at > :now")
 }
 
-func TestGetToken_ClientError(t *testing.T) {
-	client := NewMockDynamoDBClient()
-	logger := testutil.SilentLogger()
+func TestClaimToken_NotFoundExpiredOrClaimed(t *testing.T) {
+	client := &mockImageClient{
+		deleteItemFunc: func(_ context.Context, _ *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.DeleteItemOutput, error) {
+			return nil, &types.ConditionalCheckFailedException{}
+		},
+	}
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
 
-	// Inject a get item error
-	client.GetItemError = appErrors.ErrDatabaseError("test error", errors.New("database error"))
+	// A failed condition means the token can't be claimed, which is not an error
+	token, err := repo.ClaimToken(context.Background(), "ws_token_123")
 
-	repo := NewTokenRepository(client, "tokens-table", logger)
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+func TestClaimToken_ClientError(t *testing.T) {
+	client := NewMockDynamoDBClient()
+	client.DeleteItemError = errors.New("delete item failed")
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
 
-	_, err := repo.GetToken(context.Background(), "some_token")
+	token, err := repo.ClaimToken(context.Background(), "ws_token_123")
 
-	assert.Error(t, err)
+	require.Error(t, err)
+	assert.Nil(t, token)
+	assert.Contains(t, err.Error(), "failed to claim token")
 }
 
 func TestDeleteToken_Success(t *testing.T) {
@@ -172,53 +206,124 @@ func TestTokenRepository_CreateToken_ErrorHandling(t *testing.T) {
 	})
 }
 
-func TestTokenRepository_GetToken_ErrorHandling(t *testing.T) {
+func TestTokenRepository_DeleteToken_ErrorHandling(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
 	tableName := "tokens-table"
 
-	t.Run("handles get item error", func(t *testing.T) {
+	t.Run("handles delete item error", func(t *testing.T) {
 		client := NewMockDynamoDBClient()
-		client.GetItemError = errors.New("get item failed")
+		client.DeleteItemError = errors.New("delete item failed")
 		repo := NewTokenRepository(client, tableName, logger)
 
-		token, err := repo.GetToken(ctx, "token-123")
+		err := repo.DeleteToken(ctx, "token-123")
 
 		require.Error(t, err)
-		assert.Nil(t, token)
-		assert.Contains(t, err.Error(), "failed to retrieve token")
+		assert.Contains(t, err.Error(), "failed to delete token")
 	})
+}
 
-	t.Run("handles unmarshal error", func(t *testing.T) {
-		client := NewMockDynamoDBClient()
-		repo := NewTokenRepository(client, tableName, logger)
+func TestDeleteTokensByExecutionID(t *testing.T) {
+	ctx := context.Background()
+	client := NewMockDynamoDBClient()
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
+
+	for _, token := range []*api.WebSocketToken{
+		{Token: "token-1", ExecutionID: "exec-456"},
+		{Token: "token-2", ExecutionID: "exec-456"},
+		{Token: "token-3", ExecutionID: "exec-789"},
+	} {
+		require.NoError(t, repo.CreateToken(ctx, token))
+	}
 
-		// Note: attributevalue.UnmarshalMap is quite permissive and doesn't easily fail
-		// on type mismatches. To properly test unmarshal errors, we'd need to create
-		// truly malformed data that the unmarshaler can't handle, which is difficult
-		// with the current mock setup. This test verifies the function handles empty results.
-		// For actual unmarshal error testing, integration tests would be more appropriate.
-		token, err := repo.GetToken(ctx, "token-123")
+	deleted, err := repo.DeleteTokensByExecutionID(ctx, "exec-456")
 
-		// Should succeed with nil token (no error for missing items)
-		require.NoError(t, err)
-		assert.Nil(t, token)
-	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, 1, client.BatchWriteItemCalls)
 }
 
-func TestTokenRepository_DeleteToken_ErrorHandling(t *testing.T) {
+func TestDeleteTokensByExecutionID_NoTokens(t *testing.T) {
+	client := NewMockDynamoDBClient()
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
+
+	deleted, err := repo.DeleteTokensByExecutionID(context.Background(), "exec-456")
+
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Zero(t, client.BatchWriteItemCalls)
+}
+
+func TestDeleteTokensByExecutionID_Errors(t *testing.T) {
 	ctx := context.Background()
-	logger := testutil.SilentLogger()
-	tableName := "tokens-table"
 
-	t.Run("handles delete item error", func(t *testing.T) {
+	t.Run("handles query error", func(t *testing.T) {
 		client := NewMockDynamoDBClient()
-		client.DeleteItemError = errors.New("delete item failed")
-		repo := NewTokenRepository(client, tableName, logger)
+		client.QueryError = errors.New("query failed")
+		repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
 
-		err := repo.DeleteToken(ctx, "token-123")
+		_, err := repo.DeleteTokensByExecutionID(ctx, "exec-456")
 
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to delete token")
+		assert.Contains(t, err.Error(), "failed to query tokens by execution ID")
+	})
+
+	t.Run("handles batch write error", func(t *testing.T) {
+		client := NewMockDynamoDBClient()
+		repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
+		require.NoError(t, repo.CreateToken(ctx, &api.WebSocketToken{Token: "token-1", ExecutionID: "exec-456"}))
+		client.BatchWriteItemError = errors.New("batch write failed")
+
+		deleted, err := repo.DeleteTokensByExecutionID(ctx, "exec-456")
+
+		require.Error(t, err)
+		assert.Zero(t, deleted)
+		assert.Contains(t, err.Error(), "failed to delete tokens batch")
 	})
 }
+
+func TestRunToken_CreateAndGet(t *testing.T) {
+	client := NewMockDynamoDBClient()
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateRunToken(ctx, &api.RunToken{
+		TokenHash: "hash-1",
+		UserEmail: "ci@example.com",
+		Subject:   "repo:acme/api:ref:refs/heads/main",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		CreatedAt: time.Now().Unix(),
+	}))
+	require.NoError(t, repo.CreateRunToken(ctx, &api.RunToken{
+		TokenHash: "hash-expired",
+		UserEmail: "ci@example.com",
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	}))
+
+	token, err := repo.GetRunToken(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, "ci@example.com", token.UserEmail)
+	assert.Equal(t, "repo:acme/api:ref:refs/heads/main", token.Subject)
+
+	stored := client.Tables["tokens-table"][runTokenKeyPrefix+"hash-1"][""]
+	assert.NotContains(t, stored, "execution_id")
+
+	token, err = repo.GetRunToken(ctx, "hash-expired")
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	token, err = repo.GetRunToken(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+func TestGetRunToken_ClientError(t *testing.T) {
+	client := NewMockDynamoDBClient()
+	client.GetItemError = errors.New("database error")
+	repo := NewTokenRepository(client, "tokens-table", testutil.SilentLogger())
+
+	_, err := repo.GetRunToken(context.Background(), "hash-1")
+
+	assert.Error(t, err)
+}
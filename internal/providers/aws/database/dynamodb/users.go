@@ -13,6 +13,7 @@ import (
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -59,7 +60,65 @@ type userItem struct {
 	ExpiresAt           int64     `dynamodbav:"expires_at,omitempty"` // Unix timestamp for TTL
 	CreatedByRequestID  string    `dynamodbav:"created_by_request_id,omitempty"`
 	ModifiedByRequestID string    `dynamodbav:"modified_by_request_id,omitempty"`
+	Provisioned         bool      `dynamodbav:"provisioned,omitempty"`
+	ExternalID          string    `dynamodbav:"external_id,omitempty"`
+	Groups              []string  `dynamodbav:"idp_groups,omitempty"`
+	PendingLogin        bool      `dynamodbav:"pending_login,omitempty"`
+	ClaimExpiresAt      time.Time `dynamodbav:"claim_expires_at,omitempty"`
+	ServiceAccount      bool      `dynamodbav:"service_account,omitempty"`
+	Owner               string    `dynamodbav:"owner,omitempty"`
 	All                 string    `dynamodbav:"_all"` // Constant partition key for listing all users
+
+	StarredExecutions []string          `dynamodbav:"starred_executions,omitempty"`
+	SavedFilters      []savedFilterItem `dynamodbav:"saved_filters,omitempty"`
+	Version           int64             `dynamodbav:"version,omitempty"`
+}
+
+// savedFilterItem represents an execution list filter saved by a user, stored in the user item.
+type savedFilterItem struct {
+	Name      string   `dynamodbav:"name"`
+	Statuses  []string `dynamodbav:"statuses,omitempty"`
+	CreatedBy string   `dynamodbav:"created_by,omitempty"`
+	Since     string   `dynamodbav:"since,omitempty"`
+	Starred   bool     `dynamodbav:"starred,omitempty"`
+	Search    string   `dynamodbav:"search,omitempty"`
+}
+
+// toAPIUser converts the item to an API user, leaving out the API key hash.
+func (item *userItem) toAPIUser() *api.User {
+	user := &api.User{
+		Email:               item.UserEmail,
+		Role:                item.Role,
+		CreatedAt:           item.CreatedAt,
+		Revoked:             item.Revoked,
+		CreatedByRequestID:  item.CreatedByRequestID,
+		ModifiedByRequestID: item.ModifiedByRequestID,
+		Provisioned:         item.Provisioned,
+		ExternalID:          item.ExternalID,
+		Groups:              item.Groups,
+		PendingLogin:        item.PendingLogin,
+		ServiceAccount:      item.ServiceAccount,
+		Owner:               item.Owner,
+		StarredExecutions:   item.StarredExecutions,
+		Version:             item.Version,
+	}
+	for _, filter := range item.SavedFilters {
+		user.SavedFilters = append(user.SavedFilters, api.ExecutionListFilter{
+			Name:      filter.Name,
+			Statuses:  filter.Statuses,
+			CreatedBy: filter.CreatedBy,
+			Since:     filter.Since,
+			Starred:   filter.Starred,
+			Search:    filter.Search,
+		})
+	}
+	if !item.LastUsed.IsZero() {
+		user.LastUsed = &item.LastUsed
+	}
+	if !item.ClaimExpiresAt.IsZero() {
+		user.ClaimExpiresAt = &item.ClaimExpiresAt
+	}
+	return user
 }
 
 // CreateUser stores a new user with their hashed API key in DynamoDB.
@@ -82,8 +141,17 @@ func (r *UserRepository) CreateUser(
 		Revoked:             false,
 		CreatedByRequestID:  user.CreatedByRequestID,
 		ModifiedByRequestID: user.ModifiedByRequestID,
+		Provisioned:         user.Provisioned,
+		ExternalID:          user.ExternalID,
+		Groups:              user.Groups,
+		PendingLogin:        user.PendingLogin,
+		ServiceAccount:      user.ServiceAccount,
+		Owner:               user.Owner,
 		All:                 awsConstants.DynamoDBAllValue,
 	}
+	if user.ClaimExpiresAt != nil {
+		item.ClaimExpiresAt = *user.ClaimExpiresAt
+	}
 
 	// Only set ExpiresAt if provided
 	if expiresAtUnix > 0 {
@@ -116,7 +184,7 @@ func (r *UserRepository) CreateUser(
 		if stderrors.As(err, &ccf) {
 			return apperrors.ErrConflict("user with this API key already exists", nil)
 		}
-		return apperrors.ErrDatabaseError("failed to create user", err)
+		return sdkerrors.Map(err, "failed to create user", apperrors.ErrDatabaseError)
 	}
 
 	return nil
@@ -145,7 +213,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*api
 		},
 	})
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to query user by email", err)
+		return nil, sdkerrors.Map(err, "failed to query user by email", apperrors.ErrDatabaseError)
 	}
 
 	if len(result.Items) == 0 {
@@ -160,19 +228,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*api
 			fmt.Errorf("unmarshal user item: %w", unmarshalErr))
 	}
 
-	user := &api.User{
-		Email:               item.UserEmail,
-		Role:                item.Role,
-		CreatedAt:           item.CreatedAt,
-		Revoked:             item.Revoked,
-		CreatedByRequestID:  item.CreatedByRequestID,
-		ModifiedByRequestID: item.ModifiedByRequestID,
-		// Note: APIKey is intentionally omitted for security
-	}
-	if !item.LastUsed.IsZero() {
-		user.LastUsed = &item.LastUsed
-	}
-	return user, nil
+	return item.toAPIUser(), nil
 }
 
 // GetUserByAPIKeyHash retrieves a user by their hashed API key (primary key).
@@ -208,7 +264,7 @@ func (r *UserRepository) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash str
 	if err != nil {
 		reqLogger.Debug("failed to get user by API key hash", "error", err)
 
-		return nil, apperrors.ErrDatabaseError("failed to get user by API key hash", err)
+		return nil, sdkerrors.Map(err, "failed to get user by API key hash", apperrors.ErrDatabaseError)
 	}
 
 	if result.Item == nil {
@@ -222,18 +278,7 @@ func (r *UserRepository) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash str
 		return nil, fmt.Errorf("failed to unmarshal user item: %w", unmarshalErr)
 	}
 
-	user := &api.User{
-		Email:               item.UserEmail,
-		Role:                item.Role,
-		CreatedAt:           item.CreatedAt,
-		Revoked:             item.Revoked,
-		CreatedByRequestID:  item.CreatedByRequestID,
-		ModifiedByRequestID: item.ModifiedByRequestID,
-	}
-	if !item.LastUsed.IsZero() {
-		user.LastUsed = &item.LastUsed
-	}
-	return user, nil
+	return item.toAPIUser(), nil
 }
 
 // queryAPIKeyHashByEmail queries for the api_key_hash by email.
@@ -260,7 +305,7 @@ func (r *UserRepository) queryAPIKeyHashByEmail(ctx context.Context, email, purp
 		Limit: aws.Int32(1),
 	})
 	if err != nil {
-		return "", apperrors.ErrDatabaseError("failed to query user by email", err)
+		return "", sdkerrors.Map(err, "failed to query user by email", apperrors.ErrDatabaseError)
 	}
 
 	if len(result.Items) == 0 {
@@ -322,7 +367,7 @@ func (r *UserRepository) UpdateLastUsed(ctx context.Context, email string) (*tim
 		ExpressionAttributeValues: exprValues,
 	})
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to update last_used", err)
+		return nil, sdkerrors.Map(err, "failed to update last_used", apperrors.ErrDatabaseError)
 	}
 
 	return &now, nil
@@ -369,13 +414,228 @@ func (r *UserRepository) RevokeUser(ctx context.Context, email string) error {
 	})
 
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to revoke user", err)
+		return sdkerrors.Map(err, "failed to revoke user", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// UpdateUser stores the role, revocation, provisioning, claim expiry and ownership attributes of an existing user,
+// read at user.Version.
+// It fails with a concurrent modification error if the user was updated since, and increments user.Version.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *api.User) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	apiKeyHash, err := r.queryAPIKeyHashByEmail(ctx, user.Email, "update_user")
+	if err != nil {
+		return err
+	}
+
+	updateLogArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"email", user.Email,
+		"action", "update",
+	}
+	updateLogArgs = append(updateLogArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(updateLogArgs))
+
+	updateExpr := "SET #role = :role, revoked = :revoked, provisioned = :provisioned, " +
+		"external_id = :external_id, idp_groups = :idp_groups, pending_login = :pending_login, " +
+		"service_account = :service_account, #owner = :owner"
+	groups := make([]types.AttributeValue, 0, len(user.Groups))
+	for _, group := range user.Groups {
+		groups = append(groups, &types.AttributeValueMemberS{Value: group})
+	}
+	exprValues := map[string]types.AttributeValue{
+		":role":            &types.AttributeValueMemberS{Value: user.Role},
+		":revoked":         &types.AttributeValueMemberBOOL{Value: user.Revoked},
+		":provisioned":     &types.AttributeValueMemberBOOL{Value: user.Provisioned},
+		":external_id":     &types.AttributeValueMemberS{Value: user.ExternalID},
+		":idp_groups":      &types.AttributeValueMemberL{Value: groups},
+		":pending_login":   &types.AttributeValueMemberBOOL{Value: user.PendingLogin},
+		":service_account": &types.AttributeValueMemberBOOL{Value: user.ServiceAccount},
+		":owner":           &types.AttributeValueMemberS{Value: user.Owner},
+	}
+
+	requestID := logger.GetRequestID(ctx)
+	if requestID != "" {
+		updateExpr += updateExprModifiedByRequestID
+		exprValues[":request_id"] = &types.AttributeValueMemberS{Value: requestID}
+	}
+	if user.ClaimExpiresAt != nil {
+		updateExpr += ", claim_expires_at = :claim_expires_at"
+		exprValues[":claim_expires_at"] = &types.AttributeValueMemberS{
+			Value: user.ClaimExpiresAt.UTC().Format(time.RFC3339Nano),
+		}
+	} else {
+		updateExpr += " REMOVE claim_expires_at"
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
+		},
+		UpdateExpression:          aws.String(updateExpr),
+		ExpressionAttributeNames:  map[string]string{"#role": "role", "#owner": "owner"},
+		ExpressionAttributeValues: exprValues,
+		ConditionExpression:       aws.String("attribute_exists(api_key_hash)"),
+	}
+	withVersionCondition(input, user.Version)
+	if _, err = r.client.UpdateItem(ctx, input); err != nil {
+		return r.mapVersionedUpdateError(err, "failed to update user")
 	}
+	user.Version++
 
 	return nil
 }
 
-// RemoveExpiration removes the expires_at field from a user record, making them permanent.
+// UpdateUserPreferences stores the starred executions and saved list filters of an existing user, by email.
+// Like UpdateUser, it fails with a concurrent modification error if the user was updated since it was read.
+func (r *UserRepository) UpdateUserPreferences(ctx context.Context, user *api.User) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	apiKeyHash, err := r.queryAPIKeyHashByEmail(ctx, user.Email, "update_user_preferences")
+	if err != nil {
+		return err
+	}
+
+	starred := make([]types.AttributeValue, 0, len(user.StarredExecutions))
+	for _, executionID := range user.StarredExecutions {
+		starred = append(starred, &types.AttributeValueMemberS{Value: executionID})
+	}
+	filters := make([]types.AttributeValue, 0, len(user.SavedFilters))
+	for _, filter := range user.SavedFilters {
+		av, marshalErr := attributevalue.MarshalMap(savedFilterItem{
+			Name:      filter.Name,
+			Statuses:  filter.Statuses,
+			CreatedBy: filter.CreatedBy,
+			Since:     filter.Since,
+			Starred:   filter.Starred,
+			Search:    filter.Search,
+		})
+		if marshalErr != nil {
+			return apperrors.ErrDatabaseError("failed to marshal saved filter", marshalErr)
+		}
+		filters = append(filters, &types.AttributeValueMemberM{Value: av})
+	}
+
+	updateLogArgs := []any{
+		"operation", "DynamoDB.UpdateItem",
+		"table", r.tableName,
+		"email", user.Email,
+		"action", "update_preferences",
+	}
+	updateLogArgs = append(updateLogArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(updateLogArgs))
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
+		},
+		UpdateExpression: aws.String("SET starred_executions = :starred, saved_filters = :saved_filters"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":starred":       &types.AttributeValueMemberL{Value: starred},
+			":saved_filters": &types.AttributeValueMemberL{Value: filters},
+		},
+		ConditionExpression: aws.String("attribute_exists(api_key_hash)"),
+	}
+	withVersionCondition(input, user.Version)
+	if _, err = r.client.UpdateItem(ctx, input); err != nil {
+		return r.mapVersionedUpdateError(err, "failed to update user preferences")
+	}
+	user.Version++
+
+	return nil
+}
+
+// mapVersionedUpdateError maps the error of an update of a user made with withVersionCondition.
+func (r *UserRepository) mapVersionedUpdateError(err error, message string) error {
+	if isVersionConflict(err) {
+		return apperrors.ErrConcurrentModification("user was modified concurrently", nil)
+	}
+	var ccf *types.ConditionalCheckFailedException
+	if stderrors.As(err, &ccf) {
+		return apperrors.ErrNotFound("user not found", nil)
+	}
+	return sdkerrors.Map(err, message, apperrors.ErrDatabaseError)
+}
+
+// ReplaceAPIKeyHash replaces the API key of a user. The API key hash being the key of the table, the user is
+// stored under the new hash before the previous item is deleted, which is put back on failure.
+func (r *UserRepository) ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	previousHash, err := r.queryAPIKeyHashByEmail(ctx, email, "replace_api_key")
+	if err != nil {
+		return err
+	}
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"api_key_hash": &types.AttributeValueMemberS{Value: previousHash},
+		},
+	})
+	if err != nil {
+		return sdkerrors.Map(err, "failed to get user", apperrors.ErrDatabaseError)
+	}
+	if result.Item == nil {
+		return apperrors.ErrNotFound("user not found", nil)
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.PutItem",
+		"table", r.tableName,
+		"email", email,
+		"action", "replace_api_key",
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	item := result.Item
+	item["api_key_hash"] = &types.AttributeValueMemberS{Value: apiKeyHash}
+	delete(item, "expires_at")
+	if requestID := logger.GetRequestID(ctx); requestID != "" {
+		item["modified_by_request_id"] = &types.AttributeValueMemberS{Value: requestID}
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(api_key_hash)"),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if stderrors.As(err, &ccf) {
+			return apperrors.ErrConflict("user with this API key already exists", nil)
+		}
+		return sdkerrors.Map(err, "failed to store the new API key", apperrors.ErrDatabaseError)
+	}
+
+	_, err = r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"api_key_hash": &types.AttributeValueMemberS{Value: previousHash},
+		},
+	})
+	if err != nil {
+		if _, rollbackErr := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(r.tableName),
+			Key: map[string]types.AttributeValue{
+				"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
+			},
+		}); rollbackErr != nil {
+			reqLogger.Error("failed to remove the new API key after failing to delete the previous one",
+				"email", email, "error", rollbackErr)
+		}
+		return sdkerrors.Map(err, "failed to delete the previous API key", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// RemoveExpiration removes the expires_at and claim_expires_at fields from a user record, making them permanent.
 func (r *UserRepository) RemoveExpiration(ctx context.Context, email string) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
 
@@ -400,7 +660,7 @@ func (r *UserRepository) RemoveExpiration(ctx context.Context, email string) err
 		Limit: aws.Int32(1),
 	})
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to query user by email for expiration removal", err)
+		return sdkerrors.Map(err, "failed to query user by email for expiration removal", apperrors.ErrDatabaseError)
 	}
 
 	if len(result.Items) == 0 {
@@ -431,11 +691,41 @@ func (r *UserRepository) RemoveExpiration(ctx context.Context, email string) err
 		Key: map[string]types.AttributeValue{
 			"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
 		},
-		UpdateExpression: aws.String("REMOVE expires_at"),
+		UpdateExpression: aws.String("REMOVE expires_at, claim_expires_at"),
 	})
 
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to remove expiration", err)
+		return sdkerrors.Map(err, "failed to remove expiration", apperrors.ErrDatabaseError)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user record.
+func (r *UserRepository) DeleteUser(ctx context.Context, email string) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	apiKeyHash, err := r.queryAPIKeyHashByEmail(ctx, email, "delete_user")
+	if err != nil {
+		return err
+	}
+
+	logArgs := []any{
+		"operation", "DynamoDB.DeleteItem",
+		"table", r.tableName,
+		"email", email,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	_, err = r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
+		},
+	})
+	if err != nil {
+		return sdkerrors.Map(err, "failed to delete user", apperrors.ErrDatabaseError)
 	}
 
 	return nil
@@ -489,7 +779,7 @@ func (r *UserRepository) CreatePendingAPIKey(ctx context.Context, pending *api.P
 	})
 
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to create pending API key", err)
+		return sdkerrors.Map(err, "failed to create pending API key", apperrors.ErrDatabaseError)
 	}
 
 	return nil
@@ -515,7 +805,7 @@ func (r *UserRepository) GetPendingAPIKey(ctx context.Context, secretToken strin
 	})
 
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to get pending API key", err)
+		return nil, sdkerrors.Map(err, "failed to get pending API key", apperrors.ErrDatabaseError)
 	}
 
 	if result.Item == nil {
@@ -527,7 +817,11 @@ func (r *UserRepository) GetPendingAPIKey(ctx context.Context, secretToken strin
 		return nil, apperrors.ErrInternalError("failed to unmarshal pending API key", err)
 	}
 
-	// Convert back to API type
+	return item.toAPIPendingKey(), nil
+}
+
+// toAPIPendingKey converts the item back to the API type.
+func (item *pendingAPIKeyItem) toAPIPendingKey() *api.PendingAPIKey {
 	pending := &api.PendingAPIKey{
 		SecretToken:  item.SecretToken,
 		APIKey:       item.APIKey,
@@ -545,7 +839,7 @@ func (r *UserRepository) GetPendingAPIKey(ctx context.Context, secretToken strin
 		pending.ViewedAt = &viewedAt
 	}
 
-	return pending, nil
+	return pending
 }
 
 // MarkAsViewed atomically marks a pending key as viewed with the IP address.
@@ -581,7 +875,7 @@ func (r *UserRepository) MarkAsViewed(ctx context.Context, secretToken, ipAddres
 		if stderrors.As(err, &ccf) {
 			return apperrors.ErrConflict("pending key already viewed or does not exist", nil)
 		}
-		return apperrors.ErrDatabaseError("failed to mark pending key as viewed", err)
+		return sdkerrors.Map(err, "failed to mark pending key as viewed", apperrors.ErrDatabaseError)
 	}
 
 	return nil
@@ -607,12 +901,50 @@ func (r *UserRepository) DeletePendingAPIKey(ctx context.Context, secretToken st
 	})
 
 	if err != nil {
-		return apperrors.ErrDatabaseError("failed to delete pending API key", err)
+		return sdkerrors.Map(err, "failed to delete pending API key", apperrors.ErrDatabaseError)
 	}
 
 	return nil
 }
 
+// ListPendingAPIKeys returns all the pending API keys. The table is scanned, its items being deleted once
+// expired.
+func (r *UserRepository) ListPendingAPIKeys(ctx context.Context) ([]*api.PendingAPIKey, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, r.logger)
+
+	logArgs := []any{
+		"operation", "DynamoDB.Scan",
+		"table", r.pendingTableName,
+	}
+	logArgs = append(logArgs, logger.GetDeadlineInfo(ctx)...)
+	reqLogger.Debug("calling external service", "context", logger.SliceToMap(logArgs))
+
+	var pendingKeys []*api.PendingAPIKey
+	var startKey map[string]types.AttributeValue
+	for {
+		result, err := r.client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:         aws.String(r.pendingTableName),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return nil, sdkerrors.Map(err, "failed to list pending API keys", apperrors.ErrDatabaseError)
+		}
+
+		var items []pendingAPIKeyItem
+		if err = attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+			return nil, apperrors.ErrInternalError("failed to unmarshal pending API keys", err)
+		}
+		for i := range items {
+			pendingKeys = append(pendingKeys, items[i].toAPIPendingKey())
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			return pendingKeys, nil
+		}
+		startKey = result.LastEvaluatedKey
+	}
+}
+
 // ListUsers returns all users in the system sorted by email (excluding API key hashes for security).
 // Uses the all-user_email GSI to retrieve users in sorted order directly from DynamoDB.
 func (r *UserRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
@@ -641,7 +973,7 @@ func (r *UserRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
 		ScanIndexForward: aws.Bool(true), // Sort ascending by user_email (the range key)
 	})
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to list users", err)
+		return nil, sdkerrors.Map(err, "failed to list users", apperrors.ErrDatabaseError)
 	}
 
 	users := make([]*api.User, 0, len(result.Items))
@@ -652,19 +984,7 @@ func (r *UserRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
 			continue
 		}
 
-		user := &api.User{
-			Email:               dbUserItem.UserEmail,
-			Role:                dbUserItem.Role,
-			CreatedAt:           dbUserItem.CreatedAt,
-			Revoked:             dbUserItem.Revoked,
-			CreatedByRequestID:  dbUserItem.CreatedByRequestID,
-			ModifiedByRequestID: dbUserItem.ModifiedByRequestID,
-			// Note: APIKey and APIKeyHash are intentionally omitted for security
-		}
-		if !dbUserItem.LastUsed.IsZero() {
-			user.LastUsed = &dbUserItem.LastUsed
-		}
-		users = append(users, user)
+		users = append(users, dbUserItem.toAPIUser())
 	}
 
 	return users, nil
@@ -697,7 +1017,7 @@ func (r *UserRepository) GetUsersByRequestID(ctx context.Context, requestID stri
 		},
 	})
 	if err != nil {
-		return nil, apperrors.ErrDatabaseError("failed to query users by request ID", err)
+		return nil, sdkerrors.Map(err, "failed to query users by request ID", apperrors.ErrDatabaseError)
 	}
 
 	users := make([]*api.User, 0, len(result.Items))
@@ -708,18 +1028,7 @@ func (r *UserRepository) GetUsersByRequestID(ctx context.Context, requestID stri
 			continue
 		}
 
-		user := &api.User{
-			Email:               dbUserItem.UserEmail,
-			Role:                dbUserItem.Role,
-			CreatedAt:           dbUserItem.CreatedAt,
-			Revoked:             dbUserItem.Revoked,
-			CreatedByRequestID:  dbUserItem.CreatedByRequestID,
-			ModifiedByRequestID: dbUserItem.ModifiedByRequestID,
-		}
-		if !dbUserItem.LastUsed.IsZero() {
-			user.LastUsed = &dbUserItem.LastUsed
-		}
-		users = append(users, user)
+		users = append(users, dbUserItem.toAPIUser())
 	}
 
 	return users, nil
@@ -0,0 +1,140 @@
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDynamoDBLocalClient returns a client of the DynamoDB Local instance of DYNAMODB_ENDPOINT,
+// skipping the test when none is configured (run `just test-integration`).
+func newDynamoDBLocalClient(t *testing.T) *awsdynamodb.Client {
+	t.Helper()
+	if os.Getenv(awsConstants.DynamoDBEndpointEnvVar) == "" {
+		t.Skipf("%s not set, DynamoDB Local isn't running", awsConstants.DynamoDBEndpointEnvVar)
+	}
+	return dynamodb.NewSDKClient(aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "local", SecretAccessKey: "local"}, nil
+		}),
+	})
+}
+
+// createAPIKeysTable creates a table with the schema of the API keys table of the backend stack,
+// deleted at the end of the test.
+func createAPIKeysTable(t *testing.T, client *awsdynamodb.Client) string {
+	t.Helper()
+	ctx := context.Background()
+	table := fmt.Sprintf("runvoy-api-keys-%d", time.Now().UnixNano())
+	stringAttribute := func(name string) types.AttributeDefinition {
+		return types.AttributeDefinition{AttributeName: aws.String(name), AttributeType: types.ScalarAttributeTypeS}
+	}
+	keyElement := func(name string, keyType types.KeyType) types.KeySchemaElement {
+		return types.KeySchemaElement{AttributeName: aws.String(name), KeyType: keyType}
+	}
+
+	_, err := client.CreateTable(ctx, &awsdynamodb.CreateTableInput{
+		TableName:   aws.String(table),
+		BillingMode: types.BillingModePayPerRequest,
+		AttributeDefinitions: []types.AttributeDefinition{
+			stringAttribute("api_key_hash"),
+			stringAttribute("user_email"),
+			stringAttribute(awsConstants.DynamoDBAllAttribute),
+		},
+		KeySchema: []types.KeySchemaElement{keyElement("api_key_hash", types.KeyTypeHash)},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName:  aws.String("user_email-index"),
+				KeySchema:  []types.KeySchemaElement{keyElement("user_email", types.KeyTypeHash)},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+			{
+				IndexName: aws.String("all-user_email"),
+				KeySchema: []types.KeySchemaElement{
+					keyElement(awsConstants.DynamoDBAllAttribute, types.KeyTypeHash),
+					keyElement("user_email", types.KeyTypeRange),
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = client.DeleteTable(context.Background(), &awsdynamodb.DeleteTableInput{TableName: aws.String(table)})
+	})
+	return table
+}
+
+func TestUserRepository_DynamoDBLocal(t *testing.T) {
+	client := newDynamoDBLocalClient(t)
+	table := createAPIKeysTable(t, client)
+	repo := dynamodb.NewUserRepository(dynamodb.NewClientAdapter(client), table, "", testutil.SilentLogger())
+	ctx := context.Background()
+
+	for _, user := range []struct{ email, hash string }{
+		{"bob@example.com", "hash-bob"},
+		{"alice@example.com", "hash-alice"},
+	} {
+		require.NoError(t, repo.CreateUser(ctx, &api.User{
+			Email:     user.email,
+			Role:      "viewer",
+			CreatedAt: time.Now().UTC(),
+		}, user.hash, 0))
+	}
+
+	t.Run("rejects a duplicate API key", func(t *testing.T) {
+		err := repo.CreateUser(ctx, &api.User{Email: "eve@example.com", Role: "viewer"}, "hash-bob", 0)
+
+		assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+	})
+
+	t.Run("gets a user by email and API key hash", func(t *testing.T) {
+		byEmail, err := repo.GetUserByEmail(ctx, "alice@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, byEmail)
+		assert.Equal(t, "viewer", byEmail.Role)
+
+		byHash, err := repo.GetUserByAPIKeyHash(ctx, "hash-alice")
+		require.NoError(t, err)
+		require.NotNil(t, byHash)
+		assert.Equal(t, "alice@example.com", byHash.Email)
+
+		missing, err := repo.GetUserByEmail(ctx, "nobody@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("lists the users sorted by email", func(t *testing.T) {
+		users, err := repo.ListUsers(ctx)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "alice@example.com", users[0].Email)
+		assert.Equal(t, "bob@example.com", users[1].Email)
+	})
+
+	t.Run("revokes a user", func(t *testing.T) {
+		require.NoError(t, repo.RevokeUser(ctx, "bob@example.com"))
+
+		user, err := repo.GetUserByAPIKeyHash(ctx, "hash-bob")
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.True(t, user.Revoked)
+	})
+}
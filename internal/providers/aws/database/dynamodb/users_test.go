@@ -3,10 +3,12 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
@@ -443,3 +445,212 @@ func TestUserRepository_GetUserByEmail_ErrorHandling(t *testing.T) {
 		assert.Nil(t, user)
 	})
 }
+
+// seedUserItem stores a user item under the API key hash and indexes it by email.
+func seedUserItem(mockClient *MockDynamoDBClient, tableName, apiKeyHash, email string) {
+	item := map[string]types.AttributeValue{
+		"api_key_hash": &types.AttributeValueMemberS{Value: apiKeyHash},
+		"user_email":   &types.AttributeValueMemberS{Value: email},
+		"role":         &types.AttributeValueMemberS{Value: "viewer"},
+		"provisioned":  &types.AttributeValueMemberBOOL{Value: true},
+		"expires_at":   &types.AttributeValueMemberN{Value: "1700000000"},
+	}
+	if mockClient.Tables[tableName] == nil {
+		mockClient.Tables[tableName] = make(map[string]map[string]map[string]types.AttributeValue)
+	}
+	mockClient.Tables[tableName][apiKeyHash] = map[string]map[string]types.AttributeValue{"": item}
+	if mockClient.Indexes[tableName] == nil {
+		mockClient.Indexes[tableName] = make(map[string]map[string][]map[string]types.AttributeValue)
+	}
+	mockClient.Indexes[tableName]["user_email-index"] = map[string][]map[string]types.AttributeValue{
+		email: {item},
+	}
+}
+
+func TestUserRepository_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	tableName := "test-users-table"
+
+	t.Run("successfully updates user", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "hash123", "user@example.com")
+
+		err := repo.UpdateUser(ctx, &api.User{
+			Email:       "user@example.com",
+			Role:        "developer",
+			Provisioned: true,
+			Groups:      []string{"Engineering"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockClient.UpdateItemCalls)
+	})
+
+	t.Run("handles user not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+
+		err := repo.UpdateUser(ctx, &api.User{Email: "nonexistent@example.com", Role: "viewer"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+	})
+
+	t.Run("fails when the user was modified concurrently", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "hash123", "user@example.com")
+		mockClient.UpdateItemError = &types.ConditionalCheckFailedException{
+			Item: map[string]types.AttributeValue{"version": &types.AttributeValueMemberN{Value: "2"}},
+		}
+
+		err := repo.UpdateUser(ctx, &api.User{Email: "user@example.com", Role: "viewer", Version: 1})
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+		assert.Equal(t, apperrors.ErrCodeConcurrentModification, apperrors.GetErrorCode(err))
+	})
+}
+
+func TestUserRepository_UpdateUserPreferences(t *testing.T) {
+	ctx := context.Background()
+	tableName := "test-users-table"
+
+	t.Run("successfully updates preferences", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "hash123", "user@example.com")
+
+		err := repo.UpdateUserPreferences(ctx, &api.User{
+			Email:             "user@example.com",
+			StarredExecutions: []string{"exec-1"},
+			SavedFilters: []api.ExecutionListFilter{
+				{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me", Since: "168h"},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockClient.UpdateItemCalls)
+	})
+
+	t.Run("handles user not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+
+		err := repo.UpdateUserPreferences(ctx, &api.User{Email: "nonexistent@example.com"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+	})
+}
+
+func TestUserItem_ToAPIUserPreferences(t *testing.T) {
+	item := &userItem{
+		UserEmail:         "user@example.com",
+		StarredExecutions: []string{"exec-1", "exec-2"},
+		SavedFilters: []savedFilterItem{
+			{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me", Since: "168h"},
+			{Name: "starred", Starred: true},
+		},
+	}
+
+	user := item.toAPIUser()
+
+	assert.Equal(t, []string{"exec-1", "exec-2"}, user.StarredExecutions)
+	assert.Equal(t, []api.ExecutionListFilter{
+		{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me", Since: "168h"},
+		{Name: "starred", Starred: true},
+	}, user.SavedFilters)
+}
+
+func TestUserRepository_ReplaceAPIKeyHash(t *testing.T) {
+	ctx := context.Background()
+	tableName := "test-users-table"
+
+	t.Run("moves the user to the new API key hash", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "old-hash", "user@example.com")
+
+		err := repo.ReplaceAPIKeyHash(ctx, "user@example.com", "new-hash")
+
+		require.NoError(t, err)
+		assert.Empty(t, mockClient.Tables[tableName]["old-hash"])
+		item := mockClient.Tables[tableName]["new-hash"][""]
+		require.NotNil(t, item)
+		assert.Equal(t, "user@example.com", getStringValue(item["user_email"]))
+		assert.NotContains(t, item, "expires_at", "the new API key doesn't expire")
+	})
+
+	t.Run("removes the new API key when the previous one can't be deleted", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "old-hash", "user@example.com")
+		mockClient.DeleteItemError = errors.New("delete failed")
+
+		err := repo.ReplaceAPIKeyHash(ctx, "user@example.com", "new-hash")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete the previous API key")
+		assert.Equal(t, 2, mockClient.DeleteItemCalls, "the new API key is deleted as a rollback")
+	})
+}
+
+func TestUserRepository_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	tableName := "test-users-table"
+
+	t.Run("deletes the user", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+		seedUserItem(mockClient, tableName, "hash123", "user@example.com")
+
+		require.NoError(t, repo.DeleteUser(ctx, "user@example.com"))
+		assert.Empty(t, mockClient.Tables[tableName]["hash123"])
+	})
+
+	t.Run("handles user not found", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, tableName, "test-pending-table", testutil.SilentLogger())
+
+		err := repo.DeleteUser(ctx, "nonexistent@example.com")
+
+		assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetErrorCode(err))
+		assert.Zero(t, mockClient.DeleteItemCalls)
+	})
+}
+
+func TestUserRepository_ListPendingAPIKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists the pending API keys", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, "test-users-table", "test-pending-table", testutil.SilentLogger())
+		for _, token := range []string{"token-1", "token-2"} {
+			require.NoError(t, repo.CreatePendingAPIKey(ctx, &api.PendingAPIKey{
+				SecretToken: token,
+				UserEmail:   "user@example.com",
+				CreatedAt:   time.Now(),
+				ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+			}))
+		}
+
+		pendingKeys, err := repo.ListPendingAPIKeys(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, pendingKeys, 2)
+		assert.Equal(t, "user@example.com", pendingKeys[0].UserEmail)
+		assert.Equal(t, 1, mockClient.ScanCalls)
+	})
+
+	t.Run("handles scan error", func(t *testing.T) {
+		mockClient := NewMockDynamoDBClient()
+		repo := NewUserRepository(mockClient, "test-users-table", "test-pending-table", testutil.SilentLogger())
+		mockClient.ScanError = errors.New("scan failed")
+
+		_, err := repo.ListPendingAPIKeys(ctx)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list pending API keys")
+	})
+}
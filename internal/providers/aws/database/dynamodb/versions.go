@@ -0,0 +1,51 @@
+package dynamodb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// versionAttribute is the version of the records updated with optimistic concurrency control, incremented by
+// each of their updates. Records that were never updated have none, which is version 0.
+const versionAttribute = "version"
+
+// withVersionCondition makes an update apply only to the record at version, the version it was read at,
+// and increment its version. The update expression must start with a SET clause.
+// When the condition fails, the record is returned with the error, see isVersionConflict.
+func withVersionCondition(input *dynamodb.UpdateItemInput, version int64) {
+	if input.ExpressionAttributeNames == nil {
+		input.ExpressionAttributeNames = make(map[string]string)
+	}
+	if input.ExpressionAttributeValues == nil {
+		input.ExpressionAttributeValues = make(map[string]types.AttributeValue)
+	}
+	input.ExpressionAttributeNames["#version"] = versionAttribute
+	input.ExpressionAttributeValues[":next_version"] = &types.AttributeValueMemberN{
+		Value: strconv.FormatInt(version+1, 10)}
+
+	condition := "attribute_not_exists(#version)"
+	if version > 0 {
+		condition = "#version = :version"
+		input.ExpressionAttributeValues[":version"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(version, 10)}
+	}
+	if input.ConditionExpression != nil {
+		condition = aws.ToString(input.ConditionExpression) + " AND " + condition
+	}
+	input.ConditionExpression = aws.String(condition)
+	input.UpdateExpression = aws.String("SET #version = :next_version, " +
+		strings.TrimPrefix(aws.ToString(input.UpdateExpression), "SET "))
+	input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+}
+
+// isVersionConflict reports whether an update made with withVersionCondition failed because the record
+// was modified since it was read, rather than because it doesn't exist.
+func isVersionConflict(err error) bool {
+	var ccfe *types.ConditionalCheckFailedException
+	return errors.As(err, &ccfe) && len(ccfe.Item) > 0
+}
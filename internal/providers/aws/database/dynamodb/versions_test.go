@@ -0,0 +1,51 @@
+package dynamodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithVersionCondition(t *testing.T) {
+	t.Run("requires a record never updated at version 0", func(t *testing.T) {
+		input := &dynamodb.UpdateItemInput{UpdateExpression: aws.String("SET #status = :status")}
+
+		withVersionCondition(input, 0)
+
+		assert.Equal(t, "SET #version = :next_version, #status = :status", *input.UpdateExpression)
+		assert.Equal(t, "attribute_not_exists(#version)", *input.ConditionExpression)
+		assert.Equal(t, "version", input.ExpressionAttributeNames["#version"])
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "1"}, input.ExpressionAttributeValues[":next_version"])
+		assert.NotContains(t, input.ExpressionAttributeValues, ":version")
+		assert.Equal(t, types.ReturnValuesOnConditionCheckFailureAllOld, input.ReturnValuesOnConditionCheckFailure)
+	})
+
+	t.Run("requires the version read and keeps the existing condition", func(t *testing.T) {
+		input := &dynamodb.UpdateItemInput{
+			UpdateExpression:         aws.String("SET updated_at = :now REMOVE warm_pool_size"),
+			ExpressionAttributeNames: map[string]string{"#status": "status"},
+			ConditionExpression:      aws.String("attribute_exists(image_id)"),
+		}
+
+		withVersionCondition(input, 3)
+
+		assert.Equal(t, "SET #version = :next_version, updated_at = :now REMOVE warm_pool_size",
+			*input.UpdateExpression)
+		assert.Equal(t, "attribute_exists(image_id) AND #version = :version", *input.ConditionExpression)
+		assert.Equal(t, "status", input.ExpressionAttributeNames["#status"])
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "3"}, input.ExpressionAttributeValues[":version"])
+		assert.Equal(t, &types.AttributeValueMemberN{Value: "4"}, input.ExpressionAttributeValues[":next_version"])
+	})
+}
+
+func TestIsVersionConflict(t *testing.T) {
+	assert.True(t, isVersionConflict(&types.ConditionalCheckFailedException{
+		Item: map[string]types.AttributeValue{"version": &types.AttributeValueMemberN{Value: "2"}},
+	}), "the record exists at another version")
+	assert.False(t, isVersionConflict(&types.ConditionalCheckFailedException{}), "the record doesn't exist")
+	assert.False(t, isVersionConflict(errors.New("database error")))
+}
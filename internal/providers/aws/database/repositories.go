@@ -3,27 +3,35 @@ package database
 import (
 	"log/slog"
 
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/config"
+	"github.com/runvoy/runvoy/internal/crypto"
 	"github.com/runvoy/runvoy/internal/database"
 	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+	"github.com/runvoy/runvoy/internal/providers/aws/keys"
 	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
 )
 
 // Repositories bundles all AWS-backed database repositories.
 type Repositories struct {
-	UserRepo         database.UserRepository
-	ExecutionRepo    database.ExecutionRepository
-	ConnectionRepo   database.ConnectionRepository
-	LogEventRepo     database.LogEventRepository
-	TokenRepo        database.TokenRepository
-	ImageTaskDefRepo *dynamoRepo.ImageTaskDefRepository
-	SecretsRepo      database.SecretsRepository
+	UserRepo          database.UserRepository
+	ExecutionRepo     database.ExecutionRepository
+	ConnectionRepo    database.ConnectionRepository
+	LogEventRepo      database.LogEventRepository
+	TokenRepo         database.TokenRepository
+	ImageTaskDefRepo  *dynamoRepo.ImageTaskDefRepository
+	SecretsRepo       database.SecretsRepository
+	HealthReportRepo  database.HealthReportRepository
+	CommandPolicyRepo database.CommandPolicyRepository
+	ConfigRepo        database.ConfigRepository
+	LockRepo          database.LockRepository
 }
 
 // CreateRepositories creates all AWS-backed database repositories from the provided clients and configuration.
 func CreateRepositories(
 	dynamoClient dynamoRepo.Client,
 	ssmClient secrets.Client,
+	kmsClient keys.Client,
 	cfg *config.Config,
 	log *slog.Logger,
 ) *Repositories {
@@ -35,7 +43,32 @@ func CreateRepositories(
 	imageTaskDefRepo := dynamoRepo.NewImageTaskDefRepository(dynamoClient, cfg.AWS.ImageTaskDefsTable, log)
 	dynamoSecretsRepo := dynamoRepo.NewSecretsRepository(dynamoClient, cfg.AWS.SecretsMetadataTable, log)
 
-	valueStore := secrets.NewParameterStoreManager(ssmClient, cfg.AWS.SecretsPrefix, cfg.AWS.SecretsKMSKeyARN, log)
+	// Stacks deployed before health reports were persisted have no table for them.
+	var healthReportRepo database.HealthReportRepository
+	if cfg.AWS.HealthReportsTable != "" {
+		healthReportRepo = dynamoRepo.NewHealthReportRepository(dynamoClient, cfg.AWS.HealthReportsTable, log)
+	}
+
+	// Likewise for the command policy rules, which are then not enforced.
+	var commandPolicyRepo database.CommandPolicyRepository
+	if cfg.AWS.CommandPoliciesTable != "" {
+		commandPolicyRepo = dynamoRepo.NewCommandPolicyRepository(dynamoClient, cfg.AWS.CommandPoliciesTable, log)
+	}
+
+	// And for the config table holding the run freeze switch, so runs are never frozen.
+	var configRepo database.ConfigRepository
+	if cfg.AWS.ConfigTable != "" {
+		configRepo = dynamoRepo.NewConfigRepository(dynamoClient, cfg.AWS.ConfigTable, log)
+	}
+
+	// And for the locks, which executions cannot be started with then.
+	var lockRepo database.LockRepository
+	if cfg.AWS.LocksTable != "" {
+		lockRepo = dynamoRepo.NewLockRepository(dynamoClient, cfg.AWS.LocksTable, log)
+	}
+
+	envelope := crypto.NewEnvelope(keys.NewKMSKeyManager(kmsClient, cfg.AWS.SecretsKMSKeyARN))
+	valueStore := secrets.NewParameterStoreManager(ssmClient, cfg.AWS.SecretsPrefix, envelope, cfg.ResourceTags, log)
 	secretsRepo := NewSecretsRepository(dynamoSecretsRepo, valueStore, log)
 
 	log.Debug("DynamoDB backend configured", "context", map[string]string{
@@ -46,6 +79,10 @@ func CreateRepositories(
 		"websocket_tokens_table":      cfg.AWS.WebSocketTokensTable,
 		"image_taskdefs_table":        cfg.AWS.ImageTaskDefsTable,
 		"secrets_metadata_table":      cfg.AWS.SecretsMetadataTable,
+		"health_reports_table":        cfg.AWS.HealthReportsTable,
+		"command_policies_table":      cfg.AWS.CommandPoliciesTable,
+		"config_table":                cfg.AWS.ConfigTable,
+		"locks_table":                 cfg.AWS.LocksTable,
 	})
 
 	log.Debug("SSM Parameter Store secrets backend configured", "context", map[string]string{
@@ -53,13 +90,20 @@ func CreateRepositories(
 		"secrets_kms_key_arn": cfg.AWS.SecretsKMSKeyARN,
 	})
 
+	// Faults are only injected when chaos testing is enabled in the configuration.
+	inj := chaos.New(cfg.Chaos)
+
 	return &Repositories{
-		UserRepo:         userRepo,
-		ExecutionRepo:    executionRepo,
-		ConnectionRepo:   connectionRepo,
-		LogEventRepo:     logEventRepo,
-		TokenRepo:        tokenRepo,
-		ImageTaskDefRepo: imageTaskDefRepo,
-		SecretsRepo:      secretsRepo,
+		UserRepo:          chaos.WrapUserRepository(userRepo, inj),
+		ExecutionRepo:     chaos.WrapExecutionRepository(executionRepo, inj),
+		ConnectionRepo:    chaos.WrapConnectionRepository(connectionRepo, inj),
+		LogEventRepo:      chaos.WrapLogEventRepository(logEventRepo, inj),
+		TokenRepo:         chaos.WrapTokenRepository(tokenRepo, inj),
+		ImageTaskDefRepo:  imageTaskDefRepo,
+		SecretsRepo:       chaos.WrapSecretsRepository(secretsRepo, inj),
+		HealthReportRepo:  chaos.WrapHealthReportRepository(healthReportRepo, inj),
+		CommandPolicyRepo: chaos.WrapCommandPolicyRepository(commandPolicyRepo, inj),
+		ConfigRepo:        chaos.WrapConfigRepository(configRepo, inj),
+		LockRepo:          chaos.WrapLockRepository(lockRepo, inj),
 	}
 }
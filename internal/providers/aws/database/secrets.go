@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/database"
@@ -17,10 +18,11 @@ type MetadataRepository interface {
 	CreateSecret(ctx context.Context, secret *api.Secret) error
 	GetSecret(ctx context.Context, name string) (*api.Secret, error)
 	ListSecrets(ctx context.Context) ([]*api.Secret, error)
-	UpdateSecretMetadata(ctx context.Context, name, keyName, description, updatedBy string) error
+	UpdateSecretMetadata(ctx context.Context, name, keyName, description, updatedBy string, version int64) error
 	DeleteSecret(ctx context.Context, name string) error
 	SecretExists(ctx context.Context, name string) (bool, error)
 	GetSecretsByRequestID(ctx context.Context, requestID string) ([]*api.Secret, error)
+	RecordSecretUsage(ctx context.Context, name, executionID string, usedAt time.Time) error
 }
 
 // SecretsRepository implements database.SecretsRepository for AWS.
@@ -159,11 +161,14 @@ func (sr *SecretsRepository) UpdateSecret(
 		description = existingSecret.Description
 	}
 
-	// Update metadata with merged values
+	// Update metadata with merged values, unless another update merged them since they were read
 	if updateErr := sr.metadataRepo.UpdateSecretMetadata(
-		ctx, secret.Name, keyName, description, secret.UpdatedBy,
+		ctx, secret.Name, keyName, description, secret.UpdatedBy, existingSecret.Version,
 	); updateErr != nil {
 		reqLogger.Error("failed to update secret metadata", "error", updateErr, "name", secret.Name)
+		if appErrors.GetErrorCode(updateErr) == appErrors.ErrCodeConcurrentModification {
+			return fmt.Errorf("update secret metadata: %w", updateErr)
+		}
 		return appErrors.ErrInternalError("failed to update secret metadata", updateErr)
 	}
 
@@ -197,3 +202,17 @@ func (sr *SecretsRepository) GetSecretsByRequestID(ctx context.Context, requestI
 	}
 	return secretList, nil
 }
+
+// RecordSecretUsage records that an execution was started with the secret.
+// Only the metadata is updated, the value is left untouched.
+func (sr *SecretsRepository) RecordSecretUsage(
+	ctx context.Context,
+	name, executionID string,
+	usedAt time.Time,
+) error {
+	if err := sr.metadataRepo.RecordSecretUsage(ctx, name, executionID, usedAt); err != nil {
+		// Wrap the error - AppError types will still be found via errors.As() in the chain
+		return fmt.Errorf("record secret usage: %w", err)
+	}
+	return nil
+}
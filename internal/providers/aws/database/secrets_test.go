@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	appErrors "github.com/runvoy/runvoy/internal/errors"
@@ -69,7 +71,7 @@ func (m *mockMetadataRepository) ListSecrets(_ context.Context) ([]*api.Secret,
 }
 
 func (m *mockMetadataRepository) UpdateSecretMetadata(
-	_ context.Context, name, keyName, description, updatedBy string,
+	_ context.Context, name, keyName, description, updatedBy string, version int64,
 ) error {
 	if m.updateErr != nil {
 		return m.updateErr
@@ -78,6 +80,10 @@ func (m *mockMetadataRepository) UpdateSecretMetadata(
 	if !ok {
 		return appErrors.ErrSecretNotFound("secret not found", nil)
 	}
+	if secret.Version != version {
+		return appErrors.ErrConcurrentModification("secret was modified concurrently", nil)
+	}
+	secret.Version++
 	secret.KeyName = keyName
 	secret.Description = description
 	secret.UpdatedBy = updatedBy
@@ -110,6 +116,21 @@ func (m *mockMetadataRepository) GetSecretsByRequestID(_ context.Context, _ stri
 	return list, nil
 }
 
+func (m *mockMetadataRepository) RecordSecretUsage(
+	_ context.Context, name, executionID string, usedAt time.Time,
+) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	secret, exists := m.secrets[name]
+	if !exists {
+		return appErrors.ErrNotFound("secret not found", nil)
+	}
+	secret.LastUsedAt = &usedAt
+	secret.LastUsedByExecutionID = executionID
+	return nil
+}
+
 // mockValueStore is a mock implementation of the ValueStore interface
 type mockValueStore struct {
 	values      map[string]string
@@ -363,6 +384,27 @@ func TestUpdateSecret_MetadataUpdateError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestUpdateSecret_ConcurrentModification(t *testing.T) {
+	metadataRepo := newMockMetadataRepository()
+	repo := NewSecretsRepository(metadataRepo, newMockValueStore(), testutil.SilentLogger())
+	require.NoError(t, metadataRepo.CreateSecret(context.Background(), &api.Secret{
+		Name:    "test-secret",
+		KeyName: "TEST_KEY",
+	}))
+	// Another update is stored after the secret was read and before its metadata is updated
+	metadataRepo.updateErr = appErrors.ErrConcurrentModification("secret was modified concurrently", nil)
+
+	err := repo.UpdateSecret(context.Background(), &api.Secret{
+		Name:        "test-secret",
+		Description: "New description",
+		UpdatedBy:   "user@example.com",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, appErrors.GetStatusCode(err))
+	assert.Equal(t, appErrors.ErrCodeConcurrentModification, appErrors.GetErrorCode(err))
+}
+
 func TestUpdateSecret_EmptyValueDoesNotUpdateValue(t *testing.T) {
 	metadataRepo := newMockMetadataRepository()
 	valueStore := newMockValueStore()
@@ -25,12 +25,13 @@ import (
 
 // TaskDefinitionConfig contains configuration needed to build task definitions.
 type TaskDefinitionConfig struct {
-	LogGroup string
-	Region   string
+	LogGroup     string
+	Region       string
+	ResourceTags map[string]string
 }
 
-// BuildTaskDefinitionTags creates the tags to be applied to a task definition.
-func BuildTaskDefinitionTags(image string, isDefault *bool) []ecsTypes.Tag {
+// BuildTaskDefinitionTags creates the tags to be applied to a task definition, including the resource tags.
+func BuildTaskDefinitionTags(image string, isDefault *bool, resourceTags map[string]string) []ecsTypes.Tag {
 	tags := []ecsTypes.Tag{
 		{
 			Key:   awsStd.String(awsConstants.TaskDefinitionDockerImageTagKey),
@@ -38,8 +39,8 @@ func BuildTaskDefinitionTags(image string, isDefault *bool) []ecsTypes.Tag {
 		},
 	}
 
-	// Add standard tags (Application, ManagedBy)
-	standardTags := secrets.GetStandardTags()
+	// Add standard tags (Application, ManagedBy) and resource tags
+	standardTags := secrets.GetStandardTags(resourceTags)
 	for _, tag := range standardTags {
 		tags = append(tags, ecsTypes.Tag{
 			Key:   awsStd.String(tag.Key),
@@ -108,7 +109,7 @@ func RecreateTaskDefinition(
 
 	taskDefARN := *output.TaskDefinition.TaskDefinitionArn
 
-	tagTaskDefinition(ctx, ecsClient, taskDefARN, family, image, isDefault, reqLogger)
+	tagTaskDefinition(ctx, ecsClient, taskDefARN, family, image, isDefault, cfg.ResourceTags, reqLogger)
 
 	reqLogger.Info("task definition recreated", "context", map[string]string{
 		"family":              family,
@@ -125,13 +126,14 @@ func tagTaskDefinition(
 	family string,
 	image string,
 	isDefault bool,
+	resourceTags map[string]string,
 	reqLogger *slog.Logger,
 ) {
 	var isDefaultPtr *bool
 	if isDefault {
 		isDefaultPtr = awsStd.Bool(true)
 	}
-	tags := BuildTaskDefinitionTags(image, isDefaultPtr)
+	tags := BuildTaskDefinitionTags(image, isDefaultPtr, resourceTags)
 	if len(tags) == 0 {
 		return
 	}
@@ -160,19 +162,21 @@ func tagTaskDefinition(
 }
 
 // UpdateTaskDefinitionTags updates tags on an existing task definition to match expected values.
+// Resource tags no longer configured are left on the task definition.
 func UpdateTaskDefinitionTags(
 	ctx context.Context,
 	ecsClient awsClient.ECSClient,
 	taskDefARN string,
 	image string,
 	isDefault bool,
+	resourceTags map[string]string,
 	reqLogger *slog.Logger,
 ) error {
 	var isDefaultPtr *bool
 	if isDefault {
 		isDefaultPtr = awsStd.Bool(true)
 	}
-	expectedTags := BuildTaskDefinitionTags(image, isDefaultPtr)
+	expectedTags := BuildTaskDefinitionTags(image, isDefaultPtr, resourceTags)
 
 	// Get current tags
 	tagsOutput, err := ecsClient.ListTagsForResource(ctx, &ecs.ListTagsForResourceInput{
@@ -352,6 +356,7 @@ func BuildTaskDefinitionInputForConfig(
 					"echo \"This task definition is a template. Command will be overridden at runtime.\"",
 				},
 				WorkingDirectory: awsStd.String(awsConstants.SharedVolumePath),
+				StopTimeout:      awsStd.Int32(awsConstants.RunnerStopTimeoutSeconds),
 				MountPoints: []ecsTypes.MountPoint{
 					{
 						ContainerPath: awsStd.String(awsConstants.SharedVolumePath),
@@ -40,7 +40,7 @@ func TestBuildTaskDefinitionTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := BuildTaskDefinitionTags(tt.image, tt.isDefault)
+			tags := BuildTaskDefinitionTags(tt.image, tt.isDefault, nil)
 
 			assert.Len(t, tags, tt.wantTags)
 
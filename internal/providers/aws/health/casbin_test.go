@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
@@ -146,6 +147,18 @@ func (m *mockUserRepositoryForCasbin) RevokeUser(_ context.Context, _ string) er
 	return errors.New("not implemented")
 }
 
+func (*mockUserRepositoryForCasbin) UpdateUser(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*mockUserRepositoryForCasbin) UpdateUserPreferences(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*mockUserRepositoryForCasbin) ReplaceAPIKeyHash(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (m *mockUserRepositoryForCasbin) CreatePendingAPIKey(_ context.Context, _ *api.PendingAPIKey) error {
 	return errors.New("not implemented")
 }
@@ -158,6 +171,14 @@ func (m *mockUserRepositoryForCasbin) DeletePendingAPIKey(_ context.Context, _ s
 	return errors.New("not implemented")
 }
 
+func (m *mockUserRepositoryForCasbin) ListPendingAPIKeys(_ context.Context) ([]*api.PendingAPIKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserRepositoryForCasbin) DeleteUser(_ context.Context, _ string) error {
+	return errors.New("not implemented")
+}
+
 func (m *mockUserRepositoryForCasbin) MarkAsViewed(_ context.Context, _, _ string) error {
 	return errors.New("not implemented")
 }
@@ -198,9 +219,15 @@ func (m *mockSecretsRepositoryForCasbin) GetSecretsByRequestID(_ context.Context
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockSecretsRepositoryForCasbin) RecordSecretUsage(_ context.Context, _, _ string, _ time.Time) error {
+	return errors.New("not implemented")
+}
+
 // mockExecutionRepositoryForCasbin implements database.ExecutionRepository for testing
 type mockExecutionRepositoryForCasbin struct {
-	listExecutionsFunc func(ctx context.Context, limit int, statuses []string) ([]*api.Execution, error)
+	listExecutionsFunc                func(ctx context.Context, limit int, statuses []string) ([]*api.Execution, error)
+	listExecutionsWithExpiredLogsFunc func(ctx context.Context, before time.Time, limit int) ([]*api.Execution, error)
+	clearedLogsExpiry                 []string
 }
 
 func (m *mockExecutionRepositoryForCasbin) ListExecutions(
@@ -211,10 +238,19 @@ func (m *mockExecutionRepositoryForCasbin) ListExecutions(
 	return []*api.Execution{}, nil
 }
 
+func (m *mockExecutionRepositoryForCasbin) ListExecutionsByCreator(
+	_ context.Context, _ string, _ int, _ []string) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockExecutionRepositoryForCasbin) CreateExecution(_ context.Context, _ *api.Execution) error {
 	return errors.New("not implemented")
 }
 
+func (m *mockExecutionRepositoryForCasbin) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return errors.New("not implemented")
+}
+
 func (m *mockExecutionRepositoryForCasbin) GetExecution(_ context.Context, _ string) (*api.Execution, error) {
 	return nil, errors.New("not implemented")
 }
@@ -228,6 +264,57 @@ func (m *mockExecutionRepositoryForCasbin) GetExecutionsByRequestID(
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockExecutionRepositoryForCasbin) GetExecutionsByGroupID(
+	_ context.Context, _ string) ([]*api.Execution, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepositoryForCasbin) ListExecutionsWithExpiredLogs(
+	ctx context.Context, before time.Time, limit int,
+) ([]*api.Execution, error) {
+	if m.listExecutionsWithExpiredLogsFunc != nil {
+		return m.listExecutionsWithExpiredLogsFunc(ctx, before, limit)
+	}
+	return []*api.Execution{}, nil
+}
+
+func (m *mockExecutionRepositoryForCasbin) ClearExecutionLogsExpiry(_ context.Context, executionID string) error {
+	m.clearedLogsExpiry = append(m.clearedLogsExpiry, executionID)
+	return nil
+}
+
+func (m *mockExecutionRepositoryForCasbin) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockExecutionRepositoryForCasbin) AddExecutionAnnotation(
+	_ context.Context, _ string, _ *api.ExecutionAnnotation,
+) error {
+	return errors.New("not implemented")
+}
+
 func TestCapitalizeFirst(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,449 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+const (
+	cleanupActionDeleted         = "deleted"
+	cleanupActionDeregistered    = "deregistered"
+	cleanupActionWouldDelete     = "would_delete"
+	cleanupActionWouldDeregister = "would_deregister"
+	cleanupActionFailed          = "failed"
+)
+
+// orphanedTaskDefinitionGracePeriod protects the task definitions of images being registered,
+// which exist in ECS shortly before their image is stored.
+const orphanedTaskDefinitionGracePeriod = time.Hour
+
+// Cleanup deletes the ECS task definitions and runner log streams created by runvoy that nothing references
+// anymore: images deleted for longer than the retention window are purged, revisions of task definition
+// families no image uses are deregistered, deregistered revisions are deleted, log streams whose events
+// all expired are deleted, and so are the log streams of the executions whose own log retention expired.
+// With dryRun, the resources are only reported. Context and stdin uploads are left to the lifecycle rules of
+// the inputs bucket, which expire them.
+func (m *Manager) Cleanup(ctx context.Context, dryRun bool) (*api.CleanupReport, error) {
+	reqLogger := logger.DeriveRequestLogger(ctx, m.logger)
+	reqLogger.Info("starting resource cleanup", "context", map[string]bool{"dry_run": dryRun})
+
+	now := time.Now()
+	report := &api.CleanupReport{
+		Timestamp: now,
+		DryRun:    dryRun,
+		Resources: []api.CleanedResource{},
+	}
+
+	taskDefs, err := m.cleanupTaskDefinitions(ctx, now, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean up task definitions: %w", err)
+	}
+	report.Resources = append(report.Resources, taskDefs...)
+
+	streams, err := m.cleanupLogStreams(ctx, now, dryRun, reqLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean up log streams: %w", err)
+	}
+	report.Resources = append(report.Resources, streams...)
+
+	executionLogs, err := m.cleanupExpiredExecutionLogs(ctx, now, dryRun, reqLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean up execution logs: %w", err)
+	}
+	report.Resources = append(report.Resources, executionLogs...)
+
+	for _, resource := range report.Resources {
+		switch resource.Action {
+		case cleanupActionDeleted, cleanupActionDeregistered:
+			report.DeletedCount++
+		case cleanupActionFailed:
+			report.FailedCount++
+		}
+	}
+
+	return report, nil
+}
+
+// cleanupTaskDefinitions purges the images deleted for longer than the retention window, deregisters the
+// active revisions of the runvoy task definition families no image references, then deletes the inactive
+// revisions of the runvoy families.
+func (m *Manager) cleanupTaskDefinitions(
+	ctx context.Context,
+	now time.Time,
+	dryRun bool,
+) ([]api.CleanedResource, error) {
+	images, err := m.imageRepo.ListImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	resources, purged := m.purgeDeletedImages(ctx, images, now, dryRun)
+	referencedFamilies := make(map[string]bool, len(images))
+	for i := range images {
+		if !purged[images[i].ImageID] {
+			referencedFamilies[images[i].TaskDefinitionName] = true
+		}
+	}
+
+	active, err := m.listRunvoyTaskDefinitions(ctx, ecsTypes.TaskDefinitionStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	deregistered := 0
+	for _, taskDefARN := range active {
+		if deregistered >= awsConstants.CleanupMaxDeletionsPerResourceType {
+			break
+		}
+		if referencedFamilies[taskDefinitionFamily(taskDefARN)] {
+			continue
+		}
+		resource, ok := m.deregisterOrphanedTaskDefinition(ctx, taskDefARN, now, dryRun)
+		if ok {
+			resources = append(resources, resource)
+			deregistered++
+		}
+	}
+
+	inactive, err := m.listRunvoyTaskDefinitions(ctx, ecsTypes.TaskDefinitionStatusInactive)
+	if err != nil {
+		return nil, err
+	}
+	if len(inactive) > awsConstants.CleanupMaxDeletionsPerResourceType {
+		inactive = inactive[:awsConstants.CleanupMaxDeletionsPerResourceType]
+	}
+	for start := 0; start < len(inactive); start += awsConstants.ECSDeleteTaskDefinitionsMaxBatch {
+		batch := inactive[start:min(start+awsConstants.ECSDeleteTaskDefinitionsMaxBatch, len(inactive))]
+		resources = append(resources, m.deleteInactiveTaskDefinitions(ctx, batch, dryRun)...)
+	}
+
+	return resources, nil
+}
+
+// purgeDeletedImages permanently deletes the images deleted for longer than constants.ImageDeletionRetention,
+// which can no longer be restored. It returns the images purged, or that would be with dryRun, whose task
+// definition families are then no longer referenced.
+func (m *Manager) purgeDeletedImages(
+	ctx context.Context,
+	images []api.ImageInfo,
+	now time.Time,
+	dryRun bool,
+) ([]api.CleanedResource, map[string]bool) {
+	resources := []api.CleanedResource{}
+	purged := make(map[string]bool)
+	for i := range images {
+		image := &images[i]
+		if len(resources) >= awsConstants.CleanupMaxDeletionsPerResourceType {
+			break
+		}
+		if image.DeletedAt == nil || now.Sub(*image.DeletedAt) < constants.ImageDeletionRetention {
+			continue
+		}
+
+		resource := api.CleanedResource{
+			ResourceType: "image",
+			ResourceID:   image.ImageID,
+			Reason:       "image was deleted by " + image.DeletedBy + " and its retention window expired",
+			Action:       cleanupActionWouldDelete,
+		}
+		if !dryRun {
+			resource.Action = cleanupActionDeleted
+			if err := m.imageRepo.DeleteImage(ctx, image.ImageID); err != nil {
+				resource.Action = cleanupActionFailed
+				resource.Error = err.Error()
+			}
+		}
+		if resource.Action != cleanupActionFailed {
+			purged[image.ImageID] = true
+		}
+		resources = append(resources, resource)
+	}
+	return resources, purged
+}
+
+// deregisterOrphanedTaskDefinition deregisters an active revision of a family no image references.
+// It returns false for the revisions registered within the grace period, which are left alone.
+func (m *Manager) deregisterOrphanedTaskDefinition(
+	ctx context.Context,
+	taskDefARN string,
+	now time.Time,
+	dryRun bool,
+) (api.CleanedResource, bool) {
+	resource := api.CleanedResource{
+		ResourceType: "ecs_task_definition",
+		ResourceID:   taskDefARN,
+		Reason:       "task definition family is not referenced by any image",
+	}
+
+	described, err := m.ecsClient.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: awsStd.String(taskDefARN),
+	})
+	if err != nil {
+		resource.Action = cleanupActionFailed
+		resource.Error = err.Error()
+		return resource, true
+	}
+	if described.TaskDefinition != nil && described.TaskDefinition.RegisteredAt != nil &&
+		now.Sub(*described.TaskDefinition.RegisteredAt) < orphanedTaskDefinitionGracePeriod {
+		return resource, false
+	}
+
+	if dryRun {
+		resource.Action = cleanupActionWouldDeregister
+		return resource, true
+	}
+	if _, err = m.ecsClient.DeregisterTaskDefinition(ctx, &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: awsStd.String(taskDefARN),
+	}); err != nil {
+		resource.Action = cleanupActionFailed
+		resource.Error = err.Error()
+		return resource, true
+	}
+	resource.Action = cleanupActionDeregistered
+	return resource, true
+}
+
+// deleteInactiveTaskDefinitions deletes a batch of deregistered revisions with a single call.
+// ECS keeps a revision still used by tasks until they stop.
+func (m *Manager) deleteInactiveTaskDefinitions(
+	ctx context.Context,
+	taskDefARNs []string,
+	dryRun bool,
+) []api.CleanedResource {
+	resources := make([]api.CleanedResource, 0, len(taskDefARNs))
+	action := cleanupActionWouldDelete
+	failures := map[string]string{}
+	if !dryRun {
+		action = cleanupActionDeleted
+		output, err := m.ecsClient.DeleteTaskDefinitions(ctx, &ecs.DeleteTaskDefinitionsInput{
+			TaskDefinitions: taskDefARNs,
+		})
+		if err != nil {
+			for _, taskDefARN := range taskDefARNs {
+				failures[taskDefARN] = err.Error()
+			}
+		} else {
+			for _, failure := range output.Failures {
+				failures[awsStd.ToString(failure.Arn)] = awsStd.ToString(failure.Reason)
+			}
+		}
+	}
+
+	for _, taskDefARN := range taskDefARNs {
+		resource := api.CleanedResource{
+			ResourceType: "ecs_task_definition",
+			ResourceID:   taskDefARN,
+			Reason:       "task definition is deregistered",
+			Action:       action,
+		}
+		if reason, failed := failures[taskDefARN]; failed {
+			resource.Action = cleanupActionFailed
+			resource.Error = reason
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// listRunvoyTaskDefinitions returns the ARNs of the revisions with the status of the runvoy task definition
+// families.
+func (m *Manager) listRunvoyTaskDefinitions(
+	ctx context.Context,
+	status ecsTypes.TaskDefinitionStatus,
+) ([]string, error) {
+	familyPrefix := awsConstants.TaskDefinitionFamilyPrefix + "-"
+	taskDefARNs := []string{}
+
+	var nextToken *string
+	for {
+		// FamilyPrefix only matches whole family names, so the runvoy families are filtered here.
+		listOutput, err := m.ecsClient.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{
+			Status:     status,
+			NextToken:  nextToken,
+			MaxResults: awsStd.Int32(awsConstants.ECSTaskDefinitionMaxResults),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s task definitions: %w", strings.ToLower(string(status)), err)
+		}
+
+		for _, taskDefARN := range listOutput.TaskDefinitionArns {
+			if strings.HasPrefix(taskDefinitionFamily(taskDefARN), familyPrefix) {
+				taskDefARNs = append(taskDefARNs, taskDefARN)
+			}
+		}
+
+		if listOutput.NextToken == nil {
+			return taskDefARNs, nil
+		}
+		nextToken = listOutput.NextToken
+	}
+}
+
+// taskDefinitionFamily returns the family of a task definition ARN
+// (arn:aws:ecs:region:account:task-definition/family:revision).
+func taskDefinitionFamily(taskDefARN string) string {
+	familyWithRev := taskDefARN[strings.LastIndex(taskDefARN, "/")+1:]
+	family, _, _ := strings.Cut(familyWithRev, ":")
+	return family
+}
+
+// cleanupLogStreams deletes the runner log streams whose events all expired with the log group retention:
+// CloudWatch Logs removes expired events but keeps their empty streams forever.
+// Nothing is deleted when the log group keeps its events forever.
+func (m *Manager) cleanupLogStreams(
+	ctx context.Context,
+	now time.Time,
+	dryRun bool,
+	reqLogger *slog.Logger,
+) ([]api.CleanedResource, error) {
+	resources := []api.CleanedResource{}
+	if m.cwlClient == nil || m.cfg.LogGroup == "" {
+		return resources, nil
+	}
+
+	retentionDays, err := m.logGroupRetentionDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if retentionDays == 0 {
+		reqLogger.Debug("skipping log stream cleanup, log group events never expire",
+			"context", map[string]string{"log_group": m.cfg.LogGroup})
+		return resources, nil
+	}
+	expiredBefore := now.AddDate(0, 0, -retentionDays).UnixMilli()
+
+	var nextToken *string
+	for {
+		// Oldest streams first, so that the listing stops at the first stream with unexpired events.
+		output, describeErr := m.cwlClient.DescribeLogStreams(ctx, &cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName: awsStd.String(m.cfg.LogGroup),
+			OrderBy:      cwlTypes.OrderByLastEventTime,
+			Descending:   awsStd.Bool(false),
+			NextToken:    nextToken,
+			Limit:        awsStd.Int32(awsConstants.CloudWatchLogsDescribeLimit),
+		})
+		if describeErr != nil {
+			return nil, fmt.Errorf("failed to describe log streams: %w", describeErr)
+		}
+
+		for i := range output.LogStreams {
+			stream := &output.LogStreams[i]
+			lastEvent := awsStd.ToInt64(stream.LastEventTimestamp)
+			if lastEvent >= expiredBefore {
+				return resources, nil
+			}
+			if lastEvent == 0 && awsStd.ToInt64(stream.CreationTime) >= expiredBefore {
+				// A stream without events yet, created by a task that has just started.
+				continue
+			}
+			resources = append(resources,
+				m.deleteLogStream(ctx, awsStd.ToString(stream.LogStreamName), "all log events expired", dryRun))
+			if len(resources) >= awsConstants.CleanupMaxDeletionsPerResourceType {
+				return resources, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			return resources, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// cleanupExpiredExecutionLogs deletes the runner and sidecar log streams of the completed executions whose own
+// log retention expired, then clears their logs expiry so that they are not listed again.
+func (m *Manager) cleanupExpiredExecutionLogs(
+	ctx context.Context,
+	now time.Time,
+	dryRun bool,
+	reqLogger *slog.Logger,
+) ([]api.CleanedResource, error) {
+	resources := []api.CleanedResource{}
+	if m.cwlClient == nil || m.cfg.LogGroup == "" || m.executionRepo == nil {
+		return resources, nil
+	}
+
+	// Each execution has two log streams.
+	executions, err := m.executionRepo.ListExecutionsWithExpiredLogs(
+		ctx, now, awsConstants.CleanupMaxDeletionsPerResourceType/2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions with expired logs: %w", err)
+	}
+
+	for _, execution := range executions {
+		deleted := true
+		for _, streamName := range []string{
+			awsConstants.BuildLogStreamName(execution.ExecutionID),
+			awsConstants.BuildSidecarLogStreamName(execution.ExecutionID),
+		} {
+			resource := m.deleteLogStream(ctx, streamName, "log retention of the execution expired", dryRun)
+			deleted = deleted && resource.Action == cleanupActionDeleted
+			resources = append(resources, resource)
+		}
+		if !deleted {
+			continue
+		}
+		if clearErr := m.executionRepo.ClearExecutionLogsExpiry(ctx, execution.ExecutionID); clearErr != nil {
+			// The streams are deleted again, as already deleted, by the next cleanup.
+			reqLogger.Warn("failed to clear the logs expiry of the execution", "context", map[string]string{
+				"execution_id": execution.ExecutionID,
+				"error":        clearErr.Error(),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// deleteLogStream deletes a log stream of the log group for the reason. A stream that no longer exists
+// counts as deleted.
+func (m *Manager) deleteLogStream(ctx context.Context, streamName, reason string, dryRun bool) api.CleanedResource {
+	resource := api.CleanedResource{
+		ResourceType: "log_stream",
+		ResourceID:   streamName,
+		Reason:       reason,
+		Action:       cleanupActionWouldDelete,
+	}
+	if dryRun {
+		return resource
+	}
+	var notFound *cwlTypes.ResourceNotFoundException
+	if _, err := m.cwlClient.DeleteLogStream(ctx, &cloudwatchlogs.DeleteLogStreamInput{
+		LogGroupName:  awsStd.String(m.cfg.LogGroup),
+		LogStreamName: awsStd.String(streamName),
+	}); err != nil && !errors.As(err, &notFound) {
+		resource.Action = cleanupActionFailed
+		resource.Error = err.Error()
+		return resource
+	}
+	resource.Action = cleanupActionDeleted
+	return resource
+}
+
+// logGroupRetentionDays returns the retention of the runner log group, 0 when its events never expire.
+func (m *Manager) logGroupRetentionDays(ctx context.Context) (int, error) {
+	output, err := m.cwlClient.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: awsStd.String(m.cfg.LogGroup),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe log group %s: %w", m.cfg.LogGroup, err)
+	}
+	for i := range output.LogGroups {
+		if awsStd.ToString(output.LogGroups[i].LogGroupName) == m.cfg.LogGroup {
+			return int(awsStd.ToInt32(output.LogGroups[i].RetentionInDays)), nil
+		}
+	}
+	return 0, fmt.Errorf("log group %s not found", m.cfg.LogGroup)
+}
@@ -0,0 +1,259 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTaskDefARNPrefix = "arn:aws:ecs:us-east-1:123456789012:task-definition/" +
+	awsConstants.TaskDefinitionFamilyPrefix
+
+// newCleanupTestManager returns a manager whose ECS client lists the active and inactive revisions
+// and records the revisions deregistered and deleted.
+func newCleanupTestManager(
+	active, inactive []string,
+	registeredAt time.Time,
+	cwl *mockCloudWatchLogsClient,
+) (m *Manager, deregistered, deleted *[]string) {
+	deregistered, deleted = &[]string{}, &[]string{}
+	mockECS := &mockECSClient{
+		listTaskDefinitionsFunc: func(
+			_ context.Context,
+			input *ecs.ListTaskDefinitionsInput,
+			_ ...func(*ecs.Options),
+		) (*ecs.ListTaskDefinitionsOutput, error) {
+			if input.Status == ecsTypes.TaskDefinitionStatusInactive {
+				return &ecs.ListTaskDefinitionsOutput{TaskDefinitionArns: inactive}, nil
+			}
+			return &ecs.ListTaskDefinitionsOutput{TaskDefinitionArns: active}, nil
+		},
+		describeTaskDefinitionFunc: func(
+			_ context.Context,
+			_ *ecs.DescribeTaskDefinitionInput,
+			_ ...func(*ecs.Options),
+		) (*ecs.DescribeTaskDefinitionOutput, error) {
+			return &ecs.DescribeTaskDefinitionOutput{
+				TaskDefinition: &ecsTypes.TaskDefinition{RegisteredAt: awsStd.Time(registeredAt)},
+			}, nil
+		},
+		deregisterTaskDefinitionFunc: func(
+			_ context.Context,
+			input *ecs.DeregisterTaskDefinitionInput,
+			_ ...func(*ecs.Options),
+		) (*ecs.DeregisterTaskDefinitionOutput, error) {
+			*deregistered = append(*deregistered, *input.TaskDefinition)
+			return &ecs.DeregisterTaskDefinitionOutput{}, nil
+		},
+		deleteTaskDefinitionsFunc: func(
+			_ context.Context,
+			input *ecs.DeleteTaskDefinitionsInput,
+			_ ...func(*ecs.Options),
+		) (*ecs.DeleteTaskDefinitionsOutput, error) {
+			*deleted = append(*deleted, input.TaskDefinitions...)
+			return &ecs.DeleteTaskDefinitionsOutput{}, nil
+		},
+	}
+
+	m = &Manager{
+		ecsClient: mockECS,
+		imageRepo: &mockImageRepo{images: []api.ImageInfo{
+			{ImageID: "kept", TaskDefinitionName: awsConstants.TaskDefinitionFamilyPrefix + "-kept"},
+		}},
+		cfg:    &Config{LogGroup: "/aws/ecs/runvoy/runner"},
+		logger: testutil.SilentLogger(),
+	}
+	if cwl != nil {
+		m.cwlClient = cwl
+	}
+	return m, deregistered, deleted
+}
+
+func TestCleanupTaskDefinitions(t *testing.T) {
+	active := []string{
+		testTaskDefARNPrefix + "-kept:2",
+		testTaskDefARNPrefix + "-orphan:3",
+		"arn:aws:ecs:us-east-1:123456789012:task-definition/unrelated:1",
+	}
+	inactive := []string{
+		testTaskDefARNPrefix + "-kept:1",
+		"arn:aws:ecs:us-east-1:123456789012:task-definition/unrelated:2",
+	}
+
+	t.Run("deregisters orphaned and deletes inactive revisions", func(t *testing.T) {
+		m, deregistered, deleted := newCleanupTestManager(active, inactive, time.Now().Add(-24*time.Hour), nil)
+
+		report, err := m.Cleanup(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{testTaskDefARNPrefix + "-orphan:3"}, *deregistered)
+		assert.Equal(t, []string{testTaskDefARNPrefix + "-kept:1"}, *deleted)
+		assert.Equal(t, 2, report.DeletedCount)
+		assert.Zero(t, report.FailedCount)
+	})
+
+	t.Run("dry run only reports", func(t *testing.T) {
+		m, deregistered, deleted := newCleanupTestManager(active, inactive, time.Now().Add(-24*time.Hour), nil)
+
+		report, err := m.Cleanup(context.Background(), true)
+
+		require.NoError(t, err)
+		assert.Empty(t, *deregistered)
+		assert.Empty(t, *deleted)
+		assert.True(t, report.DryRun)
+		assert.Zero(t, report.DeletedCount)
+		require.Len(t, report.Resources, 2)
+		assert.Equal(t, cleanupActionWouldDeregister, report.Resources[0].Action)
+		assert.Equal(t, cleanupActionWouldDelete, report.Resources[1].Action)
+	})
+
+	t.Run("purges images deleted past the retention window", func(t *testing.T) {
+		m, deregistered, _ := newCleanupTestManager(active, nil, time.Now().Add(-30*24*time.Hour), nil)
+		expired := time.Now().Add(-constants.ImageDeletionRetention - time.Hour)
+		recent := time.Now().Add(-time.Hour)
+		repo := &mockImageRepo{images: []api.ImageInfo{
+			{
+				ImageID:            "kept",
+				TaskDefinitionName: awsConstants.TaskDefinitionFamilyPrefix + "-kept",
+				DeletedAt:          &expired,
+				DeletedBy:          "user@example.com",
+			},
+			{ImageID: "orphan", TaskDefinitionName: awsConstants.TaskDefinitionFamilyPrefix + "-orphan", DeletedAt: &recent},
+		}}
+		m.imageRepo = repo
+
+		report, err := m.Cleanup(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kept"}, repo.deleted)
+		assert.Equal(t, []string{testTaskDefARNPrefix + "-kept:2"}, *deregistered)
+		require.NotEmpty(t, report.Resources)
+		assert.Equal(t, api.CleanedResource{
+			ResourceType: "image",
+			ResourceID:   "kept",
+			Reason:       "image was deleted by user@example.com and its retention window expired",
+			Action:       cleanupActionDeleted,
+		}, report.Resources[0])
+	})
+
+	t.Run("keeps recently registered orphans", func(t *testing.T) {
+		m, deregistered, _ := newCleanupTestManager(active, nil, time.Now(), nil)
+
+		report, err := m.Cleanup(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Empty(t, *deregistered)
+		assert.Empty(t, report.Resources)
+	})
+}
+
+func TestCleanupLogStreams(t *testing.T) {
+	now := time.Now()
+	expired := now.AddDate(0, 0, -31).UnixMilli()
+	recent := now.Add(-time.Hour).UnixMilli()
+	cwl := &mockCloudWatchLogsClient{
+		logGroups: []cwlTypes.LogGroup{
+			{LogGroupName: awsStd.String("/aws/ecs/runvoy/runner"), RetentionInDays: awsStd.Int32(30)},
+		},
+		logStreams: []cwlTypes.LogStream{
+			{LogStreamName: awsStd.String("task/runner/empty-new"), CreationTime: awsStd.Int64(recent)},
+			{LogStreamName: awsStd.String("task/runner/empty-old"), CreationTime: awsStd.Int64(expired)},
+			{LogStreamName: awsStd.String("task/runner/expired"), LastEventTimestamp: awsStd.Int64(expired)},
+			{LogStreamName: awsStd.String("task/runner/recent"), LastEventTimestamp: awsStd.Int64(recent)},
+			{LogStreamName: awsStd.String("task/runner/after-recent"), LastEventTimestamp: awsStd.Int64(expired)},
+		},
+	}
+	m, _, _ := newCleanupTestManager(nil, nil, now, cwl)
+
+	report, err := m.Cleanup(context.Background(), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task/runner/empty-old", "task/runner/expired"}, cwl.deleted)
+	assert.Equal(t, 2, report.DeletedCount)
+
+	t.Run("log group without retention", func(t *testing.T) {
+		neverExpiring := &mockCloudWatchLogsClient{
+			logGroups:  []cwlTypes.LogGroup{{LogGroupName: awsStd.String("/aws/ecs/runvoy/runner")}},
+			logStreams: cwl.logStreams,
+		}
+		m, _, _ := newCleanupTestManager(nil, nil, now, neverExpiring)
+
+		report, err := m.Cleanup(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Empty(t, neverExpiring.deleted)
+		assert.Empty(t, report.Resources)
+	})
+}
+
+func TestCleanupExpiredExecutionLogs(t *testing.T) {
+	now := time.Now()
+	executions := []*api.Execution{
+		{ExecutionID: "exec-expired", LogRetentionDays: 1},
+		{ExecutionID: "exec-failing", LogRetentionDays: 7},
+	}
+	newManager := func() (*Manager, *mockCloudWatchLogsClient, *mockExecutionRepositoryForCasbin) {
+		cwl := &mockCloudWatchLogsClient{
+			logGroups: []cwlTypes.LogGroup{{LogGroupName: awsStd.String("/aws/ecs/runvoy/runner")}},
+			deleteErrs: map[string]error{
+				"task/sidecar/exec-expired": &cwlTypes.ResourceNotFoundException{},
+				"task/runner/exec-failing":  errors.New("throttled"),
+			},
+		}
+		repo := &mockExecutionRepositoryForCasbin{
+			listExecutionsWithExpiredLogsFunc: func(
+				_ context.Context, before time.Time, limit int,
+			) ([]*api.Execution, error) {
+				assert.Equal(t, now, before)
+				assert.Equal(t, awsConstants.CleanupMaxDeletionsPerResourceType/2, limit)
+				return executions, nil
+			},
+		}
+		m, _, _ := newCleanupTestManager(nil, nil, now, cwl)
+		m.executionRepo = repo
+		return m, cwl, repo
+	}
+
+	t.Run("deletes the log streams of the executions", func(t *testing.T) {
+		m, cwl, repo := newManager()
+
+		resources, err := m.cleanupExpiredExecutionLogs(context.Background(), now, false, testutil.SilentLogger())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"task/runner/exec-expired", "task/sidecar/exec-failing"}, cwl.deleted)
+		assert.Equal(t, []string{"exec-expired"}, repo.clearedLogsExpiry, "executions with streams left are kept")
+		require.Len(t, resources, 4)
+		assert.Equal(t, api.CleanedResource{
+			ResourceType: "log_stream",
+			ResourceID:   "task/sidecar/exec-expired",
+			Reason:       "log retention of the execution expired",
+			Action:       cleanupActionDeleted,
+		}, resources[1])
+		assert.Equal(t, cleanupActionFailed, resources[2].Action)
+	})
+
+	t.Run("dry run only reports", func(t *testing.T) {
+		m, cwl, repo := newManager()
+
+		resources, err := m.cleanupExpiredExecutionLogs(context.Background(), now, true, testutil.SilentLogger())
+
+		require.NoError(t, err)
+		assert.Empty(t, cwl.deleted)
+		assert.Empty(t, repo.clearedLogsExpiry)
+		require.Len(t, resources, 4)
+		assert.Equal(t, cleanupActionWouldDelete, resources[0].Action)
+	})
+}
@@ -146,7 +146,8 @@ func (m *Manager) recreateMissingTaskDefinition(
 	params := m.buildTaskDefParams(img)
 
 	taskDefCfg := &ecsdefs.TaskDefinitionConfig{
-		LogGroup: m.cfg.LogGroup,
+		LogGroup:     m.cfg.LogGroup,
+		ResourceTags: m.cfg.ResourceTags,
 	}
 
 	taskDefARN, recreateErr := ecsdefs.RecreateTaskDefinition(
@@ -322,14 +323,15 @@ func (m *Manager) verifyAndUpdateTaskDefinitionTags(
 	if isDefault {
 		isDefaultPtr = awsStd.Bool(true)
 	}
-	expectedTags := ecsdefs.BuildTaskDefinitionTags(image, isDefaultPtr)
+	expectedTags := ecsdefs.BuildTaskDefinitionTags(image, isDefaultPtr, m.cfg.ResourceTags)
 
 	tagsMatch := m.compareTags(tagsOutput.Tags, expectedTags)
 	if tagsMatch {
 		return false, nil
 	}
 
-	err = ecsdefs.UpdateTaskDefinitionTags(ctx, m.ecsClient, taskDefARN, image, isDefault, reqLogger)
+	err = ecsdefs.UpdateTaskDefinitionTags(ctx, m.ecsClient, taskDefARN, image, isDefault, m.cfg.ResourceTags,
+		reqLogger)
 	if err != nil {
 		return false, fmt.Errorf("failed to update task definition tags: %w", err)
 	}
@@ -359,7 +361,7 @@ func (m *Manager) compareTags(currentTags, expectedTags []ecsTypes.Tag) bool {
 		}
 	}
 
-	standardTags := secrets.GetStandardTags()
+	standardTags := secrets.GetStandardTags(nil)
 	for _, stdTag := range standardTags {
 		if currentMap[stdTag.Key] != stdTag.Value {
 			return false
@@ -90,7 +90,7 @@ func TestBuildTaskDefParamsUsesImageValues(t *testing.T) {
 func TestCompareTags(t *testing.T) {
 	t.Run("matches expected and standard tags", func(t *testing.T) {
 		m := &Manager{}
-		expected := ecsdefs.BuildTaskDefinitionTags("alpine:latest", awsStd.Bool(true))
+		expected := ecsdefs.BuildTaskDefinitionTags("alpine:latest", awsStd.Bool(true), nil)
 
 		current := append([]ecsTypes.Tag{}, expected...)
 		current = append(current, ecsTypes.Tag{Key: awsStd.String("extra"), Value: awsStd.String("value")})
@@ -100,7 +100,7 @@ func TestCompareTags(t *testing.T) {
 
 	t.Run("detects mismatched tags", func(t *testing.T) {
 		m := &Manager{}
-		expected := ecsdefs.BuildTaskDefinitionTags("alpine:latest", nil)
+		expected := ecsdefs.BuildTaskDefinitionTags("alpine:latest", nil, nil)
 
 		// Copy expected tags but alter a standard tag value
 		current := append([]ecsTypes.Tag{}, expected...)
@@ -20,6 +20,7 @@ import (
 // ImageTaskDefRepository defines the interface for image-taskdef mapping operations.
 type ImageTaskDefRepository interface {
 	ListImages(ctx context.Context) ([]api.ImageInfo, error)
+	DeleteImage(ctx context.Context, image string) error
 }
 
 // Manager implements the health.Manager interface for AWS.
@@ -27,6 +28,7 @@ type Manager struct {
 	ecsClient     awsClient.ECSClient
 	ssmClient     secrets.Client
 	iamClient     awsClient.IAMClient
+	cwlClient     awsClient.CloudWatchLogsClient
 	imageRepo     ImageTaskDefRepository
 	secretsRepo   database.SecretsRepository
 	userRepo      database.UserRepository
@@ -45,6 +47,7 @@ type Config struct {
 	DefaultTaskExecRoleARN string
 	LogGroup               string
 	SecretsPrefix          string
+	ResourceTags           map[string]string
 }
 
 // Initialize creates a new AWS health manager.
@@ -52,6 +55,7 @@ func Initialize(
 	ecsClient awsClient.ECSClient,
 	ssmClient secrets.Client,
 	iamClient awsClient.IAMClient,
+	cwlClient awsClient.CloudWatchLogsClient,
 	imageRepo ImageTaskDefRepository,
 	secretsRepo database.SecretsRepository,
 	userRepo database.UserRepository,
@@ -64,6 +68,7 @@ func Initialize(
 		ecsClient:     ecsClient,
 		ssmClient:     ssmClient,
 		iamClient:     iamClient,
+		cwlClient:     cwlClient,
 		imageRepo:     imageRepo,
 		secretsRepo:   secretsRepo,
 		userRepo:      userRepo,
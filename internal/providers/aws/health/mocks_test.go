@@ -6,6 +6,8 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -32,13 +34,19 @@ func (m *mockIAMClient) GetRole(
 }
 
 type mockImageRepo struct {
-	images []api.ImageInfo
+	images  []api.ImageInfo
+	deleted []string
 }
 
 func (m *mockImageRepo) ListImages(_ context.Context) ([]api.ImageInfo, error) {
 	return m.images, nil
 }
 
+func (m *mockImageRepo) DeleteImage(_ context.Context, image string) error {
+	m.deleted = append(m.deleted, image)
+	return nil
+}
+
 type mockSSMClient struct {
 	getParameterFunc func(
 		ctx context.Context,
@@ -149,6 +157,21 @@ type mockECSClient struct {
 		params *ecs.ListTagsForResourceInput,
 		optFns ...func(*ecs.Options),
 	) (*ecs.ListTagsForResourceOutput, error)
+	describeTaskDefinitionFunc func(
+		ctx context.Context,
+		params *ecs.DescribeTaskDefinitionInput,
+		optFns ...func(*ecs.Options),
+	) (*ecs.DescribeTaskDefinitionOutput, error)
+	deregisterTaskDefinitionFunc func(
+		ctx context.Context,
+		params *ecs.DeregisterTaskDefinitionInput,
+		optFns ...func(*ecs.Options),
+	) (*ecs.DeregisterTaskDefinitionOutput, error)
+	deleteTaskDefinitionsFunc func(
+		ctx context.Context,
+		params *ecs.DeleteTaskDefinitionsInput,
+		optFns ...func(*ecs.Options),
+	) (*ecs.DeleteTaskDefinitionsOutput, error)
 }
 
 func (m *mockECSClient) RunTask(
@@ -192,10 +215,13 @@ func (m *mockECSClient) StopTask(
 }
 
 func (m *mockECSClient) DescribeTaskDefinition(
-	_ context.Context,
-	_ *ecs.DescribeTaskDefinitionInput,
-	_ ...func(*ecs.Options),
+	ctx context.Context,
+	params *ecs.DescribeTaskDefinitionInput,
+	optFns ...func(*ecs.Options),
 ) (*ecs.DescribeTaskDefinitionOutput, error) {
+	if m.describeTaskDefinitionFunc != nil {
+		return m.describeTaskDefinitionFunc(ctx, params, optFns...)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -230,18 +256,24 @@ func (m *mockECSClient) RegisterTaskDefinition(
 }
 
 func (m *mockECSClient) DeregisterTaskDefinition(
-	_ context.Context,
-	_ *ecs.DeregisterTaskDefinitionInput,
-	_ ...func(*ecs.Options),
+	ctx context.Context,
+	params *ecs.DeregisterTaskDefinitionInput,
+	optFns ...func(*ecs.Options),
 ) (*ecs.DeregisterTaskDefinitionOutput, error) {
+	if m.deregisterTaskDefinitionFunc != nil {
+		return m.deregisterTaskDefinitionFunc(ctx, params, optFns...)
+	}
 	return nil, errors.New("not implemented")
 }
 
 func (m *mockECSClient) DeleteTaskDefinitions(
-	_ context.Context,
-	_ *ecs.DeleteTaskDefinitionsInput,
-	_ ...func(*ecs.Options),
+	ctx context.Context,
+	params *ecs.DeleteTaskDefinitionsInput,
+	optFns ...func(*ecs.Options),
 ) (*ecs.DeleteTaskDefinitionsOutput, error) {
+	if m.deleteTaskDefinitionsFunc != nil {
+		return m.deleteTaskDefinitionsFunc(ctx, params, optFns...)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -253,6 +285,49 @@ func (m *mockECSClient) UntagResource(
 	return nil, errors.New("not implemented")
 }
 
+type mockCloudWatchLogsClient struct {
+	logGroups  []cwlTypes.LogGroup
+	logStreams []cwlTypes.LogStream
+	deleted    []string
+	deleteErrs map[string]error
+}
+
+func (m *mockCloudWatchLogsClient) DescribeLogStreams(
+	_ context.Context,
+	_ *cloudwatchlogs.DescribeLogStreamsInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return &cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: m.logStreams}, nil
+}
+
+func (m *mockCloudWatchLogsClient) FilterLogEvents(
+	_ context.Context,
+	_ *cloudwatchlogs.FilterLogEventsInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockCloudWatchLogsClient) DescribeLogGroups(
+	_ context.Context,
+	_ *cloudwatchlogs.DescribeLogGroupsInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return &cloudwatchlogs.DescribeLogGroupsOutput{LogGroups: m.logGroups}, nil
+}
+
+func (m *mockCloudWatchLogsClient) DeleteLogStream(
+	_ context.Context,
+	params *cloudwatchlogs.DeleteLogStreamInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	if err := m.deleteErrs[*params.LogStreamName]; err != nil {
+		return nil, err
+	}
+	m.deleted = append(m.deleted, *params.LogStreamName)
+	return &cloudwatchlogs.DeleteLogStreamOutput{}, nil
+}
+
 func sampleImage(customTaskRole, customExecRole string) api.ImageInfo {
 	return api.ImageInfo{
 		ImageID:               "img-1",
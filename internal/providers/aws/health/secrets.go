@@ -140,7 +140,7 @@ func (m *Manager) verifyAndUpdateSecretTags(
 		return false, fmt.Errorf("failed to list tags: %w", err)
 	}
 
-	expectedTags := secrets.GetStandardTags()
+	expectedTags := secrets.GetStandardTags(m.cfg.ResourceTags)
 	expectedTagMap := make(map[string]string)
 	for _, tag := range expectedTags {
 		expectedTagMap[tag.Key] = tag.Value
@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// Client defines the interface for KMS operations used by the KMSKeyManager.
+// This interface makes the code easier to test by allowing mock implementations.
+type Client interface {
+	GenerateDataKey(
+		ctx context.Context,
+		params *kms.GenerateDataKeyInput,
+		optFns ...func(*kms.Options),
+	) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(
+		ctx context.Context,
+		params *kms.DecryptInput,
+		optFns ...func(*kms.Options),
+	) (*kms.DecryptOutput, error)
+}
+
+// ClientAdapter wraps the AWS SDK KMS client to implement Client interface.
+// This allows us to use the real AWS client in production while maintaining testability.
+type ClientAdapter struct {
+	client *kms.Client
+}
+
+// NewClientAdapter creates a new adapter wrapping the AWS SDK KMS client.
+func NewClientAdapter(client *kms.Client) *ClientAdapter {
+	return &ClientAdapter{client: client}
+}
+
+// GenerateDataKey wraps the AWS SDK GenerateDataKey operation.
+func (a *ClientAdapter) GenerateDataKey(
+	ctx context.Context,
+	params *kms.GenerateDataKeyInput,
+	optFns ...func(*kms.Options),
+) (*kms.GenerateDataKeyOutput, error) {
+	result, err := a.client.GenerateDataKey(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return result, nil
+}
+
+// Decrypt wraps the AWS SDK Decrypt operation.
+func (a *ClientAdapter) Decrypt(
+	ctx context.Context,
+	params *kms.DecryptInput,
+	optFns ...func(*kms.Options),
+) (*kms.DecryptOutput, error) {
+	result, err := a.client.Decrypt(ctx, params, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,2 @@
+// Package keys provides the AWS KMS implementation of crypto.KeyManager for runvoy.
+package keys
@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/crypto"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// KMSKeyManager is a crypto.KeyManager wrapping the data keys with an AWS KMS key.
+type KMSKeyManager struct {
+	client Client
+	keyID  string
+}
+
+// NewKMSKeyManager creates a KMSKeyManager wrapping the data keys with the KMS key keyID, a key ARN
+// or an alias. The data keys wrapped by other keys are unwrapped with the key they record, provided
+// the caller is allowed to decrypt with it.
+func NewKMSKeyManager(client Client, keyID string) *KMSKeyManager {
+	return &KMSKeyManager{client: client, keyID: keyID}
+}
+
+// CurrentKeyID returns the KMS key wrapping the new data keys, as configured.
+func (m *KMSKeyManager) CurrentKeyID() string {
+	return m.keyID
+}
+
+// GenerateDataKey returns a new AES-256 data key wrapped by the KMS key.
+func (m *KMSKeyManager) GenerateDataKey(ctx context.Context) (*crypto.DataKey, error) {
+	result, err := m.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(m.keyID),
+		KeySpec: types.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Plaintext) != crypto.DataKeySize || len(result.CiphertextBlob) == 0 {
+		return nil, errors.New("unexpected data key from KMS")
+	}
+
+	return &crypto.DataKey{
+		KeyID:     m.keyID,
+		Plaintext: result.Plaintext,
+		Wrapped:   result.CiphertextBlob,
+	}, nil
+}
+
+// DecryptDataKey unwraps a data key with the KMS key keyID.
+func (m *KMSKeyManager) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	result, err := m.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		var notFound *types.NotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s: %w", crypto.ErrUnknownKey, keyID, err)
+		}
+		return nil, err
+	}
+	return result.Plaintext, nil
+}
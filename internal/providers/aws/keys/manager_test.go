@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/crypto"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyARN = "arn:aws:kms:us-east-1:123456789012:key/abc"
+
+// mockClient is a KMS client "wrapping" the data keys by prefixing them with the key ID.
+type mockClient struct {
+	generateErr error
+	decryptErr  error
+	lastDecrypt *kms.DecryptInput
+}
+
+func (m *mockClient) GenerateDataKey(
+	_ context.Context,
+	params *kms.GenerateDataKeyInput,
+	_ ...func(*kms.Options),
+) (*kms.GenerateDataKeyOutput, error) {
+	if m.generateErr != nil {
+		return nil, m.generateErr
+	}
+	plaintext := bytes.Repeat([]byte{7}, crypto.DataKeySize)
+	return &kms.GenerateDataKeyOutput{
+		KeyId:          params.KeyId,
+		Plaintext:      plaintext,
+		CiphertextBlob: append([]byte(*params.KeyId+":"), plaintext...),
+	}, nil
+}
+
+func (m *mockClient) Decrypt(
+	_ context.Context,
+	params *kms.DecryptInput,
+	_ ...func(*kms.Options),
+) (*kms.DecryptOutput, error) {
+	m.lastDecrypt = params
+	if m.decryptErr != nil {
+		return nil, m.decryptErr
+	}
+	plaintext, ok := bytes.CutPrefix(params.CiphertextBlob, []byte(*params.KeyId+":"))
+	if !ok {
+		return nil, &types.IncorrectKeyException{}
+	}
+	return &kms.DecryptOutput{KeyId: params.KeyId, Plaintext: plaintext}, nil
+}
+
+func TestKMSKeyManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trips through an envelope", func(t *testing.T) {
+		client := &mockClient{}
+		envelope := crypto.NewEnvelope(NewKMSKeyManager(client, testKeyARN))
+
+		ciphertext, err := envelope.Encrypt(ctx, []byte("s3cr3t"), []byte("name"))
+		require.NoError(t, err)
+		keyID, err := crypto.KeyID(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, testKeyARN, keyID)
+
+		plaintext, err := envelope.Decrypt(ctx, ciphertext, []byte("name"))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", string(plaintext))
+		assert.Equal(t, testKeyARN, *client.lastDecrypt.KeyId)
+	})
+
+	t.Run("decrypts with the key recorded in the ciphertext", func(t *testing.T) {
+		client := &mockClient{}
+		ciphertext, err := crypto.NewEnvelope(NewKMSKeyManager(client, testKeyARN)).
+			Encrypt(ctx, []byte("s3cr3t"), nil)
+		require.NoError(t, err)
+
+		rotated := crypto.NewEnvelope(NewKMSKeyManager(client, "alias/new-key"))
+		needsRotation, err := rotated.NeedsRotation(ciphertext)
+		require.NoError(t, err)
+		assert.True(t, needsRotation)
+
+		plaintext, err := rotated.Decrypt(ctx, ciphertext, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", string(plaintext))
+		assert.Equal(t, testKeyARN, *client.lastDecrypt.KeyId)
+	})
+
+	t.Run("handles generate data key error", func(t *testing.T) {
+		m := NewKMSKeyManager(&mockClient{generateErr: errors.New("access denied")}, testKeyARN)
+
+		_, err := m.GenerateDataKey(ctx)
+		assert.ErrorContains(t, err, "access denied")
+	})
+
+	t.Run("reports deleted keys as unknown", func(t *testing.T) {
+		m := NewKMSKeyManager(&mockClient{decryptErr: &types.NotFoundException{}}, testKeyARN)
+
+		_, err := m.DecryptDataKey(ctx, testKeyARN, []byte("wrapped"))
+		assert.ErrorIs(t, err, crypto.ErrUnknownKey)
+	})
+}
@@ -15,10 +15,12 @@ import (
 // NewHandler creates a new Lambda handler with the given service.
 // The request timeout is passed to the router to configure the timeout middleware.
 // It uses algnhsa to adapt the chi router to work with Lambda Function URLs.
+// algnhsa tells binary responses by their Content-Type only, which says nothing of the compression of the
+// JSON responses, so all the responses are sent base64 encoded for the Function URL to decode them.
 func NewHandler(svc *orchestrator.Service, requestTimeout time.Duration, allowedOrigins []string) lambda.Handler {
 	if svc == nil {
 		panic("service is required")
 	}
 	router := server.NewRouter(svc, requestTimeout, allowedOrigins)
-	return algnhsa.New(router.Handler(), nil)
+	return algnhsa.New(router.Handler(), &algnhsa.Options{BinaryContentTypes: []string{"*/*"}})
 }
@@ -1,12 +1,20 @@
 package lambdaapi
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/backend/orchestrator"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHandler_ReturnsLambdaHandler(t *testing.T) {
@@ -21,3 +29,33 @@ func TestNewHandler_PanicsWithNilService(t *testing.T) {
 		NewHandler(nil, time.Second, nil)
 	})
 }
+
+func TestNewHandler_SendsCompressedResponsesBase64Encoded(t *testing.T) {
+	handler := NewHandler(&orchestrator.Service{Logger: slog.New(slog.DiscardHandler)}, 5*time.Second, nil)
+	event := `{
+		"version": "2.0",
+		"rawPath": "/api/v1/users",
+		"headers": {"accept-encoding": "gzip"},
+		"requestContext": {"http": {"method": "GET", "path": "/api/v1/users"}}
+	}`
+
+	payload, err := handler.Invoke(context.Background(), []byte(event))
+	require.NoError(t, err)
+
+	var resp struct {
+		Headers         map[string]string `json:"headers"`
+		Body            string            `json:"body"`
+		IsBase64Encoded bool              `json:"isBase64Encoded"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &resp))
+	assert.True(t, resp.IsBase64Encoded)
+	assert.Equal(t, "gzip", resp.Headers["Content-Encoding"])
+
+	compressed, err := base64.StdEncoding.DecodeString(resp.Body)
+	require.NoError(t, err)
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(body), "body: %s", body)
+}
@@ -0,0 +1,35 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// CreateContextUpload presigns an S3 PUT of a working directory archive of exactly size bytes to the inputs bucket.
+func (t *TaskManagerImpl) CreateContextUpload(
+	ctx context.Context,
+	uploadID string,
+	size int64,
+) (string, time.Time, error) {
+	return t.presignInputUpload(ctx, contextObjectKey(uploadID), size)
+}
+
+func contextObjectKey(uploadID string) string {
+	return awsConstants.ContextObjectKeyPrefix + uploadID
+}
+
+// buildContextEnvironment returns the sidecar environment variable carrying the presigned URL
+// of the working directory archive, if any.
+func buildContextEnvironment(contextURL string) []ecsTypes.KeyValuePair {
+	if contextURL == "" {
+		return nil
+	}
+	return []ecsTypes.KeyValuePair{
+		{Name: awsStd.String("RUNVOY_CONTEXT_URL"), Value: awsStd.String(contextURL)},
+	}
+}
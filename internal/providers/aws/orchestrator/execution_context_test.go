@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateContextUpload(t *testing.T) {
+	presignClient := &mockS3PresignClient{
+		presignPutObjectFunc: func(_ context.Context, params *s3.PutObjectInput) (*v4.PresignedHTTPRequest, error) {
+			assert.Equal(t, "context/abc123", awsStd.ToString(params.Key))
+			assert.Equal(t, int64(2048), awsStd.ToInt64(params.ContentLength))
+			return &v4.PresignedHTTPRequest{URL: "https://upload"}, nil
+		},
+	}
+	tm := newInputsTaskManager(presignClient, "inputs-bucket")
+
+	uploadURL, _, err := tm.CreateContextUpload(context.Background(), "abc123", 2048)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://upload", uploadURL)
+}
+
+func TestBuildContainerOverridesWithContext(t *testing.T) {
+	tm := newInputsTaskManager(nil, "")
+	req := &api.ExecutionRequest{Command: "make test", ContextUploadID: "abc123"}
+
+	overrides, _ := tm.buildContainerOverrides(
+		context.Background(), req, &gitRepoConfig{}, &inputDownloadURLs{Context: "https://download"},
+	)
+
+	require.Len(t, overrides, 2)
+	assert.Equal(t, "https://download", envValue(overrides[0].Environment, "RUNVOY_CONTEXT_URL"))
+	assert.Contains(t, overrides[0].Command[2], `tar -xzf "${CONTEXT_ARCHIVE_PATH}" -C "${CONTEXT_PATH}"`)
+	assert.Contains(t, overrides[1].Command[2], "cd "+awsConstants.ContextDirPath)
+}
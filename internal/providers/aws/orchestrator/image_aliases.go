@@ -0,0 +1,61 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// SetImageAlias points an image alias at an image ID in DynamoDB, creating the alias if needed.
+// Returns the image ID the alias pointed at before, empty when the alias was created.
+func (m *ImageRegistryImpl) SetImageAlias(ctx context.Context, alias *api.ImageAlias) (string, error) {
+	if m.imageRepo == nil {
+		return "", errors.New("image repository not configured")
+	}
+
+	previous, err := m.imageRepo.PutImageAlias(ctx, alias.Alias, alias.ImageID, alias.UpdatedBy, alias.UpdatedAt)
+	if err != nil {
+		return "", fmt.Errorf("failed to put image alias: %w", err)
+	}
+	return previous, nil
+}
+
+// GetImageAlias retrieves an image alias from DynamoDB. Returns nil if the alias doesn't exist.
+func (m *ImageRegistryImpl) GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error) {
+	if m.imageRepo == nil {
+		return nil, errors.New("image repository not configured")
+	}
+
+	imageAlias, err := m.imageRepo.GetImageAlias(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image alias: %w", err)
+	}
+	return imageAlias, nil
+}
+
+// ListImageAliases lists all image aliases from DynamoDB.
+func (m *ImageRegistryImpl) ListImageAliases(ctx context.Context) ([]api.ImageAlias, error) {
+	if m.imageRepo == nil {
+		return nil, errors.New("image repository not configured")
+	}
+
+	aliases, err := m.imageRepo.ListImageAliases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// RemoveImageAlias removes an image alias from DynamoDB.
+func (m *ImageRegistryImpl) RemoveImageAlias(ctx context.Context, alias string) error {
+	if m.imageRepo == nil {
+		return errors.New("image repository not configured")
+	}
+
+	if err := m.imageRepo.DeleteImageAlias(ctx, alias); err != nil {
+		return fmt.Errorf("failed to delete image alias: %w", err)
+	}
+	return nil
+}
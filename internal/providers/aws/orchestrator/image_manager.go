@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
@@ -48,8 +49,9 @@ func NewImageRegistry(
 	}
 }
 
-// RegisterImage registers a Docker image with optional custom IAM roles, CPU, Memory, and RuntimePlatform.
-// Creates a new task definition with a unique family name and stores the mapping in DynamoDB.
+// RegisterImage registers a Docker image with optional custom IAM roles, CPU, Memory, RuntimePlatform
+// log limits and run defaults. Creates a new task definition with a unique family name and stores the mapping
+// in DynamoDB. Registering an existing configuration again updates its log limits and run defaults when provided.
 //
 //nolint:funlen // Complex registration flow with multiple steps
 func (m *ImageRegistryImpl) RegisterImage(
@@ -61,6 +63,9 @@ func (m *ImageRegistryImpl) RegisterImage(
 	cpu *int,
 	memory *int,
 	runtimePlatform *string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
 	createdBy string,
 ) error {
 	if m.ecsClient == nil {
@@ -110,7 +115,7 @@ func (m *ImageRegistryImpl) RegisterImage(
 	if existing != nil {
 		return m.handleExistingImage(
 			ctx, image, isDefault, taskRoleName, taskExecutionRoleName,
-			existing, reqLogger,
+			logLimits, warmPoolSize, runDefaults, existing, reqLogger,
 		)
 	}
 
@@ -118,6 +123,9 @@ func (m *ImageRegistryImpl) RegisterImage(
 		ctx, image, isDefault, taskRoleName, taskExecutionRoleName,
 		region,
 		cpuVal, memoryVal, runtimePlatformVal,
+		logLimits,
+		warmPoolSize,
+		runDefaults,
 		createdBy,
 		reqLogger,
 	)
@@ -194,6 +202,86 @@ func (m *ImageRegistryImpl) GetImage(ctx context.Context, image string) (*api.Im
 	return imageInfo, nil
 }
 
+// SoftDeleteImage marks a Docker image configuration as deleted, which refuses new runs but keeps its
+// task definitions so that it can be restored until it is purged after constants.ImageDeletionRetention.
+// Like RemoveImage, it requires the full ImageID.
+func (m *ImageRegistryImpl) SoftDeleteImage(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+	imageInfo, err := m.getImageByExactID(ctx, image, "image unregister")
+	if err != nil {
+		return nil, err
+	}
+	if imageInfo.DeletedAt != nil {
+		return nil, apperrors.ErrConflict(
+			fmt.Sprintf("image %q is already deleted", image), nil)
+	}
+
+	deletedAt := time.Now().UTC()
+	if markErr := m.imageRepo.MarkImageDeleted(ctx, imageInfo.ImageID, deletedBy, deletedAt); markErr != nil {
+		return nil, fmt.Errorf("failed to mark image as deleted: %w", markErr)
+	}
+
+	logger.DeriveRequestLogger(ctx, m.logger).Info("image deleted", "context", map[string]string{
+		"image_id":   imageInfo.ImageID,
+		"deleted_by": deletedBy,
+	})
+
+	imageInfo.DeletedAt = &deletedAt
+	imageInfo.DeletedBy = deletedBy
+	imageInfo.IsDefault = awsStd.Bool(false)
+	return imageInfo, nil
+}
+
+// RestoreImage clears the deletion mark of a Docker image configuration deleted with SoftDeleteImage.
+// It requires the full ImageID.
+func (m *ImageRegistryImpl) RestoreImage(ctx context.Context, image string) (*api.ImageInfo, error) {
+	imageInfo, err := m.getImageByExactID(ctx, image, "image restore")
+	if err != nil {
+		return nil, err
+	}
+	if imageInfo.DeletedAt == nil {
+		return nil, apperrors.ErrConflict(fmt.Sprintf("image %q is not deleted", image), nil)
+	}
+
+	if restoreErr := m.imageRepo.RestoreImage(ctx, imageInfo.ImageID); restoreErr != nil {
+		return nil, fmt.Errorf("failed to restore image: %w", restoreErr)
+	}
+
+	logger.DeriveRequestLogger(ctx, m.logger).Info("image restored", "context", map[string]string{
+		"image_id": imageInfo.ImageID,
+	})
+
+	imageInfo.DeletedAt = nil
+	imageInfo.DeletedBy = ""
+	return imageInfo, nil
+}
+
+// getImageByExactID retrieves an image configuration by its full ImageID, refusing image names
+// which may match several configurations.
+func (m *ImageRegistryImpl) getImageByExactID(ctx context.Context, image, operation string) (*api.ImageInfo, error) {
+	if m.imageRepo == nil {
+		return nil, errors.New("image repository not configured")
+	}
+	if !looksLikeImageID(image) {
+		return nil, apperrors.ErrBadRequest(
+			fmt.Sprintf(
+				"%s requires exact ImageID (e.g., \"alpine:latest-a1b2c3d4\"). "+
+					"Use 'images list' to find the exact ImageID for %q",
+				operation, image,
+			),
+			nil,
+		)
+	}
+
+	imageInfo, err := m.imageRepo.GetImageTaskDefByID(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image by ImageID: %w", err)
+	}
+	if imageInfo == nil {
+		return nil, apperrors.ErrNotFound("image not found", fmt.Errorf("image %q not found", image))
+	}
+	return imageInfo, nil
+}
+
 // RemoveImage removes a Docker image and all its task definition variants from DynamoDB.
 // It also deregisters all associated task definitions from ECS.
 // If deregistration fails for any task definition, it continues to clean up the remaining ones
@@ -424,6 +512,9 @@ func (m *ImageRegistryImpl) handleExistingImage(
 	image string,
 	isDefault *bool,
 	taskRoleName, taskExecutionRoleName *string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
 	existing *api.ImageInfo,
 	reqLogger *slog.Logger,
 ) error {
@@ -432,6 +523,16 @@ func (m *ImageRegistryImpl) handleExistingImage(
 		"task_definition_family": existing.TaskDefinitionName,
 	})
 
+	// Registering a deleted configuration again restores it, its task definition was kept.
+	if existing.DeletedAt != nil {
+		if restoreErr := m.imageRepo.RestoreImage(ctx, existing.ImageID); restoreErr != nil {
+			return fmt.Errorf("failed to restore image: %w", restoreErr)
+		}
+		reqLogger.Info("deleted image restored by registration", "context", map[string]string{
+			"image_id": existing.ImageID,
+		})
+	}
+
 	shouldBeDefault := isDefault != nil && *isDefault
 	if shouldBeDefault {
 		if setErr := m.imageRepo.SetImageAsOnlyDefault(ctx, image, taskRoleName, taskExecutionRoleName); setErr != nil {
@@ -439,6 +540,28 @@ func (m *ImageRegistryImpl) handleExistingImage(
 		}
 	}
 
+	// The settings are only updated if no other registration updated them since the image was read
+	version := existing.Version
+	if logLimits != nil {
+		if setErr := m.imageRepo.SetImageLogLimits(ctx, existing.ImageID, version, logLimits); setErr != nil {
+			return fmt.Errorf("failed to set image log limits: %w", setErr)
+		}
+		version++
+	}
+
+	if runDefaults != nil {
+		if setErr := m.imageRepo.SetImageRunDefaults(ctx, existing.ImageID, version, runDefaults); setErr != nil {
+			return fmt.Errorf("failed to set image run defaults: %w", setErr)
+		}
+		version++
+	}
+
+	if warmPoolSize != nil {
+		if setErr := m.imageRepo.SetImageWarmPoolSize(ctx, existing.ImageID, version, *warmPoolSize); setErr != nil {
+			return fmt.Errorf("failed to set image warm pool size: %w", setErr)
+		}
+	}
+
 	return nil
 }
 
@@ -455,6 +578,9 @@ func (m *ImageRegistryImpl) registerNewImage(
 	region string,
 	cpu, memory int,
 	runtimePlatform string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
 	createdBy string,
 	reqLogger *slog.Logger,
 ) (taskDefARN, family string, err error) {
@@ -517,10 +643,25 @@ func (m *ImageRegistryImpl) registerNewImage(
 		family,
 		shouldBeDefault,
 		createdBy,
+		logLimits,
 	); putErr != nil {
 		return "", "", fmt.Errorf("failed to store image-taskdef mapping: %w", putErr)
 	}
 
+	var version int64
+	if runDefaults != nil {
+		if setErr := m.imageRepo.SetImageRunDefaults(ctx, imageID, version, runDefaults); setErr != nil {
+			return "", "", fmt.Errorf("failed to set image run defaults: %w", setErr)
+		}
+		version++
+	}
+
+	if warmPoolSize != nil && *warmPoolSize > 0 {
+		if setErr := m.imageRepo.SetImageWarmPoolSize(ctx, imageID, version, *warmPoolSize); setErr != nil {
+			return "", "", fmt.Errorf("failed to set image warm pool size: %w", setErr)
+		}
+	}
+
 	return taskDefARN, family, nil
 }
 
@@ -643,7 +784,7 @@ func (m *ImageRegistryImpl) registerTaskDefinitionWithRoles(
 	if isDefault {
 		isDefaultPtr = awsStd.Bool(true)
 	}
-	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefaultPtr)
+	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefaultPtr, m.cfg.ResourceTags)
 	if len(tags) > 0 {
 		tagLogArgs := []any{
 			"operation", "ECS.TagResource",
@@ -2,8 +2,9 @@ package orchestrator
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
+
+	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
 )
 
 // buildRoleARN constructs a full IAM role ARN from a role name and account ID.
@@ -19,12 +20,7 @@ func buildRoleARN(roleName *string, accountID, _ string) string {
 // ECS task definition family names must match [a-zA-Z0-9_-]+ (no dots or other special chars).
 // Replaces invalid characters (dots, etc.) with hyphens.
 func sanitizeImageIDForTaskDef(imageID string) string {
-	re := regexp.MustCompile(`[^a-zA-Z0-9_-]`)
-	sanitized := re.ReplaceAllString(imageID, "-")
-	re2 := regexp.MustCompile(`-+`)
-	sanitized = re2.ReplaceAllString(sanitized, "-")
-	sanitized = strings.Trim(sanitized, "-")
-	return "runvoy-" + sanitized
+	return dynamodb.TaskDefinitionFamilyForImageID(imageID)
 }
 
 // looksLikeImageID checks if a string looks like an ImageID format.
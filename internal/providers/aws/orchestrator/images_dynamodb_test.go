@@ -2,9 +2,12 @@ package orchestrator
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	awsClient "github.com/runvoy/runvoy/internal/providers/aws/client"
 	"github.com/runvoy/runvoy/internal/testutil"
 
@@ -174,11 +177,19 @@ func TestProvider_BuildRoleARNs(t *testing.T) {
 
 // mockImageRepo is a mock implementation of the image repository for testing
 type mockImageRepo struct {
-	getDefaultImageFunc     func(ctx context.Context) (*api.ImageInfo, error)
-	listImagesFunc          func(ctx context.Context) ([]api.ImageInfo, error)
-	deleteImageFunc         func(ctx context.Context, image string) error
-	getAnyImageTaskDefFunc  func(ctx context.Context, image string) (*api.ImageInfo, error)
-	getImageTaskDefByIDFunc func(ctx context.Context, imageID string) (*api.ImageInfo, error)
+	getDefaultImageFunc      func(ctx context.Context) (*api.ImageInfo, error)
+	listImagesFunc           func(ctx context.Context) ([]api.ImageInfo, error)
+	deleteImageFunc          func(ctx context.Context, image string) error
+	getAnyImageTaskDefFunc   func(ctx context.Context, image string) (*api.ImageInfo, error)
+	getImageTaskDefByIDFunc  func(ctx context.Context, imageID string) (*api.ImageInfo, error)
+	setImageLogLimitsFunc    func(ctx context.Context, imageID string, version int64, limits *api.LogLimits) error
+	setImageWarmPoolSizeFunc func(ctx context.Context, imageID string, version int64, size int) error
+	setImageRunDefaultsFunc  func(ctx context.Context, imageID string, version int64, defaults *api.RunDefaults) error
+	markImageDeletedFunc     func(ctx context.Context, imageID, deletedBy string, deletedAt time.Time) error
+	restoreImageFunc         func(ctx context.Context, imageID string) error
+	putImageAliasFunc        func(ctx context.Context, alias, imageID, updatedBy string, at time.Time) (string, error)
+	getImageAliasFunc        func(ctx context.Context, alias string) (*api.ImageAlias, error)
+	deleteImageAliasFunc     func(ctx context.Context, alias string) error
 }
 
 func (m *mockImageRepo) GetDefaultImage(ctx context.Context) (*api.ImageInfo, error) {
@@ -209,7 +220,34 @@ func (m *mockImageRepo) GetAnyImageTaskDef(ctx context.Context, image string) (*
 }
 
 func (m *mockImageRepo) PutImageTaskDef(
-	_ context.Context, _ string, _, _, _, _ string, _, _ *string, _, _ int, _ string, _ string, _ bool, _ string) error {
+	_ context.Context, _ string, _, _, _, _ string, _, _ *string, _, _ int, _ string, _ string, _ bool, _ string,
+	_ *api.LogLimits,
+) error {
+	return nil
+}
+
+func (m *mockImageRepo) SetImageLogLimits(
+	ctx context.Context, imageID string, version int64, limits *api.LogLimits,
+) error {
+	if m.setImageLogLimitsFunc != nil {
+		return m.setImageLogLimitsFunc(ctx, imageID, version, limits)
+	}
+	return nil
+}
+
+func (m *mockImageRepo) SetImageWarmPoolSize(ctx context.Context, imageID string, version int64, size int) error {
+	if m.setImageWarmPoolSizeFunc != nil {
+		return m.setImageWarmPoolSizeFunc(ctx, imageID, version, size)
+	}
+	return nil
+}
+
+func (m *mockImageRepo) SetImageRunDefaults(
+	ctx context.Context, imageID string, version int64, defaults *api.RunDefaults,
+) error {
+	if m.setImageRunDefaultsFunc != nil {
+		return m.setImageRunDefaultsFunc(ctx, imageID, version, defaults)
+	}
 	return nil
 }
 
@@ -227,6 +265,49 @@ func (m *mockImageRepo) DeleteImage(ctx context.Context, image string) error {
 	return nil
 }
 
+func (m *mockImageRepo) MarkImageDeleted(
+	ctx context.Context, imageID, deletedBy string, deletedAt time.Time,
+) error {
+	if m.markImageDeletedFunc != nil {
+		return m.markImageDeletedFunc(ctx, imageID, deletedBy, deletedAt)
+	}
+	return nil
+}
+
+func (m *mockImageRepo) RestoreImage(ctx context.Context, imageID string) error {
+	if m.restoreImageFunc != nil {
+		return m.restoreImageFunc(ctx, imageID)
+	}
+	return nil
+}
+
+func (m *mockImageRepo) PutImageAlias(
+	ctx context.Context, alias, imageID, updatedBy string, updatedAt time.Time,
+) (string, error) {
+	if m.putImageAliasFunc != nil {
+		return m.putImageAliasFunc(ctx, alias, imageID, updatedBy, updatedAt)
+	}
+	return "", nil
+}
+
+func (m *mockImageRepo) GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error) {
+	if m.getImageAliasFunc != nil {
+		return m.getImageAliasFunc(ctx, alias)
+	}
+	return nil, nil
+}
+
+func (m *mockImageRepo) ListImageAliases(_ context.Context) ([]api.ImageAlias, error) {
+	return []api.ImageAlias{}, nil
+}
+
+func (m *mockImageRepo) DeleteImageAlias(ctx context.Context, alias string) error {
+	if m.deleteImageAliasFunc != nil {
+		return m.deleteImageAliasFunc(ctx, alias)
+	}
+	return nil
+}
+
 func (m *mockImageRepo) UnmarkAllDefaults(_ context.Context) error {
 	return nil
 }
@@ -319,6 +400,94 @@ func TestProvider_DetermineDefaultStatus(t *testing.T) {
 	}
 }
 
+func TestProvider_HandleExistingImage_UpdatesLogLimits(t *testing.T) {
+	ctx := testutil.TestContext()
+	existing := &api.ImageInfo{ImageID: "alpine:latest-a1b2c3d4", Image: "alpine:latest"}
+
+	t.Run("updates log limits when provided", func(t *testing.T) {
+		var updatedID string
+		var updatedLimits *api.LogLimits
+		mockRepo := &mockImageRepo{
+			setImageLogLimitsFunc: func(_ context.Context, imageID string, _ int64, limits *api.LogLimits) error {
+				updatedID = imageID
+				updatedLimits = limits
+				return nil
+			},
+		}
+		manager := &ImageRegistryImpl{imageRepo: mockRepo, logger: testutil.SilentLogger()}
+		limits := &api.LogLimits{MaxLinesPerSecond: 50}
+
+		err := manager.handleExistingImage(
+			ctx, "alpine:latest", nil, nil, nil, limits, nil, nil, existing, testutil.SilentLogger())
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.ImageID, updatedID)
+		assert.Equal(t, limits, updatedLimits)
+	})
+
+	t.Run("keeps log limits when not provided", func(t *testing.T) {
+		mockRepo := &mockImageRepo{
+			setImageLogLimitsFunc: func(_ context.Context, _ string, _ int64, _ *api.LogLimits) error {
+				t.Error("log limits should not be updated")
+				return nil
+			},
+		}
+		manager := &ImageRegistryImpl{imageRepo: mockRepo, logger: testutil.SilentLogger()}
+
+		err := manager.handleExistingImage(
+			ctx, "alpine:latest", nil, nil, nil, nil, nil, nil, existing, testutil.SilentLogger())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("updates the settings at the version read", func(t *testing.T) {
+		read := &api.ImageInfo{ImageID: existing.ImageID, Image: existing.Image, Version: 3}
+		var limitsVersion, runDefaultsVersion, warmPoolVersion int64
+		var runDefaults *api.RunDefaults
+		mockRepo := &mockImageRepo{
+			setImageLogLimitsFunc: func(_ context.Context, _ string, version int64, _ *api.LogLimits) error {
+				limitsVersion = version
+				return nil
+			},
+			setImageRunDefaultsFunc: func(_ context.Context, _ string, version int64, defaults *api.RunDefaults) error {
+				runDefaultsVersion = version
+				runDefaults = defaults
+				return nil
+			},
+			setImageWarmPoolSizeFunc: func(_ context.Context, _ string, version int64, _ int) error {
+				warmPoolVersion = version
+				return nil
+			},
+		}
+		manager := &ImageRegistryImpl{imageRepo: mockRepo, logger: testutil.SilentLogger()}
+		warmPoolSize := 2
+
+		err := manager.handleExistingImage(ctx, "alpine:latest", nil, nil, nil,
+			&api.LogLimits{MaxLinesPerSecond: 50}, &warmPoolSize, &api.RunDefaults{WorkDir: "/app", Shell: "bash"},
+			read, testutil.SilentLogger())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), limitsVersion)
+		assert.Equal(t, int64(4), runDefaultsVersion, "the run defaults are set after the log limits update")
+		assert.Equal(t, int64(5), warmPoolVersion, "the warm pool size is set after the run defaults update")
+		assert.Equal(t, &api.RunDefaults{WorkDir: "/app", Shell: "bash"}, runDefaults)
+	})
+
+	t.Run("fails when the image was modified concurrently", func(t *testing.T) {
+		mockRepo := &mockImageRepo{
+			setImageLogLimitsFunc: func(_ context.Context, _ string, _ int64, _ *api.LogLimits) error {
+				return apperrors.ErrConcurrentModification("image was modified concurrently", nil)
+			},
+		}
+		manager := &ImageRegistryImpl{imageRepo: mockRepo, logger: testutil.SilentLogger()}
+
+		err := manager.handleExistingImage(ctx, "alpine:latest", nil, nil, nil,
+			&api.LogLimits{MaxLinesPerSecond: 50}, nil, nil, existing, testutil.SilentLogger())
+
+		assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+	})
+}
+
 func TestProvider_ListImages(t *testing.T) {
 	ctx := testutil.TestContext()
 
@@ -642,6 +811,112 @@ func TestProvider_RemoveImageHandlesPaginatedTaskDefinitions(t *testing.T) {
 	}, deletedInputs[1])
 }
 
+func TestProvider_SoftDeleteImage(t *testing.T) {
+	ctx := testutil.TestContext()
+	deletedAt := time.Now().UTC()
+	images := map[string]*api.ImageInfo{
+		"alpine:latest-a1b2c3d4": {ImageID: "alpine:latest-a1b2c3d4", Image: "alpine:latest"},
+		"ubuntu:22.04-a1b2c3d4":  {ImageID: "ubuntu:22.04-a1b2c3d4", Image: "ubuntu:22.04", DeletedAt: &deletedAt},
+	}
+
+	tests := []struct {
+		name       string
+		image      string
+		wantMarked bool
+		wantStatus int
+	}{
+		{name: "marks image as deleted", image: "alpine:latest-a1b2c3d4", wantMarked: true},
+		{name: "requires exact ImageID", image: "alpine:latest", wantStatus: http.StatusBadRequest},
+		{name: "image not found", image: "debian:12-a1b2c3d4", wantStatus: http.StatusNotFound},
+		{name: "already deleted", image: "ubuntu:22.04-a1b2c3d4", wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var marked string
+			repo := &mockImageRepo{
+				getImageTaskDefByIDFunc: func(_ context.Context, imageID string) (*api.ImageInfo, error) {
+					if info, ok := images[imageID]; ok {
+						copied := *info
+						return &copied, nil
+					}
+					return nil, nil
+				},
+				markImageDeletedFunc: func(_ context.Context, imageID, deletedBy string, _ time.Time) error {
+					assert.Equal(t, "user@example.com", deletedBy)
+					marked = imageID
+					return nil
+				},
+			}
+			manager := &ImageRegistryImpl{imageRepo: repo, logger: testutil.SilentLogger()}
+
+			info, err := manager.SoftDeleteImage(ctx, tt.image, "user@example.com")
+
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantStatus, apperrors.GetStatusCode(err))
+				assert.Empty(t, marked)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.image, marked)
+			require.NotNil(t, info.DeletedAt)
+			assert.Equal(t, "user@example.com", info.DeletedBy)
+		})
+	}
+}
+
+func TestProvider_RestoreImage(t *testing.T) {
+	ctx := testutil.TestContext()
+	deletedAt := time.Now().UTC()
+
+	var restored string
+	repo := &mockImageRepo{
+		getImageTaskDefByIDFunc: func(_ context.Context, imageID string) (*api.ImageInfo, error) {
+			info := &api.ImageInfo{ImageID: imageID, Image: "alpine:latest"}
+			if imageID == "alpine:latest-a1b2c3d4" {
+				info.DeletedAt, info.DeletedBy = &deletedAt, "user@example.com"
+			}
+			return info, nil
+		},
+		restoreImageFunc: func(_ context.Context, imageID string) error {
+			restored = imageID
+			return nil
+		},
+	}
+	manager := &ImageRegistryImpl{imageRepo: repo, logger: testutil.SilentLogger()}
+
+	info, err := manager.RestoreImage(ctx, "alpine:latest-a1b2c3d4")
+	require.NoError(t, err)
+	assert.Equal(t, "alpine:latest-a1b2c3d4", restored)
+	assert.Nil(t, info.DeletedAt)
+	assert.Empty(t, info.DeletedBy)
+
+	_, err = manager.RestoreImage(ctx, "ubuntu:22.04-a1b2c3d4")
+	assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+}
+
+func TestProvider_RegisterImageRestoresDeletedImage(t *testing.T) {
+	deletedAt := time.Now().UTC()
+	var restored string
+	repo := &mockImageRepo{
+		restoreImageFunc: func(_ context.Context, imageID string) error {
+			restored = imageID
+			return nil
+		},
+	}
+	manager := &ImageRegistryImpl{imageRepo: repo, logger: testutil.SilentLogger()}
+
+	err := manager.handleExistingImage(
+		testutil.TestContext(), "alpine:latest", nil, nil, nil, nil, nil, nil,
+		&api.ImageInfo{ImageID: "alpine:latest-a1b2c3d4", DeletedAt: &deletedAt},
+		testutil.SilentLogger(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "alpine:latest-a1b2c3d4", restored)
+}
+
 func TestProvider_GetImage(t *testing.T) {
 	ctx := testutil.TestContext()
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/runvoy/runvoy/internal/auth/authorization"
 	"github.com/runvoy/runvoy/internal/backend/contract"
@@ -17,14 +18,17 @@ import (
 	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
 	awsHealth "github.com/runvoy/runvoy/internal/providers/aws/health"
 	"github.com/runvoy/runvoy/internal/providers/aws/identity"
+	"github.com/runvoy/runvoy/internal/providers/aws/keys"
 	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
 	awsWebsocket "github.com/runvoy/runvoy/internal/providers/aws/websocket"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"golang.org/x/sync/errgroup"
 )
 
 // Dependencies bundles the AWS-backed implementations required by the app service.
@@ -40,7 +44,13 @@ type Dependencies struct {
 	ObservabilityManager contract.ObservabilityManager
 	WebSocketManager     contract.WebSocketManager
 	SecretsRepo          database.SecretsRepository
+	HealthReportRepo     database.HealthReportRepository
+	CommandPolicyRepo    database.CommandPolicyRepository
+	ConfigRepo           database.ConfigRepository
+	LockRepo             database.LockRepository
 	HealthManager        contract.HealthManager
+	QueryStats           *database.QueryStats
+	Metrics              contract.MetricsRecorder
 }
 
 // Initialize prepares AWS service dependencies for the app package.
@@ -62,7 +72,7 @@ func Initialize(
 		return nil, err
 	}
 
-	repos := awsDatabase.CreateRepositories(clients.dynamo, clients.ssm, cfg, log)
+	repos := awsDatabase.CreateRepositories(clients.dynamo, clients.ssm, clients.kms, cfg, log)
 	providerCfg := buildProviderConfig(cfg, clients.accountID)
 
 	managers := buildManagers(clients, repos, providerCfg, enforcer, log, cfg)
@@ -79,7 +89,13 @@ func Initialize(
 		ObservabilityManager: managers.observabilityManager,
 		WebSocketManager:     managers.wsManager,
 		SecretsRepo:          repos.SecretsRepo,
+		HealthReportRepo:     repos.HealthReportRepo,
+		CommandPolicyRepo:    repos.CommandPolicyRepo,
+		ConfigRepo:           repos.ConfigRepo,
+		LockRepo:             repos.LockRepo,
 		HealthManager:        managers.healthManager,
+		QueryStats:           clients.queryStats,
+		Metrics:              NewEMFMetricsRecorder(os.Stdout, cfg.AWS.MetricsNamespace, log),
 	}, nil
 }
 
@@ -92,9 +108,15 @@ type awsClients struct {
 	dynamo    dynamoRepo.Client
 	ecs       awsClient.ECSClient
 	ssm       secrets.Client
+	kms       keys.Client
 	cwl       awsClient.CloudWatchLogsClient
 	iam       awsClient.IAMClient
+	s3Presign awsClient.S3PresignClient
+	s3        awsClient.S3Client
 	accountID string
+
+	// queryStats aggregates the DynamoDB operations of the repositories.
+	queryStats *database.QueryStats
 }
 
 type managerSet struct {
@@ -119,6 +141,7 @@ func validateConfig(cfg *config.Config) error {
 	return nil
 }
 
+// buildAWSClients constructs the SDK clients while the account ID, the only network call, is looked up.
 func buildAWSClients(ctx context.Context, cfg *config.Config, log *slog.Logger) (*awsClients, error) {
 	factory := clientFactory{cfg: cfg, log: log}
 
@@ -126,25 +149,45 @@ func buildAWSClients(ctx context.Context, cfg *config.Config, log *slog.Logger)
 		return nil, err
 	}
 
-	accountID, err := identity.GetAccountID(ctx, cfg.AWS.SDKConfig, log)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get AWS account ID: %w", err)
-	}
+	var accountID string
+	g, egCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		id, err := identity.GetAccountID(egCtx, cfg.AWS.SDKConfig, log)
+		if err != nil {
+			return fmt.Errorf("failed to get AWS account ID: %w", err)
+		}
+		accountID = id
+		return nil
+	})
 
-	dynamoSDKClient := dynamodb.NewFromConfig(*cfg.AWS.SDKConfig)
+	dynamoSDKClient := dynamoRepo.NewSDKClient(*cfg.AWS.SDKConfig)
 	ecsSDKClient := ecs.NewFromConfig(*cfg.AWS.SDKConfig)
 	ssmSDKClient := ssm.NewFromConfig(*cfg.AWS.SDKConfig)
+	kmsSDKClient := kms.NewFromConfig(*cfg.AWS.SDKConfig)
 	cwlSDKClient := cloudwatchlogs.NewFromConfig(*cfg.AWS.SDKConfig)
 	iamSDKClient := iam.NewFromConfig(*cfg.AWS.SDKConfig)
+	s3SDKClient := s3.NewFromConfig(*cfg.AWS.SDKConfig)
+	s3PresignSDKClient := s3.NewPresignClient(s3SDKClient)
+	queryStats := database.NewQueryStats(cfg.SlowQueryThreshold)
 
-	return &awsClients{
-		dynamo:    dynamoRepo.NewClientAdapter(dynamoSDKClient),
+	clients := &awsClients{
+		dynamo:    dynamoRepo.NewInstrumentedClient(dynamoRepo.NewClientAdapter(dynamoSDKClient), queryStats, log),
 		ecs:       awsClient.NewECSClientAdapter(ecsSDKClient),
 		ssm:       secrets.NewClientAdapter(ssmSDKClient),
+		kms:       keys.NewClientAdapter(kmsSDKClient),
 		cwl:       awsClient.NewCloudWatchLogsClientAdapter(cwlSDKClient),
 		iam:       awsClient.NewIAMClientAdapter(iamSDKClient),
-		accountID: accountID,
-	}, nil
+		s3Presign: awsClient.NewS3PresignClientAdapter(s3PresignSDKClient),
+		s3:        awsClient.NewS3ClientAdapter(s3SDKClient),
+
+		queryStats: queryStats,
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	clients.accountID = accountID
+	return clients, nil
 }
 
 func (f *clientFactory) loadSDKConfig(ctx context.Context) error {
@@ -169,6 +212,9 @@ func buildProviderConfig(cfg *config.Config, accountID string) *Config {
 		DefaultTaskRoleARN:     cfg.AWS.DefaultTaskRoleARN,
 		Region:                 cfg.AWS.SDKConfig.Region,
 		AccountID:              accountID,
+		InputsBucket:           cfg.AWS.InputsBucket,
+		RunnerInitURL:          cfg.AWS.RunnerInitURL,
+		ResourceTags:           cfg.ResourceTags,
 		SDKConfig:              cfg.AWS.SDKConfig,
 	}
 }
@@ -181,15 +227,17 @@ func buildManagers(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *managerSet {
-	taskManager := NewTaskManager(clients.ecs, repos.ImageTaskDefRepo, providerCfg, log)
+	taskManager := NewTaskManager(clients.ecs, clients.s3Presign, repos.ImageTaskDefRepo, providerCfg, log)
+	taskManager.s3Client = clients.s3
 	imageRegistry := NewImageRegistry(clients.ecs, clients.iam, repos.ImageTaskDefRepo, providerCfg, log)
 	logManager := NewLogManager(clients.cwl, providerCfg, log)
 	observabilityLogGroups := []string{
 		cfg.AWS.OrchestratorLogGroup,
 		cfg.AWS.EventProcessorLogGroup,
 	}
-	observabilityManager := NewObservabilityManager(clients.cwl, log, observabilityLogGroups)
-	wsManager := awsWebsocket.Initialize(cfg, repos.ConnectionRepo, repos.TokenRepo, repos.LogEventRepo, log)
+	observabilityManager := NewObservabilityManager(clients.cwl, log, observabilityLogGroups, cfg.AWS.ECSCluster)
+	// The orchestrator only generates WebSocket URLs, messages are fanned out by the event processor.
+	wsManager := awsWebsocket.Initialize(cfg, repos.ConnectionRepo, repos.TokenRepo, repos.LogEventRepo, nil, log)
 
 	healthCfg := &awsHealth.Config{
 		Region:                 cfg.AWS.SDKConfig.Region,
@@ -198,11 +246,13 @@ func buildManagers(
 		DefaultTaskExecRoleARN: cfg.AWS.DefaultTaskExecRoleARN,
 		LogGroup:               cfg.AWS.LogGroup,
 		SecretsPrefix:          cfg.AWS.SecretsPrefix,
+		ResourceTags:           cfg.ResourceTags,
 	}
 	healthManager := awsHealth.Initialize(
 		clients.ecs,
 		clients.ssm,
 		clients.iam,
+		clients.cwl,
 		repos.ImageTaskDefRepo,
 		repos.SecretsRepo,
 		repos.UserRepo,
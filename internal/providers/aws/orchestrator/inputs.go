@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// inputDownloadURLs holds the presigned URLs the sidecar downloads the uploaded inputs of an execution from.
+type inputDownloadURLs struct {
+	Stdin   string
+	Context string
+}
+
+// resolveInputDownloadURLs presigns the downloads of the inputs referenced by the request.
+// URLs are left empty for inputs the request does not reference.
+func (t *TaskManagerImpl) resolveInputDownloadURLs(
+	ctx context.Context, req *api.ExecutionRequest,
+) (*inputDownloadURLs, error) {
+	urls := &inputDownloadURLs{}
+	var err error
+	if req.StdinUploadID != "" {
+		if urls.Stdin, err = t.presignInputDownload(ctx, stdinObjectKey(req.StdinUploadID)); err != nil {
+			return nil, err
+		}
+	}
+	if req.ContextUploadID != "" {
+		if urls.Context, err = t.presignInputDownload(ctx, contextObjectKey(req.ContextUploadID)); err != nil {
+			return nil, err
+		}
+	}
+	return urls, nil
+}
+
+// presignInputUpload presigns an S3 PUT of exactly size bytes to key in the inputs bucket.
+// Objects are removed by the bucket lifecycle rules, whether or not an execution used them.
+func (t *TaskManagerImpl) presignInputUpload(ctx context.Context, key string, size int64) (string, time.Time, error) {
+	if err := t.checkInputUploadsConfigured(); err != nil {
+		return "", time.Time{}, err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, t.logger)
+	logAWSAPICall(ctx, reqLogger, "s3.PresignPutObject", map[string]any{
+		"bucket": t.cfg.InputsBucket,
+		"key":    key,
+		"size":   size,
+	})
+
+	expiresAt := time.Now().UTC().Add(awsConstants.InputUploadURLExpiry)
+	presigned, err := t.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        awsStd.String(t.cfg.InputsBucket),
+		Key:           awsStd.String(key),
+		ContentLength: awsStd.Int64(size),
+	}, s3.WithPresignExpires(awsConstants.InputUploadURLExpiry))
+	if err != nil {
+		return "", time.Time{}, appErrors.ErrInternalError("failed to presign input upload", err)
+	}
+
+	return presigned.URL, expiresAt, nil
+}
+
+// presignInputDownload presigns the S3 GET the sidecar downloads an uploaded input from.
+func (t *TaskManagerImpl) presignInputDownload(ctx context.Context, key string) (string, error) {
+	if err := t.checkInputUploadsConfigured(); err != nil {
+		return "", err
+	}
+
+	reqLogger := logger.DeriveRequestLogger(ctx, t.logger)
+	logAWSAPICall(ctx, reqLogger, "s3.PresignGetObject", map[string]any{
+		"bucket": t.cfg.InputsBucket,
+		"key":    key,
+	})
+
+	presigned, err := t.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: awsStd.String(t.cfg.InputsBucket),
+		Key:    awsStd.String(key),
+	}, s3.WithPresignExpires(awsConstants.InputDownloadURLExpiry))
+	if err != nil {
+		return "", appErrors.ErrInternalError("failed to presign input download", err)
+	}
+
+	return presigned.URL, nil
+}
+
+func (t *TaskManagerImpl) checkInputUploadsConfigured() error {
+	if t.presignClient == nil || t.cfg.InputsBucket == "" {
+		return appErrors.ErrServiceUnavailable("execution input uploads are not configured", nil)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockS3PresignClient struct {
+	presignPutObjectFunc func(context.Context, *s3.PutObjectInput) (*v4.PresignedHTTPRequest, error)
+	presignGetObjectFunc func(context.Context, *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error)
+}
+
+func (m *mockS3PresignClient) PresignPutObject(
+	ctx context.Context,
+	params *s3.PutObjectInput,
+	_ ...func(*s3.PresignOptions),
+) (*v4.PresignedHTTPRequest, error) {
+	if m.presignPutObjectFunc != nil {
+		return m.presignPutObjectFunc(ctx, params)
+	}
+	return &v4.PresignedHTTPRequest{URL: "https://bucket.s3.amazonaws.com/put"}, nil
+}
+
+func (m *mockS3PresignClient) PresignGetObject(
+	ctx context.Context,
+	params *s3.GetObjectInput,
+	_ ...func(*s3.PresignOptions),
+) (*v4.PresignedHTTPRequest, error) {
+	if m.presignGetObjectFunc != nil {
+		return m.presignGetObjectFunc(ctx, params)
+	}
+	return &v4.PresignedHTTPRequest{URL: "https://bucket.s3.amazonaws.com/get"}, nil
+}
+
+func newInputsTaskManager(presignClient *mockS3PresignClient, bucket string) *TaskManagerImpl {
+	tm := &TaskManagerImpl{
+		cfg:    &Config{InputsBucket: bucket},
+		logger: testutil.SilentLogger(),
+	}
+	if presignClient != nil {
+		tm.presignClient = presignClient
+	}
+	return tm
+}
+
+func TestResolveInputDownloadURLs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty without uploads", func(t *testing.T) {
+		tm := newInputsTaskManager(nil, "")
+
+		urls, err := tm.resolveInputDownloadURLs(ctx, &api.ExecutionRequest{Stdin: "aGVsbG8="})
+
+		require.NoError(t, err)
+		assert.Empty(t, urls.Stdin)
+		assert.Empty(t, urls.Context)
+	})
+
+	t.Run("presigns a get of each upload", func(t *testing.T) {
+		presignClient := &mockS3PresignClient{
+			presignGetObjectFunc: func(_ context.Context, params *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error) {
+				assert.Equal(t, "inputs-bucket", awsStd.ToString(params.Bucket))
+				return &v4.PresignedHTTPRequest{URL: "https://download/" + awsStd.ToString(params.Key)}, nil
+			},
+		}
+		tm := newInputsTaskManager(presignClient, "inputs-bucket")
+
+		urls, err := tm.resolveInputDownloadURLs(ctx, &api.ExecutionRequest{
+			StdinUploadID:   "abc123",
+			ContextUploadID: "def456",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://download/stdin/abc123", urls.Stdin)
+		assert.Equal(t, "https://download/context/def456", urls.Context)
+	})
+
+	t.Run("fails when uploads are not configured", func(t *testing.T) {
+		tm := newInputsTaskManager(nil, "")
+
+		_, err := tm.resolveInputDownloadURLs(ctx, &api.ExecutionRequest{ContextUploadID: "def456"})
+
+		assert.Equal(t, appErrors.ErrCodeServiceUnavailable, appErrors.GetErrorCode(err))
+	})
+}
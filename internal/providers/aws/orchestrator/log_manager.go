@@ -33,12 +33,6 @@ func NewLogManager(
 	}
 }
 
-// buildSidecarLogStreamName constructs a CloudWatch Logs stream name for the sidecar container.
-// Format: task/sidecar/{execution_id}.
-func buildSidecarLogStreamName(executionID string) string {
-	return "task/" + awsConstants.SidecarContainerName + "/" + executionID
-}
-
 // FetchLogsByExecutionID returns CloudWatch log events for the given execution ID.
 // It fetches logs from both the runner and sidecar containers.
 // Events are returned sorted by timestamp (AWS FilterLogEvents returns events sorted).
@@ -51,7 +45,7 @@ func (l *LogManagerImpl) FetchLogsByExecutionID(ctx context.Context, executionID
 	var (
 		reqLogger     = logger.DeriveRequestLogger(ctx, l.logger)
 		runnerStream  = awsConstants.BuildLogStreamName(executionID)
-		sidecarStream = buildSidecarLogStreamName(executionID)
+		sidecarStream = awsConstants.BuildSidecarLogStreamName(executionID)
 	)
 
 	// Verify both streams exist (both are required)
@@ -15,6 +15,7 @@ import (
 	"github.com/runvoy/runvoy/internal/logger"
 	awsClient "github.com/runvoy/runvoy/internal/providers/aws/client"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
@@ -43,7 +44,7 @@ func verifyLogStreamExists(
 		Limit:               aws.Int32(awsConstants.CloudWatchLogsDescribeLimit),
 	})
 	if err != nil {
-		return appErrors.ErrInternalError("failed to describe log streams", err)
+		return sdkerrors.Map(err, "failed to describe log streams", appErrors.ErrInternalError)
 	}
 
 	if !slices.ContainsFunc(lsOut.LogStreams, func(s cwlTypes.LogStream) bool {
@@ -119,7 +120,7 @@ func getAllLogEvents(
 			if errors.As(err, &rte) {
 				break
 			}
-			return nil, appErrors.ErrInternalError("failed to filter log events", err)
+			return nil, sdkerrors.Map(err, "failed to filter log events", appErrors.ErrInternalError)
 		}
 		for _, e := range out.Events {
 			events = append(events, buildLogEventFromFilteredEvent(ctx, reqLogger, e))
@@ -57,6 +57,22 @@ func (m *mockCloudWatchLogsClient) FilterLogEvents(
 	return &cloudwatchlogs.FilterLogEventsOutput{}, nil
 }
 
+func (m *mockCloudWatchLogsClient) DescribeLogGroups(
+	_ context.Context,
+	_ *cloudwatchlogs.DescribeLogGroupsInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return &cloudwatchlogs.DescribeLogGroupsOutput{}, nil
+}
+
+func (m *mockCloudWatchLogsClient) DeleteLogStream(
+	_ context.Context,
+	_ *cloudwatchlogs.DeleteLogStreamInput,
+	_ ...func(*cloudwatchlogs.Options),
+) (*cloudwatchlogs.DeleteLogStreamOutput, error) {
+	return &cloudwatchlogs.DeleteLogStreamOutput{}, nil
+}
+
 func TestVerifyLogStreamExists(t *testing.T) {
 	ctx := context.Background()
 	logGroup := "test-log-group"
@@ -391,7 +407,7 @@ func TestBuildLogStreamName(t *testing.T) {
 
 func TestBuildSidecarLogStreamName(t *testing.T) {
 	executionID := "test-exec-123"
-	stream := buildSidecarLogStreamName(executionID)
+	stream := awsConstants.BuildSidecarLogStreamName(executionID)
 	assert.Equal(t, "task/sidecar/test-exec-123", stream)
 	assert.Contains(t, stream, executionID)
 	assert.Contains(t, stream, awsConstants.SidecarContainerName)
@@ -511,7 +527,7 @@ func TestFetchLogsByExecutionID(t *testing.T) {
 	logGroup := "test-log-group"
 	executionID := "exec-123"
 	runnerStream := awsConstants.BuildLogStreamName(executionID)
-	sidecarStream := buildSidecarLogStreamName(executionID)
+	sidecarStream := awsConstants.BuildSidecarLogStreamName(executionID)
 
 	createLogManager := func(mock *mockCloudWatchLogsClient) *LogManagerImpl {
 		return &LogManagerImpl{
@@ -865,7 +881,8 @@ func TestFetchBackendLogs(t *testing.T) {
 	t.Run("successful fetch returns sorted events from all groups", func(t *testing.T) {
 		groupA := "/aws/lambda/runvoy-orchestrator"
 		groupB := "/aws/lambda/runvoy-processor"
-		expectedPattern := fmt.Sprintf("{ $.%s = %q }", constants.RequestIDLogField, requestID)
+		expectedPattern := fmt.Sprintf("{ ($.%s = %q) || ($.%s = %q) }",
+			constants.RequestIDLogField, requestID, constants.OriginRequestIDLogField, requestID)
 		var expectedStartMillis int64
 		var expectedEndMillis int64
 		paginated := false
@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/logger"
+)
+
+// EMFMetricsRecorder implements the contract.MetricsRecorder interface with the CloudWatch
+// embedded metric format: each metric is written as a JSON document to the function output,
+// and CloudWatch Logs extracts it into a metric of the configured namespace.
+// It needs no API call, so recording never slows down event processing.
+type EMFMetricsRecorder struct {
+	writer    io.Writer
+	namespace string
+	logger    *slog.Logger
+	nowFn     func() time.Time
+	mu        sync.Mutex
+}
+
+// NewEMFMetricsRecorder creates a metrics recorder writing EMF documents to writer,
+// which is the process standard output on Lambda.
+func NewEMFMetricsRecorder(writer io.Writer, namespace string, log *slog.Logger) *EMFMetricsRecorder {
+	return &EMFMetricsRecorder{
+		writer:    writer,
+		namespace: namespace,
+		logger:    log,
+		nowFn:     time.Now,
+	}
+}
+
+type emfMetricDefinition struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit,omitempty"`
+}
+
+type emfDirective struct {
+	Namespace  string                `json:"Namespace"`
+	Dimensions [][]string            `json:"Dimensions"`
+	Metrics    []emfMetricDefinition `json:"Metrics"`
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+// RecordMetrics writes one EMF document per metric.
+func (r *EMFMetricsRecorder) RecordMetrics(ctx context.Context, metrics ...contract.Metric) {
+	timestamp := r.nowFn().UnixMilli()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range metrics {
+		document, err := r.marshalMetric(timestamp, &metrics[i])
+		if err == nil {
+			_, err = r.writer.Write(append(document, '\n'))
+		}
+		if err != nil {
+			logger.DeriveRequestLogger(ctx, r.logger).Warn("failed to record metric",
+				"error", err, "metric", metrics[i].Name)
+		}
+	}
+}
+
+func (r *EMFMetricsRecorder) marshalMetric(timestamp int64, metric *contract.Metric) ([]byte, error) {
+	dimensionNames := make([]string, 0, len(metric.Dimensions))
+	document := make(map[string]any, len(metric.Dimensions)+2)
+	for name, value := range metric.Dimensions {
+		dimensionNames = append(dimensionNames, name)
+		document[name] = value
+	}
+	slices.Sort(dimensionNames)
+
+	document[metric.Name] = metric.Value
+	document["_aws"] = emfMetadata{
+		Timestamp: timestamp,
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  r.namespace,
+			Dimensions: [][]string{dimensionNames},
+			Metrics:    []emfMetricDefinition{{Name: metric.Name, Unit: string(metric.Unit)}},
+		}},
+	}
+
+	return json.Marshal(document)
+}
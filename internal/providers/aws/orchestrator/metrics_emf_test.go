@@ -0,0 +1,55 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEMFMetricsRecorder_RecordMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewEMFMetricsRecorder(&buf, "runvoy-test", testutil.SilentLogger())
+	recorder.nowFn = func() time.Time { return time.UnixMilli(1760000000000) }
+
+	recorder.RecordMetrics(context.Background(),
+		contract.Metric{
+			Name:       "EventsProcessed",
+			Value:      1,
+			Unit:       contract.MetricUnitCount,
+			Dimensions: map[string]string{"Outcome": "success", "EventType": "logs"},
+		},
+		contract.Metric{Name: "CompletionLatency", Value: 1500, Unit: contract.MetricUnitMilliseconds},
+	)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "logs", first["EventType"])
+	assert.Equal(t, "success", first["Outcome"])
+	assert.InDelta(t, 1, first["EventsProcessed"], 0)
+	assert.Equal(t, map[string]any{
+		"Timestamp": float64(1760000000000),
+		"CloudWatchMetrics": []any{map[string]any{
+			"Namespace":  "runvoy-test",
+			"Dimensions": []any{[]any{"EventType", "Outcome"}},
+			"Metrics":    []any{map[string]any{"Name": "EventsProcessed", "Unit": "Count"}},
+		}},
+	}, first["_aws"])
+
+	var second map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.InDelta(t, 1500, second["CompletionLatency"], 0)
+	directive := second["_aws"].(map[string]any)["CloudWatchMetrics"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{[]any{}}, directive["Dimensions"])
+}
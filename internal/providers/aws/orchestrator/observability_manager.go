@@ -18,33 +18,43 @@ import (
 	"github.com/runvoy/runvoy/internal/logger"
 	awsClient "github.com/runvoy/runvoy/internal/providers/aws/client"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
 )
 
 // ObservabilityManagerImpl implements the ObservabilityManager interface for AWS CloudWatch Logs.
-// It handles retrieving backend infrastructure logs for debugging and tracing.
+// It handles retrieving backend infrastructure logs for debugging and tracing,
+// and the resource usage of the running tasks reported by Container Insights.
 type ObservabilityManagerImpl struct {
-	cwlClient awsClient.CloudWatchLogsClient
-	logger    *slog.Logger
-	nowFn     func() time.Time
-	logGroups []string
+	cwlClient             awsClient.CloudWatchLogsClient
+	logger                *slog.Logger
+	nowFn                 func() time.Time
+	logGroups             []string
+	resourceUsageLogGroup string
 }
 
 // NewObservabilityManager creates a new AWS observability manager.
+// The resource usage of the tasks is read from the Container Insights performance log group of ecsCluster.
 func NewObservabilityManager(
 	cwlClient awsClient.CloudWatchLogsClient,
 	log *slog.Logger,
 	logGroups []string,
+	ecsCluster string,
 ) *ObservabilityManagerImpl {
-	return &ObservabilityManagerImpl{
+	manager := &ObservabilityManagerImpl{
 		cwlClient: cwlClient,
 		logger:    log,
 		nowFn:     time.Now,
 		logGroups: sanitizeLogGroups(logGroups),
 	}
+	if ecsCluster != "" {
+		manager.resourceUsageLogGroup = fmt.Sprintf(awsConstants.ContainerInsightsPerformanceLogGroupFormat, ecsCluster)
+	}
+	return manager
 }
 
 // FetchBackendLogs retrieves backend infrastructure logs using CloudWatch Logs FilterLogEvents.
-// It scans all runvoy Lambda log groups for entries that contain the provided request ID.
+// It scans all runvoy Lambda log groups for the entries of the provided request ID, and for the event processor
+// entries of the executions the request started.
 func (o *ObservabilityManagerImpl) FetchBackendLogs(ctx context.Context, requestID string) ([]api.LogEvent, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, o.logger)
 
@@ -120,7 +130,7 @@ func (o *ObservabilityManagerImpl) fetchLogEventsForGroup(
 
 		out, err := o.cwlClient.FilterLogEvents(ctx, input)
 		if err != nil {
-			return nil, appErrors.ErrInternalError("failed to filter backend log events", err)
+			return nil, sdkerrors.Map(err, "failed to filter backend log events", appErrors.ErrInternalError)
 		}
 
 		for _, event := range out.Events {
@@ -140,7 +150,8 @@ func (o *ObservabilityManagerImpl) fetchLogEventsForGroup(
 }
 
 func (o *ObservabilityManagerImpl) buildFilterPattern(requestID string) string {
-	return fmt.Sprintf("{ $.%s = %q }", constants.RequestIDLogField, requestID)
+	return fmt.Sprintf("{ ($.%s = %q) || ($.%s = %q) }",
+		constants.RequestIDLogField, requestID, constants.OriginRequestIDLogField, requestID)
 }
 
 func (o *ObservabilityManagerImpl) lookbackWindowMillis() (startMillis, endMillis int64) {
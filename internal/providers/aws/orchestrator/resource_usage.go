@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+)
+
+// containerInsightsTaskEvent holds the fields of a Container Insights task performance event.
+// CPU is reported in CPU units and memory in MiB.
+type containerInsightsTaskEvent struct {
+	Type           string  `json:"Type"`
+	TaskID         string  `json:"TaskId"`
+	CPUUtilized    float64 `json:"CpuUtilized"`
+	CPUReserved    float64 `json:"CpuReserved"`
+	MemoryUtilized float64 `json:"MemoryUtilized"`
+	MemoryReserved float64 `json:"MemoryReserved"`
+	// NetworkRxBytes and NetworkTxBytes are per-second rates over the sampling interval.
+	NetworkRxBytes float64 `json:"NetworkRxBytes"`
+	NetworkTxBytes float64 `json:"NetworkTxBytes"`
+}
+
+// FetchResourceUsage retrieves the latest Container Insights performance event of each running task.
+// The execution ID is the ECS task ID. When Container Insights did not report any task of the cluster yet,
+// its log group does not exist and no usage is returned.
+func (o *ObservabilityManagerImpl) FetchResourceUsage(
+	ctx context.Context,
+	executionIDs []string,
+) (map[string]*api.ResourceUsage, error) {
+	usage := make(map[string]*api.ResourceUsage, len(executionIDs))
+	if len(executionIDs) == 0 {
+		return usage, nil
+	}
+	if o.resourceUsageLogGroup == "" {
+		return nil, appErrors.ErrInternalError("no resource usage log group configured", nil)
+	}
+
+	now := o.now()
+	startMillis := now.Add(-awsConstants.ResourceUsageLookback).UnixMilli()
+	latest := func(taskEvent *containerInsightsTaskEvent, sampledAt time.Time) {
+		if previous, ok := usage[taskEvent.TaskID]; ok && !sampledAt.After(previous.SampledAt) {
+			return
+		}
+		usage[taskEvent.TaskID] = &api.ResourceUsage{
+			SampledAt:         sampledAt,
+			CPUUtilized:       taskEvent.CPUUtilized,
+			CPUReserved:       taskEvent.CPUReserved,
+			MemoryUtilizedMiB: taskEvent.MemoryUtilized,
+			MemoryReservedMiB: taskEvent.MemoryReserved,
+		}
+	}
+	for batch := range slices.Chunk(executionIDs, awsConstants.ResourceUsageFilterBatchSize) {
+		if err := o.filterTaskEvents(ctx, batch, startMillis, now.UnixMilli(), latest); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// SummarizeResourceUsage summarizes the Container Insights performance events of a stopped task between
+// start and end: the average CPU and the peak memory utilization, and the network bytes estimated from
+// the per-second rates of the samples. The summary has no samples when the task stopped before
+// Container Insights reported it, which happens for tasks running less than a minute.
+func (o *ObservabilityManagerImpl) SummarizeResourceUsage(
+	ctx context.Context,
+	executionID string,
+	start time.Time,
+	end time.Time,
+) (*api.ResourceSummary, error) {
+	if o.resourceUsageLogGroup == "" {
+		return nil, appErrors.ErrInternalError("no resource usage log group configured", nil)
+	}
+
+	summary := &api.ResourceSummary{}
+	var cpuUtilized, networkRxBytes, networkTxBytes float64
+	interval := awsConstants.ContainerInsightsSampleInterval.Seconds()
+	aggregate := func(taskEvent *containerInsightsTaskEvent, _ time.Time) {
+		summary.Samples++
+		cpuUtilized += taskEvent.CPUUtilized
+		summary.CPUReserved = taskEvent.CPUReserved
+		summary.PeakMemoryUtilizedMiB = max(summary.PeakMemoryUtilizedMiB, taskEvent.MemoryUtilized)
+		summary.MemoryReservedMiB = taskEvent.MemoryReserved
+		networkRxBytes += taskEvent.NetworkRxBytes * interval
+		networkTxBytes += taskEvent.NetworkTxBytes * interval
+	}
+	// The last sample is written after the task stopped, cover the interval it reports on.
+	endMillis := end.Add(awsConstants.ContainerInsightsSampleInterval).UnixMilli()
+	if err := o.filterTaskEvents(ctx, []string{executionID}, start.UnixMilli(), endMillis, aggregate); err != nil {
+		return nil, err
+	}
+
+	if summary.Samples > 0 {
+		summary.AverageCPUUtilized = cpuUtilized / float64(summary.Samples)
+		summary.NetworkRxBytes = int64(networkRxBytes)
+		summary.NetworkTxBytes = int64(networkTxBytes)
+	}
+	return summary, nil
+}
+
+// filterTaskEvents calls fn with each Container Insights task performance event of the executions
+// sampled between startMillis and endMillis. A missing log group means that no task was reported yet.
+func (o *ObservabilityManagerImpl) filterTaskEvents(
+	ctx context.Context,
+	executionIDs []string,
+	startMillis int64,
+	endMillis int64,
+	fn func(taskEvent *containerInsightsTaskEvent, sampledAt time.Time),
+) error {
+	reqLogger := logger.DeriveRequestLogger(ctx, o.logger)
+
+	var nextToken *string
+	for {
+		reqLogger.Debug("calling external service", "context", map[string]any{
+			"operation":     "CloudWatchLogs.FilterLogEvents",
+			"log_group":     o.resourceUsageLogGroup,
+			"execution_ids": executionIDs,
+		})
+
+		out, err := o.cwlClient.FilterLogEvents(ctx, &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:  aws.String(o.resourceUsageLogGroup),
+			FilterPattern: aws.String(buildResourceUsageFilterPattern(executionIDs)),
+			NextToken:     nextToken,
+			Limit:         aws.Int32(awsConstants.CloudWatchLogsEventsLimit),
+			StartTime:     aws.Int64(startMillis),
+			EndTime:       aws.Int64(endMillis),
+		})
+		if err != nil {
+			var rte *cwlTypes.ResourceNotFoundException
+			if errors.As(err, &rte) {
+				return nil
+			}
+			return sdkerrors.Map(err, "failed to filter resource usage events", appErrors.ErrInternalError)
+		}
+
+		for _, event := range out.Events {
+			var taskEvent containerInsightsTaskEvent
+			if err = json.Unmarshal([]byte(aws.ToString(event.Message)), &taskEvent); err != nil {
+				reqLogger.Debug("skipping malformed resource usage event", "error", err)
+				continue
+			}
+			fn(&taskEvent, time.UnixMilli(aws.ToInt64(event.Timestamp)).UTC())
+		}
+
+		if out.NextToken == nil || (nextToken != nil && aws.ToString(out.NextToken) == aws.ToString(nextToken)) {
+			return nil
+		}
+		nextToken = out.NextToken
+	}
+}
+
+// buildResourceUsageFilterPattern matches the task performance events of the executions.
+func buildResourceUsageFilterPattern(executionIDs []string) string {
+	conditions := make([]string, 0, len(executionIDs))
+	for _, executionID := range executionIDs {
+		conditions = append(conditions, fmt.Sprintf("$.TaskId = %q", executionID))
+	}
+	return fmt.Sprintf("{ $.Type = %q && (%s) }",
+		awsConstants.ContainerInsightsTaskEventType, strings.Join(conditions, " || "))
+}
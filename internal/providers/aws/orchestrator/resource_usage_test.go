@@ -0,0 +1,212 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwlTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/testutil"
+)
+
+func TestFetchResourceUsage(t *testing.T) {
+	ctx := context.Background()
+	fixedNow := time.Date(2025, time.December, 1, 12, 0, 0, 0, time.UTC)
+	testManager := func(mock *mockCloudWatchLogsClient) *ObservabilityManagerImpl {
+		manager := NewObservabilityManager(mock, testutil.SilentLogger(), nil, "runvoy-cluster")
+		manager.nowFn = func() time.Time { return fixedNow }
+		return manager
+	}
+	taskEvent := func(taskID string, sampledAt time.Time, cpu, memory float64) cwlTypes.FilteredLogEvent {
+		return cwlTypes.FilteredLogEvent{
+			Message: aws.String(fmt.Sprintf(
+				`{"Type":"Task","TaskId":%q,"CpuUtilized":%g,"CpuReserved":1024,"MemoryUtilized":%g,"MemoryReserved":2048}`,
+				taskID, cpu, memory)),
+			Timestamp: aws.Int64(sampledAt.UnixMilli()),
+		}
+	}
+
+	t.Run("returns the latest sample of each task", func(t *testing.T) {
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				params *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				assert.Equal(t, "/aws/ecs/containerinsights/runvoy-cluster/performance", aws.ToString(params.LogGroupName))
+				assert.Equal(t, `{ $.Type = "Task" && ($.TaskId = "task-1" || $.TaskId = "task-2") }`,
+					aws.ToString(params.FilterPattern))
+				assert.Equal(t, fixedNow.Add(-5*time.Minute).UnixMilli(), aws.ToInt64(params.StartTime))
+				assert.Equal(t, fixedNow.UnixMilli(), aws.ToInt64(params.EndTime))
+				return &cloudwatchlogs.FilterLogEventsOutput{
+					Events: []cwlTypes.FilteredLogEvent{
+						taskEvent("task-1", fixedNow.Add(-time.Minute), 512, 900),
+						taskEvent("task-1", fixedNow.Add(-2*time.Minute), 100, 100),
+						{Message: aws.String("not json"), Timestamp: aws.Int64(fixedNow.UnixMilli())},
+					},
+				}, nil
+			},
+		}
+
+		usage, err := testManager(mock).FetchResourceUsage(ctx, []string{"task-1", "task-2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*api.ResourceUsage{
+			"task-1": {
+				SampledAt:         fixedNow.Add(-time.Minute),
+				CPUUtilized:       512,
+				CPUReserved:       1024,
+				MemoryUtilizedMiB: 900,
+				MemoryReservedMiB: 2048,
+			},
+		}, usage)
+	})
+
+	t.Run("filters task IDs in batches", func(t *testing.T) {
+		calls := 0
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				_ *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				calls++
+				return &cloudwatchlogs.FilterLogEventsOutput{}, nil
+			},
+		}
+		executionIDs := make([]string, 25)
+		for i := range executionIDs {
+			executionIDs[i] = fmt.Sprintf("%032x", i)
+		}
+
+		_, err := testManager(mock).FetchResourceUsage(ctx, executionIDs)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Less(t, len(buildResourceUsageFilterPattern(executionIDs[:10])), 1024)
+	})
+
+	t.Run("returns no usage when the log group does not exist", func(t *testing.T) {
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				_ *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				return nil, &cwlTypes.ResourceNotFoundException{Message: aws.String("log group does not exist")}
+			},
+		}
+
+		usage, err := testManager(mock).FetchResourceUsage(ctx, []string{"task-1"})
+
+		require.NoError(t, err)
+		assert.Empty(t, usage)
+	})
+
+	t.Run("wraps filter errors", func(t *testing.T) {
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				_ *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				return nil, errors.New("throttled")
+			},
+		}
+
+		_, err := testManager(mock).FetchResourceUsage(ctx, []string{"task-1"})
+
+		require.Error(t, err)
+		assert.Equal(t, appErrors.ErrCodeInternalError, appErrors.GetErrorCode(err))
+	})
+
+	t.Run("skips the lookup without executions", func(t *testing.T) {
+		usage, err := testManager(&mockCloudWatchLogsClient{}).FetchResourceUsage(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, usage)
+	})
+}
+
+func TestSummarizeResourceUsage(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, time.December, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	sample := func(cpu, memory, rx, tx float64) cwlTypes.FilteredLogEvent {
+		return cwlTypes.FilteredLogEvent{
+			Message: aws.String(fmt.Sprintf(`{"Type":"Task","TaskId":"task-1","CpuUtilized":%g,"CpuReserved":1024,`+
+				`"MemoryUtilized":%g,"MemoryReserved":2048,"NetworkRxBytes":%g,"NetworkTxBytes":%g}`, cpu, memory, rx, tx)),
+			Timestamp: aws.Int64(start.UnixMilli()),
+		}
+	}
+
+	t.Run("aggregates the samples of the task", func(t *testing.T) {
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				params *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				assert.Equal(t, `{ $.Type = "Task" && ($.TaskId = "task-1") }`, aws.ToString(params.FilterPattern))
+				assert.Equal(t, start.UnixMilli(), aws.ToInt64(params.StartTime))
+				assert.Equal(t, end.Add(time.Minute).UnixMilli(), aws.ToInt64(params.EndTime))
+				return &cloudwatchlogs.FilterLogEventsOutput{
+					Events: []cwlTypes.FilteredLogEvent{
+						sample(200, 500, 10, 1),
+						sample(600, 1800, 20, 2),
+						sample(400, 900, 30, 3),
+					},
+				}, nil
+			},
+		}
+		manager := NewObservabilityManager(mock, testutil.SilentLogger(), nil, "runvoy-cluster")
+
+		summary, err := manager.SummarizeResourceUsage(ctx, "task-1", start, end)
+
+		require.NoError(t, err)
+		assert.Equal(t, &api.ResourceSummary{
+			Samples:               3,
+			AverageCPUUtilized:    400,
+			CPUReserved:           1024,
+			PeakMemoryUtilizedMiB: 1800,
+			MemoryReservedMiB:     2048,
+			NetworkRxBytes:        3600,
+			NetworkTxBytes:        360,
+		}, summary)
+	})
+
+	t.Run("has no samples when the task was not reported", func(t *testing.T) {
+		mock := &mockCloudWatchLogsClient{
+			filterLogEventsFunc: func(
+				_ context.Context,
+				_ *cloudwatchlogs.FilterLogEventsInput,
+				_ ...func(*cloudwatchlogs.Options),
+			) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+				return nil, &cwlTypes.ResourceNotFoundException{Message: aws.String("log group does not exist")}
+			},
+		}
+		manager := NewObservabilityManager(mock, testutil.SilentLogger(), nil, "runvoy-cluster")
+
+		summary, err := manager.SummarizeResourceUsage(ctx, "task-1", start, end)
+
+		require.NoError(t, err)
+		assert.Equal(t, &api.ResourceSummary{}, summary)
+	})
+
+	t.Run("fails without a resource usage log group", func(t *testing.T) {
+		manager := NewObservabilityManager(&mockCloudWatchLogsClient{}, testutil.SilentLogger(), nil, "")
+
+		_, err := manager.SummarizeResourceUsage(ctx, "task-1", start, end)
+
+		assert.Equal(t, appErrors.ErrCodeInternalError, appErrors.GetErrorCode(err))
+	})
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/runvoy/runvoy/internal/logger"
 	awsClient "github.com/runvoy/runvoy/internal/providers/aws/client"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/sdkerrors"
+	"github.com/runvoy/runvoy/internal/runnerinit"
+	"github.com/runvoy/runvoy/internal/shellquote"
 
 	awsStd "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
@@ -35,6 +38,9 @@ type Config struct {
 	DefaultTaskExecRoleARN string
 	Region                 string
 	AccountID              string
+	InputsBucket           string            // S3 bucket holding uploaded execution inputs such as stdin
+	RunnerInitURL          string            // URL of the runvoy-init binary, the shell scripts are used when empty
+	ResourceTags           map[string]string // Tags applied to the task definitions and tasks, with the standard tags
 	SDKConfig              *awsStd.Config
 }
 
@@ -53,7 +59,11 @@ type ImageTaskDefRepository interface {
 		taskDefFamily string,
 		isDefault bool,
 		registeredBy string,
+		logLimits *api.LogLimits,
 	) error
+	SetImageLogLimits(ctx context.Context, imageID string, version int64, limits *api.LogLimits) error
+	SetImageWarmPoolSize(ctx context.Context, imageID string, version int64, size int) error
+	SetImageRunDefaults(ctx context.Context, imageID string, version int64, defaults *api.RunDefaults) error
 	GetImageTaskDef(
 		ctx context.Context,
 		image string,
@@ -67,6 +77,12 @@ type ImageTaskDefRepository interface {
 	GetDefaultImage(ctx context.Context) (*api.ImageInfo, error)
 	UnmarkAllDefaults(ctx context.Context) error
 	DeleteImage(ctx context.Context, image string) error
+	MarkImageDeleted(ctx context.Context, imageID, deletedBy string, deletedAt time.Time) error
+	RestoreImage(ctx context.Context, imageID string) error
+	PutImageAlias(ctx context.Context, alias, imageID, updatedBy string, updatedAt time.Time) (string, error)
+	GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error)
+	ListImageAliases(ctx context.Context) ([]api.ImageAlias, error)
+	DeleteImageAlias(ctx context.Context, alias string) error
 	SetImageAsOnlyDefault(ctx context.Context, image string, taskRoleName, taskExecutionRoleName *string) error
 	GetImagesByRequestID(ctx context.Context, requestID string) ([]api.ImageInfo, error)
 }
@@ -74,24 +90,31 @@ type ImageTaskDefRepository interface {
 // TaskManagerImpl implements the TaskManager interface for AWS ECS Fargate.
 // It handles task lifecycle management including starting and terminating tasks.
 type TaskManagerImpl struct {
-	ecsClient awsClient.ECSClient
-	imageRepo ImageTaskDefRepository
-	cfg       *Config
-	logger    *slog.Logger
+	ecsClient     awsClient.ECSClient
+	presignClient awsClient.S3PresignClient
+	imageRepo     ImageTaskDefRepository
+	cfg           *Config
+	logger        *slog.Logger
+
+	// s3Client assigns executions to warm pool slots, it is optional.
+	s3Client awsClient.S3Client
 }
 
 // NewTaskManager creates a new AWS ECS task manager.
+// presignClient may be nil when stdin uploads are not needed (e.g. in the event processor).
 func NewTaskManager(
 	ecsClient awsClient.ECSClient,
+	presignClient awsClient.S3PresignClient,
 	imageRepo ImageTaskDefRepository,
 	cfg *Config,
 	log *slog.Logger,
 ) *TaskManagerImpl {
 	return &TaskManagerImpl{
-		ecsClient: ecsClient,
-		imageRepo: imageRepo,
-		cfg:       cfg,
-		logger:    log,
+		ecsClient:     ecsClient,
+		presignClient: presignClient,
+		imageRepo:     imageRepo,
+		cfg:           cfg,
+		logger:        log,
 	}
 }
 
@@ -109,9 +132,20 @@ func (t *TaskManagerImpl) StartTask(
 		return "", nil, err
 	}
 
+	if isWarmStartEligible(req) {
+		if executionID, createdAt, ok := t.startOnWarmSlot(ctx, userEmail, req, reqLogger); ok {
+			return executionID, createdAt, nil
+		}
+	}
+
 	gitConfig := t.configureGitRepo(ctx, req, reqLogger)
 
-	containerOverrides, mainEnvVars := t.buildContainerOverrides(ctx, req, gitConfig, reqLogger)
+	inputURLs, err := t.resolveInputDownloadURLs(ctx, req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	containerOverrides, mainEnvVars := t.buildContainerOverrides(ctx, req, gitConfig, inputURLs)
 
 	runTaskInput := t.buildRunTaskInput(userEmail, taskDefARN, containerOverrides, gitConfig.HasRepo)
 
@@ -147,7 +181,10 @@ func (t *TaskManagerImpl) resolveImage(
 
 	taskDefARN, err = t.getTaskDefinitionARNForImage(ctx, imageToUse)
 	if err != nil {
-		return "", "", appErrors.ErrBadRequest("image not registered", err)
+		if appErrors.GetErrorCode(err) == appErrors.ErrCodeImageDeleted {
+			return "", "", err
+		}
+		return "", "", appErrors.ErrImageNotRegistered("image not registered", err)
 	}
 
 	reqLogger.Debug("task definition resolved", "context", map[string]string{
@@ -200,6 +237,11 @@ func (t *TaskManagerImpl) getTaskDefinitionARNForImage(ctx context.Context, imag
 	if imageInfo == nil {
 		return "", fmt.Errorf("no task definition found for image: %s", image)
 	}
+	if imageInfo.DeletedAt != nil {
+		return "", appErrors.ErrImageDeleted(fmt.Sprintf(
+			"image %s was deleted, restore it with 'runvoy images restore %s'",
+			imageInfo.ImageID, imageInfo.ImageID), nil)
+	}
 
 	return imageInfo.TaskDefinitionName, nil
 }
@@ -240,7 +282,7 @@ func (t *TaskManagerImpl) configureGitRepo(
 
 // buildContainerOverrides constructs the container overrides for sidecar and main runner containers.
 func (t *TaskManagerImpl) buildContainerOverrides(
-	ctx context.Context, req *api.ExecutionRequest, gitConfig *gitRepoConfig, _ *slog.Logger,
+	ctx context.Context, req *api.ExecutionRequest, gitConfig *gitRepoConfig, inputURLs *inputDownloadURLs,
 ) ([]ecsTypes.ContainerOverride, []ecsTypes.KeyValuePair) {
 	requestID := logger.GetRequestID(ctx)
 
@@ -265,16 +307,41 @@ func (t *TaskManagerImpl) buildContainerOverrides(
 			ecsTypes.KeyValuePair{Name: awsStd.String("GIT_REPO"), Value: awsStd.String("")},
 		)
 	}
+	sidecarEnv = append(sidecarEnv, buildStdinEnvironment(req.Stdin, inputURLs.Stdin)...)
+	sidecarEnv = append(sidecarEnv, buildContextEnvironment(inputURLs.Context)...)
+	inputs := sidecarInputs{
+		HasGitRepo: gitConfig.HasRepo,
+		HasStdin:   req.Stdin != "" || inputURLs.Stdin != "",
+		HasContext: inputURLs.Context != "",
+	}
+
+	if t.cfg.RunnerInitURL != "" {
+		spec := runnerinit.EncodeSpec(buildRunnerInitSpec(req, requestID, gitConfig, inputs))
+		initEnv := ecsTypes.KeyValuePair{Name: awsStd.String(runnerinit.SpecEnvVar), Value: awsStd.String(spec)}
+		mainEnvVars = append(mainEnvVars, initEnv)
+		return []ecsTypes.ContainerOverride{
+			{
+				Name:        awsStd.String(awsConstants.SidecarContainerName),
+				Command:     buildInitSidecarContainerCommand(t.cfg.RunnerInitURL, inputs),
+				Environment: append(sidecarEnv, initEnv),
+			},
+			{
+				Name:        awsStd.String(awsConstants.RunnerContainerName),
+				Command:     []string{awsConstants.RunnerInitPath, "run"},
+				Environment: mainEnvVars,
+			},
+		}, mainEnvVars
+	}
 
 	return []ecsTypes.ContainerOverride{
 		{
 			Name:        awsStd.String(awsConstants.SidecarContainerName),
-			Command:     buildSidecarContainerCommand(gitConfig.HasRepo, req.Env, req.SecretVarNames),
+			Command:     buildSidecarContainerCommand(inputs, req.Env, req.SecretVarNames),
 			Environment: sidecarEnv,
 		},
 		{
 			Name:        awsStd.String(awsConstants.RunnerContainerName),
-			Command:     buildMainContainerCommand(req, requestID, req.Image, gitConfig.Info),
+			Command:     buildMainContainerCommand(req, requestID, req.Image, gitConfig.Info, inputs),
 			Environment: mainEnvVars,
 		},
 	}, mainEnvVars
@@ -286,9 +353,9 @@ func (t *TaskManagerImpl) buildRunTaskInput(
 	containerOverrides []ecsTypes.ContainerOverride,
 	hasGitRepo bool,
 ) *ecs.RunTaskInput {
-	tags := []ecsTypes.Tag{
-		{Key: awsStd.String("UserEmail"), Value: awsStd.String(userEmail)},
-	}
+	tags := append(GetStandardECSTags(t.cfg.ResourceTags),
+		ecsTypes.Tag{Key: awsStd.String("UserEmail"), Value: awsStd.String(userEmail)},
+	)
 	if hasGitRepo {
 		tags = append(tags, ecsTypes.Tag{
 			Key:   awsStd.String("HasGitRepo"),
@@ -330,7 +397,7 @@ func (t *TaskManagerImpl) executeTask(
 
 	runTaskOutput, err := t.ecsClient.RunTask(ctx, runTaskInput)
 	if err != nil {
-		return "", nil, "", appErrors.ErrInternalError("failed to start ECS task", err)
+		return "", nil, "", sdkerrors.Map(err, "failed to start ECS task", appErrors.ErrInternalError)
 	}
 	if len(runTaskOutput.Tasks) == 0 {
 		return "", nil, "", appErrors.ErrInternalError("no tasks were started", nil)
@@ -423,7 +490,7 @@ func (t *TaskManagerImpl) KillTask(ctx context.Context, executionID string) erro
 			"error", err,
 			"execution_id", executionID,
 			"task_arn", taskARN)
-		return appErrors.ErrInternalError("failed to describe task", err)
+		return sdkerrors.Map(err, "failed to describe task", appErrors.ErrInternalError)
 	}
 
 	if len(describeOutput.Tasks) == 0 {
@@ -455,7 +522,7 @@ func (t *TaskManagerImpl) KillTask(ctx context.Context, executionID string) erro
 	})
 	if err != nil {
 		reqLogger.Error("failed to stop task", "error", err, "execution_id", executionID, "task_arn", taskARN)
-		return appErrors.ErrInternalError("failed to stop task", err)
+		return sdkerrors.Map(err, "failed to stop task", appErrors.ErrInternalError)
 	}
 
 	reqLogger.Info(
@@ -486,7 +553,7 @@ func (t *TaskManagerImpl) findTaskARNByExecutionID(
 	})
 	if err != nil {
 		reqLogger.Debug("failed to list tasks", "error", err, "execution_id", executionID)
-		return "", appErrors.ErrInternalError("failed to list tasks", err)
+		return "", sdkerrors.Map(err, "failed to list tasks", appErrors.ErrInternalError)
 	}
 
 	taskARN := extractTaskARNFromList(listOutput.TaskArns, executionID)
@@ -520,10 +587,19 @@ func (t *TaskManagerImpl) findTaskARNByExecutionID(
 	return taskARN, nil
 }
 
+// sidecarInputs describes the inputs the sidecar prepares on the shared volume before the command starts.
+type sidecarInputs struct {
+	HasGitRepo bool
+	HasStdin   bool
+	HasContext bool
+}
+
 type sidecarScriptData struct {
 	ProjectName    string
 	DefaultGitRef  string
 	HasGitRepo     bool
+	HasStdin       bool
+	HasContext     bool
 	SecretVarNames []string
 	AllVarNames    []string
 }
@@ -553,8 +629,11 @@ func injectGitHubTokenIfNeeded(gitRepo string, userEnv map[string]string) string
 }
 
 // buildSidecarContainerCommand constructs the shell command for the sidecar container.
-// It handles .env file creation from user environment variables and git repository cloning.
-func buildSidecarContainerCommand(hasGitRepo bool, userEnv map[string]string, secretVarNames []string) []string {
+// It handles .env file creation from user environment variables, writing the command's standard input
+// to the shared volume, and git repository cloning or working directory archive extraction.
+func buildSidecarContainerCommand(
+	inputs sidecarInputs, userEnv map[string]string, secretVarNames []string,
+) []string {
 	allVarNames := make([]string, 0, len(userEnv))
 	for key := range userEnv {
 		allVarNames = append(allVarNames, key)
@@ -562,7 +641,9 @@ func buildSidecarContainerCommand(hasGitRepo bool, userEnv map[string]string, se
 	script := renderScript("sidecar.sh.tmpl", sidecarScriptData{
 		ProjectName:    constants.ProjectName,
 		DefaultGitRef:  constants.DefaultGitRef,
-		HasGitRepo:     hasGitRepo,
+		HasGitRepo:     inputs.HasGitRepo,
+		HasStdin:       inputs.HasStdin,
+		HasContext:     inputs.HasContext,
 		SecretVarNames: secretVarNames,
 		AllVarNames:    allVarNames,
 	})
@@ -620,16 +701,26 @@ type mainScriptRepoData struct {
 }
 
 type mainScriptData struct {
-	ProjectName string
-	RequestID   string
-	Image       string
-	Command     string
-	Repo        *mainScriptRepoData
+	ProjectName     string
+	RequestID       string
+	Image           string
+	Command         string
+	Run             string // Command line starting the Command with the requested shell
+	StopGracePeriod int
+	StdinPath       string
+	ContextDir      string
+	Repo            *mainScriptRepoData
+	WorkDir         string
 }
 
 // buildMainContainerCommand constructs the shell command for the main runner container.
-// It adds logging statements and optionally changes to the git repo working directory.
-func buildMainContainerCommand(req *api.ExecutionRequest, requestID, image string, repo *gitRepoInfo) []string {
+// It adds logging statements, optionally changes to the git repo working directory and forwards
+// SIGTERM to the command, killing it once the request's stop grace period has elapsed.
+// Depending on the inputs prepared by the sidecar, the command reads its standard input from the file
+// written by the sidecar and runs in the extracted working directory archive.
+func buildMainContainerCommand(
+	req *api.ExecutionRequest, requestID, image string, repo *gitRepoInfo, inputs sidecarInputs,
+) []string {
 	var repoData *mainScriptRepoData
 	if repo != nil {
 		workDir := awsConstants.SharedVolumePath + "/repo"
@@ -648,12 +739,32 @@ func buildMainContainerCommand(req *api.ExecutionRequest, requestID, image strin
 		}
 	}
 
+	stdinPath := ""
+	if inputs.HasStdin {
+		stdinPath = awsConstants.StdinFilePath
+	}
+	contextDir := ""
+	if inputs.HasContext {
+		contextDir = awsConstants.ContextDirPath
+	}
+
+	// The script shell runs the command in a subshell, any other shell is started with the command line
+	run := "( " + req.Command + " )"
+	if shell := constants.ExecutionShell(req.Shell); shell != "" && shell != constants.ExecutionShellSh {
+		run = shellquote.Join(shell.Args(req.Command))
+	}
+
 	script := renderScript("main.sh.tmpl", mainScriptData{
-		ProjectName: constants.ProjectName,
-		RequestID:   requestID,
-		Image:       image,
-		Command:     req.Command,
-		Repo:        repoData,
+		ProjectName:     constants.ProjectName,
+		RequestID:       requestID,
+		Image:           image,
+		Command:         req.Command,
+		Run:             run,
+		StopGracePeriod: req.StopGracePeriod,
+		StdinPath:       stdinPath,
+		ContextDir:      contextDir,
+		Repo:            repoData,
+		WorkDir:         req.WorkDir,
 	})
 
 	return []string{"/bin/sh", "-c", script}
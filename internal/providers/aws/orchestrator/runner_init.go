@@ -0,0 +1,59 @@
+package orchestrator
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/runnerinit"
+)
+
+type initSidecarScriptData struct {
+	ProjectName string
+	InitURL     string
+	InitPath    string
+	HasGitRepo  bool
+}
+
+// buildInitSidecarContainerCommand constructs the command of the sidecar of the runs using runvoy-init:
+// it downloads the binary for the task's architecture to the shared volume, where the runner container
+// runs it, and prepares the shared volume with it.
+func buildInitSidecarContainerCommand(initURL string, inputs sidecarInputs) []string {
+	script := renderScript("init_sidecar.sh.tmpl", initSidecarScriptData{
+		ProjectName: constants.ProjectName,
+		InitURL:     initURL,
+		InitPath:    awsConstants.RunnerInitPath,
+		HasGitRepo:  inputs.HasGitRepo,
+	})
+	return []string{"/bin/sh", "-c", script}
+}
+
+// buildRunnerInitSpec describes the execution to runvoy-init. The values that may hold secrets, the user
+// environment and the authenticated repository URL, are passed through the environment of the containers.
+func buildRunnerInitSpec(
+	req *api.ExecutionRequest, requestID string, gitConfig *gitRepoConfig, inputs sidecarInputs,
+) *runnerinit.Spec {
+	spec := &runnerinit.Spec{
+		RequestID:       requestID,
+		Image:           req.Image,
+		Command:         req.Command,
+		Args:            req.Args,
+		Shell:           req.Shell,
+		Dir:             req.WorkDir,
+		SharedDir:       awsConstants.SharedVolumePath,
+		EnvVarNames:     slices.Sorted(maps.Keys(req.Env)),
+		HasStdin:        inputs.HasStdin,
+		HasContext:      inputs.HasContext,
+		StopGracePeriod: req.StopGracePeriod,
+	}
+	if gitConfig.HasRepo {
+		spec.Repo = &runnerinit.RepoSpec{
+			URL:  sanitizeURLForLogging(gitConfig.AuthenticatedRepoURL),
+			Ref:  gitConfig.Ref,
+			Path: req.GitPath,
+		}
+	}
+	return spec
+}
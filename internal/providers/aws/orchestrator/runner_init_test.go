@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/runnerinit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildContainerOverridesWithRunnerInit(t *testing.T) {
+	tm := newInputsTaskManager(nil, "")
+	tm.cfg.RunnerInitURL = "https://releases.example.com/runvoy-init-linux-{arch}"
+	req := &api.ExecutionRequest{
+		Command:         "make test",
+		Image:           "golang:1.25-a1b2c3d4",
+		Env:             map[string]string{"GITHUB_TOKEN": "secret", "APP_ENV": "ci"},
+		GitPath:         "services/api",
+		WorkDir:         "cmd",
+		Shell:           "bash",
+		StopGracePeriod: 30,
+	}
+	gitConfig := &gitRepoConfig{
+		HasRepo:              true,
+		AuthenticatedRepoURL: "https://secret@github.com/acme/app",
+		Ref:                  "main",
+	}
+
+	overrides, mainEnv := tm.buildContainerOverrides(context.Background(), req, gitConfig, &inputDownloadURLs{})
+
+	require.Len(t, overrides, 2)
+	sidecarScript := overrides[0].Command[2]
+	assert.Contains(t, sidecarScript, "apk add --no-cache git")
+	assert.Contains(t, sidecarScript, `wget -q -O "`+awsConstants.RunnerInitPath+`"`)
+	assert.Contains(t, sidecarScript, `exec "`+awsConstants.RunnerInitPath+`" prepare`)
+	assert.Equal(t, []string{awsConstants.RunnerInitPath, "run"}, overrides[1].Command)
+	assert.Equal(t, "https://secret@github.com/acme/app", envValue(overrides[0].Environment, "GIT_REPO"))
+
+	spec, err := runnerinit.DecodeSpec(envValue(overrides[1].Environment, runnerinit.SpecEnvVar))
+	require.NoError(t, err)
+	assert.Equal(t, envValue(overrides[0].Environment, runnerinit.SpecEnvVar),
+		envValue(mainEnv, runnerinit.SpecEnvVar))
+	assert.Equal(t, "make test", spec.Command)
+	assert.Equal(t, []string{"APP_ENV", "GITHUB_TOKEN"}, spec.EnvVarNames)
+	assert.Equal(t, 30, spec.StopGracePeriod)
+	require.NotNil(t, spec.Repo)
+	assert.Equal(t, "https://***@github.com/acme/app", spec.Repo.URL)
+	assert.Equal(t, "bash", spec.Shell)
+	assert.Equal(t, awsConstants.SharedVolumePath+"/repo/services/api/cmd", spec.WorkDir())
+}
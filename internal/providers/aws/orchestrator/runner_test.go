@@ -1,13 +1,18 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/shellquote"
+	"github.com/runvoy/runvoy/internal/testutil"
 
 	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +20,7 @@ import (
 )
 
 func TestBuildSidecarContainerCommandWithoutGitRepo(t *testing.T) {
-	cmd := buildSidecarContainerCommand(false, map[string]string{}, []string{})
+	cmd := buildSidecarContainerCommand(sidecarInputs{}, map[string]string{}, []string{})
 
 	require.Len(t, cmd, 3, "expected shell command with interpreter and script")
 	assert.Equal(t, "/bin/sh", cmd[0])
@@ -30,7 +35,7 @@ func TestBuildSidecarContainerCommandWithoutGitRepo(t *testing.T) {
 }
 
 func TestBuildSidecarContainerCommandWithGitRepo(t *testing.T) {
-	cmd := buildSidecarContainerCommand(true, map[string]string{}, []string{})
+	cmd := buildSidecarContainerCommand(sidecarInputs{HasGitRepo: true}, map[string]string{}, []string{})
 
 	require.Len(t, cmd, 3)
 	script := cmd[2]
@@ -93,28 +98,33 @@ func TestBuildMainContainerCommandWithoutRepo(t *testing.T) {
 		Command: "echo 'hello world'",
 	}
 
-	cmd := buildMainContainerCommand(req, "request-123", "ubuntu:22.04", nil)
+	cmd := buildMainContainerCommand(req, "request-123", "ubuntu:22.04", nil, sidecarInputs{})
 
 	require.Len(t, cmd, 3)
 	commandScript := cmd[2]
 
 	assert.Contains(t,
 		commandScript,
-		fmt.Sprintf("printf '### %s runner: execution started by requestID => %%s\\n' \"request-123\"",
+		fmt.Sprintf("printf '### %s runner: execution started by requestID => %%s\\n' request-123",
 			constants.ProjectName),
 	)
 
 	assert.Contains(
 		t,
 		commandScript,
-		fmt.Sprintf("printf '### %s runner: image ID => %%s\\n' \"ubuntu:22.04\"", constants.ProjectName),
+		fmt.Sprintf("printf '### %s runner: image ID => %%s\\n' ubuntu:22.04", constants.ProjectName),
 	)
 	assert.Contains(
 		t,
 		commandScript,
-		fmt.Sprintf("printf '### %s runner: command => %%s\\n' %q", constants.ProjectName, req.Command),
+		fmt.Sprintf("printf '### %s runner: command => %%s\\n' %s", constants.ProjectName,
+			shellquote.Quote(req.Command)),
+		"the command is shown as a single quoted word, expanding nothing",
 	)
-	assert.True(t, strings.HasSuffix(commandScript, req.Command), "shell command should end with the user command")
+	assert.Contains(t, commandScript, "( "+req.Command+" ) &", "user command should run in the background")
+	assert.Contains(t, commandScript, "trap on_stop TERM INT", "SIGTERM should be handled by the runner")
+	assert.NotContains(t, commandScript, "kill -TERM", "command should be killed right away without a grace period")
+	assert.True(t, strings.HasSuffix(commandScript, `exit "$status"`), "script should exit with the command status")
 	assert.Contains(t, commandScript, "set -e", "script should enable exit on error")
 }
 
@@ -133,7 +143,7 @@ func TestBuildMainContainerCommandWithRepo(t *testing.T) {
 		Command: "uname -a",
 	}
 
-	cmd := buildMainContainerCommand(req, "req-456", "golang:1.23", repo)
+	cmd := buildMainContainerCommand(req, "req-456", "golang:1.23", repo, sidecarInputs{})
 
 	require.Len(t, cmd, 3)
 	commandScript := cmd[2]
@@ -149,7 +159,7 @@ func TestBuildMainContainerCommandWithRepo(t *testing.T) {
 		t,
 		commandScript,
 		fmt.Sprintf(
-			"printf '### %s runner: checked out repo => %%s (ref: %%s) (path: %%s)\\n' %q %q %q",
+			"printf '### %s runner: checked out repo => %%s (ref: %%s) (path: %%s)\\n' %s %s %s",
 			constants.ProjectName,
 			repoURL,
 			repoRef,
@@ -160,9 +170,42 @@ func TestBuildMainContainerCommandWithRepo(t *testing.T) {
 	assert.Contains(
 		t,
 		commandScript,
-		fmt.Sprintf("printf '### %s runner: working directory => %%s\\n' %q", constants.ProjectName, expectedWorkingDir),
+		fmt.Sprintf("printf '### %s runner: working directory => %%s\\n' %s", constants.ProjectName, expectedWorkingDir),
 	)
-	assert.True(t, strings.HasSuffix(commandScript, req.Command))
+	assert.Contains(t, commandScript, "( "+req.Command+" ) &")
+}
+
+func TestBuildMainContainerCommandWithWorkDirAndShell(t *testing.T) {
+	req := &api.ExecutionRequest{
+		Command: "shopt -s globstar && ls **/*.go",
+		WorkDir: "my app",
+		Shell:   "bash",
+	}
+
+	cmd := buildMainContainerCommand(req, "req-321", "golang:1.23", nil, sidecarInputs{})
+
+	require.Len(t, cmd, 3)
+	commandScript := cmd[2]
+	assert.Contains(t, commandScript, "cd 'my app'\n", "the working directory is quoted")
+	assert.Contains(t, commandScript, "bash -c 'shopt -s globstar && ls **/*.go' &",
+		"the command line is passed to the shell as a single quoted argument")
+	assert.NotContains(t, commandScript, "( "+req.Command+" )")
+}
+
+func TestBuildMainContainerCommandWithStopGracePeriod(t *testing.T) {
+	req := &api.ExecutionRequest{
+		Command:         "./cleanup-on-exit.sh",
+		StopGracePeriod: 45,
+	}
+
+	cmd := buildMainContainerCommand(req, "req-789", "alpine:latest", nil, sidecarInputs{})
+
+	require.Len(t, cmd, 3)
+	commandScript := cmd[2]
+
+	assert.Contains(t, commandScript, `kill -TERM "$child"`, "SIGTERM should be forwarded to the command")
+	assert.Contains(t, commandScript, `( sleep 45 && kill -KILL "$child" 2>/dev/null ) &`,
+		"command should be killed once the grace period has elapsed")
 }
 
 func TestExtractTaskARNFromList(t *testing.T) {
@@ -321,3 +364,26 @@ func TestSanitizeURLForLogging(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveImage_RefusesDeletedImage(t *testing.T) {
+	deletedAt := time.Now().UTC()
+	tm := &TaskManagerImpl{
+		imageRepo: &mockImageRepo{
+			getImageTaskDefByIDFunc: func(_ context.Context, imageID string) (*api.ImageInfo, error) {
+				return &api.ImageInfo{
+					ImageID:            imageID,
+					TaskDefinitionName: "runvoy-alpine-latest-a1b2c3d4",
+					DeletedAt:          &deletedAt,
+				}, nil
+			},
+		},
+		logger: testutil.SilentLogger(),
+	}
+
+	_, _, err := tm.resolveImage(
+		context.Background(), &api.ExecutionRequest{Image: "alpine:latest-a1b2c3d4"}, testutil.SilentLogger())
+
+	require.Error(t, err)
+	assert.Equal(t, apperrors.ErrCodeImageDeleted, apperrors.GetErrorCode(err))
+	assert.Contains(t, err.Error(), "runvoy images restore alpine:latest-a1b2c3d4")
+}
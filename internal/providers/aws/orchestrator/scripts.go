@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+
+	"github.com/runvoy/runvoy/internal/shellquote"
 )
 
 //go:embed templates/*.tmpl
@@ -14,6 +16,8 @@ var scriptTemplates embed.FS
 var scripts = template.Must(
 	template.New("scripts").
 		Option("missingkey=error").
+		// quote makes a value a single shell word, so the shell expands nothing in it
+		Funcs(template.FuncMap{"quote": shellquote.Quote}).
 		ParseFS(scriptTemplates, "templates/*.tmpl"),
 )
 
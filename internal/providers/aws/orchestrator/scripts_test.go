@@ -20,14 +20,38 @@ func TestRenderScript(t *testing.T) {
 			name:         "render main.sh template",
 			templateName: "main.sh.tmpl",
 			data: map[string]any{
-				"ProjectName": "runvoy",
-				"RequestID":   "req-123",
-				"Image":       "ubuntu:22.04",
-				"Command":     "echo hello",
-				"Repo":        nil,
+				"ProjectName":     "runvoy",
+				"RequestID":       "req-123",
+				"Image":           "ubuntu:22.04",
+				"Command":         "echo hello",
+				"Run":             "( echo hello )",
+				"StopGracePeriod": 0,
+				"StdinPath":       "",
+				"ContextDir":      "",
+				"Repo":            nil,
+				"WorkDir":         "",
 			},
 			shouldPanic: false,
-			contains:    []string{"echo hello", "runvoy", "req-123", "ubuntu:22.04"},
+			contains:    []string{"echo hello", "runvoy", "req-123", "ubuntu:22.04", "kill -KILL"},
+			notContains: []string{"kill -TERM"},
+		},
+		{
+			name:         "render main.sh template with stop grace period",
+			templateName: "main.sh.tmpl",
+			data: map[string]any{
+				"ProjectName":     "runvoy",
+				"RequestID":       "req-123",
+				"Image":           "ubuntu:22.04",
+				"Command":         "./deploy.sh",
+				"Run":             "( ./deploy.sh )",
+				"StopGracePeriod": 30,
+				"StdinPath":       "",
+				"ContextDir":      "",
+				"Repo":            nil,
+				"WorkDir":         "",
+			},
+			shouldPanic: false,
+			contains:    []string{"( ./deploy.sh ) &", "trap on_stop TERM INT", "kill -TERM", "sleep 30"},
 		},
 		{
 			name:         "render sidecar.sh template without git repo",
@@ -35,6 +59,8 @@ func TestRenderScript(t *testing.T) {
 			data: map[string]any{
 				"ProjectName":    "runvoy",
 				"HasGitRepo":     false,
+				"HasStdin":       false,
+				"HasContext":     false,
 				"DefaultGitRef":  "main",
 				"SecretVarNames": []string{},
 				"AllVarNames":    []string{},
@@ -48,6 +74,8 @@ func TestRenderScript(t *testing.T) {
 			data: map[string]any{
 				"ProjectName":    "runvoy",
 				"HasGitRepo":     true,
+				"HasStdin":       false,
+				"HasContext":     false,
 				"DefaultGitRef":  "main",
 				"SecretVarNames": []string{},
 				"AllVarNames":    []string{},
@@ -55,6 +83,72 @@ func TestRenderScript(t *testing.T) {
 			shouldPanic: false,
 			contains:    []string{"set -e", "runvoy", "git clone"},
 		},
+		{
+			name:         "render main.sh template with stdin",
+			templateName: "main.sh.tmpl",
+			data: map[string]any{
+				"ProjectName":     "runvoy",
+				"RequestID":       "req-123",
+				"Image":           "ubuntu:22.04",
+				"Command":         "python process.py",
+				"Run":             "( python process.py )",
+				"StopGracePeriod": 0,
+				"StdinPath":       "/workspace/.stdin",
+				"ContextDir":      "",
+				"Repo":            nil,
+				"WorkDir":         "",
+			},
+			shouldPanic: false,
+			contains:    []string{`( python process.py ) < /workspace/.stdin &`},
+		},
+		{
+			name:         "render sidecar.sh template with stdin",
+			templateName: "sidecar.sh.tmpl",
+			data: map[string]any{
+				"ProjectName":    "runvoy",
+				"HasGitRepo":     false,
+				"HasStdin":       true,
+				"HasContext":     false,
+				"DefaultGitRef":  "main",
+				"SecretVarNames": []string{},
+				"AllVarNames":    []string{},
+			},
+			shouldPanic: false,
+			contains:    []string{`wget -q -O "${STDIN_FILE_PATH}" "${RUNVOY_STDIN_URL}"`, "base64 -d"},
+		},
+		{
+			name:         "render main.sh template with context",
+			templateName: "main.sh.tmpl",
+			data: map[string]any{
+				"ProjectName":     "runvoy",
+				"RequestID":       "req-123",
+				"Image":           "ubuntu:22.04",
+				"Command":         "make test",
+				"Run":             "( make test )",
+				"StopGracePeriod": 0,
+				"StdinPath":       "",
+				"ContextDir":      "/workspace/context",
+				"Repo":            nil,
+				"WorkDir":         "",
+			},
+			shouldPanic: false,
+			contains:    []string{"cd /workspace/context", "( make test ) &"},
+		},
+		{
+			name:         "render sidecar.sh template with context",
+			templateName: "sidecar.sh.tmpl",
+			data: map[string]any{
+				"ProjectName":    "runvoy",
+				"HasGitRepo":     false,
+				"HasStdin":       false,
+				"HasContext":     true,
+				"DefaultGitRef":  "main",
+				"SecretVarNames": []string{},
+				"AllVarNames":    []string{},
+			},
+			shouldPanic: false,
+			contains:    []string{`wget -q -O "${CONTEXT_ARCHIVE_PATH}" "${RUNVOY_CONTEXT_URL}"`, "tar -xzf"},
+		},
 		{
 			name:         "invalid template name",
 			templateName: "nonexistent.tmpl",
@@ -102,11 +196,16 @@ func TestRenderScript_TrimsWhitespace(t *testing.T) {
 	// that the function doesn't add extra whitespace
 
 	result := renderScript("main.sh.tmpl", map[string]any{
-		"ProjectName": "runvoy",
-		"RequestID":   "req-123",
-		"Image":       "ubuntu:22.04",
-		"Command":     "test",
-		"Repo":        nil,
+		"ProjectName":     "runvoy",
+		"RequestID":       "req-123",
+		"Image":           "ubuntu:22.04",
+		"Command":         "test",
+		"Run":             "( test )",
+		"StopGracePeriod": 0,
+		"StdinPath":       "",
+		"ContextDir":      "",
+		"Repo":            nil,
+		"WorkDir":         "",
 	})
 
 	// Result should not start or end with whitespace
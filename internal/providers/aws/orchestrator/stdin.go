@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// CreateStdinUpload presigns an S3 PUT of exactly size bytes of standard input to the inputs bucket.
+func (t *TaskManagerImpl) CreateStdinUpload(
+	ctx context.Context,
+	uploadID string,
+	size int64,
+) (string, time.Time, error) {
+	return t.presignInputUpload(ctx, stdinObjectKey(uploadID), size)
+}
+
+func stdinObjectKey(uploadID string) string {
+	return awsConstants.StdinObjectKeyPrefix + uploadID
+}
+
+// buildStdinEnvironment returns the sidecar environment variables carrying the standard input,
+// either inline (base64-encoded) or as a presigned download URL.
+func buildStdinEnvironment(inlineStdin, stdinURL string) []ecsTypes.KeyValuePair {
+	switch {
+	case stdinURL != "":
+		return []ecsTypes.KeyValuePair{
+			{Name: awsStd.String("RUNVOY_STDIN_URL"), Value: awsStd.String(stdinURL)},
+		}
+	case inlineStdin != "":
+		return []ecsTypes.KeyValuePair{
+			{Name: awsStd.String("RUNVOY_STDIN_BASE64"), Value: awsStd.String(inlineStdin)},
+		}
+	default:
+		return nil
+	}
+}
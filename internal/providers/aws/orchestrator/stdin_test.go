@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateStdinUpload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("presigns a put of the exact size", func(t *testing.T) {
+		presignClient := &mockS3PresignClient{
+			presignPutObjectFunc: func(_ context.Context, params *s3.PutObjectInput) (*v4.PresignedHTTPRequest, error) {
+				assert.Equal(t, "inputs-bucket", awsStd.ToString(params.Bucket))
+				assert.Equal(t, "stdin/abc123", awsStd.ToString(params.Key))
+				assert.Equal(t, int64(5000), awsStd.ToInt64(params.ContentLength))
+				return &v4.PresignedHTTPRequest{URL: "https://inputs-bucket.s3.amazonaws.com/stdin/abc123?sig"}, nil
+			},
+		}
+		tm := newInputsTaskManager(presignClient, "inputs-bucket")
+
+		uploadURL, expiresAt, err := tm.CreateStdinUpload(ctx, "abc123", 5000)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://inputs-bucket.s3.amazonaws.com/stdin/abc123?sig", uploadURL)
+		assert.WithinDuration(t, time.Now().Add(awsConstants.InputUploadURLExpiry), expiresAt, time.Minute)
+	})
+
+	t.Run("fails when the inputs bucket is not configured", func(t *testing.T) {
+		tm := newInputsTaskManager(&mockS3PresignClient{}, "")
+
+		_, _, err := tm.CreateStdinUpload(ctx, "abc123", 5000)
+
+		assert.Equal(t, appErrors.ErrCodeServiceUnavailable, appErrors.GetErrorCode(err))
+	})
+
+	t.Run("fails without a presign client", func(t *testing.T) {
+		tm := newInputsTaskManager(nil, "inputs-bucket")
+
+		_, _, err := tm.CreateStdinUpload(ctx, "abc123", 5000)
+
+		assert.Equal(t, appErrors.ErrCodeServiceUnavailable, appErrors.GetErrorCode(err))
+	})
+
+	t.Run("reports presign errors", func(t *testing.T) {
+		presignClient := &mockS3PresignClient{
+			presignPutObjectFunc: func(_ context.Context, _ *s3.PutObjectInput) (*v4.PresignedHTTPRequest, error) {
+				return nil, errors.New("no credentials")
+			},
+		}
+		tm := newInputsTaskManager(presignClient, "inputs-bucket")
+
+		_, _, err := tm.CreateStdinUpload(ctx, "abc123", 5000)
+
+		assert.Equal(t, appErrors.ErrCodeInternalError, appErrors.GetErrorCode(err))
+	})
+}
+
+func TestBuildContainerOverridesWithStdin(t *testing.T) {
+	tm := newInputsTaskManager(nil, "")
+	gitConfig := &gitRepoConfig{}
+
+	t.Run("inline stdin", func(t *testing.T) {
+		req := &api.ExecutionRequest{Command: "wc -l", Stdin: "aGVsbG8K"}
+
+		overrides, _ := tm.buildContainerOverrides(context.Background(), req, gitConfig, &inputDownloadURLs{})
+
+		require.Len(t, overrides, 2)
+		assert.Contains(t, envValue(overrides[0].Environment, "RUNVOY_STDIN_BASE64"), "aGVsbG8K")
+		assert.Contains(t, overrides[0].Command[2], "base64 -d")
+		assert.Contains(t, overrides[1].Command[2], "( wc -l ) < "+awsConstants.StdinFilePath+" &")
+	})
+
+	t.Run("uploaded stdin", func(t *testing.T) {
+		req := &api.ExecutionRequest{Command: "wc -l", StdinUploadID: "abc123"}
+
+		overrides, _ := tm.buildContainerOverrides(context.Background(), req, gitConfig, &inputDownloadURLs{Stdin: "https://download"})
+
+		require.Len(t, overrides, 2)
+		assert.Equal(t, "https://download", envValue(overrides[0].Environment, "RUNVOY_STDIN_URL"))
+		assert.Empty(t, envValue(overrides[0].Environment, "RUNVOY_STDIN_BASE64"))
+		assert.Contains(t, overrides[1].Command[2], "( wc -l ) < "+awsConstants.StdinFilePath+" &")
+	})
+
+	t.Run("no stdin", func(t *testing.T) {
+		req := &api.ExecutionRequest{Command: "wc -l"}
+
+		overrides, _ := tm.buildContainerOverrides(context.Background(), req, gitConfig, &inputDownloadURLs{})
+
+		require.Len(t, overrides, 2)
+		assert.NotContains(t, overrides[0].Command[2], "STDIN_FILE_PATH")
+		assert.Contains(t, overrides[1].Command[2], "( wc -l ) &")
+	})
+}
+
+func envValue(env []ecsTypes.KeyValuePair, name string) string {
+	for _, kv := range env {
+		if awsStd.ToString(kv.Name) == name {
+			return awsStd.ToString(kv.Value)
+		}
+	}
+	return ""
+}
@@ -7,9 +7,9 @@ import (
 	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
 )
 
-// GetStandardECSTags returns the standard tags in ECS tag format.
-func GetStandardECSTags() []ecsTypes.Tag {
-	standardTags := secrets.GetStandardTags()
+// GetStandardECSTags returns the standard tags and the resource tags in ECS tag format.
+func GetStandardECSTags(resourceTags map[string]string) []ecsTypes.Tag {
+	standardTags := secrets.GetStandardTags(resourceTags)
 	tags := make([]ecsTypes.Tag, len(standardTags))
 	for i, tag := range standardTags {
 		tags[i] = ecsTypes.Tag{
@@ -11,7 +11,7 @@ import (
 )
 
 func TestGetStandardECSTags(t *testing.T) {
-	tags := GetStandardECSTags()
+	tags := GetStandardECSTags(nil)
 
 	// Should return 2 standard tags
 	assert.Len(t, tags, 2, "should return 2 standard tags")
@@ -37,7 +37,7 @@ func TestGetStandardECSTags(t *testing.T) {
 }
 
 func TestGetStandardECSTags_Format(t *testing.T) {
-	tags := GetStandardECSTags()
+	tags := GetStandardECSTags(nil)
 
 	// Ensure all tags are of the correct type
 	for _, tag := range tags {
@@ -53,8 +53,8 @@ func TestGetStandardECSTags_Format(t *testing.T) {
 
 func TestGetStandardECSTags_ConsistentOutput(t *testing.T) {
 	// Call multiple times to ensure consistency
-	tags1 := GetStandardECSTags()
-	tags2 := GetStandardECSTags()
+	tags1 := GetStandardECSTags(nil)
+	tags2 := GetStandardECSTags(nil)
 
 	assert.Len(t, tags2, len(tags1), "should return same number of tags")
 
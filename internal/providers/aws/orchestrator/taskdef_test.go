@@ -174,7 +174,7 @@ func TestBuildTaskDefinitionTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := ecsdefs.BuildTaskDefinitionTags(tt.image, tt.isDefault)
+			tags := ecsdefs.BuildTaskDefinitionTags(tt.image, tt.isDefault, nil)
 			assert.Len(t, tags, tt.expected)
 
 			// Verify DockerImage tag is always present
@@ -220,7 +220,7 @@ func TestBuildTaskDefinitionTags_Values(t *testing.T) {
 	image := "test-image:1.0"
 	isDefault := aws.Bool(true)
 
-	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefault)
+	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefault, nil)
 
 	// Verify all expected tags are present with correct values
 	tagMap := make(map[string]string)
@@ -240,7 +240,7 @@ func TestBuildTaskDefinitionTags_ECSCompatible(t *testing.T) {
 	image := "ubuntu:22.04"
 	isDefault := aws.Bool(true)
 
-	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefault)
+	tags := ecsdefs.BuildTaskDefinitionTags(image, isDefault, nil)
 
 	for _, tag := range tags {
 		assert.NotNil(t, tag.Key, "Tag key should not be nil")
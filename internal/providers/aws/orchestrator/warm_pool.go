@@ -0,0 +1,474 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/shellquote"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// A warm pool slot is a task started ahead of any execution for an image with a warm pool size.
+// Its sidecar polls a presigned URL of the slot's assignment object in the inputs bucket while the
+// runner container waits on the sidecar. Assigning an execution writes the assignment, a script
+// holding the environment and the command of the execution, with a conditional S3 write so that
+// a slot is never assigned twice. The sidecar then saves the assignment to the shared volume and
+// exits, which starts the runner with the image already pulled and the task already provisioned.
+
+// s3PreconditionFailed is the S3 error code of a conditional write whose condition does not hold.
+const s3PreconditionFailed = "PreconditionFailed"
+
+// shellVarNamePattern matches the environment variable names the assignment script can export.
+var shellVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// warmSlotState is the state of a warm pool slot derived from its task.
+type warmSlotState int
+
+const (
+	// warmSlotStarting is a slot whose task is still being provisioned or pulling images.
+	warmSlotStarting warmSlotState = iota
+	// warmSlotReady is a slot waiting for an assignment.
+	warmSlotReady
+	// warmSlotBusy is a slot that received an assignment or gave up waiting for one.
+	warmSlotBusy
+)
+
+// warmSlot is a task started as a warm pool slot.
+type warmSlot struct {
+	TaskARN   string
+	TaskID    string
+	SlotID    string
+	ImageID   string
+	CreatedAt time.Time
+	State     warmSlotState
+}
+
+// claimable reports whether the slot can still be assigned an execution.
+// Slots close to their maximum idle time are left alone, as they may give up before noticing the assignment.
+func (s *warmSlot) claimable(now time.Time) bool {
+	return s.State != warmSlotBusy &&
+		now.Before(s.CreatedAt.Add(awsConstants.WarmPoolSlotMaxIdle-awsConstants.WarmPoolClaimMargin))
+}
+
+// warmSlotObjectKey returns the S3 object key of the assignment of a warm pool slot.
+func warmSlotObjectKey(slotID string) string {
+	return awsConstants.WarmPoolObjectKeyPrefix + slotID
+}
+
+// isWarmStartEligible reports whether an execution can run on a warm pool slot.
+// Slots only run the command: executions needing the sidecar to prepare a git repository, standard input
+// or a working directory start a task of their own, and so do executions using secrets, which are never
+// written to the inputs bucket.
+func isWarmStartEligible(req *api.ExecutionRequest) bool {
+	if req.WarmPoolSize <= 0 || req.GitRepo != "" || req.Stdin != "" ||
+		req.StdinUploadID != "" || req.ContextUploadID != "" || len(req.SecretVarNames) > 0 {
+		return false
+	}
+	for name := range req.Env {
+		if !shellVarNamePattern.MatchString(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// startOnWarmSlot assigns the execution to an idle warm pool slot of its image.
+// It returns false when no slot could be assigned, in which case a task is started for the execution.
+func (t *TaskManagerImpl) startOnWarmSlot(
+	ctx context.Context, userEmail string, req *api.ExecutionRequest, reqLogger *slog.Logger,
+) (executionID string, createdAt *time.Time, ok bool) {
+	if t.s3Client == nil || t.cfg.InputsBucket == "" {
+		return "", nil, false
+	}
+
+	slots, err := t.listWarmSlots(ctx, reqLogger)
+	if err != nil {
+		reqLogger.Warn("failed to list warm pool slots, starting a new task", "error", err)
+		return "", nil, false
+	}
+
+	now := time.Now().UTC()
+	candidates := slices.DeleteFunc(slots, func(slot warmSlot) bool {
+		return slot.ImageID != req.Image || slot.State != warmSlotReady || !slot.claimable(now)
+	})
+	// The oldest slots are used first, before they reach their maximum idle time.
+	slices.SortFunc(candidates, func(a, b warmSlot) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	assignment := buildWarmAssignmentScript(req, logger.GetRequestID(ctx))
+	for _, slot := range candidates {
+		claimed, claimErr := t.claimWarmSlot(ctx, &slot, assignment, reqLogger)
+		if claimErr != nil {
+			reqLogger.Warn("failed to assign warm pool slot, starting a new task",
+				"error", claimErr, "task_arn", slot.TaskARN)
+			return "", nil, false
+		}
+		if !claimed {
+			continue
+		}
+
+		t.tagWarmSlotExecution(ctx, &slot, userEmail, reqLogger)
+		claimedAt := time.Now().UTC()
+		reqLogger.Info("task started", "context", map[string]any{
+			"user_email":   userEmail,
+			"task_arn":     slot.TaskARN,
+			"execution_id": slot.TaskID,
+			"created_at":   claimedAt.Format(time.RFC3339),
+			"start_type":   constants.MetricStartTypeWarm,
+			"image":        req.Image,
+		})
+		return slot.TaskID, &claimedAt, true
+	}
+
+	reqLogger.Info("no idle warm pool slot available, starting a new task", "image", req.Image)
+	return "", nil, false
+}
+
+// claimWarmSlot writes the assignment of a slot unless one was already written.
+// It returns false when another execution claimed the slot first.
+func (t *TaskManagerImpl) claimWarmSlot(
+	ctx context.Context, slot *warmSlot, assignment string, reqLogger *slog.Logger,
+) (bool, error) {
+	key := warmSlotObjectKey(slot.SlotID)
+	logAWSAPICall(ctx, reqLogger, "s3.PutObject", map[string]any{
+		"bucket":   t.cfg.InputsBucket,
+		"key":      key,
+		"task_arn": slot.TaskARN,
+	})
+
+	_, err := t.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      awsStd.String(t.cfg.InputsBucket),
+		Key:         awsStd.String(key),
+		Body:        strings.NewReader(assignment),
+		IfNoneMatch: awsStd.String("*"),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == s3PreconditionFailed {
+			reqLogger.Debug("warm pool slot already assigned", "task_arn", slot.TaskARN)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to write warm pool slot assignment: %w", err)
+	}
+	return true, nil
+}
+
+// tagWarmSlotExecution tags an assigned slot with the user the execution was started by,
+// like the tasks started for an execution. Failures are logged, the execution is already running.
+func (t *TaskManagerImpl) tagWarmSlotExecution(
+	ctx context.Context, slot *warmSlot, userEmail string, reqLogger *slog.Logger,
+) {
+	if _, err := t.ecsClient.TagResource(ctx, &ecs.TagResourceInput{
+		ResourceArn: awsStd.String(slot.TaskARN),
+		Tags:        []ecsTypes.Tag{{Key: awsStd.String("UserEmail"), Value: awsStd.String(userEmail)}},
+	}); err != nil {
+		reqLogger.Warn("failed to tag assigned warm pool slot", "error", err, "task_arn", slot.TaskARN)
+	}
+}
+
+// buildWarmAssignmentScript builds the script a warm pool slot runs for an execution.
+// It exports the environment of the execution, writes it to the .env file of the shared volume
+// like the sidecar of a new task does, and runs the command with the runner script.
+func buildWarmAssignmentScript(req *api.ExecutionRequest, requestID string) string {
+	var script strings.Builder
+	script.WriteString("rm -f \"$0\"\n")
+	fmt.Fprintf(&script, "export RUNVOY_COMMAND=%s\n", shellquote.Quote(req.Command))
+
+	names := slices.Sorted(maps.Keys(req.Env))
+	for _, name := range names {
+		fmt.Fprintf(&script, "export %s=%s\n", name, shellquote.Quote(req.Env[name]))
+	}
+	if len(names) > 0 {
+		envFilePath := awsConstants.SharedVolumePath + "/.env"
+		fmt.Fprintf(&script, "rm -f %s\n", shellquote.Quote(envFilePath))
+		for _, name := range names {
+			fmt.Fprintf(&script, "printf '%%s\\n' %s >> %s\n",
+				shellquote.Quote(name+"="+req.Env[name]), shellquote.Quote(envFilePath))
+		}
+	}
+
+	mainCommand := buildMainContainerCommand(req, requestID, req.Image, nil, sidecarInputs{})
+	script.WriteString(mainCommand[len(mainCommand)-1])
+	script.WriteString("\n")
+	return script.String()
+}
+
+// listWarmSlots returns the running warm pool slots of all images.
+func (t *TaskManagerImpl) listWarmSlots(ctx context.Context, reqLogger *slog.Logger) ([]warmSlot, error) {
+	var taskARNs []string
+	var nextToken *string
+	for {
+		logAWSAPICall(ctx, reqLogger, "ECS.ListTasks", map[string]any{
+			"cluster":    t.cfg.ECSCluster,
+			"started_by": awsConstants.WarmPoolStartedBy,
+		})
+		listOutput, err := t.ecsClient.ListTasks(ctx, &ecs.ListTasksInput{
+			Cluster:       awsStd.String(t.cfg.ECSCluster),
+			StartedBy:     awsStd.String(awsConstants.WarmPoolStartedBy),
+			DesiredStatus: ecsTypes.DesiredStatusRunning,
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list warm pool tasks: %w", err)
+		}
+		taskARNs = append(taskARNs, listOutput.TaskArns...)
+		if listOutput.NextToken == nil {
+			break
+		}
+		nextToken = listOutput.NextToken
+	}
+
+	slots := make([]warmSlot, 0, len(taskARNs))
+	for batch := range slices.Chunk(taskARNs, awsConstants.ECSDescribeTasksMaxTasks) {
+		logAWSAPICall(ctx, reqLogger, "ECS.DescribeTasks", map[string]any{
+			"cluster":    t.cfg.ECSCluster,
+			"task_count": len(batch),
+		})
+		describeOutput, err := t.ecsClient.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: awsStd.String(t.cfg.ECSCluster),
+			Tasks:   batch,
+			Include: []ecsTypes.TaskField{ecsTypes.TaskFieldTags},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe warm pool tasks: %w", err)
+		}
+		for i := range describeOutput.Tasks {
+			if slot, ok := warmSlotFromTask(&describeOutput.Tasks[i]); ok {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots, nil
+}
+
+// warmSlotFromTask reads the warm pool slot of a task from its tags and container states.
+// Tasks without the warm pool tags are not slots.
+func warmSlotFromTask(task *ecsTypes.Task) (warmSlot, bool) {
+	slot := warmSlot{
+		TaskARN: awsStd.ToString(task.TaskArn),
+		State:   warmSlotStarting,
+	}
+	for _, tag := range task.Tags {
+		switch awsStd.ToString(tag.Key) {
+		case awsConstants.WarmPoolSlotTagKey:
+			slot.SlotID = awsStd.ToString(tag.Value)
+		case awsConstants.WarmPoolImageIDTagKey:
+			slot.ImageID = awsStd.ToString(tag.Value)
+		}
+	}
+	if slot.SlotID == "" || slot.ImageID == "" {
+		return warmSlot{}, false
+	}
+	parts := strings.Split(slot.TaskARN, "/")
+	slot.TaskID = parts[len(parts)-1]
+	if task.CreatedAt != nil {
+		slot.CreatedAt = *task.CreatedAt
+	}
+
+	switch awsConstants.EcsStatus(awsStd.ToString(task.LastStatus)) { //nolint:exhaustive // other statuses are busy
+	case awsConstants.EcsStatusProvisioning, awsConstants.EcsStatusPending, awsConstants.EcsStatusActivating:
+		return slot, true
+	case awsConstants.EcsStatusRunning:
+		slot.State = warmSlotBusy
+		for _, container := range task.Containers {
+			if awsStd.ToString(container.Name) == awsConstants.SidecarContainerName &&
+				awsConstants.EcsStatus(awsStd.ToString(container.LastStatus)) == awsConstants.EcsStatusRunning {
+				slot.State = warmSlotReady
+			}
+		}
+	default:
+		slot.State = warmSlotBusy
+	}
+	return slot, true
+}
+
+// ReplenishWarmPools keeps the number of idle warm pool slots of every image at its warm pool size.
+// Missing slots are started and surplus idle slots, including those of images whose warm pool was
+// disabled or which were deleted, are stopped. Assigned slots are never stopped.
+func (t *TaskManagerImpl) ReplenishWarmPools(ctx context.Context) (started, stopped int, err error) {
+	if t.ecsClient == nil || t.imageRepo == nil {
+		return 0, 0, appErrors.ErrInternalError("warm pools are not configured", nil)
+	}
+	reqLogger := logger.DeriveRequestLogger(ctx, t.logger)
+
+	images, err := t.imageRepo.ListImages(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list images: %w", err)
+	}
+	slots, err := t.listWarmSlots(ctx, reqLogger)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := time.Now().UTC()
+	idleSlots := make(map[string][]warmSlot)
+	for _, slot := range slots {
+		if slot.claimable(now) {
+			idleSlots[slot.ImageID] = append(idleSlots[slot.ImageID], slot)
+		}
+	}
+
+	var errs []error
+	for i := range images {
+		image := &images[i]
+		if image.DeletedAt != nil {
+			continue // Its idle slots are stopped with the orphaned ones
+		}
+		idle := idleSlots[image.ImageID]
+		delete(idleSlots, image.ImageID)
+
+		if missing := image.WarmPoolSize - len(idle); missing > 0 {
+			n, launchErr := t.startWarmSlots(ctx, image, missing, reqLogger)
+			started += n
+			errs = append(errs, launchErr)
+			continue
+		}
+		// The most recent slots are kept, they have the longest time left before they stop on their own.
+		slices.SortFunc(idle, func(a, b warmSlot) int { return b.CreatedAt.Compare(a.CreatedAt) })
+		n, stopErr := t.stopWarmSlots(ctx, idle[image.WarmPoolSize:], reqLogger)
+		stopped += n
+		errs = append(errs, stopErr)
+	}
+	for _, orphaned := range idleSlots {
+		n, stopErr := t.stopWarmSlots(ctx, orphaned, reqLogger)
+		stopped += n
+		errs = append(errs, stopErr)
+	}
+
+	return started, stopped, errors.Join(errs...)
+}
+
+// startWarmSlots starts count warm pool slots for an image and returns how many were started.
+func (t *TaskManagerImpl) startWarmSlots(
+	ctx context.Context, image *api.ImageInfo, count int, reqLogger *slog.Logger,
+) (int, error) {
+	if t.presignClient == nil || t.cfg.InputsBucket == "" {
+		return 0, appErrors.ErrServiceUnavailable("execution input uploads are not configured", nil)
+	}
+
+	started := 0
+	for range count {
+		if err := t.startWarmSlot(ctx, image, reqLogger); err != nil {
+			return started, fmt.Errorf("failed to start warm pool slot for image %s: %w", image.ImageID, err)
+		}
+		started++
+	}
+	return started, nil
+}
+
+// startWarmSlot starts a single warm pool slot for an image.
+// Every slot waits on an assignment object of its own, so slots are started one RunTask call at a time.
+func (t *TaskManagerImpl) startWarmSlot(ctx context.Context, image *api.ImageInfo, reqLogger *slog.Logger) error {
+	slotID := auth.GenerateUUID()
+	key := warmSlotObjectKey(slotID)
+	logAWSAPICall(ctx, reqLogger, "s3.PresignGetObject", map[string]any{
+		"bucket": t.cfg.InputsBucket,
+		"key":    key,
+	})
+	presigned, err := t.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: awsStd.String(t.cfg.InputsBucket),
+		Key:    awsStd.String(key),
+	}, s3.WithPresignExpires(awsConstants.WarmPoolSlotMaxIdle))
+	if err != nil {
+		return fmt.Errorf("failed to presign warm pool slot assignment: %w", err)
+	}
+
+	containerOverrides := []ecsTypes.ContainerOverride{
+		{
+			Name:    awsStd.String(awsConstants.SidecarContainerName),
+			Command: buildWarmSidecarContainerCommand(),
+			Environment: []ecsTypes.KeyValuePair{
+				{
+					Name:  awsStd.String("RUNVOY_SHARED_VOLUME_PATH"),
+					Value: awsStd.String(awsConstants.SharedVolumePath),
+				},
+				{Name: awsStd.String("RUNVOY_WARM_ASSIGNMENT_URL"), Value: awsStd.String(presigned.URL)},
+			},
+		},
+		{
+			Name:    awsStd.String(awsConstants.RunnerContainerName),
+			Command: []string{"/bin/sh", "-c", "exec /bin/sh " + awsConstants.WarmPoolAssignmentPath},
+		},
+	}
+	runTaskInput := t.buildRunTaskInput("", image.TaskDefinitionName, containerOverrides, false)
+	runTaskInput.StartedBy = awsStd.String(awsConstants.WarmPoolStartedBy)
+	runTaskInput.Tags = append(GetStandardECSTags(t.cfg.ResourceTags),
+		ecsTypes.Tag{Key: awsStd.String(awsConstants.WarmPoolSlotTagKey), Value: awsStd.String(slotID)},
+		ecsTypes.Tag{Key: awsStd.String(awsConstants.WarmPoolImageIDTagKey), Value: awsStd.String(image.ImageID)},
+	)
+
+	_, _, taskARN, err := t.executeTask(ctx, runTaskInput, image.ImageID, reqLogger)
+	if err != nil {
+		return err
+	}
+
+	reqLogger.Info("warm pool slot started", "context", map[string]string{
+		"image_id": image.ImageID,
+		"slot_id":  slotID,
+		"task_arn": taskARN,
+	})
+	return nil
+}
+
+// stopWarmSlots stops idle warm pool slots and returns how many were stopped.
+func (t *TaskManagerImpl) stopWarmSlots(
+	ctx context.Context, slots []warmSlot, reqLogger *slog.Logger,
+) (int, error) {
+	stopped := 0
+	for _, slot := range slots {
+		logAWSAPICall(ctx, reqLogger, "ECS.StopTask", map[string]any{
+			"cluster":  t.cfg.ECSCluster,
+			"task_arn": slot.TaskARN,
+		})
+		if _, err := t.ecsClient.StopTask(ctx, &ecs.StopTaskInput{
+			Cluster: awsStd.String(t.cfg.ECSCluster),
+			Task:    awsStd.String(slot.TaskARN),
+			Reason:  awsStd.String("Surplus warm pool slot"),
+		}); err != nil {
+			return stopped, fmt.Errorf("failed to stop warm pool slot %s: %w", slot.TaskARN, err)
+		}
+		stopped++
+		reqLogger.Info("warm pool slot stopped", "context", map[string]string{
+			"image_id": slot.ImageID,
+			"slot_id":  slot.SlotID,
+			"task_arn": slot.TaskARN,
+		})
+	}
+	return stopped, nil
+}
+
+type warmSidecarScriptData struct {
+	ProjectName         string
+	AssignmentPath      string
+	MaxIdleSeconds      int
+	PollIntervalSeconds int
+}
+
+// buildWarmSidecarContainerCommand constructs the shell command of the sidecar of a warm pool slot.
+// It waits for the slot's assignment and saves it to the shared volume for the runner.
+func buildWarmSidecarContainerCommand() []string {
+	script := renderScript("warm_sidecar.sh.tmpl", warmSidecarScriptData{
+		ProjectName:         constants.ProjectName,
+		AssignmentPath:      awsConstants.WarmPoolAssignmentPath,
+		MaxIdleSeconds:      int(awsConstants.WarmPoolSlotMaxIdle.Seconds()),
+		PollIntervalSeconds: awsConstants.WarmPoolPollIntervalSeconds,
+	})
+	return []string{"/bin/sh", "-c", script}
+}
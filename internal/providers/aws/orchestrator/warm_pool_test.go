@@ -0,0 +1,367 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	awsStd "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockS3Client struct {
+	putObjectFunc func(context.Context, *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+}
+
+func (m *mockS3Client) PutObject(
+	ctx context.Context,
+	params *s3.PutObjectInput,
+	_ ...func(*s3.Options),
+) (*s3.PutObjectOutput, error) {
+	if m.putObjectFunc != nil {
+		return m.putObjectFunc(ctx, params)
+	}
+	return &s3.PutObjectOutput{}, nil
+}
+
+// warmSlotTask builds the ECS description of a warm pool slot task.
+func warmSlotTask(taskID, slotID, imageID, lastStatus, sidecarStatus string, createdAt time.Time) ecsTypes.Task {
+	return ecsTypes.Task{
+		TaskArn:    awsStd.String("arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/" + taskID),
+		LastStatus: awsStd.String(lastStatus),
+		CreatedAt:  awsStd.Time(createdAt),
+		Containers: []ecsTypes.Container{
+			{Name: awsStd.String(awsConstants.SidecarContainerName), LastStatus: awsStd.String(sidecarStatus)},
+			{Name: awsStd.String(awsConstants.RunnerContainerName), LastStatus: awsStd.String("PENDING")},
+		},
+		Tags: []ecsTypes.Tag{
+			{Key: awsStd.String(awsConstants.WarmPoolSlotTagKey), Value: awsStd.String(slotID)},
+			{Key: awsStd.String(awsConstants.WarmPoolImageIDTagKey), Value: awsStd.String(imageID)},
+		},
+	}
+}
+
+// newWarmPoolECSClient returns an ECS client listing the given warm pool slot tasks.
+func newWarmPoolECSClient(tasks ...ecsTypes.Task) *mockECSClient {
+	return &mockECSClient{
+		listTasksFunc: func(
+			_ context.Context, params *ecs.ListTasksInput, _ ...func(*ecs.Options),
+		) (*ecs.ListTasksOutput, error) {
+			if awsStd.ToString(params.StartedBy) != awsConstants.WarmPoolStartedBy {
+				return nil, errors.New("unexpected startedBy filter")
+			}
+			arns := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				arns = append(arns, awsStd.ToString(task.TaskArn))
+			}
+			return &ecs.ListTasksOutput{TaskArns: arns}, nil
+		},
+		describeTasksFunc: func(
+			_ context.Context, params *ecs.DescribeTasksInput, _ ...func(*ecs.Options),
+		) (*ecs.DescribeTasksOutput, error) {
+			if len(params.Include) != 1 || params.Include[0] != ecsTypes.TaskFieldTags {
+				return nil, errors.New("tags not included")
+			}
+			return &ecs.DescribeTasksOutput{Tasks: tasks}, nil
+		},
+	}
+}
+
+func TestIsWarmStartEligible(t *testing.T) {
+	tests := []struct {
+		name string
+		req  api.ExecutionRequest
+		want bool
+	}{
+		{"command with environment", api.ExecutionRequest{WarmPoolSize: 1, Env: map[string]string{"FOO": "bar"}}, true},
+		{"image without warm pool", api.ExecutionRequest{}, false},
+		{"git repository", api.ExecutionRequest{WarmPoolSize: 1, GitRepo: "https://github.com/runvoy/runvoy"}, false},
+		{"inline stdin", api.ExecutionRequest{WarmPoolSize: 1, Stdin: "input"}, false},
+		{"uploaded stdin", api.ExecutionRequest{WarmPoolSize: 1, StdinUploadID: "upload"}, false},
+		{"uploaded context", api.ExecutionRequest{WarmPoolSize: 1, ContextUploadID: "upload"}, false},
+		{"secrets", api.ExecutionRequest{WarmPoolSize: 1, SecretVarNames: []string{"TOKEN"}}, false},
+		{"invalid variable name", api.ExecutionRequest{WarmPoolSize: 1, Env: map[string]string{"A-B": "c"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isWarmStartEligible(&tt.req))
+		})
+	}
+}
+
+func TestBuildWarmAssignmentScript(t *testing.T) {
+	req := &api.ExecutionRequest{
+		Command: "echo \"$GREETING\"",
+		Image:   "alpine:latest-a1b2c3d4",
+		Env:     map[string]string{"GREETING": "it's warm", "B": "2"},
+	}
+
+	script := buildWarmAssignmentScript(req, "req-123")
+
+	assert.True(t, strings.HasPrefix(script, "rm -f \"$0\"\n"))
+	assert.Contains(t, script, `export RUNVOY_COMMAND='echo "$GREETING"'`)
+	assert.Less(t, strings.Index(script, "export B='2'"), strings.Index(script, `export GREETING='it'\''s warm'`))
+	assert.Contains(t, script, `printf '%s\n' 'GREETING=it'\''s warm' >> /workspace/.env`)
+	assert.Contains(t, script, `command => %s\n' 'echo "$GREETING"'`, "the logged command expands nothing")
+	assert.Contains(t, script, "execution started by requestID => %s\\n' req-123")
+
+	if _, err := exec.LookPath("sh"); err == nil {
+		out, runErr := exec.Command("sh", "-n", "-c", script).CombinedOutput()
+		assert.NoError(t, runErr, "assignment script must be valid shell: %s", out)
+	}
+}
+
+func TestWarmSlotFromTask(t *testing.T) {
+	createdAt := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		lastStatus    string
+		sidecarStatus string
+		want          warmSlotState
+	}{
+		{"provisioning", "PROVISIONING", "PENDING", warmSlotStarting},
+		{"waiting for an assignment", "RUNNING", "RUNNING", warmSlotReady},
+		{"assigned", "RUNNING", "STOPPED", warmSlotBusy},
+		{"stopping", "STOPPING", "STOPPED", warmSlotBusy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := warmSlotTask("task-1", "slot-1", "image-1", tt.lastStatus, tt.sidecarStatus, createdAt)
+
+			slot, ok := warmSlotFromTask(&task)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.want, slot.State)
+			assert.Equal(t, "task-1", slot.TaskID)
+			assert.Equal(t, "slot-1", slot.SlotID)
+			assert.Equal(t, "image-1", slot.ImageID)
+		})
+	}
+
+	t.Run("task without warm pool tags", func(t *testing.T) {
+		task := ecsTypes.Task{TaskArn: awsStd.String("arn:aws:ecs:us-east-1:123456789012:task/cluster/other")}
+		_, ok := warmSlotFromTask(&task)
+		assert.False(t, ok)
+	})
+}
+
+func TestWarmSlotClaimable(t *testing.T) {
+	now := time.Now().UTC()
+	fresh := warmSlot{State: warmSlotReady, CreatedAt: now.Add(-time.Minute)}
+	expiring := warmSlot{State: warmSlotReady, CreatedAt: now.Add(-awsConstants.WarmPoolSlotMaxIdle + time.Minute)}
+	busy := warmSlot{State: warmSlotBusy, CreatedAt: now}
+
+	assert.True(t, fresh.claimable(now))
+	assert.False(t, expiring.claimable(now))
+	assert.False(t, busy.claimable(now))
+}
+
+func TestStartTask_AssignsWarmPoolSlot(t *testing.T) {
+	now := time.Now().UTC()
+	ecsClient := newWarmPoolECSClient(
+		warmSlotTask("taken", "slot-taken", "alpine:latest-a1b2c3d4", "RUNNING", "RUNNING", now.Add(-3*time.Minute)),
+		warmSlotTask("other-image", "slot-other", "ubuntu:22.04-0f1e2d3c", "RUNNING", "RUNNING", now.Add(-4*time.Minute)),
+		warmSlotTask("free", "slot-free", "alpine:latest-a1b2c3d4", "RUNNING", "RUNNING", now.Add(-2*time.Minute)),
+	)
+	var taggedARN string
+	ecsClient.tagResourceFunc = func(
+		_ context.Context, params *ecs.TagResourceInput, _ ...func(*ecs.Options),
+	) (*ecs.TagResourceOutput, error) {
+		taggedARN = awsStd.ToString(params.ResourceArn)
+		return &ecs.TagResourceOutput{}, nil
+	}
+	ecsClient.runTaskFunc = func(
+		_ context.Context, _ *ecs.RunTaskInput, _ ...func(*ecs.Options),
+	) (*ecs.RunTaskOutput, error) {
+		return nil, errors.New("a warm start must not start a task")
+	}
+
+	var claimedKeys []string
+	var assignment string
+	s3Client := &mockS3Client{
+		putObjectFunc: func(_ context.Context, params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			assert.Equal(t, "*", awsStd.ToString(params.IfNoneMatch))
+			claimedKeys = append(claimedKeys, awsStd.ToString(params.Key))
+			if awsStd.ToString(params.Key) == warmSlotObjectKey("slot-taken") {
+				return nil, &smithy.GenericAPIError{Code: s3PreconditionFailed}
+			}
+			body, _ := io.ReadAll(params.Body)
+			assignment = string(body)
+			return &s3.PutObjectOutput{}, nil
+		},
+	}
+	tm := &TaskManagerImpl{
+		ecsClient: ecsClient,
+		s3Client:  s3Client,
+		imageRepo: &mockImageRepo{
+			getImageTaskDefByIDFunc: func(_ context.Context, imageID string) (*api.ImageInfo, error) {
+				return &api.ImageInfo{ImageID: imageID, TaskDefinitionName: "runvoy-image-1"}, nil
+			},
+		},
+		cfg:    &Config{ECSCluster: "runvoy-cluster", InputsBucket: "inputs"},
+		logger: testutil.SilentLogger(),
+	}
+
+	executionID, createdAt, err := tm.StartTask(context.Background(), "user@example.com", &api.ExecutionRequest{
+		Command:      "make test",
+		Image:        "alpine:latest-a1b2c3d4",
+		WarmPoolSize: 2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "free", executionID)
+	require.NotNil(t, createdAt)
+	assert.WithinDuration(t, time.Now(), *createdAt, time.Minute)
+	assert.Equal(t, []string{warmSlotObjectKey("slot-taken"), warmSlotObjectKey("slot-free")}, claimedKeys,
+		"the oldest slots of the image are claimed first")
+	assert.Contains(t, assignment, "export RUNVOY_COMMAND='make test'")
+	assert.True(t, strings.HasSuffix(taggedARN, "/free"))
+}
+
+func TestStartTask_FallsBackToNewTaskWithoutWarmSlot(t *testing.T) {
+	ecsClient := newWarmPoolECSClient()
+	runTaskCalled := false
+	ecsClient.runTaskFunc = func(
+		_ context.Context, _ *ecs.RunTaskInput, _ ...func(*ecs.Options),
+	) (*ecs.RunTaskOutput, error) {
+		runTaskCalled = true
+		return &ecs.RunTaskOutput{Tasks: []ecsTypes.Task{{
+			TaskArn:   awsStd.String("arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/cold"),
+			CreatedAt: awsStd.Time(time.Now()),
+		}}}, nil
+	}
+	tm := &TaskManagerImpl{
+		ecsClient: ecsClient,
+		s3Client:  &mockS3Client{},
+		imageRepo: &mockImageRepo{
+			getImageTaskDefByIDFunc: func(_ context.Context, imageID string) (*api.ImageInfo, error) {
+				return &api.ImageInfo{ImageID: imageID, TaskDefinitionName: "runvoy-image-1"}, nil
+			},
+		},
+		cfg:    &Config{ECSCluster: "runvoy-cluster", InputsBucket: "inputs"},
+		logger: testutil.SilentLogger(),
+	}
+
+	executionID, _, err := tm.StartTask(context.Background(), "user@example.com", &api.ExecutionRequest{
+		Command:      "make test",
+		Image:        "alpine:latest-a1b2c3d4",
+		WarmPoolSize: 1,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, runTaskCalled)
+	assert.Equal(t, "cold", executionID)
+}
+
+func TestReplenishWarmPools(t *testing.T) {
+	now := time.Now().UTC()
+	ecsClient := newWarmPoolECSClient(
+		// image-1 wants 3 slots: one idle and one still starting count, the assigned one does not.
+		warmSlotTask("idle-1", "slot-1", "image-1", "RUNNING", "RUNNING", now.Add(-time.Minute)),
+		warmSlotTask("starting-1", "slot-2", "image-1", "PENDING", "PENDING", now),
+		warmSlotTask("assigned-1", "slot-3", "image-1", "RUNNING", "STOPPED", now.Add(-2*time.Minute)),
+		// image-2 wants 1 slot: the oldest of its 2 idle slots is surplus.
+		warmSlotTask("idle-2-old", "slot-4", "image-2", "RUNNING", "RUNNING", now.Add(-10*time.Minute)),
+		warmSlotTask("idle-2-new", "slot-5", "image-2", "RUNNING", "RUNNING", now.Add(-time.Minute)),
+		// image-3 is no longer registered.
+		warmSlotTask("idle-3", "slot-6", "image-3", "RUNNING", "RUNNING", now.Add(-time.Minute)),
+		// image-5 was deleted, its warm pool is no longer kept.
+		warmSlotTask("idle-5", "slot-7", "image-5", "RUNNING", "RUNNING", now.Add(-time.Minute)),
+	)
+	var started []*ecs.RunTaskInput
+	ecsClient.runTaskFunc = func(
+		_ context.Context, params *ecs.RunTaskInput, _ ...func(*ecs.Options),
+	) (*ecs.RunTaskOutput, error) {
+		started = append(started, params)
+		return &ecs.RunTaskOutput{Tasks: []ecsTypes.Task{{
+			TaskArn:   awsStd.String("arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/new"),
+			CreatedAt: awsStd.Time(now),
+		}}}, nil
+	}
+	var stoppedARNs []string
+	ecsClient.stopTaskFunc = func(
+		_ context.Context, params *ecs.StopTaskInput, _ ...func(*ecs.Options),
+	) (*ecs.StopTaskOutput, error) {
+		stoppedARNs = append(stoppedARNs, awsStd.ToString(params.Task))
+		return &ecs.StopTaskOutput{}, nil
+	}
+	var presignedKeys []string
+	tm := &TaskManagerImpl{
+		ecsClient: ecsClient,
+		presignClient: &mockS3PresignClient{
+			presignGetObjectFunc: func(_ context.Context, params *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error) {
+				presignedKeys = append(presignedKeys, awsStd.ToString(params.Key))
+				return &v4.PresignedHTTPRequest{URL: "https://inputs.s3.amazonaws.com/assignment"}, nil
+			},
+		},
+		imageRepo: &mockImageRepo{
+			listImagesFunc: func(_ context.Context) ([]api.ImageInfo, error) {
+				return []api.ImageInfo{
+					{ImageID: "image-1", TaskDefinitionName: "runvoy-image-1", WarmPoolSize: 3},
+					{ImageID: "image-2", TaskDefinitionName: "runvoy-image-2", WarmPoolSize: 1},
+					{ImageID: "image-4", TaskDefinitionName: "runvoy-image-4"},
+					{ImageID: "image-5", TaskDefinitionName: "runvoy-image-5", WarmPoolSize: 1, DeletedAt: &now},
+				}, nil
+			},
+		},
+		cfg:    &Config{ECSCluster: "runvoy-cluster", InputsBucket: "inputs"},
+		logger: testutil.SilentLogger(),
+	}
+
+	startedCount, stoppedCount, err := tm.ReplenishWarmPools(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, startedCount)
+	assert.Equal(t, 3, stoppedCount)
+	require.Len(t, started, 1)
+	runTask := started[0]
+	assert.Equal(t, "runvoy-image-1", awsStd.ToString(runTask.TaskDefinition))
+	assert.Equal(t, awsConstants.WarmPoolStartedBy, awsStd.ToString(runTask.StartedBy))
+	require.Len(t, presignedKeys, 1)
+	assert.Contains(t, runTask.Tags, ecsTypes.Tag{
+		Key:   awsStd.String(awsConstants.WarmPoolSlotTagKey),
+		Value: awsStd.String(strings.TrimPrefix(presignedKeys[0], awsConstants.WarmPoolObjectKeyPrefix)),
+	})
+	sidecar := runTask.Overrides.ContainerOverrides[0]
+	assert.Equal(t, awsConstants.SidecarContainerName, awsStd.ToString(sidecar.Name))
+	assert.Contains(t, sidecar.Command[2], "RUNVOY_WARM_ASSIGNMENT_URL")
+	assert.ElementsMatch(t, []string{
+		"arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/idle-2-old",
+		"arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/idle-3",
+		"arn:aws:ecs:us-east-1:123456789012:task/runvoy-cluster/idle-5",
+	}, stoppedARNs)
+}
+
+func TestReplenishWarmPools_ListError(t *testing.T) {
+	tm := &TaskManagerImpl{
+		ecsClient: &mockECSClient{},
+		imageRepo: &mockImageRepo{
+			listImagesFunc: func(_ context.Context) ([]api.ImageInfo, error) {
+				return nil, errors.New("throttled")
+			},
+		},
+		cfg:    &Config{},
+		logger: testutil.SilentLogger(),
+	}
+
+	_, _, err := tm.ReplenishWarmPools(context.Background())
+
+	assert.ErrorContains(t, err, "throttled")
+}
@@ -22,6 +22,11 @@ import (
 type mockExecutionRepo struct {
 	getExecutionFunc    func(ctx context.Context, executionID string) (*api.Execution, error)
 	updateExecutionFunc func(ctx context.Context, execution *api.Execution) error
+	listExecutionsFunc  func(ctx context.Context, limit int, statuses []string) ([]*api.Execution, error)
+	addLogUsageFunc     func(ctx context.Context, executionID string, usage *api.LogUsage) error
+	addEventsFunc       func(ctx context.Context, executionID string, events []api.ExecutionEvent) error
+	markSLOBreachedFunc func(ctx context.Context, executionID string) error
+	setResultFunc       func(ctx context.Context, executionID string, result json.RawMessage) error
 }
 
 func (m *mockExecutionRepo) GetExecution(ctx context.Context, executionID string) (*api.Execution, error) {
@@ -42,7 +47,22 @@ func (m *mockExecutionRepo) CreateExecution(_ context.Context, _ *api.Execution)
 	return nil
 }
 
-func (m *mockExecutionRepo) ListExecutions(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+func (m *mockExecutionRepo) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return nil
+}
+
+func (m *mockExecutionRepo) ListExecutions(
+	ctx context.Context, limit int, statuses []string,
+) ([]*api.Execution, error) {
+	if m.listExecutionsFunc != nil {
+		return m.listExecutionsFunc(ctx, limit, statuses)
+	}
+	return nil, nil
+}
+
+func (m *mockExecutionRepo) ListExecutionsByCreator(
+	_ context.Context, _ string, _ int, _ []string,
+) ([]*api.Execution, error) {
 	return nil, nil
 }
 
@@ -50,6 +70,90 @@ func (m *mockExecutionRepo) GetExecutionsByRequestID(_ context.Context, _ string
 	return nil, nil
 }
 
+func (m *mockExecutionRepo) GetExecutionsByGroupID(_ context.Context, _ string) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepo) AddLogUsage(ctx context.Context, executionID string, usage *api.LogUsage) error {
+	if m.addLogUsageFunc != nil {
+		return m.addLogUsageFunc(ctx, executionID, usage)
+	}
+	return nil
+}
+
+func (m *mockExecutionRepo) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepo) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepo) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockExecutionRepo) MarkExecutionSLOBreached(ctx context.Context, executionID string) error {
+	if m.markSLOBreachedFunc != nil {
+		return m.markSLOBreachedFunc(ctx, executionID)
+	}
+	return nil
+}
+
+func (m *mockExecutionRepo) SetExecutionResult(ctx context.Context, executionID string, result json.RawMessage) error {
+	if m.setResultFunc != nil {
+		return m.setResultFunc(ctx, executionID, result)
+	}
+	return nil
+}
+
+func (m *mockExecutionRepo) AddExecutionEvents(
+	ctx context.Context, executionID string, events []api.ExecutionEvent,
+) error {
+	if m.addEventsFunc != nil {
+		return m.addEventsFunc(ctx, executionID, events)
+	}
+	return nil
+}
+
+func (m *mockExecutionRepo) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, nil
+}
+
+func (m *mockExecutionRepo) AddExecutionAnnotation(_ context.Context, _ string, _ *api.ExecutionAnnotation) error {
+	return nil
+}
+
+// Mock task manager for testing
+type mockTaskManager struct {
+	killTaskFunc func(ctx context.Context, executionID string) error
+}
+
+func (m *mockTaskManager) StartTask(
+	_ context.Context, _ string, _ *api.ExecutionRequest,
+) (string, *time.Time, error) {
+	return "", nil, nil
+}
+
+func (m *mockTaskManager) KillTask(ctx context.Context, executionID string) error {
+	if m.killTaskFunc != nil {
+		return m.killTaskFunc(ctx, executionID)
+	}
+	return nil
+}
+
+func (m *mockTaskManager) CreateStdinUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func (m *mockTaskManager) CreateContextUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
 // Mock WebSocket handler for testing
 type mockWebSocketHandler struct {
 	handleRequestFunc             func(ctx context.Context, rawEvent *json.RawMessage, logger *slog.Logger) (bool, error)
@@ -72,6 +176,10 @@ func (m *mockWebSocketHandler) NotifyExecutionCompletion(ctx context.Context, ex
 	return nil
 }
 
+func (m *mockWebSocketHandler) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (m *mockWebSocketHandler) SendLogsToExecution(
 	ctx context.Context, executionID *string) error {
 	if m.sendLogsFunc != nil {
@@ -302,7 +410,7 @@ func TestHandleECSTaskCompletion_Success(t *testing.T) {
 		},
 	}
 
-	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, testutil.SilentLogger())
+	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, testutil.SilentLogger())
 
 	taskEvent := ECSTaskStateChangeEvent{
 		TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/test-exec-123",
@@ -365,7 +473,7 @@ func TestHandleECSTaskCompletion_MarkRunning(t *testing.T) {
 		},
 	}
 
-	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, testutil.SilentLogger())
+	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, testutil.SilentLogger())
 
 	taskEvent := ECSTaskStateChangeEvent{
 		TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/run-exec-123",
@@ -397,7 +505,7 @@ func TestHandleECSTaskCompletion_OrphanedTask(t *testing.T) {
 
 	mockWebSocket := &mockWebSocketHandler{}
 
-	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, testutil.SilentLogger())
+	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, testutil.SilentLogger())
 
 	exitCode := 0
 	taskEvent := ECSTaskStateChangeEvent{
@@ -459,7 +567,7 @@ func TestHandleECSTaskCompletion_MissingStartedAt(t *testing.T) {
 		},
 	}
 
-	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, testutil.SilentLogger())
+	backend := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, testutil.SilentLogger())
 
 	taskEvent := ECSTaskStateChangeEvent{
 		TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/test-exec-123",
@@ -581,7 +689,7 @@ func TestHandle_EventRouting(t *testing.T) {
 			},
 		}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		// Test with actual CloudWatch event
 		event := events.CloudWatchEvent{
@@ -608,7 +716,7 @@ func TestHandle_EventRouting(t *testing.T) {
 	t.Run("routes CloudWatch Logs event correctly", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		// Create a CloudWatch Logs event (will fail parsing but should route correctly)
 		logsEvent := events.CloudwatchLogsEvent{
@@ -630,7 +738,7 @@ func TestHandle_EventRouting(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
 
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		wsEvent := events.APIGatewayWebsocketProxyRequest{
 			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
@@ -651,7 +759,7 @@ func TestHandle_EventRouting(t *testing.T) {
 	t.Run("returns error for unhandled event type", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		rawEvent := json.RawMessage(`{"unknown": "event", "type": "not_supported"}`)
 
@@ -666,7 +774,7 @@ func TestHandle_EventValidation(t *testing.T) {
 	logger := testutil.SilentLogger()
 	mockRepo := &mockExecutionRepo{}
 	mockWebSocket := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 	t.Run("handles invalid JSON", func(t *testing.T) {
 		rawEvent := json.RawMessage(`invalid json{`)
@@ -718,8 +826,7 @@ func TestHandle_EventValidation(t *testing.T) {
 		rawEvent := json.RawMessage(eventJSON)
 
 		_, err := processor.Handle(ctx, &rawEvent)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unhandled event type")
+		assert.ErrorIs(t, err, ErrInvalidEvent)
 	})
 
 	t.Run("handles WebSocket event with missing route key", func(t *testing.T) {
@@ -762,7 +869,7 @@ func TestHandle_ErrorHandling(t *testing.T) {
 			},
 		}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		taskEvent := ECSTaskStateChangeEvent{
 			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/test-123",
@@ -802,7 +909,7 @@ func TestHandle_ErrorHandling(t *testing.T) {
 			},
 		}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		taskEvent := ECSTaskStateChangeEvent{
 			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/test-exec-123",
@@ -839,7 +946,7 @@ func TestHandle_ErrorHandling(t *testing.T) {
 				return false, errors.New("websocket connection failed")
 			},
 		}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		wsEvent := events.APIGatewayWebsocketProxyRequest{
 			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
@@ -867,10 +974,10 @@ func TestHandle_ErrorHandling(t *testing.T) {
 			},
 		}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
-		// Test with minimal detail - empty taskArn
-		detailJSON := json.RawMessage(`{}`)
+		// Test with minimal detail - taskArn only
+		detailJSON := json.RawMessage(`{"taskArn": "arn:aws:ecs:us-east-1:123456789:task/cluster/orphan"}`)
 		event := events.CloudWatchEvent{
 			DetailType: "ECS Task State Change",
 			Source:     "aws.ecs",
@@ -881,15 +988,22 @@ func TestHandle_ErrorHandling(t *testing.T) {
 		rawEvent := json.RawMessage(eventJSON)
 
 		// Should route to ECS handler (no "unhandled event type" error)
-		// Empty taskArn means empty executionID, execution will be nil, returns nil (no error)
+		// The execution is nil, returns nil (no error)
 		_, err := processor.Handle(ctx, &rawEvent)
 		assert.NoError(t, err) // Orphaned tasks handled gracefully
+
+		// Without a task ARN the event is rejected by the schema
+		event.Detail = json.RawMessage(`{}`)
+		eventJSON, _ = json.Marshal(event)
+		rawEvent = json.RawMessage(eventJSON)
+		_, err = processor.Handle(ctx, &rawEvent)
+		assert.ErrorIs(t, err, ErrInvalidEvent)
 	})
 
 	t.Run("handles CloudWatch Logs parsing error", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		logsEvent := events.CloudwatchLogsEvent{
 			AWSLogs: events.CloudwatchLogsRawData{
@@ -912,7 +1026,7 @@ func TestHandleLogsEvent_Scenarios(t *testing.T) {
 	t.Run("handles logs event parsing error gracefully", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		// Invalid base64 data that will fail to parse
 		logsEvent := events.CloudwatchLogsEvent{
@@ -932,7 +1046,7 @@ func TestHandleLogsEvent_Scenarios(t *testing.T) {
 	t.Run("handles empty logs data", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		logsEvent := events.CloudwatchLogsEvent{
 			AWSLogs: events.CloudwatchLogsRawData{
@@ -943,10 +1057,9 @@ func TestHandleLogsEvent_Scenarios(t *testing.T) {
 		eventJSON, _ := json.Marshal(logsEvent)
 		rawEvent := json.RawMessage(eventJSON)
 
-		// Should not be recognized as logs event
+		// Should be rejected by the logs event schema
 		_, err := processor.Handle(ctx, &rawEvent)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unhandled event type")
+		assert.ErrorIs(t, err, ErrInvalidEvent)
 	})
 }
 
@@ -958,7 +1071,7 @@ func TestHandleWebSocketEvent_Scenarios(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
 
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		wsEvent := events.APIGatewayWebsocketProxyRequest{
 			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
@@ -982,7 +1095,7 @@ func TestHandleWebSocketEvent_Scenarios(t *testing.T) {
 	t.Run("handles different WebSocket route keys", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		routeKeys := []string{"$connect", "$disconnect", "$default", "custom-route"}
 
@@ -1014,7 +1127,7 @@ func TestHandleEventJSON(t *testing.T) {
 			},
 		}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		eventJSON := json.RawMessage(`{
 			"detail-type": "ECS Task State Change",
@@ -1030,7 +1143,7 @@ func TestHandleEventJSON(t *testing.T) {
 	t.Run("handles invalid JSON", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		eventJSON := json.RawMessage(`invalid json`)
 
@@ -1042,7 +1155,7 @@ func TestHandleEventJSON(t *testing.T) {
 	t.Run("handles non-CloudWatch event JSON", func(t *testing.T) {
 		mockRepo := &mockExecutionRepo{}
 		mockWebSocket := &mockWebSocketHandler{}
-		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, logger)
+		processor := NewProcessor(mockRepo, &noopLogEventRepo{}, mockWebSocket, nil, nil, logger)
 
 		eventJSON := json.RawMessage(`{"type": "not-cloudwatch"}`)
 
@@ -1064,7 +1177,7 @@ func TestHandleLogsEvent_InvalidBase64(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, nil, logger)
 
 	logsEvent := events.CloudwatchLogsEvent{
 		AWSLogs: events.CloudwatchLogsRawData{
@@ -1076,9 +1189,8 @@ func TestHandleLogsEvent_InvalidBase64(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Should handle event but fail on parse
-	handled, err := processor.handleLogsEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.Error(t, err)
-	assert.True(t, handled) // Returns true even when Parse() fails
 }
 
 // TestHandleLogsEvent_UnmarshalError tests handling of invalid JSON
@@ -1088,15 +1200,13 @@ func TestHandleLogsEvent_UnmarshalError(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, nil, logger)
 
 	// Invalid JSON event
 	rawEvent := json.RawMessage(`{invalid json}`)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawEvent, logger)
-	// Should return early without error on unmarshal failure
-	assert.NoError(t, err)
-	assert.False(t, handled)
+	_, err := processor.Handle(ctx, &rawEvent)
+	assert.ErrorIs(t, err, ErrUnrecognizedEvent)
 }
 
 // TestHandleLogsEvent_EmptyData tests handling of empty logs data
@@ -1106,7 +1216,7 @@ func TestHandleLogsEvent_EmptyData(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, nil, nil, logger)
 
 	// Empty data field
 	logsEvent := events.CloudwatchLogsEvent{
@@ -1118,10 +1228,9 @@ func TestHandleLogsEvent_EmptyData(t *testing.T) {
 	eventJSON, _ := json.Marshal(logsEvent)
 	rawEvent := json.RawMessage(eventJSON)
 
-	// Should handle early return for empty data
-	handled, err := processor.handleLogsEvent(ctx, &rawEvent, logger)
-	assert.NoError(t, err)
-	assert.False(t, handled)
+	// Empty data is rejected by the schema
+	_, err := processor.Handle(ctx, &rawEvent)
+	assert.ErrorIs(t, err, ErrInvalidEvent)
 }
 
 func TestHandleScheduledEvent_HealthReconcile(t *testing.T) {
@@ -1141,7 +1250,7 @@ func TestHandleScheduledEvent_HealthReconcile(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -1153,9 +1262,8 @@ func TestHandleScheduledEvent_HealthReconcile(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Route through handleCloudEvent
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	assert.True(t, reconcileCalled, "health reconcile should have been called")
 }
 
@@ -1173,7 +1281,7 @@ func TestHandleScheduledEvent_InvalidSource(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -1185,9 +1293,8 @@ func TestHandleScheduledEvent_InvalidSource(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Should ignore events from unexpected sources
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	assert.False(t, reconcileCalled, "health reconcile should not be called for invalid source")
 }
 
@@ -1205,7 +1312,7 @@ func TestHandleScheduledEvent_MissingRunvoyEvent(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	// Test with valid JSON but missing runvoy_event field (will be empty string)
 	event := events.CloudWatchEvent{
@@ -1217,11 +1324,10 @@ func TestHandleScheduledEvent_MissingRunvoyEvent(t *testing.T) {
 	eventJSON, _ := json.Marshal(event)
 	rawEvent := json.RawMessage(eventJSON)
 
-	// Should return error for missing runvoy_event (empty string)
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
-	assert.Error(t, err)
-	assert.True(t, handled)
-	assert.Contains(t, err.Error(), "unexpected runvoy_event value")
+	// Should be rejected by the scheduled event schema
+	_, err := processor.Handle(ctx, &rawEvent)
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+	assert.Contains(t, err.Error(), "runvoy_event is required")
 	assert.False(t, reconcileCalled, "health reconcile should not be called for missing runvoy_event")
 }
 
@@ -1232,7 +1338,7 @@ func TestHandleScheduledEvent_UnknownEventType(t *testing.T) {
 	mockHealthManager := &mockHealthManager{}
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -1244,9 +1350,8 @@ func TestHandleScheduledEvent_UnknownEventType(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Should return error for unknown event type
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.Error(t, err)
-	assert.True(t, handled)
 	assert.Contains(t, err.Error(), "unexpected runvoy_event value")
 }
 
@@ -1262,7 +1367,7 @@ func TestHandleScheduledEvent_HealthReconcileError(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -1274,9 +1379,8 @@ func TestHandleScheduledEvent_HealthReconcileError(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Should propagate reconciliation errors
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.Error(t, err)
-	assert.True(t, handled)
 	assert.Contains(t, err.Error(), "health reconciliation failed")
 }
 
@@ -1296,7 +1400,7 @@ func TestHandleScheduledEvent_HealthReconcileWithErrors(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -1308,14 +1412,14 @@ func TestHandleScheduledEvent_HealthReconcileWithErrors(t *testing.T) {
 	rawEvent := json.RawMessage(eventJSON)
 
 	// Should handle reconciliation with errors (non-fatal)
-	handled, err := processor.handleCloudEvent(ctx, &rawEvent, logger)
+	_, err := processor.Handle(ctx, &rawEvent)
 	assert.NoError(t, err)
-	assert.True(t, handled)
 }
 
 // mockHealthManager implements contract.HealthManager for testing
 type mockHealthManager struct {
 	reconcileFunc func(ctx context.Context) (*api.HealthReport, error)
+	cleanupFunc   func(ctx context.Context, dryRun bool) (*api.CleanupReport, error)
 }
 
 func (m *mockHealthManager) Reconcile(ctx context.Context) (*api.HealthReport, error) {
@@ -1324,3 +1428,27 @@ func (m *mockHealthManager) Reconcile(ctx context.Context) (*api.HealthReport, e
 	}
 	return &api.HealthReport{}, nil
 }
+
+func (m *mockHealthManager) Cleanup(ctx context.Context, dryRun bool) (*api.CleanupReport, error) {
+	if m.cleanupFunc != nil {
+		return m.cleanupFunc(ctx, dryRun)
+	}
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
+func TestParseTaskTimes_WarmPoolSlot(t *testing.T) {
+	reqLogger := testutil.SilentLogger()
+
+	claimedAt := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	taskEvent := &ECSTaskStateChangeEvent{
+		StartedBy: awsConstants.WarmPoolStartedBy,
+		StartedAt: "2026-10-18T12:00:00Z",
+		StoppedAt: "2026-10-18T12:31:00Z",
+	}
+
+	startedAt, _, duration, err := parseTaskTimes(taskEvent, claimedAt, reqLogger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, claimedAt, startedAt, "a warm slot execution starts when it is assigned")
+	assert.Equal(t, 60, duration)
+}
@@ -3,8 +3,11 @@ package aws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/backend/contract"
 	"github.com/runvoy/runvoy/internal/database"
@@ -20,7 +23,38 @@ type Processor struct {
 	logEventRepo     database.LogEventRepository
 	webSocketManager contract.WebSocketManager
 	healthManager    contract.HealthManager
+	taskManager      contract.TaskManager
 	logger           *slog.Logger
+
+	// healthReportRepo stores the reports of scheduled reconciliations, it is optional.
+	healthReportRepo database.HealthReportRepository
+	// metrics publishes the processor metrics, it is optional.
+	metrics contract.MetricsRecorder
+	// warmPools replenishes the warm pool slots of the images, it is optional.
+	warmPools warmPoolReplenisher
+	// resourceUsage summarizes the resource utilization of stopped tasks, it is optional.
+	resourceUsage resourceUsageSummarizer
+	// usageReports emails the weekly usage report to the admins, it is optional.
+	usageReports usageReporter
+	// claimTokens deletes the expired claim tokens and the unclaimed users, it is optional.
+	claimTokens claimTokenSweeper
+	// lockRepo releases the locks of the completed executions, it is optional.
+	lockRepo database.LockRepository
+}
+
+// warmPoolReplenisher keeps the warm pools of the images at their configured size.
+type warmPoolReplenisher interface {
+	ReplenishWarmPools(ctx context.Context) (started, stopped int, err error)
+}
+
+// usageReporter emails the usage report of the week before now.
+type usageReporter interface {
+	SendWeeklyReport(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// claimTokenSweeper deletes the claim tokens expired at now and, when configured to, the unclaimed users.
+type claimTokenSweeper interface {
+	Sweep(ctx context.Context, now time.Time) (deletedKeys, deletedUsers int, err error)
 }
 
 // NewProcessor creates a new AWS event processor.
@@ -29,6 +63,7 @@ func NewProcessor(
 	logEventRepo database.LogEventRepository,
 	webSocketManager contract.WebSocketManager,
 	healthManager contract.HealthManager,
+	taskManager contract.TaskManager,
 	log *slog.Logger,
 ) *Processor {
 	return &Processor{
@@ -36,37 +71,52 @@ func NewProcessor(
 		logEventRepo:     logEventRepo,
 		webSocketManager: webSocketManager,
 		healthManager:    healthManager,
+		taskManager:      taskManager,
 		logger:           log,
 	}
 }
 
-// Handle processes a raw AWS event by delegating to the appropriate handler.
-// It supports CloudWatch events, CloudWatch Logs, and WebSocket events.
+// Handle processes a raw AWS event by delegating to the handler of its schema.
+// It supports CloudWatch events, CloudWatch Logs, and WebSocket events, see DecodeEvent.
 func (p *Processor) Handle(ctx context.Context, rawEvent *json.RawMessage) (*json.RawMessage, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, p.logger)
 
-	// Try cloud-specific events
-	if handled, err := p.handleCloudEvent(ctx, rawEvent, reqLogger); handled {
-		return nil, err
+	event, err := DecodeEvent(*rawEvent)
+	if errors.Is(err, ErrUnregisteredDetailType) {
+		reqLogger.Warn("ignoring unhandled CloudWatch event detail type", "error", err)
+		return nil, nil
 	}
-
-	// Try logs events
-	if handled, err := p.handleLogsEvent(ctx, rawEvent, reqLogger); handled {
+	if err != nil {
+		reqLogger.Error("failed to decode event", "error", err)
 		return nil, err
 	}
 
-	// Try WebSocket events
-	if resp, handled := p.handleWebSocketEvent(ctx, rawEvent, reqLogger); handled {
-		marshaled, err := json.Marshal(resp)
-		if err != nil {
-			reqLogger.Error("failed to marshal response", "error", err)
-			return nil, fmt.Errorf("failed to marshal response: %w", err)
+	reqLogger.Debug("processing event", "schema", event.Schema)
+
+	switch event.Schema {
+	case EventSchemaECSTaskStateChangeV1:
+		err = p.handleECSTaskEvent(ctx, event.CloudWatch, reqLogger)
+	case EventSchemaScheduledV1:
+		err = p.handleScheduledEvent(ctx, event.CloudWatch, reqLogger)
+	case EventSchemaCloudWatchLogsV1:
+		err = p.handleLogsEvent(ctx, event.Logs, reqLogger)
+	case EventSchemaWebSocketV1:
+		resp := p.handleWebSocketEvent(ctx, rawEvent, reqLogger)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			err = errors.New(resp.Body)
+		}
+		p.recordEventProcessed(ctx, eventSchemas[event.Schema].metricEventType, err)
+		marshaled, marshalErr := json.Marshal(resp)
+		if marshalErr != nil {
+			reqLogger.Error("failed to marshal response", "error", marshalErr)
+			return nil, fmt.Errorf("failed to marshal response: %w", marshalErr)
 		}
 		result := json.RawMessage(marshaled)
 		return &result, nil
 	}
 
-	return nil, fmt.Errorf("unhandled event type: %s", string(*rawEvent))
+	p.recordEventProcessed(ctx, eventSchemas[event.Schema].metricEventType, err)
+	return nil, err
 }
 
 // HandleEventJSON is a helper for testing that accepts raw JSON and returns an error.
@@ -82,41 +132,3 @@ func (p *Processor) HandleEventJSON(ctx context.Context, eventJSON *json.RawMess
 	}
 	return nil
 }
-
-// handleCloudEvent processes CloudWatch events (ECS task state changes and scheduled events).
-func (p *Processor) handleCloudEvent(
-	ctx context.Context,
-	rawEvent *json.RawMessage,
-	reqLogger *slog.Logger,
-) (bool, error) {
-	var cwEvent events.CloudWatchEvent
-	if err := json.Unmarshal(*rawEvent, &cwEvent); err != nil {
-		reqLogger.Debug("event is not a CloudWatch event", "error", err)
-		return false, nil
-	}
-	if cwEvent.Source == "" || cwEvent.DetailType == "" {
-		return false, nil
-	}
-
-	reqLogger.Debug("processing CloudWatch event",
-		"context", map[string]string{
-			"source":      cwEvent.Source,
-			"detail_type": cwEvent.DetailType,
-		},
-	)
-
-	switch cwEvent.DetailType {
-	case "ECS Task State Change":
-		return true, p.handleECSTaskEvent(ctx, &cwEvent, reqLogger)
-	case "Scheduled Event":
-		return true, p.handleScheduledEvent(ctx, &cwEvent, reqLogger)
-	default:
-		reqLogger.Warn("ignoring unhandled CloudWatch event detail type",
-			"context", map[string]string{
-				"detail_type": cwEvent.DetailType,
-				"source":      cwEvent.Source,
-			},
-		)
-		return true, nil
-	}
-}
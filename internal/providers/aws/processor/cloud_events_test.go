@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/constants"
@@ -39,14 +40,70 @@ func (m *mockExecRepoForCloudEvents) CreateExecution(_ context.Context, _ *api.E
 	return nil
 }
 
+func (m *mockExecRepoForCloudEvents) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return nil
+}
+
 func (m *mockExecRepoForCloudEvents) ListExecutions(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
 	return []*api.Execution{}, nil
 }
 
+func (m *mockExecRepoForCloudEvents) ListExecutionsByCreator(
+	_ context.Context, _ string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return []*api.Execution{}, nil
+}
+
 func (m *mockExecRepoForCloudEvents) GetExecutionsByRequestID(_ context.Context, _ string) ([]*api.Execution, error) {
 	return []*api.Execution{}, nil
 }
 
+func (m *mockExecRepoForCloudEvents) GetExecutionsByGroupID(_ context.Context, _ string) ([]*api.Execution, error) {
+	return []*api.Execution{}, nil
+}
+
+func (m *mockExecRepoForCloudEvents) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return nil
+}
+
+func (m *mockExecRepoForCloudEvents) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecRepoForCloudEvents) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (m *mockExecRepoForCloudEvents) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockExecRepoForCloudEvents) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockExecRepoForCloudEvents) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+func (m *mockExecRepoForCloudEvents) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return nil
+}
+
+func (m *mockExecRepoForCloudEvents) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, nil
+}
+
+func (m *mockExecRepoForCloudEvents) AddExecutionAnnotation(
+	_ context.Context, _ string, _ *api.ExecutionAnnotation,
+) error {
+	return nil
+}
+
 // Mock WebSocket manager for cloud event tests
 type mockWSManagerForCloudEvents struct {
 	notifyExecutionUpdateFunc func(ctx context.Context, exec *api.Execution) error
@@ -69,6 +126,10 @@ func (m *mockWSManagerForCloudEvents) NotifyExecutionCompletion(_ context.Contex
 	return nil
 }
 
+func (m *mockWSManagerForCloudEvents) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (m *mockWSManagerForCloudEvents) SendMessage(_ context.Context, _ string, _ []byte) error {
 	return nil
 }
@@ -118,7 +179,7 @@ func TestProcessor_Handle_ECSEvent(t *testing.T) {
 	}
 
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create ECS Task State Change event
 	taskArn := "arn:aws:ecs:us-east-1:123456789:task/cluster/exec-test-123"
@@ -148,7 +209,7 @@ func TestProcessor_Handle_ScheduledEvent(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, healthManager, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, healthManager, nil, testutil.SilentLogger())
 
 	// Create Scheduled Event for health reconciliation
 	scheduledEvent := events.CloudWatchEvent{
@@ -169,7 +230,7 @@ func TestProcessor_Handle_ScheduledEvent(t *testing.T) {
 func TestProcessor_Handle_UnhandledCloudWatchEventType(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create CloudWatch event with unknown detail type
 	unknownEvent := events.CloudWatchEvent{
@@ -190,7 +251,7 @@ func TestProcessor_Handle_UnhandledCloudWatchEventType(t *testing.T) {
 func TestProcessor_Handle_LogsEvent(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create CloudWatch Logs event
 	logsEvent := events.CloudwatchLogsEvent{
@@ -212,7 +273,7 @@ func TestProcessor_Handle_LogsEvent(t *testing.T) {
 func TestProcessor_Handle_WebSocketConnectEvent(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create API Gateway WebSocket connect event
 	wsEvent := events.APIGatewayWebsocketProxyRequest{
@@ -235,7 +296,7 @@ func TestProcessor_Handle_WebSocketConnectEvent(t *testing.T) {
 func TestProcessor_Handle_WebSocketDisconnectEvent(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create API Gateway WebSocket disconnect event
 	wsEvent := events.APIGatewayWebsocketProxyRequest{
@@ -258,7 +319,7 @@ func TestProcessor_Handle_WebSocketDisconnectEvent(t *testing.T) {
 func TestProcessor_Handle_UnknownEventType(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Create completely unrecognized event
 	unknownEvent := map[string]any{
@@ -277,7 +338,7 @@ func TestProcessor_Handle_UnknownEventType(t *testing.T) {
 func TestProcessor_Handle_InvalidJSON(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Invalid JSON
 	invalidJSON := json.RawMessage(`{invalid json}`)
@@ -300,7 +361,7 @@ func TestProcessor_HandleCloudEvent_ECSTaskStateChange(t *testing.T) {
 	}
 
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	taskArn := "arn:aws:ecs:us-east-1:123456789:task/cluster/exec-test-123"
 	detailJSON := `{"taskArn":"` + taskArn + `","lastStatus":"RUNNING"}`
@@ -314,8 +375,7 @@ func TestProcessor_HandleCloudEvent_ECSTaskStateChange(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.True(t, handled)
+	_, err = processor.Handle(context.Background(), &rawMsg)
 	assert.NoError(t, err)
 }
 
@@ -329,7 +389,7 @@ func TestProcessor_HandleCloudEvent_ScheduledEvent(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, healthManager, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, healthManager, nil, testutil.SilentLogger())
 
 	event := events.CloudWatchEvent{
 		Source:     "aws.events",
@@ -342,15 +402,14 @@ func TestProcessor_HandleCloudEvent_ScheduledEvent(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.True(t, handled)
+	_, err = processor.Handle(context.Background(), &rawMsg)
 	assert.NoError(t, err)
 }
 
 func TestProcessor_HandleCloudEvent_UnhandledDetailType(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	event := events.CloudWatchEvent{
 		Source:     "aws.ec2",
@@ -362,15 +421,14 @@ func TestProcessor_HandleCloudEvent_UnhandledDetailType(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.True(t, handled)
+	_, err = processor.Handle(context.Background(), &rawMsg)
 	assert.NoError(t, err) // Should handle gracefully and log warning
 }
 
 func TestProcessor_HandleCloudEvent_NotCloudWatchEvent(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// Not a CloudWatch event structure
 	notCWEvent := map[string]any{
@@ -381,15 +439,14 @@ func TestProcessor_HandleCloudEvent_NotCloudWatchEvent(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.False(t, handled)
-	assert.NoError(t, err)
+	_, err = processor.Handle(context.Background(), &rawMsg)
+	assert.ErrorIs(t, err, ErrUnrecognizedEvent)
 }
 
 func TestProcessor_HandleCloudEvent_MissingSource(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// CloudWatch event without Source field
 	event := events.CloudWatchEvent{
@@ -401,15 +458,14 @@ func TestProcessor_HandleCloudEvent_MissingSource(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.False(t, handled)
-	assert.NoError(t, err)
+	_, err = processor.Handle(context.Background(), &rawMsg)
+	assert.ErrorIs(t, err, ErrUnrecognizedEvent)
 }
 
 func TestProcessor_HandleCloudEvent_MissingDetailType(t *testing.T) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	// CloudWatch event without DetailType field
 	event := events.CloudWatchEvent{
@@ -421,9 +477,8 @@ func TestProcessor_HandleCloudEvent_MissingDetailType(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleCloudEvent(context.Background(), &rawMsg, testutil.SilentLogger())
-	assert.False(t, handled)
-	assert.NoError(t, err)
+	_, err = processor.Handle(context.Background(), &rawMsg)
+	assert.ErrorIs(t, err, ErrUnrecognizedEvent)
 }
 
 // Benchmark tests
@@ -441,7 +496,7 @@ func BenchmarkProcessor_Handle_ECSEvent(b *testing.B) {
 	}
 
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	taskArn := "arn:aws:ecs:us-east-1:123456789:task/cluster/exec-test-123"
 	benchmarkDetailJSON := `{"taskArn":"` + taskArn + `","lastStatus":"RUNNING"}`
@@ -464,7 +519,7 @@ func BenchmarkProcessor_Handle_ECSEvent(b *testing.B) {
 func BenchmarkProcessor_Handle_WebSocketEvent(b *testing.B) {
 	execRepo := &mockExecRepoForCloudEvents{}
 	wsManager := &mockWSManagerForCloudEvents{}
-	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, testutil.SilentLogger())
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, wsManager, nil, nil, testutil.SilentLogger())
 
 	wsEvent := events.APIGatewayWebsocketProxyRequest{
 		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
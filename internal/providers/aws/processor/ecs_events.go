@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 
@@ -46,6 +48,10 @@ func (p *Processor) handleECSTaskEvent(
 		return fmt.Errorf("failed to get execution: %w", err)
 	}
 
+	if execution == nil && taskEvent.StartedBy == awsConstants.WarmPoolStartedBy {
+		return p.handleIdleWarmSlotEvent(ctx, executionID, &taskEvent, reqLogger)
+	}
+
 	if execution == nil {
 		reqLogger.Error("execution not found for task (orphaned task?)",
 			"cluster_arn", taskEvent.ClusterArn,
@@ -56,11 +62,18 @@ func (p *Processor) handleECSTaskEvent(
 		return nil
 	}
 
+	// Tie the processing of the event to the request that started the execution, for `runvoy trace`
+	if execution.CreatedByRequestID != "" {
+		reqLogger = reqLogger.With(constants.OriginRequestIDLogField, execution.CreatedByRequestID)
+	}
+
+	p.recordLifecycleEvents(ctx, executionID, &taskEvent, event.Time, reqLogger)
+
 	status := awsConstants.EcsStatus(taskEvent.LastStatus)
 
 	switch status { //nolint:exhaustive // we are only interested in a subset of the possible ECS task statuses
 	case awsConstants.EcsStatusRunning:
-		return p.updateExecutionToRunning(ctx, executionID, execution, reqLogger)
+		return p.updateExecutionToRunning(ctx, executionID, execution, &taskEvent, reqLogger)
 	case awsConstants.EcsStatusStopped:
 		return p.finalizeExecutionFromTaskEvent(ctx, executionID, execution, &taskEvent, reqLogger)
 	default:
@@ -74,10 +87,35 @@ func (p *Processor) handleECSTaskEvent(
 	}
 }
 
+// handleIdleWarmSlotEvent handles the events of a warm pool slot that was not assigned an execution.
+// The log lines of the slot are stored under its task ID like those of an execution, they are marked
+// for deletion once the slot stops.
+func (p *Processor) handleIdleWarmSlotEvent(
+	ctx context.Context,
+	taskID string,
+	taskEvent *ECSTaskStateChangeEvent,
+	reqLogger *slog.Logger,
+) error {
+	reqLogger.Debug("ignoring event of unassigned warm pool slot",
+		"context", map[string]string{
+			"task_id":     taskID,
+			"last_status": taskEvent.LastStatus,
+		},
+	)
+	if awsConstants.EcsStatus(taskEvent.LastStatus) != awsConstants.EcsStatusStopped {
+		return nil
+	}
+	if err := p.logEventRepo.DeleteLogEvents(ctx, taskID); err != nil {
+		reqLogger.Warn("failed to mark warm pool slot log events for TTL deletion", "error", err, "task_id", taskID)
+	}
+	return nil
+}
+
 func (p *Processor) updateExecutionToRunning(
 	ctx context.Context,
 	executionID string,
 	execution *api.Execution,
+	taskEvent *ECSTaskStateChangeEvent,
 	reqLogger *slog.Logger,
 ) error {
 	currentStatus := constants.ExecutionStatus(execution.Status)
@@ -93,7 +131,12 @@ func (p *Processor) updateExecutionToRunning(
 	}
 
 	if !constants.CanTransition(currentStatus, targetStatus) {
-		reqLogger.Debug("skipping invalid status transition to "+string(targetStatus),
+		// Completed executions only receive a RUNNING event when events arrive out of order
+		logFn := reqLogger.Debug
+		if currentStatus.IsFinal() {
+			logFn = reqLogger.Warn
+		}
+		logFn("skipping invalid status transition to "+string(targetStatus),
 			"context", map[string]string{
 				"execution_id":   executionID,
 				"current_status": execution.Status,
@@ -120,6 +163,13 @@ func (p *Processor) updateExecutionToRunning(
 		return fmt.Errorf("failed to update execution to running: %w", err)
 	}
 
+	startType := constants.MetricStartTypeCold
+	if taskEvent.StartedBy == awsConstants.WarmPoolStartedBy {
+		startType = constants.MetricStartTypeWarm
+	}
+	p.recordStartLatency(ctx, execution.StartedAt, startType)
+	p.notifyExecutionStatus(ctx, execution, currentStatus, reqLogger)
+
 	reqLogger.Debug("execution marked as "+string(targetStatus),
 		"context", map[string]string{
 			"execution_id": executionID,
@@ -137,12 +187,24 @@ func (p *Processor) finalizeExecutionFromTaskEvent(
 	reqLogger *slog.Logger,
 ) error {
 	status, exitCode := determineStatusAndExitCode(taskEvent)
-	_, stoppedAt, durationSeconds, err := parseTaskTimes(taskEvent, execution.StartedAt, reqLogger)
+	startedAt, stoppedAt, durationSeconds, err := parseTaskTimes(taskEvent, execution.StartedAt, reqLogger)
 	if err != nil {
 		return err
 	}
 
 	currentStatus := constants.ExecutionStatus(execution.Status)
+	if currentStatus == constants.ExecutionTerminating && status != string(constants.ExecutionStopped) {
+		// The kill was requested before the task stopped, even if the command exited on its own meanwhile
+		reqLogger.Info("reconciling killed execution to "+string(constants.ExecutionStopped),
+			"context", map[string]any{
+				"execution_id":      executionID,
+				"task_status":       status,
+				"exit_code":         exitCode,
+				"kill_requested_by": execution.KillRequestedBy,
+			},
+		)
+		status = string(constants.ExecutionStopped)
+	}
 	targetStatus := constants.ExecutionStatus(status)
 
 	// Always mark logs for deletion when task is stopped, even if status transition is invalid.
@@ -150,6 +212,8 @@ func (p *Processor) finalizeExecutionFromTaskEvent(
 	if err = p.logEventRepo.DeleteLogEvents(ctx, executionID); err != nil {
 		reqLogger.Error("failed to mark log events for TTL deletion", "error", err, "execution_id", executionID)
 	}
+	// Likewise for the lock, which may already have been released or taken over
+	p.releaseExecutionLock(ctx, execution, reqLogger)
 
 	if !constants.CanTransition(currentStatus, targetStatus) {
 		reqLogger.Warn("skipping invalid status transition",
@@ -166,6 +230,17 @@ func (p *Processor) finalizeExecutionFromTaskEvent(
 	execution.ExitCode = exitCode
 	execution.CompletedAt = &stoppedAt
 	execution.DurationSeconds = durationSeconds
+	if targetStatus == constants.ExecutionFailed {
+		failureReason, failureMessage := classifyFailure(taskEvent)
+		execution.FailureReason = string(failureReason)
+		execution.FailureMessage = failureMessage
+	}
+	execution.ResourceSummary = p.buildResourceSummary(ctx, executionID, taskEvent, startedAt, stoppedAt, reqLogger)
+	// Executions completing slower than expected are flagged here, unless already flagged while running.
+	sloBreached := !execution.SLOBreached && isExecutionSLOBreached(execution, stoppedAt)
+	if sloBreached {
+		execution.SLOBreached = true
+	}
 
 	// Extract request ID from context and set ModifiedByRequestID
 	requestID := logger.ExtractRequestIDFromContext(ctx)
@@ -179,8 +254,13 @@ func (p *Processor) finalizeExecutionFromTaskEvent(
 	}
 
 	reqLogger.Info("execution updated successfully", "execution", execution)
+	p.recordCompletionLatency(ctx, startedAt)
+	if sloBreached {
+		p.notifySLOBreach(ctx, execution, stoppedAt, reqLogger)
+	}
 
 	// Notify WebSocket clients about the execution completion
+	p.notifyExecutionStatus(ctx, execution, currentStatus, reqLogger)
 	if err = p.webSocketManager.NotifyExecutionCompletion(ctx, &executionID); err != nil {
 		reqLogger.Error("failed to notify websocket clients of disconnect", "error", err)
 		return fmt.Errorf("failed to notify websocket clients: %w", err)
@@ -189,6 +269,36 @@ func (p *Processor) finalizeExecutionFromTaskEvent(
 	return nil
 }
 
+// notifyExecutionStatus pushes the new status of the execution to the WebSocket clients following it,
+// along with its exit code and failure once completed. It is best-effort: clients can still poll the status.
+func (p *Processor) notifyExecutionStatus(
+	ctx context.Context,
+	execution *api.Execution,
+	previousStatus constants.ExecutionStatus,
+	reqLogger *slog.Logger,
+) {
+	change := &api.ExecutionStatusChange{
+		ExecutionID:    execution.ExecutionID,
+		PreviousStatus: string(previousStatus),
+		Status:         execution.Status,
+		Timestamp:      time.Now().UnixMilli(),
+	}
+	if execution.CompletedAt != nil {
+		exitCode := execution.ExitCode
+		change.ExitCode = &exitCode
+		change.FailureReason = execution.FailureReason
+		change.FailureMessage = execution.FailureMessage
+	}
+
+	if err := p.webSocketManager.NotifyExecutionStatus(ctx, change); err != nil {
+		reqLogger.Warn("failed to notify websocket clients of status change", "context", map[string]string{
+			"error":        err.Error(),
+			"execution_id": execution.ExecutionID,
+			"status":       execution.Status,
+		})
+	}
+}
+
 // extractExecutionIDFromTaskArn extracts the execution ID from a task ARN
 // Task ARN format: arn:aws:ecs:region:account:task/cluster-name/EXECUTION_ID.
 func extractExecutionIDFromTaskArn(taskArn string) string {
@@ -242,3 +352,18 @@ func determineStatusAndExitCode(event *ECSTaskStateChangeEvent) (status string,
 
 	return status, exitCode
 }
+
+// releaseExecutionLock releases the lock held by a stopped execution, if any.
+func (p *Processor) releaseExecutionLock(ctx context.Context, execution *api.Execution, reqLogger *slog.Logger) {
+	if execution.Lock == "" || p.lockRepo == nil {
+		return
+	}
+	err := p.lockRepo.ReleaseLock(ctx, execution.Lock, execution.ExecutionID)
+	if err != nil && appErrors.GetErrorCode(err) != appErrors.ErrCodeNotFound {
+		reqLogger.Error("failed to release execution lock", "context", map[string]string{
+			"execution_id": execution.ExecutionID,
+			"lock":         execution.Lock,
+			"error":        err.Error(),
+		})
+	}
+}
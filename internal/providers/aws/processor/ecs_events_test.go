@@ -11,14 +11,17 @@ import (
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/constants"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/fake"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockWebSocketManager is a mock for websocket notifications
 type mockWebSocketManager struct {
-	notifyFunc func(ctx context.Context, executionID *string) error
+	notifyFunc    func(ctx context.Context, executionID *string) error
+	statusChanges []*api.ExecutionStatusChange
 }
 
 func (m *mockWebSocketManager) HandleRequest(_ context.Context, _ *json.RawMessage, _ *slog.Logger) (bool, error) {
@@ -32,6 +35,11 @@ func (m *mockWebSocketManager) NotifyExecutionCompletion(ctx context.Context, ex
 	return nil
 }
 
+func (m *mockWebSocketManager) NotifyExecutionStatus(_ context.Context, change *api.ExecutionStatusChange) error {
+	m.statusChanges = append(m.statusChanges, change)
+	return nil
+}
+
 func (m *mockWebSocketManager) SendLogsToExecution(_ context.Context, _ *string) error {
 	return nil
 }
@@ -64,9 +72,11 @@ func TestHandleECSTaskEvent_Running(t *testing.T) {
 		},
 	}
 
+	wsManager := &mockWebSocketManager{}
 	p := &Processor{
-		executionRepo: execRepo,
-		logEventRepo:  &noopLogEventRepo{},
+		executionRepo:    execRepo,
+		logEventRepo:     &noopLogEventRepo{},
+		webSocketManager: wsManager,
 	}
 
 	event := &events.CloudWatchEvent{
@@ -82,6 +92,13 @@ func TestHandleECSTaskEvent_Running(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.True(t, updated, "execution should have been updated")
+	if assert.Len(t, wsManager.statusChanges, 1) {
+		change := wsManager.statusChanges[0]
+		assert.Equal(t, executionID, change.ExecutionID)
+		assert.Equal(t, string(constants.ExecutionStarting), change.PreviousStatus)
+		assert.Equal(t, string(constants.ExecutionRunning), change.Status)
+		assert.Nil(t, change.ExitCode)
+	}
 }
 
 func TestHandleECSTaskEvent_Stopped(t *testing.T) {
@@ -114,9 +131,11 @@ func TestHandleECSTaskEvent_Stopped(t *testing.T) {
 		},
 	}
 
-	wsManager := &mockWebSocketManager{
+	var wsManager *mockWebSocketManager
+	wsManager = &mockWebSocketManager{
 		notifyFunc: func(_ context.Context, execID *string) error {
 			assert.Equal(t, executionID, *execID)
+			assert.Len(t, wsManager.statusChanges, 1, "status change should be pushed before disconnecting")
 			notified = true
 			return nil
 		},
@@ -150,6 +169,61 @@ func TestHandleECSTaskEvent_Stopped(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, updated, "execution should have been updated")
 	assert.True(t, notified, "websocket notification should have been sent")
+	if assert.Len(t, wsManager.statusChanges, 1, "status change should have been pushed") {
+		change := wsManager.statusChanges[0]
+		assert.Equal(t, string(constants.ExecutionRunning), change.PreviousStatus)
+		assert.Equal(t, string(constants.ExecutionSucceeded), change.Status)
+		if assert.NotNil(t, change.ExitCode) {
+			assert.Equal(t, 0, *change.ExitCode)
+		}
+	}
+}
+
+func TestHandleECSTaskEvent_StoppedReleasesLock(t *testing.T) {
+	ctx := context.Background()
+	executionID := "test-exec-lock"
+	now := time.Now().UTC()
+	startedAt := now.Add(-5 * time.Minute).Format(time.RFC3339)
+
+	locks := fake.NewLockRepository()
+	_, err := locks.AcquireLock(ctx, &api.ExecutionLock{
+		Name: "deploy-prod", ExecutionID: executionID, Token: "token", AcquiredAt: now, ExpiresAt: now.Add(time.Hour),
+	}, now)
+	require.NoError(t, err)
+
+	p := &Processor{
+		executionRepo: &mockExecutionRepo{
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return &api.Execution{
+					ExecutionID: executionID,
+					Status:      string(constants.ExecutionRunning),
+					StartedAt:   mustParseTime(startedAt),
+					Lock:        "deploy-prod",
+				}, nil
+			},
+		},
+		logEventRepo:     &noopLogEventRepo{},
+		lockRepo:         locks,
+		webSocketManager: &mockWebSocketManager{},
+	}
+
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+			LastStatus: "STOPPED",
+			StartedAt:  startedAt,
+			StoppedAt:  now.Format(time.RFC3339),
+			StopCode:   "EssentialContainerExited",
+			Containers: []ContainerDetail{{Name: awsConstants.RunnerContainerName, ExitCode: intPtr(0)}},
+		}),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	require.NoError(t, p.handleECSTaskEvent(ctx, event, logger))
+
+	lock, err := locks.GetLock(ctx, "deploy-prod")
+	require.NoError(t, err)
+	assert.Nil(t, lock)
 }
 
 func TestHandleECSTaskEvent_OrphanedTask(t *testing.T) {
@@ -224,7 +298,7 @@ func TestUpdateExecutionToRunning_AlreadyRunning(t *testing.T) {
 	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
-	err := p.updateExecutionToRunning(ctx, executionID, execution, logger)
+	err := p.updateExecutionToRunning(ctx, executionID, execution, &ECSTaskStateChangeEvent{}, logger)
 
 	assert.NoError(t, err)
 	assert.False(t, updateCalled, "should not update if already running")
@@ -253,7 +327,7 @@ func TestUpdateExecutionToRunning_InvalidTransition(t *testing.T) {
 	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
-	err := p.updateExecutionToRunning(ctx, executionID, execution, logger)
+	err := p.updateExecutionToRunning(ctx, executionID, execution, &ECSTaskStateChangeEvent{}, logger)
 
 	assert.NoError(t, err)
 	assert.False(t, updateCalled, "should not update on invalid transition")
@@ -344,6 +418,64 @@ func TestHandleECSTaskEvent_UserInitiatedStop(t *testing.T) {
 	assert.True(t, updated)
 }
 
+func TestHandleECSTaskEvent_KilledExecutionExited(t *testing.T) {
+	ctx := context.Background()
+	executionID := "killed-exec"
+	taskArn := "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID
+
+	killRequestedAt := time.Now().Add(-time.Minute)
+	execution := &api.Execution{
+		ExecutionID:     executionID,
+		Status:          string(constants.ExecutionTerminating),
+		StartedAt:       time.Now().Add(-5 * time.Minute),
+		KillRequestedBy: "alice@example.com",
+		KillRequestedAt: &killRequestedAt,
+	}
+
+	updated := false
+	execRepo := &mockExecutionRepo{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return execution, nil
+		},
+		updateExecutionFunc: func(_ context.Context, exec *api.Execution) error {
+			assert.Equal(t, string(constants.ExecutionStopped), exec.Status)
+			assert.Equal(t, 0, exec.ExitCode)
+			assert.Equal(t, "alice@example.com", exec.KillRequestedBy)
+			updated = true
+			return nil
+		},
+	}
+
+	p := &Processor{
+		executionRepo:    execRepo,
+		logEventRepo:     &noopLogEventRepo{},
+		webSocketManager: &mockWebSocketManager{},
+	}
+
+	// The command exited on its own between the kill request and the stop of its task
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    taskArn,
+			LastStatus: "STOPPED",
+			StartedAt:  time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+			StoppedAt:  time.Now().Format(time.RFC3339),
+			StopCode:   "EssentialContainerExited",
+			Containers: []ContainerDetail{
+				{
+					Name:     awsConstants.RunnerContainerName,
+					ExitCode: intPtr(0),
+				},
+			},
+		}),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	err := p.handleECSTaskEvent(ctx, event, logger)
+
+	assert.NoError(t, err)
+	assert.True(t, updated)
+}
+
 func TestHandleECSTaskEvent_IgnoredStatus(t *testing.T) {
 	ctx := context.Background()
 	executionID := "exec-ignored"
@@ -402,3 +534,66 @@ func mustParseTime(timeStr string) time.Time {
 	}
 	return t
 }
+
+func TestHandleECSTaskEvent_IdleWarmSlotStopped(t *testing.T) {
+	ctx := context.Background()
+	taskID := "idle-slot-task"
+
+	var deletedID string
+	p := &Processor{
+		executionRepo: &mockExecutionRepo{
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return nil, nil
+			},
+		},
+		logEventRepo: &noopLogEventRepo{
+			deleteLogEventsFunc: func(_ context.Context, executionID string) error {
+				deletedID = executionID
+				return nil
+			},
+		},
+	}
+
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + taskID,
+			LastStatus: "STOPPED",
+			StartedBy:  awsConstants.WarmPoolStartedBy,
+		}),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	err := p.handleECSTaskEvent(ctx, event, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, taskID, deletedID, "the logs of an idle warm slot should be marked for deletion")
+}
+
+func TestHandleECSTaskEvent_IdleWarmSlotRunning(t *testing.T) {
+	ctx := context.Background()
+
+	p := &Processor{
+		executionRepo: &mockExecutionRepo{
+			getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+				return nil, nil
+			},
+		},
+		logEventRepo: &noopLogEventRepo{
+			deleteLogEventsFunc: func(_ context.Context, _ string) error {
+				t.Fatal("the logs of a running warm slot must be kept")
+				return nil
+			},
+		},
+	}
+
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/idle-slot-task",
+			LastStatus: "RUNNING",
+			StartedBy:  awsConstants.WarmPoolStartedBy,
+		}),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	assert.NoError(t, p.handleECSTaskEvent(ctx, event, logger))
+}
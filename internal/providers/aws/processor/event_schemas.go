@@ -0,0 +1,303 @@
+package aws
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// EventSchemaID identifies a versioned schema of the events the processor accepts.
+type EventSchemaID string
+
+// The event schemas the processor accepts. A schema ID ends with its version: a breaking change of an event
+// registers a new ID next to the previous one, which stays decodable until its producers are gone.
+const (
+	// EventSchemaECSTaskStateChangeV1 is the EventBridge event sent by ECS when a task changes state.
+	EventSchemaECSTaskStateChangeV1 EventSchemaID = "runvoy.processor.ecs-task-state-change.v1"
+	// EventSchemaScheduledV1 is the event sent by the runvoy EventBridge rules to run a periodic task.
+	EventSchemaScheduledV1 EventSchemaID = "runvoy.processor.scheduled.v1"
+	// EventSchemaCloudWatchLogsV1 is the batch of execution logs delivered by the CloudWatch Logs subscription.
+	EventSchemaCloudWatchLogsV1 EventSchemaID = "runvoy.processor.cloudwatch-logs.v1"
+	// EventSchemaWebSocketV1 is the API Gateway WebSocket event of a logs client.
+	EventSchemaWebSocketV1 EventSchemaID = "runvoy.processor.websocket.v1"
+)
+
+// Detail types of the EventBridge events the processor accepts.
+const (
+	detailTypeECSTaskStateChange = "ECS Task State Change"
+	detailTypeScheduled          = "Scheduled Event"
+)
+
+// eventBridgeEnvelopeVersion is the only version of the EventBridge event envelope.
+const eventBridgeEnvelopeVersion = "0"
+
+// scheduledDetailVersion is the version of the runvoy scheduled event detail, used when the detail names none.
+const scheduledDetailVersion = 1
+
+var (
+	// ErrUnrecognizedEvent is returned for the events matching none of the registered schemas.
+	ErrUnrecognizedEvent = errors.New("unhandled event type")
+	// ErrUnregisteredDetailType is returned for the EventBridge events of a detail type the processor ignores.
+	ErrUnregisteredDetailType = errors.New("unregistered event detail type")
+	// ErrUnsupportedEventVersion is returned for the events of a known kind but of a version the processor
+	// doesn't support.
+	ErrUnsupportedEventVersion = errors.New("unsupported event version")
+	// ErrInvalidEvent is returned for the events not valid against their schema.
+	ErrInvalidEvent = errors.New("invalid event")
+)
+
+//go:embed schemas/*.schema.json
+var schemaFiles embed.FS
+
+// eventSchema is a registered event schema.
+type eventSchema struct {
+	// file is the JSON schema document of the events in schemaFiles.
+	file string
+	// metricEventType is the event type dimension of the metrics of the events.
+	metricEventType string
+	// document is the parsed JSON schema document.
+	document map[string]any
+}
+
+// eventSchemas is the registry of the event schemas the processor accepts.
+var eventSchemas = mustLoadEventSchemas(map[EventSchemaID]*eventSchema{
+	EventSchemaECSTaskStateChangeV1: {
+		file:            "schemas/ecs-task-state-change.v1.schema.json",
+		metricEventType: constants.MetricEventTypeTaskStateChange,
+	},
+	EventSchemaScheduledV1: {
+		file:            "schemas/scheduled.v1.schema.json",
+		metricEventType: constants.MetricEventTypeScheduled,
+	},
+	EventSchemaCloudWatchLogsV1: {
+		file:            "schemas/cloudwatch-logs.v1.schema.json",
+		metricEventType: constants.MetricEventTypeLogs,
+	},
+	EventSchemaWebSocketV1: {
+		file:            "schemas/websocket.v1.schema.json",
+		metricEventType: constants.MetricEventTypeWebSocket,
+	},
+})
+
+func mustLoadEventSchemas(schemas map[EventSchemaID]*eventSchema) map[EventSchemaID]*eventSchema {
+	for id, schema := range schemas {
+		data, err := schemaFiles.ReadFile(schema.file)
+		if err != nil {
+			panic(fmt.Sprintf("failed to read event schema %s: %v", id, err))
+		}
+		if err = json.Unmarshal(data, &schema.document); err != nil {
+			panic(fmt.Sprintf("failed to parse event schema %s: %v", id, err))
+		}
+		if schema.document["$id"] != string(id) {
+			panic(fmt.Sprintf("event schema %s has $id %v", id, schema.document["$id"]))
+		}
+	}
+	return schemas
+}
+
+// EventSchemaIDs returns the IDs of the registered event schemas, sorted.
+func EventSchemaIDs() []EventSchemaID {
+	ids := make([]EventSchemaID, 0, len(eventSchemas))
+	for id := range eventSchemas {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// EventJSONSchema returns the JSON schema document of a registered event schema.
+func EventJSONSchema(id EventSchemaID) ([]byte, bool) {
+	schema, ok := eventSchemas[id]
+	if !ok {
+		return nil, false
+	}
+	data, err := schemaFiles.ReadFile(schema.file)
+	return data, err == nil
+}
+
+// DecodedEvent is an event validated against its schema, with its typed content.
+// Exactly one of the content fields is set, depending on the schema.
+type DecodedEvent struct {
+	Schema EventSchemaID
+
+	// CloudWatch is the content of the EventBridge events, ECS task state changes and scheduler ticks.
+	CloudWatch *events.CloudWatchEvent
+	// Logs is the content of the CloudWatch Logs events.
+	Logs *events.CloudwatchLogsEvent
+	// WebSocket is the content of the WebSocket events.
+	WebSocket *events.APIGatewayWebsocketProxyRequest
+}
+
+// eventEnvelope holds the fields telling the kinds of events apart.
+type eventEnvelope struct {
+	Version        string          `json:"version"`
+	Source         string          `json:"source"`
+	DetailType     string          `json:"detail-type"`
+	Detail         json.RawMessage `json:"detail"`
+	AWSLogs        json.RawMessage `json:"awslogs"`
+	RequestContext struct {
+		RouteKey string `json:"routeKey"`
+	} `json:"requestContext"`
+}
+
+// DecodeEvent identifies the schema of a raw event, validates the event against it and decodes it.
+// Events of no registered schema are rejected with ErrUnrecognizedEvent, or ErrUnregisteredDetailType for the
+// EventBridge events, events of an unsupported version with ErrUnsupportedEventVersion and events not valid
+// against their schema with ErrInvalidEvent.
+func DecodeEvent(raw json.RawMessage) (*DecodedEvent, error) {
+	var envelope eventEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnrecognizedEvent, string(raw))
+	}
+
+	id, err := identifyEventSchema(&envelope)
+	if err != nil {
+		if errors.Is(err, ErrUnrecognizedEvent) {
+			return nil, fmt.Errorf("%w: %s", ErrUnrecognizedEvent, string(raw))
+		}
+		return nil, err
+	}
+
+	var document any
+	if err = json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	if err = validateJSONSchema(eventSchemas[id].document, document, "event"); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidEvent, id, err)
+	}
+
+	decoded := &DecodedEvent{Schema: id}
+	switch id {
+	case EventSchemaECSTaskStateChangeV1, EventSchemaScheduledV1:
+		decoded.CloudWatch = new(events.CloudWatchEvent)
+		err = json.Unmarshal(raw, decoded.CloudWatch)
+	case EventSchemaCloudWatchLogsV1:
+		decoded.Logs = new(events.CloudwatchLogsEvent)
+		err = json.Unmarshal(raw, decoded.Logs)
+	case EventSchemaWebSocketV1:
+		decoded.WebSocket = new(events.APIGatewayWebsocketProxyRequest)
+		err = json.Unmarshal(raw, decoded.WebSocket)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidEvent, id, err)
+	}
+	return decoded, nil
+}
+
+// identifyEventSchema returns the schema of an event from its envelope fields.
+func identifyEventSchema(envelope *eventEnvelope) (EventSchemaID, error) {
+	switch {
+	case envelope.Source != "" && envelope.DetailType != "":
+		if envelope.Version != "" && envelope.Version != eventBridgeEnvelopeVersion {
+			return "", fmt.Errorf("%w: EventBridge envelope version %s", ErrUnsupportedEventVersion, envelope.Version)
+		}
+		switch envelope.DetailType {
+		case detailTypeECSTaskStateChange:
+			return EventSchemaECSTaskStateChangeV1, nil
+		case detailTypeScheduled:
+			var detail struct {
+				SchemaVersion *int `json:"schema_version"`
+			}
+			// A malformed detail is reported by the validation against the schema
+			_ = json.Unmarshal(envelope.Detail, &detail)
+			if detail.SchemaVersion != nil && *detail.SchemaVersion != scheduledDetailVersion {
+				return "", fmt.Errorf("%w: scheduled event version %d", ErrUnsupportedEventVersion, *detail.SchemaVersion)
+			}
+			return EventSchemaScheduledV1, nil
+		default:
+			return "", fmt.Errorf("%w: %s from %s", ErrUnregisteredDetailType, envelope.DetailType, envelope.Source)
+		}
+	case len(envelope.AWSLogs) > 0 && string(envelope.AWSLogs) != "null":
+		return EventSchemaCloudWatchLogsV1, nil
+	case envelope.RequestContext.RouteKey != "":
+		return EventSchemaWebSocketV1, nil
+	default:
+		return "", ErrUnrecognizedEvent
+	}
+}
+
+// validateJSONSchema validates a JSON value against a JSON schema document. It supports the keywords
+// the event schemas use: type, required, properties, items, enum and minLength.
+func validateJSONSchema(schema map[string]any, value any, path string) error {
+	if types, ok := schema["type"]; ok && !matchesJSONType(types, value) {
+		return fmt.Errorf("%s must be of type %v", path, types)
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return fmt.Errorf("%s must be one of %v", path, enum)
+	}
+	if minLength, ok := schema["minLength"].(float64); ok {
+		if text, isString := value.(string); isString && len(text) < int(minLength) {
+			return fmt.Errorf("%s must be at least %d characters long", path, int(minLength))
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := typed[name.(string)]; !ok {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, propertySchema := range properties {
+			property, ok := typed[name]
+			if !ok {
+				continue
+			}
+			if err := validateJSONSchema(propertySchema.(map[string]any), property, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range typed {
+			if err := validateJSONSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matchesJSONType reports whether value is of the JSON schema type, or of one of the types.
+func matchesJSONType(types, value any) bool {
+	names, ok := types.([]any)
+	if !ok {
+		names = []any{types}
+	}
+	for _, name := range names {
+		if jsonTypeOf(value, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeOf(value, name any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case float64:
+		return name == "number" || (name == "integer" && typed == float64(int64(typed)))
+	case string:
+		return name == "string"
+	case []any:
+		return name == "array"
+	case map[string]any:
+		return name == "object"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      string
+		wantSchema EventSchemaID
+		wantErr    error
+	}{
+		{
+			name: "ECS task state change",
+			event: `{"version": "0", "source": "aws.ecs", "detail-type": "ECS Task State Change",
+				"detail": {"taskArn": "arn:aws:ecs:us-east-1:123456789:task/cluster/exec-1", "lastStatus": "STOPPED",
+				"containers": [{"name": "runner", "exitCode": 0}]}}`,
+			wantSchema: EventSchemaECSTaskStateChangeV1,
+		},
+		{
+			name:       "scheduler tick",
+			event:      `{"source": "aws.events", "detail-type": "Scheduled Event", "detail": {"runvoy_event": "warm_pools"}}`,
+			wantSchema: EventSchemaScheduledV1,
+		},
+		{
+			name: "scheduler tick with its version",
+			event: `{"source": "aws.events", "detail-type": "Scheduled Event",
+				"detail": {"schema_version": 1, "runvoy_event": "warm_pools"}}`,
+			wantSchema: EventSchemaScheduledV1,
+		},
+		{
+			name:       "CloudWatch Logs",
+			event:      `{"awslogs": {"data": "H4sIAAAAAAAAAA=="}}`,
+			wantSchema: EventSchemaCloudWatchLogsV1,
+		},
+		{
+			name:       "WebSocket",
+			event:      `{"requestContext": {"routeKey": "$connect", "connectionId": "abc"}, "queryStringParameters": null}`,
+			wantSchema: EventSchemaWebSocketV1,
+		},
+		{
+			name:    "unsupported EventBridge envelope version",
+			event:   `{"version": "1", "source": "aws.ecs", "detail-type": "ECS Task State Change", "detail": {}}`,
+			wantErr: ErrUnsupportedEventVersion,
+		},
+		{
+			name: "unsupported scheduler tick version",
+			event: `{"source": "aws.events", "detail-type": "Scheduled Event",
+				"detail": {"schema_version": 2, "runvoy_event": "warm_pools"}}`,
+			wantErr: ErrUnsupportedEventVersion,
+		},
+		{
+			name:    "unregistered detail type",
+			event:   `{"source": "aws.ec2", "detail-type": "EC2 Instance State Change", "detail": {}}`,
+			wantErr: ErrUnregisteredDetailType,
+		},
+		{
+			name:    "unrecognized event",
+			event:   `{"someOtherField": "value"}`,
+			wantErr: ErrUnrecognizedEvent,
+		},
+		{
+			name:    "not JSON",
+			event:   `not json`,
+			wantErr: ErrUnrecognizedEvent,
+		},
+		{
+			name: "ECS task state change with a wrongly typed field",
+			event: `{"source": "aws.ecs", "detail-type": "ECS Task State Change",
+				"detail": {"taskArn": "arn", "containers": [{"exitCode": "0"}]}}`,
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "WebSocket without route",
+			event:   `{"requestContext": {"routeKey": ""}, "awslogs": null}`,
+			wantErr: ErrUnrecognizedEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeEvent(json.RawMessage(tt.event))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, decoded)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSchema, decoded.Schema)
+		})
+	}
+}
+
+func TestDecodeEvent_TypedContent(t *testing.T) {
+	decoded, err := DecodeEvent(json.RawMessage(
+		`{"source": "aws.events", "detail-type": "Scheduled Event", "detail": {"runvoy_event": "health_reconcile"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, decoded.CloudWatch)
+	assert.Equal(t, "aws.events", decoded.CloudWatch.Source)
+	assert.JSONEq(t, `{"runvoy_event": "health_reconcile"}`, string(decoded.CloudWatch.Detail))
+	assert.Nil(t, decoded.Logs)
+	assert.Nil(t, decoded.WebSocket)
+
+	decoded, err = DecodeEvent(json.RawMessage(`{"requestContext": {"routeKey": "$disconnect", "connectionId": "c1"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, decoded.WebSocket)
+	assert.Equal(t, "c1", decoded.WebSocket.RequestContext.ConnectionID)
+}
+
+func TestEventJSONSchema(t *testing.T) {
+	ids := EventSchemaIDs()
+	require.Len(t, ids, 4)
+
+	for _, id := range ids {
+		data, ok := EventJSONSchema(id)
+		require.True(t, ok, id)
+
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(data, &document))
+		assert.Equal(t, string(id), document["$id"])
+		assert.NotEmpty(t, eventSchemas[id].metricEventType, id)
+	}
+
+	_, ok := EventJSONSchema("runvoy.processor.unknown.v1")
+	assert.False(t, ok)
+}
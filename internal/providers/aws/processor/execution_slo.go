@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// executionDuration returns how long an execution ran: its recorded duration once completed,
+// otherwise the time elapsed since it started.
+func executionDuration(execution *api.Execution, now time.Time) time.Duration {
+	if execution.CompletedAt != nil {
+		return time.Duration(execution.DurationSeconds) * time.Second
+	}
+	return now.Sub(execution.StartedAt)
+}
+
+// isExecutionSLOBreached reports whether an execution ran longer than the max duration its playbook expects.
+func isExecutionSLOBreached(execution *api.Execution, now time.Time) bool {
+	if execution.ExpectedMaxDurationSeconds <= 0 {
+		return false
+	}
+	return executionDuration(execution, now) > time.Duration(execution.ExpectedMaxDurationSeconds)*time.Second
+}
+
+// flagRunningSLOBreach flags an active execution running longer than expected as breaching its duration SLO
+// and notifies the breach, once. Only the flag is written, the execution keeps running.
+func (p *Processor) flagRunningSLOBreach(
+	ctx context.Context,
+	execution *api.Execution,
+	now time.Time,
+	reqLogger *slog.Logger,
+) (bool, error) {
+	if execution.SLOBreached || !isExecutionSLOBreached(execution, now) {
+		return false, nil
+	}
+	if err := p.executionRepo.MarkExecutionSLOBreached(ctx, execution.ExecutionID); err != nil {
+		return false, fmt.Errorf("failed to flag execution SLO breach: %w", err)
+	}
+	execution.SLOBreached = true
+	p.notifySLOBreach(ctx, execution, now, reqLogger)
+	return true, nil
+}
+
+// notifySLOBreach logs an execution breaching its duration SLO and counts it in the metric
+// the SLO breach alarm notifies the alarm topic from.
+func (p *Processor) notifySLOBreach(
+	ctx context.Context,
+	execution *api.Execution,
+	now time.Time,
+	reqLogger *slog.Logger,
+) {
+	reqLogger.Warn("execution duration SLO breached",
+		"context", map[string]any{
+			"execution_id":                  execution.ExecutionID,
+			"playbook":                      execution.Playbook,
+			"created_by":                    execution.CreatedBy,
+			"status":                        execution.Status,
+			"expected_max_duration_seconds": execution.ExpectedMaxDurationSeconds,
+			"duration_seconds":              int(executionDuration(execution, now).Seconds()),
+		})
+	p.recordSLOBreach(ctx)
+}
@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsExecutionSLOBreached(t *testing.T) {
+	now := time.Now().UTC()
+	completedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		execution *api.Execution
+		want      bool
+	}{
+		{"no SLO", &api.Execution{StartedAt: now.Add(-24 * time.Hour)}, false},
+		{"running within SLO", &api.Execution{StartedAt: now.Add(-time.Minute), ExpectedMaxDurationSeconds: 300}, false},
+		{"running over SLO", &api.Execution{StartedAt: now.Add(-10 * time.Minute), ExpectedMaxDurationSeconds: 300},
+			true},
+		{"completed within SLO", &api.Execution{
+			StartedAt: now.Add(-2 * time.Hour), CompletedAt: &completedAt, DurationSeconds: 200,
+			ExpectedMaxDurationSeconds: 300,
+		}, false},
+		{"completed slow", &api.Execution{
+			StartedAt: now.Add(-2 * time.Hour), CompletedAt: &completedAt, DurationSeconds: 400,
+			ExpectedMaxDurationSeconds: 300,
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isExecutionSLOBreached(tt.execution, now))
+		})
+	}
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_SLOBreach(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	slow := &api.Execution{
+		ExecutionID:                "exec-slow",
+		Status:                     string(constants.ExecutionRunning),
+		StartedAt:                  now.Add(-10 * time.Minute),
+		Playbook:                   "deploy",
+		ExpectedMaxDurationSeconds: 300,
+	}
+	alreadyFlagged := &api.Execution{
+		ExecutionID:                "exec-flagged",
+		Status:                     string(constants.ExecutionRunning),
+		StartedAt:                  now.Add(-time.Hour),
+		ExpectedMaxDurationSeconds: 300,
+		SLOBreached:                true,
+	}
+	withinSLO := &api.Execution{
+		ExecutionID:                "exec-within",
+		Status:                     string(constants.ExecutionRunning),
+		StartedAt:                  now.Add(-time.Minute),
+		ExpectedMaxDurationSeconds: 300,
+	}
+
+	var flagged []string
+	updateCalled := false
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{slow, alreadyFlagged, withinSLO}, nil
+		},
+		markSLOBreachedFunc: func(_ context.Context, executionID string) error {
+			flagged = append(flagged, executionID)
+			return nil
+		},
+		updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			updateCalled = true
+			return nil
+		},
+	}
+	recorder := &recordingMetricsRecorder{}
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{},
+		&mockTaskManager{}, testutil.SilentLogger())
+	processor.metrics = recorder
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, testutil.SilentLogger())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec-slow"}, flagged)
+	assert.True(t, slow.SLOBreached)
+	assert.False(t, updateCalled, "running executions must not be rewritten")
+	assert.Len(t, recorder.byName(constants.MetricExecutionSLOBreaches), 1)
+}
+
+func TestHandle_FlagsSlowCompletion(t *testing.T) {
+	tests := []struct {
+		name           string
+		alreadyFlagged bool
+		wantBreaches   int
+	}{
+		{"completed slow", false, 1},
+		{"flagged while running", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executionID := "exec-slow"
+			startedAt := time.Now().Add(-10 * time.Minute).UTC()
+			var stored *api.Execution
+			execRepo := &mockExecutionRepo{
+				getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+					return &api.Execution{
+						ExecutionID:                executionID,
+						Status:                     string(constants.ExecutionRunning),
+						StartedAt:                  startedAt,
+						Playbook:                   "deploy",
+						ExpectedMaxDurationSeconds: 60,
+						SLOBreached:                tt.alreadyFlagged,
+					}, nil
+				},
+				updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+					stored = execution
+					return nil
+				},
+			}
+			recorder := &recordingMetricsRecorder{}
+			processor := NewProcessor(execRepo, &noopLogEventRepo{}, &mockWebSocketManager{}, nil, nil,
+				testutil.SilentLogger())
+			processor.metrics = recorder
+
+			raw := json.RawMessage(mustMarshal(events.CloudWatchEvent{
+				Source:     "aws.ecs",
+				DetailType: "ECS Task State Change",
+				Detail: mustMarshal(ECSTaskStateChangeEvent{
+					TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+					LastStatus: "STOPPED",
+					StartedAt:  startedAt.Format(time.RFC3339),
+					StoppedAt:  time.Now().UTC().Format(time.RFC3339),
+					Containers: []ContainerDetail{{Name: awsConstants.RunnerContainerName, ExitCode: intPtr(0)}},
+				}),
+			}))
+
+			_, err := processor.Handle(context.Background(), &raw)
+
+			require.NoError(t, err)
+			require.NotNil(t, stored)
+			assert.True(t, stored.SLOBreached)
+			assert.Len(t, recorder.byName(constants.MetricExecutionSLOBreaches), tt.wantBreaches)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+)
+
+// ECS error prefixes and messages that identify the cause of a failed task.
+const (
+	ecsOutOfMemoryError      = "OutOfMemoryError"
+	ecsCannotPullContainer   = "CannotPullContainerError"
+	ecsCapacityUnavailable   = "Capacity is unavailable"
+	ecsResourceUnavailable   = "RESOURCE:"
+	ecsStopCodeFailedToStart = "TaskFailedToStart"
+)
+
+// classifyFailure determines why a task failed from its stop code, stopped reason and container reasons.
+// It returns the failure reason with the ECS message it was derived from, or an empty reason when ECS
+// reports no cause beyond the exit code of the command.
+func classifyFailure(taskEvent *ECSTaskStateChangeEvent) (reason constants.FailureReason, message string) {
+	// The runner container reason is checked first, as it is the most specific:
+	// a sidecar failing to pull its image is reported on the sidecar container.
+	reasons := make([]string, 0, len(taskEvent.Containers)+1)
+	for _, container := range taskEvent.Containers {
+		if container.Reason == "" {
+			continue
+		}
+		if container.Name == awsConstants.RunnerContainerName {
+			reasons = append([]string{container.Reason}, reasons...)
+		} else {
+			reasons = append(reasons, container.Reason)
+		}
+	}
+	if taskEvent.StoppedReason != "" {
+		reasons = append(reasons, taskEvent.StoppedReason)
+	}
+
+	for _, candidate := range []struct {
+		reason constants.FailureReason
+		match  string
+	}{
+		{constants.FailureReasonOOMKilled, ecsOutOfMemoryError},
+		{constants.FailureReasonImagePullFailed, ecsCannotPullContainer},
+		{constants.FailureReasonCapacityUnavailable, ecsCapacityUnavailable},
+		{constants.FailureReasonCapacityUnavailable, ecsResourceUnavailable},
+	} {
+		for _, text := range reasons {
+			if strings.Contains(text, candidate.match) {
+				return candidate.reason, text
+			}
+		}
+	}
+
+	if taskEvent.StopCode == ecsStopCodeFailedToStart {
+		return constants.FailureReasonStartFailed, taskEvent.StoppedReason
+	}
+
+	return "", ""
+}
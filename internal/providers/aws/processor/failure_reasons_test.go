@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name            string
+		taskEvent       ECSTaskStateChangeEvent
+		expectedReason  constants.FailureReason
+		expectedMessage string
+	}{
+		{
+			name: "runner killed for exceeding its memory",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "EssentialContainerExited",
+				StoppedReason: "Essential container in task exited",
+				Containers: []ContainerDetail{{
+					Name:     awsConstants.RunnerContainerName,
+					ExitCode: intPtr(137),
+					Reason:   "OutOfMemoryError: Container killed due to memory usage",
+				}},
+			},
+			expectedReason:  constants.FailureReasonOOMKilled,
+			expectedMessage: "OutOfMemoryError: Container killed due to memory usage",
+		},
+		{
+			name: "image pull failure reported on the task",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "TaskFailedToStart",
+				StoppedReason: "CannotPullContainerError: pull image manifest has been retried 5 time(s)",
+			},
+			expectedReason:  constants.FailureReasonImagePullFailed,
+			expectedMessage: "CannotPullContainerError: pull image manifest has been retried 5 time(s)",
+		},
+		{
+			name: "image pull failure reported on a sidecar",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "TaskFailedToStart",
+				StoppedReason: "Task failed to start",
+				Containers: []ContainerDetail{
+					{Name: awsConstants.RunnerContainerName},
+					{Name: "sidecar", Reason: "CannotPullContainerError: ref not found"},
+				},
+			},
+			expectedReason:  constants.FailureReasonImagePullFailed,
+			expectedMessage: "CannotPullContainerError: ref not found",
+		},
+		{
+			name: "no Fargate capacity",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "TaskFailedToStart",
+				StoppedReason: "Capacity is unavailable at this time. Please try again later or in a different availability zone",
+			},
+			expectedReason: constants.FailureReasonCapacityUnavailable,
+			expectedMessage: "Capacity is unavailable at this time. " +
+				"Please try again later or in a different availability zone",
+		},
+		{
+			name: "other start failure",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "TaskFailedToStart",
+				StoppedReason: "ResourceInitializationError: unable to pull secrets or registry auth",
+			},
+			expectedReason:  constants.FailureReasonStartFailed,
+			expectedMessage: "ResourceInitializationError: unable to pull secrets or registry auth",
+		},
+		{
+			name: "command exited with an error",
+			taskEvent: ECSTaskStateChangeEvent{
+				StopCode:      "EssentialContainerExited",
+				StoppedReason: "Essential container in task exited",
+				Containers:    []ContainerDetail{{Name: awsConstants.RunnerContainerName, ExitCode: intPtr(1)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message := classifyFailure(&tt.taskEvent)
+
+			assert.Equal(t, tt.expectedReason, reason)
+			assert.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
+
+func TestFinalizeExecutionFromTaskEvent_RecordsFailureReason(t *testing.T) {
+	executionID := "exec-oom"
+	var updated *api.Execution
+	execRepo := &mockExecutionRepo{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID, Status: string(constants.ExecutionRunning)}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, exec *api.Execution) error {
+			updated = exec
+			return nil
+		},
+	}
+	p := &Processor{
+		executionRepo:    execRepo,
+		logEventRepo:     &noopLogEventRepo{},
+		webSocketManager: &mockWebSocketManager{},
+	}
+
+	now := time.Now()
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+			LastStatus: "STOPPED",
+			StartedAt:  now.Add(-time.Minute).Format(time.RFC3339),
+			StoppedAt:  now.Format(time.RFC3339),
+			StopCode:   "EssentialContainerExited",
+			Containers: []ContainerDetail{{
+				Name:     awsConstants.RunnerContainerName,
+				ExitCode: intPtr(137),
+				Reason:   "OutOfMemoryError: Container killed due to memory usage",
+			}},
+		}),
+	}
+
+	err := p.handleECSTaskEvent(context.Background(), event, testutil.SilentLogger())
+
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, string(constants.ExecutionFailed), updated.Status)
+	assert.Equal(t, 137, updated.ExitCode)
+	assert.Equal(t, string(constants.FailureReasonOOMKilled), updated.FailureReason)
+	assert.Equal(t, "OutOfMemoryError: Container killed due to memory usage", updated.FailureMessage)
+}
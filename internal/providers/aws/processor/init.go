@@ -4,25 +4,33 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/claims"
 	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/backend/usage"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/email"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsClient "github.com/runvoy/runvoy/internal/providers/aws/client"
 	awsDatabase "github.com/runvoy/runvoy/internal/providers/aws/database"
 	dynamoRepo "github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
 	awsHealth "github.com/runvoy/runvoy/internal/providers/aws/health"
 	"github.com/runvoy/runvoy/internal/providers/aws/identity"
+	"github.com/runvoy/runvoy/internal/providers/aws/keys"
 	awsOrchestrator "github.com/runvoy/runvoy/internal/providers/aws/orchestrator"
 	"github.com/runvoy/runvoy/internal/providers/aws/secrets"
 	"github.com/runvoy/runvoy/internal/providers/aws/websocket"
 
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 )
 
@@ -45,17 +53,25 @@ func Initialize(
 	}
 
 	awsCfg := *cfg.AWS.SDKConfig
-	dynamoSDKClient := dynamodb.NewFromConfig(awsCfg)
+	dynamoSDKClient := dynamoRepo.NewSDKClient(awsCfg)
 	ssmSDKClient := ssm.NewFromConfig(awsCfg)
+	kmsSDKClient := kms.NewFromConfig(awsCfg)
 
-	dynamoClient := dynamoRepo.NewClientAdapter(dynamoSDKClient)
+	// The processor exposes no query statistics, instrumenting its queries logs the slow ones.
+	dynamoClient := dynamoRepo.NewInstrumentedClient(
+		dynamoRepo.NewClientAdapter(dynamoSDKClient), database.NewQueryStats(cfg.SlowQueryThreshold), log)
 	ssmClient := secrets.NewClientAdapter(ssmSDKClient)
+	kmsClient := keys.NewClientAdapter(kmsSDKClient)
 
-	repos := awsDatabase.CreateRepositories(dynamoClient, ssmClient, cfg, log)
-	websocketManager := websocket.Initialize(cfg, repos.ConnectionRepo, repos.TokenRepo, repos.LogEventRepo, log)
+	repos := awsDatabase.CreateRepositories(dynamoClient, ssmClient, kmsClient, cfg, log)
+	metricsRecorder := awsOrchestrator.NewEMFMetricsRecorder(os.Stdout, cfg.AWS.MetricsNamespace, log)
+	websocketManager := websocket.Initialize(
+		cfg, repos.ConnectionRepo, repos.TokenRepo, repos.LogEventRepo, metricsRecorder, log,
+	)
 
+	// The roles loaded at startup must not be disturbed by fault injection.
 	if err := enforcer.Hydrate(
-		ctx,
+		chaos.Suppress(ctx),
 		repos.UserRepo,
 		repos.ExecutionRepo,
 		repos.SecretsRepo,
@@ -64,11 +80,14 @@ func Initialize(
 		return nil, fmt.Errorf("failed to hydrate enforcer: %w", err)
 	}
 
+	ecsClient := awsClient.NewECSClientAdapter(ecs.NewFromConfig(awsCfg))
+	cwlClient := awsClient.NewCloudWatchLogsClientAdapter(cloudwatchlogs.NewFromConfig(awsCfg))
 	healthManager := initializeHealthManager(
 		accountID,
-		awsClient.NewECSClientAdapter(ecs.NewFromConfig(awsCfg)),
+		ecsClient,
 		ssmClient,
 		awsClient.NewIAMClientAdapter(iam.NewFromConfig(awsCfg)),
+		cwlClient,
 		repos.ImageTaskDefRepo,
 		repos.SecretsRepo,
 		repos.UserRepo,
@@ -87,7 +106,46 @@ func Initialize(
 			"websocket_tokens_table":      cfg.AWS.WebSocketTokensTable,
 		})
 
-	return NewProcessor(repos.ExecutionRepo, repos.LogEventRepo, websocketManager, healthManager, log), nil
+	// The presign client is only used to start warm pool slots, which wait for their assignment
+	// on a presigned URL of the inputs bucket.
+	taskManager := awsOrchestrator.NewTaskManager(
+		ecsClient,
+		awsClient.NewS3PresignClientAdapter(s3.NewPresignClient(s3.NewFromConfig(awsCfg))),
+		repos.ImageTaskDefRepo,
+		&awsOrchestrator.Config{
+			ECSCluster:    cfg.AWS.ECSCluster,
+			Subnet1:       cfg.AWS.Subnet1,
+			Subnet2:       cfg.AWS.Subnet2,
+			SecurityGroup: cfg.AWS.SecurityGroup,
+			Region:        cfg.AWS.SDKConfig.Region,
+			AccountID:     accountID,
+			InputsBucket:  cfg.AWS.InputsBucket,
+			ResourceTags:  cfg.ResourceTags,
+			SDKConfig:     cfg.AWS.SDKConfig,
+		},
+		log,
+	)
+
+	processor := NewProcessor(
+		repos.ExecutionRepo, repos.LogEventRepo, websocketManager, healthManager, taskManager, log,
+	)
+	processor.healthReportRepo = repos.HealthReportRepo
+	processor.lockRepo = repos.LockRepo
+	processor.claimTokens = claims.NewSweeper(repos.UserRepo, cfg.DeleteUnclaimedUsers, log)
+	processor.metrics = metricsRecorder
+	if cfg.AWS.InputsBucket != "" {
+		processor.warmPools = taskManager
+	}
+	processor.resourceUsage = awsOrchestrator.NewObservabilityManager(cwlClient, log, nil, cfg.AWS.ECSCluster)
+	if cfg.SMTP.Enabled() && repos.ConfigRepo != nil {
+		sender, err := email.NewSMTPSender(cfg.SMTP.Addr, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email sender: %w", err)
+		}
+		processor.usageReports = usage.NewReporter(repos.ExecutionRepo, repos.UserRepo, repos.ConfigRepo, sender, log)
+	}
+
+	return processor, nil
 }
 
 func initializeHealthManager(
@@ -95,6 +153,7 @@ func initializeHealthManager(
 	ecsClient awsClient.ECSClient,
 	ssmClient secrets.Client,
 	iamClient awsClient.IAMClient,
+	cwlClient awsClient.CloudWatchLogsClient,
 	imageTaskDefRepo awsHealth.ImageTaskDefRepository,
 	secretsRepo database.SecretsRepository,
 	userRepo database.UserRepository,
@@ -110,11 +169,13 @@ func initializeHealthManager(
 		DefaultTaskExecRoleARN: cfg.AWS.DefaultTaskExecRoleARN,
 		LogGroup:               cfg.AWS.LogGroup,
 		SecretsPrefix:          cfg.AWS.SecretsPrefix,
+		ResourceTags:           cfg.ResourceTags,
 	}
 	return awsHealth.Initialize(
 		ecsClient,
 		ssmClient,
 		iamClient,
+		cwlClient,
 		imageTaskDefRepo,
 		secretsRepo,
 		userRepo,
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+)
+
+// recordLifecycleEvents records the lifecycle events of an execution derived from an ECS task state change.
+// Every task event carries the timestamps of all the steps reached so far, so a missed or out of order
+// event only delays the timeline. Failures are logged but do not fail the event, as the timeline
+// is diagnostic data and the execution status must still be updated.
+func (p *Processor) recordLifecycleEvents(
+	ctx context.Context,
+	executionID string,
+	taskEvent *ECSTaskStateChangeEvent,
+	eventTime time.Time,
+	reqLogger *slog.Logger,
+) {
+	lifecycleEvents := lifecycleEventsFromTask(taskEvent, reqLogger)
+	if taskEvent.StartedBy == awsConstants.WarmPoolStartedBy {
+		lifecycleEvents = warmSlotLifecycleEvents(lifecycleEvents, taskEvent, eventTime)
+	}
+	if len(lifecycleEvents) == 0 {
+		return
+	}
+
+	if err := p.executionRepo.AddExecutionEvents(ctx, executionID, lifecycleEvents); err != nil {
+		reqLogger.Warn("failed to record execution lifecycle events",
+			"error", err,
+			"execution_id", executionID,
+			"last_status", taskEvent.LastStatus,
+		)
+	}
+}
+
+// lifecycleEventsFromTask maps the timestamps of an ECS task to execution lifecycle events.
+// Timestamps that are missing or cannot be parsed are skipped.
+func lifecycleEventsFromTask(taskEvent *ECSTaskStateChangeEvent, reqLogger *slog.Logger) []api.ExecutionEvent {
+	steps := []struct {
+		eventType constants.ExecutionEventType
+		timestamp string
+		reason    string
+	}{
+		{constants.ExecutionEventProvisioning, taskEvent.CreatedAt, ""},
+		{constants.ExecutionEventPullingImage, taskEvent.PullStartedAt, ""},
+		{constants.ExecutionEventRunning, taskEvent.StartedAt, ""},
+		{constants.ExecutionEventStopping, taskEvent.StoppingAt, stopReason(taskEvent)},
+		{constants.ExecutionEventStopped, taskEvent.StoppedAt, stoppedReason(taskEvent)},
+	}
+
+	lifecycleEvents := make([]api.ExecutionEvent, 0, len(steps))
+	for _, step := range steps {
+		if step.timestamp == "" {
+			continue
+		}
+		timestamp, err := ParseTime(step.timestamp)
+		if err != nil {
+			reqLogger.Warn("skipping lifecycle event with invalid timestamp",
+				"error", err,
+				"event_type", step.eventType,
+			)
+			continue
+		}
+		lifecycleEvents = append(lifecycleEvents, api.ExecutionEvent{
+			Type:      string(step.eventType),
+			Timestamp: timestamp,
+			Reason:    step.reason,
+		})
+	}
+	return lifecycleEvents
+}
+
+// warmSlotLifecycleEvents adapts the lifecycle events of a warm pool slot to its execution.
+// The slot was provisioned and started before the execution was assigned to it, so those steps are
+// dropped and the execution is running from the first event reporting the runner container started.
+func warmSlotLifecycleEvents(
+	lifecycleEvents []api.ExecutionEvent, taskEvent *ECSTaskStateChangeEvent, eventTime time.Time,
+) []api.ExecutionEvent {
+	lifecycleEvents = slices.DeleteFunc(lifecycleEvents, func(event api.ExecutionEvent) bool {
+		switch constants.ExecutionEventType(event.Type) { //nolint:exhaustive // only steps before the assignment
+		case constants.ExecutionEventProvisioning, constants.ExecutionEventPullingImage, constants.ExecutionEventRunning:
+			return true
+		default:
+			return false
+		}
+	})
+	for _, container := range taskEvent.Containers {
+		if container.Name == awsConstants.RunnerContainerName &&
+			(container.LastStatus == string(awsConstants.EcsStatusRunning) || container.ExitCode != nil) {
+			lifecycleEvents = append(lifecycleEvents, api.ExecutionEvent{
+				Type:      string(constants.ExecutionEventRunning),
+				Timestamp: eventTime.UTC(),
+				Reason:    "started on a warm pool slot",
+			})
+			break
+		}
+	}
+	return lifecycleEvents
+}
+
+// stopReason describes why ECS is stopping a task, e.g. "EssentialContainerExited: Essential container
+// in task exited".
+func stopReason(taskEvent *ECSTaskStateChangeEvent) string {
+	switch {
+	case taskEvent.StopCode != "" && taskEvent.StoppedReason != "":
+		return taskEvent.StopCode + ": " + taskEvent.StoppedReason
+	case taskEvent.StopCode != "":
+		return taskEvent.StopCode
+	default:
+		return taskEvent.StoppedReason
+	}
+}
+
+// stoppedReason completes the stop reason with the outcome of the runner container,
+// which explains failures such as an image that could not be pulled.
+func stoppedReason(taskEvent *ECSTaskStateChangeEvent) string {
+	var parts []string
+	if reason := stopReason(taskEvent); reason != "" {
+		parts = append(parts, reason)
+	}
+	for _, container := range taskEvent.Containers {
+		if container.Name != awsConstants.RunnerContainerName {
+			continue
+		}
+		if container.Reason != "" {
+			parts = append(parts, "container: "+container.Reason)
+		}
+		if container.ExitCode != nil {
+			parts = append(parts, fmt.Sprintf("exit code %d", *container.ExitCode))
+		}
+		break
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleEventsFromTask(t *testing.T) {
+	taskEvent := &ECSTaskStateChangeEvent{
+		LastStatus:    "STOPPED",
+		CreatedAt:     "2026-10-18T12:00:00.000Z",
+		PullStartedAt: "2026-10-18T12:00:05.250Z",
+		PullStoppedAt: "2026-10-18T12:00:40.000Z",
+		StartedAt:     "2026-10-18T12:00:41.000Z",
+		StoppingAt:    "2026-10-18T12:01:00.000Z",
+		StoppedAt:     "2026-10-18T12:01:02.000Z",
+		StopCode:      "EssentialContainerExited",
+		StoppedReason: "Essential container in task exited",
+		Containers: []ContainerDetail{
+			{Name: "sidecar", Reason: "ignored", ExitCode: intPtr(0)},
+			{Name: awsConstants.RunnerContainerName, Reason: "OutOfMemoryError", ExitCode: intPtr(137)},
+		},
+	}
+
+	lifecycleEvents := lifecycleEventsFromTask(taskEvent, testutil.SilentLogger())
+
+	require.Len(t, lifecycleEvents, 5)
+	assert.Equal(t, api.ExecutionEvent{
+		Type:      string(constants.ExecutionEventPullingImage),
+		Timestamp: time.Date(2026, 10, 18, 12, 0, 5, 250*int(time.Millisecond), time.UTC),
+	}, lifecycleEvents[1])
+	assert.Equal(t, "EssentialContainerExited: Essential container in task exited", lifecycleEvents[3].Reason)
+	assert.Equal(t,
+		"EssentialContainerExited: Essential container in task exited; container: OutOfMemoryError; exit code 137",
+		lifecycleEvents[4].Reason)
+}
+
+func TestLifecycleEventsFromTask_SkipsMissingAndInvalidTimestamps(t *testing.T) {
+	taskEvent := &ECSTaskStateChangeEvent{
+		LastStatus:    "PENDING",
+		CreatedAt:     "2026-10-18T12:00:00Z",
+		PullStartedAt: "not a timestamp",
+	}
+
+	lifecycleEvents := lifecycleEventsFromTask(taskEvent, testutil.SilentLogger())
+
+	require.Len(t, lifecycleEvents, 1)
+	assert.Equal(t, string(constants.ExecutionEventProvisioning), lifecycleEvents[0].Type)
+	assert.Empty(t, lifecycleEvents[0].Reason)
+}
+
+func TestHandleECSTaskEvent_RecordsLifecycleEvents(t *testing.T) {
+	executionID := "exec-lifecycle"
+	var recorded []api.ExecutionEvent
+	updateCalled := false
+	execRepo := &mockExecutionRepo{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID, Status: string(constants.ExecutionStarting)}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			updateCalled = true
+			return nil
+		},
+		addEventsFunc: func(_ context.Context, id string, lifecycleEvents []api.ExecutionEvent) error {
+			assert.Equal(t, executionID, id)
+			recorded = lifecycleEvents
+			return errors.New("throttled")
+		},
+	}
+	p := &Processor{executionRepo: execRepo, logEventRepo: &noopLogEventRepo{}}
+
+	event := &events.CloudWatchEvent{
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:       "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+			LastStatus:    "PENDING",
+			CreatedAt:     "2026-10-18T12:00:00Z",
+			PullStartedAt: "2026-10-18T12:00:03Z",
+		}),
+	}
+
+	err := p.handleECSTaskEvent(context.Background(), event, testutil.SilentLogger())
+
+	require.NoError(t, err, "failing to record lifecycle events must not fail the task event")
+	assert.False(t, updateCalled)
+	require.Len(t, recorded, 2)
+	assert.Equal(t, string(constants.ExecutionEventPullingImage), recorded[1].Type)
+}
+
+func TestWarmSlotLifecycleEvents(t *testing.T) {
+	eventTime := time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)
+	taskEvent := &ECSTaskStateChangeEvent{
+		LastStatus:    "RUNNING",
+		StartedBy:     awsConstants.WarmPoolStartedBy,
+		CreatedAt:     "2026-10-18T12:00:00Z",
+		PullStartedAt: "2026-10-18T12:00:05Z",
+		StartedAt:     "2026-10-18T12:00:41Z",
+		Containers: []ContainerDetail{
+			{Name: awsConstants.SidecarContainerName, LastStatus: "STOPPED", ExitCode: intPtr(0)},
+			{Name: awsConstants.RunnerContainerName, LastStatus: "RUNNING"},
+		},
+	}
+
+	lifecycleEvents := warmSlotLifecycleEvents(
+		lifecycleEventsFromTask(taskEvent, testutil.SilentLogger()), taskEvent, eventTime)
+
+	require.Len(t, lifecycleEvents, 1)
+	assert.Equal(t, api.ExecutionEvent{
+		Type:      string(constants.ExecutionEventRunning),
+		Timestamp: eventTime,
+		Reason:    "started on a warm pool slot",
+	}, lifecycleEvents[0])
+}
+
+func TestWarmSlotLifecycleEvents_RunnerNotStarted(t *testing.T) {
+	taskEvent := &ECSTaskStateChangeEvent{
+		LastStatus: "RUNNING",
+		StartedBy:  awsConstants.WarmPoolStartedBy,
+		CreatedAt:  "2026-10-18T12:00:00Z",
+		StartedAt:  "2026-10-18T12:00:41Z",
+		Containers: []ContainerDetail{
+			{Name: awsConstants.SidecarContainerName, LastStatus: "RUNNING"},
+			{Name: awsConstants.RunnerContainerName, LastStatus: "PENDING"},
+		},
+	}
+
+	lifecycleEvents := warmSlotLifecycleEvents(
+		lifecycleEventsFromTask(taskEvent, testutil.SilentLogger()), taskEvent, time.Now())
+
+	assert.Empty(t, lifecycleEvents)
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/backend/logguard"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/runnerinit"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -35,27 +37,91 @@ func convertCloudWatchLogEvents(reqLogger *slog.Logger, cwLogEvents []events.Clo
 	return logEvents
 }
 
-// handleLogsEvent processes CloudWatch Logs events.
-func (p *Processor) handleLogsEvent(
+// guardLogEvents applies the log limits of an execution to a batch of its log events and records
+// the log output ingested and dropped on the execution. Guarding is best-effort: when the execution
+// cannot be loaded the backend default limits apply, and usage recording failures are only logged.
+func (p *Processor) guardLogEvents(
 	ctx context.Context,
-	rawEvent *json.RawMessage,
 	reqLogger *slog.Logger,
-) (bool, error) {
-	var cwLogsEvent events.CloudwatchLogsEvent
-	if err := json.Unmarshal(*rawEvent, &cwLogsEvent); err != nil {
-		reqLogger.Debug("event is not a CloudWatch Logs event", "error", err)
-		return false, nil
+	executionID string,
+	logEvents []api.LogEvent,
+) []api.LogEvent {
+	var limits *api.LogLimits
+	var usage *api.LogUsage
+	execution, err := p.executionRepo.GetExecution(ctx, executionID)
+	if err != nil {
+		reqLogger.Warn("failed to load execution, applying default log limits",
+			"error", err, "execution_id", executionID)
+	} else if execution != nil {
+		limits = execution.LogLimits
+		usage = execution.LogUsage
 	}
-	if cwLogsEvent.AWSLogs.Data == "" {
-		return false, nil
+
+	kept, added := logguard.Apply(limits, usage, logEvents)
+
+	if added.DroppedLines > 0 {
+		effective := logguard.EffectiveLimits(limits)
+		reqLogger.Warn("execution log output exceeds its limits, lines dropped", "context", map[string]any{
+			"execution_id":         executionID,
+			"dropped_lines":        added.DroppedLines,
+			"dropped_bytes":        added.DroppedBytes,
+			"max_lines_per_second": effective.MaxLinesPerSecond,
+			"max_bytes":            effective.MaxBytes,
+		})
 	}
 
+	if execution != nil && (added.Bytes > 0 || added.DroppedLines > 0) {
+		if usageErr := p.executionRepo.AddLogUsage(ctx, executionID, &added); usageErr != nil {
+			reqLogger.Error("failed to record execution log usage", "error", usageErr, "execution_id", executionID)
+		}
+	}
+
+	return kept
+}
+
+// recordExecutionResult stores on the execution the JSON result reported by runvoy-init in a result marker
+// of the batch, the last one winning. Failures are only logged, the log events are processed regardless.
+func (p *Processor) recordExecutionResult(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+	executionID string,
+	logEvents []api.LogEvent,
+) {
+	var result json.RawMessage
+	for i := range logEvents {
+		marker, ok := runnerinit.ParseMarker(logEvents[i].Message)
+		if !ok || marker.Event != runnerinit.EventResult {
+			continue
+		}
+		if len(marker.Result) == 0 {
+			reqLogger.Warn("execution result not reported", "execution_id", executionID, "reason", marker.Message)
+			continue
+		}
+		result = marker.Result
+	}
+	if result == nil {
+		return
+	}
+
+	if err := p.executionRepo.SetExecutionResult(ctx, executionID, result); err != nil {
+		reqLogger.Error("failed to record execution result", "error", err, "execution_id", executionID)
+		return
+	}
+	reqLogger.Info("execution result recorded", "execution_id", executionID, "result_bytes", len(result))
+}
+
+// handleLogsEvent processes CloudWatch Logs events.
+func (p *Processor) handleLogsEvent(
+	ctx context.Context,
+	cwLogsEvent *events.CloudwatchLogsEvent,
+	reqLogger *slog.Logger,
+) error {
 	data, err := cwLogsEvent.AWSLogs.Parse()
 	if err != nil {
 		reqLogger.Error("failed to parse CloudWatch Logs data",
 			"error", err,
 		)
-		return true, fmt.Errorf("failed to parse CloudWatch Logs data: %w", err)
+		return fmt.Errorf("failed to parse CloudWatch Logs data: %w", err)
 	}
 
 	executionID := awsConstants.ExtractExecutionIDFromLogStream(data.LogStream)
@@ -65,7 +131,7 @@ func (p *Processor) handleLogsEvent(
 				"log_stream": data.LogStream,
 			},
 		)
-		return true, nil
+		return nil
 	}
 
 	reqLogger.Debug("processing CloudWatch logs event",
@@ -78,11 +144,15 @@ func (p *Processor) handleLogsEvent(
 	)
 
 	logEvents := convertCloudWatchLogEvents(reqLogger, data.LogEvents)
+	// The result is read before guarding, a result line must not be dropped with the log output
+	p.recordExecutionResult(ctx, reqLogger, executionID, logEvents)
+	logEvents = p.guardLogEvents(ctx, reqLogger, executionID, logEvents)
 
 	if err = p.logEventRepo.SaveLogEvents(ctx, executionID, logEvents); err != nil {
 		reqLogger.Error("failed to persist log events", "error", err, "execution_id", executionID)
-		return true, fmt.Errorf("failed to persist log events: %w", err)
+		return fmt.Errorf("failed to persist log events: %w", err)
 	}
+	p.recordLogBufferingLag(ctx, logEvents)
 
 	sendErr := p.webSocketManager.SendLogsToExecution(ctx, &executionID)
 	if sendErr != nil {
@@ -93,5 +163,5 @@ func (p *Processor) handleLogsEvent(
 		// Don't return error - logs were processed correctly, connection issue shouldn't fail processing
 	}
 
-	return true, nil
+	return nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/runnerinit"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -54,6 +55,12 @@ func (m *mockWebSocketManagerForLogsEvents) NotifyExecutionCompletion(_ context.
 	return nil
 }
 
+func (m *mockWebSocketManagerForLogsEvents) NotifyExecutionStatus(
+	_ context.Context, _ *api.ExecutionStatusChange,
+) error {
+	return nil
+}
+
 func (m *mockWebSocketManagerForLogsEvents) SendLogsToExecution(ctx context.Context, executionID *string) error {
 	if m.sendLogsFunc != nil {
 		return m.sendLogsFunc(ctx, executionID)
@@ -117,7 +124,7 @@ func TestHandleLogsEvent_Comprehensive_Success(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, wsManager, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, wsManager, nil, nil, logger)
 
 	// Create valid CloudWatch Logs event
 	logStream := awsConstants.BuildLogStreamName(executionID)
@@ -147,10 +154,9 @@ func TestHandleLogsEvent_Comprehensive_Success(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	assert.Equal(t, executionID, savedExecutionID)
 	assert.Len(t, savedLogEvents, 2)
 	assert.Equal(t, "event-1", savedLogEvents[0].EventID)
@@ -163,22 +169,21 @@ func TestHandleLogsEvent_Comprehensive_InvalidJSON(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
 
-	processor := NewProcessor(nil, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	// Invalid JSON
 	rawMsg := json.RawMessage(`{"invalid": json}`)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err := processor.Handle(ctx, &rawMsg)
 
-	assert.NoError(t, err)
-	assert.False(t, handled) // Should return false for invalid JSON
+	assert.ErrorIs(t, err, ErrUnrecognizedEvent)
 }
 
 func TestHandleLogsEvent_Comprehensive_EmptyData(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
 
-	processor := NewProcessor(nil, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	logsEvent := events.CloudwatchLogsEvent{
 		AWSLogs: events.CloudwatchLogsRawData{
@@ -190,17 +195,16 @@ func TestHandleLogsEvent_Comprehensive_EmptyData(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
-	assert.NoError(t, err)
-	assert.False(t, handled) // Should return false for empty data
+	assert.ErrorIs(t, err, ErrInvalidEvent, "empty data is rejected by the schema")
 }
 
 func TestHandleLogsEvent_Comprehensive_ParseError(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
 
-	processor := NewProcessor(nil, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	logsEvent := events.CloudwatchLogsEvent{
 		AWSLogs: events.CloudwatchLogsRawData{
@@ -212,10 +216,9 @@ func TestHandleLogsEvent_Comprehensive_ParseError(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.Error(t, err)
-	assert.True(t, handled) // Should return true (handled) even on error
 }
 
 func TestHandleLogsEvent_Comprehensive_MissingExecutionID(t *testing.T) {
@@ -230,7 +233,7 @@ func TestHandleLogsEvent_Comprehensive_MissingExecutionID(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	// Create logs event with invalid log stream (no execution ID)
 	logStream := "invalid/log/stream/format"
@@ -255,10 +258,9 @@ func TestHandleLogsEvent_Comprehensive_MissingExecutionID(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	assert.False(t, saveCalled) // Should not save when execution ID is missing
 }
 
@@ -273,7 +275,7 @@ func TestHandleLogsEvent_Comprehensive_SaveLogEventsError(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	logStream := awsConstants.BuildLogStreamName(executionID)
 	logEvents := []events.CloudwatchLogsLogEvent{
@@ -297,10 +299,9 @@ func TestHandleLogsEvent_Comprehensive_SaveLogEventsError(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.Error(t, err)
-	assert.True(t, handled)
 	assert.Contains(t, err.Error(), "failed to persist log events")
 }
 
@@ -323,7 +324,7 @@ func TestHandleLogsEvent_Comprehensive_WebSocketError(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, wsManager, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, wsManager, nil, nil, logger)
 
 	logStream := awsConstants.BuildLogStreamName(executionID)
 	logEvents := []events.CloudwatchLogsLogEvent{
@@ -348,10 +349,9 @@ func TestHandleLogsEvent_Comprehensive_WebSocketError(t *testing.T) {
 	rawMsg := json.RawMessage(eventJSON)
 
 	// WebSocket errors should not fail the processing
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
-	assert.NoError(t, err) // Should not return error for WebSocket failures
-	assert.True(t, handled)
+	assert.NoError(t, err)           // Should not return error for WebSocket failures
 	assert.Len(t, savedLogEvents, 1) // Logs should still be saved
 }
 
@@ -368,7 +368,7 @@ func TestHandleLogsEvent_Comprehensive_EmptyLogEvents(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	logStream := awsConstants.BuildLogStreamName(executionID)
 	logEvents := []events.CloudwatchLogsLogEvent{} // Empty log events
@@ -386,10 +386,9 @@ func TestHandleLogsEvent_Comprehensive_EmptyLogEvents(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	// Empty log events should still save (empty slice)
 	assert.True(t, saveCalled)
 }
@@ -407,7 +406,7 @@ func TestHandleLogsEvent_Comprehensive_MultipleLogEvents(t *testing.T) {
 		},
 	}
 
-	processor := NewProcessor(nil, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, logger)
+	processor := NewProcessor(&mockExecutionRepo{}, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
 
 	logStream := awsConstants.BuildLogStreamName(executionID)
 	now := time.Now()
@@ -432,13 +431,105 @@ func TestHandleLogsEvent_Comprehensive_MultipleLogEvents(t *testing.T) {
 	require.NoError(t, err)
 	rawMsg := json.RawMessage(eventJSON)
 
-	handled, err := processor.handleLogsEvent(ctx, &rawMsg, logger)
+	_, err = processor.Handle(ctx, &rawMsg)
 
 	assert.NoError(t, err)
-	assert.True(t, handled)
 	assert.Len(t, savedLogEvents, 5)
 	assert.Equal(t, "event-1", savedLogEvents[0].EventID)
 	assert.Equal(t, "First message", savedLogEvents[0].Message)
 	assert.Equal(t, "event-5", savedLogEvents[4].EventID)
 	assert.Equal(t, "Fifth message", savedLogEvents[4].Message)
 }
+
+func TestHandleLogsEvent_AppliesExecutionLogLimits(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	executionID := "exec-throttled"
+
+	var savedLogEvents []api.LogEvent
+	mockLogRepo := &mockLogEventRepoForLogsEvents{
+		saveLogEventsFunc: func(_ context.Context, _ string, events []api.LogEvent) error {
+			savedLogEvents = events
+			return nil
+		},
+	}
+
+	var recordedUsage *api.LogUsage
+	execRepo := &mockExecutionRepo{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID: executionID,
+				LogLimits:   &api.LogLimits{MaxLinesPerSecond: 1},
+			}, nil
+		},
+		addLogUsageFunc: func(_ context.Context, _ string, usage *api.LogUsage) error {
+			recordedUsage = usage
+			return nil
+		},
+	}
+
+	processor := NewProcessor(execRepo, mockLogRepo, &mockWebSocketManagerForLogsEvents{}, nil, nil, logger)
+
+	timestamp := time.Now().UnixMilli() / 1000 * 1000
+	logsData, err := createValidCloudWatchLogsData("/aws/ecs/runvoy", awsConstants.BuildLogStreamName(executionID),
+		[]events.CloudwatchLogsLogEvent{
+			{ID: "event-1", Timestamp: timestamp, Message: "first"},
+			{ID: "event-2", Timestamp: timestamp + 1, Message: "second"},
+			{ID: "event-3", Timestamp: timestamp + 2, Message: "third"},
+		})
+	require.NoError(t, err)
+
+	eventJSON, err := json.Marshal(events.CloudwatchLogsEvent{AWSLogs: events.CloudwatchLogsRawData{Data: logsData}})
+	require.NoError(t, err)
+	rawMsg := json.RawMessage(eventJSON)
+
+	_, err = processor.Handle(ctx, &rawMsg)
+
+	require.NoError(t, err)
+	require.Len(t, savedLogEvents, 2)
+	assert.Equal(t, "event-1", savedLogEvents[0].EventID)
+	assert.Contains(t, savedLogEvents[1].Message, "2 log lines dropped")
+	require.NotNil(t, recordedUsage)
+	assert.Equal(t, api.LogUsage{Bytes: 5, DroppedLines: 2, DroppedBytes: 11}, *recordedUsage)
+}
+
+func TestHandleLogsEvent_RecordsExecutionResult(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	executionID := "exec-result"
+
+	var recordedID string
+	var recordedResult json.RawMessage
+	execRepo := &mockExecutionRepo{
+		setResultFunc: func(_ context.Context, execID string, result json.RawMessage) error {
+			recordedID = execID
+			recordedResult = result
+			return nil
+		},
+	}
+
+	processor := NewProcessor(execRepo, &mockLogEventRepoForLogsEvents{}, &mockWebSocketManagerForLogsEvents{},
+		nil, nil, logger)
+
+	var marker bytes.Buffer
+	runnerinit.WriteMarker(&marker, &runnerinit.Marker{
+		Event: runnerinit.EventResult, Result: json.RawMessage(`{"summary":"3 passed"}`),
+	})
+	timestamp := time.Now().UnixMilli()
+	logsData, err := createValidCloudWatchLogsData("/aws/ecs/runvoy", awsConstants.BuildLogStreamName(executionID),
+		[]events.CloudwatchLogsLogEvent{
+			{ID: "event-1", Timestamp: timestamp, Message: "tests done"},
+			{ID: "event-2", Timestamp: timestamp + 1, Message: marker.String()},
+		})
+	require.NoError(t, err)
+
+	eventJSON, err := json.Marshal(events.CloudwatchLogsEvent{AWSLogs: events.CloudwatchLogsRawData{Data: logsData}})
+	require.NoError(t, err)
+	rawMsg := json.RawMessage(eventJSON)
+
+	_, err = processor.Handle(ctx, &rawMsg)
+
+	require.NoError(t, err)
+	assert.Equal(t, executionID, recordedID)
+	assert.JSONEq(t, `{"summary":"3 passed"}`, string(recordedResult))
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// recordMetrics publishes processor metrics when a metrics recorder is configured.
+func (p *Processor) recordMetrics(ctx context.Context, metrics ...contract.Metric) {
+	if p.metrics == nil {
+		return
+	}
+	p.metrics.RecordMetrics(ctx, metrics...)
+}
+
+// recordEventProcessed counts a handled event by type and outcome.
+func (p *Processor) recordEventProcessed(ctx context.Context, eventType string, err error) {
+	outcome := constants.MetricOutcomeSuccess
+	if err != nil {
+		outcome = constants.MetricOutcomeError
+	}
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricEventsProcessed,
+		Value: 1,
+		Unit:  contract.MetricUnitCount,
+		Dimensions: map[string]string{
+			constants.MetricDimensionEventType: eventType,
+			constants.MetricDimensionOutcome:   outcome,
+		},
+	})
+}
+
+// recordCompletionLatency records the time from the task start to the processing of its completion event.
+func (p *Processor) recordCompletionLatency(ctx context.Context, taskStartedAt time.Time) {
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricCompletionLatency,
+		Value: float64(time.Since(taskStartedAt).Milliseconds()),
+		Unit:  contract.MetricUnitMilliseconds,
+	})
+}
+
+// recordStartLatency records the time from the submission of an execution to its task running,
+// by whether the execution was assigned to a warm pool slot.
+func (p *Processor) recordStartLatency(ctx context.Context, submittedAt time.Time, startType string) {
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricStartLatency,
+		Value: float64(time.Since(submittedAt).Milliseconds()),
+		Unit:  contract.MetricUnitMilliseconds,
+		Dimensions: map[string]string{
+			constants.MetricDimensionStartType: startType,
+		},
+	})
+}
+
+// recordSLOBreach counts an execution that ran longer than its playbook expects.
+func (p *Processor) recordSLOBreach(ctx context.Context) {
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricExecutionSLOBreaches,
+		Value: 1,
+		Unit:  contract.MetricUnitCount,
+	})
+}
+
+// recordStalledKill counts a killed execution whose task still hasn't stopped, at every sweep it is found at.
+func (p *Processor) recordStalledKill(ctx context.Context) {
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricStalledKills,
+		Value: 1,
+		Unit:  contract.MetricUnitCount,
+	})
+}
+
+// recordLogBufferingLag records the age of the oldest log event of a batch once it is buffered.
+func (p *Processor) recordLogBufferingLag(ctx context.Context, logEvents []api.LogEvent) {
+	if len(logEvents) == 0 {
+		return
+	}
+	oldest := logEvents[0].Timestamp
+	for _, event := range logEvents[1:] {
+		oldest = min(oldest, event.Timestamp)
+	}
+	p.recordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricLogBufferingLag,
+		Value: float64(time.Now().UnixMilli() - oldest),
+		Unit:  contract.MetricUnitMilliseconds,
+	})
+}
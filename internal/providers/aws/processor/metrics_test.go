@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetricsRecorder struct {
+	mu      sync.Mutex
+	metrics []contract.Metric
+}
+
+func (r *recordingMetricsRecorder) RecordMetrics(_ context.Context, metrics ...contract.Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metrics...)
+}
+
+func (r *recordingMetricsRecorder) byName(name string) []contract.Metric {
+	var found []contract.Metric
+	for _, metric := range r.metrics {
+		if metric.Name == name {
+			found = append(found, metric)
+		}
+	}
+	return found
+}
+
+func TestHandle_RecordsTaskCompletionMetrics(t *testing.T) {
+	executionID := "exec-metrics"
+	startedAt := time.Now().Add(-2 * time.Minute).UTC()
+	execRepo := &mockExecutionRepo{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID: executionID,
+				Status:      string(constants.ExecutionRunning),
+				StartedAt:   startedAt,
+			}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			return nil
+		},
+	}
+	recorder := &recordingMetricsRecorder{}
+	processor := NewProcessor(execRepo, &noopLogEventRepo{}, &mockWebSocketManager{}, nil, nil,
+		testutil.SilentLogger())
+	processor.metrics = recorder
+
+	raw := json.RawMessage(mustMarshal(events.CloudWatchEvent{
+		Source:     "aws.ecs",
+		DetailType: "ECS Task State Change",
+		Detail: mustMarshal(ECSTaskStateChangeEvent{
+			TaskArn:    "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+			LastStatus: "STOPPED",
+			StartedAt:  startedAt.Format(time.RFC3339),
+			StoppedAt:  time.Now().UTC().Format(time.RFC3339),
+			Containers: []ContainerDetail{{Name: awsConstants.RunnerContainerName, ExitCode: intPtr(0)}},
+		}),
+	}))
+
+	_, err := processor.Handle(context.Background(), &raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, []contract.Metric{{
+		Name:  constants.MetricEventsProcessed,
+		Value: 1,
+		Unit:  contract.MetricUnitCount,
+		Dimensions: map[string]string{
+			constants.MetricDimensionEventType: constants.MetricEventTypeTaskStateChange,
+			constants.MetricDimensionOutcome:   constants.MetricOutcomeSuccess,
+		},
+	}}, recorder.byName(constants.MetricEventsProcessed))
+
+	latencies := recorder.byName(constants.MetricCompletionLatency)
+	require.Len(t, latencies, 1)
+	assert.Equal(t, contract.MetricUnitMilliseconds, latencies[0].Unit)
+	assert.GreaterOrEqual(t, latencies[0].Value, float64((2 * time.Minute).Milliseconds()))
+}
+
+func TestHandle_RecordsLogBufferingLag(t *testing.T) {
+	logRepo := &mockLogEventRepoForLogsEvents{
+		saveLogEventsFunc: func(_ context.Context, _ string, _ []api.LogEvent) error {
+			return nil
+		},
+	}
+	wsManager := &mockWebSocketManagerForLogsEvents{
+		sendLogsFunc: func(_ context.Context, _ *string) error {
+			return nil
+		},
+	}
+	recorder := &recordingMetricsRecorder{}
+	processor := NewProcessor(&mockExecutionRepo{}, logRepo, wsManager, nil, nil, testutil.SilentLogger())
+	processor.metrics = recorder
+
+	now := time.Now()
+	logsData, err := createValidCloudWatchLogsData("/aws/ecs/runvoy", awsConstants.BuildLogStreamName("exec-1"),
+		[]events.CloudwatchLogsLogEvent{
+			{ID: "event-1", Timestamp: now.Add(-3 * time.Second).UnixMilli(), Message: "first"},
+			{ID: "event-2", Timestamp: now.UnixMilli(), Message: "second"},
+		})
+	require.NoError(t, err)
+	raw := json.RawMessage(mustMarshal(events.CloudwatchLogsEvent{
+		AWSLogs: events.CloudwatchLogsRawData{Data: logsData},
+	}))
+
+	_, err = processor.Handle(context.Background(), &raw)
+	require.NoError(t, err)
+
+	lags := recorder.byName(constants.MetricLogBufferingLag)
+	require.Len(t, lags, 1)
+	assert.GreaterOrEqual(t, lags[0].Value, float64(3000))
+	processed := recorder.byName(constants.MetricEventsProcessed)
+	require.Len(t, processed, 1)
+	assert.Equal(t, constants.MetricEventTypeLogs, processed[0].Dimensions[constants.MetricDimensionEventType])
+}
+
+func TestHandle_RecordsFailedEvents(t *testing.T) {
+	recorder := &recordingMetricsRecorder{}
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketManager{},
+		&mockHealthManager{reconcileFunc: func(_ context.Context) (*api.HealthReport, error) {
+			return nil, assert.AnError
+		}}, nil, testutil.SilentLogger())
+	processor.metrics = recorder
+
+	raw := json.RawMessage(mustMarshal(events.CloudWatchEvent{
+		Source:     "aws.events",
+		DetailType: "Scheduled Event",
+		Detail:     json.RawMessage(`{"runvoy_event":"health_reconcile"}`),
+	}))
+
+	_, err := processor.Handle(context.Background(), &raw)
+	require.Error(t, err)
+
+	assert.Equal(t, map[string]string{
+		constants.MetricDimensionEventType: constants.MetricEventTypeScheduled,
+		constants.MetricDimensionOutcome:   constants.MetricOutcomeError,
+	}, recorder.byName(constants.MetricEventsProcessed)[0].Dimensions)
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+)
+
+// resourceUsageSummarizer summarizes the resource utilization samples of a stopped task.
+type resourceUsageSummarizer interface {
+	SummarizeResourceUsage(ctx context.Context, executionID string, start, end time.Time) (*api.ResourceSummary, error)
+}
+
+// buildResourceSummary builds the resource usage summary of a stopped task. Utilization samples are
+// best-effort: when they cannot be retrieved the summary only has the reserved resources of the task
+// and its billed duration.
+func (p *Processor) buildResourceSummary(
+	ctx context.Context,
+	executionID string,
+	taskEvent *ECSTaskStateChangeEvent,
+	startedAt, stoppedAt time.Time,
+	reqLogger *slog.Logger,
+) *api.ResourceSummary {
+	summary := &api.ResourceSummary{}
+	if p.resourceUsage != nil {
+		sampled, err := p.resourceUsage.SummarizeResourceUsage(ctx, executionID, startedAt, stoppedAt)
+		if err != nil {
+			reqLogger.Warn("failed to summarize resource usage", "error", err, "execution_id", executionID)
+		} else {
+			summary = sampled
+		}
+	}
+
+	if summary.CPUReserved == 0 {
+		summary.CPUReserved, _ = strconv.ParseFloat(taskEvent.CPU, 64)
+	}
+	if summary.MemoryReservedMiB == 0 {
+		summary.MemoryReservedMiB, _ = strconv.ParseFloat(taskEvent.Memory, 64)
+	}
+	summary.BilledDurationSeconds = billedDurationSeconds(taskEvent, startedAt, stoppedAt)
+	return summary
+}
+
+// billedDurationSeconds returns the duration Fargate charges a task for: from the start of its image pull
+// to its stop, rounded up to the second, with a one minute minimum. A warm pool slot is only accounted
+// from the assignment of its execution, its idle time is the cost of the warm pool.
+func billedDurationSeconds(taskEvent *ECSTaskStateChangeEvent, startedAt, stoppedAt time.Time) int {
+	billedFrom := startedAt
+	if taskEvent.StartedBy != awsConstants.WarmPoolStartedBy && taskEvent.PullStartedAt != "" {
+		if pullStartedAt, err := ParseTime(taskEvent.PullStartedAt); err == nil && pullStartedAt.Before(startedAt) {
+			billedFrom = pullStartedAt
+		}
+	}
+	billed := max(stoppedAt.Sub(billedFrom), awsConstants.FargateMinimumBilledDuration)
+	return int(math.Ceil(billed.Seconds()))
+}
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+)
+
+type mockResourceUsageSummarizer struct {
+	summarizeFunc func(ctx context.Context, executionID string, start, end time.Time) (*api.ResourceSummary, error)
+}
+
+func (m *mockResourceUsageSummarizer) SummarizeResourceUsage(
+	ctx context.Context, executionID string, start, end time.Time,
+) (*api.ResourceSummary, error) {
+	return m.summarizeFunc(ctx, executionID, start, end)
+}
+
+func TestHandleECSTaskEvent_StoppedRecordsResourceSummary(t *testing.T) {
+	executionID := "test-exec-summary"
+	pullStartedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	startedAt := pullStartedAt.Add(20 * time.Second)
+	stoppedAt := startedAt.Add(5*time.Minute + 500*time.Millisecond)
+
+	tests := []struct {
+		name        string
+		summarizer  resourceUsageSummarizer
+		wantSummary *api.ResourceSummary
+	}{
+		{
+			name: "with utilization samples",
+			summarizer: &mockResourceUsageSummarizer{
+				summarizeFunc: func(_ context.Context, id string, start, end time.Time) (*api.ResourceSummary, error) {
+					assert.Equal(t, executionID, id)
+					assert.True(t, start.Equal(startedAt))
+					assert.True(t, end.Equal(stoppedAt))
+					return &api.ResourceSummary{
+						Samples:               5,
+						AverageCPUUtilized:    300,
+						CPUReserved:           1024,
+						PeakMemoryUtilizedMiB: 1500,
+						MemoryReservedMiB:     2048,
+					}, nil
+				},
+			},
+			wantSummary: &api.ResourceSummary{
+				Samples:               5,
+				AverageCPUUtilized:    300,
+				CPUReserved:           1024,
+				PeakMemoryUtilizedMiB: 1500,
+				MemoryReservedMiB:     2048,
+				BilledDurationSeconds: 321,
+			},
+		},
+		{
+			name: "without utilization samples",
+			summarizer: &mockResourceUsageSummarizer{
+				summarizeFunc: func(_ context.Context, _ string, _, _ time.Time) (*api.ResourceSummary, error) {
+					return nil, errors.New("throttled")
+				},
+			},
+			wantSummary: &api.ResourceSummary{
+				CPUReserved:           512,
+				MemoryReservedMiB:     1024,
+				BilledDurationSeconds: 321,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorded *api.ResourceSummary
+			execRepo := &mockExecutionRepo{
+				getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+					return &api.Execution{
+						ExecutionID: executionID,
+						Status:      string(constants.ExecutionRunning),
+						StartedAt:   startedAt,
+					}, nil
+				},
+				updateExecutionFunc: func(_ context.Context, exec *api.Execution) error {
+					recorded = exec.ResourceSummary
+					return nil
+				},
+			}
+			p := &Processor{
+				executionRepo:    execRepo,
+				logEventRepo:     &noopLogEventRepo{},
+				webSocketManager: &mockWebSocketManager{},
+				resourceUsage:    tt.summarizer,
+			}
+
+			event := &events.CloudWatchEvent{
+				Detail: mustMarshal(ECSTaskStateChangeEvent{
+					TaskArn:       "arn:aws:ecs:us-east-1:123456789012:task/cluster/" + executionID,
+					LastStatus:    "STOPPED",
+					PullStartedAt: pullStartedAt.Format(time.RFC3339Nano),
+					StartedAt:     startedAt.Format(time.RFC3339Nano),
+					StoppedAt:     stoppedAt.Format(time.RFC3339Nano),
+					StopCode:      "EssentialContainerExited",
+					CPU:           "512",
+					Memory:        "1024",
+					Containers: []ContainerDetail{
+						{Name: awsConstants.RunnerContainerName, ExitCode: intPtr(0)},
+					},
+				}),
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+			err := p.handleECSTaskEvent(context.Background(), event, logger)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSummary, recorded)
+		})
+	}
+}
+
+func TestBilledDurationSeconds(t *testing.T) {
+	startedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		taskEvent *ECSTaskStateChangeEvent
+		stoppedAt time.Time
+		want      int
+	}{
+		{
+			name:      "from the image pull rounded up to the second",
+			taskEvent: &ECSTaskStateChangeEvent{PullStartedAt: "2026-01-01T09:59:30Z"},
+			stoppedAt: startedAt.Add(2*time.Minute + 100*time.Millisecond),
+			want:      151,
+		},
+		{
+			name:      "one minute minimum",
+			taskEvent: &ECSTaskStateChangeEvent{},
+			stoppedAt: startedAt.Add(10 * time.Second),
+			want:      60,
+		},
+		{
+			name: "warm pool slot from the assignment of its execution",
+			taskEvent: &ECSTaskStateChangeEvent{
+				StartedBy:     awsConstants.WarmPoolStartedBy,
+				PullStartedAt: "2026-01-01T09:00:00Z",
+			},
+			stoppedAt: startedAt.Add(2 * time.Minute),
+			want:      120,
+		},
+		{
+			name:      "malformed pull start",
+			taskEvent: &ECSTaskStateChangeEvent{PullStartedAt: "yesterday"},
+			stoppedAt: startedAt.Add(2 * time.Minute),
+			want:      120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, billedDurationSeconds(tt.taskEvent, startedAt, tt.stoppedAt))
+		})
+	}
+}
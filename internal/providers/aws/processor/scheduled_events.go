@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/logger"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -48,6 +53,14 @@ func (p *Processor) handleScheduledEvent(
 	switch detail.RunvoyEvent {
 	case awsConstants.ScheduledEventHealthReconcile:
 		return p.handleHealthReconcileScheduledEvent(ctx, reqLogger)
+	case awsConstants.ScheduledEventExecutionTimeouts:
+		return p.handleExecutionTimeoutsScheduledEvent(ctx, reqLogger)
+	case awsConstants.ScheduledEventWarmPools:
+		return p.handleWarmPoolsScheduledEvent(ctx, reqLogger)
+	case awsConstants.ScheduledEventUsageReport:
+		return p.handleUsageReportScheduledEvent(ctx, reqLogger)
+	case awsConstants.ScheduledEventClaimTokens:
+		return p.handleClaimTokensScheduledEvent(ctx, reqLogger)
 	default:
 		return fmt.Errorf("unexpected runvoy_event value: %s", detail.RunvoyEvent)
 	}
@@ -78,5 +91,316 @@ func (p *Processor) handleHealthReconcileScheduledEvent(
 			"identity_verified": report.IdentityStatus.DefaultRolesVerified,
 		})
 
+	if p.healthReportRepo != nil {
+		if err = p.healthReportRepo.CreateHealthReport(ctx, report); err != nil {
+			reqLogger.Warn("failed to store health report", "error", err)
+		}
+	}
+
+	p.cleanupUnreferencedResources(ctx, reqLogger)
+
+	return nil
+}
+
+// cleanupUnreferencedResources deletes the provider resources no longer referenced after the scheduled
+// reconciliation, which recreates the missing resources first. A failed cleanup is retried by the next schedule.
+func (p *Processor) cleanupUnreferencedResources(ctx context.Context, reqLogger *slog.Logger) {
+	report, err := p.healthManager.Cleanup(ctx, false)
+	if err != nil {
+		reqLogger.Warn("resource cleanup failed", "error", err)
+		return
+	}
+
+	logLevel := reqLogger.Info
+	if report.FailedCount > 0 {
+		logLevel = reqLogger.Warn
+	}
+	logLevel("resource cleanup completed",
+		"context", map[string]any{
+			"deleted_count": report.DeletedCount,
+			"failed_count":  report.FailedCount,
+			"resources":     report.Resources,
+		})
+}
+
+// handleExecutionTimeoutsScheduledEvent kills active executions that have exceeded
+// their requested timeout and marks them as TIMED_OUT. Active executions running longer than
+// their playbook expects are flagged as breaching their duration SLO along the way.
+func (p *Processor) handleExecutionTimeoutsScheduledEvent(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+) error {
+	statuses := make([]string, 0, len(constants.KillableExecutionStatuses()))
+	for _, status := range constants.KillableExecutionStatuses() {
+		statuses = append(statuses, string(status))
+	}
+
+	executions, err := p.executionRepo.ListExecutions(ctx, 0, statuses)
+	if err != nil {
+		reqLogger.Error("failed to list active executions", "error", err)
+		return fmt.Errorf("failed to list active executions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	timedOut, failed, breached := 0, 0, 0
+	for _, execution := range executions {
+		flagged, flagErr := p.flagRunningSLOBreach(ctx, execution, now, reqLogger)
+		if flagErr != nil {
+			reqLogger.Warn("failed to flag execution SLO breach",
+				"error", flagErr,
+				"execution_id", execution.ExecutionID,
+			)
+		} else if flagged {
+			breached++
+		}
+		if !isExecutionTimedOut(execution, now) {
+			continue
+		}
+		if timeoutErr := p.timeoutExecution(ctx, execution, now, reqLogger); timeoutErr != nil {
+			reqLogger.Error("failed to time out execution",
+				"error", timeoutErr,
+				"execution_id", execution.ExecutionID,
+			)
+			failed++
+			continue
+		}
+		timedOut++
+	}
+
+	stalled, stalledFailed, err := p.flagStalledKills(ctx, now, reqLogger)
+	if err != nil {
+		return err
+	}
+	failed += stalledFailed
+
+	reqLogger.Info("execution timeout sweep completed",
+		"context", map[string]int{
+			"active_count":       len(executions),
+			"timed_out_count":    timedOut,
+			"error_count":        failed,
+			"slo_breached_count": breached,
+			"stalled_kill_count": stalled,
+		})
+
+	if failed > 0 {
+		return fmt.Errorf("failed to time out %d execution(s)", failed)
+	}
+	return nil
+}
+
+// handleWarmPoolsScheduledEvent starts and stops warm pool slots so every image keeps
+// as many idle slots as its warm pool size.
+func (p *Processor) handleWarmPoolsScheduledEvent(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+) error {
+	if p.warmPools == nil {
+		reqLogger.Debug("warm pools not configured, skipping replenishment")
+		return nil
+	}
+
+	started, stopped, err := p.warmPools.ReplenishWarmPools(ctx)
+	logLevel := reqLogger.Info
+	if err != nil {
+		logLevel = reqLogger.Warn
+	}
+	logLevel("warm pool replenishment completed",
+		"error", err,
+		"context", map[string]int{
+			"started_count": started,
+			"stopped_count": stopped,
+		})
+
+	if err != nil {
+		return fmt.Errorf("warm pool replenishment failed: %w", err)
+	}
+	return nil
+}
+
+// handleUsageReportScheduledEvent emails the usage report of the past week to the admins or to the
+// recipients of the notification settings.
+func (p *Processor) handleUsageReportScheduledEvent(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+) error {
+	if p.usageReports == nil {
+		reqLogger.Debug("email not configured, skipping usage report")
+		return nil
+	}
+
+	recipients, err := p.usageReports.SendWeeklyReport(ctx, time.Now())
+	if err != nil {
+		reqLogger.Error("failed to send usage report", "error", err)
+		return fmt.Errorf("failed to send usage report: %w", err)
+	}
+
+	reqLogger.Info("usage report sent",
+		"context", map[string]int{
+			"recipient_count": len(recipients),
+		})
+	return nil
+}
+
+// handleClaimTokensScheduledEvent deletes the expired claim tokens and, when configured to, the invited users
+// who never claimed their API key. The deletions failing are retried by the next schedule.
+func (p *Processor) handleClaimTokensScheduledEvent(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+) error {
+	if p.claimTokens == nil {
+		reqLogger.Debug("claim tokens sweep not configured, skipping it")
+		return nil
+	}
+
+	deletedKeys, deletedUsers, err := p.claimTokens.Sweep(ctx, time.Now())
+	logLevel := reqLogger.Info
+	if err != nil {
+		logLevel = reqLogger.Warn
+	}
+	logLevel("claim tokens swept",
+		"context", map[string]int{
+			"deleted_pending_keys": deletedKeys,
+			"deleted_users":        deletedUsers,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to sweep claim tokens: %w", err)
+	}
+	return nil
+}
+
+// isKillStalled reports whether the task of a TERMINATING execution should have stopped by now: the
+// kill was requested longer ago than its stop grace period plus constants.KillStallSeconds.
+func isKillStalled(execution *api.Execution, now time.Time) bool {
+	if execution.KillRequestedAt == nil {
+		return false
+	}
+	wait := time.Duration(execution.StopGracePeriodSeconds+constants.KillStallSeconds) * time.Second
+	return now.After(execution.KillRequestedAt.Add(wait))
+}
+
+// flagStalledKills flags the TERMINATING executions whose task never reported stopping and kills
+// their task again, the STOPPED event then completing the execution as usual. Kills still stalled
+// at the following sweeps are retried and counted in the StalledKills metric every time, so they
+// keep alerting until the task stops.
+// It returns the number of stalled kills and of executions that failed to be flagged.
+func (p *Processor) flagStalledKills(
+	ctx context.Context,
+	now time.Time,
+	reqLogger *slog.Logger,
+) (stalled, failed int, err error) {
+	executions, err := p.executionRepo.ListExecutions(ctx, 0, []string{string(constants.ExecutionTerminating)})
+	if err != nil {
+		reqLogger.Error("failed to list terminating executions", "error", err)
+		return 0, 0, fmt.Errorf("failed to list terminating executions: %w", err)
+	}
+
+	for _, execution := range executions {
+		if execution.Status != string(constants.ExecutionTerminating) || !isKillStalled(execution, now) {
+			continue
+		}
+		logStalled, message := reqLogger.Warn, "execution kill stalled, killing its task again"
+		if execution.KillStalled {
+			logStalled, message = reqLogger.Error, "execution kill still stalled, killing its task again"
+		}
+		logStalled(message,
+			"context", map[string]any{
+				"execution_id":      execution.ExecutionID,
+				"kill_requested_by": execution.KillRequestedBy,
+				"kill_requested_at": execution.KillRequestedAt,
+			})
+		if killErr := p.taskManager.KillTask(ctx, execution.ExecutionID); killErr != nil {
+			reqLogger.Warn("failed to kill the task of a stalled kill",
+				"error", killErr,
+				"execution_id", execution.ExecutionID,
+			)
+		}
+		p.recordStalledKill(ctx)
+
+		if execution.KillStalled {
+			stalled++
+			continue
+		}
+		execution.KillStalled = true
+		if updateErr := p.executionRepo.UpdateExecution(ctx, execution); updateErr != nil {
+			reqLogger.Error("failed to flag stalled kill",
+				"error", updateErr,
+				"execution_id", execution.ExecutionID,
+			)
+			failed++
+			continue
+		}
+		stalled++
+	}
+	return stalled, failed, nil
+}
+
+// isExecutionTimedOut reports whether an execution has run longer than its requested timeout.
+func isExecutionTimedOut(execution *api.Execution, now time.Time) bool {
+	if execution.TimeoutSeconds <= 0 {
+		return false
+	}
+	deadline := execution.StartedAt.Add(time.Duration(execution.TimeoutSeconds) * time.Second)
+	return now.After(deadline)
+}
+
+// timeoutExecution stops the task backing an execution and records the TIMED_OUT status.
+// Tasks that are already gone or stopping are not treated as errors.
+func (p *Processor) timeoutExecution(
+	ctx context.Context,
+	execution *api.Execution,
+	now time.Time,
+	reqLogger *slog.Logger,
+) error {
+	previousStatus := constants.ExecutionStatus(execution.Status)
+	if !constants.CanTransition(previousStatus, constants.ExecutionTimedOut) {
+		reqLogger.Warn("skipping invalid status transition",
+			"context", map[string]string{
+				"execution_id":   execution.ExecutionID,
+				"current_status": execution.Status,
+				"target_status":  string(constants.ExecutionTimedOut),
+			},
+		)
+		return nil
+	}
+
+	if err := p.taskManager.KillTask(ctx, execution.ExecutionID); err != nil {
+		switch appErrors.GetErrorCode(err) {
+		case appErrors.ErrCodeNotFound, appErrors.ErrCodeInvalidRequest:
+			reqLogger.Debug("task already stopped or not killable, marking execution as timed out",
+				"error", err,
+				"execution_id", execution.ExecutionID,
+			)
+		default:
+			return fmt.Errorf("failed to kill task: %w", err)
+		}
+	}
+
+	execution.Status = string(constants.ExecutionTimedOut)
+	execution.ExitCode = constants.TimedOutExitCode
+	execution.CompletedAt = &now
+	execution.DurationSeconds = int(now.Sub(execution.StartedAt).Seconds())
+
+	// Extract request ID from context and set ModifiedByRequestID
+	requestID := logger.ExtractRequestIDFromContext(ctx)
+	if requestID != "" {
+		execution.ModifiedByRequestID = requestID
+	}
+
+	if err := p.executionRepo.UpdateExecution(ctx, execution); err != nil {
+		return fmt.Errorf("failed to update execution: %w", err)
+	}
+
+	reqLogger.Info("execution timed out",
+		"context", map[string]any{
+			"execution_id":    execution.ExecutionID,
+			"timeout_seconds": execution.TimeoutSeconds,
+			"started_at":      execution.StartedAt,
+		})
+
+	p.notifyExecutionStatus(ctx, execution, previousStatus, reqLogger)
+	if err := p.webSocketManager.NotifyExecutionCompletion(ctx, &execution.ExecutionID); err != nil {
+		return fmt.Errorf("failed to notify websocket clients: %w", err)
+	}
+
 	return nil
 }
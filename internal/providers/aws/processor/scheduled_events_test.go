@@ -3,14 +3,20 @@ package aws
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	appErrors "github.com/runvoy/runvoy/internal/errors"
 	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandleScheduledEvent_Comprehensive_InvalidJSONDetail(t *testing.T) {
@@ -20,7 +26,7 @@ func TestHandleScheduledEvent_Comprehensive_InvalidJSONDetail(t *testing.T) {
 	mockHealthManager := &mockHealthManager{}
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	// Test with invalid JSON in detail (should trigger unmarshal error)
 	event := events.CloudWatchEvent{
@@ -42,7 +48,7 @@ func TestHandleScheduledEvent_Comprehensive_InvalidSource(t *testing.T) {
 	mockHealthManager := &mockHealthManager{}
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	// Test with invalid source (not aws.events)
 	event := events.CloudWatchEvent{
@@ -74,7 +80,7 @@ func TestHandleScheduledEvent_Comprehensive_HealthReconcile(t *testing.T) {
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -95,7 +101,7 @@ func TestHandleScheduledEvent_Comprehensive_UnknownEventType(t *testing.T) {
 	mockHealthManager := &mockHealthManager{}
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	event := events.CloudWatchEvent{
 		DetailType: "Scheduled Event",
@@ -116,7 +122,7 @@ func TestHandleScheduledEvent_Comprehensive_EmptyRunvoyEvent(t *testing.T) {
 	mockHealthManager := &mockHealthManager{}
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	// Test with valid JSON but empty runvoy_event field
 	event := events.CloudWatchEvent{
@@ -156,13 +162,72 @@ func TestHandleHealthReconcileScheduledEvent_Comprehensive_Success(t *testing.T)
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	err := processor.handleHealthReconcileScheduledEvent(ctx, logger)
 
 	assert.NoError(t, err)
 }
 
+type recordingHealthReportRepo struct {
+	reports []api.HealthReport
+}
+
+func (r *recordingHealthReportRepo) CreateHealthReport(_ context.Context, report *api.HealthReport) error {
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+func (r *recordingHealthReportRepo) ListHealthReports(_ context.Context, _ int) ([]api.HealthReport, error) {
+	return r.reports, nil
+}
+
+func TestHandleHealthReconcileScheduledEvent_StoresReport(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	mockHealthManager := &mockHealthManager{
+		reconcileFunc: func(_ context.Context) (*api.HealthReport, error) {
+			return &api.HealthReport{ReconciledCount: 1, ErrorCount: 2}, nil
+		},
+	}
+
+	reportRepo := &recordingHealthReportRepo{}
+	processor := NewProcessor(
+		&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{}, mockHealthManager, nil, logger)
+	processor.healthReportRepo = reportRepo
+
+	err := processor.handleHealthReconcileScheduledEvent(ctx, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []api.HealthReport{{ReconciledCount: 1, ErrorCount: 2}}, reportRepo.reports)
+}
+
+func TestHandleHealthReconcileScheduledEvent_CleansUpAfterReconcile(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	var calls []string
+	mockHealthManager := &mockHealthManager{
+		reconcileFunc: func(_ context.Context) (*api.HealthReport, error) {
+			calls = append(calls, "reconcile")
+			return &api.HealthReport{}, nil
+		},
+		cleanupFunc: func(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+			calls = append(calls, fmt.Sprintf("cleanup dry_run=%t", dryRun))
+			return nil, assert.AnError
+		},
+	}
+	processor := NewProcessor(
+		&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{}, mockHealthManager, nil, logger)
+
+	err := processor.handleHealthReconcileScheduledEvent(ctx, logger)
+
+	// A failed cleanup does not fail the scheduled event, the next one retries it.
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reconcile", "cleanup dry_run=false"}, calls)
+}
+
 func TestHandleHealthReconcileScheduledEvent_Comprehensive_ReconcileError(t *testing.T) {
 	ctx := context.Background()
 	logger := testutil.SilentLogger()
@@ -175,7 +240,7 @@ func TestHandleHealthReconcileScheduledEvent_Comprehensive_ReconcileError(t *tes
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	err := processor.handleHealthReconcileScheduledEvent(ctx, logger)
 
@@ -203,10 +268,517 @@ func TestHandleHealthReconcileScheduledEvent_Comprehensive_WithErrors(t *testing
 
 	mockRepo := &mockExecutionRepo{}
 	wsManager := &mockWebSocketHandler{}
-	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, logger)
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, mockHealthManager, nil, logger)
 
 	err := processor.handleHealthReconcileScheduledEvent(ctx, logger)
 
 	// Should succeed but log at Warn level due to error count > 0
 	assert.NoError(t, err)
 }
+
+func TestHandleScheduledEvent_ExecutionTimeouts(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	now := time.Now().UTC()
+
+	expired := &api.Execution{
+		ExecutionID:    "exec-expired",
+		Status:         string(constants.ExecutionRunning),
+		StartedAt:      now.Add(-10 * time.Minute),
+		TimeoutSeconds: 60,
+	}
+	withinTimeout := &api.Execution{
+		ExecutionID:    "exec-within",
+		Status:         string(constants.ExecutionRunning),
+		StartedAt:      now.Add(-30 * time.Second),
+		TimeoutSeconds: 300,
+	}
+	noTimeout := &api.Execution{
+		ExecutionID: "exec-no-timeout",
+		Status:      string(constants.ExecutionRunning),
+		StartedAt:   now.Add(-24 * time.Hour),
+	}
+
+	var listedStatuses [][]string
+	var updated []*api.Execution
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, statuses []string) ([]*api.Execution, error) {
+			listedStatuses = append(listedStatuses, statuses)
+			if len(listedStatuses) > 1 {
+				return nil, nil
+			}
+			return []*api.Execution{expired, withinTimeout, noTimeout}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			updated = append(updated, execution)
+			return nil
+		},
+	}
+
+	var killed []string
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return nil
+		},
+	}
+
+	var notified []string
+	wsManager := &mockWebSocketHandler{
+		notifyExecutionCompletionFunc: func(_ context.Context, executionID *string) error {
+			notified = append(notified, *executionID)
+			return nil
+		},
+	}
+
+	processor := NewProcessor(mockRepo, &noopLogEventRepo{}, wsManager, &mockHealthManager{}, taskManager, logger)
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventExecutionTimeouts + `"}`),
+	}
+
+	err := processor.handleScheduledEvent(ctx, &event, logger)
+
+	require.NoError(t, err)
+	require.Len(t, listedStatuses, 2)
+	assert.ElementsMatch(t, []string{"STARTING", "RUNNING"}, listedStatuses[0])
+	assert.Equal(t, []string{"TERMINATING"}, listedStatuses[1])
+	assert.Equal(t, []string{"exec-expired"}, killed)
+	assert.Equal(t, []string{"exec-expired"}, notified)
+	require.Len(t, updated, 1)
+	assert.Equal(t, string(constants.ExecutionTimedOut), updated[0].Status)
+	assert.Equal(t, constants.TimedOutExitCode, updated[0].ExitCode)
+	assert.NotNil(t, updated[0].CompletedAt)
+	assert.GreaterOrEqual(t, updated[0].DurationSeconds, 600)
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_TaskAlreadyStopped(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	execution := &api.Execution{
+		ExecutionID:    "exec-gone",
+		Status:         string(constants.ExecutionStarting),
+		StartedAt:      time.Now().Add(-time.Hour),
+		TimeoutSeconds: 60,
+	}
+
+	var updatedStatus string
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{execution}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, e *api.Execution) error {
+			updatedStatus = e.Status
+			return nil
+		},
+	}
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, _ string) error {
+			return appErrors.ErrNotFound("task not found", nil)
+		},
+	}
+
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, taskManager, logger)
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, logger)
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constants.ExecutionTimedOut), updatedStatus)
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_Terminating(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	execution := &api.Execution{
+		ExecutionID:    "exec-killed",
+		Status:         string(constants.ExecutionTerminating),
+		StartedAt:      time.Now().Add(-time.Hour),
+		TimeoutSeconds: 60,
+	}
+
+	updated := false
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{execution}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			updated = true
+			return nil
+		},
+	}
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, _ string) error {
+			t.Fatal("the task of a terminating execution should not be killed again")
+			return nil
+		},
+	}
+
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, taskManager, logger)
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, logger)
+
+	require.NoError(t, err)
+	assert.False(t, updated, "TERMINATING executions end STOPPED, not TIMED_OUT")
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_StalledKills(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+	now := time.Now().UTC()
+
+	stalledAt := now.Add(-time.Duration(constants.KillStallSeconds+60) * time.Second)
+	recentAt := now.Add(-time.Minute)
+	stalled := &api.Execution{
+		ExecutionID:     "exec-stalled",
+		Status:          string(constants.ExecutionTerminating),
+		KillRequestedBy: "alice@example.com",
+		KillRequestedAt: &stalledAt,
+	}
+	withinGracePeriod := &api.Execution{
+		ExecutionID:            "exec-graceful",
+		Status:                 string(constants.ExecutionTerminating),
+		StopGracePeriodSeconds: constants.MaxStopGracePeriodSeconds,
+		KillRequestedAt:        &stalledAt,
+	}
+	recent := &api.Execution{
+		ExecutionID:     "exec-recent",
+		Status:          string(constants.ExecutionTerminating),
+		KillRequestedAt: &recentAt,
+	}
+	alreadyFlagged := &api.Execution{
+		ExecutionID:     "exec-flagged",
+		Status:          string(constants.ExecutionTerminating),
+		KillRequestedAt: &stalledAt,
+		KillStalled:     true,
+	}
+
+	var updated []*api.Execution
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, statuses []string) ([]*api.Execution, error) {
+			if statuses[0] != string(constants.ExecutionTerminating) {
+				return nil, nil
+			}
+			return []*api.Execution{stalled, withinGracePeriod, recent, alreadyFlagged}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			updated = append(updated, execution)
+			return nil
+		},
+	}
+	var killed []string
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, executionID string) error {
+			killed = append(killed, executionID)
+			return appErrors.ErrNotFound("task not found", nil)
+		},
+	}
+
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, taskManager, logger)
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, logger)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec-stalled", "exec-flagged"}, killed, "flagged kills are retried at every sweep")
+	require.Len(t, updated, 1, "flagged kills are not updated again")
+	assert.Equal(t, "exec-stalled", updated[0].ExecutionID)
+	assert.True(t, updated[0].KillStalled)
+	assert.Equal(t, string(constants.ExecutionTerminating), updated[0].Status)
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_KillStalledAcrossSweeps(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	stalledAt := time.Now().UTC().Add(-time.Duration(constants.KillStallSeconds+60) * time.Second)
+	stored := &api.Execution{
+		ExecutionID:     "exec-stuck",
+		Status:          string(constants.ExecutionTerminating),
+		KillRequestedBy: "alice@example.com",
+		KillRequestedAt: &stalledAt,
+	}
+	updates := 0
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, statuses []string) ([]*api.Execution, error) {
+			if statuses[0] != string(constants.ExecutionTerminating) {
+				return nil, nil
+			}
+			execution := *stored
+			return []*api.Execution{&execution}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, execution *api.Execution) error {
+			updates++
+			stored = execution
+			return nil
+		},
+	}
+	kills := 0
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, _ string) error {
+			kills++
+			return nil
+		},
+	}
+	metrics := &recordingMetricsRecorder{}
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, taskManager, logger)
+	processor.metrics = metrics
+
+	for range 2 {
+		require.NoError(t, processor.handleExecutionTimeoutsScheduledEvent(ctx, logger))
+	}
+
+	assert.Equal(t, 2, kills, "the task is killed again at every sweep")
+	assert.Equal(t, 1, updates, "the execution is only flagged once")
+	assert.True(t, stored.KillStalled)
+	assert.Len(t, metrics.byName(constants.MetricStalledKills), 2, "the stalled kill is counted at every sweep")
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_KillFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	execution := &api.Execution{
+		ExecutionID:    "exec-stuck",
+		Status:         string(constants.ExecutionRunning),
+		StartedAt:      time.Now().Add(-time.Hour),
+		TimeoutSeconds: 60,
+	}
+
+	updateCalled := false
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{execution}, nil
+		},
+		updateExecutionFunc: func(_ context.Context, _ *api.Execution) error {
+			updateCalled = true
+			return nil
+		},
+	}
+	taskManager := &mockTaskManager{
+		killTaskFunc: func(_ context.Context, _ string) error {
+			return appErrors.ErrInternalError("failed to stop task", errors.New("ecs unavailable"))
+		},
+	}
+
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, taskManager, logger)
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, logger)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to time out 1 execution(s)")
+	assert.False(t, updateCalled)
+	assert.Equal(t, string(constants.ExecutionRunning), execution.Status)
+}
+
+func TestHandleScheduledEvent_ExecutionTimeouts_ListError(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	mockRepo := &mockExecutionRepo{
+		listExecutionsFunc: func(_ context.Context, _ int, _ []string) ([]*api.Execution, error) {
+			return nil, errors.New("database unavailable")
+		},
+	}
+
+	processor := NewProcessor(
+		mockRepo, &noopLogEventRepo{}, &mockWebSocketHandler{}, &mockHealthManager{}, &mockTaskManager{}, logger)
+
+	err := processor.handleExecutionTimeoutsScheduledEvent(ctx, logger)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list active executions")
+}
+
+type mockWarmPoolReplenisher struct {
+	replenishFunc func(ctx context.Context) (started, stopped int, err error)
+}
+
+func (m *mockWarmPoolReplenisher) ReplenishWarmPools(ctx context.Context) (started, stopped int, err error) {
+	return m.replenishFunc(ctx)
+}
+
+func TestHandleScheduledEvent_WarmPools(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	replenishCalled := false
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+	processor.warmPools = &mockWarmPoolReplenisher{
+		replenishFunc: func(_ context.Context) (int, int, error) {
+			replenishCalled = true
+			return 2, 1, nil
+		},
+	}
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventWarmPools + `"}`),
+	}
+
+	err := processor.handleScheduledEvent(ctx, &event, logger)
+
+	assert.NoError(t, err)
+	assert.True(t, replenishCalled)
+}
+
+func TestHandleScheduledEvent_WarmPoolsError(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+	processor.warmPools = &mockWarmPoolReplenisher{
+		replenishFunc: func(_ context.Context) (int, int, error) {
+			return 0, 0, errors.New("throttled")
+		},
+	}
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventWarmPools + `"}`),
+	}
+
+	err := processor.handleScheduledEvent(ctx, &event, logger)
+
+	assert.ErrorContains(t, err, "warm pool replenishment failed")
+}
+
+func TestHandleScheduledEvent_WarmPoolsNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventWarmPools + `"}`),
+	}
+
+	assert.NoError(t, processor.handleScheduledEvent(ctx, &event, logger))
+}
+
+type mockUsageReporter struct {
+	sendFunc func(ctx context.Context, now time.Time) ([]string, error)
+}
+
+func (m *mockUsageReporter) SendWeeklyReport(ctx context.Context, now time.Time) ([]string, error) {
+	return m.sendFunc(ctx, now)
+}
+
+func TestHandleScheduledEvent_UsageReport(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	sendCalled := false
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+	processor.usageReports = &mockUsageReporter{
+		sendFunc: func(_ context.Context, _ time.Time) ([]string, error) {
+			sendCalled = true
+			return []string{"admin@example.com"}, nil
+		},
+	}
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventUsageReport + `"}`),
+	}
+
+	err := processor.handleScheduledEvent(ctx, &event, logger)
+
+	assert.NoError(t, err)
+	assert.True(t, sendCalled)
+}
+
+func TestHandleScheduledEvent_UsageReportError(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+	processor.usageReports = &mockUsageReporter{
+		sendFunc: func(_ context.Context, _ time.Time) ([]string, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventUsageReport + `"}`),
+	}
+
+	err := processor.handleScheduledEvent(ctx, &event, logger)
+
+	assert.ErrorContains(t, err, "failed to send usage report")
+}
+
+func TestHandleScheduledEvent_UsageReportNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventUsageReport + `"}`),
+	}
+
+	assert.NoError(t, processor.handleScheduledEvent(ctx, &event, logger))
+}
+
+type mockClaimTokenSweeper struct {
+	sweepFunc func(ctx context.Context, now time.Time) (int, int, error)
+}
+
+func (m *mockClaimTokenSweeper) Sweep(ctx context.Context, now time.Time) (deletedKeys, deletedUsers int, err error) {
+	return m.sweepFunc(ctx, now)
+}
+
+func TestHandleScheduledEvent_ClaimTokens(t *testing.T) {
+	ctx := context.Background()
+	logger := testutil.SilentLogger()
+
+	event := events.CloudWatchEvent{
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Detail:     json.RawMessage(`{"runvoy_event": "` + awsConstants.ScheduledEventClaimTokens + `"}`),
+	}
+
+	processor := NewProcessor(&mockExecutionRepo{}, &noopLogEventRepo{}, &mockWebSocketHandler{},
+		&mockHealthManager{}, nil, logger)
+	assert.NoError(t, processor.handleScheduledEvent(ctx, &event, logger), "the sweep is optional")
+
+	sweepCalled := false
+	processor.claimTokens = &mockClaimTokenSweeper{
+		sweepFunc: func(_ context.Context, _ time.Time) (int, int, error) {
+			sweepCalled = true
+			return 2, 1, nil
+		},
+	}
+	assert.NoError(t, processor.handleScheduledEvent(ctx, &event, logger))
+	assert.True(t, sweepCalled)
+
+	processor.claimTokens = &mockClaimTokenSweeper{
+		sweepFunc: func(_ context.Context, _ time.Time) (int, int, error) {
+			return 1, 0, errors.New("throttled")
+		},
+	}
+	assert.ErrorContains(t, processor.handleScheduledEvent(ctx, &event, logger), "failed to sweep claim tokens")
+}
@@ -4,19 +4,26 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
+
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
 )
 
 // parseTaskTimes parses and validates the task timestamps, calculating duration.
 func parseTaskTimes(
 	taskEvent *ECSTaskStateChangeEvent, executionStartedAt time.Time, reqLogger *slog.Logger,
 ) (startedAt, stoppedAt time.Time, durationSeconds int, err error) {
-	if taskEvent.StartedAt != "" {
+	switch {
+	case taskEvent.StartedBy == awsConstants.WarmPoolStartedBy:
+		// A warm pool slot is started before its execution is assigned,
+		// the execution starts when it is assigned to the slot.
+		startedAt = executionStartedAt
+	case taskEvent.StartedAt != "":
 		startedAt, err = ParseTime(taskEvent.StartedAt)
 		if err != nil {
 			reqLogger.Error("failed to parse startedAt timestamp", "error", err, "started_at", taskEvent.StartedAt)
 			return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to parse startedAt: %w", err)
 		}
-	} else {
+	default:
 		reqLogger.Warn("startedAt missing from task event, using execution's StartedAt",
 			"execution_started_at", executionStartedAt.Format(time.RFC3339),
 		)
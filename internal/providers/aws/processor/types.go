@@ -12,8 +12,13 @@ type ECSTaskStateChangeEvent struct {
 	TaskArn       string            `json:"taskArn"`
 	LastStatus    string            `json:"lastStatus"`
 	DesiredStatus string            `json:"desiredStatus"`
+	StartedBy     string            `json:"startedBy"`
 	Containers    []ContainerDetail `json:"containers"`
+	CreatedAt     string            `json:"createdAt"`
+	PullStartedAt string            `json:"pullStartedAt"`
+	PullStoppedAt string            `json:"pullStoppedAt"`
 	StartedAt     string            `json:"startedAt"`
+	StoppingAt    string            `json:"stoppingAt"`
 	StoppedAt     string            `json:"stoppedAt"`
 	StoppedReason string            `json:"stoppedReason"`
 	StopCode      string            `json:"stopCode"`
@@ -25,6 +30,7 @@ type ECSTaskStateChangeEvent struct {
 type ContainerDetail struct {
 	ContainerArn string `json:"containerArn"`
 	Name         string `json:"name"`
+	LastStatus   string `json:"lastStatus,omitempty"`
 	ExitCode     *int   `json:"exitCode,omitempty"`
 	Reason       string `json:"reason,omitempty"`
 }
@@ -10,29 +10,21 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 )
 
-// handleWebSocketEvent processes API Gateway WebSocket events.
+// handleWebSocketEvent processes API Gateway WebSocket events through the WebSocket manager.
 func (p *Processor) handleWebSocketEvent(
 	ctx context.Context,
 	rawEvent *json.RawMessage,
 	reqLogger *slog.Logger,
-) (events.APIGatewayProxyResponse, bool) {
-	var wsReq events.APIGatewayWebsocketProxyRequest
-	if err := json.Unmarshal(*rawEvent, &wsReq); err != nil || wsReq.RequestContext.RouteKey == "" {
-		return events.APIGatewayProxyResponse{}, false
-	}
-
-	// This is a WebSocket request, handle it through the manager
+) events.APIGatewayProxyResponse {
 	if _, err := p.webSocketManager.HandleRequest(ctx, rawEvent, reqLogger); err != nil {
 		return events.APIGatewayProxyResponse{
 			StatusCode: http.StatusInternalServerError,
 			Body:       fmt.Sprintf("Internal server error: %v", err),
-		}, true
+		}
 	}
 
-	resp := events.APIGatewayProxyResponse{
+	return events.APIGatewayProxyResponse{
 		StatusCode: http.StatusOK,
 		Body:       "OK",
 	}
-
-	return resp, true
 }
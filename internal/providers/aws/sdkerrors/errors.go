@@ -0,0 +1,56 @@
+// Package sdkerrors maps the errors of the AWS SDK to the provider error kinds of the errors package.
+package sdkerrors
+
+import (
+	"errors"
+
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// errorKinds are the kinds of the error codes of the AWS APIs used by the backend. Missing resources of
+// the backend itself (e.g. ResourceNotFoundException of a table or NoSuchBucket) aren't mapped, they
+// are internal errors and not missing items of the clients.
+var errorKinds = map[string]apperrors.ProviderErrorKind{
+	"NoSuchKey":         apperrors.ProviderErrorNotFound,
+	"NotFound":          apperrors.ProviderErrorNotFound,
+	"ParameterNotFound": apperrors.ProviderErrorNotFound,
+
+	"ConditionalCheckFailedException": apperrors.ProviderErrorConflict,
+	"TransactionConflictException":    apperrors.ProviderErrorConflict,
+	"PreconditionFailed":              apperrors.ProviderErrorConflict,
+
+	"ThrottlingException":                    apperrors.ProviderErrorThrottled,
+	"Throttling":                             apperrors.ProviderErrorThrottled,
+	"ThrottledException":                     apperrors.ProviderErrorThrottled,
+	"TooManyRequestsException":               apperrors.ProviderErrorThrottled,
+	"RequestLimitExceeded":                   apperrors.ProviderErrorThrottled,
+	"ProvisionedThroughputExceededException": apperrors.ProviderErrorThrottled,
+	"RequestThrottledException":              apperrors.ProviderErrorThrottled,
+	"SlowDown":                               apperrors.ProviderErrorThrottled,
+
+	"LimitExceededException":        apperrors.ProviderErrorQuotaExceeded,
+	"ServiceQuotaExceededException": apperrors.ProviderErrorQuotaExceeded,
+
+	"ServiceUnavailable":          apperrors.ProviderErrorUnavailable,
+	"ServiceUnavailableException": apperrors.ProviderErrorUnavailable,
+	"InternalServerError":         apperrors.ProviderErrorUnavailable,
+	"InternalFailure":             apperrors.ProviderErrorUnavailable,
+	"ServerException":             apperrors.ProviderErrorUnavailable,
+}
+
+// Kind returns the provider error kind of an AWS SDK error.
+func Kind(err error) apperrors.ProviderErrorKind {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return apperrors.ProviderErrorUnknown
+	}
+	return errorKinds[apiErr.ErrorCode()]
+}
+
+// Map returns the application error of an AWS SDK error with the message, created by fallback when
+// the error has no more specific kind. The SDK error is kept as the cause, but never returned to the clients.
+func Map(err error, message string, fallback func(string, error) *apperrors.AppError) *apperrors.AppError {
+	return apperrors.FromProvider(Kind(err), message, err, fallback)
+}
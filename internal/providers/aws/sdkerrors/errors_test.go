@@ -0,0 +1,58 @@
+package sdkerrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want apperrors.ProviderErrorKind
+	}{
+		{
+			name: "conditional check failed",
+			err:  fmt.Errorf("operation error DynamoDB: PutItem: %w", &types.ConditionalCheckFailedException{}),
+			want: apperrors.ProviderErrorConflict,
+		},
+		{
+			name: "ECS throttling",
+			err:  &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"},
+			want: apperrors.ProviderErrorThrottled,
+		},
+		{
+			name: "missing table is not a missing item",
+			err:  &types.ResourceNotFoundException{},
+			want: apperrors.ProviderErrorUnknown,
+		},
+		{
+			name: "not an SDK error",
+			err:  errors.New("failed to marshal item"),
+			want: apperrors.ProviderErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Kind(tt.err))
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	err := Map(&smithy.GenericAPIError{Code: "LimitExceededException"}, "failed to start task",
+		apperrors.ErrInternalError)
+
+	assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+	assert.Equal(t, apperrors.ErrCodeQuotaExceeded, err.Code)
+	assert.Equal(t, "failed to start task", apperrors.GetPublicErrorDetails(err))
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 
 	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/crypto"
 	"github.com/runvoy/runvoy/internal/logger"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -28,25 +31,31 @@ type ValueStore interface {
 }
 
 // ParameterStoreManager implements secret value storage using AWS Systems Manager Parameter Store.
-// Secrets are stored as SecureString parameters and encrypted with KMS.
+// Secret values are encrypted by the envelope, bound to the name of their secret, and stored as
+// String parameters.
 type ParameterStoreManager struct {
 	client       Client
 	secretPrefix string // e.g., "/runvoy" or "/runvoy/secrets"
-	kmsKeyARN    string // ARN of the KMS key to use for encryption
+	envelope     *crypto.Envelope
+	resourceTags map[string]string
 	logger       *slog.Logger
 }
 
 // NewParameterStoreManager creates a new Parameter Store-based secrets manager.
-// secretPrefix should include a leading slash, e.g., "/runvoy/secrets".
+// secretPrefix should include a leading slash, e.g., "/runvoy/secrets". The parameters are tagged
+// with the standard tags and the resource tags.
 func NewParameterStoreManager(
 	client Client,
-	secretPrefix, kmsKeyARN string,
+	secretPrefix string,
+	envelope *crypto.Envelope,
+	resourceTags map[string]string,
 	log *slog.Logger,
 ) *ParameterStoreManager {
 	return &ParameterStoreManager{
 		client:       client,
 		secretPrefix: secretPrefix,
-		kmsKeyARN:    kmsKeyARN,
+		envelope:     envelope,
+		resourceTags: resourceTags,
 		logger:       log,
 	}
 }
@@ -56,18 +65,22 @@ func (m *ParameterStoreManager) getParameterName(secretName string) string {
 	return fmt.Sprintf("%s/%s", m.secretPrefix, secretName)
 }
 
-// StoreSecret saves a secret value to AWS Systems Manager Parameter Store as a SecureString.
-// The value is encrypted with the KMS key specified during initialization.
+// StoreSecret encrypts a secret value and saves it to AWS Systems Manager Parameter Store.
 func (m *ParameterStoreManager) StoreSecret(ctx context.Context, name, value string) error {
 	reqLogger := logger.DeriveRequestLogger(ctx, m.logger)
 	parameterName := m.getParameterName(name)
 	parameterTags := m.parameterTags()
 
-	_, err := m.client.PutParameter(ctx, &ssm.PutParameterInput{
+	ciphertext, err := m.envelope.EncryptString(ctx, value, name)
+	if err != nil {
+		reqLogger.Error("failed to encrypt secret", "error", err, "name", name)
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	_, err = m.client.PutParameter(ctx, &ssm.PutParameterInput{
 		Name:      aws.String(parameterName),
-		Value:     aws.String(value),
-		Type:      types.ParameterTypeSecureString,
-		KeyId:     aws.String(m.kmsKeyARN),
+		Value:     aws.String(ciphertext),
+		Type:      types.ParameterTypeString,
 		Overwrite: aws.Bool(true),
 	})
 
@@ -92,7 +105,7 @@ func (m *ParameterStoreManager) StoreSecret(ctx context.Context, name, value str
 }
 
 func (m *ParameterStoreManager) parameterTags() []types.Tag {
-	standardTags := GetStandardTags()
+	standardTags := GetStandardTags(m.resourceTags)
 	tags := make([]types.Tag, len(standardTags))
 	for i, tag := range standardTags {
 		tags[i] = types.Tag{
@@ -103,19 +116,24 @@ func (m *ParameterStoreManager) parameterTags() []types.Tag {
 	return tags
 }
 
-// GetStandardTags returns the standard tags applied to all AWS resources managed by runvoy at runtime.
-// These tags are used for resource identification and management tracking.
-func GetStandardTags() []StandardTag {
-	return []StandardTag{
+// GetStandardTags returns the standard tags applied to all AWS resources managed by runvoy at runtime,
+// followed by the resource tags configured by the operator sorted by key, e.g. for cost allocation.
+// The standard tags are used for resource identification and management tracking.
+func GetStandardTags(resourceTags map[string]string) []StandardTag {
+	tags := []StandardTag{
 		{
-			Key:   "Application",
+			Key:   constants.ResourceApplicationTagKey,
 			Value: constants.ProjectName,
 		},
 		{
-			Key:   "ManagedBy",
+			Key:   constants.ResourceManagedByTagKey,
 			Value: constants.ProjectName + "-orchestrator",
 		},
 	}
+	for _, key := range slices.Sorted(maps.Keys(resourceTags)) {
+		tags = append(tags, StandardTag{Key: key, Value: resourceTags[key]})
+	}
+	return tags
 }
 
 // StandardTag represents a standard AWS resource tag as key-value pairs.
@@ -124,8 +142,9 @@ type StandardTag struct {
 	Value string
 }
 
-// RetrieveSecret retrieves a secret value from AWS Systems Manager Parameter Store.
-// The value is automatically decrypted using the KMS key.
+// RetrieveSecret retrieves a secret value from AWS Systems Manager Parameter Store and decrypts it.
+// Values stored as SecureString parameters before envelope encryption are returned as decrypted by
+// Parameter Store.
 func (m *ParameterStoreManager) RetrieveSecret(ctx context.Context, name string) (string, error) {
 	reqLogger := logger.DeriveRequestLogger(ctx, m.logger)
 
@@ -151,7 +170,18 @@ func (m *ParameterStoreManager) RetrieveSecret(ctx context.Context, name string)
 		return "", errors.New("unexpected response from parameter store")
 	}
 
-	return *result.Parameter.Value, nil
+	if !crypto.IsEncryptedString(*result.Parameter.Value) {
+		reqLogger.Debug("secret is not envelope encrypted", "name", name)
+		return *result.Parameter.Value, nil
+	}
+
+	value, err := m.envelope.DecryptString(ctx, *result.Parameter.Value, name)
+	if err != nil {
+		reqLogger.Error("failed to decrypt secret", "error", err, "name", name)
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+
+	return value, nil
 }
 
 // DeleteSecret removes a secret from AWS Systems Manager Parameter Store.
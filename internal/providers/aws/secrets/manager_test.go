@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/runvoy/runvoy/internal/crypto"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
@@ -13,9 +15,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func newTestEnvelope(t *testing.T) *crypto.Envelope {
+	t.Helper()
+	keys, err := crypto.NewEphemeralKeyManager()
+	require.NoError(t, err)
+	return crypto.NewEnvelope(keys)
+}
+
 func TestGetParameterName(t *testing.T) {
 	logger := slog.Default()
-	m := NewParameterStoreManager(nil, "/runvoy/secrets", "arn:aws:kms:us-east-1:123456789012:key/abc", logger)
+	m := NewParameterStoreManager(nil, "/runvoy/secrets", newTestEnvelope(t), nil, logger)
 
 	t.Run("constructs correct parameter name", func(t *testing.T) {
 		name := m.getParameterName("db-password")
@@ -23,7 +32,7 @@ func TestGetParameterName(t *testing.T) {
 	})
 
 	t.Run("handles empty prefix", func(t *testing.T) {
-		m2 := NewParameterStoreManager(nil, "", "arn:aws:kms:us-east-1:123456789012:key/abc", logger)
+		m2 := NewParameterStoreManager(nil, "", newTestEnvelope(t), nil, logger)
 		name := m2.getParameterName("db-password")
 		assert.Equal(t, "/db-password", name)
 	})
@@ -32,7 +41,8 @@ func TestGetParameterName(t *testing.T) {
 		m2 := NewParameterStoreManager(
 			nil,
 			"github.com/runvoy/runvoy/secrets",
-			"arn:aws:kms:us-east-1:123456789012:key/abc",
+			newTestEnvelope(t),
+			nil,
 			logger,
 		)
 		name := m2.getParameterName("db-password")
@@ -85,13 +95,13 @@ func TestNewParameterStoreManager(t *testing.T) {
 	t.Run("creates manager with correct fields", func(t *testing.T) {
 		logger := slog.Default()
 		prefix := "/runvoy/secrets"
-		kmsKey := "arn:aws:kms:us-east-1:123456789012:key/abc"
+		envelope := newTestEnvelope(t)
 
-		m := NewParameterStoreManager(nil, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(nil, prefix, envelope, nil, logger)
 
 		require.NotNil(t, m)
 		assert.Equal(t, prefix, m.secretPrefix)
-		assert.Equal(t, kmsKey, m.kmsKeyARN)
+		assert.Same(t, envelope, m.envelope)
 		assert.NotNil(t, m.logger)
 		assert.Nil(t, m.client)
 	})
@@ -99,7 +109,7 @@ func TestNewParameterStoreManager(t *testing.T) {
 
 func TestParameterTags(t *testing.T) {
 	logger := slog.Default()
-	m := NewParameterStoreManager(nil, "/runvoy/secrets", "arn:aws:kms:us-east-1:123456789012:key/abc", logger)
+	m := NewParameterStoreManager(nil, "/runvoy/secrets", newTestEnvelope(t), nil, logger)
 
 	t.Run("returns correct tags", func(t *testing.T) {
 		tags := m.parameterTags()
@@ -207,7 +217,7 @@ func TestStoreSecret(t *testing.T) {
 	ctx := context.Background()
 	logger := slog.Default()
 	prefix := "/runvoy/secrets"
-	kmsKey := "arn:aws:kms:us-east-1:123456789012:key/abc"
+	envelope := newTestEnvelope(t)
 
 	t.Run("successfully stores secret", func(t *testing.T) {
 		mock := &mockClient{
@@ -217,9 +227,11 @@ func TestStoreSecret(t *testing.T) {
 				_ ...func(*ssm.Options),
 			) (*ssm.PutParameterOutput, error) {
 				assert.Equal(t, "/runvoy/secrets/test-secret", *input.Name)
-				assert.Equal(t, "secret-value", *input.Value)
-				assert.Equal(t, types.ParameterTypeSecureString, input.Type)
-				assert.Equal(t, kmsKey, *input.KeyId)
+				assert.NotContains(t, *input.Value, "secret-value")
+				value, err := envelope.DecryptString(ctx, *input.Value, "test-secret")
+				require.NoError(t, err)
+				assert.Equal(t, "secret-value", value)
+				assert.Equal(t, types.ParameterTypeString, input.Type)
 				assert.True(t, *input.Overwrite)
 				return &ssm.PutParameterOutput{}, nil
 			},
@@ -235,7 +247,7 @@ func TestStoreSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.StoreSecret(ctx, "test-secret", "secret-value")
 
 		assert.NoError(t, err)
@@ -252,7 +264,7 @@ func TestStoreSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.StoreSecret(ctx, "test-secret", "secret-value")
 
 		require.Error(t, err)
@@ -277,7 +289,7 @@ func TestStoreSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.StoreSecret(ctx, "test-secret", "secret-value")
 
 		assert.NoError(t, err)
@@ -288,9 +300,39 @@ func TestRetrieveSecret(t *testing.T) {
 	ctx := context.Background()
 	logger := slog.Default()
 	prefix := "/runvoy/secrets"
-	kmsKey := "arn:aws:kms:us-east-1:123456789012:key/abc"
+	envelope := newTestEnvelope(t)
+
+	t.Run("successfully retrieves and decrypts secret", func(t *testing.T) {
+		ciphertext, err := envelope.EncryptString(ctx, "secret-value", "test-secret")
+		require.NoError(t, err)
+		mock := &mockClient{
+			getParameterFunc: func(
+				_ context.Context,
+				_ *ssm.GetParameterInput,
+				_ ...func(*ssm.Options),
+			) (*ssm.GetParameterOutput, error) {
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{
+						Name:  aws.String("/runvoy/secrets/test-secret"),
+						Value: aws.String(ciphertext),
+					},
+				}, nil
+			},
+		}
+
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
+		value, err := m.RetrieveSecret(ctx, "test-secret")
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret-value", value)
+
+		// The value of a secret can't be read under the name of another one
+		_, err = m.RetrieveSecret(ctx, "other-secret")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decrypt secret")
+	})
 
-	t.Run("successfully retrieves secret", func(t *testing.T) {
+	t.Run("retrieves secret stored before envelope encryption", func(t *testing.T) {
 		expectedValue := "secret-value"
 		mock := &mockClient{
 			getParameterFunc: func(
@@ -309,7 +351,7 @@ func TestRetrieveSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		value, err := m.RetrieveSecret(ctx, "test-secret")
 
 		require.NoError(t, err)
@@ -327,7 +369,7 @@ func TestRetrieveSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		value, err := m.RetrieveSecret(ctx, "test-secret")
 
 		require.Error(t, err)
@@ -346,7 +388,7 @@ func TestRetrieveSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		value, err := m.RetrieveSecret(ctx, "test-secret")
 
 		require.Error(t, err)
@@ -367,7 +409,7 @@ func TestRetrieveSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		value, err := m.RetrieveSecret(ctx, "test-secret")
 
 		require.Error(t, err)
@@ -391,7 +433,7 @@ func TestRetrieveSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		value, err := m.RetrieveSecret(ctx, "test-secret")
 
 		require.Error(t, err)
@@ -404,7 +446,7 @@ func TestDeleteSecret(t *testing.T) {
 	ctx := context.Background()
 	logger := slog.Default()
 	prefix := "/runvoy/secrets"
-	kmsKey := "arn:aws:kms:us-east-1:123456789012:key/abc"
+	envelope := newTestEnvelope(t)
 
 	t.Run("successfully deletes secret", func(t *testing.T) {
 		mock := &mockClient{
@@ -418,7 +460,7 @@ func TestDeleteSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.DeleteSecret(ctx, "test-secret")
 
 		assert.NoError(t, err)
@@ -435,7 +477,7 @@ func TestDeleteSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.DeleteSecret(ctx, "test-secret")
 
 		assert.NoError(t, err)
@@ -452,7 +494,7 @@ func TestDeleteSecret(t *testing.T) {
 			},
 		}
 
-		m := NewParameterStoreManager(mock, prefix, kmsKey, logger)
+		m := NewParameterStoreManager(mock, prefix, envelope, nil, logger)
 		err := m.DeleteSecret(ctx, "test-secret")
 
 		require.Error(t, err)
@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/crypto"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// RotationNames returns the names of the secrets stored under the prefix.
+func (m *ParameterStoreManager) RotationNames(ctx context.Context) ([]string, error) {
+	var names []string
+	var nextToken *string
+	for {
+		output, err := m.client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+			ParameterFilters: []types.ParameterStringFilter{
+				{
+					Key:    aws.String("Path"),
+					Option: aws.String("Recursive"),
+					Values: []string{m.secretPrefix},
+				},
+			},
+			NextToken:  nextToken,
+			MaxResults: aws.Int32(awsConstants.SSMParameterMaxResults),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe parameters: %w", err)
+		}
+		for i := range output.Parameters {
+			if output.Parameters[i].Name != nil {
+				names = append(names, strings.TrimPrefix(*output.Parameters[i].Name, m.secretPrefix+"/"))
+			}
+		}
+		if output.NextToken == nil {
+			return names, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+// RotateValue re-encrypts a secret value under the current key. Values stored as SecureString parameters
+// before envelope encryption are encrypted and stored as String parameters.
+func (m *ParameterStoreManager) RotateValue(
+	ctx context.Context,
+	name string,
+	force bool,
+) (crypto.RotationOutcome, error) {
+	parameterName := m.getParameterName(name)
+	result, err := m.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(parameterName),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve secret: %w", err)
+	}
+	if result.Parameter == nil || result.Parameter.Value == nil {
+		return "", errors.New("unexpected response from parameter store")
+	}
+
+	value, outcome, err := m.envelope.RotateString(ctx, *result.Parameter.Value, name, force)
+	if err != nil {
+		return "", err
+	}
+	if outcome == crypto.RotationCurrent {
+		return outcome, nil
+	}
+
+	if _, err = m.client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(parameterName),
+		Value:     aws.String(value),
+		Type:      types.ParameterTypeString,
+		Overwrite: aws.Bool(true),
+	}); err != nil {
+		return "", fmt.Errorf("failed to store secret: %w", err)
+	}
+	return outcome, nil
+}
@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/crypto"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newParameterMapClient returns a mock client reading and writing the parameters of the map.
+func newParameterMapClient(parameters map[string]string) *mockClient {
+	return &mockClient{
+		getParameterFunc: func(
+			_ context.Context,
+			params *ssm.GetParameterInput,
+			_ ...func(*ssm.Options),
+		) (*ssm.GetParameterOutput, error) {
+			return &ssm.GetParameterOutput{
+				Parameter: &types.Parameter{Name: params.Name, Value: aws.String(parameters[*params.Name])},
+			}, nil
+		},
+		putParameterFunc: func(
+			_ context.Context,
+			params *ssm.PutParameterInput,
+			_ ...func(*ssm.Options),
+		) (*ssm.PutParameterOutput, error) {
+			parameters[*params.Name] = *params.Value
+			return &ssm.PutParameterOutput{}, nil
+		},
+	}
+}
+
+func newLocalEnvelope(t *testing.T, current string) *crypto.Envelope {
+	t.Helper()
+	keys, err := crypto.NewLocalKeyManager(current, map[string][]byte{
+		"v1": bytes.Repeat([]byte{1}, crypto.DataKeySize),
+		"v2": bytes.Repeat([]byte{2}, crypto.DataKeySize),
+	})
+	require.NoError(t, err)
+	return crypto.NewEnvelope(keys)
+}
+
+func TestRotateValue(t *testing.T) {
+	ctx := context.Background()
+	parameters := map[string]string{"/runvoy/secrets/legacy": "plain"}
+	client := newParameterMapClient(parameters)
+
+	previous := NewParameterStoreManager(client, "/runvoy/secrets", newLocalEnvelope(t, "v1"), nil, slog.Default())
+	require.NoError(t, previous.StoreSecret(ctx, "api-key", "s3cr3t"))
+	oldCiphertext := parameters["/runvoy/secrets/api-key"]
+
+	manager := NewParameterStoreManager(client, "/runvoy/secrets", newLocalEnvelope(t, "v2"), nil, slog.Default())
+
+	outcome, err := manager.RotateValue(ctx, "api-key", false)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.RotationRotated, outcome)
+	assert.NotEqual(t, oldCiphertext, parameters["/runvoy/secrets/api-key"])
+
+	outcome, err = manager.RotateValue(ctx, "api-key", false)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.RotationCurrent, outcome)
+
+	outcome, err = manager.RotateValue(ctx, "legacy", false)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.RotationEncrypted, outcome)
+	assert.True(t, crypto.IsEncryptedString(parameters["/runvoy/secrets/legacy"]))
+
+	for name, expected := range map[string]string{"api-key": "s3cr3t", "legacy": "plain"} {
+		value, retrieveErr := manager.RetrieveSecret(ctx, name)
+		require.NoError(t, retrieveErr)
+		assert.Equal(t, expected, value)
+	}
+
+	ciphertext, err := crypto.DecodeString(parameters["/runvoy/secrets/api-key"])
+	require.NoError(t, err)
+	keyID, err := crypto.KeyID(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "local:v2", keyID)
+}
+
+func TestRotationNames(t *testing.T) {
+	calls := 0
+	client := &mockClient{
+		describeParametersFunc: func(
+			_ context.Context,
+			params *ssm.DescribeParametersInput,
+			_ ...func(*ssm.Options),
+		) (*ssm.DescribeParametersOutput, error) {
+			calls++
+			assert.Equal(t, []string{"/runvoy/secrets"}, params.ParameterFilters[0].Values)
+			if params.NextToken == nil {
+				return &ssm.DescribeParametersOutput{
+					Parameters: []types.ParameterMetadata{{Name: aws.String("/runvoy/secrets/b")}},
+					NextToken:  aws.String("next"),
+				}, nil
+			}
+			return &ssm.DescribeParametersOutput{
+				Parameters: []types.ParameterMetadata{{Name: aws.String("/runvoy/secrets/a")}},
+			}, nil
+		},
+	}
+	manager := NewParameterStoreManager(client, "/runvoy/secrets", newTestEnvelope(t), nil, slog.Default())
+
+	names, err := manager.RotationNames(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, names)
+	assert.Equal(t, 2, calls)
+}
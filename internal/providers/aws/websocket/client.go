@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/runvoy/runvoy/internal/chaos"
+
 	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
 )
 
@@ -40,3 +42,21 @@ func (a *ClientAdapter) PostToConnection(
 	}
 	return result, nil
 }
+
+// droppingClient silently drops the messages picked by the chaos injector, as a lost message would be.
+type droppingClient struct {
+	Client
+	inj *chaos.Injector
+}
+
+// PostToConnection sends the message unless it is picked to be dropped.
+func (c *droppingClient) PostToConnection(
+	ctx context.Context,
+	params *apigatewaymanagementapi.PostToConnectionInput,
+	optFns ...func(*apigatewaymanagementapi.Options),
+) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+	if c.inj.Drop() {
+		return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+	}
+	return c.Client.PostToConnection(ctx, params, optFns...)
+}
@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/config"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
@@ -20,6 +24,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi/types"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -34,20 +39,26 @@ type Manager struct {
 	apiGwEndpoint *string
 	logger        *slog.Logger
 	connectionIDs []string
+	metrics       contract.MetricsRecorder
 }
 
 // Initialize creates a new AWS WebSocket manager.
+// The metrics recorder receives the fan-out counts of the messages sent to clients, it may be nil.
 func Initialize(
 	cfg *config.Config,
 	connRepo database.ConnectionRepository,
 	tokenRepo database.TokenRepository,
 	logEventRepo database.LogEventRepository,
+	metrics contract.MetricsRecorder,
 	log *slog.Logger,
 ) *Manager {
 	apiGwSDKClient := apigatewaymanagementapi.NewFromConfig(*cfg.AWS.SDKConfig, func(o *apigatewaymanagementapi.Options) {
 		o.BaseEndpoint = aws.String(cfg.AWS.WebSocketAPIEndpoint)
 	})
-	apiGwClient := NewClientAdapter(apiGwSDKClient)
+	var apiGwClient Client = NewClientAdapter(apiGwSDKClient)
+	if inj := chaos.New(cfg.Chaos); inj != nil {
+		apiGwClient = &droppingClient{Client: apiGwClient, inj: inj}
+	}
 	connectionIDs := make([]string, 0)
 
 	log.Debug("websocket manager initialized",
@@ -66,7 +77,46 @@ func Initialize(
 		apiGwEndpoint: aws.String(cfg.AWS.WebSocketAPIEndpoint),
 		logger:        log,
 		connectionIDs: connectionIDs,
+		metrics:       metrics,
+	}
+}
+
+// recordFanOut records the number of connections a message is sent to.
+func (m *Manager) recordFanOut(ctx context.Context, message string, connectionCount int) {
+	if m.metrics == nil {
+		return
+	}
+	m.metrics.RecordMetrics(ctx, contract.Metric{
+		Name:       constants.MetricWebSocketFanOut,
+		Value:      float64(connectionCount),
+		Unit:       contract.MetricUnitCount,
+		Dimensions: map[string]string{constants.MetricDimensionMessage: message},
+	})
+}
+
+// recordFanOutLatency records the time taken to send a message to all its connections.
+func (m *Manager) recordFanOutLatency(ctx context.Context, message string, latency time.Duration) {
+	if m.metrics == nil {
+		return
 	}
+	m.metrics.RecordMetrics(ctx, contract.Metric{
+		Name:       constants.MetricWebSocketFanOutLatency,
+		Value:      float64(latency.Milliseconds()),
+		Unit:       contract.MetricUnitMilliseconds,
+		Dimensions: map[string]string{constants.MetricDimensionMessage: message},
+	})
+}
+
+// recordPrunedConnections records the number of connections deleted because their client was gone.
+func (m *Manager) recordPrunedConnections(ctx context.Context, count int) {
+	if m.metrics == nil || count == 0 {
+		return
+	}
+	m.metrics.RecordMetrics(ctx, contract.Metric{
+		Name:  constants.MetricWebSocketConnectionsPruned,
+		Value: float64(count),
+		Unit:  contract.MetricUnitCount,
+	})
 }
 
 func (m *Manager) deriveLogger(ctx context.Context) *slog.Logger {
@@ -209,7 +259,7 @@ func (m *Manager) handleConnect(
 		return *errResp, nil
 	}
 
-	wsToken, errResp := m.fetchWebSocketToken(ctx, reqLogger, token, executionID)
+	wsToken, errResp := m.claimWebSocketToken(ctx, reqLogger, token, executionID)
 	if errResp != nil {
 		return *errResp, nil
 	}
@@ -232,13 +282,15 @@ func (m *Manager) handleConnect(
 	}, nil
 }
 
-func (m *Manager) fetchWebSocketToken(
+// claimWebSocketToken consumes the token of a connection request, so that a token leaked through a URL
+// cannot open another connection, and checks it was issued to a user for the requested execution.
+func (m *Manager) claimWebSocketToken(
 	ctx context.Context,
 	reqLogger *slog.Logger,
 	token string,
 	executionID string,
 ) (*api.WebSocketToken, *events.APIGatewayProxyResponse) {
-	wsToken, err := m.tokenRepo.GetToken(ctx, token)
+	wsToken, err := m.tokenRepo.ClaimToken(ctx, token)
 	if err != nil {
 		reqLogger.Error("failed to validate token", "error", err, "execution_id", executionID)
 		return nil, &events.APIGatewayProxyResponse{
@@ -248,13 +300,21 @@ func (m *Manager) fetchWebSocketToken(
 	}
 
 	if wsToken == nil {
-		reqLogger.Info("invalid or expired websocket token", "execution_id", executionID)
+		reqLogger.Info("invalid, expired or already used websocket token", "execution_id", executionID)
 		return nil, &events.APIGatewayProxyResponse{
 			StatusCode: http.StatusUnauthorized,
 			Body:       "Invalid or expired token",
 		}
 	}
 
+	if wsToken.UserEmail == "" {
+		reqLogger.Warn("websocket token not bound to a user", "execution_id", executionID)
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode: http.StatusForbidden,
+			Body:       "Token is not bound to a user",
+		}
+	}
+
 	if wsToken.ExecutionID != executionID {
 		reqLogger.Warn("execution ID mismatch in websocket token",
 			"token_execution_id", wsToken.ExecutionID,
@@ -331,10 +391,8 @@ func (m *Manager) validateConnectionParams(
 }
 
 // handleDisconnect handles the $disconnect route key.
-// It deletes the WebSocket connection and its associated token from DynamoDB.
-// Token cleanup: DynamoDB TTL automatically deletes tokens after expiration.
-// Manual deletion on disconnect is not necessary since the token expires with
-// the same TTL as the connection.
+// It deletes the WebSocket connection from DynamoDB. Its token was already deleted when the
+// connection claimed it.
 //
 //nolint:gocritic // Lambda event types are passed by value per AWS Lambda conventions
 func (m *Manager) handleDisconnect(
@@ -377,7 +435,7 @@ func (m *Manager) handleDisconnect(
 }
 
 // NotifyExecutionCompletion sends disconnect notifications to all connected clients for an execution
-// and deletes the connections from DynamoDB.
+// and deletes the connections from DynamoDB, along with the tokens of the execution not claimed yet.
 func (m *Manager) NotifyExecutionCompletion(ctx context.Context, executionID *string) error {
 	if executionID == nil || *executionID == "" {
 		return errors.New("execution ID is nil or empty")
@@ -408,6 +466,100 @@ func (m *Manager) NotifyExecutionCompletion(ctx context.Context, executionID *st
 		)
 	}
 
+	// Revoke the URLs handed out but not used yet: there is nothing left to stream.
+	revokedCount, err := m.tokenRepo.DeleteTokensByExecutionID(ctx, *executionID)
+	if err != nil {
+		reqLogger.Error("failed to revoke WebSocket tokens", "context",
+			map[string]string{
+				"error":        err.Error(),
+				"execution_id": *executionID,
+			},
+		)
+		// Don't fail - unclaimed tokens expire on their own
+	} else if revokedCount > 0 {
+		reqLogger.Debug("revoked WebSocket tokens for execution", "context",
+			map[string]string{
+				"execution_id":  *executionID,
+				"revoked_count": strconv.Itoa(revokedCount),
+			},
+		)
+	}
+
+	return nil
+}
+
+// NotifyExecutionStatus sends a status message to all connected clients for an execution, so they
+// follow its status without polling the API. The connections API Gateway reports gone are deleted.
+func (m *Manager) NotifyExecutionStatus(ctx context.Context, change *api.ExecutionStatusChange) error {
+	if change == nil || change.ExecutionID == "" {
+		return errors.New("status change is nil or has no execution ID")
+	}
+
+	reqLogger := m.deriveLogger(ctx)
+
+	connections, err := m.loadConnections(ctx, reqLogger, change.ExecutionID)
+	if err != nil {
+		return err
+	}
+
+	if len(connections) == 0 {
+		reqLogger.Debug("no active connections to notify of status change", "execution_id", change.ExecutionID)
+		return nil
+	}
+
+	statusMessageBytes, err := json.Marshal(api.WebSocketMessage{
+		Version: api.WebSocketProtocolVersion,
+		Type:    api.WebSocketMessageTypeStatus,
+		Status:  change,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal status message: %w", err)
+	}
+
+	gone, err := m.fanOut(ctx, "status", connections,
+		func(sendCtx context.Context, conn *api.WebSocketConnection) error {
+			return m.sendStatusToConnection(sendCtx, reqLogger, conn.ConnectionID, statusMessageBytes)
+		})
+	m.pruneGoneConnections(ctx, reqLogger, change.ExecutionID, gone)
+	if err != nil {
+		reqLogger.Error("some status notifications failed to send", "context", map[string]string{
+			"error":        err.Error(),
+			"execution_id": change.ExecutionID,
+		})
+		return fmt.Errorf("failed to send status to some connections: %w", err)
+	}
+
+	reqLogger.Debug("status change sent to connections", "context", map[string]string{
+		"execution_id":     change.ExecutionID,
+		"status":           change.Status,
+		"connection_count": strconv.Itoa(len(connections)),
+	})
+
+	return nil
+}
+
+// sendStatusToConnection sends a status message to a single WebSocket connection.
+func (m *Manager) sendStatusToConnection(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+	connectionID string,
+	message []byte,
+) error {
+	_, err := m.apiGwClient.PostToConnection(ctx, &apigatewaymanagementapi.PostToConnectionInput{
+		ConnectionId: aws.String(connectionID),
+		Data:         message,
+	})
+
+	if err != nil {
+		reqLogger.Error("failed to send status to connection",
+			"context", map[string]string{
+				"error":         err.Error(),
+				"connection_id": connectionID,
+			},
+		)
+		return fmt.Errorf("failed to send status to connection %s: %w", connectionID, err)
+	}
+
 	return nil
 }
 
@@ -450,44 +602,76 @@ func (m *Manager) SendLogsToExecution(
 	return m.distributeBufferedEvents(ctx, reqLogger, execID, connections, bufferedEvents)
 }
 
+// sendBufferedLogsToConnection sends the buffered events the connection has not received yet, oldest first and
+// at most constants.MaxLogEventsPerConnectionSend of them, then records the last one sent on the connection.
+// The progress is recorded as well when a send fails, so the next fan-out resumes where this one stopped.
 func (m *Manager) sendBufferedLogsToConnection(
 	ctx context.Context,
 	reqLogger *slog.Logger,
 	connection *api.WebSocketConnection,
 	bufferedEvents []api.LogEvent,
 ) error {
-	eventsToSend := filterEventsAfter(bufferedEvents, connection.LastEventID)
+	eventsToSend := filterEventsAfter(bufferedEvents, connection)
 	if len(eventsToSend) == 0 {
 		reqLogger.Debug("no buffered logs to send to connection", "context", map[string]string{
 			"connection_id": connection.ConnectionID,
 		})
 		return nil
 	}
+	if len(eventsToSend) > constants.MaxLogEventsPerConnectionSend {
+		reqLogger.Debug("connection lags behind, sending the rest of its logs with the next fan-out", "context",
+			map[string]any{
+				"connection_id": connection.ConnectionID,
+				"pending_count": len(eventsToSend),
+			})
+		eventsToSend = eventsToSend[:constants.MaxLogEventsPerConnectionSend]
+	}
 
+	sent := 0
+	var sendErr error
 	for _, event := range eventsToSend {
-		if err := m.sendLogToConnection(ctx, reqLogger, connection.ConnectionID, event); err != nil {
-			return err
+		if sendErr = m.sendLogToConnection(ctx, reqLogger, connection.ConnectionID, event); sendErr != nil {
+			break
 		}
+		sent++
+	}
+	if sendErr != nil && (sent == 0 || isGoneConnection(sendErr)) {
+		return sendErr
 	}
 
-	lastEventID := eventsToSend[len(eventsToSend)-1].EventID
-	if lastEventID == "" {
-		return nil
+	lastEvent := eventsToSend[sent-1]
+	if lastEvent.EventID == "" {
+		return sendErr
 	}
 
-	if err := m.connRepo.UpdateLastEventID(ctx, connection.ConnectionID, lastEventID); err != nil {
+	err := m.connRepo.UpdateLastEventID(ctx, connection.ConnectionID, lastEvent.EventID, lastEvent.Sequence)
+	if err != nil {
 		reqLogger.Error("failed to update last event ID", "context", map[string]any{
 			"connection_id": connection.ConnectionID,
-			"last_event_id": lastEventID,
+			"last_event_id": lastEvent.EventID,
+			"last_sequence": lastEvent.Sequence,
 			"error":         err.Error(),
 		})
-		return fmt.Errorf("failed to update last event ID: %w", err)
+		return errors.Join(sendErr, fmt.Errorf("failed to update last event ID: %w", err))
 	}
 
-	return nil
+	return sendErr
 }
 
-func filterEventsAfter(logEvents []api.LogEvent, lastEventID string) []api.LogEvent {
+// filterEventsAfter returns the buffered events the connection has not received yet: those after its last
+// sequence, or after its last event ID for the connections that received events without sequence.
+func filterEventsAfter(logEvents []api.LogEvent, connection *api.WebSocketConnection) []api.LogEvent {
+	if connection.LastSequence > 0 {
+		next := slices.IndexFunc(logEvents, func(event api.LogEvent) bool {
+			return event.Sequence > connection.LastSequence
+		})
+		if next < 0 {
+			return []api.LogEvent{}
+		}
+		return logEvents[next:]
+	}
+
+	lastEventID := connection.LastEventID
 	if lastEventID == "" {
 		return logEvents
 	}
@@ -556,21 +740,17 @@ func (m *Manager) distributeBufferedEvents(
 		},
 	)
 
-	eg, egCtx := errgroup.WithContext(ctx)
-	eg.SetLimit(constants.MaxConcurrentSends)
-
-	for _, conn := range connections {
-		eg.Go(func() error {
-			return m.sendBufferedLogsToConnection(egCtx, reqLogger, conn, bufferedEvents)
+	gone, err := m.fanOut(ctx, "logs", connections,
+		func(sendCtx context.Context, conn *api.WebSocketConnection) error {
+			return m.sendBufferedLogsToConnection(sendCtx, reqLogger, conn, bufferedEvents)
 		})
-	}
-
-	if egErr := eg.Wait(); egErr != nil {
+	m.pruneGoneConnections(ctx, reqLogger, executionID, gone)
+	if err != nil {
 		reqLogger.Error("some log sends failed", "context", map[string]any{
-			"error":        egErr.Error(),
+			"error":        err.Error(),
 			"execution_id": executionID,
 		})
-		return fmt.Errorf("failed to send logs to some connections: %w", egErr)
+		return fmt.Errorf("failed to send logs to some connections: %w", err)
 	}
 
 	reqLogger.Debug("all buffered logs sent to connections", "context", map[string]string{
@@ -581,6 +761,80 @@ func (m *Manager) distributeBufferedEvents(
 	return nil
 }
 
+// fanOut sends a message to the connections through send, on at most constants.MaxConcurrentSends workers,
+// and records the fan-out count and latency. A failing connection does not stop the sends to the others:
+// the errors are joined, except those of the connections API Gateway reports gone, whose IDs are returned.
+func (m *Manager) fanOut(
+	ctx context.Context,
+	message string,
+	connections []*api.WebSocketConnection,
+	send func(context.Context, *api.WebSocketConnection) error,
+) (gone []string, err error) {
+	m.recordFanOut(ctx, message, len(connections))
+	started := time.Now()
+
+	var mu sync.Mutex
+	var errs []error
+	var workers errgroup.Group
+	workers.SetLimit(constants.MaxConcurrentSends)
+	for _, conn := range connections {
+		workers.Go(func() error {
+			sendErr := send(ctx, conn)
+			if sendErr == nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if isGoneConnection(sendErr) {
+				gone = append(gone, conn.ConnectionID)
+			} else {
+				errs = append(errs, sendErr)
+			}
+			return nil
+		})
+	}
+	_ = workers.Wait()
+
+	m.recordFanOutLatency(ctx, message, time.Since(started))
+	return gone, errors.Join(errs...)
+}
+
+// pruneGoneConnections deletes the connections whose client is gone, so the next fan-outs skip them.
+// Pruning is best-effort: the connections expire with their TTL otherwise.
+func (m *Manager) pruneGoneConnections(
+	ctx context.Context,
+	reqLogger *slog.Logger,
+	executionID string,
+	connectionIDs []string,
+) {
+	if len(connectionIDs) == 0 {
+		return
+	}
+
+	deletedCount, err := m.connRepo.DeleteConnections(ctx, connectionIDs)
+	if err != nil {
+		reqLogger.Error("failed to prune gone WebSocket connections", "context", map[string]any{
+			"error":          err.Error(),
+			"execution_id":   executionID,
+			"connection_ids": connectionIDs,
+		})
+		return
+	}
+	m.recordPrunedConnections(ctx, deletedCount)
+
+	reqLogger.Info("pruned gone WebSocket connections", "context", map[string]any{
+		"execution_id":   executionID,
+		"connection_ids": connectionIDs,
+		"deleted_count":  deletedCount,
+	})
+}
+
+// isGoneConnection reports whether the error is API Gateway reporting the client of the connection gone.
+func isGoneConnection(err error) bool {
+	var goneErr *types.GoneException
+	return errors.As(err, &goneErr)
+}
+
 // sendLogToConnection sends a single log event to a WebSocket connection.
 func (m *Manager) sendLogToConnection(
 	ctx context.Context,
@@ -592,7 +846,11 @@ func (m *Manager) sendLogToConnection(
 		return errors.New("connection ID is empty")
 	}
 
-	logJSON, err := json.Marshal(logEvent)
+	logJSON, err := json.Marshal(api.WebSocketLogMessage{
+		Version:  api.WebSocketProtocolVersion,
+		Type:     api.WebSocketMessageTypeLog,
+		LogEvent: logEvent,
+	})
 	if err != nil {
 		reqLogger.Error("failed to marshal log event",
 			"context", map[string]any{
@@ -648,26 +906,22 @@ func (m *Manager) handleDisconnectNotification(
 		m.connectionIDs = append(m.connectionIDs, conn.ConnectionID)
 	}
 
-	errGroup, errCtx := errgroup.WithContext(ctx)
-	errGroup.SetLimit(constants.MaxConcurrentSends)
-
 	reason := api.WebSocketDisconnectReasonExecutionCompleted
 	disconnectMessage := api.WebSocketMessage{
-		Type:   api.WebSocketMessageTypeDisconnect,
-		Reason: &reason,
+		Version: api.WebSocketProtocolVersion,
+		Type:    api.WebSocketMessageTypeDisconnect,
+		Reason:  &reason,
 	}
 	disconnectMessageBytes, err := json.Marshal(disconnectMessage)
 	if err != nil {
 		return fmt.Errorf("failed to marshal disconnect message: %w", err)
 	}
 
-	for _, connectionID := range m.connectionIDs {
-		errGroup.Go(func() error {
-			return m.sendDisconnectToConnection(errCtx, reqLogger, connectionID, disconnectMessageBytes)
+	// The connections are all deleted once notified, gone ones included.
+	_, err = m.fanOut(ctx, "disconnect", connections,
+		func(sendCtx context.Context, conn *api.WebSocketConnection) error {
+			return m.sendDisconnectToConnection(sendCtx, reqLogger, conn.ConnectionID, disconnectMessageBytes)
 		})
-	}
-
-	err = errGroup.Wait()
 	if err != nil {
 		reqLogger.Error("some disconnect notifications failed to send", "context", map[string]string{
 			"error":        err.Error(),
@@ -718,7 +972,8 @@ func (m *Manager) sendDisconnectToConnection(
 }
 
 // GenerateWebSocketURL creates a WebSocket token and returns the connection URL.
-// It stores the token for validation when the client connects.
+// It stores the token, bound to the user and the execution, to be claimed when the client connects:
+// the URL opens a single connection within constants.WebSocketTokenTTL.
 func (m *Manager) GenerateWebSocketURL(
 	ctx context.Context,
 	executionID string,
@@ -735,11 +990,13 @@ func (m *Manager) GenerateWebSocketURL(
 		return ""
 	}
 
-	expiresAt := time.Now().Add(constants.ConnectionTTLHours * time.Hour).Unix()
-	var email string
-	if userEmail != nil {
-		email = *userEmail
+	if userEmail == nil || *userEmail == "" {
+		reqLogger.Error("refusing to generate a websocket token without user", "execution_id", executionID)
+		return ""
 	}
+
+	expiresAt := time.Now().Add(constants.WebSocketTokenTTL).Unix()
+	email := *userEmail
 	var clientIP string
 	if clientIPAtCreationTime != nil {
 		clientIP = *clientIPAtCreationTime
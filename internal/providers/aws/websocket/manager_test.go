@@ -7,14 +7,19 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/chaos"
+	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +29,7 @@ type mockConnectionRepoForWS struct {
 	createConnectionFunc            func(context.Context, *api.WebSocketConnection) error
 	deleteConnectionsFunc           func(context.Context, []string) (int, error)
 	getConnectionsByExecutionIDFunc func(context.Context, string) ([]*api.WebSocketConnection, error)
-	updateLastEventIDFunc           func(context.Context, string, string) error
+	updateLastEventIDFunc           func(context.Context, string, string, int64) error
 }
 
 func (m *mockConnectionRepoForWS) CreateConnection(ctx context.Context, conn *api.WebSocketConnection) error {
@@ -50,18 +55,21 @@ func (m *mockConnectionRepoForWS) GetConnectionsByExecutionID(
 	return nil, nil
 }
 
-func (m *mockConnectionRepoForWS) UpdateLastEventID(ctx context.Context, connectionID, lastEventID string) error {
+func (m *mockConnectionRepoForWS) UpdateLastEventID(
+	ctx context.Context, connectionID, lastEventID string, lastSequence int64,
+) error {
 	if m.updateLastEventIDFunc != nil {
-		return m.updateLastEventIDFunc(ctx, connectionID, lastEventID)
+		return m.updateLastEventIDFunc(ctx, connectionID, lastEventID, lastSequence)
 	}
 	return nil
 }
 
 // mockTokenRepoForWS implements database.TokenRepository for testing.
 type mockTokenRepoForWS struct {
-	createTokenFunc func(context.Context, *api.WebSocketToken) error
-	getTokenFunc    func(context.Context, string) (*api.WebSocketToken, error)
-	deleteTokenFunc func(context.Context, string) error
+	createTokenFunc               func(context.Context, *api.WebSocketToken) error
+	claimTokenFunc                func(context.Context, string) (*api.WebSocketToken, error)
+	deleteTokenFunc               func(context.Context, string) error
+	deleteTokensByExecutionIDFunc func(context.Context, string) (int, error)
 }
 
 type mockLogEventRepoForWS struct {
@@ -108,9 +116,9 @@ func (m *mockTokenRepoForWS) CreateToken(ctx context.Context, token *api.WebSock
 	return nil
 }
 
-func (m *mockTokenRepoForWS) GetToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error) {
-	if m.getTokenFunc != nil {
-		return m.getTokenFunc(ctx, tokenValue)
+func (m *mockTokenRepoForWS) ClaimToken(ctx context.Context, tokenValue string) (*api.WebSocketToken, error) {
+	if m.claimTokenFunc != nil {
+		return m.claimTokenFunc(ctx, tokenValue)
 	}
 	return nil, nil
 }
@@ -122,6 +130,21 @@ func (m *mockTokenRepoForWS) DeleteToken(ctx context.Context, tokenValue string)
 	return nil
 }
 
+func (m *mockTokenRepoForWS) DeleteTokensByExecutionID(ctx context.Context, executionID string) (int, error) {
+	if m.deleteTokensByExecutionIDFunc != nil {
+		return m.deleteTokensByExecutionIDFunc(ctx, executionID)
+	}
+	return 0, nil
+}
+
+func (m *mockTokenRepoForWS) CreateRunToken(_ context.Context, _ *api.RunToken) error {
+	return nil
+}
+
+func (m *mockTokenRepoForWS) GetRunToken(_ context.Context, _ string) (*api.RunToken, error) {
+	return nil, nil
+}
+
 func TestValidateConnectionParams(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -320,7 +343,7 @@ func TestHandleConnect(t *testing.T) {
 			}
 
 			mockTokenRepo := &mockTokenRepoForWS{
-				getTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
+				claimTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
 					// If test expects an error getting the token, return it
 					if tt.mockGetErr != nil {
 						return nil, tt.mockGetErr
@@ -351,6 +374,86 @@ func TestHandleConnect(t *testing.T) {
 	}
 }
 
+func TestHandleConnect_RejectsTokenWithoutUser(t *testing.T) {
+	mockTokenRepo := &mockTokenRepoForWS{
+		claimTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
+			return &api.WebSocketToken{Token: token, ExecutionID: "exec-123", ExpiresAt: 9999999999}, nil
+		},
+	}
+	mockConnRepo := &mockConnectionRepoForWS{
+		createConnectionFunc: func(_ context.Context, _ *api.WebSocketConnection) error {
+			t.Fatal("no connection should be created for a token not bound to a user")
+			return nil
+		},
+	}
+
+	reqLogger := testutil.SilentLogger()
+	wm := &Manager{
+		connRepo:  mockConnRepo,
+		tokenRepo: mockTokenRepo,
+		logger:    reqLogger,
+	}
+
+	req := events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			ConnectionID: "real-conn-id",
+		},
+		QueryStringParameters: map[string]string{
+			"execution_id": "exec-123",
+			"token":        "token-abc",
+		},
+	}
+
+	resp, err := wm.handleConnect(context.Background(), reqLogger, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestHandleConnect_TokenIsSingleUse(t *testing.T) {
+	claimed := false
+	mockTokenRepo := &mockTokenRepoForWS{
+		claimTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
+			if claimed {
+				return nil, nil
+			}
+			claimed = true
+			return &api.WebSocketToken{
+				Token:       token,
+				ExecutionID: "exec-123",
+				UserEmail:   "alice@example.com",
+				ExpiresAt:   9999999999,
+			}, nil
+		},
+	}
+
+	reqLogger := testutil.SilentLogger()
+	wm := &Manager{
+		connRepo:  &mockConnectionRepoForWS{},
+		tokenRepo: mockTokenRepo,
+		logger:    reqLogger,
+	}
+
+	req := events.APIGatewayWebsocketProxyRequest{
+		RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+			ConnectionID: "real-conn-id",
+		},
+		QueryStringParameters: map[string]string{
+			"execution_id": "exec-123",
+			"token":        "token-abc",
+		},
+	}
+
+	resp, err := wm.handleConnect(context.Background(), reqLogger, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req.RequestContext.ConnectionID = "replayed-conn-id"
+	resp, err = wm.handleConnect(context.Background(), reqLogger, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
 func TestHandleConnect_UsesTokenMetadata(t *testing.T) {
 	// Verify that the real connection uses metadata from the WebSocket token
 	validToken := "token-xyz"
@@ -375,7 +478,7 @@ func TestHandleConnect_UsesTokenMetadata(t *testing.T) {
 	}
 
 	mockTokenRepo := &mockTokenRepoForWS{
-		getTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
+		claimTokenFunc: func(_ context.Context, token string) (*api.WebSocketToken, error) {
 			if token == validToken {
 				return wsToken, nil
 			}
@@ -433,6 +536,14 @@ func (m *mockAPIGatewayClient) PostToConnection(
 	return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
 }
 
+type recordingMetricsRecorder struct {
+	metrics []contract.Metric
+}
+
+func (r *recordingMetricsRecorder) RecordMetrics(_ context.Context, metrics ...contract.Metric) {
+	r.metrics = append(r.metrics, metrics...)
+}
+
 func TestSendLogsToExecution(t *testing.T) {
 	ctx := context.Background()
 	executionID := "exec-123"
@@ -473,7 +584,7 @@ func TestSendLogsToExecution(t *testing.T) {
 				}
 				return nil, nil
 			},
-			updateLastEventIDFunc: func(_ context.Context, connectionID, lastEventID string) error {
+			updateLastEventIDFunc: func(_ context.Context, connectionID, lastEventID string, _ int64) error {
 				updatedConnections = append(updatedConnections, fmt.Sprintf("%s:%s", connectionID, lastEventID))
 				return nil
 			},
@@ -488,17 +599,24 @@ func TestSendLogsToExecution(t *testing.T) {
 			},
 		}
 
+		metrics := &recordingMetricsRecorder{}
 		m := &Manager{
 			connRepo:     mockConnRepo,
 			logEventRepo: mockLogRepo,
 			apiGwClient:  mockClient,
 			logger:       testutil.SilentLogger(),
+			metrics:      metrics,
 		}
 
 		err := m.SendLogsToExecution(ctx, &executionID)
 
 		assert.NoError(t, err)
 		assert.Len(t, sentMessages, 3) // conn1 gets events after evt-1, conn2 gets all
+		require.Len(t, metrics.metrics, 2)
+		assert.InDelta(t, 2, metrics.metrics[0].Value, 0)
+		assert.Equal(t, "logs", metrics.metrics[0].Dimensions[constants.MetricDimensionMessage])
+		assert.Equal(t, constants.MetricWebSocketFanOutLatency, metrics.metrics[1].Name)
+		assert.Equal(t, contract.MetricUnitMilliseconds, metrics.metrics[1].Unit)
 		require.True(t, messageListContains(sentMessages, "log message 2"))
 		assert.ElementsMatch(t, []string{"conn-1:evt-2", "conn-2:evt-2"}, updatedConnections)
 	})
@@ -643,6 +761,217 @@ func TestSendLogsToExecution(t *testing.T) {
 	})
 }
 
+func TestSendLogsToExecution_FanOut(t *testing.T) {
+	ctx := context.Background()
+	executionID := "exec-123"
+
+	newManager := func(
+		connections []*api.WebSocketConnection,
+		buffered []api.LogEvent,
+		client Client,
+		connRepo *mockConnectionRepoForWS,
+		metrics contract.MetricsRecorder,
+	) *Manager {
+		connRepo.getConnectionsByExecutionIDFunc = func(context.Context, string) ([]*api.WebSocketConnection, error) {
+			return connections, nil
+		}
+		return &Manager{
+			connRepo: connRepo,
+			logEventRepo: &mockLogEventRepoForWS{
+				listLogEventsFunc: func(context.Context, string) ([]api.LogEvent, error) {
+					return buffered, nil
+				},
+			},
+			apiGwClient: client,
+			logger:      testutil.SilentLogger(),
+			metrics:     metrics,
+		}
+	}
+
+	t.Run("keeps sending to the other connections when one fails", func(t *testing.T) {
+		connections := []*api.WebSocketConnection{{ConnectionID: "conn-bad"}, {ConnectionID: "conn-ok"}}
+		var mu sync.Mutex
+		var delivered []string
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				_ context.Context,
+				input *apigatewaymanagementapi.PostToConnectionInput,
+				_ ...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				if *input.ConnectionId == "conn-bad" {
+					return nil, errors.New("throttled")
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				delivered = append(delivered, *input.ConnectionId)
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		m := newManager(connections, []api.LogEvent{{EventID: "evt-1"}, {EventID: "evt-2"}}, client,
+			&mockConnectionRepoForWS{}, nil)
+
+		err := m.SendLogsToExecution(ctx, &executionID)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "throttled")
+		assert.Equal(t, []string{"conn-ok", "conn-ok"}, delivered)
+	})
+
+	t.Run("prunes gone connections", func(t *testing.T) {
+		connections := []*api.WebSocketConnection{{ConnectionID: "conn-gone"}, {ConnectionID: "conn-ok"}}
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				_ context.Context,
+				input *apigatewaymanagementapi.PostToConnectionInput,
+				_ ...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				if *input.ConnectionId == "conn-gone" {
+					return nil, fmt.Errorf("failed to post to connection: %w", &types.GoneException{})
+				}
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		var deleted []string
+		var updated []string
+		connRepo := &mockConnectionRepoForWS{
+			deleteConnectionsFunc: func(_ context.Context, connIDs []string) (int, error) {
+				deleted = append(deleted, connIDs...)
+				return len(connIDs), nil
+			},
+			updateLastEventIDFunc: func(_ context.Context, connectionID, _ string, _ int64) error {
+				updated = append(updated, connectionID)
+				return nil
+			},
+		}
+		metrics := &recordingMetricsRecorder{}
+		m := newManager(connections, []api.LogEvent{{EventID: "evt-1"}}, client, connRepo, metrics)
+
+		err := m.SendLogsToExecution(ctx, &executionID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"conn-gone"}, deleted)
+		assert.Equal(t, []string{"conn-ok"}, updated)
+		require.Len(t, metrics.metrics, 3)
+		assert.Equal(t, contract.Metric{
+			Name:  constants.MetricWebSocketConnectionsPruned,
+			Value: 1,
+			Unit:  contract.MetricUnitCount,
+		}, metrics.metrics[2])
+	})
+
+	t.Run("caps the events sent to a lagging connection", func(t *testing.T) {
+		buffered := make([]api.LogEvent, constants.MaxLogEventsPerConnectionSend+5)
+		for i := range buffered {
+			buffered[i] = api.LogEvent{EventID: fmt.Sprintf("evt-%d", i)}
+		}
+		sent := 0
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				context.Context,
+				*apigatewaymanagementapi.PostToConnectionInput,
+				...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				sent++
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		var lastEventID string
+		connRepo := &mockConnectionRepoForWS{
+			updateLastEventIDFunc: func(_ context.Context, _, eventID string, _ int64) error {
+				lastEventID = eventID
+				return nil
+			},
+		}
+		m := newManager([]*api.WebSocketConnection{{ConnectionID: "conn-1"}}, buffered, client, connRepo, nil)
+
+		require.NoError(t, m.SendLogsToExecution(ctx, &executionID))
+
+		assert.Equal(t, constants.MaxLogEventsPerConnectionSend, sent)
+		assert.Equal(t, fmt.Sprintf("evt-%d", constants.MaxLogEventsPerConnectionSend-1), lastEventID)
+	})
+
+	t.Run("records the progress of a connection failing midway", func(t *testing.T) {
+		calls := 0
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				context.Context,
+				*apigatewaymanagementapi.PostToConnectionInput,
+				...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				calls++
+				if calls == 2 {
+					return nil, errors.New("throttled")
+				}
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		var lastEventID string
+		connRepo := &mockConnectionRepoForWS{
+			updateLastEventIDFunc: func(_ context.Context, _, eventID string, _ int64) error {
+				lastEventID = eventID
+				return nil
+			},
+		}
+		buffered := []api.LogEvent{{EventID: "evt-1"}, {EventID: "evt-2"}, {EventID: "evt-3"}}
+		m := newManager([]*api.WebSocketConnection{{ConnectionID: "conn-1"}}, buffered, client, connRepo, nil)
+
+		err := m.SendLogsToExecution(ctx, &executionID)
+
+		require.Error(t, err)
+		assert.Equal(t, "evt-1", lastEventID)
+	})
+
+	t.Run("resumes connections after their last sequence", func(t *testing.T) {
+		var mu sync.Mutex
+		delivered := map[string][]string{}
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				_ context.Context,
+				input *apigatewaymanagementapi.PostToConnectionInput,
+				_ ...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				var message api.WebSocketLogMessage
+				if err := json.Unmarshal(input.Data, &message); err != nil {
+					return nil, err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				delivered[*input.ConnectionId] = append(delivered[*input.ConnectionId], message.EventID)
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		progress := map[string]int64{}
+		connRepo := &mockConnectionRepoForWS{
+			updateLastEventIDFunc: func(_ context.Context, connectionID, _ string, lastSequence int64) error {
+				mu.Lock()
+				defer mu.Unlock()
+				progress[connectionID] = lastSequence
+				return nil
+			},
+		}
+		// Events of interleaved batches, ordered by the sequence numbers reserved for them
+		buffered := []api.LogEvent{
+			{EventID: "b-1", Timestamp: 20, Sequence: 3},
+			{EventID: "a-1", Timestamp: 10, Sequence: 5},
+			{EventID: "a-2", Timestamp: 11, Sequence: 6},
+		}
+		connections := []*api.WebSocketConnection{
+			{ConnectionID: "conn-new"},
+			{ConnectionID: "conn-behind", LastEventID: "b-1", LastSequence: 3},
+			{ConnectionID: "conn-done", LastEventID: "a-2", LastSequence: 6},
+		}
+		m := newManager(connections, buffered, client, connRepo, nil)
+
+		require.NoError(t, m.SendLogsToExecution(ctx, &executionID))
+
+		assert.Equal(t, map[string][]string{
+			"conn-new":    {"b-1", "a-1", "a-2"},
+			"conn-behind": {"a-1", "a-2"},
+		}, delivered)
+		assert.Equal(t, map[string]int64{"conn-new": 6, "conn-behind": 6}, progress)
+	})
+}
+
 func TestSendLogToConnection(t *testing.T) {
 	ctx := context.Background()
 	reqLogger := testutil.SilentLogger()
@@ -676,10 +1005,12 @@ func TestSendLogToConnection(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, sentData)
 
-		var receivedEvent api.LogEvent
+		var receivedEvent api.WebSocketLogMessage
 		err = json.Unmarshal(sentData, &receivedEvent)
 		assert.NoError(t, err)
 		assert.Equal(t, logEvent.Message, receivedEvent.Message)
+		assert.Equal(t, api.WebSocketMessageTypeLog, receivedEvent.Type)
+		assert.Equal(t, api.WebSocketProtocolVersion, receivedEvent.Version)
 	})
 
 	t.Run("handles empty connection ID", func(t *testing.T) {
@@ -709,6 +1040,128 @@ func TestSendLogToConnection(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to send log to connection")
 	})
+
+	t.Run("drops messages picked by the chaos injector", func(t *testing.T) {
+		sent := 0
+		mockClient := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				_ context.Context,
+				_ *apigatewaymanagementapi.PostToConnectionInput,
+				_ ...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				sent++
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+
+		m := &Manager{
+			apiGwClient: &droppingClient{Client: mockClient, inj: chaos.New(chaos.Config{DropRate: 1})},
+			logger:      reqLogger,
+		}
+
+		err := m.sendLogToConnection(ctx, reqLogger, connectionID, logEvent)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestNotifyExecutionStatus(t *testing.T) {
+	ctx := context.Background()
+	exitCode := 1
+	change := &api.ExecutionStatusChange{
+		ExecutionID:    "exec-123",
+		PreviousStatus: string(constants.ExecutionRunning),
+		Status:         string(constants.ExecutionFailed),
+		ExitCode:       &exitCode,
+		FailureReason:  "command_failed",
+	}
+
+	t.Run("sends the status message to all connections and prunes gone ones", func(t *testing.T) {
+		var mu sync.Mutex
+		sent := map[string][]byte{}
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				_ context.Context,
+				input *apigatewaymanagementapi.PostToConnectionInput,
+				_ ...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				if *input.ConnectionId == "conn-gone" {
+					return nil, &types.GoneException{}
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				sent[*input.ConnectionId] = input.Data
+				return &apigatewaymanagementapi.PostToConnectionOutput{}, nil
+			},
+		}
+		var deleted []string
+		connRepo := &mockConnectionRepoForWS{
+			getConnectionsByExecutionIDFunc: func(_ context.Context, executionID string) (
+				[]*api.WebSocketConnection, error,
+			) {
+				assert.Equal(t, "exec-123", executionID)
+				return []*api.WebSocketConnection{
+					{ConnectionID: "conn-1"}, {ConnectionID: "conn-2"}, {ConnectionID: "conn-gone"},
+				}, nil
+			},
+			deleteConnectionsFunc: func(_ context.Context, connIDs []string) (int, error) {
+				deleted = append(deleted, connIDs...)
+				return len(connIDs), nil
+			},
+		}
+		m := &Manager{connRepo: connRepo, apiGwClient: client, logger: testutil.SilentLogger()}
+
+		err := m.NotifyExecutionStatus(ctx, change)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"conn-gone"}, deleted)
+		require.Len(t, sent, 2)
+		var message api.WebSocketMessage
+		require.NoError(t, json.Unmarshal(sent["conn-1"], &message))
+		assert.Equal(t, api.WebSocketProtocolVersion, message.Version)
+		assert.Equal(t, api.WebSocketMessageTypeStatus, message.Type)
+		assert.Equal(t, change, message.Status)
+	})
+
+	t.Run("returns the errors of failed sends", func(t *testing.T) {
+		client := &mockAPIGatewayClient{
+			postToConnectionFunc: func(
+				context.Context,
+				*apigatewaymanagementapi.PostToConnectionInput,
+				...func(*apigatewaymanagementapi.Options),
+			) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+				return nil, errors.New("throttled")
+			},
+		}
+		connRepo := &mockConnectionRepoForWS{
+			getConnectionsByExecutionIDFunc: func(context.Context, string) ([]*api.WebSocketConnection, error) {
+				return []*api.WebSocketConnection{{ConnectionID: "conn-1"}}, nil
+			},
+		}
+		m := &Manager{connRepo: connRepo, apiGwClient: client, logger: testutil.SilentLogger()}
+
+		err := m.NotifyExecutionStatus(ctx, change)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "throttled")
+	})
+
+	t.Run("does nothing without connections", func(t *testing.T) {
+		connRepo := &mockConnectionRepoForWS{
+			getConnectionsByExecutionIDFunc: func(context.Context, string) ([]*api.WebSocketConnection, error) {
+				return nil, nil
+			},
+		}
+		m := &Manager{connRepo: connRepo, apiGwClient: &mockAPIGatewayClient{}, logger: testutil.SilentLogger()}
+
+		assert.NoError(t, m.NotifyExecutionStatus(ctx, change))
+	})
+
+	t.Run("rejects changes without execution ID", func(t *testing.T) {
+		m := &Manager{logger: testutil.SilentLogger()}
+
+		assert.Error(t, m.NotifyExecutionStatus(ctx, &api.ExecutionStatusChange{Status: "RUNNING"}))
+	})
 }
 
 func TestNotifyExecutionCompletion(t *testing.T) {
@@ -747,16 +1200,36 @@ func TestNotifyExecutionCompletion(t *testing.T) {
 			},
 		}
 
+		var revokedExecutionID string
+		mockTokenRepo := &mockTokenRepoForWS{
+			deleteTokensByExecutionIDFunc: func(_ context.Context, execID string) (int, error) {
+				revokedExecutionID = execID
+				return 1, nil
+			},
+		}
+
+		metrics := &recordingMetricsRecorder{}
 		m := &Manager{
 			connRepo:    mockConnRepo,
+			tokenRepo:   mockTokenRepo,
 			apiGwClient: mockClient,
 			logger:      testutil.SilentLogger(),
+			metrics:     metrics,
 		}
 
 		err := m.NotifyExecutionCompletion(ctx, &executionID)
 
 		assert.NoError(t, err)
 		assert.Len(t, sentMessages, 2)
+		assert.Equal(t, executionID, revokedExecutionID)
+		require.Len(t, metrics.metrics, 2)
+		assert.Equal(t, contract.Metric{
+			Name:       constants.MetricWebSocketFanOut,
+			Value:      2,
+			Unit:       contract.MetricUnitCount,
+			Dimensions: map[string]string{constants.MetricDimensionMessage: "disconnect"},
+		}, metrics.metrics[0])
+		assert.Equal(t, constants.MetricWebSocketFanOutLatency, metrics.metrics[1].Name)
 
 		var disconnectMsg api.WebSocketMessage
 		err = json.Unmarshal([]byte(sentMessages[0]), &disconnectMsg)
@@ -792,8 +1265,31 @@ func TestNotifyExecutionCompletion(t *testing.T) {
 		}
 
 		m := &Manager{
-			connRepo: mockConnRepo,
-			logger:   testutil.SilentLogger(),
+			connRepo:  mockConnRepo,
+			tokenRepo: &mockTokenRepoForWS{},
+			logger:    testutil.SilentLogger(),
+		}
+
+		err := m.NotifyExecutionCompletion(ctx, &executionID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("does not fail when tokens can't be revoked", func(t *testing.T) {
+		mockConnRepo := &mockConnectionRepoForWS{
+			getConnectionsByExecutionIDFunc: func(_ context.Context, _ string) ([]*api.WebSocketConnection, error) {
+				return []*api.WebSocketConnection{}, nil
+			},
+		}
+		mockTokenRepo := &mockTokenRepoForWS{
+			deleteTokensByExecutionIDFunc: func(_ context.Context, _ string) (int, error) {
+				return 0, errors.New("database error")
+			},
+		}
+
+		m := &Manager{
+			connRepo:  mockConnRepo,
+			tokenRepo: mockTokenRepo,
+			logger:    testutil.SilentLogger(),
 		}
 
 		err := m.NotifyExecutionCompletion(ctx, &executionID)
@@ -906,7 +1402,7 @@ func TestGenerateWebSocketURL(t *testing.T) {
 		assert.NotEmpty(t, createdToken.Token)
 	})
 
-	t.Run("handles nil user email and client IP", func(t *testing.T) {
+	t.Run("handles nil client IP", func(t *testing.T) {
 		mockTokenRepo := &mockTokenRepoForWS{
 			createTokenFunc: func(_ context.Context, _ *api.WebSocketToken) error {
 				return nil
@@ -919,12 +1415,33 @@ func TestGenerateWebSocketURL(t *testing.T) {
 			logger:        testutil.SilentLogger(),
 		}
 
-		url := m.GenerateWebSocketURL(ctx, executionID, nil, nil)
+		url := m.GenerateWebSocketURL(ctx, executionID, &userEmail, nil)
 
 		require.NotEmpty(t, url)
 		assert.Contains(t, url, "wss://"+endpoint)
 	})
 
+	t.Run("refuses tokens not bound to a user", func(t *testing.T) {
+		created := false
+		mockTokenRepo := &mockTokenRepoForWS{
+			createTokenFunc: func(_ context.Context, _ *api.WebSocketToken) error {
+				created = true
+				return nil
+			},
+		}
+
+		m := &Manager{
+			tokenRepo:     mockTokenRepo,
+			apiGwEndpoint: &endpoint,
+			logger:        testutil.SilentLogger(),
+		}
+
+		url := m.GenerateWebSocketURL(ctx, executionID, nil, nil)
+
+		assert.Empty(t, url)
+		assert.False(t, created)
+	})
+
 	t.Run("handles token creation error", func(t *testing.T) {
 		mockTokenRepo := &mockTokenRepoForWS{
 			createTokenFunc: func(_ context.Context, _ *api.WebSocketToken) error {
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// CommandPolicyRepository is an in-memory database.CommandPolicyRepository.
+type CommandPolicyRepository struct {
+	mu    sync.Mutex
+	rules map[string]api.CommandPolicyRule
+}
+
+// NewCommandPolicyRepository creates an empty CommandPolicyRepository.
+func NewCommandPolicyRepository() *CommandPolicyRepository {
+	return &CommandPolicyRepository{rules: make(map[string]api.CommandPolicyRule)}
+}
+
+// PutCommandPolicyRule creates or replaces the rule.
+func (r *CommandPolicyRepository) PutCommandPolicyRule(_ context.Context, rule *api.CommandPolicyRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.Name] = *rule
+	return nil
+}
+
+// GetCommandPolicyRule returns the rule, or nil if it doesn't exist.
+func (r *CommandPolicyRepository) GetCommandPolicyRule(_ context.Context, name string) (*api.CommandPolicyRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules[name]
+	if !ok {
+		return nil, nil
+	}
+	return &rule, nil
+}
+
+// ListCommandPolicyRules returns all rules, sorted by name.
+func (r *CommandPolicyRepository) ListCommandPolicyRules(context.Context) ([]api.CommandPolicyRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rules := make([]api.CommandPolicyRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	slices.SortFunc(rules, func(a, b api.CommandPolicyRule) int { return cmp.Compare(a.Name, b.Name) })
+	return rules, nil
+}
+
+// DeleteCommandPolicyRule removes the rule.
+func (r *CommandPolicyRepository) DeleteCommandPolicyRule(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[name]; !ok {
+		return apperrors.ErrNotFound("command policy rule not found", fmt.Errorf("rule %s not found", name))
+	}
+	delete(r.rules, name)
+	return nil
+}
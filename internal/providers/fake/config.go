@@ -0,0 +1,101 @@
+package fake
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// ConfigRepository is an in-memory database.ConfigRepository.
+type ConfigRepository struct {
+	mu            sync.Mutex
+	freeze        *api.RunFreeze
+	announcement  *api.Announcement
+	budgets       map[string]api.Budget
+	notifications api.NotificationSettings
+}
+
+// NewConfigRepository creates an empty ConfigRepository.
+func NewConfigRepository() *ConfigRepository {
+	return &ConfigRepository{}
+}
+
+// GetRunFreeze returns the run freeze switch, or nil if it was never set.
+func (r *ConfigRepository) GetRunFreeze(context.Context) (*api.RunFreeze, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.freeze == nil {
+		return nil, nil
+	}
+	freeze := *r.freeze
+	return &freeze, nil
+}
+
+// PutRunFreeze stores the run freeze switch.
+func (r *ConfigRepository) PutRunFreeze(_ context.Context, freeze *api.RunFreeze) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *freeze
+	r.freeze = &stored
+	return nil
+}
+
+// GetAnnouncement returns the announcement, or nil if it was never set.
+func (r *ConfigRepository) GetAnnouncement(context.Context) (*api.Announcement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.announcement == nil {
+		return nil, nil
+	}
+	announcement := *r.announcement
+	return &announcement, nil
+}
+
+// PutAnnouncement stores the announcement.
+func (r *ConfigRepository) PutAnnouncement(_ context.Context, announcement *api.Announcement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *announcement
+	r.announcement = &stored
+	return nil
+}
+
+// GetBudgets returns the budgets by user email.
+func (r *ConfigRepository) GetBudgets(context.Context) (map[string]api.Budget, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	budgets := maps.Clone(r.budgets)
+	if budgets == nil {
+		budgets = map[string]api.Budget{}
+	}
+	return budgets, nil
+}
+
+// GetNotificationSettings returns the notification settings, the zero settings if none were set.
+func (r *ConfigRepository) GetNotificationSettings(context.Context) (*api.NotificationSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	settings := r.notifications
+	settings.UsageReport.Recipients = slices.Clone(settings.UsageReport.Recipients)
+	return &settings, nil
+}
+
+// PutNotificationSettings stores the notification settings.
+func (r *ConfigRepository) PutNotificationSettings(_ context.Context, settings *api.NotificationSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = *settings
+	r.notifications.UsageReport.Recipients = slices.Clone(settings.UsageReport.Recipients)
+	return nil
+}
+
+// PutBudgets stores the budgets by user email.
+func (r *ConfigRepository) PutBudgets(_ context.Context, budgets map[string]api.Budget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budgets = maps.Clone(budgets)
+	return nil
+}
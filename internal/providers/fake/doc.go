@@ -0,0 +1,8 @@
+// Package fake implements the backend contracts in memory, for local development, demos and
+// end-to-end tests: the repositories are maps, commands run in a tiny built-in interpreter or
+// as local processes, and the log streams are served by a WebSocket server on the loopback
+// interface. Nothing is persisted, everything is lost when the process exits.
+//
+// The orchestrator and the event processor only select it with the FAKE backend provider in
+// builds with the fake tag, so it never ships in the deployed services.
+package fake
@@ -0,0 +1,132 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/providers/aws/database/dynamodb"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Tables of DynamoDB Local created by the backend, with the schemas of the backend stack.
+const (
+	dynamoDBRegion               = "us-east-1"
+	dynamoDBAPIKeysTable         = constants.ProjectName + "-dev-api-keys"
+	dynamoDBPendingAPIKeysTable  = constants.ProjectName + "-dev-pending-api-keys"
+	dynamoDBConfigTable          = constants.ProjectName + "-dev-config"
+	dynamoDBUserEmailIndex       = "user_email-index"
+	dynamoDBAllUserEmailIndex    = "all-user_email"
+	dynamoDBAPIKeyHashAttribute  = "api_key_hash"
+	dynamoDBUserEmailAttribute   = "user_email"
+	dynamoDBSecretTokenAttribute = "secret_token"
+	dynamoDBConfigKeyAttribute   = "key"
+)
+
+// dynamoDBRepositories are the repositories kept in DynamoDB Local instead of memory when
+// DYNAMODB_ENDPOINT is set, to exercise the DynamoDB implementations without an AWS account.
+// Executions, logs and secrets stay in memory, the fake task manager driving them.
+type dynamoDBRepositories struct {
+	users  database.UserRepository
+	config database.ConfigRepository
+}
+
+// newDynamoDBRepositories creates the tables missing from the DynamoDB Local instance at DYNAMODB_ENDPOINT
+// and returns the repositories using them, or nil when the variable isn't set.
+func newDynamoDBRepositories(ctx context.Context, log *slog.Logger) (*dynamoDBRepositories, error) {
+	endpoint := strings.TrimSpace(os.Getenv(awsConstants.DynamoDBEndpointEnvVar))
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	// DynamoDB Local accepts any credentials
+	client := dynamodb.NewSDKClient(aws.Config{
+		Region: dynamoDBRegion,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "local", SecretAccessKey: "local"}, nil
+		}),
+	})
+	for _, table := range dynamoDBTables() {
+		if err := createTableIfMissing(ctx, client, table); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("the fake backend keeps the users and the settings in DynamoDB Local", "endpoint", endpoint)
+
+	adapter := dynamodb.NewClientAdapter(client)
+	return &dynamoDBRepositories{
+		users:  dynamodb.NewUserRepository(adapter, dynamoDBAPIKeysTable, dynamoDBPendingAPIKeysTable, log),
+		config: dynamodb.NewConfigRepository(adapter, dynamoDBConfigTable, log),
+	}, nil
+}
+
+// createTableIfMissing creates the table, keeping it when it already exists so that an instance
+// persisting its data keeps the users and the settings across restarts.
+func createTableIfMissing(ctx context.Context, client *awsdynamodb.Client, table *awsdynamodb.CreateTableInput) error {
+	_, err := client.CreateTable(ctx, table)
+	var inUse *types.ResourceInUseException
+	if err != nil && !errors.As(err, &inUse) {
+		return fmt.Errorf("failed to create the %s table in DynamoDB Local: %w", aws.ToString(table.TableName), err)
+	}
+	return nil
+}
+
+// dynamoDBTables returns the tables of the repositories kept in DynamoDB Local.
+func dynamoDBTables() []*awsdynamodb.CreateTableInput {
+	stringAttribute := func(name string) types.AttributeDefinition {
+		return types.AttributeDefinition{AttributeName: aws.String(name), AttributeType: types.ScalarAttributeTypeS}
+	}
+	keyElement := func(name string, keyType types.KeyType) types.KeySchemaElement {
+		return types.KeySchemaElement{AttributeName: aws.String(name), KeyType: keyType}
+	}
+	allProjection := &types.Projection{ProjectionType: types.ProjectionTypeAll}
+
+	return []*awsdynamodb.CreateTableInput{
+		{
+			TableName:   aws.String(dynamoDBAPIKeysTable),
+			BillingMode: types.BillingModePayPerRequest,
+			AttributeDefinitions: []types.AttributeDefinition{
+				stringAttribute(dynamoDBAPIKeyHashAttribute),
+				stringAttribute(dynamoDBUserEmailAttribute),
+				stringAttribute(awsConstants.DynamoDBAllAttribute),
+			},
+			KeySchema: []types.KeySchemaElement{keyElement(dynamoDBAPIKeyHashAttribute, types.KeyTypeHash)},
+			GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+				{
+					IndexName:  aws.String(dynamoDBUserEmailIndex),
+					KeySchema:  []types.KeySchemaElement{keyElement(dynamoDBUserEmailAttribute, types.KeyTypeHash)},
+					Projection: allProjection,
+				},
+				{
+					IndexName: aws.String(dynamoDBAllUserEmailIndex),
+					KeySchema: []types.KeySchemaElement{
+						keyElement(awsConstants.DynamoDBAllAttribute, types.KeyTypeHash),
+						keyElement(dynamoDBUserEmailAttribute, types.KeyTypeRange),
+					},
+					Projection: allProjection,
+				},
+			},
+		},
+		{
+			TableName:            aws.String(dynamoDBPendingAPIKeysTable),
+			BillingMode:          types.BillingModePayPerRequest,
+			AttributeDefinitions: []types.AttributeDefinition{stringAttribute(dynamoDBSecretTokenAttribute)},
+			KeySchema:            []types.KeySchemaElement{keyElement(dynamoDBSecretTokenAttribute, types.KeyTypeHash)},
+		},
+		{
+			TableName:            aws.String(dynamoDBConfigTable),
+			BillingMode:          types.BillingModePayPerRequest,
+			AttributeDefinitions: []types.AttributeDefinition{stringAttribute(dynamoDBConfigKeyAttribute)},
+			KeySchema:            []types.KeySchemaElement{keyElement(dynamoDBConfigKeyAttribute, types.KeyTypeHash)},
+		},
+	}
+}
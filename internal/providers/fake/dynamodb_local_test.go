@@ -0,0 +1,50 @@
+//go:build integration
+
+package fake
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialize_DynamoDBLocal(t *testing.T) {
+	if os.Getenv(awsConstants.DynamoDBEndpointEnvVar) == "" {
+		t.Skipf("%s not set, DynamoDB Local isn't running", awsConstants.DynamoDBEndpointEnvVar)
+	}
+	ctx := context.Background()
+
+	t.Setenv(APIKeyEnvVar, "first-key")
+	first, err := Initialize(ctx, testutil.SilentLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = first.Close() })
+	require.NoError(t, first.Repositories().Config.PutAnnouncement(ctx, &api.Announcement{Message: "kept"}))
+
+	// A restart finds the tables and the admin of the previous run, which gets the new API key
+	t.Setenv(APIKeyEnvVar, "second-key")
+	second, err := Initialize(ctx, testutil.SilentLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = second.Close() })
+	repos := second.Repositories()
+
+	admin, err := repos.User.GetUserByAPIKeyHash(ctx, auth.HashAPIKey("second-key"))
+	require.NoError(t, err)
+	require.NotNil(t, admin)
+	assert.Equal(t, AdminEmail, admin.Email)
+	previous, err := repos.User.GetUserByAPIKeyHash(ctx, auth.HashAPIKey("first-key"))
+	require.NoError(t, err)
+	assert.Nil(t, previous)
+
+	announcement, err := repos.Config.GetAnnouncement(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, announcement)
+	assert.Equal(t, "kept", announcement.Message)
+}
@@ -0,0 +1,268 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// ExecutionRepository is an in-memory database.ExecutionRepository.
+type ExecutionRepository struct {
+	mu         sync.Mutex
+	executions map[string]*api.Execution
+	events     map[string][]api.ExecutionEvent
+	// logsDeleted holds the executions whose logs expiry was cleared.
+	logsDeleted map[string]bool
+}
+
+// NewExecutionRepository creates an empty ExecutionRepository.
+func NewExecutionRepository() *ExecutionRepository {
+	return &ExecutionRepository{
+		executions:  make(map[string]*api.Execution),
+		events:      make(map[string][]api.ExecutionEvent),
+		logsDeleted: make(map[string]bool),
+	}
+}
+
+// CreateExecution stores a new execution.
+func (r *ExecutionRepository) CreateExecution(_ context.Context, execution *api.Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.executions[execution.ExecutionID]; ok {
+		return fmt.Errorf("execution %s already exists", execution.ExecutionID)
+	}
+	r.executions[execution.ExecutionID] = copyOf(execution)
+	return nil
+}
+
+// CreateExecutions stores new executions, none of them if one already exists.
+func (r *ExecutionRepository) CreateExecutions(_ context.Context, executions []*api.Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, execution := range executions {
+		if _, ok := r.executions[execution.ExecutionID]; ok {
+			return fmt.Errorf("execution %s already exists", execution.ExecutionID)
+		}
+	}
+	for _, execution := range executions {
+		r.executions[execution.ExecutionID] = copyOf(execution)
+	}
+	return nil
+}
+
+// GetExecution returns the execution, nil if there is none.
+func (r *ExecutionRepository) GetExecution(_ context.Context, executionID string) (*api.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyOf(r.executions[executionID]), nil
+}
+
+// UpdateExecution replaces a stored execution, unless it was updated since it was read.
+func (r *ExecutionRepository) UpdateExecution(_ context.Context, execution *api.Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.executions[execution.ExecutionID]
+	if !ok {
+		return fmt.Errorf("execution %s not found", execution.ExecutionID)
+	}
+	if stored.Version != execution.Version {
+		return apperrors.ErrConcurrentModification("execution was modified concurrently", nil)
+	}
+	from, to := constants.ExecutionStatus(stored.Status), constants.ExecutionStatus(execution.Status)
+	if from != to && !constants.CanTransition(from, to) {
+		return apperrors.ErrConflict(fmt.Sprintf("execution status cannot change from %s to %s", from, to), nil)
+	}
+	execution.Version++
+	r.executions[execution.ExecutionID] = copyOf(execution)
+	return nil
+}
+
+// update applies fn to the stored execution atomically, it is how the TaskManager records
+// status changes without racing with the orchestrator. Like the processor updates, it increments
+// the version of the execution.
+func (r *ExecutionRepository) update(executionID string, fn func(*api.Execution)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if execution, ok := r.executions[executionID]; ok {
+		fn(execution)
+		execution.Version++
+	}
+}
+
+// ListExecutions returns the executions with one of the statuses, any when none is given,
+// newest first and at most limit of them when limit is positive.
+func (r *ExecutionRepository) ListExecutions(
+	_ context.Context,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	executions := make([]*api.Execution, 0, len(r.executions))
+	for _, execution := range r.executions {
+		if len(statuses) == 0 || slices.Contains(statuses, execution.Status) {
+			executions = append(executions, copyOf(execution))
+		}
+	}
+	slices.SortFunc(executions, func(a, b *api.Execution) int { return b.StartedAt.Compare(a.StartedAt) })
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// ListExecutionsByCreator returns the executions of ListExecutions created by the user.
+func (r *ExecutionRepository) ListExecutionsByCreator(
+	ctx context.Context,
+	createdBy string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	executions, _ := r.ListExecutions(ctx, 0, statuses)
+	executions = slices.DeleteFunc(executions, func(e *api.Execution) bool { return e.CreatedBy != createdBy })
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// SearchExecutions returns the executions of ListExecutions matching all the search terms.
+func (r *ExecutionRepository) SearchExecutions(
+	ctx context.Context,
+	terms []string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	executions, _ := r.ListExecutions(ctx, 0, statuses)
+	executions = slices.DeleteFunc(executions, func(e *api.Execution) bool {
+		return !database.MatchesExecutionSearch(e, terms)
+	})
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// GetExecutionsByRequestID returns the executions created or modified by the request.
+func (r *ExecutionRepository) GetExecutionsByRequestID(
+	ctx context.Context,
+	requestID string,
+) ([]*api.Execution, error) {
+	executions, _ := r.ListExecutions(ctx, 0, nil)
+	return slices.DeleteFunc(executions, func(e *api.Execution) bool {
+		return e.CreatedByRequestID != requestID && e.ModifiedByRequestID != requestID
+	}), nil
+}
+
+// GetExecutionsByGroupID returns the executions of the group.
+func (r *ExecutionRepository) GetExecutionsByGroupID(ctx context.Context, groupID string) ([]*api.Execution, error) {
+	executions, _ := r.ListExecutions(ctx, 0, nil)
+	return slices.DeleteFunc(executions, func(e *api.Execution) bool { return e.GroupID != groupID }), nil
+}
+
+// ListExecutionsWithExpiredLogs returns the completed executions whose log retention expired before the time.
+func (r *ExecutionRepository) ListExecutionsWithExpiredLogs(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]*api.Execution, error) {
+	executions, _ := r.ListExecutions(ctx, 0, nil)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	executions = slices.DeleteFunc(executions, func(e *api.Execution) bool {
+		return e.LogRetentionDays <= 0 || e.CompletedAt == nil || r.logsDeleted[e.ExecutionID] ||
+			!e.CompletedAt.AddDate(0, 0, e.LogRetentionDays).Before(before)
+	})
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// ClearExecutionLogsExpiry leaves the execution out of ListExecutionsWithExpiredLogs.
+func (r *ExecutionRepository) ClearExecutionLogsExpiry(_ context.Context, executionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.executions[executionID]; !ok {
+		return apperrors.ErrNotFound("execution not found", nil)
+	}
+	r.logsDeleted[executionID] = true
+	return nil
+}
+
+// AddLogUsage adds the usage to the log usage of the execution.
+func (r *ExecutionRepository) AddLogUsage(_ context.Context, executionID string, usage *api.LogUsage) error {
+	r.update(executionID, func(execution *api.Execution) {
+		if execution.LogUsage == nil {
+			execution.LogUsage = &api.LogUsage{}
+		}
+		execution.LogUsage.Bytes += usage.Bytes
+		execution.LogUsage.DroppedLines += usage.DroppedLines
+		execution.LogUsage.DroppedBytes += usage.DroppedBytes
+	})
+	return nil
+}
+
+// MarkExecutionSLOBreached flags the execution as breaching its duration SLO.
+func (r *ExecutionRepository) MarkExecutionSLOBreached(_ context.Context, executionID string) error {
+	r.update(executionID, func(execution *api.Execution) {
+		execution.SLOBreached = true
+	})
+	return nil
+}
+
+// SetExecutionResult stores the JSON result reported by the execution.
+func (r *ExecutionRepository) SetExecutionResult(_ context.Context, executionID string, result json.RawMessage) error {
+	r.update(executionID, func(execution *api.Execution) {
+		execution.Result = result
+	})
+	return nil
+}
+
+// AddExecutionEvents records the lifecycle events of the execution, once per type.
+func (r *ExecutionRepository) AddExecutionEvents(
+	_ context.Context,
+	executionID string,
+	events []api.ExecutionEvent,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range events {
+		if !slices.ContainsFunc(r.events[executionID], func(e api.ExecutionEvent) bool { return e.Type == event.Type }) {
+			r.events[executionID] = append(r.events[executionID], event)
+		}
+	}
+	return nil
+}
+
+// ListExecutionEvents returns the lifecycle events of the execution in the order they were recorded.
+func (r *ExecutionRepository) ListExecutionEvents(_ context.Context, executionID string) ([]api.ExecutionEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.events[executionID]), nil
+}
+
+// AddExecutionAnnotation appends the note to the annotations of the execution.
+func (r *ExecutionRepository) AddExecutionAnnotation(
+	_ context.Context,
+	executionID string,
+	annotation *api.ExecutionAnnotation,
+) error {
+	found := false
+	r.update(executionID, func(execution *api.Execution) {
+		found = true
+		execution.Annotations = append(slices.Clip(execution.Annotations), *annotation)
+	})
+	if !found {
+		return fmt.Errorf("execution %s not found", executionID)
+	}
+	return nil
+}
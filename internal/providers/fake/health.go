@@ -0,0 +1,54 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// HealthManager is a contract.HealthManager, there is never anything to reconcile or clean up in memory.
+type HealthManager struct{}
+
+// Reconcile returns an empty report.
+func (HealthManager) Reconcile(context.Context) (*api.HealthReport, error) {
+	return &api.HealthReport{Timestamp: time.Now().UTC()}, nil
+}
+
+// Cleanup returns an empty report.
+func (HealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{Timestamp: time.Now().UTC(), DryRun: dryRun, Resources: []api.CleanedResource{}}, nil
+}
+
+// HealthReportRepository is an in-memory database.HealthReportRepository.
+type HealthReportRepository struct {
+	mu      sync.Mutex
+	reports []api.HealthReport
+}
+
+// NewHealthReportRepository creates an empty HealthReportRepository.
+func NewHealthReportRepository() *HealthReportRepository {
+	return &HealthReportRepository{}
+}
+
+// CreateHealthReport stores the report.
+func (r *HealthReportRepository) CreateHealthReport(_ context.Context, report *api.HealthReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+// ListHealthReports returns up to limit stored reports, most recent first.
+func (r *HealthReportRepository) ListHealthReports(_ context.Context, limit int) ([]api.HealthReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reports := slices.Clone(r.reports)
+	slices.Reverse(reports)
+	if limit > 0 && len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
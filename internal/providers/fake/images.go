@@ -0,0 +1,203 @@
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+const (
+	defaultCPU             = 256
+	defaultMemory          = 512
+	defaultRuntimePlatform = "Linux/ARM64"
+	imageIDHashBytes       = 4
+)
+
+// ImageRegistry is an in-memory contract.ImageRegistry, it is also the database.ImageRepository.
+// The first image registered becomes the default one.
+type ImageRegistry struct {
+	mu      sync.Mutex
+	images  []api.ImageInfo
+	aliases map[string]api.ImageAlias
+}
+
+// NewImageRegistry creates an empty ImageRegistry.
+func NewImageRegistry() *ImageRegistry {
+	return &ImageRegistry{}
+}
+
+// RegisterImage registers the image, or replaces its configuration when it is already registered.
+func (r *ImageRegistry) RegisterImage(
+	_ context.Context,
+	image string,
+	isDefault *bool,
+	taskRoleName, taskExecutionRoleName *string,
+	cpu, memory *int,
+	runtimePlatform *string,
+	logLimits *api.LogLimits,
+	warmPoolSize *int,
+	runDefaults *api.RunDefaults,
+	createdBy string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sum := sha256.Sum256([]byte(image))
+	info := api.ImageInfo{
+		ImageID:               image + "-" + hex.EncodeToString(sum[:imageIDHashBytes]),
+		Image:                 image,
+		TaskRoleName:          taskRoleName,
+		TaskExecutionRoleName: taskExecutionRoleName,
+		CPU:                   valueOr(cpu, defaultCPU),
+		Memory:                valueOr(memory, defaultMemory),
+		RuntimePlatform:       valueOr(runtimePlatform, defaultRuntimePlatform),
+		LogLimits:             logLimits,
+		WarmPoolSize:          valueOr(warmPoolSize, 0),
+		RunDefaults:           runDefaults,
+		CreatedBy:             createdBy,
+		OwnedBy:               []string{createdBy},
+		CreatedAt:             time.Now().UTC(),
+	}
+	if (isDefault != nil && *isDefault) || len(r.images) == 0 {
+		for i := range r.images {
+			r.images[i].IsDefault = nil
+		}
+		info.IsDefault = new(bool)
+		*info.IsDefault = true
+	}
+	r.images = slices.DeleteFunc(r.images, func(i api.ImageInfo) bool { return i.ImageID == info.ImageID })
+	r.images = append(r.images, info)
+	return nil
+}
+
+// ListImages returns the registered images in the order they were registered.
+func (r *ImageRegistry) ListImages(context.Context) ([]api.ImageInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.images), nil
+}
+
+// GetImage returns the image by ID or name, the default image when image is empty, nil if there is none.
+func (r *ImageRegistry) GetImage(_ context.Context, image string) (*api.ImageInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.images {
+		info := r.images[i]
+		if (image == "" && info.IsDefault != nil) || info.ImageID == image || info.Image == image {
+			return &info, nil
+		}
+	}
+	return nil, nil
+}
+
+// RemoveImage unregisters the image by ID or name.
+func (r *ImageRegistry) RemoveImage(_ context.Context, image string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before := len(r.images)
+	r.images = slices.DeleteFunc(r.images, func(i api.ImageInfo) bool { return i.ImageID == image || i.Image == image })
+	if len(r.images) == before {
+		return fmt.Errorf("image %s not found", image)
+	}
+	return nil
+}
+
+// SoftDeleteImage marks the image by ID or name as deleted.
+func (r *ImageRegistry) SoftDeleteImage(_ context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info := r.find(image)
+	if info == nil {
+		return nil, apperrors.ErrNotFound("image not found", fmt.Errorf("image %s not found", image))
+	}
+	if info.DeletedAt != nil {
+		return nil, apperrors.ErrConflict(fmt.Sprintf("image %q is already deleted", image), nil)
+	}
+	now := time.Now().UTC()
+	info.DeletedAt, info.DeletedBy, info.IsDefault = &now, deletedBy, nil
+	deleted := *info
+	return &deleted, nil
+}
+
+// RestoreImage clears the deletion mark of the image by ID or name.
+func (r *ImageRegistry) RestoreImage(_ context.Context, image string) (*api.ImageInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info := r.find(image)
+	if info == nil {
+		return nil, apperrors.ErrNotFound("image not found", fmt.Errorf("image %s not found", image))
+	}
+	if info.DeletedAt == nil {
+		return nil, apperrors.ErrConflict(fmt.Sprintf("image %q is not deleted", image), nil)
+	}
+	info.DeletedAt, info.DeletedBy = nil, ""
+	restored := *info
+	return &restored, nil
+}
+
+func (r *ImageRegistry) find(image string) *api.ImageInfo {
+	for i := range r.images {
+		if r.images[i].ImageID == image || r.images[i].Image == image {
+			return &r.images[i]
+		}
+	}
+	return nil
+}
+
+// SetImageAlias points the alias at its image ID, returning the image ID it pointed at before.
+func (r *ImageRegistry) SetImageAlias(_ context.Context, alias *api.ImageAlias) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.aliases == nil {
+		r.aliases = make(map[string]api.ImageAlias)
+	}
+	previous := r.aliases[alias.Alias].ImageID
+	r.aliases[alias.Alias] = *alias
+	return previous, nil
+}
+
+// GetImageAlias returns the alias, or nil when it doesn't exist.
+func (r *ImageRegistry) GetImageAlias(_ context.Context, alias string) (*api.ImageAlias, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, ok := r.aliases[alias]
+	if !ok {
+		return nil, nil
+	}
+	return &found, nil
+}
+
+// ListImageAliases returns the aliases sorted by alias.
+func (r *ImageRegistry) ListImageAliases(context.Context) ([]api.ImageAlias, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aliases := make([]api.ImageAlias, 0, len(r.aliases))
+	for _, alias := range r.aliases {
+		aliases = append(aliases, alias)
+	}
+	slices.SortFunc(aliases, func(a, b api.ImageAlias) int { return strings.Compare(a.Alias, b.Alias) })
+	return aliases, nil
+}
+
+// RemoveImageAlias removes the alias.
+func (r *ImageRegistry) RemoveImageAlias(_ context.Context, alias string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.aliases[alias]; !ok {
+		return apperrors.ErrNotFound("image alias not found", fmt.Errorf("image alias %s not found", alias))
+	}
+	delete(r.aliases, alias)
+	return nil
+}
+
+// GetImagesByRequestID returns no image, the fake registry does not track requests.
+func (r *ImageRegistry) GetImagesByRequestID(context.Context, string) ([]api.ImageInfo, error) {
+	return []api.ImageInfo{}, nil
+}
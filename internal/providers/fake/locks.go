@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// LockRepository is an in-memory database.LockRepository.
+type LockRepository struct {
+	mu    sync.Mutex
+	locks map[string]api.ExecutionLock
+}
+
+// NewLockRepository creates an empty LockRepository.
+func NewLockRepository() *LockRepository {
+	return &LockRepository{locks: make(map[string]api.ExecutionLock)}
+}
+
+// AcquireLock stores the lock unless a lock of the same name is held and not expired.
+func (r *LockRepository) AcquireLock(_ context.Context, lock *api.ExecutionLock, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if held, ok := r.locks[lock.Name]; ok && held.ExpiresAt.After(now) {
+		return false, nil
+	}
+	r.locks[lock.Name] = *lock
+	return true, nil
+}
+
+// GetLock returns the lock, or nil if it doesn't exist.
+func (r *LockRepository) GetLock(_ context.Context, name string) (*api.ExecutionLock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.locks[name]
+	if !ok {
+		return nil, nil
+	}
+	return &lock, nil
+}
+
+// ListLocks returns the locks not expired, sorted by name.
+func (r *LockRepository) ListLocks(_ context.Context, now time.Time) ([]api.ExecutionLock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	locks := make([]api.ExecutionLock, 0, len(r.locks))
+	for _, lock := range r.locks {
+		if lock.ExpiresAt.After(now) {
+			locks = append(locks, lock)
+		}
+	}
+	slices.SortFunc(locks, func(a, b api.ExecutionLock) int { return cmp.Compare(a.Name, b.Name) })
+	return locks, nil
+}
+
+// SetLockExecution records the execution holding the lock acquired with token.
+func (r *LockRepository) SetLockExecution(_ context.Context, name, token, executionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.locks[name]
+	if !ok || lock.Token != token {
+		return apperrors.ErrNotFound("lock not found", fmt.Errorf("lock %s not held with the token", name))
+	}
+	lock.ExecutionID = executionID
+	r.locks[name] = lock
+	return nil
+}
+
+// ReleaseLock removes the lock held with holder, or any lock of that name when holder is empty.
+func (r *LockRepository) ReleaseLock(_ context.Context, name, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.locks[name]
+	if !ok || (holder != "" && holder != lock.Token && holder != lock.ExecutionID) {
+		return apperrors.ErrNotFound("lock not found", fmt.Errorf("lock %s not held by %q", name, holder))
+	}
+	delete(r.locks, name)
+	return nil
+}
@@ -0,0 +1,60 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+)
+
+// LogStore is a contract.LogManager keeping the log lines of the executions in memory.
+type LogStore struct {
+	mu     sync.Mutex
+	events map[string][]api.LogEvent
+}
+
+// NewLogStore creates an empty LogStore.
+func NewLogStore() *LogStore {
+	return &LogStore{events: make(map[string][]api.LogEvent)}
+}
+
+// Append adds a log line to the execution.
+func (s *LogStore) Append(executionID, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timestamp := time.Now().UnixMilli()
+	s.events[executionID] = append(s.events[executionID], api.LogEvent{
+		EventID:   auth.GenerateEventID(timestamp, message+strconv.Itoa(len(s.events[executionID]))),
+		Timestamp: timestamp,
+		Message:   message,
+	})
+}
+
+// FetchLogsByExecutionID returns the log lines of the execution.
+func (s *LogStore) FetchLogsByExecutionID(_ context.Context, executionID string) ([]api.LogEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events[executionID]), nil
+}
+
+// ObservabilityManager is a contract.ObservabilityManager and contract.MetricsRecorder without backend
+// logs, resource usage nor metrics.
+type ObservabilityManager struct{}
+
+// FetchBackendLogs returns no log.
+func (ObservabilityManager) FetchBackendLogs(context.Context, string) ([]api.LogEvent, error) {
+	return []api.LogEvent{}, nil
+}
+
+// FetchResourceUsage returns no usage, local commands are not sampled.
+func (ObservabilityManager) FetchResourceUsage(context.Context, []string) (map[string]*api.ResourceUsage, error) {
+	return map[string]*api.ResourceUsage{}, nil
+}
+
+// RecordMetrics drops the metrics.
+func (ObservabilityManager) RecordMetrics(context.Context, ...contract.Metric) {}
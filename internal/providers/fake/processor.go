@@ -0,0 +1,20 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Processor is the event processor of the fake backend. It has nothing to process: the TaskManager
+// records the status changes of the executions and the WebSocketManager streams their logs.
+type Processor struct{}
+
+// Handle ignores the event.
+func (Processor) Handle(context.Context, *json.RawMessage) (*json.RawMessage, error) {
+	return nil, nil
+}
+
+// HandleEventJSON ignores the event.
+func (Processor) HandleEventJSON(context.Context, *json.RawMessage) error {
+	return nil
+}
@@ -0,0 +1,212 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/database"
+)
+
+const (
+	// RunnerEnvVar selects how Initialize runs the commands: "script" (default) for the ScriptRunner,
+	// "exec" for the ExecRunner.
+	RunnerEnvVar = "RUNVOY_FAKE_RUNNER"
+	// APIKeyEnvVar sets the API key of the admin created by Initialize, a random one is generated
+	// and logged when it is not set.
+	APIKeyEnvVar = "RUNVOY_FAKE_API_KEY"
+	// WebSocketAddrEnvVar sets the address Initialize serves the log streams on, a random port of the
+	// loopback interface is used when it is not set.
+	WebSocketAddrEnvVar = "RUNVOY_FAKE_WEBSOCKET_ADDR"
+	// AdminEmail is the email of the admin created by Initialize.
+	AdminEmail = "admin@localhost"
+
+	defaultWebSocketAddr = "127.0.0.1:0"
+
+	scriptRunnerName  = "script"
+	execRunnerName    = "exec"
+	readHeaderTimeout = 10 * time.Second
+)
+
+var (
+	_ database.UserRepository          = (*UserRepository)(nil)
+	_ database.ExecutionRepository     = (*ExecutionRepository)(nil)
+	_ database.SecretsRepository       = (*SecretsRepository)(nil)
+	_ database.HealthReportRepository  = (*HealthReportRepository)(nil)
+	_ database.CommandPolicyRepository = (*CommandPolicyRepository)(nil)
+	_ database.ConfigRepository        = (*ConfigRepository)(nil)
+	_ database.LockRepository          = (*LockRepository)(nil)
+	_ database.ImageRepository         = (*ImageRegistry)(nil)
+	_ contract.ImageRegistry           = (*ImageRegistry)(nil)
+	_ contract.TaskManager             = (*TaskManager)(nil)
+	_ contract.LogManager              = (*LogStore)(nil)
+	_ contract.ObservabilityManager    = ObservabilityManager{}
+	_ contract.MetricsRecorder         = ObservabilityManager{}
+	_ contract.WebSocketManager        = (*WebSocketManager)(nil)
+	_ contract.HealthManager           = HealthManager{}
+)
+
+// Provider holds the in-memory backend and the WebSocket server streaming the logs.
+type Provider struct {
+	Users         *UserRepository
+	Executions    *ExecutionRepository
+	Secrets       *SecretsRepository
+	HealthReports *HealthReportRepository
+	CommandPolicy *CommandPolicyRepository
+	Config        *ConfigRepository
+	Locks         *LockRepository
+	Images        *ImageRegistry
+	Logs          *LogStore
+	Tasks         *TaskManager
+	WebSocket     *WebSocketManager
+	Observability ObservabilityManager
+	Health        HealthManager
+
+	server *http.Server
+	// dynamoDB replaces Users and Config when the backend is initialized with DynamoDB Local.
+	dynamoDB *dynamoDBRepositories
+}
+
+// New creates an empty backend running the commands with runner, and starts serving its log streams
+// on a random port of the loopback interface. Close stops serving them.
+func New(runner Runner) (*Provider, error) {
+	return newProvider(runner, defaultWebSocketAddr)
+}
+
+// newProvider creates an empty backend serving its log streams on addr.
+func newProvider(runner Runner, addr string) (*Provider, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for log streams: %w", err)
+	}
+
+	executions := NewExecutionRepository()
+	logs := NewLogStore()
+	p := &Provider{
+		Users:         NewUserRepository(),
+		Executions:    executions,
+		Secrets:       NewSecretsRepository(),
+		HealthReports: NewHealthReportRepository(),
+		CommandPolicy: NewCommandPolicyRepository(),
+		Config:        NewConfigRepository(),
+		Locks:         NewLockRepository(),
+		Images:        NewImageRegistry(),
+		Logs:          logs,
+		Tasks:         NewTaskManager(executions, logs, runner),
+		WebSocket:     NewWebSocketManager(executions, logs),
+	}
+	p.Tasks.locks = p.Locks
+	p.WebSocket.SetBaseURL("http://" + listener.Addr().String())
+	p.server = &http.Server{Handler: p.WebSocket, ReadHeaderTimeout: readHeaderTimeout}
+	go func() { _ = p.server.Serve(listener) }()
+	return p, nil
+}
+
+// Close stops serving the log streams.
+func (p *Provider) Close() error {
+	return p.server.Close()
+}
+
+// Repositories returns the repositories of the backend.
+func (p *Provider) Repositories() database.Repositories {
+	repos := database.Repositories{
+		User:          p.Users,
+		Execution:     p.Executions,
+		Image:         p.Images,
+		Secrets:       p.Secrets,
+		HealthReport:  p.HealthReports,
+		CommandPolicy: p.CommandPolicy,
+		Config:        p.Config,
+		Lock:          p.Locks,
+	}
+	if p.dynamoDB != nil {
+		repos.User = p.dynamoDB.users
+		repos.Config = p.dynamoDB.config
+	}
+	return repos
+}
+
+// CreateAdmin creates an admin user authenticating with the API key. An admin kept from a previous run
+// in DynamoDB Local gets the API key instead.
+func (p *Provider) CreateAdmin(ctx context.Context, email, apiKey string) error {
+	users := p.Repositories().User
+	existing, err := users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return users.ReplaceAPIKeyHash(ctx, email, auth.HashAPIKey(apiKey))
+	}
+	return users.CreateUser(ctx, &api.User{
+		Email:     email,
+		Role:      string(authorization.RoleAdmin),
+		CreatedAt: time.Now().UTC(),
+	}, auth.HashAPIKey(apiKey), 0)
+}
+
+// Initialize creates a backend configured by the RunnerEnvVar, APIKeyEnvVar and WebSocketAddrEnvVar
+// environment variables, with an admin user to start with. When DYNAMODB_ENDPOINT is set, the users and
+// the settings are kept in that DynamoDB Local instance.
+func Initialize(ctx context.Context, log *slog.Logger) (*Provider, error) {
+	var runner Runner
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv(RunnerEnvVar))); name {
+	case "", scriptRunnerName:
+		runner = ScriptRunner
+	case execRunnerName:
+		runner = ExecRunner
+		log.Warn("the fake backend runs the commands as local processes, with the privileges of the server")
+	default:
+		return nil, fmt.Errorf("unknown %s %q (supported: %s, %s)", RunnerEnvVar, name, scriptRunnerName, execRunnerName)
+	}
+
+	apiKey := os.Getenv(APIKeyEnvVar)
+	if apiKey == "" {
+		var err error
+		if apiKey, err = auth.GenerateSecretToken(); err != nil {
+			return nil, fmt.Errorf("failed to generate the admin API key: %w", err)
+		}
+		log.Info("created the admin of the fake backend, set "+APIKeyEnvVar+" to choose its API key",
+			"email", AdminEmail, "api_key", apiKey)
+	}
+
+	addr := strings.TrimSpace(os.Getenv(WebSocketAddrEnvVar))
+	if addr == "" {
+		addr = defaultWebSocketAddr
+	}
+	p, err := newProvider(runner, addr)
+	if err != nil {
+		return nil, err
+	}
+	if p.dynamoDB, err = newDynamoDBRepositories(ctx, log); err != nil {
+		return nil, errors.Join(err, p.Close())
+	}
+	if err = p.CreateAdmin(ctx, AdminEmail, apiKey); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create the admin: %w", err), p.Close())
+	}
+	return p, nil
+}
+
+func copyOf[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	c := *value
+	return &c
+}
+
+func valueOr[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
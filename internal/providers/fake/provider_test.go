@@ -0,0 +1,155 @@
+package fake
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/constants"
+	awsConstants "github.com/runvoy/runvoy/internal/providers/aws/constants"
+	"github.com/runvoy/runvoy/internal/testutil"
+)
+
+func TestInitialize(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(awsConstants.DynamoDBEndpointEnvVar, "")
+
+	t.Run("creates the admin with the API key", func(t *testing.T) {
+		t.Setenv(APIKeyEnvVar, "test-api-key")
+		p, err := Initialize(ctx, testutil.SilentLogger())
+		require.NoError(t, err)
+		defer func() { _ = p.Close() }()
+
+		user, err := p.Users.GetUserByAPIKeyHash(ctx, auth.HashAPIKey("test-api-key"))
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, AdminEmail, user.Email)
+	})
+
+	t.Run("serves the log streams on the address", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		t.Setenv(WebSocketAddrEnvVar, addr)
+		p, err := Initialize(ctx, testutil.SilentLogger())
+		require.NoError(t, err)
+		defer func() { _ = p.Close() }()
+
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		_ = conn.Close()
+	})
+
+	t.Run("replaces the API key of an existing admin", func(t *testing.T) {
+		t.Setenv(APIKeyEnvVar, "test-api-key")
+		p, err := Initialize(ctx, testutil.SilentLogger())
+		require.NoError(t, err)
+		defer func() { _ = p.Close() }()
+
+		require.NoError(t, p.CreateAdmin(ctx, AdminEmail, "new-api-key"))
+		user, err := p.Users.GetUserByAPIKeyHash(ctx, auth.HashAPIKey("new-api-key"))
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		previous, err := p.Users.GetUserByAPIKeyHash(ctx, auth.HashAPIKey("test-api-key"))
+		require.NoError(t, err)
+		assert.Nil(t, previous)
+	})
+
+	t.Run("fails when DynamoDB Local is unreachable", func(t *testing.T) {
+		t.Setenv(awsConstants.DynamoDBEndpointEnvVar, "http://127.0.0.1:1")
+		_, err := Initialize(ctx, testutil.SilentLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "table in DynamoDB Local")
+	})
+
+	t.Run("rejects unknown runners", func(t *testing.T) {
+		t.Setenv(RunnerEnvVar, "docker")
+		_, err := Initialize(ctx, testutil.SilentLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown "+RunnerEnvVar)
+	})
+}
+
+func TestWebSocketManager(t *testing.T) {
+	ctx := context.Background()
+	p, err := New(ScriptRunner)
+	require.NoError(t, err)
+	defer func() { _ = p.Close() }()
+
+	require.NoError(t, p.Executions.CreateExecution(ctx, &api.Execution{
+		ExecutionID: "exec1",
+		Status:      string(constants.ExecutionSucceeded),
+	}))
+	p.Logs.Append("exec1", "line 1")
+	user := "alice@example.com"
+
+	t.Run("streams the logs then disconnects", func(t *testing.T) {
+		conn, resp, err := websocket.DefaultDialer.Dial(p.WebSocket.GenerateWebSocketURL(ctx, "exec1", &user, nil), nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		_ = resp.Body.Close()
+
+		var event api.LogEvent
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, "line 1", event.Message)
+
+		var message api.WebSocketMessage
+		require.NoError(t, conn.ReadJSON(&message))
+		assert.Equal(t, api.WebSocketMessageTypeStatus, message.Type)
+		require.NotNil(t, message.Status)
+		assert.Equal(t, string(constants.ExecutionSucceeded), message.Status.Status)
+
+		require.NoError(t, conn.ReadJSON(&message))
+		assert.Equal(t, api.WebSocketMessageTypeDisconnect, message.Type)
+		assert.Equal(t, api.WebSocketProtocolVersion, message.Version)
+		assert.Equal(t, 1, p.WebSocket.StreamsOpened())
+	})
+
+	t.Run("rejects reused tokens", func(t *testing.T) {
+		url := p.WebSocket.GenerateWebSocketURL(ctx, "exec1", &user, nil)
+		conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		_ = conn.Close()
+		_ = resp.Body.Close()
+
+		_, resp, err = websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("revokes the tokens of completed executions", func(t *testing.T) {
+		url := p.WebSocket.GenerateWebSocketURL(ctx, "exec1", &user, nil)
+		executionID := "exec1"
+		require.NoError(t, p.WebSocket.NotifyExecutionCompletion(ctx, &executionID))
+
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("refuses URLs not bound to a user", func(t *testing.T) {
+		assert.Empty(t, p.WebSocket.GenerateWebSocketURL(ctx, "exec1", nil, nil))
+	})
+
+	t.Run("rejects unknown tokens", func(t *testing.T) {
+		url := p.WebSocket.GenerateWebSocketURL(ctx, "exec1", &user, nil) + "x"
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
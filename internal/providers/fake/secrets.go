@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/database"
+)
+
+// SecretsRepository is an in-memory database.SecretsRepository, it keeps the values in clear.
+type SecretsRepository struct {
+	mu      sync.Mutex
+	secrets map[string]*api.Secret
+}
+
+// NewSecretsRepository creates an empty SecretsRepository.
+func NewSecretsRepository() *SecretsRepository {
+	return &SecretsRepository{secrets: make(map[string]*api.Secret)}
+}
+
+// CreateSecret stores a new secret.
+func (r *SecretsRepository) CreateSecret(_ context.Context, secret *api.Secret) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.secrets[secret.Name]; ok {
+		return fmt.Errorf("secret %s already exists", secret.Name)
+	}
+	stored := *secret
+	stored.CreatedAt = time.Now().UTC()
+	stored.UpdatedAt = stored.CreatedAt
+	r.secrets[secret.Name] = &stored
+	return nil
+}
+
+// GetSecret returns the secret, with its value when includeValue is true.
+func (r *SecretsRepository) GetSecret(_ context.Context, name string, includeValue bool) (*api.Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	secret, ok := r.secrets[name]
+	if !ok {
+		return nil, database.ErrSecretNotFound
+	}
+	return secretView(secret, includeValue), nil
+}
+
+// ListSecrets returns the secrets sorted by name, with their values when includeValue is true.
+func (r *SecretsRepository) ListSecrets(_ context.Context, includeValue bool) ([]*api.Secret, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	secrets := make([]*api.Secret, 0, len(r.secrets))
+	for _, secret := range r.secrets {
+		secrets = append(secrets, secretView(secret, includeValue))
+	}
+	slices.SortFunc(secrets, func(a, b *api.Secret) int { return cmp.Compare(a.Name, b.Name) })
+	return secrets, nil
+}
+
+// UpdateSecret updates the non-empty value, key name and description of the secret.
+func (r *SecretsRepository) UpdateSecret(_ context.Context, secret *api.Secret) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.secrets[secret.Name]
+	if !ok {
+		return database.ErrSecretNotFound
+	}
+	if secret.Value != "" {
+		stored.Value = secret.Value
+	}
+	if secret.KeyName != "" {
+		stored.KeyName = secret.KeyName
+	}
+	if secret.Description != "" {
+		stored.Description = secret.Description
+	}
+	stored.UpdatedBy = secret.UpdatedBy
+	stored.UpdatedAt = time.Now().UTC()
+	stored.ModifiedByRequestID = secret.ModifiedByRequestID
+	stored.Version++
+	return nil
+}
+
+// DeleteSecret deletes the secret.
+func (r *SecretsRepository) DeleteSecret(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.secrets[name]; !ok {
+		return database.ErrSecretNotFound
+	}
+	delete(r.secrets, name)
+	return nil
+}
+
+// GetSecretsByRequestID returns the secrets created or modified by the request, without their values.
+func (r *SecretsRepository) GetSecretsByRequestID(ctx context.Context, requestID string) ([]*api.Secret, error) {
+	secrets, _ := r.ListSecrets(ctx, false)
+	return slices.DeleteFunc(secrets, func(s *api.Secret) bool {
+		return s.CreatedByRequestID != requestID && s.ModifiedByRequestID != requestID
+	}), nil
+}
+
+// RecordSecretUsage records the last execution started with the secret.
+func (r *SecretsRepository) RecordSecretUsage(_ context.Context, name, executionID string, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.secrets[name]
+	if !ok {
+		return database.ErrSecretNotFound
+	}
+	usedAt = usedAt.UTC()
+	stored.LastUsedAt = &usedAt
+	stored.LastUsedByExecutionID = executionID
+	return nil
+}
+
+func secretView(secret *api.Secret, includeValue bool) *api.Secret {
+	view := *secret
+	if !includeValue {
+		view.Value = ""
+	}
+	return &view
+}
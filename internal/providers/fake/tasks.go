@@ -0,0 +1,244 @@
+package fake
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+const (
+	// tickInterval is how often the fake runtime and the log streams look for changes.
+	tickInterval = 20 * time.Millisecond
+	// execWaitDelay is how long a killed local process may keep its output open.
+	execWaitDelay = time.Second
+
+	killedExitCode          = 137
+	commandNotFoundExitCode = 127
+)
+
+// Runner runs the command of an execution with the environment variables, passing each line it prints
+// to output, and returns its exit code. args holds the command as an argument vector when the execution
+// was requested with one, command is then its equivalent shell command line. It stops the command when
+// ctx is canceled, i.e. when the execution is killed.
+type Runner func(ctx context.Context, command string, args []string, env map[string]string,
+	output func(line string)) int
+
+// ScriptRunner interprets the command as canned steps separated by ";", without running anything:
+// "echo <text>" prints the text, with the $VARS of the environment expanded, "exit <code>" ends the
+// command with that exit code and "sleep" blocks until the execution is killed. Other commands print
+// "command not found" and exit with 127. The argument vector is ignored, the command line is interpreted.
+func ScriptRunner(ctx context.Context, command string, _ []string, env map[string]string,
+	output func(string)) int {
+	for step := range strings.SplitSeq(command, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(step), " ")
+		switch name {
+		case "echo":
+			output(os.Expand(arg, func(key string) string { return env[key] }))
+		case "exit":
+			if exitCode, _ := strconv.Atoi(arg); exitCode != 0 {
+				return exitCode
+			}
+		case "sleep":
+			<-ctx.Done()
+			return killedExitCode
+		default:
+			output(name + ": command not found")
+			return commandNotFoundExitCode
+		}
+	}
+	return 0
+}
+
+// ExecRunner runs the command with "sh -c" as a local process, in a temporary working directory, or the
+// argument vector without a shell when set. The process only inherits PATH and HOME from the server
+// environment. The working directory and the shell of the request are not applied.
+//
+// WARNING: the commands run with the privileges of the server, only use it on a trusted machine.
+func ExecRunner(ctx context.Context, command string, args []string, env map[string]string,
+	output func(string)) int {
+	workDir, err := os.MkdirTemp("", constants.ProjectName+"-fake-")
+	if err != nil {
+		output(fmt.Sprintf("failed to create the working directory: %v", err))
+		return 1
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if len(args) > 0 {
+		cmd = exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // G204: the command to run
+	}
+	cmd.Dir = workDir
+	cmd.WaitDelay = execWaitDelay
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + os.Getenv("HOME")}
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	reader, writer := io.Pipe()
+	cmd.Stdout, cmd.Stderr = writer, writer
+	scanned := make(chan struct{})
+	go func() {
+		defer close(scanned)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			output(scanner.Text())
+		}
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	err = cmd.Run()
+	_ = writer.Close()
+	<-scanned
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return killedExitCode
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	case err != nil:
+		output(err.Error())
+		return commandNotFoundExitCode
+	default:
+		return 0
+	}
+}
+
+// TaskManager is a contract.TaskManager running the commands with a Runner instead of containers.
+// It also plays the event processor: it moves the executions it runs to RUNNING, then to their
+// terminal status, and a killed execution to STOPPED once the orchestrator marked it TERMINATING.
+// Completed executions release their lock when locks is set.
+type TaskManager struct {
+	executions *ExecutionRepository
+	logs       *LogStore
+	runner     Runner
+	locks      *LockRepository
+
+	mu     sync.Mutex
+	nextID int
+	cancel map[string]context.CancelFunc
+}
+
+// NewTaskManager creates a TaskManager recording the executions in the repository and their
+// output in the log store.
+func NewTaskManager(executions *ExecutionRepository, logs *LogStore, runner Runner) *TaskManager {
+	return &TaskManager{
+		executions: executions,
+		logs:       logs,
+		runner:     runner,
+		cancel:     make(map[string]context.CancelFunc),
+	}
+}
+
+// StartTask runs the command in the background.
+func (m *TaskManager) StartTask(
+	_ context.Context,
+	_ string,
+	req *api.ExecutionRequest,
+) (string, *time.Time, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.nextID++
+	executionID := fmt.Sprintf("exec%08d", m.nextID)
+	m.cancel[executionID] = cancel
+	m.mu.Unlock()
+
+	createdAt := time.Now().UTC()
+	go m.run(ctx, executionID, req.Command, req.Args, req.Env)
+	return executionID, &createdAt, nil
+}
+
+// KillTask stops the command of the execution.
+func (m *TaskManager) KillTask(_ context.Context, executionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cancel, ok := m.cancel[executionID]
+	if !ok {
+		return fmt.Errorf("task %s not found or already stopped", executionID)
+	}
+	delete(m.cancel, executionID)
+	cancel()
+	return nil
+}
+
+// CreateStdinUpload is not supported.
+func (m *TaskManager) CreateStdinUpload(context.Context, string, int64) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("uploads are not supported by the fake task manager")
+}
+
+// CreateContextUpload is not supported.
+func (m *TaskManager) CreateContextUpload(context.Context, string, int64) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("uploads are not supported by the fake task manager")
+}
+
+// run runs the command once its execution is recorded, and records its outcome.
+func (m *TaskManager) run(ctx context.Context, executionID, command string, args []string, env map[string]string) {
+	for m.status(executionID) == "" {
+		time.Sleep(tickInterval)
+	}
+	m.executions.update(executionID, func(e *api.Execution) {
+		// A kill may already have moved the execution past STARTING
+		if constants.CanTransition(constants.ExecutionStatus(e.Status), constants.ExecutionRunning) {
+			e.Status = string(constants.ExecutionRunning)
+		}
+	})
+
+	exitCode := m.runner(ctx, command, args, env, func(line string) { m.logs.Append(executionID, line) })
+
+	status := constants.ExecutionSucceeded
+	switch {
+	case ctx.Err() != nil:
+		status = constants.ExecutionStopped
+		for m.status(executionID) != string(constants.ExecutionTerminating) {
+			time.Sleep(tickInterval)
+		}
+	case exitCode != 0:
+		status = constants.ExecutionFailed
+	}
+
+	m.mu.Lock()
+	if cancel, ok := m.cancel[executionID]; ok {
+		cancel()
+		delete(m.cancel, executionID)
+	}
+	m.mu.Unlock()
+
+	completedAt := time.Now().UTC()
+	var lock string
+	m.executions.update(executionID, func(e *api.Execution) {
+		lock = e.Lock
+		// Killed executions end STOPPED, even when the command completed before being canceled
+		if e.Status == string(constants.ExecutionTerminating) {
+			status = constants.ExecutionStopped
+		}
+		if !constants.CanTransition(constants.ExecutionStatus(e.Status), status) {
+			return
+		}
+		e.Status = string(status)
+		e.ExitCode = exitCode
+		e.CompletedAt = &completedAt
+		e.DurationSeconds = int(completedAt.Sub(e.StartedAt).Seconds())
+	})
+	if lock != "" && m.locks != nil {
+		_ = m.locks.ReleaseLock(context.Background(), lock, executionID)
+	}
+}
+
+func (m *TaskManager) status(executionID string) string {
+	execution, _ := m.executions.GetExecution(context.Background(), executionID)
+	if execution == nil {
+		return ""
+	}
+	return execution.Status
+}
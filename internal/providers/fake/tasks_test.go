@@ -0,0 +1,137 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+const testTimeout = 5 * time.Second
+
+func collect(ctx context.Context, runner Runner, command string, env map[string]string) ([]string, int) {
+	return collectArgs(ctx, runner, command, nil, env)
+}
+
+func collectArgs(
+	ctx context.Context, runner Runner, command string, args []string, env map[string]string,
+) ([]string, int) {
+	var lines []string
+	exitCode := runner(ctx, command, args, env, func(line string) { lines = append(lines, line) })
+	return lines, exitCode
+}
+
+func TestScriptRunner(t *testing.T) {
+	t.Run("echoes with the environment expanded", func(t *testing.T) {
+		lines, exitCode := collect(context.Background(), ScriptRunner, "echo hello $NAME; echo done",
+			map[string]string{"NAME": "world"})
+		assert.Equal(t, []string{"hello world", "done"}, lines)
+		assert.Zero(t, exitCode)
+	})
+
+	t.Run("stops at the first non-zero exit", func(t *testing.T) {
+		lines, exitCode := collect(context.Background(), ScriptRunner, "echo before; exit 3; echo after", nil)
+		assert.Equal(t, []string{"before"}, lines)
+		assert.Equal(t, 3, exitCode)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		lines, exitCode := collect(context.Background(), ScriptRunner, "make build", nil)
+		assert.Equal(t, []string{"make: command not found"}, lines)
+		assert.Equal(t, commandNotFoundExitCode, exitCode)
+	})
+
+	t.Run("sleeps until killed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, exitCode := collect(ctx, ScriptRunner, "sleep", nil)
+		assert.Equal(t, killedExitCode, exitCode)
+	})
+}
+
+func TestExecRunner(t *testing.T) {
+	t.Run("runs the command with the environment", func(t *testing.T) {
+		lines, exitCode := collect(context.Background(), ExecRunner, `echo "hello $NAME"; echo oops >&2; exit 2`,
+			map[string]string{"NAME": "world"})
+		assert.Equal(t, []string{"hello world", "oops"}, lines)
+		assert.Equal(t, 2, exitCode)
+	})
+
+	t.Run("runs the arguments without a shell", func(t *testing.T) {
+		lines, exitCode := collectArgs(context.Background(), ExecRunner, `echo 'hello $NAME' ';' 'exit 2'`,
+			[]string{"echo", "hello $NAME", ";", "exit 2"}, map[string]string{"NAME": "world"})
+		assert.Equal(t, []string{"hello $NAME ; exit 2"}, lines)
+		assert.Zero(t, exitCode)
+	})
+
+	t.Run("kills the command", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, exitCode := collect(ctx, ExecRunner, "sleep 30", nil)
+		assert.Equal(t, killedExitCode, exitCode)
+	})
+}
+
+func TestTaskManager(t *testing.T) {
+	ctx := context.Background()
+	executions := NewExecutionRepository()
+	logs := NewLogStore()
+	tasks := NewTaskManager(executions, logs, ScriptRunner)
+
+	start := func(command string) string {
+		executionID, createdAt, err := tasks.StartTask(ctx, "user@example.com", &api.ExecutionRequest{Command: command})
+		require.NoError(t, err)
+		require.NoError(t, executions.CreateExecution(ctx, &api.Execution{
+			ExecutionID: executionID,
+			Status:      string(constants.ExecutionStarting),
+			StartedAt:   *createdAt,
+		}))
+		return executionID
+	}
+	waitForStatus := func(executionID string, status constants.ExecutionStatus) *api.Execution {
+		var execution *api.Execution
+		require.Eventually(t, func() bool {
+			execution, _ = executions.GetExecution(ctx, executionID)
+			return execution.Status == string(status)
+		}, testTimeout, tickInterval)
+		return execution
+	}
+
+	t.Run("records the outcome", func(t *testing.T) {
+		executionID := start("echo hi; exit 1")
+		execution := waitForStatus(executionID, constants.ExecutionFailed)
+		assert.Equal(t, 1, execution.ExitCode)
+		assert.NotNil(t, execution.CompletedAt)
+
+		events, err := logs.FetchLogsByExecutionID(ctx, executionID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "hi", events[0].Message)
+		assert.Error(t, tasks.KillTask(ctx, executionID), "completed tasks cannot be killed")
+	})
+
+	t.Run("stops once terminating", func(t *testing.T) {
+		executionID := start("sleep")
+		waitForStatus(executionID, constants.ExecutionRunning)
+		require.NoError(t, tasks.KillTask(ctx, executionID))
+		executions.update(executionID, func(e *api.Execution) { e.Status = string(constants.ExecutionTerminating) })
+
+		execution := waitForStatus(executionID, constants.ExecutionStopped)
+		assert.Equal(t, killedExitCode, execution.ExitCode)
+	})
+
+	t.Run("rejects invalid status transitions", func(t *testing.T) {
+		executionID := start("echo done")
+		execution := waitForStatus(executionID, constants.ExecutionSucceeded)
+
+		execution.Status = string(constants.ExecutionRunning)
+		err := executions.UpdateExecution(ctx, execution)
+		assert.Equal(t, apperrors.ErrCodeConflict, apperrors.GetErrorCode(err))
+	})
+}
@@ -0,0 +1,241 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// UserRepository is an in-memory database.UserRepository.
+type UserRepository struct {
+	mu      sync.Mutex
+	users   map[string]*api.User
+	hashes  map[string]string
+	pending map[string]*api.PendingAPIKey
+}
+
+// NewUserRepository creates an empty UserRepository.
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:   make(map[string]*api.User),
+		hashes:  make(map[string]string),
+		pending: make(map[string]*api.PendingAPIKey),
+	}
+}
+
+// CreateUser stores the user, identified by the hash of its API key.
+func (r *UserRepository) CreateUser(_ context.Context, user *api.User, apiKeyHash string, _ int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[user.Email]; ok {
+		return fmt.Errorf("user %s already exists", user.Email)
+	}
+	stored := *user
+	stored.APIKey = ""
+	r.users[user.Email] = &stored
+	r.hashes[apiKeyHash] = user.Email
+	return nil
+}
+
+// RemoveExpiration clears the claim expiry of the user, the users of the fake backend never expire.
+func (r *UserRepository) RemoveExpiration(_ context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user, ok := r.users[email]; ok {
+		user.ClaimExpiresAt = nil
+	}
+	return nil
+}
+
+// DeleteUser deletes the user and its API key.
+func (r *UserRepository) DeleteUser(_ context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[email]; !ok {
+		return apperrors.ErrNotFound("user not found", nil)
+	}
+	delete(r.users, email)
+	for hash, owner := range r.hashes {
+		if owner == email {
+			delete(r.hashes, hash)
+		}
+	}
+	return nil
+}
+
+// GetUserByEmail returns the user, nil if there is none.
+func (r *UserRepository) GetUserByEmail(_ context.Context, email string) (*api.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyOf(r.users[email]), nil
+}
+
+// GetUserByAPIKeyHash returns the user of the API key, nil if there is none.
+func (r *UserRepository) GetUserByAPIKeyHash(_ context.Context, apiKeyHash string) (*api.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyOf(r.users[r.hashes[apiKeyHash]]), nil
+}
+
+// UpdateLastUsed records that the user just used its API key.
+func (r *UserRepository) UpdateLastUsed(_ context.Context, email string) (*time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now().UTC()
+	if user, ok := r.users[email]; ok {
+		user.LastUsed = &now
+	}
+	return &now, nil
+}
+
+// RevokeUser revokes the API key of the user.
+func (r *UserRepository) RevokeUser(_ context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[email]
+	if !ok {
+		return fmt.Errorf("user %s not found", email)
+	}
+	user.Revoked = true
+	return nil
+}
+
+// UpdateUser stores the role, revocation, provisioning, claim expiry and ownership attributes of the user, unless
+// it was updated since it was read.
+func (r *UserRepository) UpdateUser(_ context.Context, user *api.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.storedAtVersion(user)
+	if err != nil {
+		return err
+	}
+	stored.Role = user.Role
+	stored.Revoked = user.Revoked
+	stored.Provisioned = user.Provisioned
+	stored.ExternalID = user.ExternalID
+	stored.Groups = slices.Clone(user.Groups)
+	stored.PendingLogin = user.PendingLogin
+	stored.ClaimExpiresAt = user.ClaimExpiresAt
+	stored.ServiceAccount = user.ServiceAccount
+	stored.Owner = user.Owner
+	return nil
+}
+
+// UpdateUserPreferences stores the starred executions and saved list filters of the user, unless it was
+// updated since it was read.
+func (r *UserRepository) UpdateUserPreferences(_ context.Context, user *api.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.storedAtVersion(user)
+	if err != nil {
+		return err
+	}
+	stored.StarredExecutions = slices.Clone(user.StarredExecutions)
+	stored.SavedFilters = slices.Clone(user.SavedFilters)
+	return nil
+}
+
+// storedAtVersion returns the stored user to update, with its version and the version of user incremented.
+// It fails if the user was updated since user was read. The caller holds the lock.
+func (r *UserRepository) storedAtVersion(user *api.User) (*api.User, error) {
+	stored, ok := r.users[user.Email]
+	if !ok {
+		return nil, fmt.Errorf("user %s not found", user.Email)
+	}
+	if stored.Version != user.Version {
+		return nil, apperrors.ErrConcurrentModification("user was modified concurrently", nil)
+	}
+	user.Version++
+	stored.Version = user.Version
+	return stored, nil
+}
+
+// ReplaceAPIKeyHash replaces the API key of the user.
+func (r *UserRepository) ReplaceAPIKeyHash(_ context.Context, email, apiKeyHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[email]; !ok {
+		return fmt.Errorf("user %s not found", email)
+	}
+	for hash, owner := range r.hashes {
+		if owner == email {
+			delete(r.hashes, hash)
+		}
+	}
+	r.hashes[apiKeyHash] = email
+	return nil
+}
+
+// CreatePendingAPIKey stores the API key until it is claimed.
+func (r *UserRepository) CreatePendingAPIKey(_ context.Context, pending *api.PendingAPIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending[pending.SecretToken] = copyOf(pending)
+	return nil
+}
+
+// GetPendingAPIKey returns the pending API key, nil if there is none.
+func (r *UserRepository) GetPendingAPIKey(_ context.Context, secretToken string) (*api.PendingAPIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyOf(r.pending[secretToken]), nil
+}
+
+// MarkAsViewed records that the pending API key was claimed, once.
+func (r *UserRepository) MarkAsViewed(_ context.Context, secretToken, ipAddress string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending, ok := r.pending[secretToken]
+	if !ok || pending.Viewed {
+		return errors.New("pending API key not found or already viewed")
+	}
+	now := time.Now().UTC()
+	pending.Viewed, pending.ViewedAt, pending.ViewedFromIP = true, &now, ipAddress
+	return nil
+}
+
+// DeletePendingAPIKey deletes the pending API key.
+func (r *UserRepository) DeletePendingAPIKey(_ context.Context, secretToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, secretToken)
+	return nil
+}
+
+// ListPendingAPIKeys returns the pending API keys, in no particular order.
+func (r *UserRepository) ListPendingAPIKeys(context.Context) ([]*api.PendingAPIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pendingKeys := make([]*api.PendingAPIKey, 0, len(r.pending))
+	for _, pending := range r.pending {
+		pendingKeys = append(pendingKeys, copyOf(pending))
+	}
+	return pendingKeys, nil
+}
+
+// ListUsers returns the users sorted by email.
+func (r *UserRepository) ListUsers(context.Context) ([]*api.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	users := make([]*api.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, copyOf(user))
+	}
+	slices.SortFunc(users, func(a, b *api.User) int { return cmp.Compare(a.Email, b.Email) })
+	return users, nil
+}
+
+// GetUsersByRequestID returns the users created or modified by the request.
+func (r *UserRepository) GetUsersByRequestID(ctx context.Context, requestID string) ([]*api.User, error) {
+	users, _ := r.ListUsers(ctx)
+	return slices.DeleteFunc(users, func(u *api.User) bool {
+		return u.CreatedByRequestID != requestID && u.ModifiedByRequestID != requestID
+	}), nil
+}
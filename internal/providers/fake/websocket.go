@@ -0,0 +1,190 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/chaos"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// WebSocketManager is a contract.WebSocketManager serving the log streams itself: the URLs it
+// generates point to its ServeHTTP, which sends the log lines of the execution as they are
+// appended, then the disconnect message once the execution completed.
+type WebSocketManager struct {
+	executions *ExecutionRepository
+	logs       *LogStore
+	upgrader   websocket.Upgrader
+
+	mu      sync.Mutex
+	baseURL string
+	tokens  map[string]streamToken
+
+	streams atomic.Int32
+	chaos   atomic.Pointer[chaos.Injector]
+}
+
+// NewWebSocketManager creates a WebSocketManager streaming the logs of the store. SetBaseURL must
+// be called with the URL it is served at before generating URLs.
+func NewWebSocketManager(executions *ExecutionRepository, logs *LogStore) *WebSocketManager {
+	return &WebSocketManager{
+		executions: executions,
+		logs:       logs,
+		// The tokens authenticate the streams, they can be opened from any origin like on API Gateway.
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+		tokens:   make(map[string]streamToken),
+	}
+}
+
+// streamToken is a token authenticating a single stream of the logs of an execution.
+type streamToken struct {
+	executionID string
+	expiresAt   time.Time
+}
+
+// SetBaseURL sets the http:// URL the manager is served at.
+func (m *WebSocketManager) SetBaseURL(baseURL string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.baseURL = baseURL
+}
+
+// SetChaos makes the streams drop the log lines picked by inj, nil sends them all.
+func (m *WebSocketManager) SetChaos(inj *chaos.Injector) {
+	m.chaos.Store(inj)
+}
+
+// StreamsOpened returns the number of log streams opened so far.
+func (m *WebSocketManager) StreamsOpened() int {
+	return int(m.streams.Load())
+}
+
+// HandleRequest handles no event, the streams are served by ServeHTTP.
+func (m *WebSocketManager) HandleRequest(context.Context, *json.RawMessage, *slog.Logger) (bool, error) {
+	return false, nil
+}
+
+// NotifyExecutionCompletion revokes the tokens of the execution not claimed yet, the open streams
+// notice the completion themselves.
+func (m *WebSocketManager) NotifyExecutionCompletion(_ context.Context, executionID *string) error {
+	if executionID == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	maps.DeleteFunc(m.tokens, func(_ string, t streamToken) bool { return t.executionID == *executionID })
+	return nil
+}
+
+// NotifyExecutionStatus does nothing, the streams send the status changes themselves.
+func (m *WebSocketManager) NotifyExecutionStatus(context.Context, *api.ExecutionStatusChange) error {
+	return nil
+}
+
+// SendLogsToExecution does nothing, the streams send the new log lines themselves.
+func (m *WebSocketManager) SendLogsToExecution(context.Context, *string) error { return nil }
+
+// GenerateWebSocketURL returns a ws:// URL streaming the logs of the execution once, to the user only.
+func (m *WebSocketManager) GenerateWebSocketURL(_ context.Context, executionID string, userEmail, _ *string) string {
+	if userEmail == nil || *userEmail == "" {
+		return ""
+	}
+	token, err := auth.GenerateSecretToken()
+	if err != nil {
+		return ""
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token] = streamToken{executionID: executionID, expiresAt: time.Now().Add(constants.WebSocketTokenTTL)}
+	return "ws" + strings.TrimPrefix(m.baseURL, "http") + "?token=" + token
+}
+
+// ServeHTTP streams the logs of the execution of the token in the query string, claiming the token.
+func (m *WebSocketManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	token, ok := m.tokens[r.URL.Query().Get("token")]
+	delete(m.tokens, r.URL.Query().Get("token"))
+	m.mu.Unlock()
+	if !ok || time.Now().After(token.expiresAt) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	executionID := token.executionID
+
+	conn, err := m.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	m.streams.Add(1)
+
+	sent := 0
+	status := ""
+	for {
+		execution, _ := m.executions.GetExecution(r.Context(), executionID)
+		events, _ := m.logs.FetchLogsByExecutionID(r.Context(), executionID)
+		for _, event := range events[sent:] {
+			if m.chaos.Load().Drop() {
+				continue
+			}
+			message := api.WebSocketLogMessage{
+				Version: api.WebSocketProtocolVersion, Type: api.WebSocketMessageTypeLog, LogEvent: event,
+			}
+			if err = conn.WriteJSON(message); err != nil {
+				return
+			}
+		}
+		sent = len(events)
+
+		if execution != nil && execution.Status != status {
+			if err = conn.WriteJSON(statusMessage(execution, status)); err != nil {
+				return
+			}
+			status = execution.Status
+		}
+
+		if execution != nil && slices.Contains(constants.TerminalExecutionStatuses(),
+			constants.ExecutionStatus(execution.Status)) {
+			_ = conn.WriteJSON(api.WebSocketMessage{
+				Version: api.WebSocketProtocolVersion, Type: api.WebSocketMessageTypeDisconnect,
+			})
+			// Wait for the client to close the connection.
+			_, _, _ = conn.ReadMessage()
+			return
+		}
+		time.Sleep(tickInterval)
+	}
+}
+
+// statusMessage returns the status message announcing the current status of the execution.
+func statusMessage(execution *api.Execution, previousStatus string) api.WebSocketMessage {
+	change := &api.ExecutionStatusChange{
+		ExecutionID:    execution.ExecutionID,
+		PreviousStatus: previousStatus,
+		Status:         execution.Status,
+		Timestamp:      time.Now().UnixMilli(),
+	}
+	if execution.CompletedAt != nil {
+		exitCode := execution.ExitCode
+		change.ExitCode = &exitCode
+		change.FailureReason = execution.FailureReason
+		change.FailureMessage = execution.FailureMessage
+	}
+	return api.WebSocketMessage{
+		Version: api.WebSocketProtocolVersion,
+		Type:    api.WebSocketMessageTypeStatus,
+		Status:  change,
+	}
+}
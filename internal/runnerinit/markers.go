@@ -0,0 +1,68 @@
+package runnerinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// MarkerPrefix starts the log lines runvoy-init writes, followed by the JSON of a Marker.
+const MarkerPrefix = "### " + constants.ProjectName + " init: "
+
+// Marker events.
+const (
+	EventPrepared  = "prepared"
+	EventStart     = "start"
+	EventHeartbeat = "heartbeat"
+	EventStop      = "stop"
+	EventArtifacts = "artifacts"
+	EventResult    = "result"
+	EventEnd       = "end"
+	EventError     = "error"
+)
+
+// Marker is a structured log line delimiting the phases of a run.
+type Marker struct {
+	Event     string    `json:"event"`
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id,omitempty"`
+	Image     string    `json:"image,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	WorkDir   string    `json:"work_dir,omitempty"`
+	// ExitCode is set by the end marker.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// ElapsedSeconds is the time since the command started, set by the heartbeat and end markers.
+	ElapsedSeconds float64 `json:"elapsed_seconds,omitempty"`
+	Message        string  `json:"message,omitempty"`
+	// Result is the JSON result file written by the command, set by the result marker.
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// WriteMarker writes the marker as a single log line.
+func WriteMarker(w io.Writer, marker *Marker) {
+	if marker.Time.IsZero() {
+		marker.Time = time.Now().UTC()
+	}
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s%s\n", MarkerPrefix, data)
+}
+
+// ParseMarker parses a log line written by WriteMarker, reporting false for the other lines.
+func ParseMarker(line string) (*Marker, bool) {
+	payload, ok := strings.CutPrefix(strings.TrimSpace(line), MarkerPrefix)
+	if !ok {
+		return nil, false
+	}
+	var marker Marker
+	if err := json.Unmarshal([]byte(payload), &marker); err != nil || marker.Event == "" {
+		return nil, false
+	}
+	return &marker, true
+}
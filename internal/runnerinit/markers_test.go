@@ -0,0 +1,57 @@
+package runnerinit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkers(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := 3
+	WriteMarker(&buf, &Marker{Event: EventEnd, RequestID: "req-1", ExitCode: &exitCode, ElapsedSeconds: 1.5})
+
+	line := buf.String()
+	assert.Regexp(t, `^### runvoy init: \{.*\}\n$`, line)
+
+	marker, ok := ParseMarker(line)
+	require.True(t, ok)
+	assert.Equal(t, EventEnd, marker.Event)
+	assert.Equal(t, "req-1", marker.RequestID)
+	require.NotNil(t, marker.ExitCode)
+	assert.Equal(t, 3, *marker.ExitCode)
+	assert.False(t, marker.Time.IsZero())
+
+	for _, other := range []string{"", "hello", "### runvoy sidecar: Cloning", MarkerPrefix + "not json",
+		MarkerPrefix + "{}"} {
+		_, ok = ParseMarker(other)
+		assert.False(t, ok, other)
+	}
+}
+
+func TestSpecEncoding(t *testing.T) {
+	spec := &Spec{RequestID: "req-1", Command: "echo hi", SharedDir: "/workspace", Repo: &RepoSpec{URL: "u", Ref: "main"}}
+
+	decoded, err := DecodeSpec(EncodeSpec(spec))
+
+	require.NoError(t, err)
+	assert.Equal(t, spec, decoded)
+
+	_, err = DecodeSpec("")
+	assert.Error(t, err)
+	_, err = DecodeSpec(EncodeSpec(&Spec{Command: "echo hi"}))
+	assert.Error(t, err)
+}
+
+func TestSpecWorkDir(t *testing.T) {
+	assert.Equal(t, "/workspace", (&Spec{SharedDir: "/workspace"}).WorkDir())
+	assert.Equal(t, "/workspace/context", (&Spec{SharedDir: "/workspace", HasContext: true}).WorkDir())
+	assert.Equal(t, "/workspace/repo", (&Spec{SharedDir: "/workspace", Repo: &RepoSpec{}}).WorkDir())
+	assert.Equal(t, "/workspace/repo/api",
+		(&Spec{SharedDir: "/workspace", Repo: &RepoSpec{Path: "../../api"}}).WorkDir())
+	assert.Equal(t, "/workspace/repo/api/web",
+		(&Spec{SharedDir: "/workspace", Repo: &RepoSpec{Path: "api"}, Dir: "web"}).WorkDir())
+	assert.Equal(t, "/app", (&Spec{SharedDir: "/workspace", HasContext: true, Dir: "/app/"}).WorkDir())
+}
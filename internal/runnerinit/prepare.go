@@ -0,0 +1,243 @@
+package runnerinit
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// sidecarLogPrefix starts the unstructured log lines written while preparing the shared volume.
+const sidecarLogPrefix = "### " + constants.ProjectName + " sidecar: "
+
+// downloadTimeout bounds the download of the standard input and the working directory context.
+const downloadTimeout = 5 * time.Minute
+
+// Prepare prepares the shared volume of a run in the sidecar: it writes the .env file from the user
+// environment variables, saves the standard input, extracts the working directory context and clones the
+// git repository, copying the .env file into the directory the command runs in.
+// The inputs kept out of the spec are read with getenv.
+func Prepare(ctx context.Context, spec *Spec, getenv func(string) string, out io.Writer) error {
+	if err := os.MkdirAll(spec.SharedDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create shared directory: %w", err)
+	}
+
+	envFileWritten, err := writeEnvFile(spec, getenv)
+	if err != nil {
+		return err
+	}
+	if envFileWritten {
+		_, _ = fmt.Fprintf(out, "%s.env file created with %d variables\n", sidecarLogPrefix, len(spec.EnvVarNames))
+	}
+
+	if spec.HasStdin {
+		if err = saveStdin(ctx, spec, getenv); err != nil {
+			return err
+		}
+	}
+
+	if spec.HasContext {
+		if err = extractContext(ctx, spec, getenv(ContextURLEnvVar)); err != nil {
+			return err
+		}
+	}
+
+	if spec.Repo != nil {
+		if err = cloneRepo(ctx, spec, getenv(GitRepoEnvVar), out); err != nil {
+			return err
+		}
+	}
+
+	if envFileWritten && (spec.Repo != nil || spec.HasContext) {
+		dir := spec.ContextDir()
+		if spec.Repo != nil {
+			dir = spec.RepoDir()
+		}
+		if err = copyFile(spec.EnvFilePath(), filepath.Join(dir, ".env")); err != nil {
+			return fmt.Errorf("failed to copy .env file: %w", err)
+		}
+	}
+
+	WriteMarker(out, &Marker{Event: EventPrepared, RequestID: spec.RequestID, WorkDir: spec.WorkDir()})
+	return nil
+}
+
+// writeEnvFile writes the user environment variables set in the sidecar to the .env file,
+// reporting whether it was written.
+func writeEnvFile(spec *Spec, getenv func(string) string) (bool, error) {
+	if len(spec.EnvVarNames) == 0 {
+		return false, nil
+	}
+
+	var content strings.Builder
+	for _, name := range spec.EnvVarNames {
+		fmt.Fprintf(&content, "%s=%s\n", name, getenv(UserEnvVarPrefix+name))
+	}
+	if err := os.WriteFile(spec.EnvFilePath(), []byte(content.String()), 0o600); err != nil {
+		return false, fmt.Errorf("failed to write .env file: %w", err)
+	}
+	return true, nil
+}
+
+// saveStdin writes the standard input of the command, downloaded or inline, to the shared volume.
+func saveStdin(ctx context.Context, spec *Spec, getenv func(string) string) error {
+	if url := getenv(StdinURLEnvVar); url != "" {
+		body, err := download(ctx, url)
+		if err != nil {
+			return fmt.Errorf("failed to download standard input: %w", err)
+		}
+		defer func() { _ = body.Close() }()
+		return writeFile(spec.StdinPath(), body)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(getenv(StdinBase64EnvVar))
+	if err != nil {
+		return fmt.Errorf("failed to decode standard input: %w", err)
+	}
+	if err = os.WriteFile(spec.StdinPath(), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write standard input: %w", err)
+	}
+	return nil
+}
+
+// extractContext downloads the gzipped tar archive of the working directory context and extracts it.
+func extractContext(ctx context.Context, spec *Spec, url string) error {
+	if url == "" {
+		return errors.New(ContextURLEnvVar + " is not set")
+	}
+	body, err := download(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to download context: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	if err = extractTarGz(body, spec.ContextDir()); err != nil {
+		return fmt.Errorf("failed to extract context: %w", err)
+	}
+	return nil
+}
+
+// cloneRepo shallow clones the git repository at the ref of the spec, with the git binary of the sidecar.
+func cloneRepo(ctx context.Context, spec *Spec, url string, out io.Writer) error {
+	if url == "" {
+		return errors.New(GitRepoEnvVar + " is not set")
+	}
+	ref := spec.Repo.Ref
+	if ref == "" {
+		ref = constants.DefaultGitRef
+	}
+
+	_, _ = fmt.Fprintf(out, "%sCloning %s (ref: %s)\n", sidecarLogPrefix, spec.Repo.URL, ref)
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--branch", ref, url, spec.RepoDir())
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to clone %s (ref: %s): %w", spec.Repo.URL, ref, err)
+	}
+	return nil
+}
+
+func download(ctx context.Context, url string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// extractTarGz extracts the regular files and directories of a gzipped tar archive,
+// refusing the entries escaping the destination directory.
+func extractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	if err = os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil
+		}
+		if nextErr != nil {
+			return nextErr
+		}
+
+		target := filepath.Join(dest, filepath.Clean("/"+header.Name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err = writeFileMode(target, tr, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	return writeFileMode(path, r, 0o600)
+}
+
+func writeFileMode(path string, r io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	return writeFile(dst, in)
+}
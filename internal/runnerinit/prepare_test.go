@@ -0,0 +1,108 @@
+package runnerinit
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(name string) string { return env[name] }
+}
+
+func TestPrepare(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"Makefile": "test:\n", "../escape.txt": "no"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)),
+			Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/context":
+			_, _ = w.Write(archive.Bytes())
+		case "/stdin":
+			_, _ = w.Write([]byte("uploaded input"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("prepares the env file, stdin and context", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := &Spec{SharedDir: dir, EnvVarNames: []string{"APP_ENV", "TOKEN"}, HasStdin: true, HasContext: true}
+		var out bytes.Buffer
+
+		err := Prepare(context.Background(), spec, envFunc(map[string]string{
+			"RUNVOY_USER_APP_ENV": "ci",
+			"RUNVOY_USER_TOKEN":   "secret",
+			StdinURLEnvVar:        server.URL + "/stdin",
+			ContextURLEnvVar:      server.URL + "/context",
+		}), &out)
+
+		require.NoError(t, err)
+		assertFile(t, filepath.Join(dir, ".env"), "APP_ENV=ci\nTOKEN=secret\n")
+		assertFile(t, filepath.Join(dir, ".stdin"), "uploaded input")
+		assertFile(t, filepath.Join(dir, "context", "Makefile"), "test:\n")
+		assertFile(t, filepath.Join(dir, "context", ".env"), "APP_ENV=ci\nTOKEN=secret\n")
+		assertFile(t, filepath.Join(dir, "context", "escape.txt"), "no")
+		assert.NotContains(t, out.String(), "secret")
+
+		marker, ok := ParseMarker(lastLine(out.String()))
+		require.True(t, ok)
+		assert.Equal(t, EventPrepared, marker.Event)
+		assert.Equal(t, filepath.Join(dir, "context"), marker.WorkDir)
+	})
+
+	t.Run("decodes inline stdin", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := &Spec{SharedDir: dir, HasStdin: true}
+
+		err := Prepare(context.Background(), spec, envFunc(map[string]string{StdinBase64EnvVar: "aGVsbG8K"}),
+			&bytes.Buffer{})
+
+		require.NoError(t, err)
+		assertFile(t, filepath.Join(dir, ".stdin"), "hello\n")
+		assert.NoFileExists(t, filepath.Join(dir, ".env"))
+	})
+
+	t.Run("fails when an input cannot be downloaded", func(t *testing.T) {
+		spec := &Spec{SharedDir: t.TempDir(), HasContext: true}
+
+		err := Prepare(context.Background(), spec, envFunc(map[string]string{ContextURLEnvVar: server.URL + "/missing"}),
+			&bytes.Buffer{})
+
+		assert.ErrorContains(t, err, "failed to download context")
+	})
+
+	t.Run("fails without the repository URL", func(t *testing.T) {
+		spec := &Spec{SharedDir: t.TempDir(), Repo: &RepoSpec{URL: "https://github.com/acme/app"}}
+
+		err := Prepare(context.Background(), spec, envFunc(nil), &bytes.Buffer{})
+
+		assert.ErrorContains(t, err, GitRepoEnvVar)
+	})
+}
+
+func assertFile(t *testing.T, path, expected string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
@@ -0,0 +1,291 @@
+package runnerinit
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// ExitCodeInitFailure is the exit code of the runs runvoy-init fails to start the command of.
+const ExitCodeInitFailure = 127
+
+// uploadTimeout bounds the upload of the artifacts.
+const uploadTimeout = 5 * time.Minute
+
+// Run runs the command of the spec in the runner container and returns its exit code. It writes the start
+// marker, a heartbeat marker every heartbeat interval while the command runs and the end marker with the
+// exit code. A signal received on stop is forwarded to the command as SIGTERM, which is killed once the stop
+// grace period has elapsed. The result file, if the command wrote one, is reported in the result marker and the
+// artifacts are uploaded after the command has exited, whatever its exit code.
+func Run(
+	ctx context.Context, spec *Spec, getenv func(string) string, stop <-chan os.Signal, stdout, stderr io.Writer,
+) int {
+	// The command output and the markers of the supervisor are written concurrently
+	stdout, stderr = newLockedWriters(stdout, stderr)
+
+	workDir := spec.WorkDir()
+	args := constants.ExecutionShell(spec.Shell).Args(spec.Command)
+	if len(spec.Args) > 0 {
+		// The arguments are passed to the program as is, no shell parses them
+		args = spec.Args
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // G204: the command to run
+	cmd.Dir = workDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), ResultFileEnvVar+"="+spec.ResultPath())
+	if spec.HasStdin {
+		stdin, err := os.Open(spec.StdinPath())
+		if err != nil {
+			WriteMarker(stdout, &Marker{Event: EventError, RequestID: spec.RequestID,
+				Message: fmt.Sprintf("failed to open standard input: %v", err)})
+			return endRun(stdout, spec, ExitCodeInitFailure, 0)
+		}
+		defer func() { _ = stdin.Close() }()
+		cmd.Stdin = stdin
+	}
+
+	WriteMarker(stdout, &Marker{
+		Event:     EventStart,
+		RequestID: spec.RequestID,
+		Image:     spec.Image,
+		Command:   spec.Command,
+		WorkDir:   workDir,
+	})
+	started := time.Now()
+	if err := cmd.Start(); err != nil {
+		WriteMarker(stdout, &Marker{Event: EventError, RequestID: spec.RequestID,
+			Message: fmt.Sprintf("failed to start command: %v", err)})
+		return endRun(stdout, spec, ExitCodeInitFailure, time.Since(started))
+	}
+
+	done := make(chan struct{})
+	var supervisor sync.WaitGroup
+	supervisor.Go(func() { superviseCommand(cmd.Process, spec, stop, done, started, stdout) })
+	waitErr := cmd.Wait()
+	close(done)
+	supervisor.Wait()
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(waitErr, &exitErr):
+		exitCode = exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			// Killed by a signal, reported like a shell would
+			exitCode = 128 + int(status.Signal())
+		}
+	case waitErr != nil:
+		exitCode = ExitCodeInitFailure
+	}
+	elapsed := time.Since(started)
+
+	reportResult(spec, stdout)
+	if spec.ArtifactsPath != "" {
+		uploadArtifacts(ctx, spec, workDir, getenv(ArtifactsURLEnvVar), stdout)
+	}
+
+	return endRun(stdout, spec, exitCode, elapsed)
+}
+
+// superviseCommand writes the heartbeats and forwards the stop signal until done is closed.
+func superviseCommand(
+	process *os.Process, spec *Spec, stop <-chan os.Signal, done <-chan struct{}, started time.Time, out io.Writer,
+) {
+	heartbeat := time.NewTicker(time.Duration(spec.heartbeatInterval()) * time.Second)
+	defer heartbeat.Stop()
+
+	var kill <-chan time.Time
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat.C:
+			WriteMarker(out, &Marker{
+				Event:          EventHeartbeat,
+				RequestID:      spec.RequestID,
+				ElapsedSeconds: time.Since(started).Seconds(),
+			})
+		case <-stop:
+			if spec.StopGracePeriod <= 0 {
+				WriteMarker(out, &Marker{Event: EventStop, RequestID: spec.RequestID, Message: "killing command"})
+				_ = process.Kill()
+				continue
+			}
+			WriteMarker(out, &Marker{Event: EventStop, RequestID: spec.RequestID,
+				Message: fmt.Sprintf("sending SIGTERM (grace period: %ds)", spec.StopGracePeriod)})
+			_ = process.Signal(syscall.SIGTERM)
+			if kill == nil {
+				kill = time.After(time.Duration(spec.StopGracePeriod) * time.Second)
+			}
+		case <-kill:
+			_ = process.Kill()
+		}
+	}
+}
+
+// lockedWriter serializes the writes to a writer shared by goroutines.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// newLockedWriters wraps stdout and stderr with a single lock, as they may be the same writer.
+func newLockedWriters(stdout, stderr io.Writer) (io.Writer, io.Writer) {
+	mu := &sync.Mutex{}
+	return &lockedWriter{mu: mu, w: stdout}, &lockedWriter{mu: mu, w: stderr}
+}
+
+func endRun(out io.Writer, spec *Spec, exitCode int, elapsed time.Duration) int {
+	WriteMarker(out, &Marker{
+		Event:          EventEnd,
+		RequestID:      spec.RequestID,
+		ExitCode:       &exitCode,
+		ElapsedSeconds: elapsed.Seconds(),
+	})
+	return exitCode
+}
+
+// reportResult writes the result marker with the JSON result file of the command, if it wrote one.
+// An invalid or oversized file is reported in the marker message, the exit code of the run remaining the command's.
+func reportResult(spec *Spec, out io.Writer) {
+	file, err := os.Open(spec.ResultPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	marker := &Marker{Event: EventResult, RequestID: spec.RequestID}
+	defer WriteMarker(out, marker)
+	if err != nil {
+		marker.Message = fmt.Sprintf("failed to read result file: %v", err)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(file, constants.MaxExecutionResultBytes+1))
+	switch {
+	case err != nil:
+		marker.Message = fmt.Sprintf("failed to read result file: %v", err)
+	case len(data) > constants.MaxExecutionResultBytes:
+		marker.Message = fmt.Sprintf("result file exceeds %d bytes, result not reported",
+			constants.MaxExecutionResultBytes)
+	case !json.Valid(data):
+		marker.Message = "result file is not valid JSON, result not reported"
+	default:
+		var compact bytes.Buffer
+		_ = json.Compact(&compact, data) // valid JSON
+		marker.Result = compact.Bytes()
+	}
+}
+
+// uploadArtifacts archives the artifacts path and uploads it to the presigned URL. Failures are logged,
+// the exit code of the run remaining the command's.
+func uploadArtifacts(ctx context.Context, spec *Spec, workDir, url string, out io.Writer) {
+	marker := &Marker{Event: EventArtifacts, RequestID: spec.RequestID}
+	defer WriteMarker(out, marker)
+
+	if url == "" {
+		marker.Message = ArtifactsURLEnvVar + " is not set, artifacts not uploaded"
+		return
+	}
+
+	var archive bytes.Buffer
+	count, err := archiveTarGz(filepath.Join(workDir, spec.ArtifactsPath), &archive)
+	if err != nil {
+		marker.Message = fmt.Sprintf("failed to archive artifacts: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, &archive)
+	if err != nil {
+		marker.Message = fmt.Sprintf("failed to upload artifacts: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		marker.Message = fmt.Sprintf("failed to upload artifacts: %v", err)
+		return
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		marker.Message = fmt.Sprintf("failed to upload artifacts: unexpected status %d", resp.StatusCode)
+		return
+	}
+	marker.Message = fmt.Sprintf("uploaded %d files from %s", count, spec.ArtifactsPath)
+}
+
+// archiveTarGz writes the regular files under root, a file or a directory, as a gzipped tar archive
+// and returns their number.
+func archiveTarGz(root string, w io.Writer) (int, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return 0, err
+	}
+	base := filepath.Dir(root)
+	if info.IsDir() {
+		base = root
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	count := 0
+	walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(fileInfo, "")
+		if err != nil {
+			return err
+		}
+		if header.Name, err = filepath.Rel(base, path); err != nil {
+			return err
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		if _, err = io.Copy(tw, f); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if walkErr != nil {
+		return 0, walkErr
+	}
+	if err = tw.Close(); err != nil {
+		return 0, err
+	}
+	return count, gz.Close()
+}
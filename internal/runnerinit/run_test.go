@@ -0,0 +1,199 @@
+package runnerinit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
+
+func markers(output string) []*Marker {
+	var result []*Marker
+	for line := range strings.SplitSeq(output, "\n") {
+		if marker, ok := ParseMarker(line); ok {
+			result = append(result, marker)
+		}
+	}
+	return result
+}
+
+func TestRun(t *testing.T) {
+	t.Run("reports the exit code between start and end markers", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := &Spec{RequestID: "req-1", Image: "alpine", Command: "echo hello; pwd; exit 3", SharedDir: dir}
+		var stdout, stderr bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, &stderr)
+
+		assert.Equal(t, 3, exitCode)
+		assert.Contains(t, stdout.String(), "hello\n"+dir+"\n")
+		found := markers(stdout.String())
+		require.Len(t, found, 2)
+		assert.Equal(t, EventStart, found[0].Event)
+		assert.Equal(t, "echo hello; pwd; exit 3", found[0].Command)
+		assert.Equal(t, EventEnd, found[1].Event)
+		require.NotNil(t, found[1].ExitCode)
+		assert.Equal(t, 3, *found[1].ExitCode)
+	})
+
+	t.Run("starts the arguments without a shell", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := &Spec{
+			Command:   "printf '%s|' 'a b' '$HOME' ';exit 3'",
+			Args:      []string{"printf", "%s|", "a b", "$HOME", ";exit 3"},
+			SharedDir: dir,
+		}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, stdout.String(), "a b|$HOME|;exit 3|")
+	})
+
+	t.Run("runs the command in the requested working directory with the shell", func(t *testing.T) {
+		if _, err := exec.LookPath("bash"); err != nil {
+			t.Skip("bash is not installed")
+		}
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "context", "web"), 0o750))
+		spec := &Spec{Command: `pwd; echo "${BASH_VERSION:+bash}"`, Shell: "bash", Dir: "web", SharedDir: dir,
+			HasContext: true}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, stdout.String(), filepath.Join(dir, "context", "web")+"\nbash\n")
+	})
+
+	t.Run("fails when the program of the arguments is not found", func(t *testing.T) {
+		spec := &Spec{Command: "missing-program", Args: []string{"missing-program"}, SharedDir: t.TempDir()}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, ExitCodeInitFailure, exitCode)
+		assert.Contains(t, stdout.String(), "failed to start command")
+	})
+
+	t.Run("reads the saved standard input", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".stdin"), []byte("from stdin"), 0o600))
+		spec := &Spec{Command: "cat", SharedDir: dir, HasStdin: true}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, 0, exitCode)
+		assert.Contains(t, stdout.String(), "from stdin")
+	})
+
+	t.Run("writes heartbeats", func(t *testing.T) {
+		spec := &Spec{Command: "sleep 2.5", SharedDir: t.TempDir(), HeartbeatInterval: 1}
+		var stdout bytes.Buffer
+
+		Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		heartbeats := 0
+		for _, marker := range markers(stdout.String()) {
+			if marker.Event == EventHeartbeat {
+				heartbeats++
+			}
+		}
+		assert.GreaterOrEqual(t, heartbeats, 1)
+	})
+
+	t.Run("forwards the stop signal", func(t *testing.T) {
+		spec := &Spec{Command: "trap 'exit 42' TERM; while true; do sleep 0.1; done", SharedDir: t.TempDir(),
+			StopGracePeriod: 5}
+		stop := make(chan os.Signal, 1)
+		go func() {
+			time.Sleep(300 * time.Millisecond)
+			stop <- syscall.SIGTERM
+		}()
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), stop, &stdout, io.Discard)
+
+		assert.Equal(t, 42, exitCode)
+		assert.Contains(t, stdout.String(), `"event":"stop"`)
+	})
+
+	t.Run("kills the command without grace period", func(t *testing.T) {
+		spec := &Spec{Command: "exec sleep 30", SharedDir: t.TempDir()}
+		stop := make(chan os.Signal, 1)
+		stop <- syscall.SIGTERM
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), stop, io.Discard, io.Discard)
+
+		assert.Equal(t, 128+int(syscall.SIGKILL), exitCode)
+	})
+
+	t.Run("uploads the artifacts", func(t *testing.T) {
+		var uploaded []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			uploaded, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		spec := &Spec{Command: "mkdir -p out && echo report > out/report.txt && exit 1", SharedDir: t.TempDir(),
+			ArtifactsPath: "out"}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(map[string]string{ArtifactsURLEnvVar: server.URL}), nil,
+			&stdout, io.Discard)
+
+		assert.Equal(t, 1, exitCode)
+		assert.NotEmpty(t, uploaded)
+		dest := t.TempDir()
+		require.NoError(t, extractTarGz(bytes.NewReader(uploaded), dest))
+		assertFile(t, filepath.Join(dest, "report.txt"), "report\n")
+		assert.Contains(t, stdout.String(), "uploaded 1 files from out")
+	})
+	t.Run("reports the result file", func(t *testing.T) {
+		spec := &Spec{RequestID: "req-1", Command: `printf '{"summary": {"passed": 3}}' > "$RUNVOY_RESULT_FILE"`,
+			SharedDir: t.TempDir()}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, 0, exitCode)
+		found := markers(stdout.String())
+		require.Len(t, found, 3)
+		assert.Equal(t, EventResult, found[1].Event)
+		assert.JSONEq(t, `{"summary":{"passed":3}}`, string(found[1].Result))
+		assert.Equal(t, EventEnd, found[2].Event)
+	})
+
+	t.Run("reports an invalid result file without its content", func(t *testing.T) {
+		spec := &Spec{Command: `echo 'not json' > "$RUNVOY_RESULT_FILE"`, SharedDir: t.TempDir()}
+		var stdout bytes.Buffer
+
+		exitCode := Run(context.Background(), spec, envFunc(nil), nil, &stdout, io.Discard)
+
+		assert.Equal(t, 0, exitCode)
+		found := markers(stdout.String())
+		require.Len(t, found, 3)
+		assert.Equal(t, EventResult, found[1].Event)
+		assert.Empty(t, found[1].Result)
+		assert.Contains(t, found[1].Message, "not valid JSON")
+	})
+}
@@ -0,0 +1,146 @@
+// Package runnerinit implements runvoy-init, the entrypoint baked into the runs in place of the generated
+// shell scripts. It prepares the shared volume of a run in the sidecar (environment file, standard input,
+// working directory context and git clone) and wraps the command in the runner container: it forwards the
+// stop signals, reports the exit code, writes heartbeats and uploads the artifacts, delimiting the run with
+// structured markers in the logs. The JSON result file written by the command is reported in
+// a marker as well.
+package runnerinit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// SpecEnvVar is the environment variable holding the base64-encoded JSON spec of a run.
+const SpecEnvVar = "RUNVOY_INIT_SPEC"
+
+// Environment variables holding the inputs of a run that are kept out of the spec, as they may hold secrets
+// or exceed its size, e.g. the repository URL embedding a token.
+const (
+	GitRepoEnvVar       = "GIT_REPO"
+	StdinURLEnvVar      = "RUNVOY_STDIN_URL"
+	StdinBase64EnvVar   = "RUNVOY_STDIN_BASE64"
+	ContextURLEnvVar    = "RUNVOY_CONTEXT_URL"
+	ArtifactsURLEnvVar  = "RUNVOY_ARTIFACTS_URL"
+	UserEnvVarPrefix    = "RUNVOY_USER_"
+	ResultFileEnvVar    = "RUNVOY_RESULT_FILE"
+	defaultHeartbeatSec = 30
+)
+
+// Spec describes a run to runvoy-init.
+type Spec struct {
+	RequestID string `json:"request_id"`
+	Image     string `json:"image"`
+	Command   string `json:"command"`
+	// Args is the command as an argument vector, started without a shell when set. Command then holds the
+	// equivalent shell command line, for the logs.
+	Args []string `json:"args,omitempty"`
+	// Shell runs the Command line, see constants.ExecutionShell. Empty stands for /bin/sh.
+	Shell string `json:"shell,omitempty"`
+	// Dir is the working directory requested for the run, relative to the default one or absolute.
+	Dir string `json:"workdir,omitempty"`
+	// SharedDir is the volume shared by the sidecar and the runner container.
+	SharedDir string `json:"shared_dir"`
+	// EnvVarNames are the names of the user environment variables written to the .env file, their values
+	// being read from the RUNVOY_USER_ prefixed variables so that secrets stay out of the spec.
+	EnvVarNames []string `json:"env_var_names,omitempty"`
+	// Repo is the git repository cloned before the command runs, its URL read from GIT_REPO.
+	Repo       *RepoSpec `json:"repo,omitempty"`
+	HasStdin   bool      `json:"has_stdin,omitempty"`
+	HasContext bool      `json:"has_context,omitempty"`
+	// StopGracePeriod is the number of seconds the command has to exit after SIGTERM, 0 kills it right away.
+	StopGracePeriod int `json:"stop_grace_period,omitempty"`
+	// HeartbeatInterval is the number of seconds between heartbeats, 0 stands for 30 seconds.
+	HeartbeatInterval int `json:"heartbeat_interval,omitempty"`
+	// ArtifactsPath is the path, relative to the working directory, archived and uploaded to
+	// RUNVOY_ARTIFACTS_URL once the command has exited.
+	ArtifactsPath string `json:"artifacts_path,omitempty"`
+}
+
+// RepoSpec describes the git repository of a run.
+type RepoSpec struct {
+	// URL is the repository URL without credentials, for the logs.
+	URL  string `json:"url"`
+	Ref  string `json:"ref"`
+	Path string `json:"path,omitempty"`
+}
+
+// EncodeSpec encodes the spec as the value of RUNVOY_INIT_SPEC.
+func EncodeSpec(spec *Spec) string {
+	data, _ := json.Marshal(spec) // a Spec only holds strings, numbers, booleans and slices of strings
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// DecodeSpec decodes the value of RUNVOY_INIT_SPEC.
+func DecodeSpec(value string) (*Spec, error) {
+	if value == "" {
+		return nil, errors.New(SpecEnvVar + " is not set")
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	var spec Spec
+	if err = json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+	if spec.SharedDir == "" {
+		return nil, errors.New("spec has no shared directory")
+	}
+	return &spec, nil
+}
+
+// EnvFilePath returns the path of the .env file written from the user environment variables.
+func (s *Spec) EnvFilePath() string {
+	return filepath.Join(s.SharedDir, ".env")
+}
+
+// StdinPath returns the path of the file holding the standard input of the command.
+func (s *Spec) StdinPath() string {
+	return filepath.Join(s.SharedDir, ".stdin")
+}
+
+// ResultPath returns the path of the JSON result file the command may write, exposed to it as
+// RUNVOY_RESULT_FILE.
+func (s *Spec) ResultPath() string {
+	return filepath.Join(s.SharedDir, ".result.json")
+}
+
+// ContextDir returns the directory the working directory context is extracted to.
+func (s *Spec) ContextDir() string {
+	return filepath.Join(s.SharedDir, "context")
+}
+
+// RepoDir returns the directory the git repository is cloned to.
+func (s *Spec) RepoDir() string {
+	return filepath.Join(s.SharedDir, "repo")
+}
+
+// WorkDir returns the directory the command runs in: the requested directory when absolute, otherwise the
+// requested directory within the repository path or the extracted context if any, the shared directory
+// otherwise.
+func (s *Spec) WorkDir() string {
+	if filepath.IsAbs(s.Dir) {
+		return filepath.Clean(s.Dir)
+	}
+	var base string
+	switch {
+	case s.Repo != nil:
+		base = filepath.Join(s.RepoDir(), filepath.Clean("/"+s.Repo.Path))
+	case s.HasContext:
+		base = s.ContextDir()
+	default:
+		base = s.SharedDir
+	}
+	return filepath.Join(base, s.Dir)
+}
+
+func (s *Spec) heartbeatInterval() int {
+	if s.HeartbeatInterval > 0 {
+		return s.HeartbeatInterval
+	}
+	return defaultHeartbeatSec
+}
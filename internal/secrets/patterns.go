@@ -1,6 +1,10 @@
 package secrets
 
-import "strings"
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
 
 // DefaultSecretPatterns contains the default patterns used to identify
 // environment variable names that should be treated as secrets.
@@ -17,6 +21,16 @@ var DefaultSecretPatterns = []string{
 	"TOKEN",
 }
 
+// secretValuePatterns match the values of well-known credential formats, whatever the names of their variables.
+var secretValuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(AKIA|ASIA)[0-9A-Z]{16}$`),          // AWS access key IDs
+	regexp.MustCompile(`^gh[pousr]_[A-Za-z0-9]{36,}$`),       // GitHub tokens
+	regexp.MustCompile(`^github_pat_[A-Za-z0-9_]{22,}$`),     // GitHub fine-grained tokens
+	regexp.MustCompile(`^xox[abposr]-[A-Za-z0-9-]{10,}$`),    // Slack tokens
+	regexp.MustCompile(`^(sk|rk)_live_[A-Za-z0-9]{16,}$`),    // Stripe live keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`), // PEM private keys
+}
+
 // GetSecretVariableNames returns a list of variable names from the given environment
 // that should be treated as secrets based on pattern matching.
 // These variables will be processed without exposing their values in logs.
@@ -36,6 +50,22 @@ func GetSecretVariableNames(env map[string]string) []string {
 	return secretNames
 }
 
+// GetSecretValueNames returns the sorted names of the variables whose values look like well-known credentials,
+// such as AWS access keys, GitHub tokens or private keys.
+func GetSecretValueNames(env map[string]string) []string {
+	names := []string{}
+	for key, value := range env {
+		for _, pattern := range secretValuePatterns {
+			if pattern.MatchString(value) {
+				names = append(names, key)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // MergeSecretVarNames merges known secret variable names with pattern-detected ones,
 // removing duplicates. This allows combining explicitly known secrets with
 // pattern-based detection for comprehensive coverage.
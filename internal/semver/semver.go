@@ -0,0 +1,80 @@
+// Package semver parses and compares the semantic versions of the releases, e.g. of the CLI and the backend.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const versionCoreParts = 3
+
+// Version is a semantic version of the backend or the CLI, e.g. "v1.2.3" or "0.0.0-development".
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// Parse parses a semantic version, with or without the "v" prefix.
+// Build metadata ("+...") is ignored.
+func Parse(s string) (Version, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	core, prerelease, _ := strings.Cut(raw, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != versionCoreParts {
+		return Version{}, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", s)
+	}
+
+	numbers := make([]int, versionCoreParts)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q: %q is not a number", s, part)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Prerelease: prerelease}, nil
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or greater than other.
+// A pre-release is lower than the release with the same core version.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	default:
+		return strings.Compare(v.Prerelease, other.Prerelease)
+	}
+}
+
+// IsDevelopment reports whether v is the version of a development build, 0.0.0 with any pre-release.
+func (v Version) IsDevelopment() bool {
+	return v.Major == 0 && v.Minor == 0 && v.Patch == 0
+}
+
+// String returns the version with the "v" prefix.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
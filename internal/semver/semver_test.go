@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{input: "v1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{input: "0.5.0", want: Version{Minor: 5}},
+		{input: "0.0.0-development", want: Version{Prerelease: "development"}},
+		{input: "v1.0.0-rc.1+build.5", want: Version{Major: 1, Prerelease: "rc.1"}},
+		{input: "latest", wantErr: true},
+		{input: "v1.2", wantErr: true},
+		{input: "v1.x.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "v1.2.3", b: "1.2.3", want: 0},
+		{a: "v1.2.3", b: "v1.2.4", want: -1},
+		{a: "v1.10.0", b: "v1.9.9", want: 1},
+		{a: "v2.0.0", b: "v1.99.99", want: 1},
+		{a: "v1.0.0-rc.1", b: "v1.0.0", want: -1},
+		{a: "v1.0.0", b: "v1.0.0-rc.1", want: 1},
+		{a: "v1.0.0-rc.1", b: "v1.0.0-rc.2", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			require.NoError(t, err)
+			b, err := Parse(tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.Compare(b))
+		})
+	}
+}
+
+func TestVersion_IsDevelopment(t *testing.T) {
+	assert.True(t, Version{Prerelease: "development"}.IsDevelopment())
+	assert.False(t, Version{Minor: 5}.IsDevelopment())
+}
+
+func TestVersion_String(t *testing.T) {
+	assert.Equal(t, "v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}.String())
+	assert.Equal(t, "v0.0.0-development", Version{Prerelease: "development"}.String())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	appErrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/testutil"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -442,8 +444,8 @@ func TestRoleBasedAccessExpectations(t *testing.T) {
 			"denied":  {"/api/users", "/api/health"},
 		},
 		"viewer": {
-			"allowed": {"/api/executions"},
-			"denied":  {"/api/users", "/api/images", "/api/secrets", "/api/health"},
+			"allowed": {"/api/executions", "/api/images", "/api/secrets"},
+			"denied":  {"/api/users", "/api/health"},
 		},
 	}
 
@@ -458,9 +460,17 @@ func TestRoleBasedAccessExpectations(t *testing.T) {
 // newTestRouterWithEnforcer creates a router with a service and enforcer for authorization testing.
 // This helper eliminates duplication in authorization tests.
 func newTestRouterWithEnforcer(t *testing.T, enforcer *authorization.Enforcer) *Router {
+	return newTestRouterWithEnforcerAndExecutions(t, enforcer, &testExecutionRepository{})
+}
+
+// newTestRouterWithEnforcerAndExecutions creates a router like newTestRouterWithEnforcer, reading the
+// executions from execRepo.
+func newTestRouterWithEnforcerAndExecutions(
+	t *testing.T, enforcer *authorization.Enforcer, execRepo *testExecutionRepository,
+) *Router {
 	repos := database.Repositories{
 		User:       &testUserRepository{},
-		Execution:  &testExecutionRepository{},
+		Execution:  execRepo,
 		Connection: nil,
 		Token:      &testTokenRepository{},
 		Image:      &testImageRepository{},
@@ -603,22 +613,22 @@ func TestListEndpointAuthorization(t *testing.T) {
 			description: "viewer should have access to list executions endpoint",
 		},
 		{
-			name:        "viewer cannot list images",
+			name:        "viewer can list images",
 			role:        authorization.RoleViewer,
 			userEmail:   "viewer@test.com",
 			endpoint:    "/api/v1/images",
 			action:      authorization.ActionRead,
-			shouldAllow: false,
-			description: "viewer should not have access to list images endpoint",
+			shouldAllow: true,
+			description: "viewer should have access to list images endpoint",
 		},
 		{
-			name:        "viewer cannot list secrets",
+			name:        "viewer can list secrets",
 			role:        authorization.RoleViewer,
 			userEmail:   "viewer@test.com",
 			endpoint:    "/api/v1/secrets",
 			action:      authorization.ActionRead,
-			shouldAllow: false,
-			description: "viewer should not have access to list secrets endpoint",
+			shouldAllow: true,
+			description: "viewer should have access to list the metadata of secrets",
 		},
 	}
 
@@ -695,15 +705,205 @@ func TestResourceSpecificEndpointAuthorization(t *testing.T) {
 			shouldAllow: false,
 			description: "developer should not have access to create image",
 		},
-		// Viewer role - should not have access to specific images
+		// Execution logs are reachable by every role, the service checks the execution visibility
+		{
+			name:        "developer can request execution logs",
+			role:        authorization.RoleDeveloper,
+			userEmail:   "developer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/logs",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "developer should reach the execution logs endpoint",
+		},
+		{
+			name:        "viewer can request execution logs",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/logs",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the execution logs endpoint",
+		},
+		{
+			name:        "viewer can request execution resources",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/resources",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the execution resources endpoint",
+		},
+		{
+			name:        "viewer can request the execution SLO report",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/slo",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the execution SLO report endpoint",
+		},
+		{
+			name:        "operator can clean up resources",
+			role:        authorization.RoleOperator,
+			userEmail:   "operator@test.com",
+			endpoint:    "/api/v1/health/cleanup",
+			action:      authorization.ActionCreate,
+			shouldAllow: true,
+			description: "operator should reach the resource cleanup endpoint",
+		},
+		{
+			name:        "developer cannot clean up resources",
+			role:        authorization.RoleDeveloper,
+			userEmail:   "developer@test.com",
+			endpoint:    "/api/v1/health/cleanup",
+			action:      authorization.ActionCreate,
+			shouldAllow: false,
+			description: "developer should not reach the resource cleanup endpoint",
+		},
+		{
+			name:        "operator cannot transfer service accounts",
+			role:        authorization.RoleOperator,
+			userEmail:   "operator@test.com",
+			endpoint:    "/api/v1/users/ci@test.com/owner",
+			action:      authorization.ActionUpdate,
+			shouldAllow: false,
+			description: "only admins should transfer the ownership of service accounts",
+		},
+		{
+			name:        "operator can read query statistics",
+			role:        authorization.RoleOperator,
+			userEmail:   "operator@test.com",
+			endpoint:    "/api/v1/health/stats",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "operator should reach the query statistics endpoint",
+		},
+		{
+			name:        "developer cannot read query statistics",
+			role:        authorization.RoleDeveloper,
+			userEmail:   "developer@test.com",
+			endpoint:    "/api/v1/health/stats",
+			action:      authorization.ActionRead,
+			shouldAllow: false,
+			description: "developer should not reach the query statistics endpoint",
+		},
+		{
+			name:        "viewer can request resource recommendations",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/recommendations",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the resource recommendations endpoint",
+		},
+		{
+			name:        "viewer can request execution group status",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/groups/group-123/status",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the execution group status endpoint",
+		},
+		{
+			name:        "developer cannot read execution status",
+			role:        authorization.RoleDeveloper,
+			userEmail:   "developer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/status",
+			action:      authorization.ActionRead,
+			shouldAllow: false,
+			description: "developer should not reach other execution endpoints",
+		},
 		{
-			name:        "viewer cannot read specific image",
+			name:        "developer can annotate an execution",
+			role:        authorization.RoleDeveloper,
+			userEmail:   "developer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/annotations",
+			action:      authorization.ActionCreate,
+			shouldAllow: true,
+			description: "developer should reach the execution annotations endpoint",
+		},
+		{
+			name:        "viewer cannot annotate an execution",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/annotations",
+			action:      authorization.ActionCreate,
+			shouldAllow: false,
+			description: "viewer should not annotate executions",
+		},
+		// Viewer role - read-only access to images, secrets metadata and executions
+		{
+			name:        "viewer can read specific image",
 			role:        authorization.RoleViewer,
 			userEmail:   "viewer@test.com",
 			endpoint:    "/api/v1/images/alpine:latest",
 			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should have access to read specific image",
+		},
+		{
+			name:        "viewer cannot use an image",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/images/alpine:latest",
+			action:      authorization.ActionUse,
 			shouldAllow: false,
-			description: "viewer should not have access to read specific image",
+			description: "viewer should not run with images",
+		},
+		{
+			name:        "viewer cannot reveal a secret",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/secrets/github-token",
+			action:      authorization.ActionReveal,
+			shouldAllow: false,
+			description: "viewer should only read the metadata of secrets",
+		},
+		{
+			name:        "operator can reveal a secret",
+			role:        authorization.RoleOperator,
+			userEmail:   "operator@test.com",
+			endpoint:    "/api/v1/secrets/github-token",
+			action:      authorization.ActionReveal,
+			shouldAllow: true,
+			description: "operator should read the values of secrets",
+		},
+		{
+			name:        "viewer can read execution status",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/exec-123/status",
+			action:      authorization.ActionRead,
+			shouldAllow: true,
+			description: "viewer should reach the execution status endpoint",
+		},
+		{
+			name:        "viewer cannot run commands",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/run",
+			action:      authorization.ActionCreate,
+			shouldAllow: false,
+			description: "viewer should not start executions",
+		},
+		{
+			name:        "viewer cannot kill executions",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/executions/exec-123",
+			action:      authorization.ActionDelete,
+			shouldAllow: false,
+			description: "viewer should not kill executions",
+		},
+		{
+			name:        "viewer cannot update secrets",
+			role:        authorization.RoleViewer,
+			userEmail:   "viewer@test.com",
+			endpoint:    "/api/v1/secrets/github-token",
+			action:      authorization.ActionUpdate,
+			shouldAllow: false,
+			description: "viewer should not modify secrets",
 		},
 	}
 
@@ -721,6 +921,143 @@ func TestResourceSpecificEndpointAuthorization(t *testing.T) {
 	}
 }
 
+// TestAdminEndpointAuthorization tests that admin endpoints are reserved to the admin role,
+// including for owners of a secret named like an admin action.
+func TestAdminEndpointAuthorization(t *testing.T) {
+	endpoints := []string{"/api/v1/admin/secrets/export", "/api/v1/admin/secrets/import"}
+	roles := map[authorization.Role]bool{
+		authorization.RoleAdmin:     true,
+		authorization.RoleOperator:  false,
+		authorization.RoleDeveloper: false,
+		authorization.RoleViewer:    false,
+	}
+
+	for role, shouldAllow := range roles {
+		for _, endpoint := range endpoints {
+			t.Run(string(role)+" "+endpoint, func(t *testing.T) {
+				userEmail := string(role) + "@test.com"
+				enforcer := newTestEnforcerWithRole(t, userEmail, role)
+				require.NoError(t, enforcer.AddOwnershipForResource(
+					context.Background(), authorization.FormatResourceID("secret", "export"), userEmail))
+				router := newTestRouterWithEnforcer(t, enforcer)
+
+				req := createAuthenticatedRequest("POST", endpoint, &api.User{Email: userEmail})
+
+				assert.Equal(t, shouldAllow, router.authorizeRequest(req, authorization.ActionCreate))
+			})
+		}
+	}
+}
+
+// TestImageDeletionAuthorization tests that operators can delete and restore images, and that permanently
+// removing an image is reserved to the admin role, including for the owner of the image.
+func TestImageDeletionAuthorization(t *testing.T) {
+	const imageID = "alpine:latest-a1b2c3d4"
+	requests := []struct {
+		endpoint string
+		action   authorization.Action
+		allowed  map[authorization.Role]bool
+	}{
+		{"/api/v1/images/" + imageID, authorization.ActionDelete, map[authorization.Role]bool{
+			authorization.RoleAdmin: true, authorization.RoleOperator: true,
+		}},
+		{"/api/v1/images/restore", authorization.ActionCreate, map[authorization.Role]bool{
+			authorization.RoleAdmin: true, authorization.RoleOperator: true,
+		}},
+		{"/api/v1/admin/images/" + imageID, authorization.ActionDelete, map[authorization.Role]bool{
+			authorization.RoleAdmin: true,
+		}},
+	}
+	roles := []authorization.Role{
+		authorization.RoleAdmin,
+		authorization.RoleOperator,
+		authorization.RoleDeveloper,
+		authorization.RoleViewer,
+	}
+
+	for _, role := range roles {
+		for _, r := range requests {
+			t.Run(fmt.Sprintf("%s %s %s", role, r.action, r.endpoint), func(t *testing.T) {
+				userEmail := string(role) + "@test.com"
+				enforcer := newTestEnforcerWithRole(t, userEmail, role)
+				require.NoError(t, enforcer.AddOwnershipForResource(
+					context.Background(), authorization.FormatResourceID("image", imageID), userEmail))
+				router := newTestRouterWithEnforcer(t, enforcer)
+
+				req := createAuthenticatedRequest("DELETE", r.endpoint, &api.User{Email: userEmail})
+
+				assert.Equal(t, r.allowed[role], router.authorizeRequest(req, r.action))
+			})
+		}
+	}
+}
+
+// TestImageAliasAuthorization tests that only the roles managing images can manage image aliases, which the
+// viewers can read like the other image metadata.
+func TestImageAliasAuthorization(t *testing.T) {
+	requests := []struct {
+		endpoint string
+		action   authorization.Action
+	}{
+		{"/api/v1/images/aliases", authorization.ActionCreate},
+		{"/api/v1/images/aliases/python:stable", authorization.ActionDelete},
+	}
+	allowed := map[authorization.Role]bool{authorization.RoleAdmin: true, authorization.RoleOperator: true}
+	roles := []authorization.Role{
+		authorization.RoleAdmin,
+		authorization.RoleOperator,
+		authorization.RoleDeveloper,
+		authorization.RoleViewer,
+	}
+
+	for _, role := range roles {
+		for _, r := range requests {
+			t.Run(fmt.Sprintf("%s %s %s", role, r.action, r.endpoint), func(t *testing.T) {
+				userEmail := string(role) + "@test.com"
+				router := newTestRouterWithEnforcer(t, newTestEnforcerWithRole(t, userEmail, role))
+
+				req := createAuthenticatedRequest("GET", r.endpoint, &api.User{Email: userEmail})
+
+				assert.Equal(t, allowed[role], router.authorizeRequest(req, r.action))
+			})
+		}
+	}
+}
+
+// TestExecutionPreferencesAuthorization tests that every role can star executions and manage its saved filters.
+func TestExecutionPreferencesAuthorization(t *testing.T) {
+	requests := []struct {
+		endpoint string
+		action   authorization.Action
+	}{
+		{"/api/v1/executions/exec-123/star", authorization.ActionCreate},
+		{"/api/v1/executions/exec-123/star", authorization.ActionDelete},
+		{"/api/v1/executions/filters", authorization.ActionRead},
+		{"/api/v1/executions/filters", authorization.ActionCreate},
+		{"/api/v1/executions/filters/failures", authorization.ActionDelete},
+	}
+	roles := []authorization.Role{
+		authorization.RoleAdmin,
+		authorization.RoleOperator,
+		authorization.RoleDeveloper,
+		authorization.RoleViewer,
+	}
+
+	for _, role := range roles {
+		for _, r := range requests {
+			t.Run(fmt.Sprintf("%s %s %s", role, r.action, r.endpoint), func(t *testing.T) {
+				userEmail := string(role) + "@test.com"
+				enforcer := newTestEnforcerWithRole(t, userEmail, role)
+				router := newTestRouterWithEnforcer(t, enforcer)
+
+				req := createAuthenticatedRequest("GET", r.endpoint, &api.User{Email: userEmail})
+
+				assert.True(t, router.authorizeRequest(req, r.action))
+			})
+		}
+	}
+}
+
 // testUserRepositoryWithRoles is a test user repository that returns users with valid roles
 // for testing with enforcer initialization
 type testUserRepositoryWithRoles struct{}
@@ -750,6 +1087,18 @@ func (t *testUserRepositoryWithRoles) RevokeUser(_ context.Context, _ string) er
 	return nil
 }
 
+func (*testUserRepositoryWithRoles) UpdateUser(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*testUserRepositoryWithRoles) UpdateUserPreferences(_ context.Context, _ *api.User) error {
+	return nil
+}
+
+func (*testUserRepositoryWithRoles) ReplaceAPIKeyHash(_ context.Context, _, _ string) error {
+	return nil
+}
+
 func (t *testUserRepositoryWithRoles) CreatePendingAPIKey(_ context.Context, _ *api.PendingAPIKey) error {
 	return nil
 }
@@ -766,6 +1115,14 @@ func (t *testUserRepositoryWithRoles) DeletePendingAPIKey(_ context.Context, _ s
 	return nil
 }
 
+func (t *testUserRepositoryWithRoles) ListPendingAPIKeys(_ context.Context) ([]*api.PendingAPIKey, error) {
+	return nil, nil
+}
+
+func (t *testUserRepositoryWithRoles) DeleteUser(_ context.Context, _ string) error {
+	return nil
+}
+
 func (t *testUserRepositoryWithRoles) ListUsers(_ context.Context) ([]*api.User, error) {
 	// Return users with valid roles so enforcer initialization succeeds
 	return []*api.User{
@@ -787,3 +1144,63 @@ func (t *testUserRepositoryWithRoles) ListUsers(_ context.Context) ([]*api.User,
 func (t *testUserRepositoryWithRoles) GetUsersByRequestID(_ context.Context, _ string) ([]*api.User, error) {
 	return []*api.User{}, nil
 }
+
+// TestViewerPrivateExecutionAuthorization tests that viewers, who reach the execution read endpoints,
+// are refused the private executions they do not own by the service.
+func TestViewerPrivateExecutionAuthorization(t *testing.T) {
+	ctx := context.Background()
+	executions := map[string]*api.Execution{
+		"exec-private": {
+			ExecutionID: "exec-private",
+			CreatedBy:   "owner@test.com",
+			OwnedBy:     []string{"owner@test.com"},
+			Command:     "deploy --token secret",
+			Status:      string(constants.ExecutionRunning),
+			Visibility:  string(constants.ExecutionVisibilityPrivate),
+		},
+		"exec-public": {
+			ExecutionID: "exec-public",
+			CreatedBy:   "owner@test.com",
+			OwnedBy:     []string{"owner@test.com"},
+			Command:     "make test",
+			Status:      string(constants.ExecutionRunning),
+			Visibility:  string(constants.ExecutionVisibilityPublic),
+		},
+	}
+	enforcer := newTestEnforcerWithRole(t, "viewer@test.com", authorization.RoleViewer)
+	for id, execution := range executions {
+		require.NoError(t, enforcer.SetExecutionVisibility(ctx, id, execution.Visibility))
+	}
+	router := newTestRouterWithEnforcerAndExecutions(t, enforcer, &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return executions[executionID], nil
+		},
+	})
+
+	tests := []struct {
+		executionID string
+		wantStatus  int
+	}{
+		{"exec-private", http.StatusForbidden},
+		{"exec-public", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.executionID, func(t *testing.T) {
+			endpoint := "/api/v1/executions/" + tt.executionID + "/status"
+			req := createAuthenticatedRequest(http.MethodGet, endpoint, &api.User{Email: "viewer@test.com"})
+			require.True(t, router.authorizeRequest(req, authorization.ActionRead),
+				"viewers reach the status endpoint, the service checks the execution visibility")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("executionID", tt.executionID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+			router.handleGetExecutionStatus(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusForbidden {
+				assert.NotContains(t, w.Body.String(), executions[tt.executionID].Command)
+			}
+		})
+	}
+}
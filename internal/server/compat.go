@@ -0,0 +1,94 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// The legacy action-based v1 routes, deprecated in favor of the v2 resource routes, are kept in this file
+// until their sunset date so that they can be removed at once. Setting RUNVOY_DISABLE_LEGACY_ROUTES
+// removes them before that date.
+
+// registerLegacyUsersRoutes registers the deprecated action-based v1 user routes.
+func (r *Router) registerLegacyUsersRoutes(router chi.Router) {
+	legacy := router.With(r.legacyRouteMiddleware(apiPathPrefix + constants.APIVersionV2 + "/users"))
+	legacy.Post("/create", r.handleCreateUser)
+	legacy.Post("/revoke", r.handleRevokeUser)
+}
+
+// registerLegacyImagesRoutes registers the deprecated action-based v1 image routes.
+func (r *Router) registerLegacyImagesRoutes(router chi.Router) {
+	legacy := router.With(r.legacyRouteMiddleware(apiPathPrefix + constants.APIVersionV2 + "/images"))
+	legacy.Post("/register", r.handleRegisterImage)
+}
+
+// handleRevokeUser handles POST /api/v1/users/revoke to revoke a user's API key, translating the email
+// of its body into the DELETE /api/v2/users/{email} request.
+func (r *Router) handleRevokeUser(w http.ResponseWriter, req *http.Request) {
+	var revokeReq api.RevokeUserRequest
+
+	if err := decodeRequestBody(w, req, &revokeReq); err != nil {
+		return
+	}
+
+	r.revokeUser(w, req, revokeReq.Email)
+}
+
+// legacyRouteMiddleware serves a legacy route marked deprecated, with the Deprecation and Sunset headers and
+// a link to the route replacing it in the next API version, and counts its calls to find the remaining callers.
+// When the legacy routes are disabled, it answers 410 Gone instead.
+func (r *Router) legacyRouteMiddleware(successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+			if r.svc.DisableLegacyRoutes {
+				writeErrorResponseWithCode(w, http.StatusGone, apperrors.ErrCodeLegacyRouteRemoved, "Gone",
+					"this route was removed, use "+successor)
+				return
+			}
+
+			w.Header().Set(constants.DeprecationHeader, constants.LegacyRoutesDeprecation)
+			w.Header().Set(constants.SunsetHeader, constants.LegacyRoutesSunset)
+			r.recordLegacyRouteCall(req, successor)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// recordLegacyRouteCall logs the call to a legacy route with its caller and counts it in the
+// MetricLegacyAPICalls metric.
+func (r *Router) recordLegacyRouteCall(req *http.Request, successor string) {
+	route := req.Method + " " + req.URL.Path
+	if rctx := chi.RouteContext(req.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		route = req.Method + " " + rctx.RoutePattern()
+	}
+
+	caller := ""
+	if user, ok := r.getUserFromContext(req); ok {
+		caller = user.Email
+	}
+	r.GetLoggerFromContext(req.Context()).Info("deprecated API route called",
+		"route", route,
+		"caller", caller,
+		"successor", successor,
+		"sunset", constants.LegacyRoutesSunset)
+
+	if r.svc.Metrics == nil {
+		return
+	}
+	r.svc.Metrics.RecordMetrics(req.Context(), contract.Metric{
+		Name:  constants.MetricLegacyAPICalls,
+		Value: 1,
+		Unit:  contract.MetricUnitCount,
+		Dimensions: map[string]string{
+			constants.MetricDimensionRoute: route,
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetricsRecorder struct {
+	mu      sync.Mutex
+	metrics []contract.Metric
+}
+
+func (r *recordingMetricsRecorder) RecordMetrics(_ context.Context, metrics ...contract.Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metrics...)
+}
+
+// newLegacyRoutesTestMux serves the legacy user routes of router without authentication.
+func newLegacyRoutesTestMux(router *Router) *chi.Mux {
+	mux := chi.NewRouter()
+	mux.Route("/api/v1/users", router.registerLegacyUsersRoutes)
+	return mux
+}
+
+func newLegacyRevokeRequest(t *testing.T) *http.Request {
+	body, err := json.Marshal(api.RevokeUserRequest{Email: "user@example.com"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/revoke", bytes.NewReader(body))
+	return addAuthenticatedUser(req, adminTestUser())
+}
+
+func TestLegacyRoutes_Deprecated(t *testing.T) {
+	router := newUserHandlerRouter(t, &testUserRepository{})
+	metrics := &recordingMetricsRecorder{}
+	router.svc.Metrics = metrics
+
+	w := httptest.NewRecorder()
+	newLegacyRoutesTestMux(router).ServeHTTP(w, newLegacyRevokeRequest(t))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, constants.LegacyRoutesDeprecation, w.Header().Get(constants.DeprecationHeader))
+	assert.Equal(t, constants.LegacyRoutesSunset, w.Header().Get(constants.SunsetHeader))
+	assert.Equal(t, `</api/v2/users>; rel="successor-version"`, w.Header().Get("Link"))
+
+	var resp api.RevokeUserResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "user@example.com", resp.Email)
+
+	require.Len(t, metrics.metrics, 1)
+	assert.Equal(t, constants.MetricLegacyAPICalls, metrics.metrics[0].Name)
+	assert.InDelta(t, 1, metrics.metrics[0].Value, 0)
+	assert.Equal(t, "POST /api/v1/users/revoke", metrics.metrics[0].Dimensions[constants.MetricDimensionRoute])
+}
+
+func TestLegacyRoutes_Disabled(t *testing.T) {
+	router := newUserHandlerRouter(t, &testUserRepository{})
+	metrics := &recordingMetricsRecorder{}
+	router.svc.Metrics = metrics
+	router.svc.DisableLegacyRoutes = true
+
+	w := httptest.NewRecorder()
+	newLegacyRoutesTestMux(router).ServeHTTP(w, newLegacyRevokeRequest(t))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Empty(t, w.Header().Get(constants.DeprecationHeader))
+	assert.Equal(t, `</api/v2/users>; rel="successor-version"`, w.Header().Get("Link"))
+
+	var resp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, apperrors.ErrCodeLegacyRouteRemoved, resp.Code)
+	assert.Empty(t, metrics.metrics)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+// announcementMiddleware sends the current announcement of the admins, if any, in the response headers,
+// so the CLI can show it whatever the command.
+func (r *Router) announcementMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if message := r.svc.CurrentAnnouncement(req.Context()); message != "" {
+			w.Header().Set(constants.AnnouncementHeader, message)
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// handleGetMeta handles GET /api/v1/meta to get the metadata of the backend, such as the announcement.
+func (r *Router) handleGetMeta(w http.ResponseWriter, req *http.Request) {
+	announcement, err := r.svc.GetAnnouncement(req.Context())
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get meta")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.MetaResponse{Announcement: announcement})
+}
+
+// handleSetAnnouncement handles PUT /api/v1/admin/announcement to set the announcement.
+func (r *Router) handleSetAnnouncement(w http.ResponseWriter, req *http.Request) {
+	var announcementReq api.AnnouncementRequest
+	if err := decodeRequestBody(w, req, &announcementReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	announcement, err := r.svc.SetAnnouncement(req.Context(), announcementReq.Message, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "set announcement")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.AnnouncementResponse{
+		Announcement: announcement,
+		Message:      "Announcement set successfully",
+	})
+}
+
+// handleClearAnnouncement handles DELETE /api/v1/admin/announcement to remove the announcement.
+func (r *Router) handleClearAnnouncement(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	if err := r.svc.ClearAnnouncement(req.Context(), user.Email); err != nil {
+		r.handleAndLogError(w, req, err, "clear announcement")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.AnnouncementResponse{Message: "Announcement cleared successfully"})
+}
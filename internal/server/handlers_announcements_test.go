@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAnnouncements(t *testing.T) {
+	runner := &testRunner{}
+	repos := database.Repositories{
+		User:      &testUserRepository{},
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+		Config:    fake.NewConfigRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	getMeta := func() (*httptest.ResponseRecorder, api.MetaResponse) {
+		w := httptest.NewRecorder()
+		router.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/meta", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var meta api.MetaResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&meta))
+		return w, meta
+	}
+
+	w, meta := getMeta()
+	assert.Nil(t, meta.Announcement)
+	assert.Empty(t, w.Header().Get(constants.AnnouncementHeader))
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/announcement",
+		api.AnnouncementRequest{Message: "Outage of the eu-west-1 runners until 3pm"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w, meta = getMeta()
+	require.NotNil(t, meta.Announcement)
+	assert.Equal(t, "Outage of the eu-west-1 runners until 3pm", meta.Announcement.Message)
+	assert.Equal(t, "Outage of the eu-west-1 runners until 3pm", w.Header().Get(constants.AnnouncementHeader))
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/executions", nil)
+	assert.Equal(t, "Outage of the eu-west-1 runners until 3pm", w.Header().Get(constants.AnnouncementHeader),
+		"the announcement is sent with every response")
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/announcement",
+		api.AnnouncementRequest{Message: "line one\nline two"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/admin/announcement", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w, meta = getMeta()
+	assert.Nil(t, meta.Announcement)
+	assert.Empty(t, w.Header().Get(constants.AnnouncementHeader))
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/runvoy/runvoy/internal/api"
+
 	"github.com/go-chi/chi/v5"
 )
 
@@ -33,3 +35,52 @@ func (r *Router) handleClaimAPIKey(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(claimResp)
 }
+
+// handleGetLoginConfig handles GET /api/v1/login to return the identity provider users log in with.
+func (r *Router) handleGetLoginConfig(w http.ResponseWriter, req *http.Request) {
+	resp, err := r.svc.GetLoginConfig()
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get login configuration")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleLogin handles POST /api/v1/login to exchange an ID token of the identity provider for an API key.
+func (r *Router) handleLogin(w http.ResponseWriter, req *http.Request) {
+	var loginReq api.LoginRequest
+
+	if err := decodeRequestBody(w, req, &loginReq); err != nil {
+		return
+	}
+
+	resp, err := r.svc.Login(req.Context(), loginReq)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "log in")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleExchangeGitHubToken handles POST /api/v1/github/token to exchange the OIDC token of a GitHub Actions
+// workflow for a short-lived run token.
+func (r *Router) handleExchangeGitHubToken(w http.ResponseWriter, req *http.Request) {
+	var tokenReq api.GitHubTokenRequest
+
+	if err := decodeRequestBody(w, req, &tokenReq); err != nil {
+		return
+	}
+
+	resp, err := r.svc.ExchangeGitHubToken(req.Context(), tokenReq)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "exchange GitHub token")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleListBudgets handles GET /api/v1/admin/budgets to list the budgets with the spend of the current month.
+func (r *Router) handleListBudgets(w http.ResponseWriter, req *http.Request) {
+	budgets, err := r.svc.ListBudgets(req.Context())
+	if err != nil {
+		r.handleAndLogError(w, req, err, "list budgets")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.ListBudgetsResponse{Budgets: budgets})
+}
+
+// handleSetBudget handles PUT /api/v1/admin/budgets/{email} to set the monthly budget of a user.
+func (r *Router) handleSetBudget(w http.ResponseWriter, req *http.Request) {
+	var budgetReq api.BudgetRequest
+	if err := decodeRequestBody(w, req, &budgetReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	budget, err := r.svc.SetBudget(req.Context(), chi.URLParam(req, "email"), budgetReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "set budget")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.BudgetResponse{
+		Budget:  budget,
+		Message: "Budget set successfully",
+	})
+}
+
+// handleRemoveBudget handles DELETE /api/v1/admin/budgets/{email} to remove the monthly budget of a user.
+func (r *Router) handleRemoveBudget(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	if err := r.svc.RemoveBudget(req.Context(), chi.URLParam(req, "email"), user.Email); err != nil {
+		r.handleAndLogError(w, req, err, "remove budget")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.BudgetResponse{Message: "Budget removed successfully"})
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleBudgets(t *testing.T) {
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{Image: image, ImageID: "alpine:latest-a1b2c3d4"}, nil
+		},
+		runCommandFunc: func(_ string, _ *api.ExecutionRequest) (*time.Time, error) {
+			now := time.Now()
+			return &now, nil
+		},
+	}
+	// One execution reserving 4 vCPUs and 8 GiB for 10 hours, about $1.97
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{{
+				ExecutionID: "exec-123",
+				CreatedBy:   "user@example.com",
+				StartedAt:   time.Now().UTC(),
+				ResourceSummary: &api.ResourceSummary{
+					CPUReserved: 4096, MemoryReservedMiB: 8192, BilledDurationSeconds: 36000,
+				},
+			}}, nil
+		},
+	}
+	userRepo := &testUserRepository{
+		getUserByEmailFunc: func(email string) (*api.User, error) {
+			return &api.User{Email: email}, nil
+		},
+	}
+	repos := database.Repositories{
+		User:      userRepo,
+		Execution: execRepo,
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+		Config:    fake.NewConfigRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/budgets/user@example.com",
+		api.BudgetRequest{MonthlyLimitUSD: 2, HardCap: true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var setResp api.BudgetResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&setResp))
+	require.NotNil(t, setResp.Budget)
+	assert.InDelta(t, 1.97, setResp.Budget.SpentUSD, 0.01)
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/budgets", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listResp api.ListBudgetsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listResp))
+	require.Len(t, listResp.Budgets, 1)
+	assert.Equal(t, "user@example.com", listResp.Budgets[0].UserEmail)
+	assert.True(t, listResp.Budgets[0].HardCap)
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "echo hello", Image: "alpine:latest"})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var runResp api.ExecutionResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&runResp))
+	assert.Contains(t, runResp.BudgetWarning, "of your monthly budget ($1.97 of $2.00)")
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/admin/budgets/user@example.com", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/admin/budgets/user@example.com", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errResp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+	assert.Equal(t, apperrors.ErrCodeNotFound, errResp.Code)
+}
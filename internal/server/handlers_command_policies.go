@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// handleListCommandPolicyRules handles GET /api/v1/admin/command-policies to list the command policy rules.
+func (r *Router) handleListCommandPolicyRules(w http.ResponseWriter, req *http.Request) {
+	r.handleListWithAuth(w, req,
+		func() (any, error) {
+			rules, err := r.svc.ListCommandPolicyRules(req.Context())
+			if err != nil {
+				return nil, err
+			}
+			return api.ListCommandPolicyRulesResponse{Rules: rules}, nil
+		},
+		"list command policy rules")
+}
+
+// handleGetCommandPolicyRule handles GET /api/v1/admin/command-policies/{name} to get a command policy rule.
+func (r *Router) handleGetCommandPolicyRule(w http.ResponseWriter, req *http.Request) {
+	name, ok := getRequiredURLParam(w, req, "name")
+	if !ok {
+		return
+	}
+
+	rule, err := r.svc.GetCommandPolicyRule(req.Context(), name)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get command policy rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(rule)
+}
+
+// handleCreateCommandPolicyRule handles POST /api/v1/admin/command-policies to create a command policy rule.
+func (r *Router) handleCreateCommandPolicyRule(w http.ResponseWriter, req *http.Request) {
+	var ruleReq api.CommandPolicyRuleRequest
+	if err := decodeRequestBody(w, req, &ruleReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	rule, err := r.svc.CreateCommandPolicyRule(req.Context(), &ruleReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "create command policy rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(api.CommandPolicyRuleResponse{
+		Rule:    *rule,
+		Message: "Command policy rule created successfully",
+	})
+}
+
+// handleUpdateCommandPolicyRule handles PUT /api/v1/admin/command-policies/{name} to replace a command
+// policy rule.
+func (r *Router) handleUpdateCommandPolicyRule(w http.ResponseWriter, req *http.Request) {
+	name, ok := getRequiredURLParam(w, req, "name")
+	if !ok {
+		return
+	}
+
+	var ruleReq api.CommandPolicyRuleRequest
+	if err := decodeRequestBody(w, req, &ruleReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	rule, err := r.svc.UpdateCommandPolicyRule(req.Context(), name, &ruleReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "update command policy rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.CommandPolicyRuleResponse{
+		Rule:    *rule,
+		Message: "Command policy rule updated successfully",
+	})
+}
+
+// handleDeleteCommandPolicyRule handles DELETE /api/v1/admin/command-policies/{name} to delete a command
+// policy rule.
+func (r *Router) handleDeleteCommandPolicyRule(w http.ResponseWriter, req *http.Request) {
+	name, ok := getRequiredURLParam(w, req, "name")
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	if err := r.svc.DeleteCommandPolicyRule(req.Context(), name, user.Email); err != nil {
+		r.handleAndLogError(w, req, err, "delete command policy rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.DeleteCommandPolicyRuleResponse{
+		Name:    name,
+		Message: "Command policy rule deleted successfully",
+	})
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCommandPolicyTestRouter(t *testing.T, runner *testRunner) *Router {
+	t.Helper()
+	repos := database.Repositories{
+		User:          &testUserRepository{},
+		Execution:     &testExecutionRepository{},
+		Token:         &testTokenRepository{},
+		Image:         &testImageRepository{},
+		Secrets:       &testSecretsRepository{},
+		CommandPolicy: fake.NewCommandPolicyRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	return NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+}
+
+func serveCommandPolicyRequest(router *Router, method, path string, body any) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req = addAuthToRequest(req)
+	req = addAuthenticatedUser(req, adminTestUser())
+	w := httptest.NewRecorder()
+	router.Handler().ServeHTTP(w, req)
+	return w
+}
+
+func TestHandleCommandPolicyRules(t *testing.T) {
+	router := newCommandPolicyTestRouter(t, &testRunner{})
+
+	w := serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/admin/command-policies",
+		api.CommandPolicyRuleRequest{Name: "deny-rm-root", Effect: "deny", MatchType: "prefix", Pattern: "rm -rf /"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created api.CommandPolicyRuleResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, "deny-rm-root", created.Rule.Name)
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/command-policies/deny-rm-root",
+		api.CommandPolicyRuleRequest{Effect: "deny", MatchType: "regex", Pattern: `rm\s+-rf\s+/`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/command-policies/deny-rm-root", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var rule api.CommandPolicyRule
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rule))
+	assert.Equal(t, "regex", rule.MatchType)
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/command-policies", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list api.ListCommandPolicyRulesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Len(t, list.Rules, 1)
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/admin/command-policies/deny-rm-root", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/command-policies/deny-rm-root", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleRunCommand_DeniedByCommandPolicy(t *testing.T) {
+	started := false
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{Image: image, ImageID: "alpine:latest-a1b2c3d4"}, nil
+		},
+		runCommandFunc: func(_ string, _ *api.ExecutionRequest) (*time.Time, error) {
+			started = true
+			now := time.Now()
+			return &now, nil
+		},
+	}
+	router := newCommandPolicyTestRouter(t, runner)
+
+	w := serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/admin/command-policies",
+		api.CommandPolicyRuleRequest{Name: "deny-rm-root", Effect: "deny", MatchType: "prefix", Pattern: "rm -rf /"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "rm -rf /", Image: "alpine:latest"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var errResp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+	assert.Equal(t, apperrors.ErrCodeCommandDenied, errResp.Code)
+	assert.False(t, started)
+}
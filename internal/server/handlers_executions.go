@@ -1,10 +1,13 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/constants"
@@ -26,8 +29,33 @@ func (r *Router) handleRunCommand(w http.ResponseWriter, req *http.Request) {
 	if err := decodeRequestBody(w, req, &execReq); err != nil {
 		return
 	}
+	if err := r.svc.ResolveCommandArgs(&execReq); err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+		writeErrorResponseWithCode(w, statusCode, errorCode, "invalid command", errorDetails)
+		return
+	}
+
+	// An execution started on behalf of another user is attributed to that user, its resources are
+	// authorized for that user, and the actor is recorded for the audit trail.
+	if execReq.OnBehalfOf != "" && !strings.EqualFold(execReq.OnBehalfOf, user.Email) {
+		onBehalfOf, impersonationErr := r.svc.ResolveOnBehalfOf(req.Context(), user.Email, execReq.OnBehalfOf)
+		if impersonationErr != nil {
+			statusCode, errorCode, errorDetails := extractErrorInfo(impersonationErr)
 
-	resolvedImage, err := r.svc.ResolveImage(req.Context(), execReq.Image)
+			logger.Error("impersonation denied",
+				"error", impersonationErr,
+				"status_code", statusCode,
+				"error_code", errorCode,
+				"on_behalf_of", execReq.OnBehalfOf)
+
+			writeErrorResponseWithCode(w, statusCode, errorCode, "failed to run on behalf of user", errorDetails)
+			return
+		}
+		execReq.ImpersonatedBy = user.Email
+		user = onBehalfOf
+	}
+
+	resolvedImage, err := r.svc.ResolveRunImage(req.Context(), user, execReq.Image)
 	if err != nil {
 		statusCode, errorCode, errorDetails := extractErrorInfo(err)
 
@@ -55,6 +83,18 @@ func (r *Router) handleRunCommand(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if policyErr := r.svc.EnforceCommandPolicy(req.Context(), user, &execReq, resolvedImage); policyErr != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(policyErr)
+
+		logger.Error("command denied by policy",
+			"error", policyErr,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "command denied", errorDetails)
+		return
+	}
+
 	clientIP := getClientIP(req)
 	resp, err := r.svc.RunCommand(req.Context(), user.Email, &clientIP, &execReq, resolvedImage)
 	if err != nil {
@@ -70,6 +110,62 @@ func (r *Router) handleRunCommand(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// handleCreateStdinUpload handles POST /api/v1/run/stdin to prepare the upload of the standard input
+// of an upcoming execution, for payloads too large to be sent inline with the run request.
+func (r *Router) handleCreateStdinUpload(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	if _, ok := r.requireAuthenticatedUser(w, req); !ok {
+		return
+	}
+
+	var uploadReq api.InputUploadRequest
+	if err := decodeRequestBody(w, req, &uploadReq); err != nil {
+		return
+	}
+
+	resp, err := r.svc.CreateStdinUpload(req.Context(), uploadReq.Size)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to create stdin upload", "error", err, "status_code", statusCode, "error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to create stdin upload", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleCreateContextUpload handles POST /api/v1/run/context to prepare the upload of a local directory
+// archive, extracted as the working directory of an upcoming execution.
+func (r *Router) handleCreateContextUpload(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	if _, ok := r.requireAuthenticatedUser(w, req); !ok {
+		return
+	}
+
+	var uploadReq api.InputUploadRequest
+	if err := decodeRequestBody(w, req, &uploadReq); err != nil {
+		return
+	}
+
+	resp, err := r.svc.CreateContextUpload(req.Context(), uploadReq.Size)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to create context upload", "error", err, "status_code", statusCode, "error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to create context upload", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 // handleGetExecutionLogs handles GET /api/v1/executions/{executionID}/logs to fetch logs for an execution.
 func (r *Router) handleGetExecutionLogs(w http.ResponseWriter, req *http.Request) {
 	logger := r.GetLoggerFromContext(req.Context())
@@ -146,12 +242,17 @@ func (r *Router) handleGetBackendLogsTrace(w http.ResponseWriter, req *http.Requ
 
 // handleGetExecutionStatus handles GET /api/v1/executions/{executionID}/status to fetch execution status.
 func (r *Router) handleGetExecutionStatus(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
 	executionID, ok := getRequiredURLParam(w, req, "executionID")
 	if !ok {
 		return
 	}
 
-	resp, err := r.svc.GetExecutionStatus(req.Context(), executionID)
+	resp, err := r.svc.GetExecutionStatus(req.Context(), user.Email, executionID)
 	if err != nil {
 		logger := r.GetLoggerFromContext(req.Context())
 		statusCode, errorCode, errorDetails := extractErrorInfo(err)
@@ -174,6 +275,272 @@ func (r *Router) handleGetExecutionStatus(w http.ResponseWriter, req *http.Reque
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// handleGetExecutionGroupStatus handles GET /api/v1/executions/groups/{groupID}/status to fetch
+// the aggregated status of the shards of a parallel run.
+func (r *Router) handleGetExecutionGroupStatus(w http.ResponseWriter, req *http.Request) {
+	groupID, ok := getRequiredURLParam(w, req, "groupID")
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.GetExecutionGroupStatus(req.Context(), user.Email, groupID)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to get execution group status",
+			"group_id", groupID,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(
+			w, statusCode, errorCode,
+			"failed to get execution group status for groupID "+groupID,
+			errorDetails,
+		)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetExecutionEvents handles GET /api/v1/executions/{executionID}/events to fetch the lifecycle timeline
+// of an execution.
+func (r *Router) handleGetExecutionEvents(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	executionID, ok := getRequiredURLParam(w, req, "executionID")
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.GetExecutionEvents(req.Context(), user.Email, executionID)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to get execution events",
+			"execution_id", executionID,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(
+			w, statusCode, errorCode,
+			"failed to get execution events for executionID "+executionID,
+			errorDetails,
+		)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetExecutionResult handles GET /api/v1/executions/{executionID}/result to fetch the JSON result file
+// reported by an execution.
+func (r *Router) handleGetExecutionResult(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	executionID, ok := getRequiredURLParam(w, req, "executionID")
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.GetExecutionResult(req.Context(), user.Email, executionID)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to get execution result",
+			"execution_id", executionID,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(
+			w, statusCode, errorCode,
+			"failed to get execution result for executionID "+executionID,
+			errorDetails,
+		)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleAnnotateExecution handles POST /api/v1/executions/{executionID}/annotations to attach a note
+// to an execution.
+func (r *Router) handleAnnotateExecution(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	executionID, ok := getRequiredURLParam(w, req, "executionID")
+	if !ok {
+		return
+	}
+
+	var annotationReq api.ExecutionAnnotationRequest
+	if err := decodeRequestBody(w, req, &annotationReq); err != nil {
+		return
+	}
+
+	annotation, err := r.svc.AnnotateExecution(req.Context(), user.Email, executionID, &annotationReq)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to annotate execution",
+			"execution_id", executionID,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to annotate execution", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(annotation)
+}
+
+// handleStarExecution handles POST and DELETE /api/v1/executions/{executionID}/star to star or unstar
+// an execution for the authenticated user.
+func (r *Router) handleStarExecution(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	executionID, ok := getRequiredURLParam(w, req, "executionID")
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.SetExecutionStar(req.Context(), user.Email, executionID, req.Method != http.MethodDelete)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to update execution star",
+			"execution_id", executionID,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to update execution star", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleListSavedFilters handles GET /api/v1/executions/filters to list the execution list filters
+// saved by the authenticated user.
+func (r *Router) handleListSavedFilters(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	filters, err := r.svc.ListSavedFilters(req.Context(), user.Email)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to list saved filters", "error", err, "status_code", statusCode, "error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to list saved filters", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.ListSavedFiltersResponse{Filters: filters})
+}
+
+// handleSaveFilter handles POST /api/v1/executions/filters to save an execution list filter of the
+// authenticated user under its name, replacing any filter previously saved under that name.
+func (r *Router) handleSaveFilter(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	var filter api.ExecutionListFilter
+	if err := decodeRequestBody(w, req, &filter); err != nil {
+		return
+	}
+
+	saved, err := r.svc.SaveFilter(req.Context(), user.Email, &filter)
+	if err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to save filter",
+			"name", filter.Name,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to save filter", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(saved)
+}
+
+// handleDeleteSavedFilter handles DELETE /api/v1/executions/filters/{name} to delete an execution list
+// filter saved by the authenticated user.
+func (r *Router) handleDeleteSavedFilter(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	name, ok := getRequiredURLParam(w, req, "name")
+	if !ok {
+		return
+	}
+
+	if err := r.svc.DeleteSavedFilter(req.Context(), user.Email, name); err != nil {
+		logger := r.GetLoggerFromContext(req.Context())
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to delete saved filter",
+			"name", name,
+			"error", err,
+			"status_code", statusCode,
+			"error_code", errorCode)
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to delete saved filter", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.DeleteSavedFilterResponse{
+		Name:    name,
+		Message: "Filter deleted successfully",
+	})
+}
+
 // handleKillExecution handles DELETE /api/v1/executions/{executionID} to terminate a running execution.
 func (r *Router) handleKillExecution(w http.ResponseWriter, req *http.Request) {
 	logger := r.GetLoggerFromContext(req.Context())
@@ -183,7 +550,12 @@ func (r *Router) handleKillExecution(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	resp, err := r.svc.KillExecution(req.Context(), executionID)
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.KillExecution(req.Context(), user.Email, executionID)
 	if err != nil {
 		statusCode, errorCode, errorDetails := extractErrorInfo(err)
 
@@ -208,10 +580,130 @@ func (r *Router) handleKillExecution(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// handleStopExecution handles POST /api/v1/executions/{executionID}/stop to gracefully stop a running execution.
+// The command receives SIGTERM and is given the stop grace period requested at run time before being killed.
+func (r *Router) handleStopExecution(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	executionID, ok := getRequiredURLParam(w, req, "executionID")
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.StopExecution(req.Context(), user.Email, executionID)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to stop execution",
+			"context", map[string]any{
+				"execution_id": executionID,
+				"error":        err,
+				"status_code":  statusCode,
+				"error_code":   errorCode,
+			})
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to stop execution", errorDetails)
+		return
+	}
+
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// killExecutionsQueryParams are the query parameters of a bulk kill.
+var killExecutionsQueryParams = []string{"status", "user", "older_than", "confirm"}
+
+// handleKillExecutions handles DELETE /api/v1/executions to terminate all executions matching the filters.
+// Query parameters:
+//   - status: comma-separated list of execution statuses to target (default: STARTING,RUNNING)
+//   - user: only target executions created by this user email ("me" for the authenticated user)
+//   - older_than: only target executions started at least this long ago (Go duration, e.g. "2h")
+//   - confirm: confirmation token returned by a previous call without it
+//
+// Without the confirm parameter nothing is killed and the matching executions are returned with the
+// confirmation token to send back. Any other parameter is rejected rather than ignored, as ignoring a
+// filter would widen the kill.
+//
+// Example: DELETE /api/v1/executions?status=RUNNING&user=me&older_than=2h&confirm=0123456789abcdef.
+func (r *Router) handleKillExecutions(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	query := req.URL.Query()
+	for param := range query {
+		if slices.Contains(killExecutionsQueryParams, param) {
+			continue
+		}
+		message := "unsupported " + param + " parameter"
+		if param == "tag" {
+			// Executions have no tags to filter on yet.
+			message = "filtering executions by tag is not supported"
+		}
+		writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", message,
+			"supported parameters: "+strings.Join(killExecutionsQueryParams, ", "))
+		return
+	}
+	filter := &api.KillExecutionsFilter{
+		Statuses:  getStatusesQueryParam(req),
+		CreatedBy: strings.TrimSpace(query.Get("user")),
+	}
+	if filter.CreatedBy == "me" {
+		filter.CreatedBy = user.Email
+	}
+	if olderThanParam := query.Get("older_than"); olderThanParam != "" {
+		olderThan, err := time.ParseDuration(olderThanParam)
+		if err != nil || olderThan < 0 {
+			logger.Debug("invalid older_than parameter", "context", map[string]any{
+				"error":      err,
+				"older_than": olderThanParam,
+			})
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid older_than parameter", "")
+			return
+		}
+		filter.OlderThan = olderThan
+	}
+
+	resp, err := r.svc.KillExecutions(req.Context(), user.Email, filter, query.Get("confirm"))
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to kill executions", "context", map[string]any{
+			"error":       err,
+			"status_code": statusCode,
+			"error_code":  errorCode,
+		})
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to kill executions", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 // handleListExecutions handles GET /api/v1/executions to list executions with optional filtering.
 // Query parameters:
 //   - limit: maximum number of executions to return (default: 10, use 0 to return all)
 //   - status: comma-separated list of execution statuses to filter by (e.g., "RUNNING,TERMINATING")
+//   - user: only list executions created by this user email ("me" for the authenticated user)
+//   - since: only list executions started within this duration (Go duration, e.g. "168h")
+//   - starred: only list the executions starred by the authenticated user when "true"
+//   - search: only list executions whose command, image or playbook contain all the whitespace-separated terms
+//   - filter: name of a filter saved by the authenticated user, combined with the parameters above
 //
 // Example: GET /api/v1/executions?limit=20&status=RUNNING,TERMINATING.
 func (r *Router) handleListExecutions(w http.ResponseWriter, req *http.Request) {
@@ -239,15 +731,43 @@ func (r *Router) handleListExecutions(w http.ResponseWriter, req *http.Request)
 		limit = parsedLimit
 	}
 
-	var statuses []string
-	if statusParam := req.URL.Query().Get("status"); statusParam != "" {
-		statuses = strings.Split(statusParam, ",")
-		for i, s := range statuses {
-			statuses[i] = strings.TrimSpace(s)
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	query := req.URL.Query()
+	filter := &api.ExecutionListFilter{
+		Name:      strings.TrimSpace(query.Get("filter")),
+		Statuses:  getStatusesQueryParam(req),
+		CreatedBy: strings.TrimSpace(query.Get("user")),
+		Since:     query.Get("since"),
+		Search:    strings.TrimSpace(query.Get("search")),
+	}
+	if filter.Since != "" {
+		if since, err := time.ParseDuration(filter.Since); err != nil || since <= 0 {
+			logger.Debug("invalid since parameter", "context", map[string]any{
+				"error": err,
+				"since": filter.Since,
+			})
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid since parameter", "")
+			return
+		}
+	}
+	if starredParam := query.Get("starred"); starredParam != "" {
+		starred, err := strconv.ParseBool(starredParam)
+		if err != nil {
+			logger.Debug("invalid starred parameter", "context", map[string]any{
+				"error":   err,
+				"starred": starredParam,
+			})
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid starred parameter", "")
+			return
 		}
+		filter.Starred = starred
 	}
 
-	executions, err := r.svc.ListExecutions(req.Context(), limit, statuses)
+	executions, err := r.svc.ListFilteredExecutions(req.Context(), user.Email, limit, filter)
 	if err != nil {
 		statusCode, errorCode, errorDetails := extractErrorInfo(err)
 
@@ -264,3 +784,199 @@ func (r *Router) handleListExecutions(w http.ResponseWriter, req *http.Request)
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(executions)
 }
+
+// handleDiffExecutions handles GET /api/v1/executions/diff to compare two executions.
+// Query parameters:
+//   - a, b: IDs of the executions to compare (required)
+//   - log_lines: number of final log lines to diff (default: 0, no log diff)
+//
+// Example: GET /api/v1/executions/diff?a=exec-1&b=exec-2&log_lines=50.
+func (r *Router) handleDiffExecutions(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	query := req.URL.Query()
+	executionIDA := strings.TrimSpace(query.Get("a"))
+	executionIDB := strings.TrimSpace(query.Get("b"))
+
+	logLines := 0
+	if logLinesParam := query.Get("log_lines"); logLinesParam != "" {
+		parsed, err := strconv.Atoi(logLinesParam)
+		if err != nil {
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid log_lines parameter", "")
+			return
+		}
+		logLines = parsed
+	}
+
+	resp, err := r.svc.DiffExecutions(req.Context(), user.Email, executionIDA, executionIDB, logLines)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to diff executions", "context", map[string]any{
+			"execution_id_a": executionIDA,
+			"execution_id_b": executionIDB,
+			"error":          err,
+			"status_code":    statusCode,
+			"error_code":     errorCode,
+		})
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to diff executions", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetExecutionResources handles GET /api/v1/executions/resources to fetch the latest
+// resource usage of the running executions listed to the user.
+func (r *Router) handleGetExecutionResources(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.GetExecutionResources(req.Context(), user.Email)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to get execution resources", "context", map[string]any{
+			"error":       err,
+			"status_code": statusCode,
+			"error_code":  errorCode,
+		})
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to get execution resources", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetExecutionSLOReport handles GET /api/v1/executions/slo to report the duration SLO breach rates
+// of the playbooks.
+// Query parameters:
+//   - since: Go duration of the period covered, counted back from now (default: 720h)
+//   - playbook: restricts the report to the executions of this playbook
+func (r *Router) handleGetExecutionSLOReport(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	query := req.URL.Query()
+	period := constants.DefaultSLOReportPeriod
+	if sinceParam := query.Get("since"); sinceParam != "" {
+		since, err := time.ParseDuration(sinceParam)
+		if err != nil || since <= 0 {
+			logger.Debug("invalid since parameter", "context", map[string]any{
+				"error": err,
+				"since": sinceParam,
+			})
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid since parameter", "")
+			return
+		}
+		period = since
+	}
+
+	resp, err := r.svc.GetExecutionSLOReport(req.Context(), user.Email, period, strings.TrimSpace(query.Get("playbook")))
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+
+		logger.Error("failed to get execution SLO report", "context", map[string]any{
+			"error":       err,
+			"status_code": statusCode,
+			"error_code":  errorCode,
+		})
+
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to get execution SLO report", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleStreamExecutions handles GET /api/v1/executions/stream to push execution snapshots as Server-Sent Events.
+// Query parameters:
+//   - status: comma-separated list of execution statuses to include (default: STARTING,RUNNING,TERMINATING)
+//
+// A full snapshot is sent as an "executions" event every ExecutionsStreamInterval. The server closes
+// the stream after ExecutionsStreamMaxDuration and clients are expected to reconnect.
+func (r *Router) handleStreamExecutions(w http.ResponseWriter, req *http.Request) {
+	logger := r.GetLoggerFromContext(req.Context())
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	statuses := getStatusesQueryParam(req)
+	if len(statuses) == 0 {
+		for _, status := range constants.ActiveExecutionStatuses() {
+			statuses = append(statuses, string(status))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), constants.ExecutionsStreamMaxDuration)
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+	// Best effort: servers with a write timeout shorter than the stream lifetime would cut it early.
+	_ = rc.SetWriteDeadline(time.Now().Add(constants.ExecutionsStreamMaxDuration + time.Second))
+
+	w.Header().Set(constants.ContentTypeHeader, constants.EventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(constants.ExecutionsStreamInterval)
+	defer ticker.Stop()
+
+	for {
+		executions, err := r.svc.ListVisibleExecutions(ctx, user.Email, constants.ExecutionsStreamLimit, statuses)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			statusCode, errorCode, errorDetails := extractErrorInfo(err)
+			logger.Error("failed to list executions for stream", "context", map[string]any{
+				"error":       err,
+				"status_code": statusCode,
+				"error_code":  errorCode,
+			})
+			_ = writeSSEEvent(w, api.StreamEventError, api.ErrorResponse{
+				Error:   "failed to list executions",
+				Code:    errorCode,
+				Details: errorDetails,
+			})
+			_ = rc.Flush()
+			return
+		}
+
+		if err = writeSSEEvent(w, api.StreamEventExecutions, executions); err != nil {
+			logger.Debug("executions stream closed", "error", err)
+			return
+		}
+		if err = rc.Flush(); err != nil {
+			logger.Error("executions stream does not support flushing", "error", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
 	"github.com/runvoy/runvoy/internal/backend/contract"
 	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
@@ -91,6 +94,54 @@ func TestHandleRunCommand_Success(t *testing.T) {
 	assert.NotEmpty(t, response.ExecutionID)
 }
 
+func TestHandleRunCommand_WithArgs(t *testing.T) {
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{Image: image, ImageID: "sha256:abc123"}, nil
+		},
+		runCommandFunc: func(_ string, req *api.ExecutionRequest) (*time.Time, error) {
+			assert.Equal(t, "echo 'hello world'", req.Command)
+			assert.Equal(t, []string{"echo", "hello world"}, req.Args)
+			now := time.Now()
+			return &now, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, nil, runner)
+
+	tests := []struct {
+		name     string
+		reqBody  api.ExecutionRequest
+		wantCode int
+	}{
+		{
+			name:     "resolves the command of the arguments",
+			reqBody:  api.ExecutionRequest{Args: []string{"echo", "hello world"}, Image: "alpine:latest"},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "rejects a different command",
+			reqBody: api.ExecutionRequest{
+				Command: "echo hello world", Args: []string{"echo", "hello world"}, Image: "alpine:latest",
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.reqBody)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/run", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+			w := httptest.NewRecorder()
+			router.handleRunCommand(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+		})
+	}
+}
+
 func TestHandleRunCommand_NoAuthentication(t *testing.T) {
 	router := newExecutionHandlerRouter(t, nil, nil)
 
@@ -288,6 +339,10 @@ func (s *stubWebSocketManager) NotifyExecutionCompletion(_ context.Context, _ *s
 	return nil
 }
 
+func (s *stubWebSocketManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (s *stubWebSocketManager) SendLogsToExecution(
 	_ context.Context,
 	_ *string,
@@ -461,6 +516,7 @@ func TestHandleGetExecutionStatus_Success(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "exec-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleGetExecutionStatus(w, req)
@@ -487,6 +543,7 @@ func TestHandleGetExecutionStatus_NotFound(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "nonexistent")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleGetExecutionStatus(w, req)
@@ -503,6 +560,7 @@ func TestHandleGetExecutionStatus_MissingExecutionID(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleGetExecutionStatus(w, req)
@@ -510,6 +568,506 @@ func TestHandleGetExecutionStatus_MissingExecutionID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestHandleGetExecutionStatus_PrivateExecution(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID: executionID,
+				Status:      string(constants.ExecutionRunning),
+				CreatedBy:   "owner@example.com",
+				OwnedBy:     []string{"owner@example.com"},
+				Command:     "deploy --token secret",
+				Visibility:  string(constants.ExecutionVisibilityPrivate),
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+	ctx := context.Background()
+	enforcer := router.svc.GetEnforcer()
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "operator@example.com", authorization.RoleOperator))
+	require.NoError(t, enforcer.AddRoleForUser(ctx, "owner@example.com", authorization.RoleDeveloper))
+	require.NoError(t, enforcer.AddOwnershipForResource(
+		ctx, authorization.FormatResourceID("execution", "exec-private"), "owner@example.com"))
+	require.NoError(t, enforcer.SetExecutionVisibility(ctx, "exec-private", string(constants.ExecutionVisibilityPrivate)))
+
+	newRequest := func(email, role string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/exec-private/status", http.NoBody)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("executionID", "exec-private")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		return addAuthenticatedUser(req, &api.User{Email: email, Role: role})
+	}
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionStatus(w, newRequest("operator@example.com", "operator"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "deploy --token secret")
+
+	w = httptest.NewRecorder()
+	router.handleGetExecutionStatus(w, newRequest("owner@example.com", "developer"))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// ==================== handleGetExecutionGroupStatus tests ====================
+
+func TestHandleGetExecutionGroupStatus_Success(t *testing.T) {
+	shardIndex := 0
+	execRepo := &testExecutionRepository{
+		getByGroupIDFunc: func(_ context.Context, groupID string) ([]*api.Execution, error) {
+			assert.Equal(t, "group-123", groupID)
+			return []*api.Execution{{
+				ExecutionID: "exec-123",
+				GroupID:     groupID,
+				ShardIndex:  &shardIndex,
+				Status:      string(constants.ExecutionRunning),
+				CreatedBy:   "user@example.com",
+			}}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/groups/group-123/status", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("groupID", "group-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionGroupStatus(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response api.ExecutionGroupStatusResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "group-123", response.GroupID)
+	assert.Equal(t, string(constants.ExecutionRunning), response.Status)
+	require.Len(t, response.Shards, 1)
+	assert.Equal(t, "exec-123", response.Shards[0].ExecutionID)
+}
+
+func TestHandleGetExecutionGroupStatus_NotFound(t *testing.T) {
+	router := newExecutionHandlerRouter(t, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/groups/group-unknown/status", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("groupID", "group-unknown")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionGroupStatus(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ==================== handleGetExecutionResources tests ====================
+
+func TestHandleGetExecutionResources_Success(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, statuses []string) ([]*api.Execution, error) {
+			if statuses == nil {
+				return []*api.Execution{}, nil
+			}
+			assert.Equal(t, []string{string(constants.ExecutionRunning)}, statuses)
+			return []*api.Execution{
+				{ExecutionID: "exec-1", Status: string(constants.ExecutionRunning), CreatedBy: "user@example.com"},
+			}, nil
+		},
+	}
+	runner := &testRunner{
+		fetchResourceUsageFunc: func(_ context.Context, executionIDs []string) (map[string]*api.ResourceUsage, error) {
+			assert.Equal(t, []string{"exec-1"}, executionIDs)
+			return map[string]*api.ResourceUsage{"exec-1": {CPUUtilized: 512, CPUReserved: 1024}}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, runner)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/resources", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionResources(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response api.ExecutionResourcesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response.Executions, 1)
+	require.NotNil(t, response.Executions[0].Usage)
+	assert.InDelta(t, 512, response.Executions[0].Usage.CPUUtilized, 0)
+}
+
+func TestHandleGetExecutionResources_FetchError(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{{ExecutionID: "exec-1", CreatedBy: "user@example.com"}}, nil
+		},
+	}
+	runner := &testRunner{
+		fetchResourceUsageFunc: func(_ context.Context, _ []string) (map[string]*api.ResourceUsage, error) {
+			return nil, errors.New("throttled")
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, runner)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/resources", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionResources(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// ==================== handleGetExecutionEvents tests ====================
+
+func TestHandleGetExecutionEvents_Success(t *testing.T) {
+	startedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID, StartedAt: startedAt}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/exec-123/events", http.NoBody)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", "exec-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionEvents(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response api.ExecutionEventsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "exec-123", response.ExecutionID)
+	require.Len(t, response.Events, 1)
+	assert.Equal(t, string(constants.ExecutionEventSubmitted), response.Events[0].Type)
+	assert.True(t, startedAt.Equal(response.Events[0].Timestamp))
+}
+
+func TestHandleGetExecutionEvents_NotFound(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
+			return nil, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/nonexistent/events", http.NoBody)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", "nonexistent")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionEvents(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ==================== handleGetExecutionResult tests ====================
+
+func newExecutionResultRequest(executionID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/"+executionID+"/result", http.NoBody)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", executionID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+}
+
+func TestHandleGetExecutionResult_Success(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID, Result: json.RawMessage(`{"summary":"ok"}`)}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionResult(w, newExecutionResultRequest("exec-123"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response api.ExecutionResultResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "exec-123", response.ExecutionID)
+	assert.JSONEq(t, `{"summary":"ok"}`, string(response.Result))
+}
+
+func TestHandleGetExecutionResult_NoResult(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionResult(w, newExecutionResultRequest("exec-123"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "has not reported a result")
+}
+
+// ==================== handleAnnotateExecution tests ====================
+
+func newAnnotateExecutionRequest(t *testing.T, executionID, note string) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(api.ExecutionAnnotationRequest{Note: note})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/"+executionID+"/annotations", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", executionID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+}
+
+func TestHandleAnnotateExecution_Success(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	w := httptest.NewRecorder()
+	router.handleAnnotateExecution(w, newAnnotateExecutionRequest(t, "exec-123", "caused by an upstream outage"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response api.ExecutionAnnotation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "caused by an upstream outage", response.Note)
+	assert.Equal(t, "user@example.com", response.Author)
+}
+
+func TestHandleAnnotateExecution_NoteTooLong(t *testing.T) {
+	router := newExecutionHandlerRouter(t, nil, nil)
+
+	w := httptest.NewRecorder()
+	router.handleAnnotateExecution(w,
+		newAnnotateExecutionRequest(t, "exec-123", strings.Repeat("a", constants.MaxAnnotationLength+1)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+// ==================== execution preferences tests ====================
+
+// newPreferencesHandlerRouter returns a router whose user repository stores the preferences of user@example.com.
+func newPreferencesHandlerRouter(t *testing.T, execRepo *testExecutionRepository, user *api.User) *Router {
+	t.Helper()
+	if execRepo == nil {
+		execRepo = &testExecutionRepository{}
+	}
+	userRepo := &testUserRepository{
+		getUserByEmailFunc: func(email string) (*api.User, error) {
+			if email != user.Email {
+				return nil, nil
+			}
+			return user, nil
+		},
+		updateUserPreferencesFunc: func(_ context.Context, updated *api.User) error {
+			*user = *updated
+			return nil
+		},
+	}
+	svc := newTestOrchestratorService(t, userRepo, execRepo, nil, &testRunner{}, nil, nil, nil)
+	return &Router{svc: svc}
+}
+
+func newPreferencesRequest(t *testing.T, method, path string, body any, params map[string]string) *http.Request {
+	t.Helper()
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+}
+
+func TestHandleStarExecution(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{ExecutionID: executionID}, nil
+		},
+	}
+	user := &api.User{Email: "user@example.com", Role: "admin"}
+	router := newPreferencesHandlerRouter(t, execRepo, user)
+	params := map[string]string{"executionID": "exec-123"}
+
+	w := httptest.NewRecorder()
+	router.handleStarExecution(w,
+		newPreferencesRequest(t, http.MethodPost, "/api/v1/executions/exec-123/star", nil, params))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response api.StarExecutionResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.True(t, response.Starred)
+	assert.Equal(t, []string{"exec-123"}, user.StarredExecutions)
+
+	w = httptest.NewRecorder()
+	router.handleStarExecution(w,
+		newPreferencesRequest(t, http.MethodDelete, "/api/v1/executions/exec-123/star", nil, params))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.False(t, response.Starred)
+	assert.Empty(t, user.StarredExecutions)
+}
+
+func TestHandleSavedFilters(t *testing.T) {
+	user := &api.User{Email: "user@example.com", Role: "admin"}
+	router := newPreferencesHandlerRouter(t, nil, user)
+
+	w := httptest.NewRecorder()
+	router.handleSaveFilter(w, newPreferencesRequest(t, http.MethodPost, "/api/v1/executions/filters",
+		api.ExecutionListFilter{Name: "failures", Statuses: []string{"failed"}, CreatedBy: "me"}, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.handleListSavedFilters(w, newPreferencesRequest(t, http.MethodGet, "/api/v1/executions/filters", nil, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response api.ListSavedFiltersResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, []api.ExecutionListFilter{
+		{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me"},
+	}, response.Filters)
+
+	w = httptest.NewRecorder()
+	router.handleDeleteSavedFilter(w, newPreferencesRequest(t, http.MethodDelete,
+		"/api/v1/executions/filters/failures", nil, map[string]string{"name": "failures"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, user.SavedFilters)
+
+	w = httptest.NewRecorder()
+	router.handleDeleteSavedFilter(w, newPreferencesRequest(t, http.MethodDelete,
+		"/api/v1/executions/filters/failures", nil, map[string]string{"name": "failures"}))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleSaveFilter_InvalidName(t *testing.T) {
+	router := newPreferencesHandlerRouter(t, nil, &api.User{Email: "user@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleSaveFilter(w, newPreferencesRequest(t, http.MethodPost, "/api/v1/executions/filters",
+		api.ExecutionListFilter{Name: "my failures"}, nil))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestHandleListExecutions_SavedFilter(t *testing.T) {
+	now := time.Now()
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, statuses []string) ([]*api.Execution, error) {
+			if statuses == nil {
+				return []*api.Execution{}, nil
+			}
+			assert.Equal(t, []string{"FAILED"}, statuses)
+			return []*api.Execution{
+				{ExecutionID: "exec-1", CreatedBy: "user@example.com", StartedAt: now},
+				{ExecutionID: "exec-2", CreatedBy: "other@example.com", StartedAt: now},
+				{ExecutionID: "exec-3", CreatedBy: "user@example.com", StartedAt: now.Add(-30 * 24 * time.Hour)},
+			}, nil
+		},
+	}
+	user := &api.User{
+		Email:             "user@example.com",
+		StarredExecutions: []string{"exec-1"},
+		SavedFilters: []api.ExecutionListFilter{
+			{Name: "failures", Statuses: []string{"FAILED"}, CreatedBy: "me", Since: "168h"},
+		},
+	}
+	router := newPreferencesHandlerRouter(t, execRepo, user)
+
+	w := httptest.NewRecorder()
+	router.handleListExecutions(w,
+		newPreferencesRequest(t, http.MethodGet, "/api/v1/executions?filter=failures", nil, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []*api.Execution
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "exec-1", response[0].ExecutionID)
+	assert.True(t, response[0].Starred)
+}
+
+func TestHandleListExecutions_InvalidFilterParameters(t *testing.T) {
+	router := newExecutionHandlerRouter(t, nil, nil)
+
+	for _, query := range []string{"since=a-week", "since=-1h", "starred=maybe"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?"+query, http.NoBody)
+			req = addAuthenticatedUser(req, adminTestUser())
+
+			w := httptest.NewRecorder()
+			router.handleListExecutions(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestHandleListExecutions_UnknownSavedFilter(t *testing.T) {
+	router := newPreferencesHandlerRouter(t, nil, &api.User{Email: "user@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleListExecutions(w,
+		newPreferencesRequest(t, http.MethodGet, "/api/v1/executions?filter=unknown", nil, nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleGetExecutionSLOReport(t *testing.T) {
+	now := time.Now().UTC()
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, _ []string) ([]*api.Execution, error) {
+			return []*api.Execution{{
+				ExecutionID:                "exec-1",
+				CreatedBy:                  "user@example.com",
+				Playbook:                   "deploy",
+				ExpectedMaxDurationSeconds: 300,
+				StartedAt:                  now,
+				CompletedAt:                &now,
+				SLOBreached:                true,
+			}}, nil
+		},
+	}
+	router := newPreferencesHandlerRouter(t, execRepo, &api.User{Email: "user@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleGetExecutionSLOReport(w,
+		newPreferencesRequest(t, http.MethodGet, "/api/v1/executions/slo?since=168h&playbook=deploy", nil, nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var report api.ExecutionSLOReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Len(t, report.Playbooks, 1)
+	assert.Equal(t, "deploy", report.Playbooks[0].Playbook)
+	assert.Equal(t, 1, report.Playbooks[0].Breaches)
+}
+
+func TestHandleGetExecutionSLOReport_InvalidSince(t *testing.T) {
+	router := newPreferencesHandlerRouter(t, nil, &api.User{Email: "user@example.com"})
+
+	for _, since := range []string{"a-week", "-1h", "10000h"} {
+		w := httptest.NewRecorder()
+		router.handleGetExecutionSLOReport(w,
+			newPreferencesRequest(t, http.MethodGet, "/api/v1/executions/slo?since="+since, nil, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, since)
+	}
+}
+
 // ==================== handleKillExecution tests ====================
 
 func TestHandleKillExecution_Success(t *testing.T) {
@@ -531,6 +1089,7 @@ func TestHandleKillExecution_Success(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "exec-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleKillExecution(w, req)
@@ -553,6 +1112,7 @@ func TestHandleKillExecution_NotFound(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "nonexistent")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleKillExecution(w, req)
@@ -569,6 +1129,7 @@ func TestHandleKillExecution_MissingExecutionID(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleKillExecution(w, req)
@@ -576,6 +1137,61 @@ func TestHandleKillExecution_MissingExecutionID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestHandleStopExecution_Success(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID:            executionID,
+				Status:                 string(constants.ExecutionRunning),
+				CreatedBy:              "user@example.com",
+				StopGracePeriodSeconds: 30,
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/exec-123/stop", http.NoBody)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", "exec-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleStopExecution(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.KillExecutionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "exec-123", resp.ExecutionID)
+}
+
+func TestHandleStopExecution_WithoutGracePeriod(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			return &api.Execution{
+				ExecutionID: executionID,
+				Status:      string(constants.ExecutionRunning),
+				CreatedBy:   "user@example.com",
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/exec-123/stop", http.NoBody)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("executionID", "exec-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleStopExecution(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "use kill instead")
+}
+
 func TestHandleKillExecution_NoContent(t *testing.T) {
 	execRepo := &testExecutionRepository{
 		getExecutionFunc: func(_ context.Context, _ string) (*api.Execution, error) {
@@ -590,6 +1206,7 @@ func TestHandleKillExecution_NoContent(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("executionID", "exec-finished")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
 
 	w := httptest.NewRecorder()
 	router.handleKillExecution(w, req)
@@ -629,6 +1246,7 @@ func TestHandleListExecutions_Success(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -657,6 +1275,7 @@ func TestHandleListExecutions_WithLimit(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?limit=20", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -680,6 +1299,7 @@ func TestHandleListExecutions_WithStatusFilter(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?status=RUNNING,TERMINATING", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -703,6 +1323,7 @@ func TestHandleListExecutions_WithStatusFilterAndLimit(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?limit=50&status=SUCCEEDED", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -730,6 +1351,7 @@ func TestHandleListExecutions_InvalidLimit(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?limit="+tt.limit, http.NoBody)
+			req = addAuthenticatedUser(req, adminTestUser())
 
 			w := httptest.NewRecorder()
 			router.handleListExecutions(w, req)
@@ -749,6 +1371,7 @@ func TestHandleListExecutions_ZeroLimit(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?limit=0", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -765,6 +1388,7 @@ func TestHandleListExecutions_EmptyResult(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -791,6 +1415,7 @@ func TestHandleListExecutions_ServiceError(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -816,6 +1441,7 @@ func TestHandleListExecutions_MultipleStatusesWithSpaces(t *testing.T) {
 	router := newExecutionHandlerRouter(t, execRepo, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions?status=RUNNING,%20PENDING%20,%20SUCCEEDED", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
 
 	w := httptest.NewRecorder()
 	router.handleListExecutions(w, req)
@@ -823,6 +1449,189 @@ func TestHandleListExecutions_MultipleStatusesWithSpaces(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestHandleStreamExecutions_SendsSnapshots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(limit int, statuses []string) ([]*api.Execution, error) {
+			// Called both during enforcer initialization (limit=0) and by the stream
+			if limit == 0 {
+				return []*api.Execution{}, nil
+			}
+			assert.Equal(t, constants.ExecutionsStreamLimit, limit)
+			assert.Equal(t, []string{"STARTING", "RUNNING", "TERMINATING"}, statuses)
+			cancel()
+			return []*api.Execution{
+				{ExecutionID: "exec-1", Status: string(constants.ExecutionRunning), CreatedBy: "user@example.com"},
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/stream", http.NoBody).WithContext(ctx)
+	req = addAuthenticatedUser(req, adminTestUser())
+
+	w := httptest.NewRecorder()
+	router.handleStreamExecutions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, constants.EventStreamContentType, w.Header().Get(constants.ContentTypeHeader))
+	assert.True(t, w.Flushed)
+	assert.Contains(t, w.Body.String(), "event: executions\ndata: [")
+	assert.Contains(t, w.Body.String(), `"execution_id":"exec-1"`)
+}
+
+func TestHandleStreamExecutions_WithStatusFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(limit int, statuses []string) ([]*api.Execution, error) {
+			if limit == 0 {
+				return []*api.Execution{}, nil
+			}
+			assert.Equal(t, []string{"RUNNING"}, statuses)
+			cancel()
+			return []*api.Execution{}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/stream?status=RUNNING", http.NoBody).WithContext(ctx)
+	req = addAuthenticatedUser(req, adminTestUser())
+
+	w := httptest.NewRecorder()
+	router.handleStreamExecutions(w, req)
+
+	assert.Contains(t, w.Body.String(), "event: executions\ndata: []")
+}
+
+func TestHandleStreamExecutions_SendsErrorEvent(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(limit int, _ []string) ([]*api.Execution, error) {
+			if limit == 0 {
+				return []*api.Execution{}, nil
+			}
+			return nil, apperrors.ErrDatabaseError("database unavailable", errors.New("boom"))
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/stream", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
+
+	w := httptest.NewRecorder()
+	router.handleStreamExecutions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "event: error\ndata: ")
+	assert.Contains(t, w.Body.String(), "failed to list executions")
+}
+
+func TestHandleKillExecutions_DryRun(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, statuses []string) ([]*api.Execution, error) {
+			// Called during enforcer initialization without statuses
+			if statuses == nil {
+				return []*api.Execution{}, nil
+			}
+			assert.Equal(t, []string{"RUNNING"}, statuses)
+			return []*api.Execution{
+				{ExecutionID: "exec-1", Status: "RUNNING", CreatedBy: "alice@example.com",
+					StartedAt: time.Now().Add(-3 * time.Hour)},
+				{ExecutionID: "exec-2", Status: "RUNNING", CreatedBy: "bob@example.com",
+					StartedAt: time.Now().Add(-3 * time.Hour)},
+				{ExecutionID: "exec-3", Status: "RUNNING", CreatedBy: "alice@example.com",
+					StartedAt: time.Now()},
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/executions?status=RUNNING&user=me&older_than=2h", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "alice@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleKillExecutions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp api.KillExecutionsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"exec-1"}, resp.ExecutionIDs)
+	assert.NotEmpty(t, resp.ConfirmationToken)
+}
+
+func TestHandleKillExecutions_StaleConfirmation(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, statuses []string) ([]*api.Execution, error) {
+			if statuses == nil {
+				return []*api.Execution{}, nil
+			}
+			return []*api.Execution{{ExecutionID: "exec-1", Status: "RUNNING"}}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/executions?confirm=stale", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "admin@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleKillExecutions(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandleKillExecutions_InvalidOlderThan(t *testing.T) {
+	router := newExecutionHandlerRouter(t, nil, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/executions?older_than=yesterday", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "admin@example.com"})
+
+	w := httptest.NewRecorder()
+	router.handleKillExecutions(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleKillExecutions_UnsupportedFilter(t *testing.T) {
+	for _, query := range []string{"tag=ci", "status=RUNNING&image=alpine"} {
+		t.Run(query, func(t *testing.T) {
+			matched := false
+			execRepo := &testExecutionRepository{
+				listExecutionsFunc: func(_ int, statuses []string) ([]*api.Execution, error) {
+					// Called during enforcer initialization without statuses
+					matched = matched || statuses != nil
+					return []*api.Execution{}, nil
+				},
+			}
+			router := newExecutionHandlerRouter(t, execRepo, nil)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/executions?"+query+"&confirm=token", http.NoBody)
+			req = addAuthenticatedUser(req, &api.User{Email: "admin@example.com"})
+
+			w := httptest.NewRecorder()
+			router.handleKillExecutions(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.False(t, matched, "no execution must be matched with an unsupported filter")
+		})
+	}
+}
+
+func TestHandleKillExecutions_Unauthenticated(t *testing.T) {
+	router := newExecutionHandlerRouter(t, nil, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/executions", http.NoBody)
+
+	w := httptest.NewRecorder()
+	router.handleKillExecutions(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 // ==================== Benchmark tests ====================
 
 func BenchmarkHandleRunCommand(b *testing.B) {
@@ -870,3 +1679,101 @@ func BenchmarkHandleListExecutions(b *testing.B) {
 		router.handleListExecutions(w, req)
 	}
 }
+
+func TestHandleDiffExecutions_Success(t *testing.T) {
+	execRepo := &testExecutionRepository{
+		getExecutionFunc: func(_ context.Context, executionID string) (*api.Execution, error) {
+			exitCode := 0
+			if executionID == "exec-b" {
+				exitCode = 1
+			}
+			return &api.Execution{
+				ExecutionID: executionID,
+				Status:      string(constants.ExecutionSucceeded),
+				Command:     "make test",
+				ExitCode:    exitCode,
+			}, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/diff?a=exec-a&b=exec-b", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleDiffExecutions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.ExecutionDiffResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "exec-a", resp.ExecutionA.ExecutionID)
+	assert.Equal(t, "exec-b", resp.ExecutionB.ExecutionID)
+	assert.Equal(t, []api.ExecutionFieldDiff{{Field: "exit_code", A: "0", B: "1"}}, resp.Differences)
+}
+
+func TestHandleDiffExecutions_InvalidLogLines(t *testing.T) {
+	router := newExecutionHandlerRouter(t, &testExecutionRepository{}, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/diff?a=exec-a&b=exec-b&log_lines=abc", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleDiffExecutions(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "log_lines")
+}
+
+func TestHandleDiffExecutions_MissingExecution(t *testing.T) {
+	router := newExecutionHandlerRouter(t, &testExecutionRepository{}, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/diff?a=exec-a", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleDiffExecutions(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleCreateStdinUpload(t *testing.T) {
+	router := newExecutionHandlerRouter(t, &testExecutionRepository{}, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/run/stdin", bytes.NewBufferString(`{"size": 4096}`))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleCreateStdinUpload(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.InputUploadResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.UploadID, 32)
+}
+
+func TestHandleCreateStdinUpload_InvalidSize(t *testing.T) {
+	router := newExecutionHandlerRouter(t, &testExecutionRepository{}, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/run/stdin", bytes.NewBufferString(`{"size": 0}`))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleCreateStdinUpload(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleCreateContextUpload(t *testing.T) {
+	router := newExecutionHandlerRouter(t, &testExecutionRepository{}, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/run/context", bytes.NewBufferString(`{"size": 2048}`))
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleCreateContextUpload(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp api.InputUploadResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.UploadID, 32)
+}
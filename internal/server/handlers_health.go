@@ -3,8 +3,10 @@ package server
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
 	"github.com/runvoy/runvoy/internal/constants"
 )
 
@@ -17,14 +19,35 @@ func (r *Router) handleHealth(w http.ResponseWriter, _ *http.Request) {
 		Version:  *constants.GetVersion(),
 		Region:   r.svc.Region,
 		Provider: r.svc.Provider,
+
+		MinClientVersion: constants.MinClientVersion,
 	})
 }
 
 // handleReconcileHealth triggers a full health reconciliation across managed resources.
 // It requires authentication and is intended for admin/maintenance use.
+// Query parameters:
+//   - canary: when "true", evaluates the previous canary execution and launches a new one as the caller
 func (r *Router) handleReconcileHealth(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set(constants.ContentTypeHeader, "application/json")
-	report, err := r.svc.ReconcileResources(req.Context())
+
+	var opts orchestrator.ReconcileOptions
+	if canary := req.URL.Query().Get("canary"); canary != "" {
+		enabled, parseErr := strconv.ParseBool(canary)
+		if parseErr != nil {
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid canary parameter", "")
+			return
+		}
+		if enabled {
+			user, ok := r.requireAuthenticatedUser(w, req)
+			if !ok {
+				return
+			}
+			opts.CanaryUserEmail = user.Email
+		}
+	}
+
+	report, err := r.svc.ReconcileResources(req.Context(), opts)
 	if err != nil {
 		statusCode, errorCode, errorDetails := extractErrorInfo(err)
 
@@ -55,3 +78,78 @@ func (r *Router) handleReconcileHealth(w http.ResponseWriter, req *http.Request)
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
+
+// handleListHealthReports handles GET /api/v1/health/reports to list stored reconciliation reports.
+// Query parameters:
+//   - limit: maximum number of reports to return, most recent first (default: 20)
+//
+// Example: GET /api/v1/health/reports?limit=50.
+func (r *Router) handleListHealthReports(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set(constants.ContentTypeHeader, "application/json")
+
+	limit := constants.DefaultHealthReportListLimit
+	if limitParam := req.URL.Query().Get("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid limit parameter", "")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	reports, err := r.svc.ListHealthReports(req.Context(), limit)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to list health reports", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.HealthReportsResponse{Reports: reports})
+}
+
+// handleCleanupHealth handles POST /api/v1/health/cleanup to delete the provider resources created
+// by runvoy that are no longer referenced.
+// Query parameters:
+//   - dry_run: when "true", the resources are only reported
+//
+// Example: POST /api/v1/health/cleanup?dry_run=true.
+func (r *Router) handleCleanupHealth(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set(constants.ContentTypeHeader, "application/json")
+
+	dryRun := false
+	if dryRunParam := req.URL.Query().Get("dry_run"); dryRunParam != "" {
+		parsed, parseErr := strconv.ParseBool(dryRunParam)
+		if parseErr != nil {
+			writeErrorResponseWithCode(w, http.StatusBadRequest, "invalid_request", "invalid dry_run parameter", "")
+			return
+		}
+		dryRun = parsed
+	}
+
+	report, err := r.svc.CleanupResources(req.Context(), dryRun)
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to clean up resources", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.HealthCleanupResponse{Status: "ok", Report: report})
+}
+
+// handleGetHealthStats handles GET /api/v1/health/stats to report the latency and item counts of the
+// database queries run by the backend instance serving the request, by access pattern.
+func (r *Router) handleGetHealthStats(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set(constants.ContentTypeHeader, "application/json")
+
+	stats, err := r.svc.GetDatabaseStats()
+	if err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+		writeErrorResponseWithCode(w, statusCode, errorCode, "failed to get stats", errorDetails)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.HealthStatsResponse{Database: stats})
+}
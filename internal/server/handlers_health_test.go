@@ -11,7 +11,10 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/backend/contract"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
 	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +22,7 @@ import (
 
 type mockHealthManager struct {
 	reconcileFunc func(ctx context.Context) (*api.HealthReport, error)
+	cleanupFunc   func(ctx context.Context, dryRun bool) (*api.CleanupReport, error)
 }
 
 func (m *mockHealthManager) Reconcile(ctx context.Context) (*api.HealthReport, error) {
@@ -28,6 +32,13 @@ func (m *mockHealthManager) Reconcile(ctx context.Context) (*api.HealthReport, e
 	return nil, nil
 }
 
+func (m *mockHealthManager) Cleanup(ctx context.Context, dryRun bool) (*api.CleanupReport, error) {
+	if m != nil && m.cleanupFunc != nil {
+		return m.cleanupFunc(ctx, dryRun)
+	}
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
 func newHealthTestRouter(t testing.TB, hm contract.HealthManager) *Router {
 	svc := newTestOrchestratorService(t, nil, nil, nil, nil, nil, nil, hm)
 	return &Router{svc: svc}
@@ -114,6 +125,7 @@ func TestHandleHealth_VersionInResponse(t *testing.T) {
 	assert.NotEmpty(t, response.Version)
 	assert.Equal(t, constants.AWS, response.Provider)
 	assert.Equal(t, testRegion, response.Region)
+	assert.Equal(t, constants.MinClientVersion, response.MinClientVersion)
 }
 
 func TestHandleReconcileHealth_Success(t *testing.T) {
@@ -239,6 +251,39 @@ func TestHandleReconcileHealth_NilReport(t *testing.T) {
 	assert.Contains(t, response.Details, "health reconciliation returned no report")
 }
 
+func TestHandleReconcileHealth_CanaryParameter(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		reconciled     bool
+	}{
+		{name: "invalid value", query: "?canary=maybe", expectedStatus: http.StatusBadRequest},
+		{name: "requires an authenticated user", query: "?canary=true", expectedStatus: http.StatusUnauthorized},
+		{name: "disabled", query: "?canary=false", expectedStatus: http.StatusOK, reconciled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciled := false
+			router := newHealthTestRouter(t, &mockHealthManager{
+				reconcileFunc: func(_ context.Context) (*api.HealthReport, error) {
+					reconciled = true
+					return &api.HealthReport{}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/health/reconcile"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+
+			router.handleReconcileHealth(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.reconciled, reconciled)
+		})
+	}
+}
+
 func TestHandleReconcileHealth_WithIssues(t *testing.T) {
 	now := time.Now()
 	reportWithIssues := &api.HealthReport{
@@ -444,3 +489,175 @@ func TestHandleHealth_VersionConstant(t *testing.T) {
 	assert.Equal(t, constants.AWS, response.Provider)
 	assert.Equal(t, testRegion, response.Region)
 }
+
+type testHealthReportRepository struct {
+	reports   []api.HealthReport
+	listLimit int
+}
+
+func (r *testHealthReportRepository) CreateHealthReport(_ context.Context, report *api.HealthReport) error {
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+func (r *testHealthReportRepository) ListHealthReports(_ context.Context, limit int) ([]api.HealthReport, error) {
+	r.listLimit = limit
+	return r.reports, nil
+}
+
+func newHealthReportsTestRouter(t *testing.T, reportRepo database.HealthReportRepository) *Router {
+	repos := database.Repositories{
+		User:         &testUserRepository{},
+		Execution:    &testExecutionRepository{},
+		Token:        &testTokenRepository{},
+		Image:        &testImageRepository{},
+		Secrets:      &testSecretsRepository{},
+		HealthReport: reportRepo,
+	}
+	svc, err := orchestrator.NewService(
+		context.Background(),
+		testRegion,
+		&repos,
+		&testRunner{},
+		&testRunner{},
+		&testRunner{},
+		&testRunner{},
+		testutil.SilentLogger(),
+		constants.AWS,
+		&testWebSocketManager{},
+		&noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t),
+	)
+	require.NoError(t, err)
+	return &Router{svc: svc}
+}
+
+func TestHandleListHealthReports(t *testing.T) {
+	now := time.Now().UTC()
+	reportRepo := &testHealthReportRepository{reports: []api.HealthReport{
+		{Timestamp: now, ErrorCount: 2, ComputeStatus: api.ComputeHealthStatus{RecreatedCount: 1}},
+	}}
+	router := newHealthReportsTestRouter(t, reportRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health/reports?limit=50", http.NoBody)
+	w := httptest.NewRecorder()
+	router.handleListHealthReports(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 50, reportRepo.listLimit)
+
+	var response api.HealthReportsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response.Reports, 1)
+	assert.Equal(t, 2, response.Reports[0].ErrorCount)
+	assert.Equal(t, 1, response.Reports[0].ComputeStatus.RecreatedCount)
+}
+
+func TestHandleListHealthReports_DefaultLimit(t *testing.T) {
+	reportRepo := &testHealthReportRepository{}
+	router := newHealthReportsTestRouter(t, reportRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health/reports", http.NoBody)
+	w := httptest.NewRecorder()
+	router.handleListHealthReports(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, constants.DefaultHealthReportListLimit, reportRepo.listLimit)
+}
+
+func TestHandleListHealthReports_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		reportRepo database.HealthReportRepository
+		query      string
+		wantStatus int
+	}{
+		{"non numeric limit", &testHealthReportRepository{}, "?limit=abc", http.StatusBadRequest},
+		{"limit too large", &testHealthReportRepository{}, "?limit=100000", http.StatusBadRequest},
+		{"limit zero", &testHealthReportRepository{}, "?limit=0", http.StatusBadRequest},
+		{"history not configured", nil, "", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newHealthReportsTestRouter(t, tt.reportRepo)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/health/reports"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			router.handleListHealthReports(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandleCleanupHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		cleanupErr error
+		wantStatus int
+		wantDryRun bool
+	}{
+		{"cleanup", "", nil, http.StatusOK, false},
+		{"dry run", "?dry_run=true", nil, http.StatusOK, true},
+		{"invalid dry run", "?dry_run=maybe", nil, http.StatusBadRequest, false},
+		{"cleanup error", "", assert.AnError, http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDryRun bool
+			router := newHealthTestRouter(t, &mockHealthManager{
+				cleanupFunc: func(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+					gotDryRun = dryRun
+					if tt.cleanupErr != nil {
+						return nil, tt.cleanupErr
+					}
+					return &api.CleanupReport{DryRun: dryRun, DeletedCount: 1}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/health/cleanup"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			router.handleCleanupHealth(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantDryRun, gotDryRun)
+			if tt.wantStatus == http.StatusOK {
+				var response api.HealthCleanupResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, tt.wantDryRun, response.Report.DryRun)
+			}
+		})
+	}
+}
+
+func TestHandleGetHealthStats(t *testing.T) {
+	t.Run("returns the query statistics", func(t *testing.T) {
+		router := newHealthTestRouter(t, &mockHealthManager{})
+		router.svc.QueryStats = database.NewQueryStats(100 * time.Millisecond)
+		router.svc.QueryStats.Record("Query runvoy-executions/all-started_at", 150*time.Millisecond, 20, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health/stats", http.NoBody)
+		w := httptest.NewRecorder()
+		router.handleGetHealthStats(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response api.HealthStatsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, int64(100), response.Database.SlowQueryThresholdMs)
+		require.Len(t, response.Database.Queries, 1)
+		assert.Equal(t, int64(1), response.Database.Queries[0].Slow)
+	})
+
+	t.Run("unavailable without instrumentation", func(t *testing.T) {
+		router := newHealthTestRouter(t, &mockHealthManager{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health/stats", http.NoBody)
+		w := httptest.NewRecorder()
+		router.handleGetHealthStats(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/runvoy/runvoy/internal/api"
 )
 
-// handleRegisterImage handles POST /api/v1/images/register to register a new Docker image.
+// handleRegisterImage handles POST /api/v1/images/register and POST /api/v2/images to register a new Docker image.
 func (r *Router) handleRegisterImage(w http.ResponseWriter, req *http.Request) {
 	var registerReq api.RegisterImageRequest
 
@@ -59,7 +59,50 @@ func (r *Router) handleGetImage(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(imageInfo)
 }
 
-// handleRemoveImage handles DELETE /api/v1/images/{image} to remove a registered Docker image.
+// handleDeleteImage handles DELETE /api/v1/images/{image} to delete a registered Docker image.
+// The image is kept and can be restored until it is purged after constants.ImageDeletionRetention.
+// The image parameter may contain slashes and colons and uses a catch-all (*) route to match paths with slashes.
+func (r *Router) handleDeleteImage(w http.ResponseWriter, req *http.Request) {
+	image, ok := getImagePath(w, req)
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.DeleteImage(req.Context(), image, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "delete image")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleRestoreImage handles POST /api/v1/images/restore to restore a deleted Docker image.
+func (r *Router) handleRestoreImage(w http.ResponseWriter, req *http.Request) {
+	var restoreReq api.RestoreImageRequest
+
+	if err := decodeRequestBody(w, req, &restoreReq); err != nil {
+		return
+	}
+
+	resp, err := r.svc.RestoreImage(req.Context(), restoreReq.Image)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "restore image")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleRemoveImage handles DELETE /api/v1/admin/images/{image} to permanently remove a registered Docker image,
+// deleted or not, without waiting for the retention window.
 // The image parameter may contain slashes and colons and uses a catch-all (*) route to match paths with slashes.
 func (r *Router) handleRemoveImage(w http.ResponseWriter, req *http.Request) {
 	image, ok := getImagePath(w, req)
@@ -79,3 +122,69 @@ func (r *Router) handleRemoveImage(w http.ResponseWriter, req *http.Request) {
 		Message: "Image removed successfully",
 	})
 }
+
+// handleGetResourceRecommendations handles GET /api/v1/recommendations to recommend lower CPU and memory
+// for the images whose executions listed to the user use far less than they reserve.
+func (r *Router) handleGetResourceRecommendations(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.GetResourceRecommendations(req.Context(), user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get resource recommendations")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleSetImageAlias handles POST /api/v1/images/aliases to create an image alias or point it at another image.
+func (r *Router) handleSetImageAlias(w http.ResponseWriter, req *http.Request) {
+	var aliasReq api.SetImageAliasRequest
+
+	if err := decodeRequestBody(w, req, &aliasReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.SetImageAlias(req.Context(), &aliasReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "set image alias")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleListImageAliases handles GET /api/v1/images/aliases to list all image aliases.
+func (r *Router) handleListImageAliases(w http.ResponseWriter, req *http.Request) {
+	r.handleListWithAuth(w, req,
+		func() (any, error) { return r.svc.ListImageAliases(req.Context()) },
+		"list image aliases")
+}
+
+// handleRemoveImageAlias handles DELETE /api/v1/images/aliases/{alias} to remove an image alias.
+// The alias parameter may contain slashes and colons like image names and uses a catch-all (*) route.
+func (r *Router) handleRemoveImageAlias(w http.ResponseWriter, req *http.Request) {
+	alias, ok := getImagePath(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.RemoveImageAlias(req.Context(), alias)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "remove image alias")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
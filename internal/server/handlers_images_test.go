@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/testutil"
 
@@ -555,6 +556,170 @@ func TestHandleRemoveImage_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+// ==================== handleDeleteImage tests ====================
+
+func TestHandleDeleteImage_Success(t *testing.T) {
+	deletedAt := time.Now().UTC()
+	runner := &testRunner{
+		softDeleteImageFunc: func(_ context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+			assert.Equal(t, "alpine:latest-a1b2c3d4", image)
+			assert.Equal(t, "user@example.com", deletedBy)
+			return &api.ImageInfo{ImageID: image, DeletedAt: &deletedAt, DeletedBy: deletedBy}, nil
+		},
+	}
+	router := newImageHandlerRouter(t, runner)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/images/alpine:latest-a1b2c3d4", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "operator"})
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("*", "alpine:latest-a1b2c3d4")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	router.handleDeleteImage(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response api.RemoveImageResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "alpine:latest-a1b2c3d4", response.Image)
+	require.NotNil(t, response.RestorableUntil)
+	assert.Contains(t, response.Message, "runvoy images restore")
+}
+
+func TestHandleDeleteImage_Unauthenticated(t *testing.T) {
+	router := newImageHandlerRouter(t, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/images/alpine:latest-a1b2c3d4", http.NoBody)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("*", "alpine:latest-a1b2c3d4")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	router.handleDeleteImage(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// ==================== handleRestoreImage tests ====================
+
+func TestHandleRestoreImage_Success(t *testing.T) {
+	var restored string
+	runner := &testRunner{
+		restoreImageFunc: func(_ context.Context, image string) (*api.ImageInfo, error) {
+			restored = image
+			return &api.ImageInfo{ImageID: image}, nil
+		},
+	}
+	router := newImageHandlerRouter(t, runner)
+
+	body, err := json.Marshal(api.RestoreImageRequest{Image: "alpine:latest-a1b2c3d4"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/images/restore", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.handleRestoreImage(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "alpine:latest-a1b2c3d4", restored)
+}
+
+func TestHandleRestoreImage_NotDeleted(t *testing.T) {
+	runner := &testRunner{
+		restoreImageFunc: func(_ context.Context, _ string) (*api.ImageInfo, error) {
+			return nil, apperrors.ErrConflict("image is not deleted", nil)
+		},
+	}
+	router := newImageHandlerRouter(t, runner)
+
+	body, err := json.Marshal(api.RestoreImageRequest{Image: "alpine:latest-a1b2c3d4"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/images/restore", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.handleRestoreImage(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestHandleSetImageAlias_Success(t *testing.T) {
+	var stored *api.ImageAlias
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			if image == "python:3.12" {
+				return &api.ImageInfo{ImageID: "python:3.12-a1b2c3d4", Image: image}, nil
+			}
+			return nil, nil
+		},
+		setImageAliasFunc: func(_ context.Context, alias *api.ImageAlias) (string, error) {
+			stored = alias
+			return "", nil
+		},
+	}
+	router := newImageHandlerRouter(t, runner)
+
+	body, err := json.Marshal(api.SetImageAliasRequest{Alias: "python:stable", Image: "python:3.12"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/images/aliases", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "operator"})
+
+	w := httptest.NewRecorder()
+	router.handleSetImageAlias(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, stored)
+	assert.Equal(t, "python:3.12-a1b2c3d4", stored.ImageID)
+	assert.Equal(t, "user@example.com", stored.UpdatedBy)
+}
+
+func TestHandleSetImageAlias_InvalidAlias(t *testing.T) {
+	router := newImageHandlerRouter(t, &testRunner{})
+
+	body, err := json.Marshal(api.SetImageAliasRequest{Alias: "stable", Image: "python:3.12"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/images/aliases", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "operator"})
+
+	w := httptest.NewRecorder()
+	router.handleSetImageAlias(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImageAliasRoutes(t *testing.T) {
+	var removed string
+	runner := &testRunner{
+		listImageAliasesFunc: func(_ context.Context) ([]api.ImageAlias, error) {
+			return []api.ImageAlias{{Alias: "python:stable", ImageID: "python:3.12-a1b2c3d4"}}, nil
+		},
+		removeImageAliasFunc: func(_ context.Context, alias string) error {
+			removed = alias
+			return nil
+		},
+	}
+	router := newImageHandlerRouter(t, runner)
+	mux := chi.NewRouter()
+	router.registerImagesRoutes(mux, constants.APIVersionV1)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/aliases", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listResp api.ListImageAliasesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listResp))
+	assert.Len(t, listResp.Aliases, 1)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/images/aliases/team/python:stable", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "team/python:stable", removed)
+}
+
 // ==================== Benchmark tests ====================
 
 func BenchmarkHandleRegisterImage(b *testing.B) {
@@ -638,3 +803,52 @@ func BenchmarkHandleGetImage(b *testing.B) {
 		router.handleGetImage(w, req)
 	}
 }
+
+func TestHandleGetResourceRecommendations(t *testing.T) {
+	summary := &api.ResourceSummary{
+		Samples:               2,
+		AverageCPUUtilized:    50,
+		CPUReserved:           1024,
+		PeakMemoryUtilizedMiB: 300,
+		MemoryReservedMiB:     2048,
+	}
+	execRepo := &testExecutionRepository{
+		listExecutionsFunc: func(_ int, _ []string) ([]*api.Execution, error) {
+			executions := make([]*api.Execution, 0, 5)
+			for range 5 {
+				executions = append(executions, &api.Execution{
+					ExecutionID:     "exec-1",
+					CreatedBy:       "user@example.com",
+					ImageID:         "alpine:latest",
+					ResourceSummary: summary,
+				})
+			}
+			return executions, nil
+		},
+	}
+	router := newExecutionHandlerRouter(t, execRepo, &testRunner{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recommendations", http.NoBody)
+	req = addAuthenticatedUser(req, &api.User{Email: "user@example.com", Role: "admin"})
+
+	w := httptest.NewRecorder()
+	router.handleGetResourceRecommendations(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response api.ResourceRecommendationsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response.Recommendations, 1)
+	assert.Equal(t, 256, response.Recommendations[0].RecommendedCPU)
+	assert.Equal(t, 512, response.Recommendations[0].RecommendedMemory)
+}
+
+func TestHandleGetResourceRecommendations_Unauthenticated(t *testing.T) {
+	router := newImageHandlerRouter(t, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recommendations", http.NoBody)
+	w := httptest.NewRecorder()
+	router.handleGetResourceRecommendations(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
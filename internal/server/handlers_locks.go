@@ -0,0 +1,45 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// handleListLocks handles GET /api/v1/locks to list the execution locks currently held.
+func (r *Router) handleListLocks(w http.ResponseWriter, req *http.Request) {
+	r.handleListWithAuth(w, req,
+		func() (any, error) {
+			locks, err := r.svc.ListLocks(req.Context())
+			if err != nil {
+				return nil, err
+			}
+			return api.ListLocksResponse{Locks: locks}, nil
+		},
+		"list locks")
+}
+
+// handleReleaseLock handles DELETE /api/v1/locks/{name} to release an execution lock, whatever holds it.
+func (r *Router) handleReleaseLock(w http.ResponseWriter, req *http.Request) {
+	name, ok := getRequiredURLParam(w, req, "name")
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	if err := r.svc.ReleaseLock(req.Context(), name, user.Email); err != nil {
+		r.handleAndLogError(w, req, err, "release lock")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.ReleaseLockResponse{
+		Name:    name,
+		Message: "Lock released successfully",
+	})
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleLocks(t *testing.T) {
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{Image: image, ImageID: "alpine:latest-a1b2c3d4"}, nil
+		},
+		runCommandFunc: func(_ string, _ *api.ExecutionRequest) (*time.Time, error) {
+			now := time.Now()
+			return &now, nil
+		},
+	}
+	repos := database.Repositories{
+		User:      &testUserRepository{},
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+		Lock:      fake.NewLockRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "./deploy.sh", Image: "alpine:latest", Lock: "deploy-prod"})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "./deploy.sh", Image: "alpine:latest", Lock: "deploy-prod"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var errResp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+	assert.Equal(t, apperrors.ErrCodeLockHeld, errResp.Code)
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/locks", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listResp api.ListLocksResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listResp))
+	require.Len(t, listResp.Locks, 1)
+	assert.Equal(t, "deploy-prod", listResp.Locks[0].Name)
+	assert.NotEmpty(t, listResp.Locks[0].ExecutionID)
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/locks/deploy-prod", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/locks/deploy-prod", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "./deploy.sh", Image: "alpine:latest", Lock: "deploy-prod"})
+	assert.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// handleGetNotificationSettings handles GET /api/v1/admin/notifications to get the notification settings.
+func (r *Router) handleGetNotificationSettings(w http.ResponseWriter, req *http.Request) {
+	settings, err := r.svc.GetNotificationSettings(req.Context())
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get notification settings")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.NotificationSettingsResponse{Settings: *settings})
+}
+
+// handleSetNotificationSettings handles PUT /api/v1/admin/notifications to replace the notification settings.
+func (r *Router) handleSetNotificationSettings(w http.ResponseWriter, req *http.Request) {
+	var settingsReq api.NotificationSettingsRequest
+	if err := decodeRequestBody(w, req, &settingsReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	settings, err := r.svc.SetNotificationSettings(req.Context(), &settingsReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "set notification settings")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.NotificationSettingsResponse{
+		Settings: *settings,
+		Message:  "Notification settings set successfully",
+	})
+}
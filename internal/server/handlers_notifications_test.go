@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleNotificationSettings(t *testing.T) {
+	runner := &testRunner{}
+	repos := database.Repositories{
+		User:      &testUserRepository{},
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+		Config:    fake.NewConfigRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/notifications",
+		api.NotificationSettingsRequest{
+			UsageReport: api.UsageReportSettings{Recipients: []string{"Finance@Example.com"}},
+		})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/notifications", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp api.NotificationSettingsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []string{"finance@example.com"}, resp.Settings.UsageReport.Recipients)
+	assert.False(t, resp.Settings.UsageReport.Disabled)
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/notifications",
+		api.NotificationSettingsRequest{
+			UsageReport: api.UsageReportSettings{Recipients: []string{"finance"}},
+		})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/runvoy/runvoy/internal/api"
+)
+
+// handleGetRunFreeze handles GET /api/v1/admin/freeze to get the run freeze switch.
+func (r *Router) handleGetRunFreeze(w http.ResponseWriter, req *http.Request) {
+	freeze, err := r.svc.GetRunFreeze(req.Context())
+	if err != nil {
+		r.handleAndLogError(w, req, err, "get run freeze")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{Freeze: *freeze})
+}
+
+// handleFreezeRuns handles PUT /api/v1/admin/freeze to reject the new executions with a message.
+func (r *Router) handleFreezeRuns(w http.ResponseWriter, req *http.Request) {
+	var freezeReq api.RunFreezeRequest
+	if err := decodeRequestBody(w, req, &freezeReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	freeze, err := r.svc.FreezeRuns(req.Context(), freezeReq.Message, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "freeze runs")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{
+		Freeze:  *freeze,
+		Message: "Runs frozen successfully",
+	})
+}
+
+// handleUnfreezeRuns handles DELETE /api/v1/admin/freeze to accept the new executions again.
+func (r *Router) handleUnfreezeRuns(w http.ResponseWriter, req *http.Request) {
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	freeze, err := r.svc.UnfreezeRuns(req.Context(), user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "unfreeze runs")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.RunFreezeResponse{
+		Freeze:  *freeze,
+		Message: "Runs unfrozen successfully",
+	})
+}
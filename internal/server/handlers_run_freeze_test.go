@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
+	"github.com/runvoy/runvoy/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRunFreeze(t *testing.T) {
+	started := false
+	runner := &testRunner{
+		getImageFunc: func(image string) (*api.ImageInfo, error) {
+			return &api.ImageInfo{Image: image, ImageID: "alpine:latest-a1b2c3d4"}, nil
+		},
+		runCommandFunc: func(_ string, _ *api.ExecutionRequest) (*time.Time, error) {
+			started = true
+			now := time.Now()
+			return &now, nil
+		},
+	}
+	repos := database.Repositories{
+		User:      &testUserRepository{},
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+		Config:    fake.NewConfigRepository(),
+	}
+	svc, err := orchestrator.NewService(context.Background(), testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/admin/freeze",
+		api.RunFreezeRequest{Message: "maintenance until 5pm"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/admin/freeze", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp api.RunFreezeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.True(t, resp.Freeze.Frozen)
+	assert.Equal(t, "maintenance until 5pm", resp.Freeze.Message)
+	assert.NotEmpty(t, resp.Freeze.UpdatedBy)
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "echo hello", Image: "alpine:latest"})
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var errResp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+	assert.Equal(t, apperrors.ErrCodeRunsFrozen, errResp.Code)
+	assert.Contains(t, errResp.Details, "maintenance until 5pm")
+	assert.False(t, started)
+
+	w = serveCommandPolicyRequest(router, http.MethodDelete, "/api/v1/admin/freeze", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/run",
+		api.ExecutionRequest{Command: "echo hello", Image: "alpine:latest"})
+	assert.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	assert.True(t, started)
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// registerSCIMRoutes registers the SCIM 2.0 routes the identity provider provisions users with. The identity
+// provider authenticates with the API key of an admin, sent as a bearer token.
+func (r *Router) registerSCIMRoutes(router chi.Router) {
+	router.Use(setContentTypeSCIMMiddleware, bearerAPIKeyMiddleware)
+	authMiddleware := router.With(
+		r.authenticateRequestMiddleware,
+		r.authorizeRequestMiddleware,
+	)
+
+	authMiddleware.Route("/Users", func(route chi.Router) {
+		route.Get("/", r.handleListSCIMUsers)
+		route.Post("/", r.handleCreateSCIMUser)
+		route.Get("/{id}", r.handleGetSCIMUser)
+		route.Put("/{id}", r.handleReplaceSCIMUser)
+		route.Patch("/{id}", r.handlePatchSCIMUser)
+		route.Delete("/{id}", r.handleDeleteSCIMUser)
+	})
+}
+
+// setContentTypeSCIMMiddleware sets the SCIM content type on the responses.
+func setContentTypeSCIMMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(constants.ContentTypeHeader, constants.SCIMContentType)
+		next.ServeHTTP(w, req)
+	})
+}
+
+// bearerAPIKeyMiddleware passes the bearer token of the request as its API key, as identity providers send
+// their SCIM credentials in the Authorization header.
+func bearerAPIKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if ok && req.Header.Get(constants.APIKeyHeader) == "" {
+			req.Header.Set(constants.APIKeyHeader, strings.TrimSpace(token))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// handleListSCIMUsers handles GET /scim/v2/Users to list the provisioned users.
+func (r *Router) handleListSCIMUsers(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	startIndex, _ := strconv.Atoi(query.Get("startIndex"))
+	count, _ := strconv.Atoi(query.Get("count"))
+
+	resp, err := r.svc.ListSCIMUsers(req.Context(), query.Get("filter"), startIndex, count)
+	if err != nil {
+		r.handleSCIMError(w, req, err, "list users")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleCreateSCIMUser handles POST /scim/v2/Users to provision a user.
+func (r *Router) handleCreateSCIMUser(w http.ResponseWriter, req *http.Request) {
+	var scimUser api.SCIMUser
+	if !decodeSCIMRequestBody(w, req, &scimUser) {
+		return
+	}
+
+	resp, err := r.svc.CreateSCIMUser(req.Context(), &scimUser)
+	if err != nil {
+		r.handleSCIMError(w, req, err, "create user")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetSCIMUser handles GET /scim/v2/Users/{id} to get a provisioned user.
+func (r *Router) handleGetSCIMUser(w http.ResponseWriter, req *http.Request) {
+	resp, err := r.svc.GetSCIMUser(req.Context(), chi.URLParam(req, "id"))
+	if err != nil {
+		r.handleSCIMError(w, req, err, "get user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleReplaceSCIMUser handles PUT /scim/v2/Users/{id} to replace a provisioned user.
+func (r *Router) handleReplaceSCIMUser(w http.ResponseWriter, req *http.Request) {
+	var scimUser api.SCIMUser
+	if !decodeSCIMRequestBody(w, req, &scimUser) {
+		return
+	}
+
+	resp, err := r.svc.ReplaceSCIMUser(req.Context(), chi.URLParam(req, "id"), &scimUser)
+	if err != nil {
+		r.handleSCIMError(w, req, err, "replace user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handlePatchSCIMUser handles PATCH /scim/v2/Users/{id} to update a provisioned user.
+func (r *Router) handlePatchSCIMUser(w http.ResponseWriter, req *http.Request) {
+	var patch api.SCIMPatchRequest
+	if !decodeSCIMRequestBody(w, req, &patch) {
+		return
+	}
+
+	resp, err := r.svc.PatchSCIMUser(req.Context(), chi.URLParam(req, "id"), &patch)
+	if err != nil {
+		r.handleSCIMError(w, req, err, "update user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleDeleteSCIMUser handles DELETE /scim/v2/Users/{id} to deprovision a user.
+func (r *Router) handleDeleteSCIMUser(w http.ResponseWriter, req *http.Request) {
+	if err := r.svc.DeleteSCIMUser(req.Context(), chi.URLParam(req, "id")); err != nil {
+		r.handleSCIMError(w, req, err, "delete user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeSCIMRequestBody decodes the JSON request body into v, writing a SCIM error response if it fails.
+func decodeSCIMRequestBody(w http.ResponseWriter, req *http.Request, v any) bool {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		status := http.StatusBadRequest
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeSCIMError(w, status, "invalidSyntax", "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// handleSCIMError logs a failed SCIM operation and writes the error in the SCIM format.
+func (r *Router) handleSCIMError(w http.ResponseWriter, req *http.Request, err error, operationName string) {
+	statusCode, errorCode, errorDetails := extractErrorInfo(err)
+	r.GetLoggerFromContext(req.Context()).Error(
+		"SCIM operation failed",
+		"operation", operationName,
+		"error", err,
+		"status_code", statusCode,
+		"error_code", errorCode,
+	)
+
+	scimType := ""
+	switch statusCode {
+	case http.StatusConflict:
+		scimType = "uniqueness"
+	case http.StatusBadRequest:
+		scimType = "invalidValue"
+	}
+	writeSCIMError(w, statusCode, scimType, errorDetails)
+}
+
+func writeSCIMError(w http.ResponseWriter, statusCode int, scimType, detail string) {
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(api.SCIMError{
+		Schemas:  []string{api.SCIMErrorSchema},
+		Status:   strconv.Itoa(statusCode),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
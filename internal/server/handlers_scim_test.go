@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSCIMTestRouter(t *testing.T, userRepo *testUserRepository) *Router {
+	svc := newTestOrchestratorService(t, userRepo, nil, nil, nil, nil, nil, nil)
+	return NewRouter(svc, 0, nil)
+}
+
+func TestSCIMCreateUser_BearerToken(t *testing.T) {
+	var created *api.User
+	userRepo := &testUserRepository{
+		getUserByEmailFunc: func(_ string) (*api.User, error) { return nil, nil },
+		createUserFunc: func(_ context.Context, user *api.User, _ string, _ int64) error {
+			created = user
+			return nil
+		},
+	}
+	router := newSCIMTestRouter(t, userRepo)
+
+	body := `{"schemas":["` + api.SCIMUserSchema + `"],"userName":"alice@example.com",` +
+		`"externalId":"00u1","roles":[{"value":"developer"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin-api-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, constants.SCIMContentType, w.Header().Get(constants.ContentTypeHeader))
+	var scimUser api.SCIMUser
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&scimUser))
+	assert.Equal(t, "alice@example.com", scimUser.ID)
+	require.NotNil(t, created)
+	assert.True(t, created.Provisioned)
+	assert.Equal(t, "developer", created.Role)
+}
+
+func TestSCIMCreateUser_Errors(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
+		router := newSCIMTestRouter(t, nil)
+		req := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		router := newSCIMTestRouter(t, nil) // every email belongs to an existing user
+		body := `{"userName":"alice@example.com","roles":[{"value":"viewer"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-api-key")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var scimErr api.SCIMError
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&scimErr))
+		assert.Equal(t, []string{api.SCIMErrorSchema}, scimErr.Schemas)
+		assert.Equal(t, "409", scimErr.Status)
+		assert.Equal(t, "uniqueness", scimErr.SCIMType)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		router := newSCIMTestRouter(t, nil)
+		req := httptest.NewRequest(http.MethodPatch, "/scim/v2/Users/alice@example.com", strings.NewReader(`{`))
+		req.Header.Set("Authorization", "Bearer admin-api-key")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalidSyntax")
+	})
+}
+
+func TestSCIMEndpointAuthorization(t *testing.T) {
+	roles := map[authorization.Role]bool{
+		authorization.RoleAdmin:     true,
+		authorization.RoleOperator:  false,
+		authorization.RoleDeveloper: false,
+		authorization.RoleViewer:    false,
+	}
+	for role, shouldAllow := range roles {
+		t.Run(string(role), func(t *testing.T) {
+			userEmail := string(role) + "@test.com"
+			router := newTestRouterWithEnforcer(t, newTestEnforcerWithRole(t, userEmail, role))
+
+			req := createAuthenticatedRequest(http.MethodPatch, "/scim/v2/Users/alice@example.com",
+				&api.User{Email: userEmail})
+
+			assert.Equal(t, shouldAllow, router.authorizeRequest(req, authorization.ActionUpdate))
+		})
+	}
+}
+
+func TestLoginRoutesArePublic(t *testing.T) {
+	router := newSCIMTestRouter(t, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", strings.NewReader(`{"id_token":"token"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Without an API key, the request reaches the service, which has no identity provider configured.
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/login", http.NoBody)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/github/token", strings.NewReader(`{"id_token":"token"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -38,7 +38,12 @@ func (r *Router) handleGetSecret(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	secret, err := r.svc.GetSecret(req.Context(), name)
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	secret, err := r.svc.GetSecret(req.Context(), name, user.Email)
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -52,7 +57,12 @@ func (r *Router) handleGetSecret(w http.ResponseWriter, req *http.Request) {
 
 // handleListSecrets handles GET /api/v1/secrets.
 func (r *Router) handleListSecrets(w http.ResponseWriter, req *http.Request) {
-	secrets, err := r.svc.ListSecrets(req.Context())
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	secrets, err := r.svc.ListSecrets(req.Context(), user.Email)
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -114,6 +124,50 @@ func (r *Router) handleDeleteSecret(w http.ResponseWriter, req *http.Request) {
 	})
 }
 
+// handleExportSecrets handles POST /api/v1/admin/secrets/export.
+func (r *Router) handleExportSecrets(w http.ResponseWriter, req *http.Request) {
+	var exportReq api.ExportSecretsRequest
+	if err := decodeRequestBody(w, req, &exportReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.ExportSecrets(req.Context(), &exportReq, user.Email)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleImportSecrets handles POST /api/v1/admin/secrets/import.
+func (r *Router) handleImportSecrets(w http.ResponseWriter, req *http.Request) {
+	var importReq api.ImportSecretsRequest
+	if err := decodeRequestBody(w, req, &importReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.ImportSecrets(req.Context(), &importReq, user.Email)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 // handleServiceError converts service layer errors to HTTP responses.
 func handleServiceError(w http.ResponseWriter, err error) {
 	statusCode, errorCode, errorDetails := extractErrorInfo(err)
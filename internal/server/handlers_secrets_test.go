@@ -71,6 +71,10 @@ func (t *testSecretRepository) GetSecretsByRequestID(_ context.Context, _ string
 	return []*api.Secret{}, nil
 }
 
+func (t *testSecretRepository) RecordSecretUsage(_ context.Context, _, _ string, _ time.Time) error {
+	return nil
+}
+
 func TestHandleCreateSecret_Success(t *testing.T) {
 	userRepo := &testUserRepository{}
 	execRepo := &testExecutionRepository{}
@@ -499,6 +503,18 @@ func (t *testUserRepositoryWithRolesForSecrets) RevokeUser(ctx context.Context,
 	return t.originalRepo.RevokeUser(ctx, email)
 }
 
+func (t *testUserRepositoryWithRolesForSecrets) UpdateUser(ctx context.Context, user *api.User) error {
+	return t.originalRepo.UpdateUser(ctx, user)
+}
+
+func (t *testUserRepositoryWithRolesForSecrets) UpdateUserPreferences(ctx context.Context, user *api.User) error {
+	return t.originalRepo.UpdateUserPreferences(ctx, user)
+}
+
+func (t *testUserRepositoryWithRolesForSecrets) ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error {
+	return t.originalRepo.ReplaceAPIKeyHash(ctx, email, apiKeyHash)
+}
+
 func (t *testUserRepositoryWithRolesForSecrets) CreatePendingAPIKey(ctx context.Context, key *api.PendingAPIKey) error {
 	return t.originalRepo.CreatePendingAPIKey(ctx, key)
 }
@@ -516,6 +532,14 @@ func (t *testUserRepositoryWithRolesForSecrets) DeletePendingAPIKey(ctx context.
 	return t.originalRepo.DeletePendingAPIKey(ctx, token)
 }
 
+func (t *testUserRepositoryWithRolesForSecrets) ListPendingAPIKeys(ctx context.Context) ([]*api.PendingAPIKey, error) {
+	return t.originalRepo.ListPendingAPIKeys(ctx)
+}
+
+func (t *testUserRepositoryWithRolesForSecrets) DeleteUser(ctx context.Context, email string) error {
+	return t.originalRepo.DeleteUser(ctx, email)
+}
+
 func (t *testUserRepositoryWithRolesForSecrets) ListUsers(_ context.Context) ([]*api.User, error) {
 	// Return users with valid roles for enforcer initialization
 	return []*api.User{
@@ -533,3 +557,46 @@ func (t *testUserRepositoryWithRolesForSecrets) GetUsersByRequestID(
 ) ([]*api.User, error) {
 	return t.originalRepo.GetUsersByRequestID(ctx, requestID)
 }
+
+func TestHandleExportImportSecrets(t *testing.T) {
+	secretRepo := &testSecretRepository{
+		getSecretFunc: func(_ context.Context, name string, _ bool) (*api.Secret, error) {
+			return &api.Secret{Name: name, KeyName: "DB_PASSWORD", Value: "s3cret"}, nil
+		},
+	}
+	svc := newTestService(t, &testUserRepository{}, &testExecutionRepository{}, secretRepo)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+	user := &api.User{Email: "admin@example.com"}
+
+	post := func(path string, body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(payload))
+		req = addAuthToRequest(req)
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, user))
+		w := httptest.NewRecorder()
+		router.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/admin/secrets/export", api.ExportSecretsRequest{
+		Names:      []string{"db-password"},
+		Passphrase: "correct horse battery staple",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var exportResp api.ExportSecretsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&exportResp))
+	assert.Equal(t, []string{"db-password"}, exportResp.Secrets)
+
+	w = post("/api/v1/admin/secrets/import", api.ImportSecretsRequest{
+		Bundle:     exportResp.Bundle,
+		Passphrase: "correct horse battery staple",
+		Names:      []string{"db-password"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var importResp api.ImportSecretsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&importResp))
+	assert.Equal(t, []string{"db-password"}, importResp.Created)
+
+	w = post("/api/v1/admin/secrets/export", api.ExportSecretsRequest{Passphrase: "correct horse battery staple"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
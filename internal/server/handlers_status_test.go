@@ -27,6 +27,14 @@ func (m *mockRunner) KillTask(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *mockRunner) CreateStdinUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func (m *mockRunner) CreateContextUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
 func (m *mockRunner) RegisterImage(
 	_ context.Context,
 	_ string,
@@ -34,6 +42,9 @@ func (m *mockRunner) RegisterImage(
 	_, _ *string,
 	_, _ *int,
 	_ *string,
+	_ *api.LogLimits,
+	_ *int,
+	_ *api.RunDefaults,
 	_ string,
 ) error {
 	return nil
@@ -58,6 +69,30 @@ func (m *mockRunner) RemoveImage(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *mockRunner) SoftDeleteImage(_ context.Context, _, _ string) (*api.ImageInfo, error) {
+	return nil, nil
+}
+
+func (m *mockRunner) RestoreImage(_ context.Context, _ string) (*api.ImageInfo, error) {
+	return nil, nil
+}
+
+func (m *mockRunner) SetImageAlias(_ context.Context, _ *api.ImageAlias) (string, error) {
+	return "", nil
+}
+
+func (m *mockRunner) GetImageAlias(_ context.Context, _ string) (*api.ImageAlias, error) {
+	return nil, nil
+}
+
+func (m *mockRunner) ListImageAliases(_ context.Context) ([]api.ImageAlias, error) {
+	return nil, nil
+}
+
+func (m *mockRunner) RemoveImageAlias(_ context.Context, _ string) error {
+	return nil
+}
+
 func (m *mockRunner) FetchLogsByExecutionID(_ context.Context, _ string) ([]api.LogEvent, error) {
 	return []api.LogEvent{}, nil
 }
@@ -66,6 +101,10 @@ func (m *mockRunner) FetchBackendLogs(_ context.Context, _ string) ([]api.LogEve
 	return []api.LogEvent{}, nil
 }
 
+func (m *mockRunner) FetchResourceUsage(_ context.Context, _ []string) (map[string]*api.ResourceUsage, error) {
+	return map[string]*api.ResourceUsage{}, nil
+}
+
 func (m *mockRunner) GetImagesByRequestID(_ context.Context, _ string) ([]api.ImageInfo, error) {
 	return []api.ImageInfo{}, nil
 }
@@ -28,14 +28,18 @@ const testRegion = "us-east-1"
 
 // Test mocks for repositories and runner
 type testUserRepository struct {
-	authenticateUserFunc func(apiKeyHash string) (*api.User, error)
-	updateLastUsedFunc   func(email string) error
-	getUserByEmailFunc   func(email string) (*api.User, error)
-	getPendingAPIKeyFunc func(ctx context.Context, secretToken string) (*api.PendingAPIKey, error)
-	markAsViewedFunc     func(ctx context.Context, secretToken string, ipAddress string) error
-	createUserFunc       func(ctx context.Context, user *api.User, apiKeyHash string, expiresAt int64) error
-	listUsersFunc        func(ctx context.Context) ([]*api.User, error)
-	revokeUserFunc       func(ctx context.Context, email string) error
+	authenticateUserFunc  func(apiKeyHash string) (*api.User, error)
+	updateLastUsedFunc    func(email string) error
+	getUserByEmailFunc    func(email string) (*api.User, error)
+	getPendingAPIKeyFunc  func(ctx context.Context, secretToken string) (*api.PendingAPIKey, error)
+	markAsViewedFunc      func(ctx context.Context, secretToken string, ipAddress string) error
+	createUserFunc        func(ctx context.Context, user *api.User, apiKeyHash string, expiresAt int64) error
+	listUsersFunc         func(ctx context.Context) ([]*api.User, error)
+	revokeUserFunc        func(ctx context.Context, email string) error
+	updateUserFunc        func(ctx context.Context, user *api.User) error
+	replaceAPIKeyHashFunc func(ctx context.Context, email, apiKeyHash string) error
+
+	updateUserPreferencesFunc func(ctx context.Context, user *api.User) error
 }
 
 func (t *testUserRepository) CreateUser(
@@ -93,6 +97,27 @@ func (t *testUserRepository) RevokeUser(ctx context.Context, email string) error
 	return nil
 }
 
+func (t *testUserRepository) UpdateUser(ctx context.Context, user *api.User) error {
+	if t.updateUserFunc != nil {
+		return t.updateUserFunc(ctx, user)
+	}
+	return nil
+}
+
+func (t *testUserRepository) UpdateUserPreferences(ctx context.Context, user *api.User) error {
+	if t.updateUserPreferencesFunc != nil {
+		return t.updateUserPreferencesFunc(ctx, user)
+	}
+	return nil
+}
+
+func (t *testUserRepository) ReplaceAPIKeyHash(ctx context.Context, email, apiKeyHash string) error {
+	if t.replaceAPIKeyHashFunc != nil {
+		return t.replaceAPIKeyHashFunc(ctx, email, apiKeyHash)
+	}
+	return nil
+}
+
 func (t *testUserRepository) CreatePendingAPIKey(_ context.Context, _ *api.PendingAPIKey) error {
 	return nil
 }
@@ -124,6 +149,14 @@ func (t *testUserRepository) DeletePendingAPIKey(_ context.Context, _ string) er
 	return nil
 }
 
+func (t *testUserRepository) ListPendingAPIKeys(_ context.Context) ([]*api.PendingAPIKey, error) {
+	return nil, nil
+}
+
+func (t *testUserRepository) DeleteUser(_ context.Context, _ string) error {
+	return nil
+}
+
 func (t *testUserRepository) ListUsers(ctx context.Context) ([]*api.User, error) {
 	if t.listUsersFunc != nil {
 		return t.listUsersFunc(ctx)
@@ -182,12 +215,17 @@ func newPermissiveTestEnforcerForHandlers(t testing.TB) *authorization.Enforcer
 type testExecutionRepository struct {
 	listExecutionsFunc func(limit int, statuses []string) ([]*api.Execution, error)
 	getExecutionFunc   func(ctx context.Context, executionID string) (*api.Execution, error)
+	getByGroupIDFunc   func(ctx context.Context, groupID string) ([]*api.Execution, error)
 }
 
 func (t *testExecutionRepository) CreateExecution(_ context.Context, _ *api.Execution) error {
 	return nil
 }
 
+func (t *testExecutionRepository) CreateExecutions(_ context.Context, _ []*api.Execution) error {
+	return nil
+}
+
 func (t *testExecutionRepository) GetExecution(ctx context.Context, executionID string) (*api.Execution, error) {
 	if t.getExecutionFunc != nil {
 		return t.getExecutionFunc(ctx, executionID)
@@ -218,17 +256,95 @@ func (t *testExecutionRepository) ListExecutions(
 	return []*api.Execution{}, nil
 }
 
+// ListExecutionsByCreator filters the executions of listExecutionsFunc by creator, the limit applying after it.
+func (t *testExecutionRepository) ListExecutionsByCreator(
+	_ context.Context,
+	createdBy string,
+	limit int,
+	statuses []string,
+) ([]*api.Execution, error) {
+	if t.listExecutionsFunc == nil {
+		return []*api.Execution{}, nil
+	}
+	executions, err := t.listExecutionsFunc(0, statuses)
+	if err != nil {
+		return nil, err
+	}
+	created := make([]*api.Execution, 0, len(executions))
+	for _, execution := range executions {
+		if execution.CreatedBy == createdBy {
+			created = append(created, execution)
+		}
+	}
+	if limit > 0 && len(created) > limit {
+		created = created[:limit]
+	}
+	return created, nil
+}
+
 func (t *testExecutionRepository) GetExecutionsByRequestID(_ context.Context, _ string) ([]*api.Execution, error) {
 	return []*api.Execution{}, nil
 }
 
+func (t *testExecutionRepository) GetExecutionsByGroupID(
+	ctx context.Context,
+	groupID string,
+) ([]*api.Execution, error) {
+	if t.getByGroupIDFunc != nil {
+		return t.getByGroupIDFunc(ctx, groupID)
+	}
+	return []*api.Execution{}, nil
+}
+
+func (t *testExecutionRepository) AddLogUsage(_ context.Context, _ string, _ *api.LogUsage) error {
+	return nil
+}
+
+func (t *testExecutionRepository) SearchExecutions(
+	_ context.Context, _ []string, _ int, _ []string,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (t *testExecutionRepository) ListExecutionsWithExpiredLogs(
+	_ context.Context, _ time.Time, _ int,
+) ([]*api.Execution, error) {
+	return nil, nil
+}
+
+func (t *testExecutionRepository) ClearExecutionLogsExpiry(_ context.Context, _ string) error {
+	return nil
+}
+
+func (t *testExecutionRepository) MarkExecutionSLOBreached(_ context.Context, _ string) error {
+	return nil
+}
+
+func (t *testExecutionRepository) SetExecutionResult(_ context.Context, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+func (t *testExecutionRepository) AddExecutionEvents(_ context.Context, _ string, _ []api.ExecutionEvent) error {
+	return nil
+}
+
+func (t *testExecutionRepository) ListExecutionEvents(_ context.Context, _ string) ([]api.ExecutionEvent, error) {
+	return nil, nil
+}
+
+func (t *testExecutionRepository) AddExecutionAnnotation(
+	_ context.Context, _ string, _ *api.ExecutionAnnotation,
+) error {
+	return nil
+}
+
 type testTokenRepository struct{}
 
 func (t *testTokenRepository) CreateToken(_ context.Context, _ *api.WebSocketToken) error {
 	return nil
 }
 
-func (t *testTokenRepository) GetToken(_ context.Context, _ string) (*api.WebSocketToken, error) {
+func (t *testTokenRepository) ClaimToken(_ context.Context, _ string) (*api.WebSocketToken, error) {
 	return nil, nil
 }
 
@@ -236,6 +352,18 @@ func (t *testTokenRepository) DeleteToken(_ context.Context, _ string) error {
 	return nil
 }
 
+func (t *testTokenRepository) DeleteTokensByExecutionID(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
+func (t *testTokenRepository) CreateRunToken(_ context.Context, _ *api.RunToken) error {
+	return nil
+}
+
+func (t *testTokenRepository) GetRunToken(_ context.Context, _ string) (*api.RunToken, error) {
+	return nil, nil
+}
+
 type testSecretsRepository struct{}
 
 func (t *testSecretsRepository) CreateSecret(_ context.Context, _ *api.Secret) error {
@@ -262,6 +390,10 @@ func (t *testSecretsRepository) GetSecretsByRequestID(_ context.Context, _ strin
 	return []*api.Secret{}, nil
 }
 
+func (t *testSecretsRepository) RecordSecretUsage(_ context.Context, _, _ string, _ time.Time) error {
+	return nil
+}
+
 type testImageRepository struct{}
 
 func (t *testImageRepository) GetImagesByRequestID(_ context.Context, _ string) ([]api.ImageInfo, error) {
@@ -274,6 +406,10 @@ func (t *testHealthManager) Reconcile(_ context.Context) (*api.HealthReport, err
 	return &api.HealthReport{}, nil
 }
 
+func (t *testHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
+
 type testWebSocketManager struct{}
 
 func (t *testWebSocketManager) HandleRequest(_ context.Context, _ *json.RawMessage, _ *slog.Logger) (bool, error) {
@@ -284,6 +420,10 @@ func (t *testWebSocketManager) NotifyExecutionCompletion(_ context.Context, _ *s
 	return nil
 }
 
+func (t *testWebSocketManager) NotifyExecutionStatus(_ context.Context, _ *api.ExecutionStatusChange) error {
+	return nil
+}
+
 func (t *testWebSocketManager) SendLogsToExecution(_ context.Context, _ *string) error {
 	return nil
 }
@@ -302,7 +442,14 @@ type testRunner struct {
 	listImagesFunc           func() ([]api.ImageInfo, error)
 	getImageFunc             func(image string) (*api.ImageInfo, error)
 	removeImageFunc          func(ctx context.Context, image string) error
+	softDeleteImageFunc      func(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error)
+	restoreImageFunc         func(ctx context.Context, image string) (*api.ImageInfo, error)
+	setImageAliasFunc        func(ctx context.Context, alias *api.ImageAlias) (string, error)
+	getImageAliasFunc        func(ctx context.Context, alias string) (*api.ImageAlias, error)
+	listImageAliasesFunc     func(ctx context.Context) ([]api.ImageAlias, error)
+	removeImageAliasFunc     func(ctx context.Context, alias string) error
 	fetchBackendLogsFunc     func(ctx context.Context, requestID string) ([]api.LogEvent, error)
+	fetchResourceUsageFunc   func(ctx context.Context, executionIDs []string) (map[string]*api.ResourceUsage, error)
 	getImagesByRequestIDFunc func(ctx context.Context, requestID string) ([]api.ImageInfo, error)
 }
 
@@ -325,6 +472,14 @@ func (t *testRunner) KillTask(_ context.Context, _ string) error {
 	return nil
 }
 
+func (t *testRunner) CreateStdinUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func (t *testRunner) CreateContextUpload(_ context.Context, _ string, _ int64) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
 func (t *testRunner) RegisterImage(
 	_ context.Context,
 	_ string,
@@ -332,6 +487,9 @@ func (t *testRunner) RegisterImage(
 	_, _ *string,
 	_, _ *int,
 	_ *string,
+	_ *api.LogLimits,
+	_ *int,
+	_ *api.RunDefaults,
 	_ string,
 ) error {
 	return nil
@@ -358,6 +516,48 @@ func (t *testRunner) RemoveImage(ctx context.Context, image string) error {
 	return nil
 }
 
+func (t *testRunner) SoftDeleteImage(ctx context.Context, image, deletedBy string) (*api.ImageInfo, error) {
+	if t.softDeleteImageFunc != nil {
+		return t.softDeleteImageFunc(ctx, image, deletedBy)
+	}
+	return &api.ImageInfo{ImageID: image, DeletedBy: deletedBy}, nil
+}
+
+func (t *testRunner) RestoreImage(ctx context.Context, image string) (*api.ImageInfo, error) {
+	if t.restoreImageFunc != nil {
+		return t.restoreImageFunc(ctx, image)
+	}
+	return &api.ImageInfo{ImageID: image}, nil
+}
+
+func (t *testRunner) SetImageAlias(ctx context.Context, alias *api.ImageAlias) (string, error) {
+	if t.setImageAliasFunc != nil {
+		return t.setImageAliasFunc(ctx, alias)
+	}
+	return "", nil
+}
+
+func (t *testRunner) GetImageAlias(ctx context.Context, alias string) (*api.ImageAlias, error) {
+	if t.getImageAliasFunc != nil {
+		return t.getImageAliasFunc(ctx, alias)
+	}
+	return nil, nil
+}
+
+func (t *testRunner) ListImageAliases(ctx context.Context) ([]api.ImageAlias, error) {
+	if t.listImageAliasesFunc != nil {
+		return t.listImageAliasesFunc(ctx)
+	}
+	return []api.ImageAlias{}, nil
+}
+
+func (t *testRunner) RemoveImageAlias(ctx context.Context, alias string) error {
+	if t.removeImageAliasFunc != nil {
+		return t.removeImageAliasFunc(ctx, alias)
+	}
+	return nil
+}
+
 func (t *testRunner) FetchLogsByExecutionID(_ context.Context, _ string) ([]api.LogEvent, error) {
 	return []api.LogEvent{}, nil
 }
@@ -369,6 +569,16 @@ func (t *testRunner) FetchBackendLogs(ctx context.Context, requestID string) ([]
 	return []api.LogEvent{}, nil
 }
 
+func (t *testRunner) FetchResourceUsage(
+	ctx context.Context,
+	executionIDs []string,
+) (map[string]*api.ResourceUsage, error) {
+	if t.fetchResourceUsageFunc != nil {
+		return t.fetchResourceUsageFunc(ctx, executionIDs)
+	}
+	return map[string]*api.ResourceUsage{}, nil
+}
+
 func (t *testRunner) GetImagesByRequestID(ctx context.Context, requestID string) ([]api.ImageInfo, error) {
 	if t.getImagesByRequestIDFunc != nil {
 		return t.getImagesByRequestIDFunc(ctx, requestID)
@@ -5,9 +5,11 @@ import (
 	"net/http"
 
 	"github.com/runvoy/runvoy/internal/api"
+
+	"github.com/go-chi/chi/v5"
 )
 
-// handleCreateUser handles POST /api/v1/users to create a new user with an API key.
+// handleCreateUser handles POST /api/v1/users/create and POST /api/v2/users to create a new user with an API key.
 func (r *Router) handleCreateUser(w http.ResponseWriter, req *http.Request) {
 	var createReq api.CreateUserRequest
 
@@ -30,15 +32,14 @@ func (r *Router) handleCreateUser(w http.ResponseWriter, req *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// handleRevokeUser handles POST /api/v1/users/revoke to revoke a user's API key.
-func (r *Router) handleRevokeUser(w http.ResponseWriter, req *http.Request) {
-	var revokeReq api.RevokeUserRequest
-
-	if err := decodeRequestBody(w, req, &revokeReq); err != nil {
-		return
-	}
+// handleRevokeUserByEmail handles DELETE /api/v2/users/{email} to revoke a user's API key.
+func (r *Router) handleRevokeUserByEmail(w http.ResponseWriter, req *http.Request) {
+	r.revokeUser(w, req, chi.URLParam(req, "email"))
+}
 
-	if err := r.svc.RevokeUser(req.Context(), revokeReq.Email); err != nil {
+// revokeUser revokes the API key of the user with the given email and writes the response.
+func (r *Router) revokeUser(w http.ResponseWriter, req *http.Request, email string) {
+	if err := r.svc.RevokeUser(req.Context(), email); err != nil {
 		r.handleAndLogError(w, req, err, "revoke user")
 		return
 	}
@@ -46,7 +47,7 @@ func (r *Router) handleRevokeUser(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(api.RevokeUserResponse{
 		Message: "user API key revoked successfully",
-		Email:   revokeReq.Email,
+		Email:   email,
 	})
 }
 
@@ -56,3 +57,61 @@ func (r *Router) handleListUsers(w http.ResponseWriter, req *http.Request) {
 		func() (any, error) { return r.svc.ListUsers(req.Context()) },
 		"list users")
 }
+
+// handleListPendingUsers handles GET /api/v1/users/pending to list the invited users who have not claimed
+// their API key yet.
+func (r *Router) handleListPendingUsers(w http.ResponseWriter, req *http.Request) {
+	r.handleListWithAuth(w, req,
+		func() (any, error) { return r.svc.ListPendingUsers(req.Context()) },
+		"list pending users")
+}
+
+// handleReissueClaimToken handles POST /api/v1/users/{email}/claim-token to issue a new claim token
+// to an invited user who has not claimed their API key yet.
+func (r *Router) handleReissueClaimToken(w http.ResponseWriter, req *http.Request) {
+	email, ok := getRequiredURLParam(w, req, "email")
+	if !ok {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.ReissueClaimToken(req.Context(), email, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "reissue claim token")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleTransferOwnership handles PUT /api/v1/users/{email}/owner to transfer a service account to another owner.
+func (r *Router) handleTransferOwnership(w http.ResponseWriter, req *http.Request) {
+	email, ok := getRequiredURLParam(w, req, "email")
+	if !ok {
+		return
+	}
+
+	var transferReq api.TransferOwnershipRequest
+	if err := decodeRequestBody(w, req, &transferReq); err != nil {
+		return
+	}
+
+	user, ok := r.requireAuthenticatedUser(w, req)
+	if !ok {
+		return
+	}
+
+	resp, err := r.svc.TransferOwnership(req.Context(), email, &transferReq, user.Email)
+	if err != nil {
+		r.handleAndLogError(w, req, err, "transfer ownership")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -10,8 +10,12 @@ import (
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/auth"
+	"github.com/runvoy/runvoy/internal/backend/orchestrator"
 	"github.com/runvoy/runvoy/internal/constants"
+	"github.com/runvoy/runvoy/internal/database"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/providers/fake"
 	"github.com/runvoy/runvoy/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -341,3 +345,87 @@ func TestHandleCreateUser_DifferentRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlePendingUsers(t *testing.T) {
+	ctx := context.Background()
+	users := fake.NewUserRepository()
+	require.NoError(t, users.CreateUser(ctx, adminTestUser(), auth.HashAPIKey("test-api-key"), 0))
+	repos := database.Repositories{
+		User:      users,
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+	}
+	runner := &testRunner{}
+	svc, err := orchestrator.NewService(ctx, testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPost, "/api/v2/users",
+		api.CreateUserRequest{Email: "alice@example.com", Role: "viewer"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created api.CreateUserResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	w = serveCommandPolicyRequest(router, http.MethodGet, "/api/v1/users/pending", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var pending api.ListUsersResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&pending))
+	require.Len(t, pending.Users, 1)
+	assert.Equal(t, "alice@example.com", pending.Users[0].Email)
+	assert.NotNil(t, pending.Users[0].ClaimExpiresAt)
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v1/users/alice@example.com/claim-token", nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var reissued api.CreateUserResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reissued))
+	assert.NotEmpty(t, reissued.ClaimToken)
+	assert.NotEqual(t, created.ClaimToken, reissued.ClaimToken)
+
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v2/users/missing@example.com/claim-token", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	users := fake.NewUserRepository()
+	require.NoError(t, users.CreateUser(ctx, adminTestUser(), auth.HashAPIKey("test-api-key"), 0))
+	repos := database.Repositories{
+		User:      users,
+		Execution: &testExecutionRepository{},
+		Token:     &testTokenRepository{},
+		Image:     &testImageRepository{},
+		Secrets:   &testSecretsRepository{},
+	}
+	runner := &testRunner{}
+	svc, err := orchestrator.NewService(ctx, testRegion, &repos,
+		runner, runner, runner, runner,
+		testutil.SilentLogger(), constants.AWS, &testWebSocketManager{}, &noopHealthManager{},
+		newPermissiveTestEnforcerForHandlers(t))
+	require.NoError(t, err)
+	router := NewRouter(svc, 30*1000, constants.DefaultCORSAllowedOrigins)
+
+	w := serveCommandPolicyRequest(router, http.MethodPost, "/api/v2/users", api.CreateUserRequest{
+		Email: "ci@example.com", Role: "developer", ServiceAccount: true, Owner: adminTestUser().Email,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serveCommandPolicyRequest(router, http.MethodPost, "/api/v2/users",
+		api.CreateUserRequest{Email: "bob@example.com", Role: "developer"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v1/users/ci@example.com/owner",
+		api.TransferOwnershipRequest{Owner: "bob@example.com"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var transferred api.TransferOwnershipResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&transferred))
+	assert.Equal(t, adminTestUser().Email, transferred.PreviousOwner)
+	assert.Equal(t, "bob@example.com", transferred.User.Owner)
+
+	w = serveCommandPolicyRequest(router, http.MethodPut, "/api/v2/users/bob@example.com/owner",
+		api.TransferOwnershipRequest{Owner: adminTestUser().Email})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
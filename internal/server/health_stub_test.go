@@ -15,3 +15,7 @@ var _ contract.HealthManager = (*noopHealthManager)(nil)
 func (n *noopHealthManager) Reconcile(_ context.Context) (*api.HealthReport, error) {
 	return &api.HealthReport{}, nil
 }
+
+func (n *noopHealthManager) Cleanup(_ context.Context, dryRun bool) (*api.CleanupReport, error) {
+	return &api.CleanupReport{DryRun: dryRun}, nil
+}
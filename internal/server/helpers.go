@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,26 +10,38 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/validation"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// extractErrorInfo extracts statusCode, errorCode, and errorDetails from an error.
+// extractErrorInfo extracts statusCode, errorCode, and the errorDetails returned to the client from an error.
 // Returns the HTTP status code, error code, and error details.
 func extractErrorInfo(err error) (statusCode int, errorCode, errorDetails string) {
 	return apperrors.GetStatusCode(err),
 		apperrors.GetErrorCode(err),
-		apperrors.GetErrorDetails(err)
+		apperrors.GetPublicErrorDetails(err)
 }
 
-// decodeRequestBody decodes JSON request body into the provided value.
-// If decoding fails, writes an error response and returns the error.
+// decodeRequestBody decodes JSON request body into the provided value and validates it
+// against the payload limits of the backend.
+// If decoding or validation fails, writes an error response (400, 413 or 422) and returns the error.
 // Returns nil on success.
 func decodeRequestBody(w http.ResponseWriter, req *http.Request, v any) error {
 	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "invalid request body", err.Error())
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writePayloadTooLargeResponse(w, maxBytesErr.Limit)
+		} else {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid request body", err.Error())
+		}
 		return fmt.Errorf("failed to decode request body: %w", err)
 	}
+	if err := validation.Request(v); err != nil {
+		statusCode, errorCode, errorDetails := extractErrorInfo(err)
+		writeErrorResponseWithCode(w, statusCode, errorCode, "invalid request", errorDetails)
+		return fmt.Errorf("failed to validate request body: %w", err)
+	}
 	return nil
 }
 
@@ -56,6 +69,32 @@ func getRequiredURLParam(w http.ResponseWriter, req *http.Request, name string)
 	return param, true
 }
 
+// getStatusesQueryParam parses the comma-separated "status" query parameter into a list of statuses.
+// Returns nil if the parameter is absent.
+func getStatusesQueryParam(req *http.Request) []string {
+	statusParam := req.URL.Query().Get("status")
+	if statusParam == "" {
+		return nil
+	}
+	statuses := strings.Split(statusParam, ",")
+	for i, s := range statuses {
+		statuses[i] = strings.TrimSpace(s)
+	}
+	return statuses
+}
+
+// writeSSEEvent writes a single Server-Sent Event with a JSON-encoded data payload.
+func writeSSEEvent(w http.ResponseWriter, event api.StreamEventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return nil
+}
+
 // getImagePath extracts and validates the image path from the catch-all (*) route parameter.
 // Handles URL unescaping and path normalization.
 // If the image path is missing or empty, writes a bad request error response and returns "", false.
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 
 	"github.com/go-chi/chi/v5"
@@ -147,6 +150,54 @@ func TestDecodeRequestBody_StructType(t *testing.T) {
 	})
 }
 
+func TestDecodeRequestBody_Limits(t *testing.T) {
+	t.Run("body over the size limit", func(t *testing.T) {
+		body := `{"command": "` + strings.Repeat("a", 64) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		req.Body = http.MaxBytesReader(w, req.Body, 16)
+
+		var result api.ExecutionRequest
+		err := decodeRequestBody(w, req, &result)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		var resp api.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, apperrors.ErrCodePayloadTooLarge, resp.Code)
+	})
+
+	t.Run("execution request out of bounds", func(t *testing.T) {
+		body, err := json.Marshal(api.ExecutionRequest{
+			Command: strings.Repeat("a", constants.MaxCommandLength+1),
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		var result api.ExecutionRequest
+		err = decodeRequestBody(w, req, &result)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var resp api.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, apperrors.ErrCodeValidationFailed, resp.Code)
+		assert.Contains(t, resp.Details, "command is 4097 bytes long")
+	})
+
+	t.Run("invalid image reference", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"image": "alpine; ls"}`))
+		w := httptest.NewRecorder()
+
+		var result api.RegisterImageRequest
+		err := decodeRequestBody(w, req, &result)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
 func TestGetRequiredURLParam(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1,11 +1,15 @@
 package server
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -13,36 +17,64 @@ import (
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/auth"
 	"github.com/runvoy/runvoy/internal/auth/authorization"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/constants"
 	apperrors "github.com/runvoy/runvoy/internal/errors"
 	loggerPkg "github.com/runvoy/runvoy/internal/logger"
+
+	"github.com/go-chi/chi/v5/middleware"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	loggerContextKey      contextKey = "logger"
-	lastUsedUpdateTimeout            = 5 * time.Second
+	loggerContextKey          contextKey = "logger"
+	requestLogStateContextKey contextKey = "requestLogState"
+	lastUsedUpdateTimeout                = 5 * time.Second
 )
 
-// requestIDMiddleware extracts the request ID from the context (if present) or generates a random one.
-// Priority: 1) Existing request ID in context, 2) Provider-specific request ID (via registered
-// extractors), 3) Generated random ID.
+// requestIDMiddleware extracts the request ID from the context (if present) or generates a random one,
+// and echoes it in the X-Request-ID response header.
+// Priority: 1) Existing request ID in context, 2) Valid X-Request-ID request header, sent by the CLI,
+// 3) Provider-specific request ID (via registered extractors), 4) Generated random ID.
+// When the request carries its own ID, the provider-specific one is logged alongside it so that the
+// provider's own log lines, such as the Lambda REPORT lines, can still be correlated.
 func (r *Router) requestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		requestID := loggerPkg.ExtractRequestIDFromContext(req.Context())
-
+		requestID := loggerPkg.GetRequestID(req.Context())
+		if requestID == "" {
+			requestID = validClientRequestID(req.Header.Get(constants.RequestIDHeader))
+		}
+		providerRequestID := loggerPkg.ExtractRequestIDFromContext(req.Context())
+		if requestID == "" {
+			requestID = providerRequestID
+		}
 		if requestID == "" {
 			requestID = auth.GenerateUUID()
 		}
 
 		ctx := loggerPkg.WithRequestID(req.Context(), requestID)
 		log := r.svc.Logger.With(constants.RequestIDLogField, requestID)
+		if providerRequestID != "" && providerRequestID != requestID {
+			log = log.With(constants.ProviderRequestIDLogField, providerRequestID)
+		}
 		ctx = context.WithValue(ctx, loggerContextKey, log)
+		w.Header().Set(constants.RequestIDHeader, requestID)
 
 		next.ServeHTTP(w, req.WithContext(ctx))
 	})
 }
 
+// clientRequestIDPattern matches the request IDs accepted from clients, which end up in the logs and records.
+var clientRequestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// validClientRequestID returns the request ID sent by a client, or an empty string when it is missing or invalid.
+func validClientRequestID(requestID string) string {
+	if len(requestID) > constants.MaxRequestIDLength || !clientRequestIDPattern.MatchString(requestID) {
+		return ""
+	}
+	return requestID
+}
+
 // requestTimeoutMiddleware creates a context with timeout for each request.
 // The timeout starts when the request is received, ensuring each request has
 // a fair timeout regardless of connection reuse.
@@ -98,7 +130,12 @@ func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 				}
 			}
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, "+constants.RequestIDHeader)
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join([]string{
+				constants.APIVersionHeader, constants.DeprecationHeader, constants.SunsetHeader, "Link",
+				constants.RequestIDHeader, constants.AnnouncementHeader,
+				constants.BackendVersionHeader, constants.MinClientVersionHeader,
+			}, ", "))
 			w.Header().Set("Access-Control-Max-Age", "3600")
 
 			// Handle preflight requests
@@ -120,6 +157,104 @@ func setContentTypeJSONMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// compressResponseMiddleware gzips the JSON responses of the clients accepting it, which shrinks the large
+// log and list responses several times. Event streams are left alone so that their events are not buffered.
+func compressResponseMiddleware(next http.Handler) http.Handler {
+	return middleware.Compress(constants.ResponseCompressionLevel, "application/json", constants.SCIMContentType)(next)
+}
+
+// decompressRequestMiddleware decompresses the gzip request bodies, so the handlers and the body limit only
+// see decompressed bodies, and rejects the other content codings with a 415 response. Every response
+// announces the accepted coding in its Accept-Encoding header (RFC 7694), which tells the CLI it may
+// compress its requests.
+func decompressRequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(constants.AcceptEncodingHeader, constants.GzipContentEncoding)
+
+		encoding := strings.TrimSpace(req.Header.Get(constants.ContentEncodingHeader))
+		switch {
+		case encoding == "" || strings.EqualFold(encoding, "identity"):
+		case strings.EqualFold(encoding, constants.GzipContentEncoding):
+			body, err := newGzipRequestBody(req.Body)
+			if err != nil {
+				writeErrorResponseWithCode(w, http.StatusBadRequest, apperrors.ErrCodeInvalidRequest,
+					"invalid request body", "request body is not valid gzip data")
+				return
+			}
+			req.Body = body
+			req.ContentLength = -1
+			req.Header.Del(constants.ContentEncodingHeader)
+			req.Header.Del("Content-Length")
+		default:
+			writeErrorResponseWithCode(w, http.StatusUnsupportedMediaType,
+				apperrors.ErrCodeUnsupportedContentEncoding, "unsupported content encoding",
+				fmt.Sprintf("request bodies must be sent uncompressed or with the %s content encoding",
+					constants.GzipContentEncoding))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// gzipRequestBody decompresses a request body, closing both the decompressor and the body.
+type gzipRequestBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func newGzipRequestBody(body io.ReadCloser) (*gzipRequestBody, error) {
+	if body == nil || body == http.NoBody {
+		return nil, io.ErrUnexpectedEOF
+	}
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipRequestBody{Reader: reader, body: body}, nil
+}
+
+func (b *gzipRequestBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
+// requestBodyLimitMiddleware rejects request bodies larger than maxBytes with a 413 response.
+// Bodies announcing a larger Content-Length are refused upfront, others are cut off while being read.
+func requestBodyLimitMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.ContentLength > maxBytes {
+				writePayloadTooLargeResponse(w, maxBytes)
+				return
+			}
+			if req.Body != nil {
+				req.Body = http.MaxBytesReader(w, req.Body, maxBytes)
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// chaosMiddleware delays and fails the requests picked by inj, so clients can be tested against a
+// slow or unavailable API. The failures are 503 responses, like those of an overloaded backend.
+func chaosMiddleware(inj *chaos.Injector) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if err := inj.Delay(req.Context()); err != nil || inj.Fail() {
+				writeErrorResponseWithCode(w, http.StatusServiceUnavailable, apperrors.ErrCodeServiceUnavailable,
+					"injected fault", "chaos testing is enabled on this server")
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// writePayloadTooLargeResponse writes the 413 response of a request body larger than maxBytes.
+func writePayloadTooLargeResponse(w http.ResponseWriter, maxBytes int64) {
+	writeErrorResponseWithCode(w, http.StatusRequestEntityTooLarge, apperrors.ErrCodePayloadTooLarge,
+		"request body too large", fmt.Sprintf("request body must not exceed %d bytes", maxBytes))
+}
+
 // handleAuthError handles authentication errors and writes appropriate responses.
 func handleAuthError(w http.ResponseWriter, err error) {
 	statusCode := apperrors.GetStatusCode(err)
@@ -152,7 +287,7 @@ func (r *Router) authorizeRequest(req *http.Request, action authorization.Action
 	}
 
 	enforcer := r.svc.GetEnforcer()
-	resourceObject := req.URL.Path
+	resourceObject := authorizationObject(req.URL.Path)
 	userEmail := user.Email
 
 	allowed, err := enforcer.Enforce(ctx, userEmail, resourceObject, action)
@@ -245,6 +380,9 @@ func (r *Router) authenticateRequestMiddleware(next http.Handler) http.Handler {
 		}
 
 		logger.Info("user authenticated successfully", "email", user.Email)
+		if state, ok := req.Context().Value(requestLogStateContextKey).(*requestLogState); ok {
+			state.user = user.Email
+		}
 
 		waitForLastUsedUpdate := r.startLastUsedUpdate(req.Context(), user, logger)
 
@@ -257,6 +395,7 @@ func (r *Router) authenticateRequestMiddleware(next http.Handler) http.Handler {
 
 // requestLoggingMiddleware logs incoming requests and their responses
 // Uses logger from context (includes request ID if available).
+// Credentials in the path are scrubbed, and the bodies are only logged, scrubbed, for the sampled requests.
 func (r *Router) requestLoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		logger := r.GetLoggerFromContext(req.Context())
@@ -265,23 +404,42 @@ func (r *Router) requestLoggingMiddleware(next http.Handler) http.Handler {
 		if deadline, ok := req.Context().Deadline(); ok {
 			deadlineString = deadline.Format(time.RFC3339)
 		}
+		path := scrubRequestPath(req.URL.Path)
 
 		// Wrap the response writer to capture status code
 		wrapped := &responseWriter{
 			ResponseWriter: w,
 			statusCode:     http.StatusOK, // default status code
 		}
+		state := &requestLogState{}
+		req = req.WithContext(context.WithValue(req.Context(), requestLogStateContextKey, state))
 
 		logger.Info("processing incoming client request",
 			"method", req.Method,
-			"path", req.URL.Path,
+			"path", path,
 			"remoteAddr", req.RemoteAddr,
 			"deadline", deadlineString)
 
+		if r.sampleRequestBodies(req.Context(), logger) {
+			logRequestBody(logger, req)
+			wrapped.body = &bytes.Buffer{}
+		}
+
 		next.ServeHTTP(wrapped, req)
 		duration := time.Since(start)
 
-		logger.Info("response sent to client", "status", wrapped.statusCode, "duration", duration.String())
+		logger.Info("response sent to client",
+			"method", req.Method,
+			"path", path,
+			"status", wrapped.statusCode,
+			"duration", duration.String(),
+			"duration_ms", duration.Milliseconds(),
+			"user", state.user)
+		if wrapped.body != nil {
+			logger.Debug("sampled response body",
+				"status", wrapped.statusCode,
+				"body", scrubLoggedBody(wrapped.body.Bytes(), wrapped.bodyTruncated))
+		}
 	})
 }
 
@@ -303,7 +461,7 @@ func (r *Router) getActionFromRequest(method string) authorization.Action {
 		return authorization.ActionRead
 	case http.MethodPost:
 		return authorization.ActionCreate
-	case http.MethodPut:
+	case http.MethodPut, http.MethodPatch:
 		return authorization.ActionUpdate
 	case http.MethodDelete:
 		return authorization.ActionDelete
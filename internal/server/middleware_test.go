@@ -1,18 +1,23 @@
 package server
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/chaos"
 	"github.com/runvoy/runvoy/internal/constants"
 	"github.com/runvoy/runvoy/internal/database"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
 	"github.com/runvoy/runvoy/internal/logger"
 	awsOrchestrator "github.com/runvoy/runvoy/internal/providers/aws/orchestrator"
 	"github.com/runvoy/runvoy/internal/testutil"
@@ -250,6 +255,43 @@ func TestRequestIDMiddleware(t *testing.T) {
 	})
 }
 
+func TestRequestIDMiddleware_ClientRequestID(t *testing.T) {
+	logger.RegisterContextExtractor(awsOrchestrator.NewLambdaContextExtractor())
+	defer logger.ClearContextExtractors()
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "valid header takes precedence over lambda ID", header: "cli-request-id.1", expected: "cli-request-id.1"},
+		{name: "invalid header is ignored", header: "bad id\n", expected: "lambda-request-id"},
+		{name: "too long header is ignored", header: strings.Repeat("a", 129), expected: "lambda-request-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = logger.GetRequestID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/test", http.NoBody)
+			req.Header.Set(constants.RequestIDHeader, tt.header)
+			lc := &lambdacontext.LambdaContext{AwsRequestID: "lambda-request-id"}
+			req = req.WithContext(lambdacontext.NewContext(req.Context(), lc))
+			rr := httptest.NewRecorder()
+
+			router := newRouterWithUserRepo(t, &testUserRepository{})
+			router.requestIDMiddleware(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, seen)
+			assert.Equal(t, tt.expected, rr.Header().Get(constants.RequestIDHeader), "the request ID is echoed")
+		})
+	}
+}
+
 func TestStartLastUsedUpdate_WaitsForCompletion(t *testing.T) {
 	called := make(chan struct{})
 	userRepo := &testUserRepository{
@@ -425,6 +467,211 @@ func TestResponseWriter(t *testing.T) {
 	})
 }
 
+func TestRequestBodyLimitMiddleware(t *testing.T) {
+	const maxBytes = 32
+	handler := requestBodyLimitMiddleware(maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]any
+		if err := decodeRequestBody(w, req, &body); err != nil {
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("accepts body within the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", strings.NewReader(`{"command":"ls"}`))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("rejects announced content length over the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", strings.NewReader(strings.Repeat("x", maxBytes+1)))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), apperrors.ErrCodePayloadTooLarge)
+	})
+
+	t.Run("rejects streamed body over the limit", func(t *testing.T) {
+		body := `{"command":"` + strings.Repeat("x", maxBytes) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", io.NopCloser(strings.NewReader(body)))
+		req.ContentLength = -1
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), apperrors.ErrCodePayloadTooLarge)
+	})
+}
+
+func gzipBody(t *testing.T, body string) *bytes.Buffer {
+	t.Helper()
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return &compressed
+}
+
+func TestDecompressRequestMiddleware(t *testing.T) {
+	const maxBytes = 64
+	var received map[string]any
+	handler := decompressRequestMiddleware(requestBodyLimitMiddleware(maxBytes)(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			received = nil
+			if err := decodeRequestBody(w, req, &received); err != nil {
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})))
+
+	t.Run("decompresses gzip bodies", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", gzipBody(t, `{"command":"ls"}`))
+		req.Header.Set(constants.ContentEncodingHeader, "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, map[string]any{"command": "ls"}, received)
+		assert.Equal(t, "gzip", rr.Header().Get(constants.AcceptEncodingHeader))
+	})
+
+	t.Run("accepts uncompressed bodies", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", strings.NewReader(`{"command":"ls"}`))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "gzip", rr.Header().Get(constants.AcceptEncodingHeader))
+	})
+
+	t.Run("limits the decompressed size", func(t *testing.T) {
+		body := gzipBody(t, `{"command":"`+strings.Repeat("x", 10*maxBytes)+`"}`)
+		require.Less(t, body.Len(), maxBytes)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", body)
+		req.Header.Set(constants.ContentEncodingHeader, "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("rejects invalid gzip bodies", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", strings.NewReader(`{"command":"ls"}`))
+		req.Header.Set(constants.ContentEncodingHeader, "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), apperrors.ErrCodeInvalidRequest)
+	})
+
+	t.Run("rejects other content encodings", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/run", strings.NewReader(`{"command":"ls"}`))
+		req.Header.Set(constants.ContentEncodingHeader, "br")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+		assert.Contains(t, rr.Body.String(), apperrors.ErrCodeUnsupportedContentEncoding)
+	})
+}
+
+func TestCompressResponseMiddleware(t *testing.T) {
+	payload := `{"logs":"` + strings.Repeat("hello world ", 100) + `"}`
+	handler := setContentTypeJSONMiddleware(compressResponseMiddleware(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/stream" {
+				w.Header().Set(constants.ContentTypeHeader, constants.EventStreamContentType)
+			}
+			_, _ = w.Write([]byte(payload))
+		})))
+
+	t.Run("compresses JSON responses for clients accepting gzip", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/executions", http.NoBody)
+		req.Header.Set(constants.AcceptEncodingHeader, "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, "gzip", rr.Header().Get(constants.ContentEncodingHeader))
+		assert.Less(t, rr.Body.Len(), len(payload))
+		reader, err := gzip.NewReader(rr.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, payload, string(body))
+	})
+
+	t.Run("leaves responses uncompressed for other clients", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/executions", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Empty(t, rr.Header().Get(constants.ContentEncodingHeader))
+		assert.Equal(t, payload, rr.Body.String())
+	})
+
+	t.Run("leaves event streams uncompressed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/stream", http.NoBody)
+		req.Header.Set(constants.AcceptEncodingHeader, "gzip")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Empty(t, rr.Header().Get(constants.ContentEncodingHeader))
+		assert.Equal(t, payload, rr.Body.String())
+	})
+}
+
+func TestChaosMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("fails picked requests with a 503", func(t *testing.T) {
+		handler := chaosMiddleware(chaos.New(chaos.Config{ErrorRate: 1}))(next)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", http.NoBody))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), apperrors.ErrCodeServiceUnavailable)
+	})
+
+	t.Run("delays picked requests", func(t *testing.T) {
+		handler := chaosMiddleware(chaos.New(chaos.Config{Latency: 20 * time.Millisecond, LatencyRate: 1}))(next)
+		rr := httptest.NewRecorder()
+
+		start := time.Now()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("passes requests through when disabled", func(t *testing.T) {
+		handler := chaosMiddleware(chaos.New(chaos.Config{}))(next)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
 func TestCorsMiddleware(t *testing.T) {
 	tokenRepo := &testTokenRepository{}
 
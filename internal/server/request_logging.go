@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	loggerPkg "github.com/runvoy/runvoy/internal/logger"
+)
+
+// requestLogState collects the details of a request learned after requestLoggingMiddleware ran,
+// such as the authenticated user, for the log of its response.
+type requestLogState struct {
+	user string
+}
+
+// credentialPathSegments are the path segments followed by a credential, e.g. /api/v1/claim/{token}.
+var credentialPathSegments = []string{"claim"}
+
+// scrubRequestPath redacts the credentials carried by the path of a request.
+func scrubRequestPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		for _, segment := range credentialPathSegments {
+			if segments[i] == segment && segments[i+1] != "" {
+				segments[i+1] = loggerPkg.RedactedValue
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// sampleRequestBodies picks the requests whose bodies are logged, with the configured sample rate,
+// when debug logs are enabled.
+func (r *Router) sampleRequestBodies(ctx context.Context, logger *slog.Logger) bool {
+	rate := r.svc.RequestBodyLogSampleRate
+	if rate <= 0 || !logger.Enabled(ctx, slog.LevelDebug) {
+		return false
+	}
+	return rand.Float64() < rate //nolint:gosec // sampling logs doesn't need a cryptographic source
+}
+
+// logRequestBody logs the scrubbed headers and the first constants.MaxLoggedBodySize bytes of the request body,
+// scrubbed, leaving the whole body to the handlers.
+func logRequestBody(logger *slog.Logger, req *http.Request) {
+	headers := make(map[string]string, len(req.Header))
+	for name := range req.Header {
+		headers[name] = req.Header.Get(name)
+	}
+
+	var peeked []byte
+	truncated := false
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		peeked, err = io.ReadAll(io.LimitReader(req.Body, constants.MaxLoggedBodySize+1))
+		if err != nil {
+			logger.Debug("failed to read the sampled request body", "error", err)
+		}
+		truncated = len(peeked) > constants.MaxLoggedBodySize
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(peeked), req.Body), req.Body}
+		if truncated {
+			peeked = peeked[:constants.MaxLoggedBodySize]
+		}
+	}
+
+	logger.Debug("sampled request body",
+		"headers", loggerPkg.ScrubMap(headers),
+		"body", scrubLoggedBody(peeked, truncated))
+}
+
+// scrubLoggedBody returns a body for logging, scrubbed. Truncated bodies are not valid documents and can't be
+// scrubbed, so only their size is logged.
+func scrubLoggedBody(body []byte, truncated bool) string {
+	if truncated {
+		return fmt.Sprintf("[body of more than %d bytes]", len(body))
+	}
+	return loggerPkg.ScrubJSON(body)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveLoggedRequest serves req through requestLoggingMiddleware and returns the log records it wrote.
+func serveLoggedRequest(
+	t *testing.T,
+	sampleRate float64,
+	req *http.Request,
+	handler http.HandlerFunc,
+) []map[string]any {
+	router := newUserHandlerRouter(t, nil)
+	router.svc.RequestBodyLogSampleRate = sampleRate
+
+	var logs bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	req = req.WithContext(context.WithValue(req.Context(), loggerContextKey, log))
+
+	router.requestLoggingMiddleware(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func findLogRecord(t *testing.T, records []map[string]any, msg string) map[string]any {
+	for _, record := range records {
+		if record["msg"] == msg {
+			return record
+		}
+	}
+	require.Failf(t, "log record not found", "no %q record in %v", msg, records)
+	return nil
+}
+
+func TestRequestLoggingMiddleware(t *testing.T) {
+	const body = `{"key_name": "DB_PASSWORD", "value": "hunter2", "env": {"REGION": "eu-west-1"}}`
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/secrets", strings.NewReader(body))
+		req.Header.Set(constants.APIKeyHeader, "rk_live_123")
+		return req
+	}
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		received, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(received), "the handler must receive the whole body")
+		req.Context().Value(requestLogStateContextKey).(*requestLogState).user = "alice@example.com"
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"secret": {"name": "db", "value": "hunter2"}}`))
+	}
+
+	t.Run("logs the response with its user and latency", func(t *testing.T) {
+		records := serveLoggedRequest(t, 0, newRequest(), handler)
+
+		response := findLogRecord(t, records, "response sent to client")
+		assert.Equal(t, "POST", response["method"])
+		assert.Equal(t, "/api/v1/secrets", response["path"])
+		assert.InDelta(t, http.StatusCreated, response["status"], 0)
+		assert.Equal(t, "alice@example.com", response["user"])
+		assert.Contains(t, response, "duration_ms")
+		for _, record := range records {
+			assert.NotContains(t, record["msg"], "sampled")
+		}
+	})
+
+	t.Run("logs the scrubbed bodies of the sampled requests", func(t *testing.T) {
+		records := serveLoggedRequest(t, 1, newRequest(), handler)
+
+		request := findLogRecord(t, records, "sampled request body")
+		assert.JSONEq(t, `{"key_name": "DB_PASSWORD", "value": "[REDACTED]", "env": {"REGION": "[REDACTED]"}}`,
+			request["body"].(string))
+		assert.Equal(t, "[REDACTED]", request["headers"].(map[string]any)[http.CanonicalHeaderKey(constants.APIKeyHeader)])
+
+		response := findLogRecord(t, records, "sampled response body")
+		assert.JSONEq(t, `{"secret": {"name": "db", "value": "[REDACTED]"}}`, response["body"].(string))
+	})
+
+	t.Run("scrubs the claim tokens in the path", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/claim/secret-claim-token", http.NoBody)
+		records := serveLoggedRequest(t, 0, req, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		for _, record := range records {
+			assert.NotContains(t, record["path"], "secret-claim-token")
+		}
+		assert.Equal(t, "/api/v1/claim/[REDACTED]", findLogRecord(t, records, "response sent to client")["path"])
+	})
+}
+
+func TestScrubLoggedBody_Truncated(t *testing.T) {
+	assert.Equal(t, "[body of more than 4 bytes]", scrubLoggedBody([]byte(`{"ap`), true))
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/runvoy/runvoy/internal/api"
 	"github.com/runvoy/runvoy/internal/backend/orchestrator"
+	"github.com/runvoy/runvoy/internal/constants"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -46,13 +48,28 @@ func NewRouter(
 	}
 	r.Use(corsMiddleware(allowedOrigins))
 	r.Use(setContentTypeJSONMiddleware)
+	r.Use(compressResponseMiddleware)
 	r.Use(router.requestIDMiddleware)
+	// Decompressed before the bodies are logged and limited
+	r.Use(decompressRequestMiddleware)
 	r.Use(router.requestLoggingMiddleware)
+	if svc.Chaos != nil {
+		r.Use(chaosMiddleware(svc.Chaos))
+	}
+	r.Use(requestBodyLimitMiddleware(constants.MaxRequestBodySize))
 
-	r.Route("/api/v1", func(r chi.Router) {
-		router.registerPublicRoutes(r)
-		router.registerAuthenticatedRoutes(r)
-	})
+	r.Use(negotiateAPIVersionMiddleware)
+
+	for _, version := range supportedAPIVersions {
+		r.Route(apiPathPrefix+version, func(r chi.Router) {
+			r.Use(apiVersionMiddleware(version))
+			r.Use(router.announcementMiddleware)
+			r.Use(clientVersionMiddleware)
+			router.registerPublicRoutes(r)
+			router.registerAuthenticatedRoutes(r, version)
+		})
+	}
+	r.Route("/scim/v2", router.registerSCIMRoutes)
 
 	return router
 }
@@ -62,6 +79,10 @@ type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
 	written    bool
+
+	// body captures the first constants.MaxLoggedBodySize bytes of the response when it is not nil.
+	body          *bytes.Buffer
+	bodyTruncated bool
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -78,6 +99,15 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 		rw.written = true
 	}
 
+	if rw.body != nil {
+		if room := constants.MaxLoggedBodySize - rw.body.Len(); len(b) > room {
+			rw.body.Write(b[:max(room, 0)])
+			rw.bodyTruncated = true
+		} else {
+			rw.body.Write(b)
+		}
+	}
+
 	n, err := rw.ResponseWriter.Write(b)
 	if err != nil {
 		return n, fmt.Errorf("failed to write response: %w", err)
@@ -85,6 +115,12 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, nil
 }
 
+// Unwrap returns the underlying http.ResponseWriter so http.ResponseController can reach
+// optional interfaces such as http.Flusher.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // ServeHTTP implements http.Handler for use with chi router.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.router.ServeHTTP(w, req)
@@ -131,41 +167,72 @@ func writeErrorResponseWithCode(w http.ResponseWriter, statusCode int, code, mes
 func (r *Router) registerPublicRoutes(router chi.Router) {
 	router.Get("/claim/{token}", r.handleClaimAPIKey)
 	router.Get("/health", r.handleHealth)
+	router.Get("/login", r.handleGetLoginConfig)
+	router.Get("/meta", r.handleGetMeta)
+	router.Post("/login", r.handleLogin)
+	router.Post("/github/token", r.handleExchangeGitHubToken)
 }
 
 // registerAuthenticatedRoutes registers routes that require authentication and authorization.
-func (r *Router) registerAuthenticatedRoutes(router chi.Router) {
+// The routes are the same in every API version but the ones replaced by a breaking change.
+func (r *Router) registerAuthenticatedRoutes(router chi.Router, version string) {
 	authMiddleware := router.With(
 		r.authenticateRequestMiddleware,
 		r.authorizeRequestMiddleware,
 	)
 
 	authMiddleware.Post("/health/reconcile", r.handleReconcileHealth)
+	authMiddleware.Post("/health/cleanup", r.handleCleanupHealth)
+	authMiddleware.Get("/health/reports", r.handleListHealthReports)
+	authMiddleware.Get("/health/stats", r.handleGetHealthStats)
 	authMiddleware.Post("/run", r.handleRunCommand)
+	authMiddleware.Post("/run/stdin", r.handleCreateStdinUpload)
+	authMiddleware.Post("/run/context", r.handleCreateContextUpload)
+	authMiddleware.Get("/recommendations", r.handleGetResourceRecommendations)
+	authMiddleware.Get("/locks", r.handleListLocks)
+	authMiddleware.Delete("/locks/{name}", r.handleReleaseLock)
 
-	r.registerUsersRoutes(authMiddleware)
-	r.registerImagesRoutes(authMiddleware)
+	r.registerUsersRoutes(authMiddleware, version)
+	r.registerImagesRoutes(authMiddleware, version)
 	r.registerSecretsRoutes(authMiddleware)
+	r.registerAdminRoutes(authMiddleware)
 	r.registerExecutionsRoutes(authMiddleware)
 	r.registerBackendLogsTraceRoutes(authMiddleware)
 }
 
 // registerUsersRoutes registers user management routes.
-func (r *Router) registerUsersRoutes(router chi.Router) {
+// v1 keeps the deprecated action-based routes (see compat.go), which v2 replaces with resource routes.
+func (r *Router) registerUsersRoutes(router chi.Router, version string) {
 	router.Route("/users", func(route chi.Router) {
 		route.Get("/", r.handleListUsers)
-		route.Post("/create", r.handleCreateUser)
-		route.Post("/revoke", r.handleRevokeUser)
+		route.Get("/pending", r.handleListPendingUsers)
+		route.Post("/{email}/claim-token", r.handleReissueClaimToken)
+		route.Put("/{email}/owner", r.handleTransferOwnership)
+		if version == constants.APIVersionV1 {
+			r.registerLegacyUsersRoutes(route)
+			return
+		}
+		route.Post("/", r.handleCreateUser)
+		route.Delete("/{email}", r.handleRevokeUserByEmail)
 	})
 }
 
 // registerImagesRoutes registers image management routes.
-func (r *Router) registerImagesRoutes(router chi.Router) {
+// v1 keeps the deprecated action-based registration route (see compat.go), which v2 replaces with POST /images.
+func (r *Router) registerImagesRoutes(router chi.Router, version string) {
 	router.Route("/images", func(route chi.Router) {
-		route.Post("/register", r.handleRegisterImage)
+		if version == constants.APIVersionV1 {
+			r.registerLegacyImagesRoutes(route)
+		} else {
+			route.Post("/", r.handleRegisterImage)
+		}
+		route.Post("/restore", r.handleRestoreImage)
+		route.Get("/aliases", r.handleListImageAliases)
+		route.Post("/aliases", r.handleSetImageAlias)
+		route.Delete("/aliases/*", r.handleRemoveImageAlias)
 		route.Get("/", r.handleListImages)
 		route.Get("/*", r.handleGetImage)
-		route.Delete("/*", r.handleRemoveImage)
+		route.Delete("/*", r.handleDeleteImage)
 	})
 }
 
@@ -180,12 +247,51 @@ func (r *Router) registerSecretsRoutes(router chi.Router) {
 	})
 }
 
+// registerAdminRoutes registers administration routes, which only the admin role is allowed to use.
+func (r *Router) registerAdminRoutes(router chi.Router) {
+	router.Route("/admin", func(route chi.Router) {
+		route.Post("/secrets/export", r.handleExportSecrets)
+		route.Post("/secrets/import", r.handleImportSecrets)
+		route.Delete("/images/*", r.handleRemoveImage)
+		route.Get("/command-policies", r.handleListCommandPolicyRules)
+		route.Post("/command-policies", r.handleCreateCommandPolicyRule)
+		route.Get("/command-policies/{name}", r.handleGetCommandPolicyRule)
+		route.Put("/command-policies/{name}", r.handleUpdateCommandPolicyRule)
+		route.Delete("/command-policies/{name}", r.handleDeleteCommandPolicyRule)
+		route.Get("/freeze", r.handleGetRunFreeze)
+		route.Put("/freeze", r.handleFreezeRuns)
+		route.Delete("/freeze", r.handleUnfreezeRuns)
+		route.Put("/announcement", r.handleSetAnnouncement)
+		route.Delete("/announcement", r.handleClearAnnouncement)
+		route.Get("/budgets", r.handleListBudgets)
+		route.Put("/budgets/{email}", r.handleSetBudget)
+		route.Delete("/budgets/{email}", r.handleRemoveBudget)
+		route.Get("/notifications", r.handleGetNotificationSettings)
+		route.Put("/notifications", r.handleSetNotificationSettings)
+	})
+}
+
 // registerExecutionsRoutes registers execution management routes.
 func (r *Router) registerExecutionsRoutes(router chi.Router) {
 	router.Route("/executions", func(route chi.Router) {
 		route.Get("/", r.handleListExecutions)
+		route.Delete("/", r.handleKillExecutions)
+		route.Get("/stream", r.handleStreamExecutions)
+		route.Get("/diff", r.handleDiffExecutions)
+		route.Get("/resources", r.handleGetExecutionResources)
+		route.Get("/slo", r.handleGetExecutionSLOReport)
+		route.Get("/filters", r.handleListSavedFilters)
+		route.Post("/filters", r.handleSaveFilter)
+		route.Delete("/filters/{name}", r.handleDeleteSavedFilter)
+		route.Get("/groups/{groupID}/status", r.handleGetExecutionGroupStatus)
 		route.Get("/{executionID}/logs", r.handleGetExecutionLogs)
 		route.Get("/{executionID}/status", r.handleGetExecutionStatus)
+		route.Get("/{executionID}/events", r.handleGetExecutionEvents)
+		route.Get("/{executionID}/result", r.handleGetExecutionResult)
+		route.Post("/{executionID}/annotations", r.handleAnnotateExecution)
+		route.Post("/{executionID}/star", r.handleStarExecution)
+		route.Delete("/{executionID}/star", r.handleStarExecution)
+		route.Post("/{executionID}/stop", r.handleStopExecution)
 		route.Delete("/{executionID}", r.handleKillExecution)
 	})
 }
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+	"github.com/runvoy/runvoy/internal/semver"
+)
+
+const apiPathPrefix = "/api/"
+
+// supportedAPIVersions lists the API versions the router serves, oldest first.
+var supportedAPIVersions = []string{constants.APIVersionV1, constants.APIVersionV2}
+
+// acceptedAPIVersion returns the API version the Accept header selects with a vendor media type,
+// e.g. "application/vnd.runvoy.v2+json", and false when it selects none.
+func acceptedAPIVersion(accept string) (string, bool) {
+	for mediaRange := range strings.SplitSeq(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		version, ok := strings.CutPrefix(mediaType, constants.APIMediaTypePrefix)
+		if !ok {
+			continue
+		}
+		version, ok = strings.CutSuffix(version, "+json")
+		if ok && version != "" {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// writeUnsupportedAPIVersionResponse writes the 406 response of a request selecting an API version
+// the path does not serve.
+func writeUnsupportedAPIVersionResponse(w http.ResponseWriter, details string) {
+	writeErrorResponseWithCode(w, http.StatusNotAcceptable, apperrors.ErrCodeUnsupportedAPIVersion,
+		"Unsupported API version", details)
+}
+
+// negotiateAPIVersionMiddleware routes the unversioned API requests, e.g. /api/executions, to the version
+// their Accept header selects, or to the default version.
+// Requests with a version in their path are left as is, apiVersionMiddleware checks their Accept header.
+func negotiateAPIVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rest, ok := strings.CutPrefix(req.URL.Path, apiPathPrefix)
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+		segment, _, _ := strings.Cut(rest, "/")
+		if slices.Contains(supportedAPIVersions, segment) {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		version, selected := acceptedAPIVersion(req.Header.Get("Accept"))
+		if !selected {
+			version = constants.DefaultAPIVersion
+		}
+		if !slices.Contains(supportedAPIVersions, version) {
+			writeUnsupportedAPIVersionResponse(w, fmt.Sprintf("API version %q is not supported, use one of %s",
+				version, strings.Join(supportedAPIVersions, ", ")))
+			return
+		}
+
+		versioned := new(http.Request)
+		*versioned = *req
+		versioned.URL = new(url.URL)
+		*versioned.URL = *req.URL
+		versioned.URL.Path = apiPathPrefix + version + "/" + rest
+		versioned.URL.RawPath = ""
+		next.ServeHTTP(w, versioned)
+	})
+}
+
+// apiVersionMiddleware names the API version serving the requests in the response headers, and rejects
+// the requests whose Accept header selects another version.
+func apiVersionMiddleware(version string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set(constants.APIVersionHeader, version)
+			if accepted, ok := acceptedAPIVersion(req.Header.Get("Accept")); ok && accepted != version {
+				writeUnsupportedAPIVersionResponse(w, fmt.Sprintf(
+					"the request path selects API version %s but the Accept header selects %s", version, accepted))
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// clientVersionMiddleware names the backend version and the oldest CLI version it supports in the response
+// headers, and refuses the requests of the older CLIs with 426 before serving them, so they fail with a
+// pointer to self-update rather than with errors they cannot make sense of. The health check is still
+// served to let them compare the versions, as are the development builds and the clients not sending their
+// version, like the web app.
+func clientVersionMiddleware(next http.Handler) http.Handler {
+	minClient, err := semver.Parse(constants.MinClientVersion)
+	if err != nil {
+		panic(fmt.Sprintf("invalid minimum client version: %v", err))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(constants.BackendVersionHeader, *constants.GetVersion())
+		w.Header().Set(constants.MinClientVersionHeader, constants.MinClientVersion)
+
+		client, parseErr := semver.Parse(req.Header.Get(constants.ClientVersionHeader))
+		if parseErr == nil && !client.IsDevelopment() && client.Compare(minClient) < 0 &&
+			!strings.HasSuffix(req.URL.Path, "/health") {
+			writeErrorResponseWithCode(w, http.StatusUpgradeRequired, apperrors.ErrCodeClientTooOld,
+				"CLI version not supported", fmt.Sprintf(
+					"this backend requires %s %s or later, run %s self-update to upgrade the CLI from %s",
+					constants.ProjectName, minClient, constants.ProjectName, client))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// authorizationObject returns the object the authorization of a request path is checked on.
+// The policies are written against the v1 paths, whose resources the later API versions keep.
+func authorizationObject(path string) string {
+	rest, ok := strings.CutPrefix(path, apiPathPrefix)
+	if !ok {
+		return path
+	}
+	version, resource, _ := strings.Cut(rest, "/")
+	if version == constants.APIVersionV1 || !slices.Contains(supportedAPIVersions, version) {
+		return path
+	}
+	return apiPathPrefix + constants.APIVersionV1 + "/" + resource
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVersionedTestMux serves every API version with a handler echoing the path it was routed to.
+func newVersionedTestMux() *chi.Mux {
+	mux := chi.NewRouter()
+	mux.Use(negotiateAPIVersionMiddleware)
+	for _, version := range supportedAPIVersions {
+		mux.Route(apiPathPrefix+version, func(r chi.Router) {
+			r.Use(apiVersionMiddleware(version))
+			r.Get("/*", func(w http.ResponseWriter, req *http.Request) {
+				_, _ = w.Write([]byte(req.URL.Path))
+			})
+		})
+	}
+	return mux
+}
+
+func TestAcceptedAPIVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		wantVersion string
+		wantOK      bool
+	}{
+		{name: "empty", accept: "", wantOK: false},
+		{name: "generic JSON", accept: "application/json, */*", wantOK: false},
+		{name: "vendor media type", accept: "application/vnd.runvoy.v2+json", wantVersion: "v2", wantOK: true},
+		{
+			name:        "vendor media type among others with parameters",
+			accept:      "text/html, Application/VND.runvoy.v1+json; q=0.9",
+			wantVersion: "v1",
+			wantOK:      true,
+		},
+		{name: "vendor media type without version", accept: "application/vnd.runvoy.+json", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok := acceptedAPIVersion(tt.accept)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestAPIVersionNegotiation(t *testing.T) {
+	mux := newVersionedTestMux()
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		wantStatus  int
+		wantPath    string
+		wantVersion string
+	}{
+		{
+			name:        "versioned path",
+			path:        "/api/v2/executions",
+			wantStatus:  http.StatusOK,
+			wantPath:    "/api/v2/executions",
+			wantVersion: constants.APIVersionV2,
+		},
+		{
+			name:        "unversioned path uses the default version",
+			path:        "/api/executions",
+			wantStatus:  http.StatusOK,
+			wantPath:    "/api/v1/executions",
+			wantVersion: constants.APIVersionV1,
+		},
+		{
+			name:        "unversioned path uses the version of the Accept header",
+			path:        "/api/executions",
+			accept:      "application/vnd.runvoy.v2+json",
+			wantStatus:  http.StatusOK,
+			wantPath:    "/api/v2/executions",
+			wantVersion: constants.APIVersionV2,
+		},
+		{
+			name:       "unversioned path with an unsupported version",
+			path:       "/api/executions",
+			accept:     "application/vnd.runvoy.v9+json",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:        "versioned path with a conflicting Accept header",
+			path:        "/api/v1/executions",
+			accept:      "application/vnd.runvoy.v2+json",
+			wantStatus:  http.StatusNotAcceptable,
+			wantVersion: constants.APIVersionV1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantVersion, w.Header().Get(constants.APIVersionHeader))
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantPath, w.Body.String())
+				return
+			}
+			var resp api.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, apperrors.ErrCodeUnsupportedAPIVersion, resp.Code)
+		})
+	}
+}
+
+func TestClientVersionMiddleware(t *testing.T) {
+	mux := chi.NewRouter()
+	mux.Use(clientVersionMiddleware)
+	mux.Get("/*", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		path          string
+		clientVersion string
+		wantStatus    int
+	}{
+		{name: "without a client version", path: "/api/v1/executions", wantStatus: http.StatusOK},
+		{name: "supported client", path: "/api/v1/executions", clientVersion: "v99.0.0", wantStatus: http.StatusOK},
+		{name: "development client", path: "/api/v1/executions", clientVersion: "0.0.0-development",
+			wantStatus: http.StatusOK},
+		{name: "unparsable client version", path: "/api/v1/executions", clientVersion: "dev",
+			wantStatus: http.StatusOK},
+		{name: "client too old", path: "/api/v1/executions", clientVersion: "v0.1.0",
+			wantStatus: http.StatusUpgradeRequired},
+		{name: "health check of a client too old", path: "/api/v1/health", clientVersion: "v0.1.0",
+			wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.clientVersion != "" {
+				req.Header.Set(constants.ClientVersionHeader, tt.clientVersion)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, *constants.GetVersion(), w.Header().Get(constants.BackendVersionHeader))
+			assert.Equal(t, constants.MinClientVersion, w.Header().Get(constants.MinClientVersionHeader))
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var resp api.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, apperrors.ErrCodeClientTooOld, resp.Code)
+			assert.Contains(t, resp.Details, "run runvoy self-update")
+		})
+	}
+}
+
+func TestAuthorizationObject(t *testing.T) {
+	assert.Equal(t, "/api/v1/users/a@example.com", authorizationObject("/api/v1/users/a@example.com"))
+	assert.Equal(t, "/api/v1/users/a@example.com", authorizationObject("/api/v2/users/a@example.com"))
+	assert.Equal(t, "/api/v1/images", authorizationObject("/api/v2/images"))
+	assert.Equal(t, "/api/v9/images", authorizationObject("/api/v9/images"))
+	assert.Equal(t, "/scim/v2/Users", authorizationObject("/scim/v2/Users"))
+}
+
+func TestHandleRevokeUserByEmail(t *testing.T) {
+	router := newUserHandlerRouter(t, &testUserRepository{})
+	mux := chi.NewRouter()
+	mux.Delete("/api/v2/users/{email}", router.handleRevokeUserByEmail)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/users/user@example.com", http.NoBody)
+	req = addAuthenticatedUser(req, adminTestUser())
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp api.RevokeUserResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "user@example.com", resp.Email)
+}
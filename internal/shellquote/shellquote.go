@@ -0,0 +1,29 @@
+// Package shellquote quotes the arguments of a command for the POSIX shell, to show or run a command given
+// as an argument vector as the equivalent shell command line.
+package shellquote
+
+import (
+	"strings"
+)
+
+// safeChars are the characters the shell never interprets in a word, which are left unquoted. "=" is not
+// one of them: the shell reads a leading word such as FOO=1 as a variable assignment.
+const safeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-+.,:/@%"
+
+// Quote returns the argument as a single shell word: as is when it only holds safe characters, in single
+// quotes otherwise, so that the shell expands nothing in it.
+func Quote(arg string) string {
+	if arg != "" && strings.Trim(arg, safeChars) == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// Join returns the shell command line running the arguments as is, each quoted with Quote.
+func Join(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = Quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
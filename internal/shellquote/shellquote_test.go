@@ -0,0 +1,55 @@
+package shellquote
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"make":                 "make",
+		"--output=dist/a.json": "'--output=dist/a.json'",
+		"FOO=1":                "'FOO=1'",
+		"":                     "''",
+		"hello world":          "'hello world'",
+		"$HOME":                "'$HOME'",
+		"it's":                 `'it'\''s'`,
+		"a;rm -rf /":           "'a;rm -rf /'",
+		"*.go":                 "'*.go'",
+		"~":                    "'~'",
+	}
+	for arg, expected := range tests {
+		assert.Equal(t, expected, Quote(arg), arg)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	assert.Equal(t, "echo 'hello world' '$(id)'", Join([]string{"echo", "hello world", "$(id)"}))
+	assert.Empty(t, Join(nil))
+}
+
+func TestJoin_DoesNotAssignVariables(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+
+	assert.Equal(t, "'FOO=1' env", Join([]string{"FOO=1", "env"}))
+	output, err := exec.Command("sh", "-c", Join([]string{"FOO=1", "env"})).CombinedOutput()
+	require.Error(t, err, "the shell must run a program named FOO=1 rather than env")
+	assert.NotContains(t, string(output), "FOO=1\n")
+}
+
+func TestJoin_RunsTheArgumentsAsIs(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+	args := []string{"printf", `%s\n`, "a b", "it's", "$HOME", "`id`", "*", "", "x;y|z&&w"}
+
+	output, err := exec.Command("sh", "-c", Join(args)).Output()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(args[2:], "\n")+"\n", string(output))
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// EnvSchema validates the declaration of the environment variables of an env schema.
+func EnvSchema(schema []api.EnvVarSpec) error {
+	if len(schema) > constants.MaxEnvVars {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema declares %d variables, the maximum is %d", len(schema), constants.MaxEnvVars), nil)
+	}
+	seen := make(map[string]struct{}, len(schema))
+	for i := range schema {
+		spec := &schema[i]
+		if spec.Name == "" {
+			return apperrors.ErrValidationFailed(fmt.Sprintf("env schema variable %d has no name", i+1), nil)
+		}
+		if len(spec.Name) > constants.MaxEnvVarNameLength {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("env schema variable name %.32s... is longer than %d bytes",
+					spec.Name, constants.MaxEnvVarNameLength), nil)
+		}
+		if _, ok := seen[spec.Name]; ok {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("env schema declares variable %s more than once", spec.Name), nil)
+		}
+		seen[spec.Name] = struct{}{}
+		if err := envVarSpec(spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func envVarSpec(spec *api.EnvVarSpec) error {
+	varType := EnvVarSpecType(spec)
+	if !varType.Valid() {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema variable %s has invalid type %q, expected string, url, int or enum",
+				spec.Name, spec.Type), nil)
+	}
+	if (spec.Min != nil || spec.Max != nil) && varType != constants.EnvVarTypeInt {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema variable %s sets min or max, only int variables accept them", spec.Name), nil)
+	}
+	if spec.Min != nil && spec.Max != nil && *spec.Min > *spec.Max {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema variable %s has a min greater than its max", spec.Name), nil)
+	}
+	if varType == constants.EnvVarTypeEnum && len(spec.Values) == 0 {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema variable %s is an enum without values", spec.Name), nil)
+	}
+	if varType != constants.EnvVarTypeEnum && len(spec.Values) > 0 {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("env schema variable %s sets values, only enum variables accept them", spec.Name), nil)
+	}
+	return nil
+}
+
+// EnvVarSpecType returns the type of a declared environment variable, string when not set.
+func EnvVarSpecType(spec *api.EnvVarSpec) constants.EnvVarType {
+	if spec.Type == "" {
+		return constants.EnvVarTypeString
+	}
+	return constants.EnvVarType(spec.Type)
+}
+
+// EnvAgainstSchema validates environment variables against an env schema, reporting every required variable
+// missing and every value the schema doesn't accept. Values are never included in the error, they may be secrets.
+// Variables the schema doesn't declare are accepted.
+func EnvAgainstSchema(schema []api.EnvVarSpec, env map[string]string) error {
+	var violations []string
+	for i := range schema {
+		spec := &schema[i]
+		value, ok := env[spec.Name]
+		if !ok || value == "" {
+			if spec.Required {
+				violations = append(violations, fmt.Sprintf("environment variable %s is required", spec.Name))
+			}
+			continue
+		}
+		if problem := envValueProblem(spec, value); problem != "" {
+			violations = append(violations, fmt.Sprintf("environment variable %s %s", spec.Name, problem))
+		}
+	}
+	if len(violations) > 0 {
+		return apperrors.ErrValidationFailed(strings.Join(violations, "; "), nil)
+	}
+	return nil
+}
+
+// envValueProblem describes why the value of a declared environment variable is not accepted, empty if it is.
+func envValueProblem(spec *api.EnvVarSpec, value string) string {
+	switch EnvVarSpecType(spec) {
+	case constants.EnvVarTypeURL:
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return "must be an absolute URL with a scheme and a host"
+		}
+	case constants.EnvVarTypeInt:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		switch {
+		case err != nil:
+			return "must be an integer"
+		case (spec.Min != nil && n < *spec.Min) || (spec.Max != nil && n > *spec.Max):
+			return "must be " + IntRange(spec)
+		}
+	case constants.EnvVarTypeEnum:
+		if !slices.Contains(spec.Values, value) {
+			return "must be one of: " + strings.Join(spec.Values, ", ")
+		}
+	case constants.EnvVarTypeString:
+	}
+	return ""
+}
+
+// IntRange describes the bounds of an int variable, empty when it has none.
+func IntRange(spec *api.EnvVarSpec) string {
+	switch {
+	case spec.Min != nil && spec.Max != nil:
+		return fmt.Sprintf("between %d and %d", *spec.Min, *spec.Max)
+	case spec.Min != nil:
+		return fmt.Sprintf("at least %d", *spec.Min)
+	case spec.Max != nil:
+		return fmt.Sprintf("at most %d", *spec.Max)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestEnvSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  []api.EnvVarSpec
+		wantErr string
+	}{
+		{
+			name: "valid schema",
+			schema: []api.EnvVarSpec{
+				{Name: "API_URL", Type: "url", Required: true},
+				{Name: "PORT", Type: "int", Min: intPtr(1), Max: intPtr(65535)},
+				{Name: "REGION", Type: "enum", Values: []string{"eu-west-1", "us-east-1"}},
+				{Name: "NOTE"},
+			},
+		},
+		{name: "no schema"},
+		{
+			name:    "missing name",
+			schema:  []api.EnvVarSpec{{Type: "int"}},
+			wantErr: "env schema variable 1 has no name",
+		},
+		{
+			name:    "duplicate name",
+			schema:  []api.EnvVarSpec{{Name: "PORT"}, {Name: "PORT"}},
+			wantErr: "declares variable PORT more than once",
+		},
+		{
+			name:    "invalid type",
+			schema:  []api.EnvVarSpec{{Name: "PORT", Type: "float"}},
+			wantErr: `has invalid type "float"`,
+		},
+		{
+			name:    "min on a string",
+			schema:  []api.EnvVarSpec{{Name: "NOTE", Min: intPtr(1)}},
+			wantErr: "only int variables accept them",
+		},
+		{
+			name:    "min greater than max",
+			schema:  []api.EnvVarSpec{{Name: "PORT", Type: "int", Min: intPtr(10), Max: intPtr(1)}},
+			wantErr: "min greater than its max",
+		},
+		{
+			name:    "enum without values",
+			schema:  []api.EnvVarSpec{{Name: "REGION", Type: "enum"}},
+			wantErr: "enum without values",
+		},
+		{
+			name:    "values on an int",
+			schema:  []api.EnvVarSpec{{Name: "PORT", Type: "int", Values: []string{"1"}}},
+			wantErr: "only enum variables accept them",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnvSchema(tt.schema)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetStatusCode(err))
+		})
+	}
+}
+
+func TestEnvAgainstSchema(t *testing.T) {
+	schema := []api.EnvVarSpec{
+		{Name: "API_URL", Type: "url", Required: true},
+		{Name: "PORT", Type: "int", Min: intPtr(1), Max: intPtr(65535)},
+		{Name: "RETRIES", Type: "int", Min: intPtr(0)},
+		{Name: "REGION", Type: "enum", Values: []string{"eu-west-1", "us-east-1"}, Required: true},
+		{Name: "NOTE"},
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name: "valid values",
+			env: map[string]string{
+				"API_URL": "https://api.example.com/v1",
+				"PORT":    "8080",
+				"REGION":  "eu-west-1",
+				"OTHER":   "undeclared variables are accepted",
+			},
+		},
+		{
+			name:    "missing required variables",
+			env:     map[string]string{"PORT": "8080"},
+			wantErr: "environment variable API_URL is required; environment variable REGION is required",
+		},
+		{
+			name:    "empty required variable",
+			env:     map[string]string{"API_URL": "", "REGION": "us-east-1"},
+			wantErr: "environment variable API_URL is required",
+		},
+		{
+			name:    "relative url",
+			env:     map[string]string{"API_URL": "/v1", "REGION": "us-east-1"},
+			wantErr: "environment variable API_URL must be an absolute URL with a scheme and a host",
+		},
+		{
+			name:    "not an integer",
+			env:     map[string]string{"API_URL": "https://a.b", "REGION": "us-east-1", "PORT": "http"},
+			wantErr: "environment variable PORT must be an integer",
+		},
+		{
+			name:    "integer out of range",
+			env:     map[string]string{"API_URL": "https://a.b", "REGION": "us-east-1", "PORT": "70000"},
+			wantErr: "environment variable PORT must be between 1 and 65535",
+		},
+		{
+			name:    "integer below min",
+			env:     map[string]string{"API_URL": "https://a.b", "REGION": "us-east-1", "RETRIES": "-1"},
+			wantErr: "environment variable RETRIES must be at least 0",
+		},
+		{
+			name:    "value not in enum",
+			env:     map[string]string{"API_URL": "https://a.b", "REGION": "ap-south-1"},
+			wantErr: "environment variable REGION must be one of: eu-west-1, us-east-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnvAgainstSchema(schema, tt.env)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, apperrors.GetErrorMessage(err))
+			assert.NotContains(t, err.Error(), "ap-south-1", "values must not be reported")
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Package validation checks API request payloads against the limits of the runvoy backend.
+// It is shared by the server, which rejects out-of-bounds requests with a 422 response,
+// and by the client, which validates requests before sending them so users get immediate feedback.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+// imageReferencePattern loosely matches container image references ("registry/name:tag", "name@sha256:...")
+// as well as runvoy image IDs, rejecting whitespace and shell metacharacters.
+var imageReferencePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._\-/:@]*$`)
+
+// envVarNamePattern matches the names of the environment variables the shell accepts.
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// filterNamePattern matches the names execution list filters are saved under, usable as CLI arguments.
+var filterNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_\-]*$`)
+
+// Request validates a decoded API request payload.
+// Payload types without limits are always valid.
+func Request(v any) error {
+	switch req := v.(type) {
+	case *api.ExecutionRequest:
+		return ExecutionRequest(req)
+	case *api.RegisterImageRequest:
+		if req.Image == "" {
+			return nil // reported as a bad request by the service
+		}
+		if req.RunDefaults != nil {
+			if err := WorkDir(req.RunDefaults.WorkDir); err != nil {
+				return err
+			}
+		}
+		return ImageReference(req.Image)
+	case *api.ExecutionAnnotationRequest:
+		return ExecutionAnnotation(req)
+	case *api.ExecutionListFilter:
+		return SavedFilter(req)
+	default:
+		return nil
+	}
+}
+
+// ExecutionRequest validates the command, environment variables, working directory, env schema and image of an
+// execution request.
+// The environment variables are validated against the env schema once the secrets are resolved.
+func ExecutionRequest(req *api.ExecutionRequest) error {
+	if len(req.Command) > constants.MaxCommandLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("command is %d bytes long, the maximum is %d", len(req.Command), constants.MaxCommandLength), nil)
+	}
+	if err := EnvVars(req.Env); err != nil {
+		return err
+	}
+	if err := WorkDir(req.WorkDir); err != nil {
+		return err
+	}
+	if err := EnvSchema(req.EnvSchema); err != nil {
+		return err
+	}
+	if err := Provenance(req.Provenance); err != nil {
+		return err
+	}
+	if req.Image != "" {
+		return ImageReference(req.Image)
+	}
+	return nil
+}
+
+// Provenance validates the length of the fields of the provenance of an execution, which may be nil.
+func Provenance(provenance *api.ExecutionProvenance) error {
+	if provenance == nil {
+		return nil
+	}
+	fields := []struct{ name, value string }{
+		{"client version", provenance.ClientVersion},
+		{"os", provenance.OS},
+		{"hostname", provenance.Hostname},
+		{"ci provider", provenance.CIProvider},
+		{"ci run id", provenance.CIRunID},
+		{"ci run url", provenance.CIRunURL},
+	}
+	for _, field := range fields {
+		if len(field.value) > constants.MaxProvenanceFieldLength {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("provenance %s is %d bytes long, the maximum is %d",
+					field.name, len(field.value), constants.MaxProvenanceFieldLength), nil)
+		}
+	}
+	return nil
+}
+
+// ExecutionAnnotation validates the length of a note attached to an execution.
+func ExecutionAnnotation(req *api.ExecutionAnnotationRequest) error {
+	if len(req.Note) > constants.MaxAnnotationLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("note is %d bytes long, the maximum is %d", len(req.Note), constants.MaxAnnotationLength), nil)
+	}
+	return nil
+}
+
+// SavedFilter validates the name, duration and search of an execution list filter being saved.
+func SavedFilter(filter *api.ExecutionListFilter) error {
+	if len(filter.Name) > constants.MaxSavedFilterNameLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("filter name is %d characters long, the maximum is %d",
+				len(filter.Name), constants.MaxSavedFilterNameLength), nil)
+	}
+	if !filterNamePattern.MatchString(filter.Name) {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("invalid filter name %q, use letters, digits, dashes and underscores", filter.Name), nil)
+	}
+	if filter.Since != "" {
+		if since, err := time.ParseDuration(filter.Since); err != nil || since <= 0 {
+			return apperrors.ErrValidationFailed(fmt.Sprintf("invalid since duration %q", filter.Since), nil)
+		}
+	}
+	if len(filter.Search) > constants.MaxExecutionSearchLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("search is %d characters long, the maximum is %d",
+				len(filter.Search), constants.MaxExecutionSearchLength), nil)
+	}
+	return nil
+}
+
+// EnvVars validates the number, names, value lengths and total size of environment variables.
+func EnvVars(env map[string]string) error {
+	if len(env) > constants.MaxEnvVars {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("%d environment variables set, the maximum is %d", len(env), constants.MaxEnvVars), nil)
+	}
+	size := 0
+	for name, value := range env {
+		if len(name) > constants.MaxEnvVarNameLength {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("environment variable name %.32s... is longer than %d bytes", name, constants.MaxEnvVarNameLength),
+				nil)
+		}
+		if !envVarNamePattern.MatchString(name) {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("invalid environment variable name %q, use letters, digits and underscores, "+
+					"not starting with a digit", name), nil)
+		}
+		if len(value) > constants.MaxEnvVarValueLength {
+			return apperrors.ErrValidationFailed(
+				fmt.Sprintf("value of environment variable %s is %d bytes long, the maximum is %d",
+					name, len(value), constants.MaxEnvVarValueLength), nil)
+		}
+		size += len(name) + len(value)
+	}
+	if size > constants.MaxEnvBytes {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("environment variables are %d bytes long, the maximum is %d", size, constants.MaxEnvBytes), nil)
+	}
+	return nil
+}
+
+// WorkDir validates the length of a working directory, which must fit on a line of the runner scripts.
+func WorkDir(dir string) error {
+	if len(dir) > constants.MaxWorkDirLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("working directory is %d bytes long, the maximum is %d", len(dir), constants.MaxWorkDirLength),
+			nil)
+	}
+	if strings.ContainsAny(dir, "\x00\n\r") {
+		return apperrors.ErrValidationFailed("working directory must not contain control characters", nil)
+	}
+	return nil
+}
+
+// ImageReference validates the format of an image reference or image ID.
+func ImageReference(image string) error {
+	if image == "" {
+		return apperrors.ErrValidationFailed("image is required", nil)
+	}
+	if len(image) > constants.MaxImageReferenceLength {
+		return apperrors.ErrValidationFailed(
+			fmt.Sprintf("image is %d characters long, the maximum is %d", len(image), constants.MaxImageReferenceLength), nil)
+	}
+	if !imageReferencePattern.MatchString(image) {
+		return apperrors.ErrValidationFailed(fmt.Sprintf("invalid image reference %q", image), nil)
+	}
+	return nil
+}
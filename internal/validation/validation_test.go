@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/runvoy/runvoy/internal/api"
+	"github.com/runvoy/runvoy/internal/constants"
+	apperrors "github.com/runvoy/runvoy/internal/errors"
+)
+
+func TestExecutionRequest(t *testing.T) {
+	tooManyEnv := make(map[string]string, constants.MaxEnvVars+1)
+	for i := range constants.MaxEnvVars + 1 {
+		tooManyEnv["VAR_"+strings.Repeat("X", i)] = "1"
+	}
+
+	tests := []struct {
+		name    string
+		req     api.ExecutionRequest
+		wantErr string
+	}{
+		{
+			name: "valid request",
+			req: api.ExecutionRequest{
+				Command: "echo hello",
+				Image:   "public.ecr.aws/docker/library/alpine:3.20",
+				Env:     map[string]string{"FOO": "bar"},
+			},
+		},
+		{
+			name: "command at the limit",
+			req:  api.ExecutionRequest{Command: strings.Repeat("a", constants.MaxCommandLength)},
+		},
+		{
+			name:    "command too long",
+			req:     api.ExecutionRequest{Command: strings.Repeat("a", constants.MaxCommandLength+1)},
+			wantErr: "command is 4097 bytes long",
+		},
+		{
+			name:    "too many environment variables",
+			req:     api.ExecutionRequest{Command: "env", Env: tooManyEnv},
+			wantErr: "65 environment variables set",
+		},
+		{
+			name: "environment variable name too long",
+			req: api.ExecutionRequest{
+				Command: "env",
+				Env:     map[string]string{strings.Repeat("N", constants.MaxEnvVarNameLength+1): "1"},
+			},
+			wantErr: "is longer than 128 bytes",
+		},
+		{
+			name: "environment variable value too long",
+			req: api.ExecutionRequest{
+				Command: "env",
+				Env:     map[string]string{"BIG": strings.Repeat("v", constants.MaxEnvVarValueLength+1)},
+			},
+			wantErr: "value of environment variable BIG",
+		},
+		{
+			name:    "invalid environment variable name",
+			req:     api.ExecutionRequest{Command: "env", Env: map[string]string{"MY-VAR": "1"}},
+			wantErr: `invalid environment variable name "MY-VAR"`,
+		},
+		{
+			name: "environment variables too large",
+			req: api.ExecutionRequest{
+				Command: "env",
+				Env: map[string]string{
+					"A": strings.Repeat("v", constants.MaxEnvVarValueLength),
+					"B": strings.Repeat("v", constants.MaxEnvVarValueLength),
+				},
+			},
+			wantErr: "environment variables are 8194 bytes long",
+		},
+		{
+			name:    "working directory too long",
+			req:     api.ExecutionRequest{Command: "ls", WorkDir: strings.Repeat("d", constants.MaxWorkDirLength+1)},
+			wantErr: "working directory is 1025 bytes long",
+		},
+		{
+			name:    "working directory on several lines",
+			req:     api.ExecutionRequest{Command: "ls", WorkDir: "src\nrm -rf /"},
+			wantErr: "working directory must not contain control characters",
+		},
+		{
+			name:    "invalid image",
+			req:     api.ExecutionRequest{Command: "ls", Image: "alpine; rm -rf /"},
+			wantErr: "invalid image reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ExecutionRequest(&tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, http.StatusUnprocessableEntity, apperrors.GetStatusCode(err))
+			assert.Equal(t, apperrors.ErrCodeValidationFailed, apperrors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestImageReference(t *testing.T) {
+	valid := []string{
+		"alpine",
+		"alpine:latest",
+		"alpine:latest-a1b2c3d4",
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/my-app:v1.2.3",
+		"ghcr.io/owner/image@sha256:0123456789abcdef",
+		"localhost:5000/team/tool:1",
+	}
+	for _, image := range valid {
+		assert.NoError(t, ImageReference(image), image)
+	}
+
+	invalid := []string{
+		"",
+		" alpine",
+		"alpine latest",
+		"alpine$(id)",
+		"-alpine",
+		strings.Repeat("a", constants.MaxImageReferenceLength+1),
+	}
+	for _, image := range invalid {
+		assert.Error(t, ImageReference(image), image)
+	}
+}
+
+func TestSavedFilter(t *testing.T) {
+	valid := []*api.ExecutionListFilter{
+		{Name: "failures"},
+		{Name: "my-failed_runs", Statuses: []string{"FAILED"}, CreatedBy: "me", Since: "168h"},
+	}
+	for _, filter := range valid {
+		assert.NoError(t, SavedFilter(filter), filter.Name)
+	}
+
+	invalid := []*api.ExecutionListFilter{
+		{},
+		{Name: "saved:failures"},
+		{Name: "my failures"},
+		{Name: strings.Repeat("a", constants.MaxSavedFilterNameLength+1)},
+		{Name: "failures", Since: "a week"},
+		{Name: "failures", Since: "-1h"},
+	}
+	for _, filter := range invalid {
+		err := SavedFilter(filter)
+		require.Error(t, err, filter.Name)
+		assert.Equal(t, apperrors.ErrCodeValidationFailed, apperrors.GetErrorCode(err))
+	}
+}
+
+func TestRequest(t *testing.T) {
+	assert.NoError(t, Request(&api.RegisterImageRequest{}))
+	assert.NoError(t, Request(&api.RegisterImageRequest{Image: "alpine:latest"}))
+	assert.Error(t, Request(&api.RegisterImageRequest{Image: "bad image"}))
+	assert.Error(t, Request(&api.ExecutionRequest{Command: strings.Repeat("a", constants.MaxCommandLength+1)}))
+	assert.NoError(t, Request(&api.ExecutionAnnotationRequest{Note: strings.Repeat("a", constants.MaxAnnotationLength)}))
+	assert.Error(t, Request(&api.ExecutionAnnotationRequest{Note: strings.Repeat("a", constants.MaxAnnotationLength+1)}))
+	assert.Error(t, Request(&api.ExecutionListFilter{Name: "bad name"}))
+	assert.NoError(t, Request(&api.CreateUserRequest{Email: "user@example.com"}))
+}
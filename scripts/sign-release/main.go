@@ -0,0 +1,61 @@
+// Package main signs the checksums file of a release with the ed25519 key verified by runvoy self-update.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// signingKeyEnvVar holds the base64 ed25519 private key of the releases.
+const signingKeyEnvVar = "RELEASE_SIGNING_KEY"
+
+func main() {
+	var in, out string
+	var generate bool
+	flag.StringVar(&in, "in", "", "file to sign")
+	flag.StringVar(&out, "out", "", "file to write the base64 signature to")
+	flag.BoolVar(&generate, "generate", false, "generate a new key pair and print it")
+	flag.Parse()
+
+	if generate {
+		if err := generateKey(); err != nil {
+			log.Fatalf("error: %s", err)
+		}
+		return
+	}
+	if in == "" || out == "" {
+		log.Fatal("error: -in and -out are required")
+	}
+	if err := sign(in, out); err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
+
+func generateKey() error {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (keep secret): %s\n", signingKeyEnvVar, base64.StdEncoding.EncodeToString(private))
+	fmt.Printf("RELEASE_SIGNING_PUBLIC_KEY: %s\n", base64.StdEncoding.EncodeToString(public))
+	return nil
+}
+
+func sign(in, out string) error {
+	key, err := base64.StdEncoding.DecodeString(os.Getenv(signingKeyEnvVar))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return errors.New(signingKeyEnvVar + " must hold a base64 ed25519 private key")
+	}
+	content, err := os.ReadFile(in) //nolint:gosec // G304: path given by the release pipeline
+	if err != nil {
+		return err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, content))
+	return os.WriteFile(out, []byte(signature+"\n"), 0o600)
+}
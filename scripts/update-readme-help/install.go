@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/runvoy/runvoy/internal/constants"
+)
+
+const (
+	installMetadataCommand = "install-metadata"
+	installDirName         = "install"
+	releaseDownloadURL     = "https://github.com/runvoy/runvoy/releases/download"
+	projectHomepage        = "https://github.com/runvoy/runvoy"
+	projectDescription     = "Serverless command runner: run commands on ephemeral containers in your cloud account"
+	projectLicense         = "MIT"
+)
+
+// installPlatforms are the platforms of the CLI release archives, as named by Goreleaser.
+var installPlatforms = []struct{ os, arch string }{
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"windows", "amd64"},
+	{"windows", "arm64"},
+}
+
+// installArtifact is a release archive listed in the install manifest.
+type installArtifact struct {
+	Name   string `json:"name"`
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// installManifest lists the release archives with their checksums, for the installers and the package
+// managers. It is signed like the checksums file of the release.
+type installManifest struct {
+	Version   string            `json:"version"`
+	Artifacts []installArtifact `json:"artifacts"`
+}
+
+// generateInstallMetadata writes the Homebrew formula, the Scoop manifest and the install manifest of the
+// release archives built by Goreleaser in distDir to distDir/install. The output only depends on the
+// version and the archives, so running it again on the same build gives the same files.
+func generateInstallMetadata(version, distDir string) ([]string, error) {
+	archiveVersion := strings.TrimPrefix(version, "v")
+	checksums, err := readChecksums(filepath.Join(distDir,
+		fmt.Sprintf("%s_%s_checksums.txt", constants.ProjectName, archiveVersion)))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := installManifest{Version: version}
+	for _, platform := range installPlatforms {
+		artifact, artifactErr := hashArtifact(version, distDir, platform.os, platform.arch, checksums)
+		if artifactErr != nil {
+			return nil, artifactErr
+		}
+		manifest.Artifacts = append(manifest.Artifacts, artifact)
+	}
+
+	formula, err := renderFormula(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	scoop, err := renderScoopManifest(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	outDir := filepath.Join(distDir, installDirName)
+	if err = os.MkdirAll(outDir, constants.ConfigDirPermissions); err != nil {
+		return nil, err
+	}
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{constants.ProjectName + ".rb", formula},
+		{constants.ProjectName + ".json", scoop},
+		{fmt.Sprintf("%s_%s_install.json", constants.ProjectName, archiveVersion), append(manifestJSON, '\n')},
+	}
+	written := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(outDir, file.name)
+		if err = os.WriteFile(path, file.content, constants.ConfigFilePermissions); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// readChecksums reads the "<sha256>  <name>" lines of the checksums file of the release.
+func readChecksums(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // G304: path of the release build
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checksums of the release: %w", err)
+	}
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 {
+			checksums[fields[1]] = strings.ToLower(fields[0])
+		}
+	}
+	return checksums, nil
+}
+
+// hashArtifact hashes the archive of the platform and checks it matches the checksums file, so the
+// metadata can't describe archives of another build.
+func hashArtifact(version, distDir, goos, goarch string, checksums map[string]string) (installArtifact, error) {
+	extension := ".tar.gz"
+	if goos == "windows" {
+		extension = ".zip"
+	}
+	name := fmt.Sprintf("%s_%s_%s%s", constants.ProjectName, goos, goarch, extension)
+	content, err := os.ReadFile(filepath.Join(distDir, name)) //nolint:gosec // G304: path of the release build
+	if err != nil {
+		return installArtifact{}, fmt.Errorf("failed to read the %s/%s archive: %w", goos, goarch, err)
+	}
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	if checksums[name] != digest {
+		return installArtifact{}, fmt.Errorf("%s doesn't match the checksums file of the release", name)
+	}
+	return installArtifact{
+		Name:   name,
+		OS:     goos,
+		Arch:   goarch,
+		URL:    fmt.Sprintf("%s/%s/%s", releaseDownloadURL, version, name),
+		SHA256: digest,
+	}, nil
+}
+
+func (m *installManifest) artifact(goos, goarch string) installArtifact {
+	for _, a := range m.Artifacts {
+		if a.OS == goos && a.Arch == goarch {
+			return a
+		}
+	}
+	return installArtifact{}
+}
+
+var formulaTemplate = template.Must(template.New("formula").Parse(`# typed: false
+# frozen_string_literal: true
+
+# Generated by scripts/update-readme-help from the release archives, do not edit.
+class Runvoy < Formula
+  desc "{{ .Description }}"
+  homepage "{{ .Homepage }}"
+  version "{{ .Version }}"
+  license "{{ .License }}"
+
+  on_macos do
+    on_intel do
+      url "{{ .DarwinAMD64.URL }}"
+      sha256 "{{ .DarwinAMD64.SHA256 }}"
+    end
+    on_arm do
+      url "{{ .DarwinARM64.URL }}"
+      sha256 "{{ .DarwinARM64.SHA256 }}"
+    end
+  end
+
+  on_linux do
+    on_intel do
+      url "{{ .LinuxAMD64.URL }}"
+      sha256 "{{ .LinuxAMD64.SHA256 }}"
+    end
+    on_arm do
+      url "{{ .LinuxARM64.URL }}"
+      sha256 "{{ .LinuxARM64.SHA256 }}"
+    end
+  end
+
+  def install
+    bin.install "runvoy"
+  end
+
+  test do
+    assert_match version.to_s, shell_output("#{bin}/runvoy version")
+  end
+end
+`))
+
+func renderFormula(m *installManifest) ([]byte, error) {
+	var b bytes.Buffer
+	err := formulaTemplate.Execute(&b, map[string]any{
+		"Description": projectDescription,
+		"Homepage":    projectHomepage,
+		"Version":     strings.TrimPrefix(m.Version, "v"),
+		"License":     projectLicense,
+		"DarwinAMD64": m.artifact("darwin", "amd64"),
+		"DarwinARM64": m.artifact("darwin", "arm64"),
+		"LinuxAMD64":  m.artifact("linux", "amd64"),
+		"LinuxARM64":  m.artifact("linux", "arm64"),
+	})
+	return b.Bytes(), err
+}
+
+type scoopArchitecture struct {
+	URL  string `json:"url"`
+	Hash string `json:"hash,omitempty"`
+}
+
+type scoopManifest struct {
+	Version      string                       `json:"version"`
+	Description  string                       `json:"description"`
+	Homepage     string                       `json:"homepage"`
+	License      string                       `json:"license"`
+	Architecture map[string]scoopArchitecture `json:"architecture"`
+	Bin          string                       `json:"bin"`
+	Checkver     map[string]string            `json:"checkver"`
+	Autoupdate   map[string]any               `json:"autoupdate"`
+}
+
+func renderScoopManifest(m *installManifest) ([]byte, error) {
+	amd64, arm64 := m.artifact("windows", "amd64"), m.artifact("windows", "arm64")
+	manifest := scoopManifest{
+		Version:     strings.TrimPrefix(m.Version, "v"),
+		Description: projectDescription,
+		Homepage:    projectHomepage,
+		License:     projectLicense,
+		Architecture: map[string]scoopArchitecture{
+			"64bit": {URL: amd64.URL, Hash: amd64.SHA256},
+			"arm64": {URL: arm64.URL, Hash: arm64.SHA256},
+		},
+		Bin:      constants.ProjectName + ".exe",
+		Checkver: map[string]string{"github": projectHomepage},
+		Autoupdate: map[string]any{"architecture": map[string]scoopArchitecture{
+			"64bit": {URL: releaseDownloadURL + "/v$version/" + amd64.Name},
+			"arm64": {URL: releaseDownloadURL + "/v$version/" + arm64.Name},
+		}},
+	}
+	content, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
@@ -1,4 +1,6 @@
 // Package main provides a utility to update the README.md with the latest CLI help output and version.
+// With the install-metadata command, it generates the install metadata of a release instead: the
+// Homebrew formula, the Scoop manifest and the install manifest of the archives built by Goreleaser.
 package main
 
 import (
@@ -22,7 +24,11 @@ const versionPath = "VERSION"
 
 func main() {
 	if len(os.Args) < constants.MinimumArgsUpdateReadmeHelp {
-		log.Fatalf("usage: %s <cli-binary-path>", os.Args[0])
+		log.Fatalf("usage: %s <cli-binary-path> | %s <dist-dir>", os.Args[0], installMetadataCommand)
+	}
+	if os.Args[1] == installMetadataCommand {
+		runInstallMetadata(os.Args[2:])
+		return
 	}
 
 	cliBinary := os.Args[1]
@@ -51,6 +57,24 @@ func main() {
 	log.Printf("updated %s with latest CLI help output and version", readmePath)
 }
 
+func runInstallMetadata(args []string) {
+	if len(args) != 1 || args[0] == "" {
+		log.Fatalf("usage: %s %s <dist-dir>", os.Args[0], installMetadataCommand)
+	}
+
+	version, err := readVersion(versionPath)
+	if err != nil {
+		log.Fatalf("error reading version: %s", err)
+	}
+	files, err := generateInstallMetadata(version, args[0])
+	if err != nil {
+		log.Fatalf("error generating install metadata: %s", err)
+	}
+	for _, file := range files {
+		log.Printf("generated %s", file)
+	}
+}
+
 func captureHelpOutput(cliBinary string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), constants.LongScriptContextTimeout)
 	defer cancel()
@@ -101,11 +125,12 @@ func generateVersionExamplesSection(version string) string {
 	b.WriteString("```\n\n")
 	b.WriteString("- **Windows:** Download the archive from the [release page]")
 	windowsURL := fmt.Sprintf(
-		"(https://github.com/runvoy/runvoy/releases/download/%s/runvoy_windows_amd64.tar.gz)",
+		"(https://github.com/runvoy/runvoy/releases/download/%s/runvoy_windows_amd64.zip)",
 		version,
 	)
 	b.WriteString(windowsURL)
-	b.WriteString(". Extract the `runvoy.exe` file from the archive using a tool like 7-Zip")
+	b.WriteString(" (`runvoy_windows_arm64.zip` on ARM). Extract `runvoy.exe` from the zip archive with Explorer")
+	b.WriteString(" or `Expand-Archive` and add its folder to your `PATH`")
 	return b.String()
 }
 